@@ -0,0 +1,146 @@
+// Package checkpoint stores the position of the connector in the change feed of HydrAIDE.
+//
+// The position is the last change of every Swamp the sink acknowledged. After a restart the connector resumes the
+// change feed from these positions, so the changes missed while it was away are replayed from the event journal of
+// the Swamps, and no acknowledged change is sent again.
+//
+// The checkpoint is a JSON file, written to a temporary file and renamed, so a crash during the write never leaves
+// a broken checkpoint behind.
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Position is the last acknowledged change of a Swamp
+type Position struct {
+	// Sequence is the sequence number of the change within its Swamp. It restarts from 1 after a restart of the
+	// server, so it is only informative
+	Sequence uint64 `json:"sequence"`
+	// EventTime is the time of the change on the server, the change feed is resumed after this time
+	EventTime time.Time `json:"eventTime"`
+}
+
+// Checkpoint is the set of the positions per Swamp name
+type Checkpoint struct {
+	mu        sync.Mutex
+	path      string
+	positions map[string]Position
+	dirty     bool
+}
+
+// file is the content of the checkpoint file
+type file struct {
+	Positions map[string]Position `json:"positions"`
+	SavedAt   time.Time           `json:"savedAt"`
+}
+
+// Load reads the checkpoint file. A missing file is not an error, the checkpoint starts empty
+func Load(path string) (*Checkpoint, error) {
+
+	c := &Checkpoint{
+		path:      path,
+		positions: make(map[string]Position),
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint file: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("the checkpoint file %s is invalid: %w", path, err)
+	}
+	for swampName, position := range f.Positions {
+		c.positions[swampName] = position
+	}
+
+	return c, nil
+
+}
+
+// Advance sets the position of the Swamp. The changes of a Swamp are acknowledged in their order, so the last call
+// wins
+func (c *Checkpoint) Advance(swampName string, sequence uint64, eventTime time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.positions[swampName] = Position{Sequence: sequence, EventTime: eventTime}
+	c.dirty = true
+}
+
+// Get returns the position of the Swamp
+func (c *Checkpoint) Get(swampName string) (Position, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	position, ok := c.positions[swampName]
+	return position, ok
+}
+
+// Since returns the time of the last acknowledged change per Swamp, to resume the change feed
+func (c *Checkpoint) Since() map[string]time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	since := make(map[string]time.Time, len(c.positions))
+	for swampName, position := range c.positions {
+		since[swampName] = position.EventTime
+	}
+	return since
+}
+
+// Save writes the checkpoint file, if any position changed since the last save
+func (c *Checkpoint) Save() error {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(file{Positions: c.positions, SavedAt: time.Now().UTC()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the folder of the checkpoint file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create the checkpoint file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write the checkpoint file: %w", err)
+	}
+	// the checkpoint must be on the disk before it replaces the previous one
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync the checkpoint file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close the checkpoint file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to replace the checkpoint file: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+
+}
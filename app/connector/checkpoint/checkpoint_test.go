@@ -0,0 +1,53 @@
+package checkpoint
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckpoint(t *testing.T) {
+
+	t.Run("should start empty without a checkpoint file", func(t *testing.T) {
+		c, err := Load(filepath.Join(t.TempDir(), "checkpoint.json"))
+		require.NoError(t, err)
+		assert.Empty(t, c.Since())
+	})
+
+	t.Run("should keep the positions after a reload", func(t *testing.T) {
+
+		path := filepath.Join(t.TempDir(), "connector", "checkpoint.json")
+		c, err := Load(path)
+		require.NoError(t, err)
+
+		eventTime := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+		c.Advance("users/profiles/alex", 1, eventTime.Add(-time.Second))
+		c.Advance("users/profiles/alex", 2, eventTime)
+		c.Advance("users/profiles/bob", 1, eventTime)
+		require.NoError(t, c.Save())
+
+		reloaded, err := Load(path)
+		require.NoError(t, err)
+		position, ok := reloaded.Get("users/profiles/alex")
+		require.True(t, ok)
+		assert.Equal(t, uint64(2), position.Sequence)
+		assert.True(t, eventTime.Equal(position.EventTime))
+		assert.Len(t, reloaded.Since(), 2)
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "the temporary file is removed")
+
+	})
+
+	t.Run("should refuse an invalid checkpoint file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkpoint.json")
+		require.NoError(t, os.WriteFile(path, []byte("{broken"), 0o644))
+		_, err := Load(path)
+		assert.Error(t, err)
+	})
+
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/connector/forwarder"
+	"github.com/hydraide/hydraide/app/connector/sink"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sinkKafka = "kafka"
+	sinkNATS  = "nats"
+
+	defaultAllIslands     = 1000
+	defaultCheckpointFile = "checkpoint.json"
+	defaultMaxMessageSize = 100 * 1024 * 1024
+)
+
+// configuration is the configuration of the connector, read from the environment variables
+type configuration struct {
+	server         string
+	certFile       string
+	tenantToken    string
+	allIslands     uint64
+	maxMessageSize int
+	routes         []forwarder.Route
+	checkpointFile string
+	options        *forwarder.Options
+	logLevel       slog.Level
+	sinkType       string
+	kafka          *sink.KafkaConfiguration
+	nats           *sink.NATSConfiguration
+}
+
+// loadConfig reads the configuration with the lookup function, e.g. os.LookupEnv. All errors are returned at once
+func loadConfig(lookup func(string) (string, bool)) (*configuration, error) {
+
+	var errs []error
+	get := func(key string) string {
+		value, _ := lookup(key)
+		return strings.TrimSpace(value)
+	}
+	required := func(key string) string {
+		value := get(key)
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
+		return value
+	}
+	integer := func(key string, defaultValue int) int {
+		value := get(key)
+		if value == "" {
+			return defaultValue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive integer, got %q", key, value))
+			return defaultValue
+		}
+		return number
+	}
+	duration := func(key string, defaultValue time.Duration) time.Duration {
+		value := get(key)
+		if value == "" {
+			return defaultValue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration, e.g. 1s, got %q", key, value))
+			return defaultValue
+		}
+		return d
+	}
+
+	c := &configuration{
+		server:         required("HYDRAIDE_CONNECTOR_SERVER"),
+		certFile:       get("HYDRAIDE_CONNECTOR_CERT_FILE"),
+		tenantToken:    get("HYDRAIDE_CONNECTOR_TENANT_TOKEN"),
+		allIslands:     uint64(integer("HYDRAIDE_CONNECTOR_ALL_ISLANDS", defaultAllIslands)),
+		maxMessageSize: integer("HYDRAIDE_CONNECTOR_MAX_MESSAGE_SIZE", defaultMaxMessageSize),
+		checkpointFile: get("HYDRAIDE_CONNECTOR_CHECKPOINT_FILE"),
+		options: &forwarder.Options{
+			BatchSize:       integer("HYDRAIDE_CONNECTOR_BATCH_SIZE", forwarder.DefaultBatchSize),
+			QueueSize:       integer("HYDRAIDE_CONNECTOR_QUEUE_SIZE", forwarder.DefaultQueueSize),
+			FlushInterval:   duration("HYDRAIDE_CONNECTOR_FLUSH_INTERVAL", forwarder.DefaultFlushInterval),
+			RetryBackoff:    duration("HYDRAIDE_CONNECTOR_RETRY_BACKOFF", forwarder.DefaultRetryBackoff),
+			MaxRetryBackoff: duration("HYDRAIDE_CONNECTOR_MAX_RETRY_BACKOFF", forwarder.DefaultMaxRetryBackoff),
+		},
+		sinkType: strings.ToLower(required("HYDRAIDE_CONNECTOR_SINK")),
+	}
+	if c.checkpointFile == "" {
+		c.checkpointFile = defaultCheckpointFile
+	}
+
+	if level := get("HYDRAIDE_CONNECTOR_LOG_LEVEL"); level != "" {
+		if err := c.logLevel.UnmarshalText([]byte(level)); err != nil {
+			errs = append(errs, fmt.Errorf("HYDRAIDE_CONNECTOR_LOG_LEVEL must be debug, info, warn or error, got %q", level))
+		}
+	}
+
+	// the routes are in the format pattern=destination, separated by commas
+	for _, entry := range strings.Split(required("HYDRAIDE_CONNECTOR_ROUTES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, destination, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(destination) == "" {
+			errs = append(errs, fmt.Errorf("the route %q must be in the format sanctuary/realm/swamp=destination", entry))
+			continue
+		}
+		patternName, err := name.Parse(pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("the pattern of the route %q is invalid: %w", entry, err))
+			continue
+		}
+		c.routes = append(c.routes, forwarder.Route{Pattern: patternName, Destination: strings.TrimSpace(destination)})
+	}
+
+	switch c.sinkType {
+	case sinkKafka:
+		c.kafka = &sink.KafkaConfiguration{
+			URL:      required("HYDRAIDE_CONNECTOR_KAFKA_REST_URL"),
+			Username: get("HYDRAIDE_CONNECTOR_KAFKA_USERNAME"),
+			Password: get("HYDRAIDE_CONNECTOR_KAFKA_PASSWORD"),
+		}
+	case sinkNATS:
+		c.nats = &sink.NATSConfiguration{
+			Address:    required("HYDRAIDE_CONNECTOR_NATS_ADDRESS"),
+			Token:      get("HYDRAIDE_CONNECTOR_NATS_TOKEN"),
+			Username:   get("HYDRAIDE_CONNECTOR_NATS_USERNAME"),
+			Password:   get("HYDRAIDE_CONNECTOR_NATS_PASSWORD"),
+			AckTimeout: duration("HYDRAIDE_CONNECTOR_NATS_ACK_TIMEOUT", sink.DefaultNATSAckTimeout),
+		}
+		if caFile := get("HYDRAIDE_CONNECTOR_NATS_CA_FILE"); caFile != "" || get("HYDRAIDE_CONNECTOR_NATS_TLS") == "true" {
+			tlsConfig, err := natsTLSConfig(caFile)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			c.nats.TLS = tlsConfig
+		}
+	case "":
+	default:
+		errs = append(errs, fmt.Errorf("HYDRAIDE_CONNECTOR_SINK must be %s or %s, got %q", sinkKafka, sinkNATS, c.sinkType))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c, nil
+
+}
+
+// natsTLSConfig returns the TLS configuration of NATS, with the CA of the server if given
+func natsTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HYDRAIDE_CONNECTOR_NATS_CA_FILE: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("HYDRAIDE_CONNECTOR_NATS_CA_FILE contains no certificate")
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// newSink creates the sink of the configuration
+func (c *configuration) newSink() sink.Sink {
+	if c.sinkType == sinkNATS {
+		return sink.NewNATS(c.nats)
+	}
+	return sink.NewKafka(c.kafka)
+}
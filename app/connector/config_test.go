@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func lookupFrom(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+
+	t.Run("should load the configuration of the kafka sink", func(t *testing.T) {
+
+		config, err := loadConfig(lookupFrom(map[string]string{
+			"HYDRAIDE_CONNECTOR_SERVER":         "hydra:4444",
+			"HYDRAIDE_CONNECTOR_SINK":           "Kafka",
+			"HYDRAIDE_CONNECTOR_KAFKA_REST_URL": "http://kafka-rest:8082",
+			"HYDRAIDE_CONNECTOR_ROUTES":         "users/*/*=hydraide-users, */*/*=hydraide-all",
+			"HYDRAIDE_CONNECTOR_FLUSH_INTERVAL": "250ms",
+			"HYDRAIDE_CONNECTOR_LOG_LEVEL":      "debug",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, sinkKafka, config.sinkType)
+		assert.Equal(t, "http://kafka-rest:8082", config.kafka.URL)
+		assert.Equal(t, uint64(defaultAllIslands), config.allIslands)
+		assert.Equal(t, defaultCheckpointFile, config.checkpointFile)
+		assert.Equal(t, 250*time.Millisecond, config.options.FlushInterval)
+		require.Len(t, config.routes, 2)
+		assert.Equal(t, "users/*/*", config.routes[0].Pattern.Get())
+		assert.Equal(t, "hydraide-all", config.routes[1].Destination)
+
+	})
+
+	t.Run("should report every error of the configuration", func(t *testing.T) {
+
+		_, err := loadConfig(lookupFrom(map[string]string{
+			"HYDRAIDE_CONNECTOR_SINK":       "nats",
+			"HYDRAIDE_CONNECTOR_ROUTES":     "users/*/*,orders/*/*=",
+			"HYDRAIDE_CONNECTOR_BATCH_SIZE": "-1",
+		}))
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "HYDRAIDE_CONNECTOR_SERVER is required")
+		assert.ErrorContains(t, err, "HYDRAIDE_CONNECTOR_NATS_ADDRESS is required")
+		assert.ErrorContains(t, err, `the route "users/*/*" must be`)
+		assert.ErrorContains(t, err, `the route "orders/*/*=" must be`)
+		assert.ErrorContains(t, err, "HYDRAIDE_CONNECTOR_BATCH_SIZE must be a positive integer")
+
+	})
+
+	t.Run("should refuse an unknown sink", func(t *testing.T) {
+		_, err := loadConfig(lookupFrom(map[string]string{
+			"HYDRAIDE_CONNECTOR_SERVER": "hydra:4444",
+			"HYDRAIDE_CONNECTOR_SINK":   "rabbitmq",
+			"HYDRAIDE_CONNECTOR_ROUTES": "users/*/*=users",
+		}))
+		assert.ErrorContains(t, err, `HYDRAIDE_CONNECTOR_SINK must be kafka or nats, got "rabbitmq"`)
+	})
+
+}
@@ -0,0 +1,299 @@
+// Package forwarder forwards the change feed of HydrAIDE to a sink.
+//
+// The changes are queued by the iterator of SubscribeAllFrom, and sent to the sink in batches. A batch is sent again
+// until the sink acknowledges it, and the checkpoint is only advanced after the acknowledgement, so every change is
+// delivered at least once:
+//
+//   - a change in the queue or in a failed batch is replayed after a restart, from the checkpoint
+//   - a full queue blocks the change feed instead of dropping changes; if the server closes the lagging stream, the
+//     connector subscribes again from the checkpoint
+package forwarder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/connector/checkpoint"
+	"github.com/hydraide/hydraide/app/connector/sink"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"time"
+)
+
+const (
+	DefaultBatchSize       = 500
+	DefaultQueueSize       = 10000
+	DefaultFlushInterval   = time.Second
+	DefaultRetryBackoff    = time.Second
+	DefaultMaxRetryBackoff = time.Minute
+)
+
+// ErrStopped is returned by the iterator if the forwarder is stopped
+var ErrStopped = errors.New("the forwarder is stopped")
+
+// Route sends the changes of the Swamps matching the pattern to the destination: a Kafka topic or a NATS subject
+type Route struct {
+	Pattern     name.Name
+	Destination string
+}
+
+// Options are the batching and the retry settings of the forwarder. Zero means the default
+type Options struct {
+	// BatchSize is the max number of the changes sent to the sink at once
+	BatchSize int
+	// QueueSize is the max number of the changes waiting for the sink
+	QueueSize int
+	// FlushInterval is the max time a change waits for its batch to fill
+	FlushInterval time.Duration
+	// RetryBackoff is the first wait after a failed batch, doubled after every failure up to MaxRetryBackoff
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+// Forwarder sends the changes to the sink
+type Forwarder struct {
+	sink       sink.Sink
+	checkpoint *checkpoint.Checkpoint
+	routes     []Route
+	options    Options
+	queue      chan *hydraidego.Change
+	stopped    chan struct{}
+}
+
+// Message is the value of the records: the JSON of the change
+type Message struct {
+	Swamp     string    `json:"swamp"`
+	Key       string    `json:"key"`
+	Status    string    `json:"status"`
+	EventTime time.Time `json:"eventTime"`
+	Sequence  uint64    `json:"sequence"`
+	// Value is the value of the Treasure, the encoded structs are base64 strings. The deleted changes carry the
+	// value before the deletion
+	Value any `json:"value,omitempty"`
+}
+
+// New creates the forwarder. The routes are matched in their order, the first matching route wins
+func New(s sink.Sink, c *checkpoint.Checkpoint, routes []Route, options *Options) *Forwarder {
+
+	o := Options{}
+	if options != nil {
+		o = *options
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = DefaultQueueSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultFlushInterval
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultRetryBackoff
+	}
+	if o.MaxRetryBackoff < o.RetryBackoff {
+		o.MaxRetryBackoff = max(DefaultMaxRetryBackoff, o.RetryBackoff)
+	}
+
+	return &Forwarder{
+		sink:       s,
+		checkpoint: c,
+		routes:     routes,
+		options:    o,
+		queue:      make(chan *hydraidego.Change, o.QueueSize),
+		stopped:    make(chan struct{}),
+	}
+
+}
+
+// Patterns returns the patterns of the routes, to subscribe to
+func (f *Forwarder) Patterns() []name.Name {
+	patterns := make([]name.Name, 0, len(f.routes))
+	for _, route := range f.routes {
+		patterns = append(patterns, route.Pattern)
+	}
+	return patterns
+}
+
+// Handle is the iterator of SubscribeAllFrom. It queues the change, and blocks while the queue is full.
+//
+// A Swamp whose missed changes can not be replayed is logged, the change feed continues with its new changes. The
+// other errors of the change feed are returned, so the caller can subscribe again.
+func (f *Forwarder) Handle(change *hydraidego.Change, err error) error {
+
+	if hydraidego.IsReplayNotAvailable(err) {
+		slog.Error("the missed changes of the swamp can not be replayed, resynchronize the swamp in the sink",
+			"swamp_name", change.SwampName.Get(),
+			"since", change.EventTime,
+			"error", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-f.stopped:
+		return ErrStopped
+	default:
+	}
+
+	select {
+	case f.queue <- change:
+		return nil
+	case <-f.stopped:
+		return ErrStopped
+	}
+
+}
+
+// Run sends the queued changes to the sink until the context is canceled. The changes not acknowledged by the sink
+// at the cancellation are not checkpointed, so they are replayed after a restart
+func (f *Forwarder) Run(ctx context.Context) {
+
+	defer close(f.stopped)
+
+	ticker := time.NewTicker(f.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*hydraidego.Change, 0, f.options.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change := <-f.queue:
+			batch = append(batch, change)
+			if len(batch) < f.options.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		if !f.send(ctx, batch) {
+			return
+		}
+		batch = batch[:0]
+	}
+
+}
+
+// send sends the batch until the sink acknowledges it, and advances the checkpoint. Returns false if the context
+// is canceled
+func (f *Forwarder) send(ctx context.Context, batch []*hydraidego.Change) bool {
+
+	records := make([]sink.Record, 0, len(batch))
+	for _, change := range batch {
+		record, ok := f.record(change)
+		if !ok {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	backoff := f.options.RetryBackoff
+	for attempt := 1; ; attempt++ {
+
+		err := f.sink.Publish(ctx, records)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		slog.Warn("failed to publish the changes, retrying",
+			"sink", f.sink.Name(),
+			"changes", len(records),
+			"attempt", attempt,
+			"backoff", backoff,
+			"error", err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, f.options.MaxRetryBackoff)
+
+	}
+
+	// the changes of a swamp are in the order of their sequence, so the last one is the position of the swamp
+	for _, change := range batch {
+		f.checkpoint.Advance(change.SwampName.Get(), change.Sequence, change.EventTime)
+	}
+	if err := f.checkpoint.Save(); err != nil {
+		// the changes are delivered, they are only sent again after a restart
+		slog.Error("failed to save the checkpoint",
+			"error", err)
+	}
+
+	slog.Debug("published the changes",
+		"sink", f.sink.Name(),
+		"changes", len(records))
+
+	return true
+
+}
+
+// record converts the change to the record of its route. Returns false if no route matches the swamp
+func (f *Forwarder) record(change *hydraidego.Change) (sink.Record, bool) {
+
+	destination := ""
+	for _, route := range f.routes {
+		if name.Match(route.Pattern, change.SwampName) {
+			destination = route.Destination
+			break
+		}
+	}
+	if destination == "" {
+		slog.Warn("no route matches the swamp of the change",
+			"swamp_name", change.SwampName.Get())
+		return sink.Record{}, false
+	}
+
+	swampName := change.SwampName.Get()
+	value, err := json.Marshal(Message{
+		Swamp:     swampName,
+		Key:       change.Key,
+		Status:    statusName(change.Status),
+		EventTime: change.EventTime,
+		Sequence:  change.Sequence,
+		Value:     change.Value(),
+	})
+	if err != nil {
+		// the values of the treasures are always encodable, the float NaN and Inf aside
+		slog.Error("failed to encode the change, it is skipped",
+			"swamp_name", swampName,
+			"key", change.Key,
+			"error", err)
+		return sink.Record{}, false
+	}
+
+	return sink.Record{
+		Destination: destination,
+		Key:         swampName + "/" + change.Key,
+		// the sequence restarts after a restart of the server, the event time makes the ID unique
+		ID:    fmt.Sprintf("%s/%s/%d/%d", swampName, change.Key, change.Sequence, change.EventTime.UnixNano()),
+		Value: value,
+	}, true
+
+}
+
+// statusName returns the name of the status of the change in the messages
+func statusName(status hydraidego.EventStatus) string {
+	switch status {
+	case hydraidego.StatusNew:
+		return "new"
+	case hydraidego.StatusModified:
+		return "modified"
+	case hydraidego.StatusDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
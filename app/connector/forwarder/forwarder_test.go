@@ -0,0 +1,140 @@
+package forwarder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/hydraide/hydraide/app/connector/checkpoint"
+	"github.com/hydraide/hydraide/app/connector/sink"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakySink fails the first publishes, then stores the records
+type flakySink struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	records  []sink.Record
+}
+
+func (s *flakySink) Name() string {
+	return "flaky"
+}
+
+func (s *flakySink) Publish(ctx context.Context, records []sink.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("broker unavailable")
+	}
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *flakySink) Close() error {
+	return nil
+}
+
+func (s *flakySink) published() []sink.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sink.Record(nil), s.records...)
+}
+
+func TestForwarder(t *testing.T) {
+
+	routes := []Route{
+		{Pattern: name.New().Sanctuary("users").Realm("*").Swamp("*"), Destination: "users"},
+		{Pattern: name.New().Sanctuary("*").Realm("*").Swamp("*"), Destination: "everything"},
+	}
+	eventTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("should retry the batch until the sink acknowledges it and advance the checkpoint", func(t *testing.T) {
+
+		path := filepath.Join(t.TempDir(), "checkpoint.json")
+		c, err := checkpoint.Load(path)
+		require.NoError(t, err)
+
+		s := &flakySink{failures: 2}
+		f := New(s, c, routes, &Options{
+			BatchSize:     2,
+			FlushInterval: 10 * time.Millisecond,
+			RetryBackoff:  time.Millisecond,
+		})
+		assert.Len(t, f.Patterns(), 2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			f.Run(ctx)
+			close(done)
+		}()
+
+		require.NoError(t, f.Handle(&hydraidego.Change{SwampName: name.New().Sanctuary("users").Realm("profiles").Swamp("alex"), Key: "name", Status: hydraidego.StatusNew, EventTime: eventTime, Sequence: 1}, nil))
+		require.NoError(t, f.Handle(&hydraidego.Change{SwampName: name.New().Sanctuary("orders").Realm("open").Swamp("1"), Key: "total", Status: hydraidego.StatusModified, EventTime: eventTime, Sequence: 4}, nil))
+		require.NoError(t, f.Handle(&hydraidego.Change{SwampName: name.New().Sanctuary("users").Realm("profiles").Swamp("alex"), Key: "name", Status: hydraidego.StatusDeleted, EventTime: eventTime.Add(time.Second), Sequence: 2}, nil))
+
+		assert.Eventually(t, func() bool {
+			return len(s.published()) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+		cancel()
+		<-done
+
+		records := s.published()
+		assert.Equal(t, "users", records[0].Destination)
+		assert.Equal(t, "users/profiles/alex/name", records[0].Key)
+		assert.Equal(t, "everything", records[1].Destination)
+		assert.NotEqual(t, records[0].ID, records[2].ID)
+
+		var message Message
+		require.NoError(t, json.Unmarshal(records[2].Value, &message))
+		assert.Equal(t, "deleted", message.Status)
+		assert.Equal(t, uint64(2), message.Sequence)
+
+		reloaded, err := checkpoint.Load(path)
+		require.NoError(t, err)
+		position, ok := reloaded.Get("users/profiles/alex")
+		require.True(t, ok)
+		assert.Equal(t, uint64(2), position.Sequence)
+		assert.True(t, eventTime.Add(time.Second).Equal(position.EventTime))
+
+	})
+
+	t.Run("should continue after a swamp that can not be replayed", func(t *testing.T) {
+
+		c, err := checkpoint.Load(filepath.Join(t.TempDir(), "checkpoint.json"))
+		require.NoError(t, err)
+		f := New(&flakySink{}, c, routes, nil)
+
+		gap := hydraidego.NewError(hydraidego.ErrCodeReplayNotAvailable, "replay not available")
+		assert.NoError(t, f.Handle(&hydraidego.Change{SwampName: name.New().Sanctuary("users").Realm("profiles").Swamp("alex")}, gap))
+
+		streamErr := hydraidego.NewError(hydraidego.ErrCodeSubscriberOverflow, "overflow")
+		assert.Equal(t, streamErr, f.Handle(nil, streamErr), "the other errors of the change feed are returned")
+
+	})
+
+	t.Run("should not block the change feed after the stop", func(t *testing.T) {
+
+		c, err := checkpoint.Load(filepath.Join(t.TempDir(), "checkpoint.json"))
+		require.NoError(t, err)
+		f := New(&flakySink{}, c, routes, &Options{QueueSize: 1})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		f.Run(ctx)
+
+		change := &hydraidego.Change{SwampName: name.New().Sanctuary("users").Realm("profiles").Swamp("alex")}
+		assert.ErrorIs(t, f.Handle(change, nil), ErrStopped)
+
+	})
+
+}
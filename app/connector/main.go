@@ -0,0 +1,127 @@
+// The connector forwards the changes of HydrAIDE to Kafka or NATS JetStream, so other systems can consume them.
+//
+// It subscribes to the change feed of the Swamps matching the patterns of its routes (SubscribeAll), and publishes
+// every change as a JSON record to the topic or the subject of the route. The delivery is at least once: the
+// position of the connector is checkpointed per Swamp after the broker acknowledged the changes, and after a restart
+// the missed changes are replayed from the event journal of the Swamps (see EventJournalSize of RegisterSwamp).
+//
+// The connector is configured by environment variables (or a .env file), see docs/connector.md.
+package main
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/connector/checkpoint"
+	"github.com/hydraide/hydraide/app/connector/forwarder"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	resubscribeBackoff    = time.Second
+	maxResubscribeBackoff = time.Minute
+)
+
+func main() {
+
+	_ = godotenv.Load()
+
+	config, err := loadConfig(os.LookupEnv)
+	if err != nil {
+		slog.Error("invalid configuration of the connector", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: config.logLevel})))
+
+	positions, err := checkpoint.Load(config.checkpointFile)
+	if err != nil {
+		slog.Error("failed to load the checkpoint", "error", err)
+		os.Exit(1)
+	}
+
+	s := config.newSink()
+	defer func() {
+		_ = s.Close()
+	}()
+	f := forwarder.New(s, positions, config.routes, config.options)
+
+	hydraClient := client.New([]*client.Server{{
+		Host:         config.server,
+		FromIsland:   1,
+		ToIsland:     config.allIslands,
+		CertFilePath: config.certFile,
+		TenantToken:  config.tenantToken,
+	}}, config.allIslands, config.maxMessageSize)
+	if err := hydraClient.Connect(false); err != nil {
+		slog.Error("failed to connect to the HydrAIDE server", "server", config.server, "error", err)
+		os.Exit(1)
+	}
+	defer hydraClient.CloseConnection()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	done := make(chan struct{})
+	go func() {
+		f.Run(ctx)
+		close(done)
+	}()
+
+	slog.Info("the connector is started",
+		"server", config.server,
+		"sink", s.Name(),
+		"routes", len(config.routes),
+		"resumedSwamps", len(positions.Since()))
+
+	subscribe(ctx, hydraidego.New(hydraClient), f, positions)
+
+	<-done
+	slog.Info("the connector is stopped")
+
+}
+
+// subscribe keeps the change feed open until the context is canceled. After an error of the change feed it
+// subscribes again from the checkpoint, the changes not acknowledged yet are sent again
+func subscribe(ctx context.Context, h hydraidego.Hydraidego, f *forwarder.Forwarder, positions *checkpoint.Checkpoint) {
+
+	backoff := resubscribeBackoff
+	for ctx.Err() == nil {
+
+		subscription, err := h.SubscribeAllFrom(ctx, f.Patterns(), positions.Since(), func(change *hydraidego.Change, err error) error {
+			return f.Handle(change, err)
+		})
+
+		if err == nil {
+			backoff = resubscribeBackoff
+			select {
+			case <-ctx.Done():
+			case <-subscription.Done():
+				err = subscription.Err()
+			}
+			subscription.Close()
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.Warn("the change feed is closed, subscribing again from the checkpoint",
+			"backoff", backoff,
+			"error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxResubscribeBackoff)
+
+	}
+
+}
@@ -0,0 +1,145 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// kafkaContentType is the JSON embedded format of the v2 API of the Kafka REST Proxy
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAccept      = "application/vnd.kafka.v2+json"
+)
+
+// KafkaConfiguration is the configuration of the Kafka sink
+type KafkaConfiguration struct {
+	// URL is the base URL of the Kafka REST Proxy, e.g. "http://kafka-rest:8082"
+	URL string
+	// Username and Password are the basic auth credentials of the proxy. Empty means no authentication
+	Username string
+	Password string
+}
+
+// NewKafka creates the sink publishing the records to Kafka through the Kafka REST Proxy (v2 API). The proxy
+// answers after the records are acknowledged by Kafka, so the acks setting of the proxy decides the durability
+func NewKafka(configuration *KafkaConfiguration) Sink {
+	return &kafka{
+		configuration: configuration,
+		url:           strings.TrimSuffix(configuration.URL, "/"),
+		client:        &http.Client{},
+	}
+}
+
+type kafka struct {
+	configuration *KafkaConfiguration
+	url           string
+	client        *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition *int32  `json:"partition"`
+		Offset    *int64  `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (k *kafka) Name() string {
+	return "kafka"
+}
+
+func (k *kafka) Publish(ctx context.Context, records []Record) error {
+
+	// one request per topic, in the order of the first record of the topics
+	var topics []string
+	byTopic := make(map[string][]kafkaRecord)
+	for _, record := range records {
+		if _, ok := byTopic[record.Destination]; !ok {
+			topics = append(topics, record.Destination)
+		}
+		byTopic[record.Destination] = append(byTopic[record.Destination], kafkaRecord{Key: record.Key, Value: record.Value})
+	}
+
+	for _, topic := range topics {
+		if err := k.produce(ctx, topic, byTopic[topic]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+func (k *kafka) Close() error {
+	k.client.CloseIdleConnections()
+	return nil
+}
+
+// produce sends the records of one topic
+func (k *kafka) produce(ctx context.Context, topic string, records []kafkaRecord) error {
+
+	body, err := json.Marshal(kafkaProduceRequest{Records: records})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.url+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaAccept)
+	if k.configuration.Username != "" {
+		req.SetBasicAuth(k.configuration.Username, k.configuration.Password)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("the kafka rest proxy answered %s for the topic %s: %s", resp.Status, topic, strings.TrimSpace(string(message)))
+	}
+
+	// the proxy answers 200 even if some records failed, their error is in the offsets
+	var response kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode the answer of the kafka rest proxy: %w", err)
+	}
+	if len(response.Offsets) != len(records) {
+		return fmt.Errorf("the kafka rest proxy acknowledged %d of %d records of the topic %s", len(response.Offsets), len(records), topic)
+	}
+	for i, offset := range response.Offsets {
+		if offset.ErrorCode != nil || offset.Error != nil {
+			message := ""
+			if offset.Error != nil {
+				message = *offset.Error
+			}
+			return fmt.Errorf("kafka refused the record %d of the topic %s: %s", i, topic, message)
+		}
+	}
+
+	return nil
+
+}
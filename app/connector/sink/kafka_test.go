@@ -0,0 +1,96 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestKafka(t *testing.T) {
+
+	t.Run("should produce the records per topic", func(t *testing.T) {
+
+		var mu sync.Mutex
+		produced := make(map[string][]kafkaRecord)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "hydra", username)
+			assert.Equal(t, "secret", password)
+
+			var request kafkaProduceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			mu.Lock()
+			produced[r.URL.Path] = append(produced[r.URL.Path], request.Records...)
+			mu.Unlock()
+
+			offsets := make([]map[string]any, len(request.Records))
+			for i := range offsets {
+				offsets[i] = map[string]any{"partition": 0, "offset": i, "error_code": nil, "error": nil}
+			}
+			w.Header().Set("Content-Type", kafkaAccept)
+			_ = json.NewEncoder(w).Encode(map[string]any{"offsets": offsets})
+		}))
+		defer server.Close()
+
+		s := NewKafka(&KafkaConfiguration{URL: server.URL + "/", Username: "hydra", Password: "secret"})
+		defer func() {
+			_ = s.Close()
+		}()
+
+		err := s.Publish(context.Background(), []Record{
+			{Destination: "users", Key: "users/profiles/alex/name", Value: []byte(`{"value":"Alex"}`)},
+			{Destination: "orders", Key: "orders/open/1/total", Value: []byte(`{"value":10}`)},
+			{Destination: "users", Key: "users/profiles/bob/name", Value: []byte(`{"value":"Bob"}`)},
+		})
+		require.NoError(t, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, produced["/topics/users"], 2)
+		assert.Equal(t, "users/profiles/alex/name", produced["/topics/users"][0].Key)
+		assert.JSONEq(t, `{"value":"Bob"}`, string(produced["/topics/users"][1].Value))
+		require.Len(t, produced["/topics/orders"], 1)
+
+	})
+
+	t.Run("should fail if a record is refused", func(t *testing.T) {
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{"offsets": []map[string]any{
+				{"partition": 0, "offset": 1},
+				{"error_code": 50002, "error": "leader not available"},
+			}})
+		}))
+		defer server.Close()
+
+		s := NewKafka(&KafkaConfiguration{URL: server.URL})
+		err := s.Publish(context.Background(), []Record{
+			{Destination: "users", Key: "a", Value: []byte(`{}`)},
+			{Destination: "users", Key: "b", Value: []byte(`{}`)},
+		})
+		assert.ErrorContains(t, err, "leader not available")
+
+	})
+
+	t.Run("should fail if the proxy answers an error", func(t *testing.T) {
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40401,"message":"Topic not found."}`))
+		}))
+		defer server.Close()
+
+		s := NewKafka(&KafkaConfiguration{URL: server.URL})
+		err := s.Publish(context.Background(), []Record{{Destination: "missing", Key: "a", Value: []byte(`{}`)}})
+		assert.ErrorContains(t, err, "Topic not found.")
+
+	})
+
+}
@@ -0,0 +1,364 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultNATSAckTimeout is the time to wait for the acknowledgements of a batch
+	DefaultNATSAckTimeout = 10 * time.Second
+	// natsMsgIDHeader is the header of JetStream to drop the duplicated messages
+	natsMsgIDHeader = "Nats-Msg-Id"
+	natsHeaderLine  = "NATS/1.0"
+)
+
+// NATSConfiguration is the configuration of the NATS sink
+type NATSConfiguration struct {
+	// Address is the host and the port of the NATS server, e.g. "nats:4222"
+	Address string
+	// TLS connects with TLS. Nil means a plain TCP connection
+	TLS *tls.Config
+	// Token is the authentication token, Username and Password are the user credentials. Empty means no
+	// authentication
+	Token    string
+	Username string
+	Password string
+	// AckTimeout is the time to wait for the acknowledgements of a batch. Zero means DefaultNATSAckTimeout
+	AckTimeout time.Duration
+}
+
+// NewNATS creates the sink publishing the records to NATS JetStream. The subjects must be captured by a stream of
+// JetStream: every record waits for the acknowledgement of the stream, so a record is only acknowledged if it is
+// stored. The ID of the records is sent as Nats-Msg-Id, so the stream drops the duplicates within its duplicate
+// window.
+//
+// The sink speaks the text protocol of NATS directly, it connects at the first batch and reconnects after an error.
+func NewNATS(configuration *NATSConfiguration) Sink {
+	ackTimeout := configuration.AckTimeout
+	if ackTimeout <= 0 {
+		ackTimeout = DefaultNATSAckTimeout
+	}
+	return &nats{
+		configuration: configuration,
+		ackTimeout:    ackTimeout,
+	}
+}
+
+type nats struct {
+	mu            sync.Mutex
+	configuration *NATSConfiguration
+	ackTimeout    time.Duration
+	conn          net.Conn
+	reader        *bufio.Reader
+	writer        *bufio.Writer
+	// inbox is the subject prefix of the acknowledgements of the connection
+	inbox string
+	// batch is the counter of the batches, the acknowledgements of an earlier batch are ignored
+	batch uint64
+}
+
+// natsInfo is the part of the INFO message of the server used by the sink
+type natsInfo struct {
+	Headers     bool `json:"headers"`
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnect struct {
+	Verbose      bool   `json:"verbose"`
+	Pedantic     bool   `json:"pedantic"`
+	Name         string `json:"name"`
+	Lang         string `json:"lang"`
+	Version      string `json:"version"`
+	Protocol     int    `json:"protocol"`
+	Headers      bool   `json:"headers"`
+	NoResponders bool   `json:"no_responders"`
+	AuthToken    string `json:"auth_token,omitempty"`
+	User         string `json:"user,omitempty"`
+	Pass         string `json:"pass,omitempty"`
+}
+
+// natsPubAck is the acknowledgement of JetStream
+type natsPubAck struct {
+	Stream    string `json:"stream"`
+	Sequence  uint64 `json:"seq"`
+	Duplicate bool   `json:"duplicate"`
+	Error     *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+func (n *nats) Name() string {
+	return "nats"
+}
+
+func (n *nats) Publish(ctx context.Context, records []Record) error {
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			n.disconnect()
+			return err
+		}
+	}
+
+	// the connection is in an unknown state after an error, e.g. some acknowledgements are still coming
+	if err := n.publish(ctx, records); err != nil {
+		n.disconnect()
+		return err
+	}
+
+	return nil
+
+}
+
+func (n *nats) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.disconnect()
+	return nil
+}
+
+// connect opens the connection, authenticates and subscribes to the inbox of the acknowledgements
+func (n *nats) connect(ctx context.Context) error {
+
+	dialer := &net.Dialer{Timeout: n.ackTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.configuration.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	n.conn = conn
+	_ = conn.SetDeadline(time.Now().Add(n.ackTimeout))
+	n.reader = bufio.NewReader(conn)
+
+	line, err := n.readLine()
+	if err != nil {
+		return fmt.Errorf("failed to read the info of nats: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("nats sent %q instead of its info", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		return fmt.Errorf("failed to decode the info of nats: %w", err)
+	}
+	if !info.Headers {
+		return errors.New("the nats server does not support headers, nats 2.2 or newer is required")
+	}
+
+	// the TLS handshake follows the INFO message in the protocol of NATS
+	if n.configuration.TLS != nil {
+		tlsConfig := n.configuration.TLS.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(n.configuration.Address)
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("failed the tls handshake with nats: %w", err)
+		}
+		n.conn = tlsConn
+		n.reader = bufio.NewReader(tlsConn)
+	} else if info.TLSRequired {
+		return errors.New("the nats server requires tls")
+	}
+	n.writer = bufio.NewWriter(n.conn)
+
+	connect, _ := json.Marshal(natsConnect{
+		Name:         "hydraide-connector",
+		Lang:         "go",
+		Version:      "1.0.0",
+		Protocol:     1,
+		Headers:      true,
+		NoResponders: true,
+		AuthToken:    n.configuration.Token,
+		User:         n.configuration.Username,
+		Pass:         n.configuration.Password,
+	})
+
+	random := make([]byte, 12)
+	_, _ = rand.Read(random)
+	n.inbox = "_INBOX." + hex.EncodeToString(random)
+
+	// the PONG confirms that the server accepted the CONNECT, an authentication error arrives before it
+	_, _ = fmt.Fprintf(n.writer, "CONNECT %s\r\nSUB %s.> 1\r\nPING\r\n", connect, n.inbox)
+	if err := n.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to send the connect to nats: %w", err)
+	}
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return fmt.Errorf("failed to connect to nats: %w", err)
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats refused the connection: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+
+}
+
+// disconnect closes the connection
+func (n *nats) disconnect() {
+	if n.conn != nil {
+		_ = n.conn.Close()
+	}
+	n.conn = nil
+	n.reader = nil
+	n.writer = nil
+}
+
+// publish sends the records and waits for their acknowledgements
+func (n *nats) publish(ctx context.Context, records []Record) error {
+
+	deadline := time.Now().Add(n.ackTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = n.conn.SetDeadline(deadline)
+	// the canceled context interrupts the waiting for the acknowledgements
+	stop := context.AfterFunc(ctx, func() {
+		_ = n.conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	n.batch++
+	prefix := n.inbox + "." + strconv.FormatUint(n.batch, 10) + "."
+
+	for i, record := range records {
+		header := natsHeaderLine + "\r\n"
+		if record.ID != "" {
+			header += natsMsgIDHeader + ": " + record.ID + "\r\n"
+		}
+		header += "\r\n"
+		_, _ = fmt.Fprintf(n.writer, "HPUB %s %s%d %d %d\r\n%s", record.Destination, prefix, i, len(header), len(header)+len(record.Value), header)
+		_, _ = n.writer.Write(record.Value)
+		_, _ = n.writer.WriteString("\r\n")
+	}
+	if err := n.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to publish to nats: %w", err)
+	}
+
+	acked := make([]bool, len(records))
+	remaining := len(records)
+	for remaining > 0 {
+
+		line, err := n.readLine()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read the acknowledgements of nats, %d of %d records are not acknowledged: %w", remaining, len(records), err)
+		}
+
+		switch {
+		case line == "PING":
+			_, _ = n.writer.WriteString("PONG\r\n")
+			if err := n.writer.Flush(); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats answered an error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case !strings.HasPrefix(line, "MSG ") && !strings.HasPrefix(line, "HMSG "):
+			// +OK, PONG and the INFO updates of the cluster
+			continue
+		}
+
+		subject, header, payload, err := n.readMessage(line)
+		if err != nil {
+			return err
+		}
+
+		// an acknowledgement of an earlier, failed batch
+		if !strings.HasPrefix(subject, prefix) {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimPrefix(subject, prefix))
+		if err != nil || index < 0 || index >= len(records) || acked[index] {
+			continue
+		}
+
+		// the server answers 503 if no stream captures the subject
+		if strings.HasPrefix(header, natsHeaderLine+" ") {
+			status := strings.TrimSpace(strings.SplitN(header, "\r\n", 2)[0][len(natsHeaderLine):])
+			return fmt.Errorf("nats could not store the record of the subject %s: %s", records[index].Destination, status)
+		}
+
+		var ack natsPubAck
+		if err := json.Unmarshal(payload, &ack); err != nil {
+			return fmt.Errorf("failed to decode the acknowledgement of nats: %w", err)
+		}
+		if ack.Error != nil {
+			return fmt.Errorf("jetstream refused the record of the subject %s: %d %s", records[index].Destination, ack.Error.Code, ack.Error.Description)
+		}
+
+		acked[index] = true
+		remaining--
+
+	}
+
+	return nil
+
+}
+
+// readMessage reads the payload of a MSG or HMSG line, and returns its subject, header and body
+func (n *nats) readMessage(line string) (subject string, header string, payload []byte, err error) {
+
+	fields := strings.Fields(line)
+	withHeader := fields[0] == "HMSG"
+
+	// MSG <subject> <sid> [reply-to] <#bytes>, HMSG <subject> <sid> [reply-to] <#header bytes> <#total bytes>
+	minFields := 4
+	if withHeader {
+		minFields = 5
+	}
+	if len(fields) < minFields {
+		return "", "", nil, fmt.Errorf("invalid message of nats: %q", line)
+	}
+
+	total, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || total < 0 {
+		return "", "", nil, fmt.Errorf("invalid message of nats: %q", line)
+	}
+	headerLength := 0
+	if withHeader {
+		headerLength, err = strconv.Atoi(fields[len(fields)-2])
+		if err != nil || headerLength < 0 || headerLength > total {
+			return "", "", nil, fmt.Errorf("invalid message of nats: %q", line)
+		}
+	}
+
+	content := make([]byte, total+2)
+	if _, err := io.ReadFull(n.reader, content); err != nil {
+		return "", "", nil, fmt.Errorf("failed to read the message of nats: %w", err)
+	}
+
+	return fields[1], string(content[:headerLength]), content[headerLength:total], nil
+
+}
+
+// readLine reads a control line without its CRLF
+func (n *nats) readLine() (string, error) {
+	line, err := n.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,175 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeJetStream is a minimal NATS server acknowledging the published messages like JetStream
+type fakeJetStream struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []string
+	msgIDs   []string
+	// answer returns the acknowledgement of the message, an empty answer sends the 503 of no responders
+	answer func(sequence int) string
+}
+
+func newFakeJetStream(t *testing.T, answer func(sequence int) string) *fakeJetStream {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeJetStream{listener: listener, answer: answer}
+	go f.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	return f
+}
+
+func (f *fakeJetStream) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeJetStream) handle(conn net.Conn) {
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	reader := bufio.NewReader(conn)
+	_, _ = fmt.Fprintf(conn, "INFO {\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	var sid string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			if strings.Contains(line, `"auth_token":"wrong"`) {
+				_, _ = fmt.Fprintf(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PING":
+			_, _ = fmt.Fprintf(conn, "PONG\r\n")
+		case "SUB":
+			sid = fields[2]
+		case "HPUB":
+			headerLength, _ := strconv.Atoi(fields[3])
+			total, _ := strconv.Atoi(fields[4])
+			content := make([]byte, total+2)
+			if _, err := io.ReadFull(reader, content); err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.messages = append(f.messages, fields[1]+" "+string(content[headerLength:total]))
+			for _, header := range strings.Split(string(content[:headerLength]), "\r\n") {
+				if id, ok := strings.CutPrefix(header, natsMsgIDHeader+": "); ok {
+					f.msgIDs = append(f.msgIDs, id)
+				}
+			}
+			sequence := len(f.messages)
+			f.mu.Unlock()
+
+			answer := f.answer(sequence)
+			if answer == "" {
+				status := "NATS/1.0 503\r\n\r\n"
+				_, _ = fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s\r\n", fields[2], sid, len(status), len(status), status)
+				continue
+			}
+			// a ping of the server between the acknowledgements
+			_, _ = fmt.Fprintf(conn, "PING\r\nMSG %s %s %d\r\n%s\r\n", fields[2], sid, len(answer), answer)
+		}
+	}
+
+}
+
+func TestNATS(t *testing.T) {
+
+	records := []Record{
+		{Destination: "hydraide.users", Key: "users/profiles/alex/name", ID: "users/profiles/alex/1/100", Value: []byte(`{"value":"Alex"}`)},
+		{Destination: "hydraide.users", Key: "users/profiles/bob/name", ID: "users/profiles/bob/1/200", Value: []byte(`{"value":"Bob"}`)},
+	}
+
+	t.Run("should publish the records and wait for their acknowledgements", func(t *testing.T) {
+
+		server := newFakeJetStream(t, func(sequence int) string {
+			return fmt.Sprintf(`{"stream":"HYDRAIDE","seq":%d}`, sequence)
+		})
+
+		s := NewNATS(&NATSConfiguration{Address: server.listener.Addr().String(), Token: "secret", AckTimeout: 5 * time.Second})
+		defer func() {
+			_ = s.Close()
+		}()
+
+		require.NoError(t, s.Publish(context.Background(), records))
+		require.NoError(t, s.Publish(context.Background(), records[:1]), "the connection is reused")
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, []string{
+			`hydraide.users {"value":"Alex"}`,
+			`hydraide.users {"value":"Bob"}`,
+			`hydraide.users {"value":"Alex"}`,
+		}, server.messages)
+		assert.Equal(t, "users/profiles/alex/1/100", server.msgIDs[0])
+
+	})
+
+	t.Run("should fail if jetstream refuses a record", func(t *testing.T) {
+
+		server := newFakeJetStream(t, func(sequence int) string {
+			if sequence == 2 {
+				return `{"error":{"code":503,"err_code":10077,"description":"maximum messages exceeded"}}`
+			}
+			return fmt.Sprintf(`{"stream":"HYDRAIDE","seq":%d}`, sequence)
+		})
+
+		s := NewNATS(&NATSConfiguration{Address: server.listener.Addr().String()})
+		err := s.Publish(context.Background(), records)
+		assert.ErrorContains(t, err, "maximum messages exceeded")
+
+	})
+
+	t.Run("should fail if no stream captures the subject", func(t *testing.T) {
+
+		server := newFakeJetStream(t, func(sequence int) string { return "" })
+
+		s := NewNATS(&NATSConfiguration{Address: server.listener.Addr().String()})
+		err := s.Publish(context.Background(), records)
+		assert.ErrorContains(t, err, "503")
+
+	})
+
+	t.Run("should fail if the authentication fails", func(t *testing.T) {
+
+		server := newFakeJetStream(t, func(sequence int) string { return `{"stream":"HYDRAIDE","seq":1}` })
+
+		s := NewNATS(&NATSConfiguration{Address: server.listener.Addr().String(), Token: "wrong"})
+		err := s.Publish(context.Background(), records)
+		assert.ErrorContains(t, err, "Authorization Violation")
+
+	})
+
+}
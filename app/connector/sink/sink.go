@@ -0,0 +1,31 @@
+// Package sink delivers the changes of HydrAIDE to a message broker.
+//
+// A sink publishes a batch of records and returns only when the broker stored all of them. If it returns an error,
+// some records may be stored and some not, so the connector sends the whole batch again: the delivery is at least
+// once, the consumers must tolerate the duplicates, e.g. by the ID of the records.
+package sink
+
+import "context"
+
+// Record is one change to publish
+type Record struct {
+	// Destination is the Kafka topic or the NATS subject of the record
+	Destination string
+	// Key is the key of the record, the Swamp name and the key of the Treasure. Kafka puts the records with the same
+	// key to the same partition, so the changes of a Treasure keep their order
+	Key string
+	// ID is the unique ID of the change, the brokers with deduplication, e.g. NATS JetStream, drop the duplicates by it
+	ID string
+	// Value is the JSON of the change
+	Value []byte
+}
+
+// Sink is a message broker
+type Sink interface {
+	// Name returns the name of the sink for the logs
+	Name() string
+	// Publish sends the records and returns when the broker acknowledged all of them
+	Publish(ctx context.Context, records []Record) error
+	// Close releases the connections of the sink
+	Close() error
+}
@@ -0,0 +1,195 @@
+// Package aggregate computes count, sum, min, max and avg over the numeric values of Treasures.
+// The aggregation runs server-side, so the clients do not need to download whole Swamps just to sum their values.
+//
+// Example:
+//
+//	aggregator := aggregate.New()
+//	for _, t := range treasures {
+//	    aggregator.Add(t) // non-numeric treasures are skipped
+//	}
+//	result := aggregator.Result()
+package aggregate
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"math"
+)
+
+// Aggregator collects the numeric values of the treasures. Not thread-safe.
+type Aggregator interface {
+	// Add adds the value of the treasure to the aggregates.
+	// Returns false and skips the treasure if its value is not numeric.
+	// The function only reads the treasure, so the caller does not need to start a treasure guard.
+	Add(t treasure.Treasure) bool
+	// Count returns the number of the values added so far.
+	Count() int64
+	// Result returns the aggregates of the values added so far.
+	Result() *Result
+}
+
+// Result contains the aggregates of the numeric values.
+type Result struct {
+	// Count is the number of the aggregated values
+	Count int64
+	// Sum is the sum of the values as float64
+	Sum float64
+	// Min, Max and Avg are meaningful only if Count is greater than 0
+	Min float64
+	Max float64
+	Avg float64
+	// IntegerSum is the exact sum of the values. Valid only if IntegerSumValid is true,
+	// that is, every value is an integer and the sum fits into int64.
+	IntegerSum      int64
+	IntegerSumValid bool
+}
+
+type aggregator struct {
+	count      int64
+	sum        float64
+	min        float64
+	max        float64
+	integerSum int64
+	integral   bool
+}
+
+// New creates a new, empty Aggregator
+func New() Aggregator {
+	return &aggregator{
+		integral: true,
+	}
+}
+
+func (a *aggregator) Add(t treasure.Treasure) bool {
+
+	switch t.GetContentType() {
+	case treasure.ContentTypeInt8, treasure.ContentTypeInt16, treasure.ContentTypeInt32, treasure.ContentTypeInt64:
+		v, ok := signedContent(t)
+		if !ok {
+			return false
+		}
+		a.addInteger(v)
+		a.add(float64(v))
+	case treasure.ContentTypeUint8, treasure.ContentTypeUint16, treasure.ContentTypeUint32, treasure.ContentTypeUint64:
+		v, ok := unsignedContent(t)
+		if !ok {
+			return false
+		}
+		if v > math.MaxInt64 {
+			a.integral = false
+		} else {
+			a.addInteger(int64(v))
+		}
+		a.add(float64(v))
+	case treasure.ContentTypeFloat32:
+		v, err := t.GetContentFloat32()
+		if err != nil {
+			return false
+		}
+		a.integral = false
+		a.add(float64(v))
+	case treasure.ContentTypeFloat64:
+		v, err := t.GetContentFloat64()
+		if err != nil {
+			return false
+		}
+		a.integral = false
+		a.add(v)
+	default:
+		return false
+	}
+
+	return true
+
+}
+
+func (a *aggregator) Count() int64 {
+	return a.count
+}
+
+func (a *aggregator) Result() *Result {
+
+	result := &Result{
+		Count:           a.count,
+		Sum:             a.sum,
+		IntegerSum:      a.integerSum,
+		IntegerSumValid: a.integral,
+	}
+
+	if a.count > 0 {
+		result.Min = a.min
+		result.Max = a.max
+		result.Avg = a.sum / float64(a.count)
+	}
+
+	return result
+
+}
+
+// IsNumeric returns true if the value of the treasure is an integer or a float, so an Aggregator would add it
+func IsNumeric(t treasure.Treasure) bool {
+	switch t.GetContentType() {
+	case treasure.ContentTypeInt8, treasure.ContentTypeInt16, treasure.ContentTypeInt32, treasure.ContentTypeInt64,
+		treasure.ContentTypeUint8, treasure.ContentTypeUint16, treasure.ContentTypeUint32, treasure.ContentTypeUint64,
+		treasure.ContentTypeFloat32, treasure.ContentTypeFloat64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *aggregator) add(v float64) {
+	if a.count == 0 || v < a.min {
+		a.min = v
+	}
+	if a.count == 0 || v > a.max {
+		a.max = v
+	}
+	a.sum += v
+	a.count++
+}
+
+// addInteger adds the value to the exact sum, and invalidates the exact sum on overflow
+func (a *aggregator) addInteger(v int64) {
+	if !a.integral {
+		return
+	}
+	if (v > 0 && a.integerSum > math.MaxInt64-v) || (v < 0 && a.integerSum < math.MinInt64-v) {
+		a.integral = false
+		return
+	}
+	a.integerSum += v
+}
+
+func signedContent(t treasure.Treasure) (int64, bool) {
+	switch t.GetContentType() {
+	case treasure.ContentTypeInt8:
+		v, err := t.GetContentInt8()
+		return int64(v), err == nil
+	case treasure.ContentTypeInt16:
+		v, err := t.GetContentInt16()
+		return int64(v), err == nil
+	case treasure.ContentTypeInt32:
+		v, err := t.GetContentInt32()
+		return int64(v), err == nil
+	default:
+		v, err := t.GetContentInt64()
+		return v, err == nil
+	}
+}
+
+func unsignedContent(t treasure.Treasure) (uint64, bool) {
+	switch t.GetContentType() {
+	case treasure.ContentTypeUint8:
+		v, err := t.GetContentUint8()
+		return uint64(v), err == nil
+	case treasure.ContentTypeUint16:
+		v, err := t.GetContentUint16()
+		return uint64(v), err == nil
+	case treasure.ContentTypeUint32:
+		v, err := t.GetContentUint32()
+		return uint64(v), err == nil
+	default:
+		v, err := t.GetContentUint64()
+		return v, err == nil
+	}
+}
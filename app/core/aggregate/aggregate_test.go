@@ -0,0 +1,118 @@
+package aggregate
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func newTreasure(key string, setter func(t treasure.Treasure, guardID guard.ID)) treasure.Treasure {
+	t := treasure.New(nil)
+	guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
+	t.BodySetKey(guardID, key)
+	setter(t, guardID)
+	t.ReleaseTreasureGuard(guardID)
+	return t
+}
+
+func TestAggregator(t *testing.T) {
+
+	t.Run("should aggregate the numeric values of all types", func(t *testing.T) {
+
+		treasures := []treasure.Treasure{
+			newTreasure("a", func(t treasure.Treasure, g guard.ID) { t.SetContentInt8(g, -8) }),
+			newTreasure("b", func(t treasure.Treasure, g guard.ID) { t.SetContentInt16(g, 16) }),
+			newTreasure("c", func(t treasure.Treasure, g guard.ID) { t.SetContentInt32(g, 32) }),
+			newTreasure("d", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, 64) }),
+			newTreasure("e", func(t treasure.Treasure, g guard.ID) { t.SetContentUint8(g, 8) }),
+			newTreasure("f", func(t treasure.Treasure, g guard.ID) { t.SetContentUint16(g, 16) }),
+			newTreasure("g", func(t treasure.Treasure, g guard.ID) { t.SetContentUint32(g, 32) }),
+			newTreasure("h", func(t treasure.Treasure, g guard.ID) { t.SetContentUint64(g, 40) }),
+		}
+
+		aggregator := New()
+		for _, tr := range treasures {
+			assert.True(t, aggregator.Add(tr))
+		}
+
+		result := aggregator.Result()
+		assert.Equal(t, int64(8), result.Count)
+		assert.Equal(t, float64(200), result.Sum)
+		assert.Equal(t, float64(-8), result.Min)
+		assert.Equal(t, float64(64), result.Max)
+		assert.Equal(t, float64(25), result.Avg)
+		assert.True(t, result.IntegerSumValid)
+		assert.Equal(t, int64(200), result.IntegerSum)
+
+	})
+
+	t.Run("should skip the non-numeric values", func(t *testing.T) {
+
+		aggregator := New()
+		assert.False(t, IsNumeric(newTreasure("s", func(t treasure.Treasure, g guard.ID) { t.SetContentString(g, "12") })))
+		assert.True(t, IsNumeric(newTreasure("f", func(t treasure.Treasure, g guard.ID) { t.SetContentFloat32(g, 1.5) })))
+		assert.False(t, aggregator.Add(newTreasure("s", func(t treasure.Treasure, g guard.ID) { t.SetContentString(g, "12") })))
+		assert.False(t, aggregator.Add(newTreasure("b", func(t treasure.Treasure, g guard.ID) { t.SetContentBool(g, true) })))
+		assert.False(t, aggregator.Add(newTreasure("v", func(t treasure.Treasure, g guard.ID) { t.SetContentVoid(g) })))
+		assert.True(t, aggregator.Add(newTreasure("i", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, 5) })))
+
+		assert.Equal(t, int64(1), aggregator.Count())
+		result := aggregator.Result()
+		assert.Equal(t, int64(1), result.Count)
+		assert.Equal(t, float64(5), result.Min)
+		assert.Equal(t, float64(5), result.Max)
+
+	})
+
+	t.Run("should not have an integer sum with float values", func(t *testing.T) {
+
+		aggregator := New()
+		aggregator.Add(newTreasure("a", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, 1) }))
+		aggregator.Add(newTreasure("b", func(t treasure.Treasure, g guard.ID) { t.SetContentFloat32(g, 0.5) }))
+		aggregator.Add(newTreasure("c", func(t treasure.Treasure, g guard.ID) { t.SetContentFloat64(g, 1.5) }))
+
+		result := aggregator.Result()
+		assert.Equal(t, int64(3), result.Count)
+		assert.Equal(t, float64(3), result.Sum)
+		assert.Equal(t, float64(1), result.Avg)
+		assert.False(t, result.IntegerSumValid)
+
+	})
+
+	t.Run("should keep the integer sum exact above 2^53", func(t *testing.T) {
+
+		aggregator := New()
+		aggregator.Add(newTreasure("a", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, 1<<53) }))
+		aggregator.Add(newTreasure("b", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, 1) }))
+
+		result := aggregator.Result()
+		assert.True(t, result.IntegerSumValid)
+		assert.Equal(t, int64(1<<53+1), result.IntegerSum)
+
+	})
+
+	t.Run("should drop the integer sum on overflow", func(t *testing.T) {
+
+		aggregator := New()
+		aggregator.Add(newTreasure("a", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, math.MaxInt64) }))
+		aggregator.Add(newTreasure("b", func(t treasure.Treasure, g guard.ID) { t.SetContentInt64(g, 1) }))
+		assert.False(t, aggregator.Result().IntegerSumValid)
+
+		aggregator = New()
+		aggregator.Add(newTreasure("a", func(t treasure.Treasure, g guard.ID) { t.SetContentUint64(g, math.MaxUint64) }))
+		assert.False(t, aggregator.Result().IntegerSumValid)
+
+	})
+
+	t.Run("should return zero aggregates without values", func(t *testing.T) {
+
+		result := New().Result()
+		assert.Equal(t, int64(0), result.Count)
+		assert.Equal(t, float64(0), result.Sum)
+		assert.Equal(t, float64(0), result.Avg)
+
+	})
+
+}
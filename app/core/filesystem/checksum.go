@@ -0,0 +1,138 @@
+package filesystem
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// ErrCorruptedFile is returned (wrapped) when the content of a chunk file is damaged: its checksum does not match,
+// or it can not be decompressed or parsed. Check it with errors.Is.
+var ErrCorruptedFile = errors.New("corrupted file")
+
+// checksumMagic marks the chunk files written with a checksum header.
+//
+// The header is the magic followed by the CRC-32C checksum of the compressed content (4 bytes, little-endian).
+// The files written by older versions have no header, and they are read without verification. A Snappy block of an
+// older file starts with its decoded length as varint, so it can start with 0x00 only if it is the 1 byte long empty
+// block — it can not be mistaken for the 12 bytes long header.
+var checksumMagic = []byte{0x00, 'H', 'Y', 'D', 'C', 'R', 'C', '1'}
+
+// checksumHeaderSize is the size of the checksum header in bytes
+const checksumHeaderSize = 12
+
+// castagnoliTable is the CRC-32C table. CRC-32C is hardware accelerated on the most CPUs.
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// CorruptedFile describes a chunk file found corrupted since the start of the server
+type CorruptedFile struct {
+	// FilePath is the absolute path of the file
+	FilePath string
+	// Reason is the description of the damage
+	Reason string
+	// DetectedAt is the time when the corruption was detected last
+	DetectedAt time.Time
+}
+
+// addChecksum prepends the checksum header to the compressed content
+func addChecksum(compressedContent []byte) []byte {
+	content := make([]byte, 0, checksumHeaderSize+len(compressedContent))
+	content = append(content, checksumMagic...)
+	content = binary.LittleEndian.AppendUint32(content, crc32.Checksum(compressedContent, castagnoliTable))
+	return append(content, compressedContent...)
+}
+
+// verifyChecksum verifies and removes the checksum header of the file content, and returns the compressed content.
+// The content of the files without checksum header is returned as is.
+func verifyChecksum(fileContent []byte) ([]byte, error) {
+
+	if len(fileContent) == 0 {
+		// the files are written in one step, so an empty file is an interrupted write
+		return nil, errors.New("the file is empty")
+	}
+
+	if fileContent[0] != checksumMagic[0] || len(fileContent) == 1 {
+		// file of an older version without checksum header
+		return fileContent, nil
+	}
+
+	if len(fileContent) < checksumHeaderSize || !bytes.Equal(fileContent[:len(checksumMagic)], checksumMagic) {
+		return nil, errors.New("the checksum header is damaged")
+	}
+
+	expected := binary.LittleEndian.Uint32(fileContent[len(checksumMagic):checksumHeaderSize])
+	compressedContent := fileContent[checksumHeaderSize:]
+	if actual := crc32.Checksum(compressedContent, castagnoliTable); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch: expected %08x, got %08x", expected, actual)
+	}
+
+	return compressedContent, nil
+
+}
+
+// decodeFileContent verifies, decompresses and parses the content of a chunk file.
+// The damaged files are registered as corrupted, and an ErrCorruptedFile error is returned.
+func (fs *filesystem) decodeFileContent(filePath string, fileContent []byte) ([][]byte, error) {
+
+	compressedContent, err := verifyChecksum(fileContent)
+	if err != nil {
+		return nil, fs.ReportCorruptedFile(filePath, err)
+	}
+
+	decompressedContent, err := fs.compressorInterface.Decompress(compressedContent)
+	if err != nil {
+		return nil, fs.ReportCorruptedFile(filePath, fmt.Errorf("can not decompress the content: %w", err))
+	}
+
+	fileParts, err := parseBinaryData(decompressedContent)
+	if err != nil {
+		return nil, fs.ReportCorruptedFile(filePath, fmt.Errorf("can not parse the content: %w", err))
+	}
+
+	return fileParts, nil
+
+}
+
+func (fs *filesystem) ReportCorruptedFile(filePath string, reason error) error {
+
+	fs.corruptedFiles.Store(filePath, CorruptedFile{
+		FilePath:   filePath,
+		Reason:     reason.Error(),
+		DetectedAt: time.Now().UTC(),
+	})
+
+	slog.Error("corrupted file detected", "file", filePath, "reason", reason.Error())
+
+	return fmt.Errorf("%w %s: %v", ErrCorruptedFile, filePath, reason)
+
+}
+
+func (fs *filesystem) IsCorruptedFile(filePath string) bool {
+	_, ok := fs.corruptedFiles.Load(filePath)
+	return ok
+}
+
+func (fs *filesystem) GetCorruptedFiles() []CorruptedFile {
+	files := make([]CorruptedFile, 0)
+	fs.corruptedFiles.Range(func(_, value interface{}) bool {
+		files = append(files, value.(CorruptedFile))
+		return true
+	})
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].FilePath < files[j].FilePath
+	})
+	return files
+}
+
+func (fs *filesystem) SetSkipCorruptedFiles(skip bool) {
+	fs.skipCorruptedFiles.Store(skip)
+}
+
+func (fs *filesystem) IsSkipCorruptedFiles() bool {
+	return fs.skipCorruptedFiles.Load()
+}
@@ -0,0 +1,143 @@
+package filesystem
+
+import (
+	"errors"
+	"github.com/hydraide/hydraide/app/core/compressor"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// corruptFile flips the last byte of the file
+func corruptFile(t *testing.T, filePath string) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	content[len(content)-1] ^= 0xff
+	if err := os.WriteFile(filePath, content, os.ModePerm); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+
+	content := [][]byte{[]byte("block1"), []byte("block2")}
+
+	t.Run("should detect the damaged file", func(t *testing.T) {
+
+		fs := New()
+		filePath := filepath.Join(t.TempDir(), "chunk")
+		if err := fs.SaveFile(filePath, content, false); err != nil {
+			t.Fatalf("Failed to save file: %v", err)
+		}
+		corruptFile(t, filePath)
+
+		_, err := fs.GetFile(filePath)
+		if !errors.Is(err, ErrCorruptedFile) {
+			t.Fatalf("Expected ErrCorruptedFile, got %v", err)
+		}
+		if !fs.IsCorruptedFile(filePath) {
+			t.Errorf("Expected the file to be registered as corrupted")
+		}
+		corrupted := fs.GetCorruptedFiles()
+		if len(corrupted) != 1 || corrupted[0].FilePath != filePath || corrupted[0].Reason == "" {
+			t.Errorf("Unexpected corrupted files: %+v", corrupted)
+		}
+
+		// appending to the damaged file would seal the damage with a valid checksum
+		if err := fs.SaveFile(filePath, content, true); !errors.Is(err, ErrCorruptedFile) {
+			t.Errorf("Expected ErrCorruptedFile on append, got %v", err)
+		}
+
+		// the overwritten file is valid again
+		if err := fs.SaveFile(filePath, content, false); err != nil {
+			t.Fatalf("Failed to overwrite file: %v", err)
+		}
+		if fs.IsCorruptedFile(filePath) {
+			t.Errorf("Expected the overwritten file not to be corrupted")
+		}
+
+	})
+
+	t.Run("should read the files without checksum header", func(t *testing.T) {
+
+		fs := New()
+		filePath := filepath.Join(t.TempDir(), "legacy")
+		compressedContent, err := compressor.New(compressor.Snappy).Compress(flattenContent(content))
+		if err != nil {
+			t.Fatalf("Failed to compress content: %v", err)
+		}
+		if err := os.WriteFile(filePath, compressedContent, os.ModePerm); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+
+		readContent, err := fs.GetFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to read legacy file: %v", err)
+		}
+		if !compareContent(content, readContent) {
+			t.Errorf("Content mismatch: expected %v, got %v", content, readContent)
+		}
+
+		// the appended file gets the checksum header
+		if err := fs.SaveFile(filePath, content, true); err != nil {
+			t.Fatalf("Failed to append to legacy file: %v", err)
+		}
+		raw, _ := os.ReadFile(filePath)
+		if _, err := verifyChecksum(raw); err != nil || raw[0] != checksumMagic[0] {
+			t.Errorf("Expected the appended file to have a valid checksum header")
+		}
+
+	})
+
+	t.Run("should skip or report the corrupted files of a folder", func(t *testing.T) {
+
+		fs := New()
+		folder := t.TempDir()
+		for _, fileName := range []string{"valid", "damaged"} {
+			if err := fs.SaveFile(filepath.Join(folder, fileName), content, false); err != nil {
+				t.Fatalf("Failed to save file: %v", err)
+			}
+		}
+		corruptFile(t, filepath.Join(folder, "damaged"))
+
+		contents, err := fs.GetAllFileContents(folder)
+		if err != nil {
+			t.Fatalf("Expected the corrupted file to be skipped, got %v", err)
+		}
+		if _, ok := contents["valid"]; !ok || len(contents) != 1 {
+			t.Errorf("Expected only the valid file, got %d files", len(contents))
+		}
+
+		fs.SetSkipCorruptedFiles(false)
+		if _, err := fs.GetAllFileContents(folder); !errors.Is(err, ErrCorruptedFile) {
+			t.Errorf("Expected ErrCorruptedFile without skipping, got %v", err)
+		}
+
+	})
+
+	t.Run("should detect the truncated files", func(t *testing.T) {
+
+		fs := New()
+		for _, raw := range [][]byte{{}, checksumMagic[:5], append(append([]byte{}, checksumMagic...), 1, 2)} {
+			if _, err := verifyChecksum(raw); err == nil {
+				t.Errorf("Expected an error for the truncated content %v", raw)
+			}
+		}
+
+		filePath := filepath.Join(t.TempDir(), "truncated")
+		if err := os.WriteFile(filePath, nil, os.ModePerm); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if _, err := fs.GetFile(filePath); !errors.Is(err, ErrCorruptedFile) {
+			t.Errorf("Expected ErrCorruptedFile for the empty file, got %v", err)
+		}
+
+		if _, err := parseBinaryData(append(encodeBinaryLength([]byte("block")), 'b')); err == nil {
+			t.Errorf("Expected an error for the truncated block")
+		}
+
+	})
+
+}
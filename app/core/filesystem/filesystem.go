@@ -8,6 +8,8 @@
 //   - Safe concurrent access using mutexes per file/folder
 //   - Transparent compression (e.g. Snappy) via the Compressor interface
 //   - Append and overwrite modes for structured binary data
+//   - CRC-32C checksum of every file, verified on read, so the damaged files are detected instead of
+//     failing later with confusing decode errors
 //   - Recursive deletion with depth limit
 //   - Metadata-friendly structure for integration with higher-level swamp logic
 //
@@ -19,12 +21,16 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"github.com/hydraide/hydraide/app/core/compressor"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sync"
+	"sync/atomic"
 )
 
 // Filesystem defines thread-safe file and folder operations with support for
@@ -46,6 +52,11 @@ type Filesystem interface {
 	// If false, the file is overwritten with the new content.
 	SaveFile(filePath string, content [][]byte, appendFile bool) error
 
+	// SyncFiles flushes the given files and their folders from the page cache of the operating system to the disk,
+	// so they survive a power loss. The files that do not exist anymore are skipped, but their folders are flushed,
+	// so their deletions are durable too.
+	SyncFiles(filePaths ...string) error
+
 	// DeleteFile removes the specified file if it exists.
 	DeleteFile(filePath string) error
 
@@ -58,24 +69,52 @@ type Filesystem interface {
 
 	// GetAllFileContents reads all files in the given folder (excluding listed ones),
 	// and returns a map of filename to binary content segments.
+	// The corrupted files are skipped if IsSkipCorruptedFiles is true, otherwise an ErrCorruptedFile error is returned.
 	GetAllFileContents(folderPath string, excludedFiles ...string) (map[string][][]byte, error)
 
 	// GetFileSize returns the size of the file in bytes.
 	GetFileSize(filePath string) (int64, error)
 
+	// GetFileSizes returns the sizes of the files in the given folder (excluding listed ones) in bytes,
+	// mapped by their file names. Subfolders are not listed.
+	GetFileSizes(folderPath string, excludedFiles ...string) (map[string]int64, error)
+
 	// IsFolderExists checks whether the given folder path exists.
 	IsFolderExists(folderPath string) bool
+
+	// ReportCorruptedFile registers the file as corrupted and returns the ErrCorruptedFile error of it.
+	// Used by the callers that find the content of the file undecodable, e.g. a Treasure can not be loaded from it.
+	ReportCorruptedFile(filePath string, reason error) error
+
+	// IsCorruptedFile returns true if the file is found corrupted since the start of the server.
+	IsCorruptedFile(filePath string) bool
+
+	// GetCorruptedFiles returns the files found corrupted since the start of the server, ordered by their paths.
+	// A file is removed from the list when it is overwritten or deleted.
+	GetCorruptedFiles() []CorruptedFile
+
+	// SetSkipCorruptedFiles sets whether the corrupted files are skipped while loading a folder. True by default.
+	SetSkipCorruptedFiles(skip bool)
+
+	// IsSkipCorruptedFiles returns true if the corrupted files are skipped while loading a folder.
+	IsSkipCorruptedFiles() bool
 }
 
+// binaryLengthSize is the size of the length prefix of the binary parts
+const binaryLengthSize = 4
+
 type filesystem struct {
 	folderLocks         sync.Map              // Mappa zárolások kezelése
 	compressorInterface compressor.Compressor // compressorInterface a fájlok be és -kitömörítését kezeli
+	corruptedFiles      sync.Map              // the corrupted files by their paths
+	skipCorruptedFiles  atomic.Bool           // skip the corrupted files while loading a folder
 }
 
 func New() Filesystem {
 	fs := &filesystem{
 		compressorInterface: compressor.New(compressor.Snappy),
 	}
+	fs.skipCorruptedFiles.Store(true)
 	return fs
 }
 
@@ -189,27 +228,26 @@ func (fs *filesystem) SaveFile(filePath string, content [][]byte, appendFile boo
 			return err
 		}
 
-		// Decompress existing content (if any)
+		// Verify and decompress existing content (if any). Never append to a corrupted file, because the
+		// rewritten file would get a valid checksum over the damaged content.
 		if len(existingContent) > 0 {
-			decompressedContent, err := fs.compressorInterface.Decompress(existingContent)
+			compressedContent, err := verifyChecksum(existingContent)
 			if err != nil {
-				return err
+				return fs.ReportCorruptedFile(filePath, err)
+			}
+			decompressedContent, err := fs.compressorInterface.Decompress(compressedContent)
+			if err != nil {
+				return fs.ReportCorruptedFile(filePath, fmt.Errorf("can not decompress the content: %w", err))
 			}
 			finalContent = decompressedContent
 		}
 
 		// Append new binary parts to the decompressed content
-		for _, part := range content {
-			finalContent = append(finalContent, encodeBinaryLength(part)...)
-			finalContent = append(finalContent, part...)
-		}
+		finalContent = appendBinaryParts(finalContent, content)
 
 	} else {
 		// If not appending, build content from scratch
-		for _, part := range content {
-			finalContent = append(finalContent, encodeBinaryLength(part)...)
-			finalContent = append(finalContent, part...)
-		}
+		finalContent = appendBinaryParts(nil, content)
 	}
 
 	// Compress the final binary content
@@ -218,8 +256,79 @@ func (fs *filesystem) SaveFile(filePath string, content [][]byte, appendFile boo
 		return err
 	}
 
-	// Write the compressed content to the file
-	return os.WriteFile(filePath, compressedContent, os.ModePerm)
+	// Write the compressed content to the file with its checksum
+	if err := os.WriteFile(filePath, addChecksum(compressedContent), os.ModePerm); err != nil {
+		return err
+	}
+
+	// the file is rewritten with valid content, so it is not corrupted anymore
+	fs.corruptedFiles.Delete(filePath)
+
+	return nil
+}
+
+// SyncFiles flushes the given files and their folders to the disk. The folders are flushed after the files, because
+// a new file is only durable when its entry in the folder is durable too.
+func (fs *filesystem) SyncFiles(filePaths ...string) error {
+
+	folders := make([]string, 0, 1)
+	for _, filePath := range filePaths {
+
+		if err := fs.syncFile(filePath); err != nil {
+			return err
+		}
+
+		if folder := filepath.Dir(filePath); !slices.Contains(folders, folder) {
+			folders = append(folders, folder)
+		}
+
+	}
+
+	// the folders can not be opened for writing on Windows, and NTFS keeps their entries durable by itself
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	for _, folder := range folders {
+		if err := syncPath(folder); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// syncFile flushes the file to the disk under its lock, so it is not flushed while it is rewritten
+func (fs *filesystem) syncFile(filePath string) error {
+
+	fileLock := fs.getFolderLock(filePath)
+	fileLock.Lock()
+	defer fileLock.Unlock()
+
+	if err := syncPath(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+
+}
+
+// syncPath flushes the file or the folder to the disk
+func syncPath(path string) error {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("can not sync %s: %w", path, err)
+	}
+
+	return f.Close()
+
 }
 
 // DeleteFile removes the specified file if it exists.
@@ -249,6 +358,9 @@ func (fs *filesystem) DeleteFile(filePath string) error {
 		return err // Return error if deletion failed
 	}
 
+	// a deleted file is not corrupted anymore
+	fs.corruptedFiles.Delete(filePath)
+
 	// Delete the file from remote storage (e.g., aegisInterface)
 	// TODO: implement remote file deletion
 	return nil
@@ -292,6 +404,7 @@ func (fs *filesystem) DeleteAllFiles(folderPath string) error {
 			fileLock.Unlock() // Always release the lock before returning
 			return err
 		}
+		fs.corruptedFiles.Delete(filePath)
 
 		// TODO: Implement deletion from remote Aegis storage
 		// Example:
@@ -346,19 +459,8 @@ func (fs *filesystem) GetFile(filePath string) ([][]byte, error) {
 		return nil, err
 	}
 
-	// Decompress the content
-	decompressedContent, err := fs.compressorInterface.Decompress(fileContent)
-	if err != nil {
-		return nil, err // Return if decompression failed
-	}
-
-	// Parse the binary content into individual byte slices
-	fileParts, err := parseBinaryData(decompressedContent)
-	if err != nil {
-		return nil, err
-	}
-
-	return fileParts, nil
+	// Verify, decompress and parse the content into individual byte slices
+	return fs.decodeFileContent(filePath, fileContent)
 }
 
 // GetAllFileContents reads the contents of all files in the specified folder,
@@ -436,16 +538,13 @@ func (fs *filesystem) GetAllFileContents(folderPath string, excludedFiles ...str
 		// Release the file lock
 		fileLock.Unlock()
 
-		// Decompress file content
-		decompressedContent, err := fs.compressorInterface.Decompress(fileContent)
-		if err != nil {
-			continue // Skip on decompression failure
-		}
-
-		// Parse binary data segments
-		fileParts, err := parseBinaryData(decompressedContent)
+		// Verify, decompress and parse the binary data segments
+		fileParts, err := fs.decodeFileContent(filePath, fileContent)
 		if err != nil {
-			continue // Skip on parse failure
+			if fs.IsSkipCorruptedFiles() {
+				continue // Skip the corrupted file, it is registered and logged already
+			}
+			return nil, err
 		}
 
 		// Store the parsed content under the filename
@@ -477,6 +576,51 @@ func (fs *filesystem) GetFileSize(filePath string) (int64, error) {
 	return fileInfo.Size(), nil
 }
 
+// GetFileSizes returns the sizes of the files in the given folder in bytes, excluding any files listed in excludedFiles.
+func (fs *filesystem) GetFileSizes(folderPath string, excludedFiles ...string) (map[string]int64, error) {
+	// Validate the folder path
+	if folderPath == "" {
+		return nil, errors.New("invalid folder path")
+	}
+
+	// Build a fast lookup set for excluded file names
+	excluded := make(map[string]struct{}, len(excludedFiles))
+	for _, file := range excludedFiles {
+		excluded[file] = struct{}{}
+	}
+
+	// Lock the folder to ensure safe access
+	folderLock := fs.getFolderLock(folderPath)
+	folderLock.Lock()
+	defer folderLock.Unlock()
+
+	// Read all entries in the folder
+	entries, err := os.ReadDir(folderPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSizes := make(map[string]int64, len(entries))
+	for _, entry := range entries {
+
+		// Skip the subfolders and the excluded files
+		if entry.IsDir() {
+			continue
+		}
+		if _, skip := excluded[entry.Name()]; skip {
+			continue
+		}
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			continue // Skip the file if it is deleted meanwhile
+		}
+		fileSizes[entry.Name()] = fileInfo.Size()
+	}
+
+	return fileSizes, nil
+}
+
 // parseBinaryData iterates over a decompressed binary stream and splits it into
 // separate byte slices based on length-prefixed blocks.
 // Each block is prefixed with a 4-byte little-endian length header.
@@ -496,9 +640,9 @@ func parseBinaryData(data []byte) ([][]byte, error) {
 			return nil, err // Failed to read length
 		}
 
-		// Read the data block of the given length
+		// Read the data block of the given length. A truncated block is an error
 		dataBlock := make([]byte, length)
-		_, err = reader.Read(dataBlock)
+		_, err = io.ReadFull(reader, dataBlock)
 		if err != nil {
 			return nil, err // Failed to read block
 		}
@@ -509,6 +653,22 @@ func parseBinaryData(data []byte) ([][]byte, error) {
 	return result, nil
 }
 
+// appendBinaryParts coalesces the binary parts into one sequential buffer after dst, each part prefixed with its
+// 4-byte little-endian length. The buffer is grown only once, so writing thousands of small parts does not mean
+// thousands of allocations.
+func appendBinaryParts(dst []byte, parts [][]byte) []byte {
+	size := 0
+	for _, part := range parts {
+		size += binaryLengthSize + len(part)
+	}
+	dst = slices.Grow(dst, size)
+	for _, part := range parts {
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(part)))
+		dst = append(dst, part...)
+	}
+	return dst
+}
+
 // encodeBinaryLength encodes the length of a binary slice
 // as a 4-byte little-endian value (used as a prefix).
 func encodeBinaryLength(data []byte) []byte {
@@ -2,6 +2,7 @@ package filesystem
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/hydraide/hydraide/app/core/compressor"
 	"os"
 	"path/filepath"
@@ -231,6 +232,51 @@ func generateTestContent(blocks int, baseValue int) [][]byte {
 	return content
 }
 
+// TestSyncFiles tests that the written, the deleted and the missing files can be flushed to the disk.
+func TestSyncFiles(t *testing.T) {
+	fs := New()
+
+	// Set up test environment
+	err := setupTestEnvironment()
+	if err != nil {
+		t.Fatalf("Failed to set up test environment: %v", err)
+	}
+	defer cleanupTestEnvironment()
+
+	testFolder := filepath.Join(testRootFolder, "sync_test")
+	writtenFile := filepath.Join(testFolder, "written.dat")
+	deletedFile := filepath.Join(testFolder, "deleted.dat")
+
+	if err := fs.SaveFile(writtenFile, generateTestContent(3, 1), false); err != nil {
+		t.Fatalf("Failed to save file: %v", err)
+	}
+	if err := fs.SaveFile(deletedFile, generateTestContent(3, 2), false); err != nil {
+		t.Fatalf("Failed to save file: %v", err)
+	}
+	if err := fs.DeleteFile(deletedFile); err != nil {
+		t.Fatalf("Failed to delete file: %v", err)
+	}
+
+	// the deleted file is skipped, but its folder is flushed
+	if err := fs.SyncFiles(writtenFile, deletedFile); err != nil {
+		t.Errorf("Failed to sync the files: %v", err)
+	}
+
+	// the missing folder is skipped too
+	if err := fs.SyncFiles(filepath.Join(testFolder, "missing", "file.dat")); err != nil {
+		t.Errorf("Failed to sync a file of a missing folder: %v", err)
+	}
+
+	// the content is not changed by the sync
+	content, err := fs.GetFile(writtenFile)
+	if err != nil {
+		t.Fatalf("Failed to read the synced file: %v", err)
+	}
+	if len(content) != 3 {
+		t.Errorf("Expected 3 binary parts in the synced file, got %d", len(content))
+	}
+}
+
 // TestGetFileSize verifies the correctness of the GetFileSize function,
 // including compressed size accuracy and error handling for edge cases.
 func TestGetFileSize(t *testing.T) {
@@ -272,7 +318,7 @@ func TestGetFileSize(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to compress content for %s: %v", fileName, err)
 		}
-		expectedSize := int64(len(compressedContent))
+		expectedSize := int64(checksumHeaderSize + len(compressedContent))
 
 		// Get actual file size
 		size, err := fs.GetFileSize(filePath)
@@ -280,7 +326,7 @@ func TestGetFileSize(t *testing.T) {
 			t.Errorf("Failed to get file size for %s: %v", fileName, err)
 		}
 		if size != expectedSize {
-			t.Errorf("File size mismatch for %s: expected %d bytes (compressed), got %d bytes", fileName, expectedSize, size)
+			t.Errorf("File size mismatch for %s: expected %d bytes (compressed with checksum header), got %d bytes", fileName, expectedSize, size)
 		}
 	}
 
@@ -305,6 +351,27 @@ func TestGetFileSize(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expected error for non-existent file %s, but got nil", nonExistentFile)
 	}
+
+	// 4. Test: List the sizes of all files in the folder, except the excluded ones
+	sizes, err := fs.GetFileSizes(testFolder, "file3.dat")
+	if err != nil {
+		t.Fatalf("Failed to get file sizes: %v", err)
+	}
+	if len(sizes) != 3 {
+		t.Errorf("Expected 3 file sizes, got %d: %v", len(sizes), sizes)
+	}
+	if _, ok := sizes["file3.dat"]; ok {
+		t.Errorf("Excluded file file3.dat should not be listed")
+	}
+	for fileName, listedSize := range sizes {
+		size, err := fs.GetFileSize(filepath.Join(testFolder, fileName))
+		if err != nil {
+			t.Errorf("Failed to get file size for %s: %v", fileName, err)
+		}
+		if listedSize != size {
+			t.Errorf("Listed size mismatch for %s: expected %d bytes, got %d bytes", fileName, size, listedSize)
+		}
+	}
 }
 
 // flattenContent prepares [][]byte data into a single binary slice,
@@ -436,3 +503,80 @@ func TestDeleteAllFiles(t *testing.T) {
 		}
 	}
 }
+
+// benchmarkFolder returns the folder of the benchmark files. Set HYDRAIDE_BENCH_DIR to a folder on the disk to
+// measure (e.g. an NVMe and a SATA mount), otherwise the files are written to a temporary folder.
+func benchmarkFolder(b *testing.B) string {
+	if dir := os.Getenv("HYDRAIDE_BENCH_DIR"); dir != "" {
+		folder, err := os.MkdirTemp(dir, "hydraide-bench-")
+		if err != nil {
+			b.Fatalf("Failed to create benchmark folder: %v", err)
+		}
+		b.Cleanup(func() { _ = os.RemoveAll(folder) })
+		return folder
+	}
+	return b.TempDir()
+}
+
+// BenchmarkSaveFile measures the write throughput of a chunk file with different numbers of treasure sized parts,
+// written as one coalesced buffer.
+func BenchmarkSaveFile(b *testing.B) {
+
+	fs := New()
+	folder := benchmarkFolder(b)
+
+	for _, parts := range []int{10, 100, 1000} {
+		content := make([][]byte, parts)
+		size := 0
+		for i := range content {
+			content[i] = bytes.Repeat([]byte{byte(i)}, 256)
+			size += len(content[i])
+		}
+
+		b.Run(fmt.Sprintf("parts-%d", parts), func(b *testing.B) {
+			filePath := filepath.Join(folder, fmt.Sprintf("save-%d", parts))
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := fs.SaveFile(filePath, content, false); err != nil {
+					b.Fatalf("Failed to save file: %v", err)
+				}
+			}
+		})
+	}
+
+}
+
+// BenchmarkAppendBinaryParts measures the encoding of the parts into one sequential buffer.
+func BenchmarkAppendBinaryParts(b *testing.B) {
+	content := generateTestContent(1000, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = appendBinaryParts(nil, content)
+	}
+}
+
+// TestAppendBinaryParts verifies that the coalesced buffer is the same as the parts encoded one by one.
+func TestAppendBinaryParts(t *testing.T) {
+	content := [][]byte{[]byte("block1"), {}, []byte("block3")}
+
+	if encoded := appendBinaryParts(nil, content); !bytes.Equal(encoded, flattenContent(content)) {
+		t.Errorf("Encoded content mismatch: expected %v, got %v", flattenContent(content), encoded)
+	}
+
+	prefix := []byte("existing")
+	encoded := appendBinaryParts(prefix, content)
+	if !bytes.Equal(encoded, append([]byte("existing"), flattenContent(content)...)) {
+		t.Errorf("Appended content mismatch: got %v", encoded)
+	}
+
+	parsed, err := parseBinaryData(appendBinaryParts(nil, content))
+	if err != nil {
+		t.Fatalf("Failed to parse the encoded content: %v", err)
+	}
+	if len(parsed) != len(content) || !bytes.Equal(parsed[0], content[0]) || !bytes.Equal(parsed[2], content[2]) {
+		t.Errorf("Parsed content mismatch: expected %v, got %v", content, parsed)
+	}
+}
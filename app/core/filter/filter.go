@@ -0,0 +1,690 @@
+// Package filter implements the minimal filter expression language of HydrAIDE.
+// The expressions are evaluated server-side against the Treasures of a Swamp, so the clients do not need to
+// download whole Swamps for ad-hoc queries.
+//
+// Grammar:
+//
+//	expression  = orExpr
+//	orExpr      = andExpr { ("OR" | "||") andExpr }
+//	andExpr     = unary { ("AND" | "&&") unary }
+//	unary       = ("NOT" | "!") unary | "(" expression ")" | comparison
+//	comparison  = field operator literal
+//	field       = "key" | "value" | "createdAt" | "createdBy" | "updatedAt" | "updatedBy" | "expiredAt"
+//	operator    = "=" | "==" | "!=" | "<>" | ">" | ">=" | "<" | "<="
+//	literal     = number | 'string' | "string" | true | false | date
+//
+// The date literals can be written as 2024-01-01, 2024-01-01T10:00:00 or in RFC3339 format, quoted or unquoted.
+//
+// Example:
+//
+//	value >= 18 AND createdAt > 2024-01-01
+//	(key = "user-1" OR key = "user-2") AND NOT updatedBy = "system"
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expression is a parsed filter expression
+type Expression interface {
+	// Evaluate returns true if the treasure matches the expression.
+	// The function only reads the treasure, so the caller does not need to start a treasure guard.
+	Evaluate(t treasure.Treasure) bool
+	// String returns the normalized form of the expression. Useful for logging.
+	String() string
+}
+
+// Parse parses the filter expression.
+// Returns an error if the expression is syntactically incorrect or contains unknown fields.
+func Parse(input string) (Expression, error) {
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("filter expression is empty")
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].pos)
+	}
+
+	return expr, nil
+
+}
+
+// -- tokenizer ------------------------------------------------------------------------
+
+type tokenType int
+
+const (
+	tokenWord tokenType = iota
+	tokenString
+	tokenOperator
+	tokenOpenParen
+	tokenCloseParen
+)
+
+type token struct {
+	typ  tokenType
+	text string
+	pos  int
+}
+
+func tokenize(input string) ([]token, error) {
+
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{typ: tokenOpenParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{typ: tokenCloseParen, text: ")", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var sb strings.Builder
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == r {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			tokens = append(tokens, token{typ: tokenString, text: sb.String(), pos: start})
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+			tokens = append(tokens, token{typ: tokenWord, text: string([]rune{r, r}), pos: i})
+			i += 2
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			i++
+			if i < len(runes) && (runes[i] == '=' || (r == '<' && runes[i] == '>')) {
+				i++
+			}
+			text := string(runes[start:i])
+			if text == "!" {
+				// the single exclamation mark is the negation
+				tokens = append(tokens, token{typ: tokenWord, text: text, pos: start})
+				continue
+			}
+			tokens = append(tokens, token{typ: tokenOperator, text: text, pos: start})
+		case isWordRune(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (isWordRune(runes[i]) || runes[i] == '-' || runes[i] == ':' || runes[i] == '+') {
+				i++
+			}
+			tokens = append(tokens, token{typ: tokenWord, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+
+	}
+
+	return tokens, nil
+
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// -- parser ---------------------------------------------------------------------------
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) isKeyword(keywords ...string) bool {
+	t := p.peek()
+	if t == nil || t.typ != tokenWord {
+		return false
+	}
+	for _, k := range keywords {
+		if strings.EqualFold(t.text, k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR", "||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND", "&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+
+	if p.isKeyword("NOT", "!") {
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{inner: inner}, nil
+	}
+
+	t := p.peek()
+	if t == nil {
+		return nil, errors.New("unexpected end of the filter expression")
+	}
+
+	if t.typ == tokenOpenParen {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing := p.peek()
+		if closing == nil || closing.typ != tokenCloseParen {
+			return nil, fmt.Errorf("missing closing parenthesis for the parenthesis at position %d", t.pos)
+		}
+		p.pos++
+		return inner, nil
+	}
+
+	return p.parseComparison()
+
+}
+
+func (p *parser) parseComparison() (Expression, error) {
+
+	fieldToken := p.peek()
+	if fieldToken.typ != tokenWord {
+		return nil, fmt.Errorf("expected field name at position %d, got %q", fieldToken.pos, fieldToken.text)
+	}
+	p.pos++
+
+	f, err := parseField(fieldToken)
+	if err != nil {
+		return nil, err
+	}
+
+	opToken := p.peek()
+	if opToken == nil || opToken.typ != tokenOperator {
+		return nil, fmt.Errorf("expected operator after the field %q", fieldToken.text)
+	}
+	p.pos++
+
+	op, err := parseOperator(opToken)
+	if err != nil {
+		return nil, err
+	}
+
+	literalToken := p.peek()
+	if literalToken == nil || (literalToken.typ != tokenWord && literalToken.typ != tokenString) {
+		return nil, fmt.Errorf("expected value after the operator %q", opToken.text)
+	}
+	p.pos++
+
+	lit, err := parseLiteral(f, literalToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if lit.kind == literalBool && op != operatorEqual && op != operatorNotEqual {
+		return nil, fmt.Errorf("boolean values can only be compared with = and != at position %d", opToken.pos)
+	}
+
+	return &comparison{field: f, operator: op, literal: lit}, nil
+
+}
+
+// -- fields, operators and literals ------------------------------------------------------
+
+type field int
+
+const (
+	fieldKey field = iota
+	fieldValue
+	fieldCreatedAt
+	fieldCreatedBy
+	fieldUpdatedAt
+	fieldUpdatedBy
+	fieldExpiredAt
+)
+
+var fieldNames = map[string]field{
+	"key":       fieldKey,
+	"value":     fieldValue,
+	"createdat": fieldCreatedAt,
+	"createdby": fieldCreatedBy,
+	"updatedat": fieldUpdatedAt,
+	"updatedby": fieldUpdatedBy,
+	"expiredat": fieldExpiredAt,
+	"expireat":  fieldExpiredAt,
+}
+
+func (f field) String() string {
+	switch f {
+	case fieldKey:
+		return "key"
+	case fieldValue:
+		return "value"
+	case fieldCreatedAt:
+		return "createdAt"
+	case fieldCreatedBy:
+		return "createdBy"
+	case fieldUpdatedAt:
+		return "updatedAt"
+	case fieldUpdatedBy:
+		return "updatedBy"
+	default:
+		return "expiredAt"
+	}
+}
+
+func (f field) isTime() bool {
+	return f == fieldCreatedAt || f == fieldUpdatedAt || f == fieldExpiredAt
+}
+
+func parseField(t *token) (field, error) {
+	if strings.HasPrefix(strings.ToLower(t.text), "value.") {
+		// the complex values are stored in binary (GOB) format, so the server can not see their fields
+		return 0, fmt.Errorf("nested value fields like %q are not supported, only primitive values can be filtered", t.text)
+	}
+	f, ok := fieldNames[strings.ToLower(t.text)]
+	if !ok {
+		return 0, fmt.Errorf("unknown field %q at position %d", t.text, t.pos)
+	}
+	return f, nil
+}
+
+type operator int
+
+const (
+	operatorEqual operator = iota
+	operatorNotEqual
+	operatorGreaterThan
+	operatorGreaterThanOrEqual
+	operatorLessThan
+	operatorLessThanOrEqual
+)
+
+func (o operator) String() string {
+	switch o {
+	case operatorEqual:
+		return "="
+	case operatorNotEqual:
+		return "!="
+	case operatorGreaterThan:
+		return ">"
+	case operatorGreaterThanOrEqual:
+		return ">="
+	case operatorLessThan:
+		return "<"
+	default:
+		return "<="
+	}
+}
+
+func parseOperator(t *token) (operator, error) {
+	switch t.text {
+	case "=", "==":
+		return operatorEqual, nil
+	case "!=", "<>":
+		return operatorNotEqual, nil
+	case ">":
+		return operatorGreaterThan, nil
+	case ">=":
+		return operatorGreaterThanOrEqual, nil
+	case "<":
+		return operatorLessThan, nil
+	case "<=":
+		return operatorLessThanOrEqual, nil
+	default:
+		return 0, fmt.Errorf("unknown operator %q at position %d", t.text, t.pos)
+	}
+}
+
+type literalKind int
+
+const (
+	literalString literalKind = iota
+	literalNumber
+	literalBool
+	literalTime
+)
+
+type literal struct {
+	kind literalKind
+	text string
+	// number representations. isInt and isUint are true if the number can be represented exactly as int64/uint64
+	floatValue float64
+	intValue   int64
+	uintValue  uint64
+	isInt      bool
+	isUint     bool
+	boolValue  bool
+	timeValue  int64 // unix nano
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parseTime(s string) (int64, bool) {
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed.UTC().UnixNano(), true
+		}
+	}
+	return 0, false
+}
+
+func parseLiteral(f field, t *token) (*literal, error) {
+
+	// time fields accept dates in quoted and unquoted format too
+	if f.isTime() {
+		nano, ok := parseTime(t.text)
+		if !ok {
+			return nil, fmt.Errorf("invalid date %q for the field %s at position %d", t.text, f, t.pos)
+		}
+		return &literal{kind: literalTime, text: t.text, timeValue: nano}, nil
+	}
+
+	if t.typ == tokenString {
+		return &literal{kind: literalString, text: t.text}, nil
+	}
+
+	if f != fieldValue {
+		return nil, fmt.Errorf("the field %s can only be compared to a quoted string at position %d", f, t.pos)
+	}
+
+	switch strings.ToLower(t.text) {
+	case "true":
+		return &literal{kind: literalBool, text: t.text, boolValue: true}, nil
+	case "false":
+		return &literal{kind: literalBool, text: t.text, boolValue: false}, nil
+	}
+
+	floatValue, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q at position %d, strings must be quoted", t.text, t.pos)
+	}
+
+	lit := &literal{kind: literalNumber, text: t.text, floatValue: floatValue}
+	if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+		lit.intValue = i
+		lit.isInt = true
+	}
+	if u, err := strconv.ParseUint(t.text, 10, 64); err == nil {
+		lit.uintValue = u
+		lit.isUint = true
+	}
+
+	return lit, nil
+
+}
+
+// -- expressions ----------------------------------------------------------------------
+
+type andExpression struct {
+	left, right Expression
+}
+
+func (e *andExpression) Evaluate(t treasure.Treasure) bool {
+	return e.left.Evaluate(t) && e.right.Evaluate(t)
+}
+
+func (e *andExpression) String() string {
+	return fmt.Sprintf("(%s AND %s)", e.left, e.right)
+}
+
+type orExpression struct {
+	left, right Expression
+}
+
+func (e *orExpression) Evaluate(t treasure.Treasure) bool {
+	return e.left.Evaluate(t) || e.right.Evaluate(t)
+}
+
+func (e *orExpression) String() string {
+	return fmt.Sprintf("(%s OR %s)", e.left, e.right)
+}
+
+type notExpression struct {
+	inner Expression
+}
+
+func (e *notExpression) Evaluate(t treasure.Treasure) bool {
+	return !e.inner.Evaluate(t)
+}
+
+func (e *notExpression) String() string {
+	return fmt.Sprintf("NOT %s", e.inner)
+}
+
+type comparison struct {
+	field    field
+	operator operator
+	literal  *literal
+}
+
+func (c *comparison) String() string {
+	if c.literal.kind == literalString {
+		return fmt.Sprintf("%s %s %q", c.field, c.operator, c.literal.text)
+	}
+	return fmt.Sprintf("%s %s %s", c.field, c.operator, c.literal.text)
+}
+
+// Evaluate compares the field of the treasure to the literal.
+// Missing metadata (e.g. a treasure without createdAt) and type mismatches never match.
+func (c *comparison) Evaluate(t treasure.Treasure) bool {
+	switch c.field {
+	case fieldKey:
+		return compareOrdered(t.GetKey(), c.literal.text, c.operator)
+	case fieldCreatedBy:
+		return compareOrdered(t.GetCreatedBy(), c.literal.text, c.operator)
+	case fieldUpdatedBy:
+		return compareOrdered(t.GetModifiedBy(), c.literal.text, c.operator)
+	case fieldCreatedAt:
+		return compareTime(t.GetCreatedAt(), c.literal.timeValue, c.operator)
+	case fieldUpdatedAt:
+		return compareTime(t.GetModifiedAt(), c.literal.timeValue, c.operator)
+	case fieldExpiredAt:
+		return compareTime(t.GetExpirationTime(), c.literal.timeValue, c.operator)
+	default:
+		return c.evaluateValue(t)
+	}
+}
+
+func (c *comparison) evaluateValue(t treasure.Treasure) bool {
+
+	lit := c.literal
+
+	switch t.GetContentType() {
+	case treasure.ContentTypeString:
+		if lit.kind != literalString {
+			return false
+		}
+		v, err := t.GetContentString()
+		return err == nil && compareOrdered(v, lit.text, c.operator)
+	case treasure.ContentTypeBoolean:
+		if lit.kind != literalBool {
+			return false
+		}
+		v, err := t.GetContentBool()
+		if err != nil {
+			return false
+		}
+		if c.operator == operatorEqual {
+			return v == lit.boolValue
+		}
+		return v != lit.boolValue
+	case treasure.ContentTypeInt8, treasure.ContentTypeInt16, treasure.ContentTypeInt32, treasure.ContentTypeInt64:
+		if lit.kind != literalNumber {
+			return false
+		}
+		v, ok := signedContent(t)
+		if !ok {
+			return false
+		}
+		if lit.isInt {
+			return compareOrdered(v, lit.intValue, c.operator)
+		}
+		return compareOrdered(float64(v), lit.floatValue, c.operator)
+	case treasure.ContentTypeUint8, treasure.ContentTypeUint16, treasure.ContentTypeUint32, treasure.ContentTypeUint64:
+		if lit.kind != literalNumber {
+			return false
+		}
+		v, ok := unsignedContent(t)
+		if !ok {
+			return false
+		}
+		if lit.isUint {
+			return compareOrdered(v, lit.uintValue, c.operator)
+		}
+		return compareOrdered(float64(v), lit.floatValue, c.operator)
+	case treasure.ContentTypeFloat32:
+		if lit.kind != literalNumber {
+			return false
+		}
+		v, err := t.GetContentFloat32()
+		return err == nil && compareOrdered(float64(v), lit.floatValue, c.operator)
+	case treasure.ContentTypeFloat64:
+		if lit.kind != literalNumber {
+			return false
+		}
+		v, err := t.GetContentFloat64()
+		return err == nil && compareOrdered(v, lit.floatValue, c.operator)
+	default:
+		// void, byte array and uint32 slice contents can not be filtered
+		return false
+	}
+
+}
+
+func signedContent(t treasure.Treasure) (int64, bool) {
+	switch t.GetContentType() {
+	case treasure.ContentTypeInt8:
+		v, err := t.GetContentInt8()
+		return int64(v), err == nil
+	case treasure.ContentTypeInt16:
+		v, err := t.GetContentInt16()
+		return int64(v), err == nil
+	case treasure.ContentTypeInt32:
+		v, err := t.GetContentInt32()
+		return int64(v), err == nil
+	default:
+		v, err := t.GetContentInt64()
+		return v, err == nil
+	}
+}
+
+func unsignedContent(t treasure.Treasure) (uint64, bool) {
+	switch t.GetContentType() {
+	case treasure.ContentTypeUint8:
+		v, err := t.GetContentUint8()
+		return uint64(v), err == nil
+	case treasure.ContentTypeUint16:
+		v, err := t.GetContentUint16()
+		return uint64(v), err == nil
+	case treasure.ContentTypeUint32:
+		v, err := t.GetContentUint32()
+		return uint64(v), err == nil
+	default:
+		v, err := t.GetContentUint64()
+		return v, err == nil
+	}
+}
+
+func compareTime(value int64, literal int64, op operator) bool {
+	// the treasure does not have the metadata
+	if value == 0 {
+		return false
+	}
+	return compareOrdered(value, literal, op)
+}
+
+func compareOrdered[T int64 | uint64 | float64 | string](value T, literal T, op operator) bool {
+	switch op {
+	case operatorEqual:
+		return value == literal
+	case operatorNotEqual:
+		return value != literal
+	case operatorGreaterThan:
+		return value > literal
+	case operatorGreaterThanOrEqual:
+		return value >= literal
+	case operatorLessThan:
+		return value < literal
+	default:
+		return value <= literal
+	}
+}
@@ -0,0 +1,125 @@
+package filter
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTreasure(key string, setter func(t treasure.Treasure, guardID guard.ID)) treasure.Treasure {
+	t := treasure.New(nil)
+	guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
+	t.BodySetKey(guardID, key)
+	setter(t, guardID)
+	t.ReleaseTreasureGuard(guardID)
+	return t
+}
+
+func TestParse(t *testing.T) {
+
+	t.Run("should parse valid expressions", func(t *testing.T) {
+
+		expressions := map[string]string{
+			`value >= 18 AND createdAt > 2024-01-01`:            `(value >= 18 AND createdAt > 2024-01-01)`,
+			`key = "a" OR key == 'b' AND NOT value != 1`:        `(key = "a" OR (key = "b" AND NOT value != 1))`,
+			`(key = "a" || key = "b") && updatedBy <> "system"`: `((key = "a" OR key = "b") AND updatedBy != "system")`,
+			`expiredAt <= "2024-01-01T10:00:00Z"`:               `expiredAt <= 2024-01-01T10:00:00Z`,
+			`!value = true`:                                     `NOT value = true`,
+		}
+
+		for input, expected := range expressions {
+			expr, err := Parse(input)
+			assert.NoError(t, err, input)
+			if err == nil {
+				assert.Equal(t, expected, expr.String())
+			}
+		}
+
+	})
+
+	t.Run("should reject invalid expressions", func(t *testing.T) {
+
+		invalid := []string{
+			``,
+			`value >=`,
+			`value 18`,
+			`unknown = 1`,
+			`value.age >= 18`,
+			`key = abc`,
+			`createdAt > yesterday`,
+			`(value = 1`,
+			`value = 1 value = 2`,
+			`value > true`,
+			`key = "unterminated`,
+			`value = 1 & value = 2`,
+		}
+
+		for _, input := range invalid {
+			_, err := Parse(input)
+			assert.Error(t, err, input)
+		}
+
+	})
+
+}
+
+func TestEvaluate(t *testing.T) {
+
+	createdAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	adult := newTreasure("user-1", func(t treasure.Treasure, guardID guard.ID) {
+		t.SetContentInt64(guardID, 42)
+		// SetCreatedBy overwrites the createdAt, so it must be set first
+		t.SetCreatedBy(guardID, "admin")
+		t.SetCreatedAt(guardID, createdAt)
+	})
+
+	child := newTreasure("user-2", func(t treasure.Treasure, guardID guard.ID) {
+		t.SetContentUint8(guardID, 12)
+	})
+
+	name := newTreasure("name", func(t treasure.Treasure, guardID guard.ID) {
+		t.SetContentString(guardID, "Peter")
+	})
+
+	flag := newTreasure("flag", func(t treasure.Treasure, guardID guard.ID) {
+		t.SetContentBool(guardID, true)
+	})
+
+	price := newTreasure("price", func(t treasure.Treasure, guardID guard.ID) {
+		t.SetContentFloat64(guardID, 9.99)
+	})
+
+	cases := []struct {
+		expression string
+		treasure   treasure.Treasure
+		expected   bool
+	}{
+		{`value >= 18 AND createdAt > 2024-01-01`, adult, true},
+		{`value >= 18 AND createdAt > 2024-07-01`, adult, false},
+		{`value >= 18`, child, false},
+		{`value < 18.5`, child, true},
+		{`value >= -1`, child, true},
+		{`createdAt > 2024-01-01`, child, false},
+		{`NOT createdAt > 2024-01-01`, child, true},
+		{`createdBy = "admin" AND key = "user-1"`, adult, true},
+		{`key > "user-1"`, child, true},
+		{`value = "Peter"`, name, true},
+		{`value = 1`, name, false},
+		{`value != false`, flag, true},
+		{`value > 9.5 AND value < 10`, price, true},
+		{`value = "9.99"`, price, false},
+		{`key = "flag" OR key = "price"`, price, true},
+	}
+
+	for _, c := range cases {
+		expr, err := Parse(c.expression)
+		assert.NoError(t, err, c.expression)
+		if err == nil {
+			assert.Equal(t, c.expected, expr.Evaluate(c.treasure), c.expression)
+		}
+	}
+
+}
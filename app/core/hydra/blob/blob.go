@@ -0,0 +1,361 @@
+// Package blob is the content-addressed blob store of the server.
+//
+// A blob is a large binary value stored once, under the SHA-256 hash of its content. The Treasures store only the
+// hash, so the same attachment referenced by many Treasures, even in many Swamps, takes the disk space only once.
+//
+// Every blob has a reference counter. Storing a blob adds a reference, and the clients add or remove references
+// when a Treasure starts or stops referencing it. The blobs without references are removed by the garbage
+// collection, after a grace period, so a blob whose references are being moved is not lost.
+//
+// The blobs of an Island are in their own folder under the root folder of the blobs, so they can be moved with the
+// Island. Every blob has two files: the content in <hash>, and the reference counter in <hash>.refs. Both are
+// written to a temporary file first and renamed, so a crash never leaves a partial file behind.
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// refsExtension is the extension of the reference counter files
+	refsExtension = ".refs"
+	// tmpExtension is the extension of the files being written
+	tmpExtension = ".tmp"
+	// lockStripes is the number of the locks of the blobs. The blobs are distributed by the first byte of the hash
+	lockStripes = 256
+)
+
+var (
+	// ErrBlobNotFound is returned if the blob does not exist
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrInvalidHash is returned if the hash is not a lowercase hex encoded SHA-256 hash
+	ErrInvalidHash = errors.New("invalid blob hash")
+	// ErrNegativeReferences is returned if the references of a blob would be negative
+	ErrNegativeReferences = errors.New("the references of the blob can not be negative")
+)
+
+// Store is the content-addressed blob store
+type Store interface {
+	// Put stores the content in the Island, and adds one reference to it. If the same content is already stored,
+	// only the reference is added. Returns the info of the blob, and true if the content was stored now.
+	Put(islandID uint64, content []byte) (*Info, bool, error)
+	// Get returns the content of the blob.
+	// Returns ErrBlobNotFound if the blob does not exist, or a wrapped filesystem.ErrCorruptedFile if the content
+	// does not match its hash anymore.
+	Get(islandID uint64, hash string) ([]byte, error)
+	// Ref adds the delta to the references of the blob, and returns the new number of the references. A negative
+	// delta removes references. The content is kept until the garbage collection, even without references.
+	// Returns ErrBlobNotFound if the blob does not exist, or ErrNegativeReferences if there are less references
+	// than the delta removes.
+	Ref(islandID uint64, hash string, delta int64) (int64, error)
+	// CollectGarbage removes the blobs of all Islands that have no references for at least the given time, and the
+	// temporary files of the interrupted writes.
+	CollectGarbage(minAge time.Duration) (*GarbageResult, error)
+}
+
+// Info is the information of a blob
+type Info struct {
+	Hash       string
+	Size       int64
+	References int64
+}
+
+// GarbageResult is the result of a garbage collection
+type GarbageResult struct {
+	// RemovedBlobs is the number of the removed blobs
+	RemovedBlobs int64
+	// FreedBytes is the size of the removed content
+	FreedBytes int64
+}
+
+type store struct {
+	rootPath string
+	locks    [lockStripes]sync.Mutex
+}
+
+// New creates the blob store in the given root folder. The folder is created at the first Put.
+func New(rootPath string) Store {
+	return &store{
+		rootPath: rootPath,
+	}
+}
+
+// Hash returns the hash of the content, the same as the store uses
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *store) Put(islandID uint64, content []byte) (*Info, bool, error) {
+
+	hash := Hash(content)
+	mu := s.lock(hash)
+	mu.Lock()
+	defer mu.Unlock()
+
+	contentPath := s.contentPath(islandID, hash)
+
+	isNew := false
+	if _, err := os.Stat(contentPath); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(contentPath), 0755); err != nil {
+			return nil, false, fmt.Errorf("can not create the folder of the blob: %w", err)
+		}
+		if err := writeFile(contentPath, content); err != nil {
+			return nil, false, err
+		}
+		isNew = true
+	} else if err != nil {
+		return nil, false, fmt.Errorf("can not check the blob: %w", err)
+	}
+
+	references, _, err := s.readReferences(islandID, hash)
+	if err != nil {
+		return nil, false, err
+	}
+	references++
+	if err := s.writeReferences(islandID, hash, references); err != nil {
+		return nil, false, err
+	}
+
+	return &Info{
+		Hash:       hash,
+		Size:       int64(len(content)),
+		References: references,
+	}, isNew, nil
+
+}
+
+func (s *store) Get(islandID uint64, hash string) ([]byte, error) {
+
+	if !isValidHash(hash) {
+		return nil, ErrInvalidHash
+	}
+
+	content, err := os.ReadFile(s.contentPath(islandID, hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not read the blob: %w", err)
+	}
+
+	if Hash(content) != hash {
+		return nil, fmt.Errorf("%w: the content of the blob %s does not match its hash", filesystem.ErrCorruptedFile, hash)
+	}
+
+	return content, nil
+
+}
+
+func (s *store) Ref(islandID uint64, hash string, delta int64) (int64, error) {
+
+	if !isValidHash(hash) {
+		return 0, ErrInvalidHash
+	}
+
+	mu := s.lock(hash)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := os.Stat(s.contentPath(islandID, hash)); errors.Is(err, os.ErrNotExist) {
+		return 0, ErrBlobNotFound
+	} else if err != nil {
+		return 0, fmt.Errorf("can not check the blob: %w", err)
+	}
+
+	references, _, err := s.readReferences(islandID, hash)
+	if err != nil {
+		return 0, err
+	}
+	if references+delta < 0 {
+		return references, ErrNegativeReferences
+	}
+	if delta == 0 {
+		return references, nil
+	}
+
+	references += delta
+	if err := s.writeReferences(islandID, hash, references); err != nil {
+		return 0, err
+	}
+
+	return references, nil
+
+}
+
+func (s *store) CollectGarbage(minAge time.Duration) (*GarbageResult, error) {
+
+	result := &GarbageResult{}
+
+	islands, err := os.ReadDir(s.rootPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not list the blob folders: %w", err)
+	}
+
+	for _, island := range islands {
+
+		islandID, err := strconv.ParseUint(island.Name(), 10, 64)
+		if !island.IsDir() || err != nil {
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join(s.rootPath, island.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("can not list the blobs of the island %d: %w", islandID, err)
+		}
+
+		for _, file := range files {
+
+			fileName := file.Name()
+
+			// the temporary files of the interrupted writes
+			if strings.HasSuffix(fileName, tmpExtension) {
+				if fileInfo, err := file.Info(); err == nil && time.Since(fileInfo.ModTime()) >= minAge {
+					_ = os.Remove(filepath.Join(s.rootPath, island.Name(), fileName))
+				}
+				continue
+			}
+
+			if !isValidHash(fileName) {
+				continue
+			}
+
+			size, removed, err := s.removeUnreferenced(islandID, fileName, minAge)
+			if err != nil {
+				return nil, err
+			}
+			if removed {
+				result.RemovedBlobs++
+				result.FreedBytes += size
+			}
+
+		}
+
+	}
+
+	return result, nil
+
+}
+
+// removeUnreferenced removes the blob if it has no references for at least the given time. Returns the size of the
+// removed content and true if the blob was removed.
+func (s *store) removeUnreferenced(islandID uint64, hash string, minAge time.Duration) (int64, bool, error) {
+
+	mu := s.lock(hash)
+	mu.Lock()
+	defer mu.Unlock()
+
+	references, modTime, err := s.readReferences(islandID, hash)
+	if err != nil {
+		return 0, false, err
+	}
+	if references > 0 || time.Since(modTime) < minAge {
+		return 0, false, nil
+	}
+
+	contentPath := s.contentPath(islandID, hash)
+	fileInfo, err := os.Stat(contentPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("can not check the blob: %w", err)
+	}
+
+	if err := os.Remove(contentPath); err != nil {
+		return 0, false, fmt.Errorf("can not remove the blob: %w", err)
+	}
+	if err := os.Remove(contentPath + refsExtension); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, false, fmt.Errorf("can not remove the references of the blob: %w", err)
+	}
+
+	return fileInfo.Size(), true, nil
+
+}
+
+// readReferences returns the references of the blob, and the time of their last change. A blob without references
+// file has no references since the content was written, e.g. if the server stopped between the two writes.
+func (s *store) readReferences(islandID uint64, hash string) (int64, time.Time, error) {
+
+	refsPath := s.contentPath(islandID, hash) + refsExtension
+
+	data, err := os.ReadFile(refsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		fileInfo, statErr := os.Stat(s.contentPath(islandID, hash))
+		if statErr != nil {
+			return 0, time.Time{}, nil
+		}
+		return 0, fileInfo.ModTime(), nil
+	}
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("can not read the references of the blob: %w", err)
+	}
+
+	references, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("%w: the references of the blob %s are invalid", filesystem.ErrCorruptedFile, hash)
+	}
+
+	fileInfo, err := os.Stat(refsPath)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("can not check the references of the blob: %w", err)
+	}
+
+	return references, fileInfo.ModTime(), nil
+
+}
+
+func (s *store) writeReferences(islandID uint64, hash string, references int64) error {
+	return writeFile(s.contentPath(islandID, hash)+refsExtension, []byte(strconv.FormatInt(references, 10)))
+}
+
+func (s *store) contentPath(islandID uint64, hash string) string {
+	return filepath.Join(s.rootPath, strconv.FormatUint(islandID, 10), hash)
+}
+
+// lock returns the lock of the blob
+func (s *store) lock(hash string) *sync.Mutex {
+	stripe, _ := strconv.ParseUint(hash[:2], 16, 8)
+	return &s.locks[stripe]
+}
+
+// writeFile writes the file to a temporary file first, then renames it, so the file is never partially written
+func writeFile(path string, data []byte) error {
+
+	tmpPath := path + tmpExtension
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("can not write the file %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("can not rename the file %s: %w", path, err)
+	}
+	return nil
+
+}
+
+// isValidHash returns true if the hash is a lowercase hex encoded SHA-256 hash, so it can be used as a file name
+func isValidHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package blob
+
+import (
+	"errors"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+
+	t.Run("should store the same content only once", func(t *testing.T) {
+
+		s := New(t.TempDir())
+		content := []byte("attachment")
+
+		info, isNew, err := s.Put(1, content)
+		require.NoError(t, err)
+		assert.True(t, isNew)
+		assert.Equal(t, Hash(content), info.Hash)
+		assert.Equal(t, int64(len(content)), info.Size)
+		assert.Equal(t, int64(1), info.References)
+
+		info, isNew, err = s.Put(1, content)
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.Equal(t, int64(2), info.References)
+
+		read, err := s.Get(1, info.Hash)
+		require.NoError(t, err)
+		assert.Equal(t, content, read)
+
+		// the islands are separated
+		_, err = s.Get(2, info.Hash)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	})
+
+	t.Run("should count the references", func(t *testing.T) {
+
+		s := New(t.TempDir())
+		info, _, err := s.Put(1, []byte("attachment"))
+		require.NoError(t, err)
+
+		references, err := s.Ref(1, info.Hash, 3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), references)
+
+		references, err = s.Ref(1, info.Hash, -4)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), references)
+
+		_, err = s.Ref(1, info.Hash, -1)
+		assert.ErrorIs(t, err, ErrNegativeReferences)
+
+		_, err = s.Ref(1, Hash([]byte("missing")), 1)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	})
+
+	t.Run("should reject the invalid hashes", func(t *testing.T) {
+
+		s := New(t.TempDir())
+
+		_, err := s.Get(1, "../../settings")
+		assert.ErrorIs(t, err, ErrInvalidHash)
+		_, err = s.Ref(1, "ABC", 1)
+		assert.ErrorIs(t, err, ErrInvalidHash)
+
+	})
+
+	t.Run("should detect the corrupted content", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		s := New(rootPath)
+		info, _, err := s.Put(1, []byte("attachment"))
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(rootPath, "1", info.Hash), []byte("changed"), 0644))
+
+		_, err = s.Get(1, info.Hash)
+		assert.True(t, errors.Is(err, filesystem.ErrCorruptedFile))
+
+	})
+
+	t.Run("should collect only the unreferenced blobs after the grace period", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		s := New(rootPath)
+
+		kept, _, err := s.Put(1, []byte("kept"))
+		require.NoError(t, err)
+		removed, _, err := s.Put(2, []byte("removed"))
+		require.NoError(t, err)
+		_, err = s.Ref(2, removed.Hash, -1)
+		require.NoError(t, err)
+
+		// the grace period is not over yet
+		result, err := s.CollectGarbage(time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), result.RemovedBlobs)
+
+		result, err = s.CollectGarbage(0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.RemovedBlobs)
+		assert.Equal(t, int64(len("removed")), result.FreedBytes)
+
+		_, err = s.Get(2, removed.Hash)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+		_, err = s.Get(1, kept.Hash)
+		assert.NoError(t, err)
+
+	})
+
+	t.Run("should collect nothing in an empty store", func(t *testing.T) {
+		result, err := New(filepath.Join(t.TempDir(), "missing")).CollectGarbage(0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), result.RemovedBlobs)
+	})
+
+}
@@ -3,17 +3,33 @@ package hydra
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra/blob"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
+	"github.com/hydraide/hydraide/app/core/hydra/journal"
+	"github.com/hydraide/hydraide/app/core/hydra/lease"
 	"github.com/hydraide/hydraide/app/core/hydra/lock"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/chronicler"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keydirectory"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keyfilter"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
 	"github.com/hydraide/hydraide/app/core/safeops"
 	"github.com/hydraide/hydraide/app/core/settings"
 	"github.com/hydraide/hydraide/app/core/settings/setting"
 	"github.com/hydraide/hydraide/app/name"
+	"io/fs"
 	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -54,6 +70,7 @@ type Hydra interface {
 	// Returns:
 	// - A `swamp.Swamp` interface to interact with the loaded Swamp
 	// - An error if the Swamp is missing or invalid
+	// - A wrapped filesystem.ErrCorruptedFile if a file of the Swamp is corrupted and the corrupted files are not skipped
 	//
 	// Example:
 	//
@@ -95,6 +112,123 @@ type Hydra interface {
 	//     }
 	IsExistSwamp(islandID uint64, swampName name.Name) (bool, error)
 
+	// MayContainKeys checks the keys against the key filter of a Swamp without loading the Swamp into memory.
+	//
+	// The key filter is a bloom filter of the keys, written by the Swamps of the patterns registered with KeyFilter
+	// when they close. A false is definite: the key is not in the Swamp. A true means the key may be in the Swamp,
+	// so the Swamp must be summoned to decide.
+	//
+	// Returns:
+	// - One flag for every key, in the order of the keys, and true if the filter could answer
+	// - false as the second value if the filter can not answer: the Swamp is in memory, its pattern has no key
+	//   filter, or it has no valid filter file, e.g. it was not closed since the server stopped without closing it
+	//
+	// Example:
+	//
+	//     mayContain, ok := myHydra.MayContainKeys(island, swampName, []string{"domain.com"})
+	//     if ok && !mayContain[0] {
+	//         // the key is definitely not in the Swamp, and the Swamp was not loaded
+	//     }
+	MayContainKeys(islandID uint64, swampName name.Name, keys []string) (mayContain []bool, ok bool)
+
+	// GetTreasuresFromChunks reads the Treasures of the keys from the chunk files of a Swamp without loading the Swamp
+	// into memory.
+	//
+	// The key directory maps the keys to their chunk files, written by the Swamps of the patterns registered with
+	// LazyHydration when they close. Only the chunks of the requested keys are read, so a read of a few keys of a cold
+	// Swamp with millions of Treasures does not hydrate the whole Swamp. The full hydration is deferred until the
+	// Swamp is summoned, e.g. for a write, an index scan or a subscription.
+	//
+	// Returns:
+	// - The Treasures of the existing keys by their keys, and true if the chunks could answer. The keys missing from
+	//   the map do not exist. The Treasures are read-only copies, they are not part of a Swamp.
+	// - false as the second value if the chunks can not answer: the Swamp is in memory, its pattern has no key
+	//   directory, it has no valid directory file, or a chunk changed since the directory was written
+	//
+	// Example:
+	//
+	//     treasures, ok := myHydra.GetTreasuresFromChunks(island, swampName, []string{"user-1"})
+	//     if ok {
+	//         // treasures["user-1"] is the Treasure, or nil if it does not exist, and the Swamp was not loaded
+	//     }
+	GetTreasuresFromChunks(islandID uint64, swampName name.Name, keys []string) (treasures map[string]treasure.Treasure, ok bool)
+
+	// FindSwamps returns the names of all existing Swamps that match the wildcard pattern.
+	// The Sanctuary part of the pattern must be exact, the Realm and the Swamp parts can be "*".
+	//
+	// The function checks both the Swamps in the memory and the Swamps on the disk of this server.
+	// Because the folder names on the disk are hashed, the names of the stored Swamps are read from their
+	// metadata files, so the function walks through all island folders of the server.
+	//
+	// ⚠️ This is an expensive operation on servers with many Swamps, use it for admin and UI purposes only,
+	// and prefer exact names in hot paths.
+	//
+	// Returns:
+	// - The names of the matching Swamps, in no particular order
+	// - An error if the data folder can not be read
+	FindSwamps(pattern name.Name) ([]name.Name, error)
+
+	// BackupSwamps calls fn with the folder of every Swamp on the disk of this server that matches one of the
+	// patterns. Unlike FindSwamps, the Sanctuary of the patterns can be "*" too.
+	//
+	// Every matching Swamp is summoned and frozen while fn runs, so the files of the folder do not change until fn
+	// returns, and they contain all changes of the Swamp made before the call of fn. See Swamp.Freeze.
+	//
+	// The Swamps are processed one by one, so a backup does not keep the whole server frozen. The in-memory Swamps
+	// have no folder, so they are not backed up.
+	//
+	// Returns:
+	// - The first error of fn, or an error if the data folder can not be read, a Swamp can not be summoned or
+	//   the context is done. The remaining Swamps are skipped after the error.
+	BackupSwamps(ctx context.Context, patterns []name.Name, fn func(islandID uint64, swampName name.Name, folderPath string) error) error
+
+	// ExportIsland calls fn with the folder of every Swamp of the Island on the disk of this server, the same way as
+	// BackupSwamps: every Swamp is frozen while fn runs. It is used by the migration of the Island to an other
+	// server, where the files of the Island are streamed to the new server.
+	//
+	// The in-memory Swamps have no folder, so they are not exported.
+	//
+	// Returns:
+	// - The first error of fn, or an error if the data folder can not be read, a Swamp can not be summoned or
+	//   the context is done. The remaining Swamps are skipped after the error.
+	ExportIsland(ctx context.Context, islandID uint64, fn func(swampName name.Name, folderPath string) error) error
+
+	// DestroySwamps destroys every Swamp of this server that matches the pattern, the Swamps in the memory, including
+	// the in-memory Swamps, and the Swamps on the disk, with all of their files. Unlike FindSwamps, the Sanctuary of
+	// the pattern can be "*" too, and an exact name matches only the Swamp itself.
+	//
+	// If dryRun is true, the matching Swamps are only listed, and nothing is destroyed. The Swamps are destroyed in
+	// alphabetical order, and fn is called after each of them, if it is not nil, e.g. to report the progress.
+	//
+	// Real-world scenario: A migration retires the pattern of the old Swamps with their data, and lists them first
+	// with a dry run to check what is going to be deleted.
+	//
+	// Returns:
+	// - The names of the destroyed Swamps, or the Swamps that would be destroyed, in alphabetical order. On error,
+	//   the Swamps destroyed before the error.
+	// - The first error of fn, or an error if the data folder can not be read, a Swamp can not be summoned or the
+	//   context is done. The remaining Swamps are not destroyed after the error.
+	DestroySwamps(ctx context.Context, pattern name.Name, dryRun bool, fn func(swampName name.Name) error) ([]name.Name, error)
+
+	// GetNamespaceUsage returns the disk usage, the number of the Swamps and the number of the Treasures grouped by
+	// the Sanctuaries and the Realms of the Swamps, ordered by the Sanctuary and the Realm.
+	//
+	// The usage is collected by walking the Swamp folders of the Islands, without loading the Swamps into the memory.
+	// The Treasure count of a closed Swamp is the count recorded at its last close, the open Swamps are counted in the
+	// memory. The Swamps closed before the count was recorded are listed as uncounted. The in-memory Swamps take no
+	// disk space.
+	//
+	// The walk of a big server takes a while, so the usage is cached, and a usage younger than maxAge is returned
+	// without walking the folders again. Zero maxAge always walks the folders.
+	//
+	// Real-world scenario: The operators charge the disk usage back to the teams owning the Sanctuaries, and find the
+	// Realms growing out of control, without scripting against the filesystem.
+	//
+	// Returns:
+	// - The usage of every Realm with at least one Swamp, and the time it was collected.
+	// - An error if the data folder can not be read or the context is done.
+	GetNamespaceUsage(ctx context.Context, maxAge time.Duration) ([]NamespaceUsage, time.Time, error)
+
 	// SubscribeToSwampEvents enables a Head to subscribe to events from a specific Swamp using a callback function,
 	// allowing real-time monitoring or triggering business logic. This is a NON blocking function.
 	//
@@ -128,6 +262,57 @@ type Hydra interface {
 	// 2. Triggering other business logic or actions based on events occurring within a Swamp.
 	SubscribeToSwampEvents(clientID uuid.UUID, swampName name.Name, subscriberEventCallbackFunction func(event *swamp.Event)) (err error)
 
+	// SubscribeToSwampEventsWithSnapshot subscribes to the events of the Swamp like SubscribeToSwampEvents, and
+	// passes all current Treasures of the Swamp to the snapshotFunction at the same point in time.
+	//
+	// The Swamp is summoned, and while the snapshot is taken and the subscriber is registered, no Treasure can be
+	// saved or deleted in it. So every change is delivered exactly once: it is either in the snapshot, or it arrives
+	// as an event after the snapshotFunction is called.
+	//
+	// The Treasures of the snapshot are in ascending creation time order. The resumeToken is the sequence number of
+	// the last event of the Swamp before the snapshot; the events of the subscription have a higher Sequence. The
+	// sequence numbers are kept while the server runs, even if the Swamp is closed meanwhile.
+	//
+	// The snapshotFunction is called before this function returns, and must not save or delete Treasures of the
+	// same Swamp. Events can arrive to the subscriberEventCallbackFunction as soon as the snapshotFunction returned.
+	//
+	// Returns:
+	// - An error if the Swamp can not be summoned or the Hydra is shutting down
+	SubscribeToSwampEventsWithSnapshot(ctx context.Context, islandID uint64, clientID uuid.UUID, swampName name.Name,
+		snapshotFunction func(treasures []treasure.Treasure, resumeToken uint64),
+		subscriberEventCallbackFunction func(event *swamp.Event)) (err error)
+
+	// SubscribeToSwampEventsSince subscribes to the events of the Swamp like SubscribeToSwampEvents, and replays the
+	// events of the Swamp since the given time (unix nano) from the event journal of the Swamp.
+	//
+	// The event journal must be enabled for the pattern of the Swamp. The journal is kept in the memory of the
+	// server, so it covers the events since the first summon of the Swamp after the start of the server, limited by
+	// the size and the retention of the journal.
+	//
+	// The Swamp is summoned, and while the events are read from the journal and the subscriber is registered, no
+	// Treasure can be saved or deleted in it. So every event after the given time is delivered exactly once: it is
+	// either replayed, or it arrives to the subscriberEventCallbackFunction after the replayFunction is called.
+	//
+	// The replayFunction is called with the events in the order of their sequence before this function returns,
+	// and must not save or delete Treasures of the same Swamp. The resumeToken is the sequence number of the last
+	// event of the Swamp before the subscription; the events of the subscription have a higher Sequence.
+	//
+	// Returns:
+	// - ErrEventReplayNotAvailable if the journal is disabled or it does not have all events since the given time.
+	//   The subscriber must read the whole Swamp in this case.
+	// - An error if the Swamp can not be summoned or the Hydra is shutting down
+	SubscribeToSwampEventsSince(ctx context.Context, islandID uint64, clientID uuid.UUID, swampName name.Name, since int64,
+		replayFunction func(events []*swamp.Event, resumeToken uint64),
+		subscriberEventCallbackFunction func(event *swamp.Event)) (err error)
+
+	// GetLeaseTable returns the delivery counter table of the leased Treasures of the Swamp, see the
+	// LeaseExpiredTreasures function of the Swamp. The table is created at the first call, and like the event
+	// sequences, it is kept while the server runs, even if the Swamp is closed meanwhile.
+	GetLeaseTable(swampName name.Name) lease.Table
+
+	// GetBlobStore returns the content-addressed blob store of the Hydra, in the blob folder of the settings
+	GetBlobStore() blob.Store
+
 	// UnsubscribeFromSwampEvents allows a Head to unsubscribe from events of a specific Swamp, effectively stopping
 	// real-time monitoring or triggering of business logic based on those events.
 	//
@@ -155,6 +340,31 @@ type Hydra interface {
 	// It's in our interest to manage resources efficiently; unsubscribing when monitoring is no longer necessary helps in resource optimization.
 	UnsubscribeFromSwampEvents(clientID uuid.UUID, swampName name.Name) (err error)
 
+	// SubscribeToPatternEvents subscribes to the events of every Swamp matching any of the patterns, like
+	// SubscribeToSwampEvents does for one Swamp. A "*" part of a pattern matches any part of the names.
+	//
+	// The subscription does not hydrate any Swamp. The matching Swamps in the memory start sending their events
+	// immediately, and the matching Swamps hydrated later, e.g. by a write, start sending them at their hydration.
+	// A Swamp can not change without being hydrated, so no change of the matching Swamps is missed after the function
+	// returned.
+	//
+	// ⚠️ The callback function is called by the writers of the Swamps, so it must return fast.
+	SubscribeToPatternEvents(clientID uuid.UUID, patterns []name.Name, subscriberEventCallbackFunction func(event *swamp.Event)) (err error)
+
+	// UnsubscribeFromPatternEvents removes the subscription of SubscribeToPatternEvents.
+	UnsubscribeFromPatternEvents(clientID uuid.UUID) (err error)
+
+	// GetJournalEventsSince returns the events of the Swamp since the given time (unix nano) from its event journal,
+	// in the order of their sequence, without summoning the Swamp.
+	//
+	// It is used to resume a pattern subscription: subscribe with SubscribeToPatternEvents first, then read the
+	// missed events from the journal. The events appended to the journal after the subscription arrive to the
+	// subscriber too, so the caller must skip the events it already got by their Sequence.
+	//
+	// The ok is false if the Swamp has no journal, e.g. it was not summoned since the start of the server, or the
+	// journal does not have all events since the given time.
+	GetJournalEventsSince(swampName name.Name, since int64) (events []*swamp.Event, ok bool)
+
 	// SubscribeToSwampInfo allows a Head to subscribe to specific updates about the state of a given Swamp, particularly
 	// changes in the number of Treasures stored within.
 	//
@@ -287,6 +497,9 @@ const (
 	ErrorHydraIsShuttingDown = "hydra is shutting down"
 )
 
+// ErrEventReplayNotAvailable is returned if the event journal of the swamp can not replay the requested events
+var ErrEventReplayNotAvailable = errors.New("the event journal does not cover the requested time")
+
 type hydra struct {
 	mu           sync.RWMutex
 	shuttingDown int32 // Hydra shutting down flag
@@ -298,6 +511,16 @@ type hydra struct {
 	eventSubscribers sync.Map
 	// infoSubscribers  map[string]map[uuid.UUID]chan *swamp.Info
 	infoSubscribers sync.Map
+	// patternSubscribers map[string]*patternSubscriber, the subscribers of the events of many swamps by client ID
+	patternSubscribers sync.Map
+	// eventSequences is the last event sequence number per swamp name. It survives the closing of the swamp, so
+	// the sequence numbers of a swamp never go back while the server runs
+	eventSequences sync.Map
+	// journals is the event journal per swamp name, for the swamps whose pattern enables the journal. Like the
+	// sequences, the journals survive the closing of the swamp
+	journals sync.Map
+	// leaseTables is the delivery counter table per swamp name, for the swamps with leased treasures
+	leaseTables sync.Map
 
 	// summoningSwamps csak olyan swampokat tárol, amiket éppen summonolunk, hogy két rutin ne summonolhassa ugyanazt
 	// a swampot, különben képesek lennének egyszerre létrehozni, ugyanazt a swampot. Így ha az egyik summonolja a swampot,
@@ -315,6 +538,27 @@ type hydra struct {
 	// egyedi locker interface
 	lockerInterface     lock.Lock
 	filesystemInterface filesystem.Filesystem
+	blobStore           blob.Store
+
+	// the cached result of the GetNamespaceUsage, the mutex makes the concurrent callers wait for one walk
+	usageMu          sync.Mutex
+	usage            []NamespaceUsage
+	usageCollectedAt time.Time
+}
+
+// NamespaceUsage is the usage of the Swamps of a Realm of a Sanctuary
+type NamespaceUsage struct {
+	Sanctuary string
+	Realm     string
+	// SwampCount is the number of the Swamps of the Realm, including the open in-memory Swamps
+	SwampCount int
+	// TreasureCount is the number of the Treasures of the counted Swamps
+	TreasureCount int64
+	// UncountedSwamps is the number of the Swamps without a recorded Treasure count, because they were not closed
+	// since the count is recorded
+	UncountedSwamps int
+	// DiskBytes is the size of the files of the Swamps in bytes
+	DiskBytes int64
 }
 
 // New creates a new hydra database
@@ -333,6 +577,7 @@ func New(settingsInterface settings.Settings, elysiumInterface safeops.Safeops,
 		// set locker interface
 		lockerInterface:     lockerInterface,
 		filesystemInterface: filesystemInterface,
+		blobStore:           blob.New(settingsInterface.GetHydraAbsBlobFolderPath()),
 	}
 
 	return h
@@ -477,13 +722,32 @@ func (h *hydra) SummonSwamp(ctx context.Context, islandID uint64, swampName name
 
 			// The swamp does not exist in memory, so we need to create it.
 			// During creation, other processes trying to access this swamp will still have to wait.
+			// The loading from the disk waits for a free slot of the hydration scheduler, so a burst of requests
+			// to cold swamps does not saturate the disk.
+			release, acquireErr := h.acquireHydration(ctx, swampName)
+			if acquireErr != nil {
+				slog.Warn("the hydration of the swamp is cancelled while waiting for a free slot", "swampName", swampName, "error", acquireErr)
+				return nil, acquireErr
+			}
 			swampObject = h.createNewSwamp(islandID, swampName)
+			release()
+			markHydration(ctx)
+
+			// the swamp could not be loaded completely, e.g. it has a corrupted file and the filesystem does not
+			// skip the corrupted files. The swamp is closed without storing it, so it is not served with missing data
+			if chroniclerInterface := swampObject.GetChronicler(); chroniclerInterface != nil {
+				if loadErr := chroniclerInterface.GetLoadError(); loadErr != nil {
+					swampObject.Close()
+					return nil, loadErr
+				}
+			}
 
 			// Store the swamp in the hydra map, which is a sync.Map.
 			h.swamps.Store(swampName.Get(), swampObject)
 
-			// start sending events to the subscribers if there are any clients subscribed to the events
-			if h.hasEventSubscriber(swampName) {
+			// start sending events to the subscribers if there are any clients subscribed to the events, or to the
+			// journal if the swamp has one
+			if h.openJournal(swampName) || h.hasEventSubscriber(swampName) || h.hasPatternSubscriber(swampName) {
 				swampObject.StartSendingEvents()
 			}
 
@@ -519,6 +783,482 @@ func (h *hydra) IsExistSwamp(islandID uint64, swampName name.Name) (bool, error)
 
 }
 
+// MayContainKeys checks the keys against the key filter of a swamp that is not in the memory
+func (h *hydra) MayContainKeys(islandID uint64, swampName name.Name, keys []string) ([]bool, bool) {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return nil, false
+	}
+
+	// the loaded swamps answer from the memory, and their filter file is removed anyway
+	if h.getSwamp(swampName) != nil {
+		return nil, false
+	}
+
+	swampSettings := h.settingsInterface.GetBySwampName(swampName)
+	if swampSettings.GetSwampType() != setting.PermanentSwamp || !swampSettings.IsKeyFiltered() {
+		return nil, false
+	}
+
+	swampDataFolderPath := swampName.GetFullHashPath(h.settingsInterface.GetHydraAbsDataFolderPath(), islandID, h.settingsInterface.GetHashFolderDepth(), h.settingsInterface.GetMaxFoldersPerLevel())
+
+	filter, err := keyfilter.Load(swampDataFolderPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("the key filter of the swamp can not be loaded", "swampName", swampName, "error", err)
+		}
+		return nil, false
+	}
+
+	mayContain := make([]bool, len(keys))
+	for i, key := range keys {
+		mayContain[i] = filter.MayContain(key)
+	}
+
+	return mayContain, true
+
+}
+
+// GetTreasuresFromChunks reads the treasures of the keys from the chunks of a swamp that is not in the memory
+func (h *hydra) GetTreasuresFromChunks(islandID uint64, swampName name.Name, keys []string) (map[string]treasure.Treasure, bool) {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return nil, false
+	}
+
+	// the loaded swamps answer from the memory, and their directory file is removed anyway
+	if h.getSwamp(swampName) != nil {
+		return nil, false
+	}
+
+	swampSettings := h.settingsInterface.GetBySwampName(swampName)
+	if swampSettings.GetSwampType() != setting.PermanentSwamp || !swampSettings.IsLazyHydrated() {
+		return nil, false
+	}
+
+	swampDataFolderPath := swampName.GetFullHashPath(h.settingsInterface.GetHydraAbsDataFolderPath(), islandID, h.settingsInterface.GetHashFolderDepth(), h.settingsInterface.GetMaxFoldersPerLevel())
+
+	fileNames, err := keydirectory.Lookup(swampDataFolderPath, keys)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("the key directory of the swamp can not be loaded", "swampName", swampName, "error", err)
+		}
+		return nil, false
+	}
+
+	// the keys of the same chunk are read by one load of the chunk
+	keysOfFiles := make(map[string][]string)
+	for key, fileName := range fileNames {
+		keysOfFiles[fileName] = append(keysOfFiles[fileName], key)
+	}
+
+	treasures := make(map[string]treasure.Treasure, len(fileNames))
+	for fileName, fileKeys := range keysOfFiles {
+
+		// the chunk can be deleted by a compaction if the swamp was loaded since the lookup
+		byteTreasures, err := h.filesystemInterface.GetFile(filepath.Join(swampDataFolderPath, fileName))
+		if err != nil {
+			return nil, false
+		}
+
+		for _, byteTreasure := range byteTreasures {
+			treasureInterface := treasure.New(nil)
+			guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+			loadErr := treasureInterface.LoadFromByte(guardID, byteTreasure, fileName)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+			// the corrupted chunks are reported by the hydration of the swamp
+			if loadErr != nil {
+				return nil, false
+			}
+			// the deleted treasures are not loaded by the hydration either
+			if treasureInterface.GetDeletedAt() != 0 || !slices.Contains(fileKeys, treasureInterface.GetKey()) {
+				continue
+			}
+			treasures[treasureInterface.GetKey()] = treasureInterface
+		}
+
+	}
+
+	// a key of the directory is not in its chunk anymore, so the directory and the chunks disagree
+	if len(treasures) != len(fileNames) {
+		return nil, false
+	}
+
+	return treasures, true
+
+}
+
+// FindSwamps returns the names of the existing swamps matching the wildcard pattern
+func (h *hydra) FindSwamps(pattern name.Name) ([]name.Name, error) {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return nil, errors.New(ErrorHydraIsShuttingDown)
+	}
+
+	found := make(map[string]name.Name)
+
+	// swamps in the memory, including in-memory swamps without any folder
+	h.swamps.Range(func(key, value interface{}) bool {
+		swampName := value.(swamp.Swamp).GetName()
+		if swampName.ComparePattern(pattern) {
+			found[swampName.Get()] = swampName
+		}
+		return true
+	})
+
+	// swamps on the disk, identified by their metadata files
+	dataFolder := h.settingsInterface.GetHydraAbsDataFolderPath()
+	err := filepath.WalkDir(dataFolder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dataFolder {
+				// there is no swamp on the disk yet
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || d.Name() != metadata.MetaFile {
+			return nil
+		}
+		metadataInterface := metadata.New(filepath.Dir(path))
+		metadataInterface.LoadFromFile()
+		swampName := metadataInterface.GetSwampName()
+		if swampName != nil && swampName.ComparePattern(pattern) {
+			found[swampName.Get()] = swampName
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	swampNames := make([]name.Name, 0, len(found))
+	for _, swampName := range found {
+		swampNames = append(swampNames, swampName)
+	}
+
+	return swampNames, nil
+
+}
+
+// BackupSwamps freezes the matching swamps on the disk one by one, and calls fn with their folders
+// mutexes: clean
+func (h *hydra) BackupSwamps(ctx context.Context, patterns []name.Name, fn func(islandID uint64, swampName name.Name, folderPath string) error) error {
+
+	dataFolder := h.settingsInterface.GetHydraAbsDataFolderPath()
+	return h.freezeSwamps(ctx, dataFolder, func(_ uint64, swampName name.Name) bool {
+		return slices.ContainsFunc(patterns, func(pattern name.Name) bool {
+			return MatchPattern(swampName, pattern)
+		})
+	}, fn)
+
+}
+
+// ExportIsland freezes the swamps of the island on the disk one by one, and calls fn with their folders
+// mutexes: clean
+func (h *hydra) ExportIsland(ctx context.Context, islandID uint64, fn func(swampName name.Name, folderPath string) error) error {
+
+	// only the folder of the island is walked, the other islands are not read
+	islandFolder := filepath.Join(h.settingsInterface.GetHydraAbsDataFolderPath(), strconv.FormatUint(islandID, 10))
+	return h.freezeSwamps(ctx, islandFolder, func(swampIslandID uint64, _ name.Name) bool {
+		return swampIslandID == islandID
+	}, func(_ uint64, swampName name.Name, folderPath string) error {
+		return fn(swampName, folderPath)
+	})
+
+}
+
+// freezeSwamps collects the swamps of the memory and of the walked folder accepted by the matches function, then
+// freezes them one by one in the order of their names, and calls fn with their folders
+func (h *hydra) freezeSwamps(ctx context.Context, walkedFolder string, matches func(islandID uint64, swampName name.Name) bool, fn func(islandID uint64, swampName name.Name, folderPath string) error) error {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return errors.New(ErrorHydraIsShuttingDown)
+	}
+
+	// the swamps are collected first, so the walk does not see the files written during the freeze
+	targets, err := h.collectSwamps(walkedFolder, false, matches)
+	if err != nil {
+		return err
+	}
+
+	dataFolder := h.settingsInterface.GetHydraAbsDataFolderPath()
+	for _, key := range slices.Sorted(maps.Keys(targets)) {
+
+		target := targets[key]
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		swampInterface, err := h.SummonSwamp(ctx, target.islandID, target.swampName)
+		if err != nil {
+			return fmt.Errorf("can not summon the swamp %s: %w", target.swampName.Get(), err)
+		}
+
+		swampInterface.BeginVigil()
+		err = swampInterface.Freeze(func() error {
+			folderPath := target.swampName.GetFullHashPath(dataFolder, target.islandID, h.settingsInterface.GetHashFolderDepth(), h.settingsInterface.GetMaxFoldersPerLevel())
+			return fn(target.islandID, target.swampName, folderPath)
+		})
+		swampInterface.CeaseVigil()
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
+
+// DestroySwamps destroys the swamps in the memory and on the disk matching the pattern
+// mutexes: clean
+func (h *hydra) DestroySwamps(ctx context.Context, pattern name.Name, dryRun bool, fn func(swampName name.Name) error) ([]name.Name, error) {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return nil, errors.New(ErrorHydraIsShuttingDown)
+	}
+
+	targets, err := h.collectSwamps(h.settingsInterface.GetHydraAbsDataFolderPath(), true, func(_ uint64, swampName name.Name) bool {
+		return MatchPattern(swampName, pattern)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	destroyed := make([]name.Name, 0, len(targets))
+	for _, key := range slices.Sorted(maps.Keys(targets)) {
+
+		target := targets[key]
+		if dryRun {
+			destroyed = append(destroyed, target.swampName)
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return destroyed, err
+		}
+
+		swampInterface, err := h.SummonSwamp(ctx, target.islandID, target.swampName)
+		if err != nil {
+			return destroyed, fmt.Errorf("can not summon the swamp %s: %w", target.swampName.Get(), err)
+		}
+		swampInterface.Destroy()
+		destroyed = append(destroyed, target.swampName)
+
+		if fn != nil {
+			if err := fn(target.swampName); err != nil {
+				return destroyed, err
+			}
+		}
+
+	}
+
+	return destroyed, nil
+
+}
+
+func (h *hydra) GetNamespaceUsage(ctx context.Context, maxAge time.Duration) ([]NamespaceUsage, time.Time, error) {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return nil, time.Time{}, errors.New(ErrorHydraIsShuttingDown)
+	}
+
+	h.usageMu.Lock()
+	defer h.usageMu.Unlock()
+
+	if h.usage != nil && time.Since(h.usageCollectedAt) < maxAge {
+		return slices.Clone(h.usage), h.usageCollectedAt, nil
+	}
+
+	type swampUsage struct {
+		swampName     name.Name
+		treasureCount int64
+		counted       bool
+		diskBytes     int64
+	}
+	swampUsages := make(map[string]*swampUsage)
+
+	dataFolder := h.settingsInterface.GetHydraAbsDataFolderPath()
+	err := filepath.WalkDir(dataFolder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dataFolder {
+				// there is no swamp on the disk yet
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != metadata.MetaFile {
+			return nil
+		}
+		folderPath := filepath.Dir(path)
+		metadataInterface := metadata.New(folderPath)
+		metadataInterface.LoadFromFile()
+		swampName := metadataInterface.GetSwampName()
+		if swampName == nil {
+			return nil
+		}
+		usage := &swampUsage{swampName: swampName, diskBytes: folderSize(folderPath)}
+		if count, err := strconv.ParseInt(metadataInterface.GetKey(metadata.KeyTreasureCount), 10, 64); err == nil {
+			usage.treasureCount = count
+			usage.counted = true
+		}
+		swampUsages[swampName.Get()] = usage
+		return nil
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// the open swamps are counted in the memory, and the new ones have no metadata file until they are closed
+	h.swamps.Range(func(key, value interface{}) bool {
+		swampInterface := value.(swamp.Swamp)
+		swampName := swampInterface.GetName()
+		usage, ok := swampUsages[swampName.Get()]
+		if !ok {
+			usage = &swampUsage{swampName: swampName}
+			if chroniclerInterface := swampInterface.GetChronicler(); chroniclerInterface != nil {
+				usage.diskBytes = folderSize(chroniclerInterface.GetSwampAbsPath())
+			}
+			swampUsages[swampName.Get()] = usage
+		}
+		usage.treasureCount = int64(swampInterface.PeekTreasureCount())
+		usage.counted = true
+		return true
+	})
+
+	namespaces := make(map[[2]string]*NamespaceUsage)
+	for _, usage := range swampUsages {
+		namespaceKey := [2]string{usage.swampName.GetSanctuaryID(), usage.swampName.GetRealmName()}
+		namespace, ok := namespaces[namespaceKey]
+		if !ok {
+			namespace = &NamespaceUsage{Sanctuary: namespaceKey[0], Realm: namespaceKey[1]}
+			namespaces[namespaceKey] = namespace
+		}
+		namespace.SwampCount++
+		namespace.DiskBytes += usage.diskBytes
+		if usage.counted {
+			namespace.TreasureCount += usage.treasureCount
+		} else {
+			namespace.UncountedSwamps++
+		}
+	}
+
+	result := make([]NamespaceUsage, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		result = append(result, *namespace)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Sanctuary != result[j].Sanctuary {
+			return result[i].Sanctuary < result[j].Sanctuary
+		}
+		return result[i].Realm < result[j].Realm
+	})
+
+	h.usage = result
+	h.usageCollectedAt = time.Now()
+
+	return slices.Clone(result), h.usageCollectedAt, nil
+
+}
+
+// folderSize returns the size of the files of the folder in bytes. The subfolders are not counted, because the
+// swamp folders have none. A missing folder is 0 bytes
+func folderSize(folderPath string) int64 {
+	entries, err := os.ReadDir(folderPath)
+	if err != nil {
+		return 0
+	}
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// swampTarget is a swamp found by collectSwamps, with the island it is stored on
+type swampTarget struct {
+	islandID  uint64
+	swampName name.Name
+}
+
+// collectSwamps returns the swamps in the memory and the swamps on the disk under the walked folder for which
+// matches is true, by their names. The in-memory swamps have no folder, so they are collected only if withInMemory is
+// true, with island 0.
+func (h *hydra) collectSwamps(walkedFolder string, withInMemory bool, matches func(islandID uint64, swampName name.Name) bool) (map[string]swampTarget, error) {
+
+	targets := make(map[string]swampTarget)
+
+	dataFolder := h.settingsInterface.GetHydraAbsDataFolderPath()
+	// the first folder under the data folder is the island of the swamp
+	islandOf := func(folderPath string) (uint64, error) {
+		relativePath, err := filepath.Rel(dataFolder, folderPath)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseUint(strings.Split(relativePath, string(filepath.Separator))[0], 10, 64)
+	}
+
+	// the new swamps in the memory have no metadata file until they are closed
+	h.swamps.Range(func(key, value interface{}) bool {
+		swampInterface := value.(swamp.Swamp)
+		swampName := swampInterface.GetName()
+		chroniclerInterface := swampInterface.GetChronicler()
+		if chroniclerInterface == nil {
+			// the in-memory swamps have no folder, and they are summoned by their names only
+			if withInMemory && matches(0, swampName) {
+				targets[swampName.Get()] = swampTarget{swampName: swampName}
+			}
+			return true
+		}
+		if islandID, err := islandOf(chroniclerInterface.GetSwampAbsPath()); err == nil && matches(islandID, swampName) {
+			targets[swampName.Get()] = swampTarget{islandID: islandID, swampName: swampName}
+		}
+		return true
+	})
+
+	err := filepath.WalkDir(walkedFolder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == walkedFolder {
+				// there is no swamp on the disk yet
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || d.Name() != metadata.MetaFile {
+			return nil
+		}
+		metadataInterface := metadata.New(filepath.Dir(path))
+		metadataInterface.LoadFromFile()
+		swampName := metadataInterface.GetSwampName()
+		if swampName == nil {
+			return nil
+		}
+		islandID, err := islandOf(path)
+		if err != nil {
+			slog.Warn("the swamp is not in an island folder, it is skipped", "swamp", swampName.Get(), "path", path)
+			return nil
+		}
+		if matches(islandID, swampName) {
+			targets[swampName.Get()] = swampTarget{islandID: islandID, swampName: swampName}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+
+}
+
 // ListActiveSwamps returns the list of opened and active swamps
 // mutexes: clean
 func (h *hydra) ListActiveSwamps() []string {
@@ -636,6 +1376,132 @@ func (h *hydra) SubscribeToSwampEvents(clientID uuid.UUID, swampName name.Name,
 
 }
 
+// SubscribeToSwampEventsWithSnapshot subscribes to the events of the swamp and takes a snapshot of the swamp at
+// the same point in time
+func (h *hydra) SubscribeToSwampEventsWithSnapshot(ctx context.Context, islandID uint64, clientID uuid.UUID, swampName name.Name,
+	snapshotFunction func(treasures []treasure.Treasure, resumeToken uint64),
+	subscriberEventCallbackFunction func(event *swamp.Event)) error {
+
+	swampObject, err := h.SummonSwamp(ctx, islandID, swampName)
+	if err != nil {
+		return err
+	}
+
+	swampObject.BeginVigil()
+	defer swampObject.CeaseVigil()
+
+	var subscribeErr error
+	swampObject.Snapshot(func() {
+
+		// the key beacon contains all treasures, even the ones without creation time
+		treasures, beaconErr := swampObject.GetTreasuresByBeacon(swamp.BeaconTypeKey, swamp.IndexOrderAsc, 0, 0)
+		if beaconErr != nil {
+			// the swamp has no treasures
+			treasures = nil
+		}
+		sort.SliceStable(treasures, func(i, j int) bool {
+			return treasures[i].GetCreatedAt() < treasures[j].GetCreatedAt()
+		})
+
+		if subscribeErr = h.SubscribeToSwampEvents(clientID, swampName, subscriberEventCallbackFunction); subscribeErr != nil {
+			return
+		}
+
+		snapshotFunction(treasures, h.eventSequence(swampName).Load())
+
+	})
+
+	return subscribeErr
+
+}
+
+// SubscribeToSwampEventsSince subscribes to the events of the swamp and replays the events of the journal since
+// the given time
+func (h *hydra) SubscribeToSwampEventsSince(ctx context.Context, islandID uint64, clientID uuid.UUID, swampName name.Name, since int64,
+	replayFunction func(events []*swamp.Event, resumeToken uint64),
+	subscriberEventCallbackFunction func(event *swamp.Event)) error {
+
+	swampObject, err := h.SummonSwamp(ctx, islandID, swampName)
+	if err != nil {
+		return err
+	}
+
+	swampObject.BeginVigil()
+	defer swampObject.CeaseVigil()
+
+	j, ok := h.journals.Load(swampName.Get())
+	if !ok {
+		return ErrEventReplayNotAvailable
+	}
+
+	var subscribeErr error
+	swampObject.Snapshot(func() {
+
+		events, covered := j.(journal.Journal).Since(since)
+		if !covered {
+			subscribeErr = ErrEventReplayNotAvailable
+			return
+		}
+
+		if subscribeErr = h.SubscribeToSwampEvents(clientID, swampName, subscriberEventCallbackFunction); subscribeErr != nil {
+			return
+		}
+
+		replayFunction(events, h.eventSequence(swampName).Load())
+
+	})
+
+	return subscribeErr
+
+}
+
+// GetJournalEventsSince returns the events of the journal of the swamp since the given time
+func (h *hydra) GetJournalEventsSince(swampName name.Name, since int64) ([]*swamp.Event, bool) {
+	j, ok := h.journals.Load(swampName.Get())
+	if !ok {
+		return nil, false
+	}
+	return j.(journal.Journal).Since(since)
+}
+
+// openJournal creates the event journal of the swamp if its pattern enables the journal. Returns true if the swamp
+// has a journal
+func (h *hydra) openJournal(swampName name.Name) bool {
+
+	if _, ok := h.journals.Load(swampName.Get()); ok {
+		return true
+	}
+
+	swampSettings := h.settingsInterface.GetBySwampName(swampName)
+	if swampSettings.GetEventJournalSize() <= 0 {
+		return false
+	}
+
+	h.journals.LoadOrStore(swampName.Get(), journal.New(swampSettings.GetEventJournalSize(), swampSettings.GetEventJournalRetention()))
+	return true
+
+}
+
+// GetBlobStore returns the blob store of the Hydra
+func (h *hydra) GetBlobStore() blob.Store {
+	return h.blobStore
+}
+
+// GetLeaseTable returns the delivery counter table of the swamp
+func (h *hydra) GetLeaseTable(swampName name.Name) lease.Table {
+	if table, ok := h.leaseTables.Load(swampName.Get()); ok {
+		return table.(lease.Table)
+	}
+	table, _ := h.leaseTables.LoadOrStore(swampName.Get(), lease.New())
+	return table.(lease.Table)
+}
+
+// eventSequence returns the event sequence counter of the swamp
+func (h *hydra) eventSequence(swampName name.Name) *atomic.Uint64 {
+	sequence, _ := h.eventSequences.LoadOrStore(swampName.Get(), &atomic.Uint64{})
+	return sequence.(*atomic.Uint64)
+}
+
 // UnsubscribeFromSwampEvents unsubscribes the user from the events channel of the swamp
 // mutexes: clean
 func (h *hydra) UnsubscribeFromSwampEvents(clientID uuid.UUID, swampName name.Name) error {
@@ -659,8 +1525,8 @@ func (h *hydra) UnsubscribeFromSwampEvents(clientID uuid.UUID, swampName name.Na
 		return true
 	})
 
-	// stops sending events if the swamp exists and there are no subscribers to events
-	if allSubscribers == 0 {
+	// stops sending events if the swamp exists and there are no subscribers to events, and no journal
+	if _, hasJournal := h.journals.Load(canonicalForm); allSubscribers == 0 && !hasJournal && !h.hasPatternSubscriber(swampName) {
 		if swampObject, ok := h.swamps.Load(canonicalForm); ok {
 			swampObject.(swamp.Swamp).StopSendingEvents()
 		}
@@ -670,6 +1536,107 @@ func (h *hydra) UnsubscribeFromSwampEvents(clientID uuid.UUID, swampName name.Na
 
 }
 
+// patternSubscriber is a subscriber of the events of the swamps matching its patterns
+type patternSubscriber struct {
+	patterns []name.Name
+	callback func(event *swamp.Event)
+}
+
+// matches returns true if the swamp matches any pattern of the subscriber
+func (p *patternSubscriber) matches(swampName name.Name) bool {
+	for _, pattern := range p.patterns {
+		if MatchPattern(swampName, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPattern returns true if the swamp name matches the pattern. Unlike the ComparePattern of the name, the
+// sanctuary of the pattern can be a wildcard too
+func MatchPattern(swampName name.Name, pattern name.Name) bool {
+	if pattern.GetSanctuaryID() != "*" && pattern.GetSanctuaryID() != swampName.GetSanctuaryID() {
+		return false
+	}
+	if pattern.GetRealmName() != "*" && pattern.GetRealmName() != swampName.GetRealmName() {
+		return false
+	}
+	return pattern.GetSwampName() == "*" || pattern.GetSwampName() == swampName.GetSwampName()
+}
+
+// SubscribeToPatternEvents subscribes to the events of the swamps matching the patterns, without hydrating them
+// mutexes: clean
+func (h *hydra) SubscribeToPatternEvents(clientID uuid.UUID, patterns []name.Name, subscriberEventCallbackFunction func(event *swamp.Event)) error {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return errors.New(ErrorHydraIsShuttingDown)
+	}
+
+	if len(patterns) == 0 {
+		return errors.New("at least one pattern is required")
+	}
+
+	subscriber := &patternSubscriber{
+		patterns: patterns,
+		callback: subscriberEventCallbackFunction,
+	}
+	h.patternSubscribers.Store(clientID.String(), subscriber)
+
+	// the swamps hydrated from now on start sending the events at their hydration, the swamps in the memory now
+	h.swamps.Range(func(key, value interface{}) bool {
+		swampObject := value.(swamp.Swamp)
+		if subscriber.matches(swampObject.GetName()) {
+			swampObject.StartSendingEvents()
+		}
+		return true
+	})
+
+	return nil
+
+}
+
+// UnsubscribeFromPatternEvents removes the subscription of the events of the swamps matching the patterns
+// mutexes: clean
+func (h *hydra) UnsubscribeFromPatternEvents(clientID uuid.UUID) error {
+
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return errors.New(ErrorHydraIsShuttingDown)
+	}
+
+	value, ok := h.patternSubscribers.LoadAndDelete(clientID.String())
+	if !ok {
+		return nil
+	}
+	subscriber := value.(*patternSubscriber)
+
+	// stops sending the events of the swamps without other subscribers and journal
+	h.swamps.Range(func(key, value interface{}) bool {
+		swampObject := value.(swamp.Swamp)
+		swampName := swampObject.GetName()
+		if !subscriber.matches(swampName) {
+			return true
+		}
+		if _, hasJournal := h.journals.Load(swampName.Get()); hasJournal || h.hasEventSubscriber(swampName) || h.hasPatternSubscriber(swampName) {
+			return true
+		}
+		swampObject.StopSendingEvents()
+		return true
+	})
+
+	return nil
+
+}
+
+// hasPatternSubscriber checks if the swamp matches the patterns of any pattern subscriber
+func (h *hydra) hasPatternSubscriber(swampName name.Name) bool {
+	found := false
+	h.patternSubscribers.Range(func(key, value interface{}) bool {
+		found = value.(*patternSubscriber).matches(swampName)
+		return !found
+	})
+	return found
+}
+
 // GracefulStop close function for the graceful stop package
 // DO NOT CALL THIS FUNCTION DIRECTLY
 // the graceful stop package calls this function when the server is shutting down
@@ -683,9 +1650,10 @@ func (h *hydra) GracefulStop() {
 	atomic.StoreInt32(&h.shuttingDown, 1)
 
 	// Remove all event and info subscribers to prevent them from waiting for new events or information
-	// from Hydra. We close all subscriber channels, which notifies the subscribers accordingly.
-	h.eventSubscribers = sync.Map{}
-	h.infoSubscribers = sync.Map{}
+	// from Hydra. The maps are cleared in place, because the subscribers can still load from and store into them.
+	h.eventSubscribers.Clear()
+	h.infoSubscribers.Clear()
+	h.patternSubscribers.Clear()
 
 	// start a new routine and close all swamps
 	go h.tryToCloseAllSwamps()
@@ -809,13 +1777,80 @@ func (h *hydra) createNewSwamp(islandID uint64, swampName name.Name) swamp.Swamp
 	var fss *swamp.FilesystemSettings
 	// init chronicler if the swamp is permanent-type
 	if swampSettings.GetSwampType() == setting.PermanentSwamp {
+		// the key filter of the last close is removed before the swamp is loaded, because it would miss the keys
+		// written from now on if the server stopped without closing the swamp. The swamp writes a new one when it
+		// closes
+		if err := keyfilter.Remove(swampDataFolderPath); err != nil {
+			slog.Error("can not remove the key filter of the swamp", "swampName", swampName, "error", err)
+		}
+		// the key directory would miss the keys written from now on, and the chunks of the keys changed by the
+		// compaction, the same way
+		if err := keydirectory.Remove(swampDataFolderPath); err != nil {
+			slog.Error("can not remove the key directory of the swamp", "swampName", swampName, "error", err)
+		}
 		fss = &swamp.FilesystemSettings{}
 		fss.ChroniclerInterface = h.loadChronicler(swampSettings, swampDataFolderPath, metadataInterface)
 		fss.WriteInterval = swampSettings.GetWriteInterval()
+		fss.WriteBatchSize = h.settingsInterface.GetWriteBatchSize()
 	}
 
 	// create the swamp with the filesystem
-	return swamp.New(swampName, swampSettings.GetCloseAfterIdle(), fss, h.eventCallbackFunction, h.infoCallbackFunction, h.closeEventCallbackFunction, metadataInterface)
+	// the swamp does not know its island, so the hydra adds it to the events
+	eventCallbackFunction := func(event *swamp.Event) {
+		event.IslandID = islandID
+		h.eventCallbackFunction(event)
+	}
+
+	swampInterface := swamp.New(swampName, swampSettings.GetCloseAfterIdle(), fss, eventCallbackFunction, h.infoCallbackFunction, h.closeEventCallbackFunction, metadataInterface, swampSettings.IsValueIndexed())
+	swampInterface.SetServerTimestamps(swampSettings.IsServerTimestamped())
+	swampInterface.SetKeyFilter(swampSettings.GetSwampType() == setting.PermanentSwamp && swampSettings.IsKeyFiltered())
+	swampInterface.SetLazyHydration(swampSettings.GetSwampType() == setting.PermanentSwamp && swampSettings.IsLazyHydrated())
+	swampInterface.SetHistoryDepth(swampSettings.GetHistoryDepth())
+	swampInterface.SetRetention(swamp.Retention{
+		MaxAge:       swampSettings.GetMaxTreasureAge(),
+		MaxTreasures: swampSettings.GetMaxTreasures(),
+		MaxSizeByte:  swampSettings.GetMaxSwampSizeByte(),
+	})
+	swampInterface.SetDefaultExpireAfter(swampSettings.GetDefaultExpireAfter())
+	swampInterface.SetBeacons(keptBeaconTypes(swampSettings.GetKeptBeacons()), swampSettings.GetBeaconEvictAfterIdle())
+	swampInterface.SetKeyLockCounters(h.settingsInterface.GetKeyLockCounters())
+	swampInterface.SetFlushStallCounters(h.settingsInterface.GetFlushStallCounters())
+
+	return swampInterface
+
+}
+
+// keptBeaconTypes converts the kept beacons of the settings to the beacon types of the swamp. The value beacons are
+// kept by any value type, so the string type stands for all of them
+func keptBeaconTypes(keptBeacons []string) []swamp.BeaconType {
+	beaconTypes := make([]swamp.BeaconType, 0, len(keptBeacons))
+	for _, keptBeacon := range keptBeacons {
+		switch keptBeacon {
+		case setting.BeaconKey:
+			beaconTypes = append(beaconTypes, swamp.BeaconTypeKey)
+		case setting.BeaconCreationTime:
+			beaconTypes = append(beaconTypes, swamp.BeaconTypeCreationTime)
+		case setting.BeaconUpdateTime:
+			beaconTypes = append(beaconTypes, swamp.BeaconTypeUpdateTime)
+		case setting.BeaconExpirationTime:
+			beaconTypes = append(beaconTypes, swamp.BeaconTypeExpirationTime)
+		case setting.BeaconValue:
+			beaconTypes = append(beaconTypes, swamp.BeaconTypeValueString)
+		}
+	}
+	return beaconTypes
+}
+
+// acquireHydration waits for a free slot of the hydration scheduler if the swamp is loaded from the disk. The
+// in-memory swamps and the hydras without scheduler are not limited. The returned function releases the slot.
+func (h *hydra) acquireHydration(ctx context.Context, swampName name.Name) (func(), error) {
+
+	scheduler := h.settingsInterface.GetHydrationScheduler()
+	if scheduler == nil || h.settingsInterface.GetBySwampName(swampName).GetSwampType() != setting.PermanentSwamp {
+		return func() {}, nil
+	}
+
+	return scheduler.Acquire(ctx, hydration.PriorityFromContext(ctx))
 
 }
 
@@ -827,6 +1862,7 @@ func (h *hydra) loadChronicler(swampSettings setting.Setting, swampDataFolderPat
 
 	// Create the file handler along with the metadata for the swamp.
 	fs := chronicler.New(swampDataFolderPath, maxFileSizeBytes, h.settingsInterface.GetHashFolderDepth(), h.filesystemInterface, metadataInterface)
+	fs.SetFsyncPolicy(swampSettings.GetFsyncPolicy(), swampSettings.GetFsyncInterval())
 	fs.CreateDirectoryIfNotExists()
 
 	return fs
@@ -884,6 +1920,12 @@ func (h *hydra) hasInfoSubscriber(swampName name.Name) bool {
 func (h *hydra) eventCallbackFunction(event *swamp.Event) {
 
 	swampName := event.SwampName
+	// the swamp holds its snapshot lock while the event is sent, so the sequence is consistent with the snapshots
+	event.Sequence = h.eventSequence(swampName).Add(1)
+
+	if j, ok := h.journals.Load(swampName.Get()); ok {
+		j.(journal.Journal).Append(event)
+	}
 
 	if subscribers, ok := h.eventSubscribers.Load(swampName.Get()); ok {
 
@@ -950,6 +1992,14 @@ func (h *hydra) eventCallbackFunction(event *swamp.Event) {
 		})
 	}
 
+	// the subscribers of the patterns get the events of every matching swamp
+	h.patternSubscribers.Range(func(key, value interface{}) bool {
+		if subscriber := value.(*patternSubscriber); subscriber.matches(swampName) {
+			subscriber.callback(event)
+		}
+		return true
+	})
+
 }
 
 func (h *hydra) infoCallbackFunction(si *swamp.Info) {
@@ -1010,3 +2060,28 @@ func (h *hydra) infoCallbackFunction(si *swamp.Info) {
 func (h *hydra) closeEventCallbackFunction(swampName name.Name) {
 	h.swamps.Delete(swampName.Get())
 }
+
+// hydrationKey is the context key of the hydration flag of a request
+type hydrationKey struct{}
+
+// WithHydrationTracking returns a context that records whether SummonSwamp had to load a swamp into the memory
+// while serving the request. Use IsHydrationTriggered with the returned context to read the flag.
+func WithHydrationTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hydrationKey{}, &atomic.Bool{})
+}
+
+// IsHydrationTriggered returns true if a swamp was loaded into the memory with the context. Always false if the
+// context was not created by WithHydrationTracking
+func IsHydrationTriggered(ctx context.Context) bool {
+	if flag, ok := ctx.Value(hydrationKey{}).(*atomic.Bool); ok {
+		return flag.Load()
+	}
+	return false
+}
+
+// markHydration sets the hydration flag of the context, if the context tracks it
+func markHydration(ctx context.Context) {
+	if flag, ok := ctx.Value(hydrationKey{}).(*atomic.Bool); ok {
+		flag.Store(true)
+	}
+}
@@ -2,16 +2,24 @@ package hydra
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
 	"github.com/hydraide/hydraide/app/core/hydra/lock"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keydirectory"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keyfilter"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
 	"github.com/hydraide/hydraide/app/core/safeops"
 	"github.com/hydraide/hydraide/app/core/settings"
 	"github.com/hydraide/hydraide/app/name"
 	"github.com/stretchr/testify/assert"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -38,7 +46,7 @@ func TestHydra_SummonSwamp(t *testing.T) {
 		MaxFileSizeByte:  8192,
 	}
 
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
 
 	t.Run("should summon a non existing swamp", func(t *testing.T) {
@@ -68,6 +76,17 @@ func TestHydra_SummonSwamp(t *testing.T) {
 		assert.NotNil(t, swampInterface, "should not be nil")
 		assert.Equal(t, newSwampName, swampInterface.GetName(), "should be equal")
 
+		// the swamp is already in the memory, so the summon does not trigger hydration
+		trackedCtx := WithHydrationTracking(context.Background())
+		_, _ = hydraInterface.SummonSwamp(trackedCtx, 10, newSwampName)
+		assert.False(t, IsHydrationTriggered(trackedCtx), "the swamp is in the memory")
+
+		trackedCtx = WithHydrationTracking(context.Background())
+		hydratedSwamp, _ := hydraInterface.SummonSwamp(trackedCtx, 10, name.New().Sanctuary("summon").Realm("existing").Swamp("hydrated"))
+		assert.True(t, IsHydrationTriggered(trackedCtx), "the swamp is loaded into the memory")
+		assert.False(t, IsHydrationTriggered(context.Background()))
+		hydratedSwamp.Destroy()
+
 		// destory the swamp after the test
 		swampInterface.Destroy()
 
@@ -266,6 +285,57 @@ func TestHydra_SummonSwamp(t *testing.T) {
 
 	})
 
+	t.Run("should find swamps by wildcard pattern", func(t *testing.T) {
+
+		swampNames := []name.Name{
+			name.New().Sanctuary(sanctuaryForQuickTest).Realm("find-swamps").Swamp("swamp-1"),
+			name.New().Sanctuary(sanctuaryForQuickTest).Realm("find-swamps").Swamp("swamp-2"),
+			name.New().Sanctuary(sanctuaryForQuickTest).Realm("find-swamps-other").Swamp("swamp-1"),
+		}
+
+		for i, swampName := range swampNames {
+			swampInterface, err := hydraInterface.SummonSwamp(context.Background(), uint64(10+i), swampName)
+			assert.Nil(t, err, "should be nil")
+			treasure := swampInterface.CreateTreasure("treasure-1")
+			guardID := treasure.StartTreasureGuard(true)
+			treasure.SetContentString(guardID, "content")
+			treasure.Save(guardID)
+			treasure.ReleaseTreasureGuard(guardID)
+		}
+
+		collect := func(pattern name.Name) []string {
+			found, err := hydraInterface.FindSwamps(pattern)
+			assert.NoError(t, err)
+			result := make([]string, 0, len(found))
+			for _, swampName := range found {
+				result = append(result, swampName.Get())
+			}
+			return result
+		}
+
+		// the swamps are still in the memory
+		pattern := name.New().Sanctuary(sanctuaryForQuickTest).Realm("find-swamps").Swamp("*")
+		assert.ElementsMatch(t, []string{swampNames[0].Get(), swampNames[1].Get()}, collect(pattern))
+
+		// wait until the swamps are written to the disk and closed, so they are found by their metadata
+		time.Sleep(3 * time.Second)
+		assert.NotContains(t, hydraInterface.ListActiveSwamps(), swampNames[0].Get(), "the swamp should be closed")
+		assert.ElementsMatch(t, []string{swampNames[0].Get(), swampNames[1].Get()}, collect(pattern))
+
+		pattern = name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("swamp-1")
+		found := collect(pattern)
+		assert.Contains(t, found, swampNames[0].Get())
+		assert.Contains(t, found, swampNames[2].Get())
+		assert.NotContains(t, found, swampNames[1].Get())
+
+		for i, swampName := range swampNames {
+			swampInterface, err := hydraInterface.SummonSwamp(context.Background(), uint64(10+i), swampName)
+			assert.Nil(t, err, "should be nil")
+			swampInterface.Destroy()
+		}
+
+	})
+
 	t.Run("should create and modify treasure", func(t *testing.T) {
 
 		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("treasure-get-and-modify").Swamp("get-and-modify")
@@ -660,6 +730,814 @@ func TestHydra_SummonSwamp(t *testing.T) {
 
 }
 
+func TestHydra_SummonSwamp_CorruptedFile(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), fsInterface)
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("corrupted").Swamp("swamp")
+
+	// write a treasure to the disk, then damage the chunk file
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+	assert.NoError(t, err)
+	treasureInterface := swampInterface.CreateTreasure("key")
+	guardID := treasureInterface.StartTreasureGuard(true)
+	treasureInterface.SetContentString(guardID, "value")
+	treasureInterface.Save(guardID)
+	treasureInterface.ReleaseTreasureGuard(guardID)
+	swampPath := swampInterface.GetChronicler().GetSwampAbsPath()
+	swampInterface.Close()
+
+	entries, err := os.ReadDir(swampPath)
+	assert.NoError(t, err)
+	chunkPath := ""
+	for _, entry := range entries {
+		if entry.Name() != metadata.MetaFile {
+			chunkPath = filepath.Join(swampPath, entry.Name())
+		}
+	}
+	content, err := os.ReadFile(chunkPath)
+	assert.NoError(t, err)
+	content[len(content)-1] ^= 0xff
+	assert.NoError(t, os.WriteFile(chunkPath, content, os.ModePerm))
+
+	t.Run("should skip the corrupted file and register it", func(t *testing.T) {
+
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, swampInterface.CountTreasures())
+		swampInterface.Close()
+
+		corruptedFiles := fsInterface.GetCorruptedFiles()
+		assert.Equal(t, 1, len(corruptedFiles))
+		assert.Equal(t, chunkPath, corruptedFiles[0].FilePath)
+
+	})
+
+	t.Run("should not load the swamp with a corrupted file without skipping", func(t *testing.T) {
+
+		fsInterface.SetSkipCorruptedFiles(false)
+		defer fsInterface.SetSkipCorruptedFiles(true)
+
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.Nil(t, swampInterface)
+		assert.ErrorIs(t, err, filesystem.ErrCorruptedFile)
+		assert.Equal(t, 0, hydraInterface.CountActiveSwamps())
+
+	})
+
+}
+
+func TestHydra_SummonSwamp_HydrationScheduler(t *testing.T) {
+
+	scheduler := hydration.New(1)
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.SetHydrationScheduler(scheduler)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("scheduled").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	t.Run("should wait for a free hydration slot", func(t *testing.T) {
+
+		// the only slot is taken, so the loading of a cold swamp must wait
+		release, err := scheduler.Acquire(context.Background(), hydration.PriorityInteractive)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = hydraInterface.SummonSwamp(ctx, 10, name.New().Sanctuary(sanctuaryForQuickTest).Realm("scheduled").Swamp("cold"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 0, hydraInterface.CountActiveSwamps())
+
+		release()
+
+		ctx = hydration.WithPriority(context.Background(), hydration.PriorityBackground)
+		swampInterface, err := hydraInterface.SummonSwamp(ctx, 10, name.New().Sanctuary(sanctuaryForQuickTest).Realm("scheduled").Swamp("cold"))
+		assert.NoError(t, err)
+		assert.NotNil(t, swampInterface)
+
+		stats := scheduler.Stats()
+		assert.Equal(t, 0, stats.Active, "the slot is released after the loading")
+		assert.Equal(t, uint64(1), stats.Hydrations[hydration.PriorityBackground])
+
+		swampInterface.Destroy()
+
+	})
+
+}
+
+func TestHydra_MayContainKeys(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("filtered").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+		KeyFilter:        true,
+	}, nil)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("unfiltered").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	// writes the keys to the swamp, and closes it
+	fill := func(swampName name.Name) string {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("key-%d", i))
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, "value")
+			treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+		swampPath := swampInterface.GetChronicler().GetSwampAbsPath()
+		swampInterface.Close()
+		return swampPath
+	}
+
+	t.Run("should exclude the missing keys of a closed swamp", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("filtered").Swamp("urls")
+		swampPath := fill(swampName)
+		assert.FileExists(t, filepath.Join(swampPath, keyfilter.File))
+
+		mayContain, ok := hydraInterface.MayContainKeys(10, swampName, []string{"key-1", "key-99", "missing"})
+		assert.True(t, ok)
+		assert.Equal(t, []bool{true, true, false}, mayContain)
+		assert.Equal(t, 0, hydraInterface.CountActiveSwamps(), "the swamp is not loaded")
+
+		// the filter file is not loaded as a chunk file
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		assert.Equal(t, 100, swampInterface.CountTreasures())
+
+		_, ok = hydraInterface.MayContainKeys(10, swampName, []string{"missing"})
+		assert.False(t, ok, "a loaded swamp answers from the memory")
+		assert.NoFileExists(t, filepath.Join(swampPath, keyfilter.File), "the filter is removed while the swamp is loaded")
+
+		treasureInterface := swampInterface.CreateTreasure("missing")
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "value")
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+		swampInterface.Close()
+
+		mayContain, ok = hydraInterface.MayContainKeys(10, swampName, []string{"missing"})
+		assert.True(t, ok)
+		assert.Equal(t, []bool{true}, mayContain, "the new filter contains the new key")
+
+	})
+
+	t.Run("should not answer without a valid filter", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("unfiltered").Swamp("urls")
+		swampPath := fill(swampName)
+		assert.NoFileExists(t, filepath.Join(swampPath, keyfilter.File))
+		_, ok := hydraInterface.MayContainKeys(10, swampName, []string{"missing"})
+		assert.False(t, ok)
+
+		swampName = name.New().Sanctuary(sanctuaryForQuickTest).Realm("filtered").Swamp("corrupted")
+		swampPath = fill(swampName)
+		assert.NoError(t, os.WriteFile(filepath.Join(swampPath, keyfilter.File), []byte("garbage"), 0644))
+		_, ok = hydraInterface.MayContainKeys(10, swampName, []string{"missing"})
+		assert.False(t, ok)
+
+	})
+
+}
+
+func TestHydra_GetTreasuresFromChunks(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("lazy").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+		LazyHydration:    true,
+	}, nil)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("eager").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	save := func(swampInterface swamp.Swamp, key string, value string) {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, value)
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	// writes the keys to the swamp in many chunks, and closes it
+	fill := func(swampName name.Name) string {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		for i := 0; i < 1000; i++ {
+			save(swampInterface, fmt.Sprintf("key-%d", i), fmt.Sprintf("value-%d", i))
+		}
+		swampPath := swampInterface.GetChronicler().GetSwampAbsPath()
+		swampInterface.Close()
+		return swampPath
+	}
+
+	t.Run("should read only the chunks of the keys of a closed swamp", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("lazy").Swamp("users")
+		swampPath := fill(swampName)
+		assert.FileExists(t, filepath.Join(swampPath, keydirectory.File))
+
+		treasures, ok := hydraInterface.GetTreasuresFromChunks(10, swampName, []string{"key-1", "key-999", "missing"})
+		assert.True(t, ok)
+		assert.Len(t, treasures, 2)
+		value, err := treasures["key-999"].GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "value-999", value)
+		assert.Equal(t, 0, hydraInterface.CountActiveSwamps(), "the swamp is not loaded")
+
+		// the directory file is not loaded as a chunk file
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, swampInterface.CountTreasures())
+
+		_, ok = hydraInterface.GetTreasuresFromChunks(10, swampName, []string{"key-1"})
+		assert.False(t, ok, "a loaded swamp answers from the memory")
+		assert.NoFileExists(t, filepath.Join(swampPath, keydirectory.File), "the directory is removed while the swamp is loaded")
+
+		save(swampInterface, "key-1", "modified")
+		save(swampInterface, "new", "value")
+		assert.NoError(t, swampInterface.DeleteTreasure("key-2", false))
+		swampInterface.Close()
+
+		treasures, ok = hydraInterface.GetTreasuresFromChunks(10, swampName, []string{"key-1", "key-2", "new"})
+		assert.True(t, ok)
+		assert.Len(t, treasures, 2, "the deleted key does not exist")
+		value, err = treasures["key-1"].GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "modified", value)
+		value, err = treasures["new"].GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value, "the treasures written while the swamp closes are in the directory")
+
+	})
+
+	t.Run("should not answer without a valid directory", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("eager").Swamp("users")
+		swampPath := fill(swampName)
+		assert.NoFileExists(t, filepath.Join(swampPath, keydirectory.File))
+		_, ok := hydraInterface.GetTreasuresFromChunks(10, swampName, []string{"key-1"})
+		assert.False(t, ok)
+
+		swampName = name.New().Sanctuary(sanctuaryForQuickTest).Realm("lazy").Swamp("stale")
+		swampPath = fill(swampName)
+		assert.NoError(t, keydirectory.Save(swampPath, map[string]string{"key-1": "deleted-chunk"}))
+		_, ok = hydraInterface.GetTreasuresFromChunks(10, swampName, []string{"key-1"})
+		assert.False(t, ok, "the chunk of the key does not exist")
+
+	})
+
+}
+
+func TestHydra_SubscribeToSwampEventsWithSnapshot(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("snapshot").Swamp("*"), false, 5, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("snapshot").Swamp("swamp")
+
+	t.Run("should deliver every change exactly once across the snapshot and the stream", func(t *testing.T) {
+
+		const allTreasures = 2000
+
+		save := func(i int) {
+			swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+			assert.NoError(t, err)
+			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("key-%d", i))
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, "content")
+			treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+
+		// the first treasures are in the swamp before the subscription
+		for i := 0; i < 100; i++ {
+			save(i)
+		}
+
+		// the rest are saved while the snapshot is taken
+		writerDone := make(chan struct{})
+		go func() {
+			defer close(writerDone)
+			for i := 100; i < allTreasures; i++ {
+				save(i)
+			}
+		}()
+
+		var mu sync.Mutex
+		received := make(map[string]int)
+		var resumeToken uint64
+		var sequences []uint64
+
+		clientID := uuid.New()
+		err := hydraInterface.SubscribeToSwampEventsWithSnapshot(context.Background(), 10, clientID, swampName,
+			func(treasures []treasure.Treasure, token uint64) {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, treasureInterface := range treasures {
+					received[treasureInterface.GetKey()]++
+				}
+				resumeToken = token
+			},
+			func(event *swamp.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				if event.StatusType == treasure.StatusNew {
+					received[event.Treasure.GetKey()]++
+					sequences = append(sequences, event.Sequence)
+				}
+			})
+		assert.NoError(t, err)
+
+		<-writerDone
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, received, allTreasures)
+		for key, count := range received {
+			assert.Equal(t, 1, count, "the treasure %s is delivered more than once", key)
+		}
+		for _, sequence := range sequences {
+			assert.Greater(t, sequence, resumeToken)
+		}
+
+		assert.NoError(t, hydraInterface.UnsubscribeFromSwampEvents(clientID, swampName))
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		swampInterface.Destroy()
+
+	})
+
+}
+
+func TestHydra_SubscribeToSwampEventsSince(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	fss := &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("journal").Swamp("*"), false, 5, fss, &settings.PatternOptions{
+		EventJournalSize: 1000,
+	})
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("nojournal").Swamp("*"), false, 5, fss, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("journal").Swamp("swamp")
+
+	save := func(key string, content string) {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, content)
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	t.Run("should replay the events since the given time", func(t *testing.T) {
+
+		before := time.Now().UnixNano()
+		for i := 0; i < 5; i++ {
+			save(fmt.Sprintf("key-%d", i), "content")
+		}
+		since := time.Now().UnixNano()
+		for i := 5; i < 10; i++ {
+			save(fmt.Sprintf("key-%d", i), "content")
+		}
+		save("key-5", "modified")
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		swampInterface.DeleteTreasure("key-6", false)
+
+		var mu sync.Mutex
+		var replayed []*swamp.Event
+		var live []*swamp.Event
+		var resumeToken uint64
+
+		clientID := uuid.New()
+		err = hydraInterface.SubscribeToSwampEventsSince(context.Background(), 10, clientID, swampName, since,
+			func(events []*swamp.Event, token uint64) {
+				replayed = events
+				resumeToken = token
+			},
+			func(event *swamp.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				live = append(live, event)
+			})
+		assert.NoError(t, err)
+
+		if assert.Len(t, replayed, 7) {
+			for i, event := range replayed[:5] {
+				assert.Equal(t, treasure.StatusNew, event.StatusType)
+				assert.Equal(t, fmt.Sprintf("key-%d", i+5), event.Treasure.GetKey())
+				if i > 0 {
+					assert.Greater(t, event.Sequence, replayed[i-1].Sequence)
+				}
+			}
+			// the event keeps the content of the treasure at the time of the event
+			content, _ := replayed[0].Treasure.GetContentString()
+			assert.Equal(t, "content", content)
+			content, _ = replayed[5].Treasure.GetContentString()
+			assert.Equal(t, "modified", content)
+			assert.Equal(t, treasure.StatusModified, replayed[5].StatusType)
+			assert.Equal(t, treasure.StatusDeleted, replayed[6].StatusType)
+			assert.Equal(t, "key-6", replayed[6].DeletedTreasure.GetKey())
+			assert.Equal(t, replayed[6].Sequence, resumeToken)
+		}
+
+		save("key-10", "content")
+		mu.Lock()
+		if assert.Len(t, live, 1) {
+			assert.Equal(t, "key-10", live[0].Treasure.GetKey())
+			assert.Greater(t, live[0].Sequence, resumeToken)
+		}
+		mu.Unlock()
+		assert.NoError(t, hydraInterface.UnsubscribeFromSwampEvents(clientID, swampName))
+
+		// the journal started at the first summon of the swamp, so it can not cover the time before
+		err = hydraInterface.SubscribeToSwampEventsSince(context.Background(), 10, uuid.New(), swampName, before-int64(time.Hour),
+			func(events []*swamp.Event, token uint64) {}, func(event *swamp.Event) {})
+		assert.ErrorIs(t, err, ErrEventReplayNotAvailable)
+
+		swampInterface, err = hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		swampInterface.Destroy()
+
+	})
+
+	t.Run("should return the events of the journal without summoning the swamp", func(t *testing.T) {
+
+		since := time.Now().UnixNano()
+		save("journal-key-1", "content")
+		save("journal-key-2", "content")
+
+		events, ok := hydraInterface.GetJournalEventsSince(swampName, since)
+		assert.True(t, ok)
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, "journal-key-1", events[0].Treasure.GetKey())
+			assert.Equal(t, "journal-key-2", events[1].Treasure.GetKey())
+		}
+
+		_, ok = hydraInterface.GetJournalEventsSince(name.New().Sanctuary(sanctuaryForQuickTest).Realm("journal").Swamp("never-summoned"), since)
+		assert.False(t, ok, "a swamp without journal can not replay")
+
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		swampInterface.Destroy()
+
+	})
+
+	t.Run("should not replay without journal", func(t *testing.T) {
+
+		noJournalSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("nojournal").Swamp("swamp")
+		err := hydraInterface.SubscribeToSwampEventsSince(context.Background(), 10, uuid.New(), noJournalSwamp, time.Now().UnixNano(),
+			func(events []*swamp.Event, token uint64) {}, func(event *swamp.Event) {})
+		assert.ErrorIs(t, err, ErrEventReplayNotAvailable)
+
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, noJournalSwamp)
+		assert.NoError(t, err)
+		swampInterface.Destroy()
+
+	})
+
+}
+
+func TestHydra_SubscribeToPatternEvents(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 5, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	save := func(swampName name.Name, key string) {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+		assert.NoError(t, err)
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "content")
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	t.Run("should deliver the events of every matching swamp without hydrating them", func(t *testing.T) {
+
+		hotSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("feed").Swamp("hot")
+		coldSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("feed").Swamp("cold")
+		otherSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("other").Swamp("swamp")
+
+		// the hot swamp is in the memory before the subscription
+		save(hotSwamp, "before")
+
+		var mu sync.Mutex
+		var received []string
+		clientID := uuid.New()
+		err := hydraInterface.SubscribeToPatternEvents(clientID, []name.Name{name.New().Sanctuary("*").Realm("feed").Swamp("*")}, func(event *swamp.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			if event.StatusType == treasure.StatusNew {
+				received = append(received, event.SwampName.Get()+"/"+event.Treasure.GetKey())
+			}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hotSwamp.Get()}, hydraInterface.ListActiveSwamps(), "the subscription hydrates no swamp")
+
+		save(hotSwamp, "hot-key")
+		save(coldSwamp, "cold-key")
+		save(otherSwamp, "other-key")
+
+		mu.Lock()
+		assert.Equal(t, []string{hotSwamp.Get() + "/hot-key", coldSwamp.Get() + "/cold-key"}, received)
+		mu.Unlock()
+
+		assert.NoError(t, hydraInterface.UnsubscribeFromPatternEvents(clientID))
+		save(coldSwamp, "after")
+
+		mu.Lock()
+		assert.Len(t, received, 2)
+		mu.Unlock()
+
+		for _, swampName := range []name.Name{hotSwamp, coldSwamp, otherSwamp} {
+			swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 10, swampName)
+			assert.NoError(t, err)
+			swampInterface.Destroy()
+		}
+
+	})
+
+	t.Run("should require a pattern", func(t *testing.T) {
+		assert.Error(t, hydraInterface.SubscribeToPatternEvents(uuid.New(), nil, func(event *swamp.Event) {}))
+	})
+
+}
+
+func TestHydra_BackupSwamps(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 60,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	save := func(islandID uint64, swampName name.Name, key string) {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), islandID, swampName)
+		assert.NoError(t, err)
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "content")
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	closedSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("backup").Swamp("closed")
+	openSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("backup").Swamp("open")
+	otherSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("other").Swamp("swamp")
+
+	save(11, closedSwamp, "key")
+	save(12, otherSwamp, "key")
+	// wait until the swamps are written to the disk and closed
+	time.Sleep(2500 * time.Millisecond)
+	assert.Empty(t, hydraInterface.ListActiveSwamps(), "the swamps should be closed")
+
+	// the open swamp is not written to the disk yet
+	save(13, openSwamp, "key")
+
+	t.Run("should freeze the matching swamps and call the function with their folders", func(t *testing.T) {
+
+		islands := make(map[string]uint64)
+		err := hydraInterface.BackupSwamps(context.Background(), []name.Name{name.New().Sanctuary("*").Realm("backup").Swamp("*")}, func(islandID uint64, swampName name.Name, folderPath string) error {
+			islands[swampName.Get()] = islandID
+			entries, err := os.ReadDir(folderPath)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, entries, "the waiting treasures are written before the function")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]uint64{closedSwamp.Get(): 11, openSwamp.Get(): 13}, islands)
+
+	})
+
+	t.Run("should return the error of the function", func(t *testing.T) {
+		err := hydraInterface.BackupSwamps(context.Background(), []name.Name{name.New().Sanctuary("*").Realm("*").Swamp("*")}, func(islandID uint64, swampName name.Name, folderPath string) error {
+			return errors.New("upload failed")
+		})
+		assert.EqualError(t, err, "upload failed")
+	})
+
+	t.Run("should stop when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := hydraInterface.BackupSwamps(ctx, []name.Name{name.New().Sanctuary("*").Realm("*").Swamp("*")}, func(islandID uint64, swampName name.Name, folderPath string) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should export only the swamps of the island", func(t *testing.T) {
+
+		islandSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("other").Swamp("island")
+		save(11, islandSwamp, "key")
+
+		var exported []string
+		err := hydraInterface.ExportIsland(context.Background(), 11, func(swampName name.Name, folderPath string) error {
+			exported = append(exported, swampName.Get())
+			entries, err := os.ReadDir(folderPath)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, entries)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{closedSwamp.Get(), islandSwamp.Get()}, exported)
+
+		err = hydraInterface.ExportIsland(context.Background(), 99, func(swampName name.Name, folderPath string) error {
+			return errors.New("the island has no swamp")
+		})
+		assert.NoError(t, err)
+
+	})
+
+}
+
+func TestHydra_DestroySwamps(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 60,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("memory").Swamp("*"), true, 5, nil, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	save := func(islandID uint64, swampName name.Name, key string) {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), islandID, swampName)
+		assert.NoError(t, err)
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "content")
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	closedSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("purge").Swamp("closed")
+	openSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("purge").Swamp("open")
+	otherSwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("other").Swamp("swamp")
+	memorySwamp := name.New().Sanctuary(sanctuaryForQuickTest).Realm("memory").Swamp("swamp")
+
+	save(11, closedSwamp, "key")
+	save(12, otherSwamp, "key")
+	// wait until the swamps are written to the disk and closed
+	time.Sleep(2500 * time.Millisecond)
+	assert.Empty(t, hydraInterface.ListActiveSwamps(), "the swamps should be closed")
+
+	save(13, openSwamp, "key")
+	save(14, memorySwamp, "key")
+
+	swampNames := func(names []name.Name) []string {
+		var result []string
+		for _, swampName := range names {
+			result = append(result, swampName.Get())
+		}
+		return result
+	}
+
+	t.Run("should only list the matching swamps in a dry run", func(t *testing.T) {
+		destroyed, err := hydraInterface.DestroySwamps(context.Background(), name.New().Sanctuary(sanctuaryForQuickTest).Realm("purge").Swamp("*"), true, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{closedSwamp.Get(), openSwamp.Get()}, swampNames(destroyed))
+		isExist, err := hydraInterface.IsExistSwamp(11, closedSwamp)
+		assert.NoError(t, err)
+		assert.True(t, isExist)
+	})
+
+	t.Run("should destroy the matching swamps on the disk and in the memory", func(t *testing.T) {
+
+		var reported []string
+		destroyed, err := hydraInterface.DestroySwamps(context.Background(), name.New().Sanctuary(sanctuaryForQuickTest).Realm("purge").Swamp("*"), false, func(swampName name.Name) error {
+			reported = append(reported, swampName.Get())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{closedSwamp.Get(), openSwamp.Get()}, swampNames(destroyed))
+		assert.Equal(t, swampNames(destroyed), reported, "the function is called after every destroyed swamp")
+
+		for islandID, swampName := range map[uint64]name.Name{11: closedSwamp, 13: openSwamp} {
+			isExist, err := hydraInterface.IsExistSwamp(islandID, swampName)
+			assert.NoError(t, err)
+			assert.False(t, isExist, swampName.Get())
+		}
+
+		isExist, err := hydraInterface.IsExistSwamp(12, otherSwamp)
+		assert.NoError(t, err)
+		assert.True(t, isExist, "the swamps of the other realms are kept")
+
+	})
+
+	t.Run("should destroy the in-memory swamps by their exact names", func(t *testing.T) {
+		destroyed, err := hydraInterface.DestroySwamps(context.Background(), memorySwamp, false, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{memorySwamp.Get()}, swampNames(destroyed))
+		isExist, err := hydraInterface.IsExistSwamp(14, memorySwamp)
+		assert.NoError(t, err)
+		assert.False(t, isExist)
+	})
+
+}
+
+func TestHydra_GetNamespaceUsage(t *testing.T) {
+
+	settingsInterface := settings.NewWithRootPath(t.TempDir(), testMaxDepth, testMaxFolderPerLevel)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("memory").Swamp("*"), true, 5, nil, nil)
+	hydraInterface := New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+
+	save := func(islandID uint64, swampName name.Name, keys ...string) {
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), islandID, swampName)
+		assert.NoError(t, err)
+		for _, key := range keys {
+			treasureInterface := swampInterface.CreateTreasure(key)
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, "content")
+			treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+	}
+
+	save(21, name.New().Sanctuary(sanctuaryForQuickTest).Realm("users").Swamp("alex"), "a", "b")
+	save(22, name.New().Sanctuary(sanctuaryForQuickTest).Realm("users").Swamp("peter"), "a")
+	save(23, name.New().Sanctuary(sanctuaryForQuickTest).Realm("orders").Swamp("2024"), "a", "b", "c")
+	// wait until the swamps are written to the disk and closed
+	time.Sleep(2500 * time.Millisecond)
+	assert.Empty(t, hydraInterface.ListActiveSwamps(), "the swamps should be closed")
+
+	save(24, name.New().Sanctuary(sanctuaryForQuickTest).Realm("users").Swamp("open"), "a", "b", "c", "d")
+	save(25, name.New().Sanctuary(sanctuaryForQuickTest).Realm("memory").Swamp("cache"), "a")
+
+	t.Run("should group the closed and the open swamps by their realms", func(t *testing.T) {
+		usage, collectedAt, err := hydraInterface.GetNamespaceUsage(context.Background(), 0)
+		assert.NoError(t, err)
+		assert.False(t, collectedAt.IsZero())
+		assert.Len(t, usage, 3)
+
+		assert.Equal(t, "memory", usage[0].Realm)
+		assert.Equal(t, 1, usage[0].SwampCount)
+		assert.Equal(t, int64(1), usage[0].TreasureCount)
+		assert.Zero(t, usage[0].DiskBytes, "the in-memory swamps take no disk space")
+
+		assert.Equal(t, "orders", usage[1].Realm)
+		assert.Equal(t, 1, usage[1].SwampCount)
+		assert.Equal(t, int64(3), usage[1].TreasureCount, "the count of the closed swamp is recorded at its close")
+		assert.Positive(t, usage[1].DiskBytes)
+
+		assert.Equal(t, sanctuaryForQuickTest, usage[2].Sanctuary)
+		assert.Equal(t, "users", usage[2].Realm)
+		assert.Equal(t, 3, usage[2].SwampCount)
+		assert.Equal(t, int64(7), usage[2].TreasureCount)
+		assert.Zero(t, usage[2].UncountedSwamps)
+	})
+
+	t.Run("should return the cached usage younger than the max age", func(t *testing.T) {
+		first, firstCollectedAt, err := hydraInterface.GetNamespaceUsage(context.Background(), time.Hour)
+		assert.NoError(t, err)
+		save(26, name.New().Sanctuary(sanctuaryForQuickTest).Realm("invoices").Swamp("new"), "a")
+
+		cached, cachedCollectedAt, err := hydraInterface.GetNamespaceUsage(context.Background(), time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, first, cached)
+		assert.Equal(t, firstCollectedAt, cachedCollectedAt)
+
+		refreshed, _, err := hydraInterface.GetNamespaceUsage(context.Background(), 0)
+		assert.NoError(t, err)
+		assert.Len(t, refreshed, 4)
+	})
+
+}
+
 // hydra_test.go:690: Total time: 3.989516361s (1000000 op)
 // Average per op: 3.989µs
 func TestHydraInsertTiming(t *testing.T) {
@@ -677,6 +1555,7 @@ func TestHydraInsertTiming(t *testing.T) {
 	settingsInterface.RegisterPattern(
 		name.New().Sanctuary("test").Realm("*").Swamp("*"),
 		false, 1, fss,
+		nil,
 	)
 
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
@@ -719,6 +1598,7 @@ func TestHydraInsertTiming_InMemory(t *testing.T) {
 		true, // inMemory = true
 		1,
 		nil, // nincs szükség FileSystemSettings-re inMemory módban
+		nil,
 	)
 
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
@@ -760,6 +1640,7 @@ func TestHydraBulkInsertTiming_InMemory(t *testing.T) {
 		true, // inMemory = true
 		3500, // closeAfterIdleSec
 		nil,  // nincs fájlkorlát
+		nil,
 	)
 
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
@@ -805,6 +1686,7 @@ func TestHydraGetTiming(t *testing.T) {
 		false, // nem inMemory most, de lehetne az is
 		3500,
 		fss,
+		nil,
 	)
 
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
@@ -848,6 +1730,7 @@ func TestHydraBatchGetTiming(t *testing.T) {
 		true, // inMemory
 		3600,
 		nil,
+		nil,
 	)
 
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
@@ -888,6 +1771,7 @@ func TestHydraGetTiming_Parallel(t *testing.T) {
 		true,
 		3600,
 		nil,
+		nil,
 	)
 
 	hydraInterface := New(settingsInterface, elysiumInterface, lockerInterface, fsInterface)
@@ -952,6 +1836,7 @@ func TestHydraGetTiming_Parallel_MultiSwamp(t *testing.T) {
 			true, // inMemory
 			3600,
 			nil,
+			nil,
 		)
 	}
 
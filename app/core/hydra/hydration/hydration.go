@@ -0,0 +1,226 @@
+// Package hydration schedules the loading of the Swamps from the disk into the memory.
+//
+// A burst of requests touching thousands of cold Swamps would start thousands of parallel loads, which saturates
+// the disk and slows down every request, including the ones served from the memory. The scheduler limits the number
+// of the concurrent hydrations, and serves the waiting interactive reads before the background scans.
+package hydration
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Priority is the priority of a hydration in the queue of the scheduler
+type Priority int
+
+const (
+	// PriorityInteractive is the priority of the requests of the clients waiting for the answer. This is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is the priority of the scans and the maintenance jobs. They get a free slot only if no
+	// interactive hydration is waiting.
+	PriorityBackground
+)
+
+// String returns the name of the priority, used as a metric label
+func (p Priority) String() string {
+	if p == PriorityBackground {
+		return "background"
+	}
+	return "interactive"
+}
+
+// Scheduler limits the number of the concurrent hydrations
+type Scheduler interface {
+	// Acquire waits for a free hydration slot. The waiting interactive hydrations get the slot before the waiting
+	// background ones, and the hydrations with the same priority are served in the order of their arrival.
+	//
+	// The returned release function must be called when the hydration finished, to pass the slot to the next
+	// waiting hydration. Returns the error of the context if the context is done before the slot is acquired.
+	Acquire(ctx context.Context, priority Priority) (release func(), err error)
+	// Stats returns the current state and the counters of the scheduler
+	Stats() Stats
+}
+
+// Stats is the current state and the counters of the scheduler
+type Stats struct {
+	// Limit is the max number of the concurrent hydrations. 0 means unlimited
+	Limit int
+	// Active is the number of the running hydrations
+	Active int
+	// QueueDepth is the number of the hydrations waiting for a slot, per priority
+	QueueDepth map[Priority]int
+	// Hydrations is the number of the acquired slots since the start, per priority
+	Hydrations map[Priority]uint64
+	// WaitTime is the total time the hydrations waited for a slot, per priority
+	WaitTime map[Priority]time.Duration
+}
+
+type scheduler struct {
+	mu     sync.Mutex
+	limit  int
+	active int
+	// the waiting hydrations per priority, in the order of their arrival
+	queues map[Priority]*list.List
+
+	hydrations map[Priority]*atomic.Uint64
+	waitTime   map[Priority]*atomic.Int64
+}
+
+// waiter is a hydration waiting for a slot. The ready channel is closed when the slot is handed to the waiter.
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// priorities are the known priorities in the order of their service
+var priorities = []Priority{PriorityInteractive, PriorityBackground}
+
+// New creates a scheduler that runs at most limit hydrations at the same time. 0 or less means unlimited, so the
+// scheduler only collects the statistics.
+func New(limit int) Scheduler {
+
+	if limit < 0 {
+		limit = 0
+	}
+
+	s := &scheduler{
+		limit:      limit,
+		queues:     make(map[Priority]*list.List, len(priorities)),
+		hydrations: make(map[Priority]*atomic.Uint64, len(priorities)),
+		waitTime:   make(map[Priority]*atomic.Int64, len(priorities)),
+	}
+	for _, p := range priorities {
+		s.queues[p] = list.New()
+		s.hydrations[p] = &atomic.Uint64{}
+		s.waitTime[p] = &atomic.Int64{}
+	}
+
+	return s
+
+}
+
+func (s *scheduler) Acquire(ctx context.Context, priority Priority) (func(), error) {
+
+	if _, ok := s.queues[priority]; !ok {
+		priority = PriorityInteractive
+	}
+
+	started := time.Now()
+
+	s.mu.Lock()
+	// a free slot is taken immediately, if no one with the same or higher priority is waiting for it
+	if (s.limit == 0 || s.active < s.limit) && !s.hasWaiterBefore(priority) {
+		s.active++
+		s.mu.Unlock()
+		s.hydrations[priority].Add(1)
+		return s.releaseFunc(), nil
+	}
+	w := &waiter{ready: make(chan struct{})}
+	element := s.queues[priority].PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		s.hydrations[priority].Add(1)
+		s.waitTime[priority].Add(int64(time.Since(started)))
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if w.granted {
+			// the slot was handed over meanwhile, so it must be passed to the next waiter
+			s.mu.Unlock()
+			s.release()
+		} else {
+			s.queues[priority].Remove(element)
+			s.mu.Unlock()
+		}
+		s.waitTime[priority].Add(int64(time.Since(started)))
+		return nil, ctx.Err()
+	}
+
+}
+
+func (s *scheduler) Stats() Stats {
+
+	stats := Stats{
+		QueueDepth: make(map[Priority]int, len(priorities)),
+		Hydrations: make(map[Priority]uint64, len(priorities)),
+		WaitTime:   make(map[Priority]time.Duration, len(priorities)),
+	}
+
+	s.mu.Lock()
+	stats.Limit = s.limit
+	stats.Active = s.active
+	for _, p := range priorities {
+		stats.QueueDepth[p] = s.queues[p].Len()
+	}
+	s.mu.Unlock()
+
+	for _, p := range priorities {
+		stats.Hydrations[p] = s.hydrations[p].Load()
+		stats.WaitTime[p] = time.Duration(s.waitTime[p].Load())
+	}
+
+	return stats
+
+}
+
+// hasWaiterBefore returns true if a hydration with the same or a higher priority is waiting. The caller must hold
+// the mutex
+func (s *scheduler) hasWaiterBefore(priority Priority) bool {
+	for _, p := range priorities {
+		if s.queues[p].Len() > 0 {
+			return true
+		}
+		if p == priority {
+			break
+		}
+	}
+	return false
+}
+
+// releaseFunc returns the release function of an acquired slot. Calling it more than once releases the slot once
+func (s *scheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(s.release)
+	}
+}
+
+// release hands the slot to the first waiter with the highest priority, or frees it if no one is waiting
+func (s *scheduler) release() {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range priorities {
+		if front := s.queues[p].Front(); front != nil {
+			w := s.queues[p].Remove(front).(*waiter)
+			w.granted = true
+			close(w.ready)
+			return
+		}
+	}
+
+	s.active--
+
+}
+
+// priorityKey is the context key of the hydration priority of a request
+type priorityKey struct{}
+
+// WithPriority returns a context whose hydrations are scheduled with the given priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the hydration priority of the context. PriorityInteractive if the context has none
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityInteractive
+}
@@ -0,0 +1,140 @@
+package hydration
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+
+	t.Run("should not block without limit", func(t *testing.T) {
+
+		s := New(0)
+		var releases []func()
+		for i := 0; i < 100; i++ {
+			release, err := s.Acquire(context.Background(), PriorityBackground)
+			assert.NoError(t, err)
+			releases = append(releases, release)
+		}
+
+		stats := s.Stats()
+		assert.Equal(t, 0, stats.Limit)
+		assert.Equal(t, 100, stats.Active)
+		assert.Equal(t, uint64(100), stats.Hydrations[PriorityBackground])
+
+		for _, release := range releases {
+			release()
+		}
+		assert.Equal(t, 0, s.Stats().Active)
+
+	})
+
+	t.Run("should limit the concurrent hydrations", func(t *testing.T) {
+
+		s := New(2)
+		first, err := s.Acquire(context.Background(), PriorityInteractive)
+		assert.NoError(t, err)
+		_, err = s.Acquire(context.Background(), PriorityInteractive)
+		assert.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			release, err := s.Acquire(context.Background(), PriorityInteractive)
+			assert.NoError(t, err)
+			close(acquired)
+			release()
+		}()
+
+		assert.Eventually(t, func() bool {
+			return s.Stats().QueueDepth[PriorityInteractive] == 1
+		}, time.Second, time.Millisecond)
+
+		select {
+		case <-acquired:
+			t.Fatal("the third hydration should wait for a free slot")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		first()
+		// calling the release twice must not free an extra slot
+		first()
+
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("the third hydration should get the released slot")
+		}
+
+		assert.Eventually(t, func() bool {
+			return s.Stats().Active == 1
+		}, time.Second, time.Millisecond)
+		assert.Greater(t, s.Stats().WaitTime[PriorityInteractive], time.Duration(0))
+
+	})
+
+	t.Run("should serve the interactive hydrations before the background ones", func(t *testing.T) {
+
+		s := New(1)
+		release, err := s.Acquire(context.Background(), PriorityInteractive)
+		assert.NoError(t, err)
+
+		var mu sync.Mutex
+		var order []Priority
+		var wg sync.WaitGroup
+		acquire := func(priority Priority) {
+			defer wg.Done()
+			r, err := s.Acquire(context.Background(), priority)
+			assert.NoError(t, err)
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+			r()
+		}
+
+		wg.Add(2)
+		go acquire(PriorityBackground)
+		assert.Eventually(t, func() bool {
+			return s.Stats().QueueDepth[PriorityBackground] == 1
+		}, time.Second, time.Millisecond)
+		go acquire(PriorityInteractive)
+		assert.Eventually(t, func() bool {
+			return s.Stats().QueueDepth[PriorityInteractive] == 1
+		}, time.Second, time.Millisecond)
+
+		release()
+		wg.Wait()
+
+		assert.Equal(t, []Priority{PriorityInteractive, PriorityBackground}, order)
+		assert.Equal(t, 0, s.Stats().Active)
+
+	})
+
+	t.Run("should return the error of the cancelled context", func(t *testing.T) {
+
+		s := New(1)
+		release, err := s.Acquire(context.Background(), PriorityInteractive)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = s.Acquire(ctx, PriorityBackground)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 0, s.Stats().QueueDepth[PriorityBackground])
+
+		release()
+		assert.Equal(t, 0, s.Stats().Active)
+
+	})
+
+	t.Run("should read the priority from the context", func(t *testing.T) {
+		assert.Equal(t, PriorityInteractive, PriorityFromContext(context.Background()))
+		ctx := WithPriority(context.Background(), PriorityBackground)
+		assert.Equal(t, PriorityBackground, PriorityFromContext(ctx))
+		assert.Equal(t, "background", PriorityBackground.String())
+		assert.Equal(t, "interactive", PriorityInteractive.String())
+	})
+
+}
@@ -0,0 +1,137 @@
+// Package journal keeps the recent events of a Swamp in the memory.
+//
+// A subscriber that restarts after a crash or loses its connection misses the events of the Swamp while it is away.
+// Without the journal it has to read the whole Swamp again. With the journal it can ask for the events since the
+// time of the last event it processed, and continue from there.
+//
+// The journal is a ring buffer with a size limit and an optional retention time. It lives in the memory of the
+// server, so it starts empty after a restart of the server.
+package journal
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Journal is the event journal of one Swamp
+type Journal interface {
+	// Append adds the event to the end of the journal. The oldest events are dropped if the journal is full or they
+	// are older than the retention time.
+	Append(event *swamp.Event)
+	// Since returns the events with a newer EventTime than since (unix nano), in the order of their sequence.
+	//
+	// The ok is false if the journal can not guarantee that it has all events since the given time, because some of
+	// them were already dropped or the journal was started later. The caller must read the whole Swamp in this case.
+	Since(since int64) (events []*swamp.Event, ok bool)
+	// CoveredFrom returns the time (unix nano) since the journal has all events of the Swamp
+	CoveredFrom() int64
+	// Len returns the number of the events in the journal
+	Len() int
+}
+
+type journal struct {
+	mu        sync.Mutex
+	retention time.Duration
+	// events is the ring buffer of the events, the oldest event is at the head
+	events []*swamp.Event
+	head   int
+	count  int
+	// coveredFrom is the time since the journal has all events. It moves forward when an event is dropped
+	coveredFrom int64
+}
+
+// New creates an empty journal that keeps at most maxEvents events, for at most retention time. The retention 0
+// means the events are only dropped when the journal is full. The journal covers the events from its creation time.
+func New(maxEvents int, retention time.Duration) Journal {
+
+	if maxEvents < 1 {
+		maxEvents = 1
+	}
+
+	return &journal{
+		retention:   retention,
+		events:      make([]*swamp.Event, maxEvents),
+		coveredFrom: time.Now().UnixNano(),
+	}
+
+}
+
+func (j *journal) Append(event *swamp.Event) {
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.count == len(j.events) {
+		j.dropOldest()
+	}
+
+	j.events[(j.head+j.count)%len(j.events)] = event
+	j.count++
+
+	j.dropExpired(event.EventTime)
+
+}
+
+func (j *journal) Since(since int64) ([]*swamp.Event, bool) {
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.dropExpired(time.Now().UnixNano())
+
+	if since < j.coveredFrom {
+		return nil, false
+	}
+
+	var events []*swamp.Event
+	for i := 0; i < j.count; i++ {
+		if event := j.events[(j.head+i)%len(j.events)]; event.EventTime > since {
+			events = append(events, event)
+		}
+	}
+
+	// the concurrent writes of the swamp can append their events in a different order than their sequence
+	sort.Slice(events, func(a, b int) bool {
+		return events[a].Sequence < events[b].Sequence
+	})
+
+	return events, true
+
+}
+
+func (j *journal) CoveredFrom() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.coveredFrom
+}
+
+func (j *journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.count
+}
+
+// dropExpired drops the events older than the retention time. The caller must hold the mutex
+func (j *journal) dropExpired(now int64) {
+	if j.retention <= 0 {
+		return
+	}
+	limit := now - int64(j.retention)
+	for j.count > 0 && j.events[j.head].EventTime < limit {
+		j.dropOldest()
+	}
+}
+
+// dropOldest drops the oldest event, so the journal covers the events only after its time. The caller must hold
+// the mutex
+func (j *journal) dropOldest() {
+	oldest := j.events[j.head]
+	if oldest.EventTime > j.coveredFrom {
+		j.coveredFrom = oldest.EventTime
+	}
+	j.events[j.head] = nil
+	j.head = (j.head + 1) % len(j.events)
+	j.count--
+}
@@ -0,0 +1,82 @@
+package journal
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestJournal(t *testing.T) {
+
+	t.Run("should return the events since the given time", func(t *testing.T) {
+
+		j := New(10, 0)
+		start := j.CoveredFrom()
+		for i := 1; i <= 5; i++ {
+			j.Append(&swamp.Event{Sequence: uint64(i), EventTime: start + int64(i)})
+		}
+
+		events, ok := j.Since(start + 2)
+		assert.True(t, ok)
+		if assert.Len(t, events, 3) {
+			assert.Equal(t, uint64(3), events[0].Sequence)
+			assert.Equal(t, uint64(5), events[2].Sequence)
+		}
+
+		events, ok = j.Since(start + 5)
+		assert.True(t, ok)
+		assert.Empty(t, events)
+
+	})
+
+	t.Run("should not cover the time before its start", func(t *testing.T) {
+
+		j := New(10, 0)
+		events, ok := j.Since(j.CoveredFrom() - 1)
+		assert.False(t, ok)
+		assert.Nil(t, events)
+
+	})
+
+	t.Run("should drop the oldest events if the journal is full", func(t *testing.T) {
+
+		j := New(3, 0)
+		start := j.CoveredFrom()
+		for i := 1; i <= 5; i++ {
+			j.Append(&swamp.Event{Sequence: uint64(i), EventTime: start + int64(i)})
+		}
+
+		assert.Equal(t, 3, j.Len())
+		assert.Equal(t, start+2, j.CoveredFrom())
+
+		_, ok := j.Since(start + 1)
+		assert.False(t, ok)
+
+		events, ok := j.Since(start + 2)
+		assert.True(t, ok)
+		if assert.Len(t, events, 3) {
+			assert.Equal(t, uint64(3), events[0].Sequence)
+			assert.Equal(t, uint64(5), events[2].Sequence)
+		}
+
+	})
+
+	t.Run("should drop the events older than the retention", func(t *testing.T) {
+
+		j := New(10, time.Minute)
+		start := j.CoveredFrom()
+		j.Append(&swamp.Event{Sequence: 1, EventTime: start - int64(2*time.Minute)})
+		j.Append(&swamp.Event{Sequence: 2, EventTime: start + 1})
+
+		assert.Equal(t, 1, j.Len())
+		assert.Equal(t, start, j.CoveredFrom())
+		events, ok := j.Since(start)
+		assert.True(t, ok)
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, uint64(2), events[0].Sequence)
+		}
+
+	})
+
+}
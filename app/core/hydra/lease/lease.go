@@ -0,0 +1,84 @@
+// Package lease counts the deliveries of the leased Treasures of a Swamp.
+//
+// A queue-style Swamp hands its expired Treasures to the consumers with a lease: the Treasure is hidden until the
+// lease expires, and it is deleted only when the consumer acknowledges it. A Treasure that is never acknowledged is
+// delivered again and again, so the consumers need the number of its deliveries to move it to a dead-letter Swamp.
+//
+// The counters live in the memory of the server, so they start from zero after a restart of the server.
+package lease
+
+import (
+	"sync"
+)
+
+// Table is the delivery counter table of one Swamp
+type Table interface {
+	// Deliver records a new lease of the Treasure, and returns the number of its deliveries including this one.
+	//
+	// The count continues only if the Treasure still expires at the time set by its last lease or release, so a
+	// Treasure that was deleted and created again with the same key starts from one.
+	Deliver(key string, previousExpiration int64, leaseUntil int64) int32
+	// Release records that the consumer gave the Treasure back, and it becomes visible again at visibleAt
+	Release(key string, visibleAt int64)
+	// Remove forgets the Treasure, after it was acknowledged or moved to a dead-letter Swamp
+	Remove(key string)
+	// Len returns the number of the counted Treasures
+	Len() int
+}
+
+type table struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// entry is the delivery counter of a Treasure. The expiration is the expiration time of the Treasure set by its
+// last lease or release
+type entry struct {
+	deliveries int32
+	expiration int64
+}
+
+// New creates an empty delivery counter table
+func New() Table {
+	return &table{
+		entries: make(map[string]*entry),
+	}
+}
+
+func (t *table) Deliver(key string, previousExpiration int64, leaseUntil int64) int32 {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, ok := t.entries[key]
+	if !ok || e.expiration != previousExpiration {
+		e = &entry{}
+		t.entries[key] = e
+	}
+
+	e.deliveries++
+	e.expiration = leaseUntil
+
+	return e.deliveries
+
+}
+
+func (t *table) Release(key string, visibleAt int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[key]; ok {
+		e.expiration = visibleAt
+	}
+}
+
+func (t *table) Remove(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, key)
+}
+
+func (t *table) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
@@ -0,0 +1,35 @@
+package lease
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+
+	t.Run("should count the deliveries of the treasure", func(t *testing.T) {
+
+		table := New()
+		assert.Equal(t, int32(1), table.Deliver("key", 100, 200))
+		// the lease expired without acknowledgement
+		assert.Equal(t, int32(2), table.Deliver("key", 200, 300))
+		// the consumer released the treasure
+		table.Release("key", 350)
+		assert.Equal(t, int32(3), table.Deliver("key", 350, 400))
+
+		table.Remove("key")
+		assert.Equal(t, 0, table.Len())
+		assert.Equal(t, int32(1), table.Deliver("key", 400, 500))
+
+	})
+
+	t.Run("should restart the count of a new treasure with the same key", func(t *testing.T) {
+
+		table := New()
+		assert.Equal(t, int32(1), table.Deliver("key", 100, 200))
+		assert.Equal(t, int32(1), table.Deliver("key", 150, 300))
+		assert.Equal(t, 1, table.Len())
+
+	})
+
+}
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
 	"maps"
-	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -556,18 +555,19 @@ type Beacon interface {
 type beacon struct {
 	mu              sync.RWMutex
 	treasuresByKeys map[string]treasure.Treasure
-	// treasuresByOrder is used for storing Treasures in the order they were added to the beacon, or can be sorted by
-	// other sorters like expiration time, creation time, etc...
-	treasuresByOrder []treasure.Treasure
+	// treasuresByOrder is used for storing Treasures in the order they were added to the beacon, or in the order of
+	// the last sorter like expiration time, creation time, etc... The sorted beacon keeps its order while the treasures
+	// are added and deleted, so the beacon is not sorted again after every change.
+	treasuresByOrder *orderedList
 	// initialized is used for initializing the treasure only once
 	// We use the initialized field to determine whether anything has used the beacon before.
 	// Instantiation alone does not initialize the beacon, but its first use does. This is necessary because we need
 	// to know if there was anything that wants to use the beacon, as the beacon needs to be built only in this case.
 	initialized int32
 	// isOrdered true if we want to keep the treasures in treasuresByOrder too.
-	// The beacon will also use the treasuresByOrder slice for storing treasures. This becomes necessary when we want to sort
+	// The beacon will also use the treasuresByOrder list for storing treasures. This becomes necessary when we want to sort
 	// the treasures, whether based on the time they were added, or through more complex sorting such as by expiration date
-	// etc... Don't forget to set the value to True, otherwise the beacon won't handle the treasuresByOrder array, and it will
+	// etc... Don't forget to set the value to True, otherwise the beacon won't handle the treasuresByOrder list, and it will
 	// always be empty!
 	isOrdered bool
 }
@@ -589,7 +589,7 @@ func (b *beacon) GetAll() map[string]treasure.Treasure {
 type IterationType int
 
 const (
-	// IterationTypeOrdered iteration by the ordered slice
+	// IterationTypeOrdered iteration by the ordered list
 	IterationTypeOrdered IterationType = iota + 1
 	// IterationTypeKey iteration by the keys map
 	IterationTypeKey
@@ -611,10 +611,8 @@ func (b *beacon) Iterate(iterFunc func(treasureObj treasure.Treasure) bool, it I
 		}
 		return
 	} else if it == IterationTypeOrdered {
-		for _, treasureObj := range b.treasuresByOrder {
-			if !iterFunc(treasureObj) {
-				break
-			}
+		if b.isOrdered {
+			b.treasuresByOrder.each(iterFunc)
 		}
 		return
 	}
@@ -635,9 +633,9 @@ func (b *beacon) Get(key string) (d treasure.Treasure) {
 }
 
 // SetIsOrdered sets the isOrdered flag to true or false
-// The beacon will also use the treasuresByOrder slice for storing treasures. This becomes necessary when we want to sort
+// The beacon will also use the treasuresByOrder list for storing treasures. This becomes necessary when we want to sort
 // the treasures, whether based on the time they were added, or through more complex sorting such as by expiration date
-// etc... SetIsOrdered automatically copies the unordered treasures to the ordered slice (reset it, then copy the unordered treasures)
+// etc... SetIsOrdered automatically copies the unordered treasures to the ordered list (reset it, then copy the unordered treasures)
 func (b *beacon) SetIsOrdered(isOrdered bool) {
 
 	b.mu.Lock()
@@ -647,16 +645,17 @@ func (b *beacon) SetIsOrdered(isOrdered bool) {
 		return
 	}
 
-	// clear the isOrdered slice if we don't want to keep the ordered treasures
+	// clear the ordered list if we don't want to keep the ordered treasures
 	if !isOrdered {
-		b.treasuresByOrder = nil // reset the ordered treasures slice
+		b.treasuresByOrder = nil // reset the ordered treasures list
 		b.isOrdered = isOrdered  // set the isOrdered flag
 		return
 	}
 
 	// copy the unordered treasures to the ordered treasures
+	b.treasuresByOrder = newOrderedList(sorter{})
 	for _, treasureObj := range b.treasuresByKeys {
-		b.treasuresByOrder = append(b.treasuresByOrder, treasureObj)
+		b.treasuresByOrder.insert(treasureObj)
 	}
 
 	b.isOrdered = isOrdered
@@ -689,12 +688,19 @@ func (b *beacon) IsInitialized() bool {
 func (b *beacon) Reset() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	b.treasuresByKeys = make(map[string]treasure.Treasure)
-	b.treasuresByOrder = nil
+	b.clear()
 	atomic.StoreInt32(&b.initialized, 0)
 }
 
-// PushManyFromMap add elements to the main unordered map and the ordered slice too if,
+// clear removes all treasures from the beacon. The ordered beacon keeps its order for the new treasures.
+func (b *beacon) clear() {
+	b.treasuresByKeys = make(map[string]treasure.Treasure)
+	if b.isOrdered {
+		b.treasuresByOrder = newOrderedList(b.treasuresByOrder.sorter)
+	}
+}
+
+// PushManyFromMap add elements to the main unordered map and the ordered list too if,
 // the ordered list is enabled
 func (b *beacon) PushManyFromMap(treasures map[string]treasure.Treasure) {
 	b.mu.Lock()
@@ -703,12 +709,12 @@ func (b *beacon) PushManyFromMap(treasures map[string]treasure.Treasure) {
 	// add elements to the ordered treasure if there is any ordered treasures
 	if b.isOrdered {
 		for _, treasureObj := range treasures {
-			b.treasuresByOrder = append(b.treasuresByOrder, treasureObj)
+			b.treasuresByOrder.insert(treasureObj)
 		}
 	}
 }
 
-// Add adds a new element to the beacon
+// Add adds a new element to the beacon. The ordered beacon inserts it to its position in O(log n)
 func (b *beacon) Add(d treasure.Treasure) {
 	atomic.StoreInt32(&b.initialized, 1)
 	// add element if the key is not in the map
@@ -717,7 +723,7 @@ func (b *beacon) Add(d treasure.Treasure) {
 	if _, ok := b.treasuresByKeys[d.GetKey()]; !ok {
 		b.treasuresByKeys[d.GetKey()] = d
 		if b.isOrdered {
-			b.treasuresByOrder = append(b.treasuresByOrder, d)
+			b.treasuresByOrder.insert(d)
 		}
 	}
 }
@@ -734,15 +740,9 @@ func (b *beacon) Delete(key string) {
 		delete(b.treasuresByKeys, key)
 	}
 
-	//* delete from treasuresByOrder slice
-	//* delete from desc slice
+	//* delete from the ordered list
 	if b.isOrdered {
-		for index, treasureObj := range b.treasuresByOrder {
-			if treasureObj.GetKey() == key {
-				b.treasuresByOrder = append(b.treasuresByOrder[:index], b.treasuresByOrder[index+1:]...)
-				break
-			}
-		}
+		b.treasuresByOrder.remove(key)
 	}
 
 }
@@ -787,12 +787,7 @@ func (b *beacon) ShiftOne(key string) (d treasure.Treasure) {
 		delete(b.treasuresByKeys, key)
 
 		if b.isOrdered {
-			for indx, treasureObj := range b.treasuresByOrder {
-				if treasureObj.GetKey() == key {
-					b.treasuresByOrder = append(b.treasuresByOrder[:indx], b.treasuresByOrder[indx+1:]...)
-					break
-				}
-			}
+			b.treasuresByOrder.remove(key)
 		}
 	}
 	return
@@ -806,22 +801,19 @@ func (b *beacon) ShiftMany(howMany int) []treasure.Treasure {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if !b.isOrdered {
+		return nil
+	}
+
 	var shiftedTreasures []treasure.Treasure
-	var remainingTreasures []treasure.Treasure
-	counter := 0
-	for _, treasureObj := range b.treasuresByOrder {
-		if counter < howMany {
-			lockID := treasureObj.StartTreasureGuard(true)
-			clonedTreasure := treasureObj.Clone(lockID)
-			treasureObj.ReleaseTreasureGuard(lockID)
-			shiftedTreasures = append(shiftedTreasures, clonedTreasure)
-			delete(b.treasuresByKeys, treasureObj.GetKey())
-			counter++
-		} else {
-			remainingTreasures = append(remainingTreasures, treasureObj)
-		}
+	for _, treasureObj := range b.treasuresByOrder.slice(0, howMany) {
+		lockID := treasureObj.StartTreasureGuard(true)
+		clonedTreasure := treasureObj.Clone(lockID)
+		treasureObj.ReleaseTreasureGuard(lockID)
+		shiftedTreasures = append(shiftedTreasures, clonedTreasure)
+		delete(b.treasuresByKeys, treasureObj.GetKey())
+		b.treasuresByOrder.remove(treasureObj.GetKey())
 	}
-	b.treasuresByOrder = remainingTreasures
 	return shiftedTreasures
 
 }
@@ -833,23 +825,28 @@ func (b *beacon) ShiftExpired(howMany int) []treasure.Treasure {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	var shiftedTreasures []treasure.Treasure
-	var remainingTreasures []treasure.Treasure
+	if !b.isOrdered {
+		return nil
+	}
 
-	counter := 0
-	for _, treasureObj := range b.treasuresByOrder {
+	var shiftedTreasures []treasure.Treasure
+	b.treasuresByOrder.each(func(treasureObj treasure.Treasure) bool {
+		if len(shiftedTreasures) == howMany {
+			return false
+		}
 		lockerID := treasureObj.StartTreasureGuard(true)
-		if counter < howMany && treasureObj.GetExpirationTime() < time.Now().UTC().UnixNano() {
-			clonedTreasure := treasureObj.Clone(lockerID)
-			shiftedTreasures = append(shiftedTreasures, clonedTreasure)
-			delete(b.treasuresByKeys, treasureObj.GetKey())
-			counter++
-		} else {
-			remainingTreasures = append(remainingTreasures, treasureObj)
+		if treasureObj.GetExpirationTime() < time.Now().UTC().UnixNano() {
+			shiftedTreasures = append(shiftedTreasures, treasureObj.Clone(lockerID))
 		}
 		treasureObj.ReleaseTreasureGuard(lockerID)
+		return true
+	})
+
+	// the list is not modified during the iteration
+	for _, treasureObj := range shiftedTreasures {
+		delete(b.treasuresByKeys, treasureObj.GetKey())
+		b.treasuresByOrder.remove(treasureObj.GetKey())
 	}
-	b.treasuresByOrder = remainingTreasures
 	return shiftedTreasures
 
 }
@@ -862,17 +859,20 @@ func (b *beacon) CloneOrderedTreasures(thenReset bool) []treasure.Treasure {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	// clone the slice because we don't want to expose the internal slice
-	clone := make([]treasure.Treasure, len(b.treasuresByOrder))
-	for index, treasureObj := range b.treasuresByOrder {
-		lockerID := treasureObj.StartTreasureGuard(true)
-		clone[index] = treasureObj.Clone(lockerID)
-		treasureObj.ReleaseTreasureGuard(lockerID)
+	// clone the treasures because we don't want to expose the internal ones
+	var clone []treasure.Treasure
+	if b.isOrdered {
+		clone = make([]treasure.Treasure, 0, b.treasuresByOrder.length)
+		b.treasuresByOrder.each(func(treasureObj treasure.Treasure) bool {
+			lockerID := treasureObj.StartTreasureGuard(true)
+			clone = append(clone, treasureObj.Clone(lockerID))
+			treasureObj.ReleaseTreasureGuard(lockerID)
+			return true
+		})
 	}
 
 	if thenReset {
-		b.treasuresByOrder = nil
-		b.treasuresByKeys = make(map[string]treasure.Treasure)
+		b.clear()
 	}
 
 	return clone
@@ -895,14 +895,13 @@ func (b *beacon) CloneUnorderedTreasures(thenReset bool) map[string]treasure.Tre
 	}
 
 	if thenReset {
-		b.treasuresByOrder = nil
-		b.treasuresByKeys = make(map[string]treasure.Treasure)
+		b.clear()
 	}
 
 	return treasuresClone
 }
 
-// GetManyFromOrderPosition returns the elements with the given offset and limit
+// GetManyFromOrderPosition returns the elements with the given offset and limit. The position is found in O(log n)
 func (b *beacon) GetManyFromOrderPosition(from int, limit int) ([]treasure.Treasure, error) {
 
 	atomic.StoreInt32(&b.initialized, 1)
@@ -913,13 +912,10 @@ func (b *beacon) GetManyFromOrderPosition(from int, limit int) ([]treasure.Treas
 	if !b.isOrdered {
 		return nil, errors.New("beacon is not ordered")
 	}
-	if from > len(b.treasuresByOrder) {
+	if from > b.treasuresByOrder.length {
 		return nil, errors.New("from is greater than the number of elements in the beacon")
 	}
-	if from+limit > len(b.treasuresByOrder) {
-		return b.treasuresByOrder[from:], nil
-	}
-	return b.treasuresByOrder[from : from+limit], nil
+	return b.treasuresByOrder.slice(from, limit), nil
 
 }
 
@@ -938,19 +934,17 @@ func (b *beacon) GetManyFromKey(fromKey *string, limit *int32) ([]treasure.Treas
 		counterLimit = *limit
 	}
 
-	// get the treasures from the orderedTreasures
+	// start from the treasure of the fromKey, or from the first treasure if the fromKey is nil
+	start := b.treasuresByOrder.first()
+	if fromKey != nil {
+		start = b.treasuresByOrder.find(*fromKey)
+	}
+
 	var selectedTreasures []treasure.Treasure
 	counter := int32(0)
-	foundKey := false
-
-	for _, t := range b.treasuresByOrder {
-		// if the fromKey is not nil, skip the treasure until the fromKey is found
-		if !foundKey && (fromKey != nil && *fromKey != t.GetKey()) {
-			continue
-		}
-		foundKey = true
+	for n := start; n != nil; n = n.next[0] {
 		counter++
-		selectedTreasures = append(selectedTreasures, t)
+		selectedTreasures = append(selectedTreasures, n.treasure)
 		if counter == counterLimit {
 			break
 		}
@@ -971,28 +965,30 @@ func (b *beacon) FilterOrderedTreasures(filterFunc func(treasureObj treasure.Tre
 	}
 
 	var filteredTreasures []treasure.Treasure
-	counter := 0
-
-	for index, treasureObj := range b.treasuresByOrder {
-		if counter == howMany {
-			break
+	b.treasuresByOrder.each(func(treasureObj treasure.Treasure) bool {
+		if len(filteredTreasures) == howMany {
+			return false
 		}
 		if filterFunc(treasureObj) {
 			filteredTreasures = append(filteredTreasures, treasureObj)
-			// remove the item from the original slice
-			// and from the map too
-			if remove {
-				delete(b.treasuresByKeys, treasureObj.GetKey())
-				b.treasuresByOrder = append(b.treasuresByOrder[:index], b.treasuresByOrder[index+1:]...)
-			}
-			counter++
+		}
+		return true
+	})
+
+	// remove the items from the ordered list and from the map too
+	if remove {
+		for _, treasureObj := range filteredTreasures {
+			delete(b.treasuresByKeys, treasureObj.GetKey())
+			b.treasuresByOrder.remove(treasureObj.GetKey())
 		}
 	}
+
 	return filteredTreasures, nil
 }
 
-// SortByCreationTimeAsc sorts the orderedTreasures by the creation time ascending
-func (b *beacon) SortByCreationTimeAsc() error {
+// sortBy sorts the ordered treasures by the sorter, then the beacon keeps this order while the treasures are added
+// and deleted. If strict is true, the sort fails and the order is not changed if a value can not be read.
+func (b *beacon) sortBy(s sorter, strict bool) error {
 
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -1001,555 +997,195 @@ func (b *beacon) SortByCreationTimeAsc() error {
 		return errors.New("the beacon is not ordered")
 	}
 
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetCreatedAt() < b.treasuresByOrder[l].GetCreatedAt()
-	})
+	sortedList, err := b.treasuresByOrder.sorted(s, strict)
+	if err != nil {
+		return err
+	}
+	b.treasuresByOrder = sortedList
 
 	return nil
 
 }
 
+// SortByCreationTimeAsc sorts the orderedTreasures by the creation time ascending
+func (b *beacon) SortByCreationTimeAsc() error {
+	return b.sortBy(sorter{value: creationTimeValue}, false)
+}
+
 // SortByCreationTimeDesc sorts the orderedTreasures by the creation time descending
 func (b *beacon) SortByCreationTimeDesc() error {
-
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetCreatedAt() > b.treasuresByOrder[l].GetCreatedAt()
-	})
-	return nil
+	return b.sortBy(sorter{value: creationTimeValue, desc: true}, false)
 }
 
 // SortByKeyAsc sorts the orderedTreasures by the key ascending
 func (b *beacon) SortByKeyAsc() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetKey() < b.treasuresByOrder[l].GetKey()
-	})
-	return nil
+	return b.sortBy(sorter{value: keyValue}, false)
 }
 
 // SortByKeyDesc sorts the orderedTreasures by the key descending
 func (b *beacon) SortByKeyDesc() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetKey() > b.treasuresByOrder[l].GetKey()
-	})
-	return nil
+	return b.sortBy(sorter{value: keyValue, desc: true}, false)
 }
 
 func (b *beacon) SortByExpirationTimeAsc() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetExpirationTime() < b.treasuresByOrder[l].GetExpirationTime()
-	})
-	return nil
+	return b.sortBy(sorter{value: expirationTimeValue}, false)
 }
 
 func (b *beacon) SortByExpirationTimeDesc() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetExpirationTime() > b.treasuresByOrder[l].GetExpirationTime()
-	})
-	return nil
+	return b.sortBy(sorter{value: expirationTimeValue, desc: true}, false)
 }
+
 func (b *beacon) SortByUpdateTimeAsc() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetModifiedAt() < b.treasuresByOrder[l].GetModifiedAt()
-	})
-	return nil
+	return b.sortBy(sorter{value: updateTimeValue}, false)
 }
+
 func (b *beacon) SortByUpdateTimeDesc() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		return b.treasuresByOrder[k].GetModifiedAt() > b.treasuresByOrder[l].GetModifiedAt()
-	})
-	return nil
+	return b.sortBy(sorter{value: updateTimeValue, desc: true}, false)
 }
 
 func (b *beacon) SortByValueFloat32ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentFloat32()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentFloat32()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: float32Value}, false)
 }
+
 func (b *beacon) SortByValueFloat32DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentFloat32()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentFloat32()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: float32Value, desc: true}, false)
 }
+
 func (b *beacon) SortByValueFloat64ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentFloat64()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentFloat64()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: float64Value}, false)
 }
+
 func (b *beacon) SortByValueFloat64DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentFloat64()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentFloat64()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: float64Value, desc: true}, false)
 }
 
 func (b *beacon) SortByValueUint8ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint8()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint8()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint8Value}, false)
 }
+
 func (b *beacon) SortByValueUint8DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint8()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint8()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint8Value, desc: true}, false)
 }
 
 func (b *beacon) SortByValueUint16ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint16()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint16()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint16Value}, false)
 }
+
 func (b *beacon) SortByValueUint16DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint16()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint16()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint16Value, desc: true}, false)
 }
 
 func (b *beacon) SortByValueUint32ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint32()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint32()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint32Value}, false)
 }
+
 func (b *beacon) SortByValueUint32DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint32()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint32()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint32Value, desc: true}, false)
 }
 
 func (b *beacon) SortByValueUint64ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint64()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint64()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint64Value}, false)
 }
+
 func (b *beacon) SortByValueUint64DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentUint64()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentUint64()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: uint64Value, desc: true}, false)
 }
 
 func (b *beacon) SortByValueInt8ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentInt8()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentInt8()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: int8Value}, false)
 }
+
 func (b *beacon) SortByValueInt8DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentInt8()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentInt8()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: int8Value, desc: true}, false)
 }
+
 func (b *beacon) SortByValueInt16ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentInt16()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentInt16()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: int16Value}, false)
 }
+
 func (b *beacon) SortByValueInt16DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentInt16()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentInt16()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: int16Value, desc: true}, false)
 }
+
 func (b *beacon) SortByValueInt32ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentInt32()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentInt32()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: int32Value}, false)
 }
+
 func (b *beacon) SortByValueInt32DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentInt32()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentInt32()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+	return b.sortBy(sorter{value: int32Value, desc: true}, false)
 }
 
+// SortByValueInt64ASC sorts the orderedTreasures by the int64 content ascending. It fails if a content is not an int64
 func (b *beacon) SortByValueInt64ASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
+	if err := b.sortBy(sorter{value: int64Value}, true); err != nil {
+		return fmt.Errorf("cannot sort ascending: %w", err)
 	}
+	return nil
+}
 
-	// Extract values in advance, and throw an error if any of them are invalid.
-	type item struct {
-		value int64
-		t     treasure.Treasure
+// SortByValueInt64DESC sorts the orderedTreasures by the int64 content descending. It fails if a content is not an int64
+func (b *beacon) SortByValueInt64DESC() error {
+	if err := b.sortBy(sorter{value: int64Value, desc: true}, true); err != nil {
+		return fmt.Errorf("cannot sort descending: %w", err)
 	}
+	return nil
+}
 
-	items := make([]item, 0, len(b.treasuresByOrder))
-	for i, t := range b.treasuresByOrder {
-		v, err := t.GetContentInt64()
-		if err != nil {
-			return fmt.Errorf("cannot sort ascending: index %d, key %q is not an int64: %w", i, t.GetKey(), err)
-		}
-		items = append(items, item{value: v, t: t})
-	}
+func (b *beacon) SortByValueStringASC() error {
+	return b.sortBy(sorter{value: stringValue}, false)
+}
 
-	// ordering the items by value
-	sort.SliceStable(items, func(i, j int) bool {
-		return items[i].value < items[j].value
-	})
+func (b *beacon) SortByValueStringDESC() error {
+	return b.sortBy(sorter{value: stringValue, desc: true}, false)
+}
 
-	// load back all ordered items
-	for i, it := range items {
-		b.treasuresByOrder[i] = it.t
-	}
+// -- the order values of the sorters ----------------------------------------------------------------------------------
 
-	return nil
+func keyValue(t treasure.Treasure) (orderValue, error) {
+	return orderValue{text: t.GetKey()}, nil
 }
 
-func (b *beacon) SortByValueInt64DESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+func creationTimeValue(t treasure.Treasure) (orderValue, error) {
+	return orderValue{number: t.GetCreatedAt()}, nil
+}
 
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
+func updateTimeValue(t treasure.Treasure) (orderValue, error) {
+	return orderValue{number: t.GetModifiedAt()}, nil
+}
 
-	type item struct {
-		value int64
-		t     treasure.Treasure
-	}
+func expirationTimeValue(t treasure.Treasure) (orderValue, error) {
+	return orderValue{number: t.GetExpirationTime()}, nil
+}
 
-	items := make([]item, 0, len(b.treasuresByOrder))
-	for i, t := range b.treasuresByOrder {
-		v, err := t.GetContentInt64()
-		if err != nil {
-			return fmt.Errorf("cannot sort descending: index %d, key %q is not an int64: %w", i, t.GetKey(), err)
-		}
-		items = append(items, item{value: v, t: t})
-	}
+func int8Value(t treasure.Treasure) (orderValue, error)  { return signedValue(t.GetContentInt8()) }
+func int16Value(t treasure.Treasure) (orderValue, error) { return signedValue(t.GetContentInt16()) }
+func int32Value(t treasure.Treasure) (orderValue, error) { return signedValue(t.GetContentInt32()) }
+func int64Value(t treasure.Treasure) (orderValue, error) { return signedValue(t.GetContentInt64()) }
 
-	sort.SliceStable(items, func(i, j int) bool {
-		return items[i].value > items[j].value
-	})
+func uint8Value(t treasure.Treasure) (orderValue, error)  { return unsignedValue(t.GetContentUint8()) }
+func uint16Value(t treasure.Treasure) (orderValue, error) { return unsignedValue(t.GetContentUint16()) }
+func uint32Value(t treasure.Treasure) (orderValue, error) { return unsignedValue(t.GetContentUint32()) }
+func uint64Value(t treasure.Treasure) (orderValue, error) { return unsignedValue(t.GetContentUint64()) }
 
-	for i, it := range items {
-		b.treasuresByOrder[i] = it.t
-	}
+func float32Value(t treasure.Treasure) (orderValue, error) {
+	value, err := t.GetContentFloat32()
+	return orderValue{float: float64(value)}, err
+}
 
-	return nil
+func float64Value(t treasure.Treasure) (orderValue, error) {
+	value, err := t.GetContentFloat64()
+	return orderValue{float: value}, err
 }
 
-func (b *beacon) SortByValueStringASC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentString()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentString()
-		if err != nil {
-			return false
-		}
-		return kVal < lVal
-	})
-	return nil
+func stringValue(t treasure.Treasure) (orderValue, error) {
+	value, err := t.GetContentString()
+	return orderValue{text: value}, err
 }
-func (b *beacon) SortByValueStringDESC() error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	if !b.isOrdered {
-		return errors.New("the beacon is not ordered")
-	}
-	sort.Slice(b.treasuresByOrder, func(k, l int) bool {
-		kVal, err := b.treasuresByOrder[k].GetContentString()
-		if err != nil {
-			return false
-		}
-		lVal, err := b.treasuresByOrder[l].GetContentString()
-		if err != nil {
-			return false
-		}
-		return kVal > lVal
-	})
-	return nil
+
+func signedValue[T int8 | int16 | int32 | int64](value T, err error) (orderValue, error) {
+	return orderValue{number: int64(value)}, err
+}
+
+func unsignedValue[T uint8 | uint16 | uint32 | uint64](value T, err error) (orderValue, error) {
+	return orderValue{unsigned: uint64(value)}, err
 }
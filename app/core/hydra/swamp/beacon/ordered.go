@@ -0,0 +1,297 @@
+package beacon
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"math/rand/v2"
+	"strings"
+)
+
+// maxListLevel is the maximum number of the levels of the ordered list. With the 1/4 promotion probability it is
+// enough for far more treasures than a swamp can hold
+const maxListLevel = 32
+
+// orderValue is the value a treasure is ordered by. A beacon uses only one of the fields, the others are zero
+type orderValue struct {
+	// invalid is true if the value could not be read from the treasure, e.g. its content has an other type. These
+	// treasures are ordered after the valid ones in both directions
+	invalid  bool
+	number   int64
+	unsigned uint64
+	float    float64
+	text     string
+}
+
+func (v orderValue) compare(other orderValue) int {
+	if c := cmp.Compare(v.number, other.number); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.unsigned, other.unsigned); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.float, other.float); c != 0 {
+		return c
+	}
+	return strings.Compare(v.text, other.text)
+}
+
+// sorter is the order of the ordered treasures
+type sorter struct {
+	// value reads the order value of the treasure. Nil means the treasures are kept in the order they were added
+	value func(t treasure.Treasure) (orderValue, error)
+	desc  bool
+}
+
+// compare orders the treasures by their values, then by their keys, so every treasure has a fixed position
+func (s sorter) compare(aValue orderValue, aKey string, bValue orderValue, bKey string) int {
+	if aValue.invalid != bValue.invalid {
+		if aValue.invalid {
+			return 1
+		}
+		return -1
+	}
+	c := aValue.compare(bValue)
+	if s.desc {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(aKey, bKey)
+}
+
+// orderedList is an indexable skip list of the treasures. It keeps the treasures sorted while they are inserted and
+// removed in O(log n), and it finds the treasure of a position in O(log n), so a page of k treasures is read in
+// O(log n + k). The width of a link is the number of the treasures it steps over, this makes the positions
+// searchable.
+type orderedList struct {
+	head   *listNode
+	level  int
+	length int
+	sorter sorter
+	// values are the order values of the treasures when they were inserted. The value of a modified treasure can
+	// differ from it, so its node is found by the stored value
+	values map[string]orderValue
+	// sequence orders the treasures by their insertion if the list has no order value
+	sequence int64
+}
+
+type listNode struct {
+	key      string
+	treasure treasure.Treasure
+	value    orderValue
+	next     []*listNode
+	width    []int
+}
+
+func newOrderedList(s sorter) *orderedList {
+	return &orderedList{
+		head: &listNode{
+			next:  make([]*listNode, maxListLevel),
+			width: make([]int, maxListLevel),
+		},
+		level:  1,
+		sorter: s,
+		values: make(map[string]orderValue),
+	}
+}
+
+// orderValueOf reads the order value of the treasure
+func (l *orderedList) orderValueOf(t treasure.Treasure) (orderValue, error) {
+	if l.sorter.value == nil {
+		l.sequence++
+		return orderValue{number: l.sequence}, nil
+	}
+	value, err := l.sorter.value(t)
+	value.invalid = err != nil
+	return value, err
+}
+
+// insert adds the treasure to its position. The treasure already in the list is moved to its new position.
+func (l *orderedList) insert(t treasure.Treasure) {
+	key := t.GetKey()
+	if _, ok := l.values[key]; ok {
+		l.remove(key)
+	}
+	value, _ := l.orderValueOf(t)
+	l.insertValue(key, t, value)
+}
+
+// insertValue adds the treasure of a new key to the position of the value
+func (l *orderedList) insertValue(key string, t treasure.Treasure, value orderValue) {
+
+	// update holds the last node before the new one on each level, rank holds the position of that node
+	var update [maxListLevel]*listNode
+	var rank [maxListLevel]int
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && l.sorter.compare(x.next[i].value, x.next[i].key, value, key) < 0 {
+			rank[i] += x.width[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	level := randomListLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			l.head.width[i] = l.length
+		}
+		l.level = level
+	}
+
+	n := &listNode{key: key, treasure: t, value: value, next: make([]*listNode, level), width: make([]int, level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+		n.width[i] = update[i].width[i] - (rank[0] - rank[i])
+		update[i].width[i] = rank[0] - rank[i] + 1
+	}
+	// the higher links step over the new node
+	for i := level; i < l.level; i++ {
+		update[i].width[i]++
+	}
+
+	l.length++
+	l.values[key] = value
+
+}
+
+// remove removes the treasure of the key, and returns it. It returns nil if the key is not in the list
+func (l *orderedList) remove(key string) treasure.Treasure {
+
+	value, ok := l.values[key]
+	if !ok {
+		return nil
+	}
+
+	var update [maxListLevel]*listNode
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && l.sorter.compare(x.next[i].value, x.next[i].key, value, key) < 0 {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	x = x.next[0]
+	if x == nil || x.key != key {
+		return nil
+	}
+
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i] == x {
+			update[i].width[i] += x.width[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].width[i]--
+		}
+	}
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+
+	l.length--
+	delete(l.values, key)
+	return x.treasure
+
+}
+
+// find returns the node of the key, or nil if the key is not in the list
+func (l *orderedList) find(key string) *listNode {
+
+	value, ok := l.values[key]
+	if !ok {
+		return nil
+	}
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && l.sorter.compare(x.next[i].value, x.next[i].key, value, key) < 0 {
+			x = x.next[i]
+		}
+	}
+
+	if x = x.next[0]; x != nil && x.key == key {
+		return x
+	}
+	return nil
+
+}
+
+// at returns the node of the position, or nil if the position is out of the list
+func (l *orderedList) at(position int) *listNode {
+
+	if position < 0 || position >= l.length {
+		return nil
+	}
+
+	// the head is at rank 0, so the node of the position is at rank position+1
+	target := position + 1
+	traversed := 0
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.width[i] <= target {
+			traversed += x.width[i]
+			x = x.next[i]
+		}
+		if traversed == target {
+			return x
+		}
+	}
+	return nil
+
+}
+
+// first returns the first node, or nil if the list is empty
+func (l *orderedList) first() *listNode {
+	return l.head.next[0]
+}
+
+// slice returns the treasures from the position, at most limit treasures
+func (l *orderedList) slice(from int, limit int) []treasure.Treasure {
+	treasures := make([]treasure.Treasure, 0, max(min(limit, l.length-from), 0))
+	for n := l.at(from); n != nil && len(treasures) < limit; n = n.next[0] {
+		treasures = append(treasures, n.treasure)
+	}
+	return treasures
+}
+
+// each calls the function with the treasures in their order, until the function returns false
+func (l *orderedList) each(fn func(t treasure.Treasure) bool) {
+	for n := l.first(); n != nil; n = n.next[0] {
+		if !fn(n.treasure) {
+			return
+		}
+	}
+}
+
+// sorted returns a new list with the same treasures in the order of the sorter. If strict is true, the treasures
+// whose value can not be read fail the sort, otherwise they are ordered after the others
+func (l *orderedList) sorted(s sorter, strict bool) (*orderedList, error) {
+	sortedList := newOrderedList(s)
+	position := 0
+	for n := l.first(); n != nil; n = n.next[0] {
+		value, err := sortedList.orderValueOf(n.treasure)
+		if err != nil && strict {
+			return nil, fmt.Errorf("index %d, key %q: %w", position, n.key, err)
+		}
+		sortedList.insertValue(n.key, n.treasure, value)
+		position++
+	}
+	return sortedList, nil
+}
+
+// randomListLevel returns the level of a new node, every level is reached with 1/4 probability from the lower one
+func randomListLevel() int {
+	level := 1
+	for level < maxListLevel && rand.Uint32()&3 == 0 {
+		level++
+	}
+	return level
+}
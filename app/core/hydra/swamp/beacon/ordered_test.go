@@ -0,0 +1,174 @@
+package beacon
+
+import (
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func newInt64Treasure(key string, value int64) treasure.Treasure {
+	treasureInterface := treasure.New(MySaveFunction)
+	guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+	treasureInterface.BodySetKey(guardID, key)
+	treasureInterface.SetContentInt64(guardID, value)
+	treasureInterface.ReleaseTreasureGuard(guardID)
+	return treasureInterface
+}
+
+// keysOf returns the keys of the treasures in their order
+func keysOf(treasures []treasure.Treasure) []string {
+	keys := make([]string, 0, len(treasures))
+	for _, treasureObj := range treasures {
+		keys = append(keys, treasureObj.GetKey())
+	}
+	return keys
+}
+
+func TestOrderedList(t *testing.T) {
+
+	t.Run("should keep the order of the inserted and removed treasures", func(t *testing.T) {
+
+		l := newOrderedList(sorter{value: int64Value})
+		values := map[string]int64{}
+		for i := 0; i < 2000; i++ {
+			key := fmt.Sprintf("key-%d", rand.IntN(500))
+			if rand.IntN(4) == 0 {
+				l.remove(key)
+				delete(values, key)
+				continue
+			}
+			values[key] = rand.Int64N(100)
+			l.insert(newInt64Treasure(key, values[key]))
+		}
+
+		expected := make([]string, 0, len(values))
+		for key := range values {
+			expected = append(expected, key)
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			if values[expected[i]] != values[expected[j]] {
+				return values[expected[i]] < values[expected[j]]
+			}
+			return expected[i] < expected[j]
+		})
+
+		require.Equal(t, len(expected), l.length)
+		assert.Equal(t, expected, keysOf(l.slice(0, l.length)))
+		for position := 0; position < l.length; position += 37 {
+			assert.Equal(t, expected[position], l.at(position).key, "position %d", position)
+			assert.Equal(t, expected[position:min(position+5, len(expected))], keysOf(l.slice(position, 5)))
+		}
+		assert.Nil(t, l.at(l.length))
+		assert.Empty(t, l.slice(l.length, 10))
+
+	})
+
+	t.Run("should order the treasures descending and put the invalid values last", func(t *testing.T) {
+
+		l := newOrderedList(sorter{value: int64Value, desc: true})
+		invalid := treasure.New(MySaveFunction)
+		guardID := invalid.StartTreasureGuard(true, guard.BodyAuthID)
+		invalid.BodySetKey(guardID, "text")
+		invalid.SetContentString(guardID, "not a number")
+		invalid.ReleaseTreasureGuard(guardID)
+
+		l.insert(invalid)
+		l.insert(newInt64Treasure("one", 1))
+		l.insert(newInt64Treasure("three", 3))
+		l.insert(newInt64Treasure("two", 2))
+
+		assert.Equal(t, []string{"three", "two", "one", "text"}, keysOf(l.slice(0, 10)))
+		assert.Equal(t, "two", l.find("two").key)
+		assert.Nil(t, l.find("four"))
+
+		_, err := l.sorted(sorter{value: int64Value}, true)
+		assert.ErrorContains(t, err, `index 3, key "text"`)
+
+	})
+
+	t.Run("should move the modified treasure to its new position", func(t *testing.T) {
+
+		l := newOrderedList(sorter{value: int64Value})
+		moving := newInt64Treasure("moving", 1)
+		l.insert(moving)
+		l.insert(newInt64Treasure("middle", 5))
+
+		guardID := moving.StartTreasureGuard(true)
+		moving.SetContentInt64(guardID, 10)
+		moving.ReleaseTreasureGuard(guardID)
+
+		// the node is found by the value of the insertion, not by the new content
+		l.insert(moving)
+		assert.Equal(t, []string{"middle", "moving"}, keysOf(l.slice(0, 10)))
+		assert.Equal(t, 2, l.length)
+
+	})
+
+}
+
+// BenchmarkBeacon_Add compares the sorted insert of the ordered list with the previous implementation of the beacon,
+// which appended the treasure to a slice and sorted the whole slice again after every insert.
+func BenchmarkBeacon_Add(b *testing.B) {
+
+	const size = 1_000_000
+	treasures := make([]treasure.Treasure, size)
+	for i := range treasures {
+		treasures[i] = newInt64Treasure(fmt.Sprintf("key-%d", i), rand.Int64())
+	}
+
+	b.Run("ordered list", func(b *testing.B) {
+		beaconInterface := New()
+		beaconInterface.SetIsOrdered(true)
+		for _, treasureObj := range treasures {
+			beaconInterface.Add(treasureObj)
+		}
+		require.NoError(b, beaconInterface.SortByValueInt64ASC())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			treasureObj := newInt64Treasure("new", rand.Int64())
+			beaconInterface.Add(treasureObj)
+			beaconInterface.Delete("new")
+		}
+	})
+
+	b.Run("sorted slice", func(b *testing.B) {
+		ordered := slices.Clone(treasures)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ordered = append(ordered, newInt64Treasure("new", rand.Int64()))
+			sort.Slice(ordered, func(k, l int) bool {
+				kValue, _ := ordered[k].GetContentInt64()
+				lValue, _ := ordered[l].GetContentInt64()
+				return kValue < lValue
+			})
+			ordered = slices.DeleteFunc(ordered, func(t treasure.Treasure) bool { return t.GetKey() == "new" })
+		}
+	})
+
+}
+
+// BenchmarkBeacon_GetManyFromOrderPosition reads a page from the middle of a beacon with 1M treasures
+func BenchmarkBeacon_GetManyFromOrderPosition(b *testing.B) {
+
+	const size = 1_000_000
+	beaconInterface := New()
+	beaconInterface.SetIsOrdered(true)
+	for i := 0; i < size; i++ {
+		beaconInterface.Add(newInt64Treasure(fmt.Sprintf("key-%d", i), rand.Int64()))
+	}
+	require.NoError(b, beaconInterface.SortByValueInt64ASC())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := beaconInterface.GetManyFromOrderPosition(size/2, 100); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+}
@@ -6,30 +6,59 @@
 package chronicler
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/hydraide/hydraide/app/core/compressor"
 	"github.com/hydraide/hydraide/app/core/filesystem"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/beacon"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keydirectory"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keyfilter"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
 	"github.com/hydraide/hydraide/app/name"
 	"log/slog"
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 )
 
 type Chronicler interface {
 	Write(treasures []treasure.Treasure)
-	Load(indexObj beacon.Beacon)
+	// Load loads the treasures of the swamp from the filesystem to the indexObj, and the shadow-deleted treasures to
+	// the shadowIndexObj
+	Load(indexObj beacon.Beacon, shadowIndexObj beacon.Beacon)
+	// GetLoadError returns the error of the last Load, e.g. a wrapped filesystem.ErrCorruptedFile if a corrupted
+	// file is found and the filesystem does not skip the corrupted files. Nil if the load was successful.
+	GetLoadError() error
+	// Compact rewrites the chunk files with less live records than minLiveRatio into as few new files as possible,
+	// without the deleted treasures, and deletes the old files. The actual file is never compacted, because the new
+	// treasures are still written to it.
+	Compact(minLiveRatio float64) (CompactionResult, error)
+	// GetSize returns the size of the chunk files of the swamp on the disk. The actual file is not counted, because
+	// its deleted treasures can not be removed by the compaction, so it is not the size the swamp can be reduced to.
+	GetSize() (int64, error)
+	// SetFsyncPolicy sets when the written files are flushed to the disk. The interval is the min time between two
+	// flushes of the setting.FsyncInterval policy.
+	SetFsyncPolicy(policy setting.FsyncPolicy, interval time.Duration)
+	// Sync flushes the files written since the last flush to the disk, whatever the policy is. Called when the swamp
+	// closes, so the files of the setting.FsyncInterval policy are not left in the page cache.
+	Sync()
 	CreateDirectoryIfNotExists()
 	Destroy()
 	GetSwampAbsPath() string
 	IsFilesystemInitiated() bool
 	RegisterSaveFunction(swampSaveFunction func(t treasure.Treasure, guardID guard.ID) treasure.TreasureStatus)
 	DontSendFilePointer() // if we don't want to send the file pointer to the swamp, because it will be closed soon
+	// GetUnsentFileNames returns the file names of the treasures written since DontSendFilePointer, by their keys,
+	// because the closing swamp does not know where its last written treasures are
+	GetUnsentFileNames() map[string]string
 	// RegisterFilePointerFunction egy filepointer callback funkciót regisztrálhat a swamp
 	RegisterFilePointerFunction(filePointerFunction func(event []*FileNameEvent) error)
 }
@@ -39,6 +68,14 @@ type FileNameEvent struct {
 	FileName    string
 }
 
+// CompactionResult summarizes a compaction of the swamp's chunk files
+type CompactionResult struct {
+	CompactedFiles   int   // the number of the rewritten and deleted files
+	WrittenFiles     int   // the number of the new files the live treasures are written to
+	RemovedTreasures int   // the number of the deleted treasures removed from the files
+	ReclaimedBytes   int64 // the size of the deleted files minus the size of the new files
+}
+
 const (
 	SnappyCompressionPercent = 0.36 // the compression rate of the snappy compression method
 	ActualFileKeyInMeta      = "actual"
@@ -49,19 +86,29 @@ type chronicler struct {
 	swampName                   name.Name
 	swampDataFolderPath         string // absolute path, where the .actual file is located
 	maxFileSize                 int
+	maxFileSizeBytes            int64 // the max file size on the disk, without the compression overload
 	modifiedTreasuresForWrite   map[string]map[string]treasure.Treasure
 	newTreasuresForWrite        []treasure.Treasure
 	compressionMethod           compressor.Type
 	sanctuaryAbsPath            string // the path of the hydra, where the swamp is located
 	filesystemInitiated         bool   // true if the directory is initiated
 	swampSaveFunction           func(t treasure.Treasure, guardID guard.ID) treasure.TreasureStatus
-	dontSendFilePointer         bool // true if we don't want to send the file pointer to the swamp, because it will be closed soon
-	compressedFolderExists      bool // true if the compressed folder exists
+	dontSendFilePointer         bool              // true if we don't want to send the file pointer to the swamp, because it will be closed soon
+	unsentFileNames             map[string]string // the file names of the treasures written since dontSendFilePointer
+	compressedFolderExists      bool              // true if the compressed folder exists
 	filePointerCallbackFunction func(event []*FileNameEvent) error
 	filesystemInterface         filesystem.Filesystem
 	compressorInterface         compressor.Compressor
 	metadataInterface           metadata.Metadata
 	maxDepth                    int
+	loadError                   error
+	compactedFiles              map[string]struct{} // the files deleted by the compaction since the load
+	relocatedTreasures          map[string]string   // the new file names of the treasures moved by the compaction
+	fsyncPolicy                 setting.FsyncPolicy
+	fsyncInterval               time.Duration
+	unsyncedFiles               []string    // the files written or deleted since the last flush
+	lastSync                    time.Time   // the time of the last flush of the interval policy
+	syncTimer                   *time.Timer // flushes the files of the interval policy if no write flushes them in time
 }
 
 // New creates new filesystem for a swamp
@@ -70,11 +117,13 @@ func New(swampDataFolderPath string, maxFileSize int64, maxDepth int, filesystem
 	fsObj := &chronicler{
 		swampDataFolderPath:       swampDataFolderPath,
 		maxFileSize:               calculateOverloadSize(maxFileSize),
+		maxFileSizeBytes:          maxFileSize,
 		modifiedTreasuresForWrite: make(map[string]map[string]treasure.Treasure),
 		compressionMethod:         compressor.Snappy, // we used the Snappy compression method by default
 		filesystemInterface:       filesystemInterface,
 		metadataInterface:         metaInterface,
 		maxDepth:                  maxDepth,
+		fsyncPolicy:               setting.FsyncNever,
 	}
 
 	fsObj.compressorInterface = compressor.New(fsObj.compressionMethod)
@@ -90,6 +139,12 @@ func (c *chronicler) DontSendFilePointer() {
 	c.dontSendFilePointer = true
 }
 
+func (c *chronicler) GetUnsentFileNames() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.unsentFileNames
+}
+
 func (c *chronicler) RegisterFilePointerFunction(filePointerFunction func(event []*FileNameEvent) error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -109,6 +164,79 @@ func (c *chronicler) GetSwampAbsPath() string {
 	return c.swampDataFolderPath
 }
 
+// SetFsyncPolicy sets when the written files are flushed to the disk
+func (c *chronicler) SetFsyncPolicy(policy setting.FsyncPolicy, interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fsyncPolicy = policy.Or(setting.FsyncNever)
+	c.fsyncInterval = interval
+}
+
+// Sync flushes the files written since the last flush to the disk
+func (c *chronicler) Sync() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncFiles()
+}
+
+// markUnsynced registers the written or deleted file for the next flush, unless the operating system flushes it
+func (c *chronicler) markUnsynced(filePath string) {
+	if c.fsyncPolicy == setting.FsyncNever || slices.Contains(c.unsyncedFiles, filePath) {
+		return
+	}
+	c.unsyncedFiles = append(c.unsyncedFiles, filePath)
+}
+
+// syncIfDue flushes the files by the policy after a write. The interval policy flushes them if the interval elapsed
+// since the last flush, otherwise a timer flushes them when it elapses, so they are flushed in time even if the
+// swamp is not written again.
+func (c *chronicler) syncIfDue() {
+
+	if len(c.unsyncedFiles) == 0 {
+		return
+	}
+
+	switch c.fsyncPolicy {
+	case setting.FsyncAlways:
+		c.syncFiles()
+	case setting.FsyncInterval:
+		wait := c.fsyncInterval - time.Since(c.lastSync)
+		if wait <= 0 {
+			c.syncFiles()
+			return
+		}
+		if c.syncTimer == nil {
+			c.syncTimer = time.AfterFunc(wait, c.Sync)
+		}
+	default:
+		// the operating system flushes the files
+	}
+
+}
+
+// syncFiles flushes the unsynced files to the disk. The failed flush is not retried, because the operating system
+// may drop the dirty pages of a file after a failed flush, so a successful retry would not mean the data is durable.
+func (c *chronicler) syncFiles() {
+
+	if c.syncTimer != nil {
+		c.syncTimer.Stop()
+		c.syncTimer = nil
+	}
+
+	c.lastSync = time.Now()
+
+	if len(c.unsyncedFiles) == 0 {
+		return
+	}
+
+	if err := c.filesystemInterface.SyncFiles(c.unsyncedFiles...); err != nil {
+		slog.Error("can not flush the files of the swamp to the disk", "swamp folder", c.swampDataFolderPath, "error", err)
+	}
+
+	c.unsyncedFiles = nil
+
+}
+
 // CreateDirectoryIfNotExists creates the directory for the swamp if it is not exists
 // the create method separated, because the Hydra can call Destroy method separately, so the New method can not create
 // the swamp directory itself
@@ -133,6 +261,13 @@ func (c *chronicler) Destroy() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// the files are deleted, so there is nothing to flush
+	if c.syncTimer != nil {
+		c.syncTimer.Stop()
+		c.syncTimer = nil
+	}
+	c.unsyncedFiles = nil
+
 	if err := c.filesystemInterface.DeleteAllFiles(c.swampDataFolderPath); err != nil {
 		slog.Error("can not delete the swamp directory", "error", err)
 		return
@@ -152,40 +287,83 @@ func (c *chronicler) IsFilesystemInitiated() bool {
 }
 
 // Load the whole swamp from the filesystem with all contents and return with it
-func (c *chronicler) Load(indexObj beacon.Beacon) {
+func (c *chronicler) Load(indexObj beacon.Beacon, shadowIndexObj beacon.Beacon) {
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	contents, err := c.filesystemInterface.GetAllFileContents(c.swampDataFolderPath, metadata.MetaFile)
+	c.loadError = nil
+	c.compactedFiles = nil
+	c.relocatedTreasures = nil
+
+	contents, err := c.filesystemInterface.GetAllFileContents(c.swampDataFolderPath, metadata.MetaFile, keyfilter.File, keydirectory.File)
 	if err != nil {
 		slog.Error("can not read the actual file", "error", err)
+		c.loadError = err
 		return
 	}
 
 	// iterating over the contents
 	treasures := make(map[string]treasure.Treasure)
+	shadowTreasures := make(map[string]treasure.Treasure)
 
 	for fileName, byteTreasures := range contents {
+
+		// the treasures of a file are added only if all of them can be loaded, so a corrupted file is skipped
+		// as a whole, the same way as the files with invalid checksum
+		fileTreasures := make([]treasure.Treasure, 0, len(byteTreasures))
+		var errFromByte error
+
 		for _, byteTreasure := range byteTreasures {
 
 			treasureInterface := treasure.New(c.swampSaveFunction)
 			guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
-			errFromByte := treasureInterface.LoadFromByte(guardID, byteTreasure, fileName)
+			errFromByte = treasureInterface.LoadFromByte(guardID, byteTreasure, fileName)
+			treasureInterface.ReleaseTreasureGuard(guardID)
 			if errFromByte != nil {
+				break
+			}
+			// the deleted treasures stay in the files until the compaction, but they must not be loaded back
+			if isTombstone(treasureInterface) {
+				continue
+			}
+			fileTreasures = append(fileTreasures, treasureInterface)
+
+		}
+
+		if errFromByte != nil {
+			corruptedErr := c.filesystemInterface.ReportCorruptedFile(filepath.Join(c.swampDataFolderPath, fileName), errFromByte)
+			if !c.filesystemInterface.IsSkipCorruptedFiles() {
+				c.loadError = corruptedErr
 				return
 			}
-			treasureInterface.ReleaseTreasureGuard(guardID)
-			treasures[treasureInterface.GetKey()] = treasureInterface
+			continue
+		}
 
+		for _, t := range fileTreasures {
+			if t.GetDeletedAt() != 0 {
+				shadowTreasures[t.GetKey()] = t
+				continue
+			}
+			treasures[t.GetKey()] = t
 		}
+
 	}
 
 	// add all treasures to the index object
 	indexObj.PushManyFromMap(treasures)
+	if len(shadowTreasures) > 0 {
+		shadowIndexObj.PushManyFromMap(shadowTreasures)
+	}
 
 }
 
+func (c *chronicler) GetLoadError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.loadError
+}
+
 // Write all Treasures to the filesystem
 func (c *chronicler) Write(treasures []treasure.Treasure) {
 
@@ -202,7 +380,10 @@ func (c *chronicler) Write(treasures []treasure.Treasure) {
 
 	for _, selectedTreasure := range treasures {
 
-		fileName := selectedTreasure.GetFileName()
+		fileName, skip := c.resolveFileName(selectedTreasure)
+		if skip {
+			continue
+		}
 
 		if fileName != nil {
 			// add the treasure to the modified treasures map if it is not exists
@@ -232,6 +413,240 @@ func (c *chronicler) Write(treasures []treasure.Treasure) {
 		c.writeNewTreasures(c.newTreasuresForWrite)
 	}
 
+	c.syncIfDue()
+
+}
+
+// resolveFileName returns the file name of the treasure. The file name can be outdated if the treasure was waiting for
+// the writer while the compaction moved it to a new file, so the new file name is returned instead of the deleted one.
+// Skip is true if the compaction dropped the record of the deleted treasure, so there is nothing to write.
+func (c *chronicler) resolveFileName(t treasure.Treasure) (fileName *string, skip bool) {
+
+	fileName = t.GetFileName()
+	if fileName == nil {
+		return nil, false
+	}
+
+	if _, compacted := c.compactedFiles[*fileName]; !compacted {
+		return fileName, false
+	}
+
+	if newFileName, ok := c.relocatedTreasures[t.GetKey()]; ok {
+		return &newFileName, false
+	}
+
+	// the record of the treasure was dropped by the compaction, because it was deleted
+	if isTombstone(t) {
+		return nil, true
+	}
+
+	// the treasure was not found in its file, so it is written as a new treasure
+	return nil, false
+
+}
+
+// isTombstone returns true if the treasure is deleted, but its record is still in the file.
+// The shadow deleted treasures keep their content, so they are not tombstones.
+func isTombstone(t treasure.Treasure) bool {
+	return t.GetDeletedAt() != 0 && !t.GetShadowDelete() && t.GetContentType() == treasure.ContentTypeVoid
+}
+
+// Compact rewrites the chunk files of the swamp, where the ratio of the live records is below minLiveRatio.
+//
+// The deleted treasures stay in their files as tombstones, so after heavy delete churn the swamp folder is full of
+// chunks that are mostly dead data. They waste disk space and slow down the hydration of the swamp, because every
+// record must be decoded. The compaction merges the live records of these files into new, full files, and deletes
+// the old ones. The new files are written before the old files are deleted, so a crash in between leaves duplicates
+// of the same treasures, but never loses them.
+func (c *chronicler) Compact(minLiveRatio float64) (CompactionResult, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := CompactionResult{}
+
+	if minLiveRatio <= 0 || minLiveRatio > 1 {
+		return result, fmt.Errorf("invalid live ratio %v, it must be greater than 0 and at most 1", minLiveRatio)
+	}
+
+	if !c.filesystemInterface.IsFolderExists(c.swampDataFolderPath) {
+		return result, nil
+	}
+
+	fileSizes, err := c.filesystemInterface.GetFileSizes(c.swampDataFolderPath, metadata.MetaFile, keyfilter.File, keydirectory.File)
+	if err != nil {
+		return result, err
+	}
+
+	// the actual file is never compacted, because the new treasures are still appended to it
+	actualFileName := c.metadataInterface.GetKey(ActualFileKeyInMeta)
+	fileNames := make([]string, 0, len(fileSizes))
+	for fileName := range fileSizes {
+		if fileName == actualFileName || c.filesystemInterface.IsCorruptedFile(filepath.Join(c.swampDataFolderPath, fileName)) {
+			continue
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	type liveRecord struct {
+		key     string
+		content []byte
+	}
+
+	compactedFileNames := make([]string, 0)
+	liveRecords := make([]liveRecord, 0)
+	for _, fileName := range fileNames {
+
+		byteTreasures, err := c.filesystemInterface.GetFile(filepath.Join(c.swampDataFolderPath, fileName))
+		if err != nil {
+			slog.Error("can not read the file for the compaction", "file name", fileName, "error", err)
+			continue
+		}
+
+		fileRecords := make([]liveRecord, 0, len(byteTreasures))
+		totalBytes, liveBytes := 0, 0
+		var loadErr error
+		for _, byteTreasure := range byteTreasures {
+			treasureObject := treasure.New(c.swampSaveFunction)
+			guardID := treasureObject.StartTreasureGuard(true, guard.BodyAuthID)
+			loadErr = treasureObject.LoadFromByte(guardID, byteTreasure, fileName)
+			treasureObject.ReleaseTreasureGuard(guardID)
+			if loadErr != nil {
+				break
+			}
+			totalBytes += len(byteTreasure)
+			if isTombstone(treasureObject) {
+				continue
+			}
+			liveBytes += len(byteTreasure)
+			fileRecords = append(fileRecords, liveRecord{key: treasureObject.GetKey(), content: byteTreasure})
+		}
+
+		// the file is left untouched if any of its treasures can not be loaded
+		if loadErr != nil {
+			slog.Error("can not load the treasure for the compaction", "file name", fileName, "error", loadErr)
+			continue
+		}
+
+		if totalBytes > 0 && float64(liveBytes)/float64(totalBytes) >= minLiveRatio {
+			continue
+		}
+
+		compactedFileNames = append(compactedFileNames, fileName)
+		liveRecords = append(liveRecords, fileRecords...)
+		result.RemovedTreasures += len(byteTreasures) - len(fileRecords)
+		result.ReclaimedBytes += fileSizes[fileName]
+
+	}
+
+	if len(compactedFileNames) == 0 {
+		return CompactionResult{}, nil
+	}
+
+	// write the live records into new files, the same way as the new treasures are written
+	filePointerEvents := make([]*FileNameEvent, 0, len(liveRecords))
+	writtenFilePaths := make([]string, 0)
+	for i := 0; i < len(liveRecords); {
+
+		newFileName := uuid.NewString()
+		newFilePath := filepath.Join(c.swampDataFolderPath, newFileName)
+
+		byteContent := make([][]byte, 0)
+		estimatedSize := 0
+		for ; i < len(liveRecords); i++ {
+			estimatedSize += int(float64(len(liveRecords[i].content)) * SnappyCompressionPercent)
+			if estimatedSize > c.maxFileSize && len(byteContent) > 0 {
+				break
+			}
+			byteContent = append(byteContent, liveRecords[i].content)
+			filePointerEvents = append(filePointerEvents, &FileNameEvent{
+				TreasureKey: liveRecords[i].key,
+				FileName:    newFileName,
+			})
+		}
+
+		if err := c.filesystemInterface.SaveFile(newFilePath, byteContent, false); err != nil {
+			// roll back the written files, the old files still contain all treasures
+			for _, writtenFilePath := range writtenFilePaths {
+				c.deleteFile(writtenFilePath)
+			}
+			return CompactionResult{}, err
+		}
+		writtenFilePaths = append(writtenFilePaths, newFilePath)
+
+	}
+
+	// the new files must be on the disk before the old files are deleted, unless the operating system flushes them,
+	// otherwise a power loss could keep the deletions and lose the new files
+	if c.fsyncPolicy != setting.FsyncNever {
+		if err := c.filesystemInterface.SyncFiles(writtenFilePaths...); err != nil {
+			for _, writtenFilePath := range writtenFilePaths {
+				c.deleteFile(writtenFilePath)
+			}
+			return CompactionResult{}, err
+		}
+	}
+
+	// register the new file names for the treasures waiting for the writer with the old file names
+	if c.compactedFiles == nil {
+		c.compactedFiles = make(map[string]struct{})
+		c.relocatedTreasures = make(map[string]string)
+	}
+	for _, fileName := range compactedFileNames {
+		c.compactedFiles[fileName] = struct{}{}
+	}
+	for _, e := range filePointerEvents {
+		c.relocatedTreasures[e.TreasureKey] = e.FileName
+	}
+
+	// delete the old files, after the new files are written
+	for _, fileName := range compactedFileNames {
+		c.deleteFile(filepath.Join(c.swampDataFolderPath, fileName))
+	}
+
+	for _, writtenFilePath := range writtenFilePaths {
+		if size, err := c.filesystemInterface.GetFileSize(writtenFilePath); err == nil {
+			result.ReclaimedBytes -= size
+		}
+	}
+
+	c.sendFilePointerEvents(filePointerEvents)
+
+	c.syncIfDue()
+
+	result.CompactedFiles = len(compactedFileNames)
+	result.WrittenFiles = len(writtenFilePaths)
+
+	slog.Info("the swamp is compacted", "swamp folder", c.swampDataFolderPath, "compacted files", result.CompactedFiles,
+		"written files", result.WrittenFiles, "removed treasures", result.RemovedTreasures, "reclaimed bytes", result.ReclaimedBytes)
+
+	return result, nil
+
+}
+
+// GetSize returns the size of the chunk files of the swamp on the disk
+func (c *chronicler) GetSize() (int64, error) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.filesystemInterface.IsFolderExists(c.swampDataFolderPath) {
+		return 0, nil
+	}
+
+	fileSizes, err := c.filesystemInterface.GetFileSizes(c.swampDataFolderPath, metadata.MetaFile, keyfilter.File, keydirectory.File, c.metadataInterface.GetKey(ActualFileKeyInMeta))
+	if err != nil {
+		return 0, err
+	}
+
+	size := int64(0)
+	for _, fileSize := range fileSizes {
+		size += fileSize
+	}
+
+	return size, nil
+
 }
 
 // modifyTreasuresInFilesystem modifies the treasures in the filesystem
@@ -243,71 +658,143 @@ func (c *chronicler) modifyTreasuresInFilesystem() {
 	}
 }
 
-// writeNewTreasures recursively writes the new treasures to the filesystem
+// writeNewTreasures writes the new treasures to the filesystem. The treasures are converted to binary in parallel,
+// then coalesced into one sequential buffer per file, so every file is written at once, and the rest of the treasures
+// continue in a new file when the actual file is full.
 func (c *chronicler) writeNewTreasures(newTreasures []treasure.Treasure) {
 
+	binaryTreasures := convertTreasures(newTreasures)
+
 	workingFile := c.getActualFile()
 
 	fileSize, _ := c.filesystemInterface.GetFileSize(workingFile)
-	byteContent := make([][]byte, 0)
 
 	// get the actual size of the file
 	actualSizeInBytes := int(fileSize)
 	// count how many newTreasures can be written to the file
 	countTreasures := len(newTreasures)
 
+	byteContent := make([][]byte, 0, countTreasures)
 	filePointerEvents := make([]*FileNameEvent, 0, countTreasures)
 
 	// iterating over the newTreasures
 	for k, t := range newTreasures {
 
-		// convert the treasure to the binary data
-		guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
-		b, convertErr := t.ConvertToByte(guardID)
-		if convertErr != nil {
-			t.ReleaseTreasureGuard(guardID)
+		// the treasure can not be converted to binary
+		b := binaryTreasures[k]
+		if b == nil {
 			continue
 		}
-		t.ReleaseTreasureGuard(guardID)
 
 		byteContent = append(byteContent, b)
 
+		// collect file pointer events, the treasure is written to the working file even if the file is full after it
+		filePointerEvents = append(filePointerEvents, &FileNameEvent{
+			TreasureKey: t.GetKey(),
+			FileName:    filepath.Base(workingFile),
+		})
+
 		actualSizeInBytes += int(float64(len(b)) * SnappyCompressionPercent)
 
-		// if the size of the folder is bigger than the max folder size we need to create a new folder and write the rest of the newTreasures
-		if actualSizeInBytes > c.maxFileSize {
-			// if there are more than one treasure that wait for the next folder
-			if k+1 < countTreasures {
-				// create new actual file
-				c.createActualFile()
-				// send the rest of the newTreasures to the next folder
-				c.writeNewTreasures(newTreasures[k+1:])
-				break
+		// if the file is full and there are more treasures waiting, write the file and continue in a new file
+		if actualSizeInBytes > c.maxFileSize && k+1 < countTreasures {
+			if !c.saveNewTreasures(workingFile, byteContent, filePointerEvents) {
+				return
 			}
+			workingFile = c.createActualFile()
+			actualSizeInBytes = 0
+			byteContent = make([][]byte, 0, countTreasures-k-1)
+			filePointerEvents = make([]*FileNameEvent, 0, countTreasures-k-1)
 		}
 
-		// collect file pointer events
-		filePointerEvents = append(filePointerEvents, &FileNameEvent{
-			TreasureKey: t.GetKey(),
-			FileName:    filepath.Base(workingFile),
-		})
+	}
+
+	c.saveNewTreasures(workingFile, byteContent, filePointerEvents)
+
+}
+
+// minTreasuresPerConverter is the min number of treasures converted by one goroutine, because converting a few
+// treasures is faster than starting a goroutine
+const minTreasuresPerConverter = 256
 
+// convertTreasures converts the treasures to binary in parallel, because the gob encoding of the treasures costs
+// much more than writing them to the disk. The binary data is nil if the treasure can not be converted.
+func convertTreasures(treasures []treasure.Treasure) [][]byte {
+
+	binaryTreasures := make([][]byte, len(treasures))
+
+	converters := min(runtime.GOMAXPROCS(0), (len(treasures)+minTreasuresPerConverter-1)/minTreasuresPerConverter)
+	if converters <= 1 {
+		for i, t := range treasures {
+			binaryTreasures[i] = convertTreasure(t)
+		}
+		return binaryTreasures
+	}
+
+	wg := sync.WaitGroup{}
+	chunkSize := (len(treasures) + converters - 1) / converters
+	for from := 0; from < len(treasures); from += chunkSize {
+		to := min(from+chunkSize, len(treasures))
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			for i := from; i < to; i++ {
+				binaryTreasures[i] = convertTreasure(treasures[i])
+			}
+		}(from, to)
+	}
+	wg.Wait()
+
+	return binaryTreasures
+
+}
+
+// convertTreasure converts the treasure to binary under its guard. Returns nil if the treasure can not be converted.
+func convertTreasure(t treasure.Treasure) []byte {
+	guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
+	defer t.ReleaseTreasureGuard(guardID)
+	b, err := t.ConvertToByte(guardID)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// saveNewTreasures appends the coalesced treasures to the file and sends their file pointer events.
+// Returns false if the file can not be written.
+func (c *chronicler) saveNewTreasures(filePath string, byteContent [][]byte, filePointerEvents []*FileNameEvent) bool {
+
+	if len(byteContent) == 0 {
+		return true
 	}
 
 	// write the data to filesystem
-	if err := c.filesystemInterface.SaveFile(workingFile, byteContent, true); err != nil {
+	if err := c.filesystemInterface.SaveFile(filePath, byteContent, true); err != nil {
 		slog.Error("can not write the new treasures to the filesystem", "error", err)
-		return
+		return false
 	}
+	c.markUnsynced(filePath)
 
 	// send file pointer events
 	c.sendFilePointerEvents(filePointerEvents)
 
+	return true
+
 }
 
 func (c *chronicler) sendFilePointerEvents(filePointerEvents []*FileNameEvent) {
 
-	if c.dontSendFilePointer || len(filePointerEvents) == 0 {
+	if len(filePointerEvents) == 0 {
+		return
+	}
+
+	if c.dontSendFilePointer {
+		if c.unsentFileNames == nil {
+			c.unsentFileNames = make(map[string]string)
+		}
+		for _, e := range filePointerEvents {
+			c.unsentFileNames[e.TreasureKey] = e.FileName
+		}
 		return
 	}
 
@@ -388,6 +875,7 @@ func (c *chronicler) writeModifiedTreasures(fileName string, treasures map[strin
 		slog.Error("can not write the modified treasures to the filesystem", "error", err, "file absolute path", fp)
 		return
 	}
+	c.markUnsynced(fp)
 
 }
 
@@ -395,7 +883,10 @@ func (c *chronicler) deleteFile(filePath string) {
 
 	if err := c.filesystemInterface.DeleteFile(filePath); err != nil {
 		slog.Error("can not delete the file", "error", err, "file absolute path", filePath)
+		return
 	}
+	// the folder of the deleted file is flushed
+	c.markUnsynced(filePath)
 
 }
 
@@ -427,7 +918,12 @@ func (c *chronicler) decompressFile(fp string) []byte {
 func (c *chronicler) getActualFile() (actualFilePath string) {
 	actualFilePath = c.metadataInterface.GetKey(ActualFileKeyInMeta)
 	if actualFilePath != "" {
-		return filepath.Join(c.swampDataFolderPath, actualFilePath)
+		actualFilePath = filepath.Join(c.swampDataFolderPath, actualFilePath)
+		// the new treasures are never appended to a corrupted file, they continue in a new file, and the
+		// corrupted file is left untouched for the investigation
+		if !c.filesystemInterface.IsCorruptedFile(actualFilePath) {
+			return actualFilePath
+		}
 	}
 	return c.createActualFile()
 }
@@ -443,6 +939,7 @@ func (c *chronicler) createActualFile() (filePath string) {
 		slog.Error("can not create the actual file", "error", err, "file absolute path", filePath)
 		return ""
 	}
+	c.markUnsynced(filePath)
 
 	// create the actual key in the metadata
 	c.metadataInterface.SetKey(ActualFileKeyInMeta, actualFileUUID)
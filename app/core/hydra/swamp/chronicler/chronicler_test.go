@@ -0,0 +1,110 @@
+package chronicler
+
+import (
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncRecorder records the files flushed by the chronicler
+type syncRecorder struct {
+	filesystem.Filesystem
+	mu     sync.Mutex
+	synced [][]string
+}
+
+func (r *syncRecorder) SyncFiles(filePaths ...string) error {
+	r.mu.Lock()
+	r.synced = append(r.synced, append([]string(nil), filePaths...))
+	r.mu.Unlock()
+	return r.Filesystem.SyncFiles(filePaths...)
+}
+
+func (r *syncRecorder) syncs() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.synced)
+}
+
+func newTestTreasure(key string) treasure.Treasure {
+	t := treasure.New(nil)
+	guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
+	t.BodySetKey(guardID, key)
+	t.SetContentString(guardID, "value of "+key)
+	t.ReleaseTreasureGuard(guardID)
+	return t
+}
+
+func newTestChronicler(t *testing.T, policy setting.FsyncPolicy, interval time.Duration) (Chronicler, *syncRecorder) {
+	folder := t.TempDir()
+	recorder := &syncRecorder{Filesystem: filesystem.New()}
+	c := New(folder, 65536, 2, recorder, metadata.New(folder))
+	c.SetFsyncPolicy(policy, interval)
+	c.CreateDirectoryIfNotExists()
+	return c, recorder
+}
+
+func TestChronicler_FsyncPolicy(t *testing.T) {
+
+	t.Run("should flush the files after every write with the always policy", func(t *testing.T) {
+
+		c, recorder := newTestChronicler(t, setting.FsyncAlways, 0)
+
+		c.Write([]treasure.Treasure{newTestTreasure("a")})
+		require.Equal(t, 1, recorder.syncs())
+		assert.Len(t, recorder.synced[0], 1, "the actual file must be flushed once")
+
+		c.Write([]treasure.Treasure{newTestTreasure("b")})
+		assert.Equal(t, 2, recorder.syncs())
+
+	})
+
+	t.Run("should leave the flushing to the operating system with the never policy", func(t *testing.T) {
+
+		c, recorder := newTestChronicler(t, setting.FsyncNever, 0)
+
+		c.Write([]treasure.Treasure{newTestTreasure("a")})
+		c.Sync()
+		assert.Equal(t, 0, recorder.syncs())
+
+	})
+
+	t.Run("should flush the files once per interval and when the swamp closes", func(t *testing.T) {
+
+		c, recorder := newTestChronicler(t, setting.FsyncInterval, time.Hour)
+
+		// the first write is flushed, because no flush happened before it
+		c.Write([]treasure.Treasure{newTestTreasure("a")})
+		require.Equal(t, 1, recorder.syncs())
+
+		c.Write([]treasure.Treasure{newTestTreasure("b")})
+		c.Write([]treasure.Treasure{newTestTreasure("c")})
+		assert.Equal(t, 1, recorder.syncs(), "the writes within the interval must not be flushed")
+
+		c.Sync()
+		assert.Equal(t, 2, recorder.syncs())
+
+	})
+
+	t.Run("should flush the files when the interval elapses without a write", func(t *testing.T) {
+
+		c, recorder := newTestChronicler(t, setting.FsyncInterval, 50*time.Millisecond)
+
+		c.Write([]treasure.Treasure{newTestTreasure("a")})
+		c.Write([]treasure.Treasure{newTestTreasure("b")})
+		require.Equal(t, 1, recorder.syncs())
+
+		assert.Eventually(t, func() bool {
+			return recorder.syncs() == 2
+		}, time.Second, 10*time.Millisecond)
+
+	})
+
+}
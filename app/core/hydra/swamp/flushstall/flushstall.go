@@ -0,0 +1,48 @@
+// Package flushstall counts how often the flushes of the Swamps stall the operations on the Treasures.
+//
+// The writer of a Swamp does not hold the guards of the dirty Treasures while it converts them to binary and writes
+// them to the disk. It takes a snapshot of every Treasure under its guard, and writes the snapshots. An operation that
+// needs the guard of a Treasure while its snapshot is taken waits for the writer: this wait is the stall. The snapshot
+// is a copy in the memory, so the stalls must stay rare and short, and the counters show it.
+package flushstall
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is the effect of the flushes on the operations
+type Stats struct {
+	// Snapshots is the number of the Treasures snapshotted by the flushes
+	Snapshots uint64
+	// Stalls is the number of the operations that waited for a snapshot
+	Stalls uint64
+	// StallTime is the total time the stalled operations waited, at most
+	StallTime time.Duration
+}
+
+// Counters sums the stalls of the flushes, e.g. of every Swamp of the Hydra. The zero value is ready to use.
+type Counters struct {
+	snapshots  atomic.Uint64
+	stalls     atomic.Uint64
+	stallNanos atomic.Uint64
+}
+
+// Record counts a snapshot, the operations that waited for it, and the time the snapshot held the Treasure. Every
+// waiting operation is counted with the whole hold time, because the time they joined the queue is unknown.
+func (c *Counters) Record(waiting int, held time.Duration) {
+	c.snapshots.Add(1)
+	if waiting > 0 {
+		c.stalls.Add(uint64(waiting))
+		c.stallNanos.Add(uint64(waiting) * uint64(held))
+	}
+}
+
+// Stats returns the stalls summed by the counters
+func (c *Counters) Stats() Stats {
+	return Stats{
+		Snapshots: c.snapshots.Load(),
+		Stalls:    c.stalls.Load(),
+		StallTime: time.Duration(c.stallNanos.Load()),
+	}
+}
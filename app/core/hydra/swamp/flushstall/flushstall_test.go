@@ -0,0 +1,25 @@
+package flushstall
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCounters(t *testing.T) {
+
+	t.Run("should count the waiting operations with the hold time", func(t *testing.T) {
+
+		counters := &Counters{}
+		counters.Record(0, time.Millisecond)
+		counters.Record(2, time.Millisecond)
+
+		assert.Equal(t, Stats{
+			Snapshots: 2,
+			Stalls:    2,
+			StallTime: 2 * time.Millisecond,
+		}, counters.Stats())
+
+	})
+
+}
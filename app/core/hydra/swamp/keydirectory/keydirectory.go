@@ -0,0 +1,270 @@
+// Package keydirectory provides the directory of the keys of a swamp, mapping every key to the chunk file that stores
+// it. The directory is persisted alongside the chunk files.
+//
+// A read of a few keys of a cold swamp loads only the chunks of these keys by the directory, instead of hydrating the
+// whole swamp with millions of treasures. A key missing from the directory is definitely not in the swamp.
+//
+// The directory is written when the swamp closes, and removed when the swamp is loaded again, the same way as the
+// key filter. A directory on the disk is therefore never older than the chunk files: if the server stops without
+// closing the swamp, there is no directory, and the swamp is loaded as without it.
+//
+// The keys are distributed to buckets by their hashes, so a lookup reads only a few small parts of the file, not the
+// whole directory:
+//
+//	header | offsets of the file names | offsets of the buckets | file names | buckets
+//
+// Every file name and bucket ends with its checksum, so a partially written directory is not used.
+//
+// Example:
+//
+//	_ = keydirectory.Save(swampFolder, map[string]string{"domain.com": "f0c5..."})
+//
+//	fileNames, err := keydirectory.Lookup(swampFolder, []string{"domain.com", "other.com"})
+//	if err == nil {
+//	    // fileNames["domain.com"] is the chunk of the key, and other.com is definitely not in the swamp
+//	}
+package keydirectory
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/cespare/xxhash/v2"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// File is the name of the directory file in the folder of the swamp. The chunk files of the swamp never use this
+// name, so the loaders of the chunks must skip it, like the metadata file
+const File = "keydirectory"
+
+// keysPerBucket is the average number of the keys in a bucket, read at once by a lookup
+const keysPerBucket = 32
+
+// magic identifies the format of the directory file
+var magic = [4]byte{'H', 'K', 'D', '1'}
+
+// headerSize is the size of the magic, the number of the file names, the number of the buckets and the file size
+const headerSize = 4 + 4 + 4 + 8
+
+// checksumSize is the size of the checksum at the end of every file name and bucket
+const checksumSize = 4
+
+// ErrInvalidFile is returned by Lookup if the directory file is truncated, corrupted or has an unknown format
+var ErrInvalidFile = errors.New("invalid key directory file")
+
+// Save writes the directory of the keys to the directory file of the swamp folder. The fileNames maps the keys to the
+// names of their chunk files.
+func Save(folderPath string, fileNames map[string]string) error {
+
+	// the file names are stored once, and the keys refer to them by their indexes
+	fileIndexes := make(map[string]uint64)
+	names := make([]string, 0)
+	buckets := make([][]byte, bucketCount(len(fileNames)))
+	for key, fileName := range fileNames {
+		fileIndex, ok := fileIndexes[fileName]
+		if !ok {
+			fileIndex = uint64(len(names))
+			fileIndexes[fileName] = fileIndex
+			names = append(names, fileName)
+		}
+		bucket := bucketOf(key, len(buckets))
+		buckets[bucket] = binary.AppendUvarint(buckets[bucket], uint64(len(key)))
+		buckets[bucket] = append(buckets[bucket], key...)
+		buckets[bucket] = binary.AppendUvarint(buckets[bucket], fileIndex)
+	}
+
+	nameParts := make([][]byte, 0, len(names))
+	for _, fileName := range names {
+		nameParts = append(nameParts, []byte(fileName))
+	}
+
+	// the offsets of the file names and of the buckets, both followed by the offset of the end of their last part
+	offsets := make([]byte, 0, (len(names)+1+len(buckets)+1)*8)
+	offset := uint64(headerSize + (len(names)+1+len(buckets)+1)*8)
+	for _, parts := range [][][]byte{nameParts, buckets} {
+		for _, part := range parts {
+			offsets = binary.LittleEndian.AppendUint64(offsets, offset)
+			offset += uint64(len(part) + checksumSize)
+		}
+		offsets = binary.LittleEndian.AppendUint64(offsets, offset)
+	}
+
+	data := make([]byte, headerSize, offset)
+	copy(data, magic[:])
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(names)))
+	binary.LittleEndian.PutUint32(data[8:], uint32(len(buckets)))
+	binary.LittleEndian.PutUint64(data[12:], offset)
+	data = append(data, offsets...)
+	for _, part := range slices.Concat(nameParts, buckets) {
+		data = append(data, part...)
+		data = binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(part))
+	}
+
+	if err := os.WriteFile(filepath.Join(folderPath, File), data, 0644); err != nil {
+		return fmt.Errorf("can not write the key directory: %w", err)
+	}
+
+	return nil
+
+}
+
+// Lookup returns the names of the chunk files of the keys from the directory file of the swamp folder. The keys
+// missing from the returned map are not in the swamp. Returns an error wrapping os.ErrNotExist if the swamp has no
+// directory file, and ErrInvalidFile if the file can not be used.
+func Lookup(folderPath string, keys []string) (map[string]string, error) {
+
+	file, err := os.Open(filepath.Join(folderPath, File))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerSize)
+	if err := readAt(file, header, 0); err != nil || [4]byte(header[:4]) != magic {
+		return nil, ErrInvalidFile
+	}
+	d := &directory{
+		file:      file,
+		names:     uint64(binary.LittleEndian.Uint32(header[4:])),
+		buckets:   uint64(binary.LittleEndian.Uint32(header[8:])),
+		size:      binary.LittleEndian.Uint64(header[12:]),
+		fileNames: make(map[uint64]string),
+	}
+	if d.buckets == 0 || d.size != uint64(stat.Size()) {
+		return nil, ErrInvalidFile
+	}
+
+	// the keys of the same bucket are looked up by one read
+	keysOfBuckets := make(map[uint64][]string)
+	for _, key := range keys {
+		bucket := uint64(bucketOf(key, int(d.buckets)))
+		keysOfBuckets[bucket] = append(keysOfBuckets[bucket], key)
+	}
+
+	found := make(map[string]string)
+	for bucket, bucketKeys := range keysOfBuckets {
+		if err := d.lookupBucket(bucket, bucketKeys, found); err != nil {
+			return nil, err
+		}
+	}
+
+	return found, nil
+
+}
+
+// Remove deletes the directory file of the swamp folder, if there is any
+func Remove(folderPath string) error {
+	if err := os.Remove(filepath.Join(folderPath, File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("can not remove the key directory: %w", err)
+	}
+	return nil
+}
+
+// directory is an opened directory file
+type directory struct {
+	file      *os.File
+	names     uint64            // the number of the file names
+	buckets   uint64            // the number of the buckets
+	size      uint64            // the size of the file
+	fileNames map[uint64]string // the file names read by the lookup
+}
+
+// lookupBucket adds the chunk files of the keys of the bucket to the found map
+func (d *directory) lookupBucket(bucket uint64, keys []string, found map[string]string) error {
+
+	content, err := d.readPart(d.names + 1 + bucket)
+	if err != nil {
+		return err
+	}
+
+	for len(content) > 0 {
+		keyLength, n := binary.Uvarint(content)
+		if n <= 0 || keyLength > uint64(len(content)-n) {
+			return ErrInvalidFile
+		}
+		key := string(content[n : n+int(keyLength)])
+		content = content[n+int(keyLength):]
+		fileIndex, n := binary.Uvarint(content)
+		if n <= 0 || fileIndex >= d.names {
+			return ErrInvalidFile
+		}
+		content = content[n:]
+		if !slices.Contains(keys, key) {
+			continue
+		}
+		fileName, err := d.fileName(fileIndex)
+		if err != nil {
+			return err
+		}
+		found[key] = fileName
+	}
+
+	return nil
+
+}
+
+// fileName returns the file name of the index
+func (d *directory) fileName(fileIndex uint64) (string, error) {
+	if fileName, ok := d.fileNames[fileIndex]; ok {
+		return fileName, nil
+	}
+	content, err := d.readPart(fileIndex)
+	if err != nil {
+		return "", err
+	}
+	d.fileNames[fileIndex] = string(content)
+	return d.fileNames[fileIndex], nil
+}
+
+// readPart reads the part of the index, and checks its checksum. The file names are the first parts, and the buckets
+// follow them after the end offset of the file names.
+func (d *directory) readPart(part uint64) ([]byte, error) {
+
+	offsets := make([]byte, 16)
+	if err := readAt(d.file, offsets, int64(headerSize+part*8)); err != nil {
+		return nil, ErrInvalidFile
+	}
+	from, to := binary.LittleEndian.Uint64(offsets), binary.LittleEndian.Uint64(offsets[8:])
+	dataOffset := uint64(headerSize) + (d.names+1+d.buckets+1)*8
+	if from < dataOffset || to < from+checksumSize || to > d.size {
+		return nil, ErrInvalidFile
+	}
+
+	content := make([]byte, to-from)
+	if err := readAt(d.file, content, int64(from)); err != nil {
+		return nil, ErrInvalidFile
+	}
+	content, checksum := content[:len(content)-checksumSize], binary.LittleEndian.Uint32(content[len(content)-checksumSize:])
+	if crc32.ChecksumIEEE(content) != checksum {
+		return nil, ErrInvalidFile
+	}
+
+	return content, nil
+
+}
+
+// readAt fills the buffer from the offset of the file. Returns an error if the file ends before the buffer is full.
+func readAt(file *os.File, buffer []byte, offset int64) error {
+	_, err := file.ReadAt(buffer, offset)
+	return err
+}
+
+// bucketCount returns the number of the buckets of the keys
+func bucketCount(keys int) int {
+	return max(1, (keys+keysPerBucket-1)/keysPerBucket)
+}
+
+// bucketOf returns the bucket of the key
+func bucketOf(key string, buckets int) int {
+	return int(xxhash.Sum64String(key) % uint64(buckets))
+}
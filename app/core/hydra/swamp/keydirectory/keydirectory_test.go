@@ -0,0 +1,87 @@
+package keydirectory
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyDirectory(t *testing.T) {
+
+	t.Run("should look up the chunk files of the keys", func(t *testing.T) {
+		folder := t.TempDir()
+		fileNames := make(map[string]string)
+		for i := 0; i < 10000; i++ {
+			fileNames[fmt.Sprintf("key-%d", i)] = fmt.Sprintf("chunk-%d", i/100)
+		}
+		assert.NoError(t, Save(folder, fileNames))
+
+		found, err := Lookup(folder, []string{"key-0", "key-150", "key-9999", "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"key-0":    "chunk-0",
+			"key-150":  "chunk-1",
+			"key-9999": "chunk-99",
+		}, found)
+
+		keys := make([]string, 0, len(fileNames))
+		for key := range fileNames {
+			keys = append(keys, key)
+		}
+		found, err = Lookup(folder, keys)
+		assert.NoError(t, err)
+		assert.Equal(t, fileNames, found)
+	})
+
+	t.Run("should find nothing in an empty directory", func(t *testing.T) {
+		folder := t.TempDir()
+		assert.NoError(t, Save(folder, map[string]string{}))
+		found, err := Lookup(folder, []string{"key"})
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("should not look up a missing directory", func(t *testing.T) {
+		_, err := Lookup(t.TempDir(), []string{"key"})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should not use a truncated or corrupted directory", func(t *testing.T) {
+		folder := t.TempDir()
+		assert.NoError(t, Save(folder, map[string]string{"key": "chunk"}))
+
+		path := filepath.Join(folder, File)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.WriteFile(path, data[:len(data)-3], 0644))
+		_, err = Lookup(folder, []string{"key"})
+		assert.ErrorIs(t, err, ErrInvalidFile)
+
+		// the last byte is the checksum of the only bucket
+		corrupted := append([]byte{}, data...)
+		corrupted[len(corrupted)-1] ^= 0xff
+		assert.NoError(t, os.WriteFile(path, corrupted, 0644))
+		_, err = Lookup(folder, []string{"key"})
+		assert.ErrorIs(t, err, ErrInvalidFile)
+
+		// a zeroed tail, e.g. after a power loss
+		zeroed := append([]byte{}, data...)
+		clear(zeroed[headerSize:])
+		assert.NoError(t, os.WriteFile(path, zeroed, 0644))
+		_, err = Lookup(folder, []string{"key"})
+		assert.ErrorIs(t, err, ErrInvalidFile)
+	})
+
+	t.Run("should remove the directory", func(t *testing.T) {
+		folder := t.TempDir()
+		assert.NoError(t, Save(folder, map[string]string{"key": "chunk"}))
+		assert.NoError(t, Remove(folder))
+		_, err := Lookup(folder, []string{"key"})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.NoError(t, Remove(folder), "a missing directory is not an error")
+	})
+
+}
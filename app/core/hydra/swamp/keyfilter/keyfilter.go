@@ -0,0 +1,174 @@
+// Package keyfilter provides the bloom filter of the keys of a swamp, persisted alongside its chunk files.
+//
+// The filter answers "definitely not present" for most of the missing keys without loading the swamp, so an existence
+// check of a new key — e.g. in a deduplication pipeline — does not hydrate a cold swamp with millions of treasures.
+// A "may be present" answer is a false positive in about 1% of the cases, so the swamp must still be loaded to decide.
+//
+// The filter is written when the swamp closes, and removed when the swamp is loaded again. A filter on the disk is
+// therefore never older than the chunk files: if the server stops without closing the swamp, there is no filter, and
+// the swamp is loaded as without it.
+//
+// Example:
+//
+//	filter := keyfilter.New(len(keys))
+//	for _, key := range keys {
+//	    filter.Add(key)
+//	}
+//	_ = keyfilter.Save(swampFolder, filter)
+//
+//	loaded, err := keyfilter.Load(swampFolder)
+//	if err == nil && !loaded.MayContain("domain.com") {
+//	    // the key is definitely not in the swamp
+//	}
+package keyfilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/cespare/xxhash/v2"
+	"hash/crc32"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// File is the name of the filter file in the folder of the swamp. The chunk files of the swamp never use this name,
+// so the loaders of the chunks must skip it, like the metadata file
+const File = "keyfilter"
+
+// falsePositiveRate is the ratio of the missing keys the filter answers as "may be present"
+const falsePositiveRate = 0.01
+
+// minBits is the size of the filter of an empty or very small swamp
+const minBits = 64
+
+// magic identifies the format of the filter file
+var magic = [4]byte{'H', 'K', 'F', '1'}
+
+// headerSize is the size of the magic, the number of the hash functions and the number of the bits
+const headerSize = 4 + 4 + 8
+
+// ErrInvalidFile is returned by Load if the filter file is truncated, corrupted or has an unknown format
+var ErrInvalidFile = errors.New("invalid key filter file")
+
+// Filter is a bloom filter of keys. Not thread-safe.
+type Filter struct {
+	bits   []uint64
+	size   uint64 // the number of the bits
+	hashes uint32 // the number of the hash functions
+}
+
+// New creates an empty filter sized for the expected number of the keys
+func New(expectedKeys int) *Filter {
+
+	if expectedKeys < 1 {
+		expectedKeys = 1
+	}
+
+	// the optimal size and number of hash functions of the false positive rate
+	size := uint64(math.Ceil(-float64(expectedKeys) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	size = max(size, minBits)
+	hashes := uint32(math.Round(float64(size) / float64(expectedKeys) * math.Ln2))
+	hashes = max(hashes, 1)
+
+	return &Filter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+
+}
+
+// Add adds the key to the filter
+func (f *Filter) Add(key string) {
+	h1, h2 := hashKey(key)
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MayContain returns false if the key was definitely not added to the filter, and true if it may have been added
+func (f *Filter) MayContain(key string) bool {
+	h1, h2 := hashKey(key)
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Save writes the filter to the filter file of the swamp folder. The file ends with a checksum, so a partially written
+// file is not loaded
+func Save(folderPath string, f *Filter) error {
+
+	data := make([]byte, headerSize, headerSize+len(f.bits)*8+4)
+	copy(data, magic[:])
+	binary.LittleEndian.PutUint32(data[4:], f.hashes)
+	binary.LittleEndian.PutUint64(data[8:], f.size)
+	for _, word := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	data = binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+
+	if err := os.WriteFile(filepath.Join(folderPath, File), data, 0644); err != nil {
+		return fmt.Errorf("can not write the key filter: %w", err)
+	}
+
+	return nil
+
+}
+
+// Load reads the filter file of the swamp folder. Returns an error wrapping os.ErrNotExist if the swamp has no filter
+// file, and ErrInvalidFile if the file can not be used
+func Load(folderPath string) (*Filter, error) {
+
+	data, err := os.ReadFile(filepath.Join(folderPath, File))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < headerSize+4 || [4]byte(data[:4]) != magic {
+		return nil, ErrInvalidFile
+	}
+
+	body, checksum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != checksum {
+		return nil, ErrInvalidFile
+	}
+
+	f := &Filter{
+		hashes: binary.LittleEndian.Uint32(body[4:]),
+		size:   binary.LittleEndian.Uint64(body[8:]),
+	}
+	words := body[headerSize:]
+	if f.hashes == 0 || f.size == 0 || uint64(len(words)) != (f.size+63)/64*8 {
+		return nil, ErrInvalidFile
+	}
+
+	f.bits = make([]uint64, len(words)/8)
+	for i := range f.bits {
+		f.bits[i] = binary.LittleEndian.Uint64(words[i*8:])
+	}
+
+	return f, nil
+
+}
+
+// Remove deletes the filter file of the swamp folder, if there is any
+func Remove(folderPath string) error {
+	if err := os.Remove(filepath.Join(folderPath, File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("can not remove the key filter: %w", err)
+	}
+	return nil
+}
+
+// hashKey returns the two hashes of the key, combined to the hash functions of the filter by double hashing
+func hashKey(key string) (uint64, uint64) {
+	h := xxhash.Sum64String(key)
+	// the second hash is odd, so it never repeats the same bit for all hash functions
+	return h, (h>>32 | h<<32) | 1
+}
@@ -0,0 +1,95 @@
+package keyfilter
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+
+	t.Run("should contain all added keys", func(t *testing.T) {
+		f := New(10000)
+		for i := 0; i < 10000; i++ {
+			f.Add(fmt.Sprintf("key-%d", i))
+		}
+		for i := 0; i < 10000; i++ {
+			assert.True(t, f.MayContain(fmt.Sprintf("key-%d", i)))
+		}
+	})
+
+	t.Run("should exclude most of the missing keys", func(t *testing.T) {
+		f := New(10000)
+		for i := 0; i < 10000; i++ {
+			f.Add(fmt.Sprintf("key-%d", i))
+		}
+		falsePositives := 0
+		for i := 0; i < 10000; i++ {
+			if f.MayContain(fmt.Sprintf("missing-%d", i)) {
+				falsePositives++
+			}
+		}
+		assert.Less(t, falsePositives, 300, "the false positive rate is about 1%")
+	})
+
+	t.Run("should exclude every key of an empty filter", func(t *testing.T) {
+		f := New(0)
+		assert.False(t, f.MayContain("key"))
+		f.Add("key")
+		assert.True(t, f.MayContain("key"))
+	})
+
+}
+
+func TestFile(t *testing.T) {
+
+	t.Run("should save and load the filter", func(t *testing.T) {
+		folder := t.TempDir()
+		f := New(1000)
+		for i := 0; i < 1000; i++ {
+			f.Add(fmt.Sprintf("key-%d", i))
+		}
+		assert.NoError(t, Save(folder, f))
+
+		loaded, err := Load(folder)
+		assert.NoError(t, err)
+		assert.Equal(t, f, loaded)
+	})
+
+	t.Run("should not load a missing filter", func(t *testing.T) {
+		_, err := Load(t.TempDir())
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should not load a truncated or corrupted filter", func(t *testing.T) {
+		folder := t.TempDir()
+		f := New(100)
+		f.Add("key")
+		assert.NoError(t, Save(folder, f))
+
+		path := filepath.Join(folder, File)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.WriteFile(path, data[:len(data)-3], 0644))
+		_, err = Load(folder)
+		assert.ErrorIs(t, err, ErrInvalidFile)
+
+		data[headerSize] ^= 0xff
+		assert.NoError(t, os.WriteFile(path, data, 0644))
+		_, err = Load(folder)
+		assert.ErrorIs(t, err, ErrInvalidFile)
+	})
+
+	t.Run("should remove the filter", func(t *testing.T) {
+		folder := t.TempDir()
+		assert.NoError(t, Save(folder, New(1)))
+		assert.NoError(t, Remove(folder))
+		_, err := Load(folder)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.NoError(t, Remove(folder), "a missing filter is not an error")
+	})
+
+}
@@ -0,0 +1,113 @@
+// Package keylock serializes the read-modify-write operations of the same key within a Swamp with striped mutexes.
+//
+// The keys are distributed between the stripes by their hash, so only the writes of the same key (or of two keys in
+// the same stripe) wait for each other's lock. The locks count how often a writer had to wait, and how long, so the
+// contention of the Swamps can be monitored.
+package keylock
+
+import (
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultStripes is the number of the stripes of a Swamp
+const DefaultStripes = 64
+
+// Stats is the contention of the locks
+type Stats struct {
+	// Acquisitions is the number of the taken locks
+	Acquisitions uint64
+	// Contended is the number of the locks that waited for an other writer of the same stripe
+	Contended uint64
+	// WaitTime is the total wait time of the contended locks
+	WaitTime time.Duration
+}
+
+// Counters sums the contention of many Locks, e.g. of every Swamp of the Hydra. The zero value is ready to use.
+type Counters struct {
+	acquisitions atomic.Uint64
+	contended    atomic.Uint64
+	waitNanos    atomic.Uint64
+}
+
+// Stats returns the contention summed by the counters
+func (c *Counters) Stats() Stats {
+	return Stats{
+		Acquisitions: c.acquisitions.Load(),
+		Contended:    c.contended.Load(),
+		WaitTime:     time.Duration(c.waitNanos.Load()),
+	}
+}
+
+// record counts a taken lock and its wait time
+func (c *Counters) record(contended bool, waited time.Duration) {
+	c.acquisitions.Add(1)
+	if contended {
+		c.contended.Add(1)
+		c.waitNanos.Add(uint64(waited))
+	}
+}
+
+// Locks are the striped locks of the keys of a Swamp
+type Locks interface {
+	// Lock locks the stripe of the key, and returns the function that unlocks it. The lock is not reentrant: the
+	// holder must not lock an other key of the same Swamp, because the two keys may be in the same stripe.
+	Lock(key string) (unlock func())
+	// Stats returns the contention of the locks
+	Stats() Stats
+	// SetCounters sets the shared counters the contention is summed to, besides the own stats of the locks. Nil
+	// stops the summing
+	SetCounters(counters *Counters)
+}
+
+type locks struct {
+	seed    maphash.Seed
+	stripes []sync.Mutex
+	own     Counters
+	shared  atomic.Pointer[Counters]
+}
+
+// New creates the locks with the given number of stripes. The number is at least 1.
+func New(stripes int) Locks {
+	return &locks{
+		seed:    maphash.MakeSeed(),
+		stripes: make([]sync.Mutex, max(stripes, 1)),
+	}
+}
+
+func (l *locks) Lock(key string) func() {
+
+	stripe := &l.stripes[l.stripeOf(key)]
+
+	// the wait is measured only if the stripe is taken, so the uncontended lock costs no clock read
+	contended := !stripe.TryLock()
+	var waited time.Duration
+	if contended {
+		started := time.Now()
+		stripe.Lock()
+		waited = time.Since(started)
+	}
+
+	l.own.record(contended, waited)
+	if shared := l.shared.Load(); shared != nil {
+		shared.record(contended, waited)
+	}
+
+	return stripe.Unlock
+
+}
+
+// stripeOf returns the index of the stripe of the key
+func (l *locks) stripeOf(key string) uint64 {
+	return maphash.String(l.seed, key) % uint64(len(l.stripes))
+}
+
+func (l *locks) Stats() Stats {
+	return l.own.Stats()
+}
+
+func (l *locks) SetCounters(counters *Counters) {
+	l.shared.Store(counters)
+}
@@ -0,0 +1,89 @@
+package keylock
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocks(t *testing.T) {
+
+	t.Run("should serialize the writers of the same key", func(t *testing.T) {
+
+		l := New(DefaultStripes)
+		counters := &Counters{}
+		l.SetCounters(counters)
+
+		value := 0
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				unlock := l.Lock("hot")
+				defer unlock()
+				current := value
+				time.Sleep(10 * time.Microsecond)
+				value = current + 1
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 100, value)
+		assert.Equal(t, uint64(100), l.Stats().Acquisitions)
+		assert.Positive(t, l.Stats().Contended)
+		assert.Positive(t, l.Stats().WaitTime)
+		assert.Equal(t, l.Stats(), counters.Stats(), "the shared counters sum the same locks")
+
+	})
+
+	t.Run("should not block the keys of other stripes", func(t *testing.T) {
+
+		l := New(DefaultStripes)
+		unlock := l.Lock("key-0")
+		defer unlock()
+
+		// at least one of the keys is in an other stripe
+		done := make(chan struct{})
+		go func() {
+			for i := 1; i < 10; i++ {
+				key := fmt.Sprintf("key-%d", i)
+				if l.(*locks).stripeOf(key) != l.(*locks).stripeOf("key-0") {
+					l.Lock(key)()
+					close(done)
+					return
+				}
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the lock of an other stripe is blocked")
+		}
+		assert.Zero(t, l.Stats().Contended)
+
+	})
+
+	t.Run("should have at least one stripe", func(t *testing.T) {
+		l := New(0)
+		l.Lock("a")()
+		assert.Equal(t, uint64(1), l.Stats().Acquisitions)
+	})
+
+}
+
+func BenchmarkLocks(b *testing.B) {
+
+	l := New(DefaultStripes)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			l.Lock(fmt.Sprintf("key-%d", i%1000))()
+			i++
+		}
+	})
+
+}
@@ -12,6 +12,8 @@ package metadata
 
 import (
 	"encoding/gob"
+	"errors"
+	"fmt"
 	"github.com/hydraide/hydraide/app/name"
 	"log/slog"
 	"os"
@@ -22,6 +24,28 @@ import (
 
 const MetaFile = "meta"
 
+// KeyTreasureCount is the internal key of the number of the treasures of the swamp, recorded when the swamp closes,
+// so the usage of the closed swamps can be reported without loading them
+const KeyTreasureCount = "treasureCount"
+
+const (
+	// MaxAnnotations is the maximum number of annotations per swamp
+	MaxAnnotations = 64
+	// MaxAnnotationKeyLength is the maximum length of an annotation key in bytes
+	MaxAnnotationKeyLength = 128
+	// MaxAnnotationValueLength is the maximum length of an annotation value in bytes
+	MaxAnnotationValueLength = 1024
+)
+
+var (
+	// ErrAnnotationKeyEmpty is returned if the annotation key is empty
+	ErrAnnotationKeyEmpty = errors.New("annotation key cannot be empty")
+	// ErrAnnotationTooLong is returned if the annotation key or value is longer than allowed
+	ErrAnnotationTooLong = errors.New("annotation is too long")
+	// ErrTooManyAnnotations is returned if the swamp already has the maximum number of annotations
+	ErrTooManyAnnotations = errors.New("too many annotations")
+)
+
 type Meta struct {
 	// The full name of the swamp, used for reverse lookup even if accessed through a hashed folder path. Variable length.
 	SwampName string
@@ -34,6 +58,10 @@ type Meta struct {
 	BackupAt time.Time
 	// KeyValuePairs are custom metadata entries that can only be set internally by the system.
 	KeyValuePairs map[string]string
+	// Annotations are small key-value pairs set by the clients, for example the owner service,
+	// the schema version or the retention class of the swamp. They are kept apart from the KeyValuePairs,
+	// so clients can never overwrite the internal entries.
+	Annotations map[string]string
 }
 
 type Metadata interface {
@@ -50,6 +78,7 @@ type Metadata interface {
 	SetSwampName(swampName name.Name)
 
 	// GetSwampName returns the swamp's name, used to access the swamp directly.
+	// Returns nil if the swamp name is not stored in the metadata yet.
 	GetSwampName() name.Name
 
 	// GetCreatedAt returns the timestamp of metadata creation.
@@ -70,6 +99,14 @@ type Metadata interface {
 	// SetUpdatedAt updates the last modification timestamp to now.
 	SetUpdatedAt()
 
+	// SetAnnotation stores a client annotation. An empty value deletes the annotation.
+	// Returns an error if the key is empty, the key or value is too long, or the swamp already has
+	// MaxAnnotations annotations.
+	SetAnnotation(key, value string) error
+
+	// GetAnnotations returns a copy of all client annotations of the swamp.
+	GetAnnotations() map[string]string
+
 	// Destroy deletes the metadata file and clears the in-memory metadata object.
 	// Useful when the object it was attached to has been deleted and will never be used again.
 	// Note: currently the file system deletes the metadata file via DeleteAllFiles within the swamp folder,
@@ -132,6 +169,9 @@ func (m *metadata) SetSwampName(swampName name.Name) {
 func (m *metadata) GetSwampName() name.Name {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	if m.meta.SwampName == "" {
+		return nil
+	}
 	return name.Load(m.meta.SwampName)
 }
 
@@ -192,6 +232,59 @@ func (m *metadata) SetUpdatedAt() {
 	m.isModified = true
 }
 
+func (m *metadata) SetAnnotation(key, value string) error {
+
+	if key == "" {
+		return ErrAnnotationKeyEmpty
+	}
+	if len(key) > MaxAnnotationKeyLength {
+		return fmt.Errorf("%w: the key is longer than %d bytes", ErrAnnotationTooLong, MaxAnnotationKeyLength)
+	}
+	if len(value) > MaxAnnotationValueLength {
+		return fmt.Errorf("%w: the value is longer than %d bytes", ErrAnnotationTooLong, MaxAnnotationValueLength)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.meta.Annotations[key]
+
+	if value == "" {
+		if ok {
+			delete(m.meta.Annotations, key)
+			m.isModified = true
+		}
+		return nil
+	}
+
+	if ok && existing == value {
+		return nil
+	}
+	if !ok && len(m.meta.Annotations) >= MaxAnnotations {
+		return fmt.Errorf("%w: a swamp can have at most %d annotations", ErrTooManyAnnotations, MaxAnnotations)
+	}
+
+	// the metadata files saved before the annotations were introduced have no annotation map
+	if m.meta.Annotations == nil {
+		m.meta.Annotations = make(map[string]string)
+	}
+	m.meta.Annotations[key] = value
+	m.isModified = true
+
+	return nil
+
+}
+
+func (m *metadata) GetAnnotations() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	annotations := make(map[string]string, len(m.meta.Annotations))
+	for key, value := range m.meta.Annotations {
+		annotations[key] = value
+	}
+	return annotations
+}
+
 // load metadata
 func (m *metadata) load() {
 
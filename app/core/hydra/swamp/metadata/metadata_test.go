@@ -1,11 +1,13 @@
 package metadata
 
 import (
+	"fmt"
 	"github.com/hydraide/hydraide/app/name"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -137,3 +139,46 @@ func TestUpdatedAt(t *testing.T) {
 	assert.Less(t, m2.GetUpdatedAt(), time.Now())
 
 }
+
+func TestAnnotations(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	m := New(tmpDir)
+	m.LoadFromFile()
+
+	require.NoError(t, m.SetAnnotation("owner", "billing-service"))
+	require.NoError(t, m.SetAnnotation("schemaVersion", "3"))
+	assert.Equal(t, map[string]string{"owner": "billing-service", "schemaVersion": "3"}, m.GetAnnotations())
+
+	// the annotations are separated from the internal key-value pairs
+	assert.Equal(t, "", m.GetKey("owner"))
+
+	// the returned map is a copy
+	m.GetAnnotations()["owner"] = "modified"
+	assert.Equal(t, "billing-service", m.GetAnnotations()["owner"])
+
+	// empty value deletes the annotation
+	require.NoError(t, m.SetAnnotation("schemaVersion", ""))
+	assert.Equal(t, map[string]string{"owner": "billing-service"}, m.GetAnnotations())
+
+	// the annotations are persisted
+	m.SaveToFile()
+	m2 := New(tmpDir)
+	m2.LoadFromFile()
+	assert.Equal(t, map[string]string{"owner": "billing-service"}, m2.GetAnnotations())
+}
+
+func TestAnnotationLimits(t *testing.T) {
+	m := New(t.TempDir())
+
+	assert.ErrorIs(t, m.SetAnnotation("", "value"), ErrAnnotationKeyEmpty)
+	assert.ErrorIs(t, m.SetAnnotation(strings.Repeat("k", MaxAnnotationKeyLength+1), "value"), ErrAnnotationTooLong)
+	assert.ErrorIs(t, m.SetAnnotation("key", strings.Repeat("v", MaxAnnotationValueLength+1)), ErrAnnotationTooLong)
+
+	for i := 0; i < MaxAnnotations; i++ {
+		require.NoError(t, m.SetAnnotation(fmt.Sprintf("key-%d", i), "value"))
+	}
+	assert.ErrorIs(t, m.SetAnnotation("one-more", "value"), ErrTooManyAnnotations)
+	// existing annotations can still be modified
+	assert.NoError(t, m.SetAnnotation("key-0", "modified"))
+}
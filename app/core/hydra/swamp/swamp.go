@@ -5,20 +5,30 @@ import (
 	"errors"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/beacon"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/chronicler"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keydirectory"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keyfilter"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/valueindex"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/vigil"
 	"github.com/hydraide/hydraide/app/name"
 	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
 const (
-	ErrorValueIsNotInt   = "the value is not an integer"
-	ErrorValueIsNotFloat = "the value is not a float"
+	ErrorValueIsNotInt    = "the value is not an integer"
+	ErrorValueIsNotFloat  = "the value is not a float"
+	ErrorValueIsNotString = "the value is not a string"
+	ErrorValueIsNotBool   = "the value is not a bool"
 )
 
 type Swamp interface {
@@ -70,6 +80,35 @@ type Swamp interface {
 	// 2. Populating the treasure with data before storing it in the Swamp.
 	CreateTreasure(key string) treasure.Treasure
 
+	// LockKey locks the key for a read-modify-write, e.g. CreateTreasure, setting the content and Save, and returns
+	// the function that unlocks it. Without the lock, two writers of a new key would create two Treasures, and one
+	// of the writes would be lost.
+	//
+	// The keys are locked by stripes, so the writers of different keys do not wait for the lock of each other,
+	// unless their keys are in the same stripe. The increments of the Swamp lock their keys by themselves.
+	//
+	// ⚠️ The lock is not reentrant: do not lock an other key of the Swamp while holding one, and do not call the
+	// increments of the Swamp while holding the lock, because they may be in the same stripe.
+	LockKey(key string) (unlock func())
+
+	// ReserveTreasures reserves room for the given number of new Treasures, if the Treasures of the Swamp and the
+	// reserved ones are not more than max with them. The count and the reservation are done under the same lock, so
+	// two concurrent writers can not both take the last free room. The returned function releases the reservation
+	// after the new Treasures were saved, or their write failed. The total is the number of the Treasures with the
+	// reservation, and it is returned even if the reservation failed.
+	ReserveTreasures(count int, max int) (release func(), total int, ok bool)
+
+	// GetKeyLockStats returns the contention of the key locks of the Swamp since it was opened.
+	GetKeyLockStats() keylock.Stats
+
+	// SetKeyLockCounters sets the shared counters the contention of the key locks is summed to, e.g. by the Hydra
+	// for the metrics of all Swamps.
+	SetKeyLockCounters(counters *keylock.Counters)
+
+	// SetFlushStallCounters sets the shared counters the stalls caused by the writer of the Swamp are summed to, e.g.
+	// by the Hydra for the metrics of all Swamps. Nil stops the counting.
+	SetFlushStallCounters(counters *flushstall.Counters)
+
 	// GetTreasure retrieves a single "Treasure" from a "Swamp" by its unique key.
 	//
 	// This function takes a key string as a parameter, which uniquely identifies the desired treasure within the Swamp.
@@ -126,6 +165,23 @@ type Swamp interface {
 	// - A map of key-value pairs representing the retrieved treasures.
 	GetAll() map[string]treasure.Treasure
 
+	// IterateAll calls the function with every "Treasure" of the "Swamp", until the function returns false.
+	//
+	// Unlike GetAll, it does not build a map of the treasures, so the caller can process a huge Swamp one treasure
+	// at a time, e.g. stream the treasures to a client. The references of the treasures are taken at the start of
+	// the iteration, and the function is called without holding the lock of the Swamp, so the function can take its
+	// time and can read or write the Swamp. The treasures created during the iteration are not visited, the
+	// treasures deleted during the iteration are still visited. The order of the treasures is not defined.
+	//
+	// Example:
+	//     swamp.BeginVigil()
+	//     swamp.IterateAll(func(t treasure.Treasure) bool {
+	//         ... // Do something with the treasure
+	//         return true
+	//     })
+	//     swamp.CeaseVigil()
+	IterateAll(fn func(treasureObj treasure.Treasure) bool)
+
 	// GetTreasuresByBeacon retrieves one or more "Treasures" from a "Swamp" based on a selected Beacon (index).
 	//
 	// This function allows you to query treasures from a Swamp using a Beacon, which is an index that helps in efficient retrieval.
@@ -195,6 +251,117 @@ type Swamp interface {
 	// 2. Real-time data querying and processing for applications with dynamic data.
 	GetTreasuresByBeacon(beaconType BeaconType, beaconOrderType BeaconOrder, from int32, limit int32) ([]treasure.Treasure, error)
 
+	// GetTopTreasures returns the first n treasures of the beacon, e.g. the top 100 scores of a leaderboard.
+	//
+	// The treasures are read directly from the ordered beacon, so the cost of the call is proportional to n,
+	// not to the size of the swamp, once the beacon is built. For the value beacons only the treasures holding
+	// the content type of the beacon are returned, e.g. a BeaconTypeValueInt64 beacon skips the float and string values.
+	//
+	// Returns an error if n is not greater than 0, or ErrorValueBeaconNotSortable if the value beacon can not be
+	// built, because the swamp holds values of other types than the type of the beacon.
+	GetTopTreasures(beaconType BeaconType, beaconOrderType BeaconOrder, n int32) ([]treasure.Treasure, error)
+
+	// GetTreasuresByValue retrieves all "Treasures" from a "Swamp" whose content equals the content of the probe treasure.
+	//
+	// The lookup uses the secondary value index of the Swamp, so it does not scan the Swamp. The value index must be
+	// enabled for the Swamp's pattern at registration time, otherwise the function returns with the
+	// ErrorValueIndexNotEnabled error. The index is built at the first lookup and maintained incrementally by every
+	// later write and delete.
+	//
+	// The probe is a standalone treasure created by the caller and it carries only the content we are looking for.
+	// The content type is part of the comparison, so an int8(1) probe does not match an int64(1) treasure.
+	//
+	// Example:
+	//     // find all users registered with the given email address
+	//     probe := treasure.New(nil)
+	//     guardID := probe.StartTreasureGuard(true)
+	//     probe.SetContentString(guardID, "john@example.com")
+	//     probe.ReleaseTreasureGuard(guardID)
+	//
+	//     userSwamp.BeginVigil()
+	//     users, err := userSwamp.GetTreasuresByValue(probe)
+	//     userSwamp.CeaseVigil()
+	//
+	// Returns:
+	// ([]treasure.Treasure): The treasures holding the value in no particular order. Nil if there is no such treasure.
+	// (error): ErrorValueIndexNotEnabled if the value index is not enabled for the Swamp.
+	GetTreasuresByValue(probe treasure.Treasure) ([]treasure.Treasure, error)
+
+	// IsValueIndexed returns true if the secondary value index is enabled for the Swamp
+	IsValueIndexed() bool
+
+	// SetServerTimestamps turns on or off the server managed timestamps of the Swamp.
+	//
+	// If it is on, the Swamp sets the creation time of every new Treasure and the modification time of every
+	// modified Treasure to the time of the save, overwriting the times set by the callers. It makes the ordering by
+	// these times independent of the clocks of the clients.
+	SetServerTimestamps(enabled bool)
+
+	// IsServerTimestamped returns true if the server managed timestamps are on
+	IsServerTimestamped() bool
+
+	// SetKeyFilter turns on or off the key filter of the Swamp.
+	//
+	// If it is on, the Swamp writes a bloom filter of its keys next to its files when it closes, so the hydra can
+	// answer the existence checks of the missing keys without loading the Swamp again. The key filter applies only to
+	// the Swamps written to the filesystem.
+	SetKeyFilter(enabled bool)
+
+	// SetLazyHydration turns on or off the key directory of the Swamp.
+	//
+	// If it is on, the Swamp writes the directory of the chunk files of its keys next to its files when it closes, so
+	// the hydra can read a few Treasures by loading only their chunks, without loading the Swamp again. The key
+	// directory applies only to the Swamps written to the filesystem.
+	SetLazyHydration(enabled bool)
+
+	// SetBeacons sets which order beacons the Swamp maintains all the time, and how long the others stay in the memory.
+	//
+	// The kept beacons are built right away, and they are never evicted. The other beacons are built at their first
+	// use, like without this setting, but they are evicted from the memory when they were not used for evictAfterIdle,
+	// and rebuilt at their next use. Any value type in kept keeps the value beacons, whatever type they are sorted by.
+	// 0 evictAfterIdle turns off the eviction, so every beacon is kept after its first use.
+	SetBeacons(kept []BeaconType, evictAfterIdle time.Duration)
+
+	// SetHistoryDepth sets how many versions of each Treasure are kept in its history. 0 turns off the history.
+	//
+	// If the history is on, every save of a new or a modified Treasure stores its content as a new version, with the
+	// time of the save and the creator or the modifier of the Treasure. The history is stored in the Treasure itself,
+	// so it is written to the filesystem, and it is loaded back with the Treasure. Use the GetVersions method of the
+	// Treasure to read it.
+	SetHistoryDepth(depth int)
+
+	// GetHistoryDepth returns how many versions of each Treasure are kept in its history. 0 means the history is off
+	GetHistoryDepth() int
+
+	// SetRetention sets the retention policy of the Swamp. The zero Retention turns off the retention.
+	//
+	// The Swamp deletes the Treasures above the limits of the policy every time it writes to the filesystem, and
+	// when it closes, the oldest ones by creation time first. The deletions are sent to the subscribers like any
+	// other deletion. The retention applies only to the Swamps written to the filesystem.
+	SetRetention(retention Retention)
+
+	// GetRetention returns the retention policy of the Swamp
+	GetRetention() Retention
+
+	// SetDefaultExpireAfter sets the expiration time of the Treasures saved without an expiration time, measured
+	// from the save. 0 turns it off.
+	//
+	// Every save of a new or a modified Treasure without an expiration time sets it, so the Treasure expires even
+	// if the client forgot to set its expiration time. The Treasures with an expiration time keep it.
+	SetDefaultExpireAfter(d time.Duration)
+
+	// GetDefaultExpireAfter returns the expiration time of the Treasures saved without an expiration time
+	GetDefaultExpireAfter() time.Duration
+
+	// RevertTreasure sets the content of the Treasure to the content of a version from its history, and saves it.
+	// The revert is a change like any other, so it is stored as a new version, and the subscribers get a modified
+	// event.
+	//
+	// Returns:
+	// (error): ErrorTreasureDoesNotExists if the Treasure does not exist, or treasure.ErrVersionNotFound if the
+	// version is not in the history of the Treasure.
+	RevertTreasure(key string, version uint64, modifiedBy string) error
+
 	// CloneAndDeleteExpiredTreasures retrieves one or more expired Treasures from the Swamp based on their expiration
 	// time and removes them. , Use this function carefully as it deletes the Treasures from the Swamp.
 	//
@@ -247,6 +414,27 @@ type Swamp interface {
 	// 2. Automating scheduled operations, such as sending emails or notifications, based on expiration times.
 	CloneAndDeleteExpiredTreasures(howMany int32) ([]treasure.Treasure, error)
 
+	// LeaseExpiredTreasures retrieves one or more expired Treasures from the Swamp like CloneAndDeleteExpiredTreasures,
+	// but instead of deleting them, it hides them until leaseUntil by setting their ExpirationTime to it.
+	//
+	// The new ExpirationTime of the Treasure is the ID of the lease. The consumer must end the lease with EndLease:
+	// it deletes the Treasure after a successful processing, or makes it visible again after a failure. If the
+	// consumer crashes, the Treasure becomes expired again at leaseUntil, and the next call leases it again.
+	//
+	// Returns:
+	// ([]treasure.Treasure): The clones of the leased Treasures, with the new ExpirationTime.
+	// ([]int64): The ExpirationTime of the Treasures before the lease, in the same order.
+	// (error): An error if any issues occur during the lease. The error will be nil, if no expired Treasures are found.
+	LeaseExpiredTreasures(howMany int32, leaseUntil time.Time) (leased []treasure.Treasure, previousExpirations []int64, err error)
+
+	// EndLease ends the lease of the Treasure, given by LeaseExpiredTreasures. If remove is true, the Treasure is
+	// deleted from the Swamp, otherwise it expires again at visibleAt, so it can be leased again after that.
+	//
+	// Returns:
+	// (error): ErrorLeaseNotFound if the Treasure does not exist, or its ExpirationTime is not the leaseID anymore,
+	// because it was leased again by an other consumer after the lease expired, or it was modified meanwhile.
+	EndLease(key string, leaseID int64, remove bool, visibleAt time.Time) error
+
 	// DeleteTreasure deletes a single "Treasure" from a "Swamp" by its unique key.
 	//
 	// Parameters:
@@ -278,6 +466,26 @@ type Swamp interface {
 	// 1. Securely deleting specific data entries, such as user accounts or records, from the Swamp.
 	DeleteTreasure(key string, shadowDelete bool) error
 
+	// GetShadowDeletedTreasures returns the shadow-deleted Treasures of the Swamp, ordered by their deletion time.
+	//
+	// The shadow-deleted Treasures are not part of the Swamp anymore: they are not counted, they can not be read, and
+	// they are not in the indexes. But their content is kept, also on the filesystem, so they can be listed for
+	// auditing, and restored with RestoreTreasure.
+	//
+	// Returns:
+	// ([]treasure.Treasure): The shadow-deleted Treasures, with their DeletedAt and DeletedBy fields. Do not modify them.
+	GetShadowDeletedTreasures() []treasure.Treasure
+
+	// RestoreTreasure puts a shadow-deleted Treasure back to the Swamp with the content it had before the deletion.
+	// The restored Treasure is sent to the subscribers as a new Treasure.
+	//
+	// If a new Treasure was created with the same key after the shadow delete, the shadow-deleted version is dropped,
+	// so it can not be restored anymore.
+	//
+	// Returns:
+	// (error): ErrorTreasureDoesNotExists if there is no shadow-deleted Treasure with the key.
+	RestoreTreasure(key string) error
+
 	// CloneTreasures returns a clone of the main beaconKey map.
 	//
 	// Real-world use-case:
@@ -381,6 +589,27 @@ type Swamp interface {
 	// (int): The number of treasures in the swamp.
 	CountTreasures() int
 
+	// PeekTreasureCount returns the number of treasures in the swamp, like the CountTreasures, but it is not an
+	// interaction with the swamp, so a periodic report of the open swamps does not keep them open.
+	PeekTreasureCount() int
+
+	// CountTreasuresByFilter returns the number of treasures in the swamp matching the filter.
+	//
+	// Unlike CountTreasures, it walks all treasures of the swamp, so use it only if the filter is needed.
+	// A nil filter counts all treasures, the same as CountTreasures.
+	//
+	// Example Usage:
+	// ----------------
+	// Count the overdue jobs of a queue swamp without shifting them:
+	//
+	//	   mySwamp.BeginVigil()
+	//     overdue := mySwamp.CountTreasuresByFilter(&CountFilter{ExpiredOnly: true})
+	//     mySwamp.CeaseVigil()
+	//
+	// Returns:
+	// (int): The number of treasures matching the filter.
+	CountTreasuresByFilter(filter *CountFilter) int
+
 	// IsClosing returns true if the swamp is currently in the process of closing.
 	//
 	// Real-world use-case:
@@ -446,6 +675,54 @@ type Swamp interface {
 	// - This function should be called to ensure that valuable data is not lost when the system is halted or restarted.
 	WriteTreasuresToFilesystem()
 
+	// Compact rewrites the chunk files of the swamp that are mostly dead data.
+	//
+	// Real-world use-case:
+	// - The deleted treasures stay in their chunk files as tombstones, so after heavy delete churn the swamp folder
+	//   contains many chunks with only a few live treasures. They waste disk space, and the hydration of the swamp has
+	//   to decode all of them. The compaction rewrites the live treasures of the files, where the ratio of the live
+	//   records is below minLiveRatio, into as few new files as possible.
+	//
+	// Example Usage:
+	// ----------------
+	//
+	//     mySwamp.BeginVigil()
+	//     result, err := mySwamp.Compact(0.5)
+	//     mySwamp.CeaseVigil()
+	//
+	// Notes:
+	// - The treasures waiting for the writer are written to the filesystem first.
+	// - The in-memory swamps have no files, so the compaction does nothing.
+	//
+	// Returns:
+	// (chronicler.CompactionResult): The number of the rewritten and the new files, the removed treasures and the reclaimed bytes.
+	// (error): An error if the live ratio is invalid, the swamp is closing, or the files can not be written.
+	Compact(minLiveRatio float64) (chronicler.CompactionResult, error)
+
+	// Freeze writes the waiting treasures to the filesystem, and runs fn while the files of the swamp do not change.
+	//
+	// Real-world use-case:
+	// - The backup copies the files of the swamp. A file written during the copy would be saved half old and half
+	//   new, so the files are frozen until fn returns. The swamp still serves the reads and the writes from the
+	//   memory, the frozen changes are written to the filesystem after fn.
+	//
+	// Example Usage:
+	// ----------------
+	//
+	//     mySwamp.BeginVigil()
+	//     err := mySwamp.Freeze(func() error {
+	//         return copyFolder(mySwamp.GetChronicler().GetSwampAbsPath(), target)
+	//     })
+	//     mySwamp.CeaseVigil()
+	//
+	// Notes:
+	// - Keep fn short, the swamp can not be closed while it runs.
+	// - The in-memory swamps have no files, fn runs without writing anything.
+	//
+	// Returns:
+	// (error): The error of fn, or an error if the swamp is closing.
+	Freeze(fn func() error) error
+
 	// GetChronicler returns the Chronicler interface associated with the swamp.
 	//
 	// Real-world use-case:
@@ -559,6 +836,24 @@ type Swamp interface {
 	//     mySwamp.StopSendingEvents()
 	StopSendingEvents()
 
+	// Snapshot calls the function while no Treasure can be saved or deleted in the swamp, so no event is sent
+	// meanwhile. The saves and the deletes started before the call are finished first, together with their events.
+	//
+	// Real-world use-case:
+	// - Reading all Treasures of the swamp and subscribing to its events at the same point, so the subscriber gets
+	//   every change exactly once, either in the snapshot or as an event.
+	//
+	// ⚠️ The function must not save or delete Treasures of the same swamp, because it would wait for itself.
+	//
+	// Example Usage:
+	// ----------------
+	//
+	//     mySwamp.Snapshot(func() {
+	//         treasures, _ = mySwamp.GetTreasuresByBeacon(BeaconTypeCreationTime, IndexOrderAsc, 0, 0)
+	//         subscribe()
+	//     })
+	Snapshot(fn func())
+
 	// GetBeacon one beacon from the swamp by the beacon type and order.
 	// This function useful if we want to iterate over the beacon and get the treasures from it
 	GetBeacon(beaconType BeaconType, order BeaconOrder) beacon.Beacon
@@ -647,10 +942,49 @@ type Swamp interface {
 	// }
 	// fmt.Println("New Value:", newValue, "Incremented:", incremented)
 	IncrementFloat64(key string, f float64, condition *IncrementFloat64Condition) (newValue float64, incremented bool, err error)
+
+	// SetIfLater stores the UNIX timestamp (in seconds) in the key, if it is later than the stored timestamp, or the
+	// key does not exist. Returns the stored timestamp after the call, and whether it was set.
+	//
+	// The stored value must be an int64, otherwise an ErrorValueIsNotInt error is returned.
+	SetIfLater(key string, timestamp int64) (storedTimestamp int64, isSet bool, err error)
+
+	// AddDuration adds the seconds to the UNIX timestamp (in seconds) stored in the key, and returns the new timestamp.
+	//
+	// If the key does not exist, the seconds are added to the current time. With fromNowIfPast, they are added to the
+	// current time also if the stored timestamp is in the past, e.g. to renew an expired lease from now.
+	//
+	// The stored value must be an int64, otherwise an ErrorValueIsNotInt error is returned.
+	AddDuration(key string, seconds int64, fromNowIfPast bool) (newTimestamp int64, err error)
+
+	// SetStringIf stores the string value in the key, if the optional condition is satisfied. The compare and the set
+	// are atomic, so a status can be moved from "pending" to "processing" by only one of the concurrent writers.
+	//
+	// Returns the stored value after the call (empty if the key does not exist), and whether the value was set.
+	// The stored value must be a string, otherwise an ErrorValueIsNotString error is returned.
+	//
+	// Example:
+	//
+	// condition := &SetStringCondition{
+	//     Operator: SetIfOperatorEqual,
+	//     Value:    "pending",
+	// }
+	// storedValue, isSet, err := s.SetStringIf("job-42", "processing", condition)
+	SetStringIf(key string, value string, condition *SetStringCondition) (storedValue string, isSet bool, err error)
+
+	// SetBoolIf same logic as SetStringIf but for bool values. The stored value must be a bool, otherwise an
+	// ErrorValueIsNotBool error is returned.
+	SetBoolIf(key string, value bool, condition *SetBoolCondition) (storedValue bool, isSet bool, err error)
 }
 
 const (
 	ErrorTreasureDoesNotExists = "treasure does not exists"
+	ErrorValueIndexNotEnabled  = "value index is not enabled for the swamp"
+	// ErrorLeaseNotFound is returned if the lease of the treasure is not valid anymore
+	ErrorLeaseNotFound = "the lease of the treasure does not exist or it was taken over"
+	// ErrorValueBeaconNotSortable is returned if a value beacon can not be built, because not all values of the
+	// swamp have the type of the beacon
+	ErrorValueBeaconNotSortable = "the swamp contains values of other types than the type of the value index"
 )
 
 // BeaconType is used to define the type of the Beacon.
@@ -725,6 +1059,9 @@ const (
 //   - EventTime (int64): The time of the event in Unix time (milliseconds).
 //   - StatusType (TreasureStatus): The type of the event that occurred, which can indicate whether it was a creation,
 //     modification, deletion, or no change to a treasure.
+//   - IslandID (uint64): The island of the Swamp, set by the Hydra.
+//   - Destroyed (bool): True if the whole Swamp is destroyed. The event has no treasure, and its StatusType is
+//     StatusVoid, so the subscribers of the treasure changes can skip it.
 //
 // Use-cases:
 // 1. Create a realtime chat application where users can see when other users join or leave the chat room or send messages.
@@ -732,11 +1069,14 @@ const (
 // 2. Providing detailed information about changes to treasures and their timestamps.
 type Event struct {
 	SwampName       name.Name               // name of the swamp
-	Treasure        treasure.Treasure       // the new treasure that is added to the swamp
+	Treasure        treasure.Treasure       // a copy of the new or modified treasure at the time of the event
 	OldTreasure     treasure.Treasure       // the treasure itself that is modified or deleted
 	DeletedTreasure treasure.Treasure       // the treasure that is deleted
-	EventTime       int64                   // the time of the event in unix time (millisecond)
+	EventTime       int64                   // the time of the event in unix time (nanosecond)
 	StatusType      treasure.TreasureStatus // type of the event that is happened
+	Sequence        uint64                  // the sequence number of the event in the swamp, set by the hydra
+	IslandID        uint64                  // the island of the swamp, set by the hydra
+	Destroyed       bool                    // the swamp is destroyed with all of its treasures
 }
 
 // Info is a structure used to retrieve real-time information about a Swamp, specifically the count of treasures it contains.
@@ -775,10 +1115,27 @@ type swamp struct {
 	// beaconKey is the main index of the swamp.
 	// this is an ordered index by the creation time of the Treasures
 	beaconKey beacon.Beacon // this is the main index of the swamp.
+	// shadowBeacon holds the shadow-deleted treasures by their keys. They are not in the beaconKey and the other beacons
+	shadowBeacon beacon.Beacon
 
 	closeAfterIdle      time.Duration // the minimum time that the swamp is in the memory
 	lastInteractionTime int64         // the last time that the swamp is interacted with the client
 	writeInterval       time.Duration // the interval that the swamp writes the Treasures to the chroniclerInterface
+	writeBatchSize      int           // the max number of the Treasures written to the chroniclerInterface at once, 0 means all
+
+	// keyLocks serialize the read-modify-write operations of the same key, see LockKey
+	keyLocks keylock.Locks
+	// reservedTreasures is the number of the new Treasures reserved by the writers, see ReserveTreasures. Guarded by
+	// reservationMu
+	reservedTreasures int
+	reservationMu     sync.Mutex
+	// flushStallCounters are the counters of the stalls caused by the writer, see SetFlushStallCounters
+	flushStallCounters atomic.Pointer[flushstall.Counters]
+
+	// the saves and the deletes hold it for reading until their event is sent, the Snapshot holds it for writing
+	eventMu sync.RWMutex
+	// the leases and the ends of the leases are serialized, so a lease can not be ended while it is taken over
+	leaseMu sync.Mutex
 
 	// all beaconKey are sorted by the following fields
 	keyBeaconASC             beacon.Beacon // ordered list of the Treasures by the ascendant BeaconKey field
@@ -792,6 +1149,21 @@ type swamp struct {
 
 	valueBeaconASC  beacon.Beacon // ordered list of the Treasures by the ascendant Value field
 	valueBeaconDESC beacon.Beacon // ordered list of the Treasures by the descendant Value field
+	// valueBeaconType is the value type the value beacons are sorted by. The value beacons are shared by all value
+	// types, so they are rebuilt when a query asks for another type. Guarded by valueBeaconMu.
+	valueBeaconType BeaconType
+	valueBeaconMu   sync.Mutex
+
+	// beaconsMu is held for reading by every use and update of the order beacons above, and for writing by the
+	// eviction of the idle beacons. The eviction only tries to lock it, so the nested read locks can not deadlock.
+	beaconsMu            sync.RWMutex
+	keptBeacons          int32                  // the bit mask of the beacon slots that are never evicted
+	beaconEvictAfterIdle int64                  // the idle time of the evicted beacons in nanoseconds, 0 if off
+	beaconLastUsed       [beaconSlotCount]int64 // the unix nano time of the last use of the beacon slots
+
+	// valueIndex is the secondary hash index from the values to the keys of the Treasures.
+	// nil if the value index is not enabled for the swamp
+	valueIndex valueindex.ValueIndex
 
 	// -------------------  the following fields are used for the unordered list -------------------
 	// treasuresWaitingForWriter just the key of the treasures that are waiting for the writer to write them to the chroniclerInterface
@@ -811,18 +1183,43 @@ type swamp struct {
 
 	inMemorySwamp int32 // if the swamp is an in-memory swamp we don't write it to the filesystem
 
+	serverTimestamps int32 // if the swamp sets the creation and modification times of the treasures
+	keyFilter        int32 // if the swamp writes the bloom filter of its keys when it closes
+	lazyHydration    int32 // if the swamp writes the directory of the chunk files of its keys when it closes
+	historyDepth     int32 // the number of the versions kept in the history of the treasures, 0 if the history is off
+
+	retention          Retention // the retention policy of the swamp, guarded by mu
+	defaultExpireAfter int64     // the expiration time of the treasures saved without one in nanoseconds, 0 if off
+
 	metadataInterface metadata.Metadata // the metadata interface that the swamp is using
 }
 
+// Retention is the retention policy of the swamp. The zero values mean no limit.
+type Retention struct {
+	// MaxAge is the max age of the treasures by their creation time. The treasures without creation time are never
+	// deleted by the age.
+	MaxAge time.Duration
+	// MaxTreasures is the max number of the treasures. The treasures without creation time are the oldest ones.
+	MaxTreasures int
+	// MaxSizeByte is the max size of the chunk files of the swamp, without the actual file. The oldest treasures are
+	// deleted in proportion to the excess, and the files are compacted.
+	MaxSizeByte int64
+}
+
+// retentionPageSize is the number of the treasures read from the creation time beacon at once by the retention
+const retentionPageSize = 1000
+
 type FilesystemSettings struct {
 	ChroniclerInterface chronicler.Chronicler
 	WriteInterval       time.Duration
+	// WriteBatchSize is the max number of treasures handed to the chronicler in one write. 0 means all waiting treasures.
+	WriteBatchSize int
 }
 
 // New creates a new swamp object
 func New(name name.Name, closeAfterIdle time.Duration, filesystemSettings *FilesystemSettings,
 	swampEventCallback func(event *Event), swampInfoCallback func(info *Info), swampCloseCallback func(n name.Name),
-	metadataInterface metadata.Metadata) Swamp {
+	metadataInterface metadata.Metadata, valueIndexed bool) Swamp {
 
 	s := &swamp{
 		name:                name,
@@ -833,10 +1230,12 @@ func New(name name.Name, closeAfterIdle time.Duration, filesystemSettings *Files
 		swampCloseCallback:  swampCloseCallback,
 		closeAfterIdle:      closeAfterIdle,
 		metadataInterface:   metadataInterface,
+		keyLocks:            keylock.New(keylock.DefaultStripes),
 	}
 
 	/// IMPORTANT the w.expirationTimeBeaconASC will be nil if orderType is unordered!!!!
 	s.beaconKey = beacon.New()
+	s.shadowBeacon = beacon.New()
 
 	if filesystemSettings == nil {
 		// the swamp is an IN-Memory swamp
@@ -844,6 +1243,7 @@ func New(name name.Name, closeAfterIdle time.Duration, filesystemSettings *Files
 	} else {
 		// the swamp is permanent swamp. The data will be written to the filesystem and loaded from the filesystem
 		s.writeInterval = filesystemSettings.WriteInterval
+		s.writeBatchSize = filesystemSettings.WriteBatchSize
 		atomic.StoreInt32(&s.inMemorySwamp, 0)
 		s.chroniclerInterface = filesystemSettings.ChroniclerInterface
 		// regisztráljuk a chroniclerInterface-be azt a funkciót, amit a chronicler akkor hív meg, amikor
@@ -853,7 +1253,7 @@ func New(name name.Name, closeAfterIdle time.Duration, filesystemSettings *Files
 		// load the swamp from the chroniclerInterface while the swamp is created
 		s.chroniclerInterface.RegisterSaveFunction(s.SaveFunction)
 		// The swamp is Permanent-Type so we need to load the data from the filesystem
-		s.chroniclerInterface.Load(s.beaconKey)
+		s.chroniclerInterface.Load(s.beaconKey, s.shadowBeacon)
 	}
 
 	s.goRoutineContext, s.goRoutineCancelFunction = context.WithCancel(context.Background())
@@ -883,6 +1283,11 @@ func New(name name.Name, closeAfterIdle time.Duration, filesystemSettings *Files
 	s.valueBeaconDESC = beacon.New()
 	s.valueBeaconDESC.SetIsOrdered(true)
 
+	// create the value index only if it is enabled for the swamp
+	if valueIndexed {
+		s.valueIndex = valueindex.New()
+	}
+
 	// create beacon for the treasuresWaitingForWriter
 	s.treasuresWaitingForWriter = beacon.New()
 	// the treasuresWaitingForWriter is not ordered, because the ordering is not important here
@@ -951,6 +1356,10 @@ const (
 )
 
 func (s *swamp) IncrementUint8(key string, i uint8, condition *IncrementUInt8Condition) (newValue uint8, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1023,6 +1432,10 @@ func (s *swamp) IncrementUint8(key string, i uint8, condition *IncrementUInt8Con
 	return contentInt, true, nil
 }
 func (s *swamp) IncrementUint16(key string, i uint16, condition *IncrementUInt16Condition) (newValue uint16, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1095,6 +1508,10 @@ func (s *swamp) IncrementUint16(key string, i uint16, condition *IncrementUInt16
 	return contentInt, true, nil
 }
 func (s *swamp) IncrementUint32(key string, i uint32, condition *IncrementUInt32Condition) (newValue uint32, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1167,6 +1584,10 @@ func (s *swamp) IncrementUint32(key string, i uint32, condition *IncrementUInt32
 	return contentInt, true, nil
 }
 func (s *swamp) IncrementUint64(key string, i uint64, condition *IncrementUInt64Condition) (newValue uint64, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1239,6 +1660,10 @@ func (s *swamp) IncrementUint64(key string, i uint64, condition *IncrementUInt64
 	return contentInt, true, nil
 }
 func (s *swamp) IncrementInt8(key string, i int8, condition *IncrementInt8Condition) (newValue int8, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1311,6 +1736,10 @@ func (s *swamp) IncrementInt8(key string, i int8, condition *IncrementInt8Condit
 	return contentInt, true, nil
 }
 func (s *swamp) IncrementInt16(key string, i int16, condition *IncrementInt16Condition) (newValue int16, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1383,6 +1812,10 @@ func (s *swamp) IncrementInt16(key string, i int16, condition *IncrementInt16Con
 	return contentInt, true, nil
 }
 func (s *swamp) IncrementInt32(key string, i int32, condition *IncrementInt32Condition) (newValue int32, incremented bool, err error) {
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1457,6 +1890,10 @@ func (s *swamp) IncrementInt32(key string, i int32, condition *IncrementInt32Con
 
 func (s *swamp) IncrementInt64(key string, i int64, condition *IncrementInt64Condition) (newValue int64, incremented bool, err error) {
 
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1530,6 +1967,176 @@ func (s *swamp) IncrementInt64(key string, i int64, condition *IncrementInt64Con
 
 }
 
+func (s *swamp) SetIfLater(key string, timestamp int64) (storedTimestamp int64, isSet bool, err error) {
+
+	// the creation of a new treasure and the comparison must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
+	treasureObj, stored, exists, err := s.timestampTreasure(key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if exists && timestamp <= stored {
+		return stored, false, nil
+	}
+
+	guardID := treasureObj.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+	treasureObj.SetContentInt64(guardID, timestamp)
+	treasureObj.Save(guardID)
+
+	return timestamp, true, nil
+
+}
+
+func (s *swamp) AddDuration(key string, seconds int64, fromNowIfPast bool) (newTimestamp int64, err error) {
+
+	// the creation of a new treasure and the addition must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
+	treasureObj, stored, exists, err := s.timestampTreasure(key)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().Unix()
+	if !exists || (fromNowIfPast && stored < now) {
+		stored = now
+	}
+	newTimestamp = stored + seconds
+
+	guardID := treasureObj.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+	treasureObj.SetContentInt64(guardID, newTimestamp)
+	treasureObj.Save(guardID)
+
+	return newTimestamp, nil
+
+}
+
+func (s *swamp) SetStringIf(key string, value string, condition *SetStringCondition) (storedValue string, isSet bool, err error) {
+
+	// the check of the condition and the set must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
+	treasureObj := s.beaconKey.Get(key)
+	if treasureObj != nil {
+		if treasureObj.GetContentType() != treasure.ContentTypeString {
+			return "", false, errors.New(ErrorValueIsNotString)
+		}
+		if storedValue, err = treasureObj.GetContentString(); err != nil {
+			return "", false, errors.New(ErrorValueIsNotString)
+		}
+	}
+
+	if condition != nil && !condition.Operator.isSatisfied(treasureObj != nil, storedValue == condition.Value) {
+		return storedValue, false, nil
+	}
+
+	if treasureObj == nil {
+		treasureObj = s.CreateTreasure(key)
+	}
+
+	guardID := treasureObj.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+	treasureObj.SetContentString(guardID, value)
+	treasureObj.Save(guardID)
+
+	return value, true, nil
+
+}
+
+func (s *swamp) SetBoolIf(key string, value bool, condition *SetBoolCondition) (storedValue bool, isSet bool, err error) {
+
+	// the check of the condition and the set must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
+	treasureObj := s.beaconKey.Get(key)
+	if treasureObj != nil {
+		if treasureObj.GetContentType() != treasure.ContentTypeBoolean {
+			return false, false, errors.New(ErrorValueIsNotBool)
+		}
+		if storedValue, err = treasureObj.GetContentBool(); err != nil {
+			return false, false, errors.New(ErrorValueIsNotBool)
+		}
+	}
+
+	if condition != nil && !condition.Operator.isSatisfied(treasureObj != nil, storedValue == condition.Value) {
+		return storedValue, false, nil
+	}
+
+	if treasureObj == nil {
+		treasureObj = s.CreateTreasure(key)
+	}
+
+	guardID := treasureObj.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+	treasureObj.SetContentBool(guardID, value)
+	treasureObj.Save(guardID)
+
+	return value, true, nil
+
+}
+
+// timestampTreasure returns the treasure of the key with its int64 timestamp, or a new treasure if the key does not
+// exist. The key must be locked by the caller.
+func (s *swamp) timestampTreasure(key string) (treasureObj treasure.Treasure, timestamp int64, exists bool, err error) {
+
+	treasureObj = s.beaconKey.Get(key)
+	if treasureObj == nil {
+		return s.CreateTreasure(key), 0, false, nil
+	}
+
+	if treasureObj.GetContentType() != treasure.ContentTypeInt64 {
+		return nil, 0, false, errors.New(ErrorValueIsNotInt)
+	}
+
+	timestamp, err = treasureObj.GetContentInt64()
+	if err != nil {
+		return nil, 0, false, errors.New(ErrorValueIsNotInt)
+	}
+
+	return treasureObj, timestamp, true, nil
+
+}
+
+// SetIfOperator is the condition of the SetStringIf and SetBoolIf functions
+type SetIfOperator int
+
+const (
+	// SetIfOperatorEqual is satisfied if the key exists, and its value is equal to the value of the condition
+	SetIfOperatorEqual SetIfOperator = 1
+	// SetIfOperatorNotExists is satisfied if the key does not exist. The value of the condition is ignored
+	SetIfOperatorNotExists SetIfOperator = 2
+)
+
+// isSatisfied checks the operator against the existence of the key, and the equality of its value with the value of
+// the condition
+func (o SetIfOperator) isSatisfied(exists bool, equals bool) bool {
+	switch o {
+	case SetIfOperatorEqual:
+		return exists && equals
+	case SetIfOperatorNotExists:
+		return !exists
+	}
+	return false
+}
+
+type SetStringCondition struct {
+	Operator SetIfOperator
+	Value    string
+}
+
+type SetBoolCondition struct {
+	Operator SetIfOperator
+	Value    bool
+}
+
 type IncrementFloat32Condition struct {
 	RelationalOperator RelationalOperator
 	Value              float32
@@ -1542,6 +2149,10 @@ type IncrementFloat64Condition struct {
 
 func (s *swamp) IncrementFloat32(key string, f float32, condition *IncrementFloat32Condition) (newValue float32, incremented bool, err error) {
 
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1617,6 +2228,10 @@ func (s *swamp) IncrementFloat32(key string, f float32, condition *IncrementFloa
 
 func (s *swamp) IncrementFloat64(key string, f float64, condition *IncrementFloat64Condition) (newValue float64, incremented bool, err error) {
 
+	// the creation of a new treasure and the increment must not be split by an other writer of the key
+	unlock := s.keyLocks.Lock(key)
+	defer unlock()
+
 	// get the key treasure by its key
 	treasureObj := s.beaconKey.Get(key)
 	// ha a treasure nem létezik még, akkor létrehozzuk azt
@@ -1692,6 +2307,9 @@ func (s *swamp) IncrementFloat64(key string, f float64, condition *IncrementFloa
 
 func (s *swamp) GetBeacon(beaconType BeaconType, order BeaconOrder) beacon.Beacon {
 
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
 	switch beaconType {
 	case BeaconTypeCreationTime:
 		s.buildBeacon(s.creationTimeBeaconASC, s.creationTimeBeaconDESC, BeaconTypeCreationTime)
@@ -1712,7 +2330,7 @@ func (s *swamp) GetBeacon(beaconType BeaconType, order BeaconOrder) beacon.Beaco
 		}
 		return s.updateTimeBeaconDESC
 	case BeaconTypeValueInt64, BeaconTypeValueFloat64, BeaconTypeValueString:
-		s.buildBeacon(s.valueBeaconASC, s.valueBeaconDESC, BeaconTypeValueInt64)
+		s.buildValueBeacon(beaconType)
 		if order == IndexOrderAsc {
 			return s.valueBeaconASC
 		}
@@ -1757,6 +2375,49 @@ func (s *swamp) CountTreasuresWaitingForWriter() int {
 	return s.treasuresWaitingForWriter.Count()
 }
 
+// LockKey locks the key of the swamp until the returned function is called
+func (s *swamp) LockKey(key string) func() {
+	return s.keyLocks.Lock(key)
+}
+
+// ReserveTreasures reserves room for the new treasures of a writer, if the swamp does not exceed max with them
+func (s *swamp) ReserveTreasures(count int, max int) (func(), int, bool) {
+
+	s.reservationMu.Lock()
+	defer s.reservationMu.Unlock()
+
+	total := s.beaconKey.Count() + s.reservedTreasures + count
+	if total > max {
+		return nil, total, false
+	}
+	s.reservedTreasures += count
+
+	var releaseOnce sync.Once
+	return func() {
+		releaseOnce.Do(func() {
+			s.reservationMu.Lock()
+			defer s.reservationMu.Unlock()
+			s.reservedTreasures -= count
+		})
+	}, total, true
+
+}
+
+// GetKeyLockStats returns the contention of the key locks of the swamp since it was opened
+func (s *swamp) GetKeyLockStats() keylock.Stats {
+	return s.keyLocks.Stats()
+}
+
+// SetKeyLockCounters sets the shared counters the contention of the key locks is summed to
+func (s *swamp) SetKeyLockCounters(counters *keylock.Counters) {
+	s.keyLocks.SetCounters(counters)
+}
+
+// SetFlushStallCounters sets the shared counters the stalls caused by the writer of the swamp are summed to
+func (s *swamp) SetFlushStallCounters(counters *flushstall.Counters) {
+	s.flushStallCounters.Store(counters)
+}
+
 // CreateTreasure creates a new Treasure object if it is not existing in the swamp or returns with the existing one.
 func (s *swamp) CreateTreasure(key string) treasure.Treasure {
 
@@ -1777,6 +2438,10 @@ func (s *swamp) CreateTreasure(key string) treasure.Treasure {
 
 func (s *swamp) SaveFunction(t treasure.Treasure, guardID guard.ID) treasure.TreasureStatus {
 
+	// the change and its event must not be split by a snapshot
+	s.eventMu.RLock()
+	defer s.eventMu.RUnlock()
+
 	// set the last interaction time to the current time
 	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
 
@@ -1786,6 +2451,29 @@ func (s *swamp) SaveFunction(t treasure.Treasure, guardID guard.ID) treasure.Tre
 	// and the treasure is totally new
 	if existedTreasureObj == nil {
 
+		// the new treasure replaces the shadow-deleted treasure with the same key, and it overwrites its record in the
+		// file, so the key is stored only once
+		if shadowTreasure := s.shadowBeacon.Get(t.GetKey()); shadowTreasure != nil && shadowTreasure != t {
+			s.shadowBeacon.Delete(t.GetKey())
+			s.treasuresWaitingForWriter.Delete(t.GetKey())
+			if fileName := shadowTreasure.GetFileName(); fileName != nil && t.GetFileName() == nil {
+				t.BodySetFileName(guardID, *fileName)
+			}
+		}
+
+		// the creation time must be set before the treasure is added to the beacons
+		// a restored treasure keeps its original creation time, its deletion marks were removed by the restore
+		if atomic.LoadInt32(&s.serverTimestamps) == 1 && !t.IsDeletedAtChanged() {
+			t.SetCreatedAt(guardID, time.Now())
+		}
+
+		// the expiration time must be set before the treasure is added to the beacons, and before its version is stored
+		s.setDefaultExpiration(t, guardID)
+
+		if depth := atomic.LoadInt32(&s.historyDepth); depth > 0 {
+			t.AppendVersion(guardID, time.Now().UnixNano(), t.GetCreatedBy(), int(depth))
+		}
+
 		// add the treasure to the treasuresWaitingForWriter index
 		s.treasuresWaitingForWriter.Add(t)
 
@@ -1793,7 +2481,7 @@ func (s *swamp) SaveFunction(t treasure.Treasure, guardID guard.ID) treasure.Tre
 		s.beaconKey.Add(t)
 		// add treasure to all other beacons if needed
 		s.addTreasureToBeacons(t)
-		s.sendEventToHydra(t, nil, treasure.StatusNew)
+		s.sendEventToHydra(t, nil, treasure.StatusNew, guardID)
 		s.sendSwampInfo()
 
 		// immediately write the treasure to the chroniclerInterface if the write interval is 0
@@ -1820,6 +2508,23 @@ func (s *swamp) SaveFunction(t treasure.Treasure, guardID guard.ID) treasure.Tre
 		t.IsCreatedAtChanged() || t.IsCreatedByChanged() || t.IsDeletedAtChanged() ||
 		t.IsDeletedByChanged() || t.IsModifiedAtChanged() || t.IsModifiedByChanged() {
 
+		if atomic.LoadInt32(&s.serverTimestamps) == 1 {
+			t.SetModifiedAt(guardID, time.Now())
+		}
+
+		// the treasure had no expiration time, so it is not in the beacons by the expiration time yet
+		if s.setDefaultExpiration(t, guardID) {
+			s.beaconsMu.RLock()
+			s.deleteTreasureIfBeaconInitialized(s.expirationTimeBeaconASC, t.GetKey())
+			s.deleteTreasureIfBeaconInitialized(s.expirationTimeBeaconDESC, t.GetKey())
+			s.addToExpirationTimeBeacon(t)
+			s.beaconsMu.RUnlock()
+		}
+
+		if depth := atomic.LoadInt32(&s.historyDepth); depth > 0 {
+			t.AppendVersion(guardID, time.Now().UnixNano(), t.GetModifiedBy(), int(depth))
+		}
+
 		// if the content type changed...
 		if t.IsContentTypeChanged() {
 			// delete the treasure from the beacons
@@ -1828,13 +2533,18 @@ func (s *swamp) SaveFunction(t treasure.Treasure, guardID guard.ID) treasure.Tre
 			if t.GetContentType() != treasure.ContentTypeVoid {
 				s.addTreasureToBeacons(t)
 			}
+		} else if t.IsContentChanged() || t.IsCreatedAtChanged() || t.IsModifiedAtChanged() || t.IsExpirationTimeChanged() {
+			// the beacons are not sorted again, so the modified treasure is moved to its new position in them, and the
+			// value index follows the new content
+			s.deleteTreasureFromBeacons(t.GetKey())
+			s.addTreasureToBeacons(t)
 		}
 
 		// the treasure is modified, we need to add it to the swamp and write it to the chroniclerInterface
 		s.treasuresWaitingForWriter.Add(t)
 
 		// send the event to the hydra
-		s.sendEventToHydra(t, existedTreasureObj, treasure.StatusModified)
+		s.sendEventToHydra(t, existedTreasureObj, treasure.StatusModified, guardID)
 
 		// immediately write the treasure to the chroniclerInterface if the write interval is 0
 		s.mu.RLock()
@@ -1883,8 +2593,19 @@ func (s *swamp) Close() {
 		s.chroniclerInterface.DontSendFilePointer()
 		// write files to the filesystem that are waiting for the writer
 		s.fileWriterHandler(true)
+		// the treasure count is reported by the usage of the closed swamp
+		s.metadataInterface.SetKey(metadata.KeyTreasureCount, strconv.Itoa(s.beaconKey.Count()))
 		// save metadata to the filesystem if there is any changes
 		s.metadataInterface.SaveToFile()
+		// flush the files the fsync policy has not flushed yet
+		s.chroniclerInterface.Sync()
+		// the key filter is written after the last file, so it never misses a written key
+		if atomic.LoadInt32(&s.keyFilter) == 1 {
+			s.saveKeyFilter()
+		}
+		if atomic.LoadInt32(&s.lazyHydration) == 1 {
+			s.saveKeyDirectory()
+		}
 	}
 
 	// megvárjuk a bezárás előtt, hogy a chronicler minden adatot kiírjon a filerendszerbe, különben lehet olyan, hogy
@@ -1914,6 +2635,9 @@ func (s *swamp) Destroy() {
 
 	atomic.StoreInt32(&s.closing, 1)
 
+	// the subscribers, e.g. the write-ahead log, learn that all treasures of the swamp are gone
+	s.sendDestroyedEventToHydra()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -1979,6 +2703,15 @@ func (s *swamp) StopSendingInformation() {
 	atomic.StoreInt32(&s.isInformationSendingActive, 0)
 }
 
+// Snapshot calls the function while no treasure can be saved or deleted in the swamp
+func (s *swamp) Snapshot(fn func()) {
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+	fn()
+}
+
 // StartSendingEvents is a function that starts sending events about the swamp to the client if the client is subscribed to it
 func (s *swamp) StartSendingEvents() {
 	// set the last interaction time to the current time
@@ -2005,6 +2738,9 @@ func (s *swamp) GetTreasuresByBeacon(beaconType BeaconType, beaconOrderType Beac
 		limit = int32(s.beaconKey.Count())
 	}
 
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
 	var selectedTreasures []treasure.Treasure
 	var err error
 	switch beaconType {
@@ -2034,6 +2770,273 @@ func (s *swamp) GetTreasuresByBeacon(beaconType BeaconType, beaconOrderType Beac
 
 }
 
+// GetTopTreasures returns the first n treasures of the beacon directly from the ordered beacon
+func (s *swamp) GetTopTreasures(beaconType BeaconType, beaconOrderType BeaconOrder, n int32) ([]treasure.Treasure, error) {
+
+	if n <= 0 {
+		return nil, errors.New("n must be greater than 0")
+	}
+
+	contentType, isValueBeacon := valueBeaconContentType(beaconType)
+	if !isValueBeacon {
+		// the key and metadata beacons contain every treasure, so the first n elements are the top n
+		return s.GetTreasuresByBeacon(beaconType, beaconOrderType, 0, n)
+	}
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
+	s.buildValueBeacon(beaconType)
+
+	var b beacon.Beacon
+	switch beaconOrderType {
+	case IndexOrderAsc:
+		b = s.valueBeaconASC
+	case IndexOrderDesc:
+		b = s.valueBeaconDESC
+	default:
+		return nil, errors.New("invalid order")
+	}
+
+	// the beacon stays uninitialized if it can not be sorted, because some values have another type
+	if !b.IsInitialized() {
+		return nil, errors.New(ErrorValueBeaconNotSortable)
+	}
+
+	topTreasures := make([]treasure.Treasure, 0, n)
+	b.Iterate(func(treasureObj treasure.Treasure) bool {
+		if treasureObj.GetContentType() != contentType {
+			return true
+		}
+		topTreasures = append(topTreasures, treasureObj)
+		return int32(len(topTreasures)) < n
+	}, beacon.IterationTypeOrdered)
+
+	return topTreasures, nil
+
+}
+
+// GetTreasuresByValue returns the treasures holding the same value as the probe by the value index
+func (s *swamp) GetTreasuresByValue(probe treasure.Treasure) ([]treasure.Treasure, error) {
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	if s.valueIndex == nil {
+		return nil, errors.New(ErrorValueIndexNotEnabled)
+	}
+
+	// build the value index if it is not built yet
+	s.buildValueIndex()
+
+	var returningTreasures []treasure.Treasure
+	for _, key := range s.valueIndex.GetKeys(probe) {
+		if treasureObj := s.beaconKey.Get(key); treasureObj != nil {
+			returningTreasures = append(returningTreasures, treasureObj)
+		}
+	}
+
+	return returningTreasures, nil
+
+}
+
+// IsValueIndexed returns true if the value index is enabled for the swamp
+func (s *swamp) IsValueIndexed() bool {
+	return s.valueIndex != nil
+}
+
+func (s *swamp) SetServerTimestamps(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&s.serverTimestamps, 1)
+		return
+	}
+	atomic.StoreInt32(&s.serverTimestamps, 0)
+}
+
+func (s *swamp) SetKeyFilter(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&s.keyFilter, 1)
+		return
+	}
+	atomic.StoreInt32(&s.keyFilter, 0)
+}
+
+// saveKeyFilter writes the bloom filter of the keys of the swamp to its folder. An empty swamp is deleted from the
+// filesystem, so it gets no filter
+func (s *swamp) saveKeyFilter() {
+
+	if s.beaconKey.Count() == 0 {
+		return
+	}
+
+	filter := keyfilter.New(s.beaconKey.Count())
+	s.beaconKey.Iterate(func(treasureObj treasure.Treasure) bool {
+		filter.Add(treasureObj.GetKey())
+		return true
+	}, beacon.IterationTypeKey)
+
+	if err := keyfilter.Save(s.chroniclerInterface.GetSwampAbsPath(), filter); err != nil {
+		slog.Error("can not save the key filter of the swamp", "swampName", s.name.Get(), "error", err)
+	}
+
+}
+
+func (s *swamp) SetLazyHydration(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&s.lazyHydration, 1)
+		return
+	}
+	atomic.StoreInt32(&s.lazyHydration, 0)
+}
+
+func (s *swamp) SetBeacons(kept []BeaconType, evictAfterIdle time.Duration) {
+
+	var mask int32
+	for _, bc := range kept {
+		mask |= 1 << beaconSlotOf(bc)
+	}
+	atomic.StoreInt32(&s.keptBeacons, mask)
+	if evictAfterIdle < 0 {
+		evictAfterIdle = 0
+	}
+	atomic.StoreInt64(&s.beaconEvictAfterIdle, int64(evictAfterIdle))
+
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
+	// the type of the value beacons is unknown until their first use, so they are only kept
+	for _, bc := range kept {
+		switch beaconSlotOf(bc) {
+		case beaconSlotKey:
+			s.buildBeacon(s.keyBeaconASC, s.keyBeaconDESC, BeaconTypeKey)
+		case beaconSlotCreationTime:
+			s.buildBeacon(s.creationTimeBeaconASC, s.creationTimeBeaconDESC, BeaconTypeCreationTime)
+		case beaconSlotUpdateTime:
+			s.buildBeacon(s.updateTimeBeaconASC, s.updateTimeBeaconDESC, BeaconTypeUpdateTime)
+		case beaconSlotExpirationTime:
+			s.buildBeacon(s.expirationTimeBeaconASC, s.expirationTimeBeaconDESC, BeaconTypeExpirationTime)
+		}
+	}
+
+}
+
+// saveKeyDirectory writes the directory of the chunk files of the keys of the swamp to its folder. The directory is
+// not written if the file of any treasure is unknown, e.g. its write failed, because a key missing from the
+// directory would be answered as not existing
+func (s *swamp) saveKeyDirectory() {
+
+	if s.beaconKey.Count() == 0 {
+		return
+	}
+
+	// the treasures written while the swamp closes did not get their file names
+	unsentFileNames := s.chroniclerInterface.GetUnsentFileNames()
+
+	fileNames := make(map[string]string, s.beaconKey.Count())
+	complete := true
+	s.beaconKey.Iterate(func(treasureObj treasure.Treasure) bool {
+		key := treasureObj.GetKey()
+		if fileName, ok := unsentFileNames[key]; ok {
+			fileNames[key] = fileName
+			return true
+		}
+		fileName := treasureObj.GetFileName()
+		if fileName == nil {
+			complete = false
+			return false
+		}
+		fileNames[key] = *fileName
+		return true
+	}, beacon.IterationTypeKey)
+
+	if !complete {
+		slog.Warn("the key directory of the swamp is not written, because the file of a treasure is unknown", "swampName", s.name.Get())
+		return
+	}
+
+	if err := keydirectory.Save(s.chroniclerInterface.GetSwampAbsPath(), fileNames); err != nil {
+		slog.Error("can not save the key directory of the swamp", "swampName", s.name.Get(), "error", err)
+	}
+
+}
+
+func (s *swamp) IsServerTimestamped() bool {
+	return atomic.LoadInt32(&s.serverTimestamps) == 1
+}
+
+func (s *swamp) SetHistoryDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	atomic.StoreInt32(&s.historyDepth, int32(depth))
+}
+
+func (s *swamp) GetHistoryDepth() int {
+	return int(atomic.LoadInt32(&s.historyDepth))
+}
+
+func (s *swamp) SetRetention(retention Retention) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retention = retention
+}
+
+func (s *swamp) GetRetention() Retention {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.retention
+}
+
+func (s *swamp) SetDefaultExpireAfter(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	atomic.StoreInt64(&s.defaultExpireAfter, int64(d))
+}
+
+func (s *swamp) GetDefaultExpireAfter() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.defaultExpireAfter))
+}
+
+// setDefaultExpiration sets the default expiration time of the treasure if it has no expiration time.
+// Returns true if the expiration time was set.
+func (s *swamp) setDefaultExpiration(t treasure.Treasure, guardID guard.ID) bool {
+	d := atomic.LoadInt64(&s.defaultExpireAfter)
+	if d <= 0 || t.GetExpirationTime() != 0 {
+		return false
+	}
+	t.SetExpirationTime(guardID, time.Now().Add(time.Duration(d)))
+	return true
+}
+
+// RevertTreasure sets the content of the treasure back to a version of its history and saves the treasure
+func (s *swamp) RevertTreasure(key string, version uint64, modifiedBy string) error {
+
+	treasureObj := s.beaconKey.Get(key)
+	if treasureObj == nil {
+		return errors.New(ErrorTreasureDoesNotExists)
+	}
+
+	guardID := treasureObj.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+
+	if err := treasureObj.RevertToVersion(guardID, version); err != nil {
+		return err
+	}
+
+	treasureObj.SetModifiedAt(guardID, time.Now())
+	if modifiedBy != "" {
+		treasureObj.SetModifiedBy(guardID, modifiedBy)
+	}
+	treasureObj.Save(guardID)
+
+	return nil
+
+}
+
 // CloneTreasures returns a clone of the swamp object with all beacons and treasures
 func (s *swamp) CloneTreasures() map[string]treasure.Treasure {
 	// set the last interaction time to the current time
@@ -2063,6 +3066,23 @@ func (s *swamp) GetAll() map[string]treasure.Treasure {
 	return s.beaconKey.GetAll()
 }
 
+// IterateAll calls the function with every "Treasure" of the "Swamp" until the function returns false
+func (s *swamp) IterateAll(fn func(treasureObj treasure.Treasure) bool) {
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+	// only the references are copied under the lock of the beacon, so the slow callbacks do not block the writers
+	treasures := make([]treasure.Treasure, 0, s.beaconKey.Count())
+	s.beaconKey.Iterate(func(treasureObj treasure.Treasure) bool {
+		treasures = append(treasures, treasureObj)
+		return true
+	}, beacon.IterationTypeKey)
+	for _, treasureObj := range treasures {
+		if !fn(treasureObj) {
+			return
+		}
+	}
+}
+
 // CountTreasures Returns the number of treasures in the swamp.
 // This function can be useful for capacity planning or when you want to get information about the state of the swamp.
 func (s *swamp) CountTreasures() int {
@@ -2071,6 +3091,58 @@ func (s *swamp) CountTreasures() int {
 	return s.beaconKey.Count()
 }
 
+func (s *swamp) PeekTreasureCount() int {
+	return s.beaconKey.Count()
+}
+
+// CountFilter selects the treasures counted by CountTreasuresByFilter. The conditions are combined with AND, and the
+// zero values do not filter.
+type CountFilter struct {
+	// ExpiredOnly counts only the treasures whose expiration time is set and is already in the past
+	ExpiredOnly bool
+	// KeyPrefix counts only the treasures whose key starts with the prefix
+	KeyPrefix string
+	// UpdatedSince counts only the treasures modified at or after the UnixNano timestamp. A treasure that was never
+	// modified counts with its creation time
+	UpdatedSince int64
+}
+
+// CountTreasuresByFilter returns the number of treasures matching the filter
+func (s *swamp) CountTreasuresByFilter(filter *CountFilter) int {
+
+	if filter == nil {
+		return s.CountTreasures()
+	}
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	now := time.Now().UTC().UnixNano()
+	count := 0
+	s.beaconKey.Iterate(func(treasureObj treasure.Treasure) bool {
+		if filter.KeyPrefix != "" && !strings.HasPrefix(treasureObj.GetKey(), filter.KeyPrefix) {
+			return true
+		}
+		if filter.ExpiredOnly && (treasureObj.GetExpirationTime() == 0 || treasureObj.GetExpirationTime() >= now) {
+			return true
+		}
+		if filter.UpdatedSince > 0 {
+			updatedAt := treasureObj.GetModifiedAt()
+			if updatedAt == 0 {
+				updatedAt = treasureObj.GetCreatedAt()
+			}
+			if updatedAt < filter.UpdatedSince {
+				return true
+			}
+		}
+		count++
+		return true
+	}, beacon.IterationTypeKey)
+
+	return count
+
+}
+
 // DeleteTreasure Deletes a single "Treasure" from a "Swamp" by its unique key.
 // Real-world use-case: Deleting a user account upon request.
 // shadowDelete is a flag that indicates whether the treasure should be deleted from the chroniclerInterface too
@@ -2089,7 +3161,8 @@ func (s *swamp) DeleteTreasure(key string, shadowDelete bool) error {
 	s.deleteHandler(key, shadowDelete)
 
 	// destroy the swamp if there is no treasure in it
-	if s.beaconKey.Count() == 0 {
+	// the shadow-deleted treasures are kept until they are restored or replaced
+	if s.beaconKey.Count() == 0 && s.shadowBeacon.Count() == 0 {
 		// feloldjuk a vigiliát, mert nincs több treasure a swampban és a Destroy megkövetelei a Vigil feloldását
 		s.CeaseVigil()
 		s.Destroy()
@@ -2100,6 +3173,54 @@ func (s *swamp) DeleteTreasure(key string, shadowDelete bool) error {
 
 }
 
+// GetShadowDeletedTreasures returns the shadow-deleted treasures ordered by their deletion time
+func (s *swamp) GetShadowDeletedTreasures() []treasure.Treasure {
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	treasures := make([]treasure.Treasure, 0, s.shadowBeacon.Count())
+	s.shadowBeacon.Iterate(func(treasureObj treasure.Treasure) bool {
+		treasures = append(treasures, treasureObj)
+		return true
+	}, beacon.IterationTypeKey)
+
+	sort.Slice(treasures, func(i, j int) bool {
+		if treasures[i].GetDeletedAt() == treasures[j].GetDeletedAt() {
+			return treasures[i].GetKey() < treasures[j].GetKey()
+		}
+		return treasures[i].GetDeletedAt() < treasures[j].GetDeletedAt()
+	})
+
+	return treasures
+
+}
+
+// RestoreTreasure removes the deletion marks of the shadow-deleted treasure and saves it back to the swamp as a new
+// treasure, so it gets back to all beacons, and it is written to the chronicler
+func (s *swamp) RestoreTreasure(key string) error {
+
+	treasureObj := s.shadowBeacon.Get(key)
+	if treasureObj == nil {
+		return errors.New(ErrorTreasureDoesNotExists)
+	}
+
+	guardID := treasureObj.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+
+	// the treasure could be replaced while we waited for its guard
+	if s.shadowBeacon.Get(key) != treasureObj {
+		return errors.New(ErrorTreasureDoesNotExists)
+	}
+
+	s.shadowBeacon.Delete(key)
+	treasureObj.BodySetForRestore(guardID)
+	treasureObj.Save(guardID)
+
+	return nil
+
+}
+
 // CloneAndDeleteExpiredTreasures retrieves one or more expired Treasures from the Swamp based on their expiration time and removes them.
 // Use this function carefully as it deletes the Treasures from the Swamp.
 func (s *swamp) CloneAndDeleteExpiredTreasures(howMany int32) ([]treasure.Treasure, error) {
@@ -2107,6 +3228,9 @@ func (s *swamp) CloneAndDeleteExpiredTreasures(howMany int32) ([]treasure.Treasu
 	// set the last interaction time to the current time
 	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
 
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
 	// build the expirationTimeIndex if it is not built yet
 	s.buildBeacon(s.expirationTimeBeaconASC, s.expirationTimeBeaconDESC, BeaconTypeExpirationTime)
 
@@ -2124,6 +3248,88 @@ func (s *swamp) CloneAndDeleteExpiredTreasures(howMany int32) ([]treasure.Treasu
 	return shiftedTreasures, nil
 }
 
+// LeaseExpiredTreasures hides the expired treasures until leaseUntil, instead of deleting them
+func (s *swamp) LeaseExpiredTreasures(howMany int32, leaseUntil time.Time) ([]treasure.Treasure, []int64, error) {
+
+	s.leaseMu.Lock()
+	defer s.leaseMu.Unlock()
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
+	// build the expirationTimeIndex if it is not built yet
+	s.buildBeacon(s.expirationTimeBeaconASC, s.expirationTimeBeaconDESC, BeaconTypeExpirationTime)
+
+	// the shift removes the expired treasures from the ascendant beacon, so no other lease can take them
+	shiftedTreasures := s.expirationTimeBeaconASC.ShiftExpired(int(howMany))
+
+	leased := make([]treasure.Treasure, 0, len(shiftedTreasures))
+	previousExpirations := make([]int64, 0, len(shiftedTreasures))
+	for _, shifted := range shiftedTreasures {
+
+		treasureObj := s.beaconKey.Get(shifted.GetKey())
+		if treasureObj == nil {
+			continue
+		}
+
+		clonedTreasure := s.setLeaseExpiration(treasureObj, leaseUntil)
+		leased = append(leased, clonedTreasure)
+		previousExpirations = append(previousExpirations, shifted.GetExpirationTime())
+
+	}
+
+	return leased, previousExpirations, nil
+
+}
+
+// EndLease deletes the leased treasure or makes it visible again at visibleAt
+func (s *swamp) EndLease(key string, leaseID int64, remove bool, visibleAt time.Time) error {
+
+	s.leaseMu.Lock()
+	defer s.leaseMu.Unlock()
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	treasureObj := s.beaconKey.Get(key)
+	if treasureObj == nil || treasureObj.GetExpirationTime() != leaseID {
+		return errors.New(ErrorLeaseNotFound)
+	}
+
+	if remove {
+		s.deleteHandler(key, false)
+		return nil
+	}
+
+	s.setLeaseExpiration(treasureObj, visibleAt)
+	return nil
+
+}
+
+// setLeaseExpiration saves the new expiration time of the treasure, moves it to its new place in the expiration
+// time beacons, and returns its clone
+func (s *swamp) setLeaseExpiration(treasureObj treasure.Treasure, expiration time.Time) treasure.Treasure {
+
+	guardID := treasureObj.StartTreasureGuard(true)
+	treasureObj.SetExpirationTime(guardID, expiration)
+	treasureObj.Save(guardID)
+	clonedTreasure := treasureObj.Clone(guardID)
+	treasureObj.ReleaseTreasureGuard(guardID)
+
+	// the save does not reorder the beacons by the expiration time
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+	s.deleteTreasureIfBeaconInitialized(s.expirationTimeBeaconASC, treasureObj.GetKey())
+	s.deleteTreasureIfBeaconInitialized(s.expirationTimeBeaconDESC, treasureObj.GetKey())
+	s.addToExpirationTimeBeacon(treasureObj)
+
+	return clonedTreasure
+
+}
+
 // TreasureExists Checks if the given key exists in the swamp.
 // This function can be useful before attempting to bury a new treasure or unearth an existing one.
 func (s *swamp) TreasureExists(key string) bool {
@@ -2141,6 +3347,55 @@ func (s *swamp) WriteTreasuresToFilesystem() {
 	}
 }
 
+// Compact merges the underfilled chunk files of the swamp by the chroniclerInterface
+func (s *swamp) Compact(minLiveRatio float64) (chronicler.CompactionResult, error) {
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	if atomic.LoadInt32(&s.inMemorySwamp) == 1 || s.chroniclerInterface == nil {
+		return chronicler.CompactionResult{}, nil
+	}
+
+	// the swamp can not be closed and the write listener can not write during the compaction
+	s.closeWriteMutex.Lock()
+	defer s.closeWriteMutex.Unlock()
+
+	if atomic.LoadInt32(&s.closing) == 1 {
+		return chronicler.CompactionResult{}, errors.New("swamp is closing")
+	}
+
+	// write the waiting treasures first, so the compaction sees the latest content of the files
+	s.fileWriterHandler(false)
+
+	return s.chroniclerInterface.Compact(minLiveRatio)
+
+}
+
+// Freeze runs fn while the write listener can not write the files of the swamp
+func (s *swamp) Freeze(fn func() error) error {
+
+	// set the last interaction time to the current time
+	atomic.StoreInt64(&s.lastInteractionTime, time.Now().UnixNano())
+
+	// the swamp can not be closed and the write listener can not write until fn returns
+	s.closeWriteMutex.Lock()
+	defer s.closeWriteMutex.Unlock()
+
+	if atomic.LoadInt32(&s.closing) == 1 {
+		return errors.New("swamp is closing")
+	}
+
+	if atomic.LoadInt32(&s.inMemorySwamp) == 0 && s.chroniclerInterface != nil {
+		// write the waiting treasures and the metadata first, so fn sees the latest state of the files
+		s.fileWriterHandler(false)
+		s.metadataInterface.SaveToFile()
+	}
+
+	return fn()
+
+}
+
 // fileWriterHandler writes all new, modified or deleted treasures to the chroniclerInterface
 // and sets the writeActive to 0 when it is finished and empty the treasuresWaitingForWriter slice
 // ONLY ONE fileWriterHandler can be active at the same time to prevent the concurrent loader writes to the chroniclerInterface
@@ -2170,29 +3425,162 @@ func (s *swamp) fileWriterHandler(isCloseWrite bool) {
 
 	}
 
+	// the treasures above the limits of the retention are deleted first, so their deletion is written with the
+	// other changes. The deleted treasures stay in the files until the compaction, so the size limit compacts them.
+	if s.enforceRetention() {
+		defer s.compactAfterRetention()
+	}
+
 	// if there is no treasures waiting for write, then return
 	if s.treasuresWaitingForWriter.Count() == 0 {
 		return
 	}
 
-	var treasuresToWrite []treasure.Treasure
-	s.treasuresWaitingForWriter.Iterate(func(t treasure.Treasure) bool {
-		treasuresToWrite = append(treasuresToWrite, t)
-		return true
-	}, beacon.IterationTypeKey)
+	// the treasures waiting at the start of the write are written in batches of writeBatchSize treasures, so a huge
+	// queue is not converted to binary at once. The treasures added meanwhile wait for the next write.
+	remaining := s.treasuresWaitingForWriter.Count()
+	for remaining > 0 {
+
+		batchSize := remaining
+		if s.writeBatchSize > 0 && s.writeBatchSize < batchSize {
+			batchSize = s.writeBatchSize
+		}
+
+		treasuresToWrite := make([]treasure.Treasure, 0, batchSize)
+		s.treasuresWaitingForWriter.Iterate(func(t treasure.Treasure) bool {
+			treasuresToWrite = append(treasuresToWrite, t)
+			return len(treasuresToWrite) < batchSize
+		}, beacon.IterationTypeKey)
+
+		if len(treasuresToWrite) == 0 {
+			return
+		}
+		remaining -= len(treasuresToWrite)
+
+		// the snapshots of the treasures are written, so the treasures are not blocked while the snapshots are
+		// converted to binary and written to the disk. A treasure modified meanwhile waits for the next write.
+		snapshots := make([]treasure.Treasure, 0, len(treasuresToWrite))
+		for _, t := range treasuresToWrite {
+			// delete the treasure from the treasuresWaitingForWriter index
+			s.treasuresWaitingForWriter.Delete(t.GetKey())
+			snapshots = append(snapshots, s.snapshotTreasure(t))
+		}
+
+		// A Write funkció megvárja ameddig az előző write befejezi a munkáját, így nem kell
+		// külön szinkronizálni a két írási folyamatot
+		s.chroniclerInterface.Write(snapshots)
 
-	// delete the treasures from the swamp and from the chroniclerInterface too
-	for _, t := range treasuresToWrite {
-		// delete the treasure from the treasuresWaitingForWriter index
-		s.treasuresWaitingForWriter.Delete(t.GetKey())
 	}
 
-	// A Write funkció megvárja ameddig az előző write befejezi a munkáját, így nem kell
-	// külön szinkronizálni a két írási folyamatot
-	s.chroniclerInterface.Write(treasuresToWrite)
+}
+
+// snapshotTreasure copies the treasure for the writer under its guard, and counts the operations that waited for the
+// guard meanwhile
+func (s *swamp) snapshotTreasure(t treasure.Treasure) treasure.Treasure {
+
+	guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
+	started := time.Now()
+	snapshot := t.Snapshot(guardID)
+	held := time.Since(started)
+	waiting := t.Waiters()
+	t.ReleaseTreasureGuard(guardID)
+
+	if counters := s.flushStallCounters.Load(); counters != nil {
+		counters.Record(waiting, held)
+	}
+
+	return snapshot
 
 }
 
+// enforceRetention deletes the treasures above the limits of the retention policy, the oldest ones by creation time
+// first. Returns true if treasures were deleted because of the size limit, so the files must be compacted.
+func (s *swamp) enforceRetention() (compact bool) {
+
+	retention := s.GetRetention()
+	if atomic.LoadInt32(&s.inMemorySwamp) == 1 || (retention.MaxAge <= 0 && retention.MaxTreasures <= 0 && retention.MaxSizeByte <= 0) {
+		return false
+	}
+
+	count := s.beaconKey.Count()
+	if count == 0 {
+		return false
+	}
+
+	// the number of the oldest treasures deleted regardless of their age
+	evict := 0
+	if retention.MaxTreasures > 0 && count > retention.MaxTreasures {
+		evict = count - retention.MaxTreasures
+	}
+	if retention.MaxSizeByte > 0 {
+		size, err := s.chroniclerInterface.GetSize()
+		if err != nil {
+			slog.Error("can not get the size of the swamp for the retention", "swampName", s.name.Get(), "error", err)
+		} else if size > retention.MaxSizeByte {
+			// the treasures are assumed to be of the same size, so the excess is removed in proportion
+			bySize := int((int64(count)*(size-retention.MaxSizeByte) + size - 1) / size)
+			if bySize > evict {
+				evict = bySize
+			}
+			compact = bySize > 0
+		}
+	}
+
+	var maxCreatedAt int64
+	if retention.MaxAge > 0 {
+		maxCreatedAt = time.Now().Add(-retention.MaxAge).UTC().UnixNano()
+	}
+
+	// collect the keys first, because the deletion changes the order positions of the beacon
+	s.beaconsMu.RLock()
+	s.buildBeacon(s.creationTimeBeaconASC, s.creationTimeBeaconDESC, BeaconTypeCreationTime)
+	keys := make([]string, 0, evict)
+	for from := 0; from < count; from += retentionPageSize {
+
+		treasures, err := s.creationTimeBeaconASC.GetManyFromOrderPosition(from, retentionPageSize)
+		if err != nil || len(treasures) == 0 {
+			break
+		}
+
+		done := false
+		for _, t := range treasures {
+			createdAt := t.GetCreatedAt()
+			if len(keys) < evict || (maxCreatedAt > 0 && createdAt != 0 && createdAt < maxCreatedAt) {
+				keys = append(keys, t.GetKey())
+				continue
+			}
+			// the treasures without creation time are at the beginning of the beacon
+			if createdAt != 0 {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+
+	}
+	s.beaconsMu.RUnlock()
+
+	for _, key := range keys {
+		s.deleteHandler(key, false)
+	}
+
+	if len(keys) > 0 {
+		slog.Info("treasures deleted by the retention", "swampName", s.name.Get(), "deletedTreasures", len(keys))
+	}
+
+	return compact && len(keys) > 0
+
+}
+
+// compactAfterRetention removes the treasures deleted by the size limit of the retention from the files
+func (s *swamp) compactAfterRetention() {
+	if _, err := s.chroniclerInterface.Compact(1); err != nil {
+		slog.Error("can not compact the swamp after the retention", "swampName", s.name.Get(), "error", err)
+	}
+}
+
 // Info returns detailed information about the swamp
 func (s *swamp) sendSwampInfo() {
 	if atomic.LoadInt32(&s.isInformationSendingActive) == 0 {
@@ -2212,6 +3600,10 @@ func (s *swamp) sendSwampInfo() {
 func (s *swamp) deleteHandler(key string, shadowDelete bool) (deletedTreasure treasure.Treasure) {
 
 	// clone the treasure itself to the clonedTreasure
+	// the change and its event must not be split by a snapshot
+	s.eventMu.RLock()
+	defer s.eventMu.RUnlock()
+
 	treasureObj := s.beaconKey.Get(key)
 	if treasureObj == nil {
 		return nil
@@ -2226,13 +3618,20 @@ func (s *swamp) deleteHandler(key string, shadowDelete bool) (deletedTreasure tr
 
 	// remove the treasure from the treasuresWaitingForWriter slice if the treasure does not have a loader pointer
 	// because it is meaning the treasure is not saved yet to the chroniclerInterface, but it is deleted from the swamp
-	if treasureObj.GetFileName() == nil {
+	if shadowDelete {
+		// the shadow-deleted treasure keeps its content, and it must be written to the chroniclerInterface even if it
+		// was not written yet, so it can be restored after the swamp is closed
+		treasureObj.BodySetForDeletion(guardID, "", true)
+		s.treasuresWaitingForWriter.Add(treasureObj)
+		s.shadowBeacon.Add(treasureObj)
+		// beállítjuk az utolsó módosítás dátumát a metában
+		s.metadataInterface.SetUpdatedAt()
+	} else if treasureObj.GetFileName() == nil {
 		// delete the treasure from the treasuresWaitingForWriter index
 		s.treasuresWaitingForWriter.Delete(key)
 	} else {
 		// set the treasure for deletion
-		// todo: itt meg kell oldani, hogy a törlésnél legyen kérhető a shadow delete is.
-		treasureObj.BodySetForDeletion(guardID, "", shadowDelete)
+		treasureObj.BodySetForDeletion(guardID, "", false)
 		s.treasuresWaitingForWriter.Add(treasureObj)
 		// beállítjuk az utolsó módosítás dátumát a metában
 		s.metadataInterface.SetUpdatedAt()
@@ -2271,9 +3670,32 @@ func (s *swamp) sendDeletedEventToClient(d treasure.Treasure) {
 
 }
 
+// sendDestroyedEventToHydra sends the event of the destroyed swamp to the Hydra
+func (s *swamp) sendDestroyedEventToHydra() {
+
+	if atomic.LoadInt32(&s.isEventSendingActive) == 0 {
+		return
+	}
+
+	// the destruction must not be split by a snapshot from the changes before it
+	s.eventMu.RLock()
+	defer s.eventMu.RUnlock()
+
+	s.swampEventCallback(&Event{
+		SwampName:  s.GetName(),
+		EventTime:  time.Now().UTC().UnixNano(),
+		StatusType: treasure.StatusVoid,
+		Destroyed:  true,
+	})
+
+}
+
 // addTreasureToBeacons - add treasures to all the indexes
 func (s *swamp) addTreasureToBeacons(d treasure.Treasure) {
 
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
+
 	// try to add the treasure to the keyBeacon
 	s.addToKeyBeacon(d)
 	if d.GetCreatedAt() != 0 {
@@ -2288,11 +3710,15 @@ func (s *swamp) addTreasureToBeacons(d treasure.Treasure) {
 
 	// value beacon
 	s.addToValueBeacon(d)
+	// value index
+	s.addToValueIndex(d)
 
 }
 
 // deleteTreasureFromBeacons - delete the treasure from all beacons if the treasure is exists in the beacon
 func (s *swamp) deleteTreasureFromBeacons(key string) {
+	s.beaconsMu.RLock()
+	defer s.beaconsMu.RUnlock()
 	// delete the key from the beacon only if the beacon is initialized
 	s.deleteTreasureIfBeaconInitialized(s.keyBeaconASC, key)
 	s.deleteTreasureIfBeaconInitialized(s.keyBeaconDESC, key)
@@ -2304,6 +3730,9 @@ func (s *swamp) deleteTreasureFromBeacons(key string) {
 	s.deleteTreasureIfBeaconInitialized(s.expirationTimeBeaconDESC, key)
 	s.deleteTreasureIfBeaconInitialized(s.valueBeaconASC, key)
 	s.deleteTreasureIfBeaconInitialized(s.valueBeaconDESC, key)
+	if s.valueIndex != nil && s.valueIndex.IsInitialized() {
+		s.valueIndex.Delete(key)
+	}
 
 }
 
@@ -2373,7 +3802,7 @@ func (s *swamp) findInExpirationTimeBeacon(order BeaconOrder, from int32, limit
 // findInValueBeacon - find the treasures in the valueIntBeaconASC or valueIntBeaconDESC slice
 // Build the two indexes if they are not exists or the indexes are empty
 func (s *swamp) findInValueBeacon(order BeaconOrder, bc BeaconType, from int32, limit int32) ([]treasure.Treasure, error) {
-	s.buildBeacon(s.valueBeaconASC, s.valueBeaconDESC, bc)
+	s.buildValueBeacon(bc)
 	switch order {
 	case IndexOrderAsc:
 		return s.valueBeaconASC.GetManyFromOrderPosition(int(from), int(limit))
@@ -2386,8 +3815,25 @@ func (s *swamp) findInValueBeacon(order BeaconOrder, bc BeaconType, from int32,
 
 // -- helper functions for beacons -----------------------------------------------------
 // ------------------------------------------------------------------------------------
+// buildValueBeacon builds the value beacons sorted by the given value type. The beacons are rebuilt from scratch
+// if they were sorted by another value type before, or if the previous build failed.
+func (s *swamp) buildValueBeacon(bc BeaconType) {
+	s.touchBeacon(bc)
+	s.valueBeaconMu.Lock()
+	defer s.valueBeaconMu.Unlock()
+	if s.valueBeaconType == bc && s.valueBeaconASC.IsInitialized() && s.valueBeaconDESC.IsInitialized() {
+		return
+	}
+	s.valueBeaconASC.Reset()
+	s.valueBeaconDESC.Reset()
+	s.valueBeaconType = bc
+	s.buildBeacon(s.valueBeaconASC, s.valueBeaconDESC, bc)
+}
+
 func (s *swamp) buildBeacon(beaconASC beacon.Beacon, beaconDESC beacon.Beacon, bc BeaconType) {
 
+	s.touchBeacon(bc)
+
 	// build the index only if it is not initialized
 	if beaconASC.IsInitialized() && beaconASC.IsInitialized() {
 		return
@@ -2483,6 +3929,111 @@ func (s *swamp) buildBeacon(beaconASC beacon.Beacon, beaconDESC beacon.Beacon, b
 
 }
 
+// beaconSlot is a pair of the order beacons of the swamp, the unit of the eviction of the idle beacons
+type beaconSlot int
+
+const (
+	beaconSlotKey beaconSlot = iota
+	beaconSlotCreationTime
+	beaconSlotUpdateTime
+	beaconSlotExpirationTime
+	// beaconSlotValue is the slot of the value beacons, whatever type they are sorted by
+	beaconSlotValue
+	beaconSlotCount
+)
+
+// beaconSlotOf returns the slot of the beacons of the beacon type
+func beaconSlotOf(bc BeaconType) beaconSlot {
+	switch bc {
+	case BeaconTypeKey:
+		return beaconSlotKey
+	case BeaconTypeCreationTime:
+		return beaconSlotCreationTime
+	case BeaconTypeUpdateTime:
+		return beaconSlotUpdateTime
+	case BeaconTypeExpirationTime:
+		return beaconSlotExpirationTime
+	default:
+		return beaconSlotValue
+	}
+}
+
+// beaconsOfSlot returns the ascendant and the descendant beacons of the slot
+func (s *swamp) beaconsOfSlot(slot beaconSlot) (beacon.Beacon, beacon.Beacon) {
+	switch slot {
+	case beaconSlotKey:
+		return s.keyBeaconASC, s.keyBeaconDESC
+	case beaconSlotCreationTime:
+		return s.creationTimeBeaconASC, s.creationTimeBeaconDESC
+	case beaconSlotUpdateTime:
+		return s.updateTimeBeaconASC, s.updateTimeBeaconDESC
+	case beaconSlotExpirationTime:
+		return s.expirationTimeBeaconASC, s.expirationTimeBeaconDESC
+	default:
+		return s.valueBeaconASC, s.valueBeaconDESC
+	}
+}
+
+// touchBeacon sets the last use of the beacons of the beacon type to now, so they are not evicted
+func (s *swamp) touchBeacon(bc BeaconType) {
+	atomic.StoreInt64(&s.beaconLastUsed[beaconSlotOf(bc)], time.Now().UnixNano())
+}
+
+// evictIdleBeacons evicts the beacons from the memory that are not kept and were not used for the eviction time.
+// They are rebuilt at their next use. The beacons are not evicted while any of them is in use, they are evicted at
+// a later call instead.
+func (s *swamp) evictIdleBeacons(now time.Time) {
+
+	evictAfterIdle := atomic.LoadInt64(&s.beaconEvictAfterIdle)
+	if evictAfterIdle == 0 {
+		return
+	}
+
+	if !s.beaconsMu.TryLock() {
+		return
+	}
+	defer s.beaconsMu.Unlock()
+
+	kept := atomic.LoadInt32(&s.keptBeacons)
+	for slot := beaconSlot(0); slot < beaconSlotCount; slot++ {
+		if kept&(1<<slot) != 0 || now.UnixNano()-atomic.LoadInt64(&s.beaconLastUsed[slot]) < evictAfterIdle {
+			continue
+		}
+		beaconASC, beaconDESC := s.beaconsOfSlot(slot)
+		if !beaconASC.IsInitialized() && !beaconDESC.IsInitialized() {
+			continue
+		}
+		beaconASC.Reset()
+		beaconDESC.Reset()
+	}
+
+}
+
+// buildValueIndex builds the value index from all treasures of the swamp if it is not built yet
+func (s *swamp) buildValueIndex() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.valueIndex.IsInitialized() {
+		return
+	}
+	// set the index to initialized before the build, so the parallel writes are added to the index too
+	s.valueIndex.SetInitialized(true)
+	for _, treasureObj := range s.beaconKey.GetAll() {
+		s.valueIndex.Add(treasureObj)
+	}
+}
+
+// addToValueIndex adds the treasure to the value index if the index is enabled and already built
+func (s *swamp) addToValueIndex(treasureInterface treasure.Treasure) {
+	// if the index is not built yet, then the treasure will be added at the first lookup
+	if s.valueIndex == nil || !s.valueIndex.IsInitialized() {
+		return
+	}
+	s.valueIndex.Add(treasureInterface)
+}
+
+// addToKeyBeacon adds the treasure to the keyBeaconASC and keyBeaconDESC beacons. The beacons keep their order, so the
+// treasure is inserted to its position without sorting the beacons again
 func (s *swamp) addToKeyBeacon(treasureInterface treasure.Treasure) {
 	// check if the index is already built
 	// if not, then we don't need to add the treasures to the index
@@ -2490,19 +4041,11 @@ func (s *swamp) addToKeyBeacon(treasureInterface treasure.Treasure) {
 		return
 	}
 	s.keyBeaconASC.Add(treasureInterface)
-	err := s.keyBeaconASC.SortByKeyAsc()
-	if err != nil {
-		slog.Error("failed to sort keyBeaconASC", "error", err)
-	}
 	s.keyBeaconDESC.Add(treasureInterface)
-	err = s.keyBeaconDESC.SortByKeyDesc()
-	if err != nil {
-		slog.Error("failed to sort keyBeaconDESC", "error", err)
-	}
 }
 
-// addToCreationTimeBeacon - add the treasures to the creationTimeBeaconASC and creationTimeBeaconDESC slices if the treasure
-// is not already in the slices
+// addToCreationTimeBeacon - add the treasures to the creationTimeBeaconASC and creationTimeBeaconDESC beacons if the
+// treasure is not already in the beacons
 func (s *swamp) addToCreationTimeBeacon(treasureInterface treasure.Treasure) {
 	// check if the index is already built
 	// if not, then we don't need to add the treasures to the index
@@ -2510,15 +4053,7 @@ func (s *swamp) addToCreationTimeBeacon(treasureInterface treasure.Treasure) {
 		return
 	}
 	s.creationTimeBeaconASC.Add(treasureInterface)
-	err := s.creationTimeBeaconASC.SortByCreationTimeAsc()
-	if err != nil {
-		slog.Error("failed to sort creationTimeBeaconASC", "error", err)
-	}
 	s.creationTimeBeaconDESC.Add(treasureInterface)
-	err = s.creationTimeBeaconDESC.SortByCreationTimeDesc()
-	if err != nil {
-		slog.Error("failed to sort creationTimeBeaconDESC", "error", err)
-	}
 }
 func (s *swamp) addToUpdateTimeBeacon(treasureInterface treasure.Treasure) {
 	// check if the index is already built
@@ -2527,16 +4062,7 @@ func (s *swamp) addToUpdateTimeBeacon(treasureInterface treasure.Treasure) {
 		return
 	}
 	s.updateTimeBeaconASC.Add(treasureInterface)
-	err := s.updateTimeBeaconASC.SortByUpdateTimeAsc()
-	if err != nil {
-		slog.Error("failed to sort updateTimeBeaconASC", "error", err)
-	}
 	s.updateTimeBeaconDESC.Add(treasureInterface)
-	err = s.updateTimeBeaconDESC.SortByUpdateTimeDesc()
-	if err != nil {
-		slog.Error("failed to sort updateTimeBeaconDESC", "error", err)
-	}
-
 }
 func (s *swamp) addToExpirationTimeBeacon(treasureInterface treasure.Treasure) {
 	// check if the index is already built
@@ -2545,17 +4071,7 @@ func (s *swamp) addToExpirationTimeBeacon(treasureInterface treasure.Treasure) {
 		return
 	}
 	s.expirationTimeBeaconASC.Add(treasureInterface)
-	err := s.expirationTimeBeaconASC.SortByExpirationTimeAsc()
-	if err != nil {
-		slog.Error("failed to sort expirationTimeBeaconASC", "error", err)
-	}
-
 	s.expirationTimeBeaconDESC.Add(treasureInterface)
-	err = s.expirationTimeBeaconDESC.SortByExpirationTimeDesc()
-	if err != nil {
-		slog.Error("failed to sort expirationTimeBeaconDESC", "error", err)
-	}
-
 }
 func (s *swamp) addToValueBeacon(treasureInterface treasure.Treasure) {
 	// check if the index is already built
@@ -2563,25 +4079,54 @@ func (s *swamp) addToValueBeacon(treasureInterface treasure.Treasure) {
 	if !s.valueBeaconASC.IsInitialized() {
 		return
 	}
+	s.valueBeaconMu.Lock()
+	defer s.valueBeaconMu.Unlock()
+	// the beacons keep the order of the value type they were built for
 	s.valueBeaconASC.Add(treasureInterface)
-	err := s.valueBeaconASC.SortByValueInt64ASC()
-	if err != nil {
-		slog.Error("failed to sort valueIntBeaconASC", "error", err)
-	}
 	s.valueBeaconDESC.Add(treasureInterface)
-	err = s.valueBeaconDESC.SortByValueInt64DESC()
-	if err != nil {
-		slog.Error("failed to sort valueIntBeaconDESC", "error", err)
+}
+
+// valueBeaconContentType returns the content type of the treasures a value beacon can order.
+// Returns false for the key and metadata beacons.
+func valueBeaconContentType(bc BeaconType) (treasure.ContentType, bool) {
+	switch bc {
+	case BeaconTypeValueUint8:
+		return treasure.ContentTypeUint8, true
+	case BeaconTypeValueUint16:
+		return treasure.ContentTypeUint16, true
+	case BeaconTypeValueUint32:
+		return treasure.ContentTypeUint32, true
+	case BeaconTypeValueUint64:
+		return treasure.ContentTypeUint64, true
+	case BeaconTypeValueInt8:
+		return treasure.ContentTypeInt8, true
+	case BeaconTypeValueInt16:
+		return treasure.ContentTypeInt16, true
+	case BeaconTypeValueInt32:
+		return treasure.ContentTypeInt32, true
+	case BeaconTypeValueInt64:
+		return treasure.ContentTypeInt64, true
+	case BeaconTypeValueFloat32:
+		return treasure.ContentTypeFloat32, true
+	case BeaconTypeValueFloat64:
+		return treasure.ContentTypeFloat64, true
+	case BeaconTypeValueString:
+		return treasure.ContentTypeString, true
+	default:
+		return treasure.ContentTypeVoid, false
 	}
 }
 
 // sendEventToHydra sends the event to the ManagerInterface
-func (s *swamp) sendEventToHydra(newTreasure, oldTreasure treasure.Treasure, status treasure.TreasureStatus) {
+// The event gets a copy of the new treasure, because the events can be kept after the treasure is modified again
+func (s *swamp) sendEventToHydra(newTreasure, oldTreasure treasure.Treasure, status treasure.TreasureStatus, guardID guard.ID) {
 
 	if atomic.LoadInt32(&s.isEventSendingActive) == 0 {
 		return
 	}
 
+	newTreasure = newTreasure.Clone(guardID)
+
 	swampName := s.GetName()
 
 	// create the event_channel_handler for the ManagerInterface database
@@ -2658,6 +4203,8 @@ func (s *swamp) startCloseListener() {
 			currentTime := time.Now()
 			lastInteractionTime := time.Unix(0, atomic.LoadInt64(&s.lastInteractionTime))
 
+			s.evictIdleBeacons(currentTime)
+
 			func() {
 
 				// lockolunk, hogy az ellenőrzés ideje alatt ne tudjon leállítani senki és írni se tudjon senki, de a fiepointer eventek se kerüljenek be,
@@ -2706,6 +4253,10 @@ func (s *swamp) FilePointerCallbackFunction(filePointerEvents []*chronicler.File
 
 			func() {
 				treasureObj := s.beaconKey.Get(e.TreasureKey)
+				if treasureObj == nil {
+					// the shadow-deleted treasures are written to the files, too
+					treasureObj = s.shadowBeacon.Get(e.TreasureKey)
+				}
 				if treasureObj == nil {
 					return
 				}
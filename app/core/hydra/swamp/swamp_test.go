@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"github.com/hydraide/hydraide/app/core/filesystem"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/chronicler"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
 	"github.com/hydraide/hydraide/app/core/settings"
 	"github.com/hydraide/hydraide/app/name"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -31,7 +37,7 @@ func TestNew(t *testing.T) {
 		WriteIntervalSec: 1,
 		MaxFileSizeByte:  8192,
 	}
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -62,7 +68,7 @@ func TestNew(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 
 		treasureInterface := swampInterface.CreateTreasure("test")
 		assert.NotNil(t, treasureInterface)
@@ -107,7 +113,7 @@ func TestNew(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		treasureInterface := swampInterface.CreateTreasure("test")
@@ -153,7 +159,7 @@ func TestNew(t *testing.T) {
 			WriteInterval:       writeInterval,
 		}
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 
 		swampInterface.BeginVigil()
 		treasureInterface := swampInterface.CreateTreasure("test")
@@ -182,7 +188,7 @@ func TestSwamp_DeleteAllTreasures(t *testing.T) {
 		WriteIntervalSec: 1,
 		MaxFileSizeByte:  8192,
 	}
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -213,7 +219,7 @@ func TestSwamp_DeleteAllTreasures(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 		for i := 0; i < 100; i++ {
 			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("test-%d", i))
@@ -251,7 +257,7 @@ func TestSwamp_SendingInformation(t *testing.T) {
 		WriteIntervalSec: 1,
 		MaxFileSizeByte:  8192,
 	}
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -287,7 +293,7 @@ func TestSwamp_SendingInformation(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 
 		swampInterface.StartSendingInformation()
 
@@ -320,7 +326,7 @@ func TestSwamp_SendingEvent(t *testing.T) {
 		WriteIntervalSec: 1,
 		MaxFileSizeByte:  8192,
 	}
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -355,7 +361,7 @@ func TestSwamp_SendingEvent(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		defer swampInterface.Destroy()
 
 		swampInterface.BeginVigil()
@@ -392,7 +398,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		WriteIntervalSec: 1,
 		MaxFileSizeByte:  8192,
 	}
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -419,7 +425,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		for i := 0; i < allTests; i++ {
@@ -539,7 +545,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		for i := 0; i < allTests; i++ {
@@ -608,7 +614,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		for i := 0; i < allTests; i++ {
@@ -677,7 +683,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		for i := 0; i < allTests; i++ {
@@ -748,7 +754,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 		defer swampInterface.CeaseVigil()
 
@@ -812,7 +818,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 		defer swampInterface.CeaseVigil()
 
@@ -849,7 +855,7 @@ func TestSwamp_GetTreasuresByBeacon(t *testing.T) {
 
 		// create a new swamp with the same name and simulate the re-summoning of the swamp
 		metadataInterface = metadata.New(hashPath)
-		swampInterface = New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface = New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 		defer swampInterface.CeaseVigil()
 
@@ -881,7 +887,7 @@ func TestSwamp_GetTreasuresByBeaconWithVariousMethod(t *testing.T) {
 		MaxFileSizeByte:  8192,
 	}
 
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -908,7 +914,7 @@ func TestSwamp_GetTreasuresByBeaconWithVariousMethod(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		for i := 0; i < allTests; i++ {
@@ -962,6 +968,102 @@ func TestSwamp_GetTreasuresByBeaconWithVariousMethod(t *testing.T) {
 
 }
 
+func TestSwamp_GetTopTreasures(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	settingsInterface := settings.New(testMaxDepth, testMaxFolderPerLevel)
+
+	fss := &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}
+
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
+	closeAfterIdle := 1 * time.Second
+	writeInterval := 1 * time.Second
+	maxFileSize := int64(8192)
+
+	t.Run("should get the top treasures from the value beacon", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-get-top-treasures").Swamp("leaderboard")
+
+		hashPath := swampName.GetFullHashPath(settingsInterface.GetHydraAbsDataFolderPath(), testAllServers, testMaxDepth, testMaxFolderPerLevel)
+		chroniclerInterface := chronicler.New(hashPath, maxFileSize, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       writeInterval,
+		}
+
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+		swampInterface.BeginVigil()
+
+		saveScore := func(key string, score float64) {
+			treasureInterface := swampInterface.CreateTreasure(key)
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentFloat64(guardID, score)
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+
+		// the scores are not in the order of the keys
+		for i := 0; i < 20; i++ {
+			saveScore(fmt.Sprintf("player-%d", i), float64((i*7)%20)+0.5)
+		}
+
+		topTreasures, err := swampInterface.GetTopTreasures(BeaconTypeValueFloat64, IndexOrderDesc, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(topTreasures))
+		for i, expected := range []float64{19.5, 18.5, 17.5} {
+			v, err := topTreasures[i].GetContentFloat64()
+			assert.Nil(t, err)
+			assert.Equal(t, expected, v)
+		}
+
+		bottomTreasures, err := swampInterface.GetTopTreasures(BeaconTypeValueFloat64, IndexOrderAsc, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(bottomTreasures))
+		v, _ := bottomTreasures[0].GetContentFloat64()
+		assert.Equal(t, 0.5, v)
+
+		// a new score after the beacon is built must be sorted by the float value of the beacon
+		saveScore("player-new", 100.5)
+		topTreasures, err = swampInterface.GetTopTreasures(BeaconTypeValueFloat64, IndexOrderDesc, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(topTreasures))
+		assert.Equal(t, "player-new", topTreasures[0].GetKey())
+
+		// n larger than the swamp returns every treasure
+		topTreasures, err = swampInterface.GetTopTreasures(BeaconTypeValueFloat64, IndexOrderDesc, 1000)
+		assert.Nil(t, err)
+		assert.Equal(t, 21, len(topTreasures))
+
+		// the swamp holds float values, so the int64 beacon can not be built
+		_, err = swampInterface.GetTopTreasures(BeaconTypeValueInt64, IndexOrderDesc, 3)
+		assert.NotNil(t, err)
+
+		// the float beacon is rebuilt after the int64 query
+		topTreasures, err = swampInterface.GetTopTreasures(BeaconTypeValueFloat64, IndexOrderDesc, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, "player-new", topTreasures[0].GetKey())
+
+		// the key beacon works without value type
+		topTreasures, err = swampInterface.GetTopTreasures(BeaconTypeKey, IndexOrderAsc, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, "player-0", topTreasures[0].GetKey())
+		assert.Equal(t, "player-1", topTreasures[1].GetKey())
+
+		_, err = swampInterface.GetTopTreasures(BeaconTypeValueFloat64, IndexOrderDesc, 0)
+		assert.NotNil(t, err)
+
+		swampInterface.CeaseVigil()
+		swampInterface.Destroy()
+
+	})
+
+}
+
 // Test for GetAndDeleteRandomTreasures
 // Test for GetAndDeleteExpiredTreasures
 func TestSwamp_GetAndDelete(t *testing.T) {
@@ -975,7 +1077,7 @@ func TestSwamp_GetAndDelete(t *testing.T) {
 		MaxFileSizeByte:  8192,
 	}
 
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -1002,7 +1104,7 @@ func TestSwamp_GetAndDelete(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		for i := 0; i < allTests; i++ {
@@ -1043,7 +1145,7 @@ func TestSwamp_GetAllTreasures(t *testing.T) {
 		MaxFileSizeByte:  8192,
 	}
 
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 1 * time.Second
 	maxFileSize := int64(8192)
@@ -1070,7 +1172,7 @@ func TestSwamp_GetAllTreasures(t *testing.T) {
 		}
 
 		metadataInterface := metadata.New(hashPath)
-		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 		swampInterface.BeginVigil()
 
 		receivedTreasures := swampInterface.GetAll()
@@ -1097,6 +1199,49 @@ func TestSwamp_GetAllTreasures(t *testing.T) {
 		swampInterface.CeaseVigil()
 
 	})
+
+	t.Run("should iterate all treasures", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-iterate").Swamp("all-treasures")
+		swampInterface := New(swampName, closeAfterIdle, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+
+		visited := 0
+		swampInterface.IterateAll(func(treasureObj treasure.Treasure) bool {
+			visited++
+			return true
+		})
+		assert.Zero(t, visited, "the empty swamp has no treasures")
+
+		for i := 0; i < 10; i++ {
+			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("%d", i))
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, fmt.Sprintf("content-%d", i))
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+
+		keys := make(map[string]bool)
+		swampInterface.IterateAll(func(treasureObj treasure.Treasure) bool {
+			keys[treasureObj.GetKey()] = true
+			// the callback can write the swamp without a deadlock
+			treasureInterface := swampInterface.CreateTreasure("created-" + treasureObj.GetKey())
+			guardID := treasureInterface.StartTreasureGuard(true)
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+			return true
+		})
+		assert.Len(t, keys, 10, "the treasures created during the iteration are not visited")
+
+		visited = 0
+		swampInterface.IterateAll(func(treasureObj treasure.Treasure) bool {
+			visited++
+			return visited < 3
+		})
+		assert.Equal(t, 3, visited, "the iteration stops when the callback returns false")
+
+	})
 }
 
 // elapsed time in seconds: 0.072401
@@ -1115,7 +1260,7 @@ func TestSaveSpeed(t *testing.T) {
 		MaxFileSizeByte:  8192,
 	}
 
-	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss)
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
 	closeAfterIdle := 1 * time.Second
 	writeInterval := 0 * time.Second
 	maxFileSize := int64(8192)
@@ -1136,7 +1281,7 @@ func TestSaveSpeed(t *testing.T) {
 	}
 
 	metadataInterface := metadata.New(hashPath)
-	swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface)
+	swampInterface := New(swampName, closeAfterIdle, fssSwamp, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadataInterface, false)
 
 	swampInterface.BeginVigil()
 
@@ -1187,3 +1332,1398 @@ func TestSaveSpeed(t *testing.T) {
 	swampInterface.CeaseVigil()
 
 }
+
+func TestGetTreasuresByValue(t *testing.T) {
+
+	swampEventCallbackFunc := func(e *Event) {}
+	swampInfoCallbackFunc := func(i *Info) {}
+	closeCallbackFunc := func(n name.Name) {}
+
+	newProbe := func(content string) treasure.Treasure {
+		probe := treasure.New(nil)
+		guardID := probe.StartTreasureGuard(true)
+		probe.SetContentString(guardID, content)
+		probe.ReleaseTreasureGuard(guardID)
+		return probe
+	}
+
+	saveString := func(s Swamp, key string, content string) {
+		treasureInterface := s.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, content)
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	t.Run("should find treasures by value and follow the modifications", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("value-index").Swamp("enabled")
+		swampInterface := New(swampName, 10*time.Second, nil, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadata.New(""), true)
+		swampInterface.BeginVigil()
+		defer func() {
+			swampInterface.CeaseVigil()
+			swampInterface.Destroy()
+		}()
+
+		assert.True(t, swampInterface.IsValueIndexed())
+
+		for i := 0; i < 10; i++ {
+			saveString(swampInterface, fmt.Sprintf("user-%d", i), fmt.Sprintf("group-%d", i%3))
+		}
+
+		// the first lookup builds the index
+		treasures, err := swampInterface.GetTreasuresByValue(newProbe("group-0"))
+		assert.NoError(t, err)
+		assert.Len(t, treasures, 4)
+
+		// the index is maintained incrementally after the build
+		saveString(swampInterface, "user-10", "group-0")
+		saveString(swampInterface, "user-0", "group-1")
+		assert.NoError(t, swampInterface.DeleteTreasure("user-3", false))
+
+		treasures, err = swampInterface.GetTreasuresByValue(newProbe("group-0"))
+		assert.NoError(t, err)
+
+		var keys []string
+		for _, treasureObj := range treasures {
+			keys = append(keys, treasureObj.GetKey())
+		}
+		assert.ElementsMatch(t, []string{"user-6", "user-9", "user-10"}, keys)
+
+		treasures, err = swampInterface.GetTreasuresByValue(newProbe("not-existing"))
+		assert.NoError(t, err)
+		assert.Nil(t, treasures)
+
+	})
+
+	t.Run("should return error if the value index is not enabled", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("value-index").Swamp("disabled")
+		swampInterface := New(swampName, 10*time.Second, nil, swampEventCallbackFunc, swampInfoCallbackFunc, closeCallbackFunc, metadata.New(""), false)
+		swampInterface.BeginVigil()
+		defer func() {
+			swampInterface.CeaseVigil()
+			swampInterface.Destroy()
+		}()
+
+		assert.False(t, swampInterface.IsValueIndexed())
+
+		_, err := swampInterface.GetTreasuresByValue(newProbe("value"))
+		assert.EqualError(t, err, ErrorValueIndexNotEnabled)
+
+	})
+
+}
+
+func TestSwamp_Compact(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-compact").Swamp("dead-data")
+	hashPath := t.TempDir()
+	maxFileSize := int64(1024)
+
+	newSwamp := func() Swamp {
+		chroniclerInterface := chronicler.New(hashPath, maxFileSize, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Hour,
+		}
+		return New(swampName, time.Hour, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+	}
+
+	countFiles := func() int {
+		sizes, err := fsInterface.GetFileSizes(hashPath, metadata.MetaFile)
+		assert.NoError(t, err)
+		return len(sizes)
+	}
+
+	t.Run("should rewrite the files of dead data and keep all live treasures", func(t *testing.T) {
+
+		swampInterface := newSwamp()
+		swampInterface.BeginVigil()
+
+		for i := 0; i < 1000; i++ {
+			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("test-%d", i))
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, fmt.Sprintf("content-%d lorem ipsum dolor sit amet", i))
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+		swampInterface.WriteTreasuresToFilesystem()
+
+		filesBeforeDelete := countFiles()
+		assert.Greater(t, filesBeforeDelete, 3, "the treasures should be written into several files")
+
+		// delete 90% of the treasures, so every file becomes mostly dead data
+		for i := 0; i < 1000; i++ {
+			if i%10 != 0 {
+				assert.NoError(t, swampInterface.DeleteTreasure(fmt.Sprintf("test-%d", i), false))
+			}
+		}
+		swampInterface.WriteTreasuresToFilesystem()
+		assert.Equal(t, filesBeforeDelete, countFiles(), "the deleted treasures should stay in the files as tombstones")
+
+		result, err := swampInterface.Compact(0.5)
+		assert.NoError(t, err)
+		assert.Greater(t, result.CompactedFiles, 1)
+		assert.Less(t, result.WrittenFiles, result.CompactedFiles)
+		assert.Greater(t, result.RemovedTreasures, 0)
+		assert.Greater(t, result.ReclaimedBytes, int64(0))
+		assert.Equal(t, filesBeforeDelete-result.CompactedFiles+result.WrittenFiles, countFiles())
+
+		// the next compaction has nothing to merge
+		result, err = swampInterface.Compact(0.5)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.CompactedFiles)
+
+		// the moved treasures are modified in their new files
+		treasureInterface, err := swampInterface.GetTreasure("test-10")
+		assert.NoError(t, err)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "modified")
+		_ = treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+
+		swampInterface.CeaseVigil()
+		swampInterface.Close()
+
+		reloadedSwamp := newSwamp()
+		reloadedSwamp.BeginVigil()
+		defer reloadedSwamp.CeaseVigil()
+
+		assert.Equal(t, 100, reloadedSwamp.CountTreasures())
+		for i := 0; i < 1000; i += 10 {
+			reloadedTreasure, err := reloadedSwamp.GetTreasure(fmt.Sprintf("test-%d", i))
+			assert.NoError(t, err)
+			content, err := reloadedTreasure.GetContentString()
+			assert.NoError(t, err)
+			if i == 10 {
+				assert.Equal(t, "modified", content)
+			} else {
+				assert.Equal(t, fmt.Sprintf("content-%d lorem ipsum dolor sit amet", i), content)
+			}
+		}
+
+	})
+
+	t.Run("should reject an invalid live ratio", func(t *testing.T) {
+		swampInterface := newSwamp()
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+		_, err := swampInterface.Compact(1.5)
+		assert.Error(t, err)
+	})
+
+}
+
+func TestSwamp_WriteBatchSize(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-write").Swamp("in-batches")
+	hashPath := t.TempDir()
+
+	newSwamp := func(writeBatchSize int) Swamp {
+		chroniclerInterface := chronicler.New(hashPath, 8192, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Hour,
+			WriteBatchSize:      writeBatchSize,
+		}
+		return New(swampName, time.Hour, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+	}
+
+	t.Run("should write all waiting treasures in batches", func(t *testing.T) {
+
+		swampInterface := newSwamp(7)
+		swampInterface.BeginVigil()
+
+		for i := 0; i < 100; i++ {
+			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("test-%d", i))
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, fmt.Sprintf("content-%d", i))
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+		assert.Equal(t, 100, swampInterface.CountTreasuresWaitingForWriter())
+
+		swampInterface.WriteTreasuresToFilesystem()
+		assert.Equal(t, 0, swampInterface.CountTreasuresWaitingForWriter())
+
+		swampInterface.CeaseVigil()
+		swampInterface.Close()
+
+		reloadedSwamp := newSwamp(0)
+		reloadedSwamp.BeginVigil()
+		defer reloadedSwamp.CeaseVigil()
+
+		assert.Equal(t, 100, reloadedSwamp.CountTreasures())
+		for i := 0; i < 100; i++ {
+			reloadedTreasure, err := reloadedSwamp.GetTreasure(fmt.Sprintf("test-%d", i))
+			assert.NoError(t, err)
+			content, err := reloadedTreasure.GetContentString()
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("content-%d", i), content)
+		}
+
+	})
+
+}
+
+// BenchmarkSwamp_WriteTreasuresToFilesystem measures the write throughput of the new treasures with different write
+// batch sizes. Set HYDRAIDE_BENCH_DIR to a folder on the disk to measure (e.g. an NVMe and a SATA mount) and compare
+// the results, otherwise the files are written to a temporary folder:
+//
+//	HYDRAIDE_BENCH_DIR=/mnt/nvme go test -run xxx -bench WriteTreasuresToFilesystem ./app/core/hydra/swamp/
+func BenchmarkSwamp_WriteTreasuresToFilesystem(b *testing.B) {
+
+	const treasuresPerWrite = 10000
+	content := strings.Repeat("x", 100)
+	fsInterface := filesystem.New()
+
+	for _, writeBatchSize := range []int{0, 100, 1000} {
+
+		b.Run(fmt.Sprintf("batch-%d", writeBatchSize), func(b *testing.B) {
+
+			hashPath := b.TempDir()
+			if dir := os.Getenv("HYDRAIDE_BENCH_DIR"); dir != "" {
+				var err error
+				if hashPath, err = os.MkdirTemp(dir, "hydraide-bench-"); err != nil {
+					b.Fatal(err)
+				}
+				b.Cleanup(func() { _ = os.RemoveAll(hashPath) })
+			}
+
+			chroniclerInterface := chronicler.New(hashPath, 65536, testMaxDepth, fsInterface, metadata.New(hashPath))
+			chroniclerInterface.CreateDirectoryIfNotExists()
+			fssSwamp := &FilesystemSettings{
+				ChroniclerInterface: chroniclerInterface,
+				WriteInterval:       time.Hour,
+				WriteBatchSize:      writeBatchSize,
+			}
+			swampInterface := New(name.New().Sanctuary(sanctuaryForQuickTest).Realm("bench").Swamp("write"), time.Hour,
+				fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+			swampInterface.BeginVigil()
+			defer swampInterface.CeaseVigil()
+
+			b.SetBytes(int64(treasuresPerWrite * len(content)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+
+				b.StopTimer()
+				for j := 0; j < treasuresPerWrite; j++ {
+					treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("%d-%d", i, j))
+					guardID := treasureInterface.StartTreasureGuard(true)
+					treasureInterface.SetContentString(guardID, content)
+					_ = treasureInterface.Save(guardID)
+					treasureInterface.ReleaseTreasureGuard(guardID)
+				}
+				b.StartTimer()
+
+				swampInterface.WriteTreasuresToFilesystem()
+
+			}
+
+		})
+
+	}
+
+}
+
+func TestSwamp_LeaseExpiredTreasures(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	settingsInterface := settings.New(testMaxDepth, testMaxFolderPerLevel)
+
+	fss := &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}
+
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
+	closeAfterIdle := 1 * time.Second
+	writeInterval := 1 * time.Second
+	maxFileSize := int64(8192)
+
+	t.Run("should lease, release and acknowledge the expired treasures", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-lease").Swamp("expired-treasures")
+
+		hashPath := swampName.GetFullHashPath(settingsInterface.GetHydraAbsDataFolderPath(), testAllServers, testMaxDepth, testMaxFolderPerLevel)
+		chroniclerInterface := chronicler.New(hashPath, maxFileSize, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       writeInterval,
+		}
+
+		metadataInterface := metadata.New(hashPath)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadataInterface, false)
+		swampInterface.BeginVigil()
+		defer func() {
+			swampInterface.CeaseVigil()
+			swampInterface.Destroy()
+		}()
+
+		for i := 0; i < 3; i++ {
+			treasureInterface := swampInterface.CreateTreasure(fmt.Sprintf("%d", i))
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, fmt.Sprintf("task-%d", i))
+			treasureInterface.SetExpirationTime(guardID, time.Now().Add(-time.Minute+time.Second*time.Duration(i)))
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+
+		leaseUntil := time.Now().Add(time.Minute)
+		leased, previousExpirations, err := swampInterface.LeaseExpiredTreasures(2, leaseUntil)
+		assert.NoError(t, err)
+		assert.Len(t, previousExpirations, 2)
+		if !assert.Len(t, leased, 2) {
+			return
+		}
+		assert.Equal(t, "0", leased[0].GetKey())
+		assert.Equal(t, "1", leased[1].GetKey())
+		assert.Equal(t, leaseUntil.UnixNano(), leased[0].GetExpirationTime())
+
+		// the leased treasures are hidden, only the third one is expired
+		others, _, err := swampInterface.LeaseExpiredTreasures(10, leaseUntil)
+		assert.NoError(t, err)
+		if assert.Len(t, others, 1) {
+			assert.Equal(t, "2", others[0].GetKey())
+		}
+
+		// the acknowledgement deletes the treasure
+		assert.NoError(t, swampInterface.EndLease("0", leased[0].GetExpirationTime(), true, time.Time{}))
+		assert.False(t, swampInterface.TreasureExists("0"))
+
+		// a stale lease can not end the lease
+		err = swampInterface.EndLease("1", leased[1].GetExpirationTime()+1, false, time.Now())
+		assert.EqualError(t, err, ErrorLeaseNotFound)
+
+		// the release makes the treasure visible again
+		assert.NoError(t, swampInterface.EndLease("1", leased[1].GetExpirationTime(), false, time.Now().Add(-time.Second)))
+		again, _, err := swampInterface.LeaseExpiredTreasures(10, leaseUntil)
+		assert.NoError(t, err)
+		if assert.Len(t, again, 1) {
+			assert.Equal(t, "1", again[0].GetKey())
+			content, contentErr := again[0].GetContentString()
+			assert.NoError(t, contentErr)
+			assert.Equal(t, "task-1", content)
+		}
+
+	})
+
+}
+
+func TestSwamp_CountTreasuresByFilter(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	settingsInterface := settings.New(testMaxDepth, testMaxFolderPerLevel)
+
+	fss := &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}
+
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
+	closeAfterIdle := 1 * time.Second
+	writeInterval := 1 * time.Second
+	maxFileSize := int64(8192)
+
+	t.Run("should count only the treasures matching the filter", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-count").Swamp("filtered-treasures")
+
+		hashPath := swampName.GetFullHashPath(settingsInterface.GetHydraAbsDataFolderPath(), testAllServers, testMaxDepth, testMaxFolderPerLevel)
+		chroniclerInterface := chronicler.New(hashPath, maxFileSize, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       writeInterval,
+		}
+
+		metadataInterface := metadata.New(hashPath)
+		swampInterface := New(swampName, closeAfterIdle, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadataInterface, false)
+		swampInterface.BeginVigil()
+		defer func() {
+			swampInterface.CeaseVigil()
+			swampInterface.Destroy()
+		}()
+
+		since := time.Now().Add(-time.Hour)
+
+		// job-0 and job-1 are overdue, job-2 expires later, report-3 never expires
+		for i := 0; i < 4; i++ {
+			key := fmt.Sprintf("job-%d", i)
+			if i == 3 {
+				key = fmt.Sprintf("report-%d", i)
+			}
+			treasureInterface := swampInterface.CreateTreasure(key)
+			guardID := treasureInterface.StartTreasureGuard(true)
+			treasureInterface.SetContentString(guardID, key)
+			treasureInterface.SetCreatedAt(guardID, since.Add(-time.Hour))
+			switch i {
+			case 0, 1:
+				treasureInterface.SetExpirationTime(guardID, time.Now().Add(-time.Minute))
+			case 2:
+				treasureInterface.SetExpirationTime(guardID, time.Now().Add(time.Minute))
+				treasureInterface.SetModifiedAt(guardID, since.Add(time.Minute))
+			}
+			_ = treasureInterface.Save(guardID)
+			treasureInterface.ReleaseTreasureGuard(guardID)
+		}
+
+		assert.Equal(t, 4, swampInterface.CountTreasuresByFilter(nil))
+		assert.Equal(t, 4, swampInterface.CountTreasuresByFilter(&CountFilter{}))
+		assert.Equal(t, 2, swampInterface.CountTreasuresByFilter(&CountFilter{ExpiredOnly: true}))
+		assert.Equal(t, 3, swampInterface.CountTreasuresByFilter(&CountFilter{KeyPrefix: "job-"}))
+		assert.Equal(t, 1, swampInterface.CountTreasuresByFilter(&CountFilter{UpdatedSince: since.UnixNano()}))
+		assert.Equal(t, 0, swampInterface.CountTreasuresByFilter(&CountFilter{KeyPrefix: "report-", ExpiredOnly: true}))
+
+	})
+
+}
+
+func TestSwamp_ServerTimestamps(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	settingsInterface := settings.New(testMaxDepth, testMaxFolderPerLevel)
+
+	fss := &settings.FileSystemSettings{
+		WriteIntervalSec: 1,
+		MaxFileSizeByte:  8192,
+	}
+
+	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 1, fss, nil)
+
+	t.Run("should set the creation and modification times by the server", func(t *testing.T) {
+
+		swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-set").Swamp("server-timestamps")
+
+		hashPath := swampName.GetFullHashPath(settingsInterface.GetHydraAbsDataFolderPath(), testAllServers, testMaxDepth, testMaxFolderPerLevel)
+		chroniclerInterface := chronicler.New(hashPath, int64(8192), testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Second,
+		}
+
+		swampInterface := New(swampName, time.Second, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+		swampInterface.SetServerTimestamps(true)
+		assert.True(t, swampInterface.IsServerTimestamped())
+		swampInterface.BeginVigil()
+		defer func() {
+			swampInterface.CeaseVigil()
+			swampInterface.Destroy()
+		}()
+
+		// the client clock is one hour behind
+		skewed := time.Now().Add(-time.Hour)
+
+		before := time.Now().UnixNano()
+		treasureInterface := swampInterface.CreateTreasure("order")
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "created")
+		treasureInterface.SetCreatedAt(guardID, skewed)
+		assert.Equal(t, treasure.StatusNew, treasureInterface.Save(guardID))
+		treasureInterface.ReleaseTreasureGuard(guardID)
+
+		createdAt := treasureInterface.GetCreatedAt()
+		assert.GreaterOrEqual(t, createdAt, before)
+		assert.Equal(t, int64(0), treasureInterface.GetModifiedAt())
+
+		before = time.Now().UnixNano()
+		guardID = treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "modified")
+		treasureInterface.SetModifiedAt(guardID, skewed)
+		assert.Equal(t, treasure.StatusModified, treasureInterface.Save(guardID))
+		treasureInterface.ReleaseTreasureGuard(guardID)
+
+		assert.Equal(t, createdAt, treasureInterface.GetCreatedAt())
+		assert.GreaterOrEqual(t, treasureInterface.GetModifiedAt(), before)
+
+	})
+
+}
+
+func TestSwamp_Retention(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-apply").Swamp("retention")
+
+	newSwamp := func(hashPath string, onEvent func(e *Event)) (Swamp, chronicler.Chronicler) {
+		chroniclerInterface := chronicler.New(hashPath, 1024, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Hour,
+		}
+		swampInterface := New(swampName, time.Hour, fssSwamp, onEvent, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+		swampInterface.StartSendingEvents()
+		return swampInterface, chroniclerInterface
+	}
+
+	save := func(swampInterface Swamp, key string, createdAt time.Time) {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, fmt.Sprintf("content of %s lorem ipsum dolor sit amet", key))
+		if !createdAt.IsZero() {
+			treasureInterface.SetCreatedAt(guardID, createdAt)
+		}
+		_ = treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	t.Run("should keep the newest treasures above the max number", func(t *testing.T) {
+
+		deleted := make([]string, 0)
+		swampInterface, _ := newSwamp(t.TempDir(), func(e *Event) {
+			if e.StatusType == treasure.StatusDeleted {
+				deleted = append(deleted, e.DeletedTreasure.GetKey())
+			}
+		})
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+
+		start := time.Now().Add(-time.Hour)
+		for i := 0; i < 10; i++ {
+			save(swampInterface, fmt.Sprintf("log-%d", i), start.Add(time.Duration(i)*time.Minute))
+		}
+		swampInterface.WriteTreasuresToFilesystem()
+
+		swampInterface.SetRetention(Retention{MaxTreasures: 4})
+		assert.Equal(t, 4, swampInterface.GetRetention().MaxTreasures)
+		swampInterface.WriteTreasuresToFilesystem()
+
+		assert.Equal(t, 4, swampInterface.CountTreasures())
+		for i := 6; i < 10; i++ {
+			assert.True(t, swampInterface.TreasureExists(fmt.Sprintf("log-%d", i)))
+		}
+		assert.ElementsMatch(t, []string{"log-0", "log-1", "log-2", "log-3", "log-4", "log-5"}, deleted)
+
+	})
+
+	t.Run("should delete the treasures older than the max age", func(t *testing.T) {
+
+		swampInterface, _ := newSwamp(t.TempDir(), func(e *Event) {})
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+
+		swampInterface.SetRetention(Retention{MaxAge: time.Hour})
+
+		save(swampInterface, "old", time.Now().Add(-2*time.Hour))
+		save(swampInterface, "new", time.Now())
+		save(swampInterface, "without-creation-time", time.Time{})
+		swampInterface.WriteTreasuresToFilesystem()
+
+		assert.False(t, swampInterface.TreasureExists("old"))
+		assert.True(t, swampInterface.TreasureExists("new"))
+		assert.True(t, swampInterface.TreasureExists("without-creation-time"))
+
+	})
+
+	t.Run("should delete the oldest treasures and compact the files above the max size", func(t *testing.T) {
+
+		hashPath := t.TempDir()
+		swampInterface, chroniclerInterface := newSwamp(hashPath, func(e *Event) {})
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+
+		start := time.Now().Add(-time.Hour)
+		for i := 0; i < 500; i++ {
+			save(swampInterface, fmt.Sprintf("log-%03d", i), start.Add(time.Duration(i)*time.Second))
+		}
+		swampInterface.WriteTreasuresToFilesystem()
+
+		sizeBefore, err := chroniclerInterface.GetSize()
+		assert.NoError(t, err)
+		assert.Greater(t, sizeBefore, int64(0))
+
+		swampInterface.SetRetention(Retention{MaxSizeByte: sizeBefore / 2})
+		swampInterface.WriteTreasuresToFilesystem()
+
+		sizeAfter, err := chroniclerInterface.GetSize()
+		assert.NoError(t, err)
+		assert.Less(t, sizeAfter, sizeBefore)
+		assert.Less(t, swampInterface.CountTreasures(), 500)
+		assert.False(t, swampInterface.TreasureExists("log-000"))
+		assert.True(t, swampInterface.TreasureExists("log-499"))
+
+	})
+
+	t.Run("should apply the retention when the swamp closes", func(t *testing.T) {
+
+		hashPath := t.TempDir()
+		swampInterface, _ := newSwamp(hashPath, func(e *Event) {})
+		swampInterface.BeginVigil()
+
+		start := time.Now().Add(-time.Hour)
+		for i := 0; i < 10; i++ {
+			save(swampInterface, fmt.Sprintf("log-%d", i), start.Add(time.Duration(i)*time.Minute))
+		}
+		swampInterface.SetRetention(Retention{MaxTreasures: 3})
+		swampInterface.CeaseVigil()
+		swampInterface.Close()
+
+		reloadedSwamp, _ := newSwamp(hashPath, func(e *Event) {})
+		reloadedSwamp.BeginVigil()
+		defer reloadedSwamp.CeaseVigil()
+		assert.Equal(t, 3, reloadedSwamp.CountTreasures())
+		assert.True(t, reloadedSwamp.TreasureExists("log-9"))
+
+	})
+
+}
+
+func TestSwamp_DefaultExpireAfter(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-set").Swamp("default-expiration")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	swampInterface.SetDefaultExpireAfter(time.Hour)
+	assert.Equal(t, time.Hour, swampInterface.GetDefaultExpireAfter())
+
+	save := func(key string, content string, expireAt time.Time) treasure.Treasure {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, content)
+		if !expireAt.IsZero() {
+			treasureInterface.SetExpirationTime(guardID, expireAt)
+		}
+		_ = treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+		return treasureInterface
+	}
+
+	t.Run("should set the expiration time of the new treasures without one", func(t *testing.T) {
+		before := time.Now().Add(time.Hour).UnixNano()
+		treasureInterface := save("session", "created", time.Time{})
+		assert.GreaterOrEqual(t, treasureInterface.GetExpirationTime(), before)
+		assert.LessOrEqual(t, treasureInterface.GetExpirationTime(), time.Now().Add(time.Hour).UnixNano())
+	})
+
+	t.Run("should keep the expiration time set by the client", func(t *testing.T) {
+		expireAt := time.Now().Add(time.Minute)
+		treasureInterface := save("explicit", "created", expireAt)
+		assert.Equal(t, expireAt.UnixNano(), treasureInterface.GetExpirationTime())
+	})
+
+	t.Run("should set the expiration time of the modified treasures without one", func(t *testing.T) {
+
+		swampInterface.SetDefaultExpireAfter(0)
+		treasureInterface := save("immortal", "created", time.Time{})
+		assert.Equal(t, int64(0), treasureInterface.GetExpirationTime())
+
+		// the treasure is ordered by the expiration time after the modification
+		_, err := swampInterface.GetTreasuresByBeacon(BeaconTypeExpirationTime, IndexOrderAsc, 0, 0)
+		assert.NoError(t, err)
+
+		swampInterface.SetDefaultExpireAfter(time.Nanosecond)
+		treasureInterface, err = swampInterface.GetTreasure("immortal")
+		assert.NoError(t, err)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "modified")
+		assert.Equal(t, treasure.StatusModified, treasureInterface.Save(guardID))
+		treasureInterface.ReleaseTreasureGuard(guardID)
+		assert.NotEqual(t, int64(0), treasureInterface.GetExpirationTime())
+
+		time.Sleep(time.Millisecond)
+		expired, err := swampInterface.CloneAndDeleteExpiredTreasures(10)
+		assert.NoError(t, err)
+		assert.Len(t, expired, 1)
+		assert.Equal(t, "immortal", expired[0].GetKey())
+
+	})
+
+}
+
+func TestSwamp_ShadowDelete(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-shadow-delete").Swamp("treasures")
+	hashPath := t.TempDir()
+
+	newSwamp := func() Swamp {
+		chroniclerInterface := chronicler.New(hashPath, int64(8192), testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Hour,
+		}
+		return New(swampName, time.Hour, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+	}
+
+	saveString := func(swampInterface Swamp, key string, content string) treasure.TreasureStatus {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		defer treasureInterface.ReleaseTreasureGuard(guardID)
+		treasureInterface.SetContentString(guardID, content)
+		return treasureInterface.Save(guardID)
+	}
+
+	shadowContents := func(swampInterface Swamp) map[string]string {
+		contents := make(map[string]string)
+		for _, shadowTreasure := range swampInterface.GetShadowDeletedTreasures() {
+			assert.NotEqual(t, int64(0), shadowTreasure.GetDeletedAt())
+			content, err := shadowTreasure.GetContentString()
+			assert.NoError(t, err)
+			contents[shadowTreasure.GetKey()] = content
+		}
+		return contents
+	}
+
+	reload := func(swampInterface Swamp) Swamp {
+		swampInterface.WriteTreasuresToFilesystem()
+		swampInterface.CeaseVigil()
+		swampInterface.Close()
+		reloadedSwamp := newSwamp()
+		reloadedSwamp.BeginVigil()
+		return reloadedSwamp
+	}
+
+	t.Run("should keep the shadow-deleted treasures until they are restored", func(t *testing.T) {
+
+		swampInterface := newSwamp()
+		swampInterface.BeginVigil()
+
+		saveString(swampInterface, "alpha", "content-alpha")
+		saveString(swampInterface, "beta", "content-beta")
+		swampInterface.WriteTreasuresToFilesystem()
+		// gamma is shadow-deleted before it is written to the filesystem
+		saveString(swampInterface, "gamma", "content-gamma")
+
+		assert.NoError(t, swampInterface.DeleteTreasure("alpha", true))
+		assert.NoError(t, swampInterface.DeleteTreasure("gamma", true))
+
+		assert.Equal(t, 1, swampInterface.CountTreasures())
+		_, err := swampInterface.GetTreasure("alpha")
+		assert.Error(t, err)
+		assert.Equal(t, map[string]string{"alpha": "content-alpha", "gamma": "content-gamma"}, shadowContents(swampInterface))
+
+		swampInterface = reload(swampInterface)
+		assert.Equal(t, 1, swampInterface.CountTreasures())
+		assert.Equal(t, map[string]string{"alpha": "content-alpha", "gamma": "content-gamma"}, shadowContents(swampInterface))
+
+		assert.NoError(t, swampInterface.RestoreTreasure("alpha"))
+		assert.Error(t, swampInterface.RestoreTreasure("alpha"))
+		assert.Error(t, swampInterface.RestoreTreasure("beta"))
+
+		restoredTreasure, err := swampInterface.GetTreasure("alpha")
+		assert.NoError(t, err)
+		content, err := restoredTreasure.GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "content-alpha", content)
+		assert.Equal(t, int64(0), restoredTreasure.GetDeletedAt())
+
+		swampInterface = reload(swampInterface)
+		defer swampInterface.CeaseVigil()
+		assert.Equal(t, 2, swampInterface.CountTreasures())
+		assert.Equal(t, map[string]string{"gamma": "content-gamma"}, shadowContents(swampInterface))
+
+	})
+
+	t.Run("should replace the shadow-deleted treasure with a new one", func(t *testing.T) {
+
+		swampInterface := newSwamp()
+		swampInterface.BeginVigil()
+
+		assert.Equal(t, treasure.StatusNew, saveString(swampInterface, "gamma", "new-gamma"))
+		assert.Empty(t, shadowContents(swampInterface))
+		assert.Error(t, swampInterface.RestoreTreasure("gamma"))
+
+		swampInterface = reload(swampInterface)
+		defer swampInterface.CeaseVigil()
+		assert.Equal(t, 3, swampInterface.CountTreasures())
+		assert.Empty(t, shadowContents(swampInterface))
+
+		treasureInterface, err := swampInterface.GetTreasure("gamma")
+		assert.NoError(t, err)
+		content, err := treasureInterface.GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "new-gamma", content)
+
+	})
+
+	t.Run("should not destroy the swamp with shadow-deleted treasures only", func(t *testing.T) {
+
+		swampInterface := New(name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-shadow-delete").Swamp("in-memory"),
+			time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+
+		saveString(swampInterface, "only", "content-only")
+		assert.NoError(t, swampInterface.DeleteTreasure("only", true))
+		assert.Equal(t, 0, swampInterface.CountTreasures())
+
+		assert.NoError(t, swampInterface.RestoreTreasure("only"))
+		assert.Equal(t, 1, swampInterface.CountTreasures())
+		assert.Empty(t, shadowContents(swampInterface))
+
+	})
+
+}
+
+func TestSwamp_History(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-keep-history").Swamp("treasures")
+	hashPath := t.TempDir()
+
+	newSwamp := func() Swamp {
+		chroniclerInterface := chronicler.New(hashPath, int64(8192), testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Hour,
+		}
+		swampInterface := New(swampName, time.Hour, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+		swampInterface.SetHistoryDepth(3)
+		return swampInterface
+	}
+
+	saveString := func(swampInterface Swamp, key string, content string, by string) {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		defer treasureInterface.ReleaseTreasureGuard(guardID)
+		treasureInterface.SetContentString(guardID, content)
+		treasureInterface.SetModifiedBy(guardID, by)
+		treasureInterface.Save(guardID)
+	}
+
+	versionContents := func(swampInterface Swamp, key string) ([]uint64, []string) {
+		treasureInterface, err := swampInterface.GetTreasure(key)
+		assert.NoError(t, err)
+		var numbers []uint64
+		var contents []string
+		for _, version := range treasureInterface.GetVersions() {
+			content, err := version.ToTreasure(key).GetContentString()
+			assert.NoError(t, err)
+			assert.NotEqual(t, int64(0), version.ModifiedAt)
+			numbers = append(numbers, version.Version)
+			contents = append(contents, content)
+		}
+		return numbers, contents
+	}
+
+	swampInterface := newSwamp()
+	swampInterface.BeginVigil()
+	assert.Equal(t, 3, swampInterface.GetHistoryDepth())
+
+	t.Run("should keep the last versions of the treasure", func(t *testing.T) {
+
+		for i := 1; i <= 4; i++ {
+			saveString(swampInterface, "doc", fmt.Sprintf("content-%d", i), "user-1")
+		}
+
+		numbers, contents := versionContents(swampInterface, "doc")
+		assert.Equal(t, []uint64{2, 3, 4}, numbers)
+		assert.Equal(t, []string{"content-2", "content-3", "content-4"}, contents)
+
+	})
+
+	t.Run("should revert to a version as a new version", func(t *testing.T) {
+
+		assert.NoError(t, swampInterface.RevertTreasure("doc", 3, "user-2"))
+		assert.ErrorIs(t, swampInterface.RevertTreasure("doc", 1, "user-2"), treasure.ErrVersionNotFound)
+		assert.Error(t, swampInterface.RevertTreasure("missing", 1, "user-2"))
+
+		treasureInterface, err := swampInterface.GetTreasure("doc")
+		assert.NoError(t, err)
+		content, err := treasureInterface.GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "content-3", content)
+		assert.Equal(t, "user-2", treasureInterface.GetModifiedBy())
+
+		numbers, contents := versionContents(swampInterface, "doc")
+		assert.Equal(t, []uint64{3, 4, 5}, numbers)
+		assert.Equal(t, []string{"content-3", "content-4", "content-3"}, contents)
+
+	})
+
+	t.Run("should load the history from the filesystem", func(t *testing.T) {
+
+		swampInterface.WriteTreasuresToFilesystem()
+		swampInterface.CeaseVigil()
+		swampInterface.Close()
+
+		swampInterface = newSwamp()
+		swampInterface.BeginVigil()
+		defer swampInterface.CeaseVigil()
+
+		numbers, contents := versionContents(swampInterface, "doc")
+		assert.Equal(t, []uint64{3, 4, 5}, numbers)
+		assert.Equal(t, []string{"content-3", "content-4", "content-3"}, contents)
+
+		saveString(swampInterface, "doc", "content-6", "user-1")
+		numbers, _ = versionContents(swampInterface, "doc")
+		assert.Equal(t, []uint64{4, 5, 6}, numbers)
+
+	})
+
+}
+
+func TestSwamp_FlushSnapshots(t *testing.T) {
+
+	fsInterface := filesystem.New()
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-flush").Swamp("the-snapshots")
+	hashPath := t.TempDir()
+
+	newSwamp := func() Swamp {
+		chroniclerInterface := chronicler.New(hashPath, 1024*1024, testMaxDepth, fsInterface, metadata.New(hashPath))
+		chroniclerInterface.CreateDirectoryIfNotExists()
+		fssSwamp := &FilesystemSettings{
+			ChroniclerInterface: chroniclerInterface,
+			WriteInterval:       time.Hour,
+		}
+		return New(swampName, time.Hour, fssSwamp, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(hashPath), false)
+	}
+
+	setContent := func(swampInterface Swamp, key string, content string) {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, content)
+		_ = treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+
+	t.Run("should write the snapshots and keep the modifications made during the flush", func(t *testing.T) {
+
+		swampInterface := newSwamp()
+		counters := &flushstall.Counters{}
+		swampInterface.SetFlushStallCounters(counters)
+		swampInterface.BeginVigil()
+
+		const count = 500
+		for i := 0; i < count; i++ {
+			setContent(swampInterface, fmt.Sprintf("key-%d", i), strings.Repeat("a", 4096))
+		}
+
+		// the treasures are modified while they are flushed
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < count; i += 10 {
+				setContent(swampInterface, fmt.Sprintf("key-%d", i), "modified")
+			}
+		}()
+		swampInterface.WriteTreasuresToFilesystem()
+		<-done
+
+		assert.Equal(t, uint64(count), counters.Stats().Snapshots)
+
+		// the modifications made after the snapshot are written by the next flush
+		swampInterface.WriteTreasuresToFilesystem()
+		assert.Equal(t, 0, swampInterface.CountTreasuresWaitingForWriter())
+		swampInterface.CeaseVigil()
+		swampInterface.Close()
+
+		reloadedSwamp := newSwamp()
+		reloadedSwamp.BeginVigil()
+		defer reloadedSwamp.CeaseVigil()
+
+		assert.Equal(t, count, reloadedSwamp.CountTreasures())
+		for i := 0; i < count; i++ {
+			reloadedTreasure, err := reloadedSwamp.GetTreasure(fmt.Sprintf("key-%d", i))
+			require.NoError(t, err)
+			content, err := reloadedTreasure.GetContentString()
+			assert.NoError(t, err)
+			if i%10 == 0 {
+				assert.Equal(t, "modified", content, "key-%d", i)
+			} else {
+				assert.Len(t, content, 4096, "key-%d", i)
+			}
+		}
+
+	})
+
+}
+
+func TestSwamp_KeyLocks(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-lock").Swamp("the-keys")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	counters := &keylock.Counters{}
+	swampInterface.SetKeyLockCounters(counters)
+
+	t.Run("should not lose the increments of the concurrent writers of a new key", func(t *testing.T) {
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, incremented, err := swampInterface.IncrementInt64("hot-counter", 1, nil)
+				assert.NoError(t, err)
+				assert.True(t, incremented)
+			}()
+		}
+		wg.Wait()
+
+		treasureInterface, err := swampInterface.GetTreasure("hot-counter")
+		assert.NoError(t, err)
+		value, err := treasureInterface.GetContentInt64()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), value)
+
+		assert.Equal(t, uint64(100), swampInterface.GetKeyLockStats().Acquisitions)
+		assert.Equal(t, swampInterface.GetKeyLockStats(), counters.Stats())
+
+	})
+
+}
+
+func TestSwamp_BeaconIncrementalOrder(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-order").Swamp("incrementally")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	for i, key := range []string{"a", "b", "c"} {
+		_, _, err := swampInterface.IncrementInt64(key, int64(i+1)*10, nil)
+		assert.NoError(t, err)
+	}
+
+	keys := func() []string {
+		treasures, err := swampInterface.GetTreasuresByBeacon(BeaconTypeValueInt64, IndexOrderDesc, 0, 10)
+		assert.NoError(t, err)
+		var keys []string
+		for _, treasureObj := range treasures {
+			keys = append(keys, treasureObj.GetKey())
+		}
+		return keys
+	}
+
+	// the first query builds the beacon, the later writes keep its order
+	assert.Equal(t, []string{"c", "b", "a"}, keys())
+
+	_, _, err := swampInterface.IncrementInt64("a", 100, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b"}, keys(), "the modified treasure is moved to its new position")
+
+	_, _, err = swampInterface.IncrementInt64("d", 15, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b", "d"}, keys(), "the new treasure is inserted to its position")
+
+	swampInterface.DeleteTreasure("c", false)
+	assert.Equal(t, []string{"a", "b", "d"}, keys())
+
+}
+
+func TestSwamp_BeaconEviction(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-evict").Swamp("idle-beacons")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+	s := swampInterface.(*swamp)
+
+	createdAt := time.Now()
+	save := func(key string, created time.Time) {
+		treasureInterface := swampInterface.CreateTreasure(key)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, key)
+		treasureInterface.SetCreatedAt(guardID, created)
+		_ = treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+	}
+	keysByCreationTime := func() []string {
+		treasures, err := swampInterface.GetTreasuresByBeacon(BeaconTypeCreationTime, IndexOrderAsc, 0, 0)
+		assert.NoError(t, err)
+		var keys []string
+		for _, treasureObj := range treasures {
+			keys = append(keys, treasureObj.GetKey())
+		}
+		return keys
+	}
+
+	save("b", createdAt.Add(2*time.Second))
+	save("a", createdAt.Add(time.Second))
+
+	swampInterface.SetBeacons([]BeaconType{BeaconTypeKey}, time.Minute)
+
+	t.Run("should build the kept beacons right away", func(t *testing.T) {
+		assert.True(t, s.keyBeaconASC.IsInitialized())
+		assert.True(t, s.keyBeaconDESC.IsInitialized())
+		assert.False(t, s.creationTimeBeaconASC.IsInitialized(), "the other beacons are built at their first use")
+	})
+
+	t.Run("should evict only the idle beacons that are not kept", func(t *testing.T) {
+
+		assert.Equal(t, []string{"a", "b"}, keysByCreationTime())
+		assert.True(t, s.creationTimeBeaconASC.IsInitialized())
+
+		s.evictIdleBeacons(time.Now())
+		assert.True(t, s.creationTimeBeaconASC.IsInitialized(), "the beacon was used right now")
+
+		s.evictIdleBeacons(time.Now().Add(2 * time.Minute))
+		assert.False(t, s.creationTimeBeaconASC.IsInitialized())
+		assert.False(t, s.creationTimeBeaconDESC.IsInitialized())
+		assert.True(t, s.keyBeaconASC.IsInitialized(), "the kept beacons are never evicted")
+
+	})
+
+	t.Run("should rebuild the evicted beacon at its next use", func(t *testing.T) {
+		save("c", createdAt)
+		assert.Equal(t, []string{"c", "a", "b"}, keysByCreationTime())
+	})
+
+	t.Run("should not evict the beacons in use", func(t *testing.T) {
+		s.beaconsMu.RLock()
+		s.evictIdleBeacons(time.Now().Add(2 * time.Minute))
+		s.beaconsMu.RUnlock()
+		assert.True(t, s.creationTimeBeaconASC.IsInitialized())
+	})
+
+	t.Run("should keep every beacon without eviction time", func(t *testing.T) {
+		swampInterface.SetBeacons(nil, 0)
+		s.evictIdleBeacons(time.Now().Add(time.Hour))
+		assert.True(t, s.creationTimeBeaconASC.IsInitialized())
+	})
+
+}
+
+func TestSwamp_Timestamps(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-handle").Swamp("the-timestamps")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	t.Run("should store only the later timestamps", func(t *testing.T) {
+
+		stored, isSet, err := swampInterface.SetIfLater("last-seen", 1000)
+		assert.NoError(t, err)
+		assert.True(t, isSet, "the missing key is created")
+		assert.Equal(t, int64(1000), stored)
+
+		stored, isSet, err = swampInterface.SetIfLater("last-seen", 900)
+		assert.NoError(t, err)
+		assert.False(t, isSet)
+		assert.Equal(t, int64(1000), stored, "the stored timestamp is returned")
+
+		stored, isSet, err = swampInterface.SetIfLater("last-seen", 1000)
+		assert.NoError(t, err)
+		assert.False(t, isSet, "the same timestamp is not later")
+		assert.Equal(t, int64(1000), stored)
+
+		stored, isSet, err = swampInterface.SetIfLater("last-seen", 1500)
+		assert.NoError(t, err)
+		assert.True(t, isSet)
+		assert.Equal(t, int64(1500), stored)
+
+	})
+
+	t.Run("should add the durations to the timestamps", func(t *testing.T) {
+
+		before := time.Now().Unix()
+		timestamp, err := swampInterface.AddDuration("lease", 60, false)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, timestamp, before+60, "the missing key is counted from now")
+		assert.LessOrEqual(t, timestamp, time.Now().Unix()+60)
+
+		next, err := swampInterface.AddDuration("lease", 30, true)
+		assert.NoError(t, err)
+		assert.Equal(t, timestamp+30, next, "the future timestamp is extended")
+
+		next, err = swampInterface.AddDuration("lease", -10, false)
+		assert.NoError(t, err)
+		assert.Equal(t, timestamp+20, next)
+
+		_, _, err = swampInterface.SetIfLater("expired", 1000)
+		assert.NoError(t, err)
+
+		next, err = swampInterface.AddDuration("expired", 60, false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1060), next, "the past timestamp is extended without fromNowIfPast")
+
+		before = time.Now().Unix()
+		next, err = swampInterface.AddDuration("expired", 60, true)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, next, before+60, "the past timestamp is counted from now")
+
+	})
+
+	t.Run("should reject the keys of other value types", func(t *testing.T) {
+
+		treasureObj := swampInterface.CreateTreasure("name")
+		guardID := treasureObj.StartTreasureGuard(true)
+		treasureObj.SetContentString(guardID, "not a timestamp")
+		treasureObj.Save(guardID)
+		treasureObj.ReleaseTreasureGuard(guardID)
+
+		_, _, err := swampInterface.SetIfLater("name", 1000)
+		assert.EqualError(t, err, ErrorValueIsNotInt)
+
+		_, err = swampInterface.AddDuration("name", 60, false)
+		assert.EqualError(t, err, ErrorValueIsNotInt)
+
+	})
+
+}
+
+func TestSwamp_SetIf(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-set").Swamp("the-conditional-values")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	t.Run("should move the string values by their conditions", func(t *testing.T) {
+
+		storedValue, isSet, err := swampInterface.SetStringIf("job", "processing", &SetStringCondition{Operator: SetIfOperatorEqual, Value: ""})
+		assert.NoError(t, err)
+		assert.False(t, isSet, "the missing key never equals")
+		assert.Equal(t, "", storedValue)
+		assert.False(t, swampInterface.TreasureExists("job"), "the unmet condition does not create the key")
+
+		storedValue, isSet, err = swampInterface.SetStringIf("job", "pending", &SetStringCondition{Operator: SetIfOperatorNotExists})
+		assert.NoError(t, err)
+		assert.True(t, isSet)
+		assert.Equal(t, "pending", storedValue)
+
+		storedValue, isSet, err = swampInterface.SetStringIf("job", "pending", &SetStringCondition{Operator: SetIfOperatorNotExists})
+		assert.NoError(t, err)
+		assert.False(t, isSet)
+		assert.Equal(t, "pending", storedValue)
+
+		storedValue, isSet, err = swampInterface.SetStringIf("job", "done", &SetStringCondition{Operator: SetIfOperatorEqual, Value: "processing"})
+		assert.NoError(t, err)
+		assert.False(t, isSet)
+		assert.Equal(t, "pending", storedValue)
+
+		storedValue, isSet, err = swampInterface.SetStringIf("job", "processing", &SetStringCondition{Operator: SetIfOperatorEqual, Value: "pending"})
+		assert.NoError(t, err)
+		assert.True(t, isSet)
+		assert.Equal(t, "processing", storedValue)
+
+		storedValue, isSet, err = swampInterface.SetStringIf("job", "failed", nil)
+		assert.NoError(t, err)
+		assert.True(t, isSet, "the value is set without a condition")
+		assert.Equal(t, "failed", storedValue)
+
+	})
+
+	t.Run("should let only one of the concurrent writers take the transition", func(t *testing.T) {
+
+		_, _, err := swampInterface.SetStringIf("contested", "pending", nil)
+		assert.NoError(t, err)
+
+		var wg sync.WaitGroup
+		var taken atomic.Int32
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, isSet, err := swampInterface.SetStringIf("contested", "processing", &SetStringCondition{Operator: SetIfOperatorEqual, Value: "pending"})
+				assert.NoError(t, err)
+				if isSet {
+					taken.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), taken.Load())
+
+	})
+
+	t.Run("should move the bool values by their conditions", func(t *testing.T) {
+
+		storedValue, isSet, err := swampInterface.SetBoolIf("sent", false, &SetBoolCondition{Operator: SetIfOperatorNotExists})
+		assert.NoError(t, err)
+		assert.True(t, isSet)
+		assert.False(t, storedValue)
+
+		storedValue, isSet, err = swampInterface.SetBoolIf("sent", true, &SetBoolCondition{Operator: SetIfOperatorEqual, Value: false})
+		assert.NoError(t, err)
+		assert.True(t, isSet)
+		assert.True(t, storedValue)
+
+		storedValue, isSet, err = swampInterface.SetBoolIf("sent", true, &SetBoolCondition{Operator: SetIfOperatorEqual, Value: false})
+		assert.NoError(t, err)
+		assert.False(t, isSet)
+		assert.True(t, storedValue)
+
+	})
+
+	t.Run("should reject the keys of other value types", func(t *testing.T) {
+
+		_, _, err := swampInterface.IncrementInt64("counter", 1, nil)
+		assert.NoError(t, err)
+
+		_, _, err = swampInterface.SetStringIf("counter", "pending", &SetStringCondition{Operator: SetIfOperatorNotExists})
+		assert.EqualError(t, err, ErrorValueIsNotString)
+
+		_, _, err = swampInterface.SetBoolIf("job", true, nil)
+		assert.EqualError(t, err, ErrorValueIsNotBool)
+
+	})
+
+}
+
+func TestSwamp_ReserveTreasures(t *testing.T) {
+
+	swampName := name.New().Sanctuary(sanctuaryForQuickTest).Realm("should-reserve").Swamp("the-treasures")
+	swampInterface := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	treasureInterface := swampInterface.CreateTreasure("existing")
+	guardID := treasureInterface.StartTreasureGuard(true)
+	treasureInterface.SetContentString(guardID, "value")
+	treasureInterface.Save(guardID)
+	treasureInterface.ReleaseTreasureGuard(guardID)
+
+	t.Run("should count the existing and the reserved treasures", func(t *testing.T) {
+
+		release, total, ok := swampInterface.ReserveTreasures(2, 4)
+		assert.True(t, ok)
+		assert.Equal(t, 3, total)
+
+		_, total, ok = swampInterface.ReserveTreasures(2, 4)
+		assert.False(t, ok)
+		assert.Equal(t, 5, total)
+
+		release()
+		release()
+		_, total, ok = swampInterface.ReserveTreasures(2, 4)
+		assert.True(t, ok)
+		assert.Equal(t, 3, total)
+
+	})
+
+	t.Run("should not give the last free room to more writers", func(t *testing.T) {
+
+		limited := New(swampName, time.Hour, nil, func(e *Event) {}, func(i *Info) {}, func(n name.Name) {}, metadata.New(t.TempDir()), false)
+		limited.BeginVigil()
+		defer limited.CeaseVigil()
+
+		var reserved atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, _, ok := limited.ReserveTreasures(1, 10); ok {
+					reserved.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(10), reserved.Load())
+
+	})
+
+}
@@ -89,6 +89,10 @@ type Guard interface {
 	// correct sequencing but is not meant for external invocation. Always use the other functions provided by
 	// the Treasure interface, and they will handle the sequencing internally using CanExecute.
 	CanExecute(guardID ID, isBodyFunction ...bool) error
+
+	// Waiters returns the number of the goroutines waiting in the queue behind the holder of the Treasure. It is
+	// used for the monitoring only, e.g. to count the operations delayed by the writer of the Swamp.
+	Waiters() int
 }
 
 type ID int64
@@ -200,3 +204,10 @@ func (g *guard) CanExecute(guardID ID, isBodyFunction ...bool) error {
 	}
 	return nil
 }
+
+// Waiters returns the number of the guard IDs waiting behind the holder of the transaction
+func (g *guard) Waiters() int {
+	g.cond.L.Lock()
+	defer g.cond.L.Unlock()
+	return max(len(g.waitForUnlock)-1, 0)
+}
@@ -1,6 +1,7 @@
 package guard
 
 import (
+	"runtime"
 	"sync"
 	"testing"
 )
@@ -55,6 +56,40 @@ func TestNew(t *testing.T) {
 	wg.Wait()
 }
 
+// TestWaiters tests that the goroutines queued behind the holder are counted
+func TestWaiters(t *testing.T) {
+
+	myObject := NewMyObject()
+	if waiters := myObject.Waiters(); waiters != 0 {
+		t.Fatalf("the free guard should have no waiters, got %d", waiters)
+	}
+
+	lockerID := myObject.StartTreasureGuard(true)
+	if waiters := myObject.Waiters(); waiters != 0 {
+		t.Fatalf("the holder should not be counted as a waiter, got %d", waiters)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			myObject.ReleaseTreasureGuard(myObject.StartTreasureGuard(true))
+		}()
+	}
+
+	for myObject.Waiters() != 2 {
+		runtime.Gosched()
+	}
+
+	myObject.ReleaseTreasureGuard(lockerID)
+	wg.Wait()
+	if waiters := myObject.Waiters(); waiters != 0 {
+		t.Fatalf("the released guard should have no waiters, got %d", waiters)
+	}
+
+}
+
 // BenchmarkNew benchmarks the Guard implementation.
 // It measures the time it takes to acquire and release a transaction using the Guard.
 // goos: windows
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
 	"reflect"
@@ -38,6 +39,21 @@ type Treasure interface {
 
 	Clone(guardID guard.ID) Treasure
 
+	// Snapshot returns a copy of the treasure as it is now, with its deletion, history and file name, so the copy can
+	// be written to the filesystem in place of the treasure. Unlike the Clone, the snapshot is the same treasure, not
+	// a new one.
+	//
+	// The snapshot has its own guard and no save method. The writer of the swamp holds the guard of the treasure only
+	// while the snapshot is taken, and converts the snapshot to binary and writes it to the disk without the guard,
+	// so the treasure is not blocked during the flush.
+	//
+	// Example:
+	//     guardID := treasure.StartTreasureGuard(true, guard.BodyAuthID)
+	//     snapshot := treasure.Snapshot(guardID)
+	//     treasure.ReleaseTreasureGuard(guardID)
+	//     chronicler.Write([]treasure.Treasure{snapshot})
+	Snapshot(guardID guard.ID) Treasure
+
 	// GetKey returns the unique key identifying the treasure in the database.
 	//
 	// GetKey is an exception to the guard protection rule, and can be called without
@@ -129,6 +145,34 @@ type Treasure interface {
 	// 3. Data analytics related to user-generated treasures.
 	GetCreatedBy() string
 
+	// GetSchemaVersion returns the schema version of the client model stored in the treasure.
+	// The version is set by the clients (SDKs) that support model versioning and migrations, the server only stores
+	// it. Returns 0 if the treasure was written without a version.
+	GetSchemaVersion() uint32
+
+	// AppendVersion stores the current content of the treasure as a new version in its history, and drops the oldest
+	// versions, so at most maxVersions versions are kept. The history is part of the treasure, so it is written to
+	// the filesystem and loaded back with the treasure.
+	//
+	// A `guardID`, which can be obtained via the `StartTreasureGuard` method, is required to synchronize and secure access to the treasure.
+	//
+	// Returns:
+	// (uint64): The number of the new version. The versions of a treasure are numbered from 1.
+	AppendVersion(guardID guard.ID, modifiedAt int64, modifiedBy string, maxVersions int) uint64
+
+	// GetVersions returns the versions stored in the history of the treasure, from the oldest to the newest.
+	// The newest version is the current content of the treasure. Do not modify the content of the versions.
+	GetVersions() []Version
+
+	// RevertToVersion sets the content of the treasure to the content of the given version from its history.
+	// The treasure must be saved after the revert, like after any other change of the content.
+	//
+	// A `guardID`, which can be obtained via the `StartTreasureGuard` method, is required to synchronize and secure access to the treasure.
+	//
+	// Returns:
+	// (error): ErrVersionNotFound if the version is not in the history of the treasure.
+	RevertToVersion(guardID guard.ID, version uint64) error
+
 	// GetDeletedAt returns the UnixNano timestamp indicating when the treasure was deleted.
 	// This can be useful for auditing or record-keeping. If the treasure has not been deleted, this method
 	// returns 0. To ensure a synchronized and safe access, a guardID obtained from StartTreasureGuard is
@@ -327,16 +371,28 @@ type Treasure interface {
 	// - To retrieve all associated domain IDs or unique identifiers efficiently.
 	Uint32SliceGetAll() ([]uint32, error)
 
+	// Uint32SliceGetRange returns at most limit values of the Uint32Slice from the offset, in the order they were
+	// pushed. Only the values of the range are decoded, so a page of a huge slice is cheap.
+	//
+	// An offset beyond the end of the slice returns no values. Returns an error if the content type is not a
+	// Uint32Slice.
+	//
+	// Example:
+	//     page, err := treasure.Uint32SliceGetRange(1000, 500)
+	Uint32SliceGetRange(offset int, limit int) ([]uint32, error)
+
 	// Uint32SlicePush adds one or more uint32 values to the Uint32Slice.
 	// This function ensures that duplicate values are not inserted, preserving the integrity
 	// of the stored set. Each uint32 value is stored in a compact 4-byte format.
 	//
 	// If the slice does not exist, it initializes it before appending new values.
 	//
-	// Returns an error if the operation fails due to memory constraints or internal inconsistencies.
+	// Returns the number of the added values: the values already in the slice, and the repeated values of the
+	// parameter are not counted. Returns an error if the operation fails due to memory constraints or internal
+	// inconsistencies.
 	//
 	// Example:
-	//     err := treasure.Uint32SlicePush([]uint32{123456, 789012})
+	//     added, err := treasure.Uint32SlicePush([]uint32{123456, 789012})
 	//     if err != nil {
 	//         fmt.Println("Error adding values:", err)
 	//     }
@@ -344,7 +400,7 @@ type Treasure interface {
 	// Use cases:
 	// - Efficiently indexing new uint32 values without duplicates.
 	// - Preventing redundant storage of identifiers in memory-sensitive applications.
-	Uint32SlicePush([]uint32) error
+	Uint32SlicePush([]uint32) (added int, err error)
 
 	// Uint32SliceDelete removes specific uint32 values from the Uint32Slice.
 	// This function scans the slice and removes occurrences of the specified values,
@@ -727,6 +783,10 @@ type Treasure interface {
 	// 2. For auditing and tracking purposes.
 	SetCreatedBy(guardID guard.ID, createdBy string)
 
+	// SetSchemaVersion sets the schema version of the client model stored in the treasure.
+	// A changed version is handled as a content modification, because the shape of the content changed.
+	SetSchemaVersion(guardID guard.ID, version uint32)
+
 	// SetModifiedBy sets the modifier ID and the current time as the modified time of the treasure.
 	// This function is optional but recommended to use for better user operation tracking.
 	// A guardID, which can be obtained via the StartTreasureGuard method, is required to synchronize and secure access to the treasure.
@@ -833,6 +893,18 @@ type Treasure interface {
 	// 3. To perform a "soft delete" operation with `shadowDelete` for future restoration or auditing purposes.
 	BodySetForDeletion(guardID guard.ID, byUserID string, shadowDelete bool)
 
+	// BodySetForRestore removes the deletion marks of a shadow-deleted treasure.
+	// The content and the expiration time of a shadow-deleted treasure are kept, so after the restore the treasure is
+	// the same as it was before the deletion.
+	//
+	// A `guardID`, which can be obtained via the `StartTreasureGuard` method, is required to synchronize and secure access to the treasure.
+	//
+	// Example:
+	//     guardID := treasure.StartTreasureGuard(true)
+	//     treasure.BodySetForRestore(guardID)
+	//     treasure.ReleaseTreasureGuard(guardID)
+	BodySetForRestore(guardID guard.ID)
+
 	// BodySetKey sets the key of the treasure when it is created.
 	// This function is critical as it establishes the unique identifier for the treasure. It is called only once,
 	// at the moment of the treasure's creation within the system. Once the key is set, it cannot be changed.
@@ -878,6 +950,8 @@ type Treasure interface {
 	ConvertToByte(guardID guard.ID) ([]byte, error)
 
 	// LoadFromByte load the Treasure from a binary
+	// The fileName is the file of the Treasure. Empty fileName means the Treasure is not stored in a file yet, e.g.
+	// it is loaded from a backup or from the write-ahead log.
 	//
 	// Note: This method is reserved for use by the Hydra Body. Hydra Head Plugins should NOT invoke this method.
 	//
@@ -990,19 +1064,49 @@ const (
 	StatusSame                           // StatusSame not send any data to the channel when a Treasure is not modified
 )
 
+// ErrVersionNotFound is returned if the requested version is not in the history of the treasure
+var ErrVersionNotFound = errors.New("the version is not found in the history of the treasure")
+
+// Version is a version of the content in the history of the treasure
+type Version struct {
+	Version    uint64   // the number of the version, the versions of a treasure are numbered from 1
+	Content    *Content // the content of the treasure in this version
+	ModifiedAt int64    // when the version was saved in UnixNano
+	ModifiedBy string   // UID of the modifier, who saved the version
+}
+
+// ToTreasure returns a read-only treasure with the key and the content of the version, so the version can be
+// converted like any other treasure. The content is cloned, so the history is not modified through the treasure.
+func (v Version) ToTreasure(key string) Treasure {
+	versionTreasure := &treasure{treasure: Model{Content: v.Content}}
+	content := versionTreasure.cloneContent()
+	return &treasure{
+		treasure: Model{
+			Key:        key,
+			Content:    &content,
+			ModifiedAt: v.ModifiedAt,
+			ModifiedBy: v.ModifiedBy,
+		},
+		Guard: guard.New(),
+	}
+}
+
 // Model is the model of the treasure but DO NOT modify this struct from outside the package
 type Model struct {
-	Key              string   // unique key of the content. This is the string from the map[string]
-	Content          *Content // content of the treasure. May be nil if we want to delete the content or the content is EMPTY
-	CreatedAt        int64    // when the data inserted into the system in UnixNano
-	CreatedBy        string   // UID of the creator, who created the treasure
-	CreatedByChanged bool     // flag to indicate if the created by is changed or not
-	DeletedAt        int64    // the unix time (UnixNano) if the content was removed from the map
-	DeletedBy        string   // UID of the deleter, who deleted the treasure
-	ModifiedAt       int64    // the unix time (UnixNano) if the content was modified
-	ModifiedBy       string   // UID of the modifier, who modified the treasure
-	ExpirationTime   int64    // the unix time for time type ordering. This field should be empty, but useful if we want to create a message queue
-	FileName         *string  // the current file name pointer. Pointer because we don't want to store the file name in the database
+	Key              string    // unique key of the content. This is the string from the map[string]
+	Content          *Content  // content of the treasure. May be nil if we want to delete the content or the content is EMPTY
+	CreatedAt        int64     // when the data inserted into the system in UnixNano
+	CreatedBy        string    // UID of the creator, who created the treasure
+	CreatedByChanged bool      // flag to indicate if the created by is changed or not
+	DeletedAt        int64     // the unix time (UnixNano) if the content was removed from the map
+	DeletedBy        string    // UID of the deleter, who deleted the treasure
+	ModifiedAt       int64     // the unix time (UnixNano) if the content was modified
+	ModifiedBy       string    // UID of the modifier, who modified the treasure
+	ExpirationTime   int64     // the unix time for time type ordering. This field should be empty, but useful if we want to create a message queue
+	SchemaVersion    uint32    // the schema version of the client model stored in the content. 0 if the client does not use versioning
+	ShadowDeleted    bool      // true if the treasure is deleted, but its content is kept, so it can be restored
+	History          []Version // the previous and the current versions of the content, if the history is enabled
+	FileName         *string   // the current file name pointer. Pointer because we don't want to store the file name in the database
 }
 
 type treasure struct {
@@ -1017,7 +1121,6 @@ type treasure struct {
 	createdByChanged      bool // flag to indicate if the created by is changed or not
 	deletedAtChanged      bool // flag to indicate if the deleted at is changed or not
 	deletedByChanged      bool // flag to indicate if the deleted by is changed or not
-	shadowDeleted         bool // flag to indicate if the treasure is shadow deleted or not
 	modifiedAtChanged     bool // flag to indicate if the modified at is changed or not
 	modifiedByChanged     bool // flag to indicate if the modified by is changed or not
 }
@@ -1218,6 +1321,15 @@ func (t *treasure) SetCreatedBy(guardID guard.ID, createdBy string) {
 	t.treasure.CreatedBy = createdBy
 	t.treasure.CreatedAt = time.Now().UTC().UnixNano()
 }
+func (t *treasure) SetSchemaVersion(guardID guard.ID, version uint32) {
+	_ = t.Guard.CanExecute(guardID)
+	if t.treasure.SchemaVersion == version {
+		return
+	}
+	t.contentChanged = true
+	t.treasure.SchemaVersion = version
+}
+
 func (t *treasure) SetModifiedBy(guardID guard.ID, modifiedBy string) {
 	_ = t.Guard.CanExecute(guardID)
 	t.modifiedByChanged = true
@@ -1240,6 +1352,7 @@ func (t *treasure) Clone(guardID guard.ID) Treasure {
 			DeletedBy:      "",
 			ModifiedAt:     t.treasure.ModifiedAt,
 			ModifiedBy:     t.treasure.ModifiedBy,
+			SchemaVersion:  t.treasure.SchemaVersion,
 			// We don't want to clone the file name pointer because this treasure will be a new treasure and the fileName
 			// should be a new, too.
 			// And the chronicler will write the treasure as a new one, only if the fileName is nil
@@ -1260,6 +1373,32 @@ func (t *treasure) Clone(guardID guard.ID) Treasure {
 
 }
 
+func (t *treasure) Snapshot(guardID guard.ID) Treasure {
+
+	_ = t.Guard.CanExecute(guardID)
+
+	snapshot := &treasure{
+		treasure: t.treasure,
+		Guard:    guard.New(),
+	}
+
+	// the content and the history are modified in place by some setters, so they are copied
+	if t.treasure.Content != nil {
+		content := t.cloneContent()
+		snapshot.treasure.Content = &content
+	}
+	if t.treasure.History != nil {
+		snapshot.treasure.History = append([]Version(nil), t.treasure.History...)
+	}
+	if t.treasure.FileName != nil {
+		fileName := *t.treasure.FileName
+		snapshot.treasure.FileName = &fileName
+	}
+
+	return snapshot
+
+}
+
 func (t *treasure) CloneContent(guardID guard.ID) Content {
 	_ = t.Guard.CanExecute(guardID)
 	return t.cloneContent()
@@ -1431,7 +1570,7 @@ func (t *treasure) BodySetForDeletion(guardID guard.ID, byUserID string, shadowD
 	t.treasure.DeletedAt = timeNow
 	t.treasure.DeletedBy = byUserID
 
-	t.shadowDeleted = shadowDelete
+	t.treasure.ShadowDeleted = shadowDelete
 
 	// DO NOT DELETE THE CONTENT and the expiration time from the treasure if the shadowDelete is true, because we need
 	// the whole treasure in unchanged state to be able to restore it, or read it as a deleted treasure
@@ -1444,6 +1583,20 @@ func (t *treasure) BodySetForDeletion(guardID guard.ID, byUserID string, shadowD
 
 }
 
+func (t *treasure) BodySetForRestore(guardID guard.ID) {
+	if canExecuteErr := t.Guard.CanExecute(guardID); canExecuteErr != nil {
+		return
+	}
+
+	t.deletedAtChanged = true
+	t.deletedByChanged = true
+
+	t.treasure.DeletedAt = 0
+	t.treasure.DeletedBy = ""
+	t.treasure.ShadowDeleted = false
+
+}
+
 func (t *treasure) GetKey() string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -1474,6 +1627,74 @@ func (t *treasure) GetCreatedBy() string {
 	return t.treasure.CreatedBy
 }
 
+func (t *treasure) AppendVersion(guardID guard.ID, modifiedAt int64, modifiedBy string, maxVersions int) uint64 {
+
+	_ = t.Guard.CanExecute(guardID)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	version := uint64(1)
+	if len(t.treasure.History) > 0 {
+		version = t.treasure.History[len(t.treasure.History)-1].Version + 1
+	}
+
+	content := Content{Void: true}
+	if t.treasure.Content != nil {
+		content = t.cloneContent()
+	}
+
+	t.treasure.History = append(t.treasure.History, Version{
+		Version:    version,
+		Content:    &content,
+		ModifiedAt: modifiedAt,
+		ModifiedBy: modifiedBy,
+	})
+
+	// drop the oldest versions, and copy the kept ones, so the dropped contents can be freed
+	if maxVersions > 0 && len(t.treasure.History) > maxVersions {
+		t.treasure.History = append([]Version(nil), t.treasure.History[len(t.treasure.History)-maxVersions:]...)
+	}
+
+	return version
+
+}
+
+func (t *treasure) GetVersions() []Version {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]Version(nil), t.treasure.History...)
+}
+
+func (t *treasure) RevertToVersion(guardID guard.ID, version uint64) error {
+
+	_ = t.Guard.CanExecute(guardID)
+
+	for _, v := range t.treasure.History {
+		if v.Version != version {
+			continue
+		}
+		// the content of the version is cloned, so the history is not modified by the later changes
+		versionTreasure := &treasure{treasure: Model{Content: v.Content}}
+		content := versionTreasure.cloneContent()
+		if versionTreasure.GetContentType() != t.GetContentType() {
+			t.contentTypeChanged = true
+		}
+		t.contentChanged = true
+		t.treasure.Content = &content
+		return nil
+	}
+
+	return ErrVersionNotFound
+
+}
+
+func (t *treasure) GetSchemaVersion() uint32 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.treasure.SchemaVersion
+}
+
 func (t *treasure) GetDeletedAt() int64 {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -1489,7 +1710,7 @@ func (t *treasure) GetDeletedBy() string {
 func (t *treasure) GetShadowDelete() bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	return t.shadowDeleted
+	return t.treasure.ShadowDeleted
 }
 
 func (t *treasure) SetModifiedAt(guardID guard.ID, modifiedAt time.Time) {
@@ -1523,6 +1744,7 @@ func (t *treasure) BodySetKey(guardID guard.ID, key string) {
 	}
 	t.treasure.DeletedBy = ""
 	t.treasure.DeletedAt = 0
+	t.treasure.ShadowDeleted = false
 	t.treasure.Key = key
 }
 
@@ -1544,6 +1766,7 @@ func (t *treasure) ConvertToByte(guardID guard.ID) ([]byte, error) {
 			DeletedBy:      t.treasure.DeletedBy,
 			ModifiedAt:     t.treasure.ModifiedAt,
 			ModifiedBy:     t.treasure.ModifiedBy,
+			SchemaVersion:  t.treasure.SchemaVersion,
 			Content:        t.treasure.Content,
 			FileName:       nil,
 		},
@@ -1580,6 +1803,11 @@ func (t *treasure) LoadFromByte(guardID guard.ID, b []byte, fileName string) err
 		return err
 	}
 	// filenév beállítása
+	// the empty file name means the treasure is not stored in a file yet, e.g. it is loaded from a backup
+	if fileName == "" {
+		t.treasure.FileName = nil
+		return nil
+	}
 	t.treasure.FileName = &fileName
 	return nil
 }
@@ -2186,7 +2414,36 @@ func (t *treasure) Uint32SliceGetAll() ([]uint32, error) {
 	return result, nil
 }
 
-func (t *treasure) Uint32SlicePush(values []uint32) error {
+func (t *treasure) Uint32SliceGetRange(offset int, limit int) ([]uint32, error) {
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.treasure.Content == nil || t.treasure.Content.Uint32Slice == nil {
+		return nil, fmt.Errorf("content type is not a uint32 slice")
+	}
+
+	size := len(*t.treasure.Content.Uint32Slice) / 4
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= size || limit <= 0 {
+		return []uint32{}, nil
+	}
+	end := size
+	if limit < size-offset {
+		end = offset + limit
+	}
+
+	result := make([]uint32, 0, end-offset)
+	for i := offset * 4; i < end*4; i += 4 {
+		result = append(result, binary.LittleEndian.Uint32((*t.treasure.Content.Uint32Slice)[i:i+4]))
+	}
+	return result, nil
+
+}
+
+func (t *treasure) Uint32SlicePush(values []uint32) (added int, err error) {
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -2222,7 +2479,7 @@ func (t *treasure) Uint32SlicePush(values []uint32) error {
 		t.contentChanged = true
 	}
 
-	return nil
+	return len(buf) / 4, nil
 
 }
 
@@ -47,15 +47,17 @@ func TestGetContentType(t *testing.T) {
 		isContentChanged := treasureInterface.IsContentChanged()
 		assert.False(t, isContentChanged, "IsContentChanged should return false")
 
-		err = treasureInterface.Uint32SlicePush(testUintSlice)
+		added, err := treasureInterface.Uint32SlicePush(testUintSlice)
 		assert.Nil(t, err, "Uint32SlicePush should not return error")
+		assert.Equal(t, len(testUintSlice), added, "Uint32SlicePush should count all the values as added")
 
 		isContentChanged = treasureInterface.IsContentChanged()
 		assert.True(t, isContentChanged, "IsContentChanged should return true")
 
 		// push content again
-		err = treasureInterface.Uint32SlicePush(testUintSlice)
+		added, err = treasureInterface.Uint32SlicePush(testUintSlice)
 		assert.Nil(t, err, "Uint32SlicePush should not return error")
+		assert.Equal(t, 0, added, "Uint32SlicePush should not count the existing values")
 
 		assert.Equal(t, treasureInterface.GetContentType(), ContentTypeUint32Slice)
 
@@ -65,8 +67,9 @@ func TestGetContentType(t *testing.T) {
 
 		// beszúrunk 3 új számot
 		testUintSlice2 := []uint32{12, 13, 17, 19, 35}
-		err = treasureInterface.Uint32SlicePush(testUintSlice2)
+		added, err = treasureInterface.Uint32SlicePush(testUintSlice2)
 		assert.Nil(t, err, "Uint32SlicePush should not return error")
+		assert.Equal(t, 3, added, "Uint32SlicePush should count only the new values")
 
 		uintSLiceFromTreasure, err = treasureInterface.Uint32SliceGetAll()
 		assert.Equal(t, nil, err, "Uint32SliceGetAll should not return error")
@@ -111,6 +114,32 @@ func TestGetContentType(t *testing.T) {
 		treasureInterface.ReleaseTreasureGuard(guardID)
 	})
 
+	t.Run("should return the ranges of the Uint32Slice", func(t *testing.T) {
+		treasureInterface := New(MySaveMethod)
+
+		_, err := treasureInterface.Uint32SliceGetRange(0, 10)
+		assert.NotNil(t, err, "Uint32SliceGetRange should return error before the slice exists")
+
+		_, err = treasureInterface.Uint32SlicePush([]uint32{50, 40, 30, 20, 10})
+		assert.Nil(t, err)
+
+		page, err := treasureInterface.Uint32SliceGetRange(0, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []uint32{50, 40}, page, "the values are returned in the order of the push")
+
+		page, err = treasureInterface.Uint32SliceGetRange(2, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []uint32{30, 20}, page)
+
+		page, err = treasureInterface.Uint32SliceGetRange(4, 100)
+		assert.Nil(t, err)
+		assert.Equal(t, []uint32{10}, page, "the last page is shorter")
+
+		page, err = treasureInterface.Uint32SliceGetRange(5, 100)
+		assert.Nil(t, err)
+		assert.Empty(t, page, "the offset beyond the end returns no values")
+	})
+
 	t.Run("should return uint8 when treasure content is uint8", func(t *testing.T) {
 		treasureInterface := New(MySaveMethod)
 		guardID := treasureInterface.StartTreasureGuard(true)
@@ -534,6 +563,82 @@ func TestGetContentType(t *testing.T) {
 
 	})
 
+	t.Run("should keep and persist the content of the shadow deleted treasure until the restore", func(t *testing.T) {
+
+		treasureInterface := New(MySaveMethod)
+		guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+		defer treasureInterface.ReleaseTreasureGuard(guardID)
+		treasureInterface.BodySetKey(guardID, "test-key")
+		treasureInterface.SetContentString(guardID, "test")
+
+		treasureInterface.BodySetForDeletion(guardID, "remover-user", true)
+		assert.True(t, treasureInterface.GetShadowDelete())
+		assert.NotEqual(t, int64(0), treasureInterface.GetDeletedAt())
+
+		// the shadow delete flag is written to the file with the content
+		b, err := treasureInterface.ConvertToByte(guardID)
+		assert.NoError(t, err)
+		loadedTreasure := New(MySaveMethod)
+		loadedGuardID := loadedTreasure.StartTreasureGuard(true, guard.BodyAuthID)
+		assert.NoError(t, loadedTreasure.LoadFromByte(loadedGuardID, b, "file"))
+		loadedTreasure.ReleaseTreasureGuard(loadedGuardID)
+		assert.True(t, loadedTreasure.GetShadowDelete())
+		content, err := loadedTreasure.GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "test", content)
+
+		treasureInterface.BodySetForRestore(guardID)
+		assert.False(t, treasureInterface.GetShadowDelete())
+		assert.Equal(t, int64(0), treasureInterface.GetDeletedAt())
+		assert.Equal(t, "", treasureInterface.GetDeletedBy())
+		content, err = treasureInterface.GetContentString()
+		assert.NoError(t, err)
+		assert.Equal(t, "test", content)
+
+	})
+
+	t.Run("should keep the versions of the content and revert to them", func(t *testing.T) {
+
+		treasureInterface := New(MySaveMethod)
+		guardID := treasureInterface.StartTreasureGuard(true)
+		defer treasureInterface.ReleaseTreasureGuard(guardID)
+
+		treasureInterface.SetContentString(guardID, "first")
+		assert.Equal(t, uint64(1), treasureInterface.AppendVersion(guardID, 1, "user-1", 2))
+		treasureInterface.SetContentInt64(guardID, 42)
+		assert.Equal(t, uint64(2), treasureInterface.AppendVersion(guardID, 2, "user-2", 2))
+		treasureInterface.SetContentInt64(guardID, 43)
+		assert.Equal(t, uint64(3), treasureInterface.AppendVersion(guardID, 3, "user-3", 2))
+
+		versions := treasureInterface.GetVersions()
+		assert.Len(t, versions, 2)
+		assert.Equal(t, uint64(2), versions[0].Version)
+		assert.Equal(t, "user-2", versions[0].ModifiedBy)
+
+		// the history should survive the serialization
+		b, err := treasureInterface.ConvertToByte(guardID)
+		assert.NoError(t, err)
+		loaded := New(MySaveMethod)
+		loadedGuardID := loaded.StartTreasureGuard(true)
+		assert.NoError(t, loaded.LoadFromByte(loadedGuardID, b, "file"))
+		loaded.ReleaseTreasureGuard(loadedGuardID)
+		assert.Equal(t, versions, loaded.GetVersions())
+
+		assert.ErrorIs(t, treasureInterface.RevertToVersion(guardID, 1), ErrVersionNotFound)
+		assert.NoError(t, treasureInterface.RevertToVersion(guardID, 2))
+		assert.True(t, treasureInterface.IsContentChanged())
+		content, err := treasureInterface.GetContentInt64()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), content)
+
+		// the reverted content should not share the memory with the history
+		treasureInterface.SetContentInt64(guardID, 44)
+		content, err = versions[0].ToTreasure("key").GetContentInt64()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), content)
+
+	})
+
 	t.Run("should test the treasure save method", func(t *testing.T) {
 
 		executed := false
@@ -555,3 +660,86 @@ func TestGetContentType(t *testing.T) {
 	})
 
 }
+
+func TestSchemaVersion(t *testing.T) {
+
+	treasureInterface := New(MySaveMethod)
+	guardID := treasureInterface.StartTreasureGuard(true)
+	treasureInterface.SetContentString(guardID, "test")
+	assert.Equal(t, uint32(0), treasureInterface.GetSchemaVersion(), "the default version should be 0")
+
+	treasureInterface.SetSchemaVersion(guardID, 2)
+	assert.Equal(t, uint32(2), treasureInterface.GetSchemaVersion())
+	assert.True(t, treasureInterface.IsContentChanged(), "the changed version should be handled as a content change")
+
+	// the version should survive the serialization and the cloning
+	b, err := treasureInterface.ConvertToByte(guardID)
+	assert.NoError(t, err)
+	clone := treasureInterface.Clone(guardID)
+	treasureInterface.ReleaseTreasureGuard(guardID)
+
+	loaded := New(MySaveMethod)
+	loadedGuardID := loaded.StartTreasureGuard(true)
+	assert.NoError(t, loaded.LoadFromByte(loadedGuardID, b, "file"))
+	loaded.ReleaseTreasureGuard(loadedGuardID)
+
+	assert.Equal(t, uint32(2), loaded.GetSchemaVersion())
+	assert.Equal(t, uint32(2), clone.GetSchemaVersion())
+
+}
+
+func TestSnapshot(t *testing.T) {
+
+	treasureInterface := New(MySaveMethod)
+	guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+	treasureInterface.BodySetKey(guardID, "key")
+	_, err := treasureInterface.Uint32SlicePush([]uint32{1, 2})
+	assert.NoError(t, err)
+	treasureInterface.BodySetFileName(guardID, "file")
+	treasureInterface.BodySetForDeletion(guardID, "deleter", true)
+	snapshot := treasureInterface.Snapshot(guardID)
+	treasureInterface.ReleaseTreasureGuard(guardID)
+
+	t.Run("should copy the treasure with its deletion and file name", func(t *testing.T) {
+		assert.Equal(t, "key", snapshot.GetKey())
+		assert.Equal(t, "file", *snapshot.GetFileName())
+		assert.Equal(t, "deleter", snapshot.GetDeletedBy())
+		assert.True(t, snapshot.GetShadowDelete())
+	})
+
+	t.Run("should not change with the treasure", func(t *testing.T) {
+
+		// the push modifies the slice of the treasure in place
+		_, err := treasureInterface.Uint32SlicePush([]uint32{3})
+		assert.NoError(t, err)
+		guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+		treasureInterface.BodySetFileName(guardID, "other-file")
+		treasureInterface.ReleaseTreasureGuard(guardID)
+
+		values, err := snapshot.Uint32SliceGetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{1, 2}, values)
+		assert.Equal(t, "file", *snapshot.GetFileName())
+
+	})
+
+	t.Run("should be written like the treasure", func(t *testing.T) {
+
+		snapshotGuardID := snapshot.StartTreasureGuard(true, guard.BodyAuthID)
+		b, err := snapshot.ConvertToByte(snapshotGuardID)
+		snapshot.ReleaseTreasureGuard(snapshotGuardID)
+		assert.NoError(t, err)
+
+		loaded := New(MySaveMethod)
+		loadedGuardID := loaded.StartTreasureGuard(true)
+		assert.NoError(t, loaded.LoadFromByte(loadedGuardID, b, "file"))
+		loaded.ReleaseTreasureGuard(loadedGuardID)
+
+		values, err := loaded.Uint32SliceGetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{1, 2}, values)
+		assert.Equal(t, "key", loaded.GetKey())
+
+	})
+
+}
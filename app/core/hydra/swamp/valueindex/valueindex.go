@@ -0,0 +1,237 @@
+// Package valueindex is the secondary value index of the swamp. The value index is a hash index that maps the values
+// of the treasures to their keys, so the swamp can find all treasures holding a given value without scanning.
+// The value index always exists only in the Memory, and it is built lazily at the first lookup, then maintained
+// incrementally by every write and delete of the swamp.
+package valueindex
+
+import (
+	"encoding/hex"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+type ValueIndex interface {
+
+	// Add adds the treasure to the index under its current value.
+	// If the treasure was already indexed under another value, the old entry is removed first, so the function
+	// can be called for both new and modified treasures.
+	//
+	// Treasures without an indexable value (void content or uint32 slices) are removed from the index.
+	Add(t treasure.Treasure)
+
+	// Delete removes the key from the index regardless of the value it was indexed under.
+	Delete(key string)
+
+	// GetKeys returns the keys of the treasures that hold the same value as the probe treasure.
+	// The probe is a standalone treasure that carries only the content we are looking for.
+	// Returns nil if the probe has no indexable value or there is no treasure with the value.
+	GetKeys(probe treasure.Treasure) []string
+
+	// Count returns the number of indexed keys
+	Count() int
+
+	// IsInitialized returns true if the index is already built from the treasures of the swamp
+	IsInitialized() bool
+
+	// SetInitialized sets the initialized flag of the index
+	SetInitialized(init bool)
+
+	// Reset removes all entries from the index and sets it to uninitialized
+	Reset()
+}
+
+type valueIndex struct {
+	mu sync.RWMutex
+	// keysByValue maps the value fingerprints to the keys of the treasures holding the value
+	keysByValue map[string]map[string]struct{}
+	// valueByKey maps the keys to their current value fingerprints, so modified and deleted treasures can be
+	// removed from the index without knowing their previous value
+	valueByKey map[string]string
+	// initialized is 1 if the index is built from the treasures of the swamp
+	initialized int32
+}
+
+// New creates a new, empty and uninitialized value index
+func New() ValueIndex {
+	return &valueIndex{
+		keysByValue: make(map[string]map[string]struct{}),
+		valueByKey:  make(map[string]string),
+	}
+}
+
+// Add adds or re-indexes the treasure under its current value
+func (v *valueIndex) Add(t treasure.Treasure) {
+
+	key := t.GetKey()
+	fingerprint, ok := Fingerprint(t)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if oldFingerprint, exists := v.valueByKey[key]; exists {
+		if ok && oldFingerprint == fingerprint {
+			// the value is not changed, nothing to do
+			return
+		}
+		v.deleteKey(key, oldFingerprint)
+	}
+
+	if !ok {
+		return
+	}
+
+	keys, exists := v.keysByValue[fingerprint]
+	if !exists {
+		keys = make(map[string]struct{})
+		v.keysByValue[fingerprint] = keys
+	}
+	keys[key] = struct{}{}
+	v.valueByKey[key] = fingerprint
+
+}
+
+// Delete removes the key from the index
+func (v *valueIndex) Delete(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if fingerprint, exists := v.valueByKey[key]; exists {
+		v.deleteKey(key, fingerprint)
+	}
+}
+
+// GetKeys returns the keys of the treasures holding the value of the probe
+func (v *valueIndex) GetKeys(probe treasure.Treasure) []string {
+
+	fingerprint, ok := Fingerprint(probe)
+	if !ok {
+		return nil
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	keys, exists := v.keysByValue[fingerprint]
+	if !exists {
+		return nil
+	}
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+
+	return result
+
+}
+
+// Count returns the number of indexed keys
+func (v *valueIndex) Count() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.valueByKey)
+}
+
+// IsInitialized returns true if the index is built
+func (v *valueIndex) IsInitialized() bool {
+	return atomic.LoadInt32(&v.initialized) == 1
+}
+
+// SetInitialized sets the initialized flag of the index
+func (v *valueIndex) SetInitialized(init bool) {
+	if init {
+		atomic.StoreInt32(&v.initialized, 1)
+		return
+	}
+	atomic.StoreInt32(&v.initialized, 0)
+}
+
+// Reset removes all entries from the index
+func (v *valueIndex) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.keysByValue = make(map[string]map[string]struct{})
+	v.valueByKey = make(map[string]string)
+	atomic.StoreInt32(&v.initialized, 0)
+}
+
+// deleteKey removes the key from the index. The caller must hold the write lock
+func (v *valueIndex) deleteKey(key string, fingerprint string) {
+	delete(v.valueByKey, key)
+	if keys, exists := v.keysByValue[fingerprint]; exists {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(v.keysByValue, fingerprint)
+		}
+	}
+}
+
+// Fingerprint returns the type-aware hash key of the treasure's content.
+// The content type is part of the fingerprint, so an int8(1) and an int64(1) never match each other.
+// Returns false if the content of the treasure is not indexable.
+func Fingerprint(t treasure.Treasure) (string, bool) {
+
+	contentType := t.GetContentType()
+	prefix := strconv.Itoa(int(contentType)) + ":"
+
+	switch contentType {
+	case treasure.ContentTypeString:
+		if v, err := t.GetContentString(); err == nil {
+			return prefix + v, true
+		}
+	case treasure.ContentTypeUint8:
+		if v, err := t.GetContentUint8(); err == nil {
+			return prefix + strconv.FormatUint(uint64(v), 10), true
+		}
+	case treasure.ContentTypeUint16:
+		if v, err := t.GetContentUint16(); err == nil {
+			return prefix + strconv.FormatUint(uint64(v), 10), true
+		}
+	case treasure.ContentTypeUint32:
+		if v, err := t.GetContentUint32(); err == nil {
+			return prefix + strconv.FormatUint(uint64(v), 10), true
+		}
+	case treasure.ContentTypeUint64:
+		if v, err := t.GetContentUint64(); err == nil {
+			return prefix + strconv.FormatUint(v, 10), true
+		}
+	case treasure.ContentTypeInt8:
+		if v, err := t.GetContentInt8(); err == nil {
+			return prefix + strconv.FormatInt(int64(v), 10), true
+		}
+	case treasure.ContentTypeInt16:
+		if v, err := t.GetContentInt16(); err == nil {
+			return prefix + strconv.FormatInt(int64(v), 10), true
+		}
+	case treasure.ContentTypeInt32:
+		if v, err := t.GetContentInt32(); err == nil {
+			return prefix + strconv.FormatInt(int64(v), 10), true
+		}
+	case treasure.ContentTypeInt64:
+		if v, err := t.GetContentInt64(); err == nil {
+			return prefix + strconv.FormatInt(v, 10), true
+		}
+	case treasure.ContentTypeFloat32:
+		if v, err := t.GetContentFloat32(); err == nil {
+			return prefix + strconv.FormatFloat(float64(v), 'g', -1, 32), true
+		}
+	case treasure.ContentTypeFloat64:
+		if v, err := t.GetContentFloat64(); err == nil {
+			return prefix + strconv.FormatFloat(v, 'g', -1, 64), true
+		}
+	case treasure.ContentTypeBoolean:
+		if v, err := t.GetContentBool(); err == nil {
+			return prefix + strconv.FormatBool(v), true
+		}
+	case treasure.ContentTypeByteArray:
+		if v, err := t.GetContentByteArray(); err == nil {
+			return prefix + hex.EncodeToString(v), true
+		}
+	default:
+		// void content and uint32 slices are not indexable
+	}
+
+	return "", false
+
+}
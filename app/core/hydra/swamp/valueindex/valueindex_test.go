@@ -0,0 +1,109 @@
+package valueindex
+
+import (
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func mySaveFunction(_ treasure.Treasure, _ guard.ID) treasure.TreasureStatus {
+	return treasure.StatusNew
+}
+
+func newStringTreasure(key string, content string) treasure.Treasure {
+	t := treasure.New(mySaveFunction)
+	guardID := t.StartTreasureGuard(true, guard.BodyAuthID)
+	t.BodySetKey(guardID, key)
+	t.SetContentString(guardID, content)
+	t.ReleaseTreasureGuard(guardID)
+	return t
+}
+
+func TestValueIndex(t *testing.T) {
+
+	t.Run("should find the keys by value", func(t *testing.T) {
+
+		v := New()
+		for i := 0; i < 10; i++ {
+			v.Add(newStringTreasure(fmt.Sprintf("key-%d", i), fmt.Sprintf("value-%d", i%2)))
+		}
+
+		assert.Equal(t, 10, v.Count())
+
+		keys := v.GetKeys(newStringTreasure("", "value-0"))
+		sort.Strings(keys)
+		assert.Equal(t, []string{"key-0", "key-2", "key-4", "key-6", "key-8"}, keys)
+
+		assert.Nil(t, v.GetKeys(newStringTreasure("", "value-2")))
+
+	})
+
+	t.Run("should follow the modification and the deletion of the treasure", func(t *testing.T) {
+
+		v := New()
+		treasureObj := newStringTreasure("key", "old")
+		v.Add(treasureObj)
+
+		guardID := treasureObj.StartTreasureGuard(true)
+		treasureObj.SetContentString(guardID, "new")
+		treasureObj.ReleaseTreasureGuard(guardID)
+		v.Add(treasureObj)
+
+		assert.Nil(t, v.GetKeys(newStringTreasure("", "old")))
+		assert.Equal(t, []string{"key"}, v.GetKeys(newStringTreasure("", "new")))
+
+		v.Delete("key")
+		assert.Nil(t, v.GetKeys(newStringTreasure("", "new")))
+		assert.Equal(t, 0, v.Count())
+
+	})
+
+	t.Run("should not match different content types", func(t *testing.T) {
+
+		v := New()
+		t8 := treasure.New(mySaveFunction)
+		guardID := t8.StartTreasureGuard(true, guard.BodyAuthID)
+		t8.BodySetKey(guardID, "int8")
+		t8.SetContentInt8(guardID, 1)
+		t8.ReleaseTreasureGuard(guardID)
+		v.Add(t8)
+
+		probe := treasure.New(mySaveFunction)
+		guardID = probe.StartTreasureGuard(true)
+		probe.SetContentInt64(guardID, 1)
+		probe.ReleaseTreasureGuard(guardID)
+
+		assert.Nil(t, v.GetKeys(probe))
+
+	})
+
+	t.Run("should not index void content", func(t *testing.T) {
+
+		v := New()
+		voidTreasure := treasure.New(mySaveFunction)
+		guardID := voidTreasure.StartTreasureGuard(true, guard.BodyAuthID)
+		voidTreasure.BodySetKey(guardID, "void")
+		voidTreasure.SetContentVoid(guardID)
+		voidTreasure.ReleaseTreasureGuard(guardID)
+		v.Add(voidTreasure)
+
+		assert.Equal(t, 0, v.Count())
+
+	})
+
+	t.Run("should reset the index", func(t *testing.T) {
+
+		v := New()
+		v.SetInitialized(true)
+		v.Add(newStringTreasure("key", "value"))
+		v.Reset()
+
+		assert.False(t, v.IsInitialized())
+		assert.Equal(t, 0, v.Count())
+
+	})
+
+}
@@ -0,0 +1,52 @@
+// Package defaults holds the default settings of the Swamps, which can be changed while the server is running.
+//
+// The defaults are used by the Swamp patterns registered without their own settings, and optionally by the Swamps
+// without a registered pattern. They are read when a pattern is registered or a Swamp is opened, so a change never
+// touches the open Swamps: they keep their settings until they are closed, and nothing is rehydrated.
+package defaults
+
+import (
+	"github.com/hydraide/hydraide/app/core/settings/setting"
+	"sync/atomic"
+)
+
+// Values are the default settings of the Swamps
+type Values struct {
+	CloseAfterIdleSec int64               // seconds before an idle swamp is closed
+	WriteIntervalSec  int64               // seconds between two writes of a swamp to the disk
+	MaxFileSizeByte   int64               // maximum size of a swamp chunk file in bytes
+	FsyncPolicy       setting.FsyncPolicy // the fsync policy, empty means setting.FsyncNever
+	FsyncIntervalSec  int64               // min seconds between two fsyncs with the setting.FsyncInterval policy
+	// ApplyToUnregisteredSwamps makes the Swamps without a registered pattern use the defaults instead of the
+	// built-in settings
+	ApplyToUnregisteredSwamps bool
+}
+
+// Defaults are the current default settings, shared by the gateways and the settings of every tenant. The zero value
+// is ready to use, and returns the zero Values until the first Set.
+type Defaults struct {
+	values atomic.Pointer[Values]
+}
+
+// New creates the defaults with the initial values
+func New(values Values) *Defaults {
+	d := &Defaults{}
+	d.Set(values)
+	return d
+}
+
+// Get returns the current values. A nil Defaults returns the zero Values.
+func (d *Defaults) Get() Values {
+	if d == nil {
+		return Values{}
+	}
+	if values := d.values.Load(); values != nil {
+		return *values
+	}
+	return Values{}
+}
+
+// Set replaces the values. The patterns registered and the Swamps opened after it get the new values.
+func (d *Defaults) Set(values Values) {
+	d.values.Store(&values)
+}
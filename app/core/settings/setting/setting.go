@@ -27,8 +27,102 @@ type Setting interface {
 	// Real-world scenario: In-memory swamps are useful for testing and broadcasting data between services.
 	// Permanent swamps are useful for storing data that needs to be persisted.
 	GetSwampType() SwampType
+	// IsValueIndexed returns true if the swamp maintains a secondary value index.
+	// Real-world scenario: If you often need to find the keys that hold a given value (e.g. all users with a given
+	// email address), the value index makes it possible without scanning the whole swamp.
+	IsValueIndexed() bool
+	// GetEventJournalSize returns the max number of the events kept in the event journal of the swamp. 0 means the
+	// journal is disabled.
+	// Real-world scenario: If the subscribers of the swamp must continue from their last processed event after a
+	// restart, the journal lets them replay the missed events instead of reading the whole swamp again.
+	GetEventJournalSize() int
+	// GetEventJournalRetention returns how long the events are kept in the event journal. 0 means the events are only
+	// dropped when the journal is full.
+	GetEventJournalRetention() time.Duration
+	// IsServerTimestamped returns true if the server sets the creation and modification times of the treasures.
+	// Real-world scenario: If many application servers write the same swamp, their clocks are never exactly in sync,
+	// so the times set by the clients can break the ordering by creation or modification time.
+	IsServerTimestamped() bool
+	// GetHistoryDepth returns how many versions of each treasure are kept in its history. 0 means the history is
+	// disabled.
+	// Real-world scenario: If the changes of financial records must be audited, or a wrong change must be reverted,
+	// the history keeps the previous values with the time and the author of the change.
+	GetHistoryDepth() int
+	// GetMaxTreasureAge returns the retention time of the treasures, measured from their creation time. 0 means no
+	// age limit.
+	// Real-world scenario: Logs or events that are only needed for a few weeks are deleted by the server, instead of
+	// an external job reading and deleting them.
+	GetMaxTreasureAge() time.Duration
+	// GetMaxTreasures returns the max number of the treasures kept in the swamp. 0 means no limit.
+	// Real-world scenario: Only the last N notifications of a user are kept, the oldest ones are deleted.
+	GetMaxTreasures() int
+	// GetMaxSwampSizeByte returns the max size of the files of the swamp on the disk. 0 means no limit.
+	// Real-world scenario: A swamp of raw sensor data must not fill the disk, so the oldest data is deleted above
+	// the limit.
+	GetMaxSwampSizeByte() int64
+	// GetDefaultExpireAfter returns the expiration time of the treasures written without an expiration time,
+	// measured from the write. 0 means the treasures never expire by default.
+	// Real-world scenario: The sessions must expire even if a client forgets to set their expiration time.
+	GetDefaultExpireAfter() time.Duration
+	// GetMaxKeyLength returns the max length of the keys of the written treasures in bytes. 0 means no limit.
+	// Real-world scenario: The keys are IDs of a known format, so a much longer key is a bug of the client.
+	GetMaxKeyLength() int
+	// GetMaxValueSizeByte returns the max size of the values of the written treasures. 0 means no limit.
+	// Real-world scenario: A profile picture stored by mistake in a swamp of small user records would slow down
+	// the loading of the whole swamp.
+	GetMaxValueSizeByte() int64
+	// GetRequiredMetadata returns the metadata fields every written treasure must have, e.g. MetadataCreatedBy.
+	// Real-world scenario: Every change of the records must be attributed to a user or a service.
+	GetRequiredMetadata() []string
+	// GetFsyncPolicy returns when the written files of the swamp are flushed to the disk.
+	// Real-world scenario: A swamp of payments must not lose a write after a power loss, so it is flushed after every
+	// write, while a swamp of page views favors the throughput and leaves the flushing to the operating system.
+	GetFsyncPolicy() FsyncPolicy
+	// GetFsyncInterval returns the min time between two flushes of the swamp with the FsyncInterval policy.
+	GetFsyncInterval() time.Duration
+	// IsKeyFiltered returns true if the swamp keeps a bloom filter of its keys on the disk, so the existence checks
+	// of the missing keys are answered without loading the swamp.
+	// Real-world scenario: A crawler checks millions of new URLs against cold swamps of already seen URLs, and most
+	// of the checks are answered without loading the swamps.
+	IsKeyFiltered() bool
+	// IsLazyHydrated returns true if the swamp keeps a directory of the chunk files of its keys on the disk, so the
+	// reads of a few keys load only their chunks, not the whole swamp.
+	// Real-world scenario: A profile page reads one user from a swamp of millions of users, that is closed most of the
+	// time, and only the chunk of the user is read from the disk.
+	IsLazyHydrated() bool
+	// GetKeptBeacons returns the order beacons the swamp maintains all the time, e.g. BeaconKey. Empty means every
+	// beacon is kept after its first use.
+	// Real-world scenario: A swamp of user profiles is only read by keys and by creation time, so the other beacons
+	// are built only for the rare admin queries, and they are dropped from the memory after them.
+	GetKeptBeacons() []string
+	// GetBeaconEvictAfterIdle returns how long the beacons that are not kept stay in the memory after their last use.
+	// 0 means they are never evicted, because the swamp keeps all of its beacons.
+	GetBeaconEvictAfterIdle() time.Duration
 }
 
+// The metadata fields of the treasures, the values of the RequiredMetadata
+const (
+	MetadataCreatedAt = "createdAt"
+	MetadataCreatedBy = "createdBy"
+	MetadataUpdatedAt = "updatedAt"
+	MetadataUpdatedBy = "updatedBy"
+	MetadataExpiredAt = "expiredAt"
+)
+
+// The order beacons of the swamps, the values of the KeptBeacons
+const (
+	BeaconKey            = "key"
+	BeaconCreationTime   = "creationTime"
+	BeaconUpdateTime     = "updateTime"
+	BeaconExpirationTime = "expirationTime"
+	// BeaconValue is the beacon of the values, whatever the type of the values is
+	BeaconValue = "value"
+)
+
+// DefaultBeaconEvictAfterIdle is how long the beacons that are not kept stay in the memory after their last use, if
+// the pattern keeps some beacons but does not set the time
+const DefaultBeaconEvictAfterIdle = 10 * time.Minute
+
 type SwampType string
 
 const (
@@ -38,6 +132,37 @@ const (
 	PermanentSwamp SwampType = "PermanentSwamp"
 )
 
+// FsyncPolicy decides when the files written by a swamp are flushed from the page cache of the operating system to
+// the disk
+type FsyncPolicy string
+
+const (
+	// FsyncNever leaves the flushing to the operating system. The empty policy is FsyncNever too.
+	FsyncNever FsyncPolicy = "never"
+	// FsyncInterval flushes the written files at most once per fsync interval, and when the swamp closes.
+	FsyncInterval FsyncPolicy = "interval"
+	// FsyncAlways flushes the written files after every write of the swamp.
+	FsyncAlways FsyncPolicy = "always"
+)
+
+// Or returns the policy, or the defaultPolicy if the policy is empty
+func (p FsyncPolicy) Or(defaultPolicy FsyncPolicy) FsyncPolicy {
+	if p == "" {
+		return defaultPolicy
+	}
+	return p
+}
+
+// IsValid returns true if the policy is known. The empty policy is valid, it means FsyncNever.
+func (p FsyncPolicy) IsValid() bool {
+	switch p {
+	case "", FsyncNever, FsyncInterval, FsyncAlways:
+		return true
+	default:
+		return false
+	}
+}
+
 type SwampSetting struct {
 	Pattern name.Name
 	// InMemory true if the swamp just keeps the data in memory and doesn't write to SSD.
@@ -49,6 +174,46 @@ type SwampSetting struct {
 	WriteIntervalSec time.Duration
 	// MaxFileSizeByte The maximum file size of the swamp's file. Only used if the swamp is not in-memory swamp (i.e. it writes to SSD).
 	MaxFileSizeByte int64
+	// ValueIndex true if the swamp maintains a secondary hash index from values to keys.
+	ValueIndex bool
+	// EventJournalSize The max number of the events kept in the event journal of the swamp. 0 disables the journal.
+	EventJournalSize int
+	// EventJournalRetention How long the events are kept in the event journal. 0 means no time limit.
+	EventJournalRetention time.Duration
+	// ServerTimestamps true if the server sets the creation and modification times of the treasures, and ignores
+	// the times sent by the clients.
+	ServerTimestamps bool
+	// HistoryDepth The number of the versions kept in the history of each treasure. 0 disables the history.
+	HistoryDepth int
+	// MaxTreasureAge The retention time of the treasures, measured from their creation time. 0 means no age limit.
+	MaxTreasureAge time.Duration
+	// MaxTreasures The max number of the treasures kept in the swamp. 0 means no limit.
+	MaxTreasures int
+	// MaxSwampSizeByte The max size of the files of the swamp on the disk. 0 means no limit.
+	MaxSwampSizeByte int64
+	// DefaultExpireAfter The expiration time of the treasures written without an expiration time. 0 means no
+	// default expiration.
+	DefaultExpireAfter time.Duration
+	// MaxKeyLength The max length of the keys of the written treasures in bytes. 0 means no limit.
+	MaxKeyLength int
+	// MaxValueSizeByte The max size of the values of the written treasures. 0 means no limit.
+	MaxValueSizeByte int64
+	// RequiredMetadata The metadata fields every written treasure must have, e.g. MetadataCreatedBy.
+	RequiredMetadata []string
+	// FsyncPolicy When the written files of the swamp are flushed to the disk. Only used if InMemory is false.
+	FsyncPolicy FsyncPolicy
+	// FsyncInterval The min time between two flushes with the FsyncInterval policy.
+	FsyncInterval time.Duration
+	// KeyFilter true if the swamp keeps a bloom filter of its keys on the disk. Only used if InMemory is false.
+	KeyFilter bool
+	// LazyHydration true if the swamp keeps a directory of the chunk files of its keys. Only used if InMemory is false.
+	LazyHydration bool
+	// KeptBeacons The order beacons the swamp maintains all the time, e.g. BeaconKey. The other beacons are built at
+	// their first use and evicted after BeaconEvictAfterIdle. Empty means every beacon is kept after its first use.
+	KeptBeacons []string
+	// BeaconEvictAfterIdle How long the beacons that are not kept stay in the memory after their last use. 0 means
+	// DefaultBeaconEvictAfterIdle.
+	BeaconEvictAfterIdle time.Duration
 }
 
 type setting struct {
@@ -88,3 +253,99 @@ func (s *setting) GetSwampType() SwampType {
 	}
 	return PermanentSwamp
 }
+
+// IsValueIndexed returns true if the value index is enabled for the swamp
+func (s *setting) IsValueIndexed() bool {
+	return s.ws.ValueIndex
+}
+
+// GetEventJournalSize returns the max number of the events in the event journal of the swamp
+func (s *setting) GetEventJournalSize() int {
+	return s.ws.EventJournalSize
+}
+
+// GetEventJournalRetention returns the retention time of the event journal of the swamp
+func (s *setting) GetEventJournalRetention() time.Duration {
+	return s.ws.EventJournalRetention
+}
+
+// IsServerTimestamped returns true if the server sets the creation and modification times of the treasures
+func (s *setting) IsServerTimestamped() bool {
+	return s.ws.ServerTimestamps
+}
+
+// GetHistoryDepth returns the number of the versions kept in the history of each treasure
+func (s *setting) GetHistoryDepth() int {
+	return s.ws.HistoryDepth
+}
+
+// GetMaxTreasureAge returns the retention time of the treasures
+func (s *setting) GetMaxTreasureAge() time.Duration {
+	return s.ws.MaxTreasureAge
+}
+
+// GetMaxTreasures returns the max number of the treasures kept in the swamp
+func (s *setting) GetMaxTreasures() int {
+	return s.ws.MaxTreasures
+}
+
+// GetMaxSwampSizeByte returns the max size of the files of the swamp on the disk
+func (s *setting) GetMaxSwampSizeByte() int64 {
+	return s.ws.MaxSwampSizeByte
+}
+
+// GetDefaultExpireAfter returns the expiration time of the treasures written without an expiration time
+func (s *setting) GetDefaultExpireAfter() time.Duration {
+	return s.ws.DefaultExpireAfter
+}
+
+// GetMaxKeyLength returns the max length of the keys of the written treasures
+func (s *setting) GetMaxKeyLength() int {
+	return s.ws.MaxKeyLength
+}
+
+// GetMaxValueSizeByte returns the max size of the values of the written treasures
+func (s *setting) GetMaxValueSizeByte() int64 {
+	return s.ws.MaxValueSizeByte
+}
+
+// GetRequiredMetadata returns the metadata fields every written treasure must have
+func (s *setting) GetRequiredMetadata() []string {
+	return s.ws.RequiredMetadata
+}
+
+// GetFsyncPolicy returns when the written files of the swamp are flushed to the disk
+func (s *setting) GetFsyncPolicy() FsyncPolicy {
+	return s.ws.FsyncPolicy.Or(FsyncNever)
+}
+
+// GetFsyncInterval returns the min time between two flushes of the swamp with the FsyncInterval policy
+func (s *setting) GetFsyncInterval() time.Duration {
+	return s.ws.FsyncInterval
+}
+
+// IsKeyFiltered returns true if the swamp keeps a bloom filter of its keys on the disk
+func (s *setting) IsKeyFiltered() bool {
+	return s.ws.KeyFilter
+}
+
+// IsLazyHydrated returns true if the swamp keeps a directory of the chunk files of its keys on the disk
+func (s *setting) IsLazyHydrated() bool {
+	return s.ws.LazyHydration
+}
+
+// GetKeptBeacons returns the order beacons the swamp maintains all the time
+func (s *setting) GetKeptBeacons() []string {
+	return s.ws.KeptBeacons
+}
+
+// GetBeaconEvictAfterIdle returns how long the beacons that are not kept stay in the memory after their last use
+func (s *setting) GetBeaconEvictAfterIdle() time.Duration {
+	if len(s.ws.KeptBeacons) == 0 {
+		return 0
+	}
+	if s.ws.BeaconEvictAfterIdle <= 0 {
+		return DefaultBeaconEvictAfterIdle
+	}
+	return s.ws.BeaconEvictAfterIdle
+}
@@ -56,4 +56,27 @@ func TestNew(t *testing.T) {
 
 	})
 
+	t.Run("should return the fsync policy", func(t *testing.T) {
+
+		settingObject := New(&SwampSetting{
+			Pattern: name.New().Sanctuary("payments").Realm("*").Swamp("*"),
+		})
+		assert.Equal(t, FsyncNever, settingObject.GetFsyncPolicy(), "the empty policy must mean never")
+
+		settingObject = New(&SwampSetting{
+			Pattern:       name.New().Sanctuary("payments").Realm("*").Swamp("*"),
+			FsyncPolicy:   FsyncInterval,
+			FsyncInterval: 2 * time.Second,
+		})
+		assert.Equal(t, FsyncInterval, settingObject.GetFsyncPolicy())
+		assert.Equal(t, 2*time.Second, settingObject.GetFsyncInterval())
+
+		assert.Equal(t, FsyncAlways, FsyncPolicy("").Or(FsyncAlways))
+		assert.Equal(t, FsyncInterval, FsyncInterval.Or(FsyncAlways))
+		assert.True(t, FsyncAlways.IsValid())
+		assert.True(t, FsyncPolicy("").IsValid())
+		assert.False(t, FsyncPolicy("sometimes").IsValid())
+
+	})
+
 }
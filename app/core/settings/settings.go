@@ -3,14 +3,21 @@ package settings
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
+	"github.com/hydraide/hydraide/app/core/settings/defaults"
 	"github.com/hydraide/hydraide/app/core/settings/setting"
 	"github.com/hydraide/hydraide/app/name"
 	"log/slog"
 	"os"
-	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,17 +29,54 @@ type Settings interface {
 	GetMaxFoldersPerLevel() int
 	// GetHydraAbsDataFolderPath returns the absolute path of the hydra data folder
 	GetHydraAbsDataFolderPath() string
+	// GetHydraAbsSettingsFolderPath returns the absolute path of the hydra settings folder
+	GetHydraAbsSettingsFolderPath() string
+	// GetHydraAbsBlobFolderPath returns the absolute path of the folder of the blobs
+	GetHydraAbsBlobFolderPath() string
 	// GetBySwampName loads the settings for a specific swamp based on its name.
 	// Real-world scenario: When initializing a new swamp, you can use this function to apply pre-configured settings
 	// for that specific swamp.
 	GetBySwampName(swampName name.Name) setting.Setting
 	// RegisterPattern registers a pattern for a swamp to the settings
 	// useful when the hydra register a new Head to the system with new swamp patterns
-	RegisterPattern(pattern name.Name, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings)
+	RegisterPattern(pattern name.Name, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings, patternOptions *PatternOptions)
 	// DeregisterPattern deregister a pattern from the settings
 	DeregisterPattern(pattern name.Name)
+	// ListPatterns returns a copy of the registered patterns, sorted by their names
+	ListPatterns() []PatternModel
+	// ConflictingPattern returns the registered pattern that overlaps with the pattern, so a swamp can match both, and
+	// has other memory or filesystem settings than the given ones. Returns false if there is no such pattern.
+	ConflictingPattern(pattern name.Name, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings) (PatternModel, bool)
+	// UpdatePattern changes the idle time, the write interval and the file size of a registered pattern, and returns
+	// the updated pattern. The swamps opened after the update get the new settings.
+	UpdatePattern(pattern name.Name, update PatternUpdate) (PatternModel, error)
 	// CallbackAtChanges wait a callback function and the settigns will call it when the settings changed
 	CallbackAtChanges(func()) chan bool
+	// SetWriteBatchSize sets the max number of treasures a swamp writes to the filesystem at once.
+	// 0 means all waiting treasures. Applies to the swamps summoned after the call.
+	SetWriteBatchSize(size int)
+	// GetWriteBatchSize returns the max number of treasures a swamp writes to the filesystem at once
+	GetWriteBatchSize() int
+	// SetHydrationScheduler sets the scheduler that limits the concurrent loading of the swamps from the disk.
+	// The same scheduler can be shared by more settings, so the limit is global across them. Nil means unlimited.
+	SetHydrationScheduler(scheduler hydration.Scheduler)
+	// GetHydrationScheduler returns the scheduler of the swamp loading, or nil if the loading is unlimited
+	GetHydrationScheduler() hydration.Scheduler
+	// SetKeyLockCounters sets the counters the key lock contention of the swamps is summed to. The same counters can
+	// be shared by more settings, so the metrics cover all of them. Nil means the contention is not summed.
+	SetKeyLockCounters(counters *keylock.Counters)
+	// GetKeyLockCounters returns the counters of the key lock contention, or nil if the contention is not summed
+	GetKeyLockCounters() *keylock.Counters
+	// SetFlushStallCounters sets the counters the stalls caused by the writers of the swamps are summed to. The same
+	// counters can be shared by more settings. Nil means the stalls are not counted.
+	SetFlushStallCounters(counters *flushstall.Counters)
+	// GetFlushStallCounters returns the counters of the flush stalls, or nil if the stalls are not counted
+	GetFlushStallCounters() *flushstall.Counters
+	// SetDefaults sets the default swamp settings, which can change while the server is running. The same defaults
+	// can be shared by more settings. Nil means the built-in settings are used for the unregistered swamps.
+	SetDefaults(swampDefaults *defaults.Defaults)
+	// GetDefaults returns the default swamp settings, or nil if they are not set
+	GetDefaults() *defaults.Defaults
 }
 
 const (
@@ -41,8 +85,10 @@ const (
 )
 
 var (
-	hydraDataFolderPath     = ""
-	hydraSettingsFolderPath = ""
+	// ErrPatternNotFound is returned if the pattern is not registered
+	ErrPatternNotFound = errors.New("swamp pattern not found")
+	// ErrInMemoryPattern is returned if a filesystem setting is set for an in-memory pattern
+	ErrInMemoryPattern = errors.New("the write interval and the file size of an in-memory pattern can not be set")
 )
 
 type settings struct {
@@ -59,6 +105,14 @@ type settings struct {
 	pluginPath         string
 	maxDepthOfFolders  int
 	maxFoldersPerLevel int
+	dataFolderPath     string // the absolute path of the data folder, constant after New
+	settingsFolderPath string // the absolute path of the settings folder, constant after New
+	blobFolderPath     string // the absolute path of the blob folder, constant after New
+	writeBatchSize     atomic.Int64
+	hydrationScheduler hydration.Scheduler
+	keyLockCounters    *keylock.Counters
+	flushStallCounters *flushstall.Counters
+	swampDefaults      *defaults.Defaults
 }
 
 type Model struct {
@@ -73,18 +127,63 @@ type PatternModel struct {
 	CloseAfterIdleSec int64  `json:"closeAfterIdleSec,omitempty"`
 	WriteIntervalSec  int64  `json:"writeIntervalSec,omitempty"`
 	MaxFileSizeByte   int64  `json:"maxFileSizeByte,omitempty"`
+	ValueIndex        bool   `json:"valueIndex,omitempty"`
+	// EventJournalSize is the max number of the events in the event journal, 0 means disabled
+	EventJournalSize int `json:"eventJournalSize,omitempty"`
+	// EventJournalRetentionSec is the retention time of the event journal in seconds, 0 means no time limit
+	EventJournalRetentionSec int64 `json:"eventJournalRetentionSec,omitempty"`
+	// ServerTimestamps is true if the server sets the creation and modification times of the treasures
+	ServerTimestamps bool `json:"serverTimestamps,omitempty"`
+	// HistoryDepth is the number of the versions kept in the history of each treasure, 0 means disabled
+	HistoryDepth int `json:"historyDepth,omitempty"`
+	// MaxTreasureAgeSec is the retention time of the treasures in seconds, 0 means no age limit
+	MaxTreasureAgeSec int64 `json:"maxTreasureAgeSec,omitempty"`
+	// MaxTreasures is the max number of the treasures in a swamp, 0 means no limit
+	MaxTreasures int `json:"maxTreasures,omitempty"`
+	// MaxSwampSizeByte is the max size of the files of a swamp on the disk, 0 means no limit
+	MaxSwampSizeByte int64 `json:"maxSwampSizeByte,omitempty"`
+	// DefaultExpireAfterSec is the expiration time of the treasures written without one in seconds, 0 means none
+	DefaultExpireAfterSec int64 `json:"defaultExpireAfterSec,omitempty"`
+	// MaxKeyLength is the max length of the keys of the written treasures, 0 means no limit
+	MaxKeyLength int `json:"maxKeyLength,omitempty"`
+	// MaxValueSizeByte is the max size of the values of the written treasures, 0 means no limit
+	MaxValueSizeByte int64 `json:"maxValueSizeByte,omitempty"`
+	// RequiredMetadata are the metadata fields every written treasure must have
+	RequiredMetadata []string `json:"requiredMetadata,omitempty"`
+	// FsyncPolicy is when the written files are flushed to the disk, empty means never
+	FsyncPolicy setting.FsyncPolicy `json:"fsyncPolicy,omitempty"`
+	// FsyncIntervalSec is the min time between two flushes with the interval policy in seconds
+	FsyncIntervalSec int64 `json:"fsyncIntervalSec,omitempty"`
+	// KeyFilter is true if the swamps keep a bloom filter of their keys on the disk
+	KeyFilter bool `json:"keyFilter,omitempty"`
+	// LazyHydration is true if the swamps keep a directory of the chunk files of their keys on the disk
+	LazyHydration bool `json:"lazyHydration,omitempty"`
+	// KeptBeacons are the order beacons the swamps maintain all the time, empty means every beacon is kept
+	KeptBeacons []string `json:"keptBeacons,omitempty"`
+	// BeaconEvictAfterIdleSec is how long the other beacons stay in the memory after their last use in seconds
+	BeaconEvictAfterIdleSec int64 `json:"beaconEvictAfterIdleSec,omitempty"`
+	// RegisteredBy is the client that registered the pattern last, empty if it is unknown
+	RegisteredBy string `json:"registeredBy,omitempty"`
+	// RegisteredAt is the unix time of the last registration that changed the pattern in seconds, 0 if it is unknown
+	RegisteredAt int64 `json:"registeredAt,omitempty"`
 }
 
 // New creates a new instance of the setting
 func New(maxDepthOfFolders int, maxFoldersPerLevel int) Settings {
+	return NewWithRootPath(os.Getenv("HYDRAIDE_ROOT_PATH"), maxDepthOfFolders, maxFoldersPerLevel)
+}
+
+// NewWithRootPath creates the settings with the data and settings folders under the given root path instead of
+// the HYDRAIDE_ROOT_PATH. Used by the multi-tenant server, where every tenant has its own isolated root path.
+func NewWithRootPath(rootPath string, maxDepthOfFolders int, maxFoldersPerLevel int) Settings {
 
 	// ellenőrizzük, hogy az alapvető mentési könyvtárak léteznek-e és írhatóak-e
 	// ha nem léteznek, akkor létrehozzuk azokat írható formában
-	hydraDataFolderPath = filepath.Join(os.Getenv("HYDRAIDE_ROOT_PATH"), "data")
-	hydraSettingsFolderPath = filepath.Join(os.Getenv("HYDRAIDE_ROOT_PATH"), "settings")
+	dataFolderPath := filepath.Join(rootPath, "data")
+	settingsFolderPath := filepath.Join(rootPath, "settings")
 
-	checkFolder(hydraDataFolderPath)
-	checkFolder(hydraSettingsFolderPath)
+	checkFolder(dataFolderPath)
+	checkFolder(settingsFolderPath)
 
 	t := &settings{
 		patterns: make(map[string]setting.Setting),
@@ -93,6 +192,9 @@ func New(maxDepthOfFolders int, maxFoldersPerLevel int) Settings {
 		},
 		maxDepthOfFolders:  maxDepthOfFolders,
 		maxFoldersPerLevel: maxFoldersPerLevel,
+		dataFolderPath:     dataFolderPath,
+		settingsFolderPath: settingsFolderPath,
+		blobFolderPath:     filepath.Join(rootPath, "blobs"),
 	}
 
 	// load the saved settings from the filesystem at the startup
@@ -116,7 +218,85 @@ func (s *settings) GetMaxFoldersPerLevel() int {
 func (s *settings) GetHydraAbsDataFolderPath() string {
 	// nem kell lockolni, mert ez egy konstans és az értéke nem változhat futásidő alatt,
 	// így nem kell gátolni az egyidejű hozzáférést, ami lassítaná a rendszert
-	return hydraDataFolderPath
+	return s.dataFolderPath
+}
+
+// GetHydraAbsSettingsFolderPath returns the folder where the settings are persisted
+func (s *settings) GetHydraAbsSettingsFolderPath() string {
+	// no need to lock, because the value never changes at runtime
+	return s.settingsFolderPath
+}
+
+// GetHydraAbsBlobFolderPath returns the folder of the blobs. The folder is created by the blob store at the first write
+func (s *settings) GetHydraAbsBlobFolderPath() string {
+	// no need to lock, because the value never changes at runtime
+	return s.blobFolderPath
+}
+
+// SetWriteBatchSize sets the max number of treasures a swamp writes to the filesystem at once
+func (s *settings) SetWriteBatchSize(size int) {
+	s.writeBatchSize.Store(int64(size))
+}
+
+// GetWriteBatchSize returns the max number of treasures a swamp writes to the filesystem at once, 0 means all
+func (s *settings) GetWriteBatchSize() int {
+	return int(s.writeBatchSize.Load())
+}
+
+// SetHydrationScheduler sets the scheduler of the swamp loading
+func (s *settings) SetHydrationScheduler(scheduler hydration.Scheduler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hydrationScheduler = scheduler
+}
+
+// GetHydrationScheduler returns the scheduler of the swamp loading, nil means unlimited
+func (s *settings) GetHydrationScheduler() hydration.Scheduler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hydrationScheduler
+}
+
+// SetKeyLockCounters sets the counters of the key lock contention
+func (s *settings) SetKeyLockCounters(counters *keylock.Counters) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyLockCounters = counters
+}
+
+// GetKeyLockCounters returns the counters of the key lock contention, nil means the contention is not summed
+func (s *settings) GetKeyLockCounters() *keylock.Counters {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keyLockCounters
+}
+
+// SetFlushStallCounters sets the counters of the flush stalls
+func (s *settings) SetFlushStallCounters(counters *flushstall.Counters) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushStallCounters = counters
+}
+
+// GetFlushStallCounters returns the counters of the flush stalls, nil means the stalls are not counted
+func (s *settings) GetFlushStallCounters() *flushstall.Counters {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flushStallCounters
+}
+
+// SetDefaults sets the default swamp settings
+func (s *settings) SetDefaults(swampDefaults *defaults.Defaults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.swampDefaults = swampDefaults
+}
+
+// GetDefaults returns the default swamp settings, nil means they are not set
+func (s *settings) GetDefaults() *defaults.Defaults {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.swampDefaults
 }
 
 // FileSystemSettings contains the settings for the filesystem-type swamps
@@ -125,20 +305,89 @@ type FileSystemSettings struct {
 	WriteIntervalSec int64
 	// MaxFileSizeByte is the maximum size of the file fragments of the swamp in bytes
 	MaxFileSizeByte int64
+	// FsyncPolicy is when the written files of the swamp are flushed to the disk. Empty means setting.FsyncNever
+	FsyncPolicy setting.FsyncPolicy
+	// FsyncIntervalSec is the min time between two flushes of the swamp with the setting.FsyncInterval policy
+	FsyncIntervalSec int64
+	// KeyFilter makes the swamp keep a bloom filter of its keys next to its files, so the existence checks of the
+	// missing keys do not load the swamp
+	KeyFilter bool
+	// LazyHydration makes the swamp keep a directory of the chunk files of its keys next to its files, so the reads of
+	// a few keys of a closed swamp load only their chunks
+	LazyHydration bool
+}
+
+// PatternOptions contains the optional, type independent settings of the swamp pattern
+type PatternOptions struct {
+	// ValueIndex enables the secondary value index for the swamps of the pattern
+	ValueIndex bool
+	// EventJournalSize is the max number of the events kept in the event journal of the swamps. 0 disables the journal
+	EventJournalSize int
+	// EventJournalRetention is how long the events are kept in the event journal. 0 means no time limit
+	EventJournalRetention time.Duration
+	// ServerTimestamps makes the server set the creation and modification times of the treasures
+	ServerTimestamps bool
+	// HistoryDepth is the number of the versions kept in the history of each treasure. 0 disables the history
+	HistoryDepth int
+	// MaxTreasureAge is the retention time of the treasures, measured from their creation time. 0 means no age limit
+	MaxTreasureAge time.Duration
+	// MaxTreasures is the max number of the treasures kept in a swamp. The oldest ones are deleted above it.
+	// 0 means no limit
+	MaxTreasures int
+	// MaxSwampSizeByte is the max size of the files of a swamp on the disk. The oldest treasures are deleted above
+	// it. 0 means no limit
+	MaxSwampSizeByte int64
+	// DefaultExpireAfter is the expiration time of the treasures written without an expiration time, measured from
+	// the write. 0 means the treasures never expire by default
+	DefaultExpireAfter time.Duration
+	// MaxKeyLength is the max length of the keys of the written treasures in bytes. 0 means no limit
+	MaxKeyLength int
+	// MaxValueSizeByte is the max size of the values of the written treasures. 0 means no limit
+	MaxValueSizeByte int64
+	// RequiredMetadata are the metadata fields every written treasure must have, e.g. setting.MetadataCreatedBy
+	RequiredMetadata []string
+	// KeptBeacons are the order beacons the swamps maintain all the time, e.g. setting.BeaconKey. The other beacons
+	// are built at their first use and evicted after BeaconEvictAfterIdle. Empty means every beacon is kept
+	KeptBeacons []string
+	// BeaconEvictAfterIdle is how long the beacons that are not kept stay in the memory after their last use.
+	// 0 means setting.DefaultBeaconEvictAfterIdle
+	BeaconEvictAfterIdle time.Duration
+	// RegisteredBy is the client that registers the pattern, saved with the pattern. Empty if it is unknown
+	RegisteredBy string
 }
 
 // RegisterPattern registers a pattern for a swamp to the settings only if it is not exist
 // inMemorySwamp is true if the swamp is in-memory type, otherwise it is false
 // If the swamp is filesystem type, then the filesystemSettings must be set otherwise it is nil
-func (s *settings) RegisterPattern(pattern name.Name, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings) {
+// patternOptions is optional, nil means the default options
+func (s *settings) RegisterPattern(pattern name.Name, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings, patternOptions *PatternOptions) {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if patternOptions == nil {
+		patternOptions = &PatternOptions{}
+	}
+
 	swampSetting := &setting.SwampSetting{
 		Pattern:           pattern,
 		InMemory:          inMemorySwamp,
 		CloseAfterIdleSec: time.Duration(closeAfterIdleSec) * time.Second,
+		ValueIndex:        patternOptions.ValueIndex,
+		// the retention is stored in seconds, so it is rounded the same way as after a restart
+		EventJournalSize:      patternOptions.EventJournalSize,
+		EventJournalRetention: patternOptions.EventJournalRetention.Truncate(time.Second),
+		ServerTimestamps:      patternOptions.ServerTimestamps,
+		HistoryDepth:          patternOptions.HistoryDepth,
+		MaxTreasureAge:        patternOptions.MaxTreasureAge.Truncate(time.Second),
+		MaxTreasures:          patternOptions.MaxTreasures,
+		MaxSwampSizeByte:      patternOptions.MaxSwampSizeByte,
+		DefaultExpireAfter:    patternOptions.DefaultExpireAfter.Truncate(time.Second),
+		MaxKeyLength:          patternOptions.MaxKeyLength,
+		MaxValueSizeByte:      patternOptions.MaxValueSizeByte,
+		RequiredMetadata:      patternOptions.RequiredMetadata,
+		KeptBeacons:           patternOptions.KeptBeacons,
+		BeaconEvictAfterIdle:  patternOptions.BeaconEvictAfterIdle.Truncate(time.Second),
 	}
 
 	// the swamp is filesystem type
@@ -148,9 +397,27 @@ func (s *settings) RegisterPattern(pattern name.Name, inMemorySwamp bool, closeA
 		if _, ok := s.patterns[pattern.Get()]; ok {
 			// check if the actual pattern setting is different from the new setting
 			if s.patterns[pattern.Get()].GetCloseAfterIdle() == time.Duration(closeAfterIdleSec)*time.Second &&
+				s.patterns[pattern.Get()].IsValueIndexed() == patternOptions.ValueIndex &&
+				s.patterns[pattern.Get()].GetEventJournalSize() == swampSetting.EventJournalSize &&
+				s.patterns[pattern.Get()].GetEventJournalRetention() == swampSetting.EventJournalRetention &&
+				s.patterns[pattern.Get()].IsServerTimestamped() == patternOptions.ServerTimestamps &&
+				s.patterns[pattern.Get()].GetHistoryDepth() == patternOptions.HistoryDepth &&
+				s.patterns[pattern.Get()].GetMaxTreasureAge() == swampSetting.MaxTreasureAge &&
+				s.patterns[pattern.Get()].GetMaxTreasures() == patternOptions.MaxTreasures &&
+				s.patterns[pattern.Get()].GetMaxSwampSizeByte() == patternOptions.MaxSwampSizeByte &&
+				s.patterns[pattern.Get()].GetDefaultExpireAfter() == swampSetting.DefaultExpireAfter &&
+				s.patterns[pattern.Get()].GetMaxKeyLength() == patternOptions.MaxKeyLength &&
+				s.patterns[pattern.Get()].GetMaxValueSizeByte() == patternOptions.MaxValueSizeByte &&
+				slices.Equal(s.patterns[pattern.Get()].GetRequiredMetadata(), patternOptions.RequiredMetadata) &&
+				slices.Equal(s.patterns[pattern.Get()].GetKeptBeacons(), patternOptions.KeptBeacons) &&
+				s.patterns[pattern.Get()].GetBeaconEvictAfterIdle() == setting.New(swampSetting).GetBeaconEvictAfterIdle() &&
 				(filesystemSettings != nil &&
 					(s.patterns[pattern.Get()].GetWriteInterval() == time.Duration(filesystemSettings.WriteIntervalSec)*time.Second &&
-						s.patterns[pattern.Get()].GetMaxFileSizeByte() == filesystemSettings.MaxFileSizeByte)) {
+						s.patterns[pattern.Get()].GetMaxFileSizeByte() == filesystemSettings.MaxFileSizeByte &&
+						s.patterns[pattern.Get()].GetFsyncPolicy() == filesystemSettings.FsyncPolicy.Or(setting.FsyncNever) &&
+						s.patterns[pattern.Get()].GetFsyncInterval() == time.Duration(filesystemSettings.FsyncIntervalSec)*time.Second &&
+						s.patterns[pattern.Get()].IsKeyFiltered() == filesystemSettings.KeyFilter &&
+						s.patterns[pattern.Get()].IsLazyHydrated() == filesystemSettings.LazyHydration)) {
 				// do nothing, because the pattern is already exist and not changed
 				// so, we don't need to save the settings to the filesystem
 				return
@@ -162,6 +429,10 @@ func (s *settings) RegisterPattern(pattern name.Name, inMemorySwamp bool, closeA
 		if filesystemSettings != nil {
 			swampSetting.WriteIntervalSec = time.Duration(filesystemSettings.WriteIntervalSec) * time.Second
 			swampSetting.MaxFileSizeByte = filesystemSettings.MaxFileSizeByte
+			swampSetting.FsyncPolicy = filesystemSettings.FsyncPolicy
+			swampSetting.FsyncInterval = time.Duration(filesystemSettings.FsyncIntervalSec) * time.Second
+			swampSetting.KeyFilter = filesystemSettings.KeyFilter
+			swampSetting.LazyHydration = filesystemSettings.LazyHydration
 		}
 
 	}
@@ -175,11 +446,32 @@ func (s *settings) RegisterPattern(pattern name.Name, inMemorySwamp bool, closeA
 		pm := &PatternModel{
 			NameCanonicalForm: pattern.Get(),
 			InMemory:          inMemorySwamp,
+			ValueIndex:        patternOptions.ValueIndex,
+			EventJournalSize:  patternOptions.EventJournalSize,
+			// whole seconds, like the other durations of the model
+			EventJournalRetentionSec: int64(patternOptions.EventJournalRetention / time.Second),
+			ServerTimestamps:         patternOptions.ServerTimestamps,
+			HistoryDepth:             patternOptions.HistoryDepth,
+			MaxTreasureAgeSec:        int64(patternOptions.MaxTreasureAge / time.Second),
+			MaxTreasures:             patternOptions.MaxTreasures,
+			MaxSwampSizeByte:         patternOptions.MaxSwampSizeByte,
+			DefaultExpireAfterSec:    int64(patternOptions.DefaultExpireAfter / time.Second),
+			MaxKeyLength:             patternOptions.MaxKeyLength,
+			MaxValueSizeByte:         patternOptions.MaxValueSizeByte,
+			RequiredMetadata:         patternOptions.RequiredMetadata,
+			KeptBeacons:              patternOptions.KeptBeacons,
+			BeaconEvictAfterIdleSec:  int64(patternOptions.BeaconEvictAfterIdle / time.Second),
+			RegisteredBy:             patternOptions.RegisteredBy,
+			RegisteredAt:             time.Now().Unix(),
 		}
 
 		if !inMemorySwamp {
 			pm.WriteIntervalSec = filesystemSettings.WriteIntervalSec
 			pm.MaxFileSizeByte = filesystemSettings.MaxFileSizeByte
+			pm.FsyncPolicy = filesystemSettings.FsyncPolicy
+			pm.FsyncIntervalSec = filesystemSettings.FsyncIntervalSec
+			pm.KeyFilter = filesystemSettings.KeyFilter
+			pm.LazyHydration = filesystemSettings.LazyHydration
 		}
 
 		pm.CloseAfterIdleSec = closeAfterIdleSec
@@ -193,7 +485,7 @@ func (s *settings) RegisterPattern(pattern name.Name, inMemorySwamp bool, closeA
 
 	}()
 
-	slog.Info("swamp pattern registered", "pattern", pattern.Get())
+	slog.Info("swamp pattern registered", "pattern", pattern.Get(), "registeredBy", patternOptions.RegisteredBy)
 
 }
 
@@ -216,6 +508,150 @@ func (s *settings) DeregisterPattern(pattern name.Name) {
 
 }
 
+// ListPatterns returns a copy of the registered patterns, sorted by their names
+func (s *settings) ListPatterns() []PatternModel {
+
+	s.modelMutex.RLock()
+	defer s.modelMutex.RUnlock()
+
+	patterns := make([]PatternModel, 0, len(s.model.Patterns))
+	for _, pm := range s.model.Patterns {
+		pattern := *pm
+		pattern.RequiredMetadata = slices.Clone(pm.RequiredMetadata)
+		pattern.KeptBeacons = slices.Clone(pm.KeptBeacons)
+		patterns = append(patterns, pattern)
+	}
+
+	slices.SortFunc(patterns, func(a, b PatternModel) int {
+		return strings.Compare(a.NameCanonicalForm, b.NameCanonicalForm)
+	})
+
+	return patterns
+
+}
+
+// ConflictingPattern returns the first registered pattern by name that overlaps with the pattern and has other
+// memory or filesystem settings. The pattern itself is checked first, so a changed registration is reported against
+// its own previous settings.
+func (s *settings) ConflictingPattern(pattern name.Name, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings) (PatternModel, bool) {
+
+	if pm, ok := s.registeredPattern(pattern.Get()); ok {
+		if !hasSameSwampSettings(pm, inMemorySwamp, closeAfterIdleSec, filesystemSettings) {
+			return pm, true
+		}
+	}
+
+	for _, pm := range s.ListPatterns() {
+		if pm.NameCanonicalForm == pattern.Get() || !patternsOverlap(pattern, name.Load(pm.NameCanonicalForm)) {
+			continue
+		}
+		if !hasSameSwampSettings(pm, inMemorySwamp, closeAfterIdleSec, filesystemSettings) {
+			return pm, true
+		}
+	}
+
+	return PatternModel{}, false
+
+}
+
+// registeredPattern returns a copy of the registered pattern
+func (s *settings) registeredPattern(canonicalForm string) (PatternModel, bool) {
+	s.modelMutex.RLock()
+	defer s.modelMutex.RUnlock()
+	pm, ok := s.model.Patterns[canonicalForm]
+	if !ok {
+		return PatternModel{}, false
+	}
+	pattern := *pm
+	pattern.RequiredMetadata = slices.Clone(pm.RequiredMetadata)
+	pattern.KeptBeacons = slices.Clone(pm.KeptBeacons)
+	return pattern, true
+}
+
+// patternsOverlap returns true if a swamp can match both patterns. The sanctuaries of the patterns are never
+// wildcards, the realms and the swamps overlap if they are equal or one of them is a wildcard.
+func patternsOverlap(a name.Name, b name.Name) bool {
+	partsOverlap := func(x, y string) bool {
+		return x == y || x == "*" || y == "*"
+	}
+	return a.GetSanctuaryID() == b.GetSanctuaryID() &&
+		partsOverlap(a.GetRealmName(), b.GetRealmName()) &&
+		partsOverlap(a.GetSwampName(), b.GetSwampName())
+}
+
+// hasSameSwampSettings returns true if the registered pattern keeps its swamps in the memory and writes them to the
+// filesystem the same way as the given settings
+func hasSameSwampSettings(pm PatternModel, inMemorySwamp bool, closeAfterIdleSec int64, filesystemSettings *FileSystemSettings) bool {
+	if pm.InMemory != inMemorySwamp || pm.CloseAfterIdleSec != closeAfterIdleSec {
+		return false
+	}
+	if inMemorySwamp || filesystemSettings == nil {
+		return true
+	}
+	return pm.WriteIntervalSec == filesystemSettings.WriteIntervalSec &&
+		pm.MaxFileSizeByte == filesystemSettings.MaxFileSizeByte &&
+		pm.FsyncPolicy.Or(setting.FsyncNever) == filesystemSettings.FsyncPolicy.Or(setting.FsyncNever) &&
+		pm.FsyncIntervalSec == filesystemSettings.FsyncIntervalSec
+}
+
+// PatternUpdate contains the settings of a registered pattern to change. The zero values keep the current settings.
+type PatternUpdate struct {
+	// CloseAfterIdleSec is the time in seconds the swamps stay open after their last access
+	CloseAfterIdleSec int64
+	// WriteIntervalSec is the time interval when the swamps write their data to the filesystem
+	WriteIntervalSec int64
+	// MaxFileSizeByte is the maximum size of the file fragments of the swamps in bytes
+	MaxFileSizeByte int64
+}
+
+// UpdatePattern changes the settings of a registered pattern, saves them to the filesystem, and returns the updated
+// pattern. The open swamps keep their settings, the swamps opened after the update get the new ones.
+func (s *settings) UpdatePattern(pattern name.Name, update PatternUpdate) (PatternModel, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.modelMutex.Lock()
+	defer s.modelMutex.Unlock()
+
+	pm, ok := s.model.Patterns[pattern.Get()]
+	if !ok {
+		return PatternModel{}, ErrPatternNotFound
+	}
+
+	if pm.InMemory && (update.WriteIntervalSec > 0 || update.MaxFileSizeByte > 0) {
+		return PatternModel{}, ErrInMemoryPattern
+	}
+
+	if update.CloseAfterIdleSec > 0 {
+		pm.CloseAfterIdleSec = update.CloseAfterIdleSec
+	}
+	if update.WriteIntervalSec > 0 {
+		pm.WriteIntervalSec = update.WriteIntervalSec
+	}
+	if update.MaxFileSizeByte > 0 {
+		pm.MaxFileSizeByte = update.MaxFileSizeByte
+	}
+
+	// the in-memory patterns are not matched by the swamps, like at the registration
+	if !pm.InMemory {
+		s.patterns[pm.NameCanonicalForm] = patternSetting(pm)
+	}
+
+	if err := s.SaveSettingsToFilesystem(); err != nil {
+		slog.Error("failed to save settings to filesystem", "error", err)
+	}
+
+	slog.Info("swamp pattern updated", "pattern", pm.NameCanonicalForm, "closeAfterIdleSec", pm.CloseAfterIdleSec,
+		"writeIntervalSec", pm.WriteIntervalSec, "maxFileSizeByte", pm.MaxFileSizeByte)
+
+	updated := *pm
+	updated.RequiredMetadata = slices.Clone(pm.RequiredMetadata)
+	updated.KeptBeacons = slices.Clone(pm.KeptBeacons)
+	return updated, nil
+
+}
+
 // GetBySwampName loads the setting of the swamp by the name of the swamp
 func (s *settings) GetBySwampName(swampName name.Name) setting.Setting {
 
@@ -231,6 +667,18 @@ func (s *settings) GetBySwampName(swampName name.Name) setting.Setting {
 		}
 	}
 
+	// the configured defaults are read at every call, so the swamps opened after a reload get the new values
+	if values := s.swampDefaults.Get(); values.ApplyToUnregisteredSwamps {
+		return setting.New(&setting.SwampSetting{
+			Pattern:           swampName,
+			CloseAfterIdleSec: time.Duration(values.CloseAfterIdleSec) * time.Second,
+			WriteIntervalSec:  time.Duration(values.WriteIntervalSec) * time.Second,
+			MaxFileSizeByte:   values.MaxFileSizeByte,
+			FsyncPolicy:       values.FsyncPolicy,
+			FsyncInterval:     time.Duration(values.FsyncIntervalSec) * time.Second,
+		})
+	}
+
 	// ha nem találunk olyan beállítást, ami a megadott mintához tartozik, akkor visszaadjuk az alapértelmezett beállítást
 	// ebben az esetben is a mentés helye nem változik, csak a beállítások lesznek alapértelmezettek
 	return setting.New(&setting.SwampSetting{
@@ -256,9 +704,9 @@ func (s *settings) SaveSettingsToFilesystem() error {
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
-	filePath := path.Join(hydraSettingsFolderPath, fileName)
+	filePath := filepath.Join(s.settingsFolderPath, fileName)
 
-	err = os.MkdirAll(path.Join(hydraSettingsFolderPath), 0755)
+	err = os.MkdirAll(filepath.Join(s.settingsFolderPath), 0755)
 	if err != nil {
 		return fmt.Errorf("failed to create directory path: %w", err)
 	}
@@ -276,7 +724,7 @@ func (s *settings) loadSettingsFromFilesystem() error {
 	s.modelMutex.Lock()
 	defer s.modelMutex.Unlock()
 
-	filePath := path.Join(hydraSettingsFolderPath, fileName)
+	filePath := filepath.Join(s.settingsFolderPath, fileName)
 	data, err := os.ReadFile(filePath)
 
 	if err != nil {
@@ -304,14 +752,7 @@ func (s *settings) loadSettingsFromFilesystem() error {
 		if s.model.Patterns != nil {
 			for _, pattern := range s.model.Patterns {
 
-				patternNameObj := name.Load(pattern.NameCanonicalForm)
-
-				s.patterns[pattern.NameCanonicalForm] = setting.New(&setting.SwampSetting{
-					Pattern:           patternNameObj,
-					CloseAfterIdleSec: time.Duration(pattern.CloseAfterIdleSec) * time.Second,
-					WriteIntervalSec:  time.Duration(pattern.WriteIntervalSec) * time.Second,
-					MaxFileSizeByte:   pattern.MaxFileSizeByte,
-				})
+				s.patterns[pattern.NameCanonicalForm] = patternSetting(pattern)
 
 			}
 		}
@@ -327,6 +768,34 @@ func (s *settings) loadSettingsFromFilesystem() error {
 
 }
 
+// patternSetting creates the setting of the swamps from the saved pattern
+func patternSetting(pattern *PatternModel) setting.Setting {
+	return setting.New(&setting.SwampSetting{
+		Pattern:               name.Load(pattern.NameCanonicalForm),
+		CloseAfterIdleSec:     time.Duration(pattern.CloseAfterIdleSec) * time.Second,
+		WriteIntervalSec:      time.Duration(pattern.WriteIntervalSec) * time.Second,
+		MaxFileSizeByte:       pattern.MaxFileSizeByte,
+		ValueIndex:            pattern.ValueIndex,
+		EventJournalSize:      pattern.EventJournalSize,
+		EventJournalRetention: time.Duration(pattern.EventJournalRetentionSec) * time.Second,
+		ServerTimestamps:      pattern.ServerTimestamps,
+		HistoryDepth:          pattern.HistoryDepth,
+		MaxTreasureAge:        time.Duration(pattern.MaxTreasureAgeSec) * time.Second,
+		MaxTreasures:          pattern.MaxTreasures,
+		MaxSwampSizeByte:      pattern.MaxSwampSizeByte,
+		DefaultExpireAfter:    time.Duration(pattern.DefaultExpireAfterSec) * time.Second,
+		MaxKeyLength:          pattern.MaxKeyLength,
+		MaxValueSizeByte:      pattern.MaxValueSizeByte,
+		RequiredMetadata:      pattern.RequiredMetadata,
+		KeptBeacons:           pattern.KeptBeacons,
+		BeaconEvictAfterIdle:  time.Duration(pattern.BeaconEvictAfterIdleSec) * time.Second,
+		FsyncPolicy:           pattern.FsyncPolicy,
+		FsyncInterval:         time.Duration(pattern.FsyncIntervalSec) * time.Second,
+		KeyFilter:             pattern.KeyFilter,
+		LazyHydration:         pattern.LazyHydration,
+	})
+}
+
 // checkDataFolder ellenőrzi, hogy a hydra adatmentési könyvtár létezik-e és írható-e
 func checkFolder(folderPath string) {
 	// ellenőrizzük, hogy a folder létezik-e és írható-e
@@ -339,12 +808,12 @@ func checkFolder(folderPath string) {
 		}
 	}
 	// ellenőrizzük, hogy a folder írható-e
-	if err := os.WriteFile(path.Join(folderPath, writetestFile), []byte("test"), 0644); err != nil {
+	if err := os.WriteFile(filepath.Join(folderPath, writetestFile), []byte("test"), 0644); err != nil {
 		slog.Error("Hydraide folder is not writable", "folder", folderPath, "error", err)
 		panic("Hydraide folder is not writable")
 	}
 	// töröljük a teszt fájlt
-	if err := os.Remove(path.Join(folderPath, writetestFile)); err != nil {
+	if err := os.Remove(filepath.Join(folderPath, writetestFile)); err != nil {
 		slog.Error("failed to remove test file", "error", err, "folder", folderPath)
 		panic("failed to remove test file")
 	}
@@ -2,8 +2,14 @@ package settings
 
 import (
 	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
+	"github.com/hydraide/hydraide/app/core/settings/defaults"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
 	"github.com/hydraide/hydraide/app/name"
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -41,7 +47,7 @@ func TestNew(t *testing.T) {
 		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
 			WriteIntervalSec: 14,
 			MaxFileSizeByte:  888888,
-		})
+		}, nil)
 
 		// töröljük a patternt
 		defer configs.DeregisterPattern(pattern)
@@ -59,4 +65,492 @@ func TestNew(t *testing.T) {
 
 	})
 
+	t.Run("should register and reload the value index option", func(t *testing.T) {
+
+		maxDepthOfFolders := 2
+		maxFoldersPerLevel := 2000
+
+		configs := New(maxDepthOfFolders, maxFoldersPerLevel)
+		pattern := name.New().Sanctuary("settingstest3").Realm("*").Swamp("indexed")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			ValueIndex: true,
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest3").Realm("users").Swamp("indexed")
+		assert.True(t, configs.GetBySwampName(swampName).IsValueIndexed(), "should be value indexed")
+
+		// the option must survive the reload of the settings from the filesystem
+		reloadedConfigs := New(maxDepthOfFolders, maxFoldersPerLevel)
+		assert.True(t, reloadedConfigs.GetBySwampName(swampName).IsValueIndexed(), "should be value indexed after reload")
+
+		// the default settings are not value indexed
+		assert.False(t, configs.GetBySwampName(name.New().Sanctuary("settingstest3").Realm("users").Swamp("other")).IsValueIndexed())
+
+	})
+
+	t.Run("should register and reload the event journal options", func(t *testing.T) {
+
+		maxDepthOfFolders := 2
+		maxFoldersPerLevel := 2000
+
+		configs := New(maxDepthOfFolders, maxFoldersPerLevel)
+		pattern := name.New().Sanctuary("settingstest4").Realm("*").Swamp("journaled")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			EventJournalSize:      500,
+			EventJournalRetention: time.Hour,
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest4").Realm("users").Swamp("journaled")
+		assert.Equal(t, 500, configs.GetBySwampName(swampName).GetEventJournalSize())
+		assert.Equal(t, time.Hour, configs.GetBySwampName(swampName).GetEventJournalRetention())
+
+		// the options must survive the reload of the settings from the filesystem
+		reloadedConfigs := New(maxDepthOfFolders, maxFoldersPerLevel)
+		assert.Equal(t, 500, reloadedConfigs.GetBySwampName(swampName).GetEventJournalSize())
+		assert.Equal(t, time.Hour, reloadedConfigs.GetBySwampName(swampName).GetEventJournalRetention())
+
+		// the journal is disabled by default
+		assert.Equal(t, 0, configs.GetBySwampName(name.New().Sanctuary("settingstest4").Realm("users").Swamp("other")).GetEventJournalSize())
+
+	})
+
+	t.Run("should register and reload the server timestamps option", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest5").Realm("*").Swamp("stamped")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			ServerTimestamps: true,
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest5").Realm("orders").Swamp("stamped")
+		assert.True(t, configs.GetBySwampName(swampName).IsServerTimestamped())
+		assert.True(t, New(2, 2000).GetBySwampName(swampName).IsServerTimestamped())
+
+		// the client timestamps are used by default
+		assert.False(t, configs.GetBySwampName(name.New().Sanctuary("settingstest5").Realm("orders").Swamp("other")).IsServerTimestamped())
+
+	})
+
+	t.Run("should register and reload the history depth", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest6").Realm("*").Swamp("audited")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			HistoryDepth: 10,
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest6").Realm("ledger").Swamp("audited")
+		assert.Equal(t, 10, configs.GetBySwampName(swampName).GetHistoryDepth())
+		assert.Equal(t, 10, New(2, 2000).GetBySwampName(swampName).GetHistoryDepth())
+
+		// the history is disabled by default
+		assert.Equal(t, 0, configs.GetBySwampName(name.New().Sanctuary("settingstest6").Realm("ledger").Swamp("other")).GetHistoryDepth())
+
+	})
+
+	t.Run("should register and reload the retention", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest7").Realm("*").Swamp("logs")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			MaxTreasureAge:   36*time.Hour + 500*time.Millisecond,
+			MaxTreasures:     1000,
+			MaxSwampSizeByte: 1 << 20,
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest7").Realm("app").Swamp("logs")
+		for _, s := range []setting.Setting{configs.GetBySwampName(swampName), New(2, 2000).GetBySwampName(swampName)} {
+			// stored in whole seconds
+			assert.Equal(t, 36*time.Hour, s.GetMaxTreasureAge())
+			assert.Equal(t, 1000, s.GetMaxTreasures())
+			assert.Equal(t, int64(1<<20), s.GetMaxSwampSizeByte())
+		}
+
+		// no retention by default
+		other := configs.GetBySwampName(name.New().Sanctuary("settingstest7").Realm("app").Swamp("other"))
+		assert.Equal(t, time.Duration(0), other.GetMaxTreasureAge())
+		assert.Equal(t, 0, other.GetMaxTreasures())
+		assert.Equal(t, int64(0), other.GetMaxSwampSizeByte())
+
+	})
+
+	t.Run("should register and reload the default expiration", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest8").Realm("*").Swamp("sessions")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			DefaultExpireAfter: 30 * time.Minute,
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest8").Realm("web").Swamp("sessions")
+		assert.Equal(t, 30*time.Minute, configs.GetBySwampName(swampName).GetDefaultExpireAfter())
+		assert.Equal(t, 30*time.Minute, New(2, 2000).GetBySwampName(swampName).GetDefaultExpireAfter())
+
+		// the treasures never expire by default
+		assert.Equal(t, time.Duration(0), configs.GetBySwampName(name.New().Sanctuary("settingstest8").Realm("web").Swamp("other")).GetDefaultExpireAfter())
+
+	})
+
+	t.Run("should register and reload the schema constraints", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest9").Realm("*").Swamp("users")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, &PatternOptions{
+			MaxKeyLength:     36,
+			MaxValueSizeByte: 4096,
+			RequiredMetadata: []string{setting.MetadataCreatedBy, setting.MetadataUpdatedBy},
+		})
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest9").Realm("eu").Swamp("users")
+		for _, s := range []setting.Setting{configs.GetBySwampName(swampName), New(2, 2000).GetBySwampName(swampName)} {
+			assert.Equal(t, 36, s.GetMaxKeyLength())
+			assert.Equal(t, int64(4096), s.GetMaxValueSizeByte())
+			assert.Equal(t, []string{setting.MetadataCreatedBy, setting.MetadataUpdatedBy}, s.GetRequiredMetadata())
+		}
+
+	})
+
+	t.Run("should register and reload the fsync policy", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest10").Realm("*").Swamp("payments")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+			FsyncPolicy:      setting.FsyncInterval,
+			FsyncIntervalSec: 3,
+		}, nil)
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest10").Realm("eu").Swamp("payments")
+		for _, s := range []setting.Setting{configs.GetBySwampName(swampName), New(2, 2000).GetBySwampName(swampName)} {
+			assert.Equal(t, setting.FsyncInterval, s.GetFsyncPolicy())
+			assert.Equal(t, 3*time.Second, s.GetFsyncInterval())
+		}
+
+		// the operating system flushes the files of the unregistered swamps
+		assert.Equal(t, setting.FsyncNever, configs.GetBySwampName(name.New().Sanctuary("settingstest10").Realm("eu").Swamp("other")).GetFsyncPolicy())
+
+	})
+
+	t.Run("should register and reload the key filter", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest11").Realm("*").Swamp("urls")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+			KeyFilter:        true,
+		}, nil)
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest11").Realm("eu").Swamp("urls")
+		for _, s := range []setting.Setting{configs.GetBySwampName(swampName), New(2, 2000).GetBySwampName(swampName)} {
+			assert.True(t, s.IsKeyFiltered())
+		}
+
+		// a registration without the key filter turns it off
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+		}, nil)
+		assert.False(t, configs.GetBySwampName(swampName).IsKeyFiltered())
+
+	})
+
+	t.Run("should register and reload the lazy hydration", func(t *testing.T) {
+
+		configs := New(2, 2000)
+		pattern := name.New().Sanctuary("settingstest12").Realm("*").Swamp("users")
+
+		configs.RegisterPattern(pattern, false, 5, &FileSystemSettings{
+			WriteIntervalSec: 1,
+			MaxFileSizeByte:  8192,
+			LazyHydration:    true,
+		}, nil)
+
+		defer configs.DeregisterPattern(pattern)
+
+		swampName := name.New().Sanctuary("settingstest12").Realm("eu").Swamp("users")
+		for _, s := range []setting.Setting{configs.GetBySwampName(swampName), New(2, 2000).GetBySwampName(swampName)} {
+			assert.True(t, s.IsLazyHydrated())
+			assert.False(t, s.IsKeyFiltered())
+		}
+
+	})
+
+}
+
+func TestNewWithRootPath(t *testing.T) {
+
+	t.Run("should keep the settings of different root paths isolated", func(t *testing.T) {
+
+		rootA := t.TempDir()
+		rootB := t.TempDir()
+
+		settingsA := NewWithRootPath(rootA, 1, 1000)
+		settingsB := NewWithRootPath(rootB, 1, 1000)
+
+		assert.Equal(t, filepath.Join(rootA, "data"), settingsA.GetHydraAbsDataFolderPath())
+		assert.Equal(t, filepath.Join(rootB, "settings"), settingsB.GetHydraAbsSettingsFolderPath())
+
+		pattern := name.New().Sanctuary("tenant").Realm("*").Swamp("*")
+		settingsA.RegisterPattern(pattern, false, 42, &FileSystemSettings{WriteIntervalSec: 1, MaxFileSizeByte: 8192}, nil)
+
+		swampName := name.New().Sanctuary("tenant").Realm("users").Swamp("alice")
+		assert.True(t, settingsA.GetBySwampName(swampName).GetCloseAfterIdle() == 42*time.Second)
+		assert.False(t, settingsB.GetBySwampName(swampName).GetCloseAfterIdle() == 42*time.Second)
+
+		// the pattern is persisted only under the root path of its settings
+		reloadedA := NewWithRootPath(rootA, 1, 1000)
+		reloadedB := NewWithRootPath(rootB, 1, 1000)
+		assert.True(t, reloadedA.GetBySwampName(swampName).GetCloseAfterIdle() == 42*time.Second)
+		assert.False(t, reloadedB.GetBySwampName(swampName).GetCloseAfterIdle() == 42*time.Second)
+
+	})
+
+}
+
+func TestSettings_WriteBatchSize(t *testing.T) {
+
+	t.Run("should write all waiting treasures at once by default", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		assert.Equal(t, 0, settingsInterface.GetWriteBatchSize())
+	})
+
+	t.Run("should set the write batch size", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.SetWriteBatchSize(5000)
+		assert.Equal(t, 5000, settingsInterface.GetWriteBatchSize())
+	})
+
+}
+
+func TestSettings_HydrationScheduler(t *testing.T) {
+
+	t.Run("should not limit the hydrations by default", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		assert.Nil(t, settingsInterface.GetHydrationScheduler())
+	})
+
+	t.Run("should share the scheduler between the settings", func(t *testing.T) {
+		scheduler := hydration.New(4)
+		settingsA := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsB := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsA.SetHydrationScheduler(scheduler)
+		settingsB.SetHydrationScheduler(scheduler)
+		assert.Same(t, scheduler, settingsA.GetHydrationScheduler())
+		assert.Same(t, scheduler, settingsB.GetHydrationScheduler())
+	})
+
+}
+
+func TestSettings_KeyLockCounters(t *testing.T) {
+
+	settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+	assert.Nil(t, settingsInterface.GetKeyLockCounters())
+
+	counters := &keylock.Counters{}
+	settingsInterface.SetKeyLockCounters(counters)
+	assert.Same(t, counters, settingsInterface.GetKeyLockCounters())
+
+}
+
+func TestSettings_FlushStallCounters(t *testing.T) {
+
+	settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+	assert.Nil(t, settingsInterface.GetFlushStallCounters())
+
+	counters := &flushstall.Counters{}
+	settingsInterface.SetFlushStallCounters(counters)
+	assert.Same(t, counters, settingsInterface.GetFlushStallCounters())
+
+}
+
+func TestSettings_Defaults(t *testing.T) {
+
+	swampName := name.New().Sanctuary("defaults").Realm("myrealm").Swamp("myswamp")
+
+	t.Run("should use the built-in settings for the unregistered swamps by default", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.SetDefaults(defaults.New(defaults.Values{CloseAfterIdleSec: 30, WriteIntervalSec: 3, MaxFileSizeByte: 1024}))
+		swampSetting := settingsInterface.GetBySwampName(swampName)
+		assert.Equal(t, 5*time.Second, swampSetting.GetCloseAfterIdle())
+		assert.Equal(t, int64(65536), swampSetting.GetMaxFileSizeByte())
+	})
+
+	t.Run("should apply the reloaded defaults to the unregistered swamps", func(t *testing.T) {
+		swampDefaults := defaults.New(defaults.Values{CloseAfterIdleSec: 30, WriteIntervalSec: 3, MaxFileSizeByte: 1024, ApplyToUnregisteredSwamps: true})
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.SetDefaults(swampDefaults)
+		assert.Same(t, swampDefaults, settingsInterface.GetDefaults())
+
+		swampSetting := settingsInterface.GetBySwampName(swampName)
+		assert.Equal(t, 30*time.Second, swampSetting.GetCloseAfterIdle())
+		assert.Equal(t, 3*time.Second, swampSetting.GetWriteInterval())
+		assert.Equal(t, int64(1024), swampSetting.GetMaxFileSizeByte())
+
+		swampDefaults.Set(defaults.Values{CloseAfterIdleSec: 60, WriteIntervalSec: 1, MaxFileSizeByte: 2048, ApplyToUnregisteredSwamps: true})
+		swampSetting = settingsInterface.GetBySwampName(swampName)
+		assert.Equal(t, 60*time.Second, swampSetting.GetCloseAfterIdle())
+		assert.Equal(t, int64(2048), swampSetting.GetMaxFileSizeByte())
+	})
+
+}
+
+func TestSettings_UpdatePattern(t *testing.T) {
+
+	pattern := name.New().Sanctuary("update").Realm("*").Swamp("*")
+	swampName := name.New().Sanctuary("update").Realm("users").Swamp("alice")
+
+	t.Run("should list the registered patterns sorted by their names", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.RegisterPattern(pattern, false, 10, &FileSystemSettings{WriteIntervalSec: 2, MaxFileSizeByte: 8192}, nil)
+		settingsInterface.RegisterPattern(name.New().Sanctuary("cache").Realm("*").Swamp("*"), true, 30, nil, nil)
+
+		patterns := settingsInterface.ListPatterns()
+		assert.Len(t, patterns, 2)
+		assert.Equal(t, "cache/*/*", patterns[0].NameCanonicalForm)
+		assert.True(t, patterns[0].InMemory)
+		assert.Equal(t, "update/*/*", patterns[1].NameCanonicalForm)
+		assert.Equal(t, int64(10), patterns[1].CloseAfterIdleSec)
+		assert.Equal(t, int64(2), patterns[1].WriteIntervalSec)
+		assert.Equal(t, int64(8192), patterns[1].MaxFileSizeByte)
+	})
+
+	t.Run("should update only the given settings and keep them after a restart", func(t *testing.T) {
+		rootPath := t.TempDir()
+		settingsInterface := NewWithRootPath(rootPath, 1, 1000)
+		settingsInterface.RegisterPattern(pattern, false, 10, &FileSystemSettings{WriteIntervalSec: 2, MaxFileSizeByte: 8192}, &PatternOptions{ValueIndex: true})
+
+		updated, err := settingsInterface.UpdatePattern(pattern, PatternUpdate{CloseAfterIdleSec: 60, MaxFileSizeByte: 65536})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(60), updated.CloseAfterIdleSec)
+		assert.Equal(t, int64(2), updated.WriteIntervalSec)
+		assert.Equal(t, int64(65536), updated.MaxFileSizeByte)
+
+		for _, s := range []Settings{settingsInterface, NewWithRootPath(rootPath, 1, 1000)} {
+			swampSetting := s.GetBySwampName(swampName)
+			assert.Equal(t, 60*time.Second, swampSetting.GetCloseAfterIdle())
+			assert.Equal(t, 2*time.Second, swampSetting.GetWriteInterval())
+			assert.Equal(t, int64(65536), swampSetting.GetMaxFileSizeByte())
+			assert.True(t, swampSetting.IsValueIndexed())
+		}
+	})
+
+	t.Run("should return an error for an unknown pattern", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		_, err := settingsInterface.UpdatePattern(pattern, PatternUpdate{CloseAfterIdleSec: 60})
+		assert.ErrorIs(t, err, ErrPatternNotFound)
+	})
+
+	t.Run("should not set the filesystem settings of an in-memory pattern", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.RegisterPattern(pattern, true, 10, nil, nil)
+		_, err := settingsInterface.UpdatePattern(pattern, PatternUpdate{WriteIntervalSec: 5})
+		assert.ErrorIs(t, err, ErrInMemoryPattern)
+
+		updated, err := settingsInterface.UpdatePattern(pattern, PatternUpdate{CloseAfterIdleSec: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), updated.CloseAfterIdleSec)
+	})
+
+}
+
+func TestSettings_ConflictingPattern(t *testing.T) {
+
+	pattern := name.New().Sanctuary("conflict").Realm("*").Swamp("*")
+	fss := &FileSystemSettings{WriteIntervalSec: 2, MaxFileSizeByte: 8192}
+
+	t.Run("should not conflict with the same settings", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.RegisterPattern(pattern, false, 10, fss, nil)
+		_, conflicts := settingsInterface.ConflictingPattern(pattern, false, 10, &FileSystemSettings{WriteIntervalSec: 2, MaxFileSizeByte: 8192})
+		assert.False(t, conflicts)
+	})
+
+	t.Run("should conflict with the other settings of the same pattern", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.RegisterPattern(pattern, false, 10, fss, &PatternOptions{RegisteredBy: "service-a"})
+		conflicting, conflicts := settingsInterface.ConflictingPattern(pattern, false, 10, &FileSystemSettings{WriteIntervalSec: 5, MaxFileSizeByte: 8192})
+		assert.True(t, conflicts)
+		assert.Equal(t, "conflict/*/*", conflicting.NameCanonicalForm)
+		assert.Equal(t, "service-a", conflicting.RegisteredBy)
+		assert.NotZero(t, conflicting.RegisteredAt)
+	})
+
+	t.Run("should conflict with an overlapping pattern", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.RegisterPattern(pattern, false, 10, fss, nil)
+		conflicting, conflicts := settingsInterface.ConflictingPattern(name.New().Sanctuary("conflict").Realm("users").Swamp("*"), true, 10, nil)
+		assert.True(t, conflicts)
+		assert.Equal(t, "conflict/*/*", conflicting.NameCanonicalForm)
+	})
+
+	t.Run("should not conflict with a pattern of other swamps", func(t *testing.T) {
+		settingsInterface := NewWithRootPath(t.TempDir(), 1, 1000)
+		settingsInterface.RegisterPattern(name.New().Sanctuary("conflict").Realm("users").Swamp("*"), false, 10, fss, nil)
+		_, conflicts := settingsInterface.ConflictingPattern(name.New().Sanctuary("conflict").Realm("orders").Swamp("*"), true, 10, nil)
+		assert.False(t, conflicts)
+		_, conflicts = settingsInterface.ConflictingPattern(name.New().Sanctuary("other").Realm("users").Swamp("*"), true, 10, nil)
+		assert.False(t, conflicts)
+	})
+
+	t.Run("should keep the registrant after a restart", func(t *testing.T) {
+		rootPath := t.TempDir()
+		settingsInterface := NewWithRootPath(rootPath, 1, 1000)
+		settingsInterface.RegisterPattern(pattern, false, 10, fss, &PatternOptions{RegisteredBy: "service-a"})
+		patterns := NewWithRootPath(rootPath, 1, 1000).ListPatterns()
+		if assert.Len(t, patterns, 1) {
+			assert.Equal(t, "service-a", patterns[0].RegisteredBy)
+			assert.NotZero(t, patterns[0].RegisteredAt)
+		}
+	})
+
 }
@@ -0,0 +1,150 @@
+// Package sliceset computes the union, the intersection and the difference of uint32 slices.
+// The set operations run server-side, so the clients do not need to download the slices of a reverse index just to
+// combine them.
+//
+// Example:
+//
+//	// the documents of "go" and "database", but not of "deprecated"
+//	both, _ := sliceset.Compute(sliceset.Intersection, [][]uint32{goDocs, databaseDocs})
+//	result, _ := sliceset.Compute(sliceset.Difference, [][]uint32{both, deprecatedDocs})
+package sliceset
+
+import "fmt"
+
+// Operator is a set operation
+type Operator int
+
+const (
+	// Union returns the values of any of the sets, in the order of their first appearance
+	Union Operator = iota
+	// Intersection returns the values of the first set which are in all other sets, in the order of the first set
+	Intersection
+	// Difference returns the values of the first set which are in none of the other sets, in the order of the first
+	// set
+	Difference
+)
+
+func (o Operator) String() string {
+	switch o {
+	case Union:
+		return "union"
+	case Intersection:
+		return "intersection"
+	case Difference:
+		return "difference"
+	}
+	return fmt.Sprintf("Operator(%d)", int(o))
+}
+
+// Compute returns the result of the operator on the sets. The result has no repeated values, even if a set has.
+// The sets are not modified. Returns an error if the operator is unknown.
+func Compute(operator Operator, sets [][]uint32) ([]uint32, error) {
+
+	switch operator {
+	case Union:
+		return union(sets), nil
+	case Intersection:
+		return intersection(sets), nil
+	case Difference:
+		return difference(sets), nil
+	}
+
+	return nil, fmt.Errorf("unknown set operator: %d", int(operator))
+
+}
+
+func union(sets [][]uint32) []uint32 {
+
+	total := 0
+	for _, set := range sets {
+		total += len(set)
+	}
+
+	seen := make(map[uint32]struct{}, total)
+	result := make([]uint32, 0, total)
+	for _, set := range sets {
+		for _, value := range set {
+			if _, ok := seen[value]; ok {
+				continue
+			}
+			seen[value] = struct{}{}
+			result = append(result, value)
+		}
+	}
+
+	return result
+
+}
+
+func intersection(sets [][]uint32) []uint32 {
+
+	if len(sets) == 0 {
+		return []uint32{}
+	}
+
+	// an empty set empties the intersection, so the other sets are not even indexed
+	for _, set := range sets {
+		if len(set) == 0 {
+			return []uint32{}
+		}
+	}
+
+	others := make([]map[uint32]struct{}, 0, len(sets)-1)
+	for _, set := range sets[1:] {
+		others = append(others, toMap(set))
+	}
+
+	return filter(sets[0], func(value uint32) bool {
+		for _, other := range others {
+			if _, ok := other[value]; !ok {
+				return false
+			}
+		}
+		return true
+	})
+
+}
+
+func difference(sets [][]uint32) []uint32 {
+
+	if len(sets) == 0 {
+		return []uint32{}
+	}
+
+	excluded := make(map[uint32]struct{})
+	for _, set := range sets[1:] {
+		for _, value := range set {
+			excluded[value] = struct{}{}
+		}
+	}
+
+	return filter(sets[0], func(value uint32) bool {
+		_, ok := excluded[value]
+		return !ok
+	})
+
+}
+
+// filter returns the values of the set accepted by the function, without the repeated values
+func filter(set []uint32, accept func(value uint32) bool) []uint32 {
+	seen := make(map[uint32]struct{}, len(set))
+	result := make([]uint32, 0, len(set))
+	for _, value := range set {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		if accept(value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func toMap(set []uint32) map[uint32]struct{} {
+	m := make(map[uint32]struct{}, len(set))
+	for _, value := range set {
+		m[value] = struct{}{}
+	}
+	return m
+}
@@ -0,0 +1,69 @@
+package sliceset
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+
+	sets := [][]uint32{
+		{5, 1, 3, 7},
+		{3, 9, 5},
+		{5, 3, 4},
+	}
+
+	t.Run("should return the union in the order of the first appearance", func(t *testing.T) {
+		result, err := Compute(Union, sets)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{5, 1, 3, 7, 9, 4}, result)
+	})
+
+	t.Run("should return the intersection in the order of the first set", func(t *testing.T) {
+		result, err := Compute(Intersection, sets)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{5, 3}, result)
+
+		result, err = Compute(Intersection, [][]uint32{{1, 2}, {}})
+		assert.NoError(t, err)
+		assert.Empty(t, result, "an empty set empties the intersection")
+	})
+
+	t.Run("should return the difference in the order of the first set", func(t *testing.T) {
+		result, err := Compute(Difference, sets)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{1, 7}, result)
+
+		result, err = Compute(Difference, sets[:1])
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{5, 1, 3, 7}, result, "a single set is returned as it is")
+	})
+
+	t.Run("should not repeat the values", func(t *testing.T) {
+		for _, operator := range []Operator{Union, Intersection, Difference} {
+			result, err := Compute(operator, [][]uint32{{2, 2, 1}, {1, 2, 2}})
+			assert.NoError(t, err)
+			assert.Len(t, result, map[Operator]int{Union: 2, Intersection: 2, Difference: 0}[operator], operator.String())
+		}
+	})
+
+	t.Run("should return empty results for no sets", func(t *testing.T) {
+		for _, operator := range []Operator{Union, Intersection, Difference} {
+			result, err := Compute(operator, nil)
+			assert.NoError(t, err)
+			assert.Empty(t, result, operator.String())
+		}
+	})
+
+	t.Run("should not modify the sets", func(t *testing.T) {
+		_, err := Compute(Union, sets)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{5, 1, 3, 7}, sets[0])
+	})
+
+	t.Run("should reject the unknown operators", func(t *testing.T) {
+		_, err := Compute(Operator(42), sets)
+		assert.Error(t, err)
+	})
+
+}
@@ -20,6 +20,8 @@ type Zeus interface {
 	GetSafeops() safeops.Safeops
 	// GetSettings the settings interface from the Zeus
 	GetSettings() settings.Settings
+	// GetFilesystem the filesystem interface from the Zeus
+	GetFilesystem() filesystem.Filesystem
 	// StartHydra the Hydra
 	StartHydra()
 	// StopHydra graceful stops the hydra
@@ -72,6 +74,10 @@ func (z *zeus) GetSettings() settings.Settings {
 	return z.settingsInterface
 }
 
+func (z *zeus) GetFilesystem() filesystem.Filesystem {
+	return z.filesystemInterface
+}
+
 func (z *zeus) GetSafeops() safeops.Safeops {
 	return z.safeopsInterface
 }
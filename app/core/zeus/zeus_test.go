@@ -85,7 +85,7 @@ func TestZeus_StartHydra(t *testing.T) {
 		settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 3600, &settings.FileSystemSettings{
 			WriteIntervalSec: 1,
 			MaxFileSizeByte:  8192, // 8KB
-		})
+		}, nil)
 
 		zeusInterface := New(settingsInterface, fsInterface)
 		zeusInterface.StartHydra()
@@ -137,7 +137,7 @@ func BenchmarkNew(b *testing.B) {
 	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 10, &settings.FileSystemSettings{
 		WriteIntervalSec: 10,
 		MaxFileSizeByte:  8192, // 8KB
-	})
+	}, nil)
 
 	zeusInterface := New(settingsInterface, fsInterface)
 	zeusInterface.StartHydra()
@@ -180,7 +180,7 @@ func BenchmarkRead(b *testing.B) {
 	settingsInterface.RegisterPattern(name.New().Sanctuary(sanctuaryForQuickTest).Realm("*").Swamp("*"), false, 10, &settings.FileSystemSettings{
 		WriteIntervalSec: 10,
 		MaxFileSizeByte:  8192, // 8KB
-	})
+	}, nil)
 
 	zeusInterface := New(settingsInterface, fsInterface)
 	zeusInterface.StartHydra()
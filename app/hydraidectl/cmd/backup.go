@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/backup"
+	"github.com/hydraide/hydraide/app/server/wal"
+	"github.com/spf13/cobra"
+)
+
+// backupFlags are the bucket flags of the backup commands. The empty flags are read from the same environment
+// variables as the server uses
+type backupFlags struct {
+	endpoint  string
+	region    string
+	bucket    string
+	prefix    string
+	accessKey string
+	secretKey string
+}
+
+var backupBucketFlags backupFlags
+
+var (
+	restoreID         string
+	restoreTarget     string
+	restorePatterns   []string
+	restoreTenant     string
+	restoreTempFolder string
+	restoreYes        bool
+	restoreUntil      string
+	restoreWAL        string
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "List and restore the S3 backups of HydrAIDE",
+	Long: `
+💾 The scheduled backups of the HydrAIDE server are uploaded to Amazon S3 or a S3 compatible storage (e.g. MinIO).
+
+The bucket is set by the flags, or by the same environment variables as the server uses:
+  HYDRAIDE_BACKUP_S3_ENDPOINT, HYDRAIDE_BACKUP_S3_REGION, HYDRAIDE_BACKUP_S3_BUCKET,
+  HYDRAIDE_BACKUP_S3_PREFIX, HYDRAIDE_BACKUP_S3_ACCESS_KEY, HYDRAIDE_BACKUP_S3_SECRET_KEY
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the backups in the bucket",
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		bucket, err := backupBucketFlags.open()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		backups, err := backup.ListBackups(ctx, bucket, backupBucketFlags.prefix)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			fmt.Println("There is no backup in the bucket.")
+			return nil
+		}
+
+		fmt.Printf("%-18s  %-10s  %12s\n", "ID", "STATUS", "SIZE")
+		for _, b := range backups {
+			status := "complete"
+			if !b.Complete {
+				status = "incomplete"
+			}
+			fmt.Printf("%-18s  %-10s  %12s\n", b.ID, status, formatBackupSize(b.Size))
+		}
+		return nil
+
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a backup into the root folder of a stopped HydrAIDE instance",
+	Long: `
+♻️ Restore downloads the archive of the backup, verifies it by its manifest, and extracts the swamps into the
+target folder. The files of the restored swamps are replaced, the other swamps are not touched.
+
+⏪ With --until, the swamps are restored to their state at the point in time: the newest complete backup before it
+is rebuilt, the write-ahead log of the instance is replayed on it until the point in time, and the changed
+treasures are written back to the target folder.
+
+⚠️ Stop the HydrAIDE instance before the restore, and start it again after it.
+
+Examples:
+  hydraidectl backup restore --target /var/hydraide
+  hydraidectl backup restore --target /var/hydraide --id 20261018T020000Z --pattern "users/*/*"
+  hydraidectl backup restore --target /var/hydraide --tenant acme
+  hydraidectl backup restore --target /var/hydraide --until 2026-10-18T09:41:00Z --pattern "users/*/*"
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		if restoreTarget == "" {
+			return errors.New("the --target folder is required, it is the HYDRAIDE_ROOT_PATH of the instance")
+		}
+		var until time.Time
+		if restoreUntil != "" {
+			if restoreID != "" {
+				return errors.New("the --id and the --until can not be used together, the point in time selects the backup")
+			}
+			parsed, err := time.Parse(time.RFC3339, restoreUntil)
+			if err != nil {
+				return fmt.Errorf("the --until must be a RFC 3339 time, e.g. 2026-10-18T09:41:00Z: %w", err)
+			}
+			until = parsed
+		}
+		var patterns []name.Name
+		for _, pattern := range restorePatterns {
+			swampPattern, err := backup.ParsePattern(pattern)
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, swampPattern)
+		}
+
+		bucket, err := backupBucketFlags.open()
+		if err != nil {
+			return err
+		}
+
+		if !restoreYes {
+			fmt.Printf("⚠️ The swamps of the backup will be replaced in %s. Is the HydrAIDE instance stopped? [y/N]: ", restoreTarget)
+			var answer string
+			_, _ = fmt.Scanln(&answer)
+			if answer != "y" && answer != "Y" {
+				fmt.Println("The restore is canceled.")
+				return nil
+			}
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		started := time.Now()
+		if !until.IsZero() {
+			return restorePointInTime(ctx, bucket, until, patterns, started)
+		}
+
+		result, err := backup.Restore(ctx, bucket, &backup.RestoreRequest{
+			Prefix:       backupBucketFlags.prefix,
+			ID:           restoreID,
+			TargetFolder: restoreTarget,
+			Patterns:     patterns,
+			Tenant:       restoreTenant,
+			TempFolder:   restoreTempFolder,
+		})
+		if err != nil {
+			return fmt.Errorf("the restore failed: %w", err)
+		}
+
+		if result.Swamps == 0 {
+			fmt.Printf("The backup %s has no matching swamp, nothing is restored.\n", result.Manifest.ID)
+			return nil
+		}
+		fmt.Printf("✅ %d swamps (%d files) of the backup %s are restored in %s.\n",
+			result.Swamps, result.Files, result.Manifest.ID, time.Since(started).Round(time.Millisecond))
+		return nil
+
+	},
+}
+
+// restorePointInTime restores the swamps of the target folder to the point in time, from the backups and the
+// write-ahead log of the instance
+func restorePointInTime(ctx context.Context, bucket backup.Bucket, until time.Time, patterns []name.Name, started time.Time) error {
+
+	walFolder := restoreWAL
+	if walFolder == "" {
+		walFolder = filepath.Join(restoreTarget, "wal")
+	}
+
+	hydras := &backup.LocalHydras{RootPath: restoreTarget}
+	// the hydras write the restored treasures to the files when they stop
+	defer hydras.Close()
+
+	recovery, err := backup.NewRecovery(&backup.RecoveryConfiguration{
+		Bucket:     bucket,
+		Prefix:     backupBucketFlags.prefix,
+		WAL:        wal.OpenReader(walFolder),
+		TempFolder: restoreTempFolder,
+		Hydra:      hydras.Open,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := recovery.RestorePointInTime(ctx, &backup.PointInTimeRequest{
+		Until:    until,
+		Patterns: patterns,
+		Tenant:   restoreTenant,
+	})
+	if err != nil {
+		return fmt.Errorf("the restore failed: %w", err)
+	}
+
+	fmt.Printf("✅ %d swamps are restored to %s from the backup %s and %d records of the write-ahead log in %s: %d treasures are written back, %d are deleted.\n",
+		result.Swamps, until.Format(time.RFC3339), result.Manifest.ID, result.Records, time.Since(started).Round(time.Millisecond), result.Restored, result.Deleted)
+	return nil
+
+}
+
+// open returns the bucket of the flags and the environment variables
+func (f *backupFlags) open() (backup.Bucket, error) {
+	for _, flag := range []struct {
+		value *string
+		env   string
+	}{
+		{&f.endpoint, "HYDRAIDE_BACKUP_S3_ENDPOINT"},
+		{&f.region, "HYDRAIDE_BACKUP_S3_REGION"},
+		{&f.bucket, "HYDRAIDE_BACKUP_S3_BUCKET"},
+		{&f.prefix, "HYDRAIDE_BACKUP_S3_PREFIX"},
+		{&f.accessKey, "HYDRAIDE_BACKUP_S3_ACCESS_KEY"},
+		{&f.secretKey, "HYDRAIDE_BACKUP_S3_SECRET_KEY"},
+	} {
+		if *flag.value == "" {
+			*flag.value = os.Getenv(flag.env)
+		}
+	}
+	return backup.NewS3Bucket(&backup.S3Configuration{
+		Endpoint:  f.endpoint,
+		Region:    f.region,
+		Bucket:    f.bucket,
+		AccessKey: f.accessKey,
+		SecretKey: f.secretKey,
+	})
+}
+
+// formatBackupSize formats the size in bytes with a binary unit
+func formatBackupSize(size int64) string {
+	switch {
+	case size >= GB:
+		return fmt.Sprintf("%.2f GB", float64(size)/GB)
+	case size >= MB:
+		return fmt.Sprintf("%.2f MB", float64(size)/MB)
+	case size >= KB:
+		return fmt.Sprintf("%.2f KB", float64(size)/KB)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func init() {
+
+	backupCmd.PersistentFlags().StringVar(&backupBucketFlags.endpoint, "endpoint", "", "the base URL of the storage, e.g. http://minio:9000")
+	backupCmd.PersistentFlags().StringVar(&backupBucketFlags.region, "region", "", "the region of the bucket (default us-east-1)")
+	backupCmd.PersistentFlags().StringVar(&backupBucketFlags.bucket, "bucket", "", "the bucket of the backups")
+	backupCmd.PersistentFlags().StringVar(&backupBucketFlags.prefix, "prefix", "", "the prefix of the keys of the backups")
+	backupCmd.PersistentFlags().StringVar(&backupBucketFlags.accessKey, "access-key", "", "the access key of the storage")
+	backupCmd.PersistentFlags().StringVar(&backupBucketFlags.secretKey, "secret-key", "", "the secret key of the storage")
+
+	backupRestoreCmd.Flags().StringVar(&restoreID, "id", "", "the ID of the backup (default the newest complete backup)")
+	backupRestoreCmd.Flags().StringVar(&restoreTarget, "target", "", "the root folder of the instance (HYDRAIDE_ROOT_PATH)")
+	backupRestoreCmd.Flags().StringArrayVar(&restorePatterns, "pattern", nil, "restore only the swamps of the sanctuary/realm/swamp pattern, \"*\" matches any part, repeatable")
+	backupRestoreCmd.Flags().StringVar(&restoreTenant, "tenant", "", "restore only the swamps of the tenant")
+	backupRestoreCmd.Flags().StringVar(&restoreTempFolder, "temp-folder", "", "the folder of the downloaded archive (default the system temp folder)")
+	backupRestoreCmd.Flags().StringVar(&restoreUntil, "until", "", "restore the swamps to their state at the RFC 3339 point in time, from the backups and the write-ahead log")
+	backupRestoreCmd.Flags().StringVar(&restoreWAL, "wal", "", "the folder of the write-ahead log of the instance (default the wal folder of the target)")
+	backupRestoreCmd.Flags().BoolVarP(&restoreYes, "yes", "y", false, "restore without the confirmation")
+
+	backupCmd.AddCommand(backupListCmd, backupRestoreCmd)
+	rootCmd.AddCommand(backupCmd)
+
+}
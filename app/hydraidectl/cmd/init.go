@@ -2,15 +2,21 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 
 	"github.com/hydraide/hydraide/app/hydraidectl/cmd/utils"
 	"github.com/hydraide/hydraide/app/hydraidectl/cmd/utils/certificate"
+	"github.com/hydraide/hydraide/app/server/config"
+	"github.com/hydraide/hydraide/app/server/platform"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // validatePort validates that the provided port string is a valid integer between 1 and 65535
@@ -152,6 +158,110 @@ func formatSize(bytes int64) string {
 	return fmt.Sprintf("%dB", bytes)
 }
 
+// starterConfig returns the content of the hydraide.yaml written by the wizard: the built-in defaults of the server,
+// overridden by the answers of the wizard. Every key is listed, so the file documents what can be configured.
+func starterConfig(envCfg EnvConfig) ([]byte, error) {
+
+	cfg := config.Default()
+
+	port, err := strconv.Atoi(envCfg.HydraidePort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s: %w", envCfg.HydraidePort, err)
+	}
+	healthCheckPort, err := strconv.Atoi(envCfg.HealthCheckPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health check port %s: %w", envCfg.HealthCheckPort, err)
+	}
+
+	cfg.Server.Port = port
+	cfg.Server.HealthCheckPort = healthCheckPort
+	cfg.Defaults.CloseAfterIdleSec = int64(envCfg.CloseAfterIdle)
+	cfg.Defaults.WriteIntervalSec = int64(envCfg.WriteInterval)
+	cfg.Defaults.FileSize = int64(envCfg.FileSize)
+	cfg.Logging.Level = envCfg.LogLevel
+	cfg.Logging.SystemResourceLogging = envCfg.SystemResourceLogging
+	cfg.Logging.GrpcServerErrorLogging = envCfg.GRPCServerErrorLogging
+	cfg.Logging.Graylog.Enabled = envCfg.GraylogEnabled
+	cfg.Logging.Graylog.Server = envCfg.GraylogServer
+	if envCfg.GraylogServiceName != "" {
+		cfg.Logging.Graylog.ServiceName = envCfg.GraylogServiceName
+	}
+	cfg.Limits.MaxMessageSize = int(envCfg.GRPCMaxMessageSize)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	content.WriteString("# HydrAIDE Configuration\n")
+	content.WriteString("# Generated by hydraidectl init. Every key can be overridden by its environment variable,\n")
+	content.WriteString("# see docs/how-to-install-hydraide.md\n\n")
+
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to encode the configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode the configuration: %w", err)
+	}
+
+	return content.Bytes(), nil
+
+}
+
+// clientAddress returns the address the SDK snippets connect to: the first domain or IP address added to the
+// certificate by the user, or localhost
+func clientAddress(cert CertConfig, port string) string {
+	host := "localhost"
+	for _, dns := range cert.DNS {
+		if dns != "localhost" {
+			host = dns
+			break
+		}
+	}
+	if host == "localhost" {
+		for _, ip := range cert.IP {
+			if ip != "127.0.0.1" && ip != "::1" {
+				host = ip
+				break
+			}
+		}
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// sdkSnippets returns the code of the Go and the Python SDK connecting to the installed server. The clients verify
+// the server by the CA certificate, so the clientCRT file must be copied to the machines of the clients.
+func sdkSnippets(address string, clientCRT string, maxMessageSize int64) string {
+
+	var snippets strings.Builder
+
+	snippets.WriteString("Go (github.com/hydraide/hydraide/sdk/go/hydraidego):\n\n")
+	fmt.Fprintf(&snippets, `	hydraClient := client.New([]*client.Server{
+		{Host: %q, FromIsland: 1, ToIsland: 1000, CertFilePath: %q},
+	}, 1000, %d)
+	if err := hydraClient.Connect(true); err != nil {
+		log.Fatalf("failed to connect to HydrAIDE: %%v", err)
+	}
+	defer hydraClient.CloseConnection()
+	h := hydraidego.New(hydraClient)
+`, address, filepath.ToSlash(clientCRT), maxMessageSize)
+
+	snippets.WriteString("\nPython (hydraidepy):\n\n")
+	fmt.Fprintf(&snippets, `	client = Client(
+		servers=[Server(host=%q, from_island=1, to_island=1000, cert_file_path=%q)],
+		all_islands=1000,
+		max_message_size=%d,
+	)
+	client.connect()
+	h = Hydraide(client)
+`, address, filepath.ToSlash(clientCRT), maxMessageSize)
+
+	return snippets.String()
+
+}
+
 type CertConfig struct {
 	CN  string
 	DNS []string
@@ -219,9 +329,14 @@ var initCmd = &cobra.Command{
 			ips := strings.Split(strings.TrimSpace(ipInput), ",")
 			for _, ip := range ips {
 				ip = strings.TrimSpace(ip)
-				if ip != "" {
-					cert.IP = append(cert.IP, ip)
+				if ip == "" {
+					continue
 				}
+				if net.ParseIP(ip) == nil {
+					fmt.Printf("⚠️ Skipping invalid IP address: %s\n", ip)
+					continue
+				}
+				cert.IP = append(cert.IP, ip)
 			}
 		}
 
@@ -267,13 +382,30 @@ var initCmd = &cobra.Command{
 			break
 		}
 
+		defaultBasePath := "/mnt/hydraide"
+		if runtime.GOOS != "linux" {
+			defaultBasePath = platform.DefaultRootPath()
+		}
+
 		fmt.Println("\n📁 Base Path for HydrAIDE")
 		fmt.Println("This is the main directory where HydrAIDE will store its core files.")
-		fmt.Print("Base path (default: /mnt/hydraide): ")
-		envCfg.HydraideBasePath, _ = reader.ReadString('\n')
-		envCfg.HydraideBasePath = strings.TrimSpace(envCfg.HydraideBasePath)
-		if envCfg.HydraideBasePath == "" {
-			envCfg.HydraideBasePath = "/mnt/hydraide"
+		for {
+			fmt.Printf("Base path (default: %s): ", defaultBasePath)
+			basePathInput, _ := reader.ReadString('\n')
+			basePathInput = strings.TrimSpace(basePathInput)
+			if basePathInput == "" {
+				basePathInput = defaultBasePath
+			}
+
+			// the same normalization as the server does, so ~, $VAR and %VAR% work here, too
+			basePath, err := platform.NormalizePath(basePathInput)
+			if err != nil {
+				fmt.Printf("❌ Invalid path: %v. Please try again.\n", err)
+				continue
+			}
+
+			envCfg.HydraideBasePath = basePath
+			break
 		}
 
 		// LOG_LEVEL
@@ -495,7 +627,7 @@ var initCmd = &cobra.Command{
 
 		// todo: start the instance installation process
 
-		// - todo: create the necessary directories
+		// - create the necessary directories
 
 		folders := []string{"certificate", "data", "settings"}
 		fmt.Println("📂 Creating application folders...", folders)
@@ -512,44 +644,93 @@ var initCmd = &cobra.Command{
 			fmt.Println(verbose)
 		}
 
-		// - todo: generate the TLS certificate
-		fmt.Println("🔒 Generating TLS certificate...")
-		certGen := certificate.New(cert.CN, cert.DNS, cert.IP)
-		if err = certGen.Generate(); err != nil {
-			fmt.Println("❌ Error generating TLS certificate:", err)
-			return
+		// - generate the TLS certificate, unless the user keeps the existing one
+		certificateDir := filepath.Join(envCfg.HydraideBasePath, "certificate")
+		clientCRTPath := filepath.Join(certificateDir, "client.crt")
+		certificatePaths := []string{clientCRTPath, filepath.Join(certificateDir, "server.crt"), filepath.Join(certificateDir, "server.key")}
+
+		generateCertificate := true
+		if _, err := os.Stat(certificatePaths[1]); err == nil {
+			fmt.Printf("\n⚠️  Found existing TLS certificate at: %s\n", certificateDir)
+			fmt.Println("   A new certificate needs a new client.crt on every client, too.")
+			fmt.Print("❓ Do you want to replace it with a new one? (y/n) [default: n]: ")
+			replace, _ := reader.ReadString('\n')
+			replace = strings.ToLower(strings.TrimSpace(replace))
+			generateCertificate = replace == "y" || replace == "yes"
 		}
-		fmt.Println("✅ TLS certificate generated successfully.")
-		clientCRT, serverCRT, serverKEY := certGen.Files()
-		fmt.Println("  • Client CRT: ", clientCRT)
-		fmt.Println("  • Server CRT: ", serverCRT)
-		fmt.Println("  • Server KEY: ", serverKEY)
-
-		// - todo: copy the server and client TLS certificate to the certificate directory
-
-		fmt.Println("📂 Copying TLS certificates to the certificate directory...")
-		fmt.Printf("  • Client CRT: From %s  to  %s \n", clientCRT, filepath.Join(envCfg.HydraideBasePath, "certificate", filepath.Base(clientCRT)))
-		if err := utils.MoveFile(clientCRT, filepath.Join(envCfg.HydraideBasePath, "certificate", filepath.Base(clientCRT))); err != nil {
-			fmt.Println("❌ Error copying client certificate:", err)
-			return
+
+		if generateCertificate {
+
+			for _, path := range certificatePaths {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					fmt.Println("❌ Error removing the old certificate file:", err)
+					return
+				}
+			}
+
+			fmt.Println("🔒 Generating TLS certificate...")
+			certGen := certificate.New(cert.CN, cert.DNS, cert.IP)
+			if err = certGen.Generate(); err != nil {
+				fmt.Println("❌ Error generating TLS certificate:", err)
+				return
+			}
+			fmt.Println("✅ TLS certificate generated successfully.")
+			dnsNames, ipAddresses := certGen.SANs()
+			fmt.Println("  • DNS SANs:   ", strings.Join(dnsNames, ", "))
+			fmt.Println("  • IP SANs:    ", strings.Join(ipAddresses, ", "))
+
+			// - move the server and client TLS certificate to the certificate directory
+			fmt.Println("📂 Copying TLS certificates to the certificate directory...")
+			clientCRT, serverCRT, serverKEY := certGen.Files()
+			for i, generated := range []string{clientCRT, serverCRT, serverKEY} {
+				fmt.Printf("  • %s: From %s  to  %s \n", filepath.Base(generated), generated, certificatePaths[i])
+				if err := utils.MoveFile(generated, certificatePaths[i]); err != nil {
+					fmt.Println("❌ Error copying the certificate file:", err)
+					return
+				}
+			}
+			_ = os.Remove(filepath.Dir(clientCRT))
+
+			fmt.Println("✅ TLS certificates copied successfully.")
+
+		} else {
+			fmt.Println("ℹ️  Keeping the existing TLS certificate")
 		}
-		fmt.Printf("  • Server CRT: From %s  to  %s \n", serverCRT, filepath.Join(envCfg.HydraideBasePath, "certificate", filepath.Base(serverCRT)))
-		if err := utils.MoveFile(serverCRT, filepath.Join(envCfg.HydraideBasePath, "certificate", filepath.Base(serverCRT))); err != nil {
-			fmt.Println("❌ Error copying server certificate:", err)
+
+		// ===========================
+		// CREATE THE STARTER CONFIG
+		// ===========================
+		configContent, err := starterConfig(envCfg)
+		if err != nil {
+			fmt.Println("❌ Error creating the configuration:", err)
 			return
 		}
-		fmt.Printf("  • Server KEY: From %s  to  %s \n", serverKEY, filepath.Join(envCfg.HydraideBasePath, "certificate", filepath.Base(serverKEY)))
-		if err := utils.MoveFile(serverKEY, filepath.Join(envCfg.HydraideBasePath, "certificate", filepath.Base(serverKEY))); err != nil {
-			fmt.Println("❌ Error copying server key:", err)
-			return
+
+		configPath := filepath.Join(envCfg.HydraideBasePath, config.FileName)
+		writeConfig := true
+		if _, err := os.Stat(configPath); err == nil {
+			fmt.Printf("\n⚠️  Found existing configuration file at: %s\n", configPath)
+			fmt.Print("❓ Do you want to overwrite this file? (y/n) [default: n]: ")
+			overwrite, _ := reader.ReadString('\n')
+			overwrite = strings.ToLower(strings.TrimSpace(overwrite))
+			writeConfig = overwrite == "y" || overwrite == "yes"
 		}
 
-		fmt.Println("✅ TLS certificates copied successfully.")
+		if writeConfig {
+			if err := os.WriteFile(configPath, configContent, 0644); err != nil {
+				fmt.Println("❌ Error writing the configuration file:", err)
+				return
+			}
+			fmt.Println("✅ Configuration file created at:", configPath)
+		} else {
+			fmt.Println("ℹ️  Keeping the existing configuration file")
+		}
 
-		// - todo: create the .env file (based on the .env_sample) to base path and fill in the values
 		// ===========================
 		// CREATE .ENV FILE
 		// ===========================
+		// the .env file only points the server to the base path, the configuration is in the hydraide.yaml, because
+		// the environment variables would override the keys of the config file
 		currentDir, err := os.Getwd()
 		if err != nil {
 			fmt.Println("❌ Error getting current directory:", err)
@@ -557,6 +738,7 @@ var initCmd = &cobra.Command{
 		}
 
 		envPath := filepath.Join(currentDir, ".env")
+		writeEnvFile := true
 
 		// Check if .env exists and warn user
 		if _, err := os.Stat(envPath); err == nil {
@@ -575,64 +757,30 @@ var initCmd = &cobra.Command{
 			fmt.Print("\n❓ Do you want to overwrite this file? (y/n) [default: y]: ")
 			overwrite, _ := reader.ReadString('\n')
 			overwrite = strings.ToLower(strings.TrimSpace(overwrite))
-
-			if overwrite == "n" || overwrite == "no" {
-				fmt.Println("ℹ️  Keeping existing .env file")
-				fmt.Println("✅ Proceeding with installation using existing configuration")
-				return
-			}
-
-			fmt.Println("🔄 Overwriting existing .env file...")
+			writeEnvFile = overwrite != "n" && overwrite != "no"
 		}
 
-		// Create or truncate the .env file
-		envFile, err := os.Create(envPath) // This automatically clears the file if it exists
-		if err != nil {
-			fmt.Println("❌ Error creating .env file:", err)
-			return
-		}
-		defer func() {
-			if err := envFile.Close(); err != nil {
-				fmt.Println("❌ Error closing .env file:", err)
-			} else {
-				fmt.Println("✅ .env file closed successfully.")
+		if writeEnvFile {
+			envContent := "# HydrAIDE Configuration\n" +
+				"# Generated by hydraidectl init, the settings of the server are in " + configPath + "\n\n" +
+				"HYDRAIDE_ROOT_PATH=" + envCfg.HydraideBasePath + "\n"
+			if err := os.WriteFile(envPath, []byte(envContent), 0644); err != nil {
+				fmt.Println("❌ Error writing .env file:", err)
+				return
 			}
-		}()
-
-		// Write all environment variables
-		writer := bufio.NewWriter(envFile)
-		writeEnv := func(key, value string) {
-			_, _ = writer.WriteString(fmt.Sprintf("%s=%s\n", key, value))
-		}
-
-		// Write header comment
-		_, _ = writer.WriteString("# HydrAIDE Configuration\n")
-		_, _ = writer.WriteString("# Generated automatically - DO NOT EDIT MANUALLY\n\n")
-
-		// Write all configuration values
-		writeEnv("LOG_LEVEL", envCfg.LogLevel)
-		writeEnv("LOG_TIME_FORMAT", "2006-01-02T15:04:05Z07:00")
-		writeEnv("SYSTEM_RESOURCE_LOGGING", strconv.FormatBool(envCfg.SystemResourceLogging))
-		writeEnv("GRAYLOG_ENABLED", strconv.FormatBool(envCfg.GraylogEnabled))
-		writeEnv("GRAYLOG_SERVER", envCfg.GraylogServer)
-		writeEnv("GRAYLOG_SERVICE_NAME", envCfg.GraylogServiceName)
-		writeEnv("GRPC_MAX_MESSAGE_SIZE", strconv.FormatInt(envCfg.GRPCMaxMessageSize, 10))
-		writeEnv("GRPC_SERVER_ERROR_LOGGING", strconv.FormatBool(envCfg.GRPCServerErrorLogging))
-		writeEnv("HYDRAIDE_ROOT_PATH", envCfg.HydraideBasePath)
-		writeEnv("HYDRAIDE_SERVER_PORT", envCfg.HydraidePort)
-		writeEnv("HYDRAIDE_DEFAULT_CLOSE_AFTER_IDLE", strconv.Itoa(envCfg.CloseAfterIdle))
-		writeEnv("HYDRAIDE_DEFAULT_WRITE_INTERVAL", strconv.Itoa(envCfg.WriteInterval))
-		writeEnv("HYDRAIDE_DEFAULT_FILE_SIZE", strconv.Itoa(envCfg.FileSize))
-		writeEnv("HEALTH_CHECK_PORT", envCfg.HealthCheckPort)
-
-		// Add final newline and flush
-		_, _ = writer.WriteString("\n")
-		if err := writer.Flush(); err != nil {
-			fmt.Println("❌ Error writing to .env file:", err)
-			return
+			fmt.Println("✅ .env file created/updated successfully at:", envPath)
+		} else {
+			fmt.Println("ℹ️  Keeping existing .env file")
 		}
 
-		fmt.Println("✅ .env file created/updated successfully at:", envPath)
+		// ===========================
+		// SDK CONNECTION SNIPPETS
+		// ===========================
+		fmt.Println("\n🔌 Connect from your application")
+		fmt.Printf("Copy %s to the machines of your clients, they verify the server with it.\n", clientCRTPath)
+		fmt.Println("The clients must connect by an address of the certificate.")
+		fmt.Println()
+		fmt.Println(sdkSnippets(clientAddress(cert, envCfg.HydraidePort), clientCRTPath, envCfg.GRPCMaxMessageSize))
 
 		// - todo: download the latest binary (or the tagged one) from the github releases
 		// - todo: create a service file based on the user's operating system
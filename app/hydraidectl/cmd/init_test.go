@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/hydraide/hydraide/app/server/config"
 )
 
 func TestValidatePort(t *testing.T) {
@@ -398,3 +403,93 @@ func TestFormatSize(t *testing.T) {
 		})
 	}
 }
+
+func TestStarterConfig(t *testing.T) {
+
+	envCfg := EnvConfig{
+		LogLevel:               "warn",
+		GRPCMaxMessageSize:     100 * MB,
+		GRPCServerErrorLogging: true,
+		CloseAfterIdle:         10,
+		WriteInterval:          5,
+		FileSize:               8192,
+		HydraidePort:           "4900",
+		HealthCheckPort:        "4901",
+	}
+
+	content, err := starterConfig(envCfg)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	// the server must load the generated file as it is
+	configPath := filepath.Join(t.TempDir(), config.FileName)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatalf("Failed to write the config file: %v", err)
+	}
+	t.Setenv(config.EnvConfigFile, configPath)
+
+	cfg, _, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected the server to load the starter config, but got: %v", err)
+	}
+	if cfg.Server.Port != 4900 || cfg.Server.HealthCheckPort != 4901 {
+		t.Errorf("Expected the ports 4900 and 4901, but got %d and %d", cfg.Server.Port, cfg.Server.HealthCheckPort)
+	}
+	if cfg.Logging.Level != "warn" || cfg.Defaults.WriteIntervalSec != 5 || cfg.Limits.MaxMessageSize != 100*MB {
+		t.Errorf("Expected the answers of the wizard in the config, but got %+v", cfg)
+	}
+
+	envCfg.GraylogEnabled = true
+	if _, err := starterConfig(envCfg); err == nil {
+		t.Error("Expected an error for the Graylog without a server address, but got none")
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		cert     CertConfig
+		expected string
+	}{
+		{
+			name:     "localhost only",
+			cert:     CertConfig{DNS: []string{"localhost"}, IP: []string{"127.0.0.1"}},
+			expected: "localhost:4900",
+		},
+		{
+			name:     "domain name first",
+			cert:     CertConfig{DNS: []string{"localhost", "hydraide.lan"}, IP: []string{"127.0.0.1", "10.0.0.5"}},
+			expected: "hydraide.lan:4900",
+		},
+		{
+			name:     "IPv6 address",
+			cert:     CertConfig{DNS: []string{"localhost"}, IP: []string{"127.0.0.1", "fd00::5"}},
+			expected: "[fd00::5]:4900",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := clientAddress(tt.cert, "4900")
+			if result != tt.expected {
+				t.Errorf("Expected '%s', but got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSdkSnippets(t *testing.T) {
+	snippets := sdkSnippets("hydraide.lan:4900", "/mnt/hydraide/certificate/client.crt", 10*MB)
+
+	for _, expected := range []string{
+		`{Host: "hydraide.lan:4900", FromIsland: 1, ToIsland: 1000, CertFilePath: "/mnt/hydraide/certificate/client.crt"}`,
+		`}, 1000, 10485760)`,
+		`Server(host="hydraide.lan:4900", from_island=1, to_island=1000, cert_file_path="/mnt/hydraide/certificate/client.crt")`,
+		`max_message_size=10485760,`,
+	} {
+		if !strings.Contains(snippets, expected) {
+			t.Errorf("Expected the snippets to contain '%s', but got:\n%s", expected, snippets)
+		}
+	}
+}
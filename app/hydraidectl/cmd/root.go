@@ -25,6 +25,8 @@ Try:
   hydraidectl stop
   hydraidectl destroy
   hydraidectl list
+  hydraidectl backup list
+  hydraidectl backup restore
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_ = cmd.Help()
@@ -11,12 +11,16 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
 type Certificate interface {
 	Generate() error
 	Files() (clientCRT, serverCRT, serverKEY string)
+	// SANs returns the DNS names and the IP addresses of the server certificate
+	SANs() (dns []string, ip []string)
 }
 
 type certificate struct {
@@ -29,89 +33,115 @@ type certificate struct {
 	serverKEY string
 }
 
+// New creates the generator of a self-signed CA and a server certificate signed by it. The server certificate is
+// valid for the given DNS names and IP addresses, and for localhost, 127.0.0.1, ::1, the hostname of the machine and
+// the name, if the name is a hostname. The clients must use an address of the certificate to connect.
 func New(name string, dns []string, ip []string) Certificate {
-	tempDir := os.TempDir()
+
+	dns = append(slices.Clone(dns), "localhost")
+	if hostname, err := os.Hostname(); err == nil {
+		dns = append(dns, hostname)
+	}
+	if isHostname(name) {
+		dns = append(dns, name)
+	}
+	ip = append(slices.Clone(ip), "127.0.0.1", "::1")
+
 	return &certificate{
-		name:      name,
-		dns:       dns,
-		ip:        ip,
-		tempDir:   tempDir,
-		clientCRT: filepath.Join(tempDir, "client.crt"),
-		serverCRT: filepath.Join(tempDir, "server.crt"),
-		serverKEY: filepath.Join(tempDir, "server.key"),
+		name: name,
+		dns:  unique(dns),
+		ip:   unique(ip),
 	}
+
 }
 
+// Generate generates the certificates into a private temporary folder:
+//   - client.crt: the certificate of the CA, the clients verify the server with it
+//   - server.crt and server.key: the certificate and the private key of the server
+//
+// The private key of the CA is not saved, so nobody can sign another certificate trusted by the clients. A new
+// server certificate, e.g. for a new address, needs a new CA, too.
 func (c *certificate) Generate() error {
+
+	var ips []net.IP
+	for _, ip := range c.ip {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return fmt.Errorf("invalid IP address in the certificate: %s", ip)
+		}
+		ips = append(ips, parsed)
+	}
+
+	tempDir, err := os.MkdirTemp("", "hydraide-certificate-")
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary folder of the certificates: %w", err)
+	}
+	c.tempDir = tempDir
+	c.clientCRT = filepath.Join(tempDir, "client.crt")
+	c.serverCRT = filepath.Join(tempDir, "server.crt")
+	c.serverKEY = filepath.Join(tempDir, "server.key")
+
 	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
 		return fmt.Errorf("failed to generate CA private key: %w", err)
 	}
 
+	caSerial, err := serialNumber()
+	if err != nil {
+		return err
+	}
+
 	caTemplate := x509.Certificate{
-		SerialNumber:          big.NewInt(1),
+		SerialNumber:          caSerial,
 		Subject:               pkix.Name{Country: []string{"HU"}, Organization: []string{"HydrAIDE"}, OrganizationalUnit: []string{"CLI"}, CommonName: "HydrAIDE Root CA"},
-		NotBefore:             time.Now(),
+		NotBefore:             time.Now().Add(-time.Hour),
 		NotAfter:              time.Now().AddDate(10, 0, 0),
 		IsCA:                  true,
 		BasicConstraintsValid: true,
-		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		MaxPathLenZero:        true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
 	}
 
 	caCertBytes, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
 	if err != nil {
 		return fmt.Errorf("failed to create CA cert: %w", err)
 	}
+	caCert, err := x509.ParseCertificate(caCertBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse CA cert: %w", err)
+	}
 
 	if err := writeCert(c.clientCRT, caCertBytes); err != nil {
 		return err
 	}
-	if err := writeKey(filepath.Join(c.tempDir, "client.key"), caKey); err != nil {
-		return err
-	}
 
 	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
 		return fmt.Errorf("failed to generate server private key: %w", err)
 	}
 
-	sn, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	serverSerial, err := serialNumber()
+	if err != nil {
+		return err
+	}
 
 	serverTemplate := x509.Certificate{
-		SerialNumber: sn,
+		SerialNumber: serverSerial,
 		Subject: pkix.Name{
 			Country:            []string{"HU"},
-			Province:           []string{"PlaceholderState"},
-			Locality:           []string{"PlaceholderCity"},
-			Organization:       []string{"PlaceholderOrg"},
-			OrganizationalUnit: []string{"PlaceholderUnit"},
+			Organization:       []string{"HydrAIDE"},
+			OrganizationalUnit: []string{"Server"},
 			CommonName:         c.name,
 		},
-		NotBefore:   time.Now(),
+		NotBefore:   time.Now().Add(-time.Hour),
 		NotAfter:    time.Now().AddDate(10, 0, 0),
-		KeyUsage:    x509.KeyUsageDigitalSignature,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
 		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:    c.dns,
+		IPAddresses: ips,
 	}
 
-	for _, dns := range c.dns {
-		serverTemplate.DNSNames = append(serverTemplate.DNSNames, dns)
-	}
-	for _, ip := range c.ip {
-		if parsed := net.ParseIP(ip); parsed != nil {
-			serverTemplate.IPAddresses = append(serverTemplate.IPAddresses, parsed)
-		}
-	}
-
-	caCert, err := readCert(c.clientCRT)
-	if err != nil {
-		return err
-	}
-	caPrivKey, err := readKey(filepath.Join(c.tempDir, "client.key"))
-	if err != nil {
-		return err
-	}
-
-	serverCertBytes, err := x509.CreateCertificate(rand.Reader, &serverTemplate, caCert, &serverKey.PublicKey, caPrivKey)
+	serverCertBytes, err := x509.CreateCertificate(rand.Reader, &serverTemplate, caCert, &serverKey.PublicKey, caKey)
 	if err != nil {
 		return fmt.Errorf("failed to create server cert: %w", err)
 	}
@@ -135,6 +165,49 @@ func (c *certificate) Files() (string, string, string) {
 	return c.clientCRT, c.serverCRT, c.serverKEY
 }
 
+func (c *certificate) SANs() ([]string, []string) {
+	return c.dns, c.ip
+}
+
+// isHostname returns true if the name can be a DNS name of the certificate, e.g. api.hydraide.local, but not an IP
+// address or a name with spaces
+func isHostname(name string) bool {
+	if name == "" || net.ParseIP(name) != nil {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// unique removes the empty and the duplicated values, keeping the order of the first occurrences
+func unique(values []string) []string {
+	var result []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func serialNumber() (*big.Int, error) {
+	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return sn, nil
+}
+
 func writeCert(path string, certBytes []byte) error {
 	certOut, err := os.Create(path)
 	if err != nil {
@@ -144,29 +217,12 @@ func writeCert(path string, certBytes []byte) error {
 	return pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
 }
 
+// writeKey writes the private key readable only by its owner
 func writeKey(path string, key *rsa.PrivateKey) error {
-	keyOut, err := os.Create(path)
+	keyOut, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
 	if err != nil {
 		return fmt.Errorf("failed to create key file: %w", err)
 	}
 	defer keyOut.Close()
 	return pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
 }
-
-func readCert(path string) (*x509.Certificate, error) {
-	certBytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	block, _ := pem.Decode(certBytes)
-	return x509.ParseCertificate(block.Bytes)
-}
-
-func readKey(path string) (*rsa.PrivateKey, error) {
-	keyBytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	block, _ := pem.Decode(keyBytes)
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
-}
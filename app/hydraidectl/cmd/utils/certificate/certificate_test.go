@@ -1,7 +1,12 @@
 package certificate
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -12,8 +17,45 @@ func TestCertificateGenerate(t *testing.T) {
 	assert.NoError(t, err, "Certificate generation should not return an error")
 
 	clientCrt, serverCrt, serverKey := cert.Files()
+	defer os.RemoveAll(filepath.Dir(clientCrt))
 	assert.FileExists(t, clientCrt, "File should exist: "+clientCrt)
 	assert.FileExists(t, serverCrt, "File should exist: "+serverCrt)
 	assert.FileExists(t, serverKey, "File should exist: "+serverKey)
 
+	t.Run("should sign the server certificate by the CA for every address", func(t *testing.T) {
+
+		roots := x509.NewCertPool()
+		roots.AddCert(parseCert(t, clientCrt))
+		server := parseCert(t, serverCrt)
+
+		for _, address := range []string{"localhost", "127.0.0.1", "::1", "test.hydraide.local", "api.hydraide.local", "192.168.1.10"} {
+			_, err := server.Verify(x509.VerifyOptions{DNSName: address, Roots: roots})
+			assert.NoError(t, err, "the certificate must be valid for "+address)
+		}
+
+		_, err := server.Verify(x509.VerifyOptions{DNSName: "other.hydraide.local", Roots: roots})
+		assert.Error(t, err)
+
+	})
+
+	t.Run("should keep the private key readable only by its owner", func(t *testing.T) {
+		info, err := os.Stat(serverKey)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("should return an error for an invalid IP address", func(t *testing.T) {
+		assert.Error(t, New("hydraide", nil, []string{"300.1.1.1"}).Generate())
+	})
+
+}
+
+func parseCert(t *testing.T, path string) *x509.Certificate {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(content)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
 }
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -92,10 +93,37 @@ func MoveFile(src, dst string) error {
 	}
 
 	// First try atomic rename (works within same filesystem)
-	err = os.Rename(src, dst)
+	if err = os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	// the temporary folder is often on another filesystem, e.g. tmpfs, so copy the file with its permissions
+	if err := copyFile(src, dst, srcInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", src, dst, err)
+	}
+
+	return os.Remove(src)
+}
+
+// copyFile copies the content of the src file to the new dst file
+func copyFile(src, dst string, perm os.FileMode) error {
+
+	in, err := os.Open(src)
 	if err != nil {
 		return err
 	}
+	defer in.Close()
 
-	return nil
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+
+	return out.Close()
 }
@@ -50,6 +50,10 @@ import (
 	"sync"
 )
 
+// HashFunction describes the hash function of GetFolderNumber for the authors of the SDKs, whose SDKs must compute
+// the same islands as the server
+const HashFunction = "xxhash64(sanctuary + realm + swamp, seed 0) % allIslands + 1, the parts concatenated without separators as UTF-8 bytes"
+
 type Name interface {
 	Sanctuary(sanctuaryID string) Name
 	Realm(realmName string) Name
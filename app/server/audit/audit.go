@@ -0,0 +1,440 @@
+// Package audit records the mutating RPCs of the HydrAIDE server to an append-only audit log, and queries the
+// records, so the questions like "who deleted this key, and when" can be answered.
+//
+// Every record tells who sent the request (the client ID, the IP address and the tenant), when, which operation on
+// which swamps and keys, and its result (the gRPC status code and the error message). The values of the treasures
+// are never recorded.
+//
+// The records are JSON lines in the audit folder of the server. The current file is audit.log. When it reaches the
+// max file size, it is renamed to audit-<rotation time>.log and a new audit.log is started. The rotated files are
+// kept forever by default, because an audit may need the old records, MaxFiles limits their number.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxFileSize is the size of the current file in bytes, above it is rotated
+	DefaultMaxFileSize = 100 * 1024 * 1024
+	// DefaultQueryLimit is the max number of the returned records if the filter does not set it
+	DefaultQueryLimit = 100
+	// MaxQueryLimit is the max number of the returned records of a query
+	MaxQueryLimit = 10000
+
+	currentFileName = "audit.log"
+	rotatedPrefix   = "audit-"
+	rotatedSuffix   = ".log"
+	// rotatedTimeLayout is the rotation time in the name of the rotated files, sortable as a string
+	rotatedTimeLayout = "20060102T150405.000000000Z"
+	// maxLineSize is the max size of a record when reading the files. A record has at most requestinfo.MaxKeys keys
+	maxLineSize = 16 * 1024 * 1024
+
+	recordsMetric     = "hydraide_audit_records_total"
+	writeErrorsMetric = "hydraide_audit_write_errors_total"
+)
+
+// Record is a mutating request recorded by the audit log
+type Record struct {
+	// Time is the arrival of the request
+	Time time.Time `json:"time"`
+	// Method is the name of the RPC, e.g. "Delete"
+	Method string `json:"method"`
+	// ClientID is the hydraide-client-id metadata of the request, the tenant ID of the tenants
+	ClientID string `json:"clientId,omitempty"`
+	// ClientIP is the IP address of the client
+	ClientIP string `json:"clientIp,omitempty"`
+	// Tenant is the ID of the authenticated tenant. Empty on the single-tenant servers
+	Tenant string `json:"tenant,omitempty"`
+	// SwampNames are the swamps of the request, at most requestinfo.MaxSwampNames
+	SwampNames []string `json:"swampNames,omitempty"`
+	// SwampCount is the number of the swamps of the request
+	SwampCount int `json:"swampCount"`
+	// Keys are the keys of the request, at most requestinfo.MaxKeys
+	Keys []string `json:"keys,omitempty"`
+	// KeyCount is the number of the keys of the request
+	KeyCount int `json:"keyCount"`
+	// Code is the gRPC status code of the result, e.g. "OK" or "NotFound"
+	Code string `json:"code"`
+	// Error is the error message of the failed request
+	Error string `json:"error,omitempty"`
+}
+
+// Filter selects the records of a query. The zero values match every record
+type Filter struct {
+	// From is the earliest time of the records, inclusive
+	From time.Time
+	// To is the latest time of the records, exclusive
+	To time.Time
+	// Method is the name of the RPC, e.g. "Delete"
+	Method string
+	// ClientID is the client ID of the records
+	ClientID string
+	// Tenant is the tenant of the records
+	Tenant string
+	// SwampName is a swamp of the records
+	SwampName string
+	// Key is a key of the records
+	Key string
+	// Limit is the max number of the returned records. Zero means DefaultQueryLimit, above MaxQueryLimit means
+	// MaxQueryLimit
+	Limit int
+}
+
+// Configuration is the configuration of the audit log
+type Configuration struct {
+	// Folder is the folder of the audit files. Empty means the audit folder under the root path of the server
+	Folder string
+	// MaxFileSize is the size of the current file in bytes, above it is rotated. Zero means DefaultMaxFileSize
+	MaxFileSize int64
+	// MaxFiles is the max number of the rotated files, the oldest ones are deleted above it. Zero means the rotated
+	// files are kept forever
+	MaxFiles int
+	// Metrics is the registry of the record and write error counters. Nil means the metrics are not exposed
+	Metrics metrics.Registry
+}
+
+// Log is the append-only audit log
+type Log interface {
+	// Append writes the record to the end of the log
+	Append(record *Record) error
+	// Query returns the records matching the filter, the newest first
+	Query(filter *Filter) ([]*Record, error)
+	// Close closes the current file. The log can not be used after it
+	Close() error
+}
+
+type fileLog struct {
+	configuration *Configuration
+	mu            sync.Mutex
+	file          *os.File
+	size          int64
+	records       metrics.Counter
+	writeErrors   metrics.Counter
+}
+
+// New opens the audit log in the folder of the configuration, and creates the folder if it does not exist
+func New(configuration *Configuration) (Log, error) {
+
+	if configuration.Folder == "" {
+		return nil, errors.New("the folder of the audit log is not set")
+	}
+	if configuration.MaxFileSize <= 0 {
+		configuration.MaxFileSize = DefaultMaxFileSize
+	}
+	if err := os.MkdirAll(configuration.Folder, 0700); err != nil {
+		return nil, fmt.Errorf("can not create the audit folder: %w", err)
+	}
+
+	registry := configuration.Metrics
+	if registry == nil {
+		registry = metrics.New()
+	}
+
+	l := &fileLog{
+		configuration: configuration,
+		records:       registry.Counter(recordsMetric, "Number of the records written to the audit log"),
+		writeErrors:   registry.Counter(writeErrorsMetric, "Number of the records that could not be written to the audit log"),
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+
+}
+
+func (l *fileLog) Append(record *Record) error {
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		l.writeErrors.Inc()
+		return fmt.Errorf("can not encode the audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		l.writeErrors.Inc()
+		return errors.New("the audit log is closed")
+	}
+
+	if l.size > 0 && l.size+int64(len(line)) > l.configuration.MaxFileSize {
+		if err := l.rotate(); err != nil {
+			l.writeErrors.Inc()
+			return err
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		l.writeErrors.Inc()
+		return fmt.Errorf("can not write the audit record: %w", err)
+	}
+
+	l.records.Inc()
+	return nil
+
+}
+
+func (l *fileLog) Query(filter *Filter) ([]*Record, error) {
+
+	if filter == nil {
+		filter = &Filter{}
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	if limit > MaxQueryLimit {
+		limit = MaxQueryLimit
+	}
+
+	files, err := l.snapshot(filter.From)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, f := range files {
+			_ = f.file.Close()
+		}
+	}()
+
+	// the files are read from the newest one, so the query stops as soon as the limit is reached
+	records := make([]*Record, 0)
+	for i := len(files) - 1; i >= 0 && len(records) < limit; i-- {
+		matching, err := readMatching(files[i], filter)
+		if err != nil {
+			return nil, err
+		}
+		for j := len(matching) - 1; j >= 0 && len(records) < limit; j-- {
+			records = append(records, matching[j])
+		}
+	}
+
+	return records, nil
+
+}
+
+func (l *fileLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// open opens the current file for appending
+func (l *fileLog) open() error {
+
+	file, err := os.OpenFile(filepath.Join(l.configuration.Folder, currentFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("can not open the audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("can not open the audit log: %w", err)
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+
+}
+
+// rotate renames the current file to a rotated file and starts a new current file. The caller must hold the lock
+func (l *fileLog) rotate() error {
+
+	if err := l.file.Close(); err != nil {
+		slog.Warn("can not close the audit log before the rotation", "error", err)
+	}
+	l.file = nil
+
+	rotatedName := rotatedPrefix + time.Now().UTC().Format(rotatedTimeLayout) + rotatedSuffix
+	if err := os.Rename(filepath.Join(l.configuration.Folder, currentFileName), filepath.Join(l.configuration.Folder, rotatedName)); err != nil {
+		// the records are still appended to the current file
+		slog.Error("can not rotate the audit log", "error", err)
+	}
+
+	if err := l.open(); err != nil {
+		return err
+	}
+
+	if l.configuration.MaxFiles > 0 {
+		rotated, err := l.rotatedFiles()
+		if err != nil {
+			slog.Warn("can not list the rotated audit files", "error", err)
+			return nil
+		}
+		for len(rotated) > l.configuration.MaxFiles {
+			if err := os.Remove(filepath.Join(l.configuration.Folder, rotated[0])); err != nil {
+				slog.Warn("can not delete the old audit file", "file", rotated[0], "error", err)
+			}
+			rotated = rotated[1:]
+		}
+	}
+
+	return nil
+
+}
+
+// rotatedFiles returns the names of the rotated files, the oldest first
+func (l *fileLog) rotatedFiles() ([]string, error) {
+
+	entries, err := os.ReadDir(l.configuration.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), rotatedPrefix) && strings.HasSuffix(entry.Name(), rotatedSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+
+}
+
+// openedFile is a file of the log opened for a query
+type openedFile struct {
+	file *os.File
+	// size is the size of the file when it was opened, the records appended later are not read
+	size int64
+}
+
+// snapshot opens the files of the log, the oldest first. The rotated files older than the from time are skipped.
+// The files are opened under the lock, so a rotation can not rename them between the listing and the opening, and
+// the query reads them without blocking the appends
+func (l *fileLog) snapshot(from time.Time) ([]openedFile, error) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rotated, err := l.rotatedFiles()
+	if err != nil {
+		return nil, fmt.Errorf("can not list the audit files: %w", err)
+	}
+
+	names := make([]string, 0, len(rotated)+1)
+	for _, name := range rotated {
+		// every record of a rotated file is older than its rotation time
+		rotatedAt, err := time.Parse(rotatedTimeLayout, strings.TrimSuffix(strings.TrimPrefix(name, rotatedPrefix), rotatedSuffix))
+		if err == nil && !from.IsZero() && rotatedAt.Before(from) {
+			continue
+		}
+		names = append(names, name)
+	}
+	names = append(names, currentFileName)
+
+	files := make([]openedFile, 0, len(names))
+	for _, name := range names {
+		file, err := os.Open(filepath.Join(l.configuration.Folder, name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			for _, f := range files {
+				_ = f.file.Close()
+			}
+			return nil, fmt.Errorf("can not open the audit file %s: %w", name, err)
+		}
+		size := l.size
+		if name != currentFileName {
+			if info, err := file.Stat(); err == nil {
+				size = info.Size()
+			}
+		}
+		files = append(files, openedFile{file: file, size: size})
+	}
+
+	return files, nil
+
+}
+
+// readMatching returns the records of the file matching the filter, in the order of the file. The lines that can
+// not be decoded, e.g. the last line of a crashed server, are skipped
+func readMatching(f openedFile, filter *Filter) ([]*Record, error) {
+
+	scanner := bufio.NewScanner(io.LimitReader(f.file, f.size))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var records []*Record
+	for scanner.Scan() {
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			continue
+		}
+		if filter.matches(record) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can not read the audit file %s: %w", f.file.Name(), err)
+	}
+
+	return records, nil
+
+}
+
+// matches returns true if the record matches every set field of the filter
+func (f *Filter) matches(record *Record) bool {
+	switch {
+	case !f.From.IsZero() && record.Time.Before(f.From):
+		return false
+	case !f.To.IsZero() && !record.Time.Before(f.To):
+		return false
+	case f.Method != "" && record.Method != f.Method:
+		return false
+	case f.ClientID != "" && record.ClientID != f.ClientID:
+		return false
+	case f.Tenant != "" && record.Tenant != f.Tenant:
+		return false
+	case f.SwampName != "" && !slices.Contains(record.SwampNames, f.SwampName):
+		return false
+	case f.Key != "" && !slices.Contains(record.Keys, f.Key):
+		return false
+	}
+	return true
+}
+
+// ForTenant returns the view of the log for the tenant: its queries return only the records of the tenant
+func ForTenant(log Log, tenantID string) Log {
+	return &tenantLog{Log: log, tenantID: tenantID}
+}
+
+type tenantLog struct {
+	Log
+	tenantID string
+}
+
+func (t *tenantLog) Query(filter *Filter) ([]*Record, error) {
+	tenantFilter := Filter{}
+	if filter != nil {
+		tenantFilter = *filter
+	}
+	tenantFilter.Tenant = t.tenantID
+	return t.Log.Query(&tenantFilter)
+}
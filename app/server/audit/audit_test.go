@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLog(t *testing.T) {
+
+	t.Run("should query the records with the filters, the newest first", func(t *testing.T) {
+
+		registry := metrics.New()
+		l, err := New(&Configuration{Folder: t.TempDir(), Metrics: registry})
+		require.NoError(t, err)
+		defer func() {
+			_ = l.Close()
+		}()
+
+		start := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+		require.NoError(t, l.Append(&Record{Time: start, Method: "Set", ClientID: "importer", SwampNames: []string{"users/profiles/alex"}, Keys: []string{"name"}, Code: "OK"}))
+		require.NoError(t, l.Append(&Record{Time: start.Add(time.Minute), Method: "Delete", ClientID: "admin", SwampNames: []string{"users/profiles/alex"}, Keys: []string{"name", "email"}, Code: "OK"}))
+		require.NoError(t, l.Append(&Record{Time: start.Add(2 * time.Minute), Method: "Delete", ClientID: "admin", SwampNames: []string{"users/profiles/peter"}, Keys: []string{"name"}, Code: "NotFound"}))
+
+		records, err := l.Query(nil)
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "NotFound", records[0].Code, "the newest record is the first")
+		assert.Equal(t, "Set", records[2].Method)
+
+		records, err = l.Query(&Filter{Method: "Delete", SwampName: "users/profiles/alex", Key: "name"})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "admin", records[0].ClientID)
+		assert.Equal(t, start.Add(time.Minute), records[0].Time)
+
+		records, err = l.Query(&Filter{From: start.Add(time.Minute), To: start.Add(2 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, records, 1, "the from is inclusive, the to is exclusive")
+		assert.Equal(t, "Delete", records[0].Method)
+
+		records, err = l.Query(&Filter{ClientID: "importer"})
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+
+		records, err = l.Query(&Filter{Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), "hydraide_audit_records_total 3")
+
+	})
+
+	t.Run("should keep the records after the reopening", func(t *testing.T) {
+
+		folder := t.TempDir()
+		l, err := New(&Configuration{Folder: folder})
+		require.NoError(t, err)
+		require.NoError(t, l.Append(&Record{Time: time.Now(), Method: "Destroy", Code: "OK"}))
+		require.NoError(t, l.Close())
+		assert.Error(t, l.Append(&Record{Time: time.Now(), Method: "Set"}), "the closed log can not be written")
+
+		l, err = New(&Configuration{Folder: folder})
+		require.NoError(t, err)
+		defer func() {
+			_ = l.Close()
+		}()
+		require.NoError(t, l.Append(&Record{Time: time.Now(), Method: "Set", Code: "OK"}))
+
+		records, err := l.Query(&Filter{})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "Destroy", records[1].Method)
+
+	})
+
+	t.Run("should rotate the files and delete the oldest ones", func(t *testing.T) {
+
+		folder := t.TempDir()
+		l, err := New(&Configuration{Folder: folder, MaxFileSize: 200, MaxFiles: 2})
+		require.NoError(t, err)
+		defer func() {
+			_ = l.Close()
+		}()
+
+		start := time.Now().UTC()
+		for i := 0; i < 10; i++ {
+			require.NoError(t, l.Append(&Record{Time: start.Add(time.Duration(i) * time.Second), Method: "Set", Keys: []string{fmt.Sprintf("key-%d", i)}, Code: "OK"}))
+			// the rotated files are named by the time of the rotation
+			time.Sleep(time.Millisecond)
+		}
+
+		entries, err := os.ReadDir(folder)
+		require.NoError(t, err)
+		rotated := 0
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), rotatedPrefix) {
+				rotated++
+			}
+		}
+		assert.Equal(t, 2, rotated)
+		assert.FileExists(t, filepath.Join(folder, currentFileName))
+
+		records, err := l.Query(&Filter{})
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+		assert.Less(t, len(records), 10, "the records of the deleted files are lost")
+		assert.Equal(t, []string{"key-9"}, records[0].Keys)
+		for i := 1; i < len(records); i++ {
+			assert.True(t, records[i].Time.Before(records[i-1].Time), "the records are the newest first across the files")
+		}
+
+	})
+
+	t.Run("should skip the broken lines", func(t *testing.T) {
+
+		folder := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(folder, currentFileName), []byte("{\"method\":\"Set\",\"code\":\"OK\"}\n{\"method\":\"Del"), 0600))
+
+		l, err := New(&Configuration{Folder: folder})
+		require.NoError(t, err)
+		defer func() {
+			_ = l.Close()
+		}()
+
+		records, err := l.Query(&Filter{})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "Set", records[0].Method)
+
+	})
+
+	t.Run("should query only the records of the tenant", func(t *testing.T) {
+
+		l, err := New(&Configuration{Folder: t.TempDir()})
+		require.NoError(t, err)
+		defer func() {
+			_ = l.Close()
+		}()
+
+		require.NoError(t, l.Append(&Record{Time: time.Now(), Method: "Set", Tenant: "acme", Code: "OK"}))
+		require.NoError(t, l.Append(&Record{Time: time.Now(), Method: "Set", Tenant: "globex", Code: "OK"}))
+
+		records, err := ForTenant(l, "acme").Query(&Filter{Tenant: "globex"})
+		require.NoError(t, err)
+		require.Len(t, records, 1, "the tenant can not query the records of an other tenant")
+		assert.Equal(t, "acme", records[0].Tenant)
+
+	})
+
+	t.Run("should require the folder", func(t *testing.T) {
+		_, err := New(&Configuration{})
+		assert.Error(t, err)
+	})
+
+}
@@ -0,0 +1,194 @@
+package audit
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	"github.com/hydraide/hydraide/app/server/requestinfo"
+	"github.com/hydraide/hydraide/app/server/tenancy"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+)
+
+// mutatingMethods are the RPCs that change the stored data or the settings of the swamps. Only they are recorded
+var mutatingMethods = map[string]struct{}{
+	hydrapb.HydraideService_RegisterSwamp_FullMethodName:          {},
+	hydrapb.HydraideService_DeRegisterSwamp_FullMethodName:        {},
+	hydrapb.HydraideService_UpdateSwampPattern_FullMethodName:     {},
+	hydrapb.HydraideService_Set_FullMethodName:                    {},
+	hydrapb.HydraideService_SetLargeValue_FullMethodName:          {},
+	hydrapb.HydraideService_ShiftExpiredTreasures_FullMethodName:  {},
+	hydrapb.HydraideService_LeaseExpiredTreasures_FullMethodName:  {},
+	hydrapb.HydraideService_AckLease_FullMethodName:               {},
+	hydrapb.HydraideService_NackLease_FullMethodName:              {},
+	hydrapb.HydraideService_Destroy_FullMethodName:                {},
+	hydrapb.HydraideService_DestroyMany_FullMethodName:            {},
+	hydrapb.HydraideService_Delete_FullMethodName:                 {},
+	hydrapb.HydraideService_Restore_FullMethodName:                {},
+	hydrapb.HydraideService_RevertTo_FullMethodName:               {},
+	hydrapb.HydraideService_Uint32SlicePush_FullMethodName:        {},
+	hydrapb.HydraideService_Uint32SliceDelete_FullMethodName:      {},
+	hydrapb.HydraideService_IncrementInt8_FullMethodName:          {},
+	hydrapb.HydraideService_IncrementInt16_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementInt32_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementInt64_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementUint8_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementUint16_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementUint32_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementUint64_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementFloat32_FullMethodName:       {},
+	hydrapb.HydraideService_IncrementFloat64_FullMethodName:       {},
+	hydrapb.HydraideService_SetIfLater_FullMethodName:             {},
+	hydrapb.HydraideService_AddDuration_FullMethodName:            {},
+	hydrapb.HydraideService_SetStringIf_FullMethodName:            {},
+	hydrapb.HydraideService_SetBoolIf_FullMethodName:              {},
+	hydrapb.HydraideService_Uint32SliceCombineInto_FullMethodName: {},
+	hydrapb.HydraideService_SetSwampAnnotation_FullMethodName:     {},
+	hydrapb.HydraideService_CompactSwamp_FullMethodName:           {},
+	hydrapb.HydraideService_PutBlob_FullMethodName:                {},
+	hydrapb.HydraideService_RefBlob_FullMethodName:                {},
+	hydrapb.HydraideService_CollectBlobGarbage_FullMethodName:     {},
+	hydrapb.HydraideService_RestorePointInTime_FullMethodName:     {},
+	hydrapb.HydraideService_SetClusterTopology_FullMethodName:     {},
+	hydrapb.HydraideService_SetIslandState_FullMethodName:         {},
+	hydrapb.HydraideService_ImportIsland_FullMethodName:           {},
+	hydrapb.HydraideService_ReloadDefaults_FullMethodName:         {},
+}
+
+// IsMutating returns true if the RPC changes the stored data or the settings of the swamps
+func IsMutating(fullMethod string) bool {
+	_, ok := mutatingMethods[fullMethod]
+	return ok
+}
+
+// UnaryServerInterceptor records the mutating unary RPCs with their results, including the requests rejected by
+// the later interceptors, e.g. the rate limiter. It must run after the authentication of the tenants
+func UnaryServerInterceptor(log Log) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if !IsMutating(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		started := time.Now()
+		resp, err := handler(ctx, req)
+
+		message, _ := req.(proto.Message)
+		write(log, newRecord(ctx, started, info.FullMethod, message, err))
+
+		return resp, err
+
+	}
+}
+
+// StreamServerInterceptor records the mutating streaming RPCs with their results. The swamp and the key of the
+// record are taken from the first message of the client. It must run after the authentication of the tenants
+func StreamServerInterceptor(log Log) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if !IsMutating(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		started := time.Now()
+		stream := &recordingStream{ServerStream: ss}
+		err := handler(srv, stream)
+
+		write(log, newRecord(ss.Context(), started, info.FullMethod, stream.firstMessage(), err))
+
+		return err
+
+	}
+}
+
+// write appends the record to the log. The request is already executed, so a failed write is logged, but it does
+// not fail the request
+func write(log Log, record *Record) {
+	if err := log.Append(record); err != nil {
+		slog.Error("can not write the audit record", "method", record.Method, "swampNames", record.SwampNames, "error", err)
+	}
+}
+
+// newRecord creates the record of the request. The message is nil if the request was not received
+func newRecord(ctx context.Context, started time.Time, fullMethod string, message proto.Message, err error) *Record {
+
+	_, method := requestinfo.SplitFullMethod(fullMethod)
+	record := &Record{
+		Time:     started.UTC(),
+		Method:   method,
+		ClientID: clientID(ctx),
+		ClientIP: clientIP(ctx),
+		Code:     status.Code(err).String(),
+	}
+	if tenantID, ok := tenancy.TenantFromContext(ctx); ok {
+		record.Tenant = tenantID
+	}
+	if err != nil {
+		record.Error = status.Convert(err).Message()
+	}
+
+	if message != nil {
+		summary := requestinfo.Summarize(message)
+		record.SwampNames = summary.SwampNames
+		record.SwampCount = summary.SwampCount
+		record.Keys = summary.Keys
+		record.KeyCount = summary.KeyCount
+	}
+
+	return record
+
+}
+
+// clientID returns the client ID metadata of the request, or an empty string if the client did not send it
+func clientID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ratelimit.MetadataClientID); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// clientIP returns the IP address of the client, or an empty string if it is unknown
+func clientIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if addr, ok := p.Addr.(*net.TCPAddr); ok {
+			return addr.IP.String()
+		}
+	}
+	return ""
+}
+
+// recordingStream keeps a copy of the first message received from the client
+type recordingStream struct {
+	grpc.ServerStream
+	mu    sync.Mutex
+	first proto.Message
+}
+
+func (s *recordingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if message, ok := m.(proto.Message); ok && s.first == nil {
+		// the first message carries the swamp and the key, its chunk is limited by the max message size
+		s.first = proto.Clone(message)
+	}
+	return nil
+}
+
+func (s *recordingStream) firstMessage() proto.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.first
+}
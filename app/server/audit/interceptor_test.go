@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+
+	l, err := New(&Configuration{Folder: t.TempDir()})
+	require.NoError(t, err)
+	defer func() {
+		_ = l.Close()
+	}()
+	interceptor := UnaryServerInterceptor(l)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ratelimit.MetadataClientID, "cleanup-job"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 5000}})
+
+	t.Run("should record the mutating requests with their results", func(t *testing.T) {
+
+		request := &hydrapb.DeleteRequest{Swamps: []*hydrapb.DeleteRequest_SwampKeys{
+			{IslandID: 1, SwampName: "users/profiles/alex", Keys: []string{"email"}},
+		}}
+		info := &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Delete_FullMethodName}
+		_, err := interceptor(ctx, request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "the key does not exist")
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err), "the error of the handler is returned as it is")
+
+		records, err := l.Query(&Filter{Key: "email"})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "Delete", records[0].Method)
+		assert.Equal(t, "cleanup-job", records[0].ClientID)
+		assert.Equal(t, "10.0.0.7", records[0].ClientIP)
+		assert.Equal(t, []string{"users/profiles/alex"}, records[0].SwampNames)
+		assert.Equal(t, 1, records[0].KeyCount)
+		assert.Equal(t, "NotFound", records[0].Code)
+		assert.Equal(t, "the key does not exist", records[0].Error)
+
+	})
+
+	t.Run("should not record the reads", func(t *testing.T) {
+
+		info := &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Get_FullMethodName}
+		_, err := interceptor(ctx, &hydrapb.GetRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &hydrapb.GetResponse{}, nil
+		})
+		require.NoError(t, err)
+
+		records, err := l.Query(&Filter{Method: "Get"})
+		require.NoError(t, err)
+		assert.Empty(t, records)
+
+	})
+
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+
+	l, err := New(&Configuration{Folder: t.TempDir()})
+	require.NoError(t, err)
+	defer func() {
+		_ = l.Close()
+	}()
+
+	stream := &fakeStream{
+		ctx: context.Background(),
+		messages: []*hydrapb.SetLargeValueRequest{
+			{IslandID: 4, SwampName: "files/documents/contract", KeyValue: &hydrapb.KeyValuePair{Key: "pdf"}, Chunk: []byte("first")},
+			{Chunk: []byte("second")},
+		},
+	}
+	info := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_SetLargeValue_FullMethodName, IsClientStream: true}
+
+	err = StreamServerInterceptor(l)(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+		for {
+			message := &hydrapb.SetLargeValueRequest{}
+			if err := ss.RecvMsg(message); err != nil {
+				return nil
+			}
+		}
+	})
+	require.NoError(t, err)
+
+	records, err := l.Query(&Filter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "SetLargeValue", records[0].Method)
+	assert.Equal(t, []string{"files/documents/contract"}, records[0].SwampNames)
+	assert.Equal(t, []string{"pdf"}, records[0].Keys, "the key is taken from the first message")
+	assert.Equal(t, "OK", records[0].Code)
+
+}
+
+// fakeStream returns the messages to the RecvMsg, then io.EOF
+type fakeStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*hydrapb.SetLargeValueRequest
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	if len(s.messages) == 0 {
+		return context.Canceled
+	}
+	target := m.(*hydrapb.SetLargeValueRequest)
+	target.IslandID = s.messages[0].IslandID
+	target.SwampName = s.messages[0].SwampName
+	target.KeyValue = s.messages[0].KeyValue
+	target.Chunk = s.messages[0].Chunk
+	s.messages = s.messages[1:]
+	return nil
+}
@@ -0,0 +1,332 @@
+package backup
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// ManifestVersion is the version of the manifest format
+	ManifestVersion = 1
+
+	manifestFileName = "manifest.json"
+	archiveFileName  = "archive.tar.zst"
+)
+
+// Manifest describes a complete backup. It is uploaded after the archive, so a backup without a manifest is an
+// incomplete one, and it is ignored by the restore
+type Manifest struct {
+	// Version is the version of the manifest format
+	Version int `json:"version"`
+	// ID is the creation time of the backup in the 20060102T150405Z format, the backups are ordered by it
+	ID string `json:"id"`
+	// CreatedAt is the start of the backup
+	CreatedAt time.Time `json:"createdAt"`
+	// Host is the hostname of the server
+	Host string `json:"host,omitempty"`
+	// Patterns are the swamp patterns of the backup
+	Patterns []string `json:"patterns"`
+	// HashFolderDepth and MaxFoldersPerLevel are the folder layout of the swamps of the server, the point-in-time
+	// restore opens the swamps of the archive with them
+	HashFolderDepth    int `json:"hashFolderDepth,omitempty"`
+	MaxFoldersPerLevel int `json:"maxFoldersPerLevel,omitempty"`
+	// Archive is the key of the archive in the bucket
+	Archive string `json:"archive"`
+	// ArchiveSize is the size of the archive in bytes
+	ArchiveSize int64 `json:"archiveSize"`
+	// ArchiveSHA256 is the hex encoded SHA256 of the archive
+	ArchiveSHA256 string `json:"archiveSha256"`
+	// Swamps are the swamps in the archive
+	Swamps []ManifestSwamp `json:"swamps"`
+}
+
+// ManifestSwamp is a swamp in the archive
+type ManifestSwamp struct {
+	// Name is the name of the swamp, sanctuary/realm/swamp
+	Name string `json:"name"`
+	// Tenant is the ID of the tenant of the swamp, empty for the swamps of the main hydra
+	Tenant string `json:"tenant,omitempty"`
+	// IslandID is the island of the swamp
+	IslandID uint64 `json:"islandId"`
+	// Folder is the slash separated path of the swamp folder relative to the root path of the server
+	Folder string `json:"folder"`
+	// Files are the files of the swamp folder
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a file in the archive
+type ManifestFile struct {
+	// Path is the slash separated path of the file relative to the root path of the server
+	Path string `json:"path"`
+	// Size is the size of the file in bytes
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded SHA256 of the file
+	SHA256 string `json:"sha256"`
+}
+
+// archiveWriter writes the files of the swamp folders into a zstd compressed tar archive
+type archiveWriter struct {
+	rootPath string
+	encoder  *zstd.Encoder
+	tw       *tar.Writer
+}
+
+func newArchiveWriter(w io.Writer, rootPath string) (*archiveWriter, error) {
+	encoder, err := zstd.NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveWriter{
+		rootPath: rootPath,
+		encoder:  encoder,
+		tw:       tar.NewWriter(encoder),
+	}, nil
+}
+
+// addFolder writes the regular files of the folder into the archive with their paths relative to the root path.
+// It returns the relative path of the folder and its files
+func (a *archiveWriter) addFolder(folderPath string) (string, []ManifestFile, error) {
+
+	relativeFolder, err := filepath.Rel(a.rootPath, folderPath)
+	if err != nil {
+		return "", nil, err
+	}
+	relativeFolder = filepath.ToSlash(relativeFolder)
+	if !isSafePath(relativeFolder) {
+		return "", nil, fmt.Errorf("the folder %s is not under the root path %s", folderPath, a.rootPath)
+	}
+
+	var files []ManifestFile
+
+	err = filepath.WalkDir(folderPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == folderPath {
+				// the swamp has not written anything yet
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(a.rootPath, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		file, err := a.addFile(filePath, relativePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	})
+
+	return relativeFolder, files, err
+
+}
+
+func (a *archiveWriter) addFile(filePath string, relativePath string) (ManifestFile, error) {
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return ManifestFile{}, err
+	}
+
+	if err := a.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     relativePath,
+		Size:     info.Size(),
+		Mode:     0600,
+		ModTime:  info.ModTime(),
+	}); err != nil {
+		return ManifestFile{}, err
+	}
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(a.tw, hash), f)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	if written != info.Size() {
+		return ManifestFile{}, fmt.Errorf("the file %s changed during the backup", filePath)
+	}
+
+	return ManifestFile{
+		Path:   relativePath,
+		Size:   written,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+
+}
+
+// Close finishes the tar stream and flushes the compressed data
+func (a *archiveWriter) Close() error {
+	return errors.Join(a.tw.Close(), a.encoder.Close())
+}
+
+// extractArchive extracts the files of the archive into the target folder, and verifies them by the manifest.
+// The filter selects the files to extract by their manifest entries, nil means all files. It returns the number of
+// the extracted files
+func extractArchive(r io.Reader, targetFolder string, manifest *Manifest, filter func(swamp ManifestSwamp) bool) (int, error) {
+
+	expected := make(map[string]ManifestFile)
+	var swamps []ManifestSwamp
+	for _, swamp := range manifest.Swamps {
+		if filter != nil && !filter(swamp) {
+			continue
+		}
+		if !isSafePath(swamp.Folder) {
+			return 0, fmt.Errorf("the manifest contains an unsafe folder: %q", swamp.Folder)
+		}
+		for _, file := range swamp.Files {
+			if !strings.HasPrefix(file.Path, swamp.Folder+"/") {
+				return 0, fmt.Errorf("the file %q is not in the folder of its swamp", file.Path)
+			}
+			expected[file.Path] = file
+		}
+		swamps = append(swamps, swamp)
+	}
+
+	decoder, err := zstd.NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+	defer decoder.Close()
+
+	extracted := 0
+	tr := tar.NewReader(decoder)
+	for {
+
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return extracted, fmt.Errorf("can not read the archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !isSafePath(header.Name) {
+			return extracted, fmt.Errorf("the archive contains an unsafe path: %q", header.Name)
+		}
+		file, ok := expected[header.Name]
+		if !ok {
+			continue
+		}
+
+		if err := extractFile(tr, filepath.Join(targetFolder, filepath.FromSlash(header.Name)), file); err != nil {
+			return extracted, err
+		}
+		delete(expected, header.Name)
+		extracted++
+
+	}
+
+	if len(expected) > 0 {
+		return extracted, fmt.Errorf("%d files of the manifest are missing from the archive", len(expected))
+	}
+
+	// the files written after the backup would be loaded with the restored ones, so they are removed
+	for _, swamp := range swamps {
+		if err := removeUnknownFiles(targetFolder, swamp); err != nil {
+			return extracted, err
+		}
+	}
+
+	return extracted, nil
+
+}
+
+// extractFile writes the file to a temporary file next to the target, and renames it after its size and hash are
+// verified, so a failed restore never leaves a half written file
+func extractFile(r io.Reader, targetPath string, file ManifestFile) error {
+
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(targetPath), ".restore-*")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		_ = os.Remove(tempPath)
+	}()
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tempFile, hash), r)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("can not write %s: %w", targetPath, err)
+	}
+
+	if written != file.Size || hex.EncodeToString(hash.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("the file %s of the archive does not match the manifest", file.Path)
+	}
+
+	return os.Rename(tempPath, targetPath)
+
+}
+
+// removeUnknownFiles deletes the files of the swamp folder that are not in the manifest of the swamp
+func removeUnknownFiles(targetFolder string, swamp ManifestSwamp) error {
+	known := make(map[string]bool, len(swamp.Files))
+	for _, file := range swamp.Files {
+		known[file.Path] = true
+	}
+	folderPath := filepath.Join(targetFolder, filepath.FromSlash(swamp.Folder))
+	return filepath.WalkDir(folderPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(targetFolder, filePath)
+		if err != nil {
+			return err
+		}
+		if known[filepath.ToSlash(relativePath)] {
+			return nil
+		}
+		return os.Remove(filePath)
+	})
+}
+
+// isSafePath returns true if the slash separated path is relative and stays under its root
+func isSafePath(p string) bool {
+	if p == "" || path.IsAbs(p) || strings.Contains(p, "\\") || filepath.IsAbs(filepath.FromSlash(p)) {
+		return false
+	}
+	cleaned := path.Clean(p)
+	return cleaned == p && cleaned != "." && cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
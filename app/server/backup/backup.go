@@ -0,0 +1,445 @@
+// Package backup uploads periodic backups of the swamps to Amazon S3 or a S3 compatible storage, e.g. MinIO, and
+// restores them.
+//
+// A backup is a zstd compressed tar archive of the folders of the swamps matching the configured patterns, and a
+// manifest with the SHA256 of the archive and of every file in it. The objects of a backup are stored under
+// <prefix>/<backup ID>/ in the bucket:
+//
+//	<prefix>/20261018T020000Z/archive.tar.zst
+//	<prefix>/20261018T020000Z/manifest.json
+//
+// The manifest is uploaded last, so a backup without a manifest is incomplete, e.g. the server stopped during the
+// upload. The restore ignores the incomplete backups, and the retention deletes them.
+//
+// The swamps are frozen one by one while their files are copied into the archive (see hydra.BackupSwamps), so
+// every swamp is saved in a consistent state, while the server keeps serving the requests.
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultInterval is the time between two backups if the configuration does not set it
+	DefaultInterval = 24 * time.Hour
+	// IDLayout is the time layout of the backup IDs, sortable as a string
+	IDLayout = "20060102T150405Z"
+
+	backupsMetric        = "hydraide_backups_total"
+	backupBytesMetric    = "hydraide_backup_uploaded_bytes_total"
+	lastBackupMetric     = "hydraide_backup_last_success_timestamp_seconds"
+	prunedBackupsMetric  = "hydraide_backups_pruned_total"
+	resultSuccess        = "success"
+	resultFailure        = "failure"
+	allSwampsPatternPath = "*/*/*"
+)
+
+// ErrBackupRunning is returned by Run if an other backup is running
+var ErrBackupRunning = errors.New("a backup is already running")
+
+// Target is a hydra whose swamps are backed up
+type Target struct {
+	// Tenant is the ID of the tenant of the hydra, empty for the main hydra
+	Tenant string
+	// Hydra is the hydra of the swamps
+	Hydra hydra.Hydra
+}
+
+// Configuration is the configuration of the backup scheduler
+type Configuration struct {
+	// Interval is the time between two backups. Zero means DefaultInterval
+	Interval time.Duration
+	// Patterns are the swamps of the backups, the sanctuary, the realm and the swamp can be "*". Empty means all
+	// swamps
+	Patterns []name.Name
+	// S3 is the bucket of the backups
+	S3 S3Configuration
+	// Prefix is the prefix of the keys of the backups in the bucket, e.g. "hydraide/production". Empty means the
+	// backups are stored in the root of the bucket
+	Prefix string
+	// KeepLast is the number of the newest backups kept by the retention. Zero means the backups are not deleted
+	// by their number
+	KeepLast int
+	// MaxAge is the age above the backups are deleted by the retention. Zero means the backups are not deleted by
+	// their age. The newest complete backup is never deleted
+	MaxAge time.Duration
+	// TempFolder is the folder of the archive until it is uploaded. Empty means the temporary folder of the system
+	TempFolder string
+	// RootPath is the root folder of the server, the paths in the archive are relative to it
+	RootPath string
+	// HashFolderDepth and MaxFoldersPerLevel are the folder layout of the swamps of the server, they are saved in
+	// the manifests for the point-in-time restore
+	HashFolderDepth    int
+	MaxFoldersPerLevel int
+	// Targets returns the hydras of the backup, the main hydra and the hydras of the tenants
+	Targets func() []Target
+	// Metrics is the registry of the backup counters. Nil means the metrics are not exposed
+	Metrics metrics.Registry
+}
+
+// Scheduler creates the backups periodically
+type Scheduler interface {
+	// Start creates a backup every interval in the background, until the context is done or Stop is called
+	Start(ctx context.Context)
+	// Run creates a backup now, uploads it, and applies the retention. It returns the manifest of the backup
+	Run(ctx context.Context) (*Manifest, error)
+	// Stop stops the scheduler, and waits for the running backup to finish or fail
+	Stop()
+}
+
+type scheduler struct {
+	configuration *Configuration
+	bucket        Bucket
+	running       atomic.Bool
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+	now           func() time.Time
+
+	succeeded     metrics.Counter
+	failed        metrics.Counter
+	uploadedBytes metrics.Counter
+	pruned        metrics.Counter
+	lastSuccess   atomic.Int64
+}
+
+// New creates the scheduler of the configuration
+func New(configuration *Configuration) (Scheduler, error) {
+
+	if configuration.RootPath == "" {
+		return nil, errors.New("the root path of the backup is not set")
+	}
+	if configuration.Targets == nil {
+		return nil, errors.New("the targets of the backup are not set")
+	}
+	if configuration.Interval <= 0 {
+		configuration.Interval = DefaultInterval
+	}
+	if configuration.KeepLast < 0 || configuration.MaxAge < 0 {
+		return nil, errors.New("the retention of the backups must not be negative")
+	}
+	if len(configuration.Patterns) == 0 {
+		configuration.Patterns = []name.Name{name.Load(allSwampsPatternPath)}
+	}
+
+	bucket, err := NewS3Bucket(&configuration.S3)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScheduler(configuration, bucket), nil
+
+}
+
+func newScheduler(configuration *Configuration, bucket Bucket) *scheduler {
+
+	registry := configuration.Metrics
+	if registry == nil {
+		registry = metrics.New()
+	}
+
+	s := &scheduler{
+		configuration: configuration,
+		bucket:        bucket,
+		now:           time.Now,
+		succeeded:     registry.Counter(backupsMetric, "Number of the backups", "result", resultSuccess),
+		failed:        registry.Counter(backupsMetric, "Number of the backups", "result", resultFailure),
+		uploadedBytes: registry.Counter(backupBytesMetric, "Number of the bytes of the uploaded backup archives"),
+		pruned:        registry.Counter(prunedBackupsMetric, "Number of the backups deleted by the retention"),
+	}
+	registry.GaugeFunc(lastBackupMetric, "Unix time of the last successful backup", func() float64 {
+		return float64(s.lastSuccess.Load())
+	})
+
+	return s
+
+}
+
+func (s *scheduler) Start(ctx context.Context) {
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.configuration.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// the errors are logged and counted by Run, the next backup tries again
+				_, _ = s.Run(ctx)
+			}
+		}
+	}()
+
+}
+
+func (s *scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *scheduler) Run(ctx context.Context) (*Manifest, error) {
+
+	if !s.running.CompareAndSwap(false, true) {
+		return nil, ErrBackupRunning
+	}
+	defer s.running.Store(false)
+
+	started := s.now()
+	manifest, err := s.backup(ctx, started)
+	if err != nil {
+		s.failed.Inc()
+		slog.Error("the backup failed", "error", err)
+		return nil, err
+	}
+
+	s.succeeded.Inc()
+	s.uploadedBytes.Add(uint64(manifest.ArchiveSize))
+	s.lastSuccess.Store(s.now().Unix())
+	slog.Info("the backup is uploaded", "id", manifest.ID, "swamps", len(manifest.Swamps),
+		"size", manifest.ArchiveSize, "duration", time.Since(started))
+
+	if err := s.prune(ctx, manifest.ID); err != nil {
+		// the backup itself is complete, the retention tries again after the next backup
+		slog.Warn("can not delete the old backups", "error", err)
+	}
+
+	return manifest, nil
+
+}
+
+// backup writes the archive into a temporary file, then uploads the archive and the manifest
+func (s *scheduler) backup(ctx context.Context, started time.Time) (*Manifest, error) {
+
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		ID:        started.UTC().Format(IDLayout),
+		CreatedAt: started.UTC(),
+		Swamps:    []ManifestSwamp{},
+
+		HashFolderDepth:    s.configuration.HashFolderDepth,
+		MaxFoldersPerLevel: s.configuration.MaxFoldersPerLevel,
+	}
+	manifest.Host, _ = os.Hostname()
+	for _, pattern := range s.configuration.Patterns {
+		manifest.Patterns = append(manifest.Patterns, pattern.Get())
+	}
+	manifest.Archive = objectKey(s.configuration.Prefix, manifest.ID, archiveFileName)
+
+	archiveFile, err := os.CreateTemp(s.configuration.TempFolder, "hydraide-backup-*.tar.zst")
+	if err != nil {
+		return nil, fmt.Errorf("can not create the temporary archive: %w", err)
+	}
+	defer func() {
+		_ = archiveFile.Close()
+		_ = os.Remove(archiveFile.Name())
+	}()
+
+	hash := sha256.New()
+	archive, err := newArchiveWriter(io.MultiWriter(archiveFile, hash), s.configuration.RootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range s.configuration.Targets() {
+		err := target.Hydra.BackupSwamps(ctx, s.configuration.Patterns, func(islandID uint64, swampName name.Name, folderPath string) error {
+			folder, files, err := archive.addFolder(folderPath)
+			if err != nil {
+				return fmt.Errorf("can not archive the swamp %s: %w", swampName.Get(), err)
+			}
+			if len(files) > 0 {
+				manifest.Swamps = append(manifest.Swamps, ManifestSwamp{
+					Name:     swampName.Get(),
+					Tenant:   target.Tenant,
+					IslandID: islandID,
+					Folder:   folder,
+					Files:    files,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			_ = archive.Close()
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("can not write the archive: %w", err)
+	}
+	info, err := archiveFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+	manifest.ArchiveSize = info.Size()
+	manifest.ArchiveSHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.bucket.Put(ctx, manifest.Archive, archiveFile, manifest.ArchiveSize); err != nil {
+		return nil, fmt.Errorf("can not upload the archive: %w", err)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bucket.Put(ctx, objectKey(s.configuration.Prefix, manifest.ID, manifestFileName), strings.NewReader(string(manifestJSON)), int64(len(manifestJSON))); err != nil {
+		return nil, fmt.Errorf("can not upload the manifest: %w", err)
+	}
+
+	return manifest, nil
+
+}
+
+// prune deletes the backups above the retention limits, and the incomplete backups older than the current one
+func (s *scheduler) prune(ctx context.Context, currentID string) error {
+
+	backups, err := ListBackups(ctx, s.bucket, s.configuration.Prefix)
+	if err != nil {
+		return err
+	}
+
+	maxAgeLimit := ""
+	if s.configuration.MaxAge > 0 {
+		maxAgeLimit = s.now().Add(-s.configuration.MaxAge).UTC().Format(IDLayout)
+	}
+
+	// the backups are ordered from the newest
+	complete := 0
+	for i := len(backups) - 1; i >= 0; i-- {
+
+		backup := backups[i]
+		if backup.ID >= currentID {
+			if backup.Complete {
+				complete++
+			}
+			continue
+		}
+
+		deleteBackup := false
+		if !backup.Complete {
+			deleteBackup = true
+		} else {
+			complete++
+			// the newest complete backup is never deleted
+			if complete > 1 {
+				deleteBackup = (s.configuration.KeepLast > 0 && complete > s.configuration.KeepLast) ||
+					(maxAgeLimit != "" && backup.ID < maxAgeLimit)
+			}
+		}
+		if !deleteBackup {
+			continue
+		}
+
+		// the manifest is deleted first, so a partly deleted backup is incomplete
+		sort.Slice(backup.Keys, func(i, j int) bool {
+			return path.Base(backup.Keys[i]) == manifestFileName && path.Base(backup.Keys[j]) != manifestFileName
+		})
+		for _, key := range backup.Keys {
+			if err := s.bucket.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+		s.pruned.Inc()
+		slog.Info("the backup is deleted by the retention", "id", backup.ID, "complete", backup.Complete)
+
+	}
+
+	return nil
+
+}
+
+// Backup is a backup in the bucket
+type Backup struct {
+	// ID is the creation time of the backup in the IDLayout format
+	ID string
+	// Complete is true if the manifest of the backup is uploaded
+	Complete bool
+	// Size is the size of all objects of the backup in bytes
+	Size int64
+	// Keys are the keys of the objects of the backup
+	Keys []string
+}
+
+// ListBackups returns the backups under the prefix of the bucket, ordered from the oldest
+func ListBackups(ctx context.Context, bucket Bucket, prefix string) ([]Backup, error) {
+
+	keyPrefix := objectKey(prefix, "")
+	objects, err := bucket.List(ctx, keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Backup)
+	var ids []string
+	for _, object := range objects {
+		id, objectName, ok := strings.Cut(strings.TrimPrefix(object.Key, keyPrefix), "/")
+		if !ok || strings.Contains(objectName, "/") {
+			// not an object of a backup
+			continue
+		}
+		if _, err := time.Parse(IDLayout, id); err != nil {
+			continue
+		}
+		backup, ok := byID[id]
+		if !ok {
+			backup = &Backup{ID: id}
+			byID[id] = backup
+			ids = append(ids, id)
+		}
+		backup.Size += object.Size
+		backup.Keys = append(backup.Keys, object.Key)
+		if objectName == manifestFileName {
+			backup.Complete = true
+		}
+	}
+
+	sort.Strings(ids)
+	backups := make([]Backup, 0, len(ids))
+	for _, id := range ids {
+		backups = append(backups, *byID[id])
+	}
+
+	return backups, nil
+
+}
+
+// ParsePattern parses a swamp pattern in the sanctuary/realm/swamp format, every part can be "*"
+func ParsePattern(pattern string) (name.Name, error) {
+	parts := strings.Split(pattern, "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return nil, fmt.Errorf("the swamp pattern must be sanctuary/realm/swamp, got %q", pattern)
+	}
+	return name.Load(pattern), nil
+}
+
+// objectKey joins the prefix and the parts into a key of the bucket
+func objectKey(prefix string, parts ...string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return strings.Join(parts, "/")
+	}
+	return prefix + "/" + strings.Join(parts, "/")
+}
@@ -0,0 +1,286 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/core/hydra/lock"
+	"github.com/hydraide/hydraide/app/core/safeops"
+	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestHydra(t *testing.T, rootPath string) hydra.Hydra {
+	settingsInterface := settings.NewWithRootPath(rootPath, 2, 10)
+	settingsInterface.RegisterPattern(name.New().Sanctuary("*").Realm("*").Swamp("*"), false, 60, &settings.FileSystemSettings{
+		WriteIntervalSec: 60,
+		MaxFileSizeByte:  8192,
+	}, nil)
+	hydraInterface := hydra.New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+	t.Cleanup(hydraInterface.GracefulStop)
+	return hydraInterface
+}
+
+func saveTreasure(t *testing.T, hydraInterface hydra.Hydra, swampName name.Name, key string, content string) {
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 1, swampName)
+	require.NoError(t, err)
+	treasureInterface := swampInterface.CreateTreasure(key)
+	guardID := treasureInterface.StartTreasureGuard(true)
+	treasureInterface.SetContentString(guardID, content)
+	treasureInterface.Save(guardID)
+	treasureInterface.ReleaseTreasureGuard(guardID)
+}
+
+func loadTreasure(t *testing.T, hydraInterface hydra.Hydra, swampName name.Name, key string) (string, bool) {
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 1, swampName)
+	require.NoError(t, err)
+	treasureInterface, err := swampInterface.GetTreasure(key)
+	if err != nil {
+		return "", false
+	}
+	content, err := treasureInterface.GetContentString()
+	require.NoError(t, err)
+	return content, true
+}
+
+func TestScheduler(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("should back up the matching swamps and restore them", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		mainHydra := newTestHydra(t, rootPath)
+		tenantHydra := newTestHydra(t, filepath.Join(rootPath, "tenants", "acme"))
+
+		users := name.New().Sanctuary("users").Realm("profiles").Swamp("alex")
+		cache := name.New().Sanctuary("cache").Realm("pages").Swamp("home")
+		orders := name.New().Sanctuary("orders").Realm("2026").Swamp("october")
+		saveTreasure(t, mainHydra, users, "email", "alex@example.com")
+		saveTreasure(t, mainHydra, cache, "html", "<html>")
+		saveTreasure(t, tenantHydra, orders, "order-1", "paid")
+
+		fake, server := newFakeS3(t, "backups")
+		s, err := New(&Configuration{
+			Patterns: []name.Name{name.New().Sanctuary("users").Realm("*").Swamp("*"), name.New().Sanctuary("orders").Realm("*").Swamp("*")},
+			S3:       S3Configuration{Endpoint: server.URL, Bucket: "backups", AccessKey: "access", SecretKey: "secret"},
+			Prefix:   "prod",
+			RootPath: rootPath,
+			Targets: func() []Target {
+				return []Target{{Hydra: mainHydra}, {Tenant: "acme", Hydra: tenantHydra}}
+			},
+		})
+		require.NoError(t, err)
+
+		manifest, err := s.Run(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, fake.objects, "prod/"+manifest.ID+"/archive.tar.zst")
+		assert.Contains(t, fake.objects, "prod/"+manifest.ID+"/manifest.json")
+		require.Len(t, manifest.Swamps, 2, "the cache is not backed up")
+		assert.Equal(t, users.Get(), manifest.Swamps[0].Name)
+		assert.Equal(t, orders.Get(), manifest.Swamps[1].Name)
+		assert.Equal(t, "acme", manifest.Swamps[1].Tenant)
+		assert.True(t, strings.HasPrefix(manifest.Swamps[1].Folder, "tenants/acme/"))
+
+		// the swamp changes after the backup, the restore brings back the backed up state
+		saveTreasure(t, mainHydra, users, "email", "changed@example.com")
+		mainHydra.GracefulStop()
+		tenantHydra.GracefulStop()
+
+		bucket := newTestBucket(t, server.URL, 0)
+		result, err := Restore(ctx, bucket, &RestoreRequest{Prefix: "prod", TargetFolder: rootPath})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.ID, result.Manifest.ID, "the newest backup is restored by default")
+		assert.Equal(t, 2, result.Swamps)
+		assert.Positive(t, result.Files)
+
+		restoredHydra := newTestHydra(t, rootPath)
+		content, ok := loadTreasure(t, restoredHydra, users, "email")
+		assert.True(t, ok)
+		assert.Equal(t, "alex@example.com", content)
+		content, ok = loadTreasure(t, restoredHydra, cache, "html")
+		assert.True(t, ok, "the swamps out of the backup are not touched")
+		assert.Equal(t, "<html>", content)
+
+	})
+
+	t.Run("should restore only the selected swamps of the tenant", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		mainHydra := newTestHydra(t, rootPath)
+		tenantHydra := newTestHydra(t, filepath.Join(rootPath, "tenants", "acme"))
+		saveTreasure(t, mainHydra, name.New().Sanctuary("users").Realm("profiles").Swamp("alex"), "email", "alex@example.com")
+		saveTreasure(t, tenantHydra, name.New().Sanctuary("users").Realm("profiles").Swamp("bob"), "email", "bob@example.com")
+
+		_, server := newFakeS3(t, "backups")
+		s, err := New(&Configuration{
+			S3:       S3Configuration{Endpoint: server.URL, Bucket: "backups", AccessKey: "access", SecretKey: "secret"},
+			RootPath: rootPath,
+			Targets: func() []Target {
+				return []Target{{Hydra: mainHydra}, {Tenant: "acme", Hydra: tenantHydra}}
+			},
+		})
+		require.NoError(t, err)
+		manifest, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Len(t, manifest.Swamps, 2, "all swamps are backed up by default")
+
+		targetFolder := t.TempDir()
+		result, err := Restore(ctx, newTestBucket(t, server.URL, 0), &RestoreRequest{
+			ID:           manifest.ID,
+			TargetFolder: targetFolder,
+			Tenant:       "acme",
+			Patterns:     []name.Name{name.New().Sanctuary("*").Realm("profiles").Swamp("*")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Swamps)
+
+		_, err = os.Stat(filepath.Join(targetFolder, "tenants", "acme"))
+		assert.NoError(t, err)
+		entries, err := os.ReadDir(targetFolder)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "only the tenant is restored")
+
+	})
+
+	t.Run("should refuse the archive that does not match its manifest", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		mainHydra := newTestHydra(t, rootPath)
+		saveTreasure(t, mainHydra, name.New().Sanctuary("users").Realm("profiles").Swamp("alex"), "email", "alex@example.com")
+
+		fake, server := newFakeS3(t, "backups")
+		s, err := New(&Configuration{
+			S3:       S3Configuration{Endpoint: server.URL, Bucket: "backups", AccessKey: "access", SecretKey: "secret"},
+			RootPath: rootPath,
+			Targets: func() []Target {
+				return []Target{{Hydra: mainHydra}}
+			},
+		})
+		require.NoError(t, err)
+		manifest, err := s.Run(ctx)
+		require.NoError(t, err)
+
+		fake.objects[manifest.Archive][len(fake.objects[manifest.Archive])-1] ^= 0xff
+
+		targetFolder := t.TempDir()
+		_, err = Restore(ctx, newTestBucket(t, server.URL, 0), &RestoreRequest{TargetFolder: targetFolder})
+		assert.ErrorContains(t, err, "does not match its manifest")
+		entries, _ := os.ReadDir(targetFolder)
+		assert.Empty(t, entries, "nothing is extracted from a corrupted archive")
+
+	})
+
+}
+
+func TestScheduler_Prune(t *testing.T) {
+
+	ctx := context.Background()
+	_, server := newFakeS3(t, "backups")
+	bucket := newTestBucket(t, server.URL, 0)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	put := func(id string, complete bool) {
+		require.NoError(t, bucket.Put(ctx, "prod/"+id+"/archive.tar.zst", strings.NewReader("archive"), 7))
+		if complete {
+			require.NoError(t, bucket.Put(ctx, "prod/"+id+"/manifest.json", strings.NewReader("{}"), 2))
+		}
+	}
+	ids := func() []string {
+		backups, err := ListBackups(ctx, bucket, "prod")
+		require.NoError(t, err)
+		var result []string
+		for _, backup := range backups {
+			result = append(result, backup.ID)
+		}
+		return result
+	}
+
+	put("20261010T000000Z", true)
+	put("20261015T000000Z", false)
+	put("20261016T000000Z", true)
+	put("20261017T000000Z", true)
+	put("20261018T000000Z", true)
+	// not a backup
+	require.NoError(t, bucket.Put(ctx, "prod/notes.txt", strings.NewReader("x"), 1))
+
+	s := newScheduler(&Configuration{Prefix: "prod", KeepLast: 3, MaxAge: 5 * 24 * time.Hour}, bucket)
+	s.now = func() time.Time { return now }
+
+	require.NoError(t, s.prune(ctx, "20261018T000000Z"))
+	assert.Equal(t, []string{"20261016T000000Z", "20261017T000000Z", "20261018T000000Z"}, ids(),
+		"the oldest backup is out of the age limit, the incomplete one is deleted")
+	assert.Equal(t, uint64(2), s.pruned.Value())
+
+	s.configuration.KeepLast = 1
+	require.NoError(t, s.prune(ctx, "20261018T000000Z"))
+	assert.Equal(t, []string{"20261018T000000Z"}, ids())
+
+	// the newest complete backup is kept even if it is too old
+	s.now = func() time.Time { return now.AddDate(1, 0, 0) }
+	require.NoError(t, s.prune(ctx, "20261018T000000Z"))
+	assert.Equal(t, []string{"20261018T000000Z"}, ids())
+
+}
+
+func TestExtractArchive(t *testing.T) {
+
+	archive := func(entries map[string]string) []byte {
+		var buffer bytes.Buffer
+		encoder, err := zstd.NewWriter(&buffer)
+		require.NoError(t, err)
+		tw := tar.NewWriter(encoder)
+		for entryName, content := range entries {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: entryName, Size: int64(len(content)), Mode: 0600}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, encoder.Close())
+		return buffer.Bytes()
+	}
+
+	t.Run("should reject the paths out of the target folder", func(t *testing.T) {
+		manifest := &Manifest{Swamps: []ManifestSwamp{{Name: "a/b/c", Folder: "data", Files: []ManifestFile{{Path: "data/../../evil"}}}}}
+		_, err := extractArchive(bytes.NewReader(archive(map[string]string{"data/../../evil": "x"})), t.TempDir(), manifest, nil)
+		assert.ErrorContains(t, err, "unsafe path")
+
+		manifest = &Manifest{Swamps: []ManifestSwamp{{Name: "a/b/c", Folder: "../data"}}}
+		_, err = extractArchive(bytes.NewReader(archive(nil)), t.TempDir(), manifest, nil)
+		assert.ErrorContains(t, err, "unsafe folder")
+	})
+
+	t.Run("should keep the existing file if the file of the archive is corrupted", func(t *testing.T) {
+		targetFolder := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(targetFolder, "data"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(targetFolder, "data", "chunk"), []byte("current"), 0600))
+
+		manifest := &Manifest{Swamps: []ManifestSwamp{{Name: "a/b/c", Folder: "data", Files: []ManifestFile{{Path: "data/chunk", Size: 6, SHA256: "0000"}}}}}
+		_, err := extractArchive(bytes.NewReader(archive(map[string]string{"data/chunk": "backup"})), targetFolder, manifest, nil)
+		assert.ErrorContains(t, err, "does not match the manifest")
+
+		content, err := os.ReadFile(filepath.Join(targetFolder, "data", "chunk"))
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(content))
+	})
+
+}
+
+func TestParsePattern(t *testing.T) {
+	_, err := ParsePattern("users/*/*")
+	assert.NoError(t, err)
+	for _, pattern := range []string{"users", "users/*", "users//x", "a/b/c/d"} {
+		_, err := ParsePattern(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
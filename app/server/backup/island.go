@@ -0,0 +1,205 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/name"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrIslandNotEmpty is returned by ImportIsland if the target server already has files of the Island
+var ErrIslandNotEmpty = errors.New("the island already has files on the server")
+
+// IslandFolders are the folders of an Island on a server, the archive of the Island is relative to the root path
+type IslandFolders struct {
+	// RootPath is the root folder of the server
+	RootPath string
+	// SwampFolder is the folder of the Swamps of the Island, under the data folder
+	SwampFolder string
+	// BlobFolder is the folder of the blobs of the Island, under the blob folder
+	BlobFolder string
+	// HashFolderDepth and MaxFoldersPerLevel are the folder layout of the Swamps. The folders of the Swamps are
+	// derived from their names by them, so they must be the same on both servers of a migration
+	HashFolderDepth    int
+	MaxFoldersPerLevel int
+}
+
+// HasFiles returns true if any folder of the Island contains a file
+func (f IslandFolders) HasFiles() (bool, error) {
+	for _, folder := range []string{f.SwampFolder, f.BlobFolder} {
+		found := false
+		err := filepath.WalkDir(folder, func(filePath string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && filePath == folder {
+					return filepath.SkipAll
+				}
+				return err
+			}
+			if !d.IsDir() {
+				found = true
+				return filepath.SkipAll
+			}
+			return nil
+		})
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// Remove deletes the folders of the Island with all of their files
+func (f IslandFolders) Remove() error {
+	return errors.Join(os.RemoveAll(f.SwampFolder), os.RemoveAll(f.BlobFolder))
+}
+
+// ExportIsland writes the files of the Swamps and the blobs of the Island into a zstd compressed tar archive, for
+// the migration of the Island to an other server. The Swamps are frozen one by one while their files are archived
+// (see hydra.ExportIsland), the blobs are archived after them. It returns the manifest of the archive, where the
+// blobs are an entry without a name.
+//
+// The writes of the Island must be stopped before the export, otherwise the archive is a consistent copy of every
+// Swamp, but the Swamps changed after their export are not in it.
+func ExportIsland(ctx context.Context, h hydra.Hydra, islandID uint64, folders IslandFolders, w io.Writer) (*Manifest, error) {
+
+	started := time.Now().UTC()
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		ID:        started.Format(IDLayout),
+		CreatedAt: started,
+		Swamps:    []ManifestSwamp{},
+
+		HashFolderDepth:    folders.HashFolderDepth,
+		MaxFoldersPerLevel: folders.MaxFoldersPerLevel,
+	}
+	manifest.Host, _ = os.Hostname()
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	archive, err := newArchiveWriter(io.MultiWriter(w, hash, counter), folders.RootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	add := func(swampName string, folderPath string) error {
+		folder, files, err := archive.addFolder(folderPath)
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			manifest.Swamps = append(manifest.Swamps, ManifestSwamp{
+				Name:     swampName,
+				IslandID: islandID,
+				Folder:   folder,
+				Files:    files,
+			})
+		}
+		return nil
+	}
+
+	err = h.ExportIsland(ctx, islandID, func(swampName name.Name, folderPath string) error {
+		if err := add(swampName.Get(), folderPath); err != nil {
+			return fmt.Errorf("can not archive the swamp %s: %w", swampName.Get(), err)
+		}
+		return nil
+	})
+	if err == nil {
+		if err = add("", folders.BlobFolder); err != nil {
+			err = fmt.Errorf("can not archive the blobs of the island %d: %w", islandID, err)
+		}
+	}
+	if err != nil {
+		_ = archive.Close()
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("can not write the archive: %w", err)
+	}
+	manifest.ArchiveSize = counter.written
+	manifest.ArchiveSHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	return manifest, nil
+
+}
+
+// ImportIsland verifies the archive of ExportIsland by its manifest, and extracts it into the folders of the
+// Island. The folders of the Island must be empty, and every entry of the manifest must belong to the Island and to
+// its folders, so an archive can not overwrite other Islands. It returns the number of the extracted files.
+func ImportIsland(archive io.ReadSeeker, islandID uint64, folders IslandFolders, manifest *Manifest) (int, error) {
+
+	if manifest.HashFolderDepth != folders.HashFolderDepth || manifest.MaxFoldersPerLevel != folders.MaxFoldersPerLevel {
+		return 0, fmt.Errorf("the folder layout of the archive (depth %d, %d folders per level) differs from the layout of the server (depth %d, %d folders per level)",
+			manifest.HashFolderDepth, manifest.MaxFoldersPerLevel, folders.HashFolderDepth, folders.MaxFoldersPerLevel)
+	}
+
+	allowed := make([]string, 0, 2)
+	for _, folder := range []string{folders.SwampFolder, folders.BlobFolder} {
+		relativeFolder, err := filepath.Rel(folders.RootPath, folder)
+		if err != nil {
+			return 0, err
+		}
+		allowed = append(allowed, filepath.ToSlash(relativeFolder))
+	}
+	for _, swamp := range manifest.Swamps {
+		if swamp.IslandID != islandID {
+			return 0, fmt.Errorf("the swamp %s of the archive belongs to the island %d", swamp.Name, swamp.IslandID)
+		}
+		inIsland := false
+		for _, folder := range allowed {
+			if swamp.Folder == folder || strings.HasPrefix(swamp.Folder, folder+"/") {
+				inIsland = true
+			}
+		}
+		if !isSafePath(swamp.Folder) || !inIsland {
+			return 0, fmt.Errorf("the folder %q of the archive is not a folder of the island %d", swamp.Folder, islandID)
+		}
+	}
+
+	hasFiles, err := folders.HasFiles()
+	if err != nil {
+		return 0, err
+	}
+	if hasFiles {
+		return 0, fmt.Errorf("%w: island %d", ErrIslandNotEmpty, islandID)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, archive)
+	if err != nil {
+		return 0, fmt.Errorf("can not read the archive: %w", err)
+	}
+	if size != manifest.ArchiveSize || hex.EncodeToString(hash.Sum(nil)) != manifest.ArchiveSHA256 {
+		return 0, fmt.Errorf("the archive of the island %d does not match its manifest", islandID)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	extracted, err := extractArchive(archive, folders.RootPath, manifest, nil)
+	if err != nil {
+		// a partly imported island would be served as a complete one after a retry
+		return extracted, errors.Join(err, folders.Remove())
+	}
+
+	return extracted, nil
+
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.written += int64(len(p))
+	return len(p), nil
+}
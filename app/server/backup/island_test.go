@@ -0,0 +1,90 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func islandFolders(rootPath string, islandID string) IslandFolders {
+	return IslandFolders{
+		RootPath:           rootPath,
+		SwampFolder:        filepath.Join(rootPath, "data", islandID),
+		BlobFolder:         filepath.Join(rootPath, "blobs", islandID),
+		HashFolderDepth:    2,
+		MaxFoldersPerLevel: 10,
+	}
+}
+
+func TestIsland(t *testing.T) {
+
+	users := name.New().Sanctuary("users").Realm("profiles").Swamp("alex")
+
+	sourcePath := t.TempDir()
+	sourceHydra := newTestHydra(t, sourcePath)
+	saveTreasure(t, sourceHydra, users, "email", "alex@example.com")
+	sourceFolders := islandFolders(sourcePath, "1")
+	require.NoError(t, os.MkdirAll(sourceFolders.BlobFolder, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceFolders.BlobFolder, "hash"), []byte("blob"), 0600))
+
+	var archive bytes.Buffer
+	manifest, err := ExportIsland(context.Background(), sourceHydra, 1, sourceFolders, &archive)
+	require.NoError(t, err)
+	require.Len(t, manifest.Swamps, 2)
+	assert.Equal(t, users.Get(), manifest.Swamps[0].Name)
+	assert.Equal(t, "", manifest.Swamps[1].Name, "the blobs are the last entry")
+	assert.Equal(t, int64(archive.Len()), manifest.ArchiveSize)
+
+	t.Run("should import the island into the empty folders", func(t *testing.T) {
+
+		targetPath := t.TempDir()
+		files, err := ImportIsland(bytes.NewReader(archive.Bytes()), 1, islandFolders(targetPath, "1"), manifest)
+		require.NoError(t, err)
+		assert.Positive(t, files)
+
+		content, err := os.ReadFile(filepath.Join(targetPath, "blobs", "1", "hash"))
+		require.NoError(t, err)
+		assert.Equal(t, "blob", string(content))
+
+		targetHydra := newTestHydra(t, targetPath)
+		value, ok := loadTreasure(t, targetHydra, users, "email")
+		assert.True(t, ok)
+		assert.Equal(t, "alex@example.com", value)
+
+		_, err = ImportIsland(bytes.NewReader(archive.Bytes()), 1, islandFolders(targetPath, "1"), manifest)
+		assert.ErrorIs(t, err, ErrIslandNotEmpty)
+
+	})
+
+	t.Run("should refuse the archive of an other island or layout", func(t *testing.T) {
+
+		_, err := ImportIsland(bytes.NewReader(archive.Bytes()), 2, islandFolders(t.TempDir(), "2"), manifest)
+		assert.ErrorContains(t, err, "belongs to the island 1")
+
+		other := islandFolders(t.TempDir(), "1")
+		other.HashFolderDepth = 3
+		_, err = ImportIsland(bytes.NewReader(archive.Bytes()), 1, other, manifest)
+		assert.ErrorContains(t, err, "folder layout")
+
+	})
+
+	t.Run("should refuse the corrupted archive and leave the folders empty", func(t *testing.T) {
+
+		corrupted := bytes.Clone(archive.Bytes())
+		corrupted[len(corrupted)/2] ^= 0xff
+		targetFolders := islandFolders(t.TempDir(), "1")
+		_, err := ImportIsland(bytes.NewReader(corrupted), 1, targetFolders, manifest)
+		assert.ErrorContains(t, err, "does not match its manifest")
+
+		hasFiles, err := targetFolders.HasFiles()
+		require.NoError(t, err)
+		assert.False(t, hasFiles)
+
+	})
+
+}
@@ -0,0 +1,474 @@
+package backup
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/core/hydra/lock"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/hydraide/hydraide/app/core/safeops"
+	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/tenancy"
+	"github.com/hydraide/hydraide/app/server/wal"
+	"log/slog"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// the swamps of the rebuild stay in the memory until the rebuild is done
+const (
+	rebuildCloseAfterIdleSec = 24 * 60 * 60
+	rebuildMaxFileSize       = 1024 * 1024
+)
+
+var (
+	// ErrPointInTimeNotCovered is returned if there is no complete backup before the point in time, or the
+	// write-ahead log does not cover the time between the backup and the point in time
+	ErrPointInTimeNotCovered = errors.New("the point in time is not covered by the backups and the write-ahead log")
+	// ErrRecoveryRunning is returned if an other point-in-time restore is running
+	ErrRecoveryRunning = errors.New("a point-in-time restore is already running")
+)
+
+// PointInTimeRequest selects the point in time and the swamps of a point-in-time restore
+type PointInTimeRequest struct {
+	// Until is the point in time, the swamps are restored to their state at it
+	Until time.Time
+	// Patterns select the restored swamps, the sanctuary, the realm and the swamp can be "*"
+	Patterns []name.Name
+	// IslandIDs select the swamps of the islands. The swamps matching any pattern or island are restored, both
+	// empty means all swamps
+	IslandIDs []uint64
+	// Tenant selects the swamps of one tenant. Empty means the swamps of all tenants and the main hydra
+	Tenant string
+}
+
+// PointInTimeResult is the summary of a point-in-time restore
+type PointInTimeResult struct {
+	// Manifest is the manifest of the backup the restore started from
+	Manifest *Manifest
+	// Swamps is the number of the restored swamps
+	Swamps int
+	// Records is the number of the records of the write-ahead log replayed on the backup
+	Records int
+	// Restored is the number of the treasures written back to their state at the point in time
+	Restored int
+	// Deleted is the number of the treasures deleted, because they did not exist at the point in time
+	Deleted int
+}
+
+// RecoveryConfiguration is the configuration of the point-in-time restore
+type RecoveryConfiguration struct {
+	// Bucket is the bucket of the backups
+	Bucket Bucket
+	// Prefix is the prefix of the keys of the backups in the bucket
+	Prefix string
+	// WAL is the write-ahead log of the changes since the backups
+	WAL wal.Reader
+	// TempFolder is the folder of the downloaded archive and of the rebuilt swamps. Empty means the temporary
+	// folder of the system
+	TempFolder string
+	// Hydra returns the hydra the swamps of the tenant are restored into. Empty tenant means the main hydra. The
+	// manifest is the backup the restore started from
+	Hydra func(tenant string, manifest *Manifest) (hydra.Hydra, error)
+}
+
+// Recovery restores the swamps to a point in time
+type Recovery interface {
+	// RestorePointInTime restores the selected swamps to their state at the point in time of the request.
+	//
+	// The newest complete backup before the point in time is rebuilt in the temp folder, and the records of the
+	// write-ahead log between the backup and the point in time are replayed on it. Then the rebuilt swamps are
+	// compared with the swamps of the hydras, and the different treasures are written back, the treasures created
+	// after the point in time are deleted. The swamps changed after the backup are restored too, even if the backup
+	// does not have them.
+	//
+	// ⚠️ The changes are written while the hydras serve the requests, so the writes of the clients during the
+	// restore may be overwritten. The shadow-deleted treasures and the in-memory swamps are not restored.
+	RestorePointInTime(ctx context.Context, request *PointInTimeRequest) (*PointInTimeResult, error)
+}
+
+type recovery struct {
+	configuration *RecoveryConfiguration
+	running       atomic.Bool
+}
+
+// NewRecovery creates the point-in-time restore of the configuration
+func NewRecovery(configuration *RecoveryConfiguration) (Recovery, error) {
+	if configuration.Bucket == nil {
+		return nil, errors.New("the bucket of the backups is not set")
+	}
+	if configuration.WAL == nil {
+		return nil, errors.New("the write-ahead log is not set")
+	}
+	if configuration.Hydra == nil {
+		return nil, errors.New("the hydras of the restore are not set")
+	}
+	return &recovery{configuration: configuration}, nil
+}
+
+// RecoveryForTenant returns the view of the recovery for the tenant: it restores only the swamps of the tenant
+func RecoveryForTenant(r Recovery, tenantID string) Recovery {
+	return &tenantRecovery{Recovery: r, tenantID: tenantID}
+}
+
+type tenantRecovery struct {
+	Recovery
+	tenantID string
+}
+
+func (t *tenantRecovery) RestorePointInTime(ctx context.Context, request *PointInTimeRequest) (*PointInTimeResult, error) {
+	tenantRequest := *request
+	tenantRequest.Tenant = t.tenantID
+	return t.Recovery.RestorePointInTime(ctx, &tenantRequest)
+}
+
+// swampKey identifies a swamp of a tenant
+type swampKey struct {
+	tenant    string
+	swampName string
+}
+
+func (r *recovery) RestorePointInTime(ctx context.Context, request *PointInTimeRequest) (*PointInTimeResult, error) {
+
+	if request.Until.IsZero() {
+		return nil, errors.New("the point in time of the restore is not set")
+	}
+	if !r.running.CompareAndSwap(false, true) {
+		return nil, ErrRecoveryRunning
+	}
+	defer r.running.Store(false)
+
+	manifest, err := latestManifestBefore(ctx, r.configuration.Bucket, r.configuration.Prefix, request.Until)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.HashFolderDepth <= 0 || manifest.MaxFoldersPerLevel <= 0 {
+		return nil, fmt.Errorf("the manifest of the backup %s has no folder layout, it can not be rebuilt", manifest.ID)
+	}
+
+	coveredFrom, err := r.configuration.WAL.CoveredFrom()
+	if err != nil && !errors.Is(err, wal.ErrEmpty) {
+		return nil, err
+	}
+	if err != nil || coveredFrom.After(manifest.CreatedAt) {
+		return nil, fmt.Errorf("%w: the write-ahead log starts after the backup %s", ErrPointInTimeNotCovered, manifest.ID)
+	}
+
+	rebuildFolder, err := os.MkdirTemp(r.configuration.TempFolder, "hydraide-pitr-*")
+	if err != nil {
+		return nil, fmt.Errorf("can not create the folder of the rebuild: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(rebuildFolder)
+	}()
+
+	selector := &swampSelector{tenant: request.Tenant, patterns: request.Patterns, islandIDs: request.IslandIDs}
+	swamps := make(map[swampKey]uint64)
+	for _, s := range manifest.Swamps {
+		if selector.matches(s.Tenant, s.Name, s.IslandID) {
+			swamps[swampKey{tenant: s.Tenant, swampName: s.Name}] = s.IslandID
+		}
+	}
+
+	if _, err := restoreManifest(ctx, r.configuration.Bucket, manifest, rebuildFolder, r.configuration.TempFolder, func(s ManifestSwamp) bool {
+		return selector.matches(s.Tenant, s.Name, s.IslandID)
+	}); err != nil {
+		return nil, err
+	}
+
+	rebuild := &LocalHydras{RootPath: rebuildFolder, rebuild: true}
+	defer rebuild.Close()
+
+	result := &PointInTimeResult{Manifest: manifest}
+
+	// the records after the point in time are not replayed, but their swamps are restored, because they changed
+	err = r.configuration.WAL.Read(manifest.CreatedAt, func(record *wal.Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !selector.matches(record.Tenant, record.SwampName, record.IslandID) {
+			return nil
+		}
+		swamps[swampKey{tenant: record.Tenant, swampName: record.SwampName}] = record.IslandID
+		if record.Time.After(request.Until) {
+			return nil
+		}
+		hydraInterface, err := rebuild.Open(record.Tenant, manifest)
+		if err != nil {
+			return err
+		}
+		if err := replayRecord(ctx, hydraInterface, record); err != nil {
+			return fmt.Errorf("can not replay the change of the swamp %s: %w", record.SwampName, err)
+		}
+		result.Records++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := slices.SortedFunc(maps.Keys(swamps), func(a, b swampKey) int {
+		if a.tenant != b.tenant {
+			return cmp.Compare(a.tenant, b.tenant)
+		}
+		return cmp.Compare(a.swampName, b.swampName)
+	})
+	for _, key := range keys {
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rebuildHydra, err := rebuild.Open(key.tenant, manifest)
+		if err != nil {
+			return nil, err
+		}
+		targetHydra, err := r.configuration.Hydra(key.tenant, manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		restored, deleted, applied, err := applySwamp(ctx, rebuildHydra, targetHydra, swamps[key], key.swampName)
+		if err != nil {
+			return nil, fmt.Errorf("can not restore the swamp %s: %w", key.swampName, err)
+		}
+		if applied {
+			result.Swamps++
+			result.Restored += restored
+			result.Deleted += deleted
+		}
+
+	}
+
+	slog.Info("the swamps are restored to the point in time", "until", request.Until, "backup", manifest.ID,
+		"swamps", result.Swamps, "records", result.Records, "restored", result.Restored, "deleted", result.Deleted)
+
+	return result, nil
+
+}
+
+// latestManifestBefore returns the manifest of the newest complete backup started before the point in time
+func latestManifestBefore(ctx context.Context, bucket Bucket, prefix string, until time.Time) (*Manifest, error) {
+
+	backups, err := ListBackups(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	untilID := until.UTC().Format(IDLayout)
+	for i := len(backups) - 1; i >= 0; i-- {
+		if !backups[i].Complete || backups[i].ID > untilID {
+			continue
+		}
+		manifest, err := LoadManifest(ctx, bucket, prefix, backups[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		// the ID is truncated to seconds
+		if !manifest.CreatedAt.After(until) {
+			return manifest, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: there is no complete backup before %s", ErrPointInTimeNotCovered, until.UTC().Format(time.RFC3339))
+
+}
+
+// replayRecord applies the change of the record to the swamp of the hydra
+func replayRecord(ctx context.Context, hydraInterface hydra.Hydra, record *wal.Record) error {
+
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, record.IslandID, name.Load(record.SwampName))
+	if err != nil {
+		return err
+	}
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	switch record.Operation {
+	case wal.OperationSet:
+		return putTreasure(swampInterface, record.Key, record.Treasure)
+	case wal.OperationDelete:
+		if swampInterface.TreasureExists(record.Key) {
+			return swampInterface.DeleteTreasure(record.Key, false)
+		}
+	case wal.OperationDestroy:
+		for key := range swampInterface.GetAll() {
+			if err := swampInterface.DeleteTreasure(key, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// applySwamp writes the treasures of the rebuilt swamp into the target swamp, and deletes the treasures of the
+// target that the rebuilt swamp does not have. It returns false if the target swamp is an in-memory swamp, it is
+// not restored
+func applySwamp(ctx context.Context, rebuildHydra hydra.Hydra, targetHydra hydra.Hydra, islandID uint64, swampName string) (restored int, deleted int, applied bool, err error) {
+
+	// every hydra gets its own name, because the name caches the folder of the swamp in the first hydra
+	rebuildSwamp, err := rebuildHydra.SummonSwamp(ctx, islandID, name.Load(swampName))
+	if err != nil {
+		return 0, 0, false, err
+	}
+	rebuildSwamp.BeginVigil()
+	defer rebuildSwamp.CeaseVigil()
+
+	targetSwamp, err := targetHydra.SummonSwamp(ctx, islandID, name.Load(swampName))
+	if err != nil {
+		return 0, 0, false, err
+	}
+	targetSwamp.BeginVigil()
+	defer targetSwamp.CeaseVigil()
+
+	if targetSwamp.GetChronicler() == nil {
+		return 0, 0, false, nil
+	}
+
+	wanted := rebuildSwamp.GetAll()
+	for _, key := range slices.Sorted(maps.Keys(wanted)) {
+		b, err := treasureToByte(wanted[key])
+		if err != nil {
+			return restored, deleted, true, err
+		}
+		if current, err := targetSwamp.GetTreasure(key); err == nil {
+			currentBytes, err := treasureToByte(current)
+			if err == nil && sameTreasure(b, currentBytes) {
+				continue
+			}
+		}
+		if err := putTreasure(targetSwamp, key, b); err != nil {
+			return restored, deleted, true, err
+		}
+		restored++
+	}
+
+	for key := range targetSwamp.GetAll() {
+		if _, ok := wanted[key]; ok {
+			continue
+		}
+		if err := targetSwamp.DeleteTreasure(key, false); err != nil && targetSwamp.TreasureExists(key) {
+			return restored, deleted, true, err
+		}
+		deleted++
+	}
+
+	return restored, deleted, true, nil
+
+}
+
+// putTreasure replaces the treasure of the key with the binary state. The existing treasure is shadow-deleted first,
+// because the loaded state would be merged into it. The new treasure replaces the shadow-deleted one in the file, and
+// the swamp is not destroyed by the deletion of its last treasure
+func putTreasure(swampInterface swamp.Swamp, key string, b []byte) error {
+
+	if swampInterface.TreasureExists(key) {
+		if err := swampInterface.DeleteTreasure(key, true); err != nil {
+			return err
+		}
+	}
+
+	treasureInterface := swampInterface.CreateTreasure(key)
+	guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureInterface.ReleaseTreasureGuard(guardID)
+
+	if err := treasureInterface.LoadFromByte(guardID, b, ""); err != nil {
+		return err
+	}
+	// the restored treasure keeps its original creation time, like the restore of a shadow-deleted treasure
+	treasureInterface.BodySetForRestore(guardID)
+	treasureInterface.Save(guardID)
+
+	return nil
+
+}
+
+func treasureToByte(treasureInterface treasure.Treasure) ([]byte, error) {
+	guardID := treasureInterface.StartTreasureGuard(true, guard.BodyAuthID)
+	defer treasureInterface.ReleaseTreasureGuard(guardID)
+	return treasureInterface.ConvertToByte(guardID)
+}
+
+// sameTreasure returns true if the binary states are the same treasure. The file of the treasure is not compared,
+// because it is different in every swamp
+func sameTreasure(a []byte, b []byte) bool {
+	var modelA, modelB treasure.Model
+	if gob.NewDecoder(bytes.NewReader(a)).Decode(&modelA) != nil || gob.NewDecoder(bytes.NewReader(b)).Decode(&modelB) != nil {
+		return false
+	}
+	modelA.FileName = nil
+	modelB.FileName = nil
+	return reflect.DeepEqual(modelA, modelB)
+}
+
+// LocalHydras opens the hydras of the root folder of a stopped server, e.g. for a point-in-time restore by the CLI.
+// The hydras use the saved settings of the server, and the folder layout of the manifest
+type LocalHydras struct {
+	// RootPath is the root folder of the server
+	RootPath string
+	// rebuild opens the hydras of a rebuild, whose swamps stay in the memory until the hydras are closed
+	rebuild bool
+
+	mu     sync.Mutex
+	hydras map[string]hydra.Hydra
+}
+
+// Open returns the hydra of the tenant, and opens it at the first call. Empty tenant means the main hydra
+func (l *LocalHydras) Open(tenant string, manifest *Manifest) (hydra.Hydra, error) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if h, ok := l.hydras[tenant]; ok {
+		return h, nil
+	}
+	if manifest.HashFolderDepth <= 0 || manifest.MaxFoldersPerLevel <= 0 {
+		return nil, fmt.Errorf("the manifest of the backup %s has no folder layout", manifest.ID)
+	}
+
+	rootPath := l.RootPath
+	if tenant != "" {
+		rootPath = tenancy.RootPath(l.RootPath, tenant)
+	}
+	settingsInterface := settings.NewWithRootPath(rootPath, manifest.HashFolderDepth, manifest.MaxFoldersPerLevel)
+	if l.rebuild {
+		settingsInterface.RegisterPattern(name.Load(allSwampsPatternPath), false, rebuildCloseAfterIdleSec, &settings.FileSystemSettings{
+			WriteIntervalSec: rebuildCloseAfterIdleSec,
+			MaxFileSizeByte:  rebuildMaxFileSize,
+		}, nil)
+	}
+
+	if l.hydras == nil {
+		l.hydras = make(map[string]hydra.Hydra)
+	}
+	h := hydra.New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+	l.hydras[tenant] = h
+
+	return h, nil
+
+}
+
+// Close stops the opened hydras, and writes their swamps to the disk
+func (l *LocalHydras) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, h := range l.hydras {
+		h.GracefulStop()
+	}
+	l.hydras = nil
+}
@@ -0,0 +1,234 @@
+package backup
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/wal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// recordedHydra returns a test hydra whose changes are recorded to the write-ahead log
+func recordedHydra(t *testing.T, rootPath string, log wal.Log, tenant string) hydra.Hydra {
+	hydraInterface := newTestHydra(t, rootPath)
+	require.NoError(t, hydraInterface.SubscribeToPatternEvents(uuid.New(), []name.Name{name.Load(allSwampsPatternPath)}, log.Recorder(tenant)))
+	return hydraInterface
+}
+
+// waitForNextInstant makes sure the next change is after the returned time
+func waitForNextInstant() time.Time {
+	now := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	return now
+}
+
+func TestRecovery(t *testing.T) {
+
+	ctx := context.Background()
+
+	// the name caches the folder of its swamp, so every hydra gets its own names
+	newProfiles := func() name.Name {
+		return name.New().Sanctuary("users").Realm("profiles").Swamp("alex")
+	}
+	newSessions := func() name.Name {
+		return name.New().Sanctuary("users").Realm("sessions").Swamp("alex")
+	}
+	newOrders := func() name.Name {
+		return name.New().Sanctuary("orders").Realm("2026").Swamp("october")
+	}
+
+	newBackup := func(t *testing.T, rootPath string, serverURL string, targets ...Target) *Manifest {
+		s, err := New(&Configuration{
+			S3:                 S3Configuration{Endpoint: serverURL, Bucket: "backups", AccessKey: "access", SecretKey: "secret"},
+			RootPath:           rootPath,
+			HashFolderDepth:    2,
+			MaxFoldersPerLevel: 10,
+			Targets: func() []Target {
+				return targets
+			},
+		})
+		require.NoError(t, err)
+		manifest, err := s.Run(ctx)
+		require.NoError(t, err)
+		return manifest
+	}
+
+	t.Run("should restore the swamps to the point in time", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		log, err := wal.New(&wal.Configuration{Folder: filepath.Join(rootPath, "wal")})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = log.Close()
+		})
+		mainHydra := recordedHydra(t, rootPath, log, "")
+		tenantHydra := recordedHydra(t, filepath.Join(rootPath, "tenants", "acme"), log, "acme")
+		profiles, sessions, orders := newProfiles(), newSessions(), newOrders()
+
+		saveTreasure(t, mainHydra, profiles, "email", "alex@example.com")
+		saveTreasure(t, mainHydra, profiles, "name", "Alex")
+		saveTreasure(t, tenantHydra, orders, "order-1", "paid")
+
+		_, server := newFakeS3(t, "backups")
+		manifest := newBackup(t, rootPath, server.URL, Target{Hydra: mainHydra}, Target{Tenant: "acme", Hydra: tenantHydra})
+		require.Len(t, manifest.Swamps, 2)
+
+		// the changes between the backup and the point in time are replayed
+		saveTreasure(t, mainHydra, profiles, "email", "alex@hydraide.io")
+		saveTreasure(t, tenantHydra, orders, "order-2", "new")
+		until := waitForNextInstant()
+
+		// the changes after the point in time are reverted
+		saveTreasure(t, mainHydra, profiles, "email", "wrong@example.com")
+		profilesSwamp, err := mainHydra.SummonSwamp(ctx, 1, profiles)
+		require.NoError(t, err)
+		require.NoError(t, profilesSwamp.DeleteTreasure("name", false))
+		saveTreasure(t, mainHydra, profiles, "phone", "+36")
+		saveTreasure(t, mainHydra, sessions, "token", "abc")
+		saveTreasure(t, tenantHydra, orders, "order-2", "cancelled")
+
+		recovery, err := NewRecovery(&RecoveryConfiguration{
+			Bucket: newTestBucket(t, server.URL, 0),
+			WAL:    log,
+			Hydra: func(tenant string, _ *Manifest) (hydra.Hydra, error) {
+				if tenant == "acme" {
+					return tenantHydra, nil
+				}
+				return mainHydra, nil
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := recovery.RestorePointInTime(ctx, &PointInTimeRequest{Until: until})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.ID, result.Manifest.ID)
+		assert.Equal(t, 3, result.Swamps, "the swamp created after the backup is restored too")
+		assert.Equal(t, 2, result.Records)
+		assert.Equal(t, 3, result.Restored, "email, name and order-2")
+		assert.Equal(t, 2, result.Deleted, "phone and token")
+
+		content, _ := loadTreasure(t, mainHydra, profiles, "email")
+		assert.Equal(t, "alex@hydraide.io", content)
+		content, ok := loadTreasure(t, mainHydra, profiles, "name")
+		assert.True(t, ok)
+		assert.Equal(t, "Alex", content)
+		_, ok = loadTreasure(t, mainHydra, profiles, "phone")
+		assert.False(t, ok)
+		_, ok = loadTreasure(t, mainHydra, sessions, "token")
+		assert.False(t, ok)
+		content, _ = loadTreasure(t, tenantHydra, orders, "order-2")
+		assert.Equal(t, "new", content)
+
+	})
+
+	t.Run("should restore only the selected swamps", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		log, err := wal.New(&wal.Configuration{Folder: filepath.Join(rootPath, "wal")})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = log.Close()
+		})
+		mainHydra := recordedHydra(t, rootPath, log, "")
+		tenantHydra := recordedHydra(t, filepath.Join(rootPath, "tenants", "acme"), log, "acme")
+		profiles, sessions, tenantProfiles := newProfiles(), newSessions(), newProfiles()
+		saveTreasure(t, mainHydra, profiles, "email", "alex@example.com")
+		saveTreasure(t, mainHydra, sessions, "token", "abc")
+		saveTreasure(t, tenantHydra, tenantProfiles, "email", "acme@example.com")
+
+		_, server := newFakeS3(t, "backups")
+		newBackup(t, rootPath, server.URL, Target{Hydra: mainHydra}, Target{Tenant: "acme", Hydra: tenantHydra})
+		until := waitForNextInstant()
+
+		saveTreasure(t, mainHydra, profiles, "email", "changed@example.com")
+		saveTreasure(t, mainHydra, sessions, "token", "changed")
+		saveTreasure(t, tenantHydra, tenantProfiles, "email", "changed@example.com")
+
+		recovery, err := NewRecovery(&RecoveryConfiguration{
+			Bucket: newTestBucket(t, server.URL, 0),
+			WAL:    log,
+			Hydra: func(tenant string, _ *Manifest) (hydra.Hydra, error) {
+				if tenant == "acme" {
+					return tenantHydra, nil
+				}
+				return mainHydra, nil
+			},
+		})
+		require.NoError(t, err)
+
+		// the tenant view restores only the swamps of the tenant
+		result, err := RecoveryForTenant(recovery, "acme").RestorePointInTime(ctx, &PointInTimeRequest{
+			Until:    until,
+			Patterns: []name.Name{name.Load("users/profiles/*")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Swamps)
+
+		content, _ := loadTreasure(t, tenantHydra, tenantProfiles, "email")
+		assert.Equal(t, "acme@example.com", content)
+		content, _ = loadTreasure(t, mainHydra, profiles, "email")
+		assert.Equal(t, "changed@example.com", content, "the swamps of the main hydra are not touched")
+
+		// the swamps of the island are restored, the others are not touched
+		result, err = recovery.RestorePointInTime(ctx, &PointInTimeRequest{Until: until, IslandIDs: []uint64{1}, Tenant: "-"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Swamps, "the tenant selects the swamps too")
+
+		result, err = recovery.RestorePointInTime(ctx, &PointInTimeRequest{Until: until, IslandIDs: []uint64{2}})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Swamps, "every swamp is on the first island")
+
+		result, err = recovery.RestorePointInTime(ctx, &PointInTimeRequest{Until: until, Patterns: []name.Name{name.Load("users/sessions/*")}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Swamps)
+		content, _ = loadTreasure(t, mainHydra, sessions, "token")
+		assert.Equal(t, "abc", content)
+		content, _ = loadTreasure(t, mainHydra, profiles, "email")
+		assert.Equal(t, "changed@example.com", content)
+
+	})
+
+	t.Run("should refuse the points that are not covered", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		mainHydra := newTestHydra(t, rootPath)
+		saveTreasure(t, mainHydra, newProfiles(), "email", "alex@example.com")
+
+		_, server := newFakeS3(t, "backups")
+		manifest := newBackup(t, rootPath, server.URL, Target{Hydra: mainHydra})
+
+		// the write-ahead log started after the backup
+		log, err := wal.New(&wal.Configuration{Folder: filepath.Join(rootPath, "wal")})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = log.Close()
+		})
+
+		recovery, err := NewRecovery(&RecoveryConfiguration{
+			Bucket: newTestBucket(t, server.URL, 0),
+			WAL:    log,
+			Hydra: func(string, *Manifest) (hydra.Hydra, error) {
+				return mainHydra, nil
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = recovery.RestorePointInTime(ctx, &PointInTimeRequest{Until: time.Now()})
+		assert.ErrorIs(t, err, ErrPointInTimeNotCovered)
+		assert.ErrorContains(t, err, "write-ahead log")
+
+		_, err = recovery.RestorePointInTime(ctx, &PointInTimeRequest{Until: manifest.CreatedAt.Add(-time.Second)})
+		assert.ErrorIs(t, err, ErrPointInTimeNotCovered)
+		assert.ErrorContains(t, err, "no complete backup")
+
+		_, err = recovery.RestorePointInTime(ctx, &PointInTimeRequest{})
+		assert.Error(t, err)
+
+	})
+
+}
@@ -0,0 +1,199 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/name"
+	"io"
+	"os"
+	"slices"
+)
+
+// RestoreRequest selects the backup to restore and the target of the restore
+type RestoreRequest struct {
+	// Prefix is the prefix of the keys of the backups in the bucket
+	Prefix string
+	// ID is the ID of the backup. Empty means the newest complete backup
+	ID string
+	// TargetFolder is the root folder of the restored server. The server must not run during the restore
+	TargetFolder string
+	// Patterns select the restored swamps, the sanctuary, the realm and the swamp can be "*". Empty means all
+	// swamps of the backup
+	Patterns []name.Name
+	// Tenant selects the swamps of one tenant. Empty means the swamps of all tenants and the main hydra
+	Tenant string
+	// TempFolder is the folder of the downloaded archive. Empty means the temporary folder of the system
+	TempFolder string
+}
+
+// RestoreResult is the summary of a restore
+type RestoreResult struct {
+	// Manifest is the manifest of the restored backup
+	Manifest *Manifest
+	// Swamps is the number of the restored swamps
+	Swamps int
+	// Files is the number of the restored files
+	Files int
+}
+
+// LoadManifest downloads the manifest of the backup. Empty ID means the newest complete backup
+func LoadManifest(ctx context.Context, bucket Bucket, prefix string, id string) (*Manifest, error) {
+
+	if id == "" {
+		backups, err := ListBackups(ctx, bucket, prefix)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(backups) - 1; i >= 0; i-- {
+			if backups[i].Complete {
+				id = backups[i].ID
+				break
+			}
+		}
+		if id == "" {
+			return nil, errors.New("there is no complete backup in the bucket")
+		}
+	}
+
+	reader, err := bucket.Get(ctx, objectKey(prefix, id, manifestFileName))
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, fmt.Errorf("the backup %s does not exist or it is incomplete", id)
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	manifest := &Manifest{}
+	if err := json.NewDecoder(reader).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("can not decode the manifest of the backup %s: %w", id, err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("the manifest version %d of the backup %s is not supported", manifest.Version, id)
+	}
+
+	return manifest, nil
+
+}
+
+// Restore downloads the archive of the backup, verifies it by its manifest, and extracts the selected swamps into
+// the target folder. The existing files of the restored swamps are overwritten, the other files are not touched.
+//
+// The archive is verified before any file is extracted, and every file is verified before it replaces the
+// existing one.
+func Restore(ctx context.Context, bucket Bucket, request *RestoreRequest) (*RestoreResult, error) {
+
+	if request.TargetFolder == "" {
+		return nil, errors.New("the target folder of the restore is not set")
+	}
+
+	manifest, err := LoadManifest(ctx, bucket, request.Prefix, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := &swampSelector{tenant: request.Tenant, patterns: request.Patterns}
+	return restoreManifest(ctx, bucket, manifest, request.TargetFolder, request.TempFolder, func(swamp ManifestSwamp) bool {
+		return selector.matches(swamp.Tenant, swamp.Name, swamp.IslandID)
+	})
+
+}
+
+// restoreManifest extracts the swamps of the backup selected by the filter into the target folder
+func restoreManifest(ctx context.Context, bucket Bucket, manifest *Manifest, targetFolder string, tempFolder string, filter func(swamp ManifestSwamp) bool) (*RestoreResult, error) {
+
+	result := &RestoreResult{Manifest: manifest}
+	for _, swamp := range manifest.Swamps {
+		if filter(swamp) {
+			result.Swamps++
+		}
+	}
+	if result.Swamps == 0 {
+		return result, nil
+	}
+
+	archiveFile, err := downloadArchive(ctx, bucket, manifest, tempFolder)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = archiveFile.Close()
+		_ = os.Remove(archiveFile.Name())
+	}()
+
+	if result.Files, err = extractArchive(archiveFile, targetFolder, manifest, filter); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+// swampSelector selects the swamps of a restore by their tenant, name and island
+type swampSelector struct {
+	// tenant selects the swamps of one tenant, empty means all tenants and the main hydra
+	tenant string
+	// patterns and islandIDs select the swamps matching any of them, both empty means all swamps
+	patterns  []name.Name
+	islandIDs []uint64
+}
+
+func (s *swampSelector) matches(tenant string, swampName string, islandID uint64) bool {
+	if s.tenant != "" && tenant != s.tenant {
+		return false
+	}
+	if len(s.patterns) == 0 && len(s.islandIDs) == 0 {
+		return true
+	}
+	if slices.Contains(s.islandIDs, islandID) {
+		return true
+	}
+	parsedName, err := ParsePattern(swampName)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(s.patterns, func(pattern name.Name) bool {
+		return hydra.MatchPattern(parsedName, pattern)
+	})
+}
+
+// downloadArchive downloads the archive into a temporary file, verifies its hash, and returns the file rewound
+func downloadArchive(ctx context.Context, bucket Bucket, manifest *Manifest, tempFolder string) (*os.File, error) {
+
+	reader, err := bucket.Get(ctx, manifest.Archive)
+	if err != nil {
+		return nil, fmt.Errorf("can not download the archive of the backup %s: %w", manifest.ID, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	archiveFile, err := os.CreateTemp(tempFolder, "hydraide-restore-*.tar.zst")
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(archiveFile, hash), reader)
+	if err == nil && (size != manifest.ArchiveSize || hex.EncodeToString(hash.Sum(nil)) != manifest.ArchiveSHA256) {
+		err = fmt.Errorf("the archive of the backup %s does not match its manifest", manifest.ID)
+	}
+	if err == nil {
+		_, err = archiveFile.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = archiveFile.Close()
+		_ = os.Remove(archiveFile.Name())
+		return nil, err
+	}
+
+	return archiveFile, nil
+
+}
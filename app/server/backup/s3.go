@@ -0,0 +1,447 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRegion is the signing region if the configuration does not set it. MinIO accepts it by default
+	DefaultRegion = "us-east-1"
+	// DefaultPartSize is the size of the parts of the multipart uploads. The objects up to this size are uploaded
+	// by a single request
+	DefaultPartSize = 16 * 1024 * 1024
+	// MinPartSize is the smallest part size S3 accepts, except the last part
+	MinPartSize = 5 * 1024 * 1024
+
+	// emptyPayloadHash is the SHA256 of the empty body, signed by the requests without a body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	amzDateLayout    = "20060102T150405Z"
+	amzDayLayout     = "20060102"
+)
+
+// ErrObjectNotFound is returned by Get if the object does not exist in the bucket
+var ErrObjectNotFound = errors.New("the object does not exist")
+
+// S3Configuration is the bucket of the backups on Amazon S3 or a S3 compatible storage, e.g. MinIO
+type S3Configuration struct {
+	// Endpoint is the base URL of the storage, e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000.
+	// The bucket is addressed in the path of the URL, so it works with every S3 compatible storage
+	Endpoint string
+	// Region is the region of the bucket, used by the signature of the requests. Empty means DefaultRegion
+	Region string
+	// Bucket is the name of the bucket, it must exist
+	Bucket string
+	// AccessKey and SecretKey are the credentials of the requests
+	AccessKey string
+	SecretKey string
+	// PartSize is the size of the parts of the multipart uploads in bytes. Zero means DefaultPartSize
+	PartSize int64
+	// HTTPClient sends the requests. Nil means a client without a timeout, because the uploads may be large
+	HTTPClient *http.Client
+}
+
+// Object is an object of the bucket
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Bucket stores the objects of the backups
+type Bucket interface {
+	// Put uploads the content as the object of the key. The content above the part size is uploaded in parts
+	Put(ctx context.Context, key string, content io.ReaderAt, size int64) error
+	// Get downloads the object of the key. The caller must close the returned reader. ErrObjectNotFound is returned
+	// if the object does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// List returns the objects with the key prefix, ordered by their keys
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete deletes the object of the key. Deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+type s3Bucket struct {
+	configuration *S3Configuration
+	endpoint      *url.URL
+	httpClient    *http.Client
+	now           func() time.Time
+}
+
+// NewS3Bucket returns the bucket of the configuration
+func NewS3Bucket(configuration *S3Configuration) (Bucket, error) {
+
+	if configuration.Bucket == "" {
+		return nil, errors.New("the bucket is not set")
+	}
+	if configuration.AccessKey == "" || configuration.SecretKey == "" {
+		return nil, errors.New("the access key and the secret key are required")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(configuration.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("the endpoint must be a http or https URL, got %q", configuration.Endpoint)
+	}
+	if configuration.Region == "" {
+		configuration.Region = DefaultRegion
+	}
+	if configuration.PartSize == 0 {
+		configuration.PartSize = DefaultPartSize
+	}
+	if configuration.PartSize < MinPartSize {
+		return nil, fmt.Errorf("the part size must be at least %d bytes, got %d", MinPartSize, configuration.PartSize)
+	}
+
+	httpClient := configuration.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &s3Bucket{
+		configuration: configuration,
+		endpoint:      endpoint,
+		httpClient:    httpClient,
+		now:           time.Now,
+	}, nil
+
+}
+
+func (b *s3Bucket) Put(ctx context.Context, key string, content io.ReaderAt, size int64) error {
+
+	if size <= b.configuration.PartSize {
+		body := make([]byte, size)
+		if _, err := content.ReadAt(body, 0); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("can not read the content of %s: %w", key, err)
+		}
+		response, err := b.do(ctx, http.MethodPut, key, nil, body)
+		if err != nil {
+			return err
+		}
+		return response.Body.Close()
+	}
+
+	return b.putMultipart(ctx, key, content, size)
+
+}
+
+// putMultipart uploads the content in parts, and aborts the upload if a part fails, so the storage does not keep
+// the uploaded parts
+func (b *s3Bucket) putMultipart(ctx context.Context, key string, content io.ReaderAt, size int64) (err error) {
+
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := b.doXML(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, &initiated); err != nil {
+		return err
+	}
+	if initiated.UploadID == "" {
+		return fmt.Errorf("the storage did not return the upload ID of %s", key)
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		// the abort must run even if the context is canceled
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		if response, abortErr := b.do(abortCtx, http.MethodDelete, key, url.Values{"uploadId": {initiated.UploadID}}, nil); abortErr == nil {
+			_ = response.Body.Close()
+		}
+	}()
+
+	type completedPart struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+	completed := struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{}
+
+	part := make([]byte, b.configuration.PartSize)
+	for offset, partNumber := int64(0), 1; offset < size; offset, partNumber = offset+b.configuration.PartSize, partNumber+1 {
+
+		partSize := min(b.configuration.PartSize, size-offset)
+		if _, err := content.ReadAt(part[:partSize], offset); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("can not read the content of %s: %w", key, err)
+		}
+
+		response, err := b.do(ctx, http.MethodPut, key, url.Values{
+			"partNumber": {strconv.Itoa(partNumber)},
+			"uploadId":   {initiated.UploadID},
+		}, part[:partSize])
+		if err != nil {
+			return err
+		}
+		_ = response.Body.Close()
+
+		etag := response.Header.Get("ETag")
+		if etag == "" {
+			return fmt.Errorf("the storage did not return the ETag of the part %d of %s", partNumber, key)
+		}
+		completed.Parts = append(completed.Parts, completedPart{PartNumber: partNumber, ETag: etag})
+
+	}
+
+	body, err := xml.Marshal(completed)
+	if err != nil {
+		return err
+	}
+
+	// the completion may fail with status 200 and an error in the body
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := b.doXML(ctx, http.MethodPost, key, url.Values{"uploadId": {initiated.UploadID}}, body, &result); err != nil {
+		return err
+	}
+	if result.XMLName.Local == "Error" {
+		return fmt.Errorf("can not complete the upload of %s: %s: %s", key, result.Code, result.Message)
+	}
+
+	return nil
+
+}
+
+func (b *s3Bucket) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	response, err := b.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+func (b *s3Bucket) List(ctx context.Context, prefix string) ([]Object, error) {
+
+	var objects []Object
+	continuationToken := ""
+
+	for {
+
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if err := b.doXML(ctx, http.MethodGet, "", query, nil, &result); err != nil {
+			return nil, err
+		}
+
+		for _, content := range result.Contents {
+			objects = append(objects, Object{Key: content.Key, Size: content.Size, LastModified: content.LastModified})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = result.NextContinuationToken
+
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	return objects, nil
+
+}
+
+func (b *s3Bucket) Delete(ctx context.Context, key string) error {
+	response, err := b.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+	return response.Body.Close()
+}
+
+// doXML sends the request and decodes the XML body of the response into the result
+func (b *s3Bucket) doXML(ctx context.Context, method string, key string, query url.Values, body []byte, result any) error {
+	response, err := b.do(ctx, method, key, query, body)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if err := xml.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("can not decode the response of the storage: %w", err)
+	}
+	return nil
+}
+
+// do sends the signed request of the object, or the bucket if the key is empty. The responses with an error status
+// are returned as errors
+func (b *s3Bucket) do(ctx context.Context, method string, key string, query url.Values, body []byte) (*http.Response, error) {
+
+	objectPath := b.endpoint.Path + "/" + b.configuration.Bucket
+	if key != "" {
+		objectPath += "/" + key
+	}
+
+	requestURL := *b.endpoint
+	requestURL.Path = objectPath
+	requestURL.RawPath = uriEncode(objectPath, false)
+	requestURL.RawQuery = canonicalQuery(query)
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.ContentLength = int64(len(body))
+
+	payloadHash := emptyPayloadHash
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	signV4(request, b.configuration.AccessKey, b.configuration.SecretKey, b.configuration.Region, payloadHash, b.now())
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return response, nil
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	var s3Error struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	_ = xml.Unmarshal(responseBody, &s3Error)
+
+	if response.StatusCode == http.StatusNotFound && (s3Error.Code == "" || s3Error.Code == "NoSuchKey") {
+		return nil, fmt.Errorf("%s %s: %w", method, key, ErrObjectNotFound)
+	}
+	if s3Error.Code != "" {
+		return nil, fmt.Errorf("the storage refused %s %s with status %d: %s: %s", method, objectPath, response.StatusCode, s3Error.Code, s3Error.Message)
+	}
+	return nil, fmt.Errorf("the storage refused %s %s with status %d", method, objectPath, response.StatusCode)
+
+}
+
+// signV4 signs the request with the AWS Signature Version 4 in the Authorization header. The host, the range, the
+// content type and the x-amz-* headers are signed
+func signV4(request *http.Request, accessKey string, secretKey string, region string, payloadHash string, now time.Time) {
+
+	now = now.UTC()
+	amzDate := now.Format(amzDateLayout)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for headerName, values := range request.Header {
+		lowerName := strings.ToLower(headerName)
+		if lowerName == "range" || lowerName == "content-type" || strings.HasPrefix(lowerName, "x-amz-") {
+			headers[lowerName] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	headerNames := make([]string, 0, len(headers))
+	for headerName := range headers {
+		headerNames = append(headerNames, headerName)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, headerName := range headerNames {
+		canonicalHeaders.WriteString(headerName + ":" + headers[headerName] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		uriEncode(request.URL.Path, false),
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := now.Format(amzDayLayout) + "/" + region + "/s3/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), now.Format(amzDayLayout))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		",SignedHeaders="+signedHeaders+",Signature="+signature)
+
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery encodes the query sorted by the names, the same way in the URL and in the signature
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for queryName := range query {
+		names = append(names, queryName)
+	}
+	sort.Strings(names)
+	var pairs []string
+	for _, queryName := range names {
+		values := append([]string(nil), query[queryName]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(queryName, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode encodes every byte except the unreserved characters, as the signature requires. The slashes of the
+// paths are kept
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			encoded.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			encoded.WriteByte(c)
+		default:
+			encoded.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return encoded.String()
+}
@@ -0,0 +1,174 @@
+// Package certreloader keeps the TLS certificate of the server up to date.
+//
+// The reloader checks the modification time and size of the certificate and key files periodically, and loads the new
+// key pair when any of them has changed. The loaded certificate is served through the GetCertificate callback of the
+// tls.Config, so new connections get the new certificate immediately, while the existing connections keep running
+// with the certificate of their handshake. This makes the certificate rotation (e.g. Let's Encrypt) possible without
+// restarting the server.
+package certreloader
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCheckInterval is the default interval between two checks of the certificate files
+	DefaultCheckInterval = 30 * time.Second
+)
+
+var (
+	// ErrCertificateNotLoaded is returned by GetCertificate if there is no valid certificate loaded yet
+	ErrCertificateNotLoaded = errors.New("TLS certificate is not loaded")
+)
+
+type CertReloader interface {
+	// Start loads the certificate and starts the background watcher. The watcher stops when the context is canceled.
+	// The function does not fail if the certificate can not be loaded, because the files may appear later. Use
+	// IsLoaded to check if the server has a valid certificate.
+	Start(ctx context.Context)
+	// GetCertificate returns the current certificate. The signature matches tls.Config.GetCertificate
+	GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
+	// TLSConfig returns a tls.Config that always serves the current certificate
+	TLSConfig() *tls.Config
+	// IsLoaded returns true if a valid certificate is loaded
+	IsLoaded() bool
+	// Reload loads the key pair from the files immediately, regardless of the file changes.
+	// If the loading fails, the previous certificate is kept.
+	Reload() error
+}
+
+type certReloader struct {
+	mu            sync.RWMutex
+	crtFile       string
+	keyFile       string
+	checkInterval time.Duration
+	certificate   *tls.Certificate
+	crtState      fileState
+	keyState      fileState
+}
+
+// fileState is the part of the file info the reloader uses to detect changes
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New creates a new certificate reloader for the given files. If the checkInterval is zero or negative, the
+// DefaultCheckInterval is used.
+func New(crtFile, keyFile string, checkInterval time.Duration) CertReloader {
+	if checkInterval <= 0 {
+		checkInterval = DefaultCheckInterval
+	}
+	return &certReloader{
+		crtFile:       crtFile,
+		keyFile:       keyFile,
+		checkInterval: checkInterval,
+	}
+}
+
+func (c *certReloader) Start(ctx context.Context) {
+
+	if err := c.Reload(); err != nil {
+		slog.Error("failed to load TLS certificate, waiting for the certificate files", "crtFile", c.crtFile, "keyFile", c.keyFile, "error", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !c.isChanged() {
+					continue
+				}
+				if err := c.Reload(); err != nil {
+					slog.Error("failed to reload the changed TLS certificate, the previous certificate is kept", "crtFile", c.crtFile, "keyFile", c.keyFile, "error", err)
+					continue
+				}
+				slog.Info("TLS certificate reloaded", "crtFile", c.crtFile, "keyFile", c.keyFile)
+			}
+		}
+	}()
+
+}
+
+func (c *certReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.certificate == nil {
+		return nil, ErrCertificateNotLoaded
+	}
+	return c.certificate, nil
+}
+
+func (c *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: c.GetCertificate,
+	}
+}
+
+func (c *certReloader) IsLoaded() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.certificate != nil
+}
+
+func (c *certReloader) Reload() error {
+
+	// read the file states before loading, so a change during the loading is detected by the next check
+	crtState, _ := statFile(c.crtFile)
+	keyState, _ := statFile(c.keyFile)
+
+	certificate, err := tls.LoadX509KeyPair(c.crtFile, c.keyFile)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// save the states even if the loading failed, so we don't retry the same broken files at every tick
+	c.crtState = crtState
+	c.keyState = keyState
+
+	if err != nil {
+		return err
+	}
+
+	c.certificate = &certificate
+	return nil
+
+}
+
+// isChanged returns true if the certificate or key file is changed since the last loading
+func (c *certReloader) isChanged() bool {
+
+	crtState, crtErr := statFile(c.crtFile)
+	keyState, keyErr := statFile(c.keyFile)
+	if crtErr != nil || keyErr != nil {
+		// the files are missing or being replaced, wait for the next tick
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !crtState.equal(c.crtState) || !keyState.equal(c.keyState)
+
+}
+
+func (f fileState) equal(other fileState) bool {
+	return f.size == other.size && f.modTime.Equal(other.modTime)
+}
+
+func statFile(path string) (fileState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
@@ -0,0 +1,85 @@
+package certreloader
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertReloader(t *testing.T) {
+
+	folder := t.TempDir()
+	crtFile := filepath.Join(folder, "server.crt")
+	keyFile := filepath.Join(folder, "server.key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the files are missing at start, the reloader must not fail
+	reloader := New(crtFile, keyFile, 10*time.Millisecond)
+	reloader.Start(ctx)
+	assert.False(t, reloader.IsLoaded())
+	_, err := reloader.GetCertificate(nil)
+	assert.ErrorIs(t, err, ErrCertificateNotLoaded)
+
+	// the certificate appears later
+	writeCertificate(t, crtFile, keyFile, "first")
+	assert.Eventually(t, reloader.IsLoaded, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "first", currentCommonName(t, reloader))
+
+	// the certificate is rotated
+	time.Sleep(20 * time.Millisecond)
+	writeCertificate(t, crtFile, keyFile, "second")
+	assert.Eventually(t, func() bool {
+		return currentCommonName(t, reloader) == "second"
+	}, time.Second, 10*time.Millisecond)
+
+	// a broken certificate keeps the previous one
+	require.NoError(t, os.WriteFile(crtFile, []byte("broken"), 0600))
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, reloader.IsLoaded())
+	assert.Equal(t, "second", currentCommonName(t, reloader))
+
+}
+
+func currentCommonName(t *testing.T, reloader CertReloader) string {
+	certificate, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(t, err)
+	return parsed.Subject.CommonName
+}
+
+func writeCertificate(t *testing.T, crtFile, keyFile, commonName string) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	require.NoError(t, os.WriteFile(crtFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+}
@@ -0,0 +1,324 @@
+// Package cluster serves the topology of the HydrAIDE cluster: the servers and the Islands they serve.
+//
+// The topology is described by a YAML file, the same file on every server of the cluster. The clients connect to any
+// server as a seed, fetch the topology with the GetClusterTopology RPC, and connect to all servers by it, so the
+// servers and their Island ranges are configured at one place instead of in every client. The source checks the
+// modification time and the size of the file periodically, like the certificate reloader, so a changed topology is
+// served without restarting the server. The clients refresh their topology by its version.
+//
+// Example of the file:
+//
+//	allIslands: 1000
+//	servers:
+//	  - host: hydra01:4444
+//	    fromIsland: 1
+//	    toIsland: 500
+//	  - host: hydra02:4444
+//	    fromIsland: 501
+//	    toIsland: 1000
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"log/slog"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultCheckInterval is the default interval between two checks of the topology file
+const DefaultCheckInterval = 10 * time.Second
+
+// ErrInvalidTopology is returned for a topology whose Island ranges do not cover every Island exactly once
+var ErrInvalidTopology = errors.New("invalid cluster topology")
+
+// ErrVersionConflict is returned by Update if the topology was changed since the version the change is based on
+var ErrVersionConflict = errors.New("the cluster topology was changed by someone else")
+
+// Server is a server of the cluster with the range of its Islands
+type Server struct {
+	Host       string `yaml:"host"`       // the gRPC endpoint of the server, as the clients reach it, e.g. hydra01:4444
+	FromIsland uint64 `yaml:"fromIsland"` // the first Island of the server, inclusive
+	ToIsland   uint64 `yaml:"toIsland"`   // the last Island of the server, inclusive
+}
+
+// Topology is the servers of the cluster and their Islands
+type Topology struct {
+	AllIslands uint64   `yaml:"allIslands"` // the number of all Islands, it must not change while the cluster has data
+	Servers    []Server `yaml:"servers"`
+	// Version is the hash of the file, it changes with every change of the topology
+	Version string `yaml:"-"`
+}
+
+// Parse parses and validates the topology
+func Parse(content []byte) (*Topology, error) {
+
+	topology := &Topology{}
+	if err := yaml.Unmarshal(content, topology); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTopology, err)
+	}
+	if err := topology.Validate(); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	topology.Version = hex.EncodeToString(sum[:8])
+
+	return topology, nil
+
+}
+
+// Validate checks that the Island ranges of the servers cover every Island from 1 to AllIslands, without gaps and
+// without overlaps, the same way as the SDK checks its servers
+func (t *Topology) Validate() error {
+
+	if t.AllIslands == 0 || t.AllIslands > math.MaxUint16 {
+		return fmt.Errorf("%w: allIslands must be between 1 and %d, got %d", ErrInvalidTopology, math.MaxUint16, t.AllIslands)
+	}
+	if len(t.Servers) == 0 {
+		return fmt.Errorf("%w: no server is configured", ErrInvalidTopology)
+	}
+
+	sorted := make([]Server, len(t.Servers))
+	copy(sorted, t.Servers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].FromIsland < sorted[j].FromIsland
+	})
+
+	next := uint64(1)
+	for i, server := range sorted {
+		if server.Host == "" {
+			return fmt.Errorf("%w: the host of the server of the range %d-%d is empty", ErrInvalidTopology, server.FromIsland, server.ToIsland)
+		}
+		if server.FromIsland < 1 || server.FromIsland > server.ToIsland || server.ToIsland > t.AllIslands {
+			return fmt.Errorf("%w: the range %d-%d of the server %s must be within 1-%d",
+				ErrInvalidTopology, server.FromIsland, server.ToIsland, server.Host, t.AllIslands)
+		}
+		if server.FromIsland > next {
+			return fmt.Errorf("%w: the islands %d-%d are not served by any server", ErrInvalidTopology, next, server.FromIsland-1)
+		}
+		if server.FromIsland < next {
+			return fmt.Errorf("%w: the range %d-%d of the server %s overlaps the range of the server %s",
+				ErrInvalidTopology, server.FromIsland, server.ToIsland, server.Host, sorted[i-1].Host)
+		}
+		next = server.ToIsland + 1
+	}
+
+	if next <= t.AllIslands {
+		return fmt.Errorf("%w: the islands %d-%d are not served by any server", ErrInvalidTopology, next, t.AllIslands)
+	}
+
+	return nil
+
+}
+
+// MoveIslands returns a copy of the topology where the Islands from-to are served by the host. The ranges of the other
+// servers are split or shrunk as needed, so a server may have more ranges after the move. The version of the copy is
+// empty until it is saved
+func (t *Topology) MoveIslands(from, to uint64, host string) (*Topology, error) {
+
+	if from < 1 || from > to || to > t.AllIslands {
+		return nil, fmt.Errorf("%w: the range %d-%d must be within 1-%d", ErrInvalidTopology, from, to, t.AllIslands)
+	}
+	if host == "" {
+		return nil, fmt.Errorf("%w: the host of the moved islands is empty", ErrInvalidTopology)
+	}
+
+	owners := make([]string, t.AllIslands+1)
+	for _, server := range t.Servers {
+		for islandID := server.FromIsland; islandID <= server.ToIsland && islandID <= t.AllIslands; islandID++ {
+			owners[islandID] = server.Host
+		}
+	}
+	for islandID := from; islandID <= to; islandID++ {
+		owners[islandID] = host
+	}
+
+	moved := &Topology{AllIslands: t.AllIslands}
+	for islandID := uint64(1); islandID <= t.AllIslands; islandID++ {
+		last := len(moved.Servers) - 1
+		if last >= 0 && moved.Servers[last].Host == owners[islandID] {
+			moved.Servers[last].ToIsland = islandID
+			continue
+		}
+		moved.Servers = append(moved.Servers, Server{Host: owners[islandID], FromIsland: islandID, ToIsland: islandID})
+	}
+
+	if err := moved.Validate(); err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+
+}
+
+// Source serves the current topology of the file
+type Source interface {
+	// Start loads the topology and starts the background watcher of the file. The watcher stops when the context is
+	// canceled. The function does not fail if the topology can not be loaded, because the file may appear later
+	Start(ctx context.Context)
+	// Topology returns the current topology, nil if no valid topology is loaded
+	Topology() *Topology
+	// Reload loads the topology from the file immediately. If the loading fails, the previous topology is kept
+	Reload() error
+	// Update validates the topology, writes it to the file and serves it. The expectedVersion must be the version of
+	// the current topology, otherwise ErrVersionConflict is returned, so two concurrent changes can not overwrite each
+	// other. An empty expectedVersion is accepted only if no topology is loaded. It returns the saved topology with
+	// its new version
+	Update(topology *Topology, expectedVersion string) (*Topology, error)
+}
+
+type source struct {
+	mu            sync.RWMutex
+	path          string
+	checkInterval time.Duration
+	topology      *Topology
+	modTime       time.Time
+	size          int64
+}
+
+// NewSource creates the source of the topology file. Zero checkInterval means DefaultCheckInterval
+func NewSource(path string, checkInterval time.Duration) Source {
+	if checkInterval <= 0 {
+		checkInterval = DefaultCheckInterval
+	}
+	return &source{
+		path:          path,
+		checkInterval: checkInterval,
+	}
+}
+
+func (s *source) Start(ctx context.Context) {
+
+	if err := s.Reload(); err != nil {
+		slog.Error("can not load the cluster topology, the clients can not discover the servers until it is fixed", "path", s.path, "error", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.reloadIfChanged()
+			}
+		}
+	}()
+
+}
+
+func (s *source) Topology() *Topology {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.topology
+}
+
+func (s *source) Reload() error {
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("can not read the topology file: %w", err)
+	}
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("can not read the topology file: %w", err)
+	}
+	topology, err := Parse(content)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topology = topology
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+
+	return nil
+
+}
+
+func (s *source) Update(topology *Topology, expectedVersion string) (*Topology, error) {
+
+	if err := topology.Validate(); err != nil {
+		return nil, err
+	}
+	content, err := yaml.Marshal(topology)
+	if err != nil {
+		return nil, fmt.Errorf("can not encode the topology: %w", err)
+	}
+	saved, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := ""
+	if s.topology != nil {
+		current = s.topology.Version
+	}
+	if current != expectedVersion {
+		return nil, fmt.Errorf("%w: the current version is %q, the change is based on %q", ErrVersionConflict, current, expectedVersion)
+	}
+
+	// the file is replaced atomically, so the watcher never reads a half written topology
+	temporary := s.path + ".tmp"
+	if err := os.WriteFile(temporary, content, 0644); err != nil {
+		return nil, fmt.Errorf("can not write the topology file: %w", err)
+	}
+	if err := os.Rename(temporary, s.path); err != nil {
+		return nil, fmt.Errorf("can not write the topology file: %w", err)
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read the topology file: %w", err)
+	}
+
+	s.topology = saved
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+
+	slog.Info("the cluster topology is updated", "path", s.path, "version", saved.Version, "servers", len(saved.Servers))
+
+	return saved, nil
+
+}
+
+// reloadIfChanged reloads the topology if the modification time or the size of the file has changed
+func (s *source) reloadIfChanged() {
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		slog.Warn("can not check the cluster topology file", "path", s.path, "error", err)
+		return
+	}
+
+	s.mu.RLock()
+	changed := !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+	previous := s.topology
+	s.mu.RUnlock()
+	if !changed {
+		return
+	}
+
+	if err := s.Reload(); err != nil {
+		slog.Error("the changed cluster topology is invalid, the previous one is served", "path", s.path, "error", err)
+		return
+	}
+
+	if current := s.Topology(); previous == nil || current.Version != previous.Version {
+		slog.Info("the cluster topology is reloaded", "path", s.path, "version", current.Version, "servers", len(current.Servers))
+	}
+
+}
@@ -0,0 +1,158 @@
+package cluster
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const twoServers = `allIslands: 1000
+servers:
+  - host: hydra01:4444
+    fromIsland: 1
+    toIsland: 500
+  - host: hydra02:4444
+    fromIsland: 501
+    toIsland: 1000
+`
+
+func TestParse(t *testing.T) {
+
+	t.Run("should parse the topology with its version", func(t *testing.T) {
+		topology, err := Parse([]byte(twoServers))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000), topology.AllIslands)
+		require.Len(t, topology.Servers, 2)
+		assert.Equal(t, Server{Host: "hydra02:4444", FromIsland: 501, ToIsland: 1000}, topology.Servers[1])
+		assert.Len(t, topology.Version, 16)
+
+		same, err := Parse([]byte(twoServers))
+		require.NoError(t, err)
+		assert.Equal(t, topology.Version, same.Version)
+	})
+
+	t.Run("should refuse the invalid topologies", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"no islands":  "servers:\n  - {host: a:1, fromIsland: 1, toIsland: 1}\n",
+			"no servers":  "allIslands: 10\n",
+			"gap":         "allIslands: 10\nservers:\n  - {host: a:1, fromIsland: 1, toIsland: 4}\n  - {host: b:1, fromIsland: 6, toIsland: 10}\n",
+			"overlap":     "allIslands: 10\nservers:\n  - {host: a:1, fromIsland: 1, toIsland: 6}\n  - {host: b:1, fromIsland: 5, toIsland: 10}\n",
+			"missing end": "allIslands: 10\nservers:\n  - {host: a:1, fromIsland: 1, toIsland: 9}\n",
+			"no host":     "allIslands: 10\nservers:\n  - {fromIsland: 1, toIsland: 10}\n",
+			"not yaml":    "allIslands: [",
+		} {
+			_, err := Parse([]byte(content))
+			assert.ErrorIs(t, err, ErrInvalidTopology, name)
+		}
+	})
+
+}
+
+func TestMoveIslands(t *testing.T) {
+
+	topology, err := Parse([]byte(twoServers))
+	require.NoError(t, err)
+
+	t.Run("should split the ranges around the moved islands", func(t *testing.T) {
+		moved, err := topology.MoveIslands(401, 450, "hydra03:4444")
+		require.NoError(t, err)
+		assert.Equal(t, []Server{
+			{Host: "hydra01:4444", FromIsland: 1, ToIsland: 400},
+			{Host: "hydra03:4444", FromIsland: 401, ToIsland: 450},
+			{Host: "hydra01:4444", FromIsland: 451, ToIsland: 500},
+			{Host: "hydra02:4444", FromIsland: 501, ToIsland: 1000},
+		}, moved.Servers)
+		assert.Empty(t, moved.Version)
+		// the original topology is not changed
+		assert.Len(t, topology.Servers, 2)
+	})
+
+	t.Run("should merge the neighbouring ranges of the same host", func(t *testing.T) {
+		moved, err := topology.MoveIslands(401, 500, "hydra02:4444")
+		require.NoError(t, err)
+		assert.Equal(t, []Server{
+			{Host: "hydra01:4444", FromIsland: 1, ToIsland: 400},
+			{Host: "hydra02:4444", FromIsland: 401, ToIsland: 1000},
+		}, moved.Servers)
+	})
+
+	t.Run("should refuse the invalid ranges", func(t *testing.T) {
+		_, err := topology.MoveIslands(0, 10, "hydra03:4444")
+		assert.ErrorIs(t, err, ErrInvalidTopology)
+		_, err = topology.MoveIslands(900, 1001, "hydra03:4444")
+		assert.ErrorIs(t, err, ErrInvalidTopology)
+		_, err = topology.MoveIslands(10, 20, "")
+		assert.ErrorIs(t, err, ErrInvalidTopology)
+	})
+
+}
+
+func TestSource(t *testing.T) {
+
+	t.Run("should serve the changed topology and keep the previous one if the change is invalid", func(t *testing.T) {
+
+		path := filepath.Join(t.TempDir(), "topology.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(twoServers), 0644))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		s := NewSource(path, 10*time.Millisecond)
+		s.Start(ctx)
+		require.NotNil(t, s.Topology())
+		first := s.Topology().Version
+
+		require.NoError(t, os.WriteFile(path, []byte("allIslands: 1000\nservers:\n  - {host: hydra01:4444, fromIsland: 1, toIsland: 1000}\n"), 0644))
+		assert.Eventually(t, func() bool {
+			return s.Topology().Version != first
+		}, time.Second, 10*time.Millisecond)
+		assert.Len(t, s.Topology().Servers, 1)
+
+		second := s.Topology().Version
+		require.NoError(t, os.WriteFile(path, []byte("allIslands: 1000\n"), 0644))
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, second, s.Topology().Version)
+
+	})
+
+	t.Run("should serve nothing until the file is valid", func(t *testing.T) {
+		s := NewSource(filepath.Join(t.TempDir(), "missing.yaml"), 0)
+		s.Start(context.Background())
+		assert.Nil(t, s.Topology())
+		assert.Error(t, s.Reload())
+	})
+
+	t.Run("should save the update only on the expected version", func(t *testing.T) {
+
+		path := filepath.Join(t.TempDir(), "topology.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(twoServers), 0644))
+		s := NewSource(path, time.Hour)
+		require.NoError(t, s.Reload())
+		current := s.Topology()
+
+		moved, err := current.MoveIslands(1, 100, "hydra03:4444")
+		require.NoError(t, err)
+
+		_, err = s.Update(moved, "stale")
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Equal(t, current.Version, s.Topology().Version)
+
+		saved, err := s.Update(moved, current.Version)
+		require.NoError(t, err)
+		assert.NotEqual(t, current.Version, saved.Version)
+		assert.Equal(t, saved, s.Topology())
+
+		// the file has the new topology with the same version
+		require.NoError(t, s.Reload())
+		assert.Equal(t, saved.Version, s.Topology().Version)
+		assert.Len(t, s.Topology().Servers, 3)
+
+		_, err = s.Update(&Topology{AllIslands: 1000}, saved.Version)
+		assert.ErrorIs(t, err, ErrInvalidTopology)
+
+	})
+
+}
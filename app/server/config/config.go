@@ -0,0 +1,994 @@
+// Package config is the configuration subsystem of the HydrAIDE server.
+//
+// The configuration is built in three layers, each layer overrides the previous one:
+//
+//  1. the built-in defaults
+//  2. the hydraide.yaml file (optional)
+//  3. the environment variables (optional), so every key can be overridden individually, e.g. in Docker
+//
+// The config file is searched at the path of the HYDRAIDE_CONFIG_FILE environment variable, or in the
+// HYDRAIDE_ROOT_PATH folder as hydraide.yaml. A missing config file is not an error, the server runs with the
+// defaults and the environment variables as before. Unknown keys in the file are rejected, so typos don't go
+// unnoticed.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileName is the default name of the config file in the HydrAIDE root folder
+	FileName = "hydraide.yaml"
+	// EnvConfigFile is the environment variable that sets the path of the config file explicitly
+	EnvConfigFile = "HYDRAIDE_CONFIG_FILE"
+	// EnvRootPath is the environment variable of the HydrAIDE root folder
+	EnvRootPath = "HYDRAIDE_ROOT_PATH"
+	// DefaultRestGatewayClient is the client name of the REST gateway token set by the environment variable
+	DefaultRestGatewayClient = "default"
+)
+
+// tenantIDPattern limits the tenant IDs to safe folder names, the same as the tenancy package of the server
+var tenantIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+
+// Config is the full configuration of the HydrAIDE server
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	TLS         TLSConfig         `yaml:"tls"`
+	Defaults    DefaultsConfig    `yaml:"defaults"`
+	Logging     LoggingConfig     `yaml:"logging"`
+	Limits      LimitsConfig      `yaml:"limits"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	RestGateway RestGatewayConfig `yaml:"restGateway"`
+	Tenancy     TenancyConfig     `yaml:"tenancy"`
+	Storage     StorageConfig     `yaml:"storage"`
+	Telemetry   TelemetryConfig   `yaml:"telemetry"`
+	Audit       AuditConfig       `yaml:"audit"`
+	Backup      BackupConfig      `yaml:"backup"`
+	WAL         WALConfig         `yaml:"wal"`
+	Cluster     ClusterConfig     `yaml:"cluster"`
+}
+
+// ServerConfig contains the network settings of the server
+type ServerConfig struct {
+	Port            int `yaml:"port"`            // the port of the gRPC server
+	HealthCheckPort int `yaml:"healthCheckPort"` // the port of the health check HTTP server
+
+	// the keepalive, the flow control windows and the stream limit of the gRPC connections
+	Connection ConnectionConfig `yaml:"connection"`
+}
+
+// ConnectionConfig contains the tuning of the gRPC connections. Zero means the built-in default
+type ConnectionConfig struct {
+	KeepaliveTimeSec      int64 `yaml:"keepaliveTimeSec"`      // seconds of idle before the server pings the client
+	KeepaliveTimeoutSec   int64 `yaml:"keepaliveTimeoutSec"`   // seconds to wait for the answer of the ping
+	MaxConnectionIdleSec  int64 `yaml:"maxConnectionIdleSec"`  // seconds a connection without any RPC is kept open
+	KeepaliveMinTimeSec   int64 `yaml:"keepaliveMinTimeSec"`   // the shortest ping interval allowed for the clients
+	InitialWindowSize     int   `yaml:"initialWindowSize"`     // the flow control window of a stream in bytes, 0 means dynamic
+	InitialConnWindowSize int   `yaml:"initialConnWindowSize"` // the flow control window of a connection in bytes, 0 means dynamic
+	MaxConcurrentStreams  int   `yaml:"maxConcurrentStreams"`  // the max number of the concurrent RPCs of a connection, 0 means unlimited
+}
+
+// TLSConfig contains the settings of the server certificates
+type TLSConfig struct {
+	ReloadIntervalSec int64 `yaml:"reloadIntervalSec"` // seconds between two checks of the certificate files
+}
+
+// DefaultsConfig contains the default swamp settings used if the swamp pattern is not registered
+type DefaultsConfig struct {
+	CloseAfterIdleSec int64 `yaml:"closeAfterIdleSec"` // seconds before an idle swamp is closed
+	WriteIntervalSec  int64 `yaml:"writeIntervalSec"`  // seconds between two writes of a swamp to the disk
+	FileSize          int64 `yaml:"fileSize"`          // maximum size of a swamp chunk file in bytes
+	// the fsync policy of the swamp patterns registered without one: never, interval or always
+	FsyncPolicy      string `yaml:"fsyncPolicy"`
+	FsyncIntervalSec int64  `yaml:"fsyncIntervalSec"` // min seconds between two fsyncs of a swamp with the interval policy
+	// the swamps without a registered pattern use these defaults instead of the built-in settings
+	ApplyToUnregisteredSwamps bool `yaml:"applyToUnregisteredSwamps"`
+}
+
+// LoggingConfig contains the logging settings
+type LoggingConfig struct {
+	Level                    string        `yaml:"level"`                    // debug, info, warn or error
+	SystemResourceLogging    bool          `yaml:"systemResourceLogging"`    // log the system resource usage periodically
+	GrpcServerErrorLogging   bool          `yaml:"grpcServerErrorLogging"`   // log the errors returned to the clients
+	SlowOperationThresholdMs int64         `yaml:"slowOperationThresholdMs"` // log the slower Set/Get/GetByIndex/Delete calls, 0 disables it
+	Graylog                  GraylogConfig `yaml:"graylog"`
+	// the access log of the RPCs, one record per RPC
+	AccessLog AccessLogConfig `yaml:"accessLog"`
+	// the HTTP log sinks, at most one remote log sink may be enabled, because they share the fallback log file
+	Loki       LokiConfig       `yaml:"loki"`
+	OpenSearch OpenSearchConfig `yaml:"opensearch"`
+}
+
+// AccessLogConfig contains the settings of the optional access log, which logs one record per RPC with the method,
+// the swamp, the island, the duration, the size of the messages and the status code. The failed RPCs are always
+// logged, the successful ones are sampled
+type AccessLogConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	SampleRatio float64 `yaml:"sampleRatio"` // the ratio of the logged successful RPCs between 0 and 1
+	Reads       bool    `yaml:"reads"`       // log the unary RPCs that do not change the data
+	Writes      bool    `yaml:"writes"`      // log the unary RPCs that change the data or the settings of the swamps
+	Streams     bool    `yaml:"streams"`     // log the streaming RPCs, e.g. Subscribe and SetLargeValue
+}
+
+// GraylogConfig contains the settings of the optional Graylog log handler
+type GraylogConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Server      string `yaml:"server"`      // host:port of the Graylog server
+	ServiceName string `yaml:"serviceName"` // the service name of the log entries
+
+	// the delivery of the messages, the zero values mean the defaults of the handler
+	Transport       string `yaml:"transport"`       // tcp or udp, the udp messages are compressed and chunked
+	QueueSize       int    `yaml:"queueSize"`       // the max number of pending messages before dropping new ones
+	BatchSize       int    `yaml:"batchSize"`       // the max number of messages sent at once
+	FlushIntervalMs int64  `yaml:"flushIntervalMs"` // the max milliseconds a message waits in an incomplete batch
+	ChunkSize       int    `yaml:"chunkSize"`       // the max size of an udp datagram in bytes
+}
+
+// LokiConfig contains the settings of the optional Grafana Loki log handler
+type LokiConfig struct {
+	Enabled     bool              `yaml:"enabled"`
+	URL         string            `yaml:"url"`         // the base URL of Loki, e.g. http://loki:3100
+	ServiceName string            `yaml:"serviceName"` // the service_name label of the streams
+	Labels      map[string]string `yaml:"labels"`      // further static labels of the streams, e.g. env: production
+	TenantID    string            `yaml:"tenantId"`    // the X-Scope-OrgID of the multi-tenant Loki, empty means none
+	Username    string            `yaml:"username"`    // the basic auth credentials, empty means no authentication
+	Password    string            `yaml:"password"`
+	// the delivery of the messages, the zero values mean the defaults of the handler
+	LogDeliveryConfig `yaml:",inline"`
+}
+
+// OpenSearchConfig contains the settings of the optional OpenSearch or Elasticsearch log handler
+type OpenSearchConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	URL         string `yaml:"url"`         // the base URL of the cluster, e.g. http://opensearch:9200
+	Index       string `yaml:"index"`       // the prefix of the daily indices, empty means hydraide-logs
+	ServiceName string `yaml:"serviceName"` // the service_name field of the documents
+	Username    string `yaml:"username"`    // the basic auth credentials, empty means no authentication
+	Password    string `yaml:"password"`
+	// the delivery of the messages, the zero values mean the defaults of the handler
+	LogDeliveryConfig `yaml:",inline"`
+}
+
+// LogDeliveryConfig contains the batching settings of a HTTP log sink
+type LogDeliveryConfig struct {
+	QueueSize       int   `yaml:"queueSize"`       // the max number of pending messages before dropping new ones
+	BatchSize       int   `yaml:"batchSize"`       // the max number of messages sent at once
+	FlushIntervalMs int64 `yaml:"flushIntervalMs"` // the max milliseconds a message waits in an incomplete batch
+}
+
+// LimitsConfig contains the resource limits of the server
+type LimitsConfig struct {
+	MaxMessageSize       int             `yaml:"maxMessageSize"`       // the maximum gRPC message size in bytes
+	MaxTreasuresPerSwamp int             `yaml:"maxTreasuresPerSwamp"` // the maximum number of treasures in a swamp, 0 means unlimited
+	MaxKeyLength         int             `yaml:"maxKeyLength"`         // the maximum length of a written key in bytes, 0 means unlimited
+	MaxValueSize         int64           `yaml:"maxValueSize"`         // the maximum size of a written value in bytes, 0 means unlimited
+	RateLimit            RateLimitConfig `yaml:"rateLimit"`
+}
+
+// RateLimitConfig contains the rate limits of the clients. The top level limits are the defaults of every client,
+// and the clients map overrides them per client identity (the hydraide-client-id metadata or the client IP).
+type RateLimitConfig struct {
+	Enabled           bool `yaml:"enabled"`
+	ClientLimitConfig `yaml:",inline"`
+	Clients           map[string]ClientLimitConfig `yaml:"clients"`
+}
+
+// ClientLimitConfig contains the rate limits of one client. Zero rates mean unlimited
+type ClientLimitConfig struct {
+	RequestsPerSecond   float64 `yaml:"requestsPerSecond"`   // the sustained number of RPCs per second
+	RequestBurst        int     `yaml:"requestBurst"`        // the maximum number of RPCs in a burst
+	WriteBytesPerSecond int64   `yaml:"writeBytesPerSecond"` // the sustained number of written bytes per second
+	WriteBytesBurst     int64   `yaml:"writeBytesBurst"`     // the maximum number of written bytes in a burst
+}
+
+// TracingConfig contains the settings of the optional OpenTelemetry tracing of the RPCs
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Endpoint    string  `yaml:"endpoint"`    // host:port of the OTLP/HTTP collector
+	Insecure    bool    `yaml:"insecure"`    // connect to the collector without TLS
+	ServiceName string  `yaml:"serviceName"` // the service.name of the spans
+	SampleRatio float64 `yaml:"sampleRatio"` // the ratio of the sampled traces between 0 and 1
+}
+
+// RestGatewayConfig contains the settings of the optional HTTP/JSON gateway in front of the gRPC API
+type RestGatewayConfig struct {
+	Enabled    bool              `yaml:"enabled"`
+	Port       int               `yaml:"port"`       // the port of the HTTPS listener of the gateway
+	AllIslands int               `yaml:"allIslands"` // the number of all islands, the same as in the SDK clients
+	Tokens     map[string]string `yaml:"tokens"`     // the accepted bearer tokens by client name
+}
+
+// TenancyConfig contains the tenants of the optional multi-tenant mode. Every tenant has its own bearer token and its
+// own isolated root path (HYDRAIDE_ROOT_PATH/tenants/<tenantID>)
+type TenancyConfig struct {
+	Enabled bool                    `yaml:"enabled"`
+	Tenants map[string]TenantConfig `yaml:"tenants"` // the tenants by their IDs
+}
+
+// TenantConfig contains the settings of one tenant
+type TenantConfig struct {
+	Token                string `yaml:"token"`                // the bearer token of the tenant, must be unique
+	MaxTreasuresPerSwamp int    `yaml:"maxTreasuresPerSwamp"` // overrides limits.maxTreasuresPerSwamp, 0 means the server limit
+	// the rate limits of the tenant, used if limits.rateLimit.enabled is true
+	ClientLimitConfig `yaml:",inline"`
+}
+
+// StorageConfig contains the settings of the swamp files
+type StorageConfig struct {
+	// fail the loading of a swamp with a corrupted file instead of skipping the file
+	FailOnCorruptedFiles bool `yaml:"failOnCorruptedFiles"`
+	// the max number of treasures a swamp writes to the disk at once, 0 means all waiting treasures
+	WriteBatchSize int `yaml:"writeBatchSize"`
+	// the max number of swamps loaded from the disk at the same time, 0 means unlimited
+	MaxConcurrentHydrations int `yaml:"maxConcurrentHydrations"`
+}
+
+// TelemetryConfig contains the settings of the resource usage sampling. The samples are exposed on /metrics, and
+// logged if logging.systemResourceLogging is true
+type TelemetryConfig struct {
+	// seconds between two samples of the resource usage
+	SampleIntervalSec int64 `yaml:"sampleIntervalSec"`
+	// the free disk percent of the island roots below the writes are refused, 0 means the writes are never refused
+	MinFreeDiskPercent float64 `yaml:"minFreeDiskPercent"`
+	// the free disk percent of the island roots below the server is reported as degraded, 0 means no warning. A value
+	// below minFreeDiskPercent means the server is reported as degraded only while the writes are refused
+	WarnFreeDiskPercent float64 `yaml:"warnFreeDiskPercent"`
+}
+
+// AuditConfig contains the settings of the optional audit log of the mutating requests
+type AuditConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Folder      string `yaml:"folder"`      // the folder of the audit files, empty means HYDRAIDE_ROOT_PATH/audit
+	MaxFileSize int64  `yaml:"maxFileSize"` // the size of the current file in bytes, above it is rotated
+	MaxFiles    int    `yaml:"maxFiles"`    // the max number of the rotated files, 0 means they are kept forever
+}
+
+// BackupConfig contains the settings of the optional scheduled backups to Amazon S3 or a S3 compatible storage
+type BackupConfig struct {
+	Enabled     bool     `yaml:"enabled"`
+	IntervalSec int64    `yaml:"intervalSec"` // seconds between two backups
+	Patterns    []string `yaml:"patterns"`    // the swamp patterns of the backups, e.g. users/*/*, empty means all swamps
+	KeepLast    int      `yaml:"keepLast"`    // the number of the newest backups kept, 0 means they are not deleted by number
+	MaxAgeSec   int64    `yaml:"maxAgeSec"`   // seconds above the backups are deleted, 0 means they are not deleted by age
+	TempFolder  string   `yaml:"tempFolder"`  // the folder of the archive until it is uploaded, empty means the system temp folder
+	S3          S3Config `yaml:"s3"`
+}
+
+// WALConfig contains the settings of the optional write-ahead log, the point-in-time restore replays it on the backups
+type WALConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	Folder         string `yaml:"folder"`         // the folder of the segments, empty means HYDRAIDE_ROOT_PATH/wal
+	MaxSegmentSize int64  `yaml:"maxSegmentSize"` // the size of the current segment in bytes, above it a new segment is started
+	SyncIntervalMs int64  `yaml:"syncIntervalMs"` // milliseconds between two syncs, the records of the last interval are lost on a crash
+	MaxAgeSec      int64  `yaml:"maxAgeSec"`      // seconds above the segments are deleted, 0 means they are kept forever
+}
+
+// ClusterConfig contains the optional topology file of the cluster, served to the clients by GetClusterTopology
+type ClusterConfig struct {
+	TopologyFile     string `yaml:"topologyFile"`     // the path of the topology file, empty means the topology is not served
+	CheckIntervalSec int64  `yaml:"checkIntervalSec"` // seconds between two checks of the topology file
+}
+
+// S3Config contains the bucket of the backups
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"`  // the base URL of the storage, e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000
+	Region    string `yaml:"region"`    // the region of the bucket
+	Bucket    string `yaml:"bucket"`    // the name of the bucket, it must exist
+	Prefix    string `yaml:"prefix"`    // the prefix of the keys of the backups, e.g. hydraide/production
+	AccessKey string `yaml:"accessKey"` // the credentials of the storage
+	SecretKey string `yaml:"secretKey"`
+	PartSize  int64  `yaml:"partSize"` // the size of the parts of the multipart uploads in bytes
+}
+
+// Default returns the built-in default configuration
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            4444,
+			HealthCheckPort: 4445,
+			Connection: ConnectionConfig{
+				KeepaliveTimeSec:     240,
+				KeepaliveTimeoutSec:  20,
+				MaxConnectionIdleSec: 300,
+				KeepaliveMinTimeSec:  10,
+			},
+		},
+		TLS: TLSConfig{
+			ReloadIntervalSec: 30,
+		},
+		Defaults: DefaultsConfig{
+			CloseAfterIdleSec: 1,
+			WriteIntervalSec:  10,
+			FileSize:          8192,
+			FsyncPolicy:       "never",
+			FsyncIntervalSec:  1,
+		},
+		Logging: LoggingConfig{
+			Level:                    "debug",
+			SlowOperationThresholdMs: 1000,
+			AccessLog: AccessLogConfig{
+				SampleRatio: 1,
+				Reads:       true,
+				Writes:      true,
+				Streams:     true,
+			},
+			Graylog: GraylogConfig{
+				ServiceName: "HydrAIDE-Server",
+			},
+			Loki: LokiConfig{
+				ServiceName: "HydrAIDE-Server",
+			},
+			OpenSearch: OpenSearchConfig{
+				ServiceName: "HydrAIDE-Server",
+			},
+		},
+		Limits: LimitsConfig{
+			MaxMessageSize: 104857600, // 100 MB
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4318",
+			ServiceName: "HydrAIDE-Server",
+			SampleRatio: 1,
+		},
+		RestGateway: RestGatewayConfig{
+			Port:       4446,
+			AllIslands: 1000,
+		},
+		Telemetry: TelemetryConfig{
+			SampleIntervalSec:   10,
+			MinFreeDiskPercent:  5,
+			WarnFreeDiskPercent: 10,
+		},
+		Audit: AuditConfig{
+			MaxFileSize: 104857600, // 100 MB
+		},
+		Backup: BackupConfig{
+			IntervalSec: 86400,
+			KeepLast:    7,
+			S3: S3Config{
+				Region:   "us-east-1",
+				PartSize: 16777216, // 16 MB
+			},
+		},
+		WAL: WALConfig{
+			MaxSegmentSize: 67108864, // 64 MB
+			SyncIntervalMs: 1000,
+			MaxAgeSec:      691200, // 8 days, one day longer than the default backups are kept
+		},
+		Cluster: ClusterConfig{
+			CheckIntervalSec: 10,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the config file and the environment variables, then validates it.
+// The returned path is the path of the loaded config file, or empty if no config file was found.
+func Load() (*Config, string, error) {
+
+	cfg := Default()
+
+	path := FilePath()
+	loadedPath := ""
+	if path != "" {
+		content, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := cfg.parse(content); err != nil {
+				return nil, "", fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+			loadedPath = path
+		case errors.Is(err, os.ErrNotExist) && os.Getenv(EnvConfigFile) == "":
+			// the default config file is optional
+		default:
+			return nil, "", fmt.Errorf("can not read config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return nil, "", err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	return cfg, loadedPath, nil
+
+}
+
+// FilePath returns the path of the config file. The HYDRAIDE_CONFIG_FILE environment variable has priority,
+// otherwise the hydraide.yaml in the HydrAIDE root folder is used.
+func FilePath() string {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path
+	}
+	if root := os.Getenv(EnvRootPath); root != "" {
+		return filepath.Join(root, FileName)
+	}
+	return ""
+}
+
+// parse loads the YAML content over the current values. Unknown keys are rejected.
+func (c *Config) parse(content []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		if errors.Is(err, io.EOF) {
+			// empty file, keep the defaults
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// envOverride describes an environment variable that overrides a single config key
+type envOverride struct {
+	name  string
+	apply func(value string) error
+}
+
+// applyEnv overrides the config keys with the set environment variables
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+
+	overrides := []envOverride{
+		{"HYDRAIDE_SERVER_PORT", intSetter(&c.Server.Port)},
+		{"HEALTH_CHECK_PORT", intSetter(&c.Server.HealthCheckPort)},
+		{"HYDRAIDE_KEEPALIVE_TIME", int64Setter(&c.Server.Connection.KeepaliveTimeSec)},
+		{"HYDRAIDE_KEEPALIVE_TIMEOUT", int64Setter(&c.Server.Connection.KeepaliveTimeoutSec)},
+		{"HYDRAIDE_MAX_CONNECTION_IDLE", int64Setter(&c.Server.Connection.MaxConnectionIdleSec)},
+		{"HYDRAIDE_KEEPALIVE_MIN_TIME", int64Setter(&c.Server.Connection.KeepaliveMinTimeSec)},
+		{"HYDRAIDE_INITIAL_WINDOW_SIZE", intSetter(&c.Server.Connection.InitialWindowSize)},
+		{"HYDRAIDE_INITIAL_CONN_WINDOW_SIZE", intSetter(&c.Server.Connection.InitialConnWindowSize)},
+		{"HYDRAIDE_MAX_CONCURRENT_STREAMS", intSetter(&c.Server.Connection.MaxConcurrentStreams)},
+		{"TLS_RELOAD_INTERVAL", int64Setter(&c.TLS.ReloadIntervalSec)},
+		{"HYDRAIDE_DEFAULT_CLOSE_AFTER_IDLE", int64Setter(&c.Defaults.CloseAfterIdleSec)},
+		{"HYDRAIDE_DEFAULT_WRITE_INTERVAL", int64Setter(&c.Defaults.WriteIntervalSec)},
+		{"HYDRAIDE_DEFAULT_FILE_SIZE", int64Setter(&c.Defaults.FileSize)},
+		{"HYDRAIDE_DEFAULT_FSYNC_POLICY", stringSetter(&c.Defaults.FsyncPolicy)},
+		{"HYDRAIDE_DEFAULT_FSYNC_INTERVAL", int64Setter(&c.Defaults.FsyncIntervalSec)},
+		{"HYDRAIDE_DEFAULT_APPLY_TO_UNREGISTERED_SWAMPS", boolSetter(&c.Defaults.ApplyToUnregisteredSwamps)},
+		{"LOG_LEVEL", stringSetter(&c.Logging.Level)},
+		{"SYSTEM_RESOURCE_LOGGING", boolSetter(&c.Logging.SystemResourceLogging)},
+		{"GRPC_SERVER_ERROR_LOGGING", boolSetter(&c.Logging.GrpcServerErrorLogging)},
+		{"HYDRAIDE_SLOW_OPERATION_THRESHOLD_MS", int64Setter(&c.Logging.SlowOperationThresholdMs)},
+		{"HYDRAIDE_ACCESS_LOG_ENABLED", boolSetter(&c.Logging.AccessLog.Enabled)},
+		{"HYDRAIDE_ACCESS_LOG_SAMPLE_RATIO", float64Setter(&c.Logging.AccessLog.SampleRatio)},
+		{"HYDRAIDE_ACCESS_LOG_READS", boolSetter(&c.Logging.AccessLog.Reads)},
+		{"HYDRAIDE_ACCESS_LOG_WRITES", boolSetter(&c.Logging.AccessLog.Writes)},
+		{"HYDRAIDE_ACCESS_LOG_STREAMS", boolSetter(&c.Logging.AccessLog.Streams)},
+		{"GRAYLOG_ENABLED", boolSetter(&c.Logging.Graylog.Enabled)},
+		{"GRAYLOG_SERVER", stringSetter(&c.Logging.Graylog.Server)},
+		{"GRAYLOG_SERVICE_NAME", stringSetter(&c.Logging.Graylog.ServiceName)},
+		{"GRAYLOG_TRANSPORT", stringSetter(&c.Logging.Graylog.Transport)},
+		{"GRAYLOG_QUEUE_SIZE", intSetter(&c.Logging.Graylog.QueueSize)},
+		{"GRAYLOG_BATCH_SIZE", intSetter(&c.Logging.Graylog.BatchSize)},
+		{"GRAYLOG_FLUSH_INTERVAL_MS", int64Setter(&c.Logging.Graylog.FlushIntervalMs)},
+		{"GRAYLOG_CHUNK_SIZE", intSetter(&c.Logging.Graylog.ChunkSize)},
+		{"LOKI_ENABLED", boolSetter(&c.Logging.Loki.Enabled)},
+		{"LOKI_URL", stringSetter(&c.Logging.Loki.URL)},
+		{"LOKI_SERVICE_NAME", stringSetter(&c.Logging.Loki.ServiceName)},
+		{"LOKI_LABELS", labelsSetter(&c.Logging.Loki.Labels)},
+		{"LOKI_TENANT_ID", stringSetter(&c.Logging.Loki.TenantID)},
+		{"LOKI_USERNAME", stringSetter(&c.Logging.Loki.Username)},
+		{"LOKI_PASSWORD", stringSetter(&c.Logging.Loki.Password)},
+		{"LOKI_QUEUE_SIZE", intSetter(&c.Logging.Loki.QueueSize)},
+		{"LOKI_BATCH_SIZE", intSetter(&c.Logging.Loki.BatchSize)},
+		{"LOKI_FLUSH_INTERVAL_MS", int64Setter(&c.Logging.Loki.FlushIntervalMs)},
+		{"OPENSEARCH_ENABLED", boolSetter(&c.Logging.OpenSearch.Enabled)},
+		{"OPENSEARCH_URL", stringSetter(&c.Logging.OpenSearch.URL)},
+		{"OPENSEARCH_INDEX", stringSetter(&c.Logging.OpenSearch.Index)},
+		{"OPENSEARCH_SERVICE_NAME", stringSetter(&c.Logging.OpenSearch.ServiceName)},
+		{"OPENSEARCH_USERNAME", stringSetter(&c.Logging.OpenSearch.Username)},
+		{"OPENSEARCH_PASSWORD", stringSetter(&c.Logging.OpenSearch.Password)},
+		{"OPENSEARCH_QUEUE_SIZE", intSetter(&c.Logging.OpenSearch.QueueSize)},
+		{"OPENSEARCH_BATCH_SIZE", intSetter(&c.Logging.OpenSearch.BatchSize)},
+		{"OPENSEARCH_FLUSH_INTERVAL_MS", int64Setter(&c.Logging.OpenSearch.FlushIntervalMs)},
+		{"GRPC_MAX_MESSAGE_SIZE", intSetter(&c.Limits.MaxMessageSize)},
+		{"HYDRAIDE_MAX_TREASURES_PER_SWAMP", intSetter(&c.Limits.MaxTreasuresPerSwamp)},
+		{"HYDRAIDE_MAX_KEY_LENGTH", intSetter(&c.Limits.MaxKeyLength)},
+		{"HYDRAIDE_MAX_VALUE_SIZE", int64Setter(&c.Limits.MaxValueSize)},
+		{"HYDRAIDE_RATE_LIMIT_ENABLED", boolSetter(&c.Limits.RateLimit.Enabled)},
+		{"HYDRAIDE_RATE_LIMIT_REQUESTS_PER_SECOND", float64Setter(&c.Limits.RateLimit.RequestsPerSecond)},
+		{"HYDRAIDE_RATE_LIMIT_REQUEST_BURST", intSetter(&c.Limits.RateLimit.RequestBurst)},
+		{"HYDRAIDE_RATE_LIMIT_WRITE_BYTES_PER_SECOND", int64Setter(&c.Limits.RateLimit.WriteBytesPerSecond)},
+		{"HYDRAIDE_RATE_LIMIT_WRITE_BYTES_BURST", int64Setter(&c.Limits.RateLimit.WriteBytesBurst)},
+		{"HYDRAIDE_TRACING_ENABLED", boolSetter(&c.Tracing.Enabled)},
+		{"HYDRAIDE_TRACING_ENDPOINT", stringSetter(&c.Tracing.Endpoint)},
+		{"HYDRAIDE_TRACING_INSECURE", boolSetter(&c.Tracing.Insecure)},
+		{"HYDRAIDE_TRACING_SERVICE_NAME", stringSetter(&c.Tracing.ServiceName)},
+		{"HYDRAIDE_TRACING_SAMPLE_RATIO", float64Setter(&c.Tracing.SampleRatio)},
+		{"HYDRAIDE_REST_GATEWAY_ENABLED", boolSetter(&c.RestGateway.Enabled)},
+		{"HYDRAIDE_REST_GATEWAY_PORT", intSetter(&c.RestGateway.Port)},
+		{"HYDRAIDE_REST_GATEWAY_ALL_ISLANDS", intSetter(&c.RestGateway.AllIslands)},
+		{"HYDRAIDE_REST_GATEWAY_TOKEN", tokenSetter(&c.RestGateway.Tokens, DefaultRestGatewayClient)},
+		{"HYDRAIDE_TENANCY_ENABLED", boolSetter(&c.Tenancy.Enabled)},
+		{"HYDRAIDE_FAIL_ON_CORRUPTED_FILES", boolSetter(&c.Storage.FailOnCorruptedFiles)},
+		{"HYDRAIDE_WRITE_BATCH_SIZE", intSetter(&c.Storage.WriteBatchSize)},
+		{"HYDRAIDE_MAX_CONCURRENT_HYDRATIONS", intSetter(&c.Storage.MaxConcurrentHydrations)},
+		{"HYDRAIDE_TELEMETRY_SAMPLE_INTERVAL", int64Setter(&c.Telemetry.SampleIntervalSec)},
+		{"HYDRAIDE_MIN_FREE_DISK_PERCENT", float64Setter(&c.Telemetry.MinFreeDiskPercent)},
+		{"HYDRAIDE_WARN_FREE_DISK_PERCENT", float64Setter(&c.Telemetry.WarnFreeDiskPercent)},
+		{"HYDRAIDE_AUDIT_ENABLED", boolSetter(&c.Audit.Enabled)},
+		{"HYDRAIDE_AUDIT_FOLDER", stringSetter(&c.Audit.Folder)},
+		{"HYDRAIDE_AUDIT_MAX_FILE_SIZE", int64Setter(&c.Audit.MaxFileSize)},
+		{"HYDRAIDE_AUDIT_MAX_FILES", intSetter(&c.Audit.MaxFiles)},
+		{"HYDRAIDE_BACKUP_ENABLED", boolSetter(&c.Backup.Enabled)},
+		{"HYDRAIDE_BACKUP_INTERVAL", int64Setter(&c.Backup.IntervalSec)},
+		{"HYDRAIDE_BACKUP_PATTERNS", listSetter(&c.Backup.Patterns)},
+		{"HYDRAIDE_BACKUP_KEEP_LAST", intSetter(&c.Backup.KeepLast)},
+		{"HYDRAIDE_BACKUP_MAX_AGE", int64Setter(&c.Backup.MaxAgeSec)},
+		{"HYDRAIDE_BACKUP_TEMP_FOLDER", stringSetter(&c.Backup.TempFolder)},
+		{"HYDRAIDE_BACKUP_S3_ENDPOINT", stringSetter(&c.Backup.S3.Endpoint)},
+		{"HYDRAIDE_BACKUP_S3_REGION", stringSetter(&c.Backup.S3.Region)},
+		{"HYDRAIDE_BACKUP_S3_BUCKET", stringSetter(&c.Backup.S3.Bucket)},
+		{"HYDRAIDE_BACKUP_S3_PREFIX", stringSetter(&c.Backup.S3.Prefix)},
+		{"HYDRAIDE_BACKUP_S3_ACCESS_KEY", stringSetter(&c.Backup.S3.AccessKey)},
+		{"HYDRAIDE_BACKUP_S3_SECRET_KEY", stringSetter(&c.Backup.S3.SecretKey)},
+		{"HYDRAIDE_BACKUP_S3_PART_SIZE", int64Setter(&c.Backup.S3.PartSize)},
+		{"HYDRAIDE_WAL_ENABLED", boolSetter(&c.WAL.Enabled)},
+		{"HYDRAIDE_WAL_FOLDER", stringSetter(&c.WAL.Folder)},
+		{"HYDRAIDE_WAL_MAX_SEGMENT_SIZE", int64Setter(&c.WAL.MaxSegmentSize)},
+		{"HYDRAIDE_WAL_SYNC_INTERVAL_MS", int64Setter(&c.WAL.SyncIntervalMs)},
+		{"HYDRAIDE_WAL_MAX_AGE", int64Setter(&c.WAL.MaxAgeSec)},
+		{"HYDRAIDE_CLUSTER_TOPOLOGY_FILE", stringSetter(&c.Cluster.TopologyFile)},
+		{"HYDRAIDE_CLUSTER_TOPOLOGY_CHECK_INTERVAL", int64Setter(&c.Cluster.CheckIntervalSec)},
+	}
+
+	for _, override := range overrides {
+		value, ok := lookup(override.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := override.apply(value); err != nil {
+			return fmt.Errorf("invalid environment variable %s=%q: %w", override.name, value, err)
+		}
+	}
+
+	return nil
+
+}
+
+// Validate checks the configuration and returns all problems in one error
+func (c *Config) Validate() error {
+
+	var problems []string
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("server.port must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.HealthCheckPort < 1 || c.Server.HealthCheckPort > 65535 {
+		problems = append(problems, fmt.Sprintf("server.healthCheckPort must be between 1 and 65535, got %d", c.Server.HealthCheckPort))
+	}
+	if c.Server.Port == c.Server.HealthCheckPort {
+		problems = append(problems, fmt.Sprintf("server.port and server.healthCheckPort must be different, both are %d", c.Server.Port))
+	}
+	problems = append(problems, c.Server.Connection.validate()...)
+	if c.TLS.ReloadIntervalSec < 1 {
+		problems = append(problems, fmt.Sprintf("tls.reloadIntervalSec must be at least 1, got %d", c.TLS.ReloadIntervalSec))
+	}
+	if c.Defaults.CloseAfterIdleSec < 1 {
+		problems = append(problems, fmt.Sprintf("defaults.closeAfterIdleSec must be at least 1, got %d", c.Defaults.CloseAfterIdleSec))
+	}
+	if c.Defaults.WriteIntervalSec < 0 {
+		problems = append(problems, fmt.Sprintf("defaults.writeIntervalSec must not be negative, got %d", c.Defaults.WriteIntervalSec))
+	}
+	if c.Defaults.FileSize < 1 {
+		problems = append(problems, fmt.Sprintf("defaults.fileSize must be at least 1 byte, got %d", c.Defaults.FileSize))
+	}
+	switch c.Defaults.FsyncPolicy {
+	case "never", "interval", "always":
+	default:
+		problems = append(problems, fmt.Sprintf("defaults.fsyncPolicy must be one of never, interval, always, got %q", c.Defaults.FsyncPolicy))
+	}
+	if c.Defaults.FsyncIntervalSec < 1 {
+		problems = append(problems, fmt.Sprintf("defaults.fsyncIntervalSec must be at least 1, got %d", c.Defaults.FsyncIntervalSec))
+	}
+	if c.Storage.WriteBatchSize < 0 {
+		problems = append(problems, fmt.Sprintf("storage.writeBatchSize must not be negative, got %d", c.Storage.WriteBatchSize))
+	}
+	if c.Storage.MaxConcurrentHydrations < 0 {
+		problems = append(problems, fmt.Sprintf("storage.maxConcurrentHydrations must not be negative, got %d", c.Storage.MaxConcurrentHydrations))
+	}
+	switch c.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.level must be one of debug, info, warn, error, got %q", c.Logging.Level))
+	}
+	if c.Logging.SlowOperationThresholdMs < 0 {
+		problems = append(problems, fmt.Sprintf("logging.slowOperationThresholdMs must not be negative, got %d", c.Logging.SlowOperationThresholdMs))
+	}
+	if c.Logging.AccessLog.SampleRatio < 0 || c.Logging.AccessLog.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("logging.accessLog.sampleRatio must be between 0 and 1, got %v", c.Logging.AccessLog.SampleRatio))
+	}
+	if c.Logging.Graylog.Enabled && c.Logging.Graylog.Server == "" {
+		problems = append(problems, "logging.graylog.server is required if logging.graylog.enabled is true")
+	}
+	problems = append(problems, c.Logging.Graylog.validate()...)
+	if c.Logging.Loki.Enabled && c.Logging.Loki.URL == "" {
+		problems = append(problems, "logging.loki.url is required if logging.loki.enabled is true")
+	}
+	problems = append(problems, c.Logging.Loki.LogDeliveryConfig.validate("logging.loki")...)
+	if c.Logging.OpenSearch.Enabled && c.Logging.OpenSearch.URL == "" {
+		problems = append(problems, "logging.opensearch.url is required if logging.opensearch.enabled is true")
+	}
+	problems = append(problems, c.Logging.OpenSearch.LogDeliveryConfig.validate("logging.opensearch")...)
+	if sinks := c.Logging.enabledSinks(); len(sinks) > 1 {
+		problems = append(problems, fmt.Sprintf("at most one of logging.graylog, logging.loki and logging.opensearch may be enabled, got %s", strings.Join(sinks, ", ")))
+	}
+	if c.Limits.MaxMessageSize < 1 {
+		problems = append(problems, fmt.Sprintf("limits.maxMessageSize must be at least 1 byte, got %d", c.Limits.MaxMessageSize))
+	}
+
+	if c.Limits.MaxTreasuresPerSwamp < 0 {
+		problems = append(problems, fmt.Sprintf("limits.maxTreasuresPerSwamp must not be negative, got %d", c.Limits.MaxTreasuresPerSwamp))
+	}
+	if c.Limits.MaxKeyLength < 0 {
+		problems = append(problems, fmt.Sprintf("limits.maxKeyLength must not be negative, got %d", c.Limits.MaxKeyLength))
+	}
+	if c.Limits.MaxValueSize < 0 {
+		problems = append(problems, fmt.Sprintf("limits.maxValueSize must not be negative, got %d", c.Limits.MaxValueSize))
+	}
+	problems = append(problems, c.Limits.RateLimit.ClientLimitConfig.validate("limits.rateLimit")...)
+	for identity, clientLimits := range c.Limits.RateLimit.Clients {
+		problems = append(problems, clientLimits.validate(fmt.Sprintf("limits.rateLimit.clients[%s]", identity))...)
+	}
+
+	if c.Telemetry.SampleIntervalSec < 1 {
+		problems = append(problems, fmt.Sprintf("telemetry.sampleIntervalSec must be at least 1, got %d", c.Telemetry.SampleIntervalSec))
+	}
+	if c.Telemetry.MinFreeDiskPercent < 0 || c.Telemetry.MinFreeDiskPercent >= 100 {
+		problems = append(problems, fmt.Sprintf("telemetry.minFreeDiskPercent must be between 0 and 100, got %v", c.Telemetry.MinFreeDiskPercent))
+	}
+	if c.Telemetry.WarnFreeDiskPercent < 0 || c.Telemetry.WarnFreeDiskPercent >= 100 {
+		problems = append(problems, fmt.Sprintf("telemetry.warnFreeDiskPercent must be between 0 and 100, got %v", c.Telemetry.WarnFreeDiskPercent))
+	}
+
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" {
+		problems = append(problems, "tracing.endpoint is required if tracing.enabled is true")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("tracing.sampleRatio must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+
+	if c.RestGateway.Enabled {
+		problems = append(problems, c.RestGateway.validate(c.Server, c.Tenancy)...)
+	}
+
+	if c.Tenancy.Enabled {
+		problems = append(problems, c.Tenancy.validate()...)
+	}
+
+	if c.Audit.MaxFileSize < 1 {
+		problems = append(problems, fmt.Sprintf("audit.maxFileSize must be at least 1 byte, got %d", c.Audit.MaxFileSize))
+	}
+	if c.Audit.MaxFiles < 0 {
+		problems = append(problems, fmt.Sprintf("audit.maxFiles must not be negative, got %d", c.Audit.MaxFiles))
+	}
+
+	if c.Backup.Enabled {
+		problems = append(problems, c.Backup.validate()...)
+	}
+
+	if c.WAL.Enabled {
+		problems = append(problems, c.WAL.validate(c.Backup)...)
+	}
+
+	if c.Cluster.TopologyFile != "" && c.Cluster.CheckIntervalSec < 1 {
+		problems = append(problems, fmt.Sprintf("cluster.checkIntervalSec must be at least 1, got %d", c.Cluster.CheckIntervalSec))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	return nil
+
+}
+
+// validate checks the limits of one client, the prefix is the config path of the limits in the messages
+func (l ClientLimitConfig) validate(prefix string) []string {
+	var problems []string
+	if l.RequestsPerSecond < 0 {
+		problems = append(problems, fmt.Sprintf("%s.requestsPerSecond must not be negative, got %v", prefix, l.RequestsPerSecond))
+	}
+	if l.RequestBurst < 0 {
+		problems = append(problems, fmt.Sprintf("%s.requestBurst must not be negative, got %d", prefix, l.RequestBurst))
+	}
+	if l.WriteBytesPerSecond < 0 {
+		problems = append(problems, fmt.Sprintf("%s.writeBytesPerSecond must not be negative, got %d", prefix, l.WriteBytesPerSecond))
+	}
+	if l.WriteBytesBurst < 0 {
+		problems = append(problems, fmt.Sprintf("%s.writeBytesBurst must not be negative, got %d", prefix, l.WriteBytesBurst))
+	}
+	return problems
+}
+
+// validate checks the delivery settings of the Graylog handler
+func (g GraylogConfig) validate() []string {
+	var problems []string
+	switch g.Transport {
+	case "", "tcp", "udp":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.graylog.transport must be tcp or udp, got %q", g.Transport))
+	}
+	if g.QueueSize < 0 {
+		problems = append(problems, fmt.Sprintf("logging.graylog.queueSize must not be negative, got %d", g.QueueSize))
+	}
+	if g.BatchSize < 0 {
+		problems = append(problems, fmt.Sprintf("logging.graylog.batchSize must not be negative, got %d", g.BatchSize))
+	}
+	if g.FlushIntervalMs < 0 {
+		problems = append(problems, fmt.Sprintf("logging.graylog.flushIntervalMs must not be negative, got %d", g.FlushIntervalMs))
+	}
+	// a GELF chunk has a 12 bytes header
+	if g.ChunkSize != 0 && g.ChunkSize < 512 {
+		problems = append(problems, fmt.Sprintf("logging.graylog.chunkSize must be 0 or at least 512 bytes, got %d", g.ChunkSize))
+	}
+	return problems
+}
+
+// validate checks the batching settings of a HTTP log sink, the prefix is the config path of the sink in the messages
+func (d LogDeliveryConfig) validate(prefix string) []string {
+	var problems []string
+	if d.QueueSize < 0 {
+		problems = append(problems, fmt.Sprintf("%s.queueSize must not be negative, got %d", prefix, d.QueueSize))
+	}
+	if d.BatchSize < 0 {
+		problems = append(problems, fmt.Sprintf("%s.batchSize must not be negative, got %d", prefix, d.BatchSize))
+	}
+	if d.FlushIntervalMs < 0 {
+		problems = append(problems, fmt.Sprintf("%s.flushIntervalMs must not be negative, got %d", prefix, d.FlushIntervalMs))
+	}
+	return problems
+}
+
+// enabledSinks returns the names of the enabled remote log sinks
+func (l LoggingConfig) enabledSinks() []string {
+	var sinks []string
+	if l.Graylog.Enabled {
+		sinks = append(sinks, "graylog")
+	}
+	if l.Loki.Enabled {
+		sinks = append(sinks, "loki")
+	}
+	if l.OpenSearch.Enabled {
+		sinks = append(sinks, "opensearch")
+	}
+	return sinks
+}
+
+// validate checks the tuning of the gRPC connections
+func (c ConnectionConfig) validate() []string {
+	var problems []string
+	for name, value := range map[string]int64{
+		"keepaliveTimeSec":     c.KeepaliveTimeSec,
+		"keepaliveTimeoutSec":  c.KeepaliveTimeoutSec,
+		"maxConnectionIdleSec": c.MaxConnectionIdleSec,
+		"keepaliveMinTimeSec":  c.KeepaliveMinTimeSec,
+		"maxConcurrentStreams": int64(c.MaxConcurrentStreams),
+	} {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("server.connection.%s must not be negative, got %d", name, value))
+		}
+	}
+	// gRPC ignores the windows below 64 KB
+	for name, value := range map[string]int{
+		"initialWindowSize":     c.InitialWindowSize,
+		"initialConnWindowSize": c.InitialConnWindowSize,
+	} {
+		if value != 0 && (value < 65535 || value > math.MaxInt32) {
+			problems = append(problems, fmt.Sprintf("server.connection.%s must be 0 or between 65535 and %d, got %d", name, math.MaxInt32, value))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// validate checks the settings of the enabled REST gateway
+func (r RestGatewayConfig) validate(serverConfig ServerConfig, tenancyConfig TenancyConfig) []string {
+	var problems []string
+	if r.Port < 1 || r.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("restGateway.port must be between 1 and 65535, got %d", r.Port))
+	}
+	if r.Port == serverConfig.Port || r.Port == serverConfig.HealthCheckPort {
+		problems = append(problems, fmt.Sprintf("restGateway.port must be different from server.port and server.healthCheckPort, got %d", r.Port))
+	}
+	if r.AllIslands < 1 || r.AllIslands > math.MaxUint16 {
+		problems = append(problems, fmt.Sprintf("restGateway.allIslands must be between 1 and %d, got %d", math.MaxUint16, r.AllIslands))
+	}
+	// the tenants use their own tokens in the multi-tenant mode
+	if len(r.Tokens) == 0 && !tenancyConfig.Enabled {
+		problems = append(problems, "restGateway.tokens must contain at least one token if restGateway.enabled is true")
+	}
+	for clientName, token := range r.Tokens {
+		if token == "" {
+			problems = append(problems, fmt.Sprintf("restGateway.tokens[%s] must not be empty", clientName))
+		}
+	}
+	return problems
+}
+
+// validate checks the settings of the enabled backups
+func (b BackupConfig) validate() []string {
+	var problems []string
+	if b.IntervalSec < 60 {
+		problems = append(problems, fmt.Sprintf("backup.intervalSec must be at least 60, got %d", b.IntervalSec))
+	}
+	for _, pattern := range b.Patterns {
+		parts := strings.Split(pattern, "/")
+		if len(parts) != 3 || slices.Contains(parts, "") {
+			problems = append(problems, fmt.Sprintf("backup.patterns must be sanctuary/realm/swamp patterns, got %q", pattern))
+		}
+	}
+	if b.KeepLast < 0 {
+		problems = append(problems, fmt.Sprintf("backup.keepLast must not be negative, got %d", b.KeepLast))
+	}
+	if b.MaxAgeSec < 0 {
+		problems = append(problems, fmt.Sprintf("backup.maxAgeSec must not be negative, got %d", b.MaxAgeSec))
+	}
+	if !strings.HasPrefix(b.S3.Endpoint, "http://") && !strings.HasPrefix(b.S3.Endpoint, "https://") {
+		problems = append(problems, fmt.Sprintf("backup.s3.endpoint must be a http or https URL, got %q", b.S3.Endpoint))
+	}
+	if b.S3.Bucket == "" {
+		problems = append(problems, "backup.s3.bucket is required if backup.enabled is true")
+	}
+	if b.S3.AccessKey == "" || b.S3.SecretKey == "" {
+		problems = append(problems, "backup.s3.accessKey and backup.s3.secretKey are required if backup.enabled is true")
+	}
+	// S3 refuses the parts below 5 MB, except the last one
+	if b.S3.PartSize < 5242880 {
+		problems = append(problems, fmt.Sprintf("backup.s3.partSize must be at least 5242880 bytes, got %d", b.S3.PartSize))
+	}
+	return problems
+}
+
+// validate checks the settings of the enabled write-ahead log
+func (w WALConfig) validate(backup BackupConfig) []string {
+	var problems []string
+	if w.MaxSegmentSize < 1 {
+		problems = append(problems, fmt.Sprintf("wal.maxSegmentSize must be at least 1 byte, got %d", w.MaxSegmentSize))
+	}
+	if w.SyncIntervalMs < 1 {
+		problems = append(problems, fmt.Sprintf("wal.syncIntervalMs must be at least 1, got %d", w.SyncIntervalMs))
+	}
+	if w.MaxAgeSec < 0 {
+		problems = append(problems, fmt.Sprintf("wal.maxAgeSec must not be negative, got %d", w.MaxAgeSec))
+	}
+	// the log must reach back to the previous backup, otherwise the points between the backups are not covered
+	if backup.Enabled && w.MaxAgeSec > 0 && w.MaxAgeSec <= backup.IntervalSec {
+		problems = append(problems, fmt.Sprintf("wal.maxAgeSec must be longer than backup.intervalSec, got %d", w.MaxAgeSec))
+	}
+	return problems
+}
+
+// validate checks the tenants of the enabled multi-tenant mode
+func (t TenancyConfig) validate() []string {
+	var problems []string
+	if len(t.Tenants) == 0 {
+		problems = append(problems, "tenancy.tenants must contain at least one tenant if tenancy.enabled is true")
+	}
+	tokens := make(map[string]string, len(t.Tenants))
+	for tenantID, tenant := range t.Tenants {
+		if !tenantIDPattern.MatchString(tenantID) {
+			problems = append(problems, fmt.Sprintf("tenancy.tenants[%s] has an invalid ID, use 1-64 letters, digits, '-' or '_', starting with a letter or digit", tenantID))
+		}
+		if tenant.Token == "" {
+			problems = append(problems, fmt.Sprintf("tenancy.tenants[%s].token must not be empty", tenantID))
+		} else if other, ok := tokens[tenant.Token]; ok {
+			problems = append(problems, fmt.Sprintf("tenancy.tenants[%s].token must be unique, tenancy.tenants[%s] has the same token", tenantID, other))
+		} else {
+			tokens[tenant.Token] = tenantID
+		}
+		if tenant.MaxTreasuresPerSwamp < 0 {
+			problems = append(problems, fmt.Sprintf("tenancy.tenants[%s].maxTreasuresPerSwamp must not be negative, got %d", tenantID, tenant.MaxTreasuresPerSwamp))
+		}
+		problems = append(problems, tenant.ClientLimitConfig.validate(fmt.Sprintf("tenancy.tenants[%s]", tenantID))...)
+	}
+	return problems
+}
+
+func intSetter(target *int) func(string) error {
+	return func(value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be a number without any string characters")
+		}
+		*target = v
+		return nil
+	}
+}
+
+func int64Setter(target *int64) func(string) error {
+	return func(value string) error {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.New("must be a number without any string characters")
+		}
+		*target = v
+		return nil
+	}
+}
+
+func float64Setter(target *float64) func(string) error {
+	return func(value string) error {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("must be a number without any string characters")
+		}
+		*target = v
+		return nil
+	}
+}
+
+func boolSetter(target *bool) func(string) error {
+	return func(value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*target = v
+		return nil
+	}
+}
+
+func stringSetter(target *string) func(string) error {
+	return func(value string) error {
+		*target = value
+		return nil
+	}
+}
+
+// labelsSetter parses the comma separated key=value pairs, e.g. "env=production,region=eu"
+func labelsSetter(target *map[string]string) func(string) error {
+	return func(value string) error {
+		labels := make(map[string]string)
+		for _, pair := range strings.Split(value, ",") {
+			key, labelValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok || key == "" {
+				return fmt.Errorf("expected comma separated key=value pairs, got %q", pair)
+			}
+			labels[key] = labelValue
+		}
+		*target = labels
+		return nil
+	}
+}
+
+// listSetter parses the comma separated values, e.g. "users/*/*,orders/*/*"
+func listSetter(target *[]string) func(string) error {
+	return func(value string) error {
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		*target = values
+		return nil
+	}
+}
+
+// tokenSetter sets the token of the client in the token map
+func tokenSetter(target *map[string]string, clientName string) func(string) error {
+	return func(value string) error {
+		if *target == nil {
+			*target = make(map[string]string)
+		}
+		(*target)[clientName] = value
+		return nil
+	}
+}
@@ -0,0 +1,402 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+
+	t.Run("should use the defaults without config file", func(t *testing.T) {
+		t.Setenv(EnvRootPath, t.TempDir())
+		t.Setenv(EnvConfigFile, "")
+
+		cfg, path, err := Load()
+		require.NoError(t, err)
+		assert.Empty(t, path)
+		assert.Equal(t, Default(), cfg)
+	})
+
+	t.Run("should load the config file and override it with env", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv(EnvRootPath, root)
+		t.Setenv(EnvConfigFile, "")
+
+		content := `
+server:
+  port: 5555
+  healthCheckPort: 5556
+defaults:
+  fileSize: 65536
+  fsyncPolicy: always
+logging:
+  level: info
+  graylog:
+    enabled: true
+    server: graylog:5140
+    transport: udp
+`
+		require.NoError(t, os.WriteFile(filepath.Join(root, FileName), []byte(content), 0600))
+		t.Setenv("HYDRAIDE_SERVER_PORT", "6666")
+		t.Setenv("SYSTEM_RESOURCE_LOGGING", "true")
+		t.Setenv("HYDRAIDE_FAIL_ON_CORRUPTED_FILES", "true")
+		t.Setenv("HYDRAIDE_WRITE_BATCH_SIZE", "5000")
+		t.Setenv("HYDRAIDE_MAX_CONCURRENT_HYDRATIONS", "16")
+		t.Setenv("HYDRAIDE_MIN_FREE_DISK_PERCENT", "2.5")
+		t.Setenv("HYDRAIDE_WARN_FREE_DISK_PERCENT", "7.5")
+		t.Setenv("HYDRAIDE_KEEPALIVE_TIME", "30")
+		t.Setenv("HYDRAIDE_MAX_CONCURRENT_STREAMS", "500")
+		t.Setenv("HYDRAIDE_AUDIT_ENABLED", "true")
+		t.Setenv("HYDRAIDE_AUDIT_MAX_FILES", "30")
+		t.Setenv("HYDRAIDE_BACKUP_ENABLED", "true")
+		t.Setenv("HYDRAIDE_BACKUP_PATTERNS", "users/*/*, orders/*/*")
+		t.Setenv("HYDRAIDE_BACKUP_S3_ENDPOINT", "http://minio:9000")
+		t.Setenv("HYDRAIDE_BACKUP_S3_BUCKET", "backups")
+		t.Setenv("HYDRAIDE_BACKUP_S3_ACCESS_KEY", "access")
+		t.Setenv("HYDRAIDE_BACKUP_S3_SECRET_KEY", "secret")
+		t.Setenv("HYDRAIDE_WAL_ENABLED", "true")
+		t.Setenv("HYDRAIDE_WAL_SYNC_INTERVAL_MS", "200")
+		t.Setenv("HYDRAIDE_CLUSTER_TOPOLOGY_FILE", "/etc/hydraide/topology.yaml")
+
+		cfg, path, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, FileName), path)
+		assert.Equal(t, 6666, cfg.Server.Port, "env must override the file")
+		assert.Equal(t, 5556, cfg.Server.HealthCheckPort)
+		assert.Equal(t, int64(65536), cfg.Defaults.FileSize)
+		assert.Equal(t, int64(10), cfg.Defaults.WriteIntervalSec, "missing keys must keep the defaults")
+		assert.Equal(t, "always", cfg.Defaults.FsyncPolicy)
+		assert.Equal(t, int64(1), cfg.Defaults.FsyncIntervalSec)
+		assert.Equal(t, "info", cfg.Logging.Level)
+		assert.True(t, cfg.Logging.SystemResourceLogging)
+		assert.Equal(t, "graylog:5140", cfg.Logging.Graylog.Server)
+		assert.Equal(t, "HydrAIDE-Server", cfg.Logging.Graylog.ServiceName)
+		assert.Equal(t, "udp", cfg.Logging.Graylog.Transport)
+		assert.True(t, cfg.Storage.FailOnCorruptedFiles)
+		assert.Equal(t, 5000, cfg.Storage.WriteBatchSize)
+		assert.Equal(t, 16, cfg.Storage.MaxConcurrentHydrations)
+		assert.Equal(t, 2.5, cfg.Telemetry.MinFreeDiskPercent)
+		assert.Equal(t, 7.5, cfg.Telemetry.WarnFreeDiskPercent)
+		assert.Equal(t, int64(30), cfg.Server.Connection.KeepaliveTimeSec)
+		assert.Equal(t, int64(20), cfg.Server.Connection.KeepaliveTimeoutSec, "missing keys must keep the defaults")
+		assert.Equal(t, 500, cfg.Server.Connection.MaxConcurrentStreams)
+		assert.Equal(t, int64(10), cfg.Telemetry.SampleIntervalSec)
+		assert.True(t, cfg.Audit.Enabled)
+		assert.Equal(t, 30, cfg.Audit.MaxFiles)
+		assert.Equal(t, int64(104857600), cfg.Audit.MaxFileSize, "missing keys must keep the defaults")
+		assert.True(t, cfg.Backup.Enabled)
+		assert.Equal(t, []string{"users/*/*", "orders/*/*"}, cfg.Backup.Patterns)
+		assert.Equal(t, "http://minio:9000", cfg.Backup.S3.Endpoint)
+		assert.Equal(t, "backups", cfg.Backup.S3.Bucket)
+		assert.Equal(t, int64(86400), cfg.Backup.IntervalSec, "missing keys must keep the defaults")
+		assert.Equal(t, 7, cfg.Backup.KeepLast)
+		assert.True(t, cfg.WAL.Enabled)
+		assert.Equal(t, int64(200), cfg.WAL.SyncIntervalMs)
+		assert.Equal(t, int64(691200), cfg.WAL.MaxAgeSec, "missing keys must keep the defaults")
+		assert.Equal(t, "/etc/hydraide/topology.yaml", cfg.Cluster.TopologyFile)
+		assert.Equal(t, int64(10), cfg.Cluster.CheckIntervalSec, "missing keys must keep the defaults")
+	})
+
+	t.Run("should apply the defaults to the unregistered swamps", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+
+		content := `
+defaults:
+  writeIntervalSec: 2
+  applyToUnregisteredSwamps: false
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		t.Setenv("HYDRAIDE_DEFAULT_APPLY_TO_UNREGISTERED_SWAMPS", "true")
+
+		cfg, _, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), cfg.Defaults.WriteIntervalSec)
+		assert.True(t, cfg.Defaults.ApplyToUnregisteredSwamps, "env must override the file")
+	})
+
+	t.Run("should load the rate limits", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+
+		content := `
+limits:
+  maxTreasuresPerSwamp: 1000000
+  maxKeyLength: 256
+  rateLimit:
+    enabled: true
+    requestsPerSecond: 500
+    writeBytesPerSecond: 1048576
+    clients:
+      importer:
+        requestsPerSecond: 50
+        requestBurst: 100
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		t.Setenv("HYDRAIDE_RATE_LIMIT_REQUEST_BURST", "1000")
+		t.Setenv("HYDRAIDE_MAX_VALUE_SIZE", "1048576")
+
+		cfg, _, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 1000000, cfg.Limits.MaxTreasuresPerSwamp)
+		assert.Equal(t, 256, cfg.Limits.MaxKeyLength)
+		assert.Equal(t, int64(1048576), cfg.Limits.MaxValueSize)
+		assert.True(t, cfg.Limits.RateLimit.Enabled)
+		assert.Equal(t, float64(500), cfg.Limits.RateLimit.RequestsPerSecond)
+		assert.Equal(t, 1000, cfg.Limits.RateLimit.RequestBurst)
+		assert.Equal(t, int64(1048576), cfg.Limits.RateLimit.WriteBytesPerSecond)
+		assert.Equal(t, ClientLimitConfig{RequestsPerSecond: 50, RequestBurst: 100}, cfg.Limits.RateLimit.Clients["importer"])
+	})
+
+	t.Run("should load the loki settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+
+		content := `
+logging:
+  loki:
+    enabled: true
+    url: http://loki:3100
+    labels:
+      env: staging
+    batchSize: 1000
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		t.Setenv("LOKI_LABELS", "env=production, region=eu")
+		t.Setenv("LOKI_TENANT_ID", "hydraide")
+
+		cfg, _, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.Logging.Loki.Enabled)
+		assert.Equal(t, "http://loki:3100", cfg.Logging.Loki.URL)
+		assert.Equal(t, "HydrAIDE-Server", cfg.Logging.Loki.ServiceName)
+		assert.Equal(t, map[string]string{"env": "production", "region": "eu"}, cfg.Logging.Loki.Labels, "env must override the file")
+		assert.Equal(t, "hydraide", cfg.Logging.Loki.TenantID)
+		assert.Equal(t, 1000, cfg.Logging.Loki.BatchSize)
+	})
+
+	t.Run("should fail on the invalid labels", func(t *testing.T) {
+		t.Setenv(EnvRootPath, t.TempDir())
+		t.Setenv(EnvConfigFile, "")
+		t.Setenv("LOKI_LABELS", "env")
+
+		_, _, err := Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "LOKI_LABELS")
+	})
+
+	t.Run("should load the tracing settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+
+		content := `
+tracing:
+  enabled: true
+  endpoint: otel-collector:4318
+  insecure: true
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		t.Setenv("HYDRAIDE_TRACING_SAMPLE_RATIO", "0.25")
+		t.Setenv("HYDRAIDE_SLOW_OPERATION_THRESHOLD_MS", "250")
+		t.Setenv("HYDRAIDE_ACCESS_LOG_ENABLED", "true")
+		t.Setenv("HYDRAIDE_ACCESS_LOG_SAMPLE_RATIO", "0.1")
+		t.Setenv("HYDRAIDE_ACCESS_LOG_READS", "false")
+
+		cfg, _, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.Tracing.Enabled)
+		assert.Equal(t, "otel-collector:4318", cfg.Tracing.Endpoint)
+		assert.True(t, cfg.Tracing.Insecure)
+		assert.Equal(t, "HydrAIDE-Server", cfg.Tracing.ServiceName)
+		assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+		assert.Equal(t, int64(250), cfg.Logging.SlowOperationThresholdMs)
+		assert.True(t, cfg.Logging.AccessLog.Enabled)
+		assert.Equal(t, 0.1, cfg.Logging.AccessLog.SampleRatio)
+		assert.False(t, cfg.Logging.AccessLog.Reads)
+		assert.True(t, cfg.Logging.AccessLog.Writes)
+		assert.True(t, cfg.Logging.AccessLog.Streams)
+	})
+
+	t.Run("should load the REST gateway settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+
+		content := `
+restGateway:
+  enabled: true
+  port: 8443
+  tokens:
+    scripts: s3cret
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		t.Setenv("HYDRAIDE_REST_GATEWAY_TOKEN", "from-env")
+
+		cfg, _, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.RestGateway.Enabled)
+		assert.Equal(t, 8443, cfg.RestGateway.Port)
+		assert.Equal(t, 1000, cfg.RestGateway.AllIslands)
+		assert.Equal(t, map[string]string{"scripts": "s3cret", DefaultRestGatewayClient: "from-env"}, cfg.RestGateway.Tokens)
+	})
+
+	t.Run("should load the tenants", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+
+		content := `
+restGateway:
+  enabled: true
+tenancy:
+  enabled: true
+  tenants:
+    acme:
+      token: acme-s3cret
+      requestsPerSecond: 50
+      maxTreasuresPerSwamp: 1000
+    globex:
+      token: globex-s3cret
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		cfg, _, err := Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.Tenancy.Enabled)
+		assert.Equal(t, TenantConfig{
+			Token:                "acme-s3cret",
+			MaxTreasuresPerSwamp: 1000,
+			ClientLimitConfig:    ClientLimitConfig{RequestsPerSecond: 50},
+		}, cfg.Tenancy.Tenants["acme"])
+		assert.Equal(t, "globex-s3cret", cfg.Tenancy.Tenants["globex"].Token)
+	})
+
+	t.Run("should reject unknown keys", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.yaml")
+		t.Setenv(EnvConfigFile, path)
+		require.NoError(t, os.WriteFile(path, []byte("server:\n  prot: 5555\n"), 0600))
+
+		_, _, err := Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "prot")
+	})
+
+	t.Run("should fail if the explicit config file is missing", func(t *testing.T) {
+		t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing.yaml"))
+		_, _, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("should report the invalid env variable", func(t *testing.T) {
+		t.Setenv(EnvRootPath, t.TempDir())
+		t.Setenv(EnvConfigFile, "")
+		t.Setenv("HEALTH_CHECK_PORT", "abc")
+
+		_, _, err := Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HEALTH_CHECK_PORT")
+	})
+
+}
+
+func TestValidate(t *testing.T) {
+
+	cfg := Default()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Server.HealthCheckPort = cfg.Server.Port
+	cfg.Logging.Level = "verbose"
+	cfg.Limits.MaxMessageSize = 0
+	cfg.Limits.RateLimit.Clients = map[string]ClientLimitConfig{"importer": {RequestsPerSecond: -1}}
+	cfg.Tracing.SampleRatio = 2
+	cfg.Logging.SlowOperationThresholdMs = -1
+	cfg.Logging.AccessLog.SampleRatio = 1.5
+	cfg.RestGateway.Enabled = true
+	cfg.RestGateway.AllIslands = 0
+	cfg.Storage.WriteBatchSize = -1
+	cfg.Storage.MaxConcurrentHydrations = -1
+	cfg.Telemetry.SampleIntervalSec = 0
+	cfg.Telemetry.MinFreeDiskPercent = 100
+	cfg.Telemetry.WarnFreeDiskPercent = -1
+	cfg.Server.Connection.KeepaliveMinTimeSec = -1
+	cfg.Server.Connection.InitialWindowSize = 1024
+	cfg.Logging.Graylog.Transport = "http"
+	cfg.Logging.Graylog.Enabled = true
+	cfg.Logging.Graylog.Server = "graylog:12201"
+	cfg.Logging.Loki.Enabled = true
+	cfg.Logging.OpenSearch.BatchSize = -1
+	cfg.Audit.MaxFiles = -1
+	cfg.Limits.MaxKeyLength = -1
+	cfg.Backup.Enabled = true
+	cfg.Backup.Patterns = []string{"users/*"}
+	cfg.Backup.S3.PartSize = 1024
+	cfg.WAL.Enabled = true
+	cfg.WAL.SyncIntervalMs = 0
+	cfg.WAL.MaxAgeSec = 3600
+	cfg.Cluster.TopologyFile = "topology.yaml"
+	cfg.Cluster.CheckIntervalSec = 0
+	cfg.Defaults.FsyncPolicy = "sometimes"
+	cfg.Defaults.FsyncIntervalSec = 0
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.healthCheckPort must be different")
+	assert.Contains(t, err.Error(), "logging.level")
+	assert.Contains(t, err.Error(), "limits.maxMessageSize")
+	assert.Contains(t, err.Error(), "limits.rateLimit.clients[importer].requestsPerSecond")
+	assert.Contains(t, err.Error(), "tracing.sampleRatio")
+	assert.Contains(t, err.Error(), "logging.slowOperationThresholdMs")
+	assert.Contains(t, err.Error(), "logging.accessLog.sampleRatio")
+	assert.Contains(t, err.Error(), "limits.maxKeyLength")
+	assert.Contains(t, err.Error(), "restGateway.allIslands")
+	assert.Contains(t, err.Error(), "restGateway.tokens")
+	assert.Contains(t, err.Error(), "storage.writeBatchSize")
+	assert.Contains(t, err.Error(), "storage.maxConcurrentHydrations")
+	assert.Contains(t, err.Error(), "telemetry.sampleIntervalSec")
+	assert.Contains(t, err.Error(), "telemetry.minFreeDiskPercent")
+	assert.Contains(t, err.Error(), "telemetry.warnFreeDiskPercent")
+	assert.Contains(t, err.Error(), "server.connection.keepaliveMinTimeSec")
+	assert.Contains(t, err.Error(), "server.connection.initialWindowSize")
+	assert.Contains(t, err.Error(), "logging.graylog.transport")
+	assert.Contains(t, err.Error(), "logging.loki.url is required")
+	assert.Contains(t, err.Error(), "logging.opensearch.batchSize")
+	assert.Contains(t, err.Error(), "audit.maxFiles")
+	assert.Contains(t, err.Error(), `backup.patterns must be sanctuary/realm/swamp patterns, got "users/*"`)
+	assert.Contains(t, err.Error(), "backup.s3.endpoint")
+	assert.Contains(t, err.Error(), "backup.s3.bucket is required")
+	assert.Contains(t, err.Error(), "backup.s3.accessKey and backup.s3.secretKey are required")
+	assert.Contains(t, err.Error(), "backup.s3.partSize")
+	assert.Contains(t, err.Error(), "wal.syncIntervalMs")
+	assert.Contains(t, err.Error(), "wal.maxAgeSec must be longer than backup.intervalSec")
+	assert.Contains(t, err.Error(), "cluster.checkIntervalSec")
+	assert.Contains(t, err.Error(), "defaults.fsyncPolicy")
+	assert.Contains(t, err.Error(), "defaults.fsyncIntervalSec")
+	assert.Contains(t, err.Error(), "at most one of logging.graylog, logging.loki and logging.opensearch may be enabled, got graylog, loki")
+
+}
+
+func TestValidate_Tenancy(t *testing.T) {
+
+	cfg := Default()
+	cfg.Tenancy.Enabled = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tenancy.tenants must contain at least one tenant")
+
+	cfg.Tenancy.Tenants = map[string]TenantConfig{
+		"../acme":  {Token: "a"},
+		"globex":   {},
+		"initech":  {Token: "b", MaxTreasuresPerSwamp: -1},
+		"umbrella": {Token: "b", ClientLimitConfig: ClientLimitConfig{RequestBurst: -1}},
+	}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tenancy.tenants[../acme] has an invalid ID")
+	assert.Contains(t, err.Error(), "tenancy.tenants[globex].token must not be empty")
+	assert.Contains(t, err.Error(), "must be unique")
+	assert.Contains(t, err.Error(), "tenancy.tenants[initech].maxTreasuresPerSwamp")
+	assert.Contains(t, err.Error(), "tenancy.tenants[umbrella].requestBurst")
+
+}
@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra/blob"
+	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"strconv"
+	"time"
+)
+
+const (
+	// ErrorDomain is the domain of the ErrorInfo details attached to the gRPC errors of the server
+	ErrorDomain = "hydraide"
+	// retryPushbackTrailer is the trailer of the gRPC retry policy that tells the client when to retry the call
+	retryPushbackTrailer = "grpc-retry-pushback-ms"
+)
+
+// statusError creates a gRPC error with the given code and message, and attaches the machine-readable reason as
+// a google.rpc.ErrorInfo detail, so the SDKs don't have to parse the message to find out what went wrong.
+func statusError(code codes.Code, reason hydrapb.ErrorReason_Reason, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason.String(),
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		// the details can not be marshaled, fall back to the plain status
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// hydraError converts an error of the hydra to a gRPC error. A corrupted file of the swamp is a DataLoss error with
+// the DATA_CORRUPTED reason, every other error is an internal error.
+func hydraError(err error) error {
+	if errors.Is(err, filesystem.ErrCorruptedFile) {
+		return statusError(codes.DataLoss, hydrapb.ErrorReason_DATA_CORRUPTED, fmt.Sprintf("the swamp can not be loaded: %s", err.Error()))
+	}
+	return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+}
+
+// blobError converts an error of the blob store to a gRPC error
+func blobError(err error) error {
+	switch {
+	case errors.Is(err, blob.ErrBlobNotFound):
+		return statusError(codes.NotFound, hydrapb.ErrorReason_BLOB_NOT_FOUND, err.Error())
+	case errors.Is(err, blob.ErrInvalidHash), errors.Is(err, blob.ErrNegativeReferences):
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+	default:
+		return hydraError(err)
+	}
+}
+
+// patternConflictError creates an AlreadyExists gRPC error with the PATTERN_CONFLICT reason, and attaches the
+// conflicting registered pattern as a SwampPattern detail, so the client can see its settings and its registrant
+func patternConflictError(pattern string, conflicting settings.PatternModel) error {
+	message := fmt.Sprintf("swamp pattern %s conflicts with the registered pattern %s: inMemory=%t closeAfterIdle=%ds writeInterval=%ds maxFileSize=%d fsync=%s registeredBy=%q",
+		pattern, conflicting.NameCanonicalForm, conflicting.InMemory, conflicting.CloseAfterIdleSec, conflicting.WriteIntervalSec,
+		conflicting.MaxFileSizeByte, conflicting.FsyncPolicy.Or(setting.FsyncNever), conflicting.RegisteredBy)
+	st := status.New(codes.AlreadyExists, message)
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: hydrapb.ErrorReason_PATTERN_CONFLICT.String(),
+			Domain: ErrorDomain,
+		},
+		swampPatternToProto(conflicting),
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// QuotaExceededError creates a ResourceExhausted gRPC error with the QUOTA_EXCEEDED reason, and attaches the time
+// the client should wait before retrying as a google.rpc.RetryInfo detail.
+func QuotaExceededError(message string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, message)
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: hydrapb.ErrorReason_QUOTA_EXCEEDED.String(),
+			Domain: ErrorDomain,
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryAfter),
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// The names of the limits in the metadata of the ErrorInfo of the limit errors
+const (
+	LimitMaxTreasuresPerSwamp = "maxTreasuresPerSwamp"
+	LimitMaxKeyLength         = "maxKeyLength"
+	LimitMaxValueSize         = "maxValueSize"
+)
+
+// limitError creates a gRPC error for a request over a limit of the server. The ErrorInfo metadata contains the name
+// of the limit, its max and the actual value of the request, so the client can tell which limit it hit.
+func limitError(code codes.Code, reason hydrapb.ErrorReason_Reason, limit string, max int64, actual int64, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason.String(),
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			"limit":  limit,
+			"max":    strconv.FormatInt(max, 10),
+			"actual": strconv.FormatInt(actual, 10),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// LimitExceededError creates an InvalidArgument gRPC error with the LIMIT_EXCEEDED reason, for a key or a value
+// larger than the limit of the server. The same request is never accepted, so it must not be retried.
+func LimitExceededError(limit string, max int64, actual int64, message string) error {
+	return limitError(codes.InvalidArgument, hydrapb.ErrorReason_LIMIT_EXCEEDED, limit, max, actual, message)
+}
+
+// MessageTooLargeError creates a ResourceExhausted gRPC error with the MESSAGE_TOO_LARGE reason, for a request or a
+// response that is larger than the max message size of the server.
+func MessageTooLargeError(message string) error {
+	return statusError(codes.ResourceExhausted, hydrapb.ErrorReason_MESSAGE_TOO_LARGE, message)
+}
+
+// InsufficientStorageError creates a ResourceExhausted gRPC error with the INSUFFICIENT_STORAGE reason, for a write
+// refused because the disk of the server is almost full.
+func InsufficientStorageError(message string) error {
+	return statusError(codes.ResourceExhausted, hydrapb.ErrorReason_INSUFFICIENT_STORAGE, message)
+}
+
+// ConsistencyNotReachedError creates a FailedPrecondition gRPC error with the CONSISTENCY_NOT_REACHED reason, for a
+// request whose consistency token has writes the server does not have.
+func ConsistencyNotReachedError(message string) error {
+	return statusError(codes.FailedPrecondition, hydrapb.ErrorReason_CONSISTENCY_NOT_REACHED, message)
+}
+
+// IslandMigratingError creates an Unavailable gRPC error with the ISLAND_MIGRATING reason, for a write of an Island
+// frozen by its migration. The write can be retried after the migration.
+func IslandMigratingError(islandID uint64) error {
+	return statusError(codes.Unavailable, hydrapb.ErrorReason_ISLAND_MIGRATING, fmt.Sprintf("the island %d is being migrated, the writes are refused until it is done", islandID))
+}
+
+// IslandMovedError creates a FailedPrecondition gRPC error with the ISLAND_MOVED reason, for a request of an Island
+// moved to an other server. The message contains the host of the new server.
+func IslandMovedError(islandID uint64, host string) error {
+	return statusError(codes.FailedPrecondition, hydrapb.ErrorReason_ISLAND_MOVED, fmt.Sprintf("the island %d moved to %s", islandID, host))
+}
+
+// SetRetryPushback tells the retry policy of the gRPC client when it can retry the failed call.
+// A negative duration tells the client not to retry at all, because the call would fail again.
+func SetRetryPushback(ctx context.Context, retryAfter time.Duration) {
+	pushback := "-1"
+	if retryAfter >= 0 {
+		pushback = strconv.FormatInt(retryAfter.Milliseconds(), 10)
+	}
+	// the error is ignored, because the trailer is only a hint for the client
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(retryPushbackTrailer, pushback))
+}
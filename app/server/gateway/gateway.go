@@ -2,39 +2,90 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/hydraide/hydraide/app/core/aggregate"
+	"github.com/hydraide/hydraide/app/core/filter"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/core/hydra/blob"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/metadata"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
 	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
 	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/core/settings/defaults"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
+	"github.com/hydraide/hydraide/app/core/sliceset"
 	"github.com/hydraide/hydraide/app/core/zeus"
 	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/audit"
+	"github.com/hydraide/hydraide/app/server/backup"
+	"github.com/hydraide/hydraide/app/server/cluster"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/hydraide/hydraide/app/server/migration"
 	"github.com/hydraide/hydraide/app/server/observer"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	"github.com/hydraide/hydraide/app/server/subscriber"
 	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
 	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
 	"runtime/debug"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type Gateway struct {
 	hydrapb.UnimplementedHydraideServiceServer
-	ObserverInterface     observer.Observer
-	SettingsInterface     settings.Settings
-	ZeusInterface         zeus.Zeus
-	DefaultCloseAfterIdle int64
-	DefaultWriteInterval  int64
-	DefaultFileSize       int64
+	ObserverInterface observer.Observer
+	// SettingsInterface holds the patterns of the swamps, and the defaults of the patterns registered without their
+	// own settings
+	SettingsInterface settings.Settings
+	ZeusInterface     zeus.Zeus
+	// DefaultsReloader reloads the default swamp settings from the configuration by the ReloadDefaults. Nil means
+	// the defaults can not be reloaded, e.g. by a tenant
+	DefaultsReloader func() (defaults.Values, error)
+	// MaxTreasuresPerSwamp is the maximum number of treasures in one swamp. Set requests that would create more
+	// treasures are rejected with InvalidArgument and the LIMIT_EXCEEDED reason. Zero means unlimited
+	MaxTreasuresPerSwamp int
+	// MaxKeyLength is the maximum length of a written key in bytes, for every swamp. The longer keys are rejected
+	// with InvalidArgument and the LIMIT_EXCEEDED reason. Zero means unlimited
+	MaxKeyLength int
+	// MaxValueSize is the maximum size of a written bytes, string or uint32 slice value in bytes, for every swamp,
+	// including the large values. The larger values are rejected with InvalidArgument and the LIMIT_EXCEEDED reason.
+	// Zero means unlimited
+	MaxValueSize int64
+	// Metrics counts the dropped events and the cancelled subscriptions of the slow subscribers. Nil means they are
+	// not exposed
+	Metrics metrics.Registry
+	// Version is the release version of the server, returned by the Heartbeat
+	Version string
+	// AuditLog is the audit log queried by the QueryAuditLog. Nil means the audit log is not enabled
+	AuditLog audit.Log
+	// Recovery restores the swamps by the RestorePointInTime. Nil means the backups or the write-ahead log are not
+	// enabled
+	Recovery backup.Recovery
+	// Topology serves the cluster topology by the GetClusterTopology. Nil means the topology file is not configured
+	Topology cluster.Source
+	// Islands are the migration states of the Islands. Nil means the Islands can not be migrated, e.g. by a tenant
+	Islands migration.Islands
 }
 
 func (g Gateway) Heartbeat(_ context.Context, in *hydrapb.HeartbeatRequest) (*hydrapb.HeartbeatResponse, error) {
 	return &hydrapb.HeartbeatResponse{
-		Pong: in.Ping,
+		Pong:          in.Ping,
+		ServerVersion: g.Version,
+		ServerTime:    timestamppb.Now(),
 	}, nil
 }
 
@@ -52,7 +103,7 @@ func (g Gateway) Lock(ctx context.Context, in *hydrapb.LockRequest) (*hydrapb.Lo
 	lockID, err := lockerInterface.Lock(ctxForLocker, in.GetKey(), time.Duration(in.GetTTL())*time.Millisecond)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.DeadlineExceeded, fmt.Sprintf("lock deadline exceeded: %s", err.Error()))
+		return nil, statusError(codes.DeadlineExceeded, hydrapb.ErrorReason_LOCK_DEADLINE_EXCEEDED, fmt.Sprintf("lock deadline exceeded: %s", err.Error()))
 	}
 
 	return &hydrapb.LockResponse{
@@ -70,13 +121,13 @@ func (g Gateway) Unlock(_ context.Context, in *hydrapb.UnlockRequest) (*hydrapb.
 	// unlock the system
 	if err := lockerInterface.Unlock(in.GetKey(), in.GetLockID()); err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("lock not found: %s", err.Error()))
+		return nil, statusError(codes.NotFound, hydrapb.ErrorReason_LOCK_NOT_FOUND, fmt.Sprintf("lock not found: %s", err.Error()))
 	}
 	return &hydrapb.UnlockResponse{}, nil
 
 }
 
-func (g Gateway) RegisterSwamp(_ context.Context, in *hydrapb.RegisterSwampRequest) (*hydrapb.RegisterSwampResponse, error) {
+func (g Gateway) RegisterSwamp(ctx context.Context, in *hydrapb.RegisterSwampRequest) (*hydrapb.RegisterSwampResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
@@ -85,13 +136,69 @@ func (g Gateway) RegisterSwamp(_ context.Context, in *hydrapb.RegisterSwampReque
 
 	if in.SwampPattern == "" {
 		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampPattern cannot be empty")
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampPattern cannot be empty")
 	}
 
+	if in.GetMaxTreasureAge() < 0 || in.GetMaxTreasures() < 0 || in.GetMaxSwampSize() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the retention limits cannot be negative")
+	}
+
+	if in.GetDefaultExpireAfter() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "DefaultExpireAfter cannot be negative")
+	}
+
+	if in.GetMaxKeyLength() < 0 || in.GetMaxValueSize() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the schema constraints cannot be negative")
+	}
+
+	fsyncPolicy, ok := fsyncPolicies[in.GetFsync()]
+	if !ok {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("unknown fsync policy: %d", in.GetFsync()))
+	}
+
+	if in.GetFsyncInterval() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "FsyncInterval cannot be negative")
+	}
+
+	requiredMetadata := make([]string, 0, len(in.GetRequiredMetadata()))
+	for _, field := range in.GetRequiredMetadata() {
+		metadataName, ok := metadataFields[field]
+		if !ok {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("unknown metadata field in RequiredMetadata: %d", field))
+		}
+		if !slices.Contains(requiredMetadata, metadataName) {
+			requiredMetadata = append(requiredMetadata, metadataName)
+		}
+	}
+	if len(requiredMetadata) == 0 {
+		// nil, so the registration without constraints equals the stored one
+		requiredMetadata = nil
+	}
+
+	if in.GetBeaconEvictAfterIdle() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "BeaconEvictAfterIdle cannot be negative")
+	}
+
+	var keptBeacons []string
+	for _, beaconType := range in.GetKeptBeacons() {
+		beaconName, ok := beaconNames[beaconType]
+		if !ok {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("unknown beacon in KeptBeacons: %d", beaconType))
+		}
+		if !slices.Contains(keptBeacons, beaconName) {
+			keptBeacons = append(keptBeacons, beaconName)
+		}
+	}
+	// sorted, so the order of the request does not change the registered pattern
+	slices.Sort(keptBeacons)
+
 	// try to create the pattern from the input string
 	swampPattern := name.Load(in.SwampPattern)
 
-	closeAfterIdle := g.DefaultCloseAfterIdle
+	// the defaults can be reloaded at any time, so they are read once for the whole registration
+	swampDefaults := g.SettingsInterface.GetDefaults().Get()
+
+	closeAfterIdle := swampDefaults.CloseAfterIdleSec
 
 	if in.CloseAfterIdle > 0 {
 		closeAfterIdle = in.CloseAfterIdle
@@ -104,24 +211,63 @@ func (g Gateway) RegisterSwamp(_ context.Context, in *hydrapb.RegisterSwampReque
 		if in.WriteInterval != nil && *in.WriteInterval > 0 {
 			fss.WriteIntervalSec = *in.WriteInterval
 		} else {
-			fss.WriteIntervalSec = g.DefaultWriteInterval
+			fss.WriteIntervalSec = swampDefaults.WriteIntervalSec
 		}
 
 		if in.MaxFileSize != nil && *in.MaxFileSize > 0 {
 			fss.MaxFileSizeByte = *in.MaxFileSize
 		} else {
-			fss.MaxFileSizeByte = g.DefaultFileSize
+			fss.MaxFileSizeByte = swampDefaults.MaxFileSizeByte
+		}
+
+		fss.FsyncPolicy = fsyncPolicy.Or(swampDefaults.FsyncPolicy).Or(setting.FsyncNever)
+		if fss.FsyncPolicy == setting.FsyncInterval {
+			fss.FsyncIntervalSec = in.GetFsyncInterval()
+			if fss.FsyncIntervalSec == 0 {
+				fss.FsyncIntervalSec = swampDefaults.FsyncIntervalSec
+			}
+			if fss.FsyncIntervalSec == 0 {
+				fss.FsyncIntervalSec = defaultFsyncInterval
+			}
 		}
 
+		fss.KeyFilter = in.GetKeyFilter()
+		fss.LazyHydration = in.GetLazyHydration()
+
 	}
 
-	g.SettingsInterface.RegisterPattern(swampPattern, in.IsInMemorySwamp, closeAfterIdle, fss)
+	// the system lock serializes the registrations, so the checked patterns can not change until the registration
+	if conflicting, ok := g.SettingsInterface.ConflictingPattern(swampPattern, in.IsInMemorySwamp, closeAfterIdle, fss); ok {
+		if !in.GetForce() {
+			return nil, patternConflictError(swampPattern.Get(), conflicting)
+		}
+		slog.Warn("swamp pattern registration is forced over a conflicting pattern", "pattern", swampPattern.Get(),
+			"conflictingPattern", conflicting.NameCanonicalForm, "conflictingRegisteredBy", conflicting.RegisteredBy)
+	}
+
+	g.SettingsInterface.RegisterPattern(swampPattern, in.IsInMemorySwamp, closeAfterIdle, fss, &settings.PatternOptions{
+		ValueIndex:            in.GetValueIndex(),
+		EventJournalSize:      int(in.GetEventJournalSize()),
+		EventJournalRetention: time.Duration(in.GetEventJournalRetention()) * time.Second,
+		ServerTimestamps:      in.GetServerTimestamps(),
+		HistoryDepth:          int(in.GetHistoryDepth()),
+		MaxTreasureAge:        time.Duration(in.GetMaxTreasureAge()) * time.Second,
+		MaxTreasures:          int(in.GetMaxTreasures()),
+		MaxSwampSizeByte:      in.GetMaxSwampSize(),
+		DefaultExpireAfter:    time.Duration(in.GetDefaultExpireAfter()) * time.Second,
+		MaxKeyLength:          int(in.GetMaxKeyLength()),
+		MaxValueSizeByte:      in.GetMaxValueSize(),
+		RequiredMetadata:      requiredMetadata,
+		KeptBeacons:           keptBeacons,
+		BeaconEvictAfterIdle:  time.Duration(in.GetBeaconEvictAfterIdle()) * time.Second,
+		RegisteredBy:          ratelimit.ClientIdentity(ctx),
+	})
 
 	return &hydrapb.RegisterSwampResponse{}, nil
 
 }
 
-func (g Gateway) DeRegisterSwamp(_ context.Context, in *hydrapb.DeRegisterSwampRequest) (*hydrapb.DeRegisterSwampResponse, error) {
+func (g Gateway) DeRegisterSwamp(ctx context.Context, in *hydrapb.DeRegisterSwampRequest) (*hydrapb.DeRegisterSwampResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
@@ -130,16 +276,116 @@ func (g Gateway) DeRegisterSwamp(_ context.Context, in *hydrapb.DeRegisterSwampR
 
 	if in.SwampPattern == "" {
 		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampPattern cannot be empty")
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampPattern cannot be empty")
 	}
 
 	// try to create the pattern from the input string
 	swampPattern := name.Load(in.SwampPattern)
 
+	response := &hydrapb.DeRegisterSwampResponse{}
+
+	// the swamps are destroyed while the pattern is registered, because their settings are needed to summon them
+	if in.GetPurge() || in.GetDryRun() {
+		if strings.Count(in.SwampPattern, "/") != 2 {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("invalid swamp pattern: %s", in.SwampPattern))
+		}
+		purgedSwamps, err := g.ZeusInterface.GetHydra().DestroySwamps(ctx, swampPattern, in.GetDryRun(), nil)
+		if err != nil {
+			return nil, hydraError(err)
+		}
+		for _, swampName := range purgedSwamps {
+			response.PurgedSwamps = append(response.PurgedSwamps, swampName.Get())
+		}
+		if in.GetDryRun() {
+			return response, nil
+		}
+	}
+
 	g.SettingsInterface.DeregisterPattern(swampPattern)
 
-	return &hydrapb.DeRegisterSwampResponse{}, nil
+	return response, nil
+
+}
+
+// ListSwampPatterns lists the registered swamp patterns with their settings
+func (g Gateway) ListSwampPatterns(_ context.Context, _ *hydrapb.ListSwampPatternsRequest) (*hydrapb.ListSwampPatternsResponse, error) {
+
+	defer handlePanic()
+
+	patterns := g.SettingsInterface.ListPatterns()
+
+	response := &hydrapb.ListSwampPatternsResponse{
+		Patterns: make([]*hydrapb.SwampPattern, 0, len(patterns)),
+	}
+	for _, pattern := range patterns {
+		response.Patterns = append(response.Patterns, swampPatternToProto(pattern))
+	}
+
+	return response, nil
+
+}
+
+// UpdateSwampPattern changes the idle time, the write interval and the file size of a registered swamp pattern. The
+// swamps opened after it get the new settings
+func (g Gateway) UpdateSwampPattern(_ context.Context, in *hydrapb.UpdateSwampPatternRequest) (*hydrapb.UpdateSwampPatternResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.GetSwampPattern() == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampPattern cannot be empty")
+	}
+
+	if (in.CloseAfterIdle != nil && in.GetCloseAfterIdle() <= 0) ||
+		(in.WriteInterval != nil && in.GetWriteInterval() <= 0) ||
+		(in.MaxFileSize != nil && in.GetMaxFileSize() <= 0) {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "CloseAfterIdle, WriteInterval and MaxFileSize must be positive if they are set")
+	}
+
+	updated, err := g.SettingsInterface.UpdatePattern(name.Load(in.GetSwampPattern()), settings.PatternUpdate{
+		CloseAfterIdleSec: in.GetCloseAfterIdle(),
+		WriteIntervalSec:  in.GetWriteInterval(),
+		MaxFileSizeByte:   in.GetMaxFileSize(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, settings.ErrPatternNotFound):
+			return nil, statusError(codes.NotFound, hydrapb.ErrorReason_SWAMP_PATTERN_NOT_FOUND, fmt.Sprintf("swamp pattern is not registered: %s", in.GetSwampPattern()))
+		case errors.Is(err, settings.ErrInMemoryPattern):
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+		default:
+			return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, err.Error())
+		}
+	}
+
+	return &hydrapb.UpdateSwampPatternResponse{
+		Pattern: swampPatternToProto(updated),
+	}, nil
+
+}
 
+// swampPatternToProto converts the registered pattern to the SwampPattern of the responses
+func swampPatternToProto(pattern settings.PatternModel) *hydrapb.SwampPattern {
+	swampPattern := &hydrapb.SwampPattern{
+		SwampPattern:    pattern.NameCanonicalForm,
+		IsInMemorySwamp: pattern.InMemory,
+		CloseAfterIdle:  pattern.CloseAfterIdleSec,
+		WriteInterval:   pattern.WriteIntervalSec,
+		MaxFileSize:     pattern.MaxFileSizeByte,
+		FsyncInterval:   pattern.FsyncIntervalSec,
+		RegisteredBy:    pattern.RegisteredBy,
+	}
+	if pattern.RegisteredAt > 0 {
+		swampPattern.RegisteredAt = timestamppb.New(time.Unix(pattern.RegisteredAt, 0))
+	}
+	if !pattern.InMemory {
+		swampPattern.Fsync = fsyncPolicyToProto(pattern.FsyncPolicy)
+		swampPattern.KeyFilter = pattern.KeyFilter
+		swampPattern.LazyHydration = pattern.LazyHydration
+	}
+	return swampPattern
 }
 
 func (g Gateway) Set(ctx context.Context, in *hydrapb.SetRequest) (*hydrapb.SetResponse, error) {
@@ -153,15 +399,31 @@ func (g Gateway) Set(ctx context.Context, in *hydrapb.SetRequest) (*hydrapb.SetR
 	for _, swampRequest := range in.GetSwamps() {
 		// check if the swamp name is valid and exist or not
 		// we don't need to check the existence of the swamp because we will create it if it does not exist
-		if _, err := checkSwampName(g.ZeusInterface, swampRequest.GetIslandID(), swampRequest.SwampName, false); err != nil {
+		swampName, err := checkSwampName(g.ZeusInterface, swampRequest.GetIslandID(), swampRequest.SwampName, false)
+		if err != nil {
 			return nil, err
 		}
 		if swampRequest.GetKeyValues() == nil {
 			// return with grpc error message
-			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("KeyValues cannot be empty for the swamp: %s", swampRequest.GetSwampName()))
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("KeyValues cannot be empty for the swamp: %s", swampRequest.GetSwampName()))
+		}
+		// nothing of the request is written if a treasure violates the limits of the server or the constraints of
+		// its swamp
+		if err := g.checkWriteLimits(swampRequest); err != nil {
+			return nil, err
+		}
+		if err := checkSchemaConstraints(g.SettingsInterface.GetBySwampName(swampName), swampRequest); err != nil {
+			return nil, err
 		}
 	}
 
+	// nothing of the request is written if a swamp would exceed the max treasures per swamp
+	releaseReservations, err := g.reserveNewTreasures(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseReservations()
+
 	// try to summon the swamp
 	hydraInterface := g.ZeusInterface.GetHydra()
 
@@ -218,34 +480,39 @@ func (g Gateway) Set(ctx context.Context, in *hydrapb.SetRequest) (*hydrapb.SetR
 
 			for _, item := range swampRequest.GetKeyValues() {
 
-				// if "create if not" exist is false and the treasure does not exist
-				if !swampRequest.GetCreateIfNotExist() && !swampInterface.TreasureExists(item.Key) {
-					response = append(response, &hydrapb.KeyStatusPair{
-						Key:    item.Key,
-						Status: hydrapb.Status_NOT_FOUND,
-					})
-					continue
-				}
-
-				if !swampRequest.Overwrite && swampInterface.TreasureExists(item.Key) {
-					response = append(response, &hydrapb.KeyStatusPair{
-						Key:    item.Key,
-						Status: hydrapb.Status_NOTHING_CHANGED,
-					})
-					// check the treasure and skip if it exists
-					continue
-				}
-
 				// anonymous function to handle the treasure
 				func() {
 
+					// the writers of the same key are serialized, so the existence of the key can not change between
+					// the checks and the write
+					unlock := swampInterface.LockKey(item.Key)
+					defer unlock()
+
+					// if "create if not" exist is false and the treasure does not exist
+					if !swampRequest.GetCreateIfNotExist() && !swampInterface.TreasureExists(item.Key) {
+						response = append(response, &hydrapb.KeyStatusPair{
+							Key:    item.Key,
+							Status: hydrapb.Status_NOT_FOUND,
+						})
+						return
+					}
+
+					// check the treasure and skip if it exists
+					if !swampRequest.Overwrite && swampInterface.TreasureExists(item.Key) {
+						response = append(response, &hydrapb.KeyStatusPair{
+							Key:    item.Key,
+							Status: hydrapb.Status_NOTHING_CHANGED,
+						})
+						return
+					}
+
 					// create the treasure and start the guard
 					treasureInterface := swampInterface.CreateTreasure(item.Key)
 					guardID := treasureInterface.StartTreasureGuard(true)
 					defer treasureInterface.ReleaseTreasureGuard(guardID)
 
 					// set the content type and content
-					keyValuesToTreasure(item, treasureInterface, guardID)
+					keyValuesToTreasure(item, treasureInterface, guardID, swampInterface.IsServerTimestamped())
 
 					treasureStatus := treasureInterface.Save(guardID)
 
@@ -253,10 +520,22 @@ func (g Gateway) Set(ctx context.Context, in *hydrapb.SetRequest) (*hydrapb.SetR
 					responseStatus := convertTreasureStatusToPbStatus(treasureStatus)
 
 					// add the key and status to the response
-					response = append(response, &hydrapb.KeyStatusPair{
+					keyStatusPair := &hydrapb.KeyStatusPair{
 						Key:    item.Key,
 						Status: responseStatus,
-					})
+					}
+
+					// the client needs the times set by the server, because it does not know them
+					if swampInterface.IsServerTimestamped() {
+						if treasureInterface.GetCreatedAt() > 0 {
+							keyStatusPair.CreatedAt = timestamppb.New(time.Unix(0, treasureInterface.GetCreatedAt()))
+						}
+						if treasureInterface.GetModifiedAt() > 0 {
+							keyStatusPair.UpdatedAt = timestamppb.New(time.Unix(0, treasureInterface.GetModifiedAt()))
+						}
+					}
+
+					response = append(response, keyStatusPair)
 
 				}()
 
@@ -268,9 +547,8 @@ func (g Gateway) Set(ctx context.Context, in *hydrapb.SetRequest) (*hydrapb.SetR
 
 		if internalError != nil {
 			// return with grpc error message
-			return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", internalError.Error()))
+			return nil, hydraError(internalError)
 		}
-
 		swampResponses = append(swampResponses, swampResponse)
 
 	}
@@ -282,6 +560,69 @@ func (g Gateway) Set(ctx context.Context, in *hydrapb.SetRequest) (*hydrapb.SetR
 
 }
 
+// reserveNewTreasures reserves the room of the new treasures of every swamp of the request, if the max treasures per
+// swamp is set. The returned function releases the reservations after the request is written. If a swamp would exceed
+// the limit, the reservations of the other swamps are released, and the error is returned.
+func (g Gateway) reserveNewTreasures(ctx context.Context, in *hydrapb.SetRequest) (func(), error) {
+
+	var releases []func()
+	releaseAll := func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+
+	if g.MaxTreasuresPerSwamp <= 0 {
+		return releaseAll, nil
+	}
+
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	for _, swampRequest := range in.GetSwamps() {
+
+		// only the requests with CreateIfNotExist can create new treasures
+		if !swampRequest.GetCreateIfNotExist() {
+			continue
+		}
+
+		swampInterface, err := hydraInterface.SummonSwamp(ctx, swampRequest.GetIslandID(), name.Load(swampRequest.SwampName))
+		if err != nil {
+			releaseAll()
+			return nil, hydraError(err)
+		}
+
+		// the vigil is kept until the reservation is released, so the swamp is not closed before the write
+		swampInterface.BeginVigil()
+
+		newKeys := make(map[string]struct{})
+		for _, item := range swampRequest.GetKeyValues() {
+			if !swampInterface.TreasureExists(item.Key) {
+				newKeys[item.Key] = struct{}{}
+			}
+		}
+		if len(newKeys) == 0 {
+			swampInterface.CeaseVigil()
+			continue
+		}
+
+		release, total, ok := swampInterface.ReserveTreasures(len(newKeys), g.MaxTreasuresPerSwamp)
+		if !ok {
+			swampInterface.CeaseVigil()
+			releaseAll()
+			return nil, LimitExceededError(LimitMaxTreasuresPerSwamp, int64(g.MaxTreasuresPerSwamp), int64(total),
+				fmt.Sprintf("the swamp %s would exceed the limit of %d treasures", swampRequest.SwampName, g.MaxTreasuresPerSwamp))
+		}
+		releases = append(releases, func() {
+			release()
+			swampInterface.CeaseVigil()
+		})
+
+	}
+
+	return releaseAll, nil
+
+}
+
 func (g Gateway) Get(ctx context.Context, in *hydrapb.GetRequest) (*hydrapb.GetResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
@@ -297,7 +638,7 @@ func (g Gateway) Get(ctx context.Context, in *hydrapb.GetRequest) (*hydrapb.GetR
 		}
 		if swampRequest.GetKeys() == nil || swampRequest.GetKeys()[0] == "" {
 			// return with grpc error message
-			return nil, status.Error(codes.InvalidArgument, "Keys cannot be empty")
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Keys cannot be empty")
 		}
 	}
 
@@ -329,16 +670,29 @@ func (g Gateway) Get(ctx context.Context, in *hydrapb.GetRequest) (*hydrapb.GetR
 				return
 			}
 
-			swampInterface, err := hydraInterface.SummonSwamp(ctx, swampRequest.GetIslandID(), swampName)
-			if err != nil {
-				// internal error
-				internalError = err
-				return
-			}
+			// a closed swamp with a key directory answers from the chunks of the keys, without loading the swamp
+			treasures, fromChunks := hydraInterface.GetTreasuresFromChunks(swampRequest.GetIslandID(), swampName, swampRequest.GetKeys())
+			if !fromChunks {
 
-			// begin the vigil, to prevent closing of the swamp
-			swampInterface.BeginVigil()
-			defer swampInterface.CeaseVigil()
+				swampInterface, err := hydraInterface.SummonSwamp(ctx, swampRequest.GetIslandID(), swampName)
+				if err != nil {
+					// internal error
+					internalError = err
+					return
+				}
+
+				// begin the vigil, to prevent closing of the swamp
+				swampInterface.BeginVigil()
+				defer swampInterface.CeaseVigil()
+
+				treasures = make(map[string]treasure.Treasure, len(swampRequest.GetKeys()))
+				for _, key := range swampRequest.GetKeys() {
+					if treasureInterface, err := swampInterface.GetTreasure(key); err == nil {
+						treasures[key] = treasureInterface
+					}
+				}
+
+			}
 
 			var response []*hydrapb.Treasure
 
@@ -349,13 +703,13 @@ func (g Gateway) Get(ctx context.Context, in *hydrapb.GetRequest) (*hydrapb.GetR
 					IsExist: true, // default value
 				}
 
-				treasureInterface, err := swampInterface.GetTreasure(key)
-				if err != nil {
+				treasureInterface, ok := treasures[key]
+				if !ok {
 					// the treasure does not exist
 					t.IsExist = false // override the default value
 				} else {
-					// convert the treasure from the hydra to the protobuf format
-					treasureToKeyValuePair(treasureInterface, t)
+					// convert the selected parts of the treasure from the hydra to the protobuf format
+					projectTreasure(treasureInterface, t, swampRequest.Projection)
 				}
 
 				// add the treasure to the response
@@ -369,7 +723,7 @@ func (g Gateway) Get(ctx context.Context, in *hydrapb.GetRequest) (*hydrapb.GetR
 
 		if internalError != nil {
 			// return with grpc error message
-			return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", internalError.Error()))
+			return nil, hydraError(internalError)
 		}
 
 		swamps = append(swamps, swampResponse)
@@ -383,54 +737,127 @@ func (g Gateway) Get(ctx context.Context, in *hydrapb.GetRequest) (*hydrapb.GetR
 
 }
 
-func (g Gateway) GetAll(ctx context.Context, in *hydrapb.GetAllRequest) (*hydrapb.GetAllResponse, error) {
+// defaultLargeValueChunkSize is the chunk size of the GetLargeValue if the client did not set it
+const defaultLargeValueChunkSize = 1024 * 1024
 
-	g.ZeusInterface.GetSafeops().LockSystem()
-	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+// SetLargeValue reassembles the chunks of the bytes value sent by the client, and writes the treasure by the Set,
+// so the large values have exactly the same semantics, events and limits as the small ones.
+func (g Gateway) SetLargeValue(stream hydrapb.HydraideService_SetLargeValueServer) error {
 
 	defer handlePanic()
 
-	// check if the swamp name is valid and exist or not
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	var header *hydrapb.SetLargeValueRequest
+	var value []byte
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			header = in
+			value = append(value, in.GetKeyValue().GetBytesVal()...)
+		}
+		value = append(value, in.GetChunk()...)
+		// the stream is rejected at the first chunk over the limit, so the rest of the value is not buffered
+		if g.MaxValueSize > 0 && int64(len(value)) > g.MaxValueSize {
+			return LimitExceededError(LimitMaxValueSize, g.MaxValueSize, int64(len(value)),
+				fmt.Sprintf("the value of the treasure %q of the swamp %s is at least %d bytes, larger than the max value size of %d bytes", header.GetKeyValue().GetKey(), header.GetSwampName(), len(value), g.MaxValueSize))
+		}
+	}
+
+	if header == nil || header.GetKeyValue() == nil {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the first message must contain the KeyValue")
+	}
+
+	keyValue := header.GetKeyValue()
+	keyValue.BytesVal = value
+
+	response, err := g.Set(stream.Context(), &hydrapb.SetRequest{
+		Swamps: []*hydrapb.SwampRequest{
+			{
+				IslandID:         header.GetIslandID(),
+				SwampName:        header.GetSwampName(),
+				KeyValues:        []*hydrapb.KeyValuePair{keyValue},
+				CreateIfNotExist: header.GetCreateIfNotExist(),
+				Overwrite:        header.GetOverwrite(),
+			},
+		},
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	hydraInterface := g.ZeusInterface.GetHydra()
+	setLargeValueResponse := &hydrapb.SetLargeValueResponse{}
+	if swamps := response.GetSwamps(); len(swamps) > 0 {
+		setLargeValueResponse.Swamp = swamps[0]
+	}
 
-	// summon the swamp
-	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	return stream.SendAndClose(setLargeValueResponse)
+
+}
+
+// GetLargeValue reads the treasure by the Get, and sends its bytes value in chunks after the treasure itself.
+func (g Gateway) GetLargeValue(in *hydrapb.GetLargeValueRequest, stream hydrapb.HydraideService_GetLargeValueServer) error {
+
+	defer handlePanic()
+
+	if in.GetKey() == "" {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Key cannot be empty")
+	}
+
+	response, err := g.Get(stream.Context(), &hydrapb.GetRequest{
+		Swamps: []*hydrapb.GetSwamp{
+			{
+				IslandID:  in.GetIslandID(),
+				SwampName: in.GetSwampName(),
+				Keys:      []string{in.GetKey()},
+			},
+		},
+	})
 	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return err
 	}
 
-	// begin the vigil, to prevent closing of the swamp
-	swampInterface.BeginVigil()
-	defer swampInterface.CeaseVigil()
+	t := &hydrapb.Treasure{Key: in.GetKey()}
+	if swamps := response.GetSwamps(); len(swamps) > 0 && len(swamps[0].GetTreasures()) > 0 {
+		t = swamps[0].GetTreasures()[0]
+	}
 
-	treasures := swampInterface.GetAll()
+	value := t.GetBytesVal()
+	t.BytesVal = nil
 
-	var response []*hydrapb.Treasure
-	for _, treasureInterface := range treasures {
-		t := &hydrapb.Treasure{}
-		treasureToKeyValuePair(treasureInterface, t)
-		response = append(response, t)
+	if err := stream.Send(&hydrapb.GetLargeValueResponse{Treasure: t}); err != nil {
+		return err
 	}
 
-	return &hydrapb.GetAllResponse{
-		Treasures: response,
-	}, nil
+	chunkSize := int(in.GetChunkSize())
+	if chunkSize <= 0 {
+		chunkSize = defaultLargeValueChunkSize
+	}
+
+	for start := 0; start < len(value); start += chunkSize {
+		end := min(start+chunkSize, len(value))
+		if err := stream.Send(&hydrapb.GetLargeValueResponse{Chunk: value[start:end]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
 
 }
 
-func (g Gateway) GetByIndex(ctx context.Context, in *hydrapb.GetByIndexRequest) (*hydrapb.GetByIndexResponse, error) {
+func (g Gateway) GetAll(ctx context.Context, in *hydrapb.GetAllRequest) (*hydrapb.GetAllResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
+	// check if the swamp name is valid and exist or not
 	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
@@ -442,38 +869,35 @@ func (g Gateway) GetByIndex(ctx context.Context, in *hydrapb.GetByIndexRequest)
 	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
 	swampInterface.BeginVigil()
 	defer swampInterface.CeaseVigil()
 
-	treasures, err := swampInterface.GetTreasuresByBeacon(inputIndexTypeToBeaconType(in.GetIndexType()),
-		inputOrderTypeToBeaconOrderType(in.GetOrderType()), in.GetFrom(), in.GetLimit())
-
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("hydra error: %s", err.Error()))
-	}
+	treasures := swampInterface.GetAll()
 
-	// convert all treasures to the protobuf format
 	var response []*hydrapb.Treasure
 	for _, treasureInterface := range treasures {
-		// convert the treasure to the protobuf format
 		t := &hydrapb.Treasure{}
 		treasureToKeyValuePair(treasureInterface, t)
 		response = append(response, t)
 	}
 
-	// get the treasures by the index
-	return &hydrapb.GetByIndexResponse{
+	return &hydrapb.GetAllResponse{
 		Treasures: response,
 	}, nil
 
 }
 
-func (g Gateway) ShiftExpiredTreasures(ctx context.Context, in *hydrapb.ShiftExpiredTreasuresRequest) (*hydrapb.ShiftExpiredTreasuresResponse, error) {
+// defaultGetAllStreamBatchSize is the number of the treasures in one message of the GetAllStream if the client did
+// not set it
+const defaultGetAllStreamBatchSize = 500
+
+// GetAllStream streams the treasures of the swamp in batches. The treasures are converted and sent one batch at a
+// time, so the server never holds the whole swamp in protobuf format.
+func (g Gateway) GetAllStream(in *hydrapb.GetAllStreamRequest, stream hydrapb.HydraideService_GetAllStreamServer) error {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
@@ -482,622 +906,632 @@ func (g Gateway) ShiftExpiredTreasures(ctx context.Context, in *hydrapb.ShiftExp
 
 	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	var filterExpression filter.Expression
+	if in.GetFilterExpr() != "" {
+		if filterExpression, err = filter.Parse(in.GetFilterExpr()); err != nil {
+			return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_FILTER_EXPRESSION, fmt.Sprintf("invalid filter expression: %s", err.Error()))
+		}
 	}
 
 	hydraInterface := g.ZeusInterface.GetHydra()
 
 	// summon the swamp
-	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	swampInterface, err := hydraInterface.SummonSwamp(stream.Context(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
 	swampInterface.BeginVigil()
 	defer swampInterface.CeaseVigil()
 
-	howMany := in.GetHowMany()
-	if howMany == 0 {
-		// set the howMany to the default value because it is zero and if the value is zero, the user wants ALL the
-		// expired treasures
-		howMany = 1000000000 // 1 billion, this is a very large number, so it will return all the expired treasures
+	batchSize := int(in.GetBatchSize())
+	if batchSize <= 0 {
+		batchSize = defaultGetAllStreamBatchSize
 	}
 
-	// clone and delete the expired treasures from the swamp
-	treasures, err := swampInterface.CloneAndDeleteExpiredTreasures(howMany)
-	// there was an error
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("hydra error: %s", err.Error()))
+	batch := make([]*hydrapb.Treasure, 0, batchSize)
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := stream.Send(&hydrapb.GetAllStreamResponse{Treasures: batch}); err != nil {
+			return err
+		}
+		batch = make([]*hydrapb.Treasure, 0, batchSize)
+		return nil
 	}
 
-	// convert all treasures to the protobuf format
-	var response []*hydrapb.Treasure
-	for _, treasureInterface := range treasures {
-		// convert the treasure to the protobuf format
-		t := &hydrapb.Treasure{}
-		treasureToKeyValuePair(treasureInterface, t)
-		response = append(response, t)
+	var sendErr error
+	skipped := int32(0)
+	sent := int32(0)
+	swampInterface.IterateAll(func(treasureInterface treasure.Treasure) bool {
+		if filterExpression != nil && !filterExpression.Evaluate(treasureInterface) {
+			return true
+		}
+		if skipped < in.GetFrom() {
+			skipped++
+			return true
+		}
+		if in.GetKeysOnly() {
+			batch = append(batch, treasureToPbKey(treasureInterface))
+		} else {
+			t := &hydrapb.Treasure{}
+			projectTreasure(treasureInterface, t, in.Projection)
+			batch = append(batch, t)
+		}
+		sent++
+		if len(batch) >= batchSize {
+			if sendErr = send(); sendErr != nil {
+				return false
+			}
+		}
+		return in.GetLimit() <= 0 || sent < in.GetLimit()
+	})
+	if sendErr != nil {
+		return sendErr
 	}
 
-	// get the treasures by the index
-	return &hydrapb.ShiftExpiredTreasuresResponse{
-		Treasures: response,
-	}, nil
+	return send()
 
 }
 
-func (g Gateway) Destroy(ctx context.Context, in *hydrapb.DestroyRequest) (*hydrapb.DestroyResponse, error) {
+func (g Gateway) GetByIndex(ctx context.Context, in *hydrapb.GetByIndexRequest) (*hydrapb.GetByIndexResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	// check and validate the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
 	hydraInterface := g.ZeusInterface.GetHydra()
 
 	// summon the swamp
 	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
-	// destroy the swamp
-	swampInterface.Destroy()
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	return &hydrapb.DestroyResponse{}, nil
+	// the keys only response skips the values of the treasures
+	convertTreasures := func(treasures []treasure.Treasure) []*hydrapb.Treasure {
+		return projectTreasures(treasures, in.Projection)
+	}
+	if in.GetKeysOnly() {
+		convertTreasures = treasuresToPbKeys
+	}
 
-}
-
-func (g Gateway) Delete(ctx context.Context, in *hydrapb.DeleteRequest) (*hydrapb.DeleteResponse, error) {
-
-	g.ZeusInterface.GetSafeops().LockSystem()
-	defer g.ZeusInterface.GetSafeops().UnlockSystem()
-
-	defer handlePanic()
-
-	responses := make([]*hydrapb.DeleteResponse_SwampDeleteResponse, 0, len(in.GetSwamps()))
-
-	// validate all the requests
-	for _, swampRequest := range in.GetSwamps() {
-
-		// check if the swamp name is valid and exist or not
-		// we don't need to check the existence of the swamp because we will create it if it does not exist
-		swampNameObj, err := checkSwampName(g.ZeusInterface, swampRequest.GetIslandID(), swampRequest.SwampName, true)
-		if err != nil {
-			responses = append(responses, &hydrapb.DeleteResponse_SwampDeleteResponse{
-				SwampName: swampRequest.SwampName,
-				ErrorCode: hydrapb.DeleteResponse_SwampDeleteResponse_SwampDoesNotExist.Enum(),
-			})
-			continue
-		}
+	// without filter expression the beacon handles the pagination
+	if in.GetFilterExpr() == "" {
 
-		hydraInterface := g.ZeusInterface.GetHydra()
+		treasures, err := swampInterface.GetTreasuresByBeacon(inputIndexTypeToBeaconType(in.GetIndexType()),
+			inputOrderTypeToBeaconOrderType(in.GetOrderType()), in.GetFrom(), in.GetLimit())
 
-		// summon the swamp
-		swampInterface, err := hydraInterface.SummonSwamp(ctx, swampRequest.GetIslandID(), swampNameObj)
 		if err != nil {
 			// return with grpc error message
-			return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+			return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
 		}
 
-		func() {
-
-			// begin the vigil, to prevent closing of the swamp
-			swampInterface.BeginVigil()
-			defer swampInterface.CeaseVigil()
-
-			sr := &hydrapb.DeleteResponse_SwampDeleteResponse{
-				SwampName: swampRequest.SwampName,
-				ErrorCode: nil,
-			}
-
-			keyStatuses := make([]*hydrapb.KeyStatusPair, 0)
+		return &hydrapb.GetByIndexResponse{
+			Treasures: convertTreasures(treasures),
+		}, nil
 
-			for _, key := range swampRequest.GetKeys() {
-				statusPair := &hydrapb.KeyStatusPair{
-					Key: key,
-				}
-				if err := swampInterface.DeleteTreasure(key, false); err != nil {
-					statusPair.Status = hydrapb.Status_NOT_FOUND
+	}
 
-				} else {
-					statusPair.Status = hydrapb.Status_DELETED
-				}
-				keyStatuses = append(keyStatuses, statusPair)
-			}
+	filterExpression, err := filter.Parse(in.GetFilterExpr())
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_FILTER_EXPRESSION, fmt.Sprintf("invalid filter expression: %s", err.Error()))
+	}
 
-			sr.KeyStatuses = keyStatuses
-			responses = append(responses, sr)
+	// get all treasures in the order of the index, because the filter must be applied before the pagination
+	treasures, err := swampInterface.GetTreasuresByBeacon(inputIndexTypeToBeaconType(in.GetIndexType()),
+		inputOrderTypeToBeaconOrderType(in.GetOrderType()), 0, 0)
 
-		}()
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
+	}
 
+	var filteredTreasures []treasure.Treasure
+	skipped := int32(0)
+	for _, treasureInterface := range treasures {
+		if !filterExpression.Evaluate(treasureInterface) {
+			continue
+		}
+		if skipped < in.GetFrom() {
+			skipped++
+			continue
+		}
+		filteredTreasures = append(filteredTreasures, treasureInterface)
+		if in.GetLimit() > 0 && int32(len(filteredTreasures)) >= in.GetLimit() {
+			break
+		}
 	}
 
-	return &hydrapb.DeleteResponse{
-		Responses: responses,
+	return &hydrapb.GetByIndexResponse{
+		Treasures: convertTreasures(filteredTreasures),
 	}, nil
 
 }
 
-func (g Gateway) Count(ctx context.Context, in *hydrapb.CountRequest) (*hydrapb.CountResponse, error) {
+// GetTopN returns the first N treasures of the index directly from the beacon of the swamp
+func (g Gateway) GetTopN(ctx context.Context, in *hydrapb.GetTopNRequest) (*hydrapb.GetTopNResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	// try to count the treasures in all the swamps
-	var response []*hydrapb.CountSwamp
+	if in.GetN() <= 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "N must be greater than 0")
+	}
 
-	type SwampIdentifier struct {
-		IslandID  uint64
-		SwampName name.Name
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
 	}
 
-	var swamps []*SwampIdentifier
+	hydraInterface := g.ZeusInterface.GetHydra()
 
-	for _, swampIdentifier := range in.GetSwamps() {
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
 
-		swampNameObject, err := checkSwampName(g.ZeusInterface, swampIdentifier.GetIslandID(), swampIdentifier.GetSwampName(), true)
-		if err != nil {
-			// Ellenőrizzük, hogy a hiba állapota 'NotFound' kódú-e
-			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
-				// this is not an error, just a swamp that does not exist
-				response = append(response, &hydrapb.CountSwamp{
-					SwampName: swampIdentifier.GetSwampName(),
-					Count:     0,
-					IsExist:   false,
-				})
-			} else {
-				// return with grpc error message
-				return nil, err
-			}
-		}
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-		swamps = append(swamps, &SwampIdentifier{
-			IslandID:  swampIdentifier.GetIslandID(),
-			SwampName: swampNameObject,
-		})
+	treasures, err := swampInterface.GetTopTreasures(inputIndexTypeToBeaconType(in.GetIndexType()),
+		inputOrderTypeToBeaconOrderType(in.GetOrderType()), in.GetN())
+	if err != nil {
+		if err.Error() == swamp.ErrorValueBeaconNotSortable {
+			return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, err.Error())
+		}
+		// return with grpc error message
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
 	}
 
-	hydraInterface := g.ZeusInterface.GetHydra()
+	return &hydrapb.GetTopNResponse{
+		Treasures: treasuresToPbTreasures(treasures),
+	}, nil
 
-	// iterating over only the existing swamps
-	for _, swampIdentifier := range swamps {
+}
 
-		// summon the swamp
-		swampInterface, err := hydraInterface.SummonSwamp(ctx, swampIdentifier.IslandID, swampIdentifier.SwampName)
-		if err != nil {
-			// return with grpc error message
-			return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
-		}
-		if swampInterface == nil {
-			// return with grpc error message
-			return nil, status.Error(codes.Internal, "internal server error in hydra: swamp interface is nil")
-		}
+// treasuresToPbTreasures converts the treasures to the protobuf format
+func treasuresToPbTreasures(treasures []treasure.Treasure) []*hydrapb.Treasure {
 
-		func() {
+	// convert all treasures to the protobuf format
+	var response []*hydrapb.Treasure
+	for _, treasureInterface := range treasures {
+		// convert the treasure to the protobuf format
+		t := &hydrapb.Treasure{}
+		treasureToKeyValuePair(treasureInterface, t)
+		response = append(response, t)
+	}
 
-			// begin the vigil, to prevent closing of the swamp
-			swampInterface.BeginVigil()
-			defer swampInterface.CeaseVigil()
+	return response
 
-			count := swampInterface.CountTreasures()
-			response = append(response, &hydrapb.CountSwamp{
-				SwampName: swampIdentifier.SwampName.Get(),
-				Count:     int32(count),
-				IsExist:   true,
-			})
+}
 
-		}()
+// treasuresToPbKeys converts the treasures to the protobuf format with their keys and metadata, but without their values
+func treasuresToPbKeys(treasures []treasure.Treasure) []*hydrapb.Treasure {
 
+	var response []*hydrapb.Treasure
+	for _, treasureInterface := range treasures {
+		response = append(response, treasureToPbKey(treasureInterface))
 	}
 
-	// return with the count of the treasures
-	return &hydrapb.CountResponse{
-		Swamps: response,
-	}, nil
+	return response
 
 }
 
-func (g Gateway) IsSwampExist(_ context.Context, in *hydrapb.IsSwampExistRequest) (*hydrapb.IsSwampExistResponse, error) {
+// treasureToPbKey converts the treasure to the protobuf format with its key and metadata, but without its value
+func treasureToPbKey(treasureInterface treasure.Treasure) *hydrapb.Treasure {
+	t := &hydrapb.Treasure{
+		Key:     treasureInterface.GetKey(),
+		IsExist: true,
+	}
+	treasureMetadataToPb(treasureInterface, t)
+	return t
+}
 
-	g.ZeusInterface.GetSafeops().LockSystem()
-	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+// projectTreasures converts the treasures to the protobuf format with the parts selected by the projection
+func projectTreasures(treasures []treasure.Treasure, projection *hydrapb.Projection) []*hydrapb.Treasure {
 
-	defer handlePanic()
+	if projection == nil {
+		return treasuresToPbTreasures(treasures)
+	}
 
-	_, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
-	if err != nil {
-		return &hydrapb.IsSwampExistResponse{
-			IsExist: false,
-		}, err
+	var response []*hydrapb.Treasure
+	for _, treasureInterface := range treasures {
+		t := &hydrapb.Treasure{}
+		projectTreasure(treasureInterface, t, projection)
+		response = append(response, t)
 	}
 
-	return &hydrapb.IsSwampExistResponse{
-		IsExist: true,
-	}, nil
+	return response
 
 }
 
-func (g Gateway) IsKeyExist(_ context.Context, in *hydrapb.IsKeyExistRequest) (*hydrapb.IsKeyExistResponse, error) {
+// projectTreasure converts the parts of the treasure selected by the projection to the protobuf format.
+// Without projection the whole treasure is converted.
+func projectTreasure(treasureInterface treasure.Treasure, t *hydrapb.Treasure, projection *hydrapb.Projection) {
+
+	if projection == nil {
+		treasureToKeyValuePair(treasureInterface, t)
+		return
+	}
+
+	// the value is converted only if it is needed, because it is the expensive part
+	if projection.GetValue() {
+		treasureToKeyValuePair(treasureInterface, t)
+	} else {
+		t.Key = treasureInterface.GetKey()
+		t.IsExist = true
+		treasureMetadataToPb(treasureInterface, t)
+	}
+
+	if !projection.GetCreatedAt() {
+		t.CreatedAt = nil
+	}
+	if !projection.GetCreatedBy() {
+		t.CreatedBy = nil
+	}
+	if !projection.GetUpdatedAt() {
+		t.UpdatedAt = nil
+	}
+	if !projection.GetUpdatedBy() {
+		t.UpdatedBy = nil
+	}
+	if !projection.GetExpiredAt() {
+		t.ExpiredAt = nil
+	}
+
+}
+
+func (g Gateway) GetByValue(ctx context.Context, in *hydrapb.GetByValueRequest) (*hydrapb.GetByValueResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	// check if the swamp name is correct and exist
+	if in.GetValue() == nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Value cannot be empty")
+	}
+
 	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// summon the swamp
 	hydraInterface := g.ZeusInterface.GetHydra()
-	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
+
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
 	swampInterface.BeginVigil()
 	defer swampInterface.CeaseVigil()
 
-	isExist := swampInterface.TreasureExists(in.Key)
+	if !swampInterface.IsValueIndexed() {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_VALUE_INDEX_NOT_ENABLED, "value index is not enabled for the swamp pattern")
+	}
 
-	return &hydrapb.IsKeyExistResponse{
-		IsExist: isExist,
+	// create a standalone probe treasure that holds only the searched value
+	probe := treasure.New(nil)
+	guardID := probe.StartTreasureGuard(true)
+	keyValuesToTreasure(in.GetValue(), probe, guardID, false)
+	probe.ReleaseTreasureGuard(guardID)
+
+	treasures, err := swampInterface.GetTreasuresByValue(probe)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
+	}
+
+	return &hydrapb.GetByValueResponse{
+		Treasures: treasuresToPbTreasures(treasures),
 	}, nil
 
 }
 
-func (g Gateway) SubscribeToEvents(in *hydrapb.SubscribeToEventsRequest, eventServer hydrapb.HydraideService_SubscribeToEventsServer) error {
+// GetByReference returns the treasures of the target swamp referenced by the IDs of a uint32 slice
+func (g Gateway) GetByReference(ctx context.Context, in *hydrapb.GetByReferenceRequest) (*hydrapb.GetByReferenceResponse, error) {
 
-	// do not use the g.ZeusInterface.GetSafeops().LockSystem() because if we use it, we can never stop the server because of the active subscribers
-	// until the client closes the connection
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	// check if the swamp name is correct
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
-	if err != nil {
-		return status.Error(codes.InvalidArgument, err.Error())
+	if in.GetTargetSwampName() == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "TargetSwampName cannot be empty")
 	}
 
-	subscriberUUID := uuid.New()
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
+	}
 
-	// the subscription is successful, now we can start to send the events to the client
-	// Get the server context
 	hydraInterface := g.ZeusInterface.GetHydra()
 
-	eventCallbackFunction := func(event *swamp.Event) {
+	// summon the swamp of the slice
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
 
-		if event == nil {
-			return
-		}
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-		// send the event to the client
-		defer handlePanic()
+	treasureInterface, err := swampInterface.GetTreasure(in.GetKey())
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("the key does not exist: %s", err.Error()))
+	}
 
-		// get the canonical form of the swamp name
-		eventSwampName := event.SwampName.Get()
-		// convert the hydra treasure to the protobuf format
-		convertedTreasure := &hydrapb.Treasure{}
-		// convert the status type to the protobuf format
-		convertedStatusType := convertTreasureStatusToPbStatus(event.StatusType)
+	ids, err := treasureInterface.Uint32SliceGetAll()
+	if err != nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the treasure type is not slice. err: %s", err.Error()))
+	}
 
-		// convert the event time to the protobuf format
-		convertedEventTime := timestamppb.New(time.Unix(event.EventTime, 0))
-		convertedOldTreasure := &hydrapb.Treasure{}
-		convertedDeletedTreasure := &hydrapb.Treasure{}
+	response := make([]*hydrapb.Treasure, 0, len(ids))
+	keyOfID := func(id uint32) string {
+		return in.GetTargetKeyPrefix() + strconv.FormatUint(uint64(id), 10)
+	}
 
-		switch event.StatusType {
-		case treasure.StatusNew:
+	// the missing target swamp is not created, all of its references are missing
+	targetSwampName := name.Load(in.GetTargetSwampName())
+	isExist, err := hydraInterface.IsExistSwamp(in.GetTargetIslandID(), targetSwampName)
+	if err != nil || !isExist {
+		for _, id := range ids {
+			response = append(response, &hydrapb.Treasure{Key: keyOfID(id), IsExist: false})
+		}
+		return &hydrapb.GetByReferenceResponse{Treasures: response}, nil
+	}
 
-			if event.Treasure != nil {
-				treasureToKeyValuePair(event.Treasure, convertedTreasure)
-			}
+	targetSwampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetTargetIslandID(), targetSwampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
 
-		case treasure.StatusModified:
+	targetSwampInterface.BeginVigil()
+	defer targetSwampInterface.CeaseVigil()
 
-			if event.Treasure != nil {
-				treasureToKeyValuePair(event.Treasure, convertedTreasure)
-			}
-			if event.OldTreasure != nil {
-				treasureToKeyValuePair(event.OldTreasure, convertedOldTreasure)
-			}
+	for _, id := range ids {
+		t := &hydrapb.Treasure{Key: keyOfID(id), IsExist: true}
+		referencedTreasure, err := targetSwampInterface.GetTreasure(t.Key)
+		if err != nil {
+			t.IsExist = false
+		} else {
+			projectTreasure(referencedTreasure, t, in.Projection)
+		}
+		response = append(response, t)
+	}
 
-		case treasure.StatusDeleted:
+	return &hydrapb.GetByReferenceResponse{Treasures: response}, nil
 
-			if event.DeletedTreasure != nil {
-				treasureToKeyValuePair(event.DeletedTreasure, convertedDeletedTreasure)
-			}
+}
 
-		default:
+func (g Gateway) ShiftExpiredTreasures(ctx context.Context, in *hydrapb.ShiftExpiredTreasuresRequest) (*hydrapb.ShiftExpiredTreasuresResponse, error) {
 
-			return
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
-		}
+	defer handlePanic()
 
-		// send the message to the client
-		if sendErr := eventServer.SendMsg(&hydrapb.SubscribeToEventsResponse{
-			SwampName:       eventSwampName,
-			Treasure:        convertedTreasure,
-			Status:          convertedStatusType,
-			OldTreasure:     convertedOldTreasure,
-			DeletedTreasure: convertedDeletedTreasure,
-			EventTime:       convertedEventTime,
-		}); sendErr != nil {
-			slog.Error("failed to send the event to the client",
-				"error", sendErr.Error(),
-				"swamp_name", eventSwampName)
-		}
-
-	}
-
-	if err := hydraInterface.SubscribeToSwampEvents(subscriberUUID, swampName, eventCallbackFunction); err != nil {
-		return status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
-	}
-
-	for {
-		select {
-		// we are waiting for the client to close the connection
-		case <-eventServer.Context().Done():
-
-			err := eventServer.Context().Err()
-			if err != nil && !errors.Is(err, context.Canceled) {
-				slog.Warn("connection closed with an unexpected error",
-					"uuid", subscriberUUID,
-					"error", err.Error())
-			} else {
-				slog.Debug("the client closed the connection gracefully",
-					"uuid", subscriberUUID)
-			}
-
-			// Unsubscribe logic
-			if err := hydraInterface.UnsubscribeFromSwampEvents(subscriberUUID, swampName); err != nil {
-				slog.Error("failed to unsubscribe the subscriber from the swamp",
-					"uuid", subscriberUUID,
-					"error", err.Error())
-			}
-
-			return nil
-
-		}
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
 	}
 
-}
-
-func (g Gateway) SubscribeToInfo(in *hydrapb.SubscribeToInfoRequest, infoServer hydrapb.HydraideService_SubscribeToInfoServer) error {
-
-	// do not use the g.ZeusInterface.GetSafeops().LockSystem() because if we use it, we can never stop the server because of the active subscribers
-	// until the client closes the connection
-	defer handlePanic()
+	hydraInterface := g.ZeusInterface.GetHydra()
 
-	// check if the swamp name is correct
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
 	if err != nil {
-		return status.Error(codes.InvalidArgument, err.Error())
+		// return with grpc error message
+		return nil, hydraError(err)
 	}
 
-	// make the channel for the subscriber
-	subscriberUUID := uuid.New()
-
-	infoSubscriptionCallbackFunction := func(info *swamp.Info) {
-		// send the event to the client
-		func() {
-
-			defer handlePanic()
-
-			// get the canonical form of the swamp name
-			infoSwampName := info.SwampName.Get()
-
-			// send the info to the client
-			if sendErr := infoServer.Send(&hydrapb.SubscribeToInfoResponse{
-				SwampName:   infoSwampName,
-				AllElements: info.AllElements,
-			}); sendErr != nil {
-				slog.Error("failed to send the info to the client",
-					"error", sendErr.Error(),
-					"swamp_name", infoSwampName)
-			}
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-		}()
+	howMany := in.GetHowMany()
+	if howMany == 0 {
+		// set the howMany to the default value because it is zero and if the value is zero, the user wants ALL the
+		// expired treasures
+		howMany = 1000000000 // 1 billion, this is a very large number, so it will return all the expired treasures
 	}
 
-	// subscribe to the swamp for information
-	hydraInterface := g.ZeusInterface.GetHydra()
-	if err := hydraInterface.SubscribeToSwampInfo(subscriberUUID, swampName, infoSubscriptionCallbackFunction); err != nil {
-		return status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+	// clone and delete the expired treasures from the swamp
+	treasures, err := swampInterface.CloneAndDeleteExpiredTreasures(howMany)
+	// there was an error
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
 	}
 
-	defer func() {
-		handlePanic()
-		// remove the subscriber from the swamp when the client closes the connection
-		if err := hydraInterface.UnsubscribeFromSwampInfo(subscriberUUID, swampName); err != nil {
-			slog.Error("failed to unsubscribe the subscriber from the swamp",
-				"uuid", subscriberUUID,
-				"error", err.Error())
-		}
-		slog.Debug("the subscriber is removed from the swamp",
-			"uuid", subscriberUUID,
-		)
-	}()
-
-	for {
-		select {
-		// várunk arra, hogy a user bezárja a kapcsolatot
-		case <-infoServer.Context().Done():
-			// remove the subscriber from the swamp when the client closes the connection
-			if err := hydraInterface.UnsubscribeFromSwampInfo(subscriberUUID, swampName); err != nil {
-				slog.Error("failed to unsubscribe the subscriber from the swamp",
-					"uuid", subscriberUUID,
-					"error", err.Error(),
-				)
-			}
-			return nil
-
-		}
+	// convert all treasures to the protobuf format
+	var response []*hydrapb.Treasure
+	for _, treasureInterface := range treasures {
+		// convert the treasure to the protobuf format
+		t := &hydrapb.Treasure{}
+		treasureToKeyValuePair(treasureInterface, t)
+		response = append(response, t)
 	}
 
+	// get the treasures by the index
+	return &hydrapb.ShiftExpiredTreasuresResponse{
+		Treasures: response,
+	}, nil
+
 }
 
-func (g Gateway) Uint32SlicePush(ctx context.Context, in *hydrapb.AddToUint32SlicePushRequest) (*hydrapb.AddToUint32SlicePushResponse, error) {
+func (g Gateway) LeaseExpiredTreasures(ctx context.Context, in *hydrapb.LeaseExpiredTreasuresRequest) (*hydrapb.LeaseExpiredTreasuresResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
+	if in.GetLeaseTime() <= 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "LeaseTime must be greater than 0")
+	}
+
 	hydraInterface := g.ZeusInterface.GetHydra()
 
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	var errorsWhilePush []string
+	howMany := in.GetHowMany()
+	if howMany == 0 {
+		// zero means all the expired treasures, like at the ShiftExpiredTreasures
+		howMany = 1000000000
+	}
 
-	for _, pair := range in.KeySlicePairs {
+	leaseUntil := time.Now().Add(time.Duration(in.GetLeaseTime()) * time.Millisecond)
+	treasures, previousExpirations, err := swampInterface.LeaseExpiredTreasures(howMany, leaseUntil)
+	if err != nil {
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
+	}
 
-		func() {
+	leaseTable := hydraInterface.GetLeaseTable(swampName)
 
-			treasureObj := swampObj.CreateTreasure(pair.GetKey())
+	response := make([]*hydrapb.LeasedTreasure, 0, len(treasures))
+	for i, treasureInterface := range treasures {
+		t := &hydrapb.Treasure{}
+		treasureToKeyValuePair(treasureInterface, t)
+		leaseID := treasureInterface.GetExpirationTime()
+		response = append(response, &hydrapb.LeasedTreasure{
+			Treasure:   t,
+			LeaseID:    leaseID,
+			Deliveries: leaseTable.Deliver(treasureInterface.GetKey(), previousExpirations[i], leaseID),
+		})
+	}
 
-			guardID := treasureObj.StartTreasureGuard(true)
-			defer treasureObj.ReleaseTreasureGuard(guardID)
+	return &hydrapb.LeaseExpiredTreasuresResponse{
+		Treasures: response,
+	}, nil
 
-			if err := treasureObj.Uint32SlicePush(pair.GetValues()); err != nil {
-				errorsWhilePush = append(errorsWhilePush, err.Error())
-			}
+}
 
-		}()
+func (g Gateway) AckLease(ctx context.Context, in *hydrapb.AckLeaseRequest) (*hydrapb.AckLeaseResponse, error) {
 
+	if err := g.endLease(ctx, in.GetIslandID(), in.GetSwampName(), in.GetKey(), in.GetLeaseID(), true, 0); err != nil {
+		return nil, err
 	}
 
-	if len(errorsWhilePush) > 0 {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the following errors occurred: %s", strings.Join(errorsWhilePush, ", ")))
+	return &hydrapb.AckLeaseResponse{}, nil
+
+}
+
+func (g Gateway) NackLease(ctx context.Context, in *hydrapb.NackLeaseRequest) (*hydrapb.NackLeaseResponse, error) {
+
+	if in.GetRetryAfter() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "RetryAfter can not be negative")
 	}
 
-	return nil, nil
+	if err := g.endLease(ctx, in.GetIslandID(), in.GetSwampName(), in.GetKey(), in.GetLeaseID(), false, time.Duration(in.GetRetryAfter())*time.Millisecond); err != nil {
+		return nil, err
+	}
+
+	return &hydrapb.NackLeaseResponse{}, nil
 
 }
 
-func (g Gateway) Uint32SliceDelete(ctx context.Context, in *hydrapb.Uint32SliceDeleteRequest) (*hydrapb.Uint32SliceDeleteResponse, error) {
+// endLease deletes the leased treasure if remove is true, otherwise it makes the treasure visible again after the
+// retryAfter time
+func (g Gateway) endLease(ctx context.Context, islandID uint64, swampNameString string, key string, leaseID int64, remove bool, retryAfter time.Duration) error {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
+	swampName, err := checkSwampName(g.ZeusInterface, islandID, swampNameString, true)
+	if err != nil {
+		return err
 	}
 
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
-	if err != nil {
-		return nil, err
+	if key == "" {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Key cannot be empty")
 	}
 
-	// get the hydra interface
 	hydraInterface := g.ZeusInterface.GetHydra()
 
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, islandID, swampName)
 	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
-
-	var errorsWhileDelete []string
-
-	for _, pair := range in.KeySlicePairs {
-
-		func() {
-
-			// try to load the treasure
-			treasureObj, err := swampObj.GetTreasure(pair.GetKey())
-			// the treasure does not exist so we can't delete the slice from it
-			if err != nil {
-				return
-			}
-
-			guardID := treasureObj.StartTreasureGuard(true)
-			defer treasureObj.ReleaseTreasureGuard(guardID)
-
-			if err := treasureObj.Uint32SliceDelete(pair.GetValues()); err != nil {
-				errorsWhileDelete = append(errorsWhileDelete, err.Error())
-			}
-
-			// check the length of the slice in the treasure
-			// if the length is 0, we can delete the treasure
-			size, err := treasureObj.Uint32SliceSize()
-			if err != nil || size == 0 {
-				// delete the treasure
-				if err := swampObj.DeleteTreasure(pair.GetKey(), false); err != nil {
-					errorsWhileDelete = append(errorsWhileDelete, err.Error())
-				}
-			}
-
-		}()
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
+	visibleAt := time.Now().Add(retryAfter)
+	if err := swampInterface.EndLease(key, leaseID, remove, visibleAt); err != nil {
+		if err.Error() == swamp.ErrorLeaseNotFound {
+			return statusError(codes.NotFound, hydrapb.ErrorReason_LEASE_NOT_FOUND, err.Error())
+		}
+		return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
 	}
 
-	if len(errorsWhileDelete) > 0 {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the following errors occurred: %s", strings.Join(errorsWhileDelete, ", ")))
+	leaseTable := hydraInterface.GetLeaseTable(swampName)
+	if remove {
+		leaseTable.Remove(key)
+	} else {
+		leaseTable.Release(key, visibleAt.UTC().UnixNano())
 	}
 
-	return nil, nil
+	return nil
 
 }
 
-func (g Gateway) Uint32SliceSize(ctx context.Context, in *hydrapb.Uint32SliceSizeRequest) (*hydrapb.Uint32SliceSizeResponse, error) {
+func (g Gateway) Destroy(ctx context.Context, in *hydrapb.DestroyRequest) (*hydrapb.DestroyResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-
-	// check the name of the swamp
+	// check and validate the name of the swamp
 	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
 	if err != nil {
 		return nil, err
@@ -1107,988 +1541,3746 @@ func (g Gateway) Uint32SliceSize(ctx context.Context, in *hydrapb.Uint32SliceSiz
 	hydraInterface := g.ZeusInterface.GetHydra()
 
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
-	}
-
-	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
-
-	treasureObj, err := swampObj.GetTreasure(in.GetKey())
-	if err != nil {
-		return &hydrapb.Uint32SliceSizeResponse{Size: 0}, status.Error(codes.InvalidArgument, fmt.Sprintf("the key does not exist: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
-	size, err := treasureObj.Uint32SliceSize()
-	if err != nil {
-		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("the treasure type is not slice. err: %s", err.Error()))
-	}
+	// destroy the swamp
+	swampInterface.Destroy()
 
-	return &hydrapb.Uint32SliceSizeResponse{Size: int64(size)}, nil
+	return &hydrapb.DestroyResponse{}, nil
 
 }
 
-func (g Gateway) Uint32SliceIsValueExist(ctx context.Context, in *hydrapb.Uint32SliceIsValueExistRequest) (*hydrapb.Uint32SliceIsValueExistResponse, error) {
+func (g Gateway) DestroyMany(in *hydrapb.DestroyManyRequest, stream hydrapb.HydraideService_DestroyManyServer) error {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
+	if in.SwampPattern == "" {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampPattern cannot be empty")
+	}
+	if strings.Count(in.SwampPattern, "/") != 2 {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("invalid swamp pattern: %s", in.SwampPattern))
+	}
+	// the token protects from the patterns built by mistake, so it is checked against the raw pattern
+	if in.GetConfirmationToken() != in.SwampPattern {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "ConfirmationToken must be the same as the SwampPattern")
 	}
 
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// the error of the stream is returned as it is, the errors of the hydra are converted
+	var sendErr error
+	_, err := g.ZeusInterface.GetHydra().DestroySwamps(stream.Context(), name.Load(in.SwampPattern), false, func(swampName name.Name) error {
+		sendErr = stream.Send(&hydrapb.DestroyManyResponse{SwampName: swampName.Get()})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
 	if err != nil {
-		return nil, err
+		return hydraError(err)
 	}
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
+	return nil
 
-	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
-	}
+}
 
-	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+func (g Gateway) Delete(ctx context.Context, in *hydrapb.DeleteRequest) (*hydrapb.DeleteResponse, error) {
 
-	treasureObj, err := swampObj.GetTreasure(in.GetKey())
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the key does not exist: %s", err.Error()))
-	}
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
-	sl, err := treasureObj.Uint32SliceGetAll()
-	for _, value := range sl {
-		if value == in.GetValue() {
-			return &hydrapb.Uint32SliceIsValueExistResponse{IsExist: true}, nil
+	defer handlePanic()
+
+	responses := make([]*hydrapb.DeleteResponse_SwampDeleteResponse, 0, len(in.GetSwamps()))
+
+	// validate all the requests
+	for _, swampRequest := range in.GetSwamps() {
+
+		// check if the swamp name is valid and exist or not
+		// we don't need to check the existence of the swamp because we will create it if it does not exist
+		swampNameObj, err := checkSwampName(g.ZeusInterface, swampRequest.GetIslandID(), swampRequest.SwampName, true)
+		if err != nil {
+			responses = append(responses, &hydrapb.DeleteResponse_SwampDeleteResponse{
+				SwampName: swampRequest.SwampName,
+				ErrorCode: hydrapb.DeleteResponse_SwampDeleteResponse_SwampDoesNotExist.Enum(),
+			})
+			continue
 		}
-	}
 
-	return &hydrapb.Uint32SliceIsValueExistResponse{IsExist: false}, nil
-}
+		hydraInterface := g.ZeusInterface.GetHydra()
 
-func (g Gateway) IncrementInt8(ctx context.Context, in *hydrapb.IncrementInt8Request) (*hydrapb.IncrementInt8Response, error) {
+		// summon the swamp
+		swampInterface, err := hydraInterface.SummonSwamp(ctx, swampRequest.GetIslandID(), swampNameObj)
+		if err != nil {
+			// return with grpc error message
+			return nil, hydraError(err)
+		}
 
-	g.ZeusInterface.GetSafeops().LockSystem()
-	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+		func() {
 
-	defer handlePanic()
+			// begin the vigil, to prevent closing of the swamp
+			swampInterface.BeginVigil()
+			defer swampInterface.CeaseVigil()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
+			sr := &hydrapb.DeleteResponse_SwampDeleteResponse{
+				SwampName: swampRequest.SwampName,
+				ErrorCode: nil,
+			}
 
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
-	if err != nil {
-		return nil, err
-	}
+			keyStatuses := make([]*hydrapb.KeyStatusPair, 0)
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
+			for _, key := range swampRequest.GetKeys() {
+				statusPair := &hydrapb.KeyStatusPair{
+					Key: key,
+				}
+				if err := swampInterface.DeleteTreasure(key, swampRequest.GetShadowDelete()); err != nil {
+					statusPair.Status = hydrapb.Status_NOT_FOUND
 
-	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
-	}
+				} else {
+					statusPair.Status = hydrapb.Status_DELETED
+				}
+				keyStatuses = append(keyStatuses, statusPair)
+			}
 
-	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+			sr.KeyStatuses = keyStatuses
+			responses = append(responses, sr)
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementInt8Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementInt8Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              int8(in.GetCondition().GetValue()),
-		}
-	}
+		}()
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementInt8(in.Key, int8(in.IncrementBy), condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementInt8Response{
-		Value:         int32(newValue),
-		IsIncremented: isIncremented,
+	return &hydrapb.DeleteResponse{
+		Responses: responses,
 	}, nil
 
 }
 
-func (g Gateway) IncrementInt16(ctx context.Context, in *hydrapb.IncrementInt16Request) (*hydrapb.IncrementInt16Response, error) {
+func (g Gateway) ListDeleted(_ context.Context, in *hydrapb.ListDeletedRequest) (*hydrapb.ListDeletedResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// check if the swamp name is correct and exist
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
-
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	hydraInterface := g.ZeusInterface.GetHydra()
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
-
-	// create the condition if it is not nil
-	var condition *swamp.IncrementInt16Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementInt16Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              int16(in.GetCondition().GetValue()),
-		}
-	}
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementInt16(in.Key, int16(in.IncrementBy), condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	shadowTreasures := swampInterface.GetShadowDeletedTreasures()
+	treasures := make([]*hydrapb.Treasure, 0, len(shadowTreasures))
+	for _, treasureInterface := range shadowTreasures {
+		t := &hydrapb.Treasure{}
+		treasureToKeyValuePair(treasureInterface, t)
+		t.DeletedAt = timestamppb.New(time.Unix(0, treasureInterface.GetDeletedAt()))
+		treasures = append(treasures, t)
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementInt16Response{
-		Value:         int32(newValue),
-		IsIncremented: isIncremented,
+	return &hydrapb.ListDeletedResponse{
+		Treasures: treasures,
 	}, nil
 
 }
 
-func (g Gateway) IncrementInt32(ctx context.Context, in *hydrapb.IncrementInt32Request) (*hydrapb.IncrementInt32Response, error) {
+func (g Gateway) Restore(_ context.Context, in *hydrapb.RestoreRequest) (*hydrapb.RestoreResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// check if the swamp name is correct and exist
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
-
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	hydraInterface := g.ZeusInterface.GetHydra()
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementInt32Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementInt32Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              in.GetCondition().GetValue(),
+	keyStatuses := make([]*hydrapb.KeyStatusPair, 0, len(in.GetKeys()))
+	for _, key := range in.GetKeys() {
+		statusPair := &hydrapb.KeyStatusPair{
+			Key:    key,
+			Status: hydrapb.Status_NEW,
 		}
+		if err := swampInterface.RestoreTreasure(key); err != nil {
+			statusPair.Status = hydrapb.Status_NOT_FOUND
+		}
+		keyStatuses = append(keyStatuses, statusPair)
 	}
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementInt32(in.Key, in.IncrementBy, condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
-	}
-
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementInt32Response{
-		Value:         newValue,
-		IsIncremented: isIncremented,
+	return &hydrapb.RestoreResponse{
+		KeyStatuses: keyStatuses,
 	}, nil
 
 }
 
-func (g Gateway) IncrementInt64(ctx context.Context, in *hydrapb.IncrementInt64Request) (*hydrapb.IncrementInt64Response, error) {
+func (g Gateway) GetHistory(_ context.Context, in *hydrapb.GetHistoryRequest) (*hydrapb.GetHistoryResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// check if the swamp name is correct and exist
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
-
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	hydraInterface := g.ZeusInterface.GetHydra()
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementInt64Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementInt64Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              in.GetCondition().GetValue(),
-		}
+	treasureInterface, err := swampInterface.GetTreasure(in.GetKey())
+	if err != nil {
+		return nil, statusError(codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("key not found: %s", in.GetKey()))
 	}
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementInt64(in.Key, in.IncrementBy, condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	history := treasureInterface.GetVersions()
+	versions := make([]*hydrapb.Treasure, 0, len(history))
+	for _, version := range history {
+		t := &hydrapb.Treasure{}
+		treasureToKeyValuePair(version.ToTreasure(in.GetKey()), t)
+		versionNumber := version.Version
+		// the time and the modifier of the version are returned in the UpdatedAt and UpdatedBy fields
+		t.Version = &versionNumber
+		versions = append(versions, t)
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementInt64Response{
-		Value:         newValue,
-		IsIncremented: isIncremented,
+	return &hydrapb.GetHistoryResponse{
+		Versions: versions,
 	}, nil
 
 }
 
-func (g Gateway) IncrementUint8(ctx context.Context, in *hydrapb.IncrementUint8Request) (*hydrapb.IncrementUint8Response, error) {
+func (g Gateway) RevertTo(_ context.Context, in *hydrapb.RevertToRequest) (*hydrapb.RevertToResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// check if the swamp name is correct and exist
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
-
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	hydraInterface := g.ZeusInterface.GetHydra()
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementUInt8Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementUInt8Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              uint8(in.GetCondition().GetValue()),
+	if err := swampInterface.RevertTreasure(in.GetKey(), in.GetVersion(), in.GetUpdatedBy()); err != nil {
+		if errors.Is(err, treasure.ErrVersionNotFound) {
+			return nil, statusError(codes.NotFound, hydrapb.ErrorReason_VERSION_NOT_FOUND, fmt.Sprintf("version %d not found in the history of the key: %s", in.GetVersion(), in.GetKey()))
 		}
+		return nil, statusError(codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("key not found: %s", in.GetKey()))
 	}
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementUint8(in.Key, uint8(in.IncrementBy), condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
-	}
-
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementUint8Response{
-		Value:         uint32(newValue),
-		IsIncremented: isIncremented,
-	}, nil
+	return &hydrapb.RevertToResponse{}, nil
 
 }
 
-func (g Gateway) IncrementUint16(ctx context.Context, in *hydrapb.IncrementUint16Request) (*hydrapb.IncrementUint16Response, error) {
+func (g Gateway) Count(ctx context.Context, in *hydrapb.CountRequest) (*hydrapb.CountResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
+	// try to count the treasures in all the swamps
+	var response []*hydrapb.CountSwamp
 
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
-	if err != nil {
-		return nil, err
+	type SwampIdentifier struct {
+		IslandID  uint64
+		SwampName name.Name
 	}
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
+	var swamps []*SwampIdentifier
 
-	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+	// the filter is the same for all swamps of the request
+	filter := countFilterFromPb(in.GetFilter())
+
+	for _, swampIdentifier := range in.GetSwamps() {
+
+		swampNameObject, err := checkSwampName(g.ZeusInterface, swampIdentifier.GetIslandID(), swampIdentifier.GetSwampName(), true)
+		if err != nil {
+			// a swamp that does not exist is not an error, because one request can count many swamps
+			if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+				response = append(response, &hydrapb.CountSwamp{
+					SwampName: swampIdentifier.GetSwampName(),
+					Count:     0,
+					IsExist:   false,
+				})
+				continue
+			}
+			// return with grpc error message
+			return nil, err
+		}
+
+		swamps = append(swamps, &SwampIdentifier{
+			IslandID:  swampIdentifier.GetIslandID(),
+			SwampName: swampNameObject,
+		})
 	}
 
-	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+	hydraInterface := g.ZeusInterface.GetHydra()
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementUInt16Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementUInt16Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              uint16(in.GetCondition().GetValue()),
+	// iterating over only the existing swamps
+	for _, swampIdentifier := range swamps {
+
+		// summon the swamp
+		swampInterface, err := hydraInterface.SummonSwamp(ctx, swampIdentifier.IslandID, swampIdentifier.SwampName)
+		if err != nil {
+			// return with grpc error message
+			return nil, hydraError(err)
+		}
+		if swampInterface == nil {
+			// return with grpc error message
+			return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, "internal server error in hydra: swamp interface is nil")
 		}
+
+		func() {
+
+			// begin the vigil, to prevent closing of the swamp
+			swampInterface.BeginVigil()
+			defer swampInterface.CeaseVigil()
+
+			count := swampInterface.CountTreasuresByFilter(filter)
+			response = append(response, &hydrapb.CountSwamp{
+				SwampName: swampIdentifier.SwampName.Get(),
+				Count:     int32(count),
+				IsExist:   true,
+			})
+
+		}()
+
 	}
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementUint16(in.Key, uint16(in.IncrementBy), condition)
+	// return with the count of the treasures
+	return &hydrapb.CountResponse{
+		Swamps: response,
+	}, nil
+
+}
+
+func (g Gateway) IsSwampExist(_ context.Context, in *hydrapb.IsSwampExistRequest) (*hydrapb.IsSwampExistResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	_, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+		return &hydrapb.IsSwampExistResponse{
+			IsExist: false,
+		}, err
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementUint16Response{
-		Value:         uint32(newValue),
-		IsIncremented: isIncremented,
+	return &hydrapb.IsSwampExistResponse{
+		IsExist: true,
 	}, nil
 
 }
 
-func (g Gateway) IncrementUint32(ctx context.Context, in *hydrapb.IncrementUint32Request) (*hydrapb.IncrementUint32Response, error) {
+func (g Gateway) ExistsMany(_ context.Context, in *hydrapb.ExistsManyRequest) (*hydrapb.ExistsManyResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
+	hydraInterface := g.ZeusInterface.GetHydra()
+	response := &hydrapb.ExistsManyResponse{
+		Results: make([]*hydrapb.ExistsManyResult, 0, len(in.GetSwamps())),
 	}
 
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
-	if err != nil {
-		return nil, err
-	}
+	for _, swampIdentifier := range in.GetSwamps() {
 
-	// get the hydra interface
-	hydraInterface := g.ZeusInterface.GetHydra()
+		if strings.Count(swampIdentifier.GetSwampName(), "/") != 2 {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("invalid swamp name or pattern: %s", swampIdentifier.GetSwampName()))
+		}
 
-	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
-	}
+		result := &hydrapb.ExistsManyResult{
+			SwampName: swampIdentifier.GetSwampName(),
+		}
 
-	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+		pattern := name.Load(swampIdentifier.GetSwampName())
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementUInt32Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementUInt32Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              in.GetCondition().GetValue(),
+		if !pattern.IsWildcardPattern() {
+			isExist, err := hydraInterface.IsExistSwamp(swampIdentifier.GetIslandID(), pattern)
+			if err != nil {
+				return nil, hydraError(err)
+			}
+			if isExist {
+				result.ExistingSwamps = append(result.ExistingSwamps, pattern.Get())
+			}
+			response.Results = append(response.Results, result)
+			continue
 		}
-	}
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementUint32(in.Key, in.IncrementBy, condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+		if pattern.GetSanctuaryID() == "*" {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the sanctuary part of the pattern cannot be a wildcard")
+		}
+
+		swampNames, err := hydraInterface.FindSwamps(pattern)
+		if err != nil {
+			return nil, hydraError(err)
+		}
+		for _, swampName := range swampNames {
+			result.ExistingSwamps = append(result.ExistingSwamps, swampName.Get())
+		}
+		response.Results = append(response.Results, result)
+
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementUint32Response{
-		Value:         newValue,
-		IsIncremented: isIncremented,
-	}, nil
+	return response, nil
 
 }
 
-func (g Gateway) IncrementUint64(ctx context.Context, in *hydrapb.IncrementUint64Request) (*hydrapb.IncrementUint64Response, error) {
+func (g Gateway) IsKeyExist(_ context.Context, in *hydrapb.IsKeyExistRequest) (*hydrapb.IsKeyExistResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// check if the swamp name is correct and exist
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
 	hydraInterface := g.ZeusInterface.GetHydra()
 
+	// the key filter of a closed swamp excludes most of the missing keys without loading the swamp
+	if mayContain, ok := hydraInterface.MayContainKeys(in.GetIslandID(), swampName, []string{in.Key}); ok && !mayContain[0] {
+		return &hydrapb.IsKeyExistResponse{
+			IsExist: false,
+		}, nil
+	}
+
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
-
-	// create the condition if it is not nil
-	var condition *swamp.IncrementUInt64Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementUInt64Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              in.GetCondition().GetValue(),
-		}
-	}
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementUint64(in.Key, in.IncrementBy, condition)
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
-	}
+	isExist := swampInterface.TreasureExists(in.Key)
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementUint64Response{
-		Value:         newValue,
-		IsIncremented: isIncremented,
+	return &hydrapb.IsKeyExistResponse{
+		IsExist: isExist,
 	}, nil
 
 }
 
-func (g Gateway) IncrementFloat32(ctx context.Context, in *hydrapb.IncrementFloat32Request) (*hydrapb.IncrementFloat32Response, error) {
+func (g Gateway) IsKeysExist(_ context.Context, in *hydrapb.IsKeysExistRequest) (*hydrapb.IsKeysExistResponse, error) {
 
 	g.ZeusInterface.GetSafeops().LockSystem()
 	defer g.ZeusInterface.GetSafeops().UnlockSystem()
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
-	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	// check if the swamp name is correct and exist
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// get the hydra interface
 	hydraInterface := g.ZeusInterface.GetHydra()
 
+	// the swamp is loaded only if the key filter can not exclude all keys, because the other keys are answered from
+	// the memory anyway
+	if mayContain, ok := hydraInterface.MayContainKeys(in.GetIslandID(), swampName, in.GetKeys()); ok && !slices.Contains(mayContain, true) {
+		return &hydrapb.IsKeysExistResponse{
+			IsExist: make([]bool, len(in.GetKeys())),
+		}, nil
+	}
+
 	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	swampInterface, err := hydraInterface.SummonSwamp(context.Background(), in.GetIslandID(), swampName)
 	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return nil, hydraError(err)
 	}
 
 	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
-
-	// create the condition if it is not nil
-	var condition *swamp.IncrementFloat32Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementFloat32Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              in.GetCondition().GetValue(),
-		}
-	}
-
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementFloat32(in.Key, in.IncrementBy, condition)
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
 
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not a float: %s", err.Error()))
+	isExist := make([]bool, len(in.GetKeys()))
+	for i, key := range in.GetKeys() {
+		isExist[i] = swampInterface.TreasureExists(key)
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementFloat32Response{
-		Value:         newValue,
-		IsIncremented: isIncremented,
+	return &hydrapb.IsKeysExistResponse{
+		IsExist: isExist,
 	}, nil
 
 }
 
-func (g Gateway) IncrementFloat64(ctx context.Context, in *hydrapb.IncrementFloat64Request) (*hydrapb.IncrementFloat64Response, error) {
+func (g Gateway) SubscribeToEvents(in *hydrapb.SubscribeToEventsRequest, eventServer hydrapb.HydraideService_SubscribeToEventsServer) error {
 
-	g.ZeusInterface.GetSafeops().LockSystem()
-	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+	// do not use the g.ZeusInterface.GetSafeops().LockSystem() because if we use it, we can never stop the server because of the active subscribers
+	// until the client closes the connection
 
 	defer handlePanic()
 
-	if in.SwampName == "" {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
-	}
-	if in.IncrementBy == 0 {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "IncrementBy cannot be zero")
-	}
-
-	// check the name of the swamp
+	// check if the swamp name is correct
 	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
 	if err != nil {
-		return nil, err
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
 	}
 
-	// get the hydra interface
+	subscriberUUID := uuid.New()
+
+	// the subscription is successful, now we can start to send the events to the client
+	// Get the server context
 	hydraInterface := g.ZeusInterface.GetHydra()
 
-	// summon the swamp
-	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	replay := in.GetSince() != nil
+	if replay && in.GetIncludeSnapshot() {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncludeSnapshot and Since can not be used together")
+	}
+
+	// the events are sent by this goroutine after the snapshot or the replayed events, so the writers of the swamp
+	// only put them to the queue
+	queue, err := g.subscriberQueue(hydrapb.HydraideService_SubscribeToEvents_FullMethodName, in.GetBufferSize(), in.GetOverflow(), subscriber.PolicyBlock)
 	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.Internal, fmt.Sprintf("internal server error in hydra: %s", err.Error()))
+		return err
 	}
+	defer queue.Close()
 
-	// begin the vigil, to prevent closing of the swamp
-	swampObj.BeginVigil()
-	defer swampObj.CeaseVigil()
+	eventCallbackFunction := func(event *swamp.Event) {
 
-	// create the condition if it is not nil
-	var condition *swamp.IncrementFloat64Condition
-	if in.GetCondition() != nil {
-		condition = &swamp.IncrementFloat64Condition{
-			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
-			Value:              in.GetCondition().GetValue(),
+		if event == nil {
+			return
 		}
-	}
 
-	// increment the value with the condition
-	newValue, isIncremented, err := swampObj.IncrementFloat64(in.Key, in.IncrementBy, condition)
+		defer handlePanic()
+
+		if response := eventToResponse(event); response != nil {
+			queue.Push(response)
+		}
 
-	if err != nil {
-		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("the value of the key is not a float: %s", err.Error()))
 	}
 
-	// return with the new value and the status of the increment
-	return &hydrapb.IncrementFloat64Response{
-		Value:         newValue,
-		IsIncremented: isIncremented,
-	}, nil
+	// the snapshot or the replayed events are sent before the end marker, then the queued events follow them
+	var initial []*hydrapb.SubscribeToEventsResponse
+	var resumeToken uint64
 
-}
+	switch {
+	case in.GetIncludeSnapshot():
+
+		snapshotFunction := func(treasures []treasure.Treasure, token uint64) {
+			snapshotTime := timestamppb.Now()
+			initial = make([]*hydrapb.SubscribeToEventsResponse, 0, len(treasures))
+			for _, treasureInterface := range treasures {
+				convertedTreasure := &hydrapb.Treasure{}
+				treasureToKeyValuePair(treasureInterface, convertedTreasure)
+				initial = append(initial, &hydrapb.SubscribeToEventsResponse{
+					SwampName: swampName.Get(),
+					Treasure:  convertedTreasure,
+					Status:    hydrapb.Status_NOTHING_CHANGED,
+					EventTime: snapshotTime,
+				})
+			}
+			resumeToken = token
+		}
 
-// keyValuesToTreasure converts the key value pairs to the treasure content
-// this function not save the treasure, only set its content
-func keyValuesToTreasure(keyValuePair *hydrapb.KeyValuePair, treasureInterface treasure.Treasure, guardID guard.ID) {
+		// the server can not stop while the snapshot is taken, but it can stop during the stream as before
+		g.ZeusInterface.GetSafeops().LockSystem()
+		err := hydraInterface.SubscribeToSwampEventsWithSnapshot(eventServer.Context(), in.GetIslandID(), subscriberUUID, swampName, snapshotFunction, eventCallbackFunction)
+		g.ZeusInterface.GetSafeops().UnlockSystem()
+		if err != nil {
+			return hydraError(err)
+		}
 
-	// Ensure keyValuePair is not nil to avoid panic
-	if keyValuePair == nil {
-		treasureInterface.SetContentVoid(guardID)
-		return
-	}
+	case replay:
 
-	// convert interface to the correct type and set the content
-	switch {
-	case keyValuePair.Int8Val != nil:
-		treasureInterface.SetContentInt8(guardID, int8(*keyValuePair.Int8Val))
-	case keyValuePair.Int16Val != nil:
-		treasureInterface.SetContentInt16(guardID, int16(*keyValuePair.Int16Val))
-	case keyValuePair.Int32Val != nil:
-		treasureInterface.SetContentInt32(guardID, *keyValuePair.Int32Val)
-	case keyValuePair.Int64Val != nil:
-		treasureInterface.SetContentInt64(guardID, *keyValuePair.Int64Val)
-	case keyValuePair.Uint8Val != nil:
-		treasureInterface.SetContentUint8(guardID, uint8(*keyValuePair.Uint8Val))
-	case keyValuePair.Uint16Val != nil:
-		treasureInterface.SetContentUint16(guardID, uint16(*keyValuePair.Uint16Val))
-	case keyValuePair.Uint32Val != nil:
-		treasureInterface.SetContentUint32(guardID, *keyValuePair.Uint32Val)
-	case keyValuePair.Uint64Val != nil:
-		treasureInterface.SetContentUint64(guardID, *keyValuePair.Uint64Val)
-	case keyValuePair.Float32Val != nil:
-		treasureInterface.SetContentFloat32(guardID, *keyValuePair.Float32Val)
-	case keyValuePair.Float64Val != nil:
-		treasureInterface.SetContentFloat64(guardID, *keyValuePair.Float64Val)
-	case keyValuePair.StringVal != nil:
-		treasureInterface.SetContentString(guardID, *keyValuePair.StringVal)
-	case keyValuePair.BoolVal != nil:
-		var booleanValue bool
-		switch *keyValuePair.BoolVal {
-		case hydrapb.Boolean_TRUE:
-			booleanValue = true
-		case hydrapb.Boolean_FALSE:
-			booleanValue = false
-		default:
-			booleanValue = false
+		replayFunction := func(events []*swamp.Event, token uint64) {
+			initial = make([]*hydrapb.SubscribeToEventsResponse, 0, len(events))
+			for _, event := range events {
+				if response := eventToResponse(event); response != nil {
+					initial = append(initial, response)
+				}
+			}
+			resumeToken = token
 		}
-		treasureInterface.SetContentBool(guardID, booleanValue)
-	case keyValuePair.BytesVal != nil:
-		treasureInterface.SetContentByteArray(guardID, keyValuePair.BytesVal)
-	case keyValuePair.Uint32Slice != nil:
 
-		if err := treasureInterface.Uint32SlicePush(keyValuePair.Uint32Slice); err != nil {
-			slog.Error("failed to push the uint32 slice to the treasure", "error", err.Error())
+		// the server can not stop while the journal is read, but it can stop during the stream as before
+		g.ZeusInterface.GetSafeops().LockSystem()
+		err := hydraInterface.SubscribeToSwampEventsSince(eventServer.Context(), in.GetIslandID(), subscriberUUID, swampName, in.GetSince().AsTime().UnixNano(), replayFunction, eventCallbackFunction)
+		g.ZeusInterface.GetSafeops().UnlockSystem()
+		if errors.Is(err, hydra.ErrEventReplayNotAvailable) {
+			return statusError(codes.OutOfRange, hydrapb.ErrorReason_REPLAY_NOT_AVAILABLE, err.Error())
 		}
-	case keyValuePair.VoidVal != nil && *keyValuePair.VoidVal:
-		// set the content to void
-		treasureInterface.SetContentVoid(guardID)
+		if err != nil {
+			return hydraError(err)
+		}
+
 	default:
-		treasureInterface.SetContentVoid(guardID)
+
+		if err := hydraInterface.SubscribeToSwampEvents(subscriberUUID, swampName, eventCallbackFunction); err != nil {
+			return hydraError(err)
+		}
+
 	}
 
-	// set other values if they are not empty
-	if isValidTimestamp(keyValuePair.GetCreatedAt()) {
-		treasureInterface.SetCreatedAt(guardID, keyValuePair.GetCreatedAt().AsTime())
+	defer func() {
+		// the blocked writers are released first, they can hold the swamp the unsubscription waits for
+		queue.Close()
+		if err := hydraInterface.UnsubscribeFromSwampEvents(subscriberUUID, swampName); err != nil {
+			slog.Error("failed to unsubscribe the subscriber from the swamp",
+				"uuid", subscriberUUID,
+				"error", err.Error())
+		}
+	}()
+
+	if in.GetIncludeSnapshot() || replay {
+		for _, response := range initial {
+			if err := eventServer.Send(response); err != nil {
+				return err
+			}
+		}
+		if err := eventServer.Send(&hydrapb.SubscribeToEventsResponse{
+			SwampName:   swampName.Get(),
+			SnapshotEnd: true,
+			ResumeToken: resumeToken,
+			EventTime:   timestamppb.Now(),
+		}); err != nil {
+			return err
+		}
 	}
-	if keyValuePair.GetCreatedBy() != "" {
-		treasureInterface.SetCreatedBy(guardID, keyValuePair.GetCreatedBy())
+
+	for {
+		select {
+		// we are waiting for the client to close the connection
+		case <-eventServer.Context().Done():
+
+			err := eventServer.Context().Err()
+			if err != nil && !errors.Is(err, context.Canceled) {
+				slog.Warn("connection closed with an unexpected error",
+					"uuid", subscriberUUID,
+					"error", err.Error())
+			} else {
+				slog.Debug("the client closed the connection gracefully",
+					"uuid", subscriberUUID)
+			}
+
+			return nil
+
+		case <-queue.Overflow():
+			return subscriberOverflowError(subscriberUUID, queue.Size())
+		case response := <-queue.Items():
+			if err := sendQueuedEvent(eventServer, queue, response); err != nil {
+				slog.Error("failed to send the event to the client",
+					"error", err.Error(),
+					"swamp_name", response.GetSwampName())
+				return err
+			}
+		}
 	}
-	if isValidTimestamp(keyValuePair.GetUpdatedAt()) {
-		treasureInterface.SetModifiedAt(guardID, keyValuePair.GetUpdatedAt().AsTime())
+
+}
+
+// subscriberQueue creates the event queue of a subscription with the buffer size and the overflow policy of the
+// client, or the default policy of the RPC
+func (g Gateway) subscriberQueue(method string, bufferSize uint32, overflow hydrapb.OverflowPolicy_Policy, defaultPolicy subscriber.Policy) (*subscriber.Queue[*hydrapb.SubscribeToEventsResponse], error) {
+
+	policy := defaultPolicy
+	switch overflow {
+	case hydrapb.OverflowPolicy_BLOCK:
+		policy = subscriber.PolicyBlock
+	case hydrapb.OverflowPolicy_DROP_OLDEST:
+		policy = subscriber.PolicyDropOldest
+	case hydrapb.OverflowPolicy_CANCEL:
+		policy = subscriber.PolicyCancel
+	default:
+		// the default policy of the RPC
 	}
-	if keyValuePair.GetUpdatedBy() != "" {
-		treasureInterface.SetModifiedBy(guardID, keyValuePair.GetUpdatedBy())
+
+	queue, err := subscriber.New[*hydrapb.SubscribeToEventsResponse](int(bufferSize), policy, g.Metrics, method)
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
 	}
-	if isValidTimestamp(keyValuePair.GetExpiredAt()) {
-		treasureInterface.SetExpirationTime(guardID, keyValuePair.GetExpiredAt().AsTime())
+
+	return queue, nil
+
+}
+
+// sendQueuedEvent sends the event of the queue, after the number of the events dropped before it
+func sendQueuedEvent(stream grpc.ServerStream, queue *subscriber.Queue[*hydrapb.SubscribeToEventsResponse], response *hydrapb.SubscribeToEventsResponse) error {
+	if dropped := queue.TakeDropped(); dropped > 0 {
+		if err := stream.SendMsg(&hydrapb.SubscribeToEventsResponse{
+			DroppedEvents: dropped,
+			EventTime:     timestamppb.Now(),
+		}); err != nil {
+			return err
+		}
 	}
+	return stream.SendMsg(response)
 }
 
-// treasureToKeyValuePair converts the treasure content from the hydra to the protobuf format
-func treasureToKeyValuePair(treasureInterface treasure.Treasure, t *hydrapb.Treasure) {
+// subscriberOverflowError closes the stream of a subscriber cancelled by its full queue
+func subscriberOverflowError(subscriberUUID uuid.UUID, size int) error {
+	slog.Warn("the subscriber is too slow, the stream is closed",
+		"uuid", subscriberUUID,
+		"bufferSize", size)
+	return statusError(codes.ResourceExhausted, hydrapb.ErrorReason_SUBSCRIBER_OVERFLOW,
+		fmt.Sprintf("the client could not keep up with the events, more than %d events were waiting", size))
+}
 
-	// Set the key of the treasure
-	t.Key = treasureInterface.GetKey()
-	t.IsExist = true
+// SubscribeAll streams the events of every swamp matching the patterns, without hydrating the swamps
+func (g Gateway) SubscribeAll(in *hydrapb.SubscribeAllRequest, eventServer hydrapb.HydraideService_SubscribeAllServer) error {
 
-	switch treasureInterface.GetContentType() {
-	case treasure.ContentTypeInt8:
-		val, contentErr := treasureInterface.GetContentInt8()
-		if contentErr == nil {
-			v := int32(val)
-			t.Int8Val = &v
-		}
-	case treasure.ContentTypeInt16:
-		val, contentErr := treasureInterface.GetContentInt16()
-		if contentErr == nil {
-			v := int32(val)
-			t.Int16Val = &v
-		}
-	case treasure.ContentTypeInt32:
-		val, contentErr := treasureInterface.GetContentInt32()
-		if contentErr == nil {
-			t.Int32Val = &val
-		}
-	case treasure.ContentTypeInt64:
-		val, contentErr := treasureInterface.GetContentInt64()
-		if contentErr == nil {
-			t.Int64Val = &val
+	defer handlePanic()
+
+	if len(in.GetPatterns()) == 0 {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "at least one pattern is required")
+	}
+
+	patterns := make([]name.Name, 0, len(in.GetPatterns()))
+	for _, pattern := range in.GetPatterns() {
+		parts := strings.Split(pattern, "/")
+		if len(parts) != 3 || slices.Contains(parts, "") {
+			return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the pattern must be in the format sanctuary/realm/swamp, got %q", pattern))
 		}
-	case treasure.ContentTypeUint8:
-		val, contentErr := treasureInterface.GetContentUint8()
-		if contentErr == nil {
-			v := uint32(val)
-			t.Uint8Val = &v
+		patterns = append(patterns, name.Load(pattern))
+	}
+
+	// the swamps to resume must match the patterns, otherwise their new events would never arrive
+	for swampName, since := range in.GetSince() {
+		if parts := strings.Split(swampName, "/"); len(parts) != 3 || slices.Contains(parts, "") || slices.Contains(parts, "*") {
+			return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the swamp name must be in the format sanctuary/realm/swamp, got %q", swampName))
 		}
-	case treasure.ContentTypeUint16:
-		val, contentErr := treasureInterface.GetContentUint16()
-		if contentErr == nil {
-			v := uint32(val)
-			t.Uint16Val = &v
+		if since == nil {
+			return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the since time of the swamp %q is missing", swampName))
 		}
-	case treasure.ContentTypeUint32:
-		val, contentErr := treasureInterface.GetContentUint32()
-		if contentErr == nil {
-			t.Uint32Val = &val
+		if !slices.ContainsFunc(patterns, func(pattern name.Name) bool { return hydra.MatchPattern(name.Load(swampName), pattern) }) {
+			return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the swamp %q does not match any pattern", swampName))
 		}
-	case treasure.ContentTypeUint64:
-		val, contentErr := treasureInterface.GetContentUint64()
-		if contentErr == nil {
-			t.Uint64Val = &val
+	}
+
+	subscriberUUID := uuid.New()
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// the events are sent by this goroutine, so the writers of the swamps only put them to the queue. By default the
+	// stream of a lagging client is closed, so the writers of the swamps are never blocked by a slow client
+	queue, err := g.subscriberQueue(hydrapb.HydraideService_SubscribeAll_FullMethodName, in.GetBufferSize(), in.GetOverflow(), subscriber.PolicyCancel)
+	if err != nil {
+		return err
+	}
+	defer queue.Close()
+
+	eventCallbackFunction := func(event *swamp.Event) {
+
+		if event == nil {
+			return
 		}
-	case treasure.ContentTypeFloat32:
-		val, contentErr := treasureInterface.GetContentFloat32()
-		if contentErr == nil {
-			t.Float32Val = &val
+
+		defer handlePanic()
+
+		if response := eventToResponse(event); response != nil {
+			queue.Push(response)
 		}
-	case treasure.ContentTypeFloat64:
-		val, contentErr := treasureInterface.GetContentFloat64()
-		if contentErr == nil {
-			t.Float64Val = &val
+
+	}
+
+	if err := hydraInterface.SubscribeToPatternEvents(subscriberUUID, patterns, eventCallbackFunction); err != nil {
+		return hydraError(err)
+	}
+	defer func() {
+		// the blocked writers are released first, they can hold the swamp the unsubscription waits for
+		queue.Close()
+		if err := hydraInterface.UnsubscribeFromPatternEvents(subscriberUUID); err != nil {
+			slog.Error("failed to unsubscribe the subscriber from the patterns",
+				"uuid", subscriberUUID,
+				"error", err.Error())
 		}
-	case treasure.ContentTypeString:
-		val, contentErr := treasureInterface.GetContentString()
-		if contentErr == nil {
-			t.StringVal = &val
+	}()
+
+	// the missed events are replayed after the subscription, so no event falls between the replay and the stream.
+	// The events appended to the journals since the subscription are in the buffer too, they are skipped by their
+	// sequence
+	replayed := make(map[string]map[uint64]struct{}, len(in.GetSince()))
+	for swampName, since := range in.GetSince() {
+		journalEvents, ok := hydraInterface.GetJournalEventsSince(name.Load(swampName), since.AsTime().UnixNano())
+		if !ok {
+			if err := eventServer.Send(&hydrapb.SubscribeToEventsResponse{
+				SwampName:          swampName,
+				ReplayNotAvailable: true,
+				EventTime:          since,
+			}); err != nil {
+				return err
+			}
+			continue
 		}
-	case treasure.ContentTypeBoolean:
-		val, contentErr := treasureInterface.GetContentBool()
-		if contentErr == nil {
-			// convert the boolean to the protobuf type
-			if val {
-				t.BoolVal = hydrapb.Boolean_TRUE.Enum()
-			} else {
-				t.BoolVal = hydrapb.Boolean_FALSE.Enum()
+		sequences := make(map[uint64]struct{}, len(journalEvents))
+		for _, event := range journalEvents {
+			response := eventToResponse(event)
+			if response == nil {
+				continue
+			}
+			if err := eventServer.Send(response); err != nil {
+				return err
 			}
+			sequences[event.Sequence] = struct{}{}
 		}
-	case treasure.ContentTypeByteArray:
-		val, contentErr := treasureInterface.GetContentByteArray()
+		replayed[swampName] = sequences
+	}
+
+	// the subscription is active, every change from now on is streamed
+	if err := eventServer.Send(&hydrapb.SubscribeToEventsResponse{
+		SnapshotEnd: true,
+		EventTime:   timestamppb.Now(),
+	}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-eventServer.Context().Done():
+			slog.Debug("the client closed the change feed",
+				"uuid", subscriberUUID)
+			return nil
+		case <-queue.Overflow():
+			return subscriberOverflowError(subscriberUUID, queue.Size())
+		case response := <-queue.Items():
+			if sequences, ok := replayed[response.GetSwampName()]; ok {
+				if _, isReplayed := sequences[response.GetSequence()]; isReplayed {
+					delete(sequences, response.GetSequence())
+					continue
+				}
+			}
+			if err := sendQueuedEvent(eventServer, queue, response); err != nil {
+				slog.Error("failed to send the event to the client",
+					"error", err.Error(),
+					"swamp_name", response.GetSwampName())
+				return err
+			}
+		}
+	}
+
+}
+
+// eventToResponse converts the event of the swamp to the protobuf format. Returns nil for the unknown event types
+func eventToResponse(event *swamp.Event) *hydrapb.SubscribeToEventsResponse {
+
+	// convert the hydra treasure to the protobuf format
+	convertedTreasure := &hydrapb.Treasure{}
+	// convert the status type to the protobuf format
+	convertedStatusType := convertTreasureStatusToPbStatus(event.StatusType)
+
+	// convert the event time to the protobuf format
+	convertedEventTime := timestamppb.New(time.Unix(0, event.EventTime))
+	convertedOldTreasure := &hydrapb.Treasure{}
+	convertedDeletedTreasure := &hydrapb.Treasure{}
+
+	switch event.StatusType {
+	case treasure.StatusNew:
+
+		if event.Treasure != nil {
+			treasureToKeyValuePair(event.Treasure, convertedTreasure)
+		}
+
+	case treasure.StatusModified:
+
+		if event.Treasure != nil {
+			treasureToKeyValuePair(event.Treasure, convertedTreasure)
+		}
+		if event.OldTreasure != nil {
+			treasureToKeyValuePair(event.OldTreasure, convertedOldTreasure)
+		}
+
+	case treasure.StatusDeleted:
+
+		if event.DeletedTreasure != nil {
+			treasureToKeyValuePair(event.DeletedTreasure, convertedDeletedTreasure)
+		}
+
+	default:
+
+		return nil
+
+	}
+
+	return &hydrapb.SubscribeToEventsResponse{
+		SwampName:       event.SwampName.Get(),
+		Treasure:        convertedTreasure,
+		Status:          convertedStatusType,
+		OldTreasure:     convertedOldTreasure,
+		DeletedTreasure: convertedDeletedTreasure,
+		EventTime:       convertedEventTime,
+		Sequence:        event.Sequence,
+	}
+
+}
+
+func (g Gateway) SubscribeToInfo(in *hydrapb.SubscribeToInfoRequest, infoServer hydrapb.HydraideService_SubscribeToInfoServer) error {
+
+	// do not use the g.ZeusInterface.GetSafeops().LockSystem() because if we use it, we can never stop the server because of the active subscribers
+	// until the client closes the connection
+	defer handlePanic()
+
+	// check if the swamp name is correct
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+	}
+
+	// make the channel for the subscriber
+	subscriberUUID := uuid.New()
+
+	infoSubscriptionCallbackFunction := func(info *swamp.Info) {
+		// send the event to the client
+		func() {
+
+			defer handlePanic()
+
+			// get the canonical form of the swamp name
+			infoSwampName := info.SwampName.Get()
+
+			// send the info to the client
+			if sendErr := infoServer.Send(&hydrapb.SubscribeToInfoResponse{
+				SwampName:   infoSwampName,
+				AllElements: info.AllElements,
+			}); sendErr != nil {
+				slog.Error("failed to send the info to the client",
+					"error", sendErr.Error(),
+					"swamp_name", infoSwampName)
+			}
+
+		}()
+	}
+
+	// subscribe to the swamp for information
+	hydraInterface := g.ZeusInterface.GetHydra()
+	if err := hydraInterface.SubscribeToSwampInfo(subscriberUUID, swampName, infoSubscriptionCallbackFunction); err != nil {
+		return hydraError(err)
+	}
+
+	defer func() {
+		handlePanic()
+		// remove the subscriber from the swamp when the client closes the connection
+		if err := hydraInterface.UnsubscribeFromSwampInfo(subscriberUUID, swampName); err != nil {
+			slog.Error("failed to unsubscribe the subscriber from the swamp",
+				"uuid", subscriberUUID,
+				"error", err.Error())
+		}
+		slog.Debug("the subscriber is removed from the swamp",
+			"uuid", subscriberUUID,
+		)
+	}()
+
+	for {
+		select {
+		// várunk arra, hogy a user bezárja a kapcsolatot
+		case <-infoServer.Context().Done():
+			// remove the subscriber from the swamp when the client closes the connection
+			if err := hydraInterface.UnsubscribeFromSwampInfo(subscriberUUID, swampName); err != nil {
+				slog.Error("failed to unsubscribe the subscriber from the swamp",
+					"uuid", subscriberUUID,
+					"error", err.Error(),
+				)
+			}
+			return nil
+
+		}
+	}
+
+}
+
+func (g Gateway) Uint32SlicePush(ctx context.Context, in *hydrapb.AddToUint32SlicePushRequest) (*hydrapb.AddToUint32SlicePushResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range in.GetKeySlicePairs() {
+		if err := g.checkKeyLength(pair.GetKey()); err != nil {
+			return nil, err
+		}
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// nothing of the request is written if a slice would be larger than the max value size after the push
+	if g.MaxValueSize > 0 {
+		for _, pair := range in.GetKeySlicePairs() {
+			treasureObj, err := swampObj.GetTreasure(pair.GetKey())
+			if err != nil {
+				treasureObj = nil
+			}
+			if err := g.checkValueSize(in.GetSwampName(), pair.GetKey(), uint32SliceSizeAfterPush(treasureObj, pair.GetValues())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var errorsWhilePush []string
+	var limitErr error
+	results := make([]*hydrapb.Uint32SlicePushResult, 0, len(in.KeySlicePairs))
+
+	for _, pair := range in.KeySlicePairs {
+
+		func() {
+
+			unlock := swampObj.LockKey(pair.GetKey())
+			defer unlock()
+
+			treasureObj := swampObj.CreateTreasure(pair.GetKey())
+
+			// the slice could grow by an other push since the check of the request
+			if g.MaxValueSize > 0 {
+				if err := g.checkValueSize(in.GetSwampName(), pair.GetKey(), uint32SliceSizeAfterPush(treasureObj, pair.GetValues())); err != nil {
+					limitErr = err
+					return
+				}
+			}
+
+			guardID := treasureObj.StartTreasureGuard(true)
+			defer treasureObj.ReleaseTreasureGuard(guardID)
+
+			added, err := treasureObj.Uint32SlicePush(pair.GetValues())
+			if err != nil {
+				errorsWhilePush = append(errorsWhilePush, err.Error())
+				return
+			}
+
+			results = append(results, &hydrapb.Uint32SlicePushResult{
+				Key:            pair.GetKey(),
+				Added:          uint64(added),
+				AlreadyPresent: uint64(len(pair.GetValues()) - added),
+			})
+
+			// save the treasure only if a value was added, so a new treasure gets into the swamp, and the change is
+			// written to the disk
+			if treasureObj.IsContentChanged() {
+				treasureObj.Save(guardID)
+			}
+
+		}()
+
+	}
+
+	if len(errorsWhilePush) > 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the following errors occurred: %s", strings.Join(errorsWhilePush, ", ")))
+	}
+	if limitErr != nil {
+		return nil, limitErr
+	}
+
+	return &hydrapb.AddToUint32SlicePushResponse{
+		Results: results,
+	}, nil
+
+}
+
+func (g Gateway) Uint32SliceDelete(ctx context.Context, in *hydrapb.Uint32SliceDeleteRequest) (*hydrapb.Uint32SliceDeleteResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	var errorsWhileDelete []string
+
+	for _, pair := range in.KeySlicePairs {
+
+		func() {
+
+			// a push must not add to the slice while its emptied treasure is deleted
+			unlock := swampObj.LockKey(pair.GetKey())
+			defer unlock()
+
+			// try to load the treasure
+			treasureObj, err := swampObj.GetTreasure(pair.GetKey())
+			// the treasure does not exist so we can't delete the slice from it
+			if err != nil {
+				return
+			}
+
+			guardID := treasureObj.StartTreasureGuard(true)
+			defer treasureObj.ReleaseTreasureGuard(guardID)
+
+			if err := treasureObj.Uint32SliceDelete(pair.GetValues()); err != nil {
+				errorsWhileDelete = append(errorsWhileDelete, err.Error())
+			}
+
+			// check the length of the slice in the treasure
+			// if the length is 0, we can delete the treasure
+			size, err := treasureObj.Uint32SliceSize()
+			if err != nil || size == 0 {
+				// delete the treasure
+				if err := swampObj.DeleteTreasure(pair.GetKey(), false); err != nil {
+					errorsWhileDelete = append(errorsWhileDelete, err.Error())
+				}
+			}
+
+		}()
+
+	}
+
+	if len(errorsWhileDelete) > 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the following errors occurred: %s", strings.Join(errorsWhileDelete, ", ")))
+	}
+
+	return nil, nil
+
+}
+
+func (g Gateway) Uint32SliceSize(ctx context.Context, in *hydrapb.Uint32SliceSizeRequest) (*hydrapb.Uint32SliceSizeResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	treasureObj, err := swampObj.GetTreasure(in.GetKey())
+	if err != nil {
+		return &hydrapb.Uint32SliceSizeResponse{Size: 0}, statusError(codes.InvalidArgument, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("the key does not exist: %s", err.Error()))
+	}
+
+	size, err := treasureObj.Uint32SliceSize()
+	if err != nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the treasure type is not slice. err: %s", err.Error()))
+	}
+
+	return &hydrapb.Uint32SliceSizeResponse{Size: int64(size)}, nil
+
+}
+
+func (g Gateway) Uint32SliceIsValueExist(ctx context.Context, in *hydrapb.Uint32SliceIsValueExistRequest) (*hydrapb.Uint32SliceIsValueExistResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	treasureObj, err := swampObj.GetTreasure(in.GetKey())
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("the key does not exist: %s", err.Error()))
+	}
+
+	sl, err := treasureObj.Uint32SliceGetAll()
+	for _, value := range sl {
+		if value == in.GetValue() {
+			return &hydrapb.Uint32SliceIsValueExistResponse{IsExist: true}, nil
+		}
+	}
+
+	return &hydrapb.Uint32SliceIsValueExistResponse{IsExist: false}, nil
+}
+
+func (g Gateway) Uint32SliceGetRange(ctx context.Context, in *hydrapb.Uint32SliceGetRangeRequest) (*hydrapb.Uint32SliceGetRangeResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.GetOffset() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Offset cannot be negative")
+	}
+	if in.GetLimit() <= 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Limit must be positive")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	treasureObj, err := swampObj.GetTreasure(in.GetKey())
+	if err != nil {
+		return nil, statusError(codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("the key does not exist: %s", err.Error()))
+	}
+
+	size, err := treasureObj.Uint32SliceSize()
+	if err != nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the treasure type is not slice. err: %s", err.Error()))
+	}
+
+	values, err := treasureObj.Uint32SliceGetRange(int(min(in.GetOffset(), int64(size))), int(min(in.GetLimit(), int64(size))))
+	if err != nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the treasure type is not slice. err: %s", err.Error()))
+	}
+
+	return &hydrapb.Uint32SliceGetRangeResponse{
+		Values: values,
+		Size:   int64(size),
+	}, nil
+
+}
+
+// defaultUint32SliceStreamBatchSize is the number of the values in one message of the Uint32SliceStream if the client
+// did not set it. 10000 values are 40 KB, far below the max message size
+const defaultUint32SliceStreamBatchSize = 10000
+
+func (g Gateway) Uint32SliceStream(in *hydrapb.Uint32SliceStreamRequest, stream hydrapb.HydraideService_Uint32SliceStreamServer) error {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(stream.Context(), in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	treasureObj, err := swampObj.GetTreasure(in.GetKey())
+	if err != nil {
+		return statusError(codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND, fmt.Sprintf("the key does not exist: %s", err.Error()))
+	}
+
+	// the values are copied at the start, so the pushes and the deletes of the key do not shift the values of the
+	// stream, and the key is not locked while a slow client reads
+	values, err := treasureObj.Uint32SliceGetAll()
+	if err != nil {
+		return statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the treasure type is not slice. err: %s", err.Error()))
+	}
+
+	batchSize := int(in.GetBatchSize())
+	if batchSize <= 0 {
+		batchSize = defaultUint32SliceStreamBatchSize
+	}
+
+	for from := 0; from < len(values); from += batchSize {
+		if err := stream.Send(&hydrapb.Uint32SliceStreamResponse{Values: values[from:min(from+batchSize, len(values))]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+func (g Gateway) Uint32SliceCombine(in *hydrapb.Uint32SliceCombineRequest, stream hydrapb.HydraideService_Uint32SliceCombineServer) error {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if len(in.GetKeys()) == 0 {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Keys cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(stream.Context(), in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	values, err := combineUint32Slices(swampObj, in.GetOperator(), in.GetKeys())
+	if err != nil {
+		return err
+	}
+
+	batchSize := int(in.GetBatchSize())
+	if batchSize <= 0 {
+		batchSize = defaultUint32SliceStreamBatchSize
+	}
+
+	for from := 0; from < len(values); from += batchSize {
+		if err := stream.Send(&hydrapb.Uint32SliceStreamResponse{Values: values[from:min(from+batchSize, len(values))]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+func (g Gateway) Uint32SliceCombineInto(ctx context.Context, in *hydrapb.Uint32SliceCombineIntoRequest) (*hydrapb.Uint32SliceCombineIntoResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if len(in.GetKeys()) == 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Keys cannot be empty")
+	}
+	if in.GetDestinationKey() == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "DestinationKey cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetDestinationKey()); err != nil {
+		return nil, err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// the destination is locked before the sources are read, so two combinations into the same key, or a push to the
+	// destination can not interleave with this one. The other sources are not locked, their changes during the
+	// combination may or may not be in the result
+	unlock := swampObj.LockKey(in.GetDestinationKey())
+	defer unlock()
+
+	values, err := combineUint32Slices(swampObj, in.GetOperator(), in.GetKeys())
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkValueSize(in.GetSwampName(), in.GetDestinationKey(), int64(len(values)*4)); err != nil {
+		return nil, err
+	}
+
+	treasureObj, err := swampObj.GetTreasure(in.GetDestinationKey())
+	exists := err == nil
+	if exists {
+		if contentType := treasureObj.GetContentType(); contentType != treasure.ContentTypeUint32Slice && contentType != treasure.ContentTypeVoid {
+			return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, "the treasure type of the destination key is not slice")
+		}
+	}
+
+	// an empty slice is not kept, like an emptied slice of the Uint32SliceDelete
+	if len(values) == 0 {
+		if exists {
+			if err := swampObj.DeleteTreasure(in.GetDestinationKey(), false); err != nil {
+				return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not delete the destination key: %s", err.Error()))
+			}
+		}
+		return &hydrapb.Uint32SliceCombineIntoResponse{Size: 0}, nil
+	}
+
+	// the unchanged destination is not saved again, so the subscribers do not get an event of a no-op
+	if exists {
+		if current, err := treasureObj.Uint32SliceGetAll(); err == nil && slices.Equal(current, values) {
+			return &hydrapb.Uint32SliceCombineIntoResponse{Size: int64(len(values))}, nil
+		}
+	}
+
+	treasureObj = swampObj.CreateTreasure(in.GetDestinationKey())
+
+	guardID := treasureObj.StartTreasureGuard(true)
+	defer treasureObj.ReleaseTreasureGuard(guardID)
+
+	treasureObj.ResetContentUint32Slice(guardID)
+	if _, err := treasureObj.Uint32SlicePush(values); err != nil {
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not set the destination key: %s", err.Error()))
+	}
+	treasureObj.Save(guardID)
+
+	return &hydrapb.Uint32SliceCombineIntoResponse{Size: int64(len(values))}, nil
+
+}
+
+// combineUint32Slices reads the uint32 slices of the keys, and returns the result of the operator on them. A missing
+// key is an empty slice
+func combineUint32Slices(swampObj swamp.Swamp, operator hydrapb.Uint32SliceCombination_Operator, keys []string) ([]uint32, error) {
+
+	var setOperator sliceset.Operator
+	switch operator {
+	case hydrapb.Uint32SliceCombination_UNION:
+		setOperator = sliceset.Union
+	case hydrapb.Uint32SliceCombination_INTERSECTION:
+		setOperator = sliceset.Intersection
+	case hydrapb.Uint32SliceCombination_DIFFERENCE:
+		setOperator = sliceset.Difference
+	default:
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("unknown operator: %d", operator))
+	}
+
+	sets := make([][]uint32, 0, len(keys))
+	for _, key := range keys {
+		treasureObj, err := swampObj.GetTreasure(key)
+		if err != nil {
+			sets = append(sets, nil)
+			continue
+		}
+		values, err := treasureObj.Uint32SliceGetAll()
+		if err != nil {
+			return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the treasure type of the key %s is not slice. err: %s", key, err.Error()))
+		}
+		sets = append(sets, values)
+	}
+
+	result, err := sliceset.Compute(setOperator, sets)
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+	}
+
+	return result, nil
+
+}
+
+func (g Gateway) IncrementInt8(ctx context.Context, in *hydrapb.IncrementInt8Request) (*hydrapb.IncrementInt8Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementInt8Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementInt8Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              int8(in.GetCondition().GetValue()),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementInt8(in.Key, int8(in.IncrementBy), condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementInt8Response{
+		Value:         int32(newValue),
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementInt16(ctx context.Context, in *hydrapb.IncrementInt16Request) (*hydrapb.IncrementInt16Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementInt16Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementInt16Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              int16(in.GetCondition().GetValue()),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementInt16(in.Key, int16(in.IncrementBy), condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementInt16Response{
+		Value:         int32(newValue),
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementInt32(ctx context.Context, in *hydrapb.IncrementInt32Request) (*hydrapb.IncrementInt32Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementInt32Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementInt32Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              in.GetCondition().GetValue(),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementInt32(in.Key, in.IncrementBy, condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementInt32Response{
+		Value:         newValue,
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementInt64(ctx context.Context, in *hydrapb.IncrementInt64Request) (*hydrapb.IncrementInt64Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementInt64Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementInt64Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              in.GetCondition().GetValue(),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementInt64(in.Key, in.IncrementBy, condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementInt64Response{
+		Value:         newValue,
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementUint8(ctx context.Context, in *hydrapb.IncrementUint8Request) (*hydrapb.IncrementUint8Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementUInt8Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementUInt8Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              uint8(in.GetCondition().GetValue()),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementUint8(in.Key, uint8(in.IncrementBy), condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementUint8Response{
+		Value:         uint32(newValue),
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementUint16(ctx context.Context, in *hydrapb.IncrementUint16Request) (*hydrapb.IncrementUint16Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementUInt16Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementUInt16Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              uint16(in.GetCondition().GetValue()),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementUint16(in.Key, uint16(in.IncrementBy), condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementUint16Response{
+		Value:         uint32(newValue),
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementUint32(ctx context.Context, in *hydrapb.IncrementUint32Request) (*hydrapb.IncrementUint32Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementUInt32Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementUInt32Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              in.GetCondition().GetValue(),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementUint32(in.Key, in.IncrementBy, condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementUint32Response{
+		Value:         newValue,
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementUint64(ctx context.Context, in *hydrapb.IncrementUint64Request) (*hydrapb.IncrementUint64Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementUInt64Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementUInt64Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              in.GetCondition().GetValue(),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementUint64(in.Key, in.IncrementBy, condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not an integer: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementUint64Response{
+		Value:         newValue,
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementFloat32(ctx context.Context, in *hydrapb.IncrementFloat32Request) (*hydrapb.IncrementFloat32Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementFloat32Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementFloat32Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              in.GetCondition().GetValue(),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementFloat32(in.Key, in.IncrementBy, condition)
+
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not a float: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementFloat32Response{
+		Value:         newValue,
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) IncrementFloat64(ctx context.Context, in *hydrapb.IncrementFloat64Request) (*hydrapb.IncrementFloat64Response, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.IncrementBy == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "IncrementBy cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// get the hydra interface
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampObj, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.IncrementFloat64Condition
+	if in.GetCondition() != nil {
+		condition = &swamp.IncrementFloat64Condition{
+			RelationalOperator: relationalOperatorToSwampRelationalOperator(in.GetCondition().GetRelationalOperator()),
+			Value:              in.GetCondition().GetValue(),
+		}
+	}
+
+	// increment the value with the condition
+	newValue, isIncremented, err := swampObj.IncrementFloat64(in.Key, in.IncrementBy, condition)
+
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not a float: %s", err.Error()))
+	}
+
+	// return with the new value and the status of the increment
+	return &hydrapb.IncrementFloat64Response{
+		Value:         newValue,
+		IsIncremented: isIncremented,
+	}, nil
+
+}
+
+func (g Gateway) SetIfLater(ctx context.Context, in *hydrapb.SetIfLaterRequest) (*hydrapb.SetIfLaterResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	storedTimestamp, isSet, err := swampObj.SetIfLater(in.Key, in.Timestamp)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not a timestamp: %s", err.Error()))
+	}
+
+	return &hydrapb.SetIfLaterResponse{
+		Timestamp: storedTimestamp,
+		IsSet:     isSet,
+	}, nil
+
+}
+
+func (g Gateway) AddDuration(ctx context.Context, in *hydrapb.AddDurationRequest) (*hydrapb.AddDurationResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.Seconds == 0 {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Seconds cannot be zero")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	newTimestamp, err := swampObj.AddDuration(in.Key, in.Seconds, in.FromNowIfPast)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not a timestamp: %s", err.Error()))
+	}
+
+	return &hydrapb.AddDurationResponse{
+		Timestamp: newTimestamp,
+	}, nil
+
+}
+
+func (g Gateway) SetStringIf(ctx context.Context, in *hydrapb.SetStringIfRequest) (*hydrapb.SetStringIfResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.SetStringCondition
+	if in.GetCondition() != nil {
+		condition = &swamp.SetStringCondition{
+			Operator: setIfOperatorToSwampSetIfOperator(in.GetCondition().GetOperator()),
+			Value:    in.GetCondition().GetValue(),
+		}
+	}
+
+	storedValue, isSet, err := swampObj.SetStringIf(in.Key, in.Value, condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not a string: %s", err.Error()))
+	}
+
+	return &hydrapb.SetStringIfResponse{
+		Value: storedValue,
+		IsSet: isSet,
+	}, nil
+
+}
+
+func (g Gateway) SetBoolIf(ctx context.Context, in *hydrapb.SetBoolIfRequest) (*hydrapb.SetBoolIfResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.SwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	// check the name of the swamp
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkKeyLength(in.GetKey()); err != nil {
+		return nil, err
+	}
+
+	// summon the swamp
+	swampObj, err := g.ZeusInterface.GetHydra().SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampObj.BeginVigil()
+	defer swampObj.CeaseVigil()
+
+	// create the condition if it is not nil
+	var condition *swamp.SetBoolCondition
+	if in.GetCondition() != nil {
+		condition = &swamp.SetBoolCondition{
+			Operator: setIfOperatorToSwampSetIfOperator(in.GetCondition().GetOperator()),
+			Value:    in.GetCondition().GetValue(),
+		}
+	}
+
+	storedValue, isSet, err := swampObj.SetBoolIf(in.Key, in.Value, condition)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_WRONG_VALUE_TYPE, fmt.Sprintf("the value of the key is not a bool: %s", err.Error()))
+	}
+
+	return &hydrapb.SetBoolIfResponse{
+		Value: storedValue,
+		IsSet: isSet,
+	}, nil
+
+}
+
+// SetSwampAnnotation sets an annotation in the metadata of the swamp. An empty value deletes the annotation
+func (g Gateway) SetSwampAnnotation(ctx context.Context, in *hydrapb.SetSwampAnnotationRequest) (*hydrapb.SetSwampAnnotationResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.GetKey() == "" {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "Key cannot be empty")
+	}
+
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	if err := swampInterface.GetMetadata().SetAnnotation(in.GetKey(), in.GetValue()); err != nil {
+		if errors.Is(err, metadata.ErrTooManyAnnotations) {
+			return nil, statusError(codes.ResourceExhausted, hydrapb.ErrorReason_QUOTA_EXCEEDED, err.Error())
+		}
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+	}
+
+	return &hydrapb.SetSwampAnnotationResponse{}, nil
+
+}
+
+func (g Gateway) GetSwampAnnotations(ctx context.Context, in *hydrapb.GetSwampAnnotationsRequest) (*hydrapb.GetSwampAnnotationsResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	return &hydrapb.GetSwampAnnotationsResponse{
+		Annotations: swampInterface.GetMetadata().GetAnnotations(),
+	}, nil
+
+}
+
+// Aggregate computes the count, sum, min, max and avg of the numeric values of the swamp.
+// The key prefix and the filter expression are applied before the From and Limit range of the index,
+// and the treasures without numeric value are skipped.
+func (g Gateway) Aggregate(ctx context.Context, in *hydrapb.AggregateRequest) (*hydrapb.AggregateResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.GetFrom() < 0 || in.GetLimit() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "From and Limit cannot be negative")
+	}
+
+	var filterExpression filter.Expression
+	if in.GetFilterExpr() != "" {
+		var err error
+		filterExpression, err = filter.Parse(in.GetFilterExpr())
+		if err != nil {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_FILTER_EXPRESSION, fmt.Sprintf("invalid filter expression: %s", err.Error()))
+		}
+	}
+
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
+		// return with grpc error message
+		return nil, hydraError(err)
+	}
+
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	// get all treasures in the order of the index, because the prefix and the filter must be applied before the range
+	treasures, err := swampInterface.GetTreasuresByBeacon(inputIndexTypeToBeaconType(in.GetIndexType()),
+		inputOrderTypeToBeaconOrderType(in.GetOrderType()), 0, 0)
+	if err != nil {
+		// return with grpc error message
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("hydra error: %s", err.Error()))
+	}
+
+	aggregator := aggregate.New()
+	skipped := int32(0)
+	for _, treasureInterface := range treasures {
+		if in.GetKeyPrefix() != "" && !strings.HasPrefix(treasureInterface.GetKey(), in.GetKeyPrefix()) {
+			continue
+		}
+		if filterExpression != nil && !filterExpression.Evaluate(treasureInterface) {
+			continue
+		}
+		if skipped < in.GetFrom() {
+			if aggregate.IsNumeric(treasureInterface) {
+				skipped++
+			}
+			continue
+		}
+		aggregator.Add(treasureInterface)
+		if in.GetLimit() > 0 && aggregator.Count() >= int64(in.GetLimit()) {
+			break
+		}
+	}
+
+	result := aggregator.Result()
+	response := &hydrapb.AggregateResponse{
+		Count: result.Count,
+		Sum:   result.Sum,
+	}
+	if result.Count > 0 {
+		response.Min = &result.Min
+		response.Max = &result.Max
+		response.Avg = &result.Avg
+	}
+	if result.IntegerSumValid {
+		response.IntegerSum = &result.IntegerSum
+	}
+
+	return response, nil
+
+}
+
+// keyValuesToTreasure sets the content and the metadata of the treasure from the key value pair. If serverTimestamps
+// is true, the creation and modification times of the client are ignored, because the swamp sets them.
+// This function does not save the treasure, only sets its content
+func keyValuesToTreasure(keyValuePair *hydrapb.KeyValuePair, treasureInterface treasure.Treasure, guardID guard.ID, serverTimestamps bool) {
+
+	// Ensure keyValuePair is not nil to avoid panic
+	if keyValuePair == nil {
+		treasureInterface.SetContentVoid(guardID)
+		return
+	}
+
+	// convert interface to the correct type and set the content
+	switch {
+	case keyValuePair.Int8Val != nil:
+		treasureInterface.SetContentInt8(guardID, int8(*keyValuePair.Int8Val))
+	case keyValuePair.Int16Val != nil:
+		treasureInterface.SetContentInt16(guardID, int16(*keyValuePair.Int16Val))
+	case keyValuePair.Int32Val != nil:
+		treasureInterface.SetContentInt32(guardID, *keyValuePair.Int32Val)
+	case keyValuePair.Int64Val != nil:
+		treasureInterface.SetContentInt64(guardID, *keyValuePair.Int64Val)
+	case keyValuePair.Uint8Val != nil:
+		treasureInterface.SetContentUint8(guardID, uint8(*keyValuePair.Uint8Val))
+	case keyValuePair.Uint16Val != nil:
+		treasureInterface.SetContentUint16(guardID, uint16(*keyValuePair.Uint16Val))
+	case keyValuePair.Uint32Val != nil:
+		treasureInterface.SetContentUint32(guardID, *keyValuePair.Uint32Val)
+	case keyValuePair.Uint64Val != nil:
+		treasureInterface.SetContentUint64(guardID, *keyValuePair.Uint64Val)
+	case keyValuePair.Float32Val != nil:
+		treasureInterface.SetContentFloat32(guardID, *keyValuePair.Float32Val)
+	case keyValuePair.Float64Val != nil:
+		treasureInterface.SetContentFloat64(guardID, *keyValuePair.Float64Val)
+	case keyValuePair.StringVal != nil:
+		treasureInterface.SetContentString(guardID, *keyValuePair.StringVal)
+	case keyValuePair.BoolVal != nil:
+		var booleanValue bool
+		switch *keyValuePair.BoolVal {
+		case hydrapb.Boolean_TRUE:
+			booleanValue = true
+		case hydrapb.Boolean_FALSE:
+			booleanValue = false
+		default:
+			booleanValue = false
+		}
+		treasureInterface.SetContentBool(guardID, booleanValue)
+	case keyValuePair.BytesVal != nil:
+		treasureInterface.SetContentByteArray(guardID, keyValuePair.BytesVal)
+	case keyValuePair.Uint32Slice != nil:
+
+		if _, err := treasureInterface.Uint32SlicePush(keyValuePair.Uint32Slice); err != nil {
+			slog.Error("failed to push the uint32 slice to the treasure", "error", err.Error())
+		}
+	case keyValuePair.VoidVal != nil && *keyValuePair.VoidVal:
+		// set the content to void
+		treasureInterface.SetContentVoid(guardID)
+	default:
+		treasureInterface.SetContentVoid(guardID)
+	}
+
+	// set other values if they are not empty
+	// the authors are set before the times, because setting an author stamps its time with the current time, and the
+	// times sent by the client must win
+	if keyValuePair.GetCreatedBy() != "" {
+		treasureInterface.SetCreatedBy(guardID, keyValuePair.GetCreatedBy())
+	}
+	if !serverTimestamps && isValidTimestamp(keyValuePair.GetCreatedAt()) {
+		treasureInterface.SetCreatedAt(guardID, keyValuePair.GetCreatedAt().AsTime())
+	}
+	if keyValuePair.GetUpdatedBy() != "" {
+		treasureInterface.SetModifiedBy(guardID, keyValuePair.GetUpdatedBy())
+	}
+	if !serverTimestamps && isValidTimestamp(keyValuePair.GetUpdatedAt()) {
+		treasureInterface.SetModifiedAt(guardID, keyValuePair.GetUpdatedAt().AsTime())
+	}
+	if isValidTimestamp(keyValuePair.GetExpiredAt()) {
+		treasureInterface.SetExpirationTime(guardID, keyValuePair.GetExpiredAt().AsTime())
+	}
+	if keyValuePair.SchemaVersion != nil {
+		treasureInterface.SetSchemaVersion(guardID, keyValuePair.GetSchemaVersion())
+	}
+}
+
+// treasureToKeyValuePair converts the treasure content from the hydra to the protobuf format
+func treasureToKeyValuePair(treasureInterface treasure.Treasure, t *hydrapb.Treasure) {
+
+	// Set the key of the treasure
+	t.Key = treasureInterface.GetKey()
+	t.IsExist = true
+
+	switch treasureInterface.GetContentType() {
+	case treasure.ContentTypeInt8:
+		val, contentErr := treasureInterface.GetContentInt8()
+		if contentErr == nil {
+			v := int32(val)
+			t.Int8Val = &v
+		}
+	case treasure.ContentTypeInt16:
+		val, contentErr := treasureInterface.GetContentInt16()
+		if contentErr == nil {
+			v := int32(val)
+			t.Int16Val = &v
+		}
+	case treasure.ContentTypeInt32:
+		val, contentErr := treasureInterface.GetContentInt32()
+		if contentErr == nil {
+			t.Int32Val = &val
+		}
+	case treasure.ContentTypeInt64:
+		val, contentErr := treasureInterface.GetContentInt64()
+		if contentErr == nil {
+			t.Int64Val = &val
+		}
+	case treasure.ContentTypeUint8:
+		val, contentErr := treasureInterface.GetContentUint8()
+		if contentErr == nil {
+			v := uint32(val)
+			t.Uint8Val = &v
+		}
+	case treasure.ContentTypeUint16:
+		val, contentErr := treasureInterface.GetContentUint16()
+		if contentErr == nil {
+			v := uint32(val)
+			t.Uint16Val = &v
+		}
+	case treasure.ContentTypeUint32:
+		val, contentErr := treasureInterface.GetContentUint32()
+		if contentErr == nil {
+			t.Uint32Val = &val
+		}
+	case treasure.ContentTypeUint64:
+		val, contentErr := treasureInterface.GetContentUint64()
+		if contentErr == nil {
+			t.Uint64Val = &val
+		}
+	case treasure.ContentTypeFloat32:
+		val, contentErr := treasureInterface.GetContentFloat32()
+		if contentErr == nil {
+			t.Float32Val = &val
+		}
+	case treasure.ContentTypeFloat64:
+		val, contentErr := treasureInterface.GetContentFloat64()
+		if contentErr == nil {
+			t.Float64Val = &val
+		}
+	case treasure.ContentTypeString:
+		val, contentErr := treasureInterface.GetContentString()
+		if contentErr == nil {
+			t.StringVal = &val
+		}
+	case treasure.ContentTypeBoolean:
+		val, contentErr := treasureInterface.GetContentBool()
+		if contentErr == nil {
+			// convert the boolean to the protobuf type
+			if val {
+				t.BoolVal = hydrapb.Boolean_TRUE.Enum()
+			} else {
+				t.BoolVal = hydrapb.Boolean_FALSE.Enum()
+			}
+		}
+	case treasure.ContentTypeByteArray:
+		val, contentErr := treasureInterface.GetContentByteArray()
 		if contentErr == nil {
 			t.BytesVal = val
 		}
-	case treasure.ContentTypeUint32Slice:
-		val, contentErr := treasureInterface.Uint32SliceGetAll()
-		if contentErr == nil {
-			t.Uint32Slice = val
+	case treasure.ContentTypeUint32Slice:
+		val, contentErr := treasureInterface.Uint32SliceGetAll()
+		if contentErr == nil {
+			t.Uint32Slice = val
+		}
+	default:
+		// do nothing
+	}
+
+	treasureMetadataToPb(treasureInterface, t)
+
+}
+
+// treasureMetadataToPb sets the metadata of the treasure in the protobuf treasure
+func treasureMetadataToPb(treasureInterface treasure.Treasure, t *hydrapb.Treasure) {
+
+	if treasureInterface.GetCreatedAt() > 0 {
+		t.CreatedAt = timestamppb.New(time.Unix(0, treasureInterface.GetCreatedAt()))
+	}
+	if treasureInterface.GetCreatedBy() != "" {
+		createdBy := treasureInterface.GetCreatedBy()
+		t.CreatedBy = &createdBy
+	}
+	if treasureInterface.GetModifiedAt() > 0 {
+		t.UpdatedAt = timestamppb.New(time.Unix(0, treasureInterface.GetModifiedAt()))
+	}
+	if treasureInterface.GetModifiedBy() != "" {
+		updatedBy := treasureInterface.GetModifiedBy()
+		t.UpdatedBy = &updatedBy
+	}
+	if treasureInterface.GetExpirationTime() > 0 {
+		t.ExpiredAt = timestamppb.New(time.Unix(0, treasureInterface.GetExpirationTime()))
+	}
+	if treasureInterface.GetSchemaVersion() > 0 {
+		schemaVersion := treasureInterface.GetSchemaVersion()
+		t.SchemaVersion = &schemaVersion
+	}
+
+}
+
+// countFilterFromPb converts the filter of the Count request to the filter of the swamp. It returns nil if the
+// request has no filter, so all treasures are counted.
+func countFilterFromPb(filter *hydrapb.CountFilter) *swamp.CountFilter {
+	if filter == nil {
+		return nil
+	}
+	countFilter := &swamp.CountFilter{
+		ExpiredOnly: filter.GetExpiredOnly(),
+		KeyPrefix:   filter.GetKeyPrefix(),
+	}
+	if isValidTimestamp(filter.GetUpdatedSince()) {
+		countFilter.UpdatedSince = filter.GetUpdatedSince().AsTime().UnixNano()
+	}
+	return countFilter
+}
+
+// isValidTimestamp checks if the timestamp is valid
+func isValidTimestamp(ts *timestamppb.Timestamp) bool {
+	if ts == nil {
+		return false
+	}
+	return ts.GetSeconds() > 0 || ts.GetNanos() > 0
+}
+
+// convertTreasureStatusToPbStatus converts the treasure status from the hydra to the protobuf status
+func convertTreasureStatusToPbStatus(treasureStatus treasure.TreasureStatus) hydrapb.Status_Code {
+	switch treasureStatus {
+	case treasure.StatusNew:
+		return hydrapb.Status_NEW
+	case treasure.StatusModified:
+		return hydrapb.Status_UPDATED
+	case treasure.StatusSame:
+		return hydrapb.Status_NOTHING_CHANGED
+	case treasure.StatusDeleted:
+		return hydrapb.Status_DELETED
+	default:
+		return hydrapb.Status_NOT_FOUND
+	}
+}
+
+// handle all the panics in the gateway
+func handlePanic() {
+	if r := recover(); r != nil {
+		// get the stack trace
+		stackTrace := debug.Stack()
+		// log the panic with the error and stack trace
+		slog.Error("grpc gateway panic", "error", r, "stack", string(stackTrace))
+	}
+}
+
+// checkSwampName check if the swamp name is valid and exist or not.
+// The function will return a grpc error message if the swamp name is invalid or does not exist.
+func checkSwampName(zeusInterface zeus.Zeus, islandID uint64, inputSwampName string, checkExist bool) (name.Name, error) {
+
+	// check the input
+	if inputSwampName == "" {
+		// return with grpc error message
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	swampName := name.Load(inputSwampName)
+
+	// check the existence of the swamp only if it is needed
+	// because the Set method does not need to check the existence of the swamp
+	if checkExist {
+		// check if the swamp is exist or not
+		hydraInterface := zeusInterface.GetHydra()
+		isExist, err := hydraInterface.IsExistSwamp(islandID, swampName)
+		if err != nil || !isExist {
+			// return with grpc error message
+			return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_SWAMP_NOT_FOUND, "Swamp does not exist")
+		}
+	}
+
+	return swampName, nil
+
+}
+
+// metadataFields maps the metadata fields of the requests to their names in the settings
+var metadataFields = map[hydrapb.Metadata_Field]string{
+	hydrapb.Metadata_CREATED_AT: setting.MetadataCreatedAt,
+	hydrapb.Metadata_CREATED_BY: setting.MetadataCreatedBy,
+	hydrapb.Metadata_UPDATED_AT: setting.MetadataUpdatedAt,
+	hydrapb.Metadata_UPDATED_BY: setting.MetadataUpdatedBy,
+	hydrapb.Metadata_EXPIRED_AT: setting.MetadataExpiredAt,
+}
+
+// beaconNames maps the beacons of the requests to their names in the settings
+var beaconNames = map[hydrapb.Beacon_Type]string{
+	hydrapb.Beacon_KEY:             setting.BeaconKey,
+	hydrapb.Beacon_CREATION_TIME:   setting.BeaconCreationTime,
+	hydrapb.Beacon_UPDATE_TIME:     setting.BeaconUpdateTime,
+	hydrapb.Beacon_EXPIRATION_TIME: setting.BeaconExpirationTime,
+	hydrapb.Beacon_VALUE:           setting.BeaconValue,
+}
+
+// fsyncPolicies are the fsync policies of the RegisterSwampRequest. The default policy is empty, so the default of the
+// server is used.
+var fsyncPolicies = map[hydrapb.FsyncPolicy_Policy]setting.FsyncPolicy{
+	hydrapb.FsyncPolicy_DEFAULT:  "",
+	hydrapb.FsyncPolicy_NEVER:    setting.FsyncNever,
+	hydrapb.FsyncPolicy_INTERVAL: setting.FsyncInterval,
+	hydrapb.FsyncPolicy_ALWAYS:   setting.FsyncAlways,
+}
+
+// fsyncPolicyToProto returns the proto form of the fsync policy, the empty policy is never
+func fsyncPolicyToProto(policy setting.FsyncPolicy) hydrapb.FsyncPolicy_Policy {
+	for protoPolicy, p := range fsyncPolicies {
+		if p != "" && p == policy.Or(setting.FsyncNever) {
+			return protoPolicy
+		}
+	}
+	return hydrapb.FsyncPolicy_DEFAULT
+}
+
+// defaultFsyncInterval is the fsync interval in seconds of the interval policy if neither the client nor the server
+// sets it
+const defaultFsyncInterval = 1
+
+// checkSchemaConstraints checks the treasures of the request against the schema constraints of the swamp pattern.
+// The function will return a grpc error message naming the swamp, the key and the violated constraint.
+func checkSchemaConstraints(swampSetting setting.Setting, swampRequest *hydrapb.SwampRequest) error {
+
+	maxKeyLength := swampSetting.GetMaxKeyLength()
+	maxValueSize := swampSetting.GetMaxValueSizeByte()
+	requiredMetadata := swampSetting.GetRequiredMetadata()
+	if maxKeyLength == 0 && maxValueSize == 0 && len(requiredMetadata) == 0 {
+		return nil
+	}
+
+	violation := func(key string, format string, args ...any) error {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_SCHEMA_VIOLATION,
+			fmt.Sprintf("the treasure %q of the swamp %s violates the schema: %s", key, swampRequest.GetSwampName(), fmt.Sprintf(format, args...)))
+	}
+
+	for _, kv := range swampRequest.GetKeyValues() {
+
+		if maxKeyLength > 0 && len(kv.GetKey()) > maxKeyLength {
+			return violation(kv.GetKey(), "the key is %d bytes, longer than the max key length of %d bytes", len(kv.GetKey()), maxKeyLength)
+		}
+
+		if maxValueSize > 0 {
+			if size := valueSize(kv); size > maxValueSize {
+				return violation(kv.GetKey(), "the value is %d bytes, larger than the max value size of %d bytes", size, maxValueSize)
+			}
+		}
+
+		for _, field := range requiredMetadata {
+			if !hasMetadata(kv, field, swampSetting) {
+				return violation(kv.GetKey(), "the required metadata %s is missing", field)
+			}
+		}
+
+	}
+
+	return nil
+
+}
+
+// checkWriteLimits checks the keys and the values of the request against the limits of the server
+func (g Gateway) checkWriteLimits(swampRequest *hydrapb.SwampRequest) error {
+
+	for _, kv := range swampRequest.GetKeyValues() {
+		if err := g.checkKeyLength(kv.GetKey()); err != nil {
+			return err
+		}
+		if err := g.checkValueSize(swampRequest.GetSwampName(), kv.GetKey(), valueSize(kv)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// checkValueSize checks the size of the written value of the treasure against the max value size of the server
+func (g Gateway) checkValueSize(swampName string, key string, size int64) error {
+	if g.MaxValueSize > 0 && size > g.MaxValueSize {
+		return LimitExceededError(LimitMaxValueSize, g.MaxValueSize, size,
+			fmt.Sprintf("the value of the treasure %q of the swamp %s is %d bytes, larger than the max value size of %d bytes", key, swampName, size, g.MaxValueSize))
+	}
+	return nil
+}
+
+// uint32SliceSizeAfterPush returns the size of the uint32 slice of the treasure in bytes after the push of the values.
+// The treasure is nil if it does not exist yet
+func uint32SliceSizeAfterPush(treasureObj treasure.Treasure, values []uint32) int64 {
+	merged := make(map[uint32]struct{}, len(values))
+	if treasureObj != nil {
+		if current, err := treasureObj.Uint32SliceGetAll(); err == nil {
+			for _, v := range current {
+				merged[v] = struct{}{}
+			}
+		}
+	}
+	for _, v := range values {
+		merged[v] = struct{}{}
+	}
+	return int64(len(merged) * 4)
+}
+
+// checkKeyLength checks the written key against the max key length of the server
+func (g Gateway) checkKeyLength(key string) error {
+	if g.MaxKeyLength > 0 && len(key) > g.MaxKeyLength {
+		return LimitExceededError(LimitMaxKeyLength, int64(g.MaxKeyLength), int64(len(key)),
+			fmt.Sprintf("the key is %d bytes, longer than the max key length of %d bytes", len(key), g.MaxKeyLength))
+	}
+	return nil
+}
+
+// valueSize returns the size of the variable size value of the treasure in bytes. The fixed size values are 0
+func valueSize(kv *hydrapb.KeyValuePair) int64 {
+	switch {
+	case kv.BytesVal != nil:
+		return int64(len(kv.GetBytesVal()))
+	case kv.StringVal != nil:
+		return int64(len(kv.GetStringVal()))
+	case kv.Uint32Slice != nil:
+		return int64(len(kv.GetUint32Slice()) * 4)
+	default:
+		return 0
+	}
+}
+
+// hasMetadata returns true if the treasure has the metadata field, or the server sets it by the settings of the swamp
+func hasMetadata(kv *hydrapb.KeyValuePair, field string, swampSetting setting.Setting) bool {
+	switch field {
+	case setting.MetadataCreatedAt:
+		return kv.GetCreatedAt() != nil || swampSetting.IsServerTimestamped()
+	case setting.MetadataCreatedBy:
+		return kv.GetCreatedBy() != ""
+	case setting.MetadataUpdatedAt:
+		return kv.GetUpdatedAt() != nil || swampSetting.IsServerTimestamped()
+	case setting.MetadataUpdatedBy:
+		return kv.GetUpdatedBy() != ""
+	case setting.MetadataExpiredAt:
+		return kv.GetExpiredAt() != nil || swampSetting.GetDefaultExpireAfter() > 0
+	default:
+		return true
+	}
+}
+
+func inputIndexTypeToBeaconType(inputIndexType hydrapb.IndexType_Type) swamp.BeaconType {
+	switch inputIndexType {
+	case hydrapb.IndexType_EXPIRATION_TIME:
+		return swamp.BeaconTypeExpirationTime
+	case hydrapb.IndexType_CREATION_TIME:
+		return swamp.BeaconTypeCreationTime
+	case hydrapb.IndexType_UPDATE_TIME:
+		return swamp.BeaconTypeUpdateTime
+	case hydrapb.IndexType_VALUE_INT8:
+		return swamp.BeaconTypeValueInt8
+	case hydrapb.IndexType_VALUE_INT16:
+		return swamp.BeaconTypeValueInt16
+	case hydrapb.IndexType_VALUE_INT32:
+		return swamp.BeaconTypeValueInt32
+	case hydrapb.IndexType_VALUE_INT64:
+		return swamp.BeaconTypeValueInt64
+	case hydrapb.IndexType_VALUE_UINT8:
+		return swamp.BeaconTypeValueUint8
+	case hydrapb.IndexType_VALUE_UINT16:
+		return swamp.BeaconTypeValueUint16
+	case hydrapb.IndexType_VALUE_UINT32:
+		return swamp.BeaconTypeValueUint32
+	case hydrapb.IndexType_VALUE_UINT64:
+		return swamp.BeaconTypeValueUint64
+	case hydrapb.IndexType_VALUE_FLOAT32:
+		return swamp.BeaconTypeValueFloat32
+	case hydrapb.IndexType_VALUE_FLOAT64:
+		return swamp.BeaconTypeValueFloat64
+	case hydrapb.IndexType_VALUE_STRING:
+		return swamp.BeaconTypeValueString
+	default:
+		return swamp.BeaconTypeValueString
+	}
+}
+
+func inputOrderTypeToBeaconOrderType(inputOrderType hydrapb.OrderType_Type) swamp.BeaconOrder {
+	switch inputOrderType {
+	case hydrapb.OrderType_ASC:
+		return swamp.IndexOrderAsc
+	case hydrapb.OrderType_DESC:
+		return swamp.IndexOrderDesc
+	default:
+		return swamp.IndexOrderAsc
+	}
+}
+
+func relationalOperatorToSwampRelationalOperator(operator hydrapb.Relational_Operator) swamp.RelationalOperator {
+	switch operator {
+	case hydrapb.Relational_EQUAL:
+		return swamp.RelationalOperatorEqual
+	case hydrapb.Relational_LESS_THAN:
+		return swamp.RelationalOperatorLessThan
+	case hydrapb.Relational_LESS_THAN_OR_EQUAL:
+		return swamp.RelationalOperatorLessThanOrEqual
+	case hydrapb.Relational_GREATER_THAN:
+		return swamp.RelationalOperatorGreaterThan
+	case hydrapb.Relational_GREATER_THAN_OR_EQUAL:
+		return swamp.RelationalOperatorGreaterThanOrEqual
+	case hydrapb.Relational_NOT_EQUAL:
+		return swamp.RelationalOperatorNotEqual
+	default:
+		return swamp.RelationalOperatorEqual
+	}
+}
+
+func setIfOperatorToSwampSetIfOperator(operator hydrapb.SetIf_Operator) swamp.SetIfOperator {
+	switch operator {
+	case hydrapb.SetIf_NOT_EXISTS:
+		return swamp.SetIfOperatorNotExists
+	default:
+		return swamp.SetIfOperatorEqual
+	}
+}
+
+// ListCorruptedFiles lists the chunk files of the server found corrupted since its start
+func (g Gateway) ListCorruptedFiles(_ context.Context, _ *hydrapb.ListCorruptedFilesRequest) (*hydrapb.ListCorruptedFilesResponse, error) {
+
+	defer handlePanic()
+
+	corruptedFiles := g.ZeusInterface.GetFilesystem().GetCorruptedFiles()
+
+	response := &hydrapb.ListCorruptedFilesResponse{
+		Files: make([]*hydrapb.CorruptedFile, 0, len(corruptedFiles)),
+	}
+	for _, corruptedFile := range corruptedFiles {
+		response.Files = append(response.Files, &hydrapb.CorruptedFile{
+			FilePath:   corruptedFile.FilePath,
+			Reason:     corruptedFile.Reason,
+			DetectedAt: timestamppb.New(corruptedFile.DetectedAt),
+		})
+	}
+
+	return response, nil
+
+}
+
+// namespaceUsageMaxAge is the max age of the cached namespace usage returned by the GetNamespaceUsage RPC
+const namespaceUsageMaxAge = time.Minute
+
+// GetNamespaceUsage returns the disk usage, the swamp count and the treasure count of the server grouped by the
+// Sanctuaries and the Realms. The usage is cached for a minute, unless the client asks for a refresh
+func (g Gateway) GetNamespaceUsage(ctx context.Context, in *hydrapb.GetNamespaceUsageRequest) (*hydrapb.GetNamespaceUsageResponse, error) {
+
+	defer handlePanic()
+
+	maxAge := namespaceUsageMaxAge
+	if in.GetRefresh() {
+		maxAge = 0
+	}
+
+	usages, collectedAt, err := g.ZeusInterface.GetHydra().GetNamespaceUsage(ctx, maxAge)
+	if err != nil {
+		return nil, hydraError(err)
+	}
+
+	response := &hydrapb.GetNamespaceUsageResponse{
+		Usages:      make([]*hydrapb.NamespaceUsage, 0, len(usages)),
+		CollectedAt: timestamppb.New(collectedAt),
+	}
+	for _, usage := range usages {
+		response.Usages = append(response.Usages, &hydrapb.NamespaceUsage{
+			Sanctuary:       usage.Sanctuary,
+			Realm:           usage.Realm,
+			SwampCount:      uint64(usage.SwampCount),
+			TreasureCount:   uint64(usage.TreasureCount),
+			UncountedSwamps: uint64(usage.UncountedSwamps),
+			DiskBytes:       uint64(usage.DiskBytes),
+		})
+	}
+
+	return response, nil
+
+}
+
+// VerifyIslandMapping returns the island the server computes for the swamp, so the clients can verify their islands
+func (g Gateway) VerifyIslandMapping(_ context.Context, in *hydrapb.VerifyIslandMappingRequest) (*hydrapb.VerifyIslandMappingResponse, error) {
+
+	defer handlePanic()
+
+	if strings.Count(in.GetSwampName(), "/") != 2 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the swamp name must be in the format sanctuary/realm/swamp, got %q", in.GetSwampName()))
+	}
+	if in.GetAllIslands() < 1 || in.GetAllIslands() > math.MaxUint16 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the number of all islands must be between 1 and %d, got %d", math.MaxUint16, in.GetAllIslands()))
+	}
+
+	swampName := name.Load(in.GetSwampName())
+
+	return &hydrapb.VerifyIslandMappingResponse{
+		IslandID:     uint64(swampName.GetFolderNumber(uint16(in.GetAllIslands()))),
+		HashFunction: name.HashFunction,
+	}, nil
+
+}
+
+// GetClusterTopology returns the servers of the cluster and their islands from the topology file of the server
+func (g Gateway) GetClusterTopology(_ context.Context, _ *hydrapb.GetClusterTopologyRequest) (*hydrapb.GetClusterTopologyResponse, error) {
+
+	defer handlePanic()
+
+	var topology *cluster.Topology
+	if g.Topology != nil {
+		topology = g.Topology.Topology()
+	}
+	if topology == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_CLUSTER_TOPOLOGY_NOT_CONFIGURED,
+			"the server has no valid cluster topology file, set HYDRAIDE_CLUSTER_TOPOLOGY_FILE or connect to the servers with their island ranges")
+	}
+
+	response := &hydrapb.GetClusterTopologyResponse{
+		Version:    topology.Version,
+		AllIslands: topology.AllIslands,
+		Servers:    make([]*hydrapb.ClusterServer, 0, len(topology.Servers)),
+	}
+	for _, server := range topology.Servers {
+		response.Servers = append(response.Servers, &hydrapb.ClusterServer{
+			Host:       server.Host,
+			FromIsland: server.FromIsland,
+			ToIsland:   server.ToIsland,
+		})
+	}
+
+	return response, nil
+
+}
+
+// SetClusterTopology saves the topology of the request, if the current version of the topology is the expected one
+func (g Gateway) SetClusterTopology(_ context.Context, in *hydrapb.SetClusterTopologyRequest) (*hydrapb.SetClusterTopologyResponse, error) {
+
+	defer handlePanic()
+
+	if g.Islands == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_ISLAND_MIGRATION_DISABLED, "the cluster topology can not be changed on this server")
+	}
+	if g.Topology == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_CLUSTER_TOPOLOGY_NOT_CONFIGURED,
+			"the server has no cluster topology file, set HYDRAIDE_CLUSTER_TOPOLOGY_FILE")
+	}
+
+	topology := &cluster.Topology{
+		AllIslands: in.GetAllIslands(),
+		Servers:    make([]cluster.Server, 0, len(in.GetServers())),
+	}
+	for _, server := range in.GetServers() {
+		topology.Servers = append(topology.Servers, cluster.Server{
+			Host:       server.GetHost(),
+			FromIsland: server.GetFromIsland(),
+			ToIsland:   server.GetToIsland(),
+		})
+	}
+
+	saved, err := g.Topology.Update(topology, in.GetExpectedVersion())
+	if err != nil {
+		switch {
+		case errors.Is(err, cluster.ErrVersionConflict):
+			return nil, statusError(codes.Aborted, hydrapb.ErrorReason_TOPOLOGY_CONFLICT, err.Error())
+		case errors.Is(err, cluster.ErrInvalidTopology):
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+		default:
+			return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not save the cluster topology: %s", err.Error()))
+		}
+	}
+
+	return &hydrapb.SetClusterTopologyResponse{
+		Version: saved.Version,
+	}, nil
+
+}
+
+// SetIslandState sets the migration state of the Island
+func (g Gateway) SetIslandState(_ context.Context, in *hydrapb.SetIslandStateRequest) (*hydrapb.SetIslandStateResponse, error) {
+
+	defer handlePanic()
+
+	if g.Islands == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_ISLAND_MIGRATION_DISABLED, "the islands can not be migrated on this server")
+	}
+	if in.GetIslandID() == 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the island ID is required")
+	}
+
+	island := migration.Island{MovedTo: in.GetMovedTo()}
+	switch in.GetState() {
+	case hydrapb.IslandState_ACTIVE:
+		island.State = migration.StateActive
+	case hydrapb.IslandState_FROZEN:
+		island.State = migration.StateFrozen
+	case hydrapb.IslandState_MOVED:
+		island.State = migration.StateMoved
+	default:
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("unknown island state: %d", in.GetState()))
+	}
+
+	if err := g.Islands.Set(in.GetIslandID(), island); err != nil {
+		if errors.Is(err, migration.ErrInvalidState) {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, err.Error())
+		}
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not save the island state: %s", err.Error()))
+	}
+
+	slog.Info("the state of the island is changed", "island", in.GetIslandID(), "state", island.State.String(), "movedTo", island.MovedTo)
+
+	return &hydrapb.SetIslandStateResponse{}, nil
+
+}
+
+// ExportIsland streams the archive of the frozen Island, and its manifest in the last message
+func (g Gateway) ExportIsland(in *hydrapb.ExportIslandRequest, stream hydrapb.HydraideService_ExportIslandServer) error {
+
+	defer handlePanic()
+
+	if g.Islands == nil {
+		return statusError(codes.FailedPrecondition, hydrapb.ErrorReason_ISLAND_MIGRATION_DISABLED, "the islands can not be migrated on this server")
+	}
+	if in.GetIslandID() == 0 {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the island ID is required")
+	}
+	if g.Islands.Get(in.GetIslandID()).State != migration.StateFrozen {
+		// the writes of an active island would be missing from the archive
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("the island %d must be frozen before the export", in.GetIslandID()))
+	}
+
+	writer := &chunkWriter{send: func(chunk []byte) error {
+		return stream.Send(&hydrapb.ExportIslandResponse{Chunk: chunk})
+	}}
+	manifest, err := backup.ExportIsland(stream.Context(), g.ZeusInterface.GetHydra(), in.GetIslandID(), g.islandFolders(in.GetIslandID()), writer)
+	if err == nil {
+		err = writer.flush()
+	}
+	if err != nil {
+		if status.Code(err) != codes.Unknown {
+			// the error of the stream
+			return err
+		}
+		return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not export the island %d: %s", in.GetIslandID(), err.Error()))
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not encode the manifest: %s", err.Error()))
+	}
+
+	return stream.Send(&hydrapb.ExportIslandResponse{Manifest: manifestJSON})
+
+}
+
+// ImportIsland writes the streamed archive into a temporary file, then verifies and extracts it into the folders of
+// the Island, and activates the Island
+func (g Gateway) ImportIsland(stream hydrapb.HydraideService_ImportIslandServer) error {
+
+	defer handlePanic()
+
+	if g.Islands == nil {
+		return statusError(codes.FailedPrecondition, hydrapb.ErrorReason_ISLAND_MIGRATION_DISABLED, "the islands can not be migrated on this server")
+	}
+
+	archiveFile, err := os.CreateTemp("", "hydraide-island-*.tar.zst")
+	if err != nil {
+		return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not create the temporary archive: %s", err.Error()))
+	}
+	defer func() {
+		_ = archiveFile.Close()
+		_ = os.Remove(archiveFile.Name())
+	}()
+
+	var islandID uint64
+	var manifestJSON []byte
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if islandID == 0 {
+			islandID = in.GetIslandID()
+		}
+		if len(in.GetManifest()) > 0 {
+			manifestJSON = in.GetManifest()
+		}
+		if _, err := archiveFile.Write(in.GetChunk()); err != nil {
+			return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not write the temporary archive: %s", err.Error()))
 		}
-	default:
-		// do nothing
 	}
 
-	if treasureInterface.GetCreatedAt() > 0 {
-		t.CreatedAt = timestamppb.New(time.Unix(0, treasureInterface.GetCreatedAt()))
+	if islandID == 0 {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the island ID is required")
 	}
-	if treasureInterface.GetCreatedBy() != "" {
-		createdBy := t.GetCreatedBy()
-		t.CreatedBy = &createdBy
+	manifest := &backup.Manifest{}
+	if err := json.Unmarshal(manifestJSON, manifest); err != nil {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the manifest of the archive is missing or invalid: %s", err.Error()))
 	}
-	if treasureInterface.GetModifiedAt() > 0 {
-		t.UpdatedAt = timestamppb.New(time.Unix(0, treasureInterface.GetModifiedAt()))
+	if _, err := archiveFile.Seek(0, io.SeekStart); err != nil {
+		return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, err.Error())
 	}
-	if t.GetUpdatedBy() != "" {
-		updatedBy := t.GetUpdatedBy()
-		t.UpdatedBy = &updatedBy
+
+	folders := g.islandFolders(islandID)
+	switch g.Islands.Get(islandID).State {
+	case migration.StateFrozen:
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("the island %d is frozen on this server, it can not be imported into its source", islandID))
+	case migration.StateMoved:
+		// the island comes back to a server it was moved from, its old files are outdated
+		if err := folders.Remove(); err != nil {
+			return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not remove the outdated files of the island %d: %s", islandID, err.Error()))
+		}
+	default:
 	}
-	if treasureInterface.GetExpirationTime() > 0 {
-		t.ExpiredAt = timestamppb.New(time.Unix(0, treasureInterface.GetExpirationTime()))
+
+	files, err := backup.ImportIsland(archiveFile, islandID, folders, manifest)
+	if err != nil {
+		if errors.Is(err, backup.ErrIslandNotEmpty) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return statusError(codes.DataLoss, hydrapb.ErrorReason_DATA_CORRUPTED, fmt.Sprintf("can not import the island %d: %s", islandID, err.Error()))
 	}
 
+	if err := g.Islands.Set(islandID, migration.Island{State: migration.StateActive}); err != nil {
+		return statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not activate the island %d: %s", islandID, err.Error()))
+	}
+
+	slog.Info("the island is imported", "island", islandID, "swamps", len(manifest.Swamps), "files", files)
+
+	return stream.SendAndClose(&hydrapb.ImportIslandResponse{
+		Swamps: uint64(len(manifest.Swamps)),
+		Files:  uint64(files),
+	})
+
 }
 
-// isValidTimestamp checks if the timestamp is valid
-func isValidTimestamp(ts *timestamppb.Timestamp) bool {
-	if ts == nil {
-		return false
+// islandFolders returns the folders of the Island on this server
+func (g Gateway) islandFolders(islandID uint64) backup.IslandFolders {
+	island := strconv.FormatUint(islandID, 10)
+	dataFolder := g.SettingsInterface.GetHydraAbsDataFolderPath()
+	return backup.IslandFolders{
+		RootPath:           filepath.Dir(dataFolder),
+		SwampFolder:        filepath.Join(dataFolder, island),
+		BlobFolder:         filepath.Join(g.SettingsInterface.GetHydraAbsBlobFolderPath(), island),
+		HashFolderDepth:    g.SettingsInterface.GetHashFolderDepth(),
+		MaxFoldersPerLevel: g.SettingsInterface.GetMaxFoldersPerLevel(),
 	}
-	return ts.GetSeconds() > 0 || ts.GetNanos() > 0
 }
 
-// convertTreasureStatusToPbStatus converts the treasure status from the hydra to the protobuf status
-func convertTreasureStatusToPbStatus(treasureStatus treasure.TreasureStatus) hydrapb.Status_Code {
-	switch treasureStatus {
-	case treasure.StatusNew:
-		return hydrapb.Status_NEW
-	case treasure.StatusModified:
-		return hydrapb.Status_UPDATED
-	case treasure.StatusSame:
-		return hydrapb.Status_NOTHING_CHANGED
-	case treasure.StatusDeleted:
-		return hydrapb.Status_DELETED
-	default:
-		return hydrapb.Status_NOT_FOUND
+// chunkWriter sends the written bytes in chunks of defaultLargeValueChunkSize
+type chunkWriter struct {
+	send   func(chunk []byte) error
+	buffer []byte
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	c.buffer = append(c.buffer, p...)
+	for len(c.buffer) >= defaultLargeValueChunkSize {
+		if err := c.send(c.buffer[:defaultLargeValueChunkSize]); err != nil {
+			return 0, err
+		}
+		c.buffer = c.buffer[defaultLargeValueChunkSize:]
 	}
+	return len(p), nil
 }
 
-// handle all the panics in the gateway
-func handlePanic() {
-	if r := recover(); r != nil {
-		// get the stack trace
-		stackTrace := debug.Stack()
-		// log the panic with the error and stack trace
-		slog.Error("grpc gateway panic", "error", r, "stack", string(stackTrace))
+// flush sends the remaining bytes
+func (c *chunkWriter) flush() error {
+	if len(c.buffer) == 0 {
+		return nil
 	}
+	err := c.send(c.buffer)
+	c.buffer = nil
+	return err
 }
 
-// checkSwampName check if the swamp name is valid and exist or not.
-// The function will return a grpc error message if the swamp name is invalid or does not exist.
-func checkSwampName(zeusInterface zeus.Zeus, islandID uint64, inputSwampName string, checkExist bool) (name.Name, error) {
+// QueryAuditLog returns the records of the audit log matching the request, the newest first
+func (g Gateway) QueryAuditLog(_ context.Context, in *hydrapb.QueryAuditLogRequest) (*hydrapb.QueryAuditLogResponse, error) {
 
-	// check the input
-	if inputSwampName == "" {
+	defer handlePanic()
+
+	if g.AuditLog == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_AUDIT_LOG_DISABLED, "the audit log is not enabled on the server")
+	}
+	if in.GetLimit() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the limit can not be negative, got %d", in.GetLimit()))
+	}
+
+	filter := &audit.Filter{
+		Method:    in.GetMethod(),
+		ClientID:  in.GetClientID(),
+		SwampName: in.GetSwampName(),
+		Key:       in.GetKey(),
+		Limit:     int(in.GetLimit()),
+	}
+	if in.GetFrom() != nil {
+		filter.From = in.GetFrom().AsTime()
+	}
+	if in.GetTo() != nil {
+		filter.To = in.GetTo().AsTime()
+	}
+
+	records, err := g.AuditLog.Query(filter)
+	if err != nil {
+		return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not query the audit log: %s", err.Error()))
+	}
+
+	response := &hydrapb.QueryAuditLogResponse{
+		Records: make([]*hydrapb.AuditRecord, 0, len(records)),
+	}
+	for _, record := range records {
+		response.Records = append(response.Records, &hydrapb.AuditRecord{
+			Time:       timestamppb.New(record.Time),
+			Method:     record.Method,
+			ClientID:   record.ClientID,
+			ClientIP:   record.ClientIP,
+			Tenant:     record.Tenant,
+			SwampNames: record.SwampNames,
+			SwampCount: int32(record.SwampCount),
+			Keys:       record.Keys,
+			KeyCount:   int32(record.KeyCount),
+			Code:       record.Code,
+			Error:      record.Error,
+		})
+	}
+
+	return response, nil
+
+}
+
+// RestorePointInTime restores the selected swamps to their state at the point in time, from the newest backup before
+// it and the write-ahead log
+func (g Gateway) RestorePointInTime(ctx context.Context, in *hydrapb.RestorePointInTimeRequest) (*hydrapb.RestorePointInTimeResponse, error) {
+
+	defer handlePanic()
+
+	if g.Recovery == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_POINT_IN_TIME_RESTORE_DISABLED, "the backups or the write-ahead log are not enabled on the server")
+	}
+	if in.GetUntil() == nil {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the point in time is required")
+	}
+	until := in.GetUntil().AsTime()
+	if until.After(time.Now()) {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the point in time can not be in the future, got %s", until.Format(time.RFC3339Nano)))
+	}
+
+	patterns := make([]name.Name, 0, len(in.GetPatterns()))
+	for _, pattern := range in.GetPatterns() {
+		parts := strings.Split(pattern, "/")
+		if len(parts) != 3 || slices.Contains(parts, "") {
+			return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("the pattern must be in the format sanctuary/realm/swamp, got %q", pattern))
+		}
+		patterns = append(patterns, name.Load(pattern))
+	}
+
+	result, err := g.Recovery.RestorePointInTime(ctx, &backup.PointInTimeRequest{
+		Until:     until,
+		Patterns:  patterns,
+		IslandIDs: in.GetIslandIDs(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, backup.ErrPointInTimeNotCovered):
+			return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_POINT_IN_TIME_NOT_COVERED, err.Error())
+		case errors.Is(err, backup.ErrRecoveryRunning):
+			return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_UNSPECIFIED, err.Error())
+		default:
+			return nil, statusError(codes.Internal, hydrapb.ErrorReason_INTERNAL, fmt.Sprintf("can not restore the point in time: %s", err.Error()))
+		}
+	}
+
+	return &hydrapb.RestorePointInTimeResponse{
+		BackupID:          result.Manifest.ID,
+		Swamps:            int64(result.Swamps),
+		ReplayedRecords:   int64(result.Records),
+		RestoredTreasures: int64(result.Restored),
+		DeletedTreasures:  int64(result.Deleted),
+	}, nil
+
+}
+
+// defaultMinLiveRatio is the live ratio of the compaction if the request does not set it
+const defaultMinLiveRatio = 0.5
+
+// CompactSwamp rewrites the chunk files of the swamp that are mostly dead data
+func (g Gateway) CompactSwamp(ctx context.Context, in *hydrapb.CompactSwampRequest) (*hydrapb.CompactSwampResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	minLiveRatio := in.GetMinLiveRatio()
+	if minLiveRatio == 0 {
+		minLiveRatio = defaultMinLiveRatio
+	}
+	if minLiveRatio < 0 || minLiveRatio > 1 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "MinLiveRatio must be between 0 and 1")
+	}
+
+	swampName, err := checkSwampName(g.ZeusInterface, in.GetIslandID(), in.SwampName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hydraInterface := g.ZeusInterface.GetHydra()
+
+	// summon the swamp
+	swampInterface, err := hydraInterface.SummonSwamp(ctx, in.GetIslandID(), swampName)
+	if err != nil {
 		// return with grpc error message
-		return nil, status.Error(codes.InvalidArgument, "SwampName cannot be empty")
+		return nil, hydraError(err)
 	}
-	swampName := name.Load(inputSwampName)
 
-	// check the existence of the swamp only if it is needed
-	// because the Set method does not need to check the existence of the swamp
-	if checkExist {
-		// check if the swamp is exist or not
-		hydraInterface := zeusInterface.GetHydra()
-		isExist, err := hydraInterface.IsExistSwamp(islandID, swampName)
-		if err != nil || !isExist {
-			// return with grpc error message
-			return nil, status.Error(codes.FailedPrecondition, "Swamp does not exist")
+	// begin the vigil, to prevent closing of the swamp
+	swampInterface.BeginVigil()
+	defer swampInterface.CeaseVigil()
+
+	result, err := swampInterface.Compact(minLiveRatio)
+	if err != nil {
+		return nil, hydraError(err)
+	}
+
+	return &hydrapb.CompactSwampResponse{
+		CompactedFiles:   int32(result.CompactedFiles),
+		WrittenFiles:     int32(result.WrittenFiles),
+		RemovedTreasures: int32(result.RemovedTreasures),
+		ReclaimedBytes:   result.ReclaimedBytes,
+	}, nil
+
+}
+
+// PutBlob receives the content of the blob in chunks, verifies its hash, and stores it in the blob store
+func (g Gateway) PutBlob(stream hydrapb.HydraideService_PutBlobServer) error {
+
+	defer handlePanic()
+
+	var header *hydrapb.PutBlobRequest
+	var content []byte
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			header = in
 		}
+		content = append(content, in.GetChunk()...)
 	}
 
-	return swampName, nil
+	if header == nil {
+		return statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "the blob has no content")
+	}
+	if header.GetHash() != "" && header.GetHash() != blob.Hash(content) {
+		return statusError(codes.DataLoss, hydrapb.ErrorReason_DATA_CORRUPTED, fmt.Sprintf("the received content of the blob does not match the hash %s", header.GetHash()))
+	}
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	info, isNew, err := g.ZeusInterface.GetHydra().GetBlobStore().Put(header.GetIslandID(), content)
+	if err != nil {
+		return blobError(err)
+	}
+
+	return stream.SendAndClose(&hydrapb.PutBlobResponse{
+		Hash:       info.Hash,
+		IsNew:      isNew,
+		References: info.References,
+		Size:       info.Size,
+	})
 
 }
 
-func inputIndexTypeToBeaconType(inputIndexType hydrapb.IndexType_Type) swamp.BeaconType {
-	switch inputIndexType {
-	case hydrapb.IndexType_EXPIRATION_TIME:
-		return swamp.BeaconTypeExpirationTime
-	case hydrapb.IndexType_CREATION_TIME:
-		return swamp.BeaconTypeCreationTime
-	case hydrapb.IndexType_UPDATE_TIME:
-		return swamp.BeaconTypeUpdateTime
-	case hydrapb.IndexType_VALUE_INT8:
-		return swamp.BeaconTypeValueInt8
-	case hydrapb.IndexType_VALUE_INT16:
-		return swamp.BeaconTypeValueInt16
-	case hydrapb.IndexType_VALUE_INT32:
-		return swamp.BeaconTypeValueInt32
-	case hydrapb.IndexType_VALUE_INT64:
-		return swamp.BeaconTypeValueInt64
-	case hydrapb.IndexType_VALUE_UINT8:
-		return swamp.BeaconTypeValueUint8
-	case hydrapb.IndexType_VALUE_UINT16:
-		return swamp.BeaconTypeValueUint16
-	case hydrapb.IndexType_VALUE_UINT32:
-		return swamp.BeaconTypeValueUint32
-	case hydrapb.IndexType_VALUE_UINT64:
-		return swamp.BeaconTypeValueUint64
-	case hydrapb.IndexType_VALUE_FLOAT32:
-		return swamp.BeaconTypeValueFloat32
-	case hydrapb.IndexType_VALUE_FLOAT64:
-		return swamp.BeaconTypeValueFloat64
-	case hydrapb.IndexType_VALUE_STRING:
-		return swamp.BeaconTypeValueString
-	default:
-		return swamp.BeaconTypeValueString
+// GetBlob reads the content of the blob, and sends it in chunks
+func (g Gateway) GetBlob(in *hydrapb.GetBlobRequest, stream hydrapb.HydraideService_GetBlobServer) error {
+
+	defer handlePanic()
+
+	content, err := func() ([]byte, error) {
+		g.ZeusInterface.GetSafeops().LockSystem()
+		defer g.ZeusInterface.GetSafeops().UnlockSystem()
+		return g.ZeusInterface.GetHydra().GetBlobStore().Get(in.GetIslandID(), in.GetHash())
+	}()
+	if err != nil {
+		return blobError(err)
+	}
+
+	chunkSize := int(in.GetChunkSize())
+	if chunkSize <= 0 {
+		chunkSize = defaultLargeValueChunkSize
+	}
+
+	for start := 0; start < len(content); start += chunkSize {
+		end := min(start+chunkSize, len(content))
+		if err := stream.Send(&hydrapb.GetBlobResponse{Chunk: content[start:end]}); err != nil {
+			return err
+		}
 	}
+
+	return nil
+
 }
 
-func inputOrderTypeToBeaconOrderType(inputOrderType hydrapb.OrderType_Type) swamp.BeaconOrder {
-	switch inputOrderType {
-	case hydrapb.OrderType_ASC:
-		return swamp.IndexOrderAsc
-	case hydrapb.OrderType_DESC:
-		return swamp.IndexOrderDesc
-	default:
-		return swamp.IndexOrderAsc
+// RefBlob adds or removes references of the blob
+func (g Gateway) RefBlob(_ context.Context, in *hydrapb.RefBlobRequest) (*hydrapb.RefBlobResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	references, err := g.ZeusInterface.GetHydra().GetBlobStore().Ref(in.GetIslandID(), in.GetHash(), in.GetDelta())
+	if err != nil {
+		return nil, blobError(err)
 	}
+
+	return &hydrapb.RefBlobResponse{
+		References: references,
+	}, nil
+
 }
 
-func relationalOperatorToSwampRelationalOperator(operator hydrapb.Relational_Operator) swamp.RelationalOperator {
-	switch operator {
-	case hydrapb.Relational_EQUAL:
-		return swamp.RelationalOperatorEqual
-	case hydrapb.Relational_LESS_THAN:
-		return swamp.RelationalOperatorLessThan
-	case hydrapb.Relational_LESS_THAN_OR_EQUAL:
-		return swamp.RelationalOperatorLessThanOrEqual
-	case hydrapb.Relational_GREATER_THAN:
-		return swamp.RelationalOperatorGreaterThan
-	case hydrapb.Relational_GREATER_THAN_OR_EQUAL:
-		return swamp.RelationalOperatorGreaterThanOrEqual
-	case hydrapb.Relational_NOT_EQUAL:
-		return swamp.RelationalOperatorNotEqual
-	default:
-		return swamp.RelationalOperatorEqual
+// CollectBlobGarbage removes the blobs without references
+func (g Gateway) CollectBlobGarbage(_ context.Context, in *hydrapb.CollectBlobGarbageRequest) (*hydrapb.CollectBlobGarbageResponse, error) {
+
+	g.ZeusInterface.GetSafeops().LockSystem()
+	defer g.ZeusInterface.GetSafeops().UnlockSystem()
+
+	defer handlePanic()
+
+	if in.GetMinAgeSeconds() < 0 {
+		return nil, statusError(codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT, "MinAgeSeconds can not be negative")
+	}
+
+	result, err := g.ZeusInterface.GetHydra().GetBlobStore().CollectGarbage(time.Duration(in.GetMinAgeSeconds()) * time.Second)
+	if err != nil {
+		return nil, blobError(err)
+	}
+
+	return &hydrapb.CollectBlobGarbageResponse{
+		RemovedBlobs: result.RemovedBlobs,
+		FreedBytes:   result.FreedBytes,
+	}, nil
+
+}
+
+// ReloadDefaults reloads the default swamp settings from the configuration of the server. The patterns registered and
+// the swamps opened after it get the new defaults, the open swamps keep their settings
+func (g Gateway) ReloadDefaults(_ context.Context, _ *hydrapb.ReloadDefaultsRequest) (*hydrapb.ReloadDefaultsResponse, error) {
+
+	defer handlePanic()
+
+	if g.DefaultsReloader == nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_DEFAULTS_RELOAD_DISABLED, "the default swamp settings can not be reloaded on this server")
+	}
+
+	values, err := g.DefaultsReloader()
+	if err != nil {
+		return nil, statusError(codes.FailedPrecondition, hydrapb.ErrorReason_UNSPECIFIED, fmt.Sprintf("can not reload the default swamp settings: %s", err.Error()))
 	}
+
+	return &hydrapb.ReloadDefaultsResponse{
+		CloseAfterIdle:            values.CloseAfterIdleSec,
+		WriteInterval:             values.WriteIntervalSec,
+		MaxFileSize:               values.MaxFileSizeByte,
+		Fsync:                     fsyncPolicyToProto(values.FsyncPolicy),
+		FsyncInterval:             values.FsyncIntervalSec,
+		ApplyToUnregisteredSwamps: values.ApplyToUnregisteredSwamps,
+	}, nil
+
 }
@@ -0,0 +1,344 @@
+// Package batch implements a slog.Handler that sends the log records to an HTTP log sink in batches.
+//
+// The records are queued in a bounded in-memory queue and sent by a background dispatcher, when the batch is full or
+// the flush interval elapsed, so a log burst never blocks the goroutines of the requests. The sinks, e.g. Loki and
+// OpenSearch, implement only the Sink interface: the encoding and the delivery of a batch.
+//
+// The handler reports whether the sink is reachable without blocking, so it can be the primary handler of the
+// fallback handler: while the sink is down, the records are written to the fallback file, and the dispatcher pings
+// the sink in the background until it is back.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultQueueSize is the max number of pending records before dropping new ones
+	DefaultQueueSize = 10000
+	// DefaultBatchSize is the max number of records sent at once
+	DefaultBatchSize = 500
+	// DefaultFlushInterval is the max time a record waits in an incomplete batch
+	DefaultFlushInterval = time.Second
+	// DefaultSendTimeout is the max time of sending a batch or a ping
+	DefaultSendTimeout = 10 * time.Second
+
+	// pingInterval is the min time between two pings of an unreachable sink
+	pingInterval = 5 * time.Second
+
+	sentMetric    = "hydraide_log_sink_sent_messages_total"
+	droppedMetric = "hydraide_log_sink_dropped_messages_total"
+	queueMetric   = "hydraide_log_sink_queue_length"
+
+	dropReasonQueueFull = "queue_full"
+	dropReasonSend      = "send"
+	dropReasonRejected  = "rejected"
+)
+
+// Entry is a log record prepared for a sink
+type Entry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	// Attrs are the attributes of the record and of the handler. The errors are converted to their messages
+	Attrs map[string]any
+}
+
+// Sink delivers the batches of the entries to a log backend
+type Sink interface {
+	// Name is the name of the sink in the metrics, e.g. "loki"
+	Name() string
+	// Send sends the entries. The error means the sink is unreachable and none of the entries is stored. The
+	// rejected count is the number of the entries the sink refused to store, e.g. because of a mapping conflict
+	Send(ctx context.Context, entries []Entry) (rejected int, err error)
+	// Ping returns nil if the sink is reachable
+	Ping(ctx context.Context) error
+}
+
+// Options are the optional settings of the handler. The zero values mean the defaults
+type Options struct {
+	// QueueSize is the max number of pending records before dropping new ones. Zero means DefaultQueueSize
+	QueueSize int
+	// BatchSize is the max number of records sent at once. Zero means DefaultBatchSize
+	BatchSize int
+	// FlushInterval is the max time a record waits in an incomplete batch. Zero means DefaultFlushInterval
+	FlushInterval time.Duration
+	// SendTimeout is the max time of sending a batch or a ping. Zero means DefaultSendTimeout
+	SendTimeout time.Duration
+	// Metrics is the registry of the queue and drop metrics. Nil means the metrics are not exposed
+	Metrics metrics.Registry
+}
+
+// Handler is a slog.Handler that queues the records and sends them to the sink in batches
+type Handler struct {
+	level      slog.Level
+	attrs      []slog.Attr
+	dispatcher *dispatcher // shared by the handlers created by WithAttrs
+}
+
+// dispatcher is the queue and the background goroutine sending the batches
+type dispatcher struct {
+	sink      Sink
+	options   Options
+	queue     chan Entry
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+	once      sync.Once
+	reachable atomic.Bool
+	lastPing  time.Time // used only by the dispatcher goroutine
+
+	sent             metrics.Counter
+	droppedQueueFull metrics.Counter
+	droppedSend      metrics.Counter
+	droppedRejected  metrics.Counter
+}
+
+// New creates the handler of the sink and starts its dispatcher. Nil options mean the defaults
+func New(sink Sink, level slog.Level, options *Options) *Handler {
+
+	o := Options{}
+	if options != nil {
+		o = *options
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = DefaultQueueSize
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultFlushInterval
+	}
+	if o.SendTimeout <= 0 {
+		o.SendTimeout = DefaultSendTimeout
+	}
+
+	registry := o.Metrics
+	if registry == nil {
+		registry = metrics.New()
+	}
+
+	const droppedHelp = "Number of the log messages dropped before they were stored by the log sink"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &dispatcher{
+		sink:             sink,
+		options:          o,
+		queue:            make(chan Entry, o.QueueSize),
+		ctx:              ctx,
+		cancel:           cancel,
+		done:             make(chan struct{}),
+		sent:             registry.Counter(sentMetric, "Number of the log messages stored by the log sink", "sink", sink.Name()),
+		droppedQueueFull: registry.Counter(droppedMetric, droppedHelp, "sink", sink.Name(), "reason", dropReasonQueueFull),
+		droppedSend:      registry.Counter(droppedMetric, droppedHelp, "sink", sink.Name(), "reason", dropReasonSend),
+		droppedRejected:  registry.Counter(droppedMetric, droppedHelp, "sink", sink.Name(), "reason", dropReasonRejected),
+	}
+	registry.GaugeFunc(queueMetric, "Number of the log messages waiting for the log sink", func() float64 {
+		return float64(len(d.queue))
+	}, "sink", sink.Name())
+
+	// optimistic until the first send, so the first records are not sent to the fallback
+	d.reachable.Store(true)
+
+	go d.run()
+
+	return &Handler{level: level, dispatcher: d}
+
+}
+
+// Enabled reports whether a given log level is enabled for this handler
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+// WithAttrs returns a copy of the handler with additional attributes, sharing the queue of the handler
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{
+		level:      h.level,
+		attrs:      append(append([]slog.Attr{}, h.attrs...), attrs...),
+		dispatcher: h.dispatcher,
+	}
+}
+
+// WithGroup returns the same handler, as attribute grouping is not supported in this implementation
+func (h *Handler) WithGroup(_ string) slog.Handler {
+	return h
+}
+
+// Handle converts the record to an entry and queues it. It never blocks: if the queue is full, the record is dropped
+// and an error is returned, so the fallback handler can keep it
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+
+	entry := Entry{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   make(map[string]any, len(h.attrs)+r.NumAttrs()),
+	}
+	for _, attr := range h.attrs {
+		entry.Attrs[attr.Key] = attrValue(attr.Value)
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		entry.Attrs[attr.Key] = attrValue(attr.Value)
+		return true
+	})
+
+	select {
+	case h.dispatcher.queue <- entry:
+		return nil
+	default:
+		h.dispatcher.droppedQueueFull.Inc()
+		return fmt.Errorf("the queue of the %s log sink is full", h.dispatcher.sink.Name())
+	}
+
+}
+
+// Reachable returns false if the last send or ping of the sink failed. It never blocks
+func (h *Handler) Reachable() bool {
+	return h.dispatcher.reachable.Load()
+}
+
+// Close sends the queued records and stops the dispatcher. It waits at most the send timeout for the last batches
+func (h *Handler) Close() error {
+	h.dispatcher.once.Do(h.dispatcher.cancel)
+	select {
+	case <-h.dispatcher.done:
+		return nil
+	case <-time.After(h.dispatcher.options.SendTimeout):
+		return errors.New("the log sink did not stop in time, the queued records are lost")
+	}
+}
+
+// run collects the entries into batches and sends them until the handler is closed
+func (d *dispatcher) run() {
+
+	defer close(d.done)
+	defer func() {
+		if r := recover(); r != nil {
+			// the logger can not be used here, because it may be the caller of this handler
+			fmt.Printf("caught panic in the %s log sink: %v\n%s\n", d.sink.Name(), r, debug.Stack())
+		}
+	}()
+
+	ticker := time.NewTicker(d.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, d.options.BatchSize)
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			// send the remaining entries before stopping
+			for {
+				select {
+				case entry := <-d.queue:
+					batch = append(batch, entry)
+					if len(batch) >= d.options.BatchSize {
+						d.flush(batch)
+						batch = batch[:0]
+					}
+				default:
+					d.flush(batch)
+					return
+				}
+			}
+
+		case entry := <-d.queue:
+			batch = append(batch, entry)
+			if len(batch) >= d.options.BatchSize {
+				d.flush(batch)
+				batch = batch[:0]
+			}
+
+		case <-ticker.C:
+			d.flush(batch)
+			batch = batch[:0]
+			d.pingIfUnreachable()
+		}
+	}
+
+}
+
+// flush sends the batch. The entries that can not be sent are dropped and counted
+func (d *dispatcher) flush(batch []Entry) {
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.options.SendTimeout)
+	defer cancel()
+
+	rejected, err := d.sink.Send(ctx, batch)
+	if err != nil {
+		d.reachable.Store(false)
+		d.droppedSend.Add(uint64(len(batch)))
+		return
+	}
+
+	d.reachable.Store(true)
+	d.droppedRejected.Add(uint64(rejected))
+	d.sent.Add(uint64(len(batch) - rejected))
+
+}
+
+// pingIfUnreachable pings the unreachable sink at most once per pingInterval, so the fallback handler sees when the
+// sink is back, even if no records are sent to it
+func (d *dispatcher) pingIfUnreachable() {
+
+	if d.reachable.Load() || time.Since(d.lastPing) < pingInterval {
+		return
+	}
+	d.lastPing = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.options.SendTimeout)
+	defer cancel()
+
+	if err := d.sink.Ping(ctx); err == nil {
+		d.reachable.Store(true)
+	}
+
+}
+
+// attrValue returns the value of the attribute for the JSON encoding. The errors are encoded as their messages,
+// because the JSON encoding of most errors is an empty object
+func attrValue(value slog.Value) any {
+	v := value.Resolve().Any()
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return v
+}
+
+// JSON returns the JSON object of the fields. If a value can not be encoded, e.g. a channel, its fmt representation
+// is used instead, so a single strange attribute does not lose the whole record
+func JSON(fields map[string]any) []byte {
+
+	if encoded, err := json.Marshal(fields); err == nil {
+		return encoded
+	}
+
+	safe := make(map[string]any, len(fields))
+	for k, v := range fields {
+		if _, err := json.Marshal(v); err != nil {
+			safe[k] = fmt.Sprint(v)
+			continue
+		}
+		safe[k] = v
+	}
+
+	// every value is encodable now
+	encoded, _ := json.Marshal(safe)
+	return encoded
+
+}
@@ -0,0 +1,186 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSink records the sent batches and fails while it is down
+type fakeSink struct {
+	mu       sync.Mutex
+	batches  [][]Entry
+	down     bool
+	rejected int
+}
+
+func (f *fakeSink) Name() string {
+	return "fake"
+}
+
+func (f *fakeSink) Send(_ context.Context, entries []Entry) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return 0, errors.New("the sink is down")
+	}
+	f.batches = append(f.batches, append([]Entry{}, entries...))
+	return f.rejected, nil
+}
+
+func (f *fakeSink) Ping(_ context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return errors.New("the sink is down")
+	}
+	return nil
+}
+
+func (f *fakeSink) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func (f *fakeSink) entries() []Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var entries []Entry
+	for _, b := range f.batches {
+		entries = append(entries, b...)
+	}
+	return entries
+}
+
+func TestHandler(t *testing.T) {
+
+	t.Run("should send the records in batches with the attributes", func(t *testing.T) {
+
+		sink := &fakeSink{}
+		registry := metrics.New()
+		h := New(sink, slog.LevelInfo, &Options{BatchSize: 2, FlushInterval: time.Hour, Metrics: registry})
+
+		logger := slog.New(h).With("swamp", "users/profiles/alex")
+		logger.Info("first", "error", errors.New("broken beacon"))
+		logger.Debug("not sent")
+		logger.Warn("second")
+		logger.Error("third")
+
+		// the third record waits in an incomplete batch until the close
+		require.NoError(t, h.Close())
+
+		entries := sink.entries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, "first", entries[0].Message)
+		assert.Equal(t, "broken beacon", entries[0].Attrs["error"], "the errors are sent as their messages")
+		assert.Equal(t, "users/profiles/alex", entries[0].Attrs["swamp"])
+		assert.Equal(t, slog.LevelWarn, entries[1].Level)
+		assert.Equal(t, "third", entries[2].Message)
+		assert.Len(t, sink.batches, 2)
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), `hydraide_log_sink_sent_messages_total{sink="fake"} 3`)
+
+	})
+
+	t.Run("should flush the incomplete batch after the flush interval", func(t *testing.T) {
+
+		sink := &fakeSink{}
+		h := New(sink, slog.LevelInfo, &Options{FlushInterval: 10 * time.Millisecond})
+		defer func() {
+			_ = h.Close()
+		}()
+
+		slog.New(h).Info("lonely record")
+
+		assert.Eventually(t, func() bool {
+			return len(sink.entries()) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+	})
+
+	t.Run("should report the unreachable sink and count the dropped records", func(t *testing.T) {
+
+		sink := &fakeSink{down: true}
+		registry := metrics.New()
+		h := New(sink, slog.LevelInfo, &Options{BatchSize: 1, Metrics: registry})
+		defer func() {
+			_ = h.Close()
+		}()
+
+		assert.True(t, h.Reachable(), "the sink is reachable until the first failed send")
+		slog.New(h).Info("lost record")
+
+		assert.Eventually(t, func() bool {
+			return !h.Reachable()
+		}, 5*time.Second, 10*time.Millisecond)
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), `hydraide_log_sink_dropped_messages_total{sink="fake",reason="send"} 1`)
+
+	})
+
+	t.Run("should count the rejected records", func(t *testing.T) {
+
+		sink := &fakeSink{rejected: 1}
+		registry := metrics.New()
+		h := New(sink, slog.LevelInfo, &Options{BatchSize: 2, Metrics: registry})
+
+		logger := slog.New(h)
+		logger.Info("stored")
+		logger.Info("rejected")
+		require.NoError(t, h.Close())
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), `hydraide_log_sink_dropped_messages_total{sink="fake",reason="rejected"} 1`)
+		assert.Contains(t, buffer.String(), `hydraide_log_sink_sent_messages_total{sink="fake"} 1`)
+
+	})
+
+	t.Run("should not block if the queue is full", func(t *testing.T) {
+
+		sink := &fakeSink{}
+		registry := metrics.New()
+		h := New(sink, slog.LevelInfo, &Options{QueueSize: 1, Metrics: registry})
+		// the dispatcher is stopped, so the queue is never emptied
+		h.dispatcher.cancel()
+		<-h.dispatcher.done
+
+		record := slog.NewRecord(time.Now(), slog.LevelInfo, "message", 0)
+		assert.NoError(t, h.Handle(context.Background(), record))
+		assert.Error(t, h.Handle(context.Background(), record))
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), `hydraide_log_sink_dropped_messages_total{sink="fake",reason="queue_full"} 1`)
+		assert.Contains(t, buffer.String(), `hydraide_log_sink_queue_length{sink="fake"} 1`)
+
+	})
+
+}
+
+func TestJSON(t *testing.T) {
+
+	t.Run("should encode the fields", func(t *testing.T) {
+		assert.JSONEq(t, `{"swamp":"users/profiles/alex","count":3}`,
+			string(JSON(map[string]any{"swamp": "users/profiles/alex", "count": 3})))
+	})
+
+	t.Run("should keep the record if a value can not be encoded", func(t *testing.T) {
+		encoded := JSON(map[string]any{"msg": "hello", "fn": func() {}})
+		assert.Contains(t, string(encoded), `"msg":"hello"`)
+		assert.Contains(t, string(encoded), `"fn":`)
+	})
+
+}
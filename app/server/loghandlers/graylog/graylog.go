@@ -1,50 +1,196 @@
 // Package graylog implements a slog.Handler that sends logs to Graylog using
 // asynchronous background dispatch with fallback awareness.
+//
+// The log records are queued in a bounded in-memory queue and sent in batches by a background dispatcher, so a log
+// burst, e.g. during a hydration storm, never blocks the goroutines of the requests. If the queue is full, the new
+// records are dropped and counted in the hydraide_graylog_dropped_messages_total metric.
+//
+// Two transports are supported:
+//   - TCP: the batch is written with a single write, the messages are separated by a NULL byte (GELF TCP)
+//   - UDP: every message larger than the compression threshold is gzip compressed, and the messages larger than the
+//     chunk size are split into GELF chunks (GELF UDP)
 package graylog
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/hydraide/hydraide/app/server/metrics"
 	"log/slog"
+	"math/rand/v2"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// Handler is a slog.Handler implementation that sends log messages to a Graylog server via TCP asynchronously.
+const (
+	// TransportTCP sends the messages over TCP, separated by a NULL byte
+	TransportTCP = "tcp"
+	// TransportUDP sends the messages over UDP, compressed and chunked
+	TransportUDP = "udp"
+
+	// DefaultQueueSize is the max number of pending messages before dropping new ones
+	DefaultQueueSize = 10000
+	// DefaultBatchSize is the max number of messages sent at once
+	DefaultBatchSize = 100
+	// DefaultFlushInterval is the max time a message waits in an incomplete batch
+	DefaultFlushInterval = time.Second
+	// DefaultChunkSize is the max size of an UDP datagram. Use 1420 if the messages go through the internet
+	DefaultChunkSize = 8192
+	// DefaultCompressionThreshold is the size above the UDP messages are gzip compressed
+	DefaultCompressionThreshold = 1024
+
+	// reconnectInterval is the min time between two connection attempts
+	reconnectInterval = 5 * time.Second
+	// dialTimeout is the max time of a connection attempt
+	dialTimeout = time.Second
+	// writeTimeout is the max time of a write, so a stuck Graylog server does not stop the dispatcher
+	writeTimeout = 5 * time.Second
+	// maxChunks is the max number of the chunks of a message allowed by GELF
+	maxChunks = 128
+	// chunkHeaderSize is the size of the magic bytes, the message ID, the sequence number and the sequence count
+	chunkHeaderSize = 12
+
+	droppedMetric = "hydraide_graylog_dropped_messages_total"
+	sentMetric    = "hydraide_graylog_sent_messages_total"
+	queueMetric   = "hydraide_graylog_queue_length"
+
+	dropReasonQueueFull = "queue_full"
+	dropReasonSend      = "send"
+	dropReasonTooLarge  = "too_large"
+)
+
+// chunkMagic are the first two bytes of a GELF chunk
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// Options are the optional settings of the handler. The zero values mean the defaults
+type Options struct {
+	// Transport is TransportTCP or TransportUDP. Empty means TransportTCP
+	Transport string
+	// QueueSize is the max number of pending messages before dropping new ones. Zero means DefaultQueueSize
+	QueueSize int
+	// BatchSize is the max number of messages sent at once. Zero means DefaultBatchSize
+	BatchSize int
+	// FlushInterval is the max time a message waits in an incomplete batch. Zero means DefaultFlushInterval
+	FlushInterval time.Duration
+	// ChunkSize is the max size of an UDP datagram in bytes. Zero means DefaultChunkSize
+	ChunkSize int
+	// CompressionThreshold is the size in bytes above the UDP messages are gzip compressed. Zero means
+	// DefaultCompressionThreshold, negative means the messages are never compressed
+	CompressionThreshold int
+	// Metrics is the registry of the queue and drop metrics. Nil means the metrics are not exposed
+	Metrics metrics.Registry
+}
+
+// Handler is a slog.Handler implementation that sends log messages to a Graylog server asynchronously.
 // It uses an internal queue and a background dispatcher to avoid blocking the main execution flow.
-// If the connection fails, it retries automatically and maintains the queue until shutdown.
+// If the connection fails, it retries automatically in the background.
 type Handler struct {
-	address string      // Graylog TCP address (e.g., "127.0.0.1:12201")
-	host    string      // Logical hostname or service identifier sent as the GELF "host" field
-	level   slog.Level  // Minimum log level to emit (e.g., Info, Warn, Error)
-	attrs   []slog.Attr // Static attributes included with every log record
-
-	queue    chan []byte        // Buffered channel for asynchronous log message delivery
-	ctx      context.Context    // Context for graceful shutdown of the dispatcher
-	cancel   context.CancelFunc // Cancel function to signal dispatcher shutdown
-	once     sync.Once          // Ensures dispatcher is started only once
-	retryMux sync.Mutex         // Mutex to synchronize reconnect attempts
-	conn     net.Conn           // Active TCP connection to Graylog
+	host  string      // Logical hostname or service identifier sent as the GELF "host" field
+	level slog.Level  // Minimum log level to emit (e.g., Info, Warn, Error)
+	attrs []slog.Attr // Static attributes included with every log record
+	sink  *sink       // The queue and the dispatcher, shared by the handlers created by WithAttrs
 }
 
-const queueSize = 1000 // Max number of pending messages before dropping new ones
+// sink is the queue and the background dispatcher of the messages
+type sink struct {
+	address string   // Graylog address (e.g., "127.0.0.1:12201")
+	options *Options // The options with the defaults applied
+
+	queue     chan []byte        // Buffered channel for asynchronous log message delivery
+	ctx       context.Context    // Context for graceful shutdown of the dispatcher
+	cancel    context.CancelFunc // Cancel function to signal dispatcher shutdown
+	done      chan struct{}      // Closed when the dispatcher sent the remaining messages and stopped
+	once      sync.Once          // Ensures the dispatcher is stopped only once
+	reachable atomic.Bool        // True if the last connection attempt or write succeeded
+
+	conn     net.Conn  // Active connection to Graylog, used only by the dispatcher
+	lastDial time.Time // Time of the last connection attempt, used only by the dispatcher
+
+	sent             metrics.Counter
+	droppedQueueFull metrics.Counter
+	droppedSend      metrics.Counter
+	droppedTooLarge  metrics.Counter
+}
 
 // New creates a new asynchronous Graylog handler with a background dispatcher.
 // It connects to the specified Graylog address and starts a goroutine that sends logs from a buffered queue.
-func New(address, host string, level slog.Level) (*Handler, error) {
+// Nil options mean the defaults.
+func New(address, host string, level slog.Level, options *Options) (*Handler, error) {
+
+	o := Options{}
+	if options != nil {
+		o = *options
+	}
+	if err := applyDefaults(&o); err != nil {
+		return nil, err
+	}
+
+	registry := o.Metrics
+	if registry == nil {
+		registry = metrics.New()
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
-	h := &Handler{
-		address: address,
-		host:    host,
-		level:   level,
-		queue:   make(chan []byte, queueSize),
-		ctx:     ctx,
-		cancel:  cancel,
+	s := &sink{
+		address:          address,
+		options:          &o,
+		queue:            make(chan []byte, o.QueueSize),
+		ctx:              ctx,
+		cancel:           cancel,
+		done:             make(chan struct{}),
+		sent:             registry.Counter(sentMetric, "Number of the log messages sent to Graylog"),
+		droppedQueueFull: registry.Counter(droppedMetric, "Number of the log messages dropped before they were sent to Graylog", "reason", dropReasonQueueFull),
+		droppedSend:      registry.Counter(droppedMetric, "Number of the log messages dropped before they were sent to Graylog", "reason", dropReasonSend),
+		droppedTooLarge:  registry.Counter(droppedMetric, "Number of the log messages dropped before they were sent to Graylog", "reason", dropReasonTooLarge),
+	}
+	registry.GaugeFunc(queueMetric, "Number of the log messages waiting for Graylog", func() float64 {
+		return float64(len(s.queue))
+	})
+
+	// optimistic until the first connection attempt, so the first records are not sent to the fallback
+	s.reachable.Store(true)
+
+	go s.dispatcher() // Start background log dispatcher
+
+	return &Handler{
+		host:  host,
+		level: level,
+		sink:  s,
+	}, nil
+
+}
+
+// applyDefaults sets the defaults of the zero options and validates the transport
+func applyDefaults(o *Options) error {
+	switch o.Transport {
+	case "":
+		o.Transport = TransportTCP
+	case TransportTCP, TransportUDP:
+	default:
+		return fmt.Errorf("unknown Graylog transport %q, use %s or %s", o.Transport, TransportTCP, TransportUDP)
 	}
-	go h.dispatcher() // Start background log dispatcher
-	return h, nil
+	if o.QueueSize <= 0 {
+		o.QueueSize = DefaultQueueSize
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultFlushInterval
+	}
+	if o.ChunkSize <= chunkHeaderSize {
+		o.ChunkSize = DefaultChunkSize
+	}
+	if o.CompressionThreshold == 0 {
+		o.CompressionThreshold = DefaultCompressionThreshold
+	}
+	return nil
 }
 
 // Enabled reports whether a given log level is enabled for this handler.
@@ -57,13 +203,10 @@ func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
 // These attributes will be included in all subsequent log messages emitted by the handler.
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	return &Handler{
-		address: h.address,
-		host:    h.host,
-		level:   h.level,
-		attrs:   append(h.attrs, attrs...), // merge existing and new attributes
-		queue:   h.queue,
-		ctx:     h.ctx,
-		cancel:  h.cancel,
+		host:  h.host,
+		level: h.level,
+		attrs: append(append([]slog.Attr{}, h.attrs...), attrs...), // merge existing and new attributes
+		sink:  h.sink,
 	}
 }
 
@@ -72,6 +215,12 @@ func (h *Handler) WithGroup(_ string) slog.Handler {
 	return h
 }
 
+// Reachable returns false if the last connection attempt or write to Graylog failed. It never blocks, so it can be
+// the checker of the fallback handler. The dispatcher retries the connection in the background.
+func (h *Handler) Reachable() bool {
+	return h.sink.reachable.Load()
+}
+
 // Handle processes a single log record and enqueues it for asynchronous delivery to Graylog.
 // It transforms the slog.Record into a GELF-compatible JSON object, appends static and dynamic attributes,
 // and sends it to the internal queue for background dispatch.
@@ -124,53 +273,220 @@ func (h *Handler) Handle(_ context.Context, r slog.Record) error {
 
 	// Enqueue for background delivery; drop if queue is full
 	select {
-	case h.queue <- append(data, '\n'):
+	case h.sink.queue <- data:
 		return nil
 	default:
-		return errors.New("graylog queue is full") // optional: could write to fallback
+		h.sink.droppedQueueFull.Inc()
+		return errors.New("graylog queue is full")
 	}
 }
 
-// dispatcher runs in a background goroutine and sends log messages from the queue to the Graylog server.
-// It maintains a single persistent TCP connection, reconnecting if the connection is lost.
-func (h *Handler) dispatcher() {
-	var err error
+// dispatcher runs in a background goroutine and sends log messages from the queue to the Graylog server in batches.
+// It maintains a single persistent connection, reconnecting if the connection is lost.
+func (s *sink) dispatcher() {
+
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, s.options.BatchSize)
 
 	for {
 		select {
-		case <-h.ctx.Done():
-			// Graceful shutdown: close connection if active
-			if h.conn != nil {
-				_ = h.conn.Close()
+		case <-s.ctx.Done():
+			// Graceful shutdown: send the remaining messages and close the connection
+			for {
+				select {
+				case msg := <-s.queue:
+					batch = append(batch, msg)
+					if len(batch) >= s.options.BatchSize {
+						s.flush(batch)
+						batch = batch[:0]
+					}
+				default:
+					s.flush(batch)
+					if s.conn != nil {
+						_ = s.conn.Close()
+					}
+					return
+				}
 			}
-			return
 
-		case msg := <-h.queue:
-			func() {
-				h.retryMux.Lock()
-				defer h.retryMux.Unlock()
+		case msg := <-s.queue:
+			batch = append(batch, msg)
+			if len(batch) >= s.options.BatchSize {
+				s.flush(batch)
+				batch = batch[:0]
+			}
 
-				// Establish TCP connection if not already connected
-				if h.conn == nil {
-					fmt.Println("Graylog connection not established, attempting to connect...")
+		case <-ticker.C:
+			// the empty flush reconnects, so the fallback handler sees when Graylog is back
+			s.flush(batch)
+			batch = batch[:0]
+		}
+	}
 
-					h.conn, err = net.Dial("tcp", h.address)
-					if err != nil {
-						return // skip this message, keep it in queue
-					}
-				}
+}
 
-				// Write message + NULL byte (0x00 terminator required by GELF TCP)
-				_, err := h.conn.Write(append(msg, 0x00))
-				if err != nil {
-					_ = h.conn.Close()
-					h.conn = nil
-					return
-				}
+// flush sends the batch to Graylog. The messages that can not be sent are dropped and counted
+func (s *sink) flush(batch [][]byte) {
+
+	if !s.connect() {
+		s.droppedSend.Add(uint64(len(batch)))
+		return
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+
+	var err error
+	if s.options.Transport == TransportUDP {
+		err = s.sendUDP(batch)
+	} else {
+		err = s.sendTCP(batch)
+	}
+
+	if err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		s.reachable.Store(false)
+	}
+
+}
+
+// connect establishes the connection if it is not established yet, at most once per reconnectInterval.
+// Returns true if the connection is established
+func (s *sink) connect() bool {
+
+	if s.conn != nil {
+		return true
+	}
+	if time.Since(s.lastDial) < reconnectInterval {
+		return false
+	}
+
+	s.lastDial = time.Now()
+	conn, err := net.DialTimeout(s.options.Transport, s.address, dialTimeout)
+	if err != nil {
+		s.reachable.Store(false)
+		return false
+	}
+
+	s.conn = conn
+	s.reachable.Store(true)
+	return true
+
+}
+
+// sendTCP writes the batch with a single write. Every message is terminated by a NULL byte, as GELF TCP requires
+func (s *sink) sendTCP(batch [][]byte) error {
+
+	size := 0
+	for _, msg := range batch {
+		size += len(msg) + 1
+	}
+
+	buffer := make([]byte, 0, size)
+	for _, msg := range batch {
+		buffer = append(buffer, msg...)
+		buffer = append(buffer, 0x00)
+	}
+
+	if _, err := s.conn.Write(buffer); err != nil {
+		s.droppedSend.Add(uint64(len(batch)))
+		return err
+	}
+
+	s.sent.Add(uint64(len(batch)))
+	return nil
+
+}
+
+// sendUDP sends every message of the batch in its own datagrams, compressed and chunked if it is large
+func (s *sink) sendUDP(batch [][]byte) error {
+
+	for i, msg := range batch {
 
-			}()
+		datagrams, err := s.datagrams(msg)
+		if err != nil {
+			s.droppedTooLarge.Inc()
+			continue
 		}
+
+		for _, datagram := range datagrams {
+			if _, err := s.conn.Write(datagram); err != nil {
+				s.droppedSend.Add(uint64(len(batch) - i))
+				return err
+			}
+		}
+		s.sent.Inc()
+
+	}
+
+	return nil
+
+}
+
+// datagrams returns the UDP datagrams of the message. The message is gzip compressed above the compression threshold,
+// and split into GELF chunks above the chunk size
+func (s *sink) datagrams(msg []byte) ([][]byte, error) {
+
+	payload := msg
+	if s.options.CompressionThreshold > 0 && len(msg) > s.options.CompressionThreshold {
+		compressed, err := compress(msg)
+		if err != nil {
+			return nil, err
+		}
+		payload = compressed
+	}
+
+	if len(payload) <= s.options.ChunkSize {
+		return [][]byte{payload}, nil
+	}
+
+	return chunk(payload, s.options.ChunkSize, rand.Uint64())
+
+}
+
+// compress returns the gzip compressed message. Graylog detects the compression by the magic bytes of gzip
+func compress(msg []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	w := gzip.NewWriter(&buffer)
+	if _, err := w.Write(msg); err != nil {
+		return nil, err
 	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// chunk splits the payload into GELF chunks of at most chunkSize bytes. Every chunk starts with the magic bytes, the
+// ID of the message, the sequence number and the sequence count. GELF allows at most 128 chunks
+func chunk(payload []byte, chunkSize int, messageID uint64) ([][]byte, error) {
+
+	dataSize := chunkSize - chunkHeaderSize
+	count := (len(payload) + dataSize - 1) / dataSize
+	if count > maxChunks {
+		return nil, fmt.Errorf("the message needs %d chunks, GELF allows at most %d", count, maxChunks)
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*dataSize, len(payload))
+		c := make([]byte, 0, chunkHeaderSize+end-i*dataSize)
+		c = append(c, chunkMagic...)
+		c = binary.BigEndian.AppendUint64(c, messageID)
+		c = append(c, byte(i), byte(count))
+		c = append(c, payload[i*dataSize:end]...)
+		chunks = append(chunks, c)
+	}
+
+	return chunks, nil
+
 }
 
 // convertLevel maps slog.Level values to GELF numerical levels.
@@ -190,11 +506,15 @@ func convertLevel(level slog.Level) int {
 	}
 }
 
-// Close gracefully shuts down the handler, stopping the dispatcher goroutine
-// and releasing the TCP connection to Graylog.
+// Close gracefully shuts down the handler: the dispatcher sends the queued messages, then it stops and releases the
+// connection to Graylog. Close waits at most a few seconds for the dispatcher.
 func (h *Handler) Close() error {
-	h.once.Do(func() {
-		h.cancel() // cancel dispatcher context
+	h.sink.once.Do(func() {
+		h.sink.cancel() // cancel dispatcher context
 	})
+	select {
+	case <-h.sink.done:
+	case <-time.After(dialTimeout + writeTimeout):
+	}
 	return nil
 }
@@ -0,0 +1,162 @@
+package graylog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+
+	t.Run("should send the batches over TCP separated by a NULL byte", func(t *testing.T) {
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() {
+			_ = listener.Close()
+		}()
+
+		received := make(chan string, 10)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer func() {
+				_ = conn.Close()
+			}()
+			reader := bufio.NewReader(conn)
+			for {
+				msg, err := reader.ReadString(0x00)
+				if err != nil {
+					return
+				}
+				received <- strings.TrimSuffix(msg, "\x00")
+			}
+		}()
+
+		registry := metrics.New()
+		h, err := New(listener.Addr().String(), "hydraide-test", slog.LevelInfo, &Options{
+			BatchSize:     2,
+			FlushInterval: 10 * time.Millisecond,
+			Metrics:       registry,
+		})
+		require.NoError(t, err)
+
+		logger := slog.New(h).With("swamp", "users/profiles/alex")
+		logger.Info("first")
+		logger.Debug("not sent")
+		logger.Warn("second")
+		logger.Error("third")
+
+		for _, expected := range []string{"first", "second", "third"} {
+			select {
+			case msg := <-received:
+				var gelf map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(msg), &gelf))
+				assert.Equal(t, expected, gelf["short_message"])
+				assert.Equal(t, "hydraide-test", gelf["host"])
+				assert.Equal(t, "users/profiles/alex", gelf["_swamp"])
+			case <-time.After(5 * time.Second):
+				t.Fatalf("the message %s is not received", expected)
+			}
+		}
+
+		require.NoError(t, h.Close())
+		assert.True(t, h.Reachable())
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), "hydraide_graylog_sent_messages_total 3")
+
+	})
+
+	t.Run("should drop and count the messages if the queue is full", func(t *testing.T) {
+
+		registry := metrics.New()
+		// nothing listens on the port, and the dispatcher is stopped, so the queue is never emptied
+		h, err := New("127.0.0.1:1", "hydraide-test", slog.LevelInfo, &Options{QueueSize: 1, Metrics: registry})
+		require.NoError(t, err)
+		h.sink.cancel()
+		<-h.sink.done
+
+		record := slog.NewRecord(time.Now(), slog.LevelInfo, "message", 0)
+		assert.NoError(t, h.Handle(context.Background(), record))
+		assert.Error(t, h.Handle(context.Background(), record), "the Handle must not block on the full queue")
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), `hydraide_graylog_dropped_messages_total{reason="queue_full"} 1`)
+		assert.Contains(t, buffer.String(), "hydraide_graylog_queue_length 1")
+
+	})
+
+	t.Run("should reject the unknown transport", func(t *testing.T) {
+		_, err := New("127.0.0.1:12201", "hydraide-test", slog.LevelInfo, &Options{Transport: "http"})
+		assert.Error(t, err)
+	})
+
+}
+
+func TestDatagrams(t *testing.T) {
+
+	s := &sink{options: &Options{Transport: TransportUDP}}
+	require.NoError(t, applyDefaults(s.options))
+
+	t.Run("should send the small message as it is", func(t *testing.T) {
+		msg := []byte(`{"short_message":"hello"}`)
+		datagrams, err := s.datagrams(msg)
+		require.NoError(t, err)
+		require.Len(t, datagrams, 1)
+		assert.Equal(t, msg, datagrams[0])
+	})
+
+	t.Run("should compress the large message", func(t *testing.T) {
+		msg := []byte(`{"short_message":"` + strings.Repeat("hydration storm ", 200) + `"}`)
+		datagrams, err := s.datagrams(msg)
+		require.NoError(t, err)
+		require.Len(t, datagrams, 1)
+
+		r, err := gzip.NewReader(bytes.NewReader(datagrams[0]))
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, msg, decompressed)
+	})
+
+}
+
+func TestChunk(t *testing.T) {
+
+	payload := bytes.Repeat([]byte("0123456789"), 10)
+
+	chunks, err := chunk(payload, 42, 0x0102030405060708)
+	require.NoError(t, err)
+	require.Len(t, chunks, 4, "30 bytes of data per chunk")
+
+	var joined []byte
+	for i, c := range chunks {
+		assert.Equal(t, chunkMagic, c[:2])
+		assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, c[2:10], "every chunk has the ID of the message")
+		assert.Equal(t, byte(i), c[10])
+		assert.Equal(t, byte(4), c[11])
+		assert.LessOrEqual(t, len(c), 42)
+		joined = append(joined, c[chunkHeaderSize:]...)
+	}
+	assert.Equal(t, payload, joined)
+
+	_, err = chunk(bytes.Repeat([]byte("x"), 129*30), 42, 1)
+	assert.Error(t, err, "GELF allows at most 128 chunks")
+
+}
@@ -0,0 +1,180 @@
+// Package loki implements a slog.Handler that pushes the logs to Grafana Loki.
+//
+// The records are sent in batches to the push API of Loki (/loki/api/v1/push). Every stream has the static labels of
+// the configuration and the level of the records as labels, and the log line is the JSON of the message and the
+// attributes, so the attributes can be filtered with the json parser of LogQL:
+//
+//	{service_name="hydraide", level="error"} | json | swamp="users/profiles/alex"
+//
+// The attributes are not labels on purpose: a label with many values, e.g. the swamp names, would create a stream
+// for every value and overload Loki.
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/loghandlers/batch"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	pushPath  = "/loki/api/v1/push"
+	readyPath = "/ready"
+	// tenantHeader is the header of the tenant ID in the multi-tenant Loki
+	tenantHeader = "X-Scope-OrgID"
+)
+
+// Configuration is the configuration of the Loki sink
+type Configuration struct {
+	// URL is the base URL of Loki, e.g. "http://loki:3100"
+	URL string
+	// Labels are the static labels of every stream, e.g. {"service_name": "hydraide"}. The level is added by the sink
+	Labels map[string]string
+	// TenantID is the tenant of the logs in the multi-tenant Loki. Empty means the tenant header is not sent
+	TenantID string
+	// Username and Password are the basic auth credentials of Loki. Empty means no authentication
+	Username string
+	Password string
+	// Options are the batching settings of the handler. Nil means the defaults
+	Options *batch.Options
+}
+
+// New creates the handler pushing the logs to Loki
+func New(configuration *Configuration, level slog.Level) *batch.Handler {
+	return batch.New(&sink{
+		configuration: configuration,
+		url:           strings.TrimSuffix(configuration.URL, "/"),
+		client:        &http.Client{},
+	}, level, configuration.Options)
+}
+
+type sink struct {
+	configuration *Configuration
+	url           string
+	client        *http.Client
+}
+
+// pushRequest is the body of the push API
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	// Values are the timestamp in Unix nanoseconds as string and the log line
+	Values [][2]string `json:"values"`
+}
+
+func (s *sink) Name() string {
+	return "loki"
+}
+
+func (s *sink) Send(ctx context.Context, entries []batch.Entry) (int, error) {
+
+	body := encode(entries, s.configuration.Labels)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+pushPath, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.authenticate(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return 0, nil
+	case resp.StatusCode == http.StatusBadRequest:
+		// Loki refuses the whole push if it is invalid, e.g. too old or too large, sending it again does not help
+		return len(entries), nil
+	default:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("loki answered %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+}
+
+func (s *sink) Ping(ctx context.Context) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+readyPath, nil)
+	if err != nil {
+		return err
+	}
+	s.authenticate(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("loki is not ready: %s", resp.Status)
+	}
+	return nil
+
+}
+
+// authenticate sets the tenant and the basic auth headers of the request
+func (s *sink) authenticate(req *http.Request) {
+	if s.configuration.TenantID != "" {
+		req.Header.Set(tenantHeader, s.configuration.TenantID)
+	}
+	if s.configuration.Username != "" {
+		req.SetBasicAuth(s.configuration.Username, s.configuration.Password)
+	}
+}
+
+// encode returns the push request of the entries. The entries are grouped into one stream per level
+func encode(entries []batch.Entry, labels map[string]string) []byte {
+
+	streams := make(map[slog.Level]*stream)
+	var order []slog.Level
+
+	for _, entry := range entries {
+
+		st, ok := streams[entry.Level]
+		if !ok {
+			streamLabels := make(map[string]string, len(labels)+1)
+			for k, v := range labels {
+				streamLabels[k] = v
+			}
+			streamLabels["level"] = strings.ToLower(entry.Level.String())
+			st = &stream{Stream: streamLabels}
+			streams[entry.Level] = st
+			order = append(order, entry.Level)
+		}
+
+		line := make(map[string]any, len(entry.Attrs)+1)
+		for k, v := range entry.Attrs {
+			line[k] = v
+		}
+		line["msg"] = entry.Message
+
+		st.Values = append(st.Values, [2]string{strconv.FormatInt(entry.Time.UnixNano(), 10), string(batch.JSON(line))})
+
+	}
+
+	request := pushRequest{Streams: make([]stream, 0, len(order))}
+	for _, level := range order {
+		request.Streams = append(request.Streams, *streams[level])
+	}
+
+	// the labels and the lines are strings, so the request is always encodable
+	body, _ := json.Marshal(request)
+	return body
+
+}
@@ -0,0 +1,122 @@
+package loki
+
+import (
+	"encoding/json"
+	"github.com/hydraide/hydraide/app/server/loghandlers/batch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+
+	t.Run("should push the records with the labels and the tenant", func(t *testing.T) {
+
+		var mu sync.Mutex
+		var pushes []pushRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, pushPath, r.URL.Path)
+			assert.Equal(t, "tenant-1", r.Header.Get(tenantHeader))
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "hydra", username)
+			assert.Equal(t, "secret", password)
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var push pushRequest
+			require.NoError(t, json.Unmarshal(body, &push))
+
+			mu.Lock()
+			pushes = append(pushes, push)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		h := New(&Configuration{
+			URL:      server.URL + "/",
+			Labels:   map[string]string{"service_name": "hydraide"},
+			TenantID: "tenant-1",
+			Username: "hydra",
+			Password: "secret",
+		}, slog.LevelInfo)
+
+		logger := slog.New(h).With("swamp", "users/profiles/alex")
+		logger.Info("hydrated")
+		logger.Error("failed")
+		require.NoError(t, h.Close())
+		assert.True(t, h.Reachable())
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, pushes, 1)
+		require.Len(t, pushes[0].Streams, 2, "one stream per level")
+
+		info := pushes[0].Streams[0]
+		assert.Equal(t, map[string]string{"service_name": "hydraide", "level": "info"}, info.Stream)
+		require.Len(t, info.Values, 1)
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal([]byte(info.Values[0][1]), &line))
+		assert.Equal(t, "hydrated", line["msg"])
+		assert.Equal(t, "users/profiles/alex", line["swamp"])
+
+		assert.Equal(t, "error", pushes[0].Streams[1].Stream["level"])
+
+	})
+
+	t.Run("should mark the unreachable Loki and recover after the ping", func(t *testing.T) {
+
+		var mu sync.Mutex
+		ready := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if r.URL.Path == readyPath && ready {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		h := New(&Configuration{URL: server.URL, Options: &batch.Options{FlushInterval: 10 * time.Millisecond}}, slog.LevelInfo)
+		defer func() {
+			_ = h.Close()
+		}()
+
+		slog.New(h).Info("lost")
+		assert.Eventually(t, func() bool {
+			return !h.Reachable()
+		}, 5*time.Second, 10*time.Millisecond)
+
+		mu.Lock()
+		ready = true
+		mu.Unlock()
+
+		assert.Eventually(t, h.Reachable, 10*time.Second, 50*time.Millisecond)
+
+	})
+
+}
+
+func TestSendRejected(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "entry too far behind", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	s := &sink{configuration: &Configuration{URL: server.URL}, url: server.URL, client: server.Client()}
+	rejected, err := s.Send(t.Context(), []batch.Entry{{Time: time.Now(), Message: "old"}, {Time: time.Now(), Message: "older"}})
+	assert.NoError(t, err, "Loki is reachable, it refused the push")
+	assert.Equal(t, 2, rejected)
+
+}
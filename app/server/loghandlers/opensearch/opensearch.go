@@ -0,0 +1,191 @@
+// Package opensearch implements a slog.Handler that indexes the logs in OpenSearch or Elasticsearch.
+//
+// The records are sent in batches to the bulk API (/_bulk) into daily indices, e.g. "hydraide-logs-2025.01.31", so
+// the old logs can be removed by deleting whole indices, e.g. with an ISM or ILM policy. Every record is a document
+// with the @timestamp, level and message fields, and the attributes of the record as further fields.
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/loghandlers/batch"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultIndex is the prefix of the daily indices if the configuration does not set one
+	DefaultIndex = "hydraide-logs"
+
+	bulkPath = "/_bulk"
+	// indexDateLayout is the date suffix of the daily indices
+	indexDateLayout = "2006.01.02"
+)
+
+// Configuration is the configuration of the OpenSearch sink
+type Configuration struct {
+	// URL is the base URL of the cluster, e.g. "http://opensearch:9200"
+	URL string
+	// Index is the prefix of the daily indices. Empty means DefaultIndex
+	Index string
+	// ServiceName is the service_name field of every document. Empty means the field is not set
+	ServiceName string
+	// Username and Password are the basic auth credentials of the cluster. Empty means no authentication
+	Username string
+	Password string
+	// Options are the batching settings of the handler. Nil means the defaults
+	Options *batch.Options
+}
+
+// New creates the handler indexing the logs in OpenSearch
+func New(configuration *Configuration, level slog.Level) *batch.Handler {
+
+	index := configuration.Index
+	if index == "" {
+		index = DefaultIndex
+	}
+
+	return batch.New(&sink{
+		configuration: configuration,
+		url:           strings.TrimSuffix(configuration.URL, "/"),
+		index:         index,
+		client:        &http.Client{},
+	}, level, configuration.Options)
+
+}
+
+type sink struct {
+	configuration *Configuration
+	url           string
+	index         string
+	client        *http.Client
+}
+
+// bulkResponse is the part of the bulk API response used by the sink
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+	} `json:"items"`
+}
+
+func (s *sink) Name() string {
+	return "opensearch"
+}
+
+func (s *sink) Send(ctx context.Context, entries []batch.Entry) (int, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+bulkPath, bytes.NewReader(encode(entries, s.index, s.configuration.ServiceName)))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	s.authenticate(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("opensearch answered %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var response bulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("failed to decode the bulk response: %w", err)
+	}
+
+	return rejectedItems(&response), nil
+
+}
+
+func (s *sink) Ping(ctx context.Context) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+"/", nil)
+	if err != nil {
+		return err
+	}
+	s.authenticate(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("opensearch is not available: %s", resp.Status)
+	}
+	return nil
+
+}
+
+// authenticate sets the basic auth header of the request
+func (s *sink) authenticate(req *http.Request) {
+	if s.configuration.Username != "" {
+		req.SetBasicAuth(s.configuration.Username, s.configuration.Password)
+	}
+}
+
+// encode returns the NDJSON body of the bulk request: an index action and a document per entry
+func encode(entries []batch.Entry, index string, serviceName string) []byte {
+
+	var body bytes.Buffer
+	for _, entry := range entries {
+
+		action := map[string]any{
+			"index": map[string]string{"_index": index + "-" + entry.Time.UTC().Format(indexDateLayout)},
+		}
+		// the action contains only strings, so it is always encodable
+		encodedAction, _ := json.Marshal(action)
+
+		document := make(map[string]any, len(entry.Attrs)+4)
+		for k, v := range entry.Attrs {
+			document[k] = v
+		}
+		if serviceName != "" {
+			document["service_name"] = serviceName
+		}
+		document["@timestamp"] = entry.Time.UTC().Format(time.RFC3339Nano)
+		document["level"] = strings.ToLower(entry.Level.String())
+		document["message"] = entry.Message
+
+		body.Write(encodedAction)
+		body.WriteByte('\n')
+		body.Write(batch.JSON(document))
+		body.WriteByte('\n')
+
+	}
+
+	return body.Bytes()
+
+}
+
+// rejectedItems returns the number of the documents the cluster refused to index, e.g. because of a mapping conflict
+func rejectedItems(response *bulkResponse) int {
+
+	if !response.Errors {
+		return 0
+	}
+
+	rejected := 0
+	for _, item := range response.Items {
+		for _, result := range item {
+			if result.Status < 200 || result.Status >= 300 {
+				rejected++
+			}
+		}
+	}
+	return rejected
+
+}
@@ -0,0 +1,87 @@
+package opensearch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/hydraide/hydraide/app/server/loghandlers/batch"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+
+	var mu sync.Mutex
+	var lines []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, bulkPath, r.URL.Path)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		for scanner.Scan() {
+			var line map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		mu.Unlock()
+
+		// the second document is refused
+		_, _ = w.Write([]byte(`{"errors":true,"items":[{"index":{"status":201}},{"index":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`))
+	}))
+	defer server.Close()
+
+	registry := metrics.New()
+	h := New(&Configuration{URL: server.URL, ServiceName: "hydraide", Options: &batch.Options{Metrics: registry}}, slog.LevelInfo)
+
+	logger := slog.New(h).With("swamp", "users/profiles/alex")
+	logger.Info("hydrated")
+	logger.Warn("slow")
+	require.NoError(t, h.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, lines, 4, "an action and a document per record")
+
+	index := lines[0]["index"].(map[string]any)["_index"].(string)
+	assert.Regexp(t, `^hydraide-logs-\d{4}\.\d{2}\.\d{2}$`, index)
+
+	assert.Equal(t, "hydrated", lines[1]["message"])
+	assert.Equal(t, "info", lines[1]["level"])
+	assert.Equal(t, "users/profiles/alex", lines[1]["swamp"])
+	assert.Equal(t, "hydraide", lines[1]["service_name"])
+	_, err := time.Parse(time.RFC3339Nano, lines[1]["@timestamp"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "warn", lines[3]["level"])
+
+	var buffer bytes.Buffer
+	require.NoError(t, registry.WriteText(&buffer))
+	assert.Contains(t, buffer.String(), `hydraide_log_sink_dropped_messages_total{sink="opensearch",reason="rejected"} 1`)
+	assert.Contains(t, buffer.String(), `hydraide_log_sink_sent_messages_total{sink="opensearch"} 1`)
+
+}
+
+func TestSendUnavailable(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "cluster_block_exception", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	s := &sink{configuration: &Configuration{}, url: server.URL, index: DefaultIndex, client: server.Client()}
+	_, err := s.Send(t.Context(), []batch.Entry{{Time: time.Now(), Message: "hello"}})
+	assert.Error(t, err)
+	assert.Error(t, s.Ping(t.Context()))
+
+}
@@ -1,20 +1,32 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/hydraide/hydraide/app/core/settings/defaults"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
+	"github.com/hydraide/hydraide/app/server/audit"
+	"github.com/hydraide/hydraide/app/server/backup"
+	"github.com/hydraide/hydraide/app/server/config"
+	"github.com/hydraide/hydraide/app/server/loghandlers/batch"
 	"github.com/hydraide/hydraide/app/server/loghandlers/fallback"
 	"github.com/hydraide/hydraide/app/server/loghandlers/graylog"
+	"github.com/hydraide/hydraide/app/server/loghandlers/loki"
+	"github.com/hydraide/hydraide/app/server/loghandlers/opensearch"
 	"github.com/hydraide/hydraide/app/server/loghandlers/slogmulti"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/hydraide/hydraide/app/server/platform"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	"github.com/hydraide/hydraide/app/server/restgateway"
 	"github.com/hydraide/hydraide/app/server/server"
+	"github.com/hydraide/hydraide/app/server/tenancy"
+	"github.com/hydraide/hydraide/app/server/tracing"
+	"github.com/hydraide/hydraide/app/server/wal"
 	"log/slog"
-	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"runtime/debug"
-	"strconv"
-	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -22,124 +34,192 @@ import (
 
 var serverInterface server.Server
 
-var (
-	graylogServer         = ""
-	graylogServiceName    = "HydrAIDE-Server"
-	logLevel              = "debug"
-	hydraMaxMessageSize   = 104857600   // 100 MB
-	defaultCloseAfterIdle = int64(1)    // 1 second
-	defaultWriteInterval  = int64(10)   // 10 seconds
-	defaultFileSize       = int64(8192) // 8 KB
-	systemResourceLogging = false
-	serverCrtPath         = ""
-	serverKeyPath         = ""
-	hydraServerPort       = 4444
-	healthCheckPort       = 4445
-)
+// version is the release version of the server, set at build time by -ldflags "-X main.version=<version>"
+var version = "dev"
 
-const (
-	hydrAIDEDefaultRootPath = "/hydraide"
+// the values are set from the configuration in the init function, see the config package for the defaults
+var (
+	graylogServer               string
+	graylogServiceName          string
+	graylogOptions              *graylog.Options
+	lokiSink                    *loki.Configuration
+	openSearchSink              *opensearch.Configuration
+	logLevel                    string
+	hydraMaxMessageSize         int
+	defaultCloseAfterIdle       int64
+	defaultWriteInterval        int64
+	defaultFileSize             int64
+	defaultFsyncPolicy          setting.FsyncPolicy
+	defaultFsyncInterval        int64
+	applyDefaultsToUnregistered bool
+	systemResourceLogging       bool
+	grpcServerErrorLogging      bool
+	serverCrtPath               string
+	serverKeyPath               string
+	hydraServerPort             int
+	healthCheckPort             int
+	tlsReloadInterval           time.Duration
+	maxTreasuresPerSwamp        int
+	maxKeyLength                int
+	maxValueSize                int64
+	failOnCorruptedFiles        bool
+	writeBatchSize              int
+	maxHydrations               int
+	telemetryInterval           time.Duration
+	minFreeDiskPercent          float64
+	warnFreeDiskPercent         float64
+	rateLimit                   *ratelimit.Configuration
+	tracingConfiguration        *tracing.Configuration
+	slowOperationThreshold      time.Duration
+	accessLog                   *server.AccessLogConfiguration
+	restGateway                 *restgateway.Configuration
+	tenancyConfiguration        *tenancy.Configuration
+	grpcConnection              *server.ConnectionConfiguration
+	auditConfiguration          *audit.Configuration
+	backupConfiguration         *backup.Configuration
+	walConfiguration            *wal.Configuration
+	clusterTopologyFile         string
+	clusterTopologyInterval     time.Duration
+	metricsRegistry             = metrics.New()
 )
 
 func init() {
 
-	// Load environment variables from .env files before anything else
-	_ = godotenv.Load()
+	// Load environment variables from .env files before anything else. The files are loaded one by one, because a
+	// missing file must not prevent loading the next one.
+	for _, envFile := range platform.EnvFiles() {
+		_ = godotenv.Load(envFile)
+	}
 
-	// check if the HYDRAIDE_SERVER_PORT and HEALTH_CHECK_PORT environment variables are set
-	var err error
-	if os.Getenv("HYDRAIDE_SERVER_PORT") != "" {
-		if hydraServerPort, err = strconv.Atoi(os.Getenv("HYDRAIDE_SERVER_PORT")); err != nil {
-			panic(fmt.Sprintf("HYDRAIDE_SERVER_PORT must be a number without any string characters: %v", err))
-		}
+	rootPath := os.Getenv("HYDRAIDE_ROOT_PATH")
+	if rootPath == "" {
+		// the default root path depends on the operating system, /hydraide in the docker container
+		rootPath = platform.DefaultRootPath()
 	}
-	if os.Getenv("HEALTH_CHECK_PORT") != "" {
-		if healthCheckPort, err = strconv.Atoi(os.Getenv("HEALTH_CHECK_PORT")); err != nil {
-			panic(fmt.Sprintf("HEALTH_CHECK_PORT must be a number without any string characters: %v", err))
-		}
+	// normalize the configured paths for the operating system, because we use these env variables in the settings
+	// and config packages, too
+	normalizeEnvPath("HYDRAIDE_ROOT_PATH", rootPath)
+	normalizeEnvPath("HYDRAIDE_CONFIG_FILE", os.Getenv("HYDRAIDE_CONFIG_FILE"))
+
+	// load the configuration from the hydraide.yaml file and let the environment variables override its keys
+	cfg, configFilePath, err := config.Load()
+	if err != nil {
+		slog.Error("failed to load the HydrAIDE configuration", "error", err)
+		panic(fmt.Sprintf("failed to load the HydrAIDE configuration: %v", err))
+	}
+	if configFilePath != "" {
+		slog.Info("HydrAIDE configuration file loaded", "path", configFilePath)
 	}
 
-	if os.Getenv("HYDRAIDE_ROOT_PATH") == "" {
-		// for the docker container, the hydrAIDE root path is set to /hydraide
-		// needed, because we use this env variable in the settings package, too
-		if err := os.Setenv("HYDRAIDE_ROOT_PATH", hydrAIDEDefaultRootPath); err != nil {
-			panic(fmt.Sprintf("failed to set HYDRAIDE_ROOT_PATH environment variable: %v", err))
+	hydraServerPort = cfg.Server.Port
+	healthCheckPort = cfg.Server.HealthCheckPort
+	grpcConnection = connectionConfiguration(cfg.Server.Connection)
+	tlsReloadInterval = time.Duration(cfg.TLS.ReloadIntervalSec) * time.Second
+	defaultCloseAfterIdle = cfg.Defaults.CloseAfterIdleSec
+	defaultWriteInterval = cfg.Defaults.WriteIntervalSec
+	defaultFileSize = cfg.Defaults.FileSize
+	defaultFsyncPolicy = setting.FsyncPolicy(cfg.Defaults.FsyncPolicy)
+	defaultFsyncInterval = cfg.Defaults.FsyncIntervalSec
+	applyDefaultsToUnregistered = cfg.Defaults.ApplyToUnregisteredSwamps
+	logLevel = cfg.Logging.Level
+	systemResourceLogging = cfg.Logging.SystemResourceLogging
+	grpcServerErrorLogging = cfg.Logging.GrpcServerErrorLogging
+	slowOperationThreshold = time.Duration(cfg.Logging.SlowOperationThresholdMs) * time.Millisecond
+	if cfg.Logging.AccessLog.Enabled {
+		accessLog = &server.AccessLogConfiguration{
+			SampleRatio: cfg.Logging.AccessLog.SampleRatio,
+			Reads:       cfg.Logging.AccessLog.Reads,
+			Writes:      cfg.Logging.AccessLog.Writes,
+			Streams:     cfg.Logging.AccessLog.Streams,
 		}
 	}
-
-	// should be handled these for linux and windows
-	serverCrtPath = filepath.Join(os.Getenv("HYDRAIDE_ROOT_PATH"), "certificate", "server.crt")
-	serverKeyPath = filepath.Join(os.Getenv("HYDRAIDE_ROOT_PATH"), "certificate", "server.key")
-
-	if _, err := os.Stat(serverCrtPath); os.IsNotExist(err) {
-		slog.Error("server certificate file server.crt are not found", "error", err.Error())
-		panic(fmt.Sprintf("server certificate file server.crt are not found in %s", serverCrtPath))
+	hydraMaxMessageSize = cfg.Limits.MaxMessageSize
+	maxTreasuresPerSwamp = cfg.Limits.MaxTreasuresPerSwamp
+	maxKeyLength = cfg.Limits.MaxKeyLength
+	maxValueSize = cfg.Limits.MaxValueSize
+	failOnCorruptedFiles = cfg.Storage.FailOnCorruptedFiles
+	writeBatchSize = cfg.Storage.WriteBatchSize
+	maxHydrations = cfg.Storage.MaxConcurrentHydrations
+	telemetryInterval = time.Duration(cfg.Telemetry.SampleIntervalSec) * time.Second
+	minFreeDiskPercent = cfg.Telemetry.MinFreeDiskPercent
+	warnFreeDiskPercent = cfg.Telemetry.WarnFreeDiskPercent
+	if cfg.Limits.RateLimit.Enabled {
+		rateLimit = rateLimitConfiguration(cfg.Limits.RateLimit)
 	}
-
-	// check if the server key and certificate files exist
-	if _, err := os.Stat(serverCrtPath); os.IsNotExist(err) {
-		slog.Error("server certificate file server.crt are not found", "error", err.Error())
-		panic(fmt.Sprintf("server certificate file server.crt are not found in %s", serverCrtPath))
-	}
-	if _, err := os.Stat(serverKeyPath); os.IsNotExist(err) {
-		slog.Error("server certificate file server.key are not found", "error", err.Error())
-		panic(fmt.Sprintf("server certificate file server.key are not found in %s", serverKeyPath))
+	if cfg.Tracing.Enabled {
+		tracingConfiguration = &tracing.Configuration{
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			ServiceName: cfg.Tracing.ServiceName,
+			SampleRatio: cfg.Tracing.SampleRatio,
+		}
 	}
-
-	// log level must have
-	if os.Getenv("LOG_LEVEL") != "" {
-		logLevel = os.Getenv("LOG_LEVEL")
+	if cfg.RestGateway.Enabled {
+		restGateway = &restgateway.Configuration{
+			Port:       cfg.RestGateway.Port,
+			Tokens:     cfg.RestGateway.Tokens,
+			AllIslands: cfg.RestGateway.AllIslands,
+		}
 	}
-
-	if os.Getenv("SYSTEM_RESOURCE_LOGGING") == "true" {
-		systemResourceLogging = true // default system resource logging is disabled
+	if cfg.Tenancy.Enabled {
+		tenancyConfiguration = tenancyConfigurationFromConfig(cfg.Tenancy)
 	}
-
-	if os.Getenv("GRAYLOG_ENABLED") == "true" {
-		if os.Getenv("GRAYLOG_SERVER") != "" {
-			graylogServer = os.Getenv("GRAYLOG_SERVER")
-		}
-		// GRAYLOG_SERVICE_NAME is optional environment variable. Set the graylog service name only if it is set
-		if os.Getenv("GRAYLOG_SERVICE_NAME") != "" {
-			graylogServiceName = os.Getenv("GRAYLOG_SERVICE_NAME")
+	if cfg.Audit.Enabled {
+		auditConfiguration = &audit.Configuration{
+			Folder:      cfg.Audit.Folder,
+			MaxFileSize: cfg.Audit.MaxFileSize,
+			MaxFiles:    cfg.Audit.MaxFiles,
 		}
 	}
-
-	// HYDRA_MAX_MESSAGE_SIZE environment variable
-	if os.Getenv("GRPC_MAX_MESSAGE_SIZE") != "" {
-		var err error
-		hydraMaxMessageSize, err = strconv.Atoi(os.Getenv("GRPC_MAX_MESSAGE_SIZE"))
-		if err != nil {
-			slog.Error("GRPC_MAX_MESSAGE_SIZE must be a number without any string characters", "error", err)
-			panic("GRPC_MAX_MESSAGE_SIZE must be a number without any string characters")
+	if cfg.Backup.Enabled {
+		backupConfiguration = backupConfigurationFromConfig(cfg.Backup)
+	}
+	if cfg.WAL.Enabled {
+		walConfiguration = &wal.Configuration{
+			Folder:         cfg.WAL.Folder,
+			MaxSegmentSize: cfg.WAL.MaxSegmentSize,
+			SyncInterval:   time.Duration(cfg.WAL.SyncIntervalMs) * time.Millisecond,
+			MaxAge:         time.Duration(cfg.WAL.MaxAgeSec) * time.Second,
 		}
 	}
-
-	if os.Getenv("HYDRAIDE_DEFAULT_CLOSE_AFTER_IDLE") != "" {
-		dcai, err := strconv.Atoi(os.Getenv("HYDRAIDE_DEFAULT_CLOSE_AFTER_IDLE"))
-		if err != nil {
-			slog.Error("HYDRAIDE_DEFAULT_CLOSE_AFTER_IDLE must be a number without any string characters", "error", err)
-			panic("HYDRAIDE_DEFAULT_CLOSE_AFTER_IDLE must be a number without any string characters")
+	clusterTopologyFile = cfg.Cluster.TopologyFile
+	clusterTopologyInterval = time.Duration(cfg.Cluster.CheckIntervalSec) * time.Second
+	if cfg.Logging.Graylog.Enabled {
+		graylogServer = cfg.Logging.Graylog.Server
+		graylogServiceName = cfg.Logging.Graylog.ServiceName
+		graylogOptions = &graylog.Options{
+			Transport:     cfg.Logging.Graylog.Transport,
+			QueueSize:     cfg.Logging.Graylog.QueueSize,
+			BatchSize:     cfg.Logging.Graylog.BatchSize,
+			FlushInterval: time.Duration(cfg.Logging.Graylog.FlushIntervalMs) * time.Millisecond,
+			ChunkSize:     cfg.Logging.Graylog.ChunkSize,
+			Metrics:       metricsRegistry,
 		}
-		defaultCloseAfterIdle = int64(dcai)
 	}
-
-	if os.Getenv("HYDRAIDE_DEFAULT_WRITE_INTERVAL") != "" {
-		dwi, err := strconv.Atoi(os.Getenv("HYDRAIDE_DEFAULT_WRITE_INTERVAL"))
-		if err != nil {
-			slog.Error("HYDRAIDE_DEFAULT_WRITE_INTERVAL must be a number without any string characters", "error", err)
-			panic("HYDRAIDE_DEFAULT_WRITE_INTERVAL must be a number without any string characters")
+	if cfg.Logging.Loki.Enabled {
+		lokiSink = lokiConfiguration(cfg.Logging.Loki)
+	}
+	if cfg.Logging.OpenSearch.Enabled {
+		openSearchSink = &opensearch.Configuration{
+			URL:         cfg.Logging.OpenSearch.URL,
+			Index:       cfg.Logging.OpenSearch.Index,
+			ServiceName: cfg.Logging.OpenSearch.ServiceName,
+			Username:    cfg.Logging.OpenSearch.Username,
+			Password:    cfg.Logging.OpenSearch.Password,
+			Options:     logDeliveryOptions(cfg.Logging.OpenSearch.LogDeliveryConfig),
 		}
-		defaultWriteInterval = int64(dwi)
 	}
 
-	if os.Getenv("HYDRAIDE_DEFAULT_FILE_SIZE") != "" {
-		dfs, err := strconv.Atoi(os.Getenv("HYDRAIDE_DEFAULT_FILE_SIZE"))
-		if err != nil {
-			slog.Error("HYDRAIDE_DEFAULT_FILE_SIZE must be a number without any string characters", "error", err)
-			panic("HYDRAIDE_DEFAULT_FILE_SIZE must be a number without any string characters")
-		}
-		defaultFileSize = int64(dfs)
+	serverCrtPath = filepath.Join(os.Getenv("HYDRAIDE_ROOT_PATH"), "certificate", "server.crt")
+	serverKeyPath = filepath.Join(os.Getenv("HYDRAIDE_ROOT_PATH"), "certificate", "server.key")
+
+	// check if the server key and certificate files exist. The missing files are not fatal, because the certificate
+	// reloader loads them as soon as they appear. Until then, the /readyz endpoint reports the TLS check as failed.
+	if _, err := os.Stat(serverCrtPath); os.IsNotExist(err) {
+		slog.Warn("server certificate file server.crt is not found, waiting for it", "path", serverCrtPath)
+	}
+	if _, err := os.Stat(serverKeyPath); os.IsNotExist(err) {
+		slog.Warn("server certificate file server.key is not found, waiting for it", "path", serverKeyPath)
 	}
 
 }
@@ -149,21 +229,28 @@ func main() {
 	defer panicHandler()
 
 	// ----------------------------------------------------------------------------
-	// Logger setup with console output + optional Graylog + file fallback
+	// Logger setup with console output + optional remote sink + file fallback
 	// ----------------------------------------------------------------------------
 	// Logging architecture:
 	// - Always: logs go to console
-	// - If Graylog is defined:
-	//   - logs go to Graylog
-	//   - if Graylog fails, logs go to fallback.log (and are retried later)
-	// - If Graylog is undefined: logs go ONLY to console (no file write)
+	// - If a remote sink (Graylog, Loki or OpenSearch) is enabled:
+	//   - logs go to the sink
+	//   - if the sink fails, logs go to fallback.log (and are retried later)
+	// - If no sink is enabled: logs go ONLY to console (no file write)
 	// ----------------------------------------------------------------------------
 
 	ll := parseLogLevel(logLevel)
 	graylogAvailable := graylogServer != ""
 
-	// Console handler — always active
-	consoleHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	// Console handler — always active. The Windows service has no console, so it writes the console logs to a file.
+	consoleOutput, closeConsoleOutput, err := platform.LogOutput(os.Getenv("HYDRAIDE_ROOT_PATH"))
+	if err != nil {
+		fmt.Printf("failed to open the log output, logging to the standard output: %v\n", err)
+		consoleOutput, closeConsoleOutput = os.Stdout, func() {}
+	}
+	defer closeConsoleOutput()
+
+	consoleHandler := slog.NewTextHandler(consoleOutput, &slog.HandlerOptions{
 		Level: ll,
 	})
 
@@ -172,7 +259,7 @@ func main() {
 
 	if graylogAvailable {
 		// Attempt to connect to Graylog
-		gh, err := graylog.New(graylogServer, graylogServiceName, ll)
+		gh, err := graylog.New(graylogServer, graylogServiceName, ll, graylogOptions)
 		if err != nil {
 			fmt.Printf("failed to connect to Graylog: %v\n", err)
 			graylogAvailable = false
@@ -185,22 +272,26 @@ func main() {
 			// Local file fallback (only enabled if Graylog is used)
 			localHandler := fallback.LocalHandler(ll)
 
-			combinedHandler = fallback.New(
-				gh,
-				localHandler,
-				func() bool {
-					conn, err := net.DialTimeout("tcp", graylogServer, 1*time.Second)
-					if err != nil {
-						return false
-					}
-					_ = conn.Close()
-					return true
-				},
-			)
+			// the handler reconnects in the background, so the check of every log record does not block
+			combinedHandler = fallback.New(gh, localHandler, gh.Reachable)
 		}
 	}
 
-	// Final logger: console only, or console + Graylog + fallback
+	// the HTTP sinks send in the background and report the failed sends, so they use the same fallback
+	if lokiSink != nil {
+		lh := loki.New(lokiSink, ll)
+		defer func() { _ = lh.Close() }()
+		slog.Info("Loki handler initialized", slog.String("url", lokiSink.URL))
+		combinedHandler = fallback.New(lh, fallback.LocalHandler(ll), lh.Reachable)
+	}
+	if openSearchSink != nil {
+		oh := opensearch.New(openSearchSink, ll)
+		defer func() { _ = oh.Close() }()
+		slog.Info("OpenSearch handler initialized", slog.String("url", openSearchSink.URL))
+		combinedHandler = fallback.New(oh, fallback.LocalHandler(ll), oh.Reachable)
+	}
+
+	// Final logger: console only, or console + remote sink + fallback
 	if combinedHandler != nil {
 		logger := slog.New(slogmulti.New(consoleHandler, combinedHandler))
 		slog.SetDefault(logger)
@@ -213,14 +304,43 @@ func main() {
 
 	// start the new Hydra server
 	serverInterface = server.New(&server.Configuration{
-		CertificateCrtFile:    serverCrtPath,
-		CertificateKeyFile:    serverKeyPath,
-		HydraServerPort:       hydraServerPort,
-		HydraMaxMessageSize:   hydraMaxMessageSize,
-		DefaultCloseAfterIdle: defaultCloseAfterIdle,
-		DefaultWriteInterval:  defaultWriteInterval,
-		DefaultFileSize:       defaultFileSize,
-		SystemResourceLogging: systemResourceLogging,
+		CertificateCrtFile:                serverCrtPath,
+		CertificateKeyFile:                serverKeyPath,
+		CertificateReloadInterval:         tlsReloadInterval,
+		HydraServerPort:                   hydraServerPort,
+		HydraMaxMessageSize:               hydraMaxMessageSize,
+		DefaultCloseAfterIdle:             defaultCloseAfterIdle,
+		DefaultWriteInterval:              defaultWriteInterval,
+		DefaultFileSize:                   defaultFileSize,
+		DefaultFsyncPolicy:                defaultFsyncPolicy,
+		DefaultFsyncInterval:              defaultFsyncInterval,
+		ApplyDefaultsToUnregisteredSwamps: applyDefaultsToUnregistered,
+		DefaultsReloader:                  reloadDefaults,
+		SystemResourceLogging:             systemResourceLogging,
+		GrpcServerErrorLogging:            grpcServerErrorLogging,
+		RateLimit:                         rateLimit,
+		MaxTreasuresPerSwamp:              maxTreasuresPerSwamp,
+		MaxKeyLength:                      maxKeyLength,
+		MaxValueSize:                      maxValueSize,
+		FailOnCorruptedFiles:              failOnCorruptedFiles,
+		WriteBatchSize:                    writeBatchSize,
+		MaxConcurrentHydrations:           maxHydrations,
+		Tracing:                           tracingConfiguration,
+		SlowOperationThreshold:            slowOperationThreshold,
+		AccessLog:                         accessLog,
+		Metrics:                           metricsRegistry,
+		RestGateway:                       restGateway,
+		Tenancy:                           tenancyConfiguration,
+		Version:                           version,
+		TelemetrySampleInterval:           telemetryInterval,
+		MinFreeDiskPercent:                minFreeDiskPercent,
+		WarnFreeDiskPercent:               warnFreeDiskPercent,
+		Connection:                        grpcConnection,
+		Audit:                             auditConfiguration,
+		Backup:                            backupConfiguration,
+		WAL:                               walConfiguration,
+		ClusterTopologyFile:               clusterTopologyFile,
+		ClusterTopologyInterval:           clusterTopologyInterval,
 	})
 
 	if err := serverInterface.Start(); err != nil {
@@ -228,16 +348,30 @@ func main() {
 		panic(fmt.Sprintf("HydrAIDE server is not running: %v", err))
 	}
 
+	// SIGHUP or the paramchange of the Windows service reloads the default swamp settings without a restart. The
+	// errors are logged by the server, and the previous defaults stay in effect
+	platform.OnReload(func() {
+		slog.Info("reload request received, reloading the default swamp settings")
+		_, _ = serverInterface.ReloadDefaults()
+	})
+
 	go func() {
 		http.HandleFunc("/health", healthCheckHandler)
+		http.HandleFunc("/healthz", livenessHandler)
+		http.HandleFunc("/readyz", readinessHandler)
+		http.Handle("/metrics", metricsRegistry.Handler())
 		port := fmt.Sprintf(":%d", healthCheckPort)
 		if err := http.ListenAndServe(port, nil); err != nil {
 			slog.Error("http server error - health check server is not running", "error", err)
 		}
 	}()
 
-	// blocker for the main goroutine and waiting for kill signal
-	waitingForKillSignal()
+	// blocker for the main goroutine, waiting for the kill signal or the stop request of the service manager
+	slog.Info("HydrAIDE server waiting for kill signal")
+	if err := platform.WaitForStop(platform.ServiceName, stopServer); err != nil {
+		slog.Error("failed to run the HydrAIDE server as a service", "error", err)
+		stopServer()
+	}
 
 }
 
@@ -268,23 +402,50 @@ func panicHandler() {
 }
 
 func gracefulStop() {
-	// stop the microservice and exit the program
+	stopServer()
+	// exit the program if the microservice is stopped gracefully
+	os.Exit(0)
+}
+
+// stopServer stops the microservice. The Windows service is reported as stopped only after it returned.
+func stopServer() {
+	slog.Info("stop request received, stopping the server gracefully")
 	serverInterface.Stop()
 	slog.Info("hydra server stopped gracefully. Program is exiting...")
 	// waiting for logs to be written to the file
 	time.Sleep(1 * time.Second)
-	// exit the program if the microservice is stopped gracefully
-	os.Exit(0)
 }
 
-func waitingForKillSignal() {
-	slog.Info("HydrAIDE server waiting for kill signal")
-	gracefulStopSignal := make(chan os.Signal, 1)
-	signal.Notify(gracefulStopSignal, syscall.SIGKILL, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-	// waiting for graceful stop signal
-	<-gracefulStopSignal
-	slog.Info("kill signal received, stopping the server gracefully")
-	gracefulStop()
+// reloadDefaults reads the configuration again and returns its default swamp settings. The environment variables are
+// the ones loaded at the start, so the changes of the config file are applied only to the keys not set by them.
+func reloadDefaults() (defaults.Values, error) {
+	cfg, configFilePath, err := config.Load()
+	if err != nil {
+		return defaults.Values{}, err
+	}
+	slog.Info("HydrAIDE configuration reloaded", "path", configFilePath)
+	return defaults.Values{
+		CloseAfterIdleSec:         cfg.Defaults.CloseAfterIdleSec,
+		WriteIntervalSec:          cfg.Defaults.WriteIntervalSec,
+		MaxFileSizeByte:           cfg.Defaults.FileSize,
+		FsyncPolicy:               setting.FsyncPolicy(cfg.Defaults.FsyncPolicy),
+		FsyncIntervalSec:          cfg.Defaults.FsyncIntervalSec,
+		ApplyToUnregisteredSwamps: cfg.Defaults.ApplyToUnregisteredSwamps,
+	}, nil
+}
+
+// normalizeEnvPath sets the environment variable to the normalized form of the path, see platform.NormalizePath
+func normalizeEnvPath(key, path string) {
+	normalizedPath, err := platform.NormalizePath(path)
+	if err != nil {
+		panic(fmt.Sprintf("failed to normalize the %s path: %v", key, err))
+	}
+	if normalizedPath == "" {
+		return
+	}
+	if err := os.Setenv(key, normalizedPath); err != nil {
+		panic(fmt.Sprintf("failed to set %s environment variable: %v", key, err))
+	}
 }
 
 func healthCheckHandler(w http.ResponseWriter, _ *http.Request) {
@@ -296,7 +457,166 @@ func healthCheckHandler(w http.ResponseWriter, _ *http.Request) {
 	if !serverInterface.IsHydraRunning() {
 		// unhealthy
 		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 	// healthy
 	w.WriteHeader(http.StatusOK)
 }
+
+// healthResponse is the JSON body of the /healthz and /readyz endpoints. The status is "ok", "degraded" if every check
+// is healthy but some of them are degraded, or "fail"
+type healthResponse struct {
+	Status string               `json:"status"`
+	Checks []server.HealthCheck `json:"checks"`
+}
+
+// livenessHandler answers the liveness probe. The server is alive while the hydra is running.
+func livenessHandler(w http.ResponseWriter, _ *http.Request) {
+	running := serverInterface != nil && serverInterface.IsHydraRunning()
+	check := server.HealthCheck{Name: "hydra", Healthy: running}
+	if !running {
+		check.Message = "HydrAIDE server is not running"
+	}
+	writeHealthResponse(w, []server.HealthCheck{check})
+}
+
+// readinessHandler answers the readiness probe with the result of every readiness check of the server
+func readinessHandler(w http.ResponseWriter, _ *http.Request) {
+	if serverInterface == nil || !serverInterface.IsHydraRunning() {
+		writeHealthResponse(w, []server.HealthCheck{{Name: "hydra", Message: "HydrAIDE server is not running"}})
+		return
+	}
+	writeHealthResponse(w, serverInterface.CheckReadiness())
+}
+
+// writeHealthResponse writes the checks as JSON. The status code is 200 if all checks are healthy, otherwise 503.
+// A degraded server answers 200, so it keeps serving the reads and the deletes
+func writeHealthResponse(w http.ResponseWriter, checks []server.HealthCheck) {
+
+	response := healthResponse{Status: "ok", Checks: checks}
+	statusCode := http.StatusOK
+	for _, check := range checks {
+		if !check.Healthy {
+			response.Status = "fail"
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+		if check.Degraded {
+			response.Status = "degraded"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("can not write the health check response", "error", err)
+	}
+
+}
+
+// connectionConfiguration converts the connection section of the config file to the connection tuning of the server
+func connectionConfiguration(cfg config.ConnectionConfig) *server.ConnectionConfiguration {
+	return &server.ConnectionConfiguration{
+		KeepaliveTime:         time.Duration(cfg.KeepaliveTimeSec) * time.Second,
+		KeepaliveTimeout:      time.Duration(cfg.KeepaliveTimeoutSec) * time.Second,
+		MaxConnectionIdle:     time.Duration(cfg.MaxConnectionIdleSec) * time.Second,
+		KeepaliveMinTime:      time.Duration(cfg.KeepaliveMinTimeSec) * time.Second,
+		InitialWindowSize:     int32(cfg.InitialWindowSize),
+		InitialConnWindowSize: int32(cfg.InitialConnWindowSize),
+		MaxConcurrentStreams:  uint32(cfg.MaxConcurrentStreams),
+	}
+}
+
+// lokiConfiguration converts the loki section of the config file to the Loki sink configuration. The service name
+// is the service_name label of the streams, unless the labels set it
+func lokiConfiguration(cfg config.LokiConfig) *loki.Configuration {
+	labels := make(map[string]string, len(cfg.Labels)+1)
+	if cfg.ServiceName != "" {
+		labels["service_name"] = cfg.ServiceName
+	}
+	for k, v := range cfg.Labels {
+		labels[k] = v
+	}
+	return &loki.Configuration{
+		URL:      cfg.URL,
+		Labels:   labels,
+		TenantID: cfg.TenantID,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		Options:  logDeliveryOptions(cfg.LogDeliveryConfig),
+	}
+}
+
+// logDeliveryOptions converts the batching settings of a HTTP log sink to the handler options
+func logDeliveryOptions(cfg config.LogDeliveryConfig) *batch.Options {
+	return &batch.Options{
+		QueueSize:     cfg.QueueSize,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: time.Duration(cfg.FlushIntervalMs) * time.Millisecond,
+		Metrics:       metricsRegistry,
+	}
+}
+
+// rateLimitConfiguration converts the rate limit section of the config file to the limiter configuration
+func rateLimitConfiguration(cfg config.RateLimitConfig) *ratelimit.Configuration {
+	configuration := &ratelimit.Configuration{
+		Default: clientLimits(cfg.ClientLimitConfig),
+		Clients: make(map[string]ratelimit.Limits, len(cfg.Clients)),
+	}
+	for identity, limits := range cfg.Clients {
+		configuration.Clients[identity] = clientLimits(limits)
+	}
+	return configuration
+}
+
+// tenancyConfigurationFromConfig converts the tenancy section of the config file to the tenancy configuration
+func tenancyConfigurationFromConfig(cfg config.TenancyConfig) *tenancy.Configuration {
+	configuration := &tenancy.Configuration{
+		Tenants: make(map[string]tenancy.Tenant, len(cfg.Tenants)),
+	}
+	for tenantID, tenant := range cfg.Tenants {
+		configuration.Tenants[tenantID] = tenancy.Tenant{
+			Token:                tenant.Token,
+			Limits:               clientLimits(tenant.ClientLimitConfig),
+			MaxTreasuresPerSwamp: tenant.MaxTreasuresPerSwamp,
+		}
+	}
+	return configuration
+}
+
+// backupConfigurationFromConfig converts the backup section of the config file to the backup configuration. The
+// patterns are validated by the config
+func backupConfigurationFromConfig(cfg config.BackupConfig) *backup.Configuration {
+	configuration := &backup.Configuration{
+		Interval: time.Duration(cfg.IntervalSec) * time.Second,
+		S3: backup.S3Configuration{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			PartSize:  cfg.S3.PartSize,
+		},
+		Prefix:     cfg.S3.Prefix,
+		KeepLast:   cfg.KeepLast,
+		MaxAge:     time.Duration(cfg.MaxAgeSec) * time.Second,
+		TempFolder: cfg.TempFolder,
+		Metrics:    metricsRegistry,
+	}
+	for _, pattern := range cfg.Patterns {
+		if swampPattern, err := backup.ParsePattern(pattern); err == nil {
+			configuration.Patterns = append(configuration.Patterns, swampPattern)
+		}
+	}
+	return configuration
+}
+
+// clientLimits converts the rate limits of one client of the config file to the limits of the limiter
+func clientLimits(l config.ClientLimitConfig) ratelimit.Limits {
+	return ratelimit.Limits{
+		RequestsPerSecond:   l.RequestsPerSecond,
+		RequestBurst:        l.RequestBurst,
+		WriteBytesPerSecond: l.WriteBytesPerSecond,
+		WriteBytesBurst:     l.WriteBytesBurst,
+	}
+}
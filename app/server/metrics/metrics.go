@@ -0,0 +1,206 @@
+// Package metrics is a minimal metrics registry of the HydrAIDE server.
+//
+// The registry holds counters and gauges and renders them in the Prometheus text exposition format on the /metrics endpoint
+// of the health check HTTP server, so any Prometheus compatible scraper can collect them without an extra exporter.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing value
+type Counter interface {
+	// Inc increments the counter by one
+	Inc()
+	// Add increments the counter by n
+	Add(n uint64)
+	// Value returns the current value of the counter
+	Value() uint64
+}
+
+// Registry holds the metrics of the server
+type Registry interface {
+	// Counter returns the counter with the given name and label pairs, and creates it at the first call.
+	// The labels are key-value pairs, e.g. Counter("hydraide_slow_operations_total", "help", "method", "Set")
+	Counter(name string, help string, labels ...string) Counter
+	// CounterFunc registers a counter whose value is read by fn at every scrape. Used for the counters maintained by
+	// other packages. Registering the same series again replaces its function
+	CounterFunc(name string, help string, fn func() float64, labels ...string)
+	// GaugeFunc registers a gauge whose value is read by fn at every scrape. Registering the same series again
+	// replaces its function
+	GaugeFunc(name string, help string, fn func() float64, labels ...string)
+	// WriteText writes all metrics in the Prometheus text exposition format
+	WriteText(w io.Writer) error
+	// Handler returns the HTTP handler of the /metrics endpoint
+	Handler() http.Handler
+}
+
+type registry struct {
+	mu       sync.RWMutex
+	families map[string]*family
+}
+
+// family is a metric name with its help text and all of its labeled series
+type family struct {
+	help   string
+	kind   string
+	series map[string]series
+}
+
+// series is a labeled value of a family
+type series interface {
+	// text returns the current value in the text exposition format
+	text() string
+}
+
+type counter struct {
+	labels string
+	value  atomic.Uint64
+}
+
+// funcSeries is a series whose value is read by a function at every scrape
+type funcSeries struct {
+	fn func() float64
+}
+
+// New creates an empty registry
+func New() Registry {
+	return &registry{
+		families: make(map[string]*family),
+	}
+}
+
+func (r *registry) Counter(name string, help string, labels ...string) Counter {
+
+	key := formatLabels(labels)
+
+	r.mu.RLock()
+	if f, ok := r.families[name]; ok {
+		if c, ok := f.series[key].(*counter); ok {
+			r.mu.RUnlock()
+			return c
+		}
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f := r.family(name, help, "counter")
+	c, ok := f.series[key].(*counter)
+	if !ok {
+		c = &counter{labels: key}
+		f.series[key] = c
+	}
+
+	return c
+
+}
+
+func (r *registry) CounterFunc(name string, help string, fn func() float64, labels ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.family(name, help, "counter").series[formatLabels(labels)] = &funcSeries{fn: fn}
+}
+
+func (r *registry) GaugeFunc(name string, help string, fn func() float64, labels ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.family(name, help, "gauge").series[formatLabels(labels)] = &funcSeries{fn: fn}
+}
+
+// family returns the family of the name, and creates it at the first call. The caller must hold the write lock
+func (r *registry) family(name string, help string, kind string) *family {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{help: help, kind: kind, series: make(map[string]series)}
+		r.families[name] = f
+	}
+	return f
+}
+
+func (r *registry) WriteText(w io.Writer) error {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := r.families[name]
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, f.help, name, f.kind); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", name, key, f.series[key].text()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+
+}
+
+func (r *registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WriteText(w)
+	})
+}
+
+func (c *counter) Inc() {
+	c.value.Add(1)
+}
+
+func (c *counter) Add(n uint64) {
+	c.value.Add(n)
+}
+
+func (c *counter) Value() uint64 {
+	return c.value.Load()
+}
+
+func (c *counter) text() string {
+	return strconv.FormatUint(c.Value(), 10)
+}
+
+func (f *funcSeries) text() string {
+	return strconv.FormatFloat(f.fn(), 'g', -1, 64)
+}
+
+// formatLabels renders the label pairs as {key="value",...}. A missing value of the last key is rendered as empty.
+func formatLabels(labels []string) string {
+
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, (len(labels)+1)/2)
+	for i := 0; i < len(labels); i += 2 {
+		value := ""
+		if i+1 < len(labels) {
+			value = labels[i+1]
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%q", labels[i], value))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+
+}
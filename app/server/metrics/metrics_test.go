@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistry_Counter(t *testing.T) {
+
+	r := New()
+
+	r.Counter("hydraide_slow_operations_total", "Number of slow operations", "method", "Set").Inc()
+	r.Counter("hydraide_slow_operations_total", "Number of slow operations", "method", "Set").Add(2)
+	r.Counter("hydraide_slow_operations_total", "Number of slow operations", "method", "Get").Inc()
+	r.Counter("hydraide_requests_total", "Number of requests").Inc()
+
+	assert.Equal(t, uint64(3), r.Counter("hydraide_slow_operations_total", "", "method", "Set").Value(), "the same series is returned")
+
+	var buffer bytes.Buffer
+	require.NoError(t, r.WriteText(&buffer))
+	assert.Equal(t, `# HELP hydraide_requests_total Number of requests
+# TYPE hydraide_requests_total counter
+hydraide_requests_total 1
+# HELP hydraide_slow_operations_total Number of slow operations
+# TYPE hydraide_slow_operations_total counter
+hydraide_slow_operations_total{method="Get"} 1
+hydraide_slow_operations_total{method="Set"} 3
+`, buffer.String())
+
+}
+
+func TestRegistry_Handler(t *testing.T) {
+
+	r := New()
+	r.Counter("hydraide_requests_total", "Number of requests").Inc()
+
+	recorder := httptest.NewRecorder()
+	r.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, recorder.Body.String(), "hydraide_requests_total 1")
+
+}
+
+func TestRegistry_Funcs(t *testing.T) {
+
+	r := New()
+
+	depth := 3.0
+	r.GaugeFunc("hydraide_hydration_queue_depth", "Number of waiting hydrations", func() float64 { return depth }, "priority", "interactive")
+	r.CounterFunc("hydraide_hydration_wait_seconds_total", "Total wait time", func() float64 { return 1.5 })
+	depth = 4
+
+	var buffer bytes.Buffer
+	require.NoError(t, r.WriteText(&buffer))
+	assert.Equal(t, `# HELP hydraide_hydration_queue_depth Number of waiting hydrations
+# TYPE hydraide_hydration_queue_depth gauge
+hydraide_hydration_queue_depth{priority="interactive"} 4
+# HELP hydraide_hydration_wait_seconds_total Total wait time
+# TYPE hydraide_hydration_wait_seconds_total counter
+hydraide_hydration_wait_seconds_total 1.5
+`, buffer.String())
+
+}
@@ -0,0 +1,179 @@
+// Package migration keeps the migration states of the Islands of the HydrAIDE server.
+//
+// An Island is moved to an other server without downtime. While its files are copied, the Island is FROZEN: its
+// writes are refused with a retryable error, but its reads are still served. After the topology of the cluster routes
+// the Island to the new server, the Island is MOVED: every request of it is refused with the host of the new server,
+// so the clients with an old topology refresh it instead of writing to the stale copy.
+//
+// The states are saved to the islands.json of the root folder, so a restarted server does not serve a moved Island
+// again. The Islands without a saved state are ACTIVE.
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileName is the state file of the Islands in the root folder of the server
+const FileName = "islands.json"
+
+// ErrInvalidState is returned for an invalid state change, e.g. a MOVED state without the new host
+var ErrInvalidState = errors.New("invalid island state")
+
+// State is the migration state of an Island
+type State int
+
+const (
+	// StateActive is the normal state, the Island is served
+	StateActive State = iota
+	// StateFrozen refuses the writes of the Island while it is copied to the new server
+	StateFrozen
+	// StateMoved refuses every request of the Island, because it is served by the new server
+	StateMoved
+)
+
+// String returns the name of the state, used in the state file and the logs
+func (s State) String() string {
+	switch s {
+	case StateActive:
+		return "active"
+	case StateFrozen:
+		return "frozen"
+	case StateMoved:
+		return "moved"
+	default:
+		return "unknown"
+	}
+}
+
+// Island is the migration state of an Island
+type Island struct {
+	// State is the state of the Island
+	State State `json:"state"`
+	// MovedTo is the host of the new server of a MOVED Island
+	MovedTo string `json:"movedTo,omitempty"`
+}
+
+// Islands are the migration states of the Islands of the server
+type Islands interface {
+	// Get returns the state of the Island, ACTIVE if it has no saved state
+	Get(islandID uint64) Island
+	// Set sets the state of the Island and saves the states. The MOVED state requires the host of the new server
+	Set(islandID uint64, island Island) error
+	// List returns the Islands whose state is not ACTIVE, in the order of their IDs
+	List() []uint64
+}
+
+type islands struct {
+	mu     sync.RWMutex
+	path   string
+	states map[uint64]Island
+}
+
+// Open loads the states of the Islands from the root folder of the server. A missing file means every Island is
+// ACTIVE
+func Open(rootPath string) (Islands, error) {
+
+	i := &islands{
+		path:   filepath.Join(rootPath, FileName),
+		states: make(map[uint64]Island),
+	}
+
+	content, err := os.ReadFile(i.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return i, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read the island states: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &i.states); err != nil {
+		return nil, fmt.Errorf("failed to parse the island states %s: %w", i.path, err)
+	}
+
+	return i, nil
+
+}
+
+func (i *islands) Get(islandID uint64) Island {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.states[islandID]
+}
+
+func (i *islands) Set(islandID uint64, island Island) error {
+
+	switch island.State {
+	case StateActive, StateFrozen:
+		island.MovedTo = ""
+	case StateMoved:
+		if island.MovedTo == "" {
+			return fmt.Errorf("%w: the host of the new server of the island %d is required", ErrInvalidState, islandID)
+		}
+	default:
+		return fmt.Errorf("%w: unknown state %d", ErrInvalidState, island.State)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	previous, existed := i.states[islandID]
+	if island.State == StateActive {
+		delete(i.states, islandID)
+	} else {
+		i.states[islandID] = island
+	}
+
+	if err := i.save(); err != nil {
+		// the state in the memory must match the file, otherwise a restart would change the state
+		if existed {
+			i.states[islandID] = previous
+		} else {
+			delete(i.states, islandID)
+		}
+		return err
+	}
+
+	return nil
+
+}
+
+func (i *islands) List() []uint64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	ids := make([]uint64, 0, len(i.states))
+	for islandID := range i.states {
+		ids = append(ids, islandID)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		return ids[a] < ids[b]
+	})
+	return ids
+}
+
+// save writes the states atomically, so a crash does not leave a half written file. The caller must hold the lock
+func (i *islands) save() error {
+
+	content, err := json.MarshalIndent(i.states, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the island states: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(i.path), 0755); err != nil {
+		return fmt.Errorf("failed to create the folder of the island states: %w", err)
+	}
+	temporary := i.path + ".tmp"
+	if err := os.WriteFile(temporary, content, 0644); err != nil {
+		return fmt.Errorf("failed to write the island states: %w", err)
+	}
+	if err := os.Rename(temporary, i.path); err != nil {
+		return fmt.Errorf("failed to write the island states: %w", err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIslands(t *testing.T) {
+
+	t.Run("should keep the states over the restarts", func(t *testing.T) {
+
+		root := t.TempDir()
+		islands, err := Open(root)
+		require.NoError(t, err)
+		assert.Equal(t, Island{State: StateActive}, islands.Get(42))
+
+		require.NoError(t, islands.Set(42, Island{State: StateFrozen}))
+		require.NoError(t, islands.Set(7, Island{State: StateMoved, MovedTo: "hydra02:4444"}))
+		assert.Equal(t, []uint64{7, 42}, islands.List())
+
+		reopened, err := Open(root)
+		require.NoError(t, err)
+		assert.Equal(t, Island{State: StateFrozen}, reopened.Get(42))
+		assert.Equal(t, Island{State: StateMoved, MovedTo: "hydra02:4444"}, reopened.Get(7))
+
+		require.NoError(t, reopened.Set(42, Island{State: StateActive, MovedTo: "ignored"}))
+		assert.Equal(t, []uint64{7}, reopened.List())
+
+	})
+
+	t.Run("should refuse the moved state without the new host", func(t *testing.T) {
+		islands, err := Open(t.TempDir())
+		require.NoError(t, err)
+		assert.ErrorIs(t, islands.Set(1, Island{State: StateMoved}), ErrInvalidState)
+		assert.ErrorIs(t, islands.Set(1, Island{State: State(9)}), ErrInvalidState)
+		assert.Empty(t, islands.List())
+	})
+
+}
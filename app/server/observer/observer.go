@@ -1,26 +1,17 @@
 // Package observer provides utilities for graceful shutdown.
 // It ensures that the server only shuts down after all ongoing processes
 // have been completed, helping to avoid data loss.
+//
+// The resource usage of the server is sampled by the telemetry package.
 package observer
 
 import (
-	"context"
-	"fmt"
-	"github.com/shirou/gopsutil/cpu"
 	"log/slog"
-	"math"
-	"runtime"
-	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
 )
 
-var allocMemoryBefore uint64
-var allocMemoryPeak uint64
-var processorLoadPeak float64
-var goroutinePeak int
-
 // Observer interface defines methods for tracking ongoing processes.
 // It's especially useful for graceful shutdowns, ensuring that the system
 // waits for all active tasks to finish.
@@ -55,26 +46,10 @@ type process struct {
 	subprocesses []string
 }
 
-func New(ctx context.Context, systemResourceLogging bool) Observer {
-
-	o := &observer{
+func New() Observer {
+	return &observer{
 		allProcesses: make(map[string]*process),
 	}
-
-	// start system resource logging only if the systemResourceLogging is true
-	if systemResourceLogging {
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					stackTrace := debug.Stack()
-					slog.Error("caught panic while detecting memory and processor peak", "error", r, "stack", string(stackTrace))
-				}
-			}()
-			o.detectMemoryAndProcessorPeak(ctx)
-		}()
-	}
-
-	return o
 }
 
 func (o *observer) StartProcess(uid string, processName string) {
@@ -132,103 +107,3 @@ func (o *observer) WaitingForAllProcessesFinished() {
 		time.Sleep(1 * time.Second)
 	}
 }
-
-func (o *observer) detectMemoryAndProcessorPeak(ctx context.Context) {
-
-	t := time.NewTicker(10 * time.Second)
-	defer t.Stop()
-
-	for {
-		select {
-		case <-t.C:
-
-			var m runtime.MemStats
-			runtime.ReadMemStats(&m)
-
-			if m.Alloc > allocMemoryPeak {
-				allocMemoryPeak = m.Alloc
-			}
-
-			if runtime.NumGoroutine() > goroutinePeak {
-				goroutinePeak = runtime.NumGoroutine()
-			}
-
-			percent, err := cpu.Percent(5*time.Second, false)
-			if err != nil {
-				slog.Error("error while getting cpu percent", "error", err)
-				continue
-			}
-
-			if len(percent) > 0 && percent[0] > processorLoadPeak {
-				processorLoadPeak = math.Round(percent[0]*100) / 100
-			}
-
-			o.logMemoryPeak()
-
-		case <-ctx.Done():
-
-			return
-
-		}
-	}
-
-}
-
-func (o *observer) logMemoryPeak() {
-
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-
-	// calculate the difference between the memory usage before and after the garbage collector
-	var diff uint64
-	prefix := "0"
-	diff = 0
-	if m.Alloc > allocMemoryBefore {
-		diff = m.Alloc - allocMemoryBefore
-		prefix = "+"
-	} else if m.Alloc < allocMemoryBefore {
-		diff = allocMemoryBefore - m.Alloc
-		prefix = "-"
-	}
-
-	if diff < 0 {
-		diff = 0
-	}
-
-	slog.Info("system resource log",
-		slog.String("Act_Alloc", bytesToReadable(m.Alloc)),
-		slog.String("Act_Diff", fmt.Sprintf("%s%s", prefix, bytesToReadable(diff))),
-		slog.Int("Act_GoRoutines", runtime.NumGoroutine()),
-		slog.Int("Peak_Goroutine", goroutinePeak),
-		slog.Float64("Peak_ProcessorLoad", processorLoadPeak),
-		slog.String("Peak_AllocMemory", bytesToReadable(allocMemoryPeak)),
-		slog.Uint64("num_GC", uint64(m.NumGC)),
-	)
-
-	allocMemoryBefore = m.Alloc
-
-}
-
-func bytesToReadable(bytes uint64) string {
-
-	const (
-		_         = iota // ignore first value by assigning to blank identifier
-		KB uint64 = 1 << (10 * iota)
-		MB
-		GB
-		TB
-	)
-
-	switch {
-	case bytes >= TB:
-		return fmt.Sprintf("%.2fTB", float64(bytes)/float64(TB))
-	case bytes >= GB:
-		return fmt.Sprintf("%.2fGB", float64(bytes)/float64(GB))
-	case bytes >= MB:
-		return fmt.Sprintf("%.2fMB", float64(bytes)/float64(MB))
-	case bytes >= KB:
-		return fmt.Sprintf("%.2fKB", float64(bytes)/float64(KB))
-	default:
-		return fmt.Sprintf("%dB", bytes)
-	}
-}
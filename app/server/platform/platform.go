@@ -0,0 +1,187 @@
+// Package platform hides the differences of the operating systems the server runs on: the default root folder, the
+// normalization of the configured paths, and the service managers that start and stop the server.
+//
+//   - Linux: the server runs in a container or under systemd, and it is stopped by a signal like any other process.
+//   - Windows: the server can run as a Windows service. The service control manager stops it by a control request
+//     instead of a signal, and the service has no console, so its logs are written to a file.
+//   - macOS: the server can run as a launchd job for the local development. launchd stops it by SIGTERM, and the
+//     default root folder is writable without root, because the root of the macOS filesystem is read-only.
+package platform
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// ServiceName is the default name of the HydrAIDE service in the service managers
+const ServiceName = "HydrAIDE"
+
+// LogFileName is the name of the log file of the server running as a Windows service, in the logs folder of the
+// root folder
+const LogFileName = "hydraide.log"
+
+// DefaultRootPath returns the default HydrAIDE root folder of the operating system:
+//   - Linux: /hydraide, the volume of the Docker image
+//   - Windows: %ProgramData%\HydrAIDE
+//   - macOS: ~/Library/Application Support/HydrAIDE
+func DefaultRootPath() string {
+	return defaultRootPath()
+}
+
+// IsService returns true if the server is started by a service manager: the service control manager of Windows, or
+// launchd of macOS.
+func IsService() bool {
+	return isService()
+}
+
+// NormalizePath returns the absolute and cleaned form of the path on the current operating system, so the same
+// configuration works on every platform:
+//   - the surrounding whitespace and quotes are removed, e.g. of a path copied from the Windows Explorer
+//   - a leading ~ is replaced by the home folder of the user
+//   - the $VAR and ${VAR} environment variables are expanded, and the %VAR% variables on Windows
+//   - the forward slashes are converted to the separator of the operating system
+//
+// The empty path stays empty.
+func NormalizePath(path string) (string, error) {
+
+	path = strings.Trim(strings.TrimSpace(path), `"'`)
+	if path == "" {
+		return "", nil
+	}
+
+	path = os.ExpandEnv(expandVariables(path))
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("can not expand the home folder of %s: %w", path, err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	absolutePath, err := filepath.Abs(filepath.FromSlash(path))
+	if err != nil {
+		return "", fmt.Errorf("can not resolve the absolute path of %s: %w", path, err)
+	}
+
+	return absolutePath, nil
+
+}
+
+// expandPercentVariables expands the %VAR% variables of the path, the format of the Windows environment variables.
+// The unknown variables and a single % are kept as they are.
+func expandPercentVariables(path string, lookup func(key string) (string, bool)) string {
+
+	var expanded strings.Builder
+	for {
+		start := strings.IndexByte(path, '%')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(path[start+1:], '%')
+		if end < 0 {
+			break
+		}
+		end += start + 1
+
+		expanded.WriteString(path[:start])
+		if value, ok := lookup(path[start+1 : end]); ok && end > start+1 {
+			expanded.WriteString(value)
+		} else {
+			expanded.WriteString(path[start : end+1])
+		}
+		path = path[end+1:]
+	}
+	expanded.WriteString(path)
+
+	return expanded.String()
+
+}
+
+// EnvFiles returns the .env files loaded at the start of the server: the one in the working folder, and the one
+// next to the executable. The service managers start the server in their own working folder, e.g. C:\Windows\System32
+// or /, so the .env file of an installed server is found next to its executable.
+func EnvFiles() []string {
+
+	files := []string{".env"}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return files
+	}
+
+	executableEnv := filepath.Join(filepath.Dir(executable), ".env")
+	if workingFolder, err := os.Getwd(); err != nil || filepath.Join(workingFolder, ".env") != executableEnv {
+		files = append(files, executableEnv)
+	}
+
+	return files
+
+}
+
+// LogOutput returns the output of the console logs. It is the standard output, except for the Windows service, whose
+// logs are appended to the LogFileName file in the logs folder of the root folder, because the service has no
+// console. The returned function closes the output.
+func LogOutput(rootPath string) (io.Writer, func(), error) {
+
+	if !logsToFile() {
+		return os.Stdout, func() {}, nil
+	}
+
+	logFolder := filepath.Join(rootPath, "logs")
+	if err := os.MkdirAll(logFolder, 0755); err != nil {
+		return nil, nil, fmt.Errorf("can not create the log folder %s: %w", logFolder, err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(logFolder, LogFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can not open the log file: %w", err)
+	}
+
+	return file, func() { _ = file.Close() }, nil
+
+}
+
+// WaitForStop blocks until the server is asked to stop, then calls stop and returns after it. The Windows service is
+// stopped by the service control manager, and reported as stopped only after the stop returned. Every other process
+// is stopped by the SIGINT, SIGTERM or SIGQUIT signal, e.g. by Docker, systemd, launchd or Ctrl+C.
+func WaitForStop(serviceName string, stop func()) error {
+	return waitForStop(serviceName, stop)
+}
+
+// reloadHandler is the function called by the reload requests, nil until OnReload
+var reloadHandler atomic.Pointer[func()]
+
+// watchReloadOnce starts the watcher of the reload requests only once
+var watchReloadOnce sync.Once
+
+// OnReload sets the function called when the server is asked to reload its configuration: by the SIGHUP signal on
+// Linux and macOS, e.g. systemctl reload or kill -HUP, and by the paramchange control of the Windows service, e.g.
+// sc.exe control HydrAIDE paramchange. The reloads are handled one by one, a later call replaces the function.
+func OnReload(reload func()) {
+	reloadHandler.Store(&reload)
+	watchReloadOnce.Do(watchReload)
+}
+
+// handleReload calls the reload function, if it is set
+func handleReload() {
+	if reload := reloadHandler.Load(); reload != nil {
+		(*reload)()
+	}
+}
+
+// waitForSignal blocks until a stop signal arrives, then calls stop
+func waitForSignal(stop func()) {
+	stopSignal := make(chan os.Signal, 1)
+	signal.Notify(stopSignal, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	defer signal.Stop(stopSignal)
+	<-stopSignal
+	stop()
+}
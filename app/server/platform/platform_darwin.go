@@ -0,0 +1,38 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// defaultRootPath is under the Application Support folder of the user, because the root of the macOS filesystem is
+// read-only, and the local development does not need root rights
+func defaultRootPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "HydrAIDE")
+	}
+	return filepath.Join(home, "Library", "Application Support", "HydrAIDE")
+}
+
+// isService is true for the launchd jobs. launchd sets the XPC_SERVICE_NAME of its jobs to their labels, while the
+// processes started from a terminal have it unset or "0".
+func isService() bool {
+	serviceName := os.Getenv("XPC_SERVICE_NAME")
+	return serviceName != "" && serviceName != "0"
+}
+
+func expandVariables(path string) string {
+	return path
+}
+
+// logsToFile is false, because launchd writes the standard output to the StandardOutPath of the job
+func logsToFile() bool {
+	return false
+}
+
+// waitForStop waits for the SIGTERM of launchd, or the signals of the terminal
+func waitForStop(_ string, stop func()) error {
+	waitForSignal(stop)
+	return nil
+}
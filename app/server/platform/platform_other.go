@@ -0,0 +1,26 @@
+//go:build !windows && !darwin
+
+package platform
+
+// defaultRootPath is the volume of the Docker image
+func defaultRootPath() string {
+	return "/hydraide"
+}
+
+// isService is false, because systemd and the containers stop the server by a signal like any other process
+func isService() bool {
+	return false
+}
+
+func expandVariables(path string) string {
+	return path
+}
+
+func logsToFile() bool {
+	return false
+}
+
+func waitForStop(_ string, stop func()) error {
+	waitForSignal(stop)
+	return nil
+}
@@ -0,0 +1,82 @@
+package platform
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizePath(t *testing.T) {
+
+	t.Run("should keep the empty path empty", func(t *testing.T) {
+		path, err := NormalizePath("  ")
+		require.NoError(t, err)
+		assert.Equal(t, "", path)
+	})
+
+	t.Run("should expand the home folder", func(t *testing.T) {
+		home, err := os.UserHomeDir()
+		require.NoError(t, err)
+		path, err := NormalizePath("~/hydraide")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, "hydraide"), path)
+	})
+
+	t.Run("should expand the environment variables and remove the quotes", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("HYDRAIDE_TEST_FOLDER", root)
+		path, err := NormalizePath(` "${HYDRAIDE_TEST_FOLDER}/data/../certificate" `)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "certificate"), path)
+	})
+
+	t.Run("should resolve the relative paths", func(t *testing.T) {
+		workingFolder, err := os.Getwd()
+		require.NoError(t, err)
+		path, err := NormalizePath("data/hydraide")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(workingFolder, "data", "hydraide"), path)
+	})
+
+}
+
+func TestExpandPercentVariables(t *testing.T) {
+
+	lookup := func(key string) (string, bool) {
+		if key == "ProgramData" {
+			return `C:\ProgramData`, true
+		}
+		return "", false
+	}
+
+	t.Run("should expand the known variables", func(t *testing.T) {
+		assert.Equal(t, `C:\ProgramData\HydrAIDE`, expandPercentVariables(`%ProgramData%\HydrAIDE`, lookup))
+	})
+
+	t.Run("should keep the unknown variables and the single percent signs", func(t *testing.T) {
+		assert.Equal(t, `%Missing%\100%`, expandPercentVariables(`%Missing%\100%`, lookup))
+		assert.Equal(t, `%%\data`, expandPercentVariables(`%%\data`, lookup))
+	})
+
+}
+
+func TestDefaultRootPath(t *testing.T) {
+
+	t.Run("should return an absolute path", func(t *testing.T) {
+		assert.True(t, filepath.IsAbs(DefaultRootPath()))
+	})
+
+}
+
+func TestLogOutput(t *testing.T) {
+
+	t.Run("should log to the standard output outside of a Windows service", func(t *testing.T) {
+		output, closeOutput, err := LogOutput(t.TempDir())
+		require.NoError(t, err)
+		defer closeOutput()
+		assert.Equal(t, os.Stdout, output)
+	})
+
+}
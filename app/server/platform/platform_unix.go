@@ -0,0 +1,20 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchReload calls the reload function at every SIGHUP signal
+func watchReload() {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			handleReload()
+		}
+	}()
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package platform
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOnReload(t *testing.T) {
+
+	t.Run("should call the reload function at every SIGHUP", func(t *testing.T) {
+
+		var reloads atomic.Int32
+		OnReload(func() { reloads.Add(1) })
+
+		assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+		assert.Eventually(t, func() bool { return reloads.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+		assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+		assert.Eventually(t, func() bool { return reloads.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	})
+
+}
@@ -0,0 +1,83 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// defaultRootPath is under the ProgramData folder, where the services keep their data on Windows
+func defaultRootPath() string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" {
+		programData = `C:\ProgramData`
+	}
+	return filepath.Join(programData, "HydrAIDE")
+}
+
+func isService() bool {
+	service, err := svc.IsWindowsService()
+	return err == nil && service
+}
+
+// expandVariables expands the %VAR% variables, e.g. %ProgramData%\HydrAIDE
+func expandVariables(path string) string {
+	return expandPercentVariables(path, os.LookupEnv)
+}
+
+// logsToFile is true for the Windows service, because it has no console
+func logsToFile() bool {
+	return isService()
+}
+
+func waitForStop(serviceName string, stop func()) error {
+
+	if !isService() {
+		waitForSignal(stop)
+		return nil
+	}
+
+	if err := svc.Run(serviceName, &serviceHandler{stop: stop}); err != nil {
+		return fmt.Errorf("the %s Windows service failed: %w", serviceName, err)
+	}
+
+	return nil
+
+}
+
+// watchReload does nothing, because the reloads are requested by the service control manager, see serviceHandler
+func watchReload() {}
+
+// serviceHandler answers the requests of the service control manager
+type serviceHandler struct {
+	stop func()
+}
+
+// Execute reports the service as running until the service control manager asks it to stop. The stop can take long
+// while the swamps are written to the disk, so the service is reported as stopping meanwhile.
+func (h *serviceHandler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+
+	changes <- svc.Status{State: svc.StartPending}
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown | svc.AcceptParamChange}
+
+	for request := range requests {
+		switch request.Cmd {
+		case svc.Interrogate:
+			changes <- request.CurrentStatus
+		case svc.ParamChange:
+			handleReload()
+			changes <- request.CurrentStatus
+		case svc.Stop, svc.Shutdown:
+			changes <- svc.Status{State: svc.StopPending}
+			h.stop()
+			return false, 0
+		default:
+			// the other requests are not accepted
+		}
+	}
+
+	return false, 0
+
+}
@@ -0,0 +1,267 @@
+// Package ratelimit limits the requests of the clients of the HydrAIDE server, so one misbehaving client can not
+// saturate the server for everyone else.
+//
+// Every client identity has its own token buckets: one for the number of requests per second, and one for the
+// bytes written per second. The identity of the client is the value of the `hydraide-client-id` gRPC metadata,
+// if the client sends it, otherwise the IP address of the client.
+//
+// The buckets are created lazily at the first request of the client, and removed after the client was idle for
+// a while, so the memory usage of the limiter depends only on the number of active clients.
+package ratelimit
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// MetadataClientID is the gRPC metadata key of the client identity
+	MetadataClientID = "hydraide-client-id"
+	// DefaultIdleTimeout is the time after the buckets of an idle client are removed
+	DefaultIdleTimeout = 10 * time.Minute
+	// unknownIdentity is used if the client has neither client ID nor IP address
+	unknownIdentity = "unknown"
+)
+
+// Limits are the rate limits of one client identity. A zero rate means unlimited.
+type Limits struct {
+	// RequestsPerSecond is the sustained number of RPCs per second
+	RequestsPerSecond float64
+	// RequestBurst is the maximum number of RPCs in a burst. Defaults to the ceiling of RequestsPerSecond
+	RequestBurst int
+	// WriteBytesPerSecond is the sustained number of written payload bytes per second
+	WriteBytesPerSecond int64
+	// WriteBytesBurst is the maximum number of written payload bytes in a burst. Defaults to WriteBytesPerSecond
+	WriteBytesBurst int64
+}
+
+// IsUnlimited returns true if none of the limits is set
+func (l Limits) IsUnlimited() bool {
+	return l.RequestsPerSecond <= 0 && l.WriteBytesPerSecond <= 0
+}
+
+// Configuration is the configuration of the limiter
+type Configuration struct {
+	// Default is the limit of every client without its own limits
+	Default Limits
+	// Clients are the limits of specific client identities (client IDs or IP addresses)
+	Clients map[string]Limits
+	// IdleTimeout is the time after the buckets of an idle client are removed. Zero means DefaultIdleTimeout
+	IdleTimeout time.Duration
+}
+
+// Decision is the result of a limit check
+type Decision struct {
+	// Allowed is true if the request can be executed
+	Allowed bool
+	// RetryAfter is the time the client should wait before retrying a rejected request
+	RetryAfter time.Duration
+	// Reason is the human-readable reason of the rejection. Empty if the request is allowed
+	Reason string
+}
+
+type Limiter interface {
+	// Start starts the background cleanup of the idle clients. The cleanup stops when the context is done
+	Start(ctx context.Context)
+	// Allow checks and consumes the limits of the client for one request with the given number of written bytes.
+	// The writeBytes is 0 for read requests.
+	Allow(identity string, writeBytes int) Decision
+	// AllowBytes checks and consumes only the write bytes limit of the client, for the next message of a client
+	// stream whose request was already allowed, e.g. a chunk of a large value
+	AllowBytes(identity string, writeBytes int) Decision
+}
+
+type limiter struct {
+	mu            sync.Mutex
+	configuration *Configuration
+	clients       map[string]*clientBuckets
+	now           func() time.Time
+}
+
+type clientBuckets struct {
+	limits   Limits
+	requests bucket
+	bytes    bucket
+	lastSeen time.Time
+}
+
+// bucket is a token bucket, refilled continuously at a fixed rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New creates a new limiter with the given configuration
+func New(configuration *Configuration) Limiter {
+	if configuration.IdleTimeout <= 0 {
+		configuration.IdleTimeout = DefaultIdleTimeout
+	}
+	return &limiter{
+		configuration: configuration,
+		clients:       make(map[string]*clientBuckets),
+		now:           time.Now,
+	}
+}
+
+// Start starts the background cleanup of the idle clients
+func (l *limiter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(l.configuration.IdleTimeout)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.removeIdleClients()
+			}
+		}
+	}()
+}
+
+// Allow checks and consumes the limits of the client
+func (l *limiter) Allow(identity string, writeBytes int) Decision {
+	return l.allow(identity, 1, writeBytes)
+}
+
+// AllowBytes checks and consumes the write bytes limit of the client
+func (l *limiter) AllowBytes(identity string, writeBytes int) Decision {
+	return l.allow(identity, 0, writeBytes)
+}
+
+// allow checks and consumes the given number of requests and written bytes of the client
+func (l *limiter) allow(identity string, requests int, writeBytes int) Decision {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	client, ok := l.clients[identity]
+	if !ok {
+		limits, exists := l.configuration.Clients[identity]
+		if !exists {
+			limits = l.configuration.Default
+		}
+		client = &clientBuckets{
+			limits:   limits,
+			requests: bucket{tokens: float64(requestBurst(limits)), last: now},
+			bytes:    bucket{tokens: float64(bytesBurst(limits)), last: now},
+		}
+		l.clients[identity] = client
+	}
+	client.lastSeen = now
+
+	if client.limits.IsUnlimited() {
+		return Decision{Allowed: true}
+	}
+
+	// check both buckets first, so a rejected request does not consume any of them
+	var requestWait time.Duration
+	if requests > 0 {
+		requestWait = client.requests.wait(now, client.limits.RequestsPerSecond, float64(requestBurst(client.limits)), float64(requests))
+	}
+	var bytesWait time.Duration
+	if writeBytes > 0 {
+		bytesWait = client.bytes.wait(now, float64(client.limits.WriteBytesPerSecond), float64(bytesBurst(client.limits)), float64(writeBytes))
+	}
+
+	if requestWait > 0 {
+		return Decision{RetryAfter: requestWait, Reason: "request rate limit exceeded"}
+	}
+	if bytesWait > 0 {
+		return Decision{RetryAfter: bytesWait, Reason: "write bytes rate limit exceeded"}
+	}
+
+	if requests > 0 {
+		client.requests.take(client.limits.RequestsPerSecond, float64(requests))
+	}
+	if writeBytes > 0 {
+		client.bytes.take(float64(client.limits.WriteBytesPerSecond), math.Min(float64(writeBytes), float64(bytesBurst(client.limits))))
+	}
+
+	return Decision{Allowed: true}
+
+}
+
+// removeIdleClients removes the buckets of the clients that were idle longer than the idle timeout
+func (l *limiter) removeIdleClients() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for identity, client := range l.clients {
+		if now.Sub(client.lastSeen) > l.configuration.IdleTimeout {
+			delete(l.clients, identity)
+		}
+	}
+}
+
+// wait refills the bucket and returns the time needed until n tokens are available. Zero means the tokens are
+// available now. A request bigger than the burst is allowed when the bucket is full, so it is never rejected forever.
+func (b *bucket) wait(now time.Time, rate float64, burst float64, n float64) time.Duration {
+
+	if rate <= 0 {
+		return 0
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*rate)
+		b.last = now
+	}
+
+	n = math.Min(n, burst)
+	if b.tokens >= n {
+		return 0
+	}
+
+	return time.Duration((n - b.tokens) / rate * float64(time.Second))
+
+}
+
+// take consumes n tokens from the bucket. The wait function must be called before to refill the bucket
+func (b *bucket) take(rate float64, n float64) {
+	if rate <= 0 {
+		return
+	}
+	b.tokens -= n
+}
+
+func requestBurst(limits Limits) int {
+	if limits.RequestBurst > 0 {
+		return limits.RequestBurst
+	}
+	return int(math.Max(1, math.Ceil(limits.RequestsPerSecond)))
+}
+
+func bytesBurst(limits Limits) int64 {
+	if limits.WriteBytesBurst > 0 {
+		return limits.WriteBytesBurst
+	}
+	return limits.WriteBytesPerSecond
+}
+
+// ClientIdentity returns the identity of the client of the request: the value of the `hydraide-client-id` metadata
+// if it is set, otherwise the IP address of the client
+func ClientIdentity(ctx context.Context) string {
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataClientID); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if addr, ok := p.Addr.(*net.TCPAddr); ok {
+			return addr.IP.String()
+		}
+	}
+
+	return unknownIdentity
+
+}
@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(configuration *Configuration) (*limiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(configuration).(*limiter)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_Requests(t *testing.T) {
+
+	l, now := newTestLimiter(&Configuration{
+		Default: Limits{RequestsPerSecond: 2, RequestBurst: 2},
+	})
+
+	assert.True(t, l.Allow("client-1", 0).Allowed)
+	assert.True(t, l.Allow("client-1", 0).Allowed)
+
+	decision := l.Allow("client-1", 0)
+	assert.False(t, decision.Allowed, "the burst is consumed")
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+	assert.NotEmpty(t, decision.Reason)
+
+	// the other clients have their own buckets
+	assert.True(t, l.Allow("client-2", 0).Allowed)
+
+	*now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow("client-1", 0).Allowed, "one token is refilled")
+	assert.False(t, l.Allow("client-1", 0).Allowed)
+
+}
+
+func TestLimiter_WriteBytes(t *testing.T) {
+
+	l, now := newTestLimiter(&Configuration{
+		Default: Limits{WriteBytesPerSecond: 1000},
+	})
+
+	assert.True(t, l.Allow("client-1", 600).Allowed)
+
+	decision := l.Allow("client-1", 600)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 200*time.Millisecond, decision.RetryAfter)
+
+	// reads are not limited by the bytes bucket
+	assert.True(t, l.Allow("client-1", 0).Allowed)
+
+	// a write bigger than the burst is allowed with a full bucket
+	*now = now.Add(time.Second)
+	assert.True(t, l.Allow("client-1", 5000).Allowed)
+	assert.False(t, l.Allow("client-1", 1).Allowed)
+
+}
+
+func TestLimiter_AllowBytes(t *testing.T) {
+
+	l, now := newTestLimiter(&Configuration{
+		Default: Limits{RequestsPerSecond: 1, WriteBytesPerSecond: 1000},
+	})
+
+	// the chunks of an allowed stream need no request token, so they are allowed after the burst of the requests
+	assert.True(t, l.Allow("client-1", 0).Allowed)
+	assert.False(t, l.Allow("client-1", 0).Allowed)
+	assert.True(t, l.AllowBytes("client-1", 600).Allowed)
+
+	decision := l.AllowBytes("client-1", 600)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 200*time.Millisecond, decision.RetryAfter)
+
+	*now = now.Add(200 * time.Millisecond)
+	assert.True(t, l.AllowBytes("client-1", 600).Allowed)
+
+}
+
+func TestLimiter_ClientOverrides(t *testing.T) {
+
+	l, _ := newTestLimiter(&Configuration{
+		Default: Limits{RequestsPerSecond: 1},
+		Clients: map[string]Limits{
+			"importer": {RequestsPerSecond: 3},
+			"admin":    {},
+		},
+	})
+
+	assert.True(t, l.Allow("someone", 0).Allowed)
+	assert.False(t, l.Allow("someone", 0).Allowed)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.Allow("importer", 0).Allowed)
+	}
+	assert.False(t, l.Allow("importer", 0).Allowed)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow("admin", 1<<20).Allowed, "unlimited client")
+	}
+
+}
+
+func TestLimiter_RemoveIdleClients(t *testing.T) {
+
+	l, now := newTestLimiter(&Configuration{
+		Default:     Limits{RequestsPerSecond: 1},
+		IdleTimeout: time.Minute,
+	})
+
+	l.Allow("client-1", 0)
+	*now = now.Add(30 * time.Second)
+	l.Allow("client-2", 0)
+
+	*now = now.Add(45 * time.Second)
+	l.removeIdleClients()
+
+	assert.NotContains(t, l.clients, "client-1")
+	assert.Contains(t, l.clients, "client-2")
+
+}
+
+func TestClientIdentity(t *testing.T) {
+
+	assert.Equal(t, unknownIdentity, ClientIdentity(context.Background()))
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	assert.Equal(t, "10.0.0.1", ClientIdentity(ctx))
+
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataClientID, "importer"))
+	assert.Equal(t, "importer", ClientIdentity(ctx))
+
+}
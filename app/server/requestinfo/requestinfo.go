@@ -0,0 +1,127 @@
+// Package requestinfo extracts the swamp names, island IDs and the number of keys of the requests of the HydrAIDE
+// server, for the tracing, the operation logs and the audit log.
+//
+// The request messages of HydrAIDE share the same field names, so the fields are collected by name from the message
+// and from its repeated swamp messages, instead of handling every request type one by one:
+//   - SwampName: the name of the swamp
+//   - SwampPattern: the swamp pattern of the registrations, collected as a swamp name
+//   - IslandID: the island of the swamp
+//   - Key, Keys, KeyValue, KeyValues: the keys of the request
+package requestinfo
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// MaxSwampNames is the maximum number of swamp names collected from a request, so a huge batch doesn't blow up the
+// spans and the log entries. The SwampCount is always the full number of swamps
+const MaxSwampNames = 32
+
+// MaxKeys is the maximum number of keys collected from a request. The KeyCount is always the full number of keys
+const MaxKeys = 1000
+
+// Summary is the summary of a request message
+type Summary struct {
+	// SwampNames are the names of the swamps of the request, at most MaxSwampNames
+	SwampNames []string
+	// SwampCount is the number of swamps of the request
+	SwampCount int
+	// IslandIDs are the unique island IDs of the request, in the order of their first occurrence
+	IslandIDs []uint64
+	// Keys are the keys of the request, at most MaxKeys
+	Keys []string
+	// KeyCount is the number of keys of the request
+	KeyCount int
+	// Size is the size of the request message in bytes
+	Size int
+}
+
+// Summarize returns the summary of the request message
+func Summarize(message proto.Message) Summary {
+
+	c := &collector{islands: make(map[uint64]struct{})}
+	c.collect(message.ProtoReflect(), 0)
+	c.summary.Size = proto.Size(message)
+
+	return c.summary
+
+}
+
+// SplitFullMethod splits the /package.Service/Method form of the full method name to the service and method name
+func SplitFullMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
+
+type collector struct {
+	summary Summary
+	islands map[uint64]struct{}
+}
+
+// collect walks the fields of the message. The nested messages are walked only one level deep, because the swamps
+// of the batch requests are always direct children of the request, and the deeper messages are the treasures.
+func (c *collector) collect(message protoreflect.Message, depth int) {
+
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+
+		switch {
+		case (field.Name() == "SwampName" || field.Name() == "SwampPattern") && field.Kind() == protoreflect.StringKind && !field.IsList():
+			c.summary.SwampCount++
+			if len(c.summary.SwampNames) < MaxSwampNames {
+				c.summary.SwampNames = append(c.summary.SwampNames, value.String())
+			}
+		case field.Name() == "IslandID" && field.Kind() == protoreflect.Uint64Kind && !field.IsList():
+			islandID := value.Uint()
+			if _, ok := c.islands[islandID]; !ok {
+				c.islands[islandID] = struct{}{}
+				c.summary.IslandIDs = append(c.summary.IslandIDs, islandID)
+			}
+		case field.Name() == "Key" && field.Kind() == protoreflect.StringKind && !field.IsList():
+			c.addKey(value.String())
+		case field.Name() == "Keys" && field.Kind() == protoreflect.StringKind && field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				c.addKey(list.Get(i).String())
+			}
+		case field.Name() == "KeyValue" && field.Kind() == protoreflect.MessageKind && !field.IsList():
+			c.addPairKey(value.Message())
+		case field.Name() == "KeyValues" && field.Kind() == protoreflect.MessageKind && field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				c.addPairKey(list.Get(i).Message())
+			}
+		case field.Kind() == protoreflect.MessageKind && field.IsList() && depth == 0:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				c.collect(list.Get(i).Message(), depth+1)
+			}
+		}
+
+		return true
+
+	})
+
+}
+
+// addPairKey adds the Key field of the key-value pair
+func (c *collector) addPairKey(pair protoreflect.Message) {
+	keyField := pair.Descriptor().Fields().ByName("Key")
+	if keyField == nil || keyField.Kind() != protoreflect.StringKind {
+		c.summary.KeyCount++
+		return
+	}
+	c.addKey(pair.Get(keyField).String())
+}
+
+// addKey counts the key, and collects it until MaxKeys
+func (c *collector) addKey(key string) {
+	c.summary.KeyCount++
+	if len(c.summary.Keys) < MaxKeys {
+		c.summary.Keys = append(c.summary.Keys, key)
+	}
+}
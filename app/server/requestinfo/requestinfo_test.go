@@ -0,0 +1,66 @@
+package requestinfo
+
+import (
+	"fmt"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+
+	t.Run("batch request", func(t *testing.T) {
+		summary := Summarize(&hydrapb.DeleteRequest{Swamps: []*hydrapb.DeleteRequest_SwampKeys{
+			{IslandID: 12, SwampName: "users/profiles/alex", Keys: []string{"a", "b"}},
+			{IslandID: 12, SwampName: "users/profiles/peter", Keys: []string{"c"}},
+		}})
+		assert.Equal(t, []string{"users/profiles/alex", "users/profiles/peter"}, summary.SwampNames)
+		assert.Equal(t, 2, summary.SwampCount)
+		assert.Equal(t, []uint64{12}, summary.IslandIDs)
+		assert.Equal(t, 3, summary.KeyCount)
+		assert.Equal(t, []string{"a", "b", "c"}, summary.Keys)
+		assert.Greater(t, summary.Size, 0)
+	})
+
+	t.Run("the keys of the key-value pairs", func(t *testing.T) {
+		summary := Summarize(&hydrapb.SetRequest{Swamps: []*hydrapb.SwampRequest{
+			{IslandID: 3, SwampName: "users/profiles/alex", KeyValues: []*hydrapb.KeyValuePair{{Key: "name"}, {Key: "email"}}},
+		}})
+		assert.Equal(t, []string{"name", "email"}, summary.Keys)
+		assert.Equal(t, 2, summary.KeyCount)
+	})
+
+	t.Run("the swamp pattern of a registration", func(t *testing.T) {
+		summary := Summarize(&hydrapb.RegisterSwampRequest{SwampPattern: "users/*/*", CloseAfterIdle: 10})
+		assert.Equal(t, []string{"users/*/*"}, summary.SwampNames)
+		assert.Equal(t, 1, summary.SwampCount)
+	})
+
+	t.Run("the keys are limited", func(t *testing.T) {
+		request := &hydrapb.DeleteRequest{Swamps: []*hydrapb.DeleteRequest_SwampKeys{{IslandID: 1, SwampName: "a/b/c"}}}
+		for i := 0; i < MaxKeys+10; i++ {
+			request.Swamps[0].Keys = append(request.Swamps[0].Keys, fmt.Sprintf("key-%d", i))
+		}
+		summary := Summarize(request)
+		assert.Len(t, summary.Keys, MaxKeys)
+		assert.Equal(t, MaxKeys+10, summary.KeyCount)
+	})
+
+	t.Run("the swamp names are limited", func(t *testing.T) {
+		request := &hydrapb.CountRequest{}
+		for i := 0; i < MaxSwampNames+10; i++ {
+			request.Swamps = append(request.Swamps, &hydrapb.CountRequest_SwampIdentifier{IslandID: uint64(i + 1), SwampName: fmt.Sprintf("a/b/%d", i)})
+		}
+		summary := Summarize(request)
+		assert.Len(t, summary.SwampNames, MaxSwampNames)
+		assert.Equal(t, MaxSwampNames+10, summary.SwampCount)
+		assert.Len(t, summary.IslandIDs, MaxSwampNames+10)
+	})
+
+}
+
+func TestSplitFullMethod(t *testing.T) {
+	service, method := SplitFullMethod(hydrapb.HydraideService_Get_FullMethodName)
+	assert.Equal(t, "hydraidepbgo.HydraideService", service)
+	assert.Equal(t, "Get", method)
+}
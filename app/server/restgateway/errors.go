@@ -0,0 +1,88 @@
+package restgateway
+
+import (
+	"github.com/hydraide/hydraide/app/server/gateway"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrorBody is the JSON body of the failed requests
+type ErrorBody struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes what went wrong. Reason is the same machine-readable reason the gRPC clients get
+// (e.g. SWAMP_NOT_FOUND), the message is human-readable and may change at any time
+type ErrorDetail struct {
+	Code    string `json:"code"` // the gRPC status code, e.g. NotFound
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message"`
+}
+
+// httpStatusByReason maps the reasons to HTTP status codes where the gRPC code is not specific enough,
+// e.g. a missing swamp is a FailedPrecondition in gRPC, but a 404 for a REST client
+var httpStatusByReason = map[string]int{
+	hydrapb.ErrorReason_SWAMP_NOT_FOUND.String():   http.StatusNotFound,
+	hydrapb.ErrorReason_KEY_NOT_FOUND.String():     http.StatusNotFound,
+	hydrapb.ErrorReason_KEY_EXISTS.String():        http.StatusConflict,
+	hydrapb.ErrorReason_CONDITION_NOT_MET.String(): http.StatusPreconditionFailed,
+	hydrapb.ErrorReason_QUOTA_EXCEEDED.String():    http.StatusTooManyRequests,
+}
+
+var httpStatusByCode = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.Canceled:           http.StatusRequestTimeout,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.OutOfRange:         http.StatusBadRequest,
+}
+
+// writeGRPCError converts the gRPC error of the service to an HTTP error response. The reason and the retry info
+// of the status details are kept, so a rate limited client gets a Retry-After header.
+func writeGRPCError(w http.ResponseWriter, err error) {
+
+	st := status.Convert(err)
+
+	reason := ""
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == gateway.ErrorDomain {
+				reason = d.GetReason()
+			}
+		case *errdetails.RetryInfo:
+			if delay := d.GetRetryDelay().AsDuration(); delay > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+				w.Header().Set("Retry-After-Ms", strconv.FormatInt(int64(math.Ceil(float64(delay)/float64(time.Millisecond))), 10))
+			}
+		}
+	}
+
+	httpStatus, ok := httpStatusByReason[reason]
+	if !ok {
+		httpStatus, ok = httpStatusByCode[st.Code()]
+		if !ok {
+			httpStatus = http.StatusInternalServerError
+		}
+	}
+
+	writeError(w, httpStatus, st.Code(), reason, st.Message())
+
+}
+
+func writeError(w http.ResponseWriter, httpStatus int, code codes.Code, reason string, message string) {
+	writeJSON(w, httpStatus, &ErrorBody{Error: ErrorDetail{Code: code.String(), Reason: reason, Message: message}})
+}
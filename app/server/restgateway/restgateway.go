@@ -0,0 +1,469 @@
+// Package restgateway is an optional HTTP/JSON gateway in front of the gRPC API of the HydrAIDE server.
+//
+// The gateway lets scripts, curl and low-code tools read and write treasures without generating gRPC stubs.
+// Every HTTP request is translated to the same RPC the SDKs call, and it runs through the same interceptors
+// (tracing, rate limits, slow operation log), so the REST clients are limited and observed like any other client.
+//
+// Endpoints, where {swamp} is the sanctuary/realm/swamp path of the swamp:
+//
+//	GET    /v1/swamps/{swamp}/count                 the number of treasures in the swamp
+//	GET    /v1/swamps/{swamp}/exists[?key=...]      whether the swamp (or the key in the swamp) exists
+//	GET    /v1/swamps/{swamp}/treasures?key=a&key=b reads the treasures of the keys
+//	GET    /v1/swamps/{swamp}/treasures/{key}       reads one treasure
+//	PUT    /v1/swamps/{swamp}/treasures/{key}       creates or overwrites one treasure
+//	POST   /v1/swamps/{swamp}/treasures             creates or overwrites many treasures
+//	DELETE /v1/swamps/{swamp}/treasures/{key}       deletes one treasure
+//
+// 🔐 Every request must send one of the configured tokens in the `Authorization: Bearer <token>` header. The name
+// of the token is the client identity of the request, so the rate limits of the clients can be set per token.
+//
+// 🏝️ The island of the swamp is calculated from its name with the same hash as the SDKs, so the number of all
+// islands must be the same as in the SDK clients. The `island` query parameter overrides the calculated island.
+package restgateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPort is the default port of the REST gateway
+	DefaultPort = 4446
+	// DefaultAllIslands is the number of all islands used by the SDK examples
+	DefaultAllIslands = 1000
+
+	swampPattern = "/v1/swamps/{sanctuary}/{realm}/{swamp}"
+)
+
+// Configuration is the configuration of the REST gateway
+type Configuration struct {
+	// Port is the port of the HTTPS listener of the gateway
+	Port int
+	// Tokens are the accepted bearer tokens by client name. The client name is the identity of the requests
+	// of the token for the rate limits, like the hydraide-client-id metadata of the gRPC clients
+	Tokens map[string]string
+	// AllIslands is the number of all islands, the same as in the SDK clients
+	AllIslands int
+	// MaxBodySize is the maximum size of a request body in bytes. Zero means unlimited
+	MaxBodySize int64
+}
+
+type restGateway struct {
+	configuration *Configuration
+	service       hydrapb.HydraideServiceServer
+	interceptor   grpc.UnaryServerInterceptor
+}
+
+// New creates the HTTP handler of the gateway. The requests are executed by the service through the interceptors
+// in the given order, the same way the gRPC server chains them.
+func New(configuration *Configuration, service hydrapb.HydraideServiceServer, interceptors ...grpc.UnaryServerInterceptor) http.Handler {
+
+	g := &restGateway{
+		configuration: configuration,
+		service:       service,
+		interceptor:   chainInterceptors(interceptors),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+swampPattern+"/count", g.count)
+	mux.HandleFunc("GET "+swampPattern+"/exists", g.exists)
+	mux.HandleFunc("GET "+swampPattern+"/treasures", g.readMany)
+	mux.HandleFunc("GET "+swampPattern+"/treasures/{key...}", g.read)
+	mux.HandleFunc("PUT "+swampPattern+"/treasures/{key...}", g.write)
+	mux.HandleFunc("POST "+swampPattern+"/treasures", g.writeMany)
+	mux.HandleFunc("DELETE "+swampPattern+"/treasures/{key...}", g.delete)
+
+	return g.authenticate(mux)
+
+}
+
+// authenticate rejects the requests without a valid bearer token, and sets the client identity of the valid ones
+func (g *restGateway) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, codes.Unauthenticated, "", "missing bearer token")
+			return
+		}
+
+		clientName := ""
+		for candidateName, candidateToken := range g.configuration.Tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(candidateToken)) == 1 {
+				clientName = candidateName
+			}
+		}
+		if clientName == "" {
+			writeError(w, http.StatusUnauthorized, codes.Unauthenticated, "", "invalid bearer token")
+			return
+		}
+
+		// the identity, the token and the address of the client are passed to the interceptors the same way as by
+		// gRPC, so the tenancy layer can authenticate the REST requests, too
+		ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs(
+			ratelimit.MetadataClientID, clientName,
+			"authorization", "Bearer "+token,
+		))
+		if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+		}
+
+		if g.configuration.MaxBodySize > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, g.configuration.MaxBodySize)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+	})
+}
+
+// invoke calls the RPC of the service through the interceptors
+func (g *restGateway) invoke(ctx context.Context, fullMethod string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	if g.interceptor == nil {
+		return handler(ctx, req)
+	}
+	return g.interceptor(ctx, req, &grpc.UnaryServerInfo{Server: g.service, FullMethod: fullMethod}, handler)
+}
+
+// swamp returns the name and the island of the swamp of the request
+func (g *restGateway) swamp(r *http.Request) (string, uint64, error) {
+
+	swampName := name.New().
+		Sanctuary(r.PathValue("sanctuary")).
+		Realm(r.PathValue("realm")).
+		Swamp(r.PathValue("swamp"))
+
+	if island := r.URL.Query().Get("island"); island != "" {
+		islandID, err := strconv.ParseUint(island, 10, 64)
+		if err != nil || islandID == 0 {
+			return "", 0, fmt.Errorf("invalid island: %s", island)
+		}
+		return swampName.Get(), islandID, nil
+	}
+
+	return swampName.Get(), uint64(swampName.GetFolderNumber(uint16(g.configuration.AllIslands))), nil
+
+}
+
+func (g *restGateway) count(w http.ResponseWriter, r *http.Request) {
+
+	swampName, islandID, err := g.swamp(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return
+	}
+
+	resp, err := g.invoke(r.Context(), hydrapb.HydraideService_Count_FullMethodName, &hydrapb.CountRequest{
+		Swamps: []*hydrapb.CountRequest_SwampIdentifier{{IslandID: islandID, SwampName: swampName}},
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.Count(ctx, req.(*hydrapb.CountRequest))
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	count := int32(0)
+	for _, swamp := range resp.(*hydrapb.CountResponse).GetSwamps() {
+		count += swamp.GetCount()
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"swamp": swampName, "count": count})
+
+}
+
+func (g *restGateway) exists(w http.ResponseWriter, r *http.Request) {
+
+	swampName, islandID, err := g.swamp(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		resp, err := g.invoke(r.Context(), hydrapb.HydraideService_IsSwampExist_FullMethodName, &hydrapb.IsSwampExistRequest{
+			IslandID:  islandID,
+			SwampName: swampName,
+		}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return g.service.IsSwampExist(ctx, req.(*hydrapb.IsSwampExistRequest))
+		})
+		if err != nil {
+			writeGRPCError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"swamp": swampName, "exists": resp.(*hydrapb.IsSwampExistResponse).GetIsExist()})
+		return
+	}
+
+	resp, err := g.invoke(r.Context(), hydrapb.HydraideService_IsKeyExist_FullMethodName, &hydrapb.IsKeyExistRequest{
+		IslandID:  islandID,
+		SwampName: swampName,
+		Key:       key,
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.IsKeyExist(ctx, req.(*hydrapb.IsKeyExistRequest))
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"swamp": swampName, "key": key, "exists": resp.(*hydrapb.IsKeyExistResponse).GetIsExist()})
+
+}
+
+func (g *restGateway) read(w http.ResponseWriter, r *http.Request) {
+
+	treasures, ok := g.get(w, r, []string{r.PathValue("key")})
+	if !ok {
+		return
+	}
+	if len(treasures) == 0 {
+		writeError(w, http.StatusNotFound, codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND.String(), "key not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, treasures[0])
+
+}
+
+func (g *restGateway) readMany(w http.ResponseWriter, r *http.Request) {
+
+	keys := r.URL.Query()["key"]
+	if len(keys) == 0 {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), "at least one key query parameter is required")
+		return
+	}
+
+	treasures, ok := g.get(w, r, keys)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"treasures": treasures})
+
+}
+
+// get reads the existing treasures of the keys. Writes the error response and returns false if the read failed
+func (g *restGateway) get(w http.ResponseWriter, r *http.Request, keys []string) ([]*Treasure, bool) {
+
+	swampName, islandID, err := g.swamp(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return nil, false
+	}
+
+	resp, err := g.invoke(r.Context(), hydrapb.HydraideService_Get_FullMethodName, &hydrapb.GetRequest{
+		Swamps: []*hydrapb.GetSwamp{{IslandID: islandID, SwampName: swampName, Keys: keys}},
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.Get(ctx, req.(*hydrapb.GetRequest))
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return nil, false
+	}
+
+	treasures := make([]*Treasure, 0, len(keys))
+	for _, swamp := range resp.(*hydrapb.GetResponse).GetSwamps() {
+		if !swamp.GetIsExist() {
+			writeError(w, http.StatusNotFound, codes.NotFound, hydrapb.ErrorReason_SWAMP_NOT_FOUND.String(), "swamp not found")
+			return nil, false
+		}
+		for _, treasure := range swamp.GetTreasures() {
+			if treasure.GetIsExist() {
+				treasures = append(treasures, treasureFromProto(treasure))
+			}
+		}
+	}
+
+	return treasures, true
+
+}
+
+func (g *restGateway) write(w http.ResponseWriter, r *http.Request) {
+
+	treasure := &Treasure{}
+	if err := decodeBody(r, treasure); err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return
+	}
+	treasure.Key = r.PathValue("key")
+
+	statuses, ok := g.set(w, r, []*Treasure{treasure})
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, statuses[0])
+
+}
+
+// writeManyRequest is the body of the bulk write
+type writeManyRequest struct {
+	Treasures []*Treasure `json:"treasures"`
+}
+
+func (g *restGateway) writeMany(w http.ResponseWriter, r *http.Request) {
+
+	body := &writeManyRequest{}
+	if err := decodeBody(r, body); err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return
+	}
+	if len(body.Treasures) == 0 {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), "treasures can not be empty")
+		return
+	}
+
+	statuses, ok := g.set(w, r, body.Treasures)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": statuses})
+
+}
+
+// KeyStatus is the result of the write or the delete of a key
+type KeyStatus struct {
+	Key    string `json:"key"`
+	Status string `json:"status"` // NEW, UPDATED, DELETED, NOTHING_CHANGED or NOT_FOUND
+}
+
+// set creates or overwrites the treasures. Writes the error response and returns false if the write failed
+func (g *restGateway) set(w http.ResponseWriter, r *http.Request, treasures []*Treasure) ([]*KeyStatus, bool) {
+
+	swampName, islandID, err := g.swamp(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return nil, false
+	}
+
+	keyValues := make([]*hydrapb.KeyValuePair, 0, len(treasures))
+	for _, treasure := range treasures {
+		kv, err := treasure.toKeyValuePair()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+			return nil, false
+		}
+		keyValues = append(keyValues, kv)
+	}
+
+	resp, err := g.invoke(r.Context(), hydrapb.HydraideService_Set_FullMethodName, &hydrapb.SetRequest{
+		Swamps: []*hydrapb.SwampRequest{{
+			IslandID:         islandID,
+			SwampName:        swampName,
+			KeyValues:        keyValues,
+			CreateIfNotExist: true,
+			Overwrite:        true,
+		}},
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.Set(ctx, req.(*hydrapb.SetRequest))
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return nil, false
+	}
+
+	var statuses []*KeyStatus
+	for _, swamp := range resp.(*hydrapb.SetResponse).GetSwamps() {
+		for _, keyStatus := range swamp.GetKeysAndStatuses() {
+			statuses = append(statuses, &KeyStatus{Key: keyStatus.GetKey(), Status: keyStatus.GetStatus().String()})
+		}
+	}
+	if len(statuses) == 0 {
+		writeError(w, http.StatusInternalServerError, codes.Internal, hydrapb.ErrorReason_INTERNAL.String(), "the write returned no status")
+		return nil, false
+	}
+
+	return statuses, true
+
+}
+
+func (g *restGateway) delete(w http.ResponseWriter, r *http.Request) {
+
+	swampName, islandID, err := g.swamp(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, hydrapb.ErrorReason_INVALID_ARGUMENT.String(), err.Error())
+		return
+	}
+	key := r.PathValue("key")
+
+	resp, err := g.invoke(r.Context(), hydrapb.HydraideService_Delete_FullMethodName, &hydrapb.DeleteRequest{
+		Swamps: []*hydrapb.DeleteRequest_SwampKeys{{IslandID: islandID, SwampName: swampName, Keys: []string{key}}},
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.service.Delete(ctx, req.(*hydrapb.DeleteRequest))
+	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	for _, swampResponse := range resp.(*hydrapb.DeleteResponse).GetResponses() {
+		if swampResponse.ErrorCode != nil {
+			writeError(w, http.StatusNotFound, codes.NotFound, hydrapb.ErrorReason_SWAMP_NOT_FOUND.String(), "swamp not found")
+			return
+		}
+		for _, keyStatus := range swampResponse.GetKeyStatuses() {
+			if keyStatus.GetStatus() == hydrapb.Status_NOT_FOUND {
+				writeError(w, http.StatusNotFound, codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND.String(), "key not found")
+				return
+			}
+			writeJSON(w, http.StatusOK, &KeyStatus{Key: keyStatus.GetKey(), Status: keyStatus.GetStatus().String()})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, codes.NotFound, hydrapb.ErrorReason_KEY_NOT_FOUND.String(), "key not found")
+
+}
+
+// decodeBody decodes the JSON body of the request. The numbers are kept as json.Number, so the 64 bit integers
+// don't lose precision
+func decodeBody(r *http.Request, target interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return fmt.Errorf("the request body is larger than %d bytes", maxBytesError.Limit)
+		}
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Debug("can not write the REST gateway response", "error", err)
+	}
+}
+
+// chainInterceptors chains the interceptors into one, the first interceptor is the outermost one
+func chainInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return interceptors[0](ctx, req, info, next)
+	}
+}
@@ -0,0 +1,298 @@
+package restgateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeService stores the treasures of one swamp in memory and records the island of the last request
+type fakeService struct {
+	hydrapb.UnimplementedHydraideServiceServer
+	treasures  map[string]*hydrapb.KeyValuePair
+	lastIsland uint64
+}
+
+func (f *fakeService) Set(_ context.Context, in *hydrapb.SetRequest) (*hydrapb.SetResponse, error) {
+	swamp := in.GetSwamps()[0]
+	f.lastIsland = swamp.GetIslandID()
+	response := &hydrapb.SwampResponse{SwampName: swamp.GetSwampName()}
+	for _, kv := range swamp.GetKeyValues() {
+		st := hydrapb.Status_NEW
+		if _, ok := f.treasures[kv.GetKey()]; ok {
+			st = hydrapb.Status_UPDATED
+		}
+		f.treasures[kv.GetKey()] = kv
+		response.KeysAndStatuses = append(response.KeysAndStatuses, &hydrapb.KeyStatusPair{Key: kv.GetKey(), Status: st})
+	}
+	return &hydrapb.SetResponse{Swamps: []*hydrapb.SwampResponse{response}}, nil
+}
+
+func (f *fakeService) Get(_ context.Context, in *hydrapb.GetRequest) (*hydrapb.GetResponse, error) {
+	swamp := in.GetSwamps()[0]
+	f.lastIsland = swamp.GetIslandID()
+	if len(f.treasures) == 0 {
+		return nil, reasonError(codes.FailedPrecondition, hydrapb.ErrorReason_SWAMP_NOT_FOUND, "Swamp does not exist")
+	}
+	response := &hydrapb.GetSwampResponse{SwampName: swamp.GetSwampName(), IsExist: true}
+	for _, key := range swamp.GetKeys() {
+		kv, ok := f.treasures[key]
+		if !ok {
+			response.Treasures = append(response.Treasures, &hydrapb.Treasure{Key: key})
+			continue
+		}
+		response.Treasures = append(response.Treasures, &hydrapb.Treasure{
+			Key:        key,
+			IsExist:    true,
+			Uint8Val:   kv.Uint8Val,
+			Int64Val:   kv.Int64Val,
+			Float64Val: kv.Float64Val,
+			StringVal:  kv.StringVal,
+			BoolVal:    kv.BoolVal,
+			BytesVal:   kv.BytesVal,
+			CreatedBy:  kv.CreatedBy,
+			ExpiredAt:  kv.ExpiredAt,
+		})
+	}
+	return &hydrapb.GetResponse{Swamps: []*hydrapb.GetSwampResponse{response}}, nil
+}
+
+func (f *fakeService) Delete(_ context.Context, in *hydrapb.DeleteRequest) (*hydrapb.DeleteResponse, error) {
+	swamp := in.GetSwamps()[0]
+	response := &hydrapb.DeleteResponse_SwampDeleteResponse{SwampName: swamp.GetSwampName()}
+	for _, key := range swamp.GetKeys() {
+		st := hydrapb.Status_NOT_FOUND
+		if _, ok := f.treasures[key]; ok {
+			st = hydrapb.Status_DELETED
+			delete(f.treasures, key)
+		}
+		response.KeyStatuses = append(response.KeyStatuses, &hydrapb.KeyStatusPair{Key: key, Status: st})
+	}
+	return &hydrapb.DeleteResponse{Responses: []*hydrapb.DeleteResponse_SwampDeleteResponse{response}}, nil
+}
+
+func (f *fakeService) Count(_ context.Context, in *hydrapb.CountRequest) (*hydrapb.CountResponse, error) {
+	return &hydrapb.CountResponse{Swamps: []*hydrapb.CountSwamp{{
+		SwampName: in.GetSwamps()[0].GetSwampName(),
+		IsExist:   true,
+		Count:     int32(len(f.treasures)),
+	}}}, nil
+}
+
+func (f *fakeService) IsSwampExist(_ context.Context, _ *hydrapb.IsSwampExistRequest) (*hydrapb.IsSwampExistResponse, error) {
+	return &hydrapb.IsSwampExistResponse{IsExist: len(f.treasures) > 0}, nil
+}
+
+func (f *fakeService) IsKeyExist(_ context.Context, in *hydrapb.IsKeyExistRequest) (*hydrapb.IsKeyExistResponse, error) {
+	_, ok := f.treasures[in.GetKey()]
+	return &hydrapb.IsKeyExistResponse{IsExist: ok}, nil
+}
+
+// reasonError creates the same errors with reason as the gateway of the server
+func reasonError(code codes.Code, reason hydrapb.ErrorReason_Reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{Reason: reason.String(), Domain: gateway.ErrorDomain})
+	if err != nil {
+		panic(err)
+	}
+	return st.Err()
+}
+
+func newTestGateway(interceptors ...grpc.UnaryServerInterceptor) (*fakeService, http.Handler) {
+	service := &fakeService{treasures: make(map[string]*hydrapb.KeyValuePair)}
+	handler := New(&Configuration{
+		Tokens:      map[string]string{"scripts": "s3cret"},
+		AllIslands:  DefaultAllIslands,
+		MaxBodySize: 1024,
+	}, service, interceptors...)
+	return service, handler
+}
+
+func call(t *testing.T, handler http.Handler, method string, path string, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	response := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+	return rec, response
+}
+
+func TestAuthentication(t *testing.T) {
+
+	_, handler := newTestGateway()
+
+	for _, header := range []string{"", "Bearer", "Bearer wrong", "Basic s3cret"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/swamps/users/profiles/alex/count", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+	}
+
+}
+
+func TestWriteAndRead(t *testing.T) {
+
+	service, handler := newTestGateway()
+
+	rec, response := call(t, handler, http.MethodPut, "/v1/swamps/users/profiles/alex/treasures/age",
+		`{"value": 42, "type": "uint8", "createdBy": "script"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, map[string]interface{}{"key": "age", "status": "NEW"}, response)
+	assert.Equal(t, uint32(42), service.treasures["age"].GetUint8Val())
+	assert.Equal(t, uint64(name.New().Sanctuary("users").Realm("profiles").Swamp("alex").GetFolderNumber(1000)), service.lastIsland,
+		"the island must be calculated the same way as by the SDKs")
+
+	rec, response = call(t, handler, http.MethodPost, "/v1/swamps/users/profiles/alex/treasures", `{"treasures": [
+		{"key": "name", "value": "Alex"},
+		{"key": "score", "value": 9007199254740993},
+		{"key": "ratio", "value": 0.5},
+		{"key": "active", "value": false},
+		{"key": "avatar", "value": "aGVsbG8=", "type": "bytes", "expireAt": "2030-01-01T00:00:00Z"}
+	]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Len(t, response["results"], 5)
+	assert.Equal(t, int64(9007199254740993), service.treasures["score"].GetInt64Val(), "the 64 bit integers must not lose precision")
+
+	rec, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/treasures/age", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "uint8", response["type"])
+	assert.Equal(t, float64(42), response["value"])
+	assert.Equal(t, "script", response["createdBy"])
+
+	rec, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/treasures?key=name&key=active&key=avatar&key=missing", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	treasures := response["treasures"].([]interface{})
+	require.Len(t, treasures, 3, "the missing keys are omitted")
+	assert.Equal(t, "Alex", treasures[0].(map[string]interface{})["value"])
+	assert.Equal(t, false, treasures[1].(map[string]interface{})["value"])
+	assert.Equal(t, "aGVsbG8=", treasures[2].(map[string]interface{})["value"])
+	assert.Equal(t, "2030-01-01T00:00:00Z", treasures[2].(map[string]interface{})["expireAt"])
+
+	rec, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/treasures/missing", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "KEY_NOT_FOUND", response["error"].(map[string]interface{})["reason"])
+
+	rec, _ = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/treasures/age?island=7", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, uint64(7), service.lastIsland, "the island parameter must override the calculated island")
+
+}
+
+func TestInvalidWrites(t *testing.T) {
+
+	_, handler := newTestGateway()
+
+	for _, body := range []string{
+		`{"value": 256, "type": "uint8"}`,
+		`{"value": -1, "type": "uint64"}`,
+		`{"value": "abc", "type": "int32"}`,
+		`{"value": 1.5, "type": "int64"}`,
+		`{"value": "not base64!", "type": "bytes"}`,
+		`{"value": [1, 2]}`,
+		`{"value": 1, "unknown": true}`,
+		`{"value": "` + strings.Repeat("x", 2048) + `"}`,
+		`not json`,
+	} {
+		rec, response := call(t, handler, http.MethodPut, "/v1/swamps/users/profiles/alex/treasures/key", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		assert.Equal(t, "INVALID_ARGUMENT", response["error"].(map[string]interface{})["reason"], body)
+	}
+
+}
+
+func TestCountExistsAndDelete(t *testing.T) {
+
+	_, handler := newTestGateway()
+
+	rec, response := call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/exists", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, false, response["exists"])
+
+	rec, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/treasures/name", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, "a missing swamp is a 404 for the REST clients")
+	assert.Equal(t, "FailedPrecondition", response["error"].(map[string]interface{})["code"])
+
+	call(t, handler, http.MethodPut, "/v1/swamps/users/profiles/alex/treasures/name", `{"value": "Alex"}`)
+	call(t, handler, http.MethodPut, "/v1/swamps/users/profiles/alex/treasures/tag", `{}`)
+
+	_, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/count", "")
+	assert.Equal(t, float64(2), response["count"])
+
+	_, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/exists?key=name", "")
+	assert.Equal(t, true, response["exists"])
+
+	_, response = call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/treasures/tag", "")
+	assert.Equal(t, "void", response["type"])
+	assert.NotContains(t, response, "value")
+
+	rec, response = call(t, handler, http.MethodDelete, "/v1/swamps/users/profiles/alex/treasures/name", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "DELETED", response["status"])
+
+	rec, _ = call(t, handler, http.MethodDelete, "/v1/swamps/users/profiles/alex/treasures/name", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+}
+
+func TestInterceptors(t *testing.T) {
+
+	var calls []string
+	var identity string
+	first := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		calls = append(calls, "first:"+info.FullMethod)
+		identity = ratelimit.ClientIdentity(ctx)
+		return handler(ctx, req)
+	}
+	limiter := func(_ context.Context, _ interface{}, info *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
+		calls = append(calls, "second:"+info.FullMethod)
+		return nil, gateway.QuotaExceededError("requests per second limit exceeded", 1500*time.Millisecond)
+	}
+	_, handler := newTestGateway(first, limiter)
+
+	rec, response := call(t, handler, http.MethodGet, "/v1/swamps/users/profiles/alex/count", "")
+	assert.Equal(t, []string{"first:" + hydrapb.HydraideService_Count_FullMethodName, "second:" + hydrapb.HydraideService_Count_FullMethodName}, calls)
+	assert.Equal(t, "scripts", identity, "the token name must be the client identity")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "1500", rec.Header().Get("Retry-After-Ms"))
+	assert.Equal(t, "QUOTA_EXCEEDED", response["error"].(map[string]interface{})["reason"])
+
+}
+
+func TestWriteGRPCError(t *testing.T) {
+
+	for _, testCase := range []struct {
+		err      error
+		expected int
+	}{
+		{reasonError(codes.FailedPrecondition, hydrapb.ErrorReason_CONDITION_NOT_MET, "condition not met"), http.StatusPreconditionFailed},
+		{reasonError(codes.FailedPrecondition, hydrapb.ErrorReason_SWAMP_NOT_FOUND, "swamp not found"), http.StatusNotFound},
+		{status.Error(codes.InvalidArgument, "invalid"), http.StatusBadRequest},
+		{status.Error(codes.DeadlineExceeded, "timeout"), http.StatusGatewayTimeout},
+		{status.Error(codes.Internal, "internal"), http.StatusInternalServerError},
+	} {
+		rec := httptest.NewRecorder()
+		writeGRPCError(rec, testCase.err)
+		assert.Equal(t, testCase.expected, rec.Code, testCase.err.Error())
+	}
+
+}
@@ -0,0 +1,289 @@
+package restgateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"strconv"
+	"time"
+)
+
+// The value types of the JSON treasures, the same as the types of the fields of the Go models
+const (
+	TypeInt8        = "int8"
+	TypeInt16       = "int16"
+	TypeInt32       = "int32"
+	TypeInt64       = "int64"
+	TypeUint8       = "uint8"
+	TypeUint16      = "uint16"
+	TypeUint32      = "uint32"
+	TypeUint64      = "uint64"
+	TypeFloat32     = "float32"
+	TypeFloat64     = "float64"
+	TypeString      = "string"
+	TypeBool        = "bool"
+	TypeBytes       = "bytes"       // base64 encoded string in JSON
+	TypeUint32Slice = "uint32Slice" // read only, use the Uint32Slice RPCs to modify it
+	TypeVoid        = "void"        // the treasure has a key, but no value
+)
+
+// Treasure is the JSON representation of a treasure.
+//
+// At write, the type of the value is inferred from the JSON value if it is not set: a string is a string, a boolean
+// is a bool, an integer number is an int64 and any other number is a float64. Set the type to the type of the
+// field of the Go model to share the swamp with Go services. A missing or null value stores a key without value.
+type Treasure struct {
+	Key       string      `json:"key"`
+	Type      string      `json:"type,omitempty"`
+	Value     interface{} `json:"value,omitempty"`
+	CreatedAt *time.Time  `json:"createdAt,omitempty"`
+	CreatedBy string      `json:"createdBy,omitempty"`
+	UpdatedAt *time.Time  `json:"updatedAt,omitempty"`
+	UpdatedBy string      `json:"updatedBy,omitempty"`
+	ExpireAt  *time.Time  `json:"expireAt,omitempty"`
+	Version   *uint32     `json:"version,omitempty"`
+}
+
+// toKeyValuePair converts the JSON treasure to the KeyValuePair of the Set request
+func (t *Treasure) toKeyValuePair() (*hydrapb.KeyValuePair, error) {
+
+	if t.Key == "" {
+		return nil, errors.New("the key of the treasure can not be empty")
+	}
+
+	kv := &hydrapb.KeyValuePair{
+		Key:           t.Key,
+		SchemaVersion: t.Version,
+	}
+	if t.CreatedAt != nil {
+		kv.CreatedAt = timestamppb.New(*t.CreatedAt)
+	}
+	if t.CreatedBy != "" {
+		kv.CreatedBy = &t.CreatedBy
+	}
+	if t.UpdatedAt != nil {
+		kv.UpdatedAt = timestamppb.New(*t.UpdatedAt)
+	}
+	if t.UpdatedBy != "" {
+		kv.UpdatedBy = &t.UpdatedBy
+	}
+	if t.ExpireAt != nil {
+		kv.ExpiredAt = timestamppb.New(*t.ExpireAt)
+	}
+
+	if err := t.setValue(kv); err != nil {
+		return nil, fmt.Errorf("invalid value of the key %s: %w", t.Key, err)
+	}
+
+	return kv, nil
+
+}
+
+// setValue sets the value of the treasure to the typed field of the KeyValuePair
+func (t *Treasure) setValue(kv *hydrapb.KeyValuePair) error {
+
+	valueType := t.Type
+	if t.Value == nil || valueType == TypeVoid {
+		void := true
+		kv.VoidVal = &void
+		return nil
+	}
+	if valueType == "" {
+		valueType = inferType(t.Value)
+	}
+
+	switch valueType {
+	case TypeInt8, TypeInt16, TypeInt32, TypeInt64:
+		v, err := intValue(t.Value, valueType)
+		if err != nil {
+			return err
+		}
+		switch valueType {
+		case TypeInt8:
+			v8 := int32(v)
+			kv.Int8Val = &v8
+		case TypeInt16:
+			v16 := int32(v)
+			kv.Int16Val = &v16
+		case TypeInt32:
+			v32 := int32(v)
+			kv.Int32Val = &v32
+		default:
+			kv.Int64Val = &v
+		}
+	case TypeUint8, TypeUint16, TypeUint32, TypeUint64:
+		v, err := uintValue(t.Value, valueType)
+		if err != nil {
+			return err
+		}
+		switch valueType {
+		case TypeUint8:
+			v8 := uint32(v)
+			kv.Uint8Val = &v8
+		case TypeUint16:
+			v16 := uint32(v)
+			kv.Uint16Val = &v16
+		case TypeUint32:
+			v32 := uint32(v)
+			kv.Uint32Val = &v32
+		default:
+			kv.Uint64Val = &v
+		}
+	case TypeFloat32, TypeFloat64:
+		number, ok := t.Value.(json.Number)
+		if !ok {
+			return fmt.Errorf("a %s value must be a number", valueType)
+		}
+		v, err := number.Float64()
+		if err != nil {
+			return fmt.Errorf("a %s value must be a number", valueType)
+		}
+		if valueType == TypeFloat32 {
+			if math.Abs(v) > math.MaxFloat32 {
+				return fmt.Errorf("%s is out of the range of float32", number)
+			}
+			v32 := float32(v)
+			kv.Float32Val = &v32
+		} else {
+			kv.Float64Val = &v
+		}
+	case TypeString:
+		v, ok := t.Value.(string)
+		if !ok {
+			return errors.New("a string value must be a string")
+		}
+		kv.StringVal = &v
+	case TypeBool:
+		v, ok := t.Value.(bool)
+		if !ok {
+			return errors.New("a bool value must be true or false")
+		}
+		boolVal := hydrapb.Boolean_FALSE
+		if v {
+			boolVal = hydrapb.Boolean_TRUE
+		}
+		kv.BoolVal = &boolVal
+	case TypeBytes:
+		encoded, ok := t.Value.(string)
+		if !ok {
+			return errors.New("a bytes value must be a base64 encoded string")
+		}
+		v, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return errors.New("a bytes value must be a base64 encoded string")
+		}
+		kv.BytesVal = v
+	default:
+		return fmt.Errorf("unsupported value type: %s", valueType)
+	}
+
+	return nil
+
+}
+
+// inferType returns the value type of a JSON value without explicit type
+func inferType(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return TypeString
+	case bool:
+		return TypeBool
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return TypeInt64
+		}
+		return TypeFloat64
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+var intBits = map[string]int{TypeInt8: 8, TypeInt16: 16, TypeInt32: 32, TypeInt64: 64}
+var uintBits = map[string]int{TypeUint8: 8, TypeUint16: 16, TypeUint32: 32, TypeUint64: 64}
+
+func intValue(value interface{}, valueType string) (int64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("a %s value must be an integer number", valueType)
+	}
+	v, err := strconv.ParseInt(number.String(), 10, intBits[valueType])
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid %s value", number, valueType)
+	}
+	return v, nil
+}
+
+func uintValue(value interface{}, valueType string) (uint64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("a %s value must be an integer number", valueType)
+	}
+	v, err := strconv.ParseUint(number.String(), 10, uintBits[valueType])
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid %s value", number, valueType)
+	}
+	return v, nil
+}
+
+// treasureFromProto converts the treasure of the Get response to its JSON representation
+func treasureFromProto(t *hydrapb.Treasure) *Treasure {
+
+	treasure := &Treasure{
+		Key:       t.GetKey(),
+		CreatedBy: t.GetCreatedBy(),
+		UpdatedBy: t.GetUpdatedBy(),
+		Version:   t.SchemaVersion,
+	}
+	if t.CreatedAt != nil {
+		createdAt := t.GetCreatedAt().AsTime()
+		treasure.CreatedAt = &createdAt
+	}
+	if t.UpdatedAt != nil {
+		updatedAt := t.GetUpdatedAt().AsTime()
+		treasure.UpdatedAt = &updatedAt
+	}
+	if t.ExpiredAt != nil {
+		expireAt := t.GetExpiredAt().AsTime()
+		treasure.ExpireAt = &expireAt
+	}
+
+	switch {
+	case t.Int8Val != nil:
+		treasure.Type, treasure.Value = TypeInt8, t.GetInt8Val()
+	case t.Int16Val != nil:
+		treasure.Type, treasure.Value = TypeInt16, t.GetInt16Val()
+	case t.Int32Val != nil:
+		treasure.Type, treasure.Value = TypeInt32, t.GetInt32Val()
+	case t.Int64Val != nil:
+		treasure.Type, treasure.Value = TypeInt64, t.GetInt64Val()
+	case t.Uint8Val != nil:
+		treasure.Type, treasure.Value = TypeUint8, t.GetUint8Val()
+	case t.Uint16Val != nil:
+		treasure.Type, treasure.Value = TypeUint16, t.GetUint16Val()
+	case t.Uint32Val != nil:
+		treasure.Type, treasure.Value = TypeUint32, t.GetUint32Val()
+	case t.Uint64Val != nil:
+		treasure.Type, treasure.Value = TypeUint64, t.GetUint64Val()
+	case t.Float32Val != nil:
+		treasure.Type, treasure.Value = TypeFloat32, t.GetFloat32Val()
+	case t.Float64Val != nil:
+		treasure.Type, treasure.Value = TypeFloat64, t.GetFloat64Val()
+	case t.StringVal != nil:
+		treasure.Type, treasure.Value = TypeString, t.GetStringVal()
+	case t.BoolVal != nil:
+		treasure.Type, treasure.Value = TypeBool, t.GetBoolVal() == hydrapb.Boolean_TRUE
+	case t.BytesVal != nil:
+		treasure.Type, treasure.Value = TypeBytes, base64.StdEncoding.EncodeToString(t.GetBytesVal())
+	case len(t.GetUint32Slice()) > 0:
+		treasure.Type, treasure.Value = TypeUint32Slice, t.GetUint32Slice()
+	default:
+		treasure.Type = TypeVoid
+	}
+
+	return treasure
+
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/audit"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	"github.com/hydraide/hydraide/app/server/requestinfo"
+	"github.com/hydraide/hydraide/app/server/tenancy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// AccessLogConfiguration is the configuration of the access log, which logs one record per RPC with the method, the
+// swamp, the island, the duration, the size of the messages and the status code of the result.
+//
+// The RPCs are grouped into three classes, and every class can be turned on and off: the unary reads, the unary
+// writes (the mutating RPCs of the audit log) and the streams. The failed RPCs of the enabled classes are always
+// logged, the successful ones are sampled by the SampleRatio.
+type AccessLogConfiguration struct {
+	// SampleRatio is the ratio of the logged successful RPCs between 0 and 1. Zero means only the failed RPCs are
+	// logged
+	SampleRatio float64
+	// Reads turns on the logging of the unary RPCs that do not change the data
+	Reads bool
+	// Writes turns on the logging of the unary RPCs that change the data or the settings of the swamps
+	Writes bool
+	// Streams turns on the logging of the streaming RPCs, e.g. Subscribe and SetLargeValue
+	Streams bool
+}
+
+// accessLog logs the records of the RPCs of the enabled classes
+type accessLog struct {
+	configuration AccessLogConfiguration
+	logger        *slog.Logger
+	random        func() float64
+}
+
+// newAccessLog creates the access log. Returns nil if the configuration is nil, so the log is disabled
+func newAccessLog(configuration *AccessLogConfiguration) *accessLog {
+	if configuration == nil {
+		return nil
+	}
+	return &accessLog{
+		configuration: *configuration,
+		logger:        slog.Default(),
+		random:        rand.Float64,
+	}
+}
+
+// enabled returns true if the class of the RPC is logged
+func (l *accessLog) enabled(fullMethod string, stream bool) bool {
+	if l == nil {
+		return false
+	}
+	switch {
+	case stream:
+		return l.configuration.Streams
+	case audit.IsMutating(fullMethod):
+		return l.configuration.Writes
+	default:
+		return l.configuration.Reads
+	}
+}
+
+// sampled returns true if the record of the RPC is logged. The failed RPCs are never dropped
+func (l *accessLog) sampled(err error) bool {
+	if err != nil || l.configuration.SampleRatio >= 1 {
+		return true
+	}
+	return l.random() < l.configuration.SampleRatio
+}
+
+// write logs the record of the RPC. The request is nil if the client did not send a message. Returns true if the
+// record was logged
+func (l *accessLog) write(ctx context.Context, fullMethod string, request proto.Message, bytesIn int, bytesOut int, duration time.Duration, err error) bool {
+
+	if !l.sampled(err) {
+		return false
+	}
+
+	_, method := requestinfo.SplitFullMethod(fullMethod)
+	attributes := []any{
+		"method", method,
+		"client", ratelimit.ClientIdentity(ctx),
+		"duration", duration,
+		"bytesIn", bytesIn,
+		"bytesOut", bytesOut,
+		"code", status.Code(err).String(),
+	}
+	if tenantID, ok := tenancy.TenantFromContext(ctx); ok {
+		attributes = append(attributes, "tenant", tenantID)
+	}
+	if request != nil {
+		summary := requestinfo.Summarize(request)
+		if len(summary.SwampNames) > 0 {
+			attributes = append(attributes, "swampName", summary.SwampNames[0])
+		}
+		attributes = append(attributes,
+			"swampCount", summary.SwampCount,
+			"islandIDs", summary.IslandIDs,
+			"keyCount", summary.KeyCount,
+		)
+	}
+	if err != nil {
+		attributes = append(attributes, "error", status.Convert(err).Message())
+	}
+
+	l.logger.Info("access", attributes...)
+
+	return true
+
+}
+
+// accessLogUnaryInterceptor logs the unary RPCs of the enabled classes, including the requests rejected by the later
+// interceptors, e.g. the rate limiter
+func accessLogUnaryInterceptor(l *accessLog) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if !l.enabled(info.FullMethod, false) {
+			return handler(ctx, req)
+		}
+
+		started := time.Now()
+		resp, err := handler(ctx, req)
+		duration := time.Since(started)
+
+		request, _ := req.(proto.Message)
+		bytesIn := 0
+		if request != nil {
+			bytesIn = proto.Size(request)
+		}
+		bytesOut := 0
+		if response, ok := resp.(proto.Message); ok && err == nil {
+			bytesOut = proto.Size(response)
+		}
+		l.write(ctx, info.FullMethod, request, bytesIn, bytesOut, duration, err)
+
+		return resp, err
+
+	}
+}
+
+// accessLogStreamInterceptor logs the streaming RPCs if the streams are enabled. The swamp of the record is taken
+// from the first message of the client, and the sizes are the sum of all messages of the stream
+func accessLogStreamInterceptor(l *accessLog) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if !l.enabled(info.FullMethod, true) {
+			return handler(srv, ss)
+		}
+
+		started := time.Now()
+		stream := &countingStream{ServerStream: ss}
+		err := handler(srv, stream)
+		duration := time.Since(started)
+
+		first, bytesIn, bytesOut := stream.counters()
+		l.write(ss.Context(), info.FullMethod, first, bytesIn, bytesOut, duration, err)
+
+		return err
+
+	}
+}
+
+// countingStream counts the bytes of the messages of the stream, and keeps the first message of the client
+type countingStream struct {
+	grpc.ServerStream
+	mu       sync.Mutex
+	first    proto.Message
+	bytesIn  int
+	bytesOut int
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	if message, ok := m.(proto.Message); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.bytesIn += proto.Size(message)
+		if s.first == nil {
+			s.first = proto.Clone(message)
+		}
+	}
+	return nil
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err != nil {
+		return err
+	}
+	if message, ok := m.(proto.Message); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.bytesOut += proto.Size(message)
+	}
+	return nil
+}
+
+func (s *countingStream) counters() (proto.Message, int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.first, s.bytesIn, s.bytesOut
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestAccessLog(t *testing.T) {
+
+	t.Run("should be disabled without configuration", func(t *testing.T) {
+		l := newAccessLog(nil)
+		assert.Nil(t, l)
+		assert.False(t, l.enabled(hydrapb.HydraideService_Get_FullMethodName, false))
+	})
+
+	t.Run("should toggle the method classes", func(t *testing.T) {
+		l := newAccessLog(&AccessLogConfiguration{SampleRatio: 1, Writes: true})
+		assert.False(t, l.enabled(hydrapb.HydraideService_Get_FullMethodName, false))
+		assert.True(t, l.enabled(hydrapb.HydraideService_Set_FullMethodName, false))
+		assert.False(t, l.enabled(hydrapb.HydraideService_SetLargeValue_FullMethodName, true), "the streams are a class of their own")
+	})
+
+	t.Run("should log the unary RPCs", func(t *testing.T) {
+		l, output := newTestAccessLog(&AccessLogConfiguration{SampleRatio: 1, Reads: true})
+		req := &hydrapb.GetRequest{Swamps: []*hydrapb.GetSwamp{{IslandID: 3, SwampName: "users/profiles/alex", Keys: []string{"a", "b"}}}}
+		resp := &hydrapb.GetResponse{Swamps: []*hydrapb.GetSwampResponse{{SwampName: "users/profiles/alex"}}}
+		info := &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Get_FullMethodName}
+
+		_, err := accessLogUnaryInterceptor(l)(context.Background(), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return resp, nil
+		})
+		require.NoError(t, err)
+
+		record := decodeAccessRecord(t, output)
+		assert.Equal(t, "Get", record["method"])
+		assert.Equal(t, "users/profiles/alex", record["swampName"])
+		assert.Equal(t, []any{float64(3)}, record["islandIDs"])
+		assert.Equal(t, float64(2), record["keyCount"])
+		assert.Equal(t, float64(proto.Size(req)), record["bytesIn"])
+		assert.Equal(t, float64(proto.Size(resp)), record["bytesOut"])
+		assert.Equal(t, "OK", record["code"])
+	})
+
+	t.Run("should sample only the successful RPCs", func(t *testing.T) {
+		l, output := newTestAccessLog(&AccessLogConfiguration{SampleRatio: 0.5, Writes: true})
+		l.random = func() float64 { return 0.7 }
+		info := &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Set_FullMethodName}
+
+		_, err := accessLogUnaryInterceptor(l)(context.Background(), &hydrapb.SetRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &hydrapb.SetResponse{}, nil
+		})
+		require.NoError(t, err)
+		assert.Zero(t, output.Len(), "the successful RPC is dropped by the sampling")
+
+		_, err = accessLogUnaryInterceptor(l)(context.Background(), &hydrapb.SetRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		})
+		require.Error(t, err)
+		record := decodeAccessRecord(t, output)
+		assert.Equal(t, "ResourceExhausted", record["code"])
+		assert.Equal(t, "too many requests", record["error"])
+		assert.Equal(t, float64(0), record["bytesOut"])
+	})
+
+	t.Run("should log the streams with the sum of their messages", func(t *testing.T) {
+		l, output := newTestAccessLog(&AccessLogConfiguration{SampleRatio: 1, Streams: true})
+		messages := []*hydrapb.SetLargeValueRequest{
+			{IslandID: 4, SwampName: "files/documents/contract", KeyValue: &hydrapb.KeyValuePair{Key: "pdf"}, Chunk: []byte("first")},
+			{Chunk: []byte("second")},
+		}
+		stream := &accessLogTestStream{ctx: context.Background(), messages: messages}
+		info := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_SetLargeValue_FullMethodName, IsClientStream: true}
+		resp := &hydrapb.SetLargeValueResponse{}
+
+		err := accessLogStreamInterceptor(l)(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+			for {
+				message := &hydrapb.SetLargeValueRequest{}
+				if err := ss.RecvMsg(message); err != nil {
+					return ss.SendMsg(resp)
+				}
+			}
+		})
+		require.NoError(t, err)
+
+		record := decodeAccessRecord(t, output)
+		assert.Equal(t, "SetLargeValue", record["method"])
+		assert.Equal(t, "files/documents/contract", record["swampName"], "the swamp is taken from the first message")
+		assert.Equal(t, float64(proto.Size(messages[0])+proto.Size(messages[1])), record["bytesIn"])
+		assert.Equal(t, float64(proto.Size(resp)), record["bytesOut"])
+	})
+
+}
+
+// newTestAccessLog creates an access log writing its records to the returned buffer as JSON lines
+func newTestAccessLog(configuration *AccessLogConfiguration) (*accessLog, *bytes.Buffer) {
+	output := &bytes.Buffer{}
+	l := newAccessLog(configuration)
+	l.logger = slog.New(slog.NewJSONHandler(output, nil))
+	return l, output
+}
+
+// decodeAccessRecord decodes the only record of the buffer
+func decodeAccessRecord(t *testing.T, output *bytes.Buffer) map[string]any {
+	t.Helper()
+	record := map[string]any{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	output.Reset()
+	return record
+}
+
+// accessLogTestStream returns the messages to the RecvMsg, then io.EOF, and drops the sent messages
+type accessLogTestStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*hydrapb.SetLargeValueRequest
+}
+
+func (s *accessLogTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *accessLogTestStream) RecvMsg(m interface{}) error {
+	if len(s.messages) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(*hydrapb.SetLargeValueRequest), s.messages[0])
+	s.messages = s.messages[1:]
+	return nil
+}
+
+func (s *accessLogTestStream) SendMsg(m interface{}) error {
+	return nil
+}
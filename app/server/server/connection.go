@@ -0,0 +1,89 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"time"
+)
+
+const (
+	// DefaultKeepaliveTime is the idle time of a connection before the server pings the client
+	DefaultKeepaliveTime = 4 * time.Minute
+	// DefaultKeepaliveTimeout is the time the server waits for the answer of its ping before it closes the connection
+	DefaultKeepaliveTimeout = 20 * time.Second
+	// DefaultMaxConnectionIdle is the time a connection without any RPC is kept open
+	DefaultMaxConnectionIdle = 5 * time.Minute
+	// DefaultKeepaliveMinTime is the shortest ping interval allowed for the clients. The clients ping every minute by
+	// default, and gRPC does not allow less than 10 seconds on the client side
+	DefaultKeepaliveMinTime = 10 * time.Second
+)
+
+// ConnectionConfiguration is the tuning of the gRPC connections of the server. The zero values mean the defaults.
+//
+// The long-lived Subscribe streams across a NAT or a load balancer die silently if the connection is idle longer
+// than the idle timeout of the NAT. The keepalive pings of the clients keep them open, and the server accepts the
+// pings as often as KeepaliveMinTime, so a client pinging every minute is not disconnected as abusive.
+type ConnectionConfiguration struct {
+	// KeepaliveTime is the idle time of a connection before the server pings the client. Zero means
+	// DefaultKeepaliveTime
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout is the time the server waits for the answer of its ping before it closes the connection.
+	// Zero means DefaultKeepaliveTimeout
+	KeepaliveTimeout time.Duration
+	// MaxConnectionIdle is the time a connection without any RPC is kept open. An open Subscribe stream is an RPC,
+	// so its connection is never idle. Zero means DefaultMaxConnectionIdle
+	MaxConnectionIdle time.Duration
+	// KeepaliveMinTime is the shortest ping interval allowed for the clients. A client pinging more often is
+	// disconnected. Zero means DefaultKeepaliveMinTime
+	KeepaliveMinTime time.Duration
+	// InitialWindowSize is the HTTP/2 flow control window of a stream in bytes. The values below 64 KB are ignored by
+	// gRPC. Zero means the default of gRPC, which grows with the bandwidth of the connection
+	InitialWindowSize int32
+	// InitialConnWindowSize is the HTTP/2 flow control window of a connection in bytes. The values below 64 KB are
+	// ignored by gRPC. Zero means the default of gRPC, which grows with the bandwidth of the connection
+	InitialConnWindowSize int32
+	// MaxConcurrentStreams is the max number of the concurrent RPCs of a connection. Zero means unlimited
+	MaxConcurrentStreams uint32
+}
+
+// serverOptions returns the gRPC server options of the connection configuration. Nil configuration means the defaults
+func (c *ConnectionConfiguration) serverOptions() []grpc.ServerOption {
+
+	if c == nil {
+		c = &ConnectionConfiguration{}
+	}
+
+	options := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              durationOrDefault(c.KeepaliveTime, DefaultKeepaliveTime),
+			Timeout:           durationOrDefault(c.KeepaliveTimeout, DefaultKeepaliveTimeout),
+			MaxConnectionIdle: durationOrDefault(c.MaxConnectionIdle, DefaultMaxConnectionIdle),
+		}),
+		// the clients may ping an idle connection, too, so the connections behind a NAT are not dropped
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             durationOrDefault(c.KeepaliveMinTime, DefaultKeepaliveMinTime),
+			PermitWithoutStream: true,
+		}),
+	}
+
+	if c.InitialWindowSize > 0 {
+		options = append(options, grpc.InitialWindowSize(c.InitialWindowSize))
+	}
+	if c.InitialConnWindowSize > 0 {
+		options = append(options, grpc.InitialConnWindowSize(c.InitialConnWindowSize))
+	}
+	if c.MaxConcurrentStreams > 0 {
+		options = append(options, grpc.MaxConcurrentStreams(c.MaxConcurrentStreams))
+	}
+
+	return options
+
+}
+
+// durationOrDefault returns the duration, or the default if the duration is not positive
+func durationOrDefault(d time.Duration, defaultDuration time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultDuration
+	}
+	return d
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConnectionConfiguration_ServerOptions(t *testing.T) {
+
+	t.Run("should set the keepalive parameters and the enforcement policy by default", func(t *testing.T) {
+		var c *ConnectionConfiguration
+		assert.Len(t, c.serverOptions(), 2)
+		assert.Len(t, (&ConnectionConfiguration{}).serverOptions(), 2)
+	})
+
+	t.Run("should set the flow control windows and the stream limit if they are configured", func(t *testing.T) {
+		c := &ConnectionConfiguration{
+			InitialWindowSize:     1 << 20,
+			InitialConnWindowSize: 1 << 22,
+			MaxConcurrentStreams:  1000,
+		}
+		assert.Len(t, c.serverOptions(), 5)
+	})
+
+}
+
+func TestDurationOrDefault(t *testing.T) {
+	assert.Equal(t, DefaultKeepaliveTime, durationOrDefault(0, DefaultKeepaliveTime))
+	assert.Equal(t, DefaultKeepaliveTime, durationOrDefault(-time.Second, DefaultKeepaliveTime))
+	assert.Equal(t, 30*time.Second, durationOrDefault(30*time.Second, DefaultKeepaliveTime))
+}
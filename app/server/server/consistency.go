@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hydraide/hydraide/app/server/audit"
+	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/consistency"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// consistencyFileName is the state file of the consistency tokens in the root folder of the server
+	consistencyFileName = "consistency.json"
+	// maxLostRuns is the number of the crashed runs remembered by the server
+	maxLostRuns = 32
+)
+
+// consistencyState is the persisted state of the consistency tokens
+type consistencyState struct {
+	// ServerID is the ID of the server in the tokens. It is generated at the first start of the server
+	ServerID string `json:"serverId"`
+	// Run is the start of the current or the last run of the server in Unix nanoseconds
+	Run int64 `json:"run"`
+	// CleanStop is true if the last run stopped gracefully, so all of its writes are on the disk
+	CleanStop bool `json:"cleanStop"`
+	// LostRuns are the runs that crashed, so their last writes, not yet flushed to the disk, can be lost
+	LostRuns []int64 `json:"lostRuns,omitempty"`
+}
+
+// consistencyTracker issues the consistency tokens of the writes and checks the tokens of the requests.
+//
+// The writes are applied in the memory before their response is sent, so every request of the current run sees the
+// writes of its token. A token of an earlier run is reached only if that run stopped gracefully, because a crashed
+// run can lose the writes that were not flushed to the disk yet.
+type consistencyTracker struct {
+	path     string
+	state    consistencyState
+	sequence atomic.Uint64
+}
+
+// openConsistencyTracker loads the state of the tokens from the folder, and starts a new run
+func openConsistencyTracker(folder string, now time.Time) (*consistencyTracker, error) {
+
+	t := &consistencyTracker{path: filepath.Join(folder, consistencyFileName)}
+
+	content, err := os.ReadFile(t.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		t.state = consistencyState{ServerID: uuid.NewString()}
+	case err != nil:
+		return nil, fmt.Errorf("failed to read the consistency state: %w", err)
+	default:
+		if err := json.Unmarshal(content, &t.state); err != nil {
+			return nil, fmt.Errorf("failed to parse the consistency state %s: %w", t.path, err)
+		}
+		if t.state.ServerID == "" {
+			t.state.ServerID = uuid.NewString()
+		}
+		if t.state.Run != 0 && !t.state.CleanStop {
+			t.state.LostRuns = append(t.state.LostRuns, t.state.Run)
+			if len(t.state.LostRuns) > maxLostRuns {
+				t.state.LostRuns = t.state.LostRuns[len(t.state.LostRuns)-maxLostRuns:]
+			}
+		}
+	}
+
+	// the run must grow even if the clock of the server goes back
+	t.state.Run = max(t.state.Run+1, now.UnixNano())
+	t.state.CleanStop = false
+
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+
+}
+
+// save writes the state atomically, so a crash does not leave a half written state
+func (t *consistencyTracker) save() error {
+
+	content, err := json.Marshal(t.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode the consistency state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return fmt.Errorf("failed to create the folder of the consistency state: %w", err)
+	}
+	temporary := t.path + ".tmp"
+	if err := os.WriteFile(temporary, content, 0644); err != nil {
+		return fmt.Errorf("failed to write the consistency state: %w", err)
+	}
+	if err := os.Rename(temporary, t.path); err != nil {
+		return fmt.Errorf("failed to write the consistency state: %w", err)
+	}
+
+	return nil
+
+}
+
+// issue returns the mark of a new applied write
+func (t *consistencyTracker) issue() consistency.Mark {
+	return consistency.Mark{ServerID: t.state.ServerID, Run: t.state.Run, Sequence: t.sequence.Add(1)}
+}
+
+// check returns a FailedPrecondition error with the CONSISTENCY_NOT_REACHED reason if the server does not have the
+// writes of the token of the request. The marks of the other servers are ignored
+func (t *consistencyTracker) check(ctx context.Context) error {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.Get(consistency.Metadata)
+	if len(values) == 0 {
+		return nil
+	}
+
+	var token consistency.Token
+	for _, value := range values {
+		parsed, err := consistency.Parse(value)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		token = token.Merge(parsed)
+	}
+
+	mark, ok := token.Mark(t.state.ServerID)
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case mark.Run == t.state.Run && mark.Sequence > t.sequence.Load():
+		return gateway.ConsistencyNotReachedError(fmt.Sprintf("the write %d of the consistency token was not applied by the server, it applied %d writes since its start",
+			mark.Sequence, t.sequence.Load()))
+	case mark.Run > t.state.Run:
+		return gateway.ConsistencyNotReachedError("the consistency token is from a later run of the server, the server was restored or its clock went back")
+	case mark.Run < t.state.Run && slices.Contains(t.state.LostRuns, mark.Run):
+		return gateway.ConsistencyNotReachedError("the writes of the consistency token can be lost, because the server crashed after them, write the data again")
+	}
+
+	return nil
+
+}
+
+// close marks the run as stopped gracefully. It must be called after all data was flushed to the disk
+func (t *consistencyTracker) close() error {
+	t.state.CleanStop = true
+	return t.save()
+}
+
+// setTrailer returns the token of the new write in the trailer of the response. The error is ignored, because the
+// REST gateway has no trailers
+func (t *consistencyTracker) setTrailer(set func(metadata.MD) error) {
+	_ = set(metadata.Pairs(consistency.Metadata, consistency.Token{t.issue()}.String()))
+}
+
+// consistencyUnaryInterceptor checks the consistency token of the requests, and returns a new token after every
+// successful write
+func consistencyUnaryInterceptor(t *consistencyTracker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		if err := t.check(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err == nil && audit.IsMutating(info.FullMethod) {
+			t.setTrailer(func(md metadata.MD) error {
+				return grpc.SetTrailer(ctx, md)
+			})
+		}
+
+		return resp, err
+
+	}
+}
+
+// consistencyStreamInterceptor is the consistencyUnaryInterceptor of the streams, e.g. the large values and the blobs
+func consistencyStreamInterceptor(t *consistencyTracker) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if err := t.check(ss.Context()); err != nil {
+			return err
+		}
+
+		err := handler(srv, ss)
+		if err == nil && audit.IsMutating(info.FullMethod) {
+			t.setTrailer(func(md metadata.MD) error {
+				ss.SetTrailer(md)
+				return nil
+			})
+		}
+
+		return err
+
+	}
+}
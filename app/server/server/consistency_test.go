@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/consistency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// withToken returns an incoming context with the consistency token
+func withToken(token consistency.Token) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(consistency.Metadata, token.String()))
+}
+
+// requireNotReached checks that the error has the CONSISTENCY_NOT_REACHED reason
+func requireNotReached(t *testing.T, err error) {
+	require.Error(t, err)
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, s.Code())
+	require.Len(t, s.Details(), 1)
+	assert.Equal(t, hydrapb.ErrorReason_CONSISTENCY_NOT_REACHED.String(), s.Details()[0].(*errdetails.ErrorInfo).GetReason())
+}
+
+func TestConsistencyTracker(t *testing.T) {
+
+	started := time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+
+	t.Run("should serve the tokens of the current run", func(t *testing.T) {
+
+		tracker, err := openConsistencyTracker(t.TempDir(), started)
+		require.NoError(t, err)
+
+		first := tracker.issue()
+		second := tracker.issue()
+		assert.Equal(t, started.UnixNano(), second.Run)
+		assert.Equal(t, first.Sequence+1, second.Sequence)
+
+		assert.NoError(t, tracker.check(context.Background()), "the requests without a token are served")
+		assert.NoError(t, tracker.check(withToken(consistency.Token{second})))
+		assert.NoError(t, tracker.check(withToken(consistency.Token{{ServerID: "other", Run: 1, Sequence: 99}})), "the marks of the other servers are ignored")
+
+		second.Sequence++
+		requireNotReached(t, tracker.check(withToken(consistency.Token{second})))
+
+		future := first
+		future.Run++
+		requireNotReached(t, tracker.check(withToken(consistency.Token{future})))
+
+		err = tracker.check(metadata.NewIncomingContext(context.Background(), metadata.Pairs(consistency.Metadata, "broken")))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	})
+
+	t.Run("should keep the tokens of a gracefully stopped run only", func(t *testing.T) {
+
+		folder := t.TempDir()
+		tracker, err := openConsistencyTracker(folder, started)
+		require.NoError(t, err)
+		stopped := tracker.issue()
+		require.NoError(t, tracker.close())
+
+		// the run after the graceful stop has all writes of the stopped run
+		tracker, err = openConsistencyTracker(folder, started.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, stopped.ServerID, tracker.issue().ServerID, "the ID of the server is persistent")
+		assert.NoError(t, tracker.check(withToken(consistency.Token{stopped})))
+		crashed := tracker.issue()
+
+		// the run after the crash can miss the writes of the crashed run
+		tracker, err = openConsistencyTracker(folder, started.Add(2*time.Minute))
+		require.NoError(t, err)
+		requireNotReached(t, tracker.check(withToken(consistency.Token{crashed})))
+		assert.NoError(t, tracker.check(withToken(consistency.Token{stopped})))
+
+	})
+
+	t.Run("should start a new run even if the clock goes back", func(t *testing.T) {
+
+		folder := t.TempDir()
+		tracker, err := openConsistencyTracker(folder, started)
+		require.NoError(t, err)
+		require.NoError(t, tracker.close())
+
+		tracker, err = openConsistencyTracker(folder, started.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Greater(t, tracker.issue().Run, started.UnixNano())
+
+	})
+
+}
+
+func TestConsistencyUnaryInterceptor(t *testing.T) {
+
+	tracker, err := openConsistencyTracker(t.TempDir(), time.Now())
+	require.NoError(t, err)
+	interceptor := consistencyUnaryInterceptor(tracker)
+
+	handled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return "ok", nil
+	}
+
+	t.Run("should issue a mark after the writes only", func(t *testing.T) {
+
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Get_FullMethodName}, handler)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), tracker.sequence.Load())
+
+		_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Set_FullMethodName}, handler)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), tracker.sequence.Load())
+
+	})
+
+	t.Run("should refuse the request before its handler if the token is not reached", func(t *testing.T) {
+
+		handled = false
+		mark := consistency.Mark{ServerID: tracker.state.ServerID, Run: tracker.state.Run, Sequence: 5}
+		_, err := interceptor(withToken(consistency.Token{mark}), nil, &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Get_FullMethodName}, handler)
+		requireNotReached(t, err)
+		assert.False(t, handled)
+
+	})
+
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/app/server/telemetry"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+)
+
+// diskWriteMethods are the RPCs that grow the data on the disk. They are refused if the disk is almost full, while the
+// reads and the deletes still work, so the space can be freed
+var diskWriteMethods = map[string]struct{}{
+	hydrapb.HydraideService_Set_FullMethodName:                    {},
+	hydrapb.HydraideService_SetLargeValue_FullMethodName:          {},
+	hydrapb.HydraideService_PutBlob_FullMethodName:                {},
+	hydrapb.HydraideService_Uint32SlicePush_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementInt8_FullMethodName:          {},
+	hydrapb.HydraideService_IncrementInt16_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementInt32_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementInt64_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementUint8_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementUint16_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementUint32_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementUint64_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementFloat32_FullMethodName:       {},
+	hydrapb.HydraideService_IncrementFloat64_FullMethodName:       {},
+	hydrapb.HydraideService_SetIfLater_FullMethodName:             {},
+	hydrapb.HydraideService_AddDuration_FullMethodName:            {},
+	hydrapb.HydraideService_SetStringIf_FullMethodName:            {},
+	hydrapb.HydraideService_SetBoolIf_FullMethodName:              {},
+	hydrapb.HydraideService_Uint32SliceCombineInto_FullMethodName: {},
+	hydrapb.HydraideService_SetSwampAnnotation_FullMethodName:     {},
+	hydrapb.HydraideService_Restore_FullMethodName:                {},
+	hydrapb.HydraideService_RevertTo_FullMethodName:               {},
+	hydrapb.HydraideService_ImportIsland_FullMethodName:           {},
+}
+
+// checkDiskSpace returns a ResourceExhausted error with the INSUFFICIENT_STORAGE reason if the request writes to the
+// disk and a disk of the island roots is almost full. The client is told not to retry, because the disk does not get
+// free space by itself. Returns nil if the telemetry is not running.
+func checkDiskSpace(ctx context.Context, t telemetry.Telemetry, fullMethod string) error {
+
+	if t == nil {
+		return nil
+	}
+	if _, ok := diskWriteMethods[fullMethod]; !ok {
+		return nil
+	}
+
+	lowDisk := t.LowDisk()
+	if lowDisk == nil {
+		return nil
+	}
+
+	gateway.SetRetryPushback(ctx, -1)
+
+	return gateway.InsufficientStorageError(fmt.Sprintf("the write is refused, because the disk of %s has only %.2f%% free space, free space by deleting treasures or add disk space to the server",
+		lowDisk.Path, lowDisk.FreePercent))
+
+}
+
+// diskSpaceStreamInterceptor refuses the streamed writes (large values and blobs) if the disk is almost full. The
+// unary writes are checked by the unary interceptor of the server
+func diskSpaceStreamInterceptor(t telemetry.Telemetry) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkDiskSpace(ss.Context(), t, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
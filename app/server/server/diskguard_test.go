@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/telemetry"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// stubTelemetry reports a fixed low disk and disk warning
+type stubTelemetry struct {
+	lowDisk     *telemetry.DiskUsage
+	diskWarning *telemetry.DiskUsage
+}
+
+func (s *stubTelemetry) Start(context.Context)             {}
+func (s *stubTelemetry) Last() *telemetry.Sample           { return nil }
+func (s *stubTelemetry) LowDisk() *telemetry.DiskUsage     { return s.lowDisk }
+func (s *stubTelemetry) DiskWarning() *telemetry.DiskUsage { return s.diskWarning }
+
+func TestCheckDiskSpace(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("should allow everything without telemetry or with enough free space", func(t *testing.T) {
+		assert.NoError(t, checkDiskSpace(ctx, nil, hydrapb.HydraideService_Set_FullMethodName))
+		assert.NoError(t, checkDiskSpace(ctx, &stubTelemetry{}, hydrapb.HydraideService_Set_FullMethodName))
+	})
+
+	lowDisk := &stubTelemetry{lowDisk: &telemetry.DiskUsage{Path: "/data", FreePercent: 1.5}}
+
+	t.Run("should refuse the writes if the disk is almost full", func(t *testing.T) {
+		for _, method := range []string{
+			hydrapb.HydraideService_Set_FullMethodName,
+			hydrapb.HydraideService_PutBlob_FullMethodName,
+			hydrapb.HydraideService_IncrementInt64_FullMethodName,
+		} {
+			err := checkDiskSpace(ctx, lowDisk, method)
+			require.Error(t, err, method)
+
+			s, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.ResourceExhausted, s.Code())
+			assert.Contains(t, s.Message(), "/data")
+			require.Len(t, s.Details(), 1)
+			assert.Equal(t, hydrapb.ErrorReason_INSUFFICIENT_STORAGE.String(), s.Details()[0].(*errdetails.ErrorInfo).GetReason())
+		}
+	})
+
+	t.Run("should allow the reads and the deletes if the disk is almost full", func(t *testing.T) {
+		for _, method := range []string{
+			hydrapb.HydraideService_Get_FullMethodName,
+			hydrapb.HydraideService_Delete_FullMethodName,
+			hydrapb.HydraideService_Destroy_FullMethodName,
+			hydrapb.HydraideService_ShiftExpiredTreasures_FullMethodName,
+		} {
+			assert.NoError(t, checkDiskSpace(ctx, lowDisk, method), method)
+		}
+	})
+
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/server/metrics"
+)
+
+const (
+	flushSnapshotsMetric = "hydraide_swamp_flush_snapshots_total"
+	flushStallsMetric    = "hydraide_swamp_flush_stalls_total"
+	flushStallTimeMetric = "hydraide_swamp_flush_stall_seconds_total"
+)
+
+// registerFlushStallMetrics exposes the stalls the writers of the swamps cause. The writers hold the treasures only
+// while they copy them, so the stalls must stay near zero even during the large flushes
+func registerFlushStallMetrics(registry metrics.Registry, counters *flushstall.Counters) {
+
+	registry.CounterFunc(flushSnapshotsMetric, "Number of the treasures copied by the writers of the swamps", func() float64 {
+		return float64(counters.Stats().Snapshots)
+	})
+	registry.CounterFunc(flushStallsMetric, "Number of the treasure operations that waited for the writer of the swamp", func() float64 {
+		return float64(counters.Stats().Stalls)
+	})
+	registry.CounterFunc(flushStallTimeMetric, "Total time the treasure operations waited for the writer of the swamp, at most", func() float64 {
+		return counters.Stats().StallTime.Seconds()
+	})
+
+}
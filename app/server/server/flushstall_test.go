@@ -0,0 +1,28 @@
+package server
+
+import (
+	"bytes"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRegisterFlushStallMetrics(t *testing.T) {
+
+	registry := metrics.New()
+	counters := &flushstall.Counters{}
+	registerFlushStallMetrics(registry, counters)
+
+	counters.Record(0, time.Microsecond)
+	counters.Record(2, time.Second)
+
+	var buffer bytes.Buffer
+	require.NoError(t, registry.WriteText(&buffer))
+	assert.Contains(t, buffer.String(), "hydraide_swamp_flush_snapshots_total 2\n")
+	assert.Contains(t, buffer.String(), "hydraide_swamp_flush_stalls_total 2\n")
+	assert.Contains(t, buffer.String(), "hydraide_swamp_flush_stall_seconds_total 2\n")
+
+}
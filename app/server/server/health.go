@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/telemetry"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+const (
+	HealthCheckTLS           = "tls"
+	HealthCheckGrpcListener  = "grpcListener"
+	HealthCheckSettingsStore = "settingsStore"
+	HealthCheckIslandFolders = "islandFolders"
+	HealthCheckDiskSpace     = "diskSpace"
+)
+
+// HealthCheck is the result of a single readiness check of the server
+type HealthCheck struct {
+	// Name is the unique name of the check, for example "tls" or "grpcListener"
+	Name string `json:"name"`
+	// Healthy is true if the check passed
+	Healthy bool `json:"healthy"`
+	// Message is the human-readable reason of the failure or the degradation. Empty if the check passed
+	Message string `json:"message,omitempty"`
+	// Degraded is true if the check passed, but the server works with limitations, for example the disk is running
+	// out of space or the writes are refused. A degraded server stays ready, because the reads and the deletes work
+	Degraded bool `json:"degraded,omitempty"`
+}
+
+// healthState holds the flags set by the server's start goroutine, so the readiness checks can see how far the
+// startup got
+type healthState struct {
+	listenerBound atomic.Bool
+}
+
+// CheckReadiness runs all readiness checks of the server and returns their results in a fixed order.
+// The server is ready only if all checks are healthy.
+func (s *server) CheckReadiness() []HealthCheck {
+
+	checks := make([]HealthCheck, 0, 5)
+
+	s.mu.RLock()
+	certReloader := s.certReloader
+	settingsInterface := s.settingsInterface
+	t := s.telemetry
+	s.mu.RUnlock()
+
+	tlsCheck := HealthCheck{Name: HealthCheckTLS, Healthy: certReloader != nil && certReloader.IsLoaded()}
+	if !tlsCheck.Healthy {
+		tlsCheck.Message = "TLS credentials are not loaded"
+	}
+	checks = append(checks, tlsCheck)
+
+	listenerCheck := HealthCheck{Name: HealthCheckGrpcListener, Healthy: s.health.listenerBound.Load()}
+	if !listenerCheck.Healthy {
+		listenerCheck.Message = fmt.Sprintf("gRPC listener is not bound to port %d", s.configuration.HydraServerPort)
+	}
+	checks = append(checks, listenerCheck)
+
+	if settingsInterface == nil {
+		checks = append(checks,
+			HealthCheck{Name: HealthCheckSettingsStore, Message: "settings are not loaded"},
+			HealthCheck{Name: HealthCheckIslandFolders, Message: "settings are not loaded"},
+		)
+		return checks
+	}
+
+	checks = append(checks, checkFolderWritable(HealthCheckSettingsStore, settingsInterface.GetHydraAbsSettingsFolderPath()))
+	checks = append(checks, checkIslandFolders(HealthCheckIslandFolders, settingsInterface.GetHydraAbsDataFolderPath()))
+	checks = append(checks, checkDiskSpaceHealth(HealthCheckDiskSpace, t))
+
+	return checks
+
+}
+
+// checkDiskSpaceHealth reports the disk watermarks of the telemetry. The check is always healthy, because the server
+// still serves the reads and the deletes on a full disk, but it is degraded below the warning watermark
+func checkDiskSpaceHealth(name string, t telemetry.Telemetry) HealthCheck {
+
+	if t == nil {
+		return HealthCheck{Name: name, Healthy: true}
+	}
+
+	if lowDisk := t.LowDisk(); lowDisk != nil {
+		return HealthCheck{Name: name, Healthy: true, Degraded: true,
+			Message: fmt.Sprintf("the writes are refused, the disk of %s has only %.2f%% free space", lowDisk.Path, lowDisk.FreePercent)}
+	}
+
+	if diskWarning := t.DiskWarning(); diskWarning != nil {
+		return HealthCheck{Name: name, Healthy: true, Degraded: true,
+			Message: fmt.Sprintf("the disk of %s is running out of space, it has only %.2f%% free space", diskWarning.Path, diskWarning.FreePercent)}
+	}
+
+	return HealthCheck{Name: name, Healthy: true}
+
+}
+
+// checkFolderWritable tries to create and remove a probe file in the folder
+func checkFolderWritable(name string, folder string) HealthCheck {
+
+	probe, err := os.CreateTemp(folder, ".readyz-*")
+	if err != nil {
+		return HealthCheck{Name: name, Message: fmt.Sprintf("folder %s is not writable: %v", folder, err)}
+	}
+
+	probePath := probe.Name()
+	_ = probe.Close()
+	if err := os.Remove(probePath); err != nil {
+		return HealthCheck{Name: name, Message: fmt.Sprintf("can not remove probe file %s: %v", probePath, err)}
+	}
+
+	return HealthCheck{Name: name, Healthy: true}
+
+}
+
+// checkIslandFolders checks if the data folder and all island folders in it are accessible.
+// A missing data folder is healthy, because it is created lazily by the first swamp.
+func checkIslandFolders(name string, dataFolder string) HealthCheck {
+
+	entries, err := os.ReadDir(dataFolder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return HealthCheck{Name: name, Healthy: true}
+		}
+		return HealthCheck{Name: name, Message: fmt.Sprintf("data folder %s is not accessible: %v", dataFolder, err)}
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		islandFolder := filepath.Join(dataFolder, entry.Name())
+		f, err := os.Open(islandFolder)
+		if err != nil {
+			return HealthCheck{Name: name, Message: fmt.Sprintf("island folder %s is not accessible: %v", islandFolder, err)}
+		}
+		_ = f.Close()
+	}
+
+	return HealthCheck{Name: name, Healthy: true}
+
+}
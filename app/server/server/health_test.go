@@ -0,0 +1,74 @@
+package server
+
+import (
+	"github.com/hydraide/hydraide/app/server/telemetry"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFolderWritable(t *testing.T) {
+
+	folder := t.TempDir()
+	check := checkFolderWritable(HealthCheckSettingsStore, folder)
+	assert.True(t, check.Healthy)
+	assert.Empty(t, check.Message)
+
+	entries, err := os.ReadDir(folder)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "the probe file should be removed")
+
+	check = checkFolderWritable(HealthCheckSettingsStore, filepath.Join(folder, "not-exists"))
+	assert.False(t, check.Healthy)
+	assert.NotEmpty(t, check.Message)
+
+}
+
+func TestCheckIslandFolders(t *testing.T) {
+
+	folder := t.TempDir()
+	assert.True(t, checkIslandFolders(HealthCheckIslandFolders, filepath.Join(folder, "not-exists")).Healthy, "missing data folder is healthy")
+
+	assert.NoError(t, os.Mkdir(filepath.Join(folder, "1"), 0755))
+	assert.True(t, checkIslandFolders(HealthCheckIslandFolders, folder).Healthy)
+
+}
+
+func TestCheckDiskSpaceHealth(t *testing.T) {
+
+	check := checkDiskSpaceHealth(HealthCheckDiskSpace, nil)
+	assert.True(t, check.Healthy)
+	assert.False(t, check.Degraded)
+
+	check = checkDiskSpaceHealth(HealthCheckDiskSpace, &stubTelemetry{})
+	assert.True(t, check.Healthy)
+	assert.False(t, check.Degraded)
+	assert.Empty(t, check.Message)
+
+	diskWarning := &telemetry.DiskUsage{Path: "/data", FreePercent: 8}
+	check = checkDiskSpaceHealth(HealthCheckDiskSpace, &stubTelemetry{diskWarning: diskWarning})
+	assert.True(t, check.Healthy)
+	assert.True(t, check.Degraded)
+	assert.Contains(t, check.Message, "running out of space")
+
+	lowDisk := &telemetry.DiskUsage{Path: "/data", FreePercent: 2}
+	check = checkDiskSpaceHealth(HealthCheckDiskSpace, &stubTelemetry{lowDisk: lowDisk, diskWarning: lowDisk})
+	assert.True(t, check.Healthy, "the reads and the deletes still work on a full disk")
+	assert.True(t, check.Degraded)
+	assert.Contains(t, check.Message, "the writes are refused")
+
+}
+
+func TestCheckReadinessWithoutStart(t *testing.T) {
+
+	s := New(&Configuration{HydraServerPort: 4444}).(*server)
+	checks := s.CheckReadiness()
+	assert.Len(t, checks, 4)
+	for _, check := range checks {
+		assert.False(t, check.Healthy, check.Name)
+		assert.NotEmpty(t, check.Message, check.Name)
+	}
+
+}
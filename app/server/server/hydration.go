@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// MetadataHydrationPriority is the gRPC metadata key of the hydration priority of a request. The value is
+	// "background" or "interactive"
+	MetadataHydrationPriority = "hydraide-hydration-priority"
+
+	hydrationQueueDepthMetric = "hydraide_hydration_queue_depth"
+	hydrationActiveMetric     = "hydraide_hydration_active"
+	hydrationsMetric          = "hydraide_hydrations_total"
+	hydrationWaitMetric       = "hydraide_hydration_wait_seconds_total"
+)
+
+// backgroundHydrationMethods are the RPCs that scan many swamps or whole swamps. Their hydrations wait until no
+// interactive hydration is waiting
+var backgroundHydrationMethods = map[string]bool{
+	hydrapb.HydraideService_GetAll_FullMethodName:             true,
+	hydrapb.HydraideService_GetAllStream_FullMethodName:       true,
+	hydrapb.HydraideService_ExistsMany_FullMethodName:         true,
+	hydrapb.HydraideService_Aggregate_FullMethodName:          true,
+	hydrapb.HydraideService_CompactSwamp_FullMethodName:       true,
+	hydrapb.HydraideService_ListCorruptedFiles_FullMethodName: true,
+	hydrapb.HydraideService_RestorePointInTime_FullMethodName: true,
+	hydrapb.HydraideService_ExportIsland_FullMethodName:       true,
+}
+
+// hydrationPriority returns the hydration priority of the request. The priority sent by the client in the
+// metadata wins, otherwise the scans are background and all other requests are interactive
+func hydrationPriority(ctx context.Context, fullMethod string) hydration.Priority {
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataHydrationPriority); len(values) > 0 {
+			switch values[0] {
+			case hydration.PriorityBackground.String():
+				return hydration.PriorityBackground
+			case hydration.PriorityInteractive.String():
+				return hydration.PriorityInteractive
+			}
+		}
+	}
+
+	if backgroundHydrationMethods[fullMethod] {
+		return hydration.PriorityBackground
+	}
+
+	return hydration.PriorityInteractive
+
+}
+
+// hydrationStreamInterceptor sets the hydration priority of the streams, the same way as the unary interceptor does
+// for the unary requests
+func hydrationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := hydration.WithPriority(ss.Context(), hydrationPriority(ss.Context(), info.FullMethod))
+		return handler(srv, &hydrationStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// hydrationStream is the stream with the hydration priority in its context
+type hydrationStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *hydrationStream) Context() context.Context {
+	return s.ctx
+}
+
+// registerHydrationMetrics exposes the queue depth, the running hydrations and the wait time of the scheduler
+func registerHydrationMetrics(registry metrics.Registry, scheduler hydration.Scheduler) {
+
+	registry.GaugeFunc(hydrationActiveMetric, "Number of the swamps being loaded from the disk", func() float64 {
+		return float64(scheduler.Stats().Active)
+	})
+
+	for _, priority := range []hydration.Priority{hydration.PriorityInteractive, hydration.PriorityBackground} {
+		p := priority
+		registry.GaugeFunc(hydrationQueueDepthMetric, "Number of the swamp loads waiting for a free hydration slot", func() float64 {
+			return float64(scheduler.Stats().QueueDepth[p])
+		}, "priority", p.String())
+		registry.CounterFunc(hydrationsMetric, "Number of the swamps loaded from the disk", func() float64 {
+			return float64(scheduler.Stats().Hydrations[p])
+		}, "priority", p.String())
+		registry.CounterFunc(hydrationWaitMetric, "Total time the swamp loads waited for a free hydration slot", func() float64 {
+			return scheduler.Stats().WaitTime[p].Seconds()
+		}, "priority", p.String())
+	}
+
+}
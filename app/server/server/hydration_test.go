@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestHydrationPriority(t *testing.T) {
+
+	t.Run("should schedule the scans in the background", func(t *testing.T) {
+		assert.Equal(t, hydration.PriorityBackground, hydrationPriority(context.Background(), hydrapb.HydraideService_GetAll_FullMethodName))
+		assert.Equal(t, hydration.PriorityInteractive, hydrationPriority(context.Background(), hydrapb.HydraideService_Get_FullMethodName))
+	})
+
+	t.Run("should use the priority of the client", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataHydrationPriority, "background"))
+		assert.Equal(t, hydration.PriorityBackground, hydrationPriority(ctx, hydrapb.HydraideService_Get_FullMethodName))
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataHydrationPriority, "interactive"))
+		assert.Equal(t, hydration.PriorityInteractive, hydrationPriority(ctx, hydrapb.HydraideService_GetAll_FullMethodName))
+	})
+
+	t.Run("should ignore the unknown priority", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataHydrationPriority, "urgent"))
+		assert.Equal(t, hydration.PriorityBackground, hydrationPriority(ctx, hydrapb.HydraideService_GetAll_FullMethodName))
+	})
+
+}
+
+func TestHydrationStreamInterceptor(t *testing.T) {
+
+	t.Run("should set the hydration priority of the stream", func(t *testing.T) {
+
+		var priority hydration.Priority
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			priority = hydration.PriorityFromContext(stream.Context())
+			return nil
+		}
+
+		interceptor := hydrationStreamInterceptor()
+		info := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_GetAllStream_FullMethodName}
+		require.NoError(t, interceptor(nil, &hydrationStream{ctx: context.Background()}, info, handler))
+		assert.Equal(t, hydration.PriorityBackground, priority)
+
+		info = &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_GetLargeValue_FullMethodName}
+		require.NoError(t, interceptor(nil, &hydrationStream{ctx: context.Background()}, info, handler))
+		assert.Equal(t, hydration.PriorityInteractive, priority)
+
+	})
+
+}
+
+func TestRegisterHydrationMetrics(t *testing.T) {
+
+	registry := metrics.New()
+	scheduler := hydration.New(1)
+	registerHydrationMetrics(registry, scheduler)
+
+	release, err := scheduler.Acquire(context.Background(), hydration.PriorityBackground)
+	require.NoError(t, err)
+	defer release()
+
+	var buffer bytes.Buffer
+	require.NoError(t, registry.WriteText(&buffer))
+	assert.Contains(t, buffer.String(), "hydraide_hydration_active 1\n")
+	assert.Contains(t, buffer.String(), "hydraide_hydration_queue_depth{priority=\"interactive\"} 0\n")
+	assert.Contains(t, buffer.String(), "hydraide_hydrations_total{priority=\"background\"} 1\n")
+	assert.Contains(t, buffer.String(), "hydraide_hydration_wait_seconds_total{priority=\"interactive\"} 0\n")
+
+}
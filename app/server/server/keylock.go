@@ -0,0 +1,28 @@
+package server
+
+import (
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
+	"github.com/hydraide/hydraide/app/server/metrics"
+)
+
+const (
+	keyLocksMetric          = "hydraide_swamp_key_locks_total"
+	keyLocksContendedMetric = "hydraide_swamp_key_locks_contended_total"
+	keyLockWaitMetric       = "hydraide_swamp_key_lock_wait_seconds_total"
+)
+
+// registerKeyLockMetrics exposes the contention of the key locks of every swamp. A growing wait time shows the hot
+// keys whose writers wait for each other
+func registerKeyLockMetrics(registry metrics.Registry, counters *keylock.Counters) {
+
+	registry.CounterFunc(keyLocksMetric, "Number of the key locks taken by the writes of the swamps", func() float64 {
+		return float64(counters.Stats().Acquisitions)
+	})
+	registry.CounterFunc(keyLocksContendedMetric, "Number of the key locks that waited for an other writer", func() float64 {
+		return float64(counters.Stats().Contended)
+	})
+	registry.CounterFunc(keyLockWaitMetric, "Total time the writes waited for the key locks", func() float64 {
+		return counters.Stats().WaitTime.Seconds()
+	})
+
+}
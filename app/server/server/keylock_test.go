@@ -0,0 +1,29 @@
+package server
+
+import (
+	"bytes"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRegisterKeyLockMetrics(t *testing.T) {
+
+	registry := metrics.New()
+	counters := &keylock.Counters{}
+	registerKeyLockMetrics(registry, counters)
+
+	locks := keylock.New(keylock.DefaultStripes)
+	locks.SetCounters(counters)
+	locks.Lock("key")()
+	locks.Lock("key")()
+
+	var buffer bytes.Buffer
+	require.NoError(t, registry.WriteText(&buffer))
+	assert.Contains(t, buffer.String(), "hydraide_swamp_key_locks_total 2\n")
+	assert.Contains(t, buffer.String(), "hydraide_swamp_key_locks_contended_total 0\n")
+	assert.Contains(t, buffer.String(), "hydraide_swamp_key_lock_wait_seconds_total 0\n")
+
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// writeMethods are the RPCs whose request payload counts against the write bytes limit of the client
+var writeMethods = map[string]struct{}{
+	hydrapb.HydraideService_Set_FullMethodName:                    {},
+	hydrapb.HydraideService_Uint32SlicePush_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementInt8_FullMethodName:          {},
+	hydrapb.HydraideService_IncrementInt16_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementInt32_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementInt64_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementUint8_FullMethodName:         {},
+	hydrapb.HydraideService_IncrementUint16_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementUint32_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementUint64_FullMethodName:        {},
+	hydrapb.HydraideService_IncrementFloat32_FullMethodName:       {},
+	hydrapb.HydraideService_IncrementFloat64_FullMethodName:       {},
+	hydrapb.HydraideService_SetIfLater_FullMethodName:             {},
+	hydrapb.HydraideService_AddDuration_FullMethodName:            {},
+	hydrapb.HydraideService_SetStringIf_FullMethodName:            {},
+	hydrapb.HydraideService_SetBoolIf_FullMethodName:              {},
+	hydrapb.HydraideService_Uint32SliceCombineInto_FullMethodName: {},
+	hydrapb.HydraideService_SetSwampAnnotation_FullMethodName:     {},
+}
+
+// writeStreamMethods are the client streams whose messages count against the write bytes limit of the client
+var writeStreamMethods = map[string]struct{}{
+	hydrapb.HydraideService_SetLargeValue_FullMethodName: {},
+	hydrapb.HydraideService_PutBlob_FullMethodName:       {},
+}
+
+// unlimitedMethods are never rate limited, so the clients can always check if the server is alive
+var unlimitedMethods = map[string]struct{}{
+	hydrapb.HydraideService_Heartbeat_FullMethodName: {},
+}
+
+// checkRateLimit checks the limits of the client of the request, and returns a ResourceExhausted error with retry
+// info if the client exceeded any of its limits. Returns nil if the limiter is not configured.
+func checkRateLimit(ctx context.Context, limiter ratelimit.Limiter, fullMethod string, req interface{}) error {
+
+	if limiter == nil {
+		return nil
+	}
+	if _, ok := unlimitedMethods[fullMethod]; ok {
+		return nil
+	}
+
+	writeBytes := 0
+	if _, ok := writeMethods[fullMethod]; ok {
+		if message, ok := req.(proto.Message); ok {
+			writeBytes = proto.Size(message)
+		}
+	}
+
+	decision := limiter.Allow(ratelimit.ClientIdentity(ctx), writeBytes)
+	if decision.Allowed {
+		return nil
+	}
+
+	// the retry policy of the SDK retries the call after the wait time instead of its own backoff
+	gateway.SetRetryPushback(ctx, decision.RetryAfter)
+
+	return gateway.QuotaExceededError(fmt.Sprintf("%s, retry after %s", decision.Reason, decision.RetryAfter), decision.RetryAfter)
+
+}
+
+// rateLimitStreamInterceptor checks the limits of the client at the start of the stream, like the unary requests.
+// The messages of the write streams are charged to the write bytes limit of the client one by one, and a message
+// over the limit waits until the client has enough bytes again, so a large value is throttled instead of rejected
+// halfway.
+func rateLimitStreamInterceptor(limiter ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkRateLimit(ss.Context(), limiter, info.FullMethod, nil); err != nil {
+			return err
+		}
+		if _, ok := writeStreamMethods[info.FullMethod]; ok {
+			ss = &rateLimitedStream{ServerStream: ss, limiter: limiter, identity: ratelimit.ClientIdentity(ss.Context())}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// rateLimitedStream charges the received messages of the stream to the write bytes limit of the client
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter  ratelimit.Limiter
+	identity string
+}
+
+func (s *rateLimitedStream) RecvMsg(m interface{}) error {
+
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	message, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	size := proto.Size(message)
+	for {
+		decision := s.limiter.AllowBytes(s.identity, size)
+		if decision.Allowed {
+			return nil
+		}
+		timer := time.NewTimer(decision.RetryAfter)
+		select {
+		case <-timer.C:
+		case <-s.Context().Done():
+			timer.Stop()
+			return status.FromContextError(s.Context().Err()).Err()
+		}
+	}
+
+}
+
+// checkResponseSize returns a ResourceExhausted error with the MESSAGE_TOO_LARGE reason if the response is larger
+// than the max message size, instead of the opaque error of gRPC the client gets if the response can not be sent.
+// The error contains the actual and the max size, and the client is told not to retry, because the same request
+// would get the same response. Returns nil if the max message size is not set.
+func checkResponseSize(ctx context.Context, maxMessageSize int, fullMethod string, resp interface{}) error {
+
+	if maxMessageSize <= 0 {
+		return nil
+	}
+
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	size := proto.Size(message)
+	if size <= maxMessageSize {
+		return nil
+	}
+
+	gateway.SetRetryPushback(ctx, -1)
+
+	return gateway.MessageTooLargeError(fmt.Sprintf("the response of %s is %d bytes, larger than the max message size of %d bytes (GRPC_MAX_MESSAGE_SIZE), read less Treasures in one request or raise the limit on the server and the client",
+		fullMethod, size, maxMessageSize))
+
+}
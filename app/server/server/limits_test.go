@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestCheckRateLimit(t *testing.T) {
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ratelimit.MetadataClientID, "importer"))
+
+	t.Run("should allow everything without limiter", func(t *testing.T) {
+		assert.NoError(t, checkRateLimit(ctx, nil, hydrapb.HydraideService_Set_FullMethodName, &hydrapb.SetRequest{}))
+	})
+
+	limiter := ratelimit.New(&ratelimit.Configuration{
+		Default: ratelimit.Limits{RequestsPerSecond: 1},
+	})
+
+	t.Run("should reject the client over its limit with retry info", func(t *testing.T) {
+		require.NoError(t, checkRateLimit(ctx, limiter, hydrapb.HydraideService_Get_FullMethodName, &hydrapb.GetRequest{}))
+
+		err := checkRateLimit(ctx, limiter, hydrapb.HydraideService_Get_FullMethodName, &hydrapb.GetRequest{})
+		require.Error(t, err)
+
+		s, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.ResourceExhausted, s.Code())
+
+		var retryInfo *errdetails.RetryInfo
+		var errorInfo *errdetails.ErrorInfo
+		for _, detail := range s.Details() {
+			switch d := detail.(type) {
+			case *errdetails.RetryInfo:
+				retryInfo = d
+			case *errdetails.ErrorInfo:
+				errorInfo = d
+			}
+		}
+		require.NotNil(t, retryInfo)
+		assert.Positive(t, retryInfo.GetRetryDelay().AsDuration())
+		require.NotNil(t, errorInfo)
+		assert.Equal(t, hydrapb.ErrorReason_QUOTA_EXCEEDED.String(), errorInfo.GetReason())
+	})
+
+	t.Run("should never limit the heartbeat", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, checkRateLimit(ctx, limiter, hydrapb.HydraideService_Heartbeat_FullMethodName, &hydrapb.HeartbeatRequest{}))
+		}
+	})
+
+}
+
+func TestCheckResponseSize(t *testing.T) {
+
+	value := string(make([]byte, 200))
+	resp := &hydrapb.GetResponse{Swamps: []*hydrapb.GetSwampResponse{
+		{SwampName: "users/profiles/alex", Treasures: []*hydrapb.Treasure{{Key: "a", IsExist: true, StringVal: &value}}},
+	}}
+
+	t.Run("should allow everything without max message size", func(t *testing.T) {
+		assert.NoError(t, checkResponseSize(context.Background(), 0, hydrapb.HydraideService_Get_FullMethodName, resp))
+	})
+
+	t.Run("should allow the response under the max message size", func(t *testing.T) {
+		assert.NoError(t, checkResponseSize(context.Background(), 1024, hydrapb.HydraideService_Get_FullMethodName, resp))
+	})
+
+	t.Run("should reject the response over the max message size with the sizes", func(t *testing.T) {
+
+		err := checkResponseSize(context.Background(), 100, hydrapb.HydraideService_Get_FullMethodName, resp)
+		require.Error(t, err)
+
+		s, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.ResourceExhausted, s.Code())
+		assert.Contains(t, s.Message(), "larger than the max message size of 100 bytes")
+
+		var errorInfo *errdetails.ErrorInfo
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok {
+				errorInfo = info
+			}
+		}
+		require.NotNil(t, errorInfo)
+		assert.Equal(t, hydrapb.ErrorReason_MESSAGE_TOO_LARGE.String(), errorInfo.GetReason())
+
+	})
+
+}
+
+func TestRateLimitStreamInterceptor(t *testing.T) {
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ratelimit.MetadataClientID, "importer"))
+	info := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_SetLargeValue_FullMethodName, IsClientStream: true}
+	chunks := func() []*hydrapb.SetLargeValueRequest {
+		return []*hydrapb.SetLargeValueRequest{
+			{SwampName: "files/documents/contract", KeyValue: &hydrapb.KeyValuePair{Key: "pdf"}, Chunk: make([]byte, 1500)},
+			{Chunk: make([]byte, 1500)},
+		}
+	}
+	receiveAll := func(srv interface{}, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(&hydrapb.SetLargeValueRequest{}); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	t.Run("should reject the stream of the client over its request limit", func(t *testing.T) {
+		interceptor := rateLimitStreamInterceptor(ratelimit.New(&ratelimit.Configuration{
+			Default: ratelimit.Limits{RequestsPerSecond: 1},
+		}))
+		require.NoError(t, interceptor(nil, &accessLogTestStream{ctx: ctx, messages: chunks()}, info, receiveAll))
+
+		err := interceptor(nil, &accessLogTestStream{ctx: ctx, messages: chunks()}, info, receiveAll)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("should throttle the chunks over the write bytes limit", func(t *testing.T) {
+		interceptor := rateLimitStreamInterceptor(ratelimit.New(&ratelimit.Configuration{
+			Default: ratelimit.Limits{WriteBytesPerSecond: 10000, WriteBytesBurst: 2000},
+		}))
+
+		// the second chunk waits until the bucket is refilled with its size
+		started := time.Now()
+		require.NoError(t, interceptor(nil, &accessLogTestStream{ctx: ctx, messages: chunks()}, info, receiveAll))
+		assert.GreaterOrEqual(t, time.Since(started), 50*time.Millisecond)
+	})
+
+	t.Run("should stop waiting for the write bytes when the stream is canceled", func(t *testing.T) {
+		interceptor := rateLimitStreamInterceptor(ratelimit.New(&ratelimit.Configuration{
+			Default: ratelimit.Limits{WriteBytesPerSecond: 1, WriteBytesBurst: 2000},
+		}))
+
+		canceledCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		err := interceptor(nil, &accessLogTestStream{ctx: canceledCtx, messages: chunks()}, info, receiveAll)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("should not charge the bytes of the read streams", func(t *testing.T) {
+		interceptor := rateLimitStreamInterceptor(ratelimit.New(&ratelimit.Configuration{
+			Default: ratelimit.Limits{WriteBytesPerSecond: 1, WriteBytesBurst: 1},
+		}))
+		readInfo := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_GetAllStream_FullMethodName}
+		require.NoError(t, interceptor(nil, &accessLogTestStream{ctx: ctx, messages: chunks()}, readInfo, receiveAll))
+	})
+
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/audit"
+	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/app/server/migration"
+	"github.com/hydraide/hydraide/app/server/requestinfo"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// migratingRetryAfter is the time the clients wait before they retry a write refused by a frozen island. The
+// islands are frozen only while their files are copied, so the retry is soon
+const migratingRetryAfter = time.Second
+
+// migrationMethods are the RPCs of the migration itself, they are served whatever the state of their island is
+var migrationMethods = map[string]struct{}{
+	hydrapb.HydraideService_SetIslandState_FullMethodName:     {},
+	hydrapb.HydraideService_ExportIsland_FullMethodName:       {},
+	hydrapb.HydraideService_ImportIsland_FullMethodName:       {},
+	hydrapb.HydraideService_SetClusterTopology_FullMethodName: {},
+	hydrapb.HydraideService_GetClusterTopology_FullMethodName: {},
+}
+
+// checkIslandState returns an error if an island of the request is migrated: the writes of a frozen island are
+// refused with a retryable error, and every request of a moved island is refused with the host of its new server,
+// which the client must not retry on this server
+func checkIslandState(ctx context.Context, islands migration.Islands, fullMethod string, req interface{}) error {
+
+	if _, ok := migrationMethods[fullMethod]; ok {
+		return nil
+	}
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	for _, islandID := range requestinfo.Summarize(message).IslandIDs {
+		island := islands.Get(islandID)
+		switch island.State {
+		case migration.StateMoved:
+			gateway.SetRetryPushback(ctx, -1)
+			return gateway.IslandMovedError(islandID, island.MovedTo)
+		case migration.StateFrozen:
+			if audit.IsMutating(fullMethod) {
+				gateway.SetRetryPushback(ctx, migratingRetryAfter)
+				return gateway.IslandMigratingError(islandID)
+			}
+		default:
+		}
+	}
+
+	return nil
+
+}
+
+// migrationUnaryInterceptor refuses the requests of the migrated islands
+func migrationUnaryInterceptor(islands migration.Islands) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkIslandState(ctx, islands, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// migrationStreamInterceptor refuses the streams of the migrated islands. The island of a stream is in its received
+// messages, so they are checked one by one
+func migrationStreamInterceptor(islands migration.Islands) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &migrationStream{ServerStream: ss, islands: islands, fullMethod: info.FullMethod})
+	}
+}
+
+// migrationStream checks the island state of every received message
+type migrationStream struct {
+	grpc.ServerStream
+	islands    migration.Islands
+	fullMethod string
+}
+
+func (s *migrationStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkIslandState(s.Context(), s.islands, s.fullMethod, m)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/migration"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestCheckIslandState(t *testing.T) {
+
+	ctx := context.Background()
+	islands, err := migration.Open(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, islands.Set(2, migration.Island{State: migration.StateFrozen}))
+	require.NoError(t, islands.Set(3, migration.Island{State: migration.StateMoved, MovedTo: "hydra02:4444"}))
+
+	set := func(islandID uint64) *hydrapb.SetRequest {
+		return &hydrapb.SetRequest{Swamps: []*hydrapb.SwampRequest{{IslandID: islandID, SwampName: "users/profiles/alex"}}}
+	}
+	get := func(islandID uint64) *hydrapb.GetRequest {
+		return &hydrapb.GetRequest{Swamps: []*hydrapb.GetSwamp{{IslandID: islandID, SwampName: "users/profiles/alex"}}}
+	}
+	reason := func(err error) (codes.Code, string, string) {
+		s, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Len(t, s.Details(), 1)
+		return s.Code(), s.Details()[0].(*errdetails.ErrorInfo).GetReason(), s.Message()
+	}
+
+	t.Run("should serve the active islands", func(t *testing.T) {
+		assert.NoError(t, checkIslandState(ctx, islands, hydrapb.HydraideService_Set_FullMethodName, set(1)))
+		assert.NoError(t, checkIslandState(ctx, islands, hydrapb.HydraideService_Get_FullMethodName, get(1)))
+	})
+
+	t.Run("should refuse only the writes of the frozen islands", func(t *testing.T) {
+		code, errorReason, _ := reason(checkIslandState(ctx, islands, hydrapb.HydraideService_Set_FullMethodName, set(2)))
+		assert.Equal(t, codes.Unavailable, code)
+		assert.Equal(t, hydrapb.ErrorReason_ISLAND_MIGRATING.String(), errorReason)
+		assert.NoError(t, checkIslandState(ctx, islands, hydrapb.HydraideService_Get_FullMethodName, get(2)))
+	})
+
+	t.Run("should refuse every request of the moved islands with the new host", func(t *testing.T) {
+		for method, req := range map[string]interface{}{
+			hydrapb.HydraideService_Set_FullMethodName: set(3),
+			hydrapb.HydraideService_Get_FullMethodName: get(3),
+		} {
+			code, errorReason, message := reason(checkIslandState(ctx, islands, method, req))
+			assert.Equal(t, codes.FailedPrecondition, code, method)
+			assert.Equal(t, hydrapb.ErrorReason_ISLAND_MOVED.String(), errorReason, method)
+			assert.Contains(t, message, "hydra02:4444", method)
+		}
+	})
+
+	t.Run("should serve the migration itself", func(t *testing.T) {
+		assert.NoError(t, checkIslandState(ctx, islands, hydrapb.HydraideService_ExportIsland_FullMethodName, &hydrapb.ExportIslandRequest{IslandID: 2}))
+		assert.NoError(t, checkIslandState(ctx, islands, hydrapb.HydraideService_SetIslandState_FullMethodName, &hydrapb.SetIslandStateRequest{IslandID: 3}))
+	})
+
+}
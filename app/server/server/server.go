@@ -5,22 +5,47 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/core/hydra/hydration"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/flushstall"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/keylock"
 	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/core/settings/defaults"
+	"github.com/hydraide/hydraide/app/core/settings/setting"
 	"github.com/hydraide/hydraide/app/core/zeus"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/hydraide/hydraide/app/server/audit"
+	"github.com/hydraide/hydraide/app/server/backup"
+	"github.com/hydraide/hydraide/app/server/certreloader"
+	"github.com/hydraide/hydraide/app/server/cluster"
 	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/hydraide/hydraide/app/server/migration"
 	"github.com/hydraide/hydraide/app/server/observer"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	"github.com/hydraide/hydraide/app/server/restgateway"
+	"github.com/hydraide/hydraide/app/server/telemetry"
+	"github.com/hydraide/hydraide/app/server/tenancy"
+	"github.com/hydraide/hydraide/app/server/tracing"
+	"github.com/hydraide/hydraide/app/server/wal"
 	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	// registers the gzip and the zstd compressors, so the server accepts the compressed messages of the clients
+	_ "github.com/hydraide/hydraide/sdk/go/hydraidego/compression"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"log/slog"
+	"maps"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"sync"
 	"time"
 )
@@ -31,15 +56,92 @@ const (
 )
 
 type Configuration struct {
+	// RootPath is the folder of the data and the settings of the server. Empty means the HYDRAIDE_ROOT_PATH
+	// environment variable
+	RootPath           string
 	CertificateCrtFile string // Server CRT file path
 	CertificateKeyFile string // Server Key file path
+	// CertificateReloadInterval is the interval between two checks of the certificate files. The changed
+	// certificate is reloaded without restarting the server. Zero means certreloader.DefaultCheckInterval
+	CertificateReloadInterval time.Duration
 	// Hydra settings
-	HydraServerPort       int   // the port where the hydra server listens
-	HydraMaxMessageSize   int   // the maximum message size in bytes
-	DefaultCloseAfterIdle int64 // the default close after idle time in seconds
-	DefaultWriteInterval  int64 // the default write interval time in seconds
-	DefaultFileSize       int64 // the default file size in bytes
-	SystemResourceLogging bool  // if true, the system resource usage is logged
+	HydraServerPort        int                 // the port where the hydra server listens
+	HydraMaxMessageSize    int                 // the maximum message size in bytes
+	DefaultCloseAfterIdle  int64               // the default close after idle time in seconds
+	DefaultWriteInterval   int64               // the default write interval time in seconds
+	DefaultFileSize        int64               // the default file size in bytes
+	DefaultFsyncPolicy     setting.FsyncPolicy // the fsync policy of the patterns registered without one, empty means never
+	DefaultFsyncInterval   int64               // the fsync interval in seconds of the patterns registered without one
+	SystemResourceLogging  bool                // if true, every sample of the resource usage is logged
+	GrpcServerErrorLogging bool                // if true, the errors returned to the clients are logged
+	// ApplyDefaultsToUnregisteredSwamps makes the swamps without a registered pattern use the default settings above
+	// instead of the built-in ones
+	ApplyDefaultsToUnregisteredSwamps bool
+	// DefaultsReloader returns the default swamp settings read again from the configuration, used by ReloadDefaults.
+	// Nil means the defaults can not be reloaded
+	DefaultsReloader func() (defaults.Values, error)
+	// RateLimit is the rate limit configuration of the clients. Nil means the clients are not rate limited
+	RateLimit *ratelimit.Configuration
+	// MaxTreasuresPerSwamp is the maximum number of treasures in one swamp. Zero means unlimited
+	MaxTreasuresPerSwamp int
+	// MaxKeyLength is the maximum length of a written key in bytes. Zero means unlimited
+	MaxKeyLength int
+	// MaxValueSize is the maximum size of a written bytes, string or uint32 slice value in bytes. Zero means unlimited
+	MaxValueSize int64
+	// FailOnCorruptedFiles makes the loading of a swamp fail if one of its files is corrupted. By default, the
+	// corrupted files are skipped, so the swamp is served without their treasures. The corrupted files are listed by
+	// the ListCorruptedFiles RPC in both cases
+	FailOnCorruptedFiles bool
+	// WriteBatchSize is the max number of treasures a swamp writes to the disk at once. Zero means all waiting treasures
+	WriteBatchSize int
+	// MaxConcurrentHydrations is the max number of swamps loaded from the disk at the same time, shared by all
+	// tenants. The waiting interactive requests are served before the background scans. Zero means unlimited
+	MaxConcurrentHydrations int
+	// Tracing is the OpenTelemetry tracing configuration. Nil means the RPCs are not traced
+	Tracing *tracing.Configuration
+	// SlowOperationThreshold is the duration above the Set, Get, GetByIndex and Delete operations are logged as slow.
+	// Zero means the slow operations are not logged
+	SlowOperationThreshold time.Duration
+	// AccessLog is the configuration of the access log, which logs one record per RPC. Nil means the RPCs are not
+	// logged
+	AccessLog *AccessLogConfiguration
+	// Metrics is the registry of the metrics of the server. Nil means the server uses its own registry
+	Metrics metrics.Registry
+	// RestGateway is the configuration of the HTTP/JSON gateway. Nil means the gateway is not started
+	RestGateway *restgateway.Configuration
+	// Tenancy is the configuration of the tenants. Nil means the server is single-tenant. If set, every request
+	// must authenticate with the token of a tenant, and the data of every tenant lives under its own root path
+	Tenancy *tenancy.Configuration
+	// Version is the release version of the server, reported to the clients by the Heartbeat
+	Version string
+	// TelemetrySampleInterval is the time between two samples of the resource usage. Zero means
+	// telemetry.DefaultSampleInterval
+	TelemetrySampleInterval time.Duration
+	// MinFreeDiskPercent is the free disk percent of the island roots below the writes are refused with the
+	// INSUFFICIENT_STORAGE reason. The reads and the deletes still work. Zero means the writes are never refused
+	MinFreeDiskPercent float64
+	// WarnFreeDiskPercent is the free disk percent of the island roots below the server is reported as degraded in
+	// the logs, the metrics and the readiness checks, while the writes are still accepted. Zero means no warning
+	WarnFreeDiskPercent float64
+	// Connection is the tuning of the gRPC connections: keepalive, flow control windows and the max concurrent
+	// streams. Nil means the defaults
+	Connection *ConnectionConfiguration
+	// Audit is the configuration of the audit log of the mutating requests. Nil means the requests are not audited.
+	// The empty folder means the audit folder under the root path
+	Audit *audit.Configuration
+	// Backup is the configuration of the scheduled backups to S3. Nil means the swamps are not backed up. The empty
+	// root path means the root path of the server, and the nil targets mean the main hydra and the tenants
+	Backup *backup.Configuration
+	// WAL is the configuration of the write-ahead log of the changes. Nil means the changes are not logged. The
+	// empty folder means the wal folder under the root path. The point-in-time restore needs the backups and the
+	// write-ahead log, too
+	WAL *wal.Configuration
+	// ClusterTopologyFile is the path of the topology file of the cluster, served to the clients by the
+	// GetClusterTopology RPC, so they can discover the servers from any seed server. Empty means it is not served
+	ClusterTopologyFile string
+	// ClusterTopologyInterval is the interval between two checks of the topology file. Zero means
+	// cluster.DefaultCheckInterval
+	ClusterTopologyInterval time.Duration
 }
 
 type Server interface {
@@ -49,6 +151,13 @@ type Server interface {
 	Stop()
 	// IsHydraRunning returns true if the hydra server is running
 	IsHydraRunning() bool
+	// CheckReadiness runs the readiness checks (TLS, gRPC listener, settings store, island folders)
+	// and returns the result of each check
+	CheckReadiness() []HealthCheck
+	// ReloadDefaults reloads the default swamp settings by the DefaultsReloader of the configuration. The patterns
+	// registered and the swamps opened after it get the new defaults, the open swamps keep their settings. If the
+	// reload fails, the previous defaults stay in effect.
+	ReloadDefaults() (defaults.Values, error)
 }
 
 type server struct {
@@ -59,6 +168,23 @@ type server struct {
 	grpcServer         *grpc.Server
 	zeusInterface      zeus.Zeus
 	observerInterface  observer.Observer
+	settingsInterface  settings.Settings
+	certReloader       certreloader.CertReloader
+	health             healthState
+	tracingShutdown    func(context.Context) error
+	restServer         *http.Server
+	tenantZeus         map[string]zeus.Zeus
+	tenantDataFolders  []string
+	hydrationScheduler hydration.Scheduler
+	keyLockCounters    *keylock.Counters
+	flushStallCounters *flushstall.Counters
+	swampDefaults      *defaults.Defaults
+	telemetry          telemetry.Telemetry
+	auditLog           audit.Log
+	backupScheduler    backup.Scheduler
+	walLog             wal.Log
+	consistency        *consistencyTracker
+	islands            migration.Islands
 }
 
 func New(configuration *Configuration) Server {
@@ -85,23 +211,222 @@ func (s *server) Start() error {
 	s.serverRunning = true
 	s.mu.Unlock()
 
-	settingsInterface := settings.New(maxDepth, foldersPerLevel)
-	s.zeusInterface = zeus.New(settingsInterface, filesystem.New())
+	if s.configuration.Tenancy != nil {
+		if err := s.configuration.Tenancy.Validate(); err != nil {
+			s.mu.Lock()
+			s.serverRunning = false
+			s.mu.Unlock()
+			return fmt.Errorf("invalid tenancy configuration: %w", err)
+		}
+	}
+
+	if s.configuration.Metrics == nil {
+		s.configuration.Metrics = metrics.New()
+	}
+
+	// the states of the islands are loaded before any request, so a moved island is never served again. The tenants
+	// have their own islands under their own root paths, they can not be migrated
+	if s.configuration.Tenancy == nil {
+		islands, err := migration.Open(s.rootPath())
+		if err != nil {
+			s.mu.Lock()
+			s.serverRunning = false
+			s.mu.Unlock()
+			return fmt.Errorf("can not load the migration states of the islands: %w", err)
+		}
+		s.islands = islands
+	}
+
+	// the server does not start without its audit log, so no request is left out of it
+	if s.configuration.Audit != nil {
+		auditConfiguration := *s.configuration.Audit
+		if auditConfiguration.Folder == "" {
+			auditConfiguration.Folder = filepath.Join(s.rootPath(), "audit")
+		}
+		if auditConfiguration.Metrics == nil {
+			auditConfiguration.Metrics = s.configuration.Metrics
+		}
+		auditLog, err := audit.New(&auditConfiguration)
+		if err != nil {
+			s.mu.Lock()
+			s.serverRunning = false
+			s.mu.Unlock()
+			return fmt.Errorf("can not open the audit log: %w", err)
+		}
+		s.auditLog = auditLog
+	}
+
+	// one scheduler for the main and the tenant hydras, because they share the same disk
+	s.hydrationScheduler = hydration.New(s.configuration.MaxConcurrentHydrations)
+	registerHydrationMetrics(s.configuration.Metrics, s.hydrationScheduler)
+	s.keyLockCounters = &keylock.Counters{}
+	registerKeyLockMetrics(s.configuration.Metrics, s.keyLockCounters)
+	s.flushStallCounters = &flushstall.Counters{}
+	registerFlushStallMetrics(s.configuration.Metrics, s.flushStallCounters)
+
+	// the invalid backup configuration is refused before any swamp is opened
+	if s.configuration.Backup != nil {
+		backupScheduler, err := s.newBackupScheduler()
+		if err != nil {
+			s.mu.Lock()
+			s.serverRunning = false
+			s.mu.Unlock()
+			if s.auditLog != nil {
+				_ = s.auditLog.Close()
+			}
+			return fmt.Errorf("can not create the backup scheduler: %w", err)
+		}
+		s.backupScheduler = backupScheduler
+	}
+
+	// the write-ahead log is opened before the hydras, so it does not miss any change
+	if s.configuration.WAL != nil {
+		walConfiguration := *s.configuration.WAL
+		if walConfiguration.Folder == "" {
+			walConfiguration.Folder = filepath.Join(s.rootPath(), "wal")
+		}
+		if walConfiguration.Metrics == nil {
+			walConfiguration.Metrics = s.configuration.Metrics
+		}
+		walLog, err := wal.New(&walConfiguration)
+		if err != nil {
+			s.mu.Lock()
+			s.serverRunning = false
+			s.mu.Unlock()
+			if s.auditLog != nil {
+				_ = s.auditLog.Close()
+			}
+			return fmt.Errorf("can not open the write-ahead log: %w", err)
+		}
+		s.walLog = walLog
+	}
+
+	// the server still serves the clients without the consistency tokens, only the tokens of its writes are missing
+	if tracker, err := openConsistencyTracker(s.rootPath(), time.Now()); err != nil {
+		slog.Error("can not open the consistency state, the writes do not return consistency tokens", "error", err)
+	} else {
+		s.consistency = tracker
+	}
+
+	// the defaults are shared by the main server and the tenants, so a reload changes them everywhere
+	swampDefaults := defaults.New(defaults.Values{
+		CloseAfterIdleSec:         s.configuration.DefaultCloseAfterIdle,
+		WriteIntervalSec:          s.configuration.DefaultWriteInterval,
+		MaxFileSizeByte:           s.configuration.DefaultFileSize,
+		FsyncPolicy:               s.configuration.DefaultFsyncPolicy,
+		FsyncIntervalSec:          s.configuration.DefaultFsyncInterval,
+		ApplyToUnregisteredSwamps: s.configuration.ApplyDefaultsToUnregisteredSwamps,
+	})
+
+	settingsInterface := settings.NewWithRootPath(s.rootPath(), maxDepth, foldersPerLevel)
+	settingsInterface.SetWriteBatchSize(s.configuration.WriteBatchSize)
+	settingsInterface.SetHydrationScheduler(s.hydrationScheduler)
+	settingsInterface.SetKeyLockCounters(s.keyLockCounters)
+	settingsInterface.SetFlushStallCounters(s.flushStallCounters)
+	settingsInterface.SetDefaults(swampDefaults)
+	s.mu.Lock()
+	s.settingsInterface = settingsInterface
+	s.swampDefaults = swampDefaults
+	s.mu.Unlock()
+
+	s.zeusInterface = zeus.New(settingsInterface, s.newFilesystem())
 	s.zeusInterface.StartHydra()
+	s.recordChanges(s.zeusInterface.GetHydra(), "")
 
 	var ctx context.Context
 	ctx, s.observerCancelFunc = context.WithCancel(context.Background())
-	s.observerInterface = observer.New(ctx, s.configuration.SystemResourceLogging)
+	s.observerInterface = observer.New()
+
+	// load the cert and key files for the server and watch them for changes. The watcher stops with the observer
+	certReloader := certreloader.New(s.configuration.CertificateCrtFile, s.configuration.CertificateKeyFile, s.configuration.CertificateReloadInterval)
+	certReloader.Start(ctx)
+	s.mu.Lock()
+	s.certReloader = certReloader
+	s.mu.Unlock()
 
 	grpcServer := gateway.Gateway{
-		ObserverInterface:     s.observerInterface,
-		SettingsInterface:     settingsInterface,
-		ZeusInterface:         s.zeusInterface,
-		DefaultCloseAfterIdle: s.configuration.DefaultCloseAfterIdle,
-		DefaultWriteInterval:  s.configuration.DefaultWriteInterval,
-		DefaultFileSize:       s.configuration.DefaultFileSize,
+		ObserverInterface:    s.observerInterface,
+		SettingsInterface:    settingsInterface,
+		ZeusInterface:        s.zeusInterface,
+		MaxTreasuresPerSwamp: s.configuration.MaxTreasuresPerSwamp,
+		MaxKeyLength:         s.configuration.MaxKeyLength,
+		MaxValueSize:         s.configuration.MaxValueSize,
+		Metrics:              s.configuration.Metrics,
+		Version:              s.configuration.Version,
+		AuditLog:             s.auditLog,
+		Recovery:             s.newRecovery(),
+		Islands:              s.islands,
+	}
+	if s.configuration.DefaultsReloader != nil {
+		grpcServer.DefaultsReloader = s.ReloadDefaults
+	}
+
+	// the topology file is watched like the certificates, the watcher stops with the observer
+	if s.configuration.ClusterTopologyFile != "" {
+		topology := cluster.NewSource(s.configuration.ClusterTopologyFile, s.configuration.ClusterTopologyInterval)
+		topology.Start(ctx)
+		grpcServer.Topology = topology
+	}
+
+	// every tenant has its own hydra under its own root path, and the router sends the requests to its gateway
+	var tenantRouter tenancy.Router
+	if s.configuration.Tenancy != nil {
+		tenantRouter = s.startTenants(&grpcServer)
+	}
+
+	// the resource usage is sampled until the observer stops
+	t := s.startTelemetry(ctx, settingsInterface)
+	s.mu.Lock()
+	s.telemetry = t
+	s.mu.Unlock()
+
+	// the backups run until the server stops
+	if s.backupScheduler != nil {
+		s.backupScheduler.Start(ctx)
+	}
+
+	// the write-ahead log is synced and its old segments are deleted until the server stops
+	if s.walLog != nil {
+		s.walLog.Start(ctx)
 	}
 
+	// the idle clients of the limiter are cleaned up until the observer stops
+	var limiter ratelimit.Limiter
+	if s.configuration.RateLimit != nil {
+		limiter = ratelimit.New(s.configuration.RateLimit)
+		limiter.Start(ctx)
+	}
+
+	// the tracing interceptor runs first, so the rejected requests are traced, too
+	var interceptors []grpc.UnaryServerInterceptor
+	if s.configuration.Tracing != nil {
+		shutdown, err := tracing.Setup(ctx, s.configuration.Tracing)
+		if err != nil {
+			slog.Error("can not set up the tracing, the RPCs are not traced", "error", err)
+		} else {
+			s.tracingShutdown = shutdown
+			interceptors = append(interceptors, tracing.UnaryServerInterceptor())
+		}
+	}
+
+	// the tenant must be known before the rate limiter, because the tenant is the identity of the client
+	if tenantRouter != nil {
+		interceptors = append(interceptors, tenantRouter.AuthInterceptor())
+	}
+
+	// the audit log records the requests rejected by the rate limiter and the disk guard, too
+	if s.auditLog != nil {
+		interceptors = append(interceptors, audit.UnaryServerInterceptor(s.auditLog))
+	}
+
+	// the access log records the rejected requests, too, with the tenant of the client
+	accessLog := newAccessLog(s.configuration.AccessLog)
+	if accessLog != nil {
+		interceptors = append(interceptors, accessLogUnaryInterceptor(accessLog))
+	}
+
+	slowLog := newSlowOperationLog(s.configuration.SlowOperationThreshold, s.configuration.Metrics)
+
 	unaryInterceptor := func(
 		ctx context.Context,
 		req interface{},
@@ -117,10 +442,27 @@ func (s *server) Start() error {
 			}
 		}
 
-		resp, err := handler(ctx, req)
+		// reject the request before it reaches the gateway if the client exceeded its limits
+		var resp interface{}
+		err := checkRateLimit(ctx, limiter, info.FullMethod, req)
+		if err == nil {
+			err = checkDiskSpace(ctx, s.telemetry, info.FullMethod)
+		}
+		if err == nil {
+			handlerCtx := slowLog.begin(ctx, info.FullMethod)
+			handlerCtx = hydration.WithPriority(handlerCtx, hydrationPriority(ctx, info.FullMethod))
+			started := time.Now()
+			resp, err = handler(handlerCtx, req)
+			slowLog.end(handlerCtx, info.FullMethod, req, time.Since(started))
+			if err == nil {
+				if err = checkResponseSize(ctx, s.configuration.HydraMaxMessageSize, info.FullMethod, resp); err != nil {
+					resp = nil
+				}
+			}
+		}
 		if err != nil {
 			// Logging GRPC Server error
-			if os.Getenv("GRPC_SERVER_ERROR_LOGGING") == "true" {
+			if s.configuration.GrpcServerErrorLogging {
 				if grpcErr, ok := status.FromError(err); ok {
 					switch grpcErr.Code() {
 					case codes.PermissionDenied:
@@ -161,6 +503,48 @@ func (s *server) Start() error {
 		}
 		return resp, err
 	}
+	// the consistency token is checked before the request and issued after the applied write
+	if s.consistency != nil {
+		interceptors = append(interceptors, consistencyUnaryInterceptor(s.consistency))
+	}
+	if s.islands != nil {
+		interceptors = append(interceptors, migrationUnaryInterceptor(s.islands))
+	}
+	interceptors = append(interceptors, unaryInterceptor)
+
+	// the router calls the gateway of the tenant instead of the registered gateway, so it must be the last one
+	var streamInterceptors []grpc.StreamServerInterceptor
+	// the tenant of the stream must be known before the audit log and the access log record it
+	if tenantRouter != nil && (s.auditLog != nil || accessLog != nil) {
+		streamInterceptors = append(streamInterceptors, tenantRouter.StreamAuthInterceptor())
+	}
+	if s.auditLog != nil {
+		streamInterceptors = append(streamInterceptors, audit.StreamServerInterceptor(s.auditLog))
+	}
+	if accessLog != nil {
+		streamInterceptors = append(streamInterceptors, accessLogStreamInterceptor(accessLog))
+	}
+	if limiter != nil {
+		streamInterceptors = append(streamInterceptors, rateLimitStreamInterceptor(limiter))
+	}
+	streamInterceptors = append(streamInterceptors, diskSpaceStreamInterceptor(s.telemetry))
+	streamInterceptors = append(streamInterceptors, hydrationStreamInterceptor())
+	if s.consistency != nil {
+		streamInterceptors = append(streamInterceptors, consistencyStreamInterceptor(s.consistency))
+	}
+	if s.islands != nil {
+		streamInterceptors = append(streamInterceptors, migrationStreamInterceptor(s.islands))
+	}
+	if tenantRouter != nil {
+		interceptors = append(interceptors, tenantRouter.RouteInterceptor())
+		streamInterceptors = append(streamInterceptors, tenantRouter.StreamInterceptor())
+	}
+
+	// the REST gateway calls the same service through the same interceptors, so its clients are limited, traced
+	// and logged like the gRPC clients
+	if s.configuration.RestGateway != nil {
+		s.startRestGateway(certReloader, &grpcServer, interceptors)
+	}
 
 	// start the main server and waiting for incoming requests
 	go func() {
@@ -179,30 +563,23 @@ func (s *server) Start() error {
 			slog.Error("can not create listener for the hydra server", "error", err)
 			panic("can not create listener for the hydra server")
 		}
+		s.health.listenerBound.Store(true)
 
-		// load cert and key files for the server
-		creds, err := credentials.NewServerTLSFromFile(s.configuration.CertificateCrtFile, s.configuration.CertificateKeyFile)
-		if err != nil {
-			slog.Error("failed to load TLS credentials", "error", err)
-			panic("failed to load TLS credentials")
-		}
-
-		kaParams := keepalive.ServerParameters{
-			// IF the connection is idle for 4 minutes, the server will send a keepalive ping.
-			Time: 4 * time.Minute,
-			// If there is no response to the keepalive ping within 20 seconds, the server will close the connection.
-			Timeout: 20 * time.Second,
-			// Maximum time a connection can be idle before it is closed.
-			MaxConnectionIdle: 5 * time.Minute,
-		}
+		// the certificate is served by the reloader, so the rotated certificates are used by the new connections
+		// without dropping the existing ones
+		creds := credentials.NewTLS(certReloader.TLSConfig())
 
-		s.grpcServer = grpc.NewServer(
+		serverOptions := []grpc.ServerOption{
 			grpc.Creds(creds),
 			grpc.MaxSendMsgSize(s.configuration.HydraMaxMessageSize),
 			grpc.MaxRecvMsgSize(s.configuration.HydraMaxMessageSize),
-			grpc.UnaryInterceptor(unaryInterceptor), // add the interceptor
-			grpc.KeepaliveParams(kaParams),          // keepalive parameters
-		)
+			grpc.ChainUnaryInterceptor(interceptors...),        // add the interceptors
+			grpc.ChainStreamInterceptor(streamInterceptors...), // the stream interceptors of the tenancy
+		}
+		// the keepalive, the flow control windows and the stream limit of the connections
+		serverOptions = append(serverOptions, s.configuration.Connection.serverOptions()...)
+
+		s.grpcServer = grpc.NewServer(serverOptions...)
 
 		// registering the server
 		hydrapb.RegisterHydraideServiceServer(s.grpcServer, &grpcServer)
@@ -212,6 +589,7 @@ func (s *server) Start() error {
 		if err = s.grpcServer.Serve(lis); err != nil {
 			slog.Error("can not start the HydrAIDE server", "error", err)
 		}
+		s.health.listenerBound.Store(false)
 
 	}()
 
@@ -219,6 +597,39 @@ func (s *server) Start() error {
 
 }
 
+// ReloadDefaults reloads the default swamp settings of the main server and the tenants
+func (s *server) ReloadDefaults() (defaults.Values, error) {
+
+	s.mu.RLock()
+	swampDefaults := s.swampDefaults
+	s.mu.RUnlock()
+
+	if swampDefaults == nil {
+		return defaults.Values{}, errors.New("the server is not started")
+	}
+	if s.configuration.DefaultsReloader == nil {
+		return defaults.Values{}, errors.New("the server has no configuration to reload the defaults from")
+	}
+
+	values, err := s.configuration.DefaultsReloader()
+	if err != nil {
+		slog.Error("can not reload the default swamp settings, the previous defaults stay in effect", "error", err)
+		return defaults.Values{}, err
+	}
+
+	swampDefaults.Set(values)
+	slog.Info("default swamp settings reloaded",
+		"closeAfterIdleSec", values.CloseAfterIdleSec,
+		"writeIntervalSec", values.WriteIntervalSec,
+		"maxFileSizeByte", values.MaxFileSizeByte,
+		"fsyncPolicy", values.FsyncPolicy,
+		"fsyncIntervalSec", values.FsyncIntervalSec,
+		"applyToUnregisteredSwamps", values.ApplyToUnregisteredSwamps)
+
+	return values, nil
+
+}
+
 // Stop stops the microservice gracefully
 func (s *server) Stop() {
 
@@ -233,6 +644,15 @@ func (s *server) Stop() {
 	s.serverRunning = false
 	s.mu.Unlock()
 
+	if s.restServer != nil {
+		// stops the REST gateway before the gRPC server, because it calls the same service
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := s.restServer.Shutdown(ctx); err != nil {
+			slog.Warn("can not stop the REST gateway gracefully", "error", err)
+		}
+		cancel()
+	}
+
 	if s.grpcServer != nil {
 		// stops the gRPC server gracefully because we don't want to get new requests from the crawler
 		s.grpcServer.GracefulStop()
@@ -245,13 +665,286 @@ func (s *server) Stop() {
 		slog.Info("all processes are finished in the background")
 	}
 
+	// the running backup is canceled, the swamps must not be frozen while the hydras stop
+	if s.backupScheduler != nil {
+		s.backupScheduler.Stop()
+	}
+
+	for tenantID, tenantZeus := range s.tenantZeus {
+		// stop the Hydra of the tenants gracefully, the same way as the main Hydra
+		tenantZeus.StopHydra()
+		slog.Info("HydrAIDE of the tenant stopped gracefully", "tenant", tenantID)
+	}
+
 	if s.zeusInterface != nil {
 		// stop the Hydra gracefully. This is a blocker function until all swamps are stopped gracefully
 		s.zeusInterface.StopHydra()
 		slog.Info("HydrAIDE server stopped gracefully. Program is exiting...")
 	}
 
+	// flush the remaining spans to the collector
+	if s.tracingShutdown != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := s.tracingShutdown(ctx); err != nil {
+			slog.Warn("can not flush the remaining spans", "error", err)
+		}
+		cancel()
+	}
+
+	// the writes of the run are on the disk, so the tokens of the run stay valid after the restart
+	if s.consistency != nil {
+		if err := s.consistency.close(); err != nil {
+			slog.Warn("can not save the clean stop of the server, the consistency tokens of this run will be refused", "error", err)
+		}
+	}
+
+	// close the write-ahead log after the last change of the hydras
+	if s.walLog != nil {
+		if err := s.walLog.Close(); err != nil {
+			slog.Warn("can not close the write-ahead log", "error", err)
+		}
+	}
+
+	// close the audit log after the last request
+	if s.auditLog != nil {
+		if err := s.auditLog.Close(); err != nil {
+			slog.Warn("can not close the audit log", "error", err)
+		}
+	}
+
 	// stop the observer's monitoring process
 	s.observerCancelFunc()
 
 }
+
+// startRestGateway starts the HTTPS listener of the REST gateway with the certificate of the gRPC server
+func (s *server) startRestGateway(certReloader certreloader.CertReloader, service hydrapb.HydraideServiceServer, interceptors []grpc.UnaryServerInterceptor) {
+
+	restConfiguration := *s.configuration.RestGateway
+	if restConfiguration.MaxBodySize == 0 {
+		restConfiguration.MaxBodySize = int64(s.configuration.HydraMaxMessageSize)
+	}
+
+	// the tenants use their own tokens for the REST gateway, too
+	if s.configuration.Tenancy != nil {
+		tokens := make(map[string]string, len(restConfiguration.Tokens)+len(s.configuration.Tenancy.Tenants))
+		for clientName, token := range restConfiguration.Tokens {
+			tokens[clientName] = token
+		}
+		for tenantID, tenant := range s.configuration.Tenancy.Tenants {
+			tokens[tenantID] = tenant.Token
+		}
+		restConfiguration.Tokens = tokens
+	}
+
+	restServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", restConfiguration.Port),
+		Handler:           restgateway.New(&restConfiguration, service, interceptors...),
+		TLSConfig:         certReloader.TLSConfig(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.mu.Lock()
+	s.restServer = restServer
+	s.mu.Unlock()
+
+	go func() {
+		slog.Info(fmt.Sprintf("HydrAIDE REST gateway is listening on port: %d", restConfiguration.Port))
+		// the certificate is served by the TLS config of the reloader, so the file arguments are empty
+		if err := restServer.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("can not start the HydrAIDE REST gateway", "error", err)
+		}
+	}()
+
+}
+
+// rootPath returns the root folder of the server, the configured one or the HYDRAIDE_ROOT_PATH by default
+func (s *server) rootPath() string {
+	if s.configuration.RootPath != "" {
+		return s.configuration.RootPath
+	}
+	return os.Getenv("HYDRAIDE_ROOT_PATH")
+}
+
+// newFilesystem creates the filesystem of a hydra with the corrupted file handling of the configuration
+func (s *server) newFilesystem() filesystem.Filesystem {
+	filesystemInterface := filesystem.New()
+	filesystemInterface.SetSkipCorruptedFiles(!s.configuration.FailOnCorruptedFiles)
+	return filesystemInterface
+}
+
+// startTelemetry starts the sampling of the resource usage. The island roots of the main and the tenant data folders
+// are sampled, and the open swamps of all hydras are summed
+func (s *server) startTelemetry(ctx context.Context, settingsInterface settings.Settings) telemetry.Telemetry {
+
+	s.mu.RLock()
+	hydras := []zeus.Zeus{s.zeusInterface}
+	for _, tenantZeus := range s.tenantZeus {
+		hydras = append(hydras, tenantZeus)
+	}
+	dataFolders := append([]string{settingsInterface.GetHydraAbsDataFolderPath()}, s.tenantDataFolders...)
+	s.mu.RUnlock()
+
+	t := telemetry.New(&telemetry.Configuration{
+		SampleInterval:      s.configuration.TelemetrySampleInterval,
+		MinFreeDiskPercent:  s.configuration.MinFreeDiskPercent,
+		WarnFreeDiskPercent: s.configuration.WarnFreeDiskPercent,
+		LogSamples:          s.configuration.SystemResourceLogging,
+		DataFolders: func() []string {
+			return dataFolders
+		},
+		OpenSwamps: func() int {
+			openSwamps := 0
+			for _, hydra := range hydras {
+				openSwamps += hydra.GetHydra().CountActiveSwamps()
+			}
+			return openSwamps
+		},
+	}, s.configuration.Metrics)
+
+	t.Start(ctx)
+
+	return t
+
+}
+
+// newBackupScheduler creates the backup scheduler of the main hydra and the tenants
+func (s *server) newBackupScheduler() (backup.Scheduler, error) {
+
+	backupConfiguration := *s.configuration.Backup
+	if backupConfiguration.RootPath == "" {
+		backupConfiguration.RootPath = s.rootPath()
+	}
+	if backupConfiguration.Metrics == nil {
+		backupConfiguration.Metrics = s.configuration.Metrics
+	}
+	backupConfiguration.HashFolderDepth = maxDepth
+	backupConfiguration.MaxFoldersPerLevel = foldersPerLevel
+	if backupConfiguration.Targets == nil {
+		backupConfiguration.Targets = func() []backup.Target {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			targets := []backup.Target{{Hydra: s.zeusInterface.GetHydra()}}
+			tenantIDs := slices.Sorted(maps.Keys(s.tenantZeus))
+			for _, tenantID := range tenantIDs {
+				targets = append(targets, backup.Target{Tenant: tenantID, Hydra: s.tenantZeus[tenantID].GetHydra()})
+			}
+			return targets
+		}
+	}
+
+	return backup.New(&backupConfiguration)
+
+}
+
+// recordChanges records the changes of all swamps of the hydra to the write-ahead log, if it is enabled
+func (s *server) recordChanges(hydraInterface hydra.Hydra, tenant string) {
+	if s.walLog == nil {
+		return
+	}
+	if err := hydraInterface.SubscribeToPatternEvents(uuid.New(), []name.Name{name.Load("*/*/*")}, s.walLog.Recorder(tenant)); err != nil {
+		slog.Error("can not record the changes to the write-ahead log", "tenant", tenant, "error", err)
+	}
+}
+
+// newRecovery creates the point-in-time restore of the main hydra and the tenants. It returns nil if the backups
+// or the write-ahead log are not enabled
+func (s *server) newRecovery() backup.Recovery {
+
+	if s.configuration.Backup == nil || s.walLog == nil {
+		return nil
+	}
+
+	bucket, err := backup.NewS3Bucket(&s.configuration.Backup.S3)
+	if err != nil {
+		slog.Error("can not create the bucket of the point-in-time restore, it is disabled", "error", err)
+		return nil
+	}
+
+	recovery, err := backup.NewRecovery(&backup.RecoveryConfiguration{
+		Bucket:     bucket,
+		Prefix:     s.configuration.Backup.Prefix,
+		WAL:        s.walLog,
+		TempFolder: s.configuration.Backup.TempFolder,
+		Hydra: func(tenant string, _ *backup.Manifest) (hydra.Hydra, error) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			if tenant == "" {
+				return s.zeusInterface.GetHydra(), nil
+			}
+			tenantZeus, ok := s.tenantZeus[tenant]
+			if !ok {
+				return nil, fmt.Errorf("the tenant %s of the backup does not exist on the server", tenant)
+			}
+			return tenantZeus.GetHydra(), nil
+		},
+	})
+	if err != nil {
+		slog.Error("can not create the point-in-time restore, it is disabled", "error", err)
+		return nil
+	}
+
+	return recovery
+
+}
+
+// startTenants starts the Hydra of every tenant under its own root path, and returns the router of the tenants.
+// The gateways of the tenants share the settings of the main gateway, except the limits of the tenant.
+func (s *server) startTenants(mainGateway *gateway.Gateway) tenancy.Router {
+
+	rootPath := s.rootPath()
+	services := make(map[string]hydrapb.HydraideServiceServer, len(s.configuration.Tenancy.Tenants))
+	tenantZeus := make(map[string]zeus.Zeus, len(s.configuration.Tenancy.Tenants))
+	tenantDataFolders := make([]string, 0, len(s.configuration.Tenancy.Tenants))
+
+	for tenantID, tenant := range s.configuration.Tenancy.Tenants {
+
+		tenantSettings := settings.NewWithRootPath(tenancy.RootPath(rootPath, tenantID), maxDepth, foldersPerLevel)
+		tenantSettings.SetWriteBatchSize(s.configuration.WriteBatchSize)
+		tenantSettings.SetHydrationScheduler(s.hydrationScheduler)
+		tenantSettings.SetKeyLockCounters(s.keyLockCounters)
+		tenantSettings.SetFlushStallCounters(s.flushStallCounters)
+		tenantSettings.SetDefaults(s.swampDefaults)
+		zeusInterface := zeus.New(tenantSettings, s.newFilesystem())
+		zeusInterface.StartHydra()
+		s.recordChanges(zeusInterface.GetHydra(), tenantID)
+		tenantZeus[tenantID] = zeusInterface
+		tenantDataFolders = append(tenantDataFolders, tenantSettings.GetHydraAbsDataFolderPath())
+
+		tenantGateway := *mainGateway
+		tenantGateway.SettingsInterface = tenantSettings
+		tenantGateway.ZeusInterface = zeusInterface
+		// the defaults are shared by every tenant, so only the operator of the server can reload them
+		tenantGateway.DefaultsReloader = nil
+		if mainGateway.AuditLog != nil {
+			// the tenants see only their own records
+			tenantGateway.AuditLog = audit.ForTenant(mainGateway.AuditLog, tenantID)
+		}
+		if mainGateway.Recovery != nil {
+			// the tenants restore only their own swamps
+			tenantGateway.Recovery = backup.RecoveryForTenant(mainGateway.Recovery, tenantID)
+		}
+		if tenant.MaxTreasuresPerSwamp > 0 {
+			tenantGateway.MaxTreasuresPerSwamp = tenant.MaxTreasuresPerSwamp
+		}
+		services[tenantID] = &tenantGateway
+
+		// the tenant is the identity of its clients at the rate limiter
+		if s.configuration.RateLimit != nil && !tenant.Limits.IsUnlimited() {
+			if s.configuration.RateLimit.Clients == nil {
+				s.configuration.RateLimit.Clients = make(map[string]ratelimit.Limits)
+			}
+			s.configuration.RateLimit.Clients[tenantID] = tenant.Limits
+		}
+
+		slog.Info("HydrAIDE tenant started", "tenant", tenantID, "rootPath", tenancy.RootPath(rootPath, tenantID))
+
+	}
+
+	s.mu.Lock()
+	s.tenantZeus = tenantZeus
+	s.tenantDataFolders = tenantDataFolders
+	s.mu.Unlock()
+
+	return tenancy.New(s.configuration.Tenancy, services)
+
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/hydraide/hydraide/app/server/requestinfo"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+	"time"
+)
+
+// slowOperationsMetric is the name of the counter of the slow operations, labeled by the method
+const slowOperationsMetric = "hydraide_slow_operations_total"
+
+// slowOperationMethods are the RPCs checked against the slow operation threshold, with their short names
+var slowOperationMethods = map[string]string{
+	hydrapb.HydraideService_Set_FullMethodName:        "Set",
+	hydrapb.HydraideService_Get_FullMethodName:        "Get",
+	hydrapb.HydraideService_GetByIndex_FullMethodName: "GetByIndex",
+	hydrapb.HydraideService_Delete_FullMethodName:     "Delete",
+}
+
+// slowOperationLog logs the operations that take longer than the threshold, and counts them per method
+type slowOperationLog struct {
+	threshold time.Duration
+	registry  metrics.Registry
+}
+
+// newSlowOperationLog creates the slow operation log. Returns nil if the threshold is not positive, so the log is
+// disabled
+func newSlowOperationLog(threshold time.Duration, registry metrics.Registry) *slowOperationLog {
+	if threshold <= 0 {
+		return nil
+	}
+	return &slowOperationLog{
+		threshold: threshold,
+		registry:  registry,
+	}
+}
+
+// begin prepares the context of a tracked operation, so the hydra can report if the operation loaded a swamp into
+// the memory. The context of the other methods is returned as is
+func (l *slowOperationLog) begin(ctx context.Context, fullMethod string) context.Context {
+	if l == nil {
+		return ctx
+	}
+	if _, ok := slowOperationMethods[fullMethod]; !ok {
+		return ctx
+	}
+	return hydra.WithHydrationTracking(ctx)
+}
+
+// end logs the operation if it was slower than the threshold. Returns true if the operation was logged
+func (l *slowOperationLog) end(ctx context.Context, fullMethod string, req interface{}, duration time.Duration) bool {
+
+	if l == nil || duration < l.threshold {
+		return false
+	}
+	method, ok := slowOperationMethods[fullMethod]
+	if !ok {
+		return false
+	}
+
+	l.registry.Counter(slowOperationsMetric, "Number of the Set, Get, GetByIndex and Delete operations slower than the threshold", "method", method).Inc()
+
+	attributes := []any{
+		"method", method,
+		"duration", duration,
+		"threshold", l.threshold,
+		"hydrationTriggered", hydra.IsHydrationTriggered(ctx),
+	}
+	if message, ok := req.(proto.Message); ok {
+		summary := requestinfo.Summarize(message)
+		if len(summary.SwampNames) > 0 {
+			attributes = append(attributes, "swampName", summary.SwampNames[0])
+		}
+		attributes = append(attributes,
+			"swampCount", summary.SwampCount,
+			"islandIDs", summary.IslandIDs,
+			"keyCount", summary.KeyCount,
+		)
+	}
+
+	slog.Warn("slow operation", attributes...)
+
+	return true
+
+}
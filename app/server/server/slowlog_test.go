@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSlowOperationLog(t *testing.T) {
+
+	t.Run("should be disabled without threshold", func(t *testing.T) {
+		l := newSlowOperationLog(0, metrics.New())
+		assert.Nil(t, l)
+		ctx := context.Background()
+		assert.Equal(t, ctx, l.begin(ctx, hydrapb.HydraideService_Set_FullMethodName))
+		assert.False(t, l.end(ctx, hydrapb.HydraideService_Set_FullMethodName, &hydrapb.SetRequest{}, time.Hour))
+	})
+
+	registry := metrics.New()
+	l := newSlowOperationLog(100*time.Millisecond, registry)
+	req := &hydrapb.GetRequest{Swamps: []*hydrapb.GetSwamp{{IslandID: 3, SwampName: "users/profiles/alex", Keys: []string{"a"}}}}
+
+	t.Run("should log and count the slow operations", func(t *testing.T) {
+		ctx := l.begin(context.Background(), hydrapb.HydraideService_Get_FullMethodName)
+		assert.False(t, hydra.IsHydrationTriggered(ctx))
+		assert.True(t, l.end(ctx, hydrapb.HydraideService_Get_FullMethodName, req, 150*time.Millisecond))
+		assert.Equal(t, uint64(1), registry.Counter(slowOperationsMetric, "", "method", "Get").Value())
+	})
+
+	t.Run("should skip the fast operations", func(t *testing.T) {
+		ctx := l.begin(context.Background(), hydrapb.HydraideService_Get_FullMethodName)
+		assert.False(t, l.end(ctx, hydrapb.HydraideService_Get_FullMethodName, req, 50*time.Millisecond))
+		assert.Equal(t, uint64(1), registry.Counter(slowOperationsMetric, "", "method", "Get").Value())
+	})
+
+	t.Run("should skip the not tracked methods", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, ctx, l.begin(ctx, hydrapb.HydraideService_GetAll_FullMethodName))
+		assert.False(t, l.end(ctx, hydrapb.HydraideService_GetAll_FullMethodName, &hydrapb.GetAllRequest{}, time.Hour))
+	})
+
+}
@@ -0,0 +1,169 @@
+// Package subscriber is the bounded event queue of the subscribers of the HydrAIDE server.
+//
+// The writers of the swamps put the events of a subscription into its queue, and the gateway sends them to the
+// client from its own goroutine. A client reading slower than the swamps are written fills its queue, and the
+// overflow policy of the subscription decides what happens then: the writers wait, the oldest events are dropped, or
+// the subscription is cancelled. Nothing is lost or piles up silently, every drop and cancellation is counted.
+package subscriber
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultSize is the size of the queue if the client does not set it
+	DefaultSize = 10000
+	// MaxSize is the largest queue a client can ask for, so a subscriber can not take all memory of the server
+	MaxSize = 1000000
+
+	droppedEventsMetric = "hydraide_subscriber_dropped_events_total"
+	cancellationsMetric = "hydraide_subscriber_cancellations_total"
+	blockedWritesMetric = "hydraide_subscriber_blocked_writes_total"
+)
+
+// Policy is the behavior of a full queue
+type Policy int
+
+const (
+	// PolicyBlock makes the writer wait until the client reads an event. No event is lost, but a slow client slows
+	// down the writers of its swamps
+	PolicyBlock Policy = iota
+	// PolicyDropOldest drops the oldest event of the queue for the new one. The client is told the number of the
+	// dropped events
+	PolicyDropOldest
+	// PolicyCancel cancels the subscription, so the client can resynchronize and subscribe again
+	PolicyCancel
+)
+
+// String returns the name of the policy, used as the label of the metrics
+func (p Policy) String() string {
+	switch p {
+	case PolicyBlock:
+		return "block"
+	case PolicyDropOldest:
+		return "drop_oldest"
+	case PolicyCancel:
+		return "cancel"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrInvalidSize is returned for a queue size above MaxSize
+var ErrInvalidSize = errors.New("invalid subscriber queue size")
+
+// Queue is the bounded event queue of a subscription. Push is safe for concurrent use by the writers, while the
+// events are read by one goroutine
+type Queue[T any] struct {
+	policy       Policy
+	items        chan T
+	closed       chan struct{}
+	closeOnce    sync.Once
+	overflow     chan struct{}
+	overflowOnce sync.Once
+	dropped      atomic.Uint64
+
+	droppedCounter   metrics.Counter
+	cancelledCounter metrics.Counter
+	blockedCounter   metrics.Counter
+}
+
+// New creates the queue with the size, DefaultSize if it is zero. The drops, the cancellations and the blocked
+// writes are counted in the registry with the method label, e.g. SubscribeAll
+func New[T any](size int, policy Policy, registry metrics.Registry, method string) (*Queue[T], error) {
+
+	if size < 0 || size > MaxSize {
+		return nil, fmt.Errorf("%w: the size must be between 0 and %d, got %d", ErrInvalidSize, MaxSize, size)
+	}
+	if size == 0 {
+		size = DefaultSize
+	}
+	if registry == nil {
+		registry = metrics.New()
+	}
+
+	labels := []string{"method", method, "policy", policy.String()}
+	return &Queue[T]{
+		policy:           policy,
+		items:            make(chan T, size),
+		closed:           make(chan struct{}),
+		overflow:         make(chan struct{}),
+		droppedCounter:   registry.Counter(droppedEventsMetric, "Number of the events dropped for the slow subscribers", labels...),
+		cancelledCounter: registry.Counter(cancellationsMetric, "Number of the subscriptions cancelled because their client was too slow", labels...),
+		blockedCounter:   registry.Counter(blockedWritesMetric, "Number of the writes that waited for a slow subscriber", labels...),
+	}, nil
+
+}
+
+// Push puts the event into the queue, by the policy if the queue is full. The events pushed after Close are ignored
+func (q *Queue[T]) Push(item T) {
+
+	select {
+	case <-q.closed:
+		return
+	case q.items <- item:
+		return
+	default:
+	}
+
+	switch q.policy {
+	case PolicyBlock:
+		q.blockedCounter.Inc()
+		select {
+		case q.items <- item:
+		case <-q.closed:
+		}
+	case PolicyDropOldest:
+		for {
+			select {
+			case q.items <- item:
+				return
+			default:
+			}
+			// the reader or an other writer can take the last event meanwhile, then there is nothing to drop
+			select {
+			case <-q.items:
+				q.dropped.Add(1)
+				q.droppedCounter.Inc()
+			default:
+			}
+		}
+	case PolicyCancel:
+		q.overflowOnce.Do(func() {
+			q.cancelledCounter.Inc()
+			close(q.overflow)
+		})
+	}
+
+}
+
+// Items returns the channel of the events
+func (q *Queue[T]) Items() <-chan T {
+	return q.items
+}
+
+// Overflow returns the channel closed when the subscription is cancelled by PolicyCancel
+func (q *Queue[T]) Overflow() <-chan struct{} {
+	return q.overflow
+}
+
+// TakeDropped returns the number of the events dropped since its last call
+func (q *Queue[T]) TakeDropped() uint64 {
+	return q.dropped.Swap(0)
+}
+
+// Size returns the capacity of the queue
+func (q *Queue[T]) Size() int {
+	return cap(q.items)
+}
+
+// Close releases the blocked writers. It must be called when the events are not read anymore
+func (q *Queue[T]) Close() {
+	q.closeOnce.Do(func() {
+		close(q.closed)
+	})
+}
@@ -0,0 +1,113 @@
+package subscriber
+
+import (
+	"bytes"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+
+	t.Run("should use the default size and refuse the too large queues", func(t *testing.T) {
+		q, err := New[int](0, PolicyBlock, nil, "Subscribe")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSize, q.Size())
+
+		_, err = New[int](MaxSize+1, PolicyBlock, nil, "Subscribe")
+		assert.ErrorIs(t, err, ErrInvalidSize)
+	})
+
+	t.Run("should make the writer wait for the reader", func(t *testing.T) {
+
+		registry := metrics.New()
+		q, err := New[int](1, PolicyBlock, registry, "SubscribeToEvents")
+		require.NoError(t, err)
+		q.Push(1)
+
+		pushed := make(chan struct{})
+		go func() {
+			q.Push(2)
+			close(pushed)
+		}()
+
+		select {
+		case <-pushed:
+			t.Fatal("the writer did not wait for the reader")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		assert.Equal(t, 1, <-q.Items())
+		<-pushed
+		assert.Equal(t, 2, <-q.Items())
+
+		var text bytes.Buffer
+		require.NoError(t, registry.WriteText(&text))
+		assert.Contains(t, text.String(), `hydraide_subscriber_blocked_writes_total{method="SubscribeToEvents",policy="block"} 1`)
+
+	})
+
+	t.Run("should release the blocked writers when the queue is closed", func(t *testing.T) {
+
+		q, err := New[int](1, PolicyBlock, nil, "SubscribeToEvents")
+		require.NoError(t, err)
+		q.Push(1)
+
+		pushed := make(chan struct{})
+		go func() {
+			q.Push(2)
+			close(pushed)
+		}()
+		q.Close()
+
+		select {
+		case <-pushed:
+		case <-time.After(time.Second):
+			t.Fatal("the writer was not released")
+		}
+		q.Push(3)
+		assert.Len(t, q.Items(), 1)
+
+	})
+
+	t.Run("should drop the oldest events and count them", func(t *testing.T) {
+
+		q, err := New[int](2, PolicyDropOldest, nil, "SubscribeAll")
+		require.NoError(t, err)
+		for i := 1; i <= 5; i++ {
+			q.Push(i)
+		}
+
+		assert.Equal(t, uint64(3), q.TakeDropped())
+		assert.Equal(t, uint64(0), q.TakeDropped(), "the dropped events are reported once")
+		assert.Equal(t, 4, <-q.Items())
+		assert.Equal(t, 5, <-q.Items())
+
+	})
+
+	t.Run("should cancel the subscription at the first overflow", func(t *testing.T) {
+
+		q, err := New[int](1, PolicyCancel, nil, "SubscribeAll")
+		require.NoError(t, err)
+		q.Push(1)
+
+		select {
+		case <-q.Overflow():
+			t.Fatal("the queue was not full")
+		default:
+		}
+
+		q.Push(2)
+		q.Push(3)
+		select {
+		case <-q.Overflow():
+		default:
+			t.Fatal("the subscription was not cancelled")
+		}
+		assert.Equal(t, uint64(0), q.TakeDropped())
+
+	})
+
+}
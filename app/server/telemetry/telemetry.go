@@ -0,0 +1,493 @@
+// Package telemetry samples the resource usage of the HydrAIDE server.
+//
+// Every sample contains the resident memory, the GC stats, the goroutines, the open swamps, the open file descriptors
+// and the free disk space of every filesystem holding island roots. The samples are exposed on the /metrics endpoint,
+// logged if the system resource logging is enabled, and they protect the server with two watermarks: below the warning
+// watermark a filesystem is reported in the logs, the metrics and the health checks, and below the minimum the writes
+// are refused until space is freed, so a full disk does not break a chunk file in the middle of a flush.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/process"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSampleInterval is the sample interval if the configuration does not set it
+	DefaultSampleInterval = 10 * time.Second
+	// DefaultMinFreeDiskPercent is the free disk percent below the writes are refused by default
+	DefaultMinFreeDiskPercent = 5
+	// DefaultWarnFreeDiskPercent is the free disk percent below the disk is reported as running out of space by default
+	DefaultWarnFreeDiskPercent = 10
+
+	residentMemoryMetric = "hydraide_process_resident_memory_bytes"
+	heapAllocMetric      = "hydraide_go_heap_alloc_bytes"
+	gcCyclesMetric       = "hydraide_go_gc_cycles_total"
+	gcPauseMetric        = "hydraide_go_gc_pause_seconds_total"
+	goroutinesMetric     = "hydraide_goroutines"
+	openSwampsMetric     = "hydraide_open_swamps"
+	openFDsMetric        = "hydraide_open_file_descriptors"
+	diskFreeMetric       = "hydraide_disk_free_bytes"
+	diskTotalMetric      = "hydraide_disk_total_bytes"
+	diskIslandsMetric    = "hydraide_disk_islands"
+	lowDiskMetric        = "hydraide_disk_low"
+	diskWarningMetric    = "hydraide_disk_warning"
+)
+
+// Configuration is the configuration of the telemetry
+type Configuration struct {
+	// SampleInterval is the time between two samples. Zero means DefaultSampleInterval
+	SampleInterval time.Duration
+	// MinFreeDiskPercent is the free disk percent below the writes are refused. Zero means the writes are never
+	// refused
+	MinFreeDiskPercent float64
+	// WarnFreeDiskPercent is the free disk percent below the disk is reported as running out of space, while the
+	// writes are still accepted. Zero means the disks are never reported
+	WarnFreeDiskPercent float64
+	// LogSamples logs every sample, like the former system resource log
+	LogSamples bool
+	// DataFolders returns the data folders of the server. The subfolders of the data folders are the island roots
+	DataFolders func() []string
+	// OpenSwamps returns the number of the open swamps. Nil means the open swamps are not sampled
+	OpenSwamps func() int
+}
+
+// Sample is the resource usage of the server at a point of time
+type Sample struct {
+	Time           time.Time
+	ResidentMemory uint64        // the resident set size of the process in bytes, 0 if it is not available
+	HeapAlloc      uint64        // the bytes of the allocated heap objects
+	GCCycles       uint32        // the number of the completed GC cycles
+	GCPauseTotal   time.Duration // the total stop-the-world pause time of the GC
+	Goroutines     int
+	OpenSwamps     int
+	OpenFDs        int32 // the number of the open file descriptors, -1 if it is not available on the platform
+	// Disks are the filesystems holding the island roots, in the order of their mount points
+	Disks []DiskUsage
+}
+
+// DiskUsage is the usage of a filesystem holding island roots
+type DiskUsage struct {
+	// Path is the mount point of the filesystem, or the data folder if the mount points can not be read
+	Path        string
+	Islands     int // the number of the island roots on the filesystem
+	FreeBytes   uint64
+	TotalBytes  uint64
+	FreePercent float64
+}
+
+// Telemetry samples the resource usage of the server
+type Telemetry interface {
+	// Start samples the resource usage immediately, then periodically until the context is done
+	Start(ctx context.Context)
+	// Last returns the last sample, nil before the first one
+	Last() *Sample
+	// LowDisk returns the first filesystem of the last sample with less free space than the configured minimum, nil if
+	// there is none or the protection is disabled
+	LowDisk() *DiskUsage
+	// DiskWarning returns the first filesystem of the last sample with less free space than the warning watermark, nil
+	// if there is none or the warning is disabled. A low disk is always below the warning watermark too
+	DiskWarning() *DiskUsage
+}
+
+type telemetry struct {
+	configuration *Configuration
+	registry      metrics.Registry
+	proc          *process.Process
+	mu            sync.RWMutex
+	last          *Sample
+	lowDisk       *DiskUsage
+	diskWarning   *DiskUsage
+	// diskSeries are the paths of the registered disk series
+	diskSeries map[string]bool
+}
+
+// New creates the telemetry and registers its metrics in the registry
+func New(configuration *Configuration, registry metrics.Registry) Telemetry {
+
+	if configuration.SampleInterval <= 0 {
+		configuration.SampleInterval = DefaultSampleInterval
+	}
+
+	t := &telemetry{
+		configuration: configuration,
+		registry:      registry,
+		diskSeries:    make(map[string]bool),
+	}
+
+	// the process stats are optional, the other stats are sampled without them
+	if proc, err := process.NewProcess(int32(os.Getpid())); err == nil {
+		t.proc = proc
+	} else {
+		slog.Warn("can not read the stats of the process, the resident memory and the file descriptors are not sampled", "error", err)
+	}
+
+	t.registerMetrics()
+
+	return t
+
+}
+
+func (t *telemetry) Start(ctx context.Context) {
+
+	t.sample()
+
+	go func() {
+
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("caught panic while sampling the resource usage", "error", r, "stack", string(debug.Stack()))
+			}
+		}()
+
+		ticker := time.NewTicker(t.configuration.SampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.sample()
+			case <-ctx.Done():
+				return
+			}
+		}
+
+	}()
+
+}
+
+func (t *telemetry) Last() *Sample {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.last
+}
+
+func (t *telemetry) LowDisk() *DiskUsage {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lowDisk
+}
+
+func (t *telemetry) DiskWarning() *DiskUsage {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.diskWarning
+}
+
+// sample takes a new sample, stores it as the last one and checks the free disk space
+func (t *telemetry) sample() {
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	s := &Sample{
+		Time:         time.Now(),
+		HeapAlloc:    m.HeapAlloc,
+		GCCycles:     m.NumGC,
+		GCPauseTotal: time.Duration(m.PauseTotalNs),
+		Goroutines:   runtime.NumGoroutine(),
+		OpenFDs:      -1,
+		Disks:        t.sampleDisks(),
+	}
+
+	if t.configuration.OpenSwamps != nil {
+		s.OpenSwamps = t.configuration.OpenSwamps()
+	}
+
+	if t.proc != nil {
+		if memoryInfo, err := t.proc.MemoryInfo(); err == nil {
+			s.ResidentMemory = memoryInfo.RSS
+		}
+		if fds, err := t.proc.NumFDs(); err == nil {
+			s.OpenFDs = fds
+		}
+	}
+
+	lowDisk := findDiskBelow(s.Disks, t.configuration.MinFreeDiskPercent)
+	// the low disk is reported as a warning too, even if the warning watermark is lower than the minimum
+	diskWarning := findDiskBelow(s.Disks, max(t.configuration.WarnFreeDiskPercent, t.configuration.MinFreeDiskPercent))
+
+	t.mu.Lock()
+	previousLowDisk := t.lowDisk
+	previousDiskWarning := t.diskWarning
+	t.last = s
+	t.lowDisk = lowDisk
+	t.diskWarning = diskWarning
+	t.mu.Unlock()
+
+	// the disks are registered at their first sample, because the island roots can be created at any time
+	for i := range s.Disks {
+		t.registerDisk(s.Disks[i].Path)
+	}
+
+	switch {
+	case diskWarning != nil && previousDiskWarning == nil && lowDisk == nil:
+		slog.Warn("the disk is running out of space, free space before the writes are refused",
+			"path", diskWarning.Path,
+			"freePercent", diskWarning.FreePercent,
+			"warnFreePercent", t.configuration.WarnFreeDiskPercent,
+			"minFreePercent", t.configuration.MinFreeDiskPercent)
+	case diskWarning == nil && previousDiskWarning != nil:
+		slog.Info("the disk has enough free space again", "path", previousDiskWarning.Path)
+	}
+
+	switch {
+	case lowDisk != nil && previousLowDisk == nil:
+		slog.Error("the disk is almost full, the writes are refused until space is freed",
+			"path", lowDisk.Path,
+			"freePercent", lowDisk.FreePercent,
+			"minFreePercent", t.configuration.MinFreeDiskPercent)
+	case lowDisk == nil && previousLowDisk != nil:
+		slog.Info("the disk has enough free space again, the writes are accepted", "path", previousLowDisk.Path)
+	}
+
+	if t.configuration.LogSamples {
+		slog.Info("system resource log",
+			slog.String("resident_memory", bytesToReadable(s.ResidentMemory)),
+			slog.String("heap_alloc", bytesToReadable(s.HeapAlloc)),
+			slog.Uint64("gc_cycles", uint64(s.GCCycles)),
+			slog.Duration("gc_pause_total", s.GCPauseTotal),
+			slog.Int("goroutines", s.Goroutines),
+			slog.Int("open_swamps", s.OpenSwamps),
+			slog.Int("open_fds", int(s.OpenFDs)),
+			slog.String("disk_free", formatDisks(s.Disks)),
+		)
+	}
+
+}
+
+// findDiskBelow returns the first disk with less free space than the percent, nil if there is none or the percent is
+// not positive
+func findDiskBelow(disks []DiskUsage, percent float64) *DiskUsage {
+	if percent <= 0 {
+		return nil
+	}
+	for i := range disks {
+		if disks[i].FreePercent < percent {
+			lowDisk := disks[i]
+			return &lowDisk
+		}
+	}
+	return nil
+}
+
+// sampleDisks returns the usage of the filesystems holding the island roots of the data folders. The island roots
+// are grouped by the mount point of their filesystem, so every filesystem is sampled only once, even if it holds
+// thousands of island roots
+func (t *telemetry) sampleDisks() []DiskUsage {
+
+	if t.configuration.DataFolders == nil {
+		return nil
+	}
+
+	var mountPoints []string
+	if partitions, err := disk.Partitions(true); err == nil {
+		for _, partition := range partitions {
+			mountPoints = append(mountPoints, partition.Mountpoint)
+		}
+	}
+
+	usages := make(map[string]*DiskUsage)
+	for _, dataFolder := range t.configuration.DataFolders() {
+
+		entries, err := os.ReadDir(dataFolder)
+		if err != nil {
+			// the data folder is created lazily by the first swamp
+			continue
+		}
+
+		for _, entry := range entries {
+
+			if _, err := strconv.ParseUint(entry.Name(), 10, 64); err != nil {
+				continue
+			}
+
+			islandRoot := filepath.Join(dataFolder, entry.Name())
+			if entry.Type()&os.ModeSymlink != 0 {
+				// the island root is mounted from another filesystem
+				if resolved, err := filepath.EvalSymlinks(islandRoot); err == nil {
+					islandRoot = resolved
+				}
+			} else if !entry.IsDir() {
+				continue
+			}
+
+			path := mountPointOf(islandRoot, mountPoints)
+			if path == "" {
+				path = dataFolder
+			}
+
+			usage, ok := usages[path]
+			if !ok {
+				stat, err := disk.Usage(islandRoot)
+				if err != nil || stat.Total == 0 {
+					continue
+				}
+				usage = &DiskUsage{
+					Path:        path,
+					FreeBytes:   stat.Free,
+					TotalBytes:  stat.Total,
+					FreePercent: float64(stat.Free) / float64(stat.Total) * 100,
+				}
+				usages[path] = usage
+			}
+			usage.Islands++
+
+		}
+
+	}
+
+	disks := make([]DiskUsage, 0, len(usages))
+	for _, usage := range usages {
+		disks = append(disks, *usage)
+	}
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Path < disks[j].Path
+	})
+
+	return disks
+
+}
+
+// mountPointOf returns the longest mount point containing the path, empty if there is none
+func mountPointOf(path string, mountPoints []string) string {
+	longest := ""
+	for _, mountPoint := range mountPoints {
+		if len(mountPoint) <= len(longest) {
+			continue
+		}
+		if path == mountPoint || strings.HasPrefix(path, strings.TrimSuffix(mountPoint, string(filepath.Separator))+string(filepath.Separator)) {
+			longest = mountPoint
+		}
+	}
+	return longest
+}
+
+// registerMetrics registers the metrics of the process. The values are read from the last sample
+func (t *telemetry) registerMetrics() {
+
+	t.registry.GaugeFunc(residentMemoryMetric, "Resident set size of the server process in bytes", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return float64(s.ResidentMemory) })
+	})
+	t.registry.GaugeFunc(heapAllocMetric, "Bytes of the allocated heap objects", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return float64(s.HeapAlloc) })
+	})
+	t.registry.CounterFunc(gcCyclesMetric, "Number of the completed GC cycles", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return float64(s.GCCycles) })
+	})
+	t.registry.CounterFunc(gcPauseMetric, "Total stop-the-world pause time of the GC", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return s.GCPauseTotal.Seconds() })
+	})
+	t.registry.GaugeFunc(goroutinesMetric, "Number of the goroutines", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return float64(s.Goroutines) })
+	})
+	t.registry.GaugeFunc(openSwampsMetric, "Number of the swamps open in the memory", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return float64(s.OpenSwamps) })
+	})
+	t.registry.GaugeFunc(openFDsMetric, "Number of the open file descriptors, -1 if not available on the platform", func() float64 {
+		return t.sampleValue(func(s *Sample) float64 { return float64(s.OpenFDs) })
+	})
+	t.registry.GaugeFunc(lowDiskMetric, "1 if the writes are refused, because a disk is almost full", func() float64 {
+		if t.LowDisk() != nil {
+			return 1
+		}
+		return 0
+	})
+	t.registry.GaugeFunc(diskWarningMetric, "1 if a disk is running out of space", func() float64 {
+		if t.DiskWarning() != nil {
+			return 1
+		}
+		return 0
+	})
+
+}
+
+// registerDisk registers the metrics of the filesystem at its first sample
+func (t *telemetry) registerDisk(path string) {
+
+	t.mu.Lock()
+	registered := t.diskSeries[path]
+	t.diskSeries[path] = true
+	t.mu.Unlock()
+
+	if registered {
+		return
+	}
+
+	t.registry.GaugeFunc(diskFreeMetric, "Free space of the filesystem holding island roots in bytes", func() float64 {
+		return t.diskValue(path, func(d *DiskUsage) float64 { return float64(d.FreeBytes) })
+	}, "path", path)
+	t.registry.GaugeFunc(diskTotalMetric, "Size of the filesystem holding island roots in bytes", func() float64 {
+		return t.diskValue(path, func(d *DiskUsage) float64 { return float64(d.TotalBytes) })
+	}, "path", path)
+	t.registry.GaugeFunc(diskIslandsMetric, "Number of the island roots on the filesystem", func() float64 {
+		return t.diskValue(path, func(d *DiskUsage) float64 { return float64(d.Islands) })
+	}, "path", path)
+
+}
+
+// sampleValue returns the value of the last sample, 0 before the first one
+func (t *telemetry) sampleValue(fn func(s *Sample) float64) float64 {
+	if s := t.Last(); s != nil {
+		return fn(s)
+	}
+	return 0
+}
+
+// diskValue returns the value of the filesystem in the last sample, 0 if the filesystem holds no island roots anymore
+func (t *telemetry) diskValue(path string, fn func(d *DiskUsage) float64) float64 {
+	if s := t.Last(); s != nil {
+		for i := range s.Disks {
+			if s.Disks[i].Path == path {
+				return fn(&s.Disks[i])
+			}
+		}
+	}
+	return 0
+}
+
+// formatDisks renders the free space of the disks for the resource log, e.g. "/data=42.50%"
+func formatDisks(disks []DiskUsage) string {
+	parts := make([]string, 0, len(disks))
+	for _, d := range disks {
+		parts = append(parts, fmt.Sprintf("%s=%.2f%%", d.Path, d.FreePercent))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func bytesToReadable(bytes uint64) string {
+
+	const (
+		_         = iota // ignore first value by assigning to blank identifier
+		KB uint64 = 1 << (10 * iota)
+		MB
+		GB
+		TB
+	)
+
+	switch {
+	case bytes >= TB:
+		return fmt.Sprintf("%.2fTB", float64(bytes)/float64(TB))
+	case bytes >= GB:
+		return fmt.Sprintf("%.2fGB", float64(bytes)/float64(GB))
+	case bytes >= MB:
+		return fmt.Sprintf("%.2fMB", float64(bytes)/float64(MB))
+	case bytes >= KB:
+		return fmt.Sprintf("%.2fKB", float64(bytes)/float64(KB))
+	default:
+		return fmt.Sprintf("%dB", bytes)
+	}
+}
@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTelemetry(t *testing.T) {
+
+	dataFolder := t.TempDir()
+	for _, folder := range []string{"1", "2", "not-an-island"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dataFolder, folder), 0755))
+	}
+
+	t.Run("should sample the resource usage and the island roots", func(t *testing.T) {
+
+		registry := metrics.New()
+		tel := New(&Configuration{
+			DataFolders: func() []string { return []string{dataFolder, filepath.Join(dataFolder, "missing")} },
+			OpenSwamps:  func() int { return 7 },
+		}, registry)
+		assert.Nil(t, tel.Last())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tel.Start(ctx)
+
+		sample := tel.Last()
+		require.NotNil(t, sample)
+		assert.Positive(t, sample.Goroutines)
+		assert.Positive(t, sample.HeapAlloc)
+		assert.Equal(t, 7, sample.OpenSwamps)
+
+		require.Len(t, sample.Disks, 1, "the island roots on the same filesystem are sampled once")
+		assert.Equal(t, 2, sample.Disks[0].Islands)
+		assert.Positive(t, sample.Disks[0].TotalBytes)
+		assert.Nil(t, tel.LowDisk(), "the protection is disabled without min free disk percent")
+		assert.Nil(t, tel.DiskWarning(), "the warning is disabled without warn free disk percent")
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), "hydraide_open_swamps 7")
+		assert.Contains(t, buffer.String(), "hydraide_disk_low 0")
+		assert.Contains(t, buffer.String(), `hydraide_disk_islands{path="`+sample.Disks[0].Path+`"} 2`)
+
+	})
+
+	t.Run("should report the low disk below the min free percent", func(t *testing.T) {
+
+		registry := metrics.New()
+		tel := New(&Configuration{
+			SampleInterval: time.Hour,
+			// every disk has less free space than 101%
+			MinFreeDiskPercent: 101,
+			DataFolders:        func() []string { return []string{dataFolder} },
+		}, registry)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tel.Start(ctx)
+
+		lowDisk := tel.LowDisk()
+		require.NotNil(t, lowDisk)
+		assert.Equal(t, tel.Last().Disks[0].Path, lowDisk.Path)
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), "hydraide_disk_low 1")
+		assert.Contains(t, buffer.String(), "hydraide_disk_warning 1", "the low disk is a warning too")
+
+	})
+
+	t.Run("should report the disk warning while the writes are accepted", func(t *testing.T) {
+
+		registry := metrics.New()
+		tel := New(&Configuration{
+			SampleInterval:      time.Hour,
+			MinFreeDiskPercent:  0.000001,
+			WarnFreeDiskPercent: 101,
+			DataFolders:         func() []string { return []string{dataFolder} },
+		}, registry)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tel.Start(ctx)
+
+		assert.Nil(t, tel.LowDisk())
+		diskWarning := tel.DiskWarning()
+		require.NotNil(t, diskWarning)
+		assert.Equal(t, tel.Last().Disks[0].Path, diskWarning.Path)
+
+		var buffer bytes.Buffer
+		require.NoError(t, registry.WriteText(&buffer))
+		assert.Contains(t, buffer.String(), "hydraide_disk_low 0")
+		assert.Contains(t, buffer.String(), "hydraide_disk_warning 1")
+
+	})
+
+}
+
+func TestMountPointOf(t *testing.T) {
+	mountPoints := []string{"/", "/data", "/data/islands", "/dat"}
+	assert.Equal(t, "/data/islands", mountPointOf("/data/islands/12", mountPoints))
+	assert.Equal(t, "/data", mountPointOf("/data/other", mountPoints))
+	assert.Equal(t, "/data", mountPointOf("/data", mountPoints))
+	assert.Equal(t, "/", mountPointOf("/database", mountPoints))
+	assert.Equal(t, "", mountPointOf("/data", nil))
+}
@@ -0,0 +1,261 @@
+// Package tenancy runs many isolated customers (tenants) on one HydrAIDE server.
+//
+// Every tenant authenticates with its own bearer token, sent in the `authorization` gRPC metadata
+// (`Bearer <token>`). The token decides the tenant, so a client can not read or write the data of another tenant,
+// even if it uses the same Sanctuary names.
+//
+// Every tenant has its own service instance with its own root path (HYDRAIDE_ROOT_PATH/tenants/<tenantID>), own
+// swamp settings and own limits. The interceptors of the package resolve the tenant of the request and route the
+// call to the service of the tenant:
+//
+//   - AuthInterceptor authenticates the request and sets the tenant ID as the client identity, so the rate limiter
+//     and the logs see the tenant. It must run before the rate limiter.
+//   - RouteInterceptor calls the service of the tenant instead of the registered service. It must be the last
+//     interceptor of the chain.
+//   - StreamAuthInterceptor authenticates the streaming requests, for the stream interceptors that need the tenant,
+//     e.g. the audit log. It is optional.
+//   - StreamInterceptor does both for the streaming RPCs. It reuses the tenant of the StreamAuthInterceptor.
+package tenancy
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MetadataAuthorization is the gRPC metadata key of the bearer token of the tenant
+	MetadataAuthorization = "authorization"
+	// tenantsFolder is the folder under the root path where the root paths of the tenants are created
+	tenantsFolder = "tenants"
+)
+
+// tenantIDPattern limits the tenant IDs to safe folder names
+var tenantIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+
+// Tenant is the configuration of one tenant
+type Tenant struct {
+	// Token is the bearer token of the tenant. Must be unique across the tenants
+	Token string
+	// Limits are the rate limits of the tenant. Used only if the rate limiter of the server is enabled
+	Limits ratelimit.Limits
+	// MaxTreasuresPerSwamp is the maximum number of treasures in one swamp of the tenant. Zero means the limit
+	// of the server
+	MaxTreasuresPerSwamp int
+}
+
+// Configuration is the configuration of the tenancy layer
+type Configuration struct {
+	// Tenants are the tenants by their IDs
+	Tenants map[string]Tenant
+}
+
+// Validate checks the tenant IDs and the tokens of the configuration
+func (c *Configuration) Validate() error {
+	if len(c.Tenants) == 0 {
+		return errors.New("at least one tenant must be configured")
+	}
+	tokens := make(map[string]string, len(c.Tenants))
+	for tenantID, tenant := range c.Tenants {
+		if err := ValidateTenantID(tenantID); err != nil {
+			return err
+		}
+		if tenant.Token == "" {
+			return fmt.Errorf("the token of the tenant %s is empty", tenantID)
+		}
+		if other, ok := tokens[tenant.Token]; ok {
+			return fmt.Errorf("the tenants %s and %s have the same token", other, tenantID)
+		}
+		tokens[tenant.Token] = tenantID
+	}
+	return nil
+}
+
+// ValidateTenantID returns an error if the tenant ID can not be used as a folder name
+func ValidateTenantID(tenantID string) error {
+	if !tenantIDPattern.MatchString(tenantID) {
+		return fmt.Errorf("invalid tenant ID %q: use 1-64 letters, digits, '-' or '_', starting with a letter or digit", tenantID)
+	}
+	return nil
+}
+
+// RootPath returns the isolated root path of the tenant under the root path of the server
+func RootPath(rootPath string, tenantID string) string {
+	return filepath.Join(rootPath, tenantsFolder, tenantID)
+}
+
+type tenantContextKey struct{}
+
+// TenantFromContext returns the ID of the authenticated tenant of the request
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok
+}
+
+type Router interface {
+	// AuthInterceptor authenticates the tenant of the unary requests
+	AuthInterceptor() grpc.UnaryServerInterceptor
+	// RouteInterceptor routes the unary requests to the service of the authenticated tenant
+	RouteInterceptor() grpc.UnaryServerInterceptor
+	// StreamAuthInterceptor authenticates the tenant of the streaming requests
+	StreamAuthInterceptor() grpc.StreamServerInterceptor
+	// StreamInterceptor authenticates the tenant of the streaming requests, if the StreamAuthInterceptor did not do
+	// it, and routes them to its service
+	StreamInterceptor() grpc.StreamServerInterceptor
+}
+
+type router struct {
+	configuration *Configuration
+	services      map[string]hydrapb.HydraideServiceServer
+	methods       map[string]grpc.MethodDesc
+	streams       map[string]grpc.StreamDesc
+}
+
+// New creates the router of the tenants. The services are the service instances of the tenants by their IDs
+func New(configuration *Configuration, services map[string]hydrapb.HydraideServiceServer) Router {
+
+	r := &router{
+		configuration: configuration,
+		services:      services,
+		methods:       make(map[string]grpc.MethodDesc),
+		streams:       make(map[string]grpc.StreamDesc),
+	}
+
+	serviceName := hydrapb.HydraideService_ServiceDesc.ServiceName
+	for _, method := range hydrapb.HydraideService_ServiceDesc.Methods {
+		r.methods[fmt.Sprintf("/%s/%s", serviceName, method.MethodName)] = method
+	}
+	for _, stream := range hydrapb.HydraideService_ServiceDesc.Streams {
+		r.streams[fmt.Sprintf("/%s/%s", serviceName, stream.StreamName)] = stream
+	}
+
+	return r
+
+}
+
+func (r *router) AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tenantCtx, err := r.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(tenantCtx, req)
+	}
+}
+
+func (r *router) RouteInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		tenantID, ok := TenantFromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "the tenant of the request is not authenticated")
+		}
+
+		method, ok := r.methods[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+		}
+
+		// the request is already decoded, the decoder of the generated handler gets a copy of it
+		decode := func(in interface{}) error {
+			target, ok := in.(proto.Message)
+			source, ok2 := req.(proto.Message)
+			if !ok || !ok2 {
+				return status.Error(codes.Internal, "the request is not a protobuf message")
+			}
+			proto.Merge(target, source)
+			return nil
+		}
+
+		return method.Handler(r.services[tenantID], ctx, decode, nil)
+
+	}
+}
+
+func (r *router) StreamAuthInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tenantCtx, err := r.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &tenantStream{ServerStream: ss, ctx: tenantCtx})
+	}
+}
+
+func (r *router) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		// the tenant in the context is always set by the StreamAuthInterceptor, the clients can not set it
+		tenantCtx := ss.Context()
+		if _, ok := TenantFromContext(tenantCtx); !ok {
+			var err error
+			if tenantCtx, err = r.authenticate(tenantCtx); err != nil {
+				return err
+			}
+		}
+
+		stream, ok := r.streams[info.FullMethod]
+		if !ok {
+			return status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+		}
+
+		tenantID, _ := TenantFromContext(tenantCtx)
+		return stream.Handler(r.services[tenantID], &tenantStream{ServerStream: ss, ctx: tenantCtx})
+
+	}
+}
+
+// authenticate resolves the tenant of the bearer token, and returns the context of the tenant
+func (r *router) authenticate(ctx context.Context) (context.Context, error) {
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := ""
+	for _, value := range md.Get(MetadataAuthorization) {
+		if t, ok := strings.CutPrefix(value, "Bearer "); ok && t != "" {
+			token = t
+			break
+		}
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing tenant token")
+	}
+
+	tenantID := ""
+	for candidateID, tenant := range r.configuration.Tenants {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(tenant.Token)) == 1 {
+			tenantID = candidateID
+		}
+	}
+	if _, ok := r.services[tenantID]; !ok || tenantID == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid tenant token")
+	}
+
+	// the tenant is the client identity of the rate limiter and the logs, whatever client ID the client sends
+	md = md.Copy()
+	md.Set(ratelimit.MetadataClientID, tenantID)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	return context.WithValue(ctx, tenantContextKey{}, tenantID), nil
+
+}
+
+// tenantStream is the server stream with the context of the tenant
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+	return s.ctx
+}
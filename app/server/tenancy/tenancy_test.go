@@ -0,0 +1,179 @@
+package tenancy
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/app/server/ratelimit"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"path/filepath"
+	"testing"
+)
+
+// fakeService answers the heartbeat with the ID of its tenant and the client identity of the request
+type fakeService struct {
+	hydrapb.UnimplementedHydraideServiceServer
+	tenantID string
+}
+
+func (f *fakeService) Heartbeat(ctx context.Context, in *hydrapb.HeartbeatRequest) (*hydrapb.HeartbeatResponse, error) {
+	return &hydrapb.HeartbeatResponse{Pong: f.tenantID + ":" + in.GetPing() + ":" + ratelimit.ClientIdentity(ctx)}, nil
+}
+
+func (f *fakeService) SubscribeToInfo(_ *hydrapb.SubscribeToInfoRequest, stream grpc.ServerStreamingServer[hydrapb.SubscribeToInfoResponse]) error {
+	tenantID, _ := TenantFromContext(stream.Context())
+	if tenantID != f.tenantID {
+		return status.Error(codes.Internal, "wrong tenant")
+	}
+	return nil
+}
+
+// fakeStream is a server stream without messages
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context    { return s.ctx }
+func (s *fakeStream) RecvMsg(_ interface{}) error { return nil }
+
+func newTestRouter() Router {
+	return New(&Configuration{
+		Tenants: map[string]Tenant{
+			"acme":   {Token: "acme-token"},
+			"globex": {Token: "globex-token"},
+		},
+	}, map[string]hydrapb.HydraideServiceServer{
+		"acme":   &fakeService{tenantID: "acme"},
+		"globex": &fakeService{tenantID: "globex"},
+	})
+}
+
+// heartbeat calls the heartbeat through the auth and the route interceptors, like the gRPC server
+func heartbeat(r Router, ctx context.Context) (*hydrapb.HeartbeatResponse, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Heartbeat_FullMethodName}
+	registered := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &hydrapb.HeartbeatResponse{Pong: "registered service"}, nil
+	}
+	resp, err := r.AuthInterceptor()(ctx, &hydrapb.HeartbeatRequest{Ping: "ping"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return r.RouteInterceptor()(ctx, req, info, registered)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*hydrapb.HeartbeatResponse), nil
+}
+
+func TestRouter(t *testing.T) {
+
+	r := newTestRouter()
+
+	t.Run("should route the requests to the service of the tenant", func(t *testing.T) {
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer acme-token"))
+		resp, err := heartbeat(r, ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "acme:ping:acme", resp.GetPong())
+
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer globex-token"))
+		resp, err = heartbeat(r, ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "globex:ping:globex", resp.GetPong())
+
+	})
+
+	t.Run("should override the client ID with the tenant", func(t *testing.T) {
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			MetadataAuthorization, "Bearer acme-token",
+			ratelimit.MetadataClientID, "globex",
+		))
+		resp, err := heartbeat(r, ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "acme:ping:acme", resp.GetPong())
+
+	})
+
+	t.Run("should reject the requests without valid token", func(t *testing.T) {
+
+		_, err := heartbeat(r, context.Background())
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer unknown"))
+		_, err = heartbeat(r, ctx)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "acme-token"))
+		_, err = heartbeat(r, ctx)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	})
+
+	t.Run("should route the streams to the service of the tenant", func(t *testing.T) {
+
+		info := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_SubscribeToInfo_FullMethodName, IsServerStream: true}
+		registered := func(srv interface{}, stream grpc.ServerStream) error {
+			return status.Error(codes.Internal, "the registered service must not be called")
+		}
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer globex-token"))
+		err := r.StreamInterceptor()(nil, &fakeStream{ctx: ctx}, info, registered)
+		assert.NoError(t, err)
+
+		err = r.StreamInterceptor()(nil, &fakeStream{ctx: context.Background()}, info, registered)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	})
+
+	t.Run("should reuse the tenant of the stream auth interceptor", func(t *testing.T) {
+
+		info := &grpc.StreamServerInfo{FullMethod: hydrapb.HydraideService_SubscribeToInfo_FullMethodName, IsServerStream: true}
+		registered := func(srv interface{}, stream grpc.ServerStream) error {
+			return status.Error(codes.Internal, "the registered service must not be called")
+		}
+
+		var tenantID string
+		route := func(srv interface{}, stream grpc.ServerStream) error {
+			tenantID, _ = TenantFromContext(stream.Context())
+			return r.StreamInterceptor()(srv, stream, info, registered)
+		}
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer globex-token"))
+		assert.NoError(t, r.StreamAuthInterceptor()(nil, &fakeStream{ctx: ctx}, info, route))
+		assert.Equal(t, "globex", tenantID)
+
+		err := r.StreamAuthInterceptor()(nil, &fakeStream{ctx: context.Background()}, info, route)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	})
+
+}
+
+func TestConfiguration_Validate(t *testing.T) {
+
+	t.Run("should accept a valid configuration", func(t *testing.T) {
+		c := &Configuration{Tenants: map[string]Tenant{"acme": {Token: "a"}, "globex_2": {Token: "b"}}}
+		assert.NoError(t, c.Validate())
+	})
+
+	t.Run("should reject the invalid configurations", func(t *testing.T) {
+		invalid := []*Configuration{
+			{},
+			{Tenants: map[string]Tenant{"acme": {}}},
+			{Tenants: map[string]Tenant{"../acme": {Token: "a"}}},
+			{Tenants: map[string]Tenant{"-acme": {Token: "a"}}},
+			{Tenants: map[string]Tenant{"acme": {Token: "a"}, "globex": {Token: "a"}}},
+		}
+		for _, c := range invalid {
+			assert.Error(t, c.Validate())
+		}
+	})
+
+	t.Run("should isolate the root paths of the tenants", func(t *testing.T) {
+		assert.Equal(t, filepath.Join("/hydraide", "tenants", "acme"), RootPath("/hydraide", "acme"))
+	})
+
+}
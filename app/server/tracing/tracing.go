@@ -0,0 +1,182 @@
+// Package tracing is the optional OpenTelemetry instrumentation of the HydrAIDE server.
+//
+// If the tracing is enabled, every RPC gets its own server span with the swamp names, island IDs, the number of
+// keys and the payload sizes of the request as attributes. The trace context of the client is extracted from the
+// gRPC metadata, so the spans of the server are the children of the client spans of the SDK, and a distributed
+// trace shows exactly which swamp read is slow.
+//
+// The spans are exported via OTLP/HTTP to the configured collector (OpenTelemetry Collector, Jaeger, Tempo, etc.).
+// If the tracing is disabled, the interceptor is not installed at all, so it has no cost.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/app/server/requestinfo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"strings"
+)
+
+// instrumentationName is the name of the tracer of the server
+const instrumentationName = "github.com/hydraide/hydraide/app/server/tracing"
+
+// The attribute keys of the HydrAIDE specific span attributes. The SDK uses the same keys for the client spans.
+const (
+	AttributeSwampNames   = attribute.Key("hydraide.swamp.names")
+	AttributeSwampCount   = attribute.Key("hydraide.swamp.count")
+	AttributeIslandIDs    = attribute.Key("hydraide.island.ids")
+	AttributeKeyCount     = attribute.Key("hydraide.key.count")
+	AttributeRequestSize  = attribute.Key("hydraide.request.size")
+	AttributeResponseSize = attribute.Key("hydraide.response.size")
+)
+
+// Configuration is the configuration of the tracing
+type Configuration struct {
+	// Endpoint is the host:port of the OTLP/HTTP collector, e.g. "otel-collector:4318"
+	Endpoint string
+	// Insecure disables the TLS of the connection to the collector
+	Insecure bool
+	// ServiceName is the service.name resource attribute of the spans
+	ServiceName string
+	// SampleRatio is the ratio of the sampled traces between 0 and 1. The sampling decision of the client is
+	// always respected, so the traces started by the SDK are never broken in half
+	SampleRatio float64
+}
+
+// Setup creates the OTLP exporter and the tracer provider, and registers them as the global OpenTelemetry tracer
+// provider and propagator. The returned function flushes the remaining spans and stops the exporter.
+func Setup(ctx context.Context, configuration *Configuration) (func(context.Context) error, error) {
+
+	if configuration == nil || configuration.Endpoint == "" {
+		return nil, errors.New("the endpoint of the tracing collector is required")
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(configuration.Endpoint)}
+	if configuration.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := resource.NewSchemaless(semconv.ServiceName(configuration.ServiceName))
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(configuration.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+
+}
+
+// UnaryServerInterceptor returns an interceptor that starts a server span for every RPC with the attributes of the
+// request. The tracer and the propagator are resolved from the global OpenTelemetry providers at every call, so
+// Setup can be called before or after the interceptor is created.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		service, method := requestinfo.SplitFullMethod(info.FullMethod)
+		attributes := []attribute.KeyValue{
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+		}
+		if message, ok := req.(proto.Message); ok {
+			attributes = append(attributes, RequestAttributes(message)...)
+		}
+
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attributes...),
+		)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+
+		if message, ok := resp.(proto.Message); ok && message != nil {
+			span.SetAttributes(AttributeResponseSize.Int(proto.Size(message)))
+		}
+
+		s, _ := status.FromError(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(s.Code())))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, s.Message())
+		}
+
+		return resp, err
+
+	}
+
+}
+
+// RequestAttributes returns the swamp names, island IDs, the number of keys and the size of the request message
+func RequestAttributes(message proto.Message) []attribute.KeyValue {
+
+	summary := requestinfo.Summarize(message)
+
+	attributes := []attribute.KeyValue{
+		AttributeRequestSize.Int(summary.Size),
+		AttributeSwampCount.Int(summary.SwampCount),
+		AttributeKeyCount.Int(summary.KeyCount),
+	}
+	if len(summary.SwampNames) > 0 {
+		attributes = append(attributes, AttributeSwampNames.StringSlice(summary.SwampNames))
+	}
+	if len(summary.IslandIDs) > 0 {
+		islandIDs := make([]int64, len(summary.IslandIDs))
+		for i, islandID := range summary.IslandIDs {
+			islandIDs[i] = int64(islandID)
+		}
+		attributes = append(attributes, AttributeIslandIDs.Int64Slice(islandIDs))
+	}
+
+	return attributes
+
+}
+
+// metadataCarrier adapts the incoming gRPC metadata to the OpenTelemetry propagators
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	hydrapb "github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func attributeMap(attributes []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attributes))
+	for _, a := range attributes {
+		m[a.Key] = a.Value
+	}
+	return m
+}
+
+func TestRequestAttributes(t *testing.T) {
+
+	t.Run("batch request", func(t *testing.T) {
+		attributes := attributeMap(RequestAttributes(&hydrapb.SetRequest{
+			Swamps: []*hydrapb.SwampRequest{
+				{IslandID: 12, SwampName: "users/profiles/alex", KeyValues: []*hydrapb.KeyValuePair{{Key: "a"}, {Key: "b"}}},
+				{IslandID: 12, SwampName: "users/profiles/peter", KeyValues: []*hydrapb.KeyValuePair{{Key: "c"}}},
+				{IslandID: 40, SwampName: "users/settings/alex", KeyValues: []*hydrapb.KeyValuePair{{Key: "d"}}},
+			},
+		}))
+		assert.Equal(t, []string{"users/profiles/alex", "users/profiles/peter", "users/settings/alex"}, attributes[AttributeSwampNames].AsStringSlice())
+		assert.Equal(t, int64(3), attributes[AttributeSwampCount].AsInt64())
+		assert.Equal(t, []int64{12, 40}, attributes[AttributeIslandIDs].AsInt64Slice())
+		assert.Equal(t, int64(4), attributes[AttributeKeyCount].AsInt64(), "the keys of the treasures are not counted twice")
+		assert.Greater(t, attributes[AttributeRequestSize].AsInt64(), int64(0))
+	})
+
+	t.Run("single swamp request", func(t *testing.T) {
+		attributes := attributeMap(RequestAttributes(&hydrapb.GetAllRequest{IslandID: 7, SwampName: "users/profiles/alex"}))
+		assert.Equal(t, []string{"users/profiles/alex"}, attributes[AttributeSwampNames].AsStringSlice())
+		assert.Equal(t, []int64{7}, attributes[AttributeIslandIDs].AsInt64Slice())
+		assert.Equal(t, int64(0), attributes[AttributeKeyCount].AsInt64())
+	})
+
+	t.Run("request without swamp", func(t *testing.T) {
+		attributes := attributeMap(RequestAttributes(&hydrapb.HeartbeatRequest{Ping: "beat"}))
+		assert.NotContains(t, attributes, AttributeSwampNames)
+		assert.Equal(t, int64(0), attributes[AttributeSwampCount].AsInt64())
+	})
+
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	// the client span of the SDK, propagated via the metadata
+	parentCtx, parent := provider.Tracer("client").Start(context.Background(), "client")
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(parentCtx, carrier)
+	parent.End()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(carrier))
+
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: hydrapb.HydraideService_Get_FullMethodName}
+	req := &hydrapb.GetRequest{Swamps: []*hydrapb.GetSwamp{{IslandID: 3, SwampName: "users/profiles/alex", Keys: []string{"a", "b"}}}}
+
+	_, err := interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		assert.True(t, trace.SpanContextFromContext(ctx).IsValid(), "the handler runs in the server span")
+		return nil, status.Error(grpccodes.FailedPrecondition, "swamp not found")
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[1]
+
+	assert.Equal(t, "hydraidepbgo.HydraideService/Get", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().TraceID(), span.Parent().TraceID(), "the server span continues the trace of the client")
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	attributes := attributeMap(span.Attributes())
+	assert.Equal(t, "Get", attributes["rpc.method"].AsString())
+	assert.Equal(t, []string{"users/profiles/alex"}, attributes[AttributeSwampNames].AsStringSlice())
+	assert.Equal(t, int64(2), attributes[AttributeKeyCount].AsInt64())
+	assert.Equal(t, int64(grpccodes.FailedPrecondition), attributes["rpc.grpc.status_code"].AsInt64())
+
+}
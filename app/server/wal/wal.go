@@ -0,0 +1,525 @@
+// Package wal records the changes of the swamps to a write-ahead log, so the swamps can be restored to any point in
+// time between a backup and now (see backup.Recovery).
+//
+// Every record is the whole state of a changed treasure, or the deletion of a treasure, or the destruction of a
+// swamp. The records do not depend on each other, so replaying a record that is already in a backup is harmless.
+//
+// The records are JSON lines in the segment files of the WAL folder. A segment is named by its start time,
+// wal-<start time>.log, and every record of a segment is newer than its start time. A new segment is started at
+// every start of the server, and when the current segment reaches the max segment size. The segments whose records
+// are all older than the max age are deleted.
+package wal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/hydraide/hydraide/app/server/metrics"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxSegmentSize is the size of the current segment in bytes, above it a new segment is started
+	DefaultMaxSegmentSize = 64 * 1024 * 1024
+	// DefaultSyncInterval is the time between two syncs of the current segment to the disk
+	DefaultSyncInterval = time.Second
+
+	segmentPrefix = "wal-"
+	segmentSuffix = ".log"
+	// segmentTimeLayout is the start time in the name of the segments, sortable as a string
+	segmentTimeLayout = "20060102T150405.000000000Z"
+
+	recordsMetric     = "hydraide_wal_records_total"
+	writeErrorsMetric = "hydraide_wal_write_errors_total"
+)
+
+// ErrEmpty is returned if the WAL has no segment
+var ErrEmpty = errors.New("the write-ahead log is empty")
+
+// Operation is the change of a record
+type Operation string
+
+const (
+	// OperationSet is a new or modified treasure, the record holds its whole state
+	OperationSet Operation = "set"
+	// OperationDelete is a deleted treasure
+	OperationDelete Operation = "delete"
+	// OperationDestroy is a destroyed swamp with all of its treasures
+	OperationDestroy Operation = "destroy"
+)
+
+// Record is a change of a swamp
+type Record struct {
+	// Time is the time of the change
+	Time time.Time `json:"time"`
+	// Tenant is the ID of the tenant of the swamp, empty for the swamps of the main hydra
+	Tenant string `json:"tenant,omitempty"`
+	// SwampName is the name of the swamp, sanctuary/realm/swamp
+	SwampName string `json:"swampName"`
+	// IslandID is the island of the swamp
+	IslandID uint64 `json:"islandId"`
+	// Sequence is the sequence number of the change in the swamp since the swamp was hydrated
+	Sequence uint64 `json:"sequence"`
+	// Operation is the change
+	Operation Operation `json:"op"`
+	// Key is the key of the changed treasure, empty for the destroyed swamps
+	Key string `json:"key,omitempty"`
+	// Treasure is the binary state of the treasure of the set records, loaded by treasure.LoadFromByte
+	Treasure []byte `json:"treasure,omitempty"`
+}
+
+// Configuration is the configuration of the write-ahead log
+type Configuration struct {
+	// Folder is the folder of the segments
+	Folder string
+	// MaxSegmentSize is the size of the current segment in bytes, above it a new segment is started. Zero means
+	// DefaultMaxSegmentSize
+	MaxSegmentSize int64
+	// SyncInterval is the time between two syncs of the current segment to the disk. The records of the last
+	// interval are lost if the machine crashes. Zero means DefaultSyncInterval
+	SyncInterval time.Duration
+	// MaxAge is the age above the segments are deleted, it must be longer than the interval of the backups. Zero
+	// means the segments are kept forever
+	MaxAge time.Duration
+	// Metrics is the registry of the record and write error counters. Nil means the metrics are not exposed
+	Metrics metrics.Registry
+}
+
+// Reader reads the records of a write-ahead log
+type Reader interface {
+	// CoveredFrom returns the start time of the oldest segment, the log has every change since it. ErrEmpty is
+	// returned if the log has no segment
+	CoveredFrom() (time.Time, error)
+	// Read calls fn with the records newer than from, in the order of the log. It stops at the first error of fn
+	Read(from time.Time, fn func(record *Record) error) error
+}
+
+// Log is the write-ahead log of the changes
+type Log interface {
+	Reader
+	// Append writes the record to the end of the log. The record is on the disk after the next sync
+	Append(record *Record) error
+	// Recorder returns the event callback of a hydra, it appends the changes of the swamps of the tenant to the log.
+	// Empty tenant means the main hydra
+	Recorder(tenant string) func(event *swamp.Event)
+	// Start syncs the log and deletes the old segments periodically, until the context is done or the log is closed
+	Start(ctx context.Context)
+	// Sync writes the buffered records to the disk
+	Sync() error
+	// Close syncs and closes the current segment. The log can not be used after it
+	Close() error
+}
+
+type fileLog struct {
+	configuration *Configuration
+	mu            sync.Mutex
+	file          *os.File
+	writer        *bufio.Writer
+	size          int64
+	now           func() time.Time
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+	records       metrics.Counter
+	writeErrors   metrics.Counter
+}
+
+// New opens the write-ahead log in the folder of the configuration, creates the folder if it does not exist, and
+// starts a new segment
+func New(configuration *Configuration) (Log, error) {
+
+	if configuration.Folder == "" {
+		return nil, errors.New("the folder of the write-ahead log is not set")
+	}
+	if configuration.MaxSegmentSize <= 0 {
+		configuration.MaxSegmentSize = DefaultMaxSegmentSize
+	}
+	if configuration.SyncInterval <= 0 {
+		configuration.SyncInterval = DefaultSyncInterval
+	}
+	if err := os.MkdirAll(configuration.Folder, 0700); err != nil {
+		return nil, fmt.Errorf("can not create the folder of the write-ahead log: %w", err)
+	}
+
+	registry := configuration.Metrics
+	if registry == nil {
+		registry = metrics.New()
+	}
+
+	l := &fileLog{
+		configuration: configuration,
+		now:           time.Now,
+		records:       registry.Counter(recordsMetric, "Number of the records written to the write-ahead log"),
+		writeErrors:   registry.Counter(writeErrorsMetric, "Number of the records that could not be written to the write-ahead log"),
+	}
+	if err := l.startSegment(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+
+}
+
+// OpenReader returns the reader of the segments in the folder, e.g. the WAL of a stopped server
+func OpenReader(folder string) Reader {
+	return &folderReader{folder: folder}
+}
+
+func (l *fileLog) Append(record *Record) error {
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		l.writeErrors.Inc()
+		return fmt.Errorf("can not encode the record of the write-ahead log: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		l.writeErrors.Inc()
+		return errors.New("the write-ahead log is closed")
+	}
+
+	if l.size > 0 && l.size+int64(len(line)) > l.configuration.MaxSegmentSize {
+		if err := l.rotate(); err != nil {
+			l.writeErrors.Inc()
+			return err
+		}
+	}
+
+	n, err := l.writer.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		l.writeErrors.Inc()
+		return fmt.Errorf("can not write the record of the write-ahead log: %w", err)
+	}
+
+	l.records.Inc()
+	return nil
+
+}
+
+func (l *fileLog) Recorder(tenant string) func(event *swamp.Event) {
+	return func(event *swamp.Event) {
+
+		if event == nil {
+			return
+		}
+
+		record, err := eventToRecord(tenant, event)
+		if err != nil {
+			l.writeErrors.Inc()
+			slog.Error("can not convert the event to a record of the write-ahead log",
+				"swampName", event.SwampName.Get(), "error", err)
+			return
+		}
+		if record == nil {
+			return
+		}
+
+		if err := l.Append(record); err != nil {
+			slog.Error("can not append the change to the write-ahead log, the change can not be restored to a point in time",
+				"swampName", record.SwampName, "key", record.Key, "error", err)
+		}
+
+	}
+}
+
+// eventToRecord converts the event of a swamp to a record. Nil record means the event is not a change
+func eventToRecord(tenant string, event *swamp.Event) (*Record, error) {
+
+	record := &Record{
+		Time:      time.Unix(0, event.EventTime).UTC(),
+		Tenant:    tenant,
+		SwampName: event.SwampName.Get(),
+		IslandID:  event.IslandID,
+		Sequence:  event.Sequence,
+	}
+
+	switch {
+	case event.Destroyed:
+		record.Operation = OperationDestroy
+	case event.StatusType == treasure.StatusDeleted && event.DeletedTreasure != nil:
+		record.Operation = OperationDelete
+		record.Key = event.DeletedTreasure.GetKey()
+	case (event.StatusType == treasure.StatusNew || event.StatusType == treasure.StatusModified) && event.Treasure != nil:
+		record.Operation = OperationSet
+		record.Key = event.Treasure.GetKey()
+		// the treasure of the event is a clone, its guard does not block the writers of the swamp
+		guardID := event.Treasure.StartTreasureGuard(true, guard.BodyAuthID)
+		b, err := event.Treasure.ConvertToByte(guardID)
+		event.Treasure.ReleaseTreasureGuard(guardID)
+		if err != nil {
+			return nil, err
+		}
+		record.Treasure = b
+	default:
+		return nil, nil
+	}
+
+	return record, nil
+
+}
+
+func (l *fileLog) Start(ctx context.Context) {
+
+	ctx, l.cancel = context.WithCancel(ctx)
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		ticker := time.NewTicker(l.configuration.SyncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := l.Sync(); err != nil {
+					slog.Error("can not sync the write-ahead log", "error", err)
+				}
+				l.deleteOldSegments()
+			}
+		}
+	}()
+
+}
+
+func (l *fileLog) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sync()
+}
+
+// sync flushes the buffer and syncs the current segment. The caller must hold the lock
+func (l *fileLog) sync() error {
+	if l.file == nil {
+		return nil
+	}
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func (l *fileLog) Close() error {
+
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := errors.Join(l.sync(), l.file.Close())
+	l.file = nil
+	return err
+
+}
+
+func (l *fileLog) CoveredFrom() (time.Time, error) {
+	return OpenReader(l.configuration.Folder).CoveredFrom()
+}
+
+// Read syncs the log first, so the records appended before the call are read
+func (l *fileLog) Read(from time.Time, fn func(record *Record) error) error {
+	if err := l.Sync(); err != nil {
+		return fmt.Errorf("can not sync the write-ahead log: %w", err)
+	}
+	return OpenReader(l.configuration.Folder).Read(from, fn)
+}
+
+// startSegment creates a new segment named by the current time. The caller must hold the lock, or must be the
+// constructor
+func (l *fileLog) startSegment() error {
+
+	// the start time must be unique and increasing, even if the clock is coarse
+	startedAt := l.now().UTC()
+	if segments, err := listSegments(l.configuration.Folder); err == nil && len(segments) > 0 {
+		if last := segments[len(segments)-1].startedAt; !startedAt.After(last) {
+			startedAt = last.Add(time.Nanosecond)
+		}
+	}
+
+	file, err := os.OpenFile(filepath.Join(l.configuration.Folder, segmentName(startedAt)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("can not create the segment of the write-ahead log: %w", err)
+	}
+
+	l.file = file
+	l.writer = bufio.NewWriter(file)
+	l.size = 0
+	return nil
+
+}
+
+// rotate closes the current segment and starts a new one. The caller must hold the lock
+func (l *fileLog) rotate() error {
+
+	if err := l.sync(); err != nil {
+		return fmt.Errorf("can not sync the segment of the write-ahead log: %w", err)
+	}
+	if err := l.file.Close(); err != nil {
+		slog.Warn("can not close the segment of the write-ahead log", "error", err)
+	}
+	l.file = nil
+
+	return l.startSegment()
+
+}
+
+// deleteOldSegments deletes the segments whose records are older than the max age. A segment is older if the next
+// segment started before the limit, and the current segment is never deleted
+func (l *fileLog) deleteOldSegments() {
+
+	if l.configuration.MaxAge <= 0 {
+		return
+	}
+
+	segments, err := listSegments(l.configuration.Folder)
+	if err != nil {
+		slog.Warn("can not list the segments of the write-ahead log", "error", err)
+		return
+	}
+
+	limit := l.now().Add(-l.configuration.MaxAge)
+	for i := 0; i+1 < len(segments) && segments[i+1].startedAt.Before(limit); i++ {
+		if err := os.Remove(filepath.Join(l.configuration.Folder, segments[i].name)); err != nil {
+			slog.Warn("can not delete the old segment of the write-ahead log", "segment", segments[i].name, "error", err)
+			return
+		}
+	}
+
+}
+
+// segment is a file of the log
+type segment struct {
+	name      string
+	startedAt time.Time
+}
+
+func segmentName(startedAt time.Time) string {
+	return segmentPrefix + startedAt.UTC().Format(segmentTimeLayout) + segmentSuffix
+}
+
+// listSegments returns the segments of the folder, the oldest first
+func listSegments(folder string) ([]segment, error) {
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	segments := make([]segment, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), segmentPrefix) || !strings.HasSuffix(entry.Name(), segmentSuffix) {
+			continue
+		}
+		startedAt, err := time.Parse(segmentTimeLayout, strings.TrimSuffix(strings.TrimPrefix(entry.Name(), segmentPrefix), segmentSuffix))
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{name: entry.Name(), startedAt: startedAt})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].startedAt.Before(segments[j].startedAt)
+	})
+
+	return segments, nil
+
+}
+
+// folderReader reads the segments of a folder
+type folderReader struct {
+	folder string
+}
+
+func (r *folderReader) CoveredFrom() (time.Time, error) {
+	segments, err := listSegments(r.folder)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can not list the segments of the write-ahead log: %w", err)
+	}
+	if len(segments) == 0 {
+		return time.Time{}, ErrEmpty
+	}
+	return segments[0].startedAt, nil
+}
+
+func (r *folderReader) Read(from time.Time, fn func(record *Record) error) error {
+
+	segments, err := listSegments(r.folder)
+	if err != nil {
+		return fmt.Errorf("can not list the segments of the write-ahead log: %w", err)
+	}
+
+	for i, s := range segments {
+		// every record of the segment is older than the start of the next segment
+		if i+1 < len(segments) && !segments[i+1].startedAt.After(from) {
+			continue
+		}
+		if err := readSegment(filepath.Join(r.folder, s.name), from, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// readSegment calls fn with the records of the segment newer than from. The lines that can not be decoded, e.g.
+// the last line of a crashed server, are skipped
+func readSegment(segmentPath string, from time.Time, fn func(record *Record) error) error {
+
+	file, err := os.Open(segmentPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// deleted by the retention since the listing
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReader(file)
+	for {
+
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			record := &Record{}
+			if decodeErr := json.Unmarshal(line, record); decodeErr == nil && record.Time.After(from) {
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("can not read the segment %s of the write-ahead log: %w", filepath.Base(segmentPath), err)
+		}
+
+	}
+
+}
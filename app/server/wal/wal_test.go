@@ -0,0 +1,187 @@
+package wal
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/hydra"
+	"github.com/hydraide/hydraide/app/core/hydra/lock"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure"
+	"github.com/hydraide/hydraide/app/core/hydra/swamp/treasure/guard"
+	"github.com/hydraide/hydraide/app/core/safeops"
+	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestLog(t *testing.T, configuration *Configuration) Log {
+	if configuration.Folder == "" {
+		configuration.Folder = t.TempDir()
+	}
+	l, err := New(configuration)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return l
+}
+
+func readAll(t *testing.T, r Reader, from time.Time) []*Record {
+	var records []*Record
+	require.NoError(t, r.Read(from, func(record *Record) error {
+		records = append(records, record)
+		return nil
+	}))
+	return records
+}
+
+func TestLog(t *testing.T) {
+
+	t.Run("should read the appended records after the from time", func(t *testing.T) {
+
+		l := newTestLog(t, &Configuration{})
+		base := time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, l.Append(&Record{Time: base.Add(time.Duration(i) * time.Second), SwampName: "users/profiles/alex", Operation: OperationSet, Key: "email"}))
+		}
+
+		records := readAll(t, l, base)
+		require.Len(t, records, 2, "the records at the from time are not read")
+		assert.Equal(t, base.Add(time.Second), records[0].Time)
+		assert.Equal(t, base.Add(2*time.Second), records[1].Time)
+
+	})
+
+	t.Run("should start a new segment above the max size and at every start", func(t *testing.T) {
+
+		folder := t.TempDir()
+		l := newTestLog(t, &Configuration{Folder: folder, MaxSegmentSize: 200})
+		base := time.Now().UTC()
+		for i := 0; i < 5; i++ {
+			require.NoError(t, l.Append(&Record{Time: base.Add(time.Duration(i) * time.Millisecond), SwampName: "users/profiles/alex", Operation: OperationDelete, Key: "key"}))
+		}
+		require.NoError(t, l.Close())
+
+		reopened := newTestLog(t, &Configuration{Folder: folder})
+		require.NoError(t, reopened.Append(&Record{Time: base.Add(time.Second), SwampName: "users/profiles/alex", Operation: OperationDestroy}))
+
+		segments, err := listSegments(folder)
+		require.NoError(t, err)
+		assert.Greater(t, len(segments), 2)
+		assert.Len(t, readAll(t, reopened, base.Add(-time.Second)), 6)
+
+		coveredFrom, err := reopened.CoveredFrom()
+		require.NoError(t, err)
+		assert.Equal(t, segments[0].startedAt, coveredFrom)
+
+	})
+
+	t.Run("should skip the broken last line of a crashed server", func(t *testing.T) {
+
+		folder := t.TempDir()
+		l := newTestLog(t, &Configuration{Folder: folder})
+		base := time.Now().UTC()
+		require.NoError(t, l.Append(&Record{Time: base, SwampName: "users/profiles/alex", Operation: OperationDelete, Key: "a"}))
+		require.NoError(t, l.Close())
+
+		segments, err := listSegments(folder)
+		require.NoError(t, err)
+		f, err := os.OpenFile(filepath.Join(folder, segments[0].name), os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"time":"2026-10-18T02:00:00Z","swampName":"users/pro`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		records := readAll(t, OpenReader(folder), base.Add(-time.Second))
+		require.Len(t, records, 1)
+		assert.Equal(t, "a", records[0].Key)
+
+	})
+
+	t.Run("should delete the segments older than the max age", func(t *testing.T) {
+
+		folder := t.TempDir()
+		for _, startedAt := range []time.Time{
+			time.Now().Add(-3 * time.Hour),
+			time.Now().Add(-2 * time.Hour),
+			time.Now().Add(-30 * time.Minute),
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(folder, segmentName(startedAt)), nil, 0600))
+		}
+
+		l := newTestLog(t, &Configuration{Folder: folder, MaxAge: time.Hour})
+		l.(*fileLog).deleteOldSegments()
+
+		segments, err := listSegments(folder)
+		require.NoError(t, err)
+		require.Len(t, segments, 3, "the segment started 2 hours ago has records younger than an hour")
+		assert.True(t, segments[0].startedAt.Before(time.Now().Add(-time.Hour)))
+
+	})
+
+	t.Run("should report the empty log", func(t *testing.T) {
+		_, err := OpenReader(filepath.Join(t.TempDir(), "missing")).CoveredFrom()
+		assert.ErrorIs(t, err, ErrEmpty)
+	})
+
+}
+
+func TestRecorder(t *testing.T) {
+
+	t.Run("should record the changes of the swamps with their islands", func(t *testing.T) {
+
+		settingsInterface := settings.NewWithRootPath(t.TempDir(), 2, 10)
+		settingsInterface.RegisterPattern(name.New().Sanctuary("*").Realm("*").Swamp("*"), false, 60, &settings.FileSystemSettings{
+			WriteIntervalSec: 60,
+			MaxFileSizeByte:  8192,
+		}, nil)
+		hydraInterface := hydra.New(settingsInterface, safeops.New(), lock.New(), filesystem.New())
+		t.Cleanup(hydraInterface.GracefulStop)
+
+		l := newTestLog(t, &Configuration{})
+		require.NoError(t, hydraInterface.SubscribeToPatternEvents(uuid.New(), []name.Name{name.Load("*/*/*")}, l.Recorder("acme")))
+
+		started := time.Now().Add(-time.Second)
+		swampName := name.New().Sanctuary("users").Realm("profiles").Swamp("alex")
+		swampInterface, err := hydraInterface.SummonSwamp(context.Background(), 7, swampName)
+		require.NoError(t, err)
+
+		treasureInterface := swampInterface.CreateTreasure("email")
+		guardID := treasureInterface.StartTreasureGuard(true)
+		treasureInterface.SetContentString(guardID, "alex@example.com")
+		treasureInterface.Save(guardID)
+		treasureInterface.ReleaseTreasureGuard(guardID)
+		require.NoError(t, swampInterface.DeleteTreasure("email", false))
+		swampInterface.Destroy()
+
+		records := readAll(t, l, started)
+		require.Len(t, records, 3)
+		for _, record := range records {
+			assert.Equal(t, "acme", record.Tenant)
+			assert.Equal(t, swampName.Get(), record.SwampName)
+			assert.Equal(t, uint64(7), record.IslandID)
+		}
+		assert.Equal(t, OperationSet, records[0].Operation)
+		assert.Equal(t, "email", records[0].Key)
+		assert.Equal(t, OperationDelete, records[1].Operation)
+		assert.Equal(t, "email", records[1].Key)
+		assert.Equal(t, OperationDestroy, records[2].Operation)
+
+		// the state of the set record is loaded into a new treasure
+		loaded := treasure.New(nil)
+		loadGuardID := loaded.StartTreasureGuard(true, guard.BodyAuthID)
+		require.NoError(t, loaded.LoadFromByte(loadGuardID, records[0].Treasure, ""))
+		loaded.ReleaseTreasureGuard(loadGuardID)
+		content, err := loaded.GetContentString()
+		require.NoError(t, err)
+		assert.Equal(t, "alex@example.com", content)
+		assert.Nil(t, loaded.GetFileName())
+
+	})
+
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/bench/runner"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultAllIslands     = 1000
+	defaultMaxMessageSize = 100 * 1024 * 1024
+	defaultOutputFile     = "bench-results.json"
+	defaultThreshold      = 0.1
+)
+
+// configuration is the configuration of the benchmark, read from the environment variables
+type configuration struct {
+	// server is the benchmarked server. Empty means an embedded engine in a temporary folder
+	server         string
+	certFile       string
+	tenantToken    string
+	allIslands     uint64
+	maxMessageSize int
+	options        *runner.Options
+	outputFile     string
+	baselineFile   string
+	threshold      float64
+}
+
+// loadConfig reads the configuration with the lookup function, e.g. os.LookupEnv. All errors are returned at once
+func loadConfig(lookup func(string) (string, bool)) (*configuration, error) {
+
+	var errs []error
+	get := func(key string) string {
+		value, _ := lookup(key)
+		return strings.TrimSpace(value)
+	}
+	integer := func(key string, defaultValue int) int {
+		value := get(key)
+		if value == "" {
+			return defaultValue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive integer, got %q", key, value))
+			return defaultValue
+		}
+		return number
+	}
+	list := func(key string) []string {
+		var items []string
+		for _, item := range strings.Split(get(key), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	c := &configuration{
+		server:         get("HYDRAIDE_BENCH_SERVER"),
+		certFile:       get("HYDRAIDE_BENCH_CERT_FILE"),
+		tenantToken:    get("HYDRAIDE_BENCH_TENANT_TOKEN"),
+		allIslands:     uint64(integer("HYDRAIDE_BENCH_ALL_ISLANDS", defaultAllIslands)),
+		maxMessageSize: integer("HYDRAIDE_BENCH_MAX_MESSAGE_SIZE", defaultMaxMessageSize),
+		options: &runner.Options{
+			Sanctuary: get("HYDRAIDE_BENCH_SANCTUARY"),
+			Samples:   integer("HYDRAIDE_BENCH_SAMPLES", runner.DefaultSamples),
+			Seed:      int64(integer("HYDRAIDE_BENCH_SEED", runner.DefaultSeed)),
+			Label:     get("HYDRAIDE_BENCH_LABEL"),
+		},
+		outputFile:   get("HYDRAIDE_BENCH_OUTPUT_FILE"),
+		baselineFile: get("HYDRAIDE_BENCH_BASELINE_FILE"),
+		threshold:    defaultThreshold,
+	}
+	if c.outputFile == "" {
+		c.outputFile = defaultOutputFile
+	}
+
+	for _, size := range list("HYDRAIDE_BENCH_SIZES") {
+		number, err := strconv.Atoi(size)
+		if err != nil || number <= 0 {
+			errs = append(errs, fmt.Errorf("HYDRAIDE_BENCH_SIZES must be positive integers separated by commas, got %q", size))
+			continue
+		}
+		c.options.Sizes = append(c.options.Sizes, number)
+	}
+
+	for _, valueType := range list("HYDRAIDE_BENCH_VALUE_TYPES") {
+		if !slices.Contains(runner.ValueTypes, runner.ValueType(valueType)) {
+			errs = append(errs, fmt.Errorf("HYDRAIDE_BENCH_VALUE_TYPES must be some of %v, got %q", runner.ValueTypes, valueType))
+			continue
+		}
+		c.options.ValueTypes = append(c.options.ValueTypes, runner.ValueType(valueType))
+	}
+
+	for _, operation := range list("HYDRAIDE_BENCH_OPERATIONS") {
+		if !slices.Contains(runner.Operations, runner.Operation(operation)) {
+			errs = append(errs, fmt.Errorf("HYDRAIDE_BENCH_OPERATIONS must be some of %v, got %q", runner.Operations, operation))
+			continue
+		}
+		c.options.Operations = append(c.options.Operations, runner.Operation(operation))
+	}
+
+	if threshold := get("HYDRAIDE_BENCH_THRESHOLD"); threshold != "" {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64)
+		if err != nil || percent < 0 {
+			errs = append(errs, fmt.Errorf("HYDRAIDE_BENCH_THRESHOLD must be a non-negative percent, e.g. 10%%, got %q", threshold))
+		} else {
+			c.threshold = percent / 100
+		}
+	}
+
+	if c.server == "" {
+		c.options.Target = "embedded"
+	} else {
+		c.options.Target = c.server
+	}
+
+	return c, errors.Join(errs...)
+
+}
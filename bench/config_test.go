@@ -0,0 +1,74 @@
+package main
+
+import (
+	"github.com/hydraide/hydraide/bench/runner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func lookupFrom(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+
+	t.Run("should benchmark an embedded engine by default", func(t *testing.T) {
+
+		config, err := loadConfig(lookupFrom(nil))
+		require.NoError(t, err)
+
+		assert.Empty(t, config.server)
+		assert.Equal(t, "embedded", config.options.Target)
+		assert.Equal(t, defaultOutputFile, config.outputFile)
+		assert.Equal(t, defaultThreshold, config.threshold)
+		assert.Equal(t, runner.DefaultSamples, config.options.Samples)
+		assert.Empty(t, config.options.Sizes)
+
+	})
+
+	t.Run("should load the scenarios and the baseline", func(t *testing.T) {
+
+		config, err := loadConfig(lookupFrom(map[string]string{
+			"HYDRAIDE_BENCH_SERVER":        "localhost:4444",
+			"HYDRAIDE_BENCH_SIZES":         "1000, 50000",
+			"HYDRAIDE_BENCH_VALUE_TYPES":   "string,struct",
+			"HYDRAIDE_BENCH_OPERATIONS":    "get,getByIndex",
+			"HYDRAIDE_BENCH_SAMPLES":       "200",
+			"HYDRAIDE_BENCH_LABEL":         "v2.3.0",
+			"HYDRAIDE_BENCH_BASELINE_FILE": "v2.2.0.json",
+			"HYDRAIDE_BENCH_THRESHOLD":     "15%",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "localhost:4444", config.options.Target)
+		assert.Equal(t, []int{1000, 50000}, config.options.Sizes)
+		assert.Equal(t, []runner.ValueType{runner.ValueString, runner.ValueStruct}, config.options.ValueTypes)
+		assert.Equal(t, []runner.Operation{runner.OperationGet, runner.OperationGetByIndex}, config.options.Operations)
+		assert.Equal(t, 200, config.options.Samples)
+		assert.Equal(t, "v2.3.0", config.options.Label)
+		assert.Equal(t, "v2.2.0.json", config.baselineFile)
+		assert.InDelta(t, 0.15, config.threshold, 0.0001)
+
+	})
+
+	t.Run("should report every error of the configuration", func(t *testing.T) {
+
+		_, err := loadConfig(lookupFrom(map[string]string{
+			"HYDRAIDE_BENCH_SIZES":       "1000,big",
+			"HYDRAIDE_BENCH_VALUE_TYPES": "string,complex",
+			"HYDRAIDE_BENCH_OPERATIONS":  "scan",
+			"HYDRAIDE_BENCH_THRESHOLD":   "-5",
+		}))
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `HYDRAIDE_BENCH_SIZES must be positive integers separated by commas, got "big"`)
+		assert.ErrorContains(t, err, `got "complex"`)
+		assert.ErrorContains(t, err, `got "scan"`)
+		assert.ErrorContains(t, err, "HYDRAIDE_BENCH_THRESHOLD must be a non-negative percent")
+
+	})
+
+}
@@ -0,0 +1,113 @@
+// The bench command measures the latencies of the core operations of HydrAIDE, and writes them as a JSON report, so
+// the performance of the releases can be compared.
+//
+// It benchmarks a running server, or an embedded engine in a temporary folder if no server is configured. With a
+// baseline report, e.g. the report of the previous release, it lists the scenarios slower than the threshold, and
+// exits with 2 if there is any, so a CI pipeline can stop a regression.
+//
+// The benchmark is configured by environment variables (or a .env file), see docs/benchmarks.md.
+package main
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/bench/runner"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/embedded"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+)
+
+const exitRegression = 2
+
+func main() {
+	os.Exit(run())
+}
+
+// run runs the benchmark and returns the exit code, so the deferred cleanups run before the exit
+func run() int {
+
+	_ = godotenv.Load()
+
+	config, err := loadConfig(os.LookupEnv)
+	if err != nil {
+		slog.Error("invalid configuration of the benchmark", "error", err)
+		return 1
+	}
+
+	var baseline *runner.Report
+	if config.baselineFile != "" {
+		if baseline, err = runner.LoadReport(config.baselineFile); err != nil {
+			slog.Error("failed to load the baseline", "error", err)
+			return 1
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var h hydraidego.Hydraidego
+	serverVersion := ""
+	if config.server == "" {
+		engine, err := embedded.New(&embedded.Options{AllIslands: config.allIslands})
+		if err != nil {
+			slog.Error("failed to start the embedded engine", "error", err)
+			return 1
+		}
+		defer engine.Close()
+		h = engine.GetHydraidego()
+	} else {
+		hydraClient := client.New([]*client.Server{{
+			Host:         config.server,
+			FromIsland:   1,
+			ToIsland:     config.allIslands,
+			CertFilePath: config.certFile,
+			TenantToken:  config.tenantToken,
+		}}, config.allIslands, config.maxMessageSize)
+		if err := hydraClient.Connect(false); err != nil {
+			slog.Error("failed to connect to the HydrAIDE server", "server", config.server, "error", err)
+			return 1
+		}
+		defer hydraClient.CloseConnection()
+		if report, err := hydraClient.AnalyzeCluster(ctx); err == nil && len(report.Servers) > 0 {
+			serverVersion = report.Servers[0].Version
+		}
+		h = hydraidego.New(hydraClient)
+	}
+
+	slog.Info("the benchmark is started", "target", config.options.Target, "serverVersion", serverVersion)
+
+	report, err := runner.Run(ctx, h, config.options)
+	if err != nil {
+		slog.Error("the benchmark failed", "error", err)
+		return 1
+	}
+	report.ServerVersion = serverVersion
+
+	if err := report.WriteFile(config.outputFile); err != nil {
+		slog.Error("failed to write the report", "file", config.outputFile, "error", err)
+		return 1
+	}
+	slog.Info("the report is written", "file", config.outputFile, "results", len(report.Results), "duration", report.Duration)
+
+	if baseline == nil {
+		return 0
+	}
+
+	regressions := report.Compare(baseline, config.threshold)
+	for _, regression := range regressions {
+		slog.Warn("performance regression", "regression", regression.String())
+	}
+	if len(regressions) > 0 {
+		slog.Error("the benchmark is slower than the baseline", "baseline", config.baselineFile, "regressions", len(regressions),
+			"threshold", config.threshold)
+		return exitRegression
+	}
+	slog.Info("no regression compared to the baseline", "baseline", config.baselineFile)
+	return 0
+
+}
@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+)
+
+// ReportFormat is the version of the JSON format of the reports. It changes only if an older report can not be
+// compared with a newer one
+const ReportFormat = 1
+
+// Report is the result of a benchmark run, written as JSON, so the runs of the releases can be compared
+type Report struct {
+	Format int `json:"format"`
+	// Label names the run, e.g. the release of the server
+	Label         string    `json:"label,omitempty"`
+	Target        string    `json:"target"`
+	ServerVersion string    `json:"serverVersion,omitempty"`
+	StartedAt     time.Time `json:"startedAt"`
+	Duration      Duration  `json:"durationNs"`
+	GoVersion     string    `json:"goVersion"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	CPUs          int       `json:"cpus"`
+	Seed          int64     `json:"seed"`
+	Samples       int       `json:"samples"`
+	Results       []*Result `json:"results"`
+}
+
+// Result is the measurement of an operation on a Swamp of a size and a value type
+type Result struct {
+	Operation Operation `json:"operation"`
+	ValueType ValueType `json:"valueType"`
+	SwampSize int       `json:"swampSize"`
+	// Samples is the number of the measured operations, Errors is the number of the failed ones. The failed operations
+	// are not in the latencies
+	Samples   int      `json:"samples"`
+	Errors    int      `json:"errors"`
+	OpsPerSec float64  `json:"opsPerSec"`
+	Mean      Duration `json:"meanNs"`
+	P50       Duration `json:"p50Ns"`
+	P95       Duration `json:"p95Ns"`
+	P99       Duration `json:"p99Ns"`
+	Max       Duration `json:"maxNs"`
+}
+
+// Duration is a time.Duration written as nanoseconds to the JSON
+type Duration int64
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Regression is a result slower than the same result of the baseline by more than the threshold
+type Regression struct {
+	Operation Operation
+	ValueType ValueType
+	SwampSize int
+	Baseline  Duration // the p50 of the baseline
+	Current   Duration // the p50 of the current run
+	Change    float64  // the change of the p50, e.g. 0.25 is 25% slower
+}
+
+func (r *Regression) String() string {
+	return fmt.Sprintf("%s %s/%d: p50 %s -> %s (+%.1f%%)", r.Operation, r.ValueType, r.SwampSize, r.Baseline, r.Current, r.Change*100)
+}
+
+// LoadReport reads a report written by WriteFile
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read the report: %w", err)
+	}
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("invalid report %s: %w", path, err)
+	}
+	if report.Format != ReportFormat {
+		return nil, fmt.Errorf("the report %s has the format %d, expected %d", path, report.Format, ReportFormat)
+	}
+	return report, nil
+}
+
+// WriteFile writes the report as indented JSON
+func (r *Report) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Compare returns the results of the report slower than the same results of the baseline by more than the threshold,
+// e.g. 0.1 for 10%. The results are compared by their p50, because it is the least sensitive to the noise of the
+// machine. The results missing from either report, and the results with errors are skipped.
+func (r *Report) Compare(baseline *Report, threshold float64) []*Regression {
+
+	type scenario struct {
+		operation Operation
+		valueType ValueType
+		swampSize int
+	}
+
+	baselineResults := make(map[scenario]*Result, len(baseline.Results))
+	for _, result := range baseline.Results {
+		baselineResults[scenario{result.Operation, result.ValueType, result.SwampSize}] = result
+	}
+
+	var regressions []*Regression
+	for _, result := range r.Results {
+		baselineResult, ok := baselineResults[scenario{result.Operation, result.ValueType, result.SwampSize}]
+		if !ok || baselineResult.P50 <= 0 || result.Errors > 0 || baselineResult.Errors > 0 {
+			continue
+		}
+		change := float64(result.P50-baselineResult.P50) / float64(baselineResult.P50)
+		if change > threshold {
+			regressions = append(regressions, &Regression{
+				Operation: result.Operation,
+				ValueType: result.ValueType,
+				SwampSize: result.SwampSize,
+				Baseline:  baselineResult.P50,
+				Current:   result.P50,
+				Change:    change,
+			})
+		}
+	}
+
+	return regressions
+
+}
+
+// newResult summarizes the latencies of the successful operations
+func newResult(operation Operation, valueType ValueType, swampSize int, latencies []time.Duration, errors int) *Result {
+
+	result := &Result{
+		Operation: operation,
+		ValueType: valueType,
+		SwampSize: swampSize,
+		Samples:   len(latencies) + errors,
+		Errors:    errors,
+	}
+	if len(latencies) == 0 {
+		return result
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+
+	result.Mean = Duration(total / time.Duration(len(latencies)))
+	result.P50 = percentile(latencies, 50)
+	result.P95 = percentile(latencies, 95)
+	result.P99 = percentile(latencies, 99)
+	result.Max = Duration(latencies[len(latencies)-1])
+	if total > 0 {
+		result.OpsPerSec = math.Round(float64(len(latencies))/total.Seconds()*100) / 100
+	}
+
+	return result
+
+}
+
+// percentile returns the p-th percentile of the sorted latencies by the nearest rank
+func percentile(sorted []time.Duration, p int) Duration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return Duration(sorted[rank-1])
+}
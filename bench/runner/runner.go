@@ -0,0 +1,357 @@
+// Package runner runs the benchmarks of the core operations of HydrAIDE, and compares their reports.
+//
+// Every scenario is an operation on a Swamp of a size and a value type. The Swamps are filled from a seeded random
+// source before the measurement, so two runs with the same seed measure the same data, and their reports can be
+// compared, e.g. the run of a release with the run of the previous release.
+//
+// The operations:
+//   - set: CatalogSave of an existing key, chosen randomly
+//   - get: CatalogRead of an existing key, chosen randomly
+//   - getByIndex: CatalogReadMany of a page of 100 Treasures by the index of the value type, from a random offset
+//   - subscribe: the time from the start of a CatalogSave to the arrival of its event at a subscriber of the Swamp
+//
+// The operations are measured one by one, so the latencies show the cost of a single request, not the throughput of
+// the server under a parallel load.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// Operation is a benchmarked operation
+type Operation string
+
+const (
+	OperationSet        Operation = "set"
+	OperationGet        Operation = "get"
+	OperationGetByIndex Operation = "getByIndex"
+	OperationSubscribe  Operation = "subscribe"
+)
+
+// Operations are all the operations, in the order of the reports
+var Operations = []Operation{OperationSet, OperationGet, OperationGetByIndex, OperationSubscribe}
+
+const (
+	// DefaultSanctuary is the Sanctuary of the benchmarked Swamps
+	DefaultSanctuary = "hydraide-bench"
+	// DefaultSamples is the number of the measured operations per scenario
+	DefaultSamples = 1000
+	// DefaultSeed is the seed of the random source of the keys and the values
+	DefaultSeed = 1
+
+	// fillBatchSize is the number of the Treasures saved by one CatalogSaveMany while a Swamp is filled
+	fillBatchSize = 1000
+	// indexPageSize is the number of the Treasures read by one getByIndex
+	indexPageSize = 100
+	// eventTimeout is the max wait for the event of a save
+	eventTimeout = 10 * time.Second
+)
+
+// DefaultSizes are the default sizes of the Swamps
+var DefaultSizes = []int{1000, 10000, 100000}
+
+// Options are the scenarios of a run. The zero values mean the defaults
+type Options struct {
+	// Sanctuary is the Sanctuary of the benchmarked Swamps. Do not use the Sanctuary of real data, the benchmarked
+	// Swamps are destroyed by the run
+	Sanctuary  string
+	Sizes      []int
+	ValueTypes []ValueType
+	Operations []Operation
+	Samples    int
+	Seed       int64
+	// Label names the run in the report, e.g. the release of the server
+	Label string
+	// Target names the benchmarked HydrAIDE in the report, e.g. the address of the server
+	Target string
+}
+
+func (o *Options) withDefaults() *Options {
+	options := *o
+	if options.Sanctuary == "" {
+		options.Sanctuary = DefaultSanctuary
+	}
+	if len(options.Sizes) == 0 {
+		options.Sizes = DefaultSizes
+	}
+	if len(options.ValueTypes) == 0 {
+		options.ValueTypes = ValueTypes
+	}
+	if len(options.Operations) == 0 {
+		options.Operations = Operations
+	}
+	if options.Samples <= 0 {
+		options.Samples = DefaultSamples
+	}
+	if options.Seed == 0 {
+		options.Seed = DefaultSeed
+	}
+	return &options
+}
+
+// Run runs the scenarios of the options, and returns their report. The Swamps are filled before their scenarios and
+// destroyed after them, so the server keeps nothing from the run.
+//
+// An error is returned only if a Swamp can not be prepared. The failed operations are counted in the results.
+func Run(ctx context.Context, h hydraidego.Hydraidego, options *Options) (*Report, error) {
+
+	if options == nil {
+		options = &Options{}
+	}
+	options = options.withDefaults()
+
+	report := &Report{
+		Format:    ReportFormat,
+		Label:     options.Label,
+		Target:    options.Target,
+		StartedAt: time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		Seed:      options.Seed,
+		Samples:   options.Samples,
+	}
+
+	pattern := name.New().Sanctuary(options.Sanctuary).Realm("*").Swamp("*")
+	if errs := h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		SwampPattern:   pattern,
+		CloseAfterIdle: time.Hour,
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second,
+			MaxFileSize:   65536,
+		},
+	}); errs != nil {
+		return nil, fmt.Errorf("can not register the pattern of the benchmark: %w", errors.Join(errs...))
+	}
+	defer func() {
+		_ = h.DeRegisterSwamp(context.Background(), pattern)
+	}()
+
+	for _, valueType := range options.ValueTypes {
+		for _, size := range options.Sizes {
+			results, err := runSwamp(ctx, h, options, valueType, size)
+			if err != nil {
+				return nil, err
+			}
+			report.Results = append(report.Results, results...)
+		}
+	}
+
+	report.Duration = Duration(time.Since(report.StartedAt))
+	return report, nil
+
+}
+
+// runSwamp fills a Swamp of the value type and the size, and runs the operations on it
+func runSwamp(ctx context.Context, h hydraidego.Hydraidego, options *Options, valueType ValueType, size int) ([]*Result, error) {
+
+	swampName := name.New().Sanctuary(options.Sanctuary).Realm(string(valueType)).Swamp(strconv.Itoa(size))
+	random := rand.New(rand.NewSource(options.Seed))
+
+	if err := fill(ctx, h, swampName, valueType, size, random); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := h.Destroy(context.Background(), swampName); err != nil {
+			slog.Warn("can not destroy the benchmarked swamp", "swamp", swampName.Get(), "error", err)
+		}
+	}()
+
+	var results []*Result
+	for _, operation := range options.Operations {
+
+		started := time.Now()
+		var result *Result
+		switch operation {
+		case OperationSet:
+			result = runSet(ctx, h, swampName, valueType, size, options.Samples, random)
+		case OperationGet:
+			result = runGet(ctx, h, swampName, valueType, size, options.Samples, random)
+		case OperationGetByIndex:
+			result = runGetByIndex(ctx, h, swampName, valueType, size, options.Samples, random)
+		case OperationSubscribe:
+			result = runSubscribe(ctx, h, swampName, valueType, size, options.Samples, random)
+		default:
+			return nil, fmt.Errorf("unknown operation %q", operation)
+		}
+
+		slog.Info("benchmark finished", "operation", operation, "valueType", valueType, "swampSize", size,
+			"p50", result.P50, "p99", result.P99, "errors", result.Errors, "took", time.Since(started))
+		results = append(results, result)
+
+	}
+
+	return results, nil
+
+}
+
+// fill destroys the Swamp, then saves the Treasures of the size in batches
+func fill(ctx context.Context, h hydraidego.Hydraidego, swampName name.Name, valueType ValueType, size int, random *rand.Rand) error {
+
+	if err := h.Destroy(ctx, swampName); err != nil {
+		return fmt.Errorf("can not destroy the swamp %s before the benchmark: %w", swampName.Get(), err)
+	}
+
+	for from := 0; from < size; from += fillBatchSize {
+		models := make([]any, 0, fillBatchSize)
+		for i := from; i < size && i < from+fillBatchSize; i++ {
+			model, err := valueType.newModel(random, benchKey(i))
+			if err != nil {
+				return err
+			}
+			models = append(models, model)
+		}
+		if err := h.CatalogSaveMany(ctx, swampName, models, nil); err != nil {
+			return fmt.Errorf("can not fill the swamp %s: %w", swampName.Get(), err)
+		}
+	}
+
+	return nil
+
+}
+
+func runSet(ctx context.Context, h hydraidego.Hydraidego, swampName name.Name, valueType ValueType, size int, samples int, random *rand.Rand) *Result {
+	latencies := make([]time.Duration, 0, samples)
+	failed := 0
+	for i := 0; i < samples; i++ {
+		model, err := valueType.newModel(random, benchKey(random.Intn(size)))
+		if err != nil {
+			failed++
+			continue
+		}
+		started := time.Now()
+		if _, err := h.CatalogSave(ctx, swampName, model); err != nil {
+			failed++
+			continue
+		}
+		latencies = append(latencies, time.Since(started))
+	}
+	return newResult(OperationSet, valueType, size, latencies, failed)
+}
+
+func runGet(ctx context.Context, h hydraidego.Hydraidego, swampName name.Name, valueType ValueType, size int, samples int, random *rand.Rand) *Result {
+	latencies := make([]time.Duration, 0, samples)
+	failed := 0
+	for i := 0; i < samples; i++ {
+		key := benchKey(random.Intn(size))
+		model := valueType.emptyModel()
+		started := time.Now()
+		if err := h.CatalogRead(ctx, swampName, key, model); err != nil {
+			failed++
+			continue
+		}
+		latencies = append(latencies, time.Since(started))
+	}
+	return newResult(OperationGet, valueType, size, latencies, failed)
+}
+
+func runGetByIndex(ctx context.Context, h hydraidego.Hydraidego, swampName name.Name, valueType ValueType, size int, samples int, random *rand.Rand) *Result {
+	latencies := make([]time.Duration, 0, samples)
+	failed := 0
+	lastPage := max(size-indexPageSize, 0)
+	for i := 0; i < samples; i++ {
+		index := &hydraidego.Index{
+			IndexType:  valueType.indexType(),
+			IndexOrder: hydraidego.IndexOrderAsc,
+			From:       int32(random.Intn(lastPage + 1)),
+			Limit:      indexPageSize,
+		}
+		read := 0
+		started := time.Now()
+		err := h.CatalogReadMany(ctx, swampName, index, valueType.templateModel(), func(model any) error {
+			read++
+			return nil
+		})
+		if err != nil || read == 0 {
+			failed++
+			continue
+		}
+		latencies = append(latencies, time.Since(started))
+	}
+	return newResult(OperationGetByIndex, valueType, size, latencies, failed)
+}
+
+func runSubscribe(ctx context.Context, h hydraidego.Hydraidego, swampName name.Name, valueType ValueType, size int, samples int, random *rand.Rand) *Result {
+
+	received := make(chan time.Time, 1)
+	subscription, err := h.Subscribe(ctx, swampName, false, valueType.templateModel(), func(model any, eventStatus hydraidego.EventStatus, err error) error {
+		if err == nil {
+			select {
+			case received <- time.Now():
+			default:
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return newResult(OperationSubscribe, valueType, size, nil, samples)
+	}
+	defer subscription.Close()
+
+	// the subscription may be registered by the server after the Subscribe returned, so the measurement starts after
+	// the event of a first save arrived
+	ready := false
+	for attempt := 0; attempt < 100 && !ready; attempt++ {
+		model, err := valueType.newModel(random, benchKey(random.Intn(size)))
+		if err != nil {
+			break
+		}
+		if _, err := h.CatalogSave(ctx, swampName, model); err != nil {
+			break
+		}
+		select {
+		case <-received:
+			ready = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if !ready {
+		return newResult(OperationSubscribe, valueType, size, nil, samples)
+	}
+
+	latencies := make([]time.Duration, 0, samples)
+	failed := 0
+	for i := 0; i < samples; i++ {
+		model, err := valueType.newModel(random, benchKey(random.Intn(size)))
+		if err != nil {
+			failed++
+			continue
+		}
+		// drop the late events of the earlier saves
+		select {
+		case <-received:
+		default:
+		}
+		started := time.Now()
+		if _, err := h.CatalogSave(ctx, swampName, model); err != nil {
+			failed++
+			continue
+		}
+		select {
+		case receivedAt := <-received:
+			latencies = append(latencies, receivedAt.Sub(started))
+		case <-time.After(eventTimeout):
+			failed++
+		case <-subscription.Done():
+			return newResult(OperationSubscribe, valueType, size, latencies, failed+samples-i)
+		}
+	}
+
+	return newResult(OperationSubscribe, valueType, size, latencies, failed)
+
+}
+
+// benchKey returns the key of the i-th Treasure of a benchmarked Swamp
+func benchKey(i int) string {
+	return fmt.Sprintf("key-%08d", i)
+}
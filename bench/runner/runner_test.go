@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/hydraidetest"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	h := hydraidetest.New(t, nil)
+
+	t.Run("should measure every operation of every swamp", func(t *testing.T) {
+
+		report, err := Run(ctx, h, &Options{
+			Sizes:   []int{50, 150},
+			Samples: 20,
+			Label:   "test",
+			Target:  "embedded",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, ReportFormat, report.Format)
+		assert.Equal(t, "test", report.Label)
+		assert.Equal(t, int64(DefaultSeed), report.Seed)
+		require.Len(t, report.Results, len(ValueTypes)*2*len(Operations))
+
+		for _, result := range report.Results {
+			assert.Equal(t, 20, result.Samples, "%s %s/%d", result.Operation, result.ValueType, result.SwampSize)
+			assert.Zero(t, result.Errors, "%s %s/%d", result.Operation, result.ValueType, result.SwampSize)
+			assert.Positive(t, result.P50)
+			assert.LessOrEqual(t, result.P50, result.P95)
+			assert.LessOrEqual(t, result.P95, result.P99)
+			assert.LessOrEqual(t, result.P99, result.Max)
+			assert.Positive(t, result.OpsPerSec)
+		}
+
+	})
+
+	t.Run("should destroy the benchmarked swamps", func(t *testing.T) {
+
+		_, err := Run(ctx, h, &Options{
+			Sizes:      []int{10},
+			ValueTypes: []ValueType{ValueInt64},
+			Operations: []Operation{OperationGet},
+			Samples:    5,
+		})
+		require.NoError(t, err)
+
+		exists, err := h.IsSwampExist(ctx, name.New().Sanctuary(DefaultSanctuary).Realm(string(ValueInt64)).Swamp("10"))
+		assert.True(t, err == nil || hydraidego.IsSwampNotFound(err))
+		assert.False(t, exists)
+
+	})
+
+}
+
+func TestReport(t *testing.T) {
+
+	result := func(operation Operation, p50 time.Duration, errors int) *Result {
+		return &Result{Operation: operation, ValueType: ValueString, SwampSize: 1000, P50: Duration(p50), Errors: errors}
+	}
+
+	t.Run("should find the results slower than the threshold", func(t *testing.T) {
+
+		baseline := &Report{Format: ReportFormat, Results: []*Result{
+			result(OperationSet, 100*time.Microsecond, 0),
+			result(OperationGet, 100*time.Microsecond, 0),
+			result(OperationGetByIndex, 100*time.Microsecond, 1),
+		}}
+		current := &Report{Format: ReportFormat, Results: []*Result{
+			result(OperationSet, 125*time.Microsecond, 0),
+			result(OperationGet, 105*time.Microsecond, 0),
+			result(OperationGetByIndex, 300*time.Microsecond, 0),
+			result(OperationSubscribe, 300*time.Microsecond, 0),
+		}}
+
+		regressions := current.Compare(baseline, 0.1)
+		require.Len(t, regressions, 1)
+		assert.Equal(t, OperationSet, regressions[0].Operation)
+		assert.InDelta(t, 0.25, regressions[0].Change, 0.001)
+		assert.Equal(t, "set string/1000: p50 100µs -> 125µs (+25.0%)", regressions[0].String())
+
+	})
+
+	t.Run("should write and load the report as JSON", func(t *testing.T) {
+
+		path := filepath.Join(t.TempDir(), "report.json")
+		report := &Report{Format: ReportFormat, Label: "v1", Results: []*Result{result(OperationGet, time.Millisecond, 0)}}
+		require.NoError(t, report.WriteFile(path))
+
+		loaded, err := LoadReport(path)
+		require.NoError(t, err)
+		assert.Equal(t, report.Label, loaded.Label)
+		assert.Equal(t, report.Results, loaded.Results)
+
+		report.Format = ReportFormat + 1
+		require.NoError(t, report.WriteFile(path))
+		_, err = LoadReport(path)
+		assert.Error(t, err)
+
+	})
+
+}
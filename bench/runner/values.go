@@ -0,0 +1,134 @@
+package runner
+
+import (
+	"fmt"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"math/rand"
+	"reflect"
+)
+
+// ValueType is the type of the values of a benchmarked Swamp
+type ValueType string
+
+const (
+	ValueString  ValueType = "string"  // a 64 byte string
+	ValueInt64   ValueType = "int64"   // a random int64
+	ValueFloat64 ValueType = "float64" // a random float64
+	ValueBytes   ValueType = "bytes"   // 1 KB of random bytes
+	ValueStruct  ValueType = "struct"  // a small struct, encoded by the SDK
+)
+
+// ValueTypes are all the value types, in the order of the reports
+var ValueTypes = []ValueType{ValueString, ValueInt64, ValueFloat64, ValueBytes, ValueStruct}
+
+const (
+	stringValueLength = 64
+	bytesValueLength  = 1024
+)
+
+type stringModel struct {
+	Key   string `hydraide:"key"`
+	Value string `hydraide:"value"`
+}
+
+type int64Model struct {
+	Key   string `hydraide:"key"`
+	Value int64  `hydraide:"value"`
+}
+
+type float64Model struct {
+	Key   string  `hydraide:"key"`
+	Value float64 `hydraide:"value"`
+}
+
+type bytesModel struct {
+	Key   string `hydraide:"key"`
+	Value []byte `hydraide:"value"`
+}
+
+// profile is the value of the struct models, a typical small record of an application
+type profile struct {
+	Name    string
+	Email   string
+	Age     int
+	Score   float64
+	Tags    []string
+	Enabled bool
+}
+
+type structModel struct {
+	Key   string   `hydraide:"key"`
+	Value *profile `hydraide:"value"`
+}
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func randomString(random *rand.Rand, length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = letters[random.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// newModel returns a model of the value type with a random value. The values depend only on the random source, so
+// the same seed gives the same Swamps
+func (v ValueType) newModel(random *rand.Rand, key string) (any, error) {
+	switch v {
+	case ValueString:
+		return &stringModel{Key: key, Value: randomString(random, stringValueLength)}, nil
+	case ValueInt64:
+		return &int64Model{Key: key, Value: random.Int63()}, nil
+	case ValueFloat64:
+		return &float64Model{Key: key, Value: random.Float64()}, nil
+	case ValueBytes:
+		value := make([]byte, bytesValueLength)
+		random.Read(value)
+		return &bytesModel{Key: key, Value: value}, nil
+	case ValueStruct:
+		return &structModel{Key: key, Value: &profile{
+			Name:    randomString(random, 12),
+			Email:   randomString(random, 16) + "@example.com",
+			Age:     random.Intn(100),
+			Score:   random.Float64(),
+			Tags:    []string{randomString(random, 6), randomString(random, 6)},
+			Enabled: random.Intn(2) == 1,
+		}}, nil
+	}
+	return nil, fmt.Errorf("unknown value type %q", v)
+}
+
+// emptyModel returns the model the reads decode into
+func (v ValueType) emptyModel() any {
+	switch v {
+	case ValueInt64:
+		return &int64Model{}
+	case ValueFloat64:
+		return &float64Model{}
+	case ValueBytes:
+		return &bytesModel{}
+	case ValueStruct:
+		return &structModel{}
+	}
+	return &stringModel{}
+}
+
+// templateModel returns the model of the streams, which take the model by value, and create a new one for every
+// Treasure
+func (v ValueType) templateModel() any {
+	return reflect.ValueOf(v.emptyModel()).Elem().Interface()
+}
+
+// indexType returns the index of the indexed reads. The bytes and the structs can not be indexed by their values, so
+// they are read by their keys
+func (v ValueType) indexType() hydraidego.IndexType {
+	switch v {
+	case ValueString:
+		return hydraidego.IndexValueString
+	case ValueInt64:
+		return hydraidego.IndexValueInt64
+	case ValueFloat64:
+		return hydraidego.IndexValueFloat64
+	}
+	return hydraidego.IndexKey
+}
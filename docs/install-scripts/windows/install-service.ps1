@@ -0,0 +1,35 @@
+# Installs the HydrAIDE server as a Windows service. Run it from an elevated PowerShell:
+#
+#   .\install-service.ps1 -BinaryPath C:\HydrAIDE\hydraide.exe
+#
+# The service runs as LocalSystem, with its data under %ProgramData%\HydrAIDE unless -RootPath is given.
+# The certificates are expected in <RootPath>\certificate, the logs are written to <RootPath>\logs\hydraide.log.
+
+param(
+    [Parameter(Mandatory = $true)]
+    [string]$BinaryPath,
+    [string]$RootPath = (Join-Path $env:ProgramData "HydrAIDE"),
+    [string]$ServiceName = "HydrAIDE"
+)
+
+$ErrorActionPreference = "Stop"
+
+$BinaryPath = (Resolve-Path $BinaryPath).Path
+New-Item -ItemType Directory -Force -Path (Join-Path $RootPath "certificate") | Out-Null
+New-Item -ItemType Directory -Force -Path (Join-Path $RootPath "logs") | Out-Null
+
+New-Service -Name $ServiceName `
+    -BinaryPathName "`"$BinaryPath`"" `
+    -DisplayName "HydrAIDE" `
+    -Description "HydrAIDE real-time data engine" `
+    -StartupType Automatic | Out-Null
+
+# the service reads its environment from the registry, the same variables as the Docker image
+$environment = @("HYDRAIDE_ROOT_PATH=$RootPath")
+Set-ItemProperty -Path "HKLM:\SYSTEM\CurrentControlSet\Services\$ServiceName" -Name Environment -Type MultiString -Value $environment
+
+# restart the service after a crash
+sc.exe failure $ServiceName reset= 86400 actions= restart/5000/restart/5000/restart/30000 | Out-Null
+
+Write-Host "The $ServiceName service is installed. Place server.crt and server.key into $(Join-Path $RootPath 'certificate'), then start it:"
+Write-Host "  Start-Service $ServiceName"
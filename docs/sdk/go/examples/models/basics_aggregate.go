@@ -0,0 +1,58 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+)
+
+// CatalogModelBasicsAggregate is a minimal example model used for demo purposes.
+// Every Treasure is one order, the key is the order ID and the value is the amount of the order in cents.
+type CatalogModelBasicsAggregate struct {
+	OrderID string `hydraide:"key"`
+	Amount  int64  `hydraide:"value"`
+}
+
+// Revenue returns the total and the average amount of the last 100 orders, computed by the HydrAIDE server.
+// It demonstrates how to:
+// - narrow the aggregation with a key prefix and an index range
+// - use the exact integer sum for money instead of the float64 sum
+//
+// Notes:
+// - Treasures without a numeric value are skipped
+// - If the Swamp does not exist: ErrCodeSwampNotFound is returned
+//
+// ✅ Best use cases:
+// - Totals and averages of counters, prices and scores without reading the whole Swamp
+func (m *CatalogModelBasicsAggregate) Revenue(repo repo.Repo) (total int64, average float64, err error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	result, err := h.Aggregate(ctx, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("CatalogModelBasicsAggregate"),
+		&hydraidego.AggregateRequest{
+			KeyPrefix: "order-",
+			Index: &hydraidego.Index{
+				IndexType:  hydraidego.IndexCreationTime,
+				IndexOrder: hydraidego.IndexOrderDesc,
+				Limit:      100,
+			},
+		})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the integer sum is exact even above 2^53, where the float64 sum would lose precision
+	if result.IntegerSumExact {
+		return result.IntegerSum, result.Avg, nil
+	}
+
+	return int64(result.Sum), result.Avg, nil
+
+}
@@ -0,0 +1,54 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+)
+
+// CompactUserSessions rewrites the chunk files of the user sessions Swamp after a cleanup job.
+//
+// The deleted Treasures stay in their chunk files as tombstones, so a Swamp where most of the Treasures are
+// deleted (e.g. expired sessions) keeps its disk usage, and every hydration still decodes the dead records.
+// The compaction rewrites only the chunks where the live data is below the given ratio, so it is cheap to run
+// regularly, e.g. right after the cleanup job.
+//
+// 🔍 When to use this:
+// - After deleting a large part of a Swamp
+// - When the folder of a Swamp is much bigger than its live data, or its hydration is slow
+//
+// ⚠️ Important Notes:
+//   - The Swamp must exist, otherwise `hydraidego.IsSwampNotFound(err)` is true.
+//   - The chunk the new Treasures are appended to is never compacted.
+//   - 0 as minLiveRatio means the server default (0.5).
+func CompactUserSessions(repo repo.Repo) (*hydraidego.CompactionResult, error) {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	swampName := name.New().Sanctuary("users").Realm("sessions").Swamp("all")
+
+	// Rewrite the chunks with less than 30% live data
+	result, err := h.CompactSwamp(ctx, swampName, 0.3)
+	if err != nil {
+		slog.Error("Error compacting the swamp", "swamp", swampName.Get(), "error", err)
+		return nil, err
+	}
+
+	slog.Info("Swamp compacted",
+		"swamp", swampName.Get(),
+		"compactedFiles", result.CompactedFiles,
+		"writtenFiles", result.WrittenFiles,
+		"removedTreasures", result.RemovedTreasures,
+		"reclaimedBytes", result.ReclaimedBytes)
+
+	return result, nil
+}
@@ -6,6 +6,7 @@ package models
 import (
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
 )
 
@@ -58,3 +59,55 @@ func (m *CatalogModelBasicsCount) Count(repo repo.Repo) (allTreasures int32, err
 	return h.Count(ctx, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("CatalogModelBasicCount"))
 
 }
+
+// CountMany returns the number of Treasures in many Swamps with a single request per server.
+// It demonstrates how to:
+// - build a list of Swamp names
+// - call the CountMany() method instead of calling Count() in a loop
+//
+// Notes:
+// - The result map is keyed by the Swamp name (name.Get())
+// - Swamps that do not exist are not in the map
+//
+// ✅ Best use cases:
+// - Dashboards that show the size of hundreds or thousands of Swamps
+func (m *CatalogModelBasicsCount) CountMany(repo repo.Repo, swampIDs []string) (map[string]int32, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	swampNames := make([]name.Name, 0, len(swampIDs))
+	for _, swampID := range swampIDs {
+		swampNames = append(swampNames, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp(swampID))
+	}
+
+	return h.CountMany(ctx, swampNames)
+
+}
+
+// CountOverdue returns the number of the overdue jobs, without shifting them.
+// It demonstrates how to:
+// - count only the expired Treasures with CountFiltered()
+// - narrow the count to the keys with a prefix
+//
+// Notes:
+// - The Treasures are counted on the server, nothing is streamed to the client
+// - The filtered count walks the whole Swamp, so prefer Count() if no filter is needed
+//
+// ✅ Best use cases:
+// - Monitoring the overdue elements of a queue
+func (m *CatalogModelBasicsCount) CountOverdue(repo repo.Repo) (int32, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	return h.CountFiltered(ctx, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("CatalogModelBasicCount"), &hydraidego.CountFilter{
+		ExpiredOnly: true,
+		KeyPrefix:   "job-",
+	})
+
+}
@@ -7,14 +7,16 @@ import (
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
 )
 
 // CatalogModelBasicsDeregisterSwamp demonstrates how to remove a Swamp pattern
 // from the HydrAIDE internal registry using the SDK.
 //
 // 🧠 Reminder:
-// This does *not* delete Swamps or Treasures — it only removes the associated
+// DeRegisterSwamp() does *not* delete Swamps or Treasures — it only removes the associated
 // pattern from the system, so that future pattern-based operations no longer match it.
+// DeRegisterSwampAndPurge() deletes the matching Swamps too.
 type CatalogModelBasicsDeregisterSwamp struct {
 	MyModelKey   string `hydraide:"key"`   // Used as the Treasure key, not relevant to deregistration
 	MyModelValue string `hydraide:"value"` // Optional field, not used in this example
@@ -50,3 +52,35 @@ func (m *CatalogModelBasicsDeregisterSwamp) DeregisterSwamp(repo repo.Repo) (err
 	// This will affect how future pattern-based operations resolve this Swamp.
 	return h.DeRegisterSwamp(ctx, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("CatalogModelBasicsDeregisterSwamp"))
 }
+
+// RetireSwamps calls DeRegisterSwampAndPurge() via the SDK to remove a Swamp pattern together with all
+// Swamps matching it, e.g. at the end of a migration.
+//
+// ✅ The Swamps are listed first with a dry run, so the caller can check what is going to be deleted.
+// ⚠️ The destroyed Swamps can not be restored.
+//
+// Returns:
+// - Nil if the purge and the deregistration succeed
+// - A list of errors if any target server failed. The call can be repeated.
+func (m *CatalogModelBasicsDeregisterSwamp) RetireSwamps(repo repo.Repo) (errors []error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	pattern := name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("*")
+
+	// list the Swamps the purge would destroy, without destroying them
+	swamps, errs := h.DeRegisterSwampAndPurge(ctx, pattern, true)
+	if errs != nil {
+		return errs
+	}
+	for _, swampName := range swamps {
+		slog.Info("the swamp is going to be destroyed", "swamp", swampName.Get())
+	}
+
+	// destroy the Swamps and remove the pattern
+	_, errs = h.DeRegisterSwampAndPurge(ctx, pattern, false)
+	return errs
+}
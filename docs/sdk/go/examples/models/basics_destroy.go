@@ -7,6 +7,7 @@ import (
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
 )
 
 // BasicsDestroy demonstrates how to permanently erase all Treasures
@@ -48,3 +49,29 @@ func (m *BasicsDestroy) Destroy(repo repo.Repo) (err error) {
 	// Perform the Swamp-wide destruction
 	return h.Destroy(ctx, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("BasicsDestroy"))
 }
+
+// DestroyTenant permanently removes every Swamp of a tenant with one DestroyMany call, instead of a Destroy for
+// each of its Swamps.
+//
+// ⚙️ Behavior:
+// - The servers find the Swamps matching the wildcard pattern themselves, on their disks and in their memory
+// - The confirmation must repeat the pattern, so a pattern built by mistake is never destroyed
+// - The iterator is called after each destroyed Swamp, e.g. to log the progress of a large teardown
+//
+// ⚠️ Important:
+// - Stop writing the Swamps of the tenant first, a Swamp written during the teardown can be created again
+// - If the call fails, e.g. by a timeout, it can be repeated, the destroyed Swamps are simply not found again
+func (m *BasicsDestroy) DestroyTenant(repo repo.Repo, tenantID string) (err error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	tenant := name.New().Sanctuary(tenantID).Realm("*").Swamp("*")
+
+	return h.DestroyMany(ctx, tenant, tenant.Get(), func(swampName name.Name) error {
+		slog.Info("swamp destroyed", "swamp", swampName.Get())
+		return nil
+	})
+}
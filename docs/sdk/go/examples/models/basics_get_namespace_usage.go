@@ -0,0 +1,53 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"log/slog"
+)
+
+// ReportNamespaceUsage logs the disk usage, the Swamp count and the Treasure count of every Realm of every
+// Sanctuary, summed across all HydrAIDE servers.
+//
+// The servers walk the Swamp folders of their Islands without loading the Swamps, so the report is cheap enough for
+// a periodic job, and it tells which Sanctuary or Realm fills the disks.
+//
+// 🔍 When to use this:
+// - In a periodic usage report or an admin dashboard
+// - When the disks of the servers grow, and you look for the Realm behind the growth
+//
+// ⚠️ Important Notes:
+//   - The servers cache the usage for a minute. Pass `refresh = true` to walk the folders again.
+//   - The Treasure count of a closed Swamp is recorded when the Swamp is closed. The Swamps not closed since the
+//     upgrade of the server are reported in `UncountedSwamps`, and their Treasures are missing from `TreasureCount`.
+func ReportNamespaceUsage(repo repo.Repo) ([]*hydraidego.NamespaceUsage, error) {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	usages, err := h.GetNamespaceUsage(ctx, false)
+	if err != nil {
+		slog.Error("Error getting the namespace usage", "error", err)
+		return nil, err
+	}
+
+	for _, usage := range usages {
+		slog.Info("HydrAIDE namespace usage",
+			"sanctuary", usage.Sanctuary,
+			"realm", usage.Realm,
+			"swamps", usage.SwampCount,
+			"treasures", usage.TreasureCount,
+			"uncountedSwamps", usage.UncountedSwamps,
+			"diskBytes", usage.DiskBytes,
+			"collectedAt", usage.CollectedAt)
+	}
+
+	return usages, nil
+}
@@ -58,3 +58,34 @@ func (m *BasicsIsKeyExist) IsKeyExist(repo repo.Repo) (isExist bool, err error)
 		name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("BasicsIsKeyExist"),
 		m.MyModelKey)
 }
+
+// FilterNewKeys returns the candidate keys that do not exist in the Swamp yet.
+//
+// ⚙️ Behavior:
+// - Checks all candidates with a single `IsKeysExist()` request, without loading their values
+// - A missing Swamp means none of the candidates were seen before
+//
+// ✅ Use this in deduplication pipelines, where thousands of candidates are checked at once.
+func (m *BasicsIsKeyExist) FilterNewKeys(repo repo.Repo, candidates []string) ([]string, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	existing, err := h.IsKeysExist(ctx,
+		name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("BasicsIsKeyExist"),
+		candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	newKeys := make([]string, 0, len(candidates))
+	for _, key := range candidates {
+		if !existing[key] {
+			newKeys = append(newKeys, key)
+		}
+	}
+
+	return newKeys, nil
+}
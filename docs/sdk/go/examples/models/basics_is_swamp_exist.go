@@ -71,3 +71,30 @@ func (m *BasicsIsSwampExist) IsSwampExist(repo repo.Repo) (isExist bool, err err
 	return h.IsSwampExist(ctx, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("BasicsIsSwampExist"))
 
 }
+
+// ExistsMany checks the existence of many Swamps with a single roundtrip per server.
+//
+// 🧠 Example use case:
+// When the UI renders hundreds of domains, call `ExistsMany()` once instead of calling
+// `IsSwampExist()` for every row.
+//
+// ⚙️ Behavior:
+// - Exact names are always in the result map, with true or false
+// - A wildcard pattern (e.g. Realm("*")) adds every existing matching Swamp with true
+//
+// ⚠️ Wildcard patterns walk all island folders of the servers, so keep them out of hot paths.
+func (m *BasicsIsSwampExist) ExistsMany(repo repo.Repo, domains []string) (map[string]bool, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	swampNames := make([]name.Name, 0, len(domains))
+	for _, domain := range domains {
+		swampNames = append(swampNames, name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp(domain))
+	}
+
+	return h.ExistsMany(ctx, swampNames)
+
+}
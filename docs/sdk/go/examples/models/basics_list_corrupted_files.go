@@ -0,0 +1,50 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"log/slog"
+)
+
+// CheckCorruptedFiles lists the corrupted chunk files of all HydrAIDE servers and logs them.
+//
+// Every chunk file is written with a checksum, and the checksum is verified when the Swamp of the file is
+// loaded into the memory. A damaged file (e.g. a failing disk, or a copy interrupted in the middle) is skipped by
+// default, and the Swamp is served without the Treasures of the file. This check makes such silent data loss visible.
+//
+// 🔍 When to use this:
+// - In a periodic health check or an admin dashboard
+// - When a Swamp misses Treasures, or a request fails with `hydraidego.IsDataCorrupted(err)`
+//
+// ⚠️ Important Notes:
+//   - Only the files of the Swamps loaded since the start of the servers are verified.
+//   - A file is listed until it is overwritten or deleted, e.g. restored from a backup.
+//   - Start the server with `HYDRAIDE_FAIL_ON_CORRUPTED_FILES=true` if a Swamp with a corrupted file must not be
+//     served at all. Its requests fail with `IsDataCorrupted(err)` in this case.
+func CheckCorruptedFiles(repo repo.Repo) ([]*hydraidego.CorruptedFile, error) {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	corruptedFiles, err := h.ListCorruptedFiles(ctx)
+	if err != nil {
+		slog.Error("Error listing the corrupted files", "error", err)
+		return nil, err
+	}
+
+	for _, file := range corruptedFiles {
+		slog.Warn("Corrupted HydrAIDE file",
+			"file", file.FilePath,
+			"reason", file.Reason,
+			"detectedAt", file.DetectedAt)
+	}
+
+	return corruptedFiles, nil
+}
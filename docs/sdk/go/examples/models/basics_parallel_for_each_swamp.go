@@ -0,0 +1,47 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+)
+
+// CompactAllUserSessions compacts the session Swamps of all users, spreading the load evenly across the servers.
+//
+// A plain loop with a goroutine per Swamp would send most of the requests to the server of the Swamps that happen
+// to come first. `ParallelForEachSwamp()` groups the Swamps by their server, and runs at most the given number of
+// calls per server at the same time, so every server works, and none of them is overloaded.
+//
+// 🔍 When to use this:
+// - Bulk migrations, re-indexing or cleanup jobs that touch many Swamps
+//
+// ⚠️ Important Notes:
+//   - The wildcard pattern is resolved to the existing Swamps only.
+//   - The first error stops the processing, and it is returned after the running calls finished.
+func CompactAllUserSessions(repo repo.Repo) error {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	pattern := name.New().Sanctuary("users").Realm("sessions").Swamp("*")
+
+	// At most 4 compactions run at the same time on every server
+	err := h.ParallelForEachSwamp(ctx, []name.Name{pattern}, 4, func(ctx context.Context, swampName name.Name) error {
+		_, err := h.CompactSwamp(ctx, swampName, 0.3)
+		return err
+	})
+	if err != nil {
+		slog.Error("Error compacting the session swamps", "pattern", pattern.Get(), "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"time"
+)
+
+// WhoDeletedTheProfile lists the deletes of a user profile in the last 24 hours from the audit log of the servers.
+//
+// The audit log records every mutating request (e.g. Set, Delete, Destroy, the increments) with the client, the time,
+// the Swamps, the keys and the result, so it answers the question: who changed this Treasure, and when?
+//
+// 🔍 When to use this:
+// - When a Treasure changed or disappeared unexpectedly
+// - For a compliance trail of the changes of the data, e.g. in an admin dashboard
+//
+// ⚠️ Important Notes:
+//   - The audit log is optional. Start the server with `HYDRAIDE_AUDIT_ENABLED=true`, otherwise the query fails
+//     with `hydraidego.IsAuditLogDisabled(err)`.
+//   - Set a client ID in the SDK, so the records show which service sent the request.
+//   - The reads are not recorded, only the requests changing the data or the settings of the Swamps.
+func WhoDeletedTheProfile(repo repo.Repo, userID string) ([]*hydraidego.AuditRecord, error) {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	records, err := h.QueryAuditLog(ctx, &hydraidego.AuditFilter{
+		From:      time.Now().Add(-24 * time.Hour),
+		Method:    "Delete",
+		SwampName: name.New().Sanctuary("users").Realm("profiles").Swamp(userID),
+		Limit:     50,
+	})
+	if err != nil {
+		if hydraidego.IsAuditLogDisabled(err) {
+			slog.Warn("The audit log is not enabled on the HydrAIDE server")
+		}
+		return nil, err
+	}
+
+	for _, record := range records {
+		slog.Info("Profile deleted",
+			"time", record.Time,
+			"client", record.ClientID,
+			"ip", record.ClientIP,
+			"keys", record.Keys,
+			"result", record.Code)
+	}
+
+	return records, nil
+}
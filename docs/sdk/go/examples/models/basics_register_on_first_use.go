@@ -0,0 +1,54 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"time"
+)
+
+// NewLazyHydraidego creates the SDK of a microservice that touches the invoices and the monthly reports only
+// sometimes, so it does not register their patterns at its start.
+//
+// 🔍 When to use this:
+// - The service has many domains, and most of its instances never touch some of them
+// - The start of the service must not wait for the registrations on every server
+//
+// ⚙️ How it works:
+//   - The patterns are declared in a registry, which checks only the requests, and sends nothing to the servers
+//   - The first operation of a Swamp matching a declared pattern, e.g. a CatalogSave to invoices/2025/acme-corp,
+//     registers the pattern by RegisterSwamp before the operation is sent
+//   - The later operations do not register the pattern again
+//
+// ⚠️ Important Notes:
+//   - A failed registration is logged, and retried by the next operation of a matching Swamp. Register the patterns
+//     at the start of the service by RegisterSwamp if the service must not run without them
+//   - Use one registry per Hydraidego instance
+func NewLazyHydraidego(clientInterface client.Client) (hydraidego.Hydraidego, error) {
+
+	registry := hydraidego.NewPatternRegistry()
+
+	err := registry.Declare(
+		&hydraidego.RegisterSwampRequest{
+			SwampPattern:   name.New().Sanctuary("invoices").Realm("*").Swamp("*"),
+			CloseAfterIdle: time.Minute,
+			FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+				WriteInterval: 5 * time.Second,
+				MaxFileSize:   65536,
+			},
+		},
+		&hydraidego.RegisterSwampRequest{
+			SwampPattern:    name.New().Sanctuary("reports").Realm("monthly").Swamp("*"),
+			IsInMemorySwamp: true,
+			CloseAfterIdle:  10 * time.Minute,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return hydraidego.New(clientInterface, hydraidego.RegisterOnFirstUse(registry)), nil
+}
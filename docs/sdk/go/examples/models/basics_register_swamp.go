@@ -42,7 +42,13 @@ func (m *BasicsRegisterSwamp) RegisterPattern(repo repo.Repo) error {
 	//
 	// ✅ Safe to call multiple times — HydrAIDE will only apply changes if the config is different.
 	//
-	// 🔄 If you re-register with different settings, changes take effect immediately.
+	// 🔄 If you re-register with different settings, HydrAIDE rejects it with a pattern conflict error,
+	// because another service may use the same Swamps with the old settings. The same happens if an
+	// overlapping pattern, e.g. users/*/* and users/profiles/*, is registered with different settings.
+	// - hydraidego.IsPatternConflict(err) tells you that the registration was rejected
+	// - hydraidego.GetConflictingPattern(err) returns the registered pattern, and the client that registered it
+	// - Set Force: true in the request to change the settings on purpose, e.g. in a new release
+	// The new settings apply to the Swamps opened after the registration.
 	//
 	// ⚠️ Some settings behave differently:
 	// - Chunk size only applies to *new* chunks. Existing ones are unaffected.
@@ -183,6 +189,73 @@ func (m *BasicsRegisterSwamp) RegisterPattern(repo repo.Repo) error {
 			//
 			// ❌ Never go below your OS filesystem's block size
 			MaxFileSize: 8192, // 8 KB
+
+			// 💽 FsyncPolicy — Controls when the written chunk files are flushed to the disk.
+			//
+			// A write that is not flushed yet survives a crash of the server, but not a power loss.
+			// → FsyncAlways for the Swamps that must never lose a write (e.g. payments)
+			// → FsyncInterval to lose at most FsyncInterval of writes (set FsyncInterval too)
+			// → FsyncNever for the best throughput (e.g. analytics)
+			// → FsyncDefault uses the default policy of the server
+			FsyncPolicy: hydraidego.FsyncDefault,
+
+			// 🔎 KeyFilter — Answers IsKeyExists / IsKeysExist of a closed Swamp without loading it.
+			//
+			// → true for the large, mostly cold Swamps checked for new keys (e.g. deduplication)
+			// → about 1% of the missing keys still load the Swamp, and the filter costs ~10 bits per key
+			KeyFilter: false,
+
+			// 💤 LazyHydration — Answers CatalogRead of a closed Swamp by reading only the chunk of the key.
+			//
+			// → true for the huge Swamps mostly read by single keys (e.g. user profiles)
+			// → every other function still hydrates the whole Swamp
+			LazyHydration: false,
+		},
+
+		// Retention makes the server delete the old Treasures, so you don't need a cron job reading and deleting them.
+		//
+		// ✅ The server applies it every time the Swamp writes to the disk, and when the Swamp closes.
+		// ✅ The oldest Treasures by `createdAt` are deleted first.
+		//
+		// 🧪 Example (logs):
+		// Keep the logs for 30 days, but never more than 100 000 of them:
+		//   MaxAge: 30 days, MaxTreasures: 100000
+		//
+		// ❗ The models need a `createdAt` field, or the pattern must use ServerTimestamps.
+		// nil means no retention.
+		Retention: &hydraidego.RetentionPolicy{
+			MaxAge:       30 * 24 * time.Hour,
+			MaxTreasures: 100000,
+		},
+
+		// DefaultExpireAfter sets the `expireAt` of the Treasures written without it.
+		//
+		// ✅ Useful for session-like Swamps, so they can't collect immortal keys when a client forgets the tag.
+		// ✅ The Treasures written with an `expireAt` keep it.
+		//
+		// 0 means the Treasures never expire by default.
+		DefaultExpireAfter: 0,
+
+		// KeptIndexes are the indexes the server keeps in the memory all the time for the open Swamps.
+		//
+		// ✅ The other indexes are built at their first use, and dropped after IndexEvictAfterIdle without use.
+		// ✅ Useful for the Swamps mostly read by keys, that don't need all of their ordered lists in the memory.
+		//
+		// Empty means every index stays in the memory after its first use, until the Swamp closes.
+		KeptIndexes:         []hydraidego.IndexType{hydraidego.IndexKey, hydraidego.IndexCreationTime},
+		IndexEvictAfterIdle: 10 * time.Minute,
+
+		// Constraints are the schema constraints the server checks on every write of the Swamps.
+		//
+		// ✅ Writes with too long keys, too large values or missing required metadata are rejected with an error
+		//    where hydraidego.IsSchemaViolation(err) is true, and nothing of the request is stored.
+		// ✅ They protect the Swamps from every client, not only from the ones using a Validator on their models.
+		//
+		// nil means no constraints.
+		Constraints: &hydraidego.SwampConstraints{
+			MaxKeyLength:     128,
+			MaxValueSize:     64 * 1024,
+			RequiredMetadata: []hydraidego.MetadataField{hydraidego.MetadataCreatedBy},
 		},
 	})
 
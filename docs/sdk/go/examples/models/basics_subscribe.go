@@ -60,14 +60,19 @@ func (m *ModelCatalogMessages) Save(r repo.Repo) error {
 
 // Subscribe connects to the Swamp and listens for new messages in real time.
 // Callback is invoked only when a new message arrives.
-// The subscription ends when the provided context is cancelled.
+// The subscription ends when the provided context is cancelled, or when the returned handle is closed.
 // This is useful for live dashboards, inter-service events, or pushing updates to UIs.
 //
+// The returned Subscription lets the caller manage the subscription without canceling the context:
+// - subscription.Close() stops it, e.g. when the WebSocket client of the messages disconnects
+// - <-subscription.Done() waits until it stopped, and subscription.Err() tells why, so it can be restarted
+// - subscription.LastEventTime() tells when the last message arrived, e.g. for a health check
+//
 // If getExistingData=true, HydrAIDE will also deliver already-existing Treasures
 // in the Swamp as if they were new – useful when you want both history and live updates.
 //
 // ⚠️ Note: You must pass a **non-pointer** empty struct of the same type to receive events.
-func (m *ModelCatalogMessages) Subscribe(ctx context.Context, r repo.Repo, callbackFunc func(m *ModelCatalogMessages) error) error {
+func (m *ModelCatalogMessages) Subscribe(ctx context.Context, r repo.Repo, callbackFunc func(m *ModelCatalogMessages) error) (*hydraidego.Subscription, error) {
 
 	h := r.GetHydraidego()
 
@@ -80,6 +85,8 @@ func (m *ModelCatalogMessages) Subscribe(ctx context.Context, r repo.Repo, callb
 	// This is useful when you expect that some messages might already exist,
 	// and want to process them immediately, without performing a separate Read() query.
 	// It gives you both historical and live messages in a single call.
+	// The snapshot and the start of the stream are atomic on the server, so a message saved
+	// meanwhile is delivered exactly once: either with the existing Treasures or as a new event.
 
 	// ⚠️ Important: The model type passed to Subscribe (e.g. ModelCatalogMessages{}) must be:
 	// - a non-pointer struct (not *ModelCatalogMessages)
@@ -88,7 +95,7 @@ func (m *ModelCatalogMessages) Subscribe(ctx context.Context, r repo.Repo, callb
 
 	// The provided callback function is invoked whenever a new message (or "Treasure") is received.
 	// This function should contain your event processing logic.
-	err := h.Subscribe(ctx, m.getName(), false, ModelCatalogMessages{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+	subscription, err := h.Subscribe(ctx, m.getName(), false, ModelCatalogMessages{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
 
 		// This is where we handle messages received via the event stream.
 
@@ -144,11 +151,123 @@ func (m *ModelCatalogMessages) Subscribe(ctx context.Context, r repo.Repo, callb
 	// return with error if subscription failed
 	if err != nil {
 		slog.Error("Error in subscribe", "err", err)
-		return err
+		return nil, err
 	}
 
-	// Ha minden rendben volt, akkor nil-t adunk vissza
-	return nil
+	// Ha minden rendben volt, akkor visszaadjuk a subscription-t
+	return subscription, nil
+
+}
+
+// SubscribeFrom continues a subscription after a restart of the service.
+//
+// The callback receives every message saved since the `since` time, even if the service was down meanwhile,
+// then the new messages in real time. Store the returned event time after each processed message, and pass
+// it as `since` at the next start.
+//
+// The replay needs the event journal of the Swamp, see the `EventJournalSize` in RegisterPattern.
+// If the journal can not replay all messages since the given time, hydraidego.IsReplayNotAvailable(err)
+// is true, and the existing messages should be read with Subscribe(getExistingData=true) instead.
+func (m *ModelCatalogMessages) SubscribeFrom(ctx context.Context, r repo.Repo, since time.Time, callbackFunc func(m *ModelCatalogMessages, eventTime time.Time) error) (*hydraidego.Subscription, error) {
+
+	h := r.GetHydraidego()
+
+	// The missed events are replayed in their original order before SubscribeFrom returns,
+	// then the live events follow in the background, just like with Subscribe.
+	subscription, err := h.SubscribeFrom(ctx, m.getName(), since, ModelCatalogMessages{}, func(model any, eventStatus hydraidego.EventStatus, eventTime time.Time, err error) error {
+
+		if err != nil {
+			slog.Error("Error in subscription callback function", "err", err)
+			return err
+		}
+
+		// only the new messages are processed, like in Subscribe
+		if eventStatus != hydraidego.StatusNew {
+			return nil
+		}
+
+		// the eventTime is the checkpoint of the subscriber
+		return callbackFunc(model.(*ModelCatalogMessages), eventTime)
+
+	})
+
+	if err != nil {
+		if hydraidego.IsReplayNotAvailable(err) {
+			slog.Warn("The missed messages can not be replayed, a full resync is needed", "since", since)
+		}
+		return nil, err
+	}
+
+	return subscription, nil
+
+}
+
+// SubscribeAllMessages forwards every message change of all message Swamps, e.g. to a data warehouse.
+//
+// Unlike Subscribe, the change feed covers every Swamp matching the pattern on every server, including the Swamps
+// created after the subscription, and it does not load any Swamp into the memory. The existing messages are not
+// streamed, only the changes after SubscribeAll returned.
+//
+// If the forwarding is too slow, the server closes the feed instead of dropping changes silently, and
+// hydraidego.IsSubscriberOverflow(err) is true. The Swamps should be resynchronized before subscribing again.
+func (m *ModelCatalogMessages) SubscribeAllMessages(ctx context.Context, r repo.Repo, forward func(swampName string, status hydraidego.EventStatus, m *ModelCatalogMessages) error) (*hydraidego.Subscription, error) {
+
+	h := r.GetHydraidego()
+
+	pattern := name.New().Sanctuary("socketService").Realm("catalog").Swamp("*")
+
+	return h.SubscribeAll(ctx, []name.Name{pattern}, func(change *hydraidego.Change, err error) error {
+
+		if err != nil {
+			if hydraidego.IsSubscriberOverflow(err) {
+				slog.Warn("The change feed could not keep up, a full resync is needed", "err", err)
+			}
+			return err
+		}
+
+		// the deleted changes carry the message as it was at the deletion
+		message := &ModelCatalogMessages{}
+		if err := change.Decode(message); err != nil {
+			return err
+		}
+
+		return forward(change.SwampName.Get(), change.Status, message)
+
+	})
+
+}
+
+// ResumeAllMessages continues the change feed of SubscribeAllMessages after a restart of the forwarder.
+//
+// The since map holds the EventTime of the last forwarded change per Swamp name, saved by the forwarder. The missed
+// changes are replayed from the event journal of the Swamps before the new ones, so the journal must be enabled for
+// the pattern with EventJournalSize. If the journal does not cover the missed time, e.g. the server was restarted,
+// hydraidego.IsReplayNotAvailable(err) is true for the Swamp, and it must be resynchronized.
+//
+// See app/connector for a complete forwarder to Kafka and NATS JetStream.
+func (m *ModelCatalogMessages) ResumeAllMessages(ctx context.Context, r repo.Repo, since map[string]time.Time, forward func(swampName string, status hydraidego.EventStatus, m *ModelCatalogMessages) error, resync func(swampName string) error) (*hydraidego.Subscription, error) {
+
+	h := r.GetHydraidego()
+
+	pattern := name.New().Sanctuary("socketService").Realm("catalog").Swamp("*")
+
+	return h.SubscribeAllFrom(ctx, []name.Name{pattern}, since, func(change *hydraidego.Change, err error) error {
+
+		if hydraidego.IsReplayNotAvailable(err) {
+			return resync(change.SwampName.Get())
+		}
+		if err != nil {
+			return err
+		}
+
+		message := &ModelCatalogMessages{}
+		if err := change.Decode(message); err != nil {
+			return err
+		}
+
+		return forward(change.SwampName.Get(), change.Status, message)
+
+	})
 
 }
 
@@ -214,6 +333,9 @@ func (m *ModelCatalogMessages) RegisterPattern(r repo.Repo) error {
 		SwampPattern:    m.getName(),
 		CloseAfterIdle:  time.Second * 86400, // Keep alive for 1 day even if idle
 		IsInMemorySwamp: true,                // Do not persist to disk; purely volatile
+		// Keep the last 10,000 events for 1 hour, so SubscribeFrom can replay the missed messages
+		EventJournalSize:      10000,
+		EventJournalRetention: time.Hour,
 	})
 
 	if errorResponses != nil {
@@ -0,0 +1,70 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+)
+
+// BasicsSwampAnnotations demonstrates how to attach small key-value annotations to a Swamp.
+//
+// Annotations are labels owned by your application — they are stored in the Swamp's metadata,
+// not among the Treasures, so they never show up in CatalogReadMany() or Count().
+//
+// 🔍 Example use cases:
+// - Record which service owns the Swamp, so operators know whom to ask
+// - Store the schema version of the model, so a migration can find the outdated Swamps
+// - Mark the retention class of the data
+type BasicsSwampAnnotations struct {
+	MyModelKey   string `hydraide:"key"`   // This field will be used as the Treasure key
+	MyModelValue string `hydraide:"value"` // This field holds the data of the Treasure
+}
+
+// Annotate marks the Swamp with the owner service and the schema version of the model.
+//
+// ⚠️ The Swamp must already exist (it must have at least one Treasure),
+// otherwise HydrAIDE returns an `ErrCodeSwampNotFound` error.
+func (m *BasicsSwampAnnotations) Annotate(repo repo.Repo) error {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	if err := h.SetSwampAnnotation(ctx, m.createName(), "owner", "billing-service"); err != nil {
+		if hydraidego.IsSwampNotFound(err) {
+			slog.Warn("the swamp does not exist yet, nothing to annotate")
+		}
+		return err
+	}
+
+	// setting an empty value would delete the annotation
+	return h.SetSwampAnnotation(ctx, m.createName(), "schemaVersion", "2")
+
+}
+
+// GetSchemaVersion returns the schema version annotation of the Swamp, or an empty string if it is not set.
+func (m *BasicsSwampAnnotations) GetSchemaVersion(repo repo.Repo) (string, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := repo.GetHydraidego()
+
+	annotations, err := h.GetSwampAnnotations(ctx, m.createName())
+	if err != nil {
+		return "", err
+	}
+
+	return annotations["schemaVersion"], nil
+
+}
+
+func (m *BasicsSwampAnnotations) createName() name.Name {
+	return name.New().Sanctuary("MySanctuary").Realm("MyRealm").Swamp("BasicsSwampAnnotations")
+}
@@ -0,0 +1,69 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"errors"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"time"
+)
+
+// PrintSwampPatterns logs every Swamp pattern registered on the servers, with its settings.
+//
+// 🔍 When to use this:
+// - In an admin tool, to see how the Swamps of each pattern are kept in memory and written to the disk
+// - To check the result of an UpdateSwampPattern
+func PrintSwampPatterns(repo repo.Repo) error {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	patterns, err := repo.GetHydraidego().ListSwampPatterns(ctx)
+	if err != nil {
+		slog.Error("Error listing the swamp patterns", "error", err)
+		return err
+	}
+
+	for _, pattern := range patterns {
+		slog.Info("Swamp pattern",
+			"pattern", pattern.SwampPattern.Get(),
+			"inMemory", pattern.IsInMemorySwamp,
+			"closeAfterIdle", pattern.CloseAfterIdle,
+			"writeInterval", pattern.WriteInterval,
+			"maxFileSize", pattern.MaxFileSize)
+	}
+
+	return nil
+}
+
+// KeepUserSessionsLonger keeps the user sessions in memory for 10 minutes after their last access, and writes them
+// to the disk less often, without redeploying the services that register the pattern.
+//
+// ⚠️ Important Notes:
+//   - The zero fields of the request keep the current settings, so only CloseAfterIdle and WriteInterval change.
+//   - The Swamps already in memory keep their settings until they close, the new ones use the new settings.
+//   - A service that registers the pattern again with the old settings gets a pattern conflict error, and the
+//     change stays, so update its RegisterSwamp, too.
+func KeepUserSessionsLonger(repo repo.Repo) error {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// The pattern exactly as it was registered, the wildcard pattern is updated on all servers
+	errs := repo.GetHydraidego().UpdateSwampPattern(ctx, &hydraidego.UpdateSwampPatternRequest{
+		SwampPattern:   name.New().Sanctuary("users").Realm("sessions").Swamp("*"),
+		CloseAfterIdle: 10 * time.Minute,
+		WriteInterval:  30 * time.Second,
+	})
+	for _, err := range errs {
+		// IsNotFound(err) is true if the pattern is not registered on the server
+		slog.Error("Error updating the swamp pattern", "error", err, "notRegistered", hydraidego.IsNotFound(err))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,50 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+)
+
+// VerifyIslandMapping checks that the SDK routes the Swamps to the same Islands as the server computes for them.
+//
+// The SDK computes the Island of every Swamp from its name, and the server stores the Swamp on the Island sent by
+// the SDK. Every SDK, in every language, must compute exactly the same Islands, otherwise the Swamps written by one
+// SDK are invisible to the others, and they are scattered to the wrong servers.
+//
+// 🔍 When to use this:
+// - At the start of a service, before it writes any data
+// - In the tests of an SDK or a client of an other language
+//
+// ⚠️ Important Notes:
+//   - The function reads and writes no data, it only asks the server of the Swamp to compute the Island.
+//   - The server supports at most 65535 Islands.
+func VerifyIslandMapping(repo repo.Repo) error {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	mapping, err := h.VerifyIslandMapping(ctx, name.New().Sanctuary("users").Realm("profiles").Swamp("alice"))
+	if hydraidego.IsIslandMismatch(err) {
+		slog.Error("The SDK and the server compute different islands",
+			"swampName", mapping.SwampName,
+			"clientIsland", mapping.ClientIslandID,
+			"serverIsland", mapping.ServerIslandID,
+			"hashFunction", mapping.HashFunction)
+		return err
+	}
+	if err != nil {
+		slog.Error("Error verifying the island mapping", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"time"
+)
+
+// CatalogModelWikiPage represents a page of a wiki, edited by many users.
+//
+// ✅ Purpose:
+// Every edit of a page must be visible later, and a broken edit must be undone with one click.
+// The Swamp of the pages is registered with HistoryDepth, so the server keeps the last versions of every page.
+//
+// 🛠 Functions used:
+//
+// - CatalogHistory() lists the versions of a page, with the editor and the time of every edit
+// - CatalogRevertTo() sets the page back to an earlier version, as a new edit
+//
+// ⚠️ The versions are stored with the page, so keep the depth low if the pages are large.
+type CatalogModelWikiPage struct {
+	Slug      string `hydraide:"key"`
+	Body      string `hydraide:"value"`
+	UpdatedBy string `hydraide:"updatedBy"`
+}
+
+// WikiPageVersion is a version of a wiki page.
+type WikiPageVersion struct {
+	Page      *CatalogModelWikiPage
+	Version   uint64
+	UpdatedAt time.Time
+	UpdatedBy string
+}
+
+// History returns the stored versions of the page, from the oldest to the newest.
+func (c *CatalogModelWikiPage) History(r repo.Repo) ([]*WikiPageVersion, error) {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	var versions []*WikiPageVersion
+
+	// The model parameter must be a non-pointer instance, the iterator gets a new pointer for every version
+	err := h.CatalogHistory(ctx, c.getSwampName(), c.Slug, CatalogModelWikiPage{}, func(model any, version uint64, updatedAt time.Time, updatedBy string) error {
+		page, ok := model.(*CatalogModelWikiPage)
+		if !ok {
+			return hydraidego.NewError(hydraidego.ErrCodeInvalidModel, "unexpected model type")
+		}
+		versions = append(versions, &WikiPageVersion{Page: page, Version: version, UpdatedAt: updatedAt, UpdatedBy: updatedBy})
+		return nil
+	})
+
+	return versions, err
+
+}
+
+// RevertTo sets the page back to the given version. The revert is stored as a new version by the user.
+func (c *CatalogModelWikiPage) RevertTo(r repo.Repo, version uint64, userID string) error {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	// returns ErrCodeNotFound if the page or the version does not exist
+	return h.CatalogRevertTo(ctx, c.getSwampName(), c.Slug, version, userID)
+
+}
+
+// RegisterPattern registers the Swamp of the wiki pages with the last 20 versions of every page.
+func (c *CatalogModelWikiPage) RegisterPattern(repo repo.Repo) error {
+
+	h := repo.GetHydraidego()
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	errorResponses := h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		SwampPattern:    c.getSwampName(),
+		CloseAfterIdle:  time.Hour,
+		IsInMemorySwamp: false,
+		HistoryDepth:    20,
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second * 10,
+			MaxFileSize:   65536, // 64 KB
+		},
+	})
+
+	if errorResponses != nil {
+		return hydraidehelper.ConcatErrors(errorResponses)
+	}
+	return nil
+
+}
+
+// getSwampName returns the Swamp of the wiki pages: wiki/catalog/pages
+func (c *CatalogModelWikiPage) getSwampName() name.Name {
+	return name.New().Sanctuary("wiki").Realm("catalog").Swamp("pages")
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"time"
+)
+
+// CatalogModelUserTags stores the tags of a user as a single Treasure.
+//
+// ✅ Purpose:
+// Adding a tag means: read the current tags, append the new one, save the result.
+// If two services add a tag to the same user at the same time with CatalogRead + CatalogSave,
+// one of the tags is lost, because the second save overwrites the first one.
+//
+// 🛠 Why CatalogMutate?
+//
+// - CatalogMutate() locks the key, reads the current state, lets you change it, then saves it
+// - Concurrent CatalogMutate() calls on the same key run one after another
+// - You don't have to name the lock key or choose a lock TTL yourself
+//
+// 🔧 Example:
+//
+// ```go
+//
+//	tags := &CatalogModelUserTags{UserID: "user-42"}
+//	err := tags.AddTag(repo, "premium")
+//
+// ```
+type CatalogModelUserTags struct {
+	UserID    string    `hydraide:"key"`
+	Tags      []string  `hydraide:"value"`
+	UpdatedAt time.Time `hydraide:"updatedAt"`
+}
+
+// AddTag adds the tag to the user, if the user does not have it yet.
+func (c *CatalogModelUserTags) AddTag(r repo.Repo, tag string) error {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	// The model parameter must be a non-pointer instance, it is only used to create the model of the current state
+	return h.CatalogMutate(ctx, c.getSwampName(), c.UserID, CatalogModelUserTags{}, func(current any) (any, error) {
+
+		// current is nil if the user has no tags yet
+		userTags, ok := current.(*CatalogModelUserTags)
+		if !ok {
+			userTags = &CatalogModelUserTags{UserID: c.UserID}
+		}
+
+		for _, t := range userTags.Tags {
+			if t == tag {
+				// returning nil leaves the Treasure untouched
+				return nil, nil
+			}
+		}
+
+		userTags.Tags = append(userTags.Tags, tag)
+		userTags.UpdatedAt = time.Now()
+
+		// the returned model is saved while the key is still locked
+		return userTags, nil
+
+	})
+
+}
+
+// RegisterPattern registers the Swamp of the user tags.
+func (c *CatalogModelUserTags) RegisterPattern(repo repo.Repo) error {
+
+	h := repo.GetHydraidego()
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	errorResponses := h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		SwampPattern:    c.getSwampName(),
+		CloseAfterIdle:  time.Hour,
+		IsInMemorySwamp: false,
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second * 10,
+			MaxFileSize:   8192, // 8 KB
+		},
+	})
+
+	if errorResponses != nil {
+		return hydraidehelper.ConcatErrors(errorResponses)
+	}
+	return nil
+
+}
+
+// getSwampName returns the Swamp of the user tags: users/catalog/tags
+func (c *CatalogModelUserTags) getSwampName() name.Name {
+	return name.New().Sanctuary("users").Realm("catalog").Swamp("tags")
+}
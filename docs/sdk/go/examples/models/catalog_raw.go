@@ -0,0 +1,54 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+)
+
+// CopyTreasure copies a single Treasure from one Swamp to another, without knowing the model stored in it.
+//
+// CatalogReadRaw returns the Treasure with its key, its typed value and its metadata, and CatalogSaveRaw stores it
+// with the same type, so the typed models read the copy the same way as the original.
+//
+// 🔍 When to use this:
+// - In the CLI, the exporters and the admin UIs, which work with any Swamp
+// - When a Treasure is moved between Swamps or servers, and its model is not compiled into the tool
+//
+// ⚠️ Important Notes:
+//   - Only the field of `Value.Type` is set: `Int` for the signed integers, `Uint` for the unsigned integers and
+//     `Float` for the floats.
+//   - The slices, maps and structs of the models are GOB-encoded, so they are read as `ValueTypeBytes`.
+//   - The times of the metadata are `time.Time`, the authors are strings.
+func CopyTreasure(repo repo.Repo, from, to name.Name, key string) error {
+	// Create a timeout-aware context for safe cancellation
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get the HydrAIDE SDK client from the shared repository
+	h := repo.GetHydraidego()
+
+	treasure, err := h.CatalogReadRaw(ctx, from, key)
+	if err != nil {
+		slog.Error("Error reading the treasure", "swamp", from.Get(), "key", key, "error", err)
+		return err
+	}
+
+	slog.Info("HydrAIDE treasure",
+		"key", treasure.Key,
+		"type", treasure.Value.Type.String(),
+		"createdAt", treasure.Metadata[hydraidego.MetadataCreatedAt],
+		"updatedBy", treasure.Metadata[hydraidego.MetadataUpdatedBy])
+
+	if _, err := h.CatalogSaveRaw(ctx, to, treasure); err != nil {
+		slog.Error("Error saving the treasure", "swamp", to.Get(), "key", key, "error", err)
+		return err
+	}
+
+	return nil
+}
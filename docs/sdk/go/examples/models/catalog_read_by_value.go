@@ -0,0 +1,127 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"time"
+)
+
+// CatalogModelUserEmail maps user IDs to their email addresses inside a HydrAIDE Catalog Swamp.
+//
+// This model demonstrates how to use `CatalogReadByValue()` to find entries by their **value**
+// instead of their key — without scanning the whole Swamp.
+//
+// ✅ Purpose:
+// Use this model when you often need the reverse lookup of a catalog — e.g.
+// "which user is registered with this email address?".
+//
+// 🧠 How CatalogReadByValue works:
+//
+//   - The Swamp pattern must be registered with `ValueIndex: true`.
+//     This tells HydrAIDE to keep a secondary hash index from values to keys.
+//
+//   - The index lives only in memory. It is built at the first lookup and then
+//     kept up to date on every write and delete, so later lookups are O(1).
+//
+//   - The type of the searched value must match the type of the `hydraide:"value"` field.
+//     A `string` value never matches an `int64` value.
+//
+// 🔧 Usage example:
+//
+//	users, err := (&CatalogModelUserEmail{}).FindByEmail(repo, "john@example.com")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, u := range users {
+//	    fmt.Println("User:", u.UserID)
+//	}
+type CatalogModelUserEmail struct {
+	UserID    string    `hydraide:"key"`       // Unique ID of the user
+	Email     string    `hydraide:"value"`     // The email address of the user
+	CreatedAt time.Time `hydraide:"createdAt"` // When the user was registered
+}
+
+// FindByEmail returns all users registered with the given email address.
+//
+// 🧠 Use case:
+// - Login or password reset by email
+// - Checking if an email address is already taken before registration
+func (c *CatalogModelUserEmail) FindByEmail(r repo.Repo, email string) ([]*CatalogModelUserEmail, error) {
+
+	// Create a context with a default timeout using the helper.
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Retrieve the HydrAIDE SDK instance from the repository.
+	h := r.GetHydraidego()
+
+	users := make([]*CatalogModelUserEmail, 0)
+
+	// Look up all entries whose value equals the email address.
+	// The model must be a non-pointer struct, HydrAIDE creates a new instance for every result.
+	err := h.CatalogReadByValue(ctx, c.createCatalogName(), email, CatalogModelUserEmail{}, func(model any) error {
+		user, ok := model.(*CatalogModelUserEmail)
+		if !ok {
+			return hydraidego.NewError(hydraidego.ErrCodeInvalidModel, "unexpected model type")
+		}
+		users = append(users, user)
+		return nil
+	})
+
+	if err != nil {
+		if hydraidego.IsFailedPrecondition(err) {
+			slog.Error("Value index is not enabled for the swamp, register the pattern with ValueIndex: true")
+		}
+		return nil, err
+	}
+
+	return users, nil
+
+}
+
+// RegisterPattern registers the Swamp used to store the user emails with the value index enabled.
+//
+// ⚠️ Without `ValueIndex: true`, CatalogReadByValue returns an `ErrCodeFailedPrecondition` error.
+func (c *CatalogModelUserEmail) RegisterPattern(repo repo.Repo) error {
+	h := repo.GetHydraidego()
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	errorResponses := h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		// Register the exact Swamp: users/catalog/emails
+		SwampPattern: c.createCatalogName(),
+
+		// Keep the Swamp warm for 6 hours after last usage, so the value index stays in memory too
+		CloseAfterIdle: time.Second * 21600,
+
+		// Use persistent storage with disk-backed flush
+		IsInMemorySwamp: false,
+
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second * 10, // flush every 10 seconds
+			MaxFileSize:   8192,             // 8 KB file chunk size
+		},
+
+		// Enable the secondary value index for lookups by email
+		ValueIndex: true,
+	})
+
+	if errorResponses != nil {
+		return hydraidehelper.ConcatErrors(errorResponses)
+	}
+
+	return nil
+}
+
+// createCatalogName defines the Swamp name used to store the user emails.
+func (c *CatalogModelUserEmail) createCatalogName() name.Name {
+	return name.New().Sanctuary("users").Realm("catalog").Swamp("emails")
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego"
@@ -122,6 +123,43 @@ func (c *CatalogModelUserSessionLog) ReadLastSessions(r repo.Repo, userID string
 	return sessions, nil
 }
 
+// ReadOldSessionIDs returns the IDs of the sessions of a user created before the given time.
+//
+// This demonstrates how to use `CatalogReadManyKeys()` when only the keys are needed,
+// e.g. to collect the sessions to delete in a cleanup job.
+// The server sends only the keys and the metadata, so the values are neither transferred nor decoded.
+func (c *CatalogModelUserSessionLog) ReadOldSessionIDs(r repo.Repo, userID string, before time.Time) ([]string, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := r.GetHydraidego()
+
+	var sessionIDs []string
+
+	// Oldest sessions first, so the iteration can stop at the first newer session
+	index := &hydraidego.Index{
+		IndexType:  hydraidego.IndexCreationTime,
+		IndexOrder: hydraidego.IndexOrderAsc,
+	}
+
+	errStop := errors.New("no more old sessions")
+	err := h.CatalogReadManyKeys(ctx, c.createSwampForUser(userID), index, func(key string, metadata *hydraidego.KeyMetadata) error {
+		if !metadata.CreatedAt.Before(before) {
+			return errStop
+		}
+		sessionIDs = append(sessionIDs, key)
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errStop) {
+		slog.Error("Failed to read old session IDs", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return sessionIDs, nil
+}
+
 // RegisterPattern registers a wildcard Swamp pattern for user-based session storage.
 //
 // Applies to Swamps like:
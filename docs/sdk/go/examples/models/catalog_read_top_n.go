@@ -0,0 +1,73 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+)
+
+// CatalogModelLeaderboard stores the best score of every player of a game inside a HydrAIDE Catalog Swamp.
+//
+// This model demonstrates how to use `CatalogReadTopN()` to answer leaderboard queries.
+//
+// 🧠 How CatalogReadTopN works:
+//
+//   - The server keeps an in-memory index of the Swamp ordered by the value of the Treasures.
+//     The index is built at the first query and kept sorted on every write while the Swamp is open.
+//
+//   - The top N Treasures are read directly from the index, so the query costs O(N),
+//     no matter how many players the Swamp holds.
+//
+//   - The index type must match the type of the `hydraide:"value"` field, here `IndexValueInt64` for int64.
+//
+// 🔧 Usage example:
+//
+//	top, err := (&CatalogModelLeaderboard{}).Top(repo, 100)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for i, player := range top {
+//	    fmt.Println(i+1, player.PlayerID, player.Score)
+//	}
+type CatalogModelLeaderboard struct {
+	PlayerID string `hydraide:"key"`   // Unique ID of the player
+	Score    int64  `hydraide:"value"` // The best score of the player
+}
+
+// Top returns the n players with the highest scores, the best player first.
+func (c *CatalogModelLeaderboard) Top(r repo.Repo, n int32) ([]*CatalogModelLeaderboard, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := r.GetHydraidego()
+
+	players := make([]*CatalogModelLeaderboard, 0, n)
+
+	// IndexOrderDesc returns the largest scores first
+	err := h.CatalogReadTopN(ctx, c.createCatalogName(), hydraidego.IndexValueInt64, n, hydraidego.IndexOrderDesc, CatalogModelLeaderboard{},
+		func(model any) error {
+			player, ok := model.(*CatalogModelLeaderboard)
+			if !ok {
+				return hydraidego.NewError(hydraidego.ErrCodeInvalidModel, "unexpected model type")
+			}
+			players = append(players, player)
+			return nil
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return players, nil
+
+}
+
+func (c *CatalogModelLeaderboard) createCatalogName() name.Name {
+	return name.New().Sanctuary("games").Realm("leaderboard").Swamp("best-scores")
+}
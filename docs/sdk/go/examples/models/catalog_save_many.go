@@ -139,6 +139,43 @@ func (c *CatalogModelGamePlayer) SaveMany(r repo.Repo, gameID string, players []
 
 }
 
+// TrySaveMany saves the valid players of the batch, and returns the indexes of the players that could not be saved.
+//
+// 🧩 Unlike `CatalogSaveMany`, a single broken player (e.g. an empty PlayerID) does not abort the whole batch:
+// `CatalogTrySaveMany` validates all players first, saves the valid ones, and reports every player with its index,
+// so the caller can fix or retry exactly the failed ones.
+func (c *CatalogModelGamePlayer) TrySaveMany(r repo.Repo, gameID string, players []*CatalogModelGamePlayer) ([]int, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := r.GetHydraidego()
+
+	models := make([]any, 0, len(players))
+	for _, p := range players {
+		models = append(models, p)
+	}
+
+	var failed []int
+	err := h.CatalogTrySaveMany(ctx, c.createCatalogName(gameID), models, func(index int, key string, status hydraidego.EventStatus, err error) error {
+		if err != nil {
+			// IsInvalidModel(err) is true, the message names the index and the key of the player
+			log.Printf("[TrySaveMany] ❌ Player %d (%q) was not saved: %v", index, key, err)
+			failed = append(failed, index)
+			return nil
+		}
+		log.Printf("[TrySaveMany] ✅ Player %s saved with status %v", key, status)
+		return nil
+	})
+	if err != nil {
+		// the request itself failed, e.g. the server is not reachable
+		return nil, err
+	}
+
+	return failed, nil
+
+}
+
 // RegisterPattern defines the Swamp behavior: disk-backed, with 15min memory timeout.
 func (c *CatalogModelGamePlayer) RegisterPattern(r repo.Repo, gameID string) error {
 
@@ -0,0 +1,111 @@
+//go:build ignore
+// +build ignore
+
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"strings"
+)
+
+// CatalogModelCustomer stores customers in a Catalog Swamp, and shows how to evolve the model with schema versioning.
+//
+// 🧬 The story of the model:
+//
+//   - v1: the customer had only a `FullName` field.
+//   - v2: the name was split into `FirstName` and `LastName`.
+//   - v3: the `Country` field was added, with "unknown" as default.
+//
+// The `hydraide:"version"` field holds the schema version of the stored data. HydrAIDE stores it as the
+// metadata of the Treasure, and the SDK runs the registered migrations at read time, so the application
+// always sees the latest form of the model, regardless of when the data was written.
+//
+// 🔧 Usage example:
+//
+//	if err := RegisterCustomerMigrations(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	customer := &CatalogModelCustomer{CustomerID: "c-123"}
+//	if err := customer.Load(repo); err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(customer.FirstName, customer.LastName, customer.Country)
+type CatalogModelCustomer struct {
+	CustomerID    string           `hydraide:"key"`     // Unique ID of the customer
+	Payload       *CustomerPayload `hydraide:"value"`   // The data of the customer
+	SchemaVersion uint32           `hydraide:"version"` // The schema version of the stored data
+}
+
+// CustomerPayload is the value of the customer Treasure
+type CustomerPayload struct {
+	// Deprecated: FullName is kept only for the v1 → v2 migration, use FirstName and LastName instead.
+	FullName  string
+	FirstName string
+	LastName  string
+	Country   string
+}
+
+// RegisterCustomerMigrations registers the migrations of the customer model.
+// Call it once at application startup, before the first read.
+func RegisterCustomerMigrations() error {
+
+	// v1 → v2: split the full name
+	if err := hydraidego.RegisterMigration(CatalogModelCustomer{}, 1, func(model any) error {
+		customer := model.(*CatalogModelCustomer)
+		if customer.Payload == nil {
+			return nil
+		}
+		first, last, _ := strings.Cut(customer.Payload.FullName, " ")
+		customer.Payload.FirstName = first
+		customer.Payload.LastName = last
+		customer.Payload.FullName = ""
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// v2 → v3: set the default country
+	if err := hydraidego.RegisterMigration(CatalogModelCustomer{}, 2, func(model any) error {
+		customer := model.(*CatalogModelCustomer)
+		if customer.Payload != nil && customer.Payload.Country == "" {
+			customer.Payload.Country = "unknown"
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Save the upgraded customers back, so every customer is migrated only once
+	return hydraidego.SetMigrationWriteBack(CatalogModelCustomer{}, true)
+
+}
+
+// Load reads the customer by its ID. Customers stored with an older schema version are upgraded transparently.
+func (c *CatalogModelCustomer) Load(r repo.Repo) error {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	return r.GetHydraidego().CatalogRead(ctx, c.createCatalogName(), c.CustomerID, c)
+
+}
+
+// Save writes the customer. A zero SchemaVersion is stamped with the latest version automatically.
+func (c *CatalogModelCustomer) Save(r repo.Repo) error {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	_, err := r.GetHydraidego().CatalogSave(ctx, c.createCatalogName(), c)
+	return err
+
+}
+
+// createCatalogName defines the Swamp name used to store the customers.
+func (c *CatalogModelCustomer) createCatalogName() name.Name {
+	return name.New().Sanctuary("customers").Realm("catalog").Swamp("all")
+}
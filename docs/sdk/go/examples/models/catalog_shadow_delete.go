@@ -0,0 +1,122 @@
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"time"
+)
+
+// CatalogModelContract represents a signed contract of a customer.
+//
+// ✅ Purpose:
+// Contracts must never disappear without a trace. When a user deletes one, it is shadow-deleted:
+// it disappears from the application, but the server keeps its content, so the deletion can be audited
+// and undone.
+//
+// 🛠 Functions used:
+//
+// - CatalogShadowDelete() hides the contract, but keeps it on the server
+// - CatalogListDeleted() lists the hidden contracts with the time of their deletion
+// - CatalogRestore() puts a hidden contract back, with its original content
+//
+// ⚠️ Saving a new contract with the same key drops the shadow-deleted version for good.
+type CatalogModelContract struct {
+	ContractID string    `hydraide:"key"`
+	Title      string    `hydraide:"value"`
+	CreatedAt  time.Time `hydraide:"createdAt"`
+}
+
+// Delete shadow-deletes the contract of the customer.
+func (c *CatalogModelContract) Delete(r repo.Repo, customerID string) error {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	if err := h.CatalogShadowDelete(ctx, c.getSwampName(customerID), c.ContractID); err != nil {
+		if hydraidego.IsNotFound(err) {
+			slog.Warn("Contract not found – nothing to delete", "contractID", c.ContractID)
+		}
+		return err
+	}
+
+	return nil
+
+}
+
+// DeletedContract is a shadow-deleted contract with the time of its deletion.
+type DeletedContract struct {
+	Contract  *CatalogModelContract
+	DeletedAt time.Time
+}
+
+// ListDeleted returns the shadow-deleted contracts of the customer, ordered by the time of their deletion.
+func (c *CatalogModelContract) ListDeleted(r repo.Repo, customerID string) ([]*DeletedContract, error) {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	var deleted []*DeletedContract
+
+	// The model parameter must be a non-pointer instance, the iterator gets a new pointer for every contract
+	err := h.CatalogListDeleted(ctx, c.getSwampName(customerID), CatalogModelContract{}, func(model any, deletedAt time.Time) error {
+		contract, ok := model.(*CatalogModelContract)
+		if !ok {
+			return hydraidego.NewError(hydraidego.ErrCodeInvalidModel, "unexpected model type")
+		}
+		deleted = append(deleted, &DeletedContract{Contract: contract, DeletedAt: deletedAt})
+		return nil
+	})
+
+	return deleted, err
+
+}
+
+// Restore puts the shadow-deleted contract back to the customer.
+func (c *CatalogModelContract) Restore(r repo.Repo, customerID string) error {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	// returns ErrCodeNotFound if the contract is not shadow-deleted
+	return h.CatalogRestore(ctx, c.getSwampName(customerID), c.ContractID)
+
+}
+
+// RegisterPattern registers the Swamps of the contracts.
+func (c *CatalogModelContract) RegisterPattern(repo repo.Repo) error {
+
+	h := repo.GetHydraidego()
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	errorResponses := h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		SwampPattern:    name.New().Sanctuary("customers").Realm("contracts").Swamp("*"),
+		CloseAfterIdle:  time.Hour,
+		IsInMemorySwamp: false,
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second * 10,
+			MaxFileSize:   8192, // 8 KB
+		},
+	})
+
+	if errorResponses != nil {
+		return hydraidehelper.ConcatErrors(errorResponses)
+	}
+	return nil
+
+}
+
+// getSwampName returns the Swamp of the contracts of a customer: customers/contracts/{customerID}
+func (c *CatalogModelContract) getSwampName(customerID string) name.Name {
+	return name.New().Sanctuary("customers").Realm("contracts").Swamp(customerID)
+}
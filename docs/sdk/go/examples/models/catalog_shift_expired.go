@@ -136,6 +136,58 @@ func (m *ModelCatalogQueue) LoadExpired(r repo.Repo, queueName string, howMany i
 	return mcq, err
 }
 
+// ProcessExpiredWithLease processes the expired tasks of the queue with at-least-once delivery.
+//
+// Unlike LoadExpired, the tasks are not deleted when they are fetched. They are hidden for the lease time,
+// and the process function decides their fate:
+//   - nil error → the task is acknowledged and deleted from the queue
+//   - error → the task is given back, and retried after one minute
+//   - after 5 failed deliveries the task is moved to the `queue/deadletter/<queueName>` Swamp for inspection
+//
+// If the worker crashes during the processing, the lease expires after 5 minutes, and the task is delivered again.
+func (m *ModelCatalogQueue) ProcessExpiredWithLease(r repo.Repo, queueName string, howMany int32, process func(task *ModelCatalogQueue) error) error {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := r.GetHydraidego()
+
+	nackOptions := &hydraidego.NackOptions{
+		RetryAfter:      time.Minute,
+		MaxDeliveries:   5,
+		DeadLetterSwamp: name.New().Sanctuary("queue").Realm("deadletter").Swamp(queueName),
+	}
+
+	return h.CatalogShiftExpiredWithLease(ctx, m.createModelCatalogQueueSwampName(queueName), howMany, 5*time.Minute, ModelCatalogQueue{}, func(model any, lease *hydraidego.Lease) error {
+
+		queueTask, ok := model.(*ModelCatalogQueue)
+		if !ok {
+			return errors.New("wrong model type")
+		}
+
+		if processErr := process(queueTask); processErr != nil {
+			deadLettered, nackErr := h.CatalogNack(ctx, lease, nackOptions)
+			if nackErr != nil {
+				slog.Error("failed to give back the task", "queueName", queueName, "taskUUID", queueTask.TaskUUID, "error", nackErr)
+				return nil
+			}
+			if deadLettered {
+				slog.Warn("task moved to the dead-letter queue", "queueName", queueName, "taskUUID", queueTask.TaskUUID, "deliveries", lease.Deliveries)
+			}
+			return nil
+		}
+
+		// the lease expired meanwhile and an other worker took the task, so it will be processed again there
+		if ackErr := h.CatalogAck(ctx, lease); ackErr != nil && !hydraidego.IsLeaseNotFound(ackErr) {
+			return ackErr
+		}
+
+		return nil
+
+	})
+
+}
+
 // RegisterPattern registers the Swamp pattern for all queues in HydrAIDE.
 // This function must be called once during startup, before any Save or Load is attempted.
 func (m *ModelCatalogQueue) RegisterPattern(repo repo.Repo) error {
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
+	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"time"
+)
+
+// CatalogModelTeamMember demonstrates how to use HydrAIDE's CatalogUpdateManyToMany
+// to update the same user in many Swamps — without creating anything new.
+//
+// 🧠 Use-case: a user is a member of many teams, and every team has its own Swamp:
+//
+//	→ Swamp name: `teams/members/<team-id>`
+//	→ Key: `user-id`
+//	→ Value: `display name of the user`
+//
+// When the user changes their display name, every membership must be updated.
+// But if the user already left a team, the membership must NOT be created again.
+//
+// ✅ CatalogUpdateManyToMany is the ideal function here because:
+//   - It can update many Swamps in one batch, routed to the right Hydra servers
+//   - It only overwrites the existing Treasures, it never creates Treasures or Swamps
+//   - It reports the result per Treasure, so the missing memberships are visible
+//
+// 🔧 Example:
+//
+//	member := &CatalogModelTeamMember{UserID: "user-42", DisplayName: "Jane Doe"}
+//	err := member.Rename(repoInstance, []string{"backend", "platform", "oncall"})
+type CatalogModelTeamMember struct {
+	UserID      string    `hydraide:"key"`
+	DisplayName string    `hydraide:"value"`
+	UpdatedAt   time.Time `hydraide:"updatedAt"`
+}
+
+// Rename updates the display name of the user in the Swamps of the given teams.
+func (c *CatalogModelTeamMember) Rename(r repo.Repo, teamIDs []string) error {
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	h := r.GetHydraidego()
+
+	c.UpdatedAt = time.Now()
+
+	// One request per team Swamp, all with the same model
+	requests := make([]*hydraidego.CatalogManyToManyRequest, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		requests = append(requests, &hydraidego.CatalogManyToManyRequest{
+			SwampName: c.getSwampName(teamID),
+			Models:    []any{c},
+		})
+	}
+
+	return h.CatalogUpdateManyToMany(ctx, requests, func(swampName name.Name, key string, status hydraidego.EventStatus) error {
+		switch status {
+		case hydraidego.StatusModified, hydraidego.StatusNothingChanged:
+			// the membership is up to date
+		case hydraidego.StatusTreasureNotFound:
+			slog.Info("The user is not a member of the team anymore", "swamp", swampName.Get(), "userID", key)
+		case hydraidego.StatusSwampNotFound:
+			slog.Warn("The team does not exist", "swamp", swampName.Get())
+		default:
+			slog.Warn("Unexpected status of the membership update", "swamp", swampName.Get(), "userID", key, "status", status)
+		}
+		return nil // returning an error here would abort the entire batch
+	})
+
+}
+
+// RegisterPattern registers the Swamps of all teams with a wildcard pattern: teams/members/*
+func (c *CatalogModelTeamMember) RegisterPattern(repo repo.Repo) error {
+
+	h := repo.GetHydraidego()
+
+	ctx, cancel := hydraidehelper.CreateHydraContext()
+	defer cancel()
+
+	errorResponses := h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		SwampPattern:    name.New().Sanctuary("teams").Realm("members").Swamp("*"),
+		CloseAfterIdle:  time.Hour,
+		IsInMemorySwamp: false,
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second * 10,
+			MaxFileSize:   8192, // 8 KB
+		},
+	})
+
+	if errorResponses != nil {
+		return hydraidehelper.ConcatErrors(errorResponses)
+	}
+	return nil
+
+}
+
+// getSwampName returns the Swamp of the members of a team: teams/members/<team-id>
+func (c *CatalogModelTeamMember) getSwampName(teamID string) name.Name {
+	return name.New().Sanctuary("teams").Realm("members").Swamp(teamID)
+}
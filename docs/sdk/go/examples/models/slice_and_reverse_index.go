@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/hydraidehelper"
 	"github.com/hydraide/hydraide/docs/sdk/go/examples/models/utils/repo"
@@ -48,6 +49,8 @@ import (
 //   - `DeleteViewersFromTags()`  → remove viewers (Uint32SliceDelete)
 //   - `GetSliceSize()`           → count viewers for a product
 //   - `IsValueExist()`           → check if a user is already listed
+//   - `GetViewersPage()`         → read the viewers of a product page by page
+//   - `ForEachViewer()`          → stream all viewers of a product
 //   - `RegisterPattern()`        → register the Swamp in memory or persistent mode
 //
 // Each function is explained in its own documentation block below.
@@ -123,13 +126,14 @@ type ModelTagProductViewers struct {
 //	    UserIDs:   []uint32{101, 102},
 //	}
 //
-//	err := viewer.PushViewersToTag(repo, "black-friday")
+//	newViewers, err := viewer.PushViewersToTag(repo, "black-friday")
 //	// Adds users 101 and 102 to the slice for product-123 under "black-friday" tag
+//	// newViewers is 2 for the first call, and 0 if the same users are pushed again
 //
 // ⚠️ Note:
 // Values are deduplicated **per operation**, but not globally across different tags or products.
 // Each Swamp is isolated by its tag name.
-func (m *ModelTagProductViewers) PushViewersToTag(r repo.Repo, tagName string) error {
+func (m *ModelTagProductViewers) PushViewersToTag(r repo.Repo, tagName string) (newViewers int, err error) {
 
 	// Create a context with a default timeout using the helper.
 	// This ensures the request is cancelled if it takes too long,
@@ -140,12 +144,19 @@ func (m *ModelTagProductViewers) PushViewersToTag(r repo.Repo, tagName string) e
 	// Retrieve the HydrAIDE SDK instance from the repository.
 	h := r.GetHydraidego()
 
-	return h.Uint32SlicePush(ctx, m.createSwampName(tagName), []*hydraidego.KeyValuesPair{
+	// The iterator reports how many user IDs were really new for the product, so the caller can count the new
+	// viewers without reading the slice before and after the push.
+	err = h.Uint32SlicePush(ctx, m.createSwampName(tagName), []*hydraidego.KeyValuesPair{
 		{
 			Key:    m.ProductID,
 			Values: m.UserIDs,
 		},
+	}, func(key string, added int, alreadyPresent int) error {
+		newViewers += added
+		return nil
 	})
+
+	return newViewers, err
 }
 
 // DeleteViewersFromTags removes one or more user IDs from uint32 slice-type Treasures inside a tag-specific Swamp.
@@ -297,6 +308,134 @@ func (m *ModelTagProductViewers) IsValueExist(r repo.Repo, tagName string, key s
 
 }
 
+// GetViewersPage returns a page of the user IDs of a product, and the number of all its viewers.
+//
+// A popular product can collect millions of viewers — far too many for one response — so the IDs are read
+// page by page, in the order they were pushed.
+//
+// 🧪 Example:
+//
+//	ids, total, err := viewerModel.GetViewersPage(repo, "black-friday", "product-123", 0, 100)
+//	// ids holds the first 100 viewers, total is the number of all viewers
+//
+// ⚠️ Note:
+// A push or a delete between two pages can shift the IDs. Use `ForEachViewer()` to read a consistent snapshot.
+func (m *ModelTagProductViewers) GetViewersPage(r repo.Repo, tagName string, key string, offset int64, limit int64) ([]uint32, int64, error) {
+
+	// Create a context with timeout.
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get HydrAIDE client
+	h := r.GetHydraidego()
+
+	return h.Uint32SliceGetRange(ctx, m.createSwampName(tagName), key, offset, limit)
+
+}
+
+// ForEachViewer calls the function with every user ID of a product, e.g. to export the viewers or to rebuild a
+// derived index.
+//
+// The IDs are streamed in batches, so even millions of viewers never have to fit into memory at once. The stream is
+// a snapshot of the slice at the start of the call.
+//
+// 🧪 Example:
+//
+//	err := viewerModel.ForEachViewer(repo, "black-friday", "product-123", func(userID uint32) error {
+//	    return notify(userID)
+//	})
+func (m *ModelTagProductViewers) ForEachViewer(r repo.Repo, tagName string, key string, fn func(userID uint32) error) error {
+
+	// The context is not limited by the default timeout of the helper, because streaming a huge slice can take longer
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	// Get HydrAIDE client
+	h := r.GetHydraidego()
+
+	return h.Uint32SliceStream(ctx, m.createSwampName(tagName), key, fn)
+
+}
+
+// GetCommonViewers returns the user IDs who viewed all the products under a tag, e.g. to target the users interested
+// in a bundle.
+//
+// The slices are intersected on the server, so only the common viewers travel over the network — not the millions
+// of viewers of every product.
+//
+// 🧪 Example:
+//
+//	ids, err := viewerModel.GetCommonViewers(repo, "black-friday", "product-123", "product-456")
+//	// ids holds the viewers of both products, in the order they viewed product-123
+func (m *ModelTagProductViewers) GetCommonViewers(r repo.Repo, tagName string, keys ...string) ([]uint32, error) {
+
+	// Create a context with timeout.
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	// Get HydrAIDE client
+	h := r.GetHydraidego()
+
+	ids := make([]uint32, 0)
+	err := h.Uint32SliceCombine(ctx, m.createSwampName(tagName), hydraidego.SliceIntersection, keys, func(userID uint32) error {
+		ids = append(ids, userID)
+		return nil
+	})
+
+	return ids, err
+
+}
+
+// ModelViewer is a user record referenced by the user IDs in the slices of ModelTagProductViewers.
+//
+// The users are stored in the `users/catalog/all` Swamp, under the `user-<ID>` keys.
+type ModelViewer struct {
+	UserKey string `hydraide:"key"`   // e.g. "user-101"
+	Name    string `hydraide:"value"` // display name of the user
+}
+
+// ReadViewers reads the users who viewed a product under a tag, in one server roundtrip.
+//
+// The slice of the product holds only the user IDs, so `CatalogReadByReference()` resolves them to the
+// user records: the server reads the slice, and returns the Treasures under the `user-<ID>` keys of the
+// users Swamp. Users without a record are skipped.
+//
+// 🧪 Example:
+//
+//	viewers, err := viewerModel.ReadViewers(repo, "flash-sale", "product-123")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, viewer := range viewers {
+//	    fmt.Println(viewer.Name)
+//	}
+func (m *ModelTagProductViewers) ReadViewers(r repo.Repo, tagName string, productID string) ([]*ModelViewer, error) {
+
+	ctx, cancelFunc := hydraidehelper.CreateHydraContext()
+	defer cancelFunc()
+
+	h := r.GetHydraidego()
+
+	var viewers []*ModelViewer
+	err := h.CatalogReadByReference(ctx, &hydraidego.ReferenceRequest{
+		SliceSwampName:  m.createSwampName(tagName),
+		SliceKey:        productID,
+		TargetSwampName: name.New().Sanctuary("users").Realm("catalog").Swamp("all"),
+		TargetKeyPrefix: "user-",
+	}, ModelViewer{}, func(model any) error {
+		viewers = append(viewers, model.(*ModelViewer))
+		return nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to read the viewers", "tag", tagName, "productID", productID, "error", err)
+		return nil, err
+	}
+
+	return viewers, nil
+
+}
+
 // RegisterPattern declares the slice-type Swamp used for a specific tag,
 // enabling HydrAIDE to prepare a container for storing product → []userID mappings.
 //
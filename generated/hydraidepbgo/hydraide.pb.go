@@ -22,6 +22,110 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OverflowPolicy_Policy int32
+
+const (
+	OverflowPolicy_DEFAULT     OverflowPolicy_Policy = 0 // The default policy of the RPC
+	OverflowPolicy_BLOCK       OverflowPolicy_Policy = 1 // The writers of the swamps wait until the client reads an event
+	OverflowPolicy_DROP_OLDEST OverflowPolicy_Policy = 2 // The oldest event of the buffer is dropped for the new one
+	OverflowPolicy_CANCEL      OverflowPolicy_Policy = 3 // The stream is closed with the SUBSCRIBER_OVERFLOW reason
+)
+
+// Enum value maps for OverflowPolicy_Policy.
+var (
+	OverflowPolicy_Policy_name = map[int32]string{
+		0: "DEFAULT",
+		1: "BLOCK",
+		2: "DROP_OLDEST",
+		3: "CANCEL",
+	}
+	OverflowPolicy_Policy_value = map[string]int32{
+		"DEFAULT":     0,
+		"BLOCK":       1,
+		"DROP_OLDEST": 2,
+		"CANCEL":      3,
+	}
+)
+
+func (x OverflowPolicy_Policy) Enum() *OverflowPolicy_Policy {
+	p := new(OverflowPolicy_Policy)
+	*p = x
+	return p
+}
+
+func (x OverflowPolicy_Policy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OverflowPolicy_Policy) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[0].Descriptor()
+}
+
+func (OverflowPolicy_Policy) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[0]
+}
+
+func (x OverflowPolicy_Policy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OverflowPolicy_Policy.Descriptor instead.
+func (OverflowPolicy_Policy) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type FsyncPolicy_Policy int32
+
+const (
+	FsyncPolicy_DEFAULT  FsyncPolicy_Policy = 0 // The default policy of the server
+	FsyncPolicy_NEVER    FsyncPolicy_Policy = 1 // The operating system flushes the files when it decides to
+	FsyncPolicy_INTERVAL FsyncPolicy_Policy = 2 // The written files are flushed at most once per FsyncInterval, and when the swamp closes
+	FsyncPolicy_ALWAYS   FsyncPolicy_Policy = 3 // The written files are flushed after every write of the swamp
+)
+
+// Enum value maps for FsyncPolicy_Policy.
+var (
+	FsyncPolicy_Policy_name = map[int32]string{
+		0: "DEFAULT",
+		1: "NEVER",
+		2: "INTERVAL",
+		3: "ALWAYS",
+	}
+	FsyncPolicy_Policy_value = map[string]int32{
+		"DEFAULT":  0,
+		"NEVER":    1,
+		"INTERVAL": 2,
+		"ALWAYS":   3,
+	}
+)
+
+func (x FsyncPolicy_Policy) Enum() *FsyncPolicy_Policy {
+	p := new(FsyncPolicy_Policy)
+	*p = x
+	return p
+}
+
+func (x FsyncPolicy_Policy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FsyncPolicy_Policy) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[1].Descriptor()
+}
+
+func (FsyncPolicy_Policy) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[1]
+}
+
+func (x FsyncPolicy_Policy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FsyncPolicy_Policy.Descriptor instead.
+func (FsyncPolicy_Policy) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{18, 0}
+}
+
 type SwampResponse_ErrCodeEnum int32
 
 const (
@@ -52,11 +156,11 @@ func (x SwampResponse_ErrCodeEnum) String() string {
 }
 
 func (SwampResponse_ErrCodeEnum) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[0].Descriptor()
+	return file_hydraide_proto_enumTypes[2].Descriptor()
 }
 
 func (SwampResponse_ErrCodeEnum) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[0]
+	return &file_hydraide_proto_enumTypes[2]
 }
 
 func (x SwampResponse_ErrCodeEnum) Number() protoreflect.EnumNumber {
@@ -65,7 +169,7 @@ func (x SwampResponse_ErrCodeEnum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SwampResponse_ErrCodeEnum.Descriptor instead.
 func (SwampResponse_ErrCodeEnum) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{21, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{31, 0}
 }
 
 type Status_Code int32
@@ -107,11 +211,11 @@ func (x Status_Code) String() string {
 }
 
 func (Status_Code) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[1].Descriptor()
+	return file_hydraide_proto_enumTypes[3].Descriptor()
 }
 
 func (Status_Code) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[1]
+	return &file_hydraide_proto_enumTypes[3]
 }
 
 func (x Status_Code) Number() protoreflect.EnumNumber {
@@ -120,7 +224,118 @@ func (x Status_Code) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Status_Code.Descriptor instead.
 func (Status_Code) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{23, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{33, 0}
+}
+
+type Metadata_Field int32
+
+const (
+	Metadata_CREATED_AT Metadata_Field = 0
+	Metadata_CREATED_BY Metadata_Field = 1
+	Metadata_UPDATED_AT Metadata_Field = 2
+	Metadata_UPDATED_BY Metadata_Field = 3
+	Metadata_EXPIRED_AT Metadata_Field = 4
+)
+
+// Enum value maps for Metadata_Field.
+var (
+	Metadata_Field_name = map[int32]string{
+		0: "CREATED_AT",
+		1: "CREATED_BY",
+		2: "UPDATED_AT",
+		3: "UPDATED_BY",
+		4: "EXPIRED_AT",
+	}
+	Metadata_Field_value = map[string]int32{
+		"CREATED_AT": 0,
+		"CREATED_BY": 1,
+		"UPDATED_AT": 2,
+		"UPDATED_BY": 3,
+		"EXPIRED_AT": 4,
+	}
+)
+
+func (x Metadata_Field) Enum() *Metadata_Field {
+	p := new(Metadata_Field)
+	*p = x
+	return p
+}
+
+func (x Metadata_Field) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Metadata_Field) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[4].Descriptor()
+}
+
+func (Metadata_Field) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[4]
+}
+
+func (x Metadata_Field) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Metadata_Field.Descriptor instead.
+func (Metadata_Field) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{57, 0}
+}
+
+type Beacon_Type int32
+
+const (
+	Beacon_KEY             Beacon_Type = 0
+	Beacon_CREATION_TIME   Beacon_Type = 1
+	Beacon_UPDATE_TIME     Beacon_Type = 2
+	Beacon_EXPIRATION_TIME Beacon_Type = 3
+	// VALUE is the beacon of the values, whatever their type is
+	Beacon_VALUE Beacon_Type = 4
+)
+
+// Enum value maps for Beacon_Type.
+var (
+	Beacon_Type_name = map[int32]string{
+		0: "KEY",
+		1: "CREATION_TIME",
+		2: "UPDATE_TIME",
+		3: "EXPIRATION_TIME",
+		4: "VALUE",
+	}
+	Beacon_Type_value = map[string]int32{
+		"KEY":             0,
+		"CREATION_TIME":   1,
+		"UPDATE_TIME":     2,
+		"EXPIRATION_TIME": 3,
+		"VALUE":           4,
+	}
+)
+
+func (x Beacon_Type) Enum() *Beacon_Type {
+	p := new(Beacon_Type)
+	*p = x
+	return p
+}
+
+func (x Beacon_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Beacon_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[5].Descriptor()
+}
+
+func (Beacon_Type) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[5]
+}
+
+func (x Beacon_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Beacon_Type.Descriptor instead.
+func (Beacon_Type) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{58, 0}
 }
 
 type Boolean_Type int32
@@ -153,11 +368,11 @@ func (x Boolean_Type) String() string {
 }
 
 func (Boolean_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[2].Descriptor()
+	return file_hydraide_proto_enumTypes[6].Descriptor()
 }
 
 func (Boolean_Type) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[2]
+	return &file_hydraide_proto_enumTypes[6]
 }
 
 func (x Boolean_Type) Number() protoreflect.EnumNumber {
@@ -166,7 +381,7 @@ func (x Boolean_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Boolean_Type.Descriptor instead.
 func (Boolean_Type) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{33, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{59, 0}
 }
 
 type IndexType_Type int32
@@ -241,11 +456,11 @@ func (x IndexType_Type) String() string {
 }
 
 func (IndexType_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[3].Descriptor()
+	return file_hydraide_proto_enumTypes[7].Descriptor()
 }
 
 func (IndexType_Type) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[3]
+	return &file_hydraide_proto_enumTypes[7]
 }
 
 func (x IndexType_Type) Number() protoreflect.EnumNumber {
@@ -254,7 +469,7 @@ func (x IndexType_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IndexType_Type.Descriptor instead.
 func (IndexType_Type) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{35, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{61, 0}
 }
 
 type OrderType_Type int32
@@ -287,11 +502,11 @@ func (x OrderType_Type) String() string {
 }
 
 func (OrderType_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[4].Descriptor()
+	return file_hydraide_proto_enumTypes[8].Descriptor()
 }
 
 func (OrderType_Type) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[4]
+	return &file_hydraide_proto_enumTypes[8]
 }
 
 func (x OrderType_Type) Number() protoreflect.EnumNumber {
@@ -300,7 +515,7 @@ func (x OrderType_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderType_Type.Descriptor instead.
 func (OrderType_Type) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{36, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{62, 0}
 }
 
 type DeleteResponse_SwampDeleteResponse_ErrorCodeEnum int32
@@ -330,11 +545,11 @@ func (x DeleteResponse_SwampDeleteResponse_ErrorCodeEnum) String() string {
 }
 
 func (DeleteResponse_SwampDeleteResponse_ErrorCodeEnum) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[5].Descriptor()
+	return file_hydraide_proto_enumTypes[9].Descriptor()
 }
 
 func (DeleteResponse_SwampDeleteResponse_ErrorCodeEnum) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[5]
+	return &file_hydraide_proto_enumTypes[9]
 }
 
 func (x DeleteResponse_SwampDeleteResponse_ErrorCodeEnum) Number() protoreflect.EnumNumber {
@@ -343,7 +558,7 @@ func (x DeleteResponse_SwampDeleteResponse_ErrorCodeEnum) Number() protoreflect.
 
 // Deprecated: Use DeleteResponse_SwampDeleteResponse_ErrorCodeEnum.Descriptor instead.
 func (DeleteResponse_SwampDeleteResponse_ErrorCodeEnum) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{39, 0, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{71, 0, 0}
 }
 
 type Relational_Operator int32
@@ -388,11 +603,11 @@ func (x Relational_Operator) String() string {
 }
 
 func (Relational_Operator) Descriptor() protoreflect.EnumDescriptor {
-	return file_hydraide_proto_enumTypes[6].Descriptor()
+	return file_hydraide_proto_enumTypes[10].Descriptor()
 }
 
 func (Relational_Operator) Type() protoreflect.EnumType {
-	return &file_hydraide_proto_enumTypes[6]
+	return &file_hydraide_proto_enumTypes[10]
 }
 
 func (x Relational_Operator) Number() protoreflect.EnumNumber {
@@ -401,7 +616,296 @@ func (x Relational_Operator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Relational_Operator.Descriptor instead.
 func (Relational_Operator) EnumDescriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{67, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{100, 0}
+}
+
+type SetIf_Operator int32
+
+const (
+	SetIf_EQUAL      SetIf_Operator = 0 // the key exists, and its value == reference
+	SetIf_NOT_EXISTS SetIf_Operator = 1 // the key does not exist, the reference is ignored
+)
+
+// Enum value maps for SetIf_Operator.
+var (
+	SetIf_Operator_name = map[int32]string{
+		0: "EQUAL",
+		1: "NOT_EXISTS",
+	}
+	SetIf_Operator_value = map[string]int32{
+		"EQUAL":      0,
+		"NOT_EXISTS": 1,
+	}
+)
+
+func (x SetIf_Operator) Enum() *SetIf_Operator {
+	p := new(SetIf_Operator)
+	*p = x
+	return p
+}
+
+func (x SetIf_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SetIf_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[11].Descriptor()
+}
+
+func (SetIf_Operator) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[11]
+}
+
+func (x SetIf_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SetIf_Operator.Descriptor instead.
+func (SetIf_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{111, 0}
+}
+
+type Uint32SliceCombination_Operator int32
+
+const (
+	Uint32SliceCombination_UNION        Uint32SliceCombination_Operator = 0 // the values of any of the slices
+	Uint32SliceCombination_INTERSECTION Uint32SliceCombination_Operator = 1 // the values of the first slice which are in all other slices
+	Uint32SliceCombination_DIFFERENCE   Uint32SliceCombination_Operator = 2 // the values of the first slice which are in none of the other slices
+)
+
+// Enum value maps for Uint32SliceCombination_Operator.
+var (
+	Uint32SliceCombination_Operator_name = map[int32]string{
+		0: "UNION",
+		1: "INTERSECTION",
+		2: "DIFFERENCE",
+	}
+	Uint32SliceCombination_Operator_value = map[string]int32{
+		"UNION":        0,
+		"INTERSECTION": 1,
+		"DIFFERENCE":   2,
+	}
+)
+
+func (x Uint32SliceCombination_Operator) Enum() *Uint32SliceCombination_Operator {
+	p := new(Uint32SliceCombination_Operator)
+	*p = x
+	return p
+}
+
+func (x Uint32SliceCombination_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Uint32SliceCombination_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[12].Descriptor()
+}
+
+func (Uint32SliceCombination_Operator) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[12]
+}
+
+func (x Uint32SliceCombination_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Uint32SliceCombination_Operator.Descriptor instead.
+func (Uint32SliceCombination_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{132, 0}
+}
+
+type ErrorReason_Reason int32
+
+const (
+	ErrorReason_UNSPECIFIED                     ErrorReason_Reason = 0  // No specific reason, use the status code
+	ErrorReason_SWAMP_NOT_FOUND                 ErrorReason_Reason = 1  // The swamp does not exist
+	ErrorReason_KEY_NOT_FOUND                   ErrorReason_Reason = 2  // The key does not exist in the swamp
+	ErrorReason_KEY_EXISTS                      ErrorReason_Reason = 3  // The key already exists in the swamp
+	ErrorReason_CONDITION_NOT_MET               ErrorReason_Reason = 4  // The condition of a conditional write was not met
+	ErrorReason_QUOTA_EXCEEDED                  ErrorReason_Reason = 5  // A limit or quota of the server is exceeded
+	ErrorReason_INVALID_ARGUMENT                ErrorReason_Reason = 6  // The request is malformed or a required field is missing
+	ErrorReason_INVALID_FILTER_EXPRESSION       ErrorReason_Reason = 7  // The filter expression can not be parsed
+	ErrorReason_WRONG_VALUE_TYPE                ErrorReason_Reason = 8  // The stored value has a different type than the operation expects
+	ErrorReason_VALUE_INDEX_NOT_ENABLED         ErrorReason_Reason = 9  // The value index is not enabled for the swamp pattern
+	ErrorReason_LOCK_NOT_FOUND                  ErrorReason_Reason = 10 // The lock does not exist or already released
+	ErrorReason_LOCK_DEADLINE_EXCEEDED          ErrorReason_Reason = 11 // The lock could not be acquired in time
+	ErrorReason_INTERNAL                        ErrorReason_Reason = 12 // Internal server error
+	ErrorReason_DATA_CORRUPTED                  ErrorReason_Reason = 13 // A file of the swamp is corrupted, see ListCorruptedFiles
+	ErrorReason_REPLAY_NOT_AVAILABLE            ErrorReason_Reason = 14 // The event journal of the swamp does not cover the requested time
+	ErrorReason_LEASE_NOT_FOUND                 ErrorReason_Reason = 15 // The lease of the treasure does not exist or it was taken over
+	ErrorReason_VERSION_NOT_FOUND               ErrorReason_Reason = 16 // The version is not in the history of the treasure
+	ErrorReason_MESSAGE_TOO_LARGE               ErrorReason_Reason = 17 // The request or the response is larger than the max message size
+	ErrorReason_BLOB_NOT_FOUND                  ErrorReason_Reason = 18 // The blob does not exist or it was removed by the garbage collection
+	ErrorReason_INSUFFICIENT_STORAGE            ErrorReason_Reason = 19 // The disk of the server is almost full, the writes are refused until space is freed
+	ErrorReason_AUDIT_LOG_DISABLED              ErrorReason_Reason = 20 // The audit log is not enabled on the server
+	ErrorReason_SCHEMA_VIOLATION                ErrorReason_Reason = 21 // The written treasure violates a schema constraint of the swamp pattern
+	ErrorReason_SUBSCRIBER_OVERFLOW             ErrorReason_Reason = 22 // The subscriber could not keep up with the events, so its stream was closed
+	ErrorReason_POINT_IN_TIME_RESTORE_DISABLED  ErrorReason_Reason = 23 // The backups or the write-ahead log are not enabled on the server
+	ErrorReason_POINT_IN_TIME_NOT_COVERED       ErrorReason_Reason = 24 // The backups and the write-ahead log do not cover the point in time
+	ErrorReason_CONSISTENCY_NOT_REACHED         ErrorReason_Reason = 25 // The server lost the writes of the consistency token of the request
+	ErrorReason_CLUSTER_TOPOLOGY_NOT_CONFIGURED ErrorReason_Reason = 26 // The server has no valid cluster topology file
+	ErrorReason_ISLAND_MIGRATING                ErrorReason_Reason = 27 // The Island is being migrated, the writes are refused until it is done
+	ErrorReason_ISLAND_MOVED                    ErrorReason_Reason = 28 // The Island moved to an other server, the message contains its host
+	ErrorReason_ISLAND_MIGRATION_DISABLED       ErrorReason_Reason = 29 // The Islands can not be migrated by the tenants
+	ErrorReason_TOPOLOGY_CONFLICT               ErrorReason_Reason = 30 // The cluster topology changed since the version of the request
+	ErrorReason_DEFAULTS_RELOAD_DISABLED        ErrorReason_Reason = 31 // The default swamp settings can not be reloaded by the tenants
+	ErrorReason_SWAMP_PATTERN_NOT_FOUND         ErrorReason_Reason = 32 // The swamp pattern is not registered
+	ErrorReason_PATTERN_CONFLICT                ErrorReason_Reason = 33 // The swamp pattern conflicts with a registered pattern with other settings
+	ErrorReason_LIMIT_EXCEEDED                  ErrorReason_Reason = 34 // A key or a value is larger than the limit of the server, see the ErrorInfo metadata
+)
+
+// Enum value maps for ErrorReason_Reason.
+var (
+	ErrorReason_Reason_name = map[int32]string{
+		0:  "UNSPECIFIED",
+		1:  "SWAMP_NOT_FOUND",
+		2:  "KEY_NOT_FOUND",
+		3:  "KEY_EXISTS",
+		4:  "CONDITION_NOT_MET",
+		5:  "QUOTA_EXCEEDED",
+		6:  "INVALID_ARGUMENT",
+		7:  "INVALID_FILTER_EXPRESSION",
+		8:  "WRONG_VALUE_TYPE",
+		9:  "VALUE_INDEX_NOT_ENABLED",
+		10: "LOCK_NOT_FOUND",
+		11: "LOCK_DEADLINE_EXCEEDED",
+		12: "INTERNAL",
+		13: "DATA_CORRUPTED",
+		14: "REPLAY_NOT_AVAILABLE",
+		15: "LEASE_NOT_FOUND",
+		16: "VERSION_NOT_FOUND",
+		17: "MESSAGE_TOO_LARGE",
+		18: "BLOB_NOT_FOUND",
+		19: "INSUFFICIENT_STORAGE",
+		20: "AUDIT_LOG_DISABLED",
+		21: "SCHEMA_VIOLATION",
+		22: "SUBSCRIBER_OVERFLOW",
+		23: "POINT_IN_TIME_RESTORE_DISABLED",
+		24: "POINT_IN_TIME_NOT_COVERED",
+		25: "CONSISTENCY_NOT_REACHED",
+		26: "CLUSTER_TOPOLOGY_NOT_CONFIGURED",
+		27: "ISLAND_MIGRATING",
+		28: "ISLAND_MOVED",
+		29: "ISLAND_MIGRATION_DISABLED",
+		30: "TOPOLOGY_CONFLICT",
+		31: "DEFAULTS_RELOAD_DISABLED",
+		32: "SWAMP_PATTERN_NOT_FOUND",
+		33: "PATTERN_CONFLICT",
+		34: "LIMIT_EXCEEDED",
+	}
+	ErrorReason_Reason_value = map[string]int32{
+		"UNSPECIFIED":                     0,
+		"SWAMP_NOT_FOUND":                 1,
+		"KEY_NOT_FOUND":                   2,
+		"KEY_EXISTS":                      3,
+		"CONDITION_NOT_MET":               4,
+		"QUOTA_EXCEEDED":                  5,
+		"INVALID_ARGUMENT":                6,
+		"INVALID_FILTER_EXPRESSION":       7,
+		"WRONG_VALUE_TYPE":                8,
+		"VALUE_INDEX_NOT_ENABLED":         9,
+		"LOCK_NOT_FOUND":                  10,
+		"LOCK_DEADLINE_EXCEEDED":          11,
+		"INTERNAL":                        12,
+		"DATA_CORRUPTED":                  13,
+		"REPLAY_NOT_AVAILABLE":            14,
+		"LEASE_NOT_FOUND":                 15,
+		"VERSION_NOT_FOUND":               16,
+		"MESSAGE_TOO_LARGE":               17,
+		"BLOB_NOT_FOUND":                  18,
+		"INSUFFICIENT_STORAGE":            19,
+		"AUDIT_LOG_DISABLED":              20,
+		"SCHEMA_VIOLATION":                21,
+		"SUBSCRIBER_OVERFLOW":             22,
+		"POINT_IN_TIME_RESTORE_DISABLED":  23,
+		"POINT_IN_TIME_NOT_COVERED":       24,
+		"CONSISTENCY_NOT_REACHED":         25,
+		"CLUSTER_TOPOLOGY_NOT_CONFIGURED": 26,
+		"ISLAND_MIGRATING":                27,
+		"ISLAND_MOVED":                    28,
+		"ISLAND_MIGRATION_DISABLED":       29,
+		"TOPOLOGY_CONFLICT":               30,
+		"DEFAULTS_RELOAD_DISABLED":        31,
+		"SWAMP_PATTERN_NOT_FOUND":         32,
+		"PATTERN_CONFLICT":                33,
+		"LIMIT_EXCEEDED":                  34,
+	}
+)
+
+func (x ErrorReason_Reason) Enum() *ErrorReason_Reason {
+	p := new(ErrorReason_Reason)
+	*p = x
+	return p
+}
+
+func (x ErrorReason_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorReason_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[13].Descriptor()
+}
+
+func (ErrorReason_Reason) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[13]
+}
+
+func (x ErrorReason_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorReason_Reason.Descriptor instead.
+func (ErrorReason_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{154, 0}
+}
+
+type IslandState_State int32
+
+const (
+	IslandState_ACTIVE IslandState_State = 0 // the Island is served
+	IslandState_FROZEN IslandState_State = 1 // the writes of the Island are refused while it is migrated
+	IslandState_MOVED  IslandState_State = 2 // the Island moved to an other server, every request of it is refused
+)
+
+// Enum value maps for IslandState_State.
+var (
+	IslandState_State_name = map[int32]string{
+		0: "ACTIVE",
+		1: "FROZEN",
+		2: "MOVED",
+	}
+	IslandState_State_value = map[string]int32{
+		"ACTIVE": 0,
+		"FROZEN": 1,
+		"MOVED":  2,
+	}
+)
+
+func (x IslandState_State) Enum() *IslandState_State {
+	p := new(IslandState_State)
+	*p = x
+	return p
+}
+
+func (x IslandState_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IslandState_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydraide_proto_enumTypes[14].Descriptor()
+}
+
+func (IslandState_State) Type() protoreflect.EnumType {
+	return &file_hydraide_proto_enumTypes[14]
+}
+
+func (x IslandState_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IslandState_State.Descriptor instead.
+func (IslandState_State) EnumDescriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{189, 0}
 }
 
 type HeartbeatRequest struct {
@@ -454,7 +958,11 @@ type HeartbeatResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Pong is the response from the HydrAIDE server.
 	// Often just echoes back a predefined value ("pong") for health check verification.
-	Pong          string `protobuf:"bytes,1,opt,name=Pong,proto3" json:"Pong,omitempty"`
+	Pong string `protobuf:"bytes,1,opt,name=Pong,proto3" json:"Pong,omitempty"`
+	// ServerVersion is the release version of the server, e.g. "v2.3.0". Empty if the server was built without it.
+	ServerVersion string `protobuf:"bytes,2,opt,name=ServerVersion,proto3" json:"ServerVersion,omitempty"`
+	// ServerTime is the time of the server when it answered, to estimate the clock skew between the client and the server.
+	ServerTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ServerTime,proto3" json:"ServerTime,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -496,6 +1004,20 @@ func (x *HeartbeatResponse) GetPong() string {
 	return ""
 }
 
+func (x *HeartbeatResponse) GetServerVersion() string {
+	if x != nil {
+		return x.ServerVersion
+	}
+	return ""
+}
+
+func (x *HeartbeatResponse) GetServerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerTime
+	}
+	return nil
+}
+
 type LockRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Key is the unique identifier for the lock.
@@ -783,33 +1305,32 @@ func (*DestroyResponse) Descriptor() ([]byte, []int) {
 	return file_hydraide_proto_rawDescGZIP(), []int{7}
 }
 
-type SubscribeToInfoRequest struct {
+type DestroyManyRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp to observe.
-	//
-	// The server will monitor this swamp and send realtime updates
-	// whenever the number of treasures in it changes.
-	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// SwampPattern is the pattern of the swamps to destroy, e.g. "tenant-42/*/*". An exact name destroys only the
+	// swamp itself.
+	SwampPattern string `protobuf:"bytes,1,opt,name=SwampPattern,proto3" json:"SwampPattern,omitempty"`
+	// ConfirmationToken must be the same as the SwampPattern, otherwise the request is rejected with an
+	// InvalidArgument error.
+	ConfirmationToken string `protobuf:"bytes,2,opt,name=ConfirmationToken,proto3" json:"ConfirmationToken,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *SubscribeToInfoRequest) Reset() {
-	*x = SubscribeToInfoRequest{}
+func (x *DestroyManyRequest) Reset() {
+	*x = DestroyManyRequest{}
 	mi := &file_hydraide_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToInfoRequest) String() string {
+func (x *DestroyManyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToInfoRequest) ProtoMessage() {}
+func (*DestroyManyRequest) ProtoMessage() {}
 
-func (x *SubscribeToInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *DestroyManyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_hydraide_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -821,12 +1342,114 @@ func (x *SubscribeToInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToInfoRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeToInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DestroyManyRequest.ProtoReflect.Descriptor instead.
+func (*DestroyManyRequest) Descriptor() ([]byte, []int) {
 	return file_hydraide_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SubscribeToInfoRequest) GetIslandID() uint64 {
+func (x *DestroyManyRequest) GetSwampPattern() string {
+	if x != nil {
+		return x.SwampPattern
+	}
+	return ""
+}
+
+func (x *DestroyManyRequest) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+type DestroyManyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampName is the name of the destroyed swamp.
+	SwampName     string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestroyManyResponse) Reset() {
+	*x = DestroyManyResponse{}
+	mi := &file_hydraide_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestroyManyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestroyManyResponse) ProtoMessage() {}
+
+func (x *DestroyManyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestroyManyResponse.ProtoReflect.Descriptor instead.
+func (*DestroyManyResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DestroyManyResponse) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+type SubscribeToInfoRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to observe.
+	//
+	// The server will monitor this swamp and send realtime updates
+	// whenever the number of treasures in it changes.
+	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToInfoRequest) Reset() {
+	*x = SubscribeToInfoRequest{}
+	mi := &file_hydraide_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToInfoRequest) ProtoMessage() {}
+
+func (x *SubscribeToInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToInfoRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToInfoRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeToInfoRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
@@ -854,7 +1477,7 @@ type SubscribeToInfoResponse struct {
 
 func (x *SubscribeToInfoResponse) Reset() {
 	*x = SubscribeToInfoResponse{}
-	mi := &file_hydraide_proto_msgTypes[9]
+	mi := &file_hydraide_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -866,7 +1489,7 @@ func (x *SubscribeToInfoResponse) String() string {
 func (*SubscribeToInfoResponse) ProtoMessage() {}
 
 func (x *SubscribeToInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[9]
+	mi := &file_hydraide_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -879,7 +1502,7 @@ func (x *SubscribeToInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeToInfoResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeToInfoResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{9}
+	return file_hydraide_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SubscribeToInfoResponse) GetSwampName() string {
@@ -903,14 +1526,23 @@ type SubscribeToEventsRequest struct {
 	// SwampName is the name of the swamp to subscribe to.
 	//
 	// All changes (insert, update, delete) in this swamp will be streamed in real time.
-	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// IncludeSnapshot sends all current treasures of the swamp before the events, in ascending creation time order.
+	IncludeSnapshot bool `protobuf:"varint,3,opt,name=IncludeSnapshot,proto3" json:"IncludeSnapshot,omitempty"`
+	// Since replays the events of the swamp after this time from the event journal, before the new events.
+	// Can not be used together with IncludeSnapshot.
+	Since *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=Since,proto3" json:"Since,omitempty"`
+	// BufferSize is the max number of the events waiting for the client. 0 means 10000, the max is 1000000.
+	BufferSize uint32 `protobuf:"varint,5,opt,name=BufferSize,proto3" json:"BufferSize,omitempty"`
+	// Overflow is the policy of the full buffer. The default is BLOCK.
+	Overflow      OverflowPolicy_Policy `protobuf:"varint,6,opt,name=Overflow,proto3,enum=hydraidepbgo.OverflowPolicy_Policy" json:"Overflow,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeToEventsRequest) Reset() {
 	*x = SubscribeToEventsRequest{}
-	mi := &file_hydraide_proto_msgTypes[10]
+	mi := &file_hydraide_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -922,7 +1554,7 @@ func (x *SubscribeToEventsRequest) String() string {
 func (*SubscribeToEventsRequest) ProtoMessage() {}
 
 func (x *SubscribeToEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[10]
+	mi := &file_hydraide_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -935,7 +1567,7 @@ func (x *SubscribeToEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeToEventsRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeToEventsRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{10}
+	return file_hydraide_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SubscribeToEventsRequest) GetIslandID() uint64 {
@@ -952,6 +1584,148 @@ func (x *SubscribeToEventsRequest) GetSwampName() string {
 	return ""
 }
 
+func (x *SubscribeToEventsRequest) GetIncludeSnapshot() bool {
+	if x != nil {
+		return x.IncludeSnapshot
+	}
+	return false
+}
+
+func (x *SubscribeToEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *SubscribeToEventsRequest) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *SubscribeToEventsRequest) GetOverflow() OverflowPolicy_Policy {
+	if x != nil {
+		return x.Overflow
+	}
+	return OverflowPolicy_DEFAULT
+}
+
+type SubscribeAllRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Patterns are the patterns of the swamp names in the format sanctuary/realm/swamp. A "*" part matches any part of
+	// the names, e.g. "users/*/*" matches every swamp of the users sanctuary. At least one pattern is required.
+	Patterns []string `protobuf:"bytes,1,rep,name=Patterns,proto3" json:"Patterns,omitempty"`
+	// Since resumes the change feed: the key is the name of a swamp, the value is the EventTime of the last event of
+	// the swamp the client processed. The missed events of the swamp are replayed from its event journal before the
+	// SnapshotEnd message. If the journal does not cover the time, a message with ReplayNotAvailable is sent for the
+	// swamp instead, and the client should read the whole swamp. The swamps must match the patterns.
+	Since map[string]*timestamppb.Timestamp `protobuf:"bytes,2,rep,name=Since,proto3" json:"Since,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// BufferSize is the max number of the events waiting for the client. 0 means 10000, the max is 1000000.
+	BufferSize uint32 `protobuf:"varint,3,opt,name=BufferSize,proto3" json:"BufferSize,omitempty"`
+	// Overflow is the policy of the full buffer. The default is CANCEL.
+	Overflow      OverflowPolicy_Policy `protobuf:"varint,4,opt,name=Overflow,proto3,enum=hydraidepbgo.OverflowPolicy_Policy" json:"Overflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeAllRequest) Reset() {
+	*x = SubscribeAllRequest{}
+	mi := &file_hydraide_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeAllRequest) ProtoMessage() {}
+
+func (x *SubscribeAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeAllRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeAllRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SubscribeAllRequest) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *SubscribeAllRequest) GetSince() map[string]*timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *SubscribeAllRequest) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *SubscribeAllRequest) GetOverflow() OverflowPolicy_Policy {
+	if x != nil {
+		return x.Overflow
+	}
+	return OverflowPolicy_DEFAULT
+}
+
+// OverflowPolicy is the behavior of a subscription whose client reads the events slower than they are written, so
+// its buffer is full
+type OverflowPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverflowPolicy) Reset() {
+	*x = OverflowPolicy{}
+	mi := &file_hydraide_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverflowPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverflowPolicy) ProtoMessage() {}
+
+func (x *OverflowPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverflowPolicy.ProtoReflect.Descriptor instead.
+func (*OverflowPolicy) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{14}
+}
+
 type SubscribeToEventsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// SwampName is the swamp where the event occurred.
@@ -967,14 +1741,30 @@ type SubscribeToEventsResponse struct {
 	// EventTime is the timestamp when the change happened (server-generated).
 	EventTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=EventTime,proto3" json:"EventTime,omitempty"`
 	// Status is the type of the event: NEW, UPDATED, DELETED, etc.
-	Status        Status_Code `protobuf:"varint,6,opt,name=Status,proto3,enum=hydraidepbgo.Status_Code" json:"Status,omitempty"`
+	// The treasures of the snapshot have the NOTHING_CHANGED status.
+	Status Status_Code `protobuf:"varint,6,opt,name=Status,proto3,enum=hydraidepbgo.Status_Code" json:"Status,omitempty"`
+	// Sequence is the sequence number of the event in the swamp. Zero for the treasures of the snapshot.
+	Sequence uint64 `protobuf:"varint,7,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	// SnapshotEnd marks the end of the snapshot or the replayed events. The message has no treasure, and the new
+	// events follow it.
+	SnapshotEnd bool `protobuf:"varint,8,opt,name=SnapshotEnd,proto3" json:"SnapshotEnd,omitempty"`
+	// ResumeToken is the sequence number of the last event included in the snapshot or the replay, sent with
+	// SnapshotEnd.
+	// The events of the stream have a higher Sequence.
+	ResumeToken uint64 `protobuf:"varint,9,opt,name=ResumeToken,proto3" json:"ResumeToken,omitempty"`
+	// ReplayNotAvailable is set on a message of SubscribeAll without treasure, if the missed events of the swamp can
+	// not be replayed, because its event journal does not cover the requested time.
+	ReplayNotAvailable bool `protobuf:"varint,10,opt,name=ReplayNotAvailable,proto3" json:"ReplayNotAvailable,omitempty"`
+	// DroppedEvents is set on a message without treasure, if the DROP_OLDEST overflow policy dropped events since the
+	// previous such message. The following events are the newest ones.
+	DroppedEvents uint64 `protobuf:"varint,11,opt,name=DroppedEvents,proto3" json:"DroppedEvents,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeToEventsResponse) Reset() {
 	*x = SubscribeToEventsResponse{}
-	mi := &file_hydraide_proto_msgTypes[11]
+	mi := &file_hydraide_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -986,7 +1776,7 @@ func (x *SubscribeToEventsResponse) String() string {
 func (*SubscribeToEventsResponse) ProtoMessage() {}
 
 func (x *SubscribeToEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[11]
+	mi := &file_hydraide_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,7 +1789,7 @@ func (x *SubscribeToEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeToEventsResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeToEventsResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{11}
+	return file_hydraide_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SubscribeToEventsResponse) GetSwampName() string {
@@ -1044,6 +1834,41 @@ func (x *SubscribeToEventsResponse) GetStatus() Status_Code {
 	return Status_NOT_FOUND
 }
 
+func (x *SubscribeToEventsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SubscribeToEventsResponse) GetSnapshotEnd() bool {
+	if x != nil {
+		return x.SnapshotEnd
+	}
+	return false
+}
+
+func (x *SubscribeToEventsResponse) GetResumeToken() uint64 {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return 0
+}
+
+func (x *SubscribeToEventsResponse) GetReplayNotAvailable() bool {
+	if x != nil {
+		return x.ReplayNotAvailable
+	}
+	return false
+}
+
+func (x *SubscribeToEventsResponse) GetDroppedEvents() uint64 {
+	if x != nil {
+		return x.DroppedEvents
+	}
+	return 0
+}
+
 type SwampKeys struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// SwampName is the name of the swamp to operate on.
@@ -1063,7 +1888,7 @@ type SwampKeys struct {
 
 func (x *SwampKeys) Reset() {
 	*x = SwampKeys{}
-	mi := &file_hydraide_proto_msgTypes[12]
+	mi := &file_hydraide_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1075,7 +1900,7 @@ func (x *SwampKeys) String() string {
 func (*SwampKeys) ProtoMessage() {}
 
 func (x *SwampKeys) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[12]
+	mi := &file_hydraide_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,7 +1913,7 @@ func (x *SwampKeys) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwampKeys.ProtoReflect.Descriptor instead.
 func (*SwampKeys) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{12}
+	return file_hydraide_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SwampKeys) GetSwampName() string {
@@ -1130,14 +1955,120 @@ type RegisterSwampRequest struct {
 	//
 	// Optional. Applies only when IsInMemorySwamp is false.
 	// Useful for optimizing SSD usage and controlling compaction behavior.
-	MaxFileSize   *int64 `protobuf:"varint,5,opt,name=MaxFileSize,proto3,oneof" json:"MaxFileSize,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	MaxFileSize *int64 `protobuf:"varint,5,opt,name=MaxFileSize,proto3,oneof" json:"MaxFileSize,omitempty"`
+	// ValueIndex enables the secondary value index for the swamps of the pattern.
+	//
+	// If true: HydrAIDE keeps a hash index from values to keys, so the GetByValue
+	// method can find the treasures holding a given value without scanning the swamp.
+	// The index costs some memory and a small overhead on every write.
+	ValueIndex bool `protobuf:"varint,6,opt,name=ValueIndex,proto3" json:"ValueIndex,omitempty"`
+	// EventJournalSize enables the event journal for the swamps of the pattern, and sets the max number of the events
+	// kept in the journal of a swamp. 0 disables the journal.
+	//
+	// The journal lets the subscribers replay the events they missed with the Since field of the
+	// SubscribeToEventsRequest. It is kept in the memory, so it starts empty after a restart of the server.
+	EventJournalSize int64 `protobuf:"varint,7,opt,name=EventJournalSize,proto3" json:"EventJournalSize,omitempty"`
+	// EventJournalRetention is how long (in seconds) the events are kept in the event journal. 0 means the events
+	// are only dropped when the journal is full.
+	EventJournalRetention int64 `protobuf:"varint,8,opt,name=EventJournalRetention,proto3" json:"EventJournalRetention,omitempty"`
+	// ServerTimestamps makes the server manage the creation and modification times of the treasures.
+	//
+	// If true: the server sets CreatedAt when a treasure is created and UpdatedAt when it is modified, using its
+	// own clock. The CreatedAt and UpdatedAt sent by the clients are ignored, so the clock skew between the
+	// application servers can not break the ordering by these times. The assigned times are returned in the
+	// KeyStatusPair of the SetResponse.
+	ServerTimestamps bool `protobuf:"varint,9,opt,name=ServerTimestamps,proto3" json:"ServerTimestamps,omitempty"`
+	// HistoryDepth is the number of the versions kept in the history of every treasure. 0 disables the history.
+	//
+	// If set: every save of a treasure stores its content as a new version, and the oldest versions are dropped
+	// above the depth. The history is written to the filesystem with the treasure. See GetHistory and RevertTo.
+	HistoryDepth int64 `protobuf:"varint,10,opt,name=HistoryDepth,proto3" json:"HistoryDepth,omitempty"`
+	// MaxTreasureAge is the retention time (in seconds) of the treasures, measured from their creation time.
+	// 0 means no age limit.
+	//
+	// The older treasures are deleted by the server when the swamp writes to the disk and when it closes.
+	// The treasures without creation time are never deleted by the age limit.
+	MaxTreasureAge int64 `protobuf:"varint,11,opt,name=MaxTreasureAge,proto3" json:"MaxTreasureAge,omitempty"`
+	// MaxTreasures is the max number of the treasures kept in a swamp. 0 means no limit.
+	//
+	// Above the limit the oldest treasures by creation time are deleted when the swamp writes to the disk and when
+	// it closes. The treasures without creation time are the oldest.
+	MaxTreasures int64 `protobuf:"varint,12,opt,name=MaxTreasures,proto3" json:"MaxTreasures,omitempty"`
+	// MaxSwampSize is the max size (in bytes) of the files of a swamp on the disk. 0 means no limit.
+	//
+	// Above the limit the oldest treasures by creation time are deleted when the swamp writes to the disk and when
+	// it closes, and the files are compacted. The size is approximate: the chunk file the new treasures are written
+	// to is not counted.
+	MaxSwampSize int64 `protobuf:"varint,13,opt,name=MaxSwampSize,proto3" json:"MaxSwampSize,omitempty"`
+	// DefaultExpireAfter is the expiration time (in seconds) of the treasures written without an expiration time,
+	// measured from the write. 0 means the treasures never expire by default.
+	//
+	// It keeps the session-like swamps from collecting treasures that never expire, when a client forgets to set
+	// the expiration time.
+	DefaultExpireAfter int64 `protobuf:"varint,14,opt,name=DefaultExpireAfter,proto3" json:"DefaultExpireAfter,omitempty"`
+	// MaxKeyLength is the max length (in bytes) of the keys written to the swamps. 0 means no limit.
+	//
+	// A Set with a longer key is rejected with the SCHEMA_VIOLATION reason, and nothing of the request is written.
+	MaxKeyLength int64 `protobuf:"varint,15,opt,name=MaxKeyLength,proto3" json:"MaxKeyLength,omitempty"`
+	// MaxValueSize is the max size (in bytes) of the string, bytes and uint32 slice values written to the swamps.
+	// 0 means no limit.
+	//
+	// A Set with a larger value is rejected with the SCHEMA_VIOLATION reason, and nothing of the request is written.
+	MaxValueSize int64 `protobuf:"varint,16,opt,name=MaxValueSize,proto3" json:"MaxValueSize,omitempty"`
+	// RequiredMetadata are the metadata fields every treasure written to the swamps must have.
+	//
+	// A Set of a treasure without one of the fields is rejected with the SCHEMA_VIOLATION reason, and nothing of the
+	// request is written. The creation and modification times are always present if the pattern uses
+	// ServerTimestamps, and the expiration time if it uses DefaultExpireAfter.
+	RequiredMetadata []Metadata_Field `protobuf:"varint,17,rep,packed,name=RequiredMetadata,proto3,enum=hydraidepbgo.Metadata_Field" json:"RequiredMetadata,omitempty"`
+	// Fsync is the policy of flushing the files written by the swamps from the page cache of the operating system to
+	// the disk. DEFAULT means the default policy of the server.
+	//
+	// Optional. Applies only when IsInMemorySwamp is false.
+	// ALWAYS makes every write of a swamp durable before the next one, e.g. for the financial swamps, while NEVER
+	// leaves the flushing to the operating system for the best throughput, e.g. for the analytics swamps.
+	Fsync FsyncPolicy_Policy `protobuf:"varint,18,opt,name=Fsync,proto3,enum=hydraidepbgo.FsyncPolicy_Policy" json:"Fsync,omitempty"`
+	// FsyncInterval is the min time (in seconds) between two fsyncs of a swamp with the INTERVAL policy.
+	// 0 means the default of the server.
+	FsyncInterval int64 `protobuf:"varint,19,opt,name=FsyncInterval,proto3" json:"FsyncInterval,omitempty"`
+	// Force registers the pattern even if it conflicts with a registered pattern.
+	//
+	// Without it, a registration that would change the memory or filesystem settings of a registered pattern, or of
+	// an overlapping one, is rejected with the PATTERN_CONFLICT reason.
+	Force bool `protobuf:"varint,20,opt,name=Force,proto3" json:"Force,omitempty"`
+	// KeyFilter makes the swamps keep a bloom filter of their keys next to their chunk files.
+	//
+	// Optional. Applies only when IsInMemorySwamp is false.
+	// The filter is written when a swamp closes, and IsKeyExist and IsKeysExist answer the missing keys of a closed
+	// swamp from it, without loading the swamp — e.g. a deduplication pipeline checking mostly new keys against huge,
+	// cold swamps. About 1% of the missing keys still load the swamp, and a swamp not closed since a crash of the
+	// server is loaded until it closes again.
+	KeyFilter bool `protobuf:"varint,21,opt,name=KeyFilter,proto3" json:"KeyFilter,omitempty"`
+	// LazyHydration makes the swamps keep a directory of the chunk files of their keys next to their chunk files.
+	//
+	// Optional. Applies only when IsInMemorySwamp is false.
+	// The directory is written when a swamp closes, and Get reads only the chunks of the requested keys of a closed
+	// swamp by it, without loading the swamp — e.g. a profile page reading one user of a huge, cold swamp of users.
+	// Any other request loads the whole swamp as before, and a swamp not closed since a crash of the server is loaded
+	// until it closes again.
+	LazyHydration bool `protobuf:"varint,22,opt,name=LazyHydration,proto3" json:"LazyHydration,omitempty"`
+	// KeptBeacons are the order beacons the swamps maintain all the time, e.g. only KEY for the swamps read by keys.
+	//
+	// Optional. Empty keeps every beacon in the memory after its first use, until the swamp closes.
+	// The kept beacons are built when a swamp is loaded. The other beacons are built at their first use, e.g. by a
+	// GetByIndex, and they are dropped from the memory after BeaconEvictAfterIdle without use, so the rare queries
+	// don't keep all eight ordered lists of a swamp in the memory.
+	KeptBeacons []Beacon_Type `protobuf:"varint,23,rep,packed,name=KeptBeacons,proto3,enum=hydraidepbgo.Beacon_Type" json:"KeptBeacons,omitempty"`
+	// BeaconEvictAfterIdle is how long (in seconds) the beacons that are not kept stay in the memory after their
+	// last use. 0 means 10 minutes. Applies only if KeptBeacons is set.
+	BeaconEvictAfterIdle int64 `protobuf:"varint,24,opt,name=BeaconEvictAfterIdle,proto3" json:"BeaconEvictAfterIdle,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *RegisterSwampRequest) Reset() {
 	*x = RegisterSwampRequest{}
-	mi := &file_hydraide_proto_msgTypes[13]
+	mi := &file_hydraide_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1149,7 +2080,7 @@ func (x *RegisterSwampRequest) String() string {
 func (*RegisterSwampRequest) ProtoMessage() {}
 
 func (x *RegisterSwampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[13]
+	mi := &file_hydraide_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,7 +2093,7 @@ func (x *RegisterSwampRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterSwampRequest.ProtoReflect.Descriptor instead.
 func (*RegisterSwampRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{13}
+	return file_hydraide_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RegisterSwampRequest) GetSwampPattern() string {
@@ -1200,6 +2131,176 @@ func (x *RegisterSwampRequest) GetMaxFileSize() int64 {
 	return 0
 }
 
+func (x *RegisterSwampRequest) GetValueIndex() bool {
+	if x != nil {
+		return x.ValueIndex
+	}
+	return false
+}
+
+func (x *RegisterSwampRequest) GetEventJournalSize() int64 {
+	if x != nil {
+		return x.EventJournalSize
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetEventJournalRetention() int64 {
+	if x != nil {
+		return x.EventJournalRetention
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetServerTimestamps() bool {
+	if x != nil {
+		return x.ServerTimestamps
+	}
+	return false
+}
+
+func (x *RegisterSwampRequest) GetHistoryDepth() int64 {
+	if x != nil {
+		return x.HistoryDepth
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetMaxTreasureAge() int64 {
+	if x != nil {
+		return x.MaxTreasureAge
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetMaxTreasures() int64 {
+	if x != nil {
+		return x.MaxTreasures
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetMaxSwampSize() int64 {
+	if x != nil {
+		return x.MaxSwampSize
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetDefaultExpireAfter() int64 {
+	if x != nil {
+		return x.DefaultExpireAfter
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetMaxKeyLength() int64 {
+	if x != nil {
+		return x.MaxKeyLength
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetMaxValueSize() int64 {
+	if x != nil {
+		return x.MaxValueSize
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetRequiredMetadata() []Metadata_Field {
+	if x != nil {
+		return x.RequiredMetadata
+	}
+	return nil
+}
+
+func (x *RegisterSwampRequest) GetFsync() FsyncPolicy_Policy {
+	if x != nil {
+		return x.Fsync
+	}
+	return FsyncPolicy_DEFAULT
+}
+
+func (x *RegisterSwampRequest) GetFsyncInterval() int64 {
+	if x != nil {
+		return x.FsyncInterval
+	}
+	return 0
+}
+
+func (x *RegisterSwampRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *RegisterSwampRequest) GetKeyFilter() bool {
+	if x != nil {
+		return x.KeyFilter
+	}
+	return false
+}
+
+func (x *RegisterSwampRequest) GetLazyHydration() bool {
+	if x != nil {
+		return x.LazyHydration
+	}
+	return false
+}
+
+func (x *RegisterSwampRequest) GetKeptBeacons() []Beacon_Type {
+	if x != nil {
+		return x.KeptBeacons
+	}
+	return nil
+}
+
+func (x *RegisterSwampRequest) GetBeaconEvictAfterIdle() int64 {
+	if x != nil {
+		return x.BeaconEvictAfterIdle
+	}
+	return 0
+}
+
+// FsyncPolicy decides when the files of a swamp are flushed to the disk after they are written
+type FsyncPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsyncPolicy) Reset() {
+	*x = FsyncPolicy{}
+	mi := &file_hydraide_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsyncPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsyncPolicy) ProtoMessage() {}
+
+func (x *FsyncPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsyncPolicy.ProtoReflect.Descriptor instead.
+func (*FsyncPolicy) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{18}
+}
+
 type RegisterSwampResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1208,7 +2309,7 @@ type RegisterSwampResponse struct {
 
 func (x *RegisterSwampResponse) Reset() {
 	*x = RegisterSwampResponse{}
-	mi := &file_hydraide_proto_msgTypes[14]
+	mi := &file_hydraide_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1220,7 +2321,7 @@ func (x *RegisterSwampResponse) String() string {
 func (*RegisterSwampResponse) ProtoMessage() {}
 
 func (x *RegisterSwampResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[14]
+	mi := &file_hydraide_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1233,23 +2334,33 @@ func (x *RegisterSwampResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterSwampResponse.ProtoReflect.Descriptor instead.
 func (*RegisterSwampResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{14}
+	return file_hydraide_proto_rawDescGZIP(), []int{19}
 }
 
 type DeRegisterSwampRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// SwampPattern is the full namespace pattern of the swamp to remove from the active registry.
 	//
-	// ⚠️ Note: This does NOT delete the swamp or its data.
+	// ⚠️ Note: This does NOT delete the swamp or its data, unless Purge is set.
 	// It only removes its configuration from the registry.
-	SwampPattern  string `protobuf:"bytes,1,opt,name=SwampPattern,proto3" json:"SwampPattern,omitempty"`
+	SwampPattern string `protobuf:"bytes,1,opt,name=SwampPattern,proto3" json:"SwampPattern,omitempty"`
+	// Purge destroys all swamps of the server matching the pattern, with their files on the disk, before the
+	// pattern is removed.
+	//
+	// Optional. The default false keeps the data of the swamps.
+	Purge bool `protobuf:"varint,2,opt,name=Purge,proto3" json:"Purge,omitempty"`
+	// DryRun lists the swamps the purge would destroy in PurgedSwamps, without destroying them and without removing
+	// the pattern.
+	//
+	// Optional. Use it to check what a migration is going to delete.
+	DryRun        bool `protobuf:"varint,3,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeRegisterSwampRequest) Reset() {
 	*x = DeRegisterSwampRequest{}
-	mi := &file_hydraide_proto_msgTypes[15]
+	mi := &file_hydraide_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1261,7 +2372,7 @@ func (x *DeRegisterSwampRequest) String() string {
 func (*DeRegisterSwampRequest) ProtoMessage() {}
 
 func (x *DeRegisterSwampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[15]
+	mi := &file_hydraide_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1274,7 +2385,7 @@ func (x *DeRegisterSwampRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeRegisterSwampRequest.ProtoReflect.Descriptor instead.
 func (*DeRegisterSwampRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{15}
+	return file_hydraide_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DeRegisterSwampRequest) GetSwampPattern() string {
@@ -1284,15 +2395,32 @@ func (x *DeRegisterSwampRequest) GetSwampPattern() string {
 	return ""
 }
 
+func (x *DeRegisterSwampRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+func (x *DeRegisterSwampRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type DeRegisterSwampResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// PurgedSwamps are the names of the destroyed swamps, or the swamps that would be destroyed by a DryRun, in
+	// alphabetical order. Empty without Purge and DryRun.
+	PurgedSwamps  []string `protobuf:"bytes,1,rep,name=PurgedSwamps,proto3" json:"PurgedSwamps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeRegisterSwampResponse) Reset() {
 	*x = DeRegisterSwampResponse{}
-	mi := &file_hydraide_proto_msgTypes[16]
+	mi := &file_hydraide_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1304,7 +2432,7 @@ func (x *DeRegisterSwampResponse) String() string {
 func (*DeRegisterSwampResponse) ProtoMessage() {}
 
 func (x *DeRegisterSwampResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[16]
+	mi := &file_hydraide_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1317,34 +2445,37 @@ func (x *DeRegisterSwampResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeRegisterSwampResponse.ProtoReflect.Descriptor instead.
 func (*DeRegisterSwampResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{16}
+	return file_hydraide_proto_rawDescGZIP(), []int{21}
 }
 
-type SetRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Swamps is a list of write instructions, each targeting a specific swamp.
-	//
-	// You can insert or update multiple key-value pairs across multiple swamps in a single request.
-	Swamps        []*SwampRequest `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+func (x *DeRegisterSwampResponse) GetPurgedSwamps() []string {
+	if x != nil {
+		return x.PurgedSwamps
+	}
+	return nil
+}
+
+type ListSwampPatternsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetRequest) Reset() {
-	*x = SetRequest{}
-	mi := &file_hydraide_proto_msgTypes[17]
+func (x *ListSwampPatternsRequest) Reset() {
+	*x = ListSwampPatternsRequest{}
+	mi := &file_hydraide_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetRequest) String() string {
+func (x *ListSwampPatternsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetRequest) ProtoMessage() {}
+func (*ListSwampPatternsRequest) ProtoMessage() {}
 
-func (x *SetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[17]
+func (x *ListSwampPatternsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1355,88 +2486,34 @@ func (x *SetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
-func (*SetRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *SetRequest) GetSwamps() []*SwampRequest {
-	if x != nil {
-		return x.Swamps
-	}
-	return nil
+// Deprecated: Use ListSwampPatternsRequest.ProtoReflect.Descriptor instead.
+func (*ListSwampPatternsRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{22}
 }
 
-type SwampRequest struct {
+type ListSwampPatternsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the globally consistent identifier of the "island" where this Swamp physically resides.
-	//
-	// An Island is a deterministic storage zone, derived from the full Swamp name
-	// (Sanctuary / Realm / Swamp) using a fast hash function (e.g. xxhash).
-	// The total number of Islands (i.e. hash slots) is fixed (e.g. 1000),
-	// and each Swamp is anchored to exactly one Island based on its full name.
-	//
-	// 🏝️ What is an Island?
-	// An Island is the smallest movable unit in the HydrAIDE system — a self-contained physical space
-	// where one or many Swamps live. On disk, it maps to a top-level folder like: `/data/234/...`.
-	//
-	// 🌐 Why is this important?
-	// This enables decentralized, orchestrator-free scaling:
-	// - The client decides which Island a Swamp belongs to.
-	// - The server simply accepts the IslandID and writes within that zone — it never hashes or routes.
-	//
-	// 🔁 How does this support scaling and migration?
-	// Island-based partitioning allows you to:
-	// - Move Swamps between servers without altering their names
-	// - Keep servers stateless and swappable
-	// - Perform migrations by just copying Island folders and updating client routing
-	// - Avoid global rebalancing or coordination layers
-	//
-	// ✨ This design ensures:
-	// - Deterministic Swamp placement
-	// - Stateless and swappable servers
-	// - Seamless, offline migration of data
-	// - True horizontal scaling without any cluster manager
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the target swamp where the data should be written.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// KeyValues is the list of key-value pairs to insert or update in this swamp.
-	KeyValues []*KeyValuePair `protobuf:"bytes,3,rep,name=KeyValues,proto3" json:"KeyValues,omitempty"`
-	// CreateIfNotExist determines whether HydrAIDE should create the swamp and/or key if they don't exist yet.
-	//
-	// - true → create missing swamp and keys if needed (upsert)
-	// - false → only allow updates to existing values
-	CreateIfNotExist bool `protobuf:"varint,4,opt,name=CreateIfNotExist,proto3" json:"CreateIfNotExist,omitempty"`
-	// Overwrite controls whether existing values should be replaced.
-	//
-	// - true → existing values will be overwritten
-	// - false → existing values will remain untouched
-	//
-	// Combine this with CreateIfNotExist for fine-grained behavior:
-	// - true + true = upsert
-	// - false + true = update only
-	// - true + false = insert only
-	// - false + false = no-op (used for dry run / test)
-	Overwrite     bool `protobuf:"varint,5,opt,name=Overwrite,proto3" json:"Overwrite,omitempty"`
+	// Patterns are the registered swamp patterns, sorted by their names.
+	Patterns      []*SwampPattern `protobuf:"bytes,1,rep,name=Patterns,proto3" json:"Patterns,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SwampRequest) Reset() {
-	*x = SwampRequest{}
-	mi := &file_hydraide_proto_msgTypes[18]
+func (x *ListSwampPatternsResponse) Reset() {
+	*x = ListSwampPatternsResponse{}
+	mi := &file_hydraide_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SwampRequest) String() string {
+func (x *ListSwampPatternsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwampRequest) ProtoMessage() {}
+func (*ListSwampPatternsResponse) ProtoMessage() {}
 
-func (x *SwampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[18]
+func (x *ListSwampPatternsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1447,118 +2524,63 @@ func (x *SwampRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwampRequest.ProtoReflect.Descriptor instead.
-func (*SwampRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ListSwampPatternsResponse.ProtoReflect.Descriptor instead.
+func (*ListSwampPatternsResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *SwampRequest) GetIslandID() uint64 {
+func (x *ListSwampPatternsResponse) GetPatterns() []*SwampPattern {
 	if x != nil {
-		return x.IslandID
-	}
-	return 0
-}
-
-func (x *SwampRequest) GetSwampName() string {
-	if x != nil {
-		return x.SwampName
-	}
-	return ""
-}
-
-func (x *SwampRequest) GetKeyValues() []*KeyValuePair {
-	if x != nil {
-		return x.KeyValues
+		return x.Patterns
 	}
 	return nil
 }
 
-func (x *SwampRequest) GetCreateIfNotExist() bool {
-	if x != nil {
-		return x.CreateIfNotExist
-	}
-	return false
-}
-
-func (x *SwampRequest) GetOverwrite() bool {
-	if x != nil {
-		return x.Overwrite
-	}
-	return false
-}
-
-type KeyValuePair struct {
+// SwampPattern is a registered swamp pattern with its settings
+type SwampPattern struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Key is the unique identifier of the treasure within the swamp.
-	Key        string        `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	Int8Val    *int32        `protobuf:"varint,2,opt,name=Int8Val,proto3,oneof" json:"Int8Val,omitempty"`
-	Int16Val   *int32        `protobuf:"varint,3,opt,name=Int16Val,proto3,oneof" json:"Int16Val,omitempty"`
-	Int32Val   *int32        `protobuf:"varint,4,opt,name=Int32Val,proto3,oneof" json:"Int32Val,omitempty"`
-	Int64Val   *int64        `protobuf:"varint,5,opt,name=Int64Val,proto3,oneof" json:"Int64Val,omitempty"`
-	Uint8Val   *uint32       `protobuf:"varint,6,opt,name=Uint8Val,proto3,oneof" json:"Uint8Val,omitempty"`
-	Uint16Val  *uint32       `protobuf:"varint,7,opt,name=Uint16Val,proto3,oneof" json:"Uint16Val,omitempty"`
-	Uint32Val  *uint32       `protobuf:"varint,8,opt,name=Uint32Val,proto3,oneof" json:"Uint32Val,omitempty"`
-	Uint64Val  *uint64       `protobuf:"varint,9,opt,name=Uint64Val,proto3,oneof" json:"Uint64Val,omitempty"`
-	Float32Val *float32      `protobuf:"fixed32,10,opt,name=Float32Val,proto3,oneof" json:"Float32Val,omitempty"`
-	Float64Val *float64      `protobuf:"fixed64,11,opt,name=Float64Val,proto3,oneof" json:"Float64Val,omitempty"`
-	StringVal  *string       `protobuf:"bytes,12,opt,name=StringVal,proto3,oneof" json:"StringVal,omitempty"`
-	BoolVal    *Boolean_Type `protobuf:"varint,13,opt,name=BoolVal,proto3,enum=hydraidepbgo.Boolean_Type,oneof" json:"BoolVal,omitempty"`
-	BytesVal   []byte        `protobuf:"bytes,14,opt,name=BytesVal,proto3,oneof" json:"BytesVal,omitempty"`
-	// Uint32Slice is a special set-like slice of uint32 values.
-	//
-	// It is deduplicated automatically by HydrAIDE: each number can only exist once.
-	// Used for reverse indexes, relations, and ID mapping.
-	Uint32Slice []uint32 `protobuf:"varint,15,rep,packed,name=Uint32Slice,proto3" json:"Uint32Slice,omitempty"`
-	// VoidVal can be used to explicitly clear the value, without removing the key.
-	//
-	// If true:
-	// - The treasure will be retained, but its content will be set to an empty/null value.
-	// - Useful for clearing sensitive data or resetting state while keeping the key.
-	//
-	// 💡 Advanced use: You can use VoidVal to store keys without any associated value.
-	// This is ideal for building key-only swamps (e.g. keyword sets, user tags, reverse indexes).
-	// Such entries take **zero storage space** beyond the key itself.
-	//
-	// 🧠 Bonus: If you don't set CreatedAt / UpdatedAt / Metadata, HydrAIDE won't generate them either.
-	// This keeps your swamp minimal and ultra-efficient when you don't need full metadata.
-	VoidVal *bool `protobuf:"varint,16,opt,name=VoidVal,proto3,oneof" json:"VoidVal,omitempty"`
-	// Metadata – these fields can be optionally set to override the system-generated timestamps.
-	// Useful for syncing external sources or customizing indexing behavior.
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=CreatedAt,proto3,oneof" json:"CreatedAt,omitempty"`
-	CreatedBy *string                `protobuf:"bytes,18,opt,name=CreatedBy,proto3,oneof" json:"CreatedBy,omitempty"`
-	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=UpdatedAt,proto3,oneof" json:"UpdatedAt,omitempty"`
-	UpdatedBy *string                `protobuf:"bytes,20,opt,name=UpdatedBy,proto3,oneof" json:"UpdatedBy,omitempty"`
-	// ExpiredAt sets the expiration time for the treasure.
-	//
-	// If this field is set, HydrAIDE will treat the treasure as **expired** after the given timestamp.
-	// Expired treasures remain accessible until explicitly queried or removed.
-	//
-	// 🔥 Powerful features unlocked with ExpiredAt:
-	// - You can use `GetByIndex` with `EXPIRATION_TIME` to retrieve only expired (or not yet expired) entries.
-	// - You can call `ShiftExpiredTreasures` to **extract and delete** expired items in a single atomic operation.
-	// - Swamps can be organized like queues, schedules, or TTL-based caches using this field.
-	//
-	// 💡 If ExpiredAt is not set, the treasure will **never expire** automatically.
-	ExpiredAt     *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=ExpiredAt,proto3,oneof" json:"ExpiredAt,omitempty"`
+	// SwampPattern is the full namespace pattern of the swamps, e.g. "search/hu/*".
+	SwampPattern string `protobuf:"bytes,1,opt,name=SwampPattern,proto3" json:"SwampPattern,omitempty"`
+	// IsInMemorySwamp is true if the swamps of the pattern exist only in memory.
+	IsInMemorySwamp bool `protobuf:"varint,2,opt,name=IsInMemorySwamp,proto3" json:"IsInMemorySwamp,omitempty"`
+	// CloseAfterIdle is how long (in seconds) a swamp stays open after its last access.
+	CloseAfterIdle int64 `protobuf:"varint,3,opt,name=CloseAfterIdle,proto3" json:"CloseAfterIdle,omitempty"`
+	// WriteInterval is how often (in seconds) a swamp writes its changes to the disk. 0 for the in-memory patterns.
+	WriteInterval int64 `protobuf:"varint,4,opt,name=WriteInterval,proto3" json:"WriteInterval,omitempty"`
+	// MaxFileSize is the max size (in bytes) of a compressed chunk file. 0 for the in-memory patterns.
+	MaxFileSize int64 `protobuf:"varint,5,opt,name=MaxFileSize,proto3" json:"MaxFileSize,omitempty"`
+	// Fsync is the policy of flushing the written files to the disk. DEFAULT for the in-memory patterns.
+	Fsync FsyncPolicy_Policy `protobuf:"varint,6,opt,name=Fsync,proto3,enum=hydraidepbgo.FsyncPolicy_Policy" json:"Fsync,omitempty"`
+	// FsyncInterval is the min time (in seconds) between two fsyncs with the INTERVAL policy.
+	FsyncInterval int64 `protobuf:"varint,7,opt,name=FsyncInterval,proto3" json:"FsyncInterval,omitempty"`
+	// RegisteredBy is the client that registered the pattern last: its client ID if it sent one, otherwise its IP
+	// address. Empty if the pattern was registered by an older server.
+	RegisteredBy string `protobuf:"bytes,8,opt,name=RegisteredBy,proto3" json:"RegisteredBy,omitempty"`
+	// RegisteredAt is the time of the last registration that changed the pattern.
+	RegisteredAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=RegisteredAt,proto3" json:"RegisteredAt,omitempty"`
+	// KeyFilter is true if the swamps keep a bloom filter of their keys for IsKeyExist and IsKeysExist.
+	KeyFilter bool `protobuf:"varint,10,opt,name=KeyFilter,proto3" json:"KeyFilter,omitempty"`
+	// LazyHydration is true if the swamps keep a directory of the chunk files of their keys for Get.
+	LazyHydration bool `protobuf:"varint,11,opt,name=LazyHydration,proto3" json:"LazyHydration,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyValuePair) Reset() {
-	*x = KeyValuePair{}
-	mi := &file_hydraide_proto_msgTypes[19]
+func (x *SwampPattern) Reset() {
+	*x = SwampPattern{}
+	mi := &file_hydraide_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyValuePair) String() string {
+func (x *SwampPattern) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyValuePair) ProtoMessage() {}
+func (*SwampPattern) ProtoMessage() {}
 
-func (x *KeyValuePair) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[19]
+func (x *SwampPattern) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1569,181 +2591,183 @@ func (x *KeyValuePair) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyValuePair.ProtoReflect.Descriptor instead.
-func (*KeyValuePair) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SwampPattern.ProtoReflect.Descriptor instead.
+func (*SwampPattern) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *KeyValuePair) GetKey() string {
+func (x *SwampPattern) GetSwampPattern() string {
 	if x != nil {
-		return x.Key
+		return x.SwampPattern
 	}
 	return ""
 }
 
-func (x *KeyValuePair) GetInt8Val() int32 {
-	if x != nil && x.Int8Val != nil {
-		return *x.Int8Val
+func (x *SwampPattern) GetIsInMemorySwamp() bool {
+	if x != nil {
+		return x.IsInMemorySwamp
 	}
-	return 0
+	return false
 }
 
-func (x *KeyValuePair) GetInt16Val() int32 {
-	if x != nil && x.Int16Val != nil {
-		return *x.Int16Val
+func (x *SwampPattern) GetCloseAfterIdle() int64 {
+	if x != nil {
+		return x.CloseAfterIdle
 	}
 	return 0
 }
 
-func (x *KeyValuePair) GetInt32Val() int32 {
-	if x != nil && x.Int32Val != nil {
-		return *x.Int32Val
+func (x *SwampPattern) GetWriteInterval() int64 {
+	if x != nil {
+		return x.WriteInterval
 	}
 	return 0
 }
 
-func (x *KeyValuePair) GetInt64Val() int64 {
-	if x != nil && x.Int64Val != nil {
-		return *x.Int64Val
+func (x *SwampPattern) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
 	}
 	return 0
 }
 
-func (x *KeyValuePair) GetUint8Val() uint32 {
-	if x != nil && x.Uint8Val != nil {
-		return *x.Uint8Val
+func (x *SwampPattern) GetFsync() FsyncPolicy_Policy {
+	if x != nil {
+		return x.Fsync
 	}
-	return 0
+	return FsyncPolicy_DEFAULT
 }
 
-func (x *KeyValuePair) GetUint16Val() uint32 {
-	if x != nil && x.Uint16Val != nil {
-		return *x.Uint16Val
+func (x *SwampPattern) GetFsyncInterval() int64 {
+	if x != nil {
+		return x.FsyncInterval
 	}
 	return 0
 }
 
-func (x *KeyValuePair) GetUint32Val() uint32 {
-	if x != nil && x.Uint32Val != nil {
-		return *x.Uint32Val
+func (x *SwampPattern) GetRegisteredBy() string {
+	if x != nil {
+		return x.RegisteredBy
 	}
-	return 0
+	return ""
 }
 
-func (x *KeyValuePair) GetUint64Val() uint64 {
-	if x != nil && x.Uint64Val != nil {
-		return *x.Uint64Val
+func (x *SwampPattern) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
 	}
-	return 0
+	return nil
 }
 
-func (x *KeyValuePair) GetFloat32Val() float32 {
-	if x != nil && x.Float32Val != nil {
-		return *x.Float32Val
+func (x *SwampPattern) GetKeyFilter() bool {
+	if x != nil {
+		return x.KeyFilter
 	}
-	return 0
+	return false
 }
 
-func (x *KeyValuePair) GetFloat64Val() float64 {
-	if x != nil && x.Float64Val != nil {
-		return *x.Float64Val
+func (x *SwampPattern) GetLazyHydration() bool {
+	if x != nil {
+		return x.LazyHydration
 	}
-	return 0
+	return false
 }
 
-func (x *KeyValuePair) GetStringVal() string {
-	if x != nil && x.StringVal != nil {
-		return *x.StringVal
-	}
-	return ""
+type UpdateSwampPatternRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampPattern is the full namespace pattern of the registered swamps, exactly as it was registered.
+	SwampPattern string `protobuf:"bytes,1,opt,name=SwampPattern,proto3" json:"SwampPattern,omitempty"`
+	// CloseAfterIdle is the new idle time (in seconds) of the swamps. Unset keeps the current one.
+	CloseAfterIdle *int64 `protobuf:"varint,2,opt,name=CloseAfterIdle,proto3,oneof" json:"CloseAfterIdle,omitempty"`
+	// WriteInterval is the new write interval (in seconds) of the swamps. Unset keeps the current one.
+	WriteInterval *int64 `protobuf:"varint,3,opt,name=WriteInterval,proto3,oneof" json:"WriteInterval,omitempty"`
+	// MaxFileSize is the new max size (in bytes) of the chunk files. Unset keeps the current one.
+	MaxFileSize   *int64 `protobuf:"varint,4,opt,name=MaxFileSize,proto3,oneof" json:"MaxFileSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyValuePair) GetBoolVal() Boolean_Type {
-	if x != nil && x.BoolVal != nil {
-		return *x.BoolVal
-	}
-	return Boolean_TRUE
+func (x *UpdateSwampPatternRequest) Reset() {
+	*x = UpdateSwampPatternRequest{}
+	mi := &file_hydraide_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyValuePair) GetBytesVal() []byte {
-	if x != nil {
-		return x.BytesVal
-	}
-	return nil
+func (x *UpdateSwampPatternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *KeyValuePair) GetUint32Slice() []uint32 {
+func (*UpdateSwampPatternRequest) ProtoMessage() {}
+
+func (x *UpdateSwampPatternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[25]
 	if x != nil {
-		return x.Uint32Slice
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *KeyValuePair) GetVoidVal() bool {
-	if x != nil && x.VoidVal != nil {
-		return *x.VoidVal
-	}
-	return false
+// Deprecated: Use UpdateSwampPatternRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSwampPatternRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *KeyValuePair) GetCreatedAt() *timestamppb.Timestamp {
+func (x *UpdateSwampPatternRequest) GetSwampPattern() string {
 	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *KeyValuePair) GetCreatedBy() string {
-	if x != nil && x.CreatedBy != nil {
-		return *x.CreatedBy
+		return x.SwampPattern
 	}
 	return ""
 }
 
-func (x *KeyValuePair) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
+func (x *UpdateSwampPatternRequest) GetCloseAfterIdle() int64 {
+	if x != nil && x.CloseAfterIdle != nil {
+		return *x.CloseAfterIdle
 	}
-	return nil
+	return 0
 }
 
-func (x *KeyValuePair) GetUpdatedBy() string {
-	if x != nil && x.UpdatedBy != nil {
-		return *x.UpdatedBy
+func (x *UpdateSwampPatternRequest) GetWriteInterval() int64 {
+	if x != nil && x.WriteInterval != nil {
+		return *x.WriteInterval
 	}
-	return ""
+	return 0
 }
 
-func (x *KeyValuePair) GetExpiredAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiredAt
+func (x *UpdateSwampPatternRequest) GetMaxFileSize() int64 {
+	if x != nil && x.MaxFileSize != nil {
+		return *x.MaxFileSize
 	}
-	return nil
+	return 0
 }
 
-type SetResponse struct {
+type UpdateSwampPatternResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Swamps is a list of responses, one per swamp.
-	Swamps        []*SwampResponse `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// Pattern is the updated pattern with all its settings.
+	Pattern       *SwampPattern `protobuf:"bytes,1,opt,name=Pattern,proto3" json:"Pattern,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetResponse) Reset() {
-	*x = SetResponse{}
-	mi := &file_hydraide_proto_msgTypes[20]
+func (x *UpdateSwampPatternResponse) Reset() {
+	*x = UpdateSwampPatternResponse{}
+	mi := &file_hydraide_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetResponse) String() string {
+func (x *UpdateSwampPatternResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetResponse) ProtoMessage() {}
+func (*UpdateSwampPatternResponse) ProtoMessage() {}
 
-func (x *SetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[20]
+func (x *UpdateSwampPatternResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1754,46 +2778,43 @@ func (x *SetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
-func (*SetResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use UpdateSwampPatternResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSwampPatternResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *SetResponse) GetSwamps() []*SwampResponse {
+func (x *UpdateSwampPatternResponse) GetPattern() *SwampPattern {
 	if x != nil {
-		return x.Swamps
+		return x.Pattern
 	}
 	return nil
 }
 
-type SwampResponse struct {
+type SetRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// SwampName is the name of the swamp this response refers to.
-	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// KeysAndStatuses contains the outcome of each key’s operation.
-	KeysAndStatuses []*KeyStatusPair `protobuf:"bytes,2,rep,name=KeysAndStatuses,proto3" json:"KeysAndStatuses,omitempty"`
-	// ErrorCode is set if the entire swamp operation failed.
-	// (e.g., swamp doesn't exist and CreateIfNotExist was false)
-	ErrorCode     *SwampResponse_ErrCodeEnum `protobuf:"varint,3,opt,name=ErrorCode,proto3,enum=hydraidepbgo.SwampResponse_ErrCodeEnum,oneof" json:"ErrorCode,omitempty"`
+	// Swamps is a list of write instructions, each targeting a specific swamp.
+	//
+	// You can insert or update multiple key-value pairs across multiple swamps in a single request.
+	Swamps        []*SwampRequest `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SwampResponse) Reset() {
-	*x = SwampResponse{}
-	mi := &file_hydraide_proto_msgTypes[21]
+func (x *SetRequest) Reset() {
+	*x = SetRequest{}
+	mi := &file_hydraide_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SwampResponse) String() string {
+func (x *SetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwampResponse) ProtoMessage() {}
+func (*SetRequest) ProtoMessage() {}
 
-func (x *SwampResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[21]
+func (x *SetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1804,57 +2825,6582 @@ func (x *SwampResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwampResponse.ProtoReflect.Descriptor instead.
-func (*SwampResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *SwampResponse) GetSwampName() string {
+func (x *SetRequest) GetSwamps() []*SwampRequest {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+type SwampRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the globally consistent identifier of the "island" where this Swamp physically resides.
+	//
+	// An Island is a deterministic storage zone, derived from the full Swamp name
+	// (Sanctuary / Realm / Swamp) using a fast hash function (e.g. xxhash).
+	// The total number of Islands (i.e. hash slots) is fixed (e.g. 1000),
+	// and each Swamp is anchored to exactly one Island based on its full name.
+	//
+	// 🏝️ What is an Island?
+	// An Island is the smallest movable unit in the HydrAIDE system — a self-contained physical space
+	// where one or many Swamps live. On disk, it maps to a top-level folder like: `/data/234/...`.
+	//
+	// 🌐 Why is this important?
+	// This enables decentralized, orchestrator-free scaling:
+	// - The client decides which Island a Swamp belongs to.
+	// - The server simply accepts the IslandID and writes within that zone — it never hashes or routes.
+	//
+	// 🔁 How does this support scaling and migration?
+	// Island-based partitioning allows you to:
+	// - Move Swamps between servers without altering their names
+	// - Keep servers stateless and swappable
+	// - Perform migrations by just copying Island folders and updating client routing
+	// - Avoid global rebalancing or coordination layers
+	//
+	// ✨ This design ensures:
+	// - Deterministic Swamp placement
+	// - Stateless and swappable servers
+	// - Seamless, offline migration of data
+	// - True horizontal scaling without any cluster manager
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the target swamp where the data should be written.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// KeyValues is the list of key-value pairs to insert or update in this swamp.
+	KeyValues []*KeyValuePair `protobuf:"bytes,3,rep,name=KeyValues,proto3" json:"KeyValues,omitempty"`
+	// CreateIfNotExist determines whether HydrAIDE should create the swamp and/or key if they don't exist yet.
+	//
+	// - true → create missing swamp and keys if needed (upsert)
+	// - false → only allow updates to existing values
+	CreateIfNotExist bool `protobuf:"varint,4,opt,name=CreateIfNotExist,proto3" json:"CreateIfNotExist,omitempty"`
+	// Overwrite controls whether existing values should be replaced.
+	//
+	// - true → existing values will be overwritten
+	// - false → existing values will remain untouched
+	//
+	// Combine this with CreateIfNotExist for fine-grained behavior:
+	// - true + true = upsert
+	// - false + true = update only
+	// - true + false = insert only
+	// - false + false = no-op (used for dry run / test)
+	Overwrite     bool `protobuf:"varint,5,opt,name=Overwrite,proto3" json:"Overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwampRequest) Reset() {
+	*x = SwampRequest{}
+	mi := &file_hydraide_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwampRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwampRequest) ProtoMessage() {}
+
+func (x *SwampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwampRequest.ProtoReflect.Descriptor instead.
+func (*SwampRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SwampRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *SwampRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *SwampResponse) GetKeysAndStatuses() []*KeyStatusPair {
+func (x *SwampRequest) GetKeyValues() []*KeyValuePair {
 	if x != nil {
-		return x.KeysAndStatuses
+		return x.KeyValues
 	}
 	return nil
 }
 
-func (x *SwampResponse) GetErrorCode() SwampResponse_ErrCodeEnum {
-	if x != nil && x.ErrorCode != nil {
-		return *x.ErrorCode
+func (x *SwampRequest) GetCreateIfNotExist() bool {
+	if x != nil {
+		return x.CreateIfNotExist
 	}
-	return SwampResponse_CanNotBeExecuted
+	return false
 }
 
-type KeyStatusPair struct {
+func (x *SwampRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+type KeyValuePair struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Key is the identifier of the treasure.
-	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	// Status indicates what happened to the key during the Set operation.
-	Status        Status_Code `protobuf:"varint,2,opt,name=Status,proto3,enum=hydraidepbgo.Status_Code" json:"Status,omitempty"`
+	// Key is the unique identifier of the treasure within the swamp.
+	Key        string        `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Int8Val    *int32        `protobuf:"varint,2,opt,name=Int8Val,proto3,oneof" json:"Int8Val,omitempty"`
+	Int16Val   *int32        `protobuf:"varint,3,opt,name=Int16Val,proto3,oneof" json:"Int16Val,omitempty"`
+	Int32Val   *int32        `protobuf:"varint,4,opt,name=Int32Val,proto3,oneof" json:"Int32Val,omitempty"`
+	Int64Val   *int64        `protobuf:"varint,5,opt,name=Int64Val,proto3,oneof" json:"Int64Val,omitempty"`
+	Uint8Val   *uint32       `protobuf:"varint,6,opt,name=Uint8Val,proto3,oneof" json:"Uint8Val,omitempty"`
+	Uint16Val  *uint32       `protobuf:"varint,7,opt,name=Uint16Val,proto3,oneof" json:"Uint16Val,omitempty"`
+	Uint32Val  *uint32       `protobuf:"varint,8,opt,name=Uint32Val,proto3,oneof" json:"Uint32Val,omitempty"`
+	Uint64Val  *uint64       `protobuf:"varint,9,opt,name=Uint64Val,proto3,oneof" json:"Uint64Val,omitempty"`
+	Float32Val *float32      `protobuf:"fixed32,10,opt,name=Float32Val,proto3,oneof" json:"Float32Val,omitempty"`
+	Float64Val *float64      `protobuf:"fixed64,11,opt,name=Float64Val,proto3,oneof" json:"Float64Val,omitempty"`
+	StringVal  *string       `protobuf:"bytes,12,opt,name=StringVal,proto3,oneof" json:"StringVal,omitempty"`
+	BoolVal    *Boolean_Type `protobuf:"varint,13,opt,name=BoolVal,proto3,enum=hydraidepbgo.Boolean_Type,oneof" json:"BoolVal,omitempty"`
+	BytesVal   []byte        `protobuf:"bytes,14,opt,name=BytesVal,proto3,oneof" json:"BytesVal,omitempty"`
+	// Uint32Slice is a special set-like slice of uint32 values.
+	//
+	// It is deduplicated automatically by HydrAIDE: each number can only exist once.
+	// Used for reverse indexes, relations, and ID mapping.
+	Uint32Slice []uint32 `protobuf:"varint,15,rep,packed,name=Uint32Slice,proto3" json:"Uint32Slice,omitempty"`
+	// VoidVal can be used to explicitly clear the value, without removing the key.
+	//
+	// If true:
+	// - The treasure will be retained, but its content will be set to an empty/null value.
+	// - Useful for clearing sensitive data or resetting state while keeping the key.
+	//
+	// 💡 Advanced use: You can use VoidVal to store keys without any associated value.
+	// This is ideal for building key-only swamps (e.g. keyword sets, user tags, reverse indexes).
+	// Such entries take **zero storage space** beyond the key itself.
+	//
+	// 🧠 Bonus: If you don't set CreatedAt / UpdatedAt / Metadata, HydrAIDE won't generate them either.
+	// This keeps your swamp minimal and ultra-efficient when you don't need full metadata.
+	VoidVal *bool `protobuf:"varint,16,opt,name=VoidVal,proto3,oneof" json:"VoidVal,omitempty"`
+	// Metadata – these fields can be optionally set to override the system-generated timestamps.
+	// Useful for syncing external sources or customizing indexing behavior.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=CreatedAt,proto3,oneof" json:"CreatedAt,omitempty"`
+	CreatedBy *string                `protobuf:"bytes,18,opt,name=CreatedBy,proto3,oneof" json:"CreatedBy,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=UpdatedAt,proto3,oneof" json:"UpdatedAt,omitempty"`
+	UpdatedBy *string                `protobuf:"bytes,20,opt,name=UpdatedBy,proto3,oneof" json:"UpdatedBy,omitempty"`
+	// ExpiredAt sets the expiration time for the treasure.
+	//
+	// If this field is set, HydrAIDE will treat the treasure as **expired** after the given timestamp.
+	// Expired treasures remain accessible until explicitly queried or removed.
+	//
+	// 🔥 Powerful features unlocked with ExpiredAt:
+	// - You can use `GetByIndex` with `EXPIRATION_TIME` to retrieve only expired (or not yet expired) entries.
+	// - You can call `ShiftExpiredTreasures` to **extract and delete** expired items in a single atomic operation.
+	// - Swamps can be organized like queues, schedules, or TTL-based caches using this field.
+	//
+	// 💡 If ExpiredAt is not set, the treasure will **never expire** automatically.
+	ExpiredAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=ExpiredAt,proto3,oneof" json:"ExpiredAt,omitempty"`
+	// SchemaVersion is the version of the client model stored in the value.
+	//
+	// The server only stores and returns it. The SDKs use it to detect values written by an older version of the
+	// model and to run the registered migrations when reading them.
+	// If not set, the stored version is left unchanged.
+	SchemaVersion *uint32 `protobuf:"varint,22,opt,name=SchemaVersion,proto3,oneof" json:"SchemaVersion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValuePair) Reset() {
+	*x = KeyValuePair{}
+	mi := &file_hydraide_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValuePair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValuePair) ProtoMessage() {}
+
+func (x *KeyValuePair) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValuePair.ProtoReflect.Descriptor instead.
+func (*KeyValuePair) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *KeyValuePair) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyValuePair) GetInt8Val() int32 {
+	if x != nil && x.Int8Val != nil {
+		return *x.Int8Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetInt16Val() int32 {
+	if x != nil && x.Int16Val != nil {
+		return *x.Int16Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetInt32Val() int32 {
+	if x != nil && x.Int32Val != nil {
+		return *x.Int32Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetInt64Val() int64 {
+	if x != nil && x.Int64Val != nil {
+		return *x.Int64Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetUint8Val() uint32 {
+	if x != nil && x.Uint8Val != nil {
+		return *x.Uint8Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetUint16Val() uint32 {
+	if x != nil && x.Uint16Val != nil {
+		return *x.Uint16Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetUint32Val() uint32 {
+	if x != nil && x.Uint32Val != nil {
+		return *x.Uint32Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetUint64Val() uint64 {
+	if x != nil && x.Uint64Val != nil {
+		return *x.Uint64Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetFloat32Val() float32 {
+	if x != nil && x.Float32Val != nil {
+		return *x.Float32Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetFloat64Val() float64 {
+	if x != nil && x.Float64Val != nil {
+		return *x.Float64Val
+	}
+	return 0
+}
+
+func (x *KeyValuePair) GetStringVal() string {
+	if x != nil && x.StringVal != nil {
+		return *x.StringVal
+	}
+	return ""
+}
+
+func (x *KeyValuePair) GetBoolVal() Boolean_Type {
+	if x != nil && x.BoolVal != nil {
+		return *x.BoolVal
+	}
+	return Boolean_TRUE
+}
+
+func (x *KeyValuePair) GetBytesVal() []byte {
+	if x != nil {
+		return x.BytesVal
+	}
+	return nil
+}
+
+func (x *KeyValuePair) GetUint32Slice() []uint32 {
+	if x != nil {
+		return x.Uint32Slice
+	}
+	return nil
+}
+
+func (x *KeyValuePair) GetVoidVal() bool {
+	if x != nil && x.VoidVal != nil {
+		return *x.VoidVal
+	}
+	return false
+}
+
+func (x *KeyValuePair) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KeyValuePair) GetCreatedBy() string {
+	if x != nil && x.CreatedBy != nil {
+		return *x.CreatedBy
+	}
+	return ""
+}
+
+func (x *KeyValuePair) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *KeyValuePair) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *KeyValuePair) GetExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+func (x *KeyValuePair) GetSchemaVersion() uint32 {
+	if x != nil && x.SchemaVersion != nil {
+		return *x.SchemaVersion
+	}
+	return 0
+}
+
+type SetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Swamps is a list of responses, one per swamp.
+	Swamps        []*SwampResponse `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetResponse) Reset() {
+	*x = SetResponse{}
+	mi := &file_hydraide_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetResponse) ProtoMessage() {}
+
+func (x *SetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetResponse) GetSwamps() []*SwampResponse {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+type SwampResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampName is the name of the swamp this response refers to.
+	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// KeysAndStatuses contains the outcome of each key’s operation.
+	KeysAndStatuses []*KeyStatusPair `protobuf:"bytes,2,rep,name=KeysAndStatuses,proto3" json:"KeysAndStatuses,omitempty"`
+	// ErrorCode is set if the entire swamp operation failed.
+	// (e.g., swamp doesn't exist and CreateIfNotExist was false)
+	ErrorCode     *SwampResponse_ErrCodeEnum `protobuf:"varint,3,opt,name=ErrorCode,proto3,enum=hydraidepbgo.SwampResponse_ErrCodeEnum,oneof" json:"ErrorCode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwampResponse) Reset() {
+	*x = SwampResponse{}
+	mi := &file_hydraide_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwampResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwampResponse) ProtoMessage() {}
+
+func (x *SwampResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwampResponse.ProtoReflect.Descriptor instead.
+func (*SwampResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SwampResponse) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *SwampResponse) GetKeysAndStatuses() []*KeyStatusPair {
+	if x != nil {
+		return x.KeysAndStatuses
+	}
+	return nil
+}
+
+func (x *SwampResponse) GetErrorCode() SwampResponse_ErrCodeEnum {
+	if x != nil && x.ErrorCode != nil {
+		return *x.ErrorCode
+	}
+	return SwampResponse_CanNotBeExecuted
+}
+
+type KeyStatusPair struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key is the identifier of the treasure.
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Status indicates what happened to the key during the Set operation.
+	Status Status_Code `protobuf:"varint,2,opt,name=Status,proto3,enum=hydraidepbgo.Status_Code" json:"Status,omitempty"`
+	// CreatedAt and UpdatedAt are the times assigned by the server, if the swamp pattern is registered with
+	// ServerTimestamps. They are empty otherwise, or if the server did not set them.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyStatusPair) Reset() {
+	*x = KeyStatusPair{}
+	mi := &file_hydraide_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyStatusPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyStatusPair) ProtoMessage() {}
+
+func (x *KeyStatusPair) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyStatusPair.ProtoReflect.Descriptor instead.
+func (*KeyStatusPair) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *KeyStatusPair) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyStatusPair) GetStatus() Status_Code {
+	if x != nil {
+		return x.Status
+	}
+	return Status_NOT_FOUND
+}
+
+func (x *KeyStatusPair) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KeyStatusPair) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Status struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	mi := &file_hydraide_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{33}
+}
+
+type GetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Swamps is a list of swamps with their associated keys to retrieve.
+	//
+	// You can batch multiple swamp/key combinations into a single request,
+	// making the operation efficient for distributed or sharded datasets.
+	//
+	// Example:
+	// - Swamp "hu/products" → get keys ["123", "456"]
+	// - Swamp "de/users" → get keys ["alice", "bob"]
+	Swamps        []*GetSwamp `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_hydraide_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetRequest) GetSwamps() []*GetSwamp {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+type GetSwamp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to read from.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Keys is the list of treasure keys to retrieve from this swamp.
+	Keys []string `protobuf:"bytes,3,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// Projection selects the parts of the treasures to return. Without projection the whole treasures are returned.
+	Projection    *Projection `protobuf:"bytes,4,opt,name=Projection,proto3,oneof" json:"Projection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSwamp) Reset() {
+	*x = GetSwamp{}
+	mi := &file_hydraide_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSwamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSwamp) ProtoMessage() {}
+
+func (x *GetSwamp) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSwamp.ProtoReflect.Descriptor instead.
+func (*GetSwamp) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetSwamp) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetSwamp) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetSwamp) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *GetSwamp) GetProjection() *Projection {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+// Projection selects the parts of the treasures returned by a read.
+//
+// The key, the existence and the schema version of the treasures are always returned, the other parts only if
+// they are selected. Use it to read only the metadata of the treasures, or only their values, without
+// transferring the rest.
+type Projection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value returns the value of the treasures.
+	Value bool `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// CreatedAt returns the creation time of the treasures.
+	CreatedAt bool `protobuf:"varint,2,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// CreatedBy returns the creator of the treasures.
+	CreatedBy bool `protobuf:"varint,3,opt,name=CreatedBy,proto3" json:"CreatedBy,omitempty"`
+	// UpdatedAt returns the last modification time of the treasures.
+	UpdatedAt bool `protobuf:"varint,4,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	// UpdatedBy returns the last modifier of the treasures.
+	UpdatedBy bool `protobuf:"varint,5,opt,name=UpdatedBy,proto3" json:"UpdatedBy,omitempty"`
+	// ExpiredAt returns the expiration time of the treasures.
+	ExpiredAt     bool `protobuf:"varint,6,opt,name=ExpiredAt,proto3" json:"ExpiredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Projection) Reset() {
+	*x = Projection{}
+	mi := &file_hydraide_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Projection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Projection) ProtoMessage() {}
+
+func (x *Projection) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Projection.ProtoReflect.Descriptor instead.
+func (*Projection) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Projection) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func (x *Projection) GetCreatedAt() bool {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return false
+}
+
+func (x *Projection) GetCreatedBy() bool {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return false
+}
+
+func (x *Projection) GetUpdatedAt() bool {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return false
+}
+
+func (x *Projection) GetUpdatedBy() bool {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return false
+}
+
+func (x *Projection) GetExpiredAt() bool {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return false
+}
+
+type GetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Swamps contains the result of each swamp that was queried.
+	Swamps        []*GetSwampResponse `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_hydraide_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetResponse) GetSwamps() []*GetSwampResponse {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+type GetSwampResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampName is the swamp from which the treasures were returned.
+	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// IsExist tells whether the swamp actually exists.
+	//
+	// If false, no data will be returned for this swamp.
+	IsExist bool `protobuf:"varint,2,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+	// Treasures contains the values of all successfully retrieved keys.
+	// If a key did not exist, it will be omitted from the list.
+	Treasures     []*Treasure `protobuf:"bytes,3,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSwampResponse) Reset() {
+	*x = GetSwampResponse{}
+	mi := &file_hydraide_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSwampResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSwampResponse) ProtoMessage() {}
+
+func (x *GetSwampResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSwampResponse.ProtoReflect.Descriptor instead.
+func (*GetSwampResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetSwampResponse) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetSwampResponse) GetIsExist() bool {
+	if x != nil {
+		return x.IsExist
+	}
+	return false
+}
+
+func (x *GetSwampResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type SetLargeValueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives. Only in the first message.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to write to. Only in the first message.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// KeyValue is the treasure to write, without the bytes value. Only in the first message.
+	//
+	// The chunks are appended to the BytesVal of the KeyValue, so it should be empty.
+	KeyValue *KeyValuePair `protobuf:"bytes,3,opt,name=KeyValue,proto3" json:"KeyValue,omitempty"`
+	// CreateIfNotExist creates the swamp and the treasure if they don't exist, like in the SwampRequest of the Set.
+	// Only in the first message.
+	CreateIfNotExist bool `protobuf:"varint,4,opt,name=CreateIfNotExist,proto3" json:"CreateIfNotExist,omitempty"`
+	// Overwrite overwrites the existing treasure, like in the SwampRequest of the Set. Only in the first message.
+	Overwrite bool `protobuf:"varint,5,opt,name=Overwrite,proto3" json:"Overwrite,omitempty"`
+	// Chunk is the next part of the bytes value. Can be set in every message, including the first one.
+	Chunk         []byte `protobuf:"bytes,6,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLargeValueRequest) Reset() {
+	*x = SetLargeValueRequest{}
+	mi := &file_hydraide_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLargeValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLargeValueRequest) ProtoMessage() {}
+
+func (x *SetLargeValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLargeValueRequest.ProtoReflect.Descriptor instead.
+func (*SetLargeValueRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetLargeValueRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *SetLargeValueRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *SetLargeValueRequest) GetKeyValue() *KeyValuePair {
+	if x != nil {
+		return x.KeyValue
+	}
+	return nil
+}
+
+func (x *SetLargeValueRequest) GetCreateIfNotExist() bool {
+	if x != nil {
+		return x.CreateIfNotExist
+	}
+	return false
+}
+
+func (x *SetLargeValueRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+func (x *SetLargeValueRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type SetLargeValueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Swamp is the result of the write, the same as the SwampResponse of the Set.
+	Swamp         *SwampResponse `protobuf:"bytes,1,opt,name=Swamp,proto3" json:"Swamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLargeValueResponse) Reset() {
+	*x = SetLargeValueResponse{}
+	mi := &file_hydraide_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLargeValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLargeValueResponse) ProtoMessage() {}
+
+func (x *SetLargeValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLargeValueResponse.ProtoReflect.Descriptor instead.
+func (*SetLargeValueResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetLargeValueResponse) GetSwamp() *SwampResponse {
+	if x != nil {
+		return x.Swamp
+	}
+	return nil
+}
+
+type GetLargeValueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to read from.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the key of the treasure to read.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// ChunkSize is the max size of the chunks of the value in bytes. 0 means 1 MB.
+	ChunkSize     uint32 `protobuf:"varint,4,opt,name=ChunkSize,proto3" json:"ChunkSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLargeValueRequest) Reset() {
+	*x = GetLargeValueRequest{}
+	mi := &file_hydraide_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLargeValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLargeValueRequest) ProtoMessage() {}
+
+func (x *GetLargeValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLargeValueRequest.ProtoReflect.Descriptor instead.
+func (*GetLargeValueRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetLargeValueRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetLargeValueRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetLargeValueRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetLargeValueRequest) GetChunkSize() uint32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+type GetLargeValueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasure is the treasure without the bytes value. Only in the first message.
+	Treasure *Treasure `protobuf:"bytes,1,opt,name=Treasure,proto3" json:"Treasure,omitempty"`
+	// Chunk is the next part of the bytes value. Not set in the first message.
+	Chunk         []byte `protobuf:"bytes,2,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLargeValueResponse) Reset() {
+	*x = GetLargeValueResponse{}
+	mi := &file_hydraide_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLargeValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLargeValueResponse) ProtoMessage() {}
+
+func (x *GetLargeValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLargeValueResponse.ProtoReflect.Descriptor instead.
+func (*GetLargeValueResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetLargeValueResponse) GetTreasure() *Treasure {
+	if x != nil {
+		return x.Treasure
+	}
+	return nil
+}
+
+func (x *GetLargeValueResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type GetAllRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp you want to retrieve all treasures from.
+	//
+	// HydrAIDE will return every key-value pair stored in this swamp, regardless of expiration or status.
+	//
+	// ⚠️ Caution: For large swamps, this can return a lot of data.
+	// Consider using pagination or GetByIndex instead for better control.
+	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllRequest) Reset() {
+	*x = GetAllRequest{}
+	mi := &file_hydraide_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllRequest) ProtoMessage() {}
+
+func (x *GetAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllRequest.ProtoReflect.Descriptor instead.
+func (*GetAllRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetAllRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetAllRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+type GetAllResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures contains all key-value pairs from the requested swamp.
+	//
+	// Each Treasure includes its key, type-specific value, metadata (if any),
+	// and its current existence status.
+	//
+	// 💡 Useful for:
+	// - Initial snapshot generation
+	// - Manual data inspection
+	// - Rebuilding in-memory views or local caches
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllResponse) Reset() {
+	*x = GetAllResponse{}
+	mi := &file_hydraide_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllResponse) ProtoMessage() {}
+
+func (x *GetAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllResponse.ProtoReflect.Descriptor instead.
+func (*GetAllResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetAllResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type GetAllStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to stream.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// FilterExpr is an optional server-side filter expression, the same as the FilterExpr of the GetByIndex.
+	// The filter is applied before From and Limit.
+	FilterExpr *string `protobuf:"bytes,3,opt,name=FilterExpr,proto3,oneof" json:"FilterExpr,omitempty"`
+	// From defines how many treasures to skip. Set to 0 to start from the beginning.
+	From int32 `protobuf:"varint,4,opt,name=From,proto3" json:"From,omitempty"`
+	// Limit defines how many treasures to stream. Set to 0 to stream all of them.
+	Limit int32 `protobuf:"varint,5,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	// KeysOnly streams only the keys and the metadata of the treasures, without their values.
+	KeysOnly bool `protobuf:"varint,6,opt,name=KeysOnly,proto3" json:"KeysOnly,omitempty"`
+	// Projection selects the parts of the treasures to stream, the same as the Projection of the Get.
+	// Without projection the whole treasures are streamed. KeysOnly takes precedence over it.
+	Projection *Projection `protobuf:"bytes,7,opt,name=Projection,proto3,oneof" json:"Projection,omitempty"`
+	// BatchSize is the max number of the treasures in one message. 0 means 500.
+	BatchSize     int32 `protobuf:"varint,8,opt,name=BatchSize,proto3" json:"BatchSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllStreamRequest) Reset() {
+	*x = GetAllStreamRequest{}
+	mi := &file_hydraide_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllStreamRequest) ProtoMessage() {}
+
+func (x *GetAllStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllStreamRequest.ProtoReflect.Descriptor instead.
+func (*GetAllStreamRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetAllStreamRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetAllStreamRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetAllStreamRequest) GetFilterExpr() string {
+	if x != nil && x.FilterExpr != nil {
+		return *x.FilterExpr
+	}
+	return ""
+}
+
+func (x *GetAllStreamRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetAllStreamRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAllStreamRequest) GetKeysOnly() bool {
+	if x != nil {
+		return x.KeysOnly
+	}
+	return false
+}
+
+func (x *GetAllStreamRequest) GetProjection() *Projection {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+func (x *GetAllStreamRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type GetAllStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures is the next batch of the treasures of the swamp.
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllStreamResponse) Reset() {
+	*x = GetAllStreamResponse{}
+	mi := &file_hydraide_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllStreamResponse) ProtoMessage() {}
+
+func (x *GetAllStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllStreamResponse.ProtoReflect.Descriptor instead.
+func (*GetAllStreamResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetAllStreamResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type ShiftExpiredTreasuresRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp you want to shift expired treasures from.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// HowMany defines how many expired treasures should be returned and deleted.
+	//
+	// - If set to 0 → HydrAIDE will return **all** expired entries from the swamp.
+	// - If greater than 0 → only the specified number of expired treasures will be returned.
+	//
+	// ⚠️ Note: This operation is destructive – the shifted treasures will be removed immediately.
+	HowMany       int32 `protobuf:"varint,3,opt,name=HowMany,proto3" json:"HowMany,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShiftExpiredTreasuresRequest) Reset() {
+	*x = ShiftExpiredTreasuresRequest{}
+	mi := &file_hydraide_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShiftExpiredTreasuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShiftExpiredTreasuresRequest) ProtoMessage() {}
+
+func (x *ShiftExpiredTreasuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShiftExpiredTreasuresRequest.ProtoReflect.Descriptor instead.
+func (*ShiftExpiredTreasuresRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ShiftExpiredTreasuresRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *ShiftExpiredTreasuresRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *ShiftExpiredTreasuresRequest) GetHowMany() int32 {
+	if x != nil {
+		return x.HowMany
+	}
+	return 0
+}
+
+type ShiftExpiredTreasuresResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures contains the expired entries that were retrieved and deleted from the swamp.
+	//
+	// Each treasure includes its key, value, and metadata at the time of expiration.
+	//
+	// 🔔 Realtime: These deletions will also trigger `SubscribeToEvents` notifications.
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShiftExpiredTreasuresResponse) Reset() {
+	*x = ShiftExpiredTreasuresResponse{}
+	mi := &file_hydraide_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShiftExpiredTreasuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShiftExpiredTreasuresResponse) ProtoMessage() {}
+
+func (x *ShiftExpiredTreasuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShiftExpiredTreasuresResponse.ProtoReflect.Descriptor instead.
+func (*ShiftExpiredTreasuresResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ShiftExpiredTreasuresResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type LeaseExpiredTreasuresRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp you want to lease expired treasures from.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// HowMany defines how many expired treasures should be leased. 0 means all expired treasures.
+	HowMany int32 `protobuf:"varint,3,opt,name=HowMany,proto3" json:"HowMany,omitempty"`
+	// LeaseTime is how long the leased treasures are hidden, in milliseconds.
+	LeaseTime     int64 `protobuf:"varint,4,opt,name=LeaseTime,proto3" json:"LeaseTime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseExpiredTreasuresRequest) Reset() {
+	*x = LeaseExpiredTreasuresRequest{}
+	mi := &file_hydraide_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseExpiredTreasuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseExpiredTreasuresRequest) ProtoMessage() {}
+
+func (x *LeaseExpiredTreasuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseExpiredTreasuresRequest.ProtoReflect.Descriptor instead.
+func (*LeaseExpiredTreasuresRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *LeaseExpiredTreasuresRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *LeaseExpiredTreasuresRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *LeaseExpiredTreasuresRequest) GetHowMany() int32 {
+	if x != nil {
+		return x.HowMany
+	}
+	return 0
+}
+
+func (x *LeaseExpiredTreasuresRequest) GetLeaseTime() int64 {
+	if x != nil {
+		return x.LeaseTime
+	}
+	return 0
+}
+
+type LeaseExpiredTreasuresResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures contains the leased treasures, with their new expiration time.
+	Treasures     []*LeasedTreasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseExpiredTreasuresResponse) Reset() {
+	*x = LeaseExpiredTreasuresResponse{}
+	mi := &file_hydraide_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseExpiredTreasuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseExpiredTreasuresResponse) ProtoMessage() {}
+
+func (x *LeaseExpiredTreasuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseExpiredTreasuresResponse.ProtoReflect.Descriptor instead.
+func (*LeaseExpiredTreasuresResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *LeaseExpiredTreasuresResponse) GetTreasures() []*LeasedTreasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type LeasedTreasure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasure is the leased treasure.
+	Treasure *Treasure `protobuf:"bytes,1,opt,name=Treasure,proto3" json:"Treasure,omitempty"`
+	// LeaseID identifies the lease, it must be sent with the AckLease or the NackLease request.
+	LeaseID int64 `protobuf:"varint,2,opt,name=LeaseID,proto3" json:"LeaseID,omitempty"`
+	// Deliveries is the number of the leases of the treasure, including this one.
+	Deliveries    int32 `protobuf:"varint,3,opt,name=Deliveries,proto3" json:"Deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeasedTreasure) Reset() {
+	*x = LeasedTreasure{}
+	mi := &file_hydraide_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeasedTreasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeasedTreasure) ProtoMessage() {}
+
+func (x *LeasedTreasure) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeasedTreasure.ProtoReflect.Descriptor instead.
+func (*LeasedTreasure) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *LeasedTreasure) GetTreasure() *Treasure {
+	if x != nil {
+		return x.Treasure
+	}
+	return nil
+}
+
+func (x *LeasedTreasure) GetLeaseID() int64 {
+	if x != nil {
+		return x.LeaseID
+	}
+	return 0
+}
+
+func (x *LeasedTreasure) GetDeliveries() int32 {
+	if x != nil {
+		return x.Deliveries
+	}
+	return 0
+}
+
+type AckLeaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp of the leased treasure.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the key of the leased treasure.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// LeaseID is the LeaseID of the LeasedTreasure.
+	LeaseID       int64 `protobuf:"varint,4,opt,name=LeaseID,proto3" json:"LeaseID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckLeaseRequest) Reset() {
+	*x = AckLeaseRequest{}
+	mi := &file_hydraide_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckLeaseRequest) ProtoMessage() {}
+
+func (x *AckLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckLeaseRequest.ProtoReflect.Descriptor instead.
+func (*AckLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AckLeaseRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *AckLeaseRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *AckLeaseRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AckLeaseRequest) GetLeaseID() int64 {
+	if x != nil {
+		return x.LeaseID
+	}
+	return 0
+}
+
+type AckLeaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckLeaseResponse) Reset() {
+	*x = AckLeaseResponse{}
+	mi := &file_hydraide_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckLeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckLeaseResponse) ProtoMessage() {}
+
+func (x *AckLeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckLeaseResponse.ProtoReflect.Descriptor instead.
+func (*AckLeaseResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{53}
+}
+
+type NackLeaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp of the leased treasure.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the key of the leased treasure.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// LeaseID is the LeaseID of the LeasedTreasure.
+	LeaseID int64 `protobuf:"varint,4,opt,name=LeaseID,proto3" json:"LeaseID,omitempty"`
+	// RetryAfter is how long the treasure stays hidden before it can be leased again, in milliseconds.
+	// 0 makes it visible immediately.
+	RetryAfter    int64 `protobuf:"varint,5,opt,name=RetryAfter,proto3" json:"RetryAfter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NackLeaseRequest) Reset() {
+	*x = NackLeaseRequest{}
+	mi := &file_hydraide_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackLeaseRequest) ProtoMessage() {}
+
+func (x *NackLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackLeaseRequest.ProtoReflect.Descriptor instead.
+func (*NackLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *NackLeaseRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *NackLeaseRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *NackLeaseRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *NackLeaseRequest) GetLeaseID() int64 {
+	if x != nil {
+		return x.LeaseID
+	}
+	return 0
+}
+
+func (x *NackLeaseRequest) GetRetryAfter() int64 {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return 0
+}
+
+type NackLeaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NackLeaseResponse) Reset() {
+	*x = NackLeaseResponse{}
+	mi := &file_hydraide_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackLeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackLeaseResponse) ProtoMessage() {}
+
+func (x *NackLeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackLeaseResponse.ProtoReflect.Descriptor instead.
+func (*NackLeaseResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{55}
+}
+
+type Treasure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key is the unique identifier of the treasure within the swamp.
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IsExist indicates whether the treasure was actually found in the swamp.
+	//
+	// If false:
+	// - The treasure was requested, but does not exist.
+	// - All value and metadata fields will be unset.
+	IsExist    bool          `protobuf:"varint,2,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+	Int8Val    *int32        `protobuf:"varint,3,opt,name=Int8Val,proto3,oneof" json:"Int8Val,omitempty"`
+	Int16Val   *int32        `protobuf:"varint,4,opt,name=Int16Val,proto3,oneof" json:"Int16Val,omitempty"`
+	Int32Val   *int32        `protobuf:"varint,5,opt,name=Int32Val,proto3,oneof" json:"Int32Val,omitempty"`
+	Int64Val   *int64        `protobuf:"varint,6,opt,name=Int64Val,proto3,oneof" json:"Int64Val,omitempty"`
+	Uint8Val   *uint32       `protobuf:"varint,7,opt,name=Uint8Val,proto3,oneof" json:"Uint8Val,omitempty"`
+	Uint16Val  *uint32       `protobuf:"varint,8,opt,name=Uint16Val,proto3,oneof" json:"Uint16Val,omitempty"`
+	Uint32Val  *uint32       `protobuf:"varint,9,opt,name=Uint32Val,proto3,oneof" json:"Uint32Val,omitempty"`
+	Uint64Val  *uint64       `protobuf:"varint,10,opt,name=Uint64Val,proto3,oneof" json:"Uint64Val,omitempty"`
+	Float32Val *float32      `protobuf:"fixed32,11,opt,name=Float32Val,proto3,oneof" json:"Float32Val,omitempty"`
+	Float64Val *float64      `protobuf:"fixed64,12,opt,name=Float64Val,proto3,oneof" json:"Float64Val,omitempty"`
+	StringVal  *string       `protobuf:"bytes,13,opt,name=StringVal,proto3,oneof" json:"StringVal,omitempty"`
+	BoolVal    *Boolean_Type `protobuf:"varint,14,opt,name=BoolVal,proto3,enum=hydraidepbgo.Boolean_Type,oneof" json:"BoolVal,omitempty"`
+	BytesVal   []byte        `protobuf:"bytes,15,opt,name=BytesVal,proto3,oneof" json:"BytesVal,omitempty"`
+	// Uint32Slice is a special, automatically deduplicated list of uint32 values.
+	//
+	// Ideal for storing IDs, reverse indexes, or permission sets.
+	Uint32Slice []uint32 `protobuf:"varint,16,rep,packed,name=Uint32Slice,proto3" json:"Uint32Slice,omitempty"`
+	// CreatedAt is the timestamp when the treasure was first created.
+	//
+	// ⚠️ HydrAIDE does not generate this automatically.
+	// This field will only be present if you explicitly set it during the Set operation.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=CreatedAt,proto3,oneof" json:"CreatedAt,omitempty"`
+	// CreatedBy stores the identifier (user/system) that created the treasure.
+	//
+	// ⚠️ Optional. Only stored if provided at write time.
+	CreatedBy *string `protobuf:"bytes,18,opt,name=CreatedBy,proto3,oneof" json:"CreatedBy,omitempty"`
+	// UpdatedAt is the last modification timestamp of the treasure.
+	//
+	// ⚠️ Optional. HydrAIDE won't auto-populate this — you must set it manually if needed.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=UpdatedAt,proto3,oneof" json:"UpdatedAt,omitempty"`
+	// UpdatedBy stores the identifier of who last modified the treasure.
+	//
+	// ⚠️ Optional. Set it explicitly in the Set request if tracking is desired.
+	UpdatedBy *string `protobuf:"bytes,20,opt,name=UpdatedBy,proto3,oneof" json:"UpdatedBy,omitempty"`
+	// ExpiredAt defines the expiration time for the treasure.
+	//
+	// After this time, the treasure is considered expired and can be retrieved or removed using:
+	// - `GetByIndex` with `EXPIRATION_TIME`
+	// - `ShiftExpiredTreasures`
+	//
+	// ⚠️ Important: HydrAIDE does NOT auto-generate this value.
+	// If you want a treasure to expire, you must set ExpiredAt yourself during the `Set` operation.
+	//
+	// If ExpiredAt is not set:
+	// - The treasure is considered to never expire
+	// - It will not appear in expiration-based queries
+	ExpiredAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=ExpiredAt,proto3,oneof" json:"ExpiredAt,omitempty"`
+	// SchemaVersion is the version of the client model stored in the value, as it was set by the writer.
+	// Not set if the treasure was written without a version.
+	SchemaVersion *uint32 `protobuf:"varint,22,opt,name=SchemaVersion,proto3,oneof" json:"SchemaVersion,omitempty"`
+	// DeletedAt is the time of the shadow delete. Set only in the treasures returned by ListDeleted.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=DeletedAt,proto3,oneof" json:"DeletedAt,omitempty"`
+	// Version is the number of the version. Set only in the treasures returned by GetHistory.
+	Version       *uint64 `protobuf:"varint,24,opt,name=Version,proto3,oneof" json:"Version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Treasure) Reset() {
+	*x = Treasure{}
+	mi := &file_hydraide_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Treasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Treasure) ProtoMessage() {}
+
+func (x *Treasure) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Treasure.ProtoReflect.Descriptor instead.
+func (*Treasure) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Treasure) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Treasure) GetIsExist() bool {
+	if x != nil {
+		return x.IsExist
+	}
+	return false
+}
+
+func (x *Treasure) GetInt8Val() int32 {
+	if x != nil && x.Int8Val != nil {
+		return *x.Int8Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetInt16Val() int32 {
+	if x != nil && x.Int16Val != nil {
+		return *x.Int16Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetInt32Val() int32 {
+	if x != nil && x.Int32Val != nil {
+		return *x.Int32Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetInt64Val() int64 {
+	if x != nil && x.Int64Val != nil {
+		return *x.Int64Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetUint8Val() uint32 {
+	if x != nil && x.Uint8Val != nil {
+		return *x.Uint8Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetUint16Val() uint32 {
+	if x != nil && x.Uint16Val != nil {
+		return *x.Uint16Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetUint32Val() uint32 {
+	if x != nil && x.Uint32Val != nil {
+		return *x.Uint32Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetUint64Val() uint64 {
+	if x != nil && x.Uint64Val != nil {
+		return *x.Uint64Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetFloat32Val() float32 {
+	if x != nil && x.Float32Val != nil {
+		return *x.Float32Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetFloat64Val() float64 {
+	if x != nil && x.Float64Val != nil {
+		return *x.Float64Val
+	}
+	return 0
+}
+
+func (x *Treasure) GetStringVal() string {
+	if x != nil && x.StringVal != nil {
+		return *x.StringVal
+	}
+	return ""
+}
+
+func (x *Treasure) GetBoolVal() Boolean_Type {
+	if x != nil && x.BoolVal != nil {
+		return *x.BoolVal
+	}
+	return Boolean_TRUE
+}
+
+func (x *Treasure) GetBytesVal() []byte {
+	if x != nil {
+		return x.BytesVal
+	}
+	return nil
+}
+
+func (x *Treasure) GetUint32Slice() []uint32 {
+	if x != nil {
+		return x.Uint32Slice
+	}
+	return nil
+}
+
+func (x *Treasure) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Treasure) GetCreatedBy() string {
+	if x != nil && x.CreatedBy != nil {
+		return *x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Treasure) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Treasure) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Treasure) GetExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+func (x *Treasure) GetSchemaVersion() uint32 {
+	if x != nil && x.SchemaVersion != nil {
+		return *x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Treasure) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Treasure) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+// Metadata names the metadata fields of the treasures
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_hydraide_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{57}
+}
+
+// Beacon is an order beacon of the swamps: the ordered lists of the treasures read by GetByIndex and the other
+// ordered reads. A pair of beacons, an ascending and a descending one, is kept for each type.
+type Beacon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Beacon) Reset() {
+	*x = Beacon{}
+	mi := &file_hydraide_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Beacon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Beacon) ProtoMessage() {}
+
+func (x *Beacon) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Beacon.ProtoReflect.Descriptor instead.
+func (*Beacon) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{58}
+}
+
+type Boolean struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Boolean) Reset() {
+	*x = Boolean{}
+	mi := &file_hydraide_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Boolean) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Boolean) ProtoMessage() {}
+
+func (x *Boolean) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Boolean.ProtoReflect.Descriptor instead.
+func (*Boolean) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{59}
+}
+
+type GetByIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to query.
+	//
+	// The index will be built in memory for this swamp at query time.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// IndexType specifies which field to index and query on.
+	//
+	// Supported indexes:
+	// - KEY → Sort alphabetically by key
+	// - EXPIRATION_TIME → Query based on ExpiredAt timestamps
+	// - CREATION_TIME → Query based on CreatedAt timestamps
+	// - UPDATE_TIME → Query based on UpdatedAt timestamps
+	// - VALUE_* → Query by the actual value (e.g., int, string, float)
+	IndexType IndexType_Type `protobuf:"varint,3,opt,name=IndexType,proto3,enum=hydraidepbgo.IndexType_Type" json:"IndexType,omitempty"`
+	// OrderType defines the sort direction:
+	// - ASC → Oldest/smallest first
+	// - DESC → Newest/largest first
+	OrderType OrderType_Type `protobuf:"varint,4,opt,name=OrderType,proto3,enum=hydraidepbgo.OrderType_Type" json:"OrderType,omitempty"`
+	// From defines the starting index (zero-based).
+	//
+	// Use it for pagination or skipping elements.
+	// Set to 0 to start from the beginning.
+	From int32 `protobuf:"varint,5,opt,name=From,proto3" json:"From,omitempty"`
+	// Limit defines how many items to return.
+	//
+	// Set to 0 to return all results (⚠️ caution on large datasets).
+	Limit int32 `protobuf:"varint,6,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	// FilterExpr is an optional server-side filter expression.
+	//
+	// Only the treasures matching the expression are returned. The filter is applied
+	// before From and Limit, so pagination works on the filtered result.
+	//
+	// Supported fields: key, value, createdAt, createdBy, updatedAt, updatedBy, expiredAt
+	// Supported operators: =, !=, >, >=, <, <= combined with AND, OR, NOT and parentheses
+	//
+	// Example: `value >= 18 AND createdAt > 2024-01-01`
+	//
+	// ⚠️ Only primitive values can be filtered. Complex values (structs, maps, slices)
+	// are stored in binary format, so their fields are not visible to the server.
+	FilterExpr *string `protobuf:"bytes,7,opt,name=FilterExpr,proto3,oneof" json:"FilterExpr,omitempty"`
+	// KeysOnly returns only the keys and the metadata of the treasures, without their values.
+	//
+	// Use it when only the keys are needed (e.g. cleanup jobs), because the values are
+	// neither transferred nor deserialized by the client.
+	KeysOnly bool `protobuf:"varint,8,opt,name=KeysOnly,proto3" json:"KeysOnly,omitempty"`
+	// Projection selects the parts of the treasures to return, the same as the Projection of the Get.
+	// Without projection the whole treasures are returned. KeysOnly takes precedence over it.
+	Projection    *Projection `protobuf:"bytes,9,opt,name=Projection,proto3,oneof" json:"Projection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIndexRequest) Reset() {
+	*x = GetByIndexRequest{}
+	mi := &file_hydraide_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIndexRequest) ProtoMessage() {}
+
+func (x *GetByIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIndexRequest.ProtoReflect.Descriptor instead.
+func (*GetByIndexRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetByIndexRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetByIndexRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetByIndexRequest) GetIndexType() IndexType_Type {
+	if x != nil {
+		return x.IndexType
+	}
+	return IndexType_KEY
+}
+
+func (x *GetByIndexRequest) GetOrderType() OrderType_Type {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ASC
+}
+
+func (x *GetByIndexRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetByIndexRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetByIndexRequest) GetFilterExpr() string {
+	if x != nil && x.FilterExpr != nil {
+		return *x.FilterExpr
+	}
+	return ""
+}
+
+func (x *GetByIndexRequest) GetKeysOnly() bool {
+	if x != nil {
+		return x.KeysOnly
+	}
+	return false
+}
+
+func (x *GetByIndexRequest) GetProjection() *Projection {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+type IndexType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexType) Reset() {
+	*x = IndexType{}
+	mi := &file_hydraide_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexType) ProtoMessage() {}
+
+func (x *IndexType) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexType.ProtoReflect.Descriptor instead.
+func (*IndexType) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{61}
+}
+
+type OrderType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderType) Reset() {
+	*x = OrderType{}
+	mi := &file_hydraide_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderType) ProtoMessage() {}
+
+func (x *OrderType) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderType.ProtoReflect.Descriptor instead.
+func (*OrderType) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{62}
+}
+
+type GetByIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures contains the list of key-value pairs sorted and filtered
+	// according to the request's index and parameters.
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIndexResponse) Reset() {
+	*x = GetByIndexResponse{}
+	mi := &file_hydraide_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIndexResponse) ProtoMessage() {}
+
+func (x *GetByIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIndexResponse.ProtoReflect.Descriptor instead.
+func (*GetByIndexResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetByIndexResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+// GetTopNRequest asks for the first N treasures of an index.
+type GetTopNRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to query.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// IndexType is the index to read, typically a VALUE_* index for leaderboards.
+	IndexType IndexType_Type `protobuf:"varint,3,opt,name=IndexType,proto3,enum=hydraidepbgo.IndexType_Type" json:"IndexType,omitempty"`
+	// OrderType is the order of the index. DESC returns the largest values first.
+	OrderType OrderType_Type `protobuf:"varint,4,opt,name=OrderType,proto3,enum=hydraidepbgo.OrderType_Type" json:"OrderType,omitempty"`
+	// N is the number of treasures to return. Must be greater than 0.
+	N             int32 `protobuf:"varint,5,opt,name=N,proto3" json:"N,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopNRequest) Reset() {
+	*x = GetTopNRequest{}
+	mi := &file_hydraide_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopNRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopNRequest) ProtoMessage() {}
+
+func (x *GetTopNRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopNRequest.ProtoReflect.Descriptor instead.
+func (*GetTopNRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetTopNRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetTopNRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetTopNRequest) GetIndexType() IndexType_Type {
+	if x != nil {
+		return x.IndexType
+	}
+	return IndexType_KEY
+}
+
+func (x *GetTopNRequest) GetOrderType() OrderType_Type {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ASC
+}
+
+func (x *GetTopNRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+// GetTopNResponse contains the first N treasures of the index in the order of the index.
+type GetTopNResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures are the treasures of the index. Fewer than N if the swamp has fewer matching treasures.
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopNResponse) Reset() {
+	*x = GetTopNResponse{}
+	mi := &file_hydraide_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopNResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopNResponse) ProtoMessage() {}
+
+func (x *GetTopNResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopNResponse.ProtoReflect.Descriptor instead.
+func (*GetTopNResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetTopNResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type GetByValueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to query.
+	//
+	// The swamp's pattern must be registered with ValueIndex enabled.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Value holds the value to look for in one of the typed fields of the KeyValuePair.
+	//
+	// The Key field is ignored. The type of the value is part of the comparison,
+	// so an Int8Val does not match a treasure stored as Int64Val.
+	// Uint32Slice and VoidVal values are not indexed and never match.
+	Value         *KeyValuePair `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByValueRequest) Reset() {
+	*x = GetByValueRequest{}
+	mi := &file_hydraide_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByValueRequest) ProtoMessage() {}
+
+func (x *GetByValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByValueRequest.ProtoReflect.Descriptor instead.
+func (*GetByValueRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GetByValueRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetByValueRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetByValueRequest) GetValue() *KeyValuePair {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type GetByValueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures contains the treasures holding the requested value, in no particular order.
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByValueResponse) Reset() {
+	*x = GetByValueResponse{}
+	mi := &file_hydraide_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByValueResponse) ProtoMessage() {}
+
+func (x *GetByValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByValueResponse.ProtoReflect.Descriptor instead.
+func (*GetByValueResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetByValueResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+// GetByReferenceRequest asks for the treasures referenced by the IDs of a uint32 slice.
+type GetByReferenceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where the swamp of the slice lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp holding the uint32 slice.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the key of the uint32 slice holding the referenced IDs.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// TargetIslandID is the island where the target swamp lives. It must be served by the same server.
+	TargetIslandID uint64 `protobuf:"varint,4,opt,name=TargetIslandID,proto3" json:"TargetIslandID,omitempty"`
+	// TargetSwampName is the name of the swamp holding the referenced treasures.
+	TargetSwampName string `protobuf:"bytes,5,opt,name=TargetSwampName,proto3" json:"TargetSwampName,omitempty"`
+	// TargetKeyPrefix is prepended to the decimal IDs to get the keys of the referenced treasures.
+	//
+	// Example: with the "user-" prefix the ID 42 references the treasure with the "user-42" key.
+	TargetKeyPrefix string `protobuf:"bytes,6,opt,name=TargetKeyPrefix,proto3" json:"TargetKeyPrefix,omitempty"`
+	// Projection selects the parts of the referenced treasures to return, the same as the Projection of the Get.
+	Projection    *Projection `protobuf:"bytes,7,opt,name=Projection,proto3,oneof" json:"Projection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByReferenceRequest) Reset() {
+	*x = GetByReferenceRequest{}
+	mi := &file_hydraide_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByReferenceRequest) ProtoMessage() {}
+
+func (x *GetByReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByReferenceRequest.ProtoReflect.Descriptor instead.
+func (*GetByReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetByReferenceRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *GetByReferenceRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetByReferenceRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetByReferenceRequest) GetTargetIslandID() uint64 {
+	if x != nil {
+		return x.TargetIslandID
+	}
+	return 0
+}
+
+func (x *GetByReferenceRequest) GetTargetSwampName() string {
+	if x != nil {
+		return x.TargetSwampName
+	}
+	return ""
+}
+
+func (x *GetByReferenceRequest) GetTargetKeyPrefix() string {
+	if x != nil {
+		return x.TargetKeyPrefix
+	}
+	return ""
+}
+
+func (x *GetByReferenceRequest) GetProjection() *Projection {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+type GetByReferenceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Treasures contains the referenced treasures in the order of the IDs in the slice.
+	//
+	// The referenced treasures that do not exist, or whose target swamp does not exist, are
+	// returned with IsExist = false.
+	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByReferenceResponse) Reset() {
+	*x = GetByReferenceResponse{}
+	mi := &file_hydraide_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByReferenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByReferenceResponse) ProtoMessage() {}
+
+func (x *GetByReferenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByReferenceResponse.ProtoReflect.Descriptor instead.
+func (*GetByReferenceResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetByReferenceResponse) GetTreasures() []*Treasure {
+	if x != nil {
+		return x.Treasures
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Swamps contains one or more swamp/key combinations for deletion.
+	//
+	// You can batch-delete multiple keys from multiple swamps in a single request.
+	Swamps        []*DeleteRequest_SwampKeys `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_hydraide_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *DeleteRequest) GetSwamps() []*DeleteRequest_SwampKeys {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+type DeleteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Responses holds the result of the delete operations, swamp by swamp.
+	Responses     []*DeleteResponse_SwampDeleteResponse `protobuf:"bytes,1,rep,name=Responses,proto3" json:"Responses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_hydraide_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DeleteResponse) GetResponses() []*DeleteResponse_SwampDeleteResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+type CountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampNames is the list of swamps you want to inspect.
+	//
+	// You can count multiple swamps at once.
+	Swamps []*CountRequest_SwampIdentifier `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// Filter narrows the counted treasures of every swamp of the request.
+	// If not set, all treasures are counted.
+	Filter        *CountFilter `protobuf:"bytes,2,opt,name=Filter,proto3,oneof" json:"Filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountRequest) Reset() {
+	*x = CountRequest{}
+	mi := &file_hydraide_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountRequest) ProtoMessage() {}
+
+func (x *CountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
+func (*CountRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CountRequest) GetSwamps() []*CountRequest_SwampIdentifier {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+func (x *CountRequest) GetFilter() *CountFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// CountFilter selects the treasures counted by the Count RPC.
+//
+// The conditions are combined with AND. The unset conditions do not filter.
+type CountFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ExpiredOnly counts only the treasures whose expiration time is set and is already in the past.
+	ExpiredOnly bool `protobuf:"varint,1,opt,name=ExpiredOnly,proto3" json:"ExpiredOnly,omitempty"`
+	// KeyPrefix counts only the treasures whose key starts with the prefix.
+	KeyPrefix string `protobuf:"bytes,2,opt,name=KeyPrefix,proto3" json:"KeyPrefix,omitempty"`
+	// UpdatedSince counts only the treasures updated at or after the timestamp.
+	// A treasure that was never updated counts with its creation time.
+	UpdatedSince  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=UpdatedSince,proto3,oneof" json:"UpdatedSince,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountFilter) Reset() {
+	*x = CountFilter{}
+	mi := &file_hydraide_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountFilter) ProtoMessage() {}
+
+func (x *CountFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountFilter.ProtoReflect.Descriptor instead.
+func (*CountFilter) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CountFilter) GetExpiredOnly() bool {
+	if x != nil {
+		return x.ExpiredOnly
+	}
+	return false
+}
+
+func (x *CountFilter) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+func (x *CountFilter) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+type CountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Swamps contains the count result for each swamp queried.
+	Swamps        []*CountSwamp `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResponse) Reset() {
+	*x = CountResponse{}
+	mi := &file_hydraide_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResponse) ProtoMessage() {}
+
+func (x *CountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
+func (*CountResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CountResponse) GetSwamps() []*CountSwamp {
+	if x != nil {
+		return x.Swamps
+	}
+	return nil
+}
+
+type CountSwamp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampName is the name of the swamp counted.
+	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// IsExist tells whether the swamp exists in the system.
+	IsExist bool `protobuf:"varint,2,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+	// Count is the number of treasures currently stored in this swamp.
+	Count         int32 `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountSwamp) Reset() {
+	*x = CountSwamp{}
+	mi := &file_hydraide_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountSwamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountSwamp) ProtoMessage() {}
+
+func (x *CountSwamp) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountSwamp.ProtoReflect.Descriptor instead.
+func (*CountSwamp) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CountSwamp) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *CountSwamp) GetIsExist() bool {
+	if x != nil {
+		return x.IsExist
+	}
+	return false
+}
+
+func (x *CountSwamp) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type IncrementInt8Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the value resides.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the identifier of the treasure to increment.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the signed integer to add (or subtract) from the current value.
+	//
+	// ⚠️ Although this is declared as int32, only int8-range values are stored.
+	// HydrAIDE internally converts and enforces the correct range.
+	//
+	// 💡 Supports negative values for decrementing.
+	IncrementBy int32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition determines whether the increment should proceed.
+	Condition     *IncrementInt8Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt8Request) Reset() {
+	*x = IncrementInt8Request{}
+	mi := &file_hydraide_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt8Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt8Request) ProtoMessage() {}
+
+func (x *IncrementInt8Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt8Request.ProtoReflect.Descriptor instead.
+func (*IncrementInt8Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *IncrementInt8Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementInt8Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementInt8Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementInt8Request) GetIncrementBy() int32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementInt8Request) GetCondition() *IncrementInt8Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementInt8Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The relational operator to apply (e.g., GREATER_THAN, EQUAL).
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// The value to compare against.
+	//
+	// ⚠️ Despite being int32 here (due to proto3 limitation), only valid int8 values are considered.
+	Value         int32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt8Condition) Reset() {
+	*x = IncrementInt8Condition{}
+	mi := &file_hydraide_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt8Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt8Condition) ProtoMessage() {}
+
+func (x *IncrementInt8Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt8Condition.ProtoReflect.Descriptor instead.
+func (*IncrementInt8Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *IncrementInt8Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementInt8Condition) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementInt8Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the resulting value after the increment (or original if unchanged).
+	//
+	// Note: ⚠️ This is represented as int32 due to proto3 constraints,
+	// but internally HydrAIDE uses int8 precision and guarantees type-safety.
+	Value int32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented indicates whether the increment was actually applied.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt8Response) Reset() {
+	*x = IncrementInt8Response{}
+	mi := &file_hydraide_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt8Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt8Response) ProtoMessage() {}
+
+func (x *IncrementInt8Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt8Response.ProtoReflect.Descriptor instead.
+func (*IncrementInt8Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *IncrementInt8Response) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementInt8Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementInt16Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the value resides.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the identifier of the treasure to increment.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the signed integer to add (or subtract) from the current value.
+	//
+	// ⚠️ Although this is declared as int32, HydrAIDE stores the value as int16.
+	// The system enforces proper bounds and type conversion internally.
+	IncrementBy int32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition determines whether the increment should proceed.
+	Condition     *IncrementInt16Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt16Request) Reset() {
+	*x = IncrementInt16Request{}
+	mi := &file_hydraide_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt16Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt16Request) ProtoMessage() {}
+
+func (x *IncrementInt16Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt16Request.ProtoReflect.Descriptor instead.
+func (*IncrementInt16Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *IncrementInt16Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementInt16Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementInt16Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementInt16Request) GetIncrementBy() int32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementInt16Request) GetCondition() *IncrementInt16Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementInt16Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The relational operator to apply (e.g., GREATER_THAN, LESS_THAN).
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// The value to compare against.
+	//
+	// ⚠️ While represented as int32 (due to proto3 constraints),
+	// HydrAIDE treats it as an int16 behind the scenes.
+	Value         int32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt16Condition) Reset() {
+	*x = IncrementInt16Condition{}
+	mi := &file_hydraide_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt16Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt16Condition) ProtoMessage() {}
+
+func (x *IncrementInt16Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt16Condition.ProtoReflect.Descriptor instead.
+func (*IncrementInt16Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *IncrementInt16Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementInt16Condition) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementInt16Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the resulting value after incrementing.
+	//
+	// Note: Represented as int32 for compatibility, but HydrAIDE ensures
+	// that the underlying type remains int16 and no data loss occurs.
+	Value int32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented tells whether the value was actually modified.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt16Response) Reset() {
+	*x = IncrementInt16Response{}
+	mi := &file_hydraide_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt16Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt16Response) ProtoMessage() {}
+
+func (x *IncrementInt16Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt16Response.ProtoReflect.Descriptor instead.
+func (*IncrementInt16Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *IncrementInt16Response) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementInt16Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementInt32Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the value resides.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the treasure key whose value we want to increment.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the signed 32-bit integer to add or subtract.
+	IncrementBy int32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition must be satisfied for the increment to apply.
+	Condition     *IncrementInt32Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt32Request) Reset() {
+	*x = IncrementInt32Request{}
+	mi := &file_hydraide_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt32Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt32Request) ProtoMessage() {}
+
+func (x *IncrementInt32Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt32Request.ProtoReflect.Descriptor instead.
+func (*IncrementInt32Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *IncrementInt32Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementInt32Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementInt32Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementInt32Request) GetIncrementBy() int32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementInt32Request) GetCondition() *IncrementInt32Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementInt32Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The relational operator for evaluating the current value.
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// Value to compare against (int32).
+	Value         int32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt32Condition) Reset() {
+	*x = IncrementInt32Condition{}
+	mi := &file_hydraide_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt32Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt32Condition) ProtoMessage() {}
+
+func (x *IncrementInt32Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt32Condition.ProtoReflect.Descriptor instead.
+func (*IncrementInt32Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *IncrementInt32Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementInt32Condition) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementInt32Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the resulting int32 value after increment.
+	Value int32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented is true if the value was changed.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt32Response) Reset() {
+	*x = IncrementInt32Response{}
+	mi := &file_hydraide_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt32Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt32Response) ProtoMessage() {}
+
+func (x *IncrementInt32Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt32Response.ProtoReflect.Descriptor instead.
+func (*IncrementInt32Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *IncrementInt32Response) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementInt32Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementInt64Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure you want to increment.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the signed 64-bit integer to add (can be negative).
+	//
+	// 💡 Ideal for working with timestamps, counters, large numeric values.
+	IncrementBy int64 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition must be met for the increment to happen.
+	Condition     *IncrementInt64Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt64Request) Reset() {
+	*x = IncrementInt64Request{}
+	mi := &file_hydraide_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt64Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt64Request) ProtoMessage() {}
+
+func (x *IncrementInt64Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt64Request.ProtoReflect.Descriptor instead.
+func (*IncrementInt64Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *IncrementInt64Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementInt64Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementInt64Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementInt64Request) GetIncrementBy() int64 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementInt64Request) GetCondition() *IncrementInt64Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementInt64Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Relational operator used for conditional increment.
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// The reference value to compare the current value against.
+	Value         int64 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt64Condition) Reset() {
+	*x = IncrementInt64Condition{}
+	mi := &file_hydraide_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt64Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt64Condition) ProtoMessage() {}
+
+func (x *IncrementInt64Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt64Condition.ProtoReflect.Descriptor instead.
+func (*IncrementInt64Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *IncrementInt64Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementInt64Condition) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementInt64Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the resulting value after the increment (or the original value if condition failed).
+	Value int64 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented indicates whether the operation was applied.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementInt64Response) Reset() {
+	*x = IncrementInt64Response{}
+	mi := &file_hydraide_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementInt64Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementInt64Response) ProtoMessage() {}
+
+func (x *IncrementInt64Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementInt64Response.ProtoReflect.Descriptor instead.
+func (*IncrementInt64Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *IncrementInt64Response) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementInt64Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementUint8Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName identifies the swamp where the treasure lives.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the treasure to increment.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the unsigned integer to add to the current value.
+	//
+	// ⚠️ Although represented as uint32 due to proto3 limitations,
+	// HydrAIDE stores this as a true uint8 internally (0–255).
+	//
+	// Any overflow is safely rejected and not applied.
+	IncrementBy uint32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition to be evaluated before applying the increment.
+	Condition     *IncrementUint8Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint8Request) Reset() {
+	*x = IncrementUint8Request{}
+	mi := &file_hydraide_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint8Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint8Request) ProtoMessage() {}
+
+func (x *IncrementUint8Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint8Request.ProtoReflect.Descriptor instead.
+func (*IncrementUint8Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *IncrementUint8Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementUint8Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementUint8Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementUint8Request) GetIncrementBy() uint32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementUint8Request) GetCondition() *IncrementUint8Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementUint8Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The relational operator (e.g. EQUAL, GREATER_THAN, etc.)
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// Value to compare against before incrementing.
+	//
+	// ⚠️ Same as above: Only values within the uint8 range are accepted internally.
+	Value         uint32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint8Condition) Reset() {
+	*x = IncrementUint8Condition{}
+	mi := &file_hydraide_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint8Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint8Condition) ProtoMessage() {}
+
+func (x *IncrementUint8Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint8Condition.ProtoReflect.Descriptor instead.
+func (*IncrementUint8Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *IncrementUint8Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementUint8Condition) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementUint8Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the resulting value after increment.
+	//
+	// ⚠️ Represented as uint32 in the response, but internally it's stored as uint8.
+	Value uint32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented is true if the increment was applied.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint8Response) Reset() {
+	*x = IncrementUint8Response{}
+	mi := &file_hydraide_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint8Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint8Response) ProtoMessage() {}
+
+func (x *IncrementUint8Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint8Response.ProtoReflect.Descriptor instead.
+func (*IncrementUint8Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *IncrementUint8Response) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementUint8Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementUint16Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the target treasure.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the unsigned integer to add (or subtract, if allowed).
+	//
+	// ⚠️ Represented as `uint32` in the gRPC interface, but HydrAIDE stores it as true `uint16` internally.
+	// Allowed value range: 0 to 65,535
+	IncrementBy uint32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition to be satisfied before increment is applied.
+	Condition     *IncrementUint16Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint16Request) Reset() {
+	*x = IncrementUint16Request{}
+	mi := &file_hydraide_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint16Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint16Request) ProtoMessage() {}
+
+func (x *IncrementUint16Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint16Request.ProtoReflect.Descriptor instead.
+func (*IncrementUint16Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *IncrementUint16Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementUint16Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementUint16Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementUint16Request) GetIncrementBy() uint32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementUint16Request) GetCondition() *IncrementUint16Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementUint16Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The logical operator used for evaluating the current value.
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// Comparison reference value.
+	//
+	// ⚠️ Although this is a `uint32`, HydrAIDE handles it as `uint16` internally.
+	Value         uint32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint16Condition) Reset() {
+	*x = IncrementUint16Condition{}
+	mi := &file_hydraide_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint16Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint16Condition) ProtoMessage() {}
+
+func (x *IncrementUint16Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint16Condition.ProtoReflect.Descriptor instead.
+func (*IncrementUint16Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *IncrementUint16Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementUint16Condition) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementUint16Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the final (or original) value after attempted increment.
+	//
+	// Still represented as `uint32` due to proto limitations, but only 0..65535 values are stored.
+	Value uint32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented tells whether the value was actually modified.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint16Response) Reset() {
+	*x = IncrementUint16Response{}
+	mi := &file_hydraide_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint16Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint16Response) ProtoMessage() {}
+
+func (x *IncrementUint16Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint16Response.ProtoReflect.Descriptor instead.
+func (*IncrementUint16Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *IncrementUint16Response) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementUint16Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementUint32Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName specifies the target swamp.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure to increment.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the unsigned 32-bit integer value to add.
+	//
+	// 💡 Supports any uint32 value (0–4,294,967,295)
+	IncrementBy uint32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition must be true for the increment to be applied.
+	Condition     *IncrementUint32Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint32Request) Reset() {
+	*x = IncrementUint32Request{}
+	mi := &file_hydraide_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint32Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint32Request) ProtoMessage() {}
+
+func (x *IncrementUint32Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint32Request.ProtoReflect.Descriptor instead.
+func (*IncrementUint32Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *IncrementUint32Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementUint32Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementUint32Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementUint32Request) GetIncrementBy() uint32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementUint32Request) GetCondition() *IncrementUint32Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementUint32Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The relational operator used to evaluate the current value.
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// The value to compare against.
+	Value         uint32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint32Condition) Reset() {
+	*x = IncrementUint32Condition{}
+	mi := &file_hydraide_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint32Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint32Condition) ProtoMessage() {}
+
+func (x *IncrementUint32Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint32Condition.ProtoReflect.Descriptor instead.
+func (*IncrementUint32Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *IncrementUint32Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementUint32Condition) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementUint32Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the updated value after incrementing,
+	// or the original if the condition failed.
+	Value uint32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented indicates whether the value was modified.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint32Response) Reset() {
+	*x = IncrementUint32Response{}
+	mi := &file_hydraide_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint32Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint32Response) ProtoMessage() {}
+
+func (x *IncrementUint32Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint32Response.ProtoReflect.Descriptor instead.
+func (*IncrementUint32Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *IncrementUint32Response) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementUint32Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementUint64Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the swamp where the treasure is located.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure whose value will be incremented.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the amount to add.
+	//
+	// 💡 Supports the full range of unsigned 64-bit integers (0 to 18 quintillion+)
+	IncrementBy uint64 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition must be satisfied for the operation to take place.
+	Condition     *IncrementUint64Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint64Request) Reset() {
+	*x = IncrementUint64Request{}
+	mi := &file_hydraide_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint64Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint64Request) ProtoMessage() {}
+
+func (x *IncrementUint64Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint64Request.ProtoReflect.Descriptor instead.
+func (*IncrementUint64Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *IncrementUint64Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementUint64Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementUint64Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementUint64Request) GetIncrementBy() uint64 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementUint64Request) GetCondition() *IncrementUint64Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementUint64Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Logical operator used to compare current value.
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// The uint64 value to compare the current treasure value against.
+	Value         uint64 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint64Condition) Reset() {
+	*x = IncrementUint64Condition{}
+	mi := &file_hydraide_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint64Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint64Condition) ProtoMessage() {}
+
+func (x *IncrementUint64Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint64Condition.ProtoReflect.Descriptor instead.
+func (*IncrementUint64Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *IncrementUint64Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementUint64Condition) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementUint64Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the resulting value after the operation (or the original).
+	Value uint64 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented is true if the operation was applied.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementUint64Response) Reset() {
+	*x = IncrementUint64Response{}
+	mi := &file_hydraide_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementUint64Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementUint64Response) ProtoMessage() {}
+
+func (x *IncrementUint64Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementUint64Response.ProtoReflect.Descriptor instead.
+func (*IncrementUint64Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *IncrementUint64Response) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementUint64Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type Relational struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Relational) Reset() {
+	*x = Relational{}
+	mi := &file_hydraide_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relational) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relational) ProtoMessage() {}
+
+func (x *Relational) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relational.ProtoReflect.Descriptor instead.
+func (*Relational) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{100}
+}
+
+type IncrementFloat32Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName identifies the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key points to the specific treasure we want to modify.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the amount to add (can be negative).
+	//
+	// 💡 Supports 32-bit floating point numbers.
+	IncrementBy float32 `protobuf:"fixed32,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition must be met for the change to happen.
+	Condition     *IncrementFloat32Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementFloat32Request) Reset() {
+	*x = IncrementFloat32Request{}
+	mi := &file_hydraide_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementFloat32Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementFloat32Request) ProtoMessage() {}
+
+func (x *IncrementFloat32Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementFloat32Request.ProtoReflect.Descriptor instead.
+func (*IncrementFloat32Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *IncrementFloat32Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementFloat32Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementFloat32Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementFloat32Request) GetIncrementBy() float32 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementFloat32Request) GetCondition() *IncrementFloat32Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementFloat32Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Logical check to evaluate before applying the increment.
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// The float value to compare the current treasure against.
+	Value         float32 `protobuf:"fixed32,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementFloat32Condition) Reset() {
+	*x = IncrementFloat32Condition{}
+	mi := &file_hydraide_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementFloat32Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementFloat32Condition) ProtoMessage() {}
+
+func (x *IncrementFloat32Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementFloat32Condition.ProtoReflect.Descriptor instead.
+func (*IncrementFloat32Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *IncrementFloat32Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementFloat32Condition) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementFloat32Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the result after the (conditional) increment.
+	Value float32 `protobuf:"fixed32,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented is true if the change was applied.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementFloat32Response) Reset() {
+	*x = IncrementFloat32Response{}
+	mi := &file_hydraide_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementFloat32Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementFloat32Response) ProtoMessage() {}
+
+func (x *IncrementFloat32Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementFloat32Response.ProtoReflect.Descriptor instead.
+func (*IncrementFloat32Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *IncrementFloat32Response) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementFloat32Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type IncrementFloat64Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the target swamp where the treasure lives.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure to be incremented.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// IncrementBy is the floating-point value to add.
+	//
+	// ✅ Can be positive or negative.
+	// ✅ Uses 64-bit floating-point precision.
+	IncrementBy float64 `protobuf:"fixed64,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
+	// Condition that must be true for the increment to apply.
+	Condition     *IncrementFloat64Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementFloat64Request) Reset() {
+	*x = IncrementFloat64Request{}
+	mi := &file_hydraide_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementFloat64Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementFloat64Request) ProtoMessage() {}
+
+func (x *IncrementFloat64Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementFloat64Request.ProtoReflect.Descriptor instead.
+func (*IncrementFloat64Request) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *IncrementFloat64Request) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IncrementFloat64Request) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IncrementFloat64Request) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementFloat64Request) GetIncrementBy() float64 {
+	if x != nil {
+		return x.IncrementBy
+	}
+	return 0
+}
+
+func (x *IncrementFloat64Request) GetCondition() *IncrementFloat64Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type IncrementFloat64Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Comparison operator (e.g. GREATER_THAN, EQUAL, etc.)
+	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
+	// Reference value to evaluate the current treasure against.
+	Value         float64 `protobuf:"fixed64,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementFloat64Condition) Reset() {
+	*x = IncrementFloat64Condition{}
+	mi := &file_hydraide_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementFloat64Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementFloat64Condition) ProtoMessage() {}
+
+func (x *IncrementFloat64Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementFloat64Condition.ProtoReflect.Descriptor instead.
+func (*IncrementFloat64Condition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *IncrementFloat64Condition) GetRelationalOperator() Relational_Operator {
+	if x != nil {
+		return x.RelationalOperator
+	}
+	return Relational_EQUAL
+}
+
+func (x *IncrementFloat64Condition) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type IncrementFloat64Response struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the new (or unchanged) value after the request.
+	Value float64 `protobuf:"fixed64,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsIncremented tells if the change actually occurred.
+	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementFloat64Response) Reset() {
+	*x = IncrementFloat64Response{}
+	mi := &file_hydraide_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementFloat64Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementFloat64Response) ProtoMessage() {}
+
+func (x *IncrementFloat64Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementFloat64Response.ProtoReflect.Descriptor instead.
+func (*IncrementFloat64Response) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *IncrementFloat64Response) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementFloat64Response) GetIsIncremented() bool {
+	if x != nil {
+		return x.IsIncremented
+	}
+	return false
+}
+
+type SetIfLaterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure of the timestamp.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Timestamp is the UNIX timestamp in seconds to store, if it is later than the stored one.
+	Timestamp     int64 `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIfLaterRequest) Reset() {
+	*x = SetIfLaterRequest{}
+	mi := &file_hydraide_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIfLaterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIfLaterRequest) ProtoMessage() {}
+
+func (x *SetIfLaterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIfLaterRequest.ProtoReflect.Descriptor instead.
+func (*SetIfLaterRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *SetIfLaterRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *SetIfLaterRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *SetIfLaterRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetIfLaterRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type SetIfLaterResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timestamp is the stored UNIX timestamp after the request: the new one if it was set, the stored one otherwise.
+	Timestamp int64 `protobuf:"varint,1,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	// IsSet tells if the timestamp was stored.
+	IsSet         bool `protobuf:"varint,2,opt,name=IsSet,proto3" json:"IsSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIfLaterResponse) Reset() {
+	*x = SetIfLaterResponse{}
+	mi := &file_hydraide_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIfLaterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIfLaterResponse) ProtoMessage() {}
+
+func (x *SetIfLaterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIfLaterResponse.ProtoReflect.Descriptor instead.
+func (*SetIfLaterResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *SetIfLaterResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SetIfLaterResponse) GetIsSet() bool {
+	if x != nil {
+		return x.IsSet
+	}
+	return false
+}
+
+type AddDurationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure of the timestamp.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Seconds is the duration to add, a negative duration moves the timestamp back. It cannot be zero.
+	Seconds int64 `protobuf:"varint,4,opt,name=Seconds,proto3" json:"Seconds,omitempty"`
+	// FromNowIfPast adds the duration to the current time of the server, if the stored timestamp is in the past.
+	FromNowIfPast bool `protobuf:"varint,5,opt,name=FromNowIfPast,proto3" json:"FromNowIfPast,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDurationRequest) Reset() {
+	*x = AddDurationRequest{}
+	mi := &file_hydraide_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDurationRequest) ProtoMessage() {}
+
+func (x *AddDurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDurationRequest.ProtoReflect.Descriptor instead.
+func (*AddDurationRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *AddDurationRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *AddDurationRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *AddDurationRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AddDurationRequest) GetSeconds() int64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *AddDurationRequest) GetFromNowIfPast() bool {
+	if x != nil {
+		return x.FromNowIfPast
+	}
+	return false
+}
+
+type AddDurationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timestamp is the new UNIX timestamp in seconds.
+	Timestamp     int64 `protobuf:"varint,1,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDurationResponse) Reset() {
+	*x = AddDurationResponse{}
+	mi := &file_hydraide_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDurationResponse) ProtoMessage() {}
+
+func (x *AddDurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDurationResponse.ProtoReflect.Descriptor instead.
+func (*AddDurationResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *AddDurationResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type SetIf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIf) Reset() {
+	*x = SetIf{}
+	mi := &file_hydraide_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIf) ProtoMessage() {}
+
+func (x *SetIf) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIf.ProtoReflect.Descriptor instead.
+func (*SetIf) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{111}
+}
+
+type SetStringIfRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure to set.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Value is the string to store, if the condition is satisfied.
+	Value string `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Condition must be met for the value to be stored. Without a condition, the value is stored unconditionally.
+	Condition     *SetStringCondition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStringIfRequest) Reset() {
+	*x = SetStringIfRequest{}
+	mi := &file_hydraide_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStringIfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStringIfRequest) ProtoMessage() {}
+
+func (x *SetStringIfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStringIfRequest.ProtoReflect.Descriptor instead.
+func (*SetStringIfRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *SetStringIfRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *SetStringIfRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *SetStringIfRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetStringIfRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SetStringIfRequest) GetCondition() *SetStringCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type SetStringCondition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operator of the condition, e.g. EQUAL or NOT_EXISTS.
+	Operator SetIf_Operator `protobuf:"varint,1,opt,name=Operator,proto3,enum=hydraidepbgo.SetIf_Operator" json:"Operator,omitempty"`
+	// The reference value to compare the stored value against.
+	Value         string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStringCondition) Reset() {
+	*x = SetStringCondition{}
+	mi := &file_hydraide_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStringCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStringCondition) ProtoMessage() {}
+
+func (x *SetStringCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStringCondition.ProtoReflect.Descriptor instead.
+func (*SetStringCondition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *SetStringCondition) GetOperator() SetIf_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return SetIf_EQUAL
+}
+
+func (x *SetStringCondition) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SetStringIfResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the stored value after the request: the new one if it was set, the stored one otherwise. Empty if the
+	// key does not exist.
+	Value string `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsSet tells if the value was stored.
+	IsSet         bool `protobuf:"varint,2,opt,name=IsSet,proto3" json:"IsSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStringIfResponse) Reset() {
+	*x = SetStringIfResponse{}
+	mi := &file_hydraide_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStringIfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStringIfResponse) ProtoMessage() {}
+
+func (x *SetStringIfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStringIfResponse.ProtoReflect.Descriptor instead.
+func (*SetStringIfResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *SetStringIfResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SetStringIfResponse) GetIsSet() bool {
+	if x != nil {
+		return x.IsSet
+	}
+	return false
+}
+
+type SetBoolIfRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the treasure is stored.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key identifies the treasure to set.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Value is the bool to store, if the condition is satisfied.
+	Value bool `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Condition must be met for the value to be stored. Without a condition, the value is stored unconditionally.
+	Condition     *SetBoolCondition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoolIfRequest) Reset() {
+	*x = SetBoolIfRequest{}
+	mi := &file_hydraide_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoolIfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoolIfRequest) ProtoMessage() {}
+
+func (x *SetBoolIfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoolIfRequest.ProtoReflect.Descriptor instead.
+func (*SetBoolIfRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *SetBoolIfRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *SetBoolIfRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *SetBoolIfRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetBoolIfRequest) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func (x *SetBoolIfRequest) GetCondition() *SetBoolCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type SetBoolCondition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operator of the condition, e.g. EQUAL or NOT_EXISTS.
+	Operator SetIf_Operator `protobuf:"varint,1,opt,name=Operator,proto3,enum=hydraidepbgo.SetIf_Operator" json:"Operator,omitempty"`
+	// The reference value to compare the stored value against.
+	Value         bool `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoolCondition) Reset() {
+	*x = SetBoolCondition{}
+	mi := &file_hydraide_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoolCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoolCondition) ProtoMessage() {}
+
+func (x *SetBoolCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoolCondition.ProtoReflect.Descriptor instead.
+func (*SetBoolCondition) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *SetBoolCondition) GetOperator() SetIf_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return SetIf_EQUAL
+}
+
+func (x *SetBoolCondition) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+type SetBoolIfResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value is the stored value after the request: the new one if it was set, the stored one otherwise. False if the
+	// key does not exist.
+	Value bool `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IsSet tells if the value was stored.
+	IsSet         bool `protobuf:"varint,2,opt,name=IsSet,proto3" json:"IsSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoolIfResponse) Reset() {
+	*x = SetBoolIfResponse{}
+	mi := &file_hydraide_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoolIfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoolIfResponse) ProtoMessage() {}
+
+func (x *SetBoolIfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoolIfResponse.ProtoReflect.Descriptor instead.
+func (*SetBoolIfResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *SetBoolIfResponse) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func (x *SetBoolIfResponse) GetIsSet() bool {
+	if x != nil {
+		return x.IsSet
+	}
+	return false
+}
+
+// KeySlicePair represents a mapping between a key and a list of uint32 values.
+// Used for slice-related operations like push and delete.
+type KeySlicePair struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key identifies the treasure containing the uint32 slice.
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Values are the uint32 values to add or remove.
+	Values        []uint32 `protobuf:"varint,2,rep,packed,name=Values,proto3" json:"Values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeySlicePair) Reset() {
+	*x = KeySlicePair{}
+	mi := &file_hydraide_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeySlicePair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeySlicePair) ProtoMessage() {}
+
+func (x *KeySlicePair) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeySlicePair.ProtoReflect.Descriptor instead.
+func (*KeySlicePair) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *KeySlicePair) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeySlicePair) GetValues() []uint32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// AddToUint32SlicePushRequest adds one or more uint32 values to one or more keys.
+type AddToUint32SlicePushRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp containing the target slices.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// KeySlicePairs contains one or more key → value(s) mappings to push into the slices.
+	KeySlicePairs []*KeySlicePair `protobuf:"bytes,3,rep,name=KeySlicePairs,proto3" json:"KeySlicePairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToUint32SlicePushRequest) Reset() {
+	*x = AddToUint32SlicePushRequest{}
+	mi := &file_hydraide_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToUint32SlicePushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToUint32SlicePushRequest) ProtoMessage() {}
+
+func (x *AddToUint32SlicePushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToUint32SlicePushRequest.ProtoReflect.Descriptor instead.
+func (*AddToUint32SlicePushRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *AddToUint32SlicePushRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *AddToUint32SlicePushRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *AddToUint32SlicePushRequest) GetKeySlicePairs() []*KeySlicePair {
+	if x != nil {
+		return x.KeySlicePairs
+	}
+	return nil
+}
+
+// AddToUint32SlicePushResponse is returned after a successful push operation.
+type AddToUint32SlicePushResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Results contains the counts of the pushed values per key, in the order of the KeySlicePairs of the request.
+	Results       []*Uint32SlicePushResult `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToUint32SlicePushResponse) Reset() {
+	*x = AddToUint32SlicePushResponse{}
+	mi := &file_hydraide_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToUint32SlicePushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToUint32SlicePushResponse) ProtoMessage() {}
+
+func (x *AddToUint32SlicePushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToUint32SlicePushResponse.ProtoReflect.Descriptor instead.
+func (*AddToUint32SlicePushResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *AddToUint32SlicePushResponse) GetResults() []*Uint32SlicePushResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Uint32SlicePushResult counts the values of a key pushed by Uint32SlicePush.
+//
+// 💡 Added + AlreadyPresent is always the number of the pushed values of the key.
+type Uint32SlicePushResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key identifies the treasure of the uint32 slice.
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Added is the number of the values added to the slice.
+	Added uint64 `protobuf:"varint,2,opt,name=Added,proto3" json:"Added,omitempty"`
+	// AlreadyPresent is the number of the values not added, because they were already in the slice, or they were
+	// repeated in the request.
+	AlreadyPresent uint64 `protobuf:"varint,3,opt,name=AlreadyPresent,proto3" json:"AlreadyPresent,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Uint32SlicePushResult) Reset() {
+	*x = Uint32SlicePushResult{}
+	mi := &file_hydraide_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SlicePushResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SlicePushResult) ProtoMessage() {}
+
+func (x *Uint32SlicePushResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SlicePushResult.ProtoReflect.Descriptor instead.
+func (*Uint32SlicePushResult) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *Uint32SlicePushResult) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Uint32SlicePushResult) GetAdded() uint64 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *Uint32SlicePushResult) GetAlreadyPresent() uint64 {
+	if x != nil {
+		return x.AlreadyPresent
+	}
+	return 0
+}
+
+// Uint32SliceDeleteRequest removes one or more values from one or more uint32 slices.
+type Uint32SliceDeleteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the slices are located.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// KeySlicePairs contains the keys and values to remove from the corresponding slices.
+	KeySlicePairs []*KeySlicePair `protobuf:"bytes,3,rep,name=KeySlicePairs,proto3" json:"KeySlicePairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceDeleteRequest) Reset() {
+	*x = Uint32SliceDeleteRequest{}
+	mi := &file_hydraide_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceDeleteRequest) ProtoMessage() {}
+
+func (x *Uint32SliceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *Uint32SliceDeleteRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *Uint32SliceDeleteRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *Uint32SliceDeleteRequest) GetKeySlicePairs() []*KeySlicePair {
+	if x != nil {
+		return x.KeySlicePairs
+	}
+	return nil
+}
+
+// Uint32SliceDeleteResponse is returned after a successful delete operation.
+// This message is intentionally empty.
+type Uint32SliceDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceDeleteResponse) Reset() {
+	*x = Uint32SliceDeleteResponse{}
+	mi := &file_hydraide_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceDeleteResponse) ProtoMessage() {}
+
+func (x *Uint32SliceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*Uint32SliceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{123}
+}
+
+// Uint32SliceSizeRequest queries the size (number of elements) of a specific uint32 slice.
+type Uint32SliceSizeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp containing the slice.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the identifier of the treasure whose slice we want to measure.
+	Key           string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceSizeRequest) Reset() {
+	*x = Uint32SliceSizeRequest{}
+	mi := &file_hydraide_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceSizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceSizeRequest) ProtoMessage() {}
+
+func (x *Uint32SliceSizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceSizeRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceSizeRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *Uint32SliceSizeRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *Uint32SliceSizeRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *Uint32SliceSizeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// Uint32SliceSizeResponse returns the current number of values in the requested slice.
+type Uint32SliceSizeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Size is the total number of uint32 values in the slice.
+	Size          int64 `protobuf:"varint,1,opt,name=Size,proto3" json:"Size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceSizeResponse) Reset() {
+	*x = Uint32SliceSizeResponse{}
+	mi := &file_hydraide_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceSizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceSizeResponse) ProtoMessage() {}
+
+func (x *Uint32SliceSizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceSizeResponse.ProtoReflect.Descriptor instead.
+func (*Uint32SliceSizeResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *Uint32SliceSizeResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Uint32SliceIsValueExistRequest checks if a specific uint32 value exists in a slice.
+type Uint32SliceIsValueExistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp containing the slice.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the treasure containing the slice.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Value is the uint32 number we want to check for existence.
+	Value         uint32 `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceIsValueExistRequest) Reset() {
+	*x = Uint32SliceIsValueExistRequest{}
+	mi := &file_hydraide_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceIsValueExistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceIsValueExistRequest) ProtoMessage() {}
+
+func (x *Uint32SliceIsValueExistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceIsValueExistRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceIsValueExistRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *Uint32SliceIsValueExistRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *Uint32SliceIsValueExistRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *Uint32SliceIsValueExistRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Uint32SliceIsValueExistRequest) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Uint32SliceIsValueExistResponse returns whether the specified value is present in the slice.
+type Uint32SliceIsValueExistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IsExist is true if the value is present in the slice, false otherwise.
+	IsExist       bool `protobuf:"varint,1,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceIsValueExistResponse) Reset() {
+	*x = Uint32SliceIsValueExistResponse{}
+	mi := &file_hydraide_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceIsValueExistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceIsValueExistResponse) ProtoMessage() {}
+
+func (x *Uint32SliceIsValueExistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceIsValueExistResponse.ProtoReflect.Descriptor instead.
+func (*Uint32SliceIsValueExistResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *Uint32SliceIsValueExistResponse) GetIsExist() bool {
+	if x != nil {
+		return x.IsExist
+	}
+	return false
+}
+
+// Uint32SliceGetRangeRequest reads a page of the values of a uint32 slice.
+type Uint32SliceGetRangeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp containing the slice.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the treasure containing the slice.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Offset is the number of the values to skip. An offset beyond the end of the slice returns no values.
+	Offset int64 `protobuf:"varint,4,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// Limit is the max number of the values to return. It must be positive.
+	Limit         int64 `protobuf:"varint,5,opt,name=Limit,proto3" json:"Limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyStatusPair) Reset() {
-	*x = KeyStatusPair{}
-	mi := &file_hydraide_proto_msgTypes[22]
+func (x *Uint32SliceGetRangeRequest) Reset() {
+	*x = Uint32SliceGetRangeRequest{}
+	mi := &file_hydraide_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyStatusPair) String() string {
+func (x *Uint32SliceGetRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyStatusPair) ProtoMessage() {}
+func (*Uint32SliceGetRangeRequest) ProtoMessage() {}
 
-func (x *KeyStatusPair) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[22]
+func (x *Uint32SliceGetRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1865,91 +9411,72 @@ func (x *KeyStatusPair) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyStatusPair.ProtoReflect.Descriptor instead.
-func (*KeyStatusPair) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use Uint32SliceGetRangeRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceGetRangeRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{128}
 }
 
-func (x *KeyStatusPair) GetKey() string {
+func (x *Uint32SliceGetRangeRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.Key
+		return x.IslandID
 	}
-	return ""
+	return 0
 }
 
-func (x *KeyStatusPair) GetStatus() Status_Code {
+func (x *Uint32SliceGetRangeRequest) GetSwampName() string {
 	if x != nil {
-		return x.Status
+		return x.SwampName
 	}
-	return Status_NOT_FOUND
-}
-
-type Status struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Status) Reset() {
-	*x = Status{}
-	mi := &file_hydraide_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *Status) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Uint32SliceGetRangeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
 }
 
-func (*Status) ProtoMessage() {}
-
-func (x *Status) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[23]
+func (x *Uint32SliceGetRangeRequest) GetOffset() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Offset
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Status.ProtoReflect.Descriptor instead.
-func (*Status) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{23}
+func (x *Uint32SliceGetRangeRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
 
-type GetRequest struct {
+// Uint32SliceGetRangeResponse returns a page of the values of a uint32 slice.
+type Uint32SliceGetRangeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Swamps is a list of swamps with their associated keys to retrieve.
-	//
-	// You can batch multiple swamp/key combinations into a single request,
-	// making the operation efficient for distributed or sharded datasets.
-	//
-	// Example:
-	// - Swamp "hu/products" → get keys ["123", "456"]
-	// - Swamp "de/users" → get keys ["alice", "bob"]
-	Swamps        []*GetSwamp `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// Values are the values of the page, in the order they were pushed.
+	Values []uint32 `protobuf:"varint,1,rep,packed,name=Values,proto3" json:"Values,omitempty"`
+	// Size is the number of all values in the slice.
+	Size          int64 `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRequest) Reset() {
-	*x = GetRequest{}
-	mi := &file_hydraide_proto_msgTypes[24]
+func (x *Uint32SliceGetRangeResponse) Reset() {
+	*x = Uint32SliceGetRangeResponse{}
+	mi := &file_hydraide_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRequest) String() string {
+func (x *Uint32SliceGetRangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRequest) ProtoMessage() {}
+func (*Uint32SliceGetRangeResponse) ProtoMessage() {}
 
-func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[24]
+func (x *Uint32SliceGetRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1960,45 +9487,55 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
-func (*GetRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use Uint32SliceGetRangeResponse.ProtoReflect.Descriptor instead.
+func (*Uint32SliceGetRangeResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{129}
 }
 
-func (x *GetRequest) GetSwamps() []*GetSwamp {
+func (x *Uint32SliceGetRangeResponse) GetValues() []uint32 {
 	if x != nil {
-		return x.Swamps
+		return x.Values
 	}
 	return nil
 }
 
-type GetSwamp struct {
+func (x *Uint32SliceGetRangeResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Uint32SliceStreamRequest streams all values of a uint32 slice.
+type Uint32SliceStreamRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp to read from.
+	// SwampName is the name of the swamp containing the slice.
 	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Keys is the list of treasure keys to retrieve from this swamp.
-	Keys          []string `protobuf:"bytes,3,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// Key is the treasure containing the slice.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// BatchSize is the max number of the values in one message. 0 means 10000.
+	BatchSize     int32 `protobuf:"varint,4,opt,name=BatchSize,proto3" json:"BatchSize,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSwamp) Reset() {
-	*x = GetSwamp{}
-	mi := &file_hydraide_proto_msgTypes[25]
+func (x *Uint32SliceStreamRequest) Reset() {
+	*x = Uint32SliceStreamRequest{}
+	mi := &file_hydraide_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSwamp) String() string {
+func (x *Uint32SliceStreamRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSwamp) ProtoMessage() {}
+func (*Uint32SliceStreamRequest) ProtoMessage() {}
 
-func (x *GetSwamp) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[25]
+func (x *Uint32SliceStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2009,55 +9546,63 @@ func (x *GetSwamp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSwamp.ProtoReflect.Descriptor instead.
-func (*GetSwamp) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use Uint32SliceStreamRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceStreamRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{130}
 }
 
-func (x *GetSwamp) GetIslandID() uint64 {
+func (x *Uint32SliceStreamRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *GetSwamp) GetSwampName() string {
+func (x *Uint32SliceStreamRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *GetSwamp) GetKeys() []string {
+func (x *Uint32SliceStreamRequest) GetKey() string {
 	if x != nil {
-		return x.Keys
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-type GetResponse struct {
+func (x *Uint32SliceStreamRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// Uint32SliceStreamResponse is the next batch of the values of a uint32 slice.
+type Uint32SliceStreamResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Swamps contains the result of each swamp that was queried.
-	Swamps        []*GetSwampResponse `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// Values are the next values of the slice, in the order they were pushed.
+	Values        []uint32 `protobuf:"varint,1,rep,packed,name=Values,proto3" json:"Values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResponse) Reset() {
-	*x = GetResponse{}
-	mi := &file_hydraide_proto_msgTypes[26]
+func (x *Uint32SliceStreamResponse) Reset() {
+	*x = Uint32SliceStreamResponse{}
+	mi := &file_hydraide_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResponse) String() string {
+func (x *Uint32SliceStreamResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponse) ProtoMessage() {}
+func (*Uint32SliceStreamResponse) ProtoMessage() {}
 
-func (x *GetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[26]
+func (x *Uint32SliceStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2068,48 +9613,87 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
-func (*GetResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use Uint32SliceStreamResponse.ProtoReflect.Descriptor instead.
+func (*Uint32SliceStreamResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{131}
 }
 
-func (x *GetResponse) GetSwamps() []*GetSwampResponse {
+func (x *Uint32SliceStreamResponse) GetValues() []uint32 {
 	if x != nil {
-		return x.Swamps
+		return x.Values
 	}
 	return nil
 }
 
-type GetSwampResponse struct {
+type Uint32SliceCombination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32SliceCombination) Reset() {
+	*x = Uint32SliceCombination{}
+	mi := &file_hydraide_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32SliceCombination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32SliceCombination) ProtoMessage() {}
+
+func (x *Uint32SliceCombination) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32SliceCombination.ProtoReflect.Descriptor instead.
+func (*Uint32SliceCombination) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{132}
+}
+
+// Uint32SliceCombineRequest combines the uint32 slices of many keys, and streams the result.
+type Uint32SliceCombineRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// SwampName is the swamp from which the treasures were returned.
-	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// IsExist tells whether the swamp actually exists.
-	//
-	// If false, no data will be returned for this swamp.
-	IsExist bool `protobuf:"varint,2,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
-	// Treasures contains the values of all successfully retrieved keys.
-	// If a key did not exist, it will be omitted from the list.
-	Treasures     []*Treasure `protobuf:"bytes,3,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp containing the slices.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Operator is the set operation.
+	Operator Uint32SliceCombination_Operator `protobuf:"varint,3,opt,name=Operator,proto3,enum=hydraidepbgo.Uint32SliceCombination_Operator" json:"Operator,omitempty"`
+	// Keys are the treasures of the combined slices, at least one. The order matters for the INTERSECTION and the
+	// DIFFERENCE: the result keeps the order of the first key.
+	Keys []string `protobuf:"bytes,4,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// BatchSize is the max number of the values in one message. 0 means 10000.
+	BatchSize     int32 `protobuf:"varint,5,opt,name=BatchSize,proto3" json:"BatchSize,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSwampResponse) Reset() {
-	*x = GetSwampResponse{}
-	mi := &file_hydraide_proto_msgTypes[27]
+func (x *Uint32SliceCombineRequest) Reset() {
+	*x = Uint32SliceCombineRequest{}
+	mi := &file_hydraide_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSwampResponse) String() string {
+func (x *Uint32SliceCombineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSwampResponse) ProtoMessage() {}
+func (*Uint32SliceCombineRequest) ProtoMessage() {}
 
-func (x *GetSwampResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[27]
+func (x *Uint32SliceCombineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2120,62 +9704,79 @@ func (x *GetSwampResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSwampResponse.ProtoReflect.Descriptor instead.
-func (*GetSwampResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use Uint32SliceCombineRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceCombineRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{133}
 }
 
-func (x *GetSwampResponse) GetSwampName() string {
+func (x *Uint32SliceCombineRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *Uint32SliceCombineRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *GetSwampResponse) GetIsExist() bool {
+func (x *Uint32SliceCombineRequest) GetOperator() Uint32SliceCombination_Operator {
 	if x != nil {
-		return x.IsExist
+		return x.Operator
 	}
-	return false
+	return Uint32SliceCombination_UNION
 }
 
-func (x *GetSwampResponse) GetTreasures() []*Treasure {
+func (x *Uint32SliceCombineRequest) GetKeys() []string {
 	if x != nil {
-		return x.Treasures
+		return x.Keys
 	}
 	return nil
 }
 
-type GetAllRequest struct {
+func (x *Uint32SliceCombineRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// Uint32SliceCombineIntoRequest combines the uint32 slices of many keys, and stores the result in a key.
+type Uint32SliceCombineIntoRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp you want to retrieve all treasures from.
-	//
-	// HydrAIDE will return every key-value pair stored in this swamp, regardless of expiration or status.
-	//
-	// ⚠️ Caution: For large swamps, this can return a lot of data.
-	// Consider using pagination or GetByIndex instead for better control.
-	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetAllRequest) Reset() {
-	*x = GetAllRequest{}
-	mi := &file_hydraide_proto_msgTypes[28]
+	// SwampName is the name of the swamp containing the slices.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Operator is the set operation.
+	Operator Uint32SliceCombination_Operator `protobuf:"varint,3,opt,name=Operator,proto3,enum=hydraidepbgo.Uint32SliceCombination_Operator" json:"Operator,omitempty"`
+	// Keys are the treasures of the combined slices, at least one. The order matters for the INTERSECTION and the
+	// DIFFERENCE: the result keeps the order of the first key.
+	Keys []string `protobuf:"bytes,4,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// DestinationKey is the treasure of the result. It is replaced by the result, or deleted if the result is empty.
+	DestinationKey string `protobuf:"bytes,5,opt,name=DestinationKey,proto3" json:"DestinationKey,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Uint32SliceCombineIntoRequest) Reset() {
+	*x = Uint32SliceCombineIntoRequest{}
+	mi := &file_hydraide_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllRequest) String() string {
+func (x *Uint32SliceCombineIntoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllRequest) ProtoMessage() {}
+func (*Uint32SliceCombineIntoRequest) ProtoMessage() {}
 
-func (x *GetAllRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[28]
+func (x *Uint32SliceCombineIntoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2186,56 +9787,70 @@ func (x *GetAllRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllRequest.ProtoReflect.Descriptor instead.
-func (*GetAllRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use Uint32SliceCombineIntoRequest.ProtoReflect.Descriptor instead.
+func (*Uint32SliceCombineIntoRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{134}
 }
 
-func (x *GetAllRequest) GetIslandID() uint64 {
+func (x *Uint32SliceCombineIntoRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *GetAllRequest) GetSwampName() string {
+func (x *Uint32SliceCombineIntoRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-type GetAllResponse struct {
+func (x *Uint32SliceCombineIntoRequest) GetOperator() Uint32SliceCombination_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return Uint32SliceCombination_UNION
+}
+
+func (x *Uint32SliceCombineIntoRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *Uint32SliceCombineIntoRequest) GetDestinationKey() string {
+	if x != nil {
+		return x.DestinationKey
+	}
+	return ""
+}
+
+// Uint32SliceCombineIntoResponse is returned after the result is stored.
+type Uint32SliceCombineIntoResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Treasures contains all key-value pairs from the requested swamp.
-	//
-	// Each Treasure includes its key, type-specific value, metadata (if any),
-	// and its current existence status.
-	//
-	// 💡 Useful for:
-	// - Initial snapshot generation
-	// - Manual data inspection
-	// - Rebuilding in-memory views or local caches
-	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	// Size is the number of the values of the result.
+	Size          int64 `protobuf:"varint,1,opt,name=Size,proto3" json:"Size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetAllResponse) Reset() {
-	*x = GetAllResponse{}
-	mi := &file_hydraide_proto_msgTypes[29]
+}
+
+func (x *Uint32SliceCombineIntoResponse) Reset() {
+	*x = Uint32SliceCombineIntoResponse{}
+	mi := &file_hydraide_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllResponse) String() string {
+func (x *Uint32SliceCombineIntoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllResponse) ProtoMessage() {}
+func (*Uint32SliceCombineIntoResponse) ProtoMessage() {}
 
-func (x *GetAllResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[29]
+func (x *Uint32SliceCombineIntoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2246,50 +9861,44 @@ func (x *GetAllResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllResponse.ProtoReflect.Descriptor instead.
-func (*GetAllResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use Uint32SliceCombineIntoResponse.ProtoReflect.Descriptor instead.
+func (*Uint32SliceCombineIntoResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{135}
 }
 
-func (x *GetAllResponse) GetTreasures() []*Treasure {
+func (x *Uint32SliceCombineIntoResponse) GetSize() int64 {
 	if x != nil {
-		return x.Treasures
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
-type ShiftExpiredTreasuresRequest struct {
+// IsSwampExistRequest checks whether a specific swamp exists in the current sanctuary.
+type IsSwampExistRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp you want to shift expired treasures from.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// HowMany defines how many expired treasures should be returned and deleted.
-	//
-	// - If set to 0 → HydrAIDE will return **all** expired entries from the swamp.
-	// - If greater than 0 → only the specified number of expired treasures will be returned.
-	//
-	// ⚠️ Note: This operation is destructive – the shifted treasures will be removed immediately.
-	HowMany       int32 `protobuf:"varint,3,opt,name=HowMany,proto3" json:"HowMany,omitempty"`
+	// SwampName is the name of the swamp to check.
+	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShiftExpiredTreasuresRequest) Reset() {
-	*x = ShiftExpiredTreasuresRequest{}
-	mi := &file_hydraide_proto_msgTypes[30]
+func (x *IsSwampExistRequest) Reset() {
+	*x = IsSwampExistRequest{}
+	mi := &file_hydraide_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShiftExpiredTreasuresRequest) String() string {
+func (x *IsSwampExistRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShiftExpiredTreasuresRequest) ProtoMessage() {}
+func (*IsSwampExistRequest) ProtoMessage() {}
 
-func (x *ShiftExpiredTreasuresRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[30]
+func (x *IsSwampExistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2300,59 +9909,49 @@ func (x *ShiftExpiredTreasuresRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShiftExpiredTreasuresRequest.ProtoReflect.Descriptor instead.
-func (*ShiftExpiredTreasuresRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use IsSwampExistRequest.ProtoReflect.Descriptor instead.
+func (*IsSwampExistRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{136}
 }
 
-func (x *ShiftExpiredTreasuresRequest) GetIslandID() uint64 {
+func (x *IsSwampExistRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *ShiftExpiredTreasuresRequest) GetSwampName() string {
+func (x *IsSwampExistRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *ShiftExpiredTreasuresRequest) GetHowMany() int32 {
-	if x != nil {
-		return x.HowMany
-	}
-	return 0
-}
-
-type ShiftExpiredTreasuresResponse struct {
+// IsSwampExistResponse returns the existence status of the swamp.
+type IsSwampExistResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Treasures contains the expired entries that were retrieved and deleted from the swamp.
-	//
-	// Each treasure includes its key, value, and metadata at the time of expiration.
-	//
-	// 🔔 Realtime: These deletions will also trigger `SubscribeToEvents` notifications.
-	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	// IsExist will be true if the swamp exists, false otherwise.
+	IsExist       bool `protobuf:"varint,1,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShiftExpiredTreasuresResponse) Reset() {
-	*x = ShiftExpiredTreasuresResponse{}
-	mi := &file_hydraide_proto_msgTypes[31]
+func (x *IsSwampExistResponse) Reset() {
+	*x = IsSwampExistResponse{}
+	mi := &file_hydraide_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShiftExpiredTreasuresResponse) String() string {
+func (x *IsSwampExistResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShiftExpiredTreasuresResponse) ProtoMessage() {}
+func (*IsSwampExistResponse) ProtoMessage() {}
 
-func (x *ShiftExpiredTreasuresResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[31]
+func (x *IsSwampExistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2363,94 +9962,42 @@ func (x *ShiftExpiredTreasuresResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShiftExpiredTreasuresResponse.ProtoReflect.Descriptor instead.
-func (*ShiftExpiredTreasuresResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use IsSwampExistResponse.ProtoReflect.Descriptor instead.
+func (*IsSwampExistResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{137}
 }
 
-func (x *ShiftExpiredTreasuresResponse) GetTreasures() []*Treasure {
+func (x *IsSwampExistResponse) GetIsExist() bool {
 	if x != nil {
-		return x.Treasures
+		return x.IsExist
 	}
-	return nil
+	return false
 }
 
-type Treasure struct {
+// ExistsManyRequest checks the existence of many swamps or wildcard patterns at once.
+type ExistsManyRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Key is the unique identifier of the treasure within the swamp.
-	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IsExist indicates whether the treasure was actually found in the swamp.
-	//
-	// If false:
-	// - The treasure was requested, but does not exist.
-	// - All value and metadata fields will be unset.
-	IsExist    bool          `protobuf:"varint,2,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
-	Int8Val    *int32        `protobuf:"varint,3,opt,name=Int8Val,proto3,oneof" json:"Int8Val,omitempty"`
-	Int16Val   *int32        `protobuf:"varint,4,opt,name=Int16Val,proto3,oneof" json:"Int16Val,omitempty"`
-	Int32Val   *int32        `protobuf:"varint,5,opt,name=Int32Val,proto3,oneof" json:"Int32Val,omitempty"`
-	Int64Val   *int64        `protobuf:"varint,6,opt,name=Int64Val,proto3,oneof" json:"Int64Val,omitempty"`
-	Uint8Val   *uint32       `protobuf:"varint,7,opt,name=Uint8Val,proto3,oneof" json:"Uint8Val,omitempty"`
-	Uint16Val  *uint32       `protobuf:"varint,8,opt,name=Uint16Val,proto3,oneof" json:"Uint16Val,omitempty"`
-	Uint32Val  *uint32       `protobuf:"varint,9,opt,name=Uint32Val,proto3,oneof" json:"Uint32Val,omitempty"`
-	Uint64Val  *uint64       `protobuf:"varint,10,opt,name=Uint64Val,proto3,oneof" json:"Uint64Val,omitempty"`
-	Float32Val *float32      `protobuf:"fixed32,11,opt,name=Float32Val,proto3,oneof" json:"Float32Val,omitempty"`
-	Float64Val *float64      `protobuf:"fixed64,12,opt,name=Float64Val,proto3,oneof" json:"Float64Val,omitempty"`
-	StringVal  *string       `protobuf:"bytes,13,opt,name=StringVal,proto3,oneof" json:"StringVal,omitempty"`
-	BoolVal    *Boolean_Type `protobuf:"varint,14,opt,name=BoolVal,proto3,enum=hydraidepbgo.Boolean_Type,oneof" json:"BoolVal,omitempty"`
-	BytesVal   []byte        `protobuf:"bytes,15,opt,name=BytesVal,proto3,oneof" json:"BytesVal,omitempty"`
-	// Uint32Slice is a special, automatically deduplicated list of uint32 values.
-	//
-	// Ideal for storing IDs, reverse indexes, or permission sets.
-	Uint32Slice []uint32 `protobuf:"varint,16,rep,packed,name=Uint32Slice,proto3" json:"Uint32Slice,omitempty"`
-	// CreatedAt is the timestamp when the treasure was first created.
-	//
-	// ⚠️ HydrAIDE does not generate this automatically.
-	// This field will only be present if you explicitly set it during the Set operation.
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=CreatedAt,proto3,oneof" json:"CreatedAt,omitempty"`
-	// CreatedBy stores the identifier (user/system) that created the treasure.
-	//
-	// ⚠️ Optional. Only stored if provided at write time.
-	CreatedBy *string `protobuf:"bytes,18,opt,name=CreatedBy,proto3,oneof" json:"CreatedBy,omitempty"`
-	// UpdatedAt is the last modification timestamp of the treasure.
-	//
-	// ⚠️ Optional. HydrAIDE won't auto-populate this — you must set it manually if needed.
-	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=UpdatedAt,proto3,oneof" json:"UpdatedAt,omitempty"`
-	// UpdatedBy stores the identifier of who last modified the treasure.
-	//
-	// ⚠️ Optional. Set it explicitly in the Set request if tracking is desired.
-	UpdatedBy *string `protobuf:"bytes,20,opt,name=UpdatedBy,proto3,oneof" json:"UpdatedBy,omitempty"`
-	// ExpiredAt defines the expiration time for the treasure.
-	//
-	// After this time, the treasure is considered expired and can be retrieved or removed using:
-	// - `GetByIndex` with `EXPIRATION_TIME`
-	// - `ShiftExpiredTreasures`
-	//
-	// ⚠️ Important: HydrAIDE does NOT auto-generate this value.
-	// If you want a treasure to expire, you must set ExpiredAt yourself during the `Set` operation.
-	//
-	// If ExpiredAt is not set:
-	// - The treasure is considered to never expire
-	// - It will not appear in expiration-based queries
-	ExpiredAt     *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=ExpiredAt,proto3,oneof" json:"ExpiredAt,omitempty"`
+	// Swamps is the list of exact swamp names or wildcard patterns to check.
+	Swamps        []*ExistsManySwamp `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Treasure) Reset() {
-	*x = Treasure{}
-	mi := &file_hydraide_proto_msgTypes[32]
+func (x *ExistsManyRequest) Reset() {
+	*x = ExistsManyRequest{}
+	mi := &file_hydraide_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Treasure) String() string {
+func (x *ExistsManyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Treasure) ProtoMessage() {}
+func (*ExistsManyRequest) ProtoMessage() {}
 
-func (x *Treasure) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[32]
+func (x *ExistsManyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2461,179 +10008,147 @@ func (x *Treasure) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Treasure.ProtoReflect.Descriptor instead.
-func (*Treasure) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *Treasure) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
+// Deprecated: Use ExistsManyRequest.ProtoReflect.Descriptor instead.
+func (*ExistsManyRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{138}
 }
 
-func (x *Treasure) GetIsExist() bool {
+func (x *ExistsManyRequest) GetSwamps() []*ExistsManySwamp {
 	if x != nil {
-		return x.IsExist
-	}
-	return false
-}
-
-func (x *Treasure) GetInt8Val() int32 {
-	if x != nil && x.Int8Val != nil {
-		return *x.Int8Val
-	}
-	return 0
-}
-
-func (x *Treasure) GetInt16Val() int32 {
-	if x != nil && x.Int16Val != nil {
-		return *x.Int16Val
-	}
-	return 0
-}
-
-func (x *Treasure) GetInt32Val() int32 {
-	if x != nil && x.Int32Val != nil {
-		return *x.Int32Val
-	}
-	return 0
-}
-
-func (x *Treasure) GetInt64Val() int64 {
-	if x != nil && x.Int64Val != nil {
-		return *x.Int64Val
-	}
-	return 0
-}
-
-func (x *Treasure) GetUint8Val() uint32 {
-	if x != nil && x.Uint8Val != nil {
-		return *x.Uint8Val
-	}
-	return 0
-}
-
-func (x *Treasure) GetUint16Val() uint32 {
-	if x != nil && x.Uint16Val != nil {
-		return *x.Uint16Val
+		return x.Swamps
 	}
-	return 0
+	return nil
 }
 
-func (x *Treasure) GetUint32Val() uint32 {
-	if x != nil && x.Uint32Val != nil {
-		return *x.Uint32Val
-	}
-	return 0
+// ExistsManySwamp identifies one exact swamp name or wildcard pattern in an ExistsManyRequest.
+type ExistsManySwamp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	// Ignored for wildcard patterns, because the matching swamps can live on any island of the server.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the exact name or the wildcard pattern of the swamp.
+	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Treasure) GetUint64Val() uint64 {
-	if x != nil && x.Uint64Val != nil {
-		return *x.Uint64Val
-	}
-	return 0
+func (x *ExistsManySwamp) Reset() {
+	*x = ExistsManySwamp{}
+	mi := &file_hydraide_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Treasure) GetFloat32Val() float32 {
-	if x != nil && x.Float32Val != nil {
-		return *x.Float32Val
-	}
-	return 0
+func (x *ExistsManySwamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Treasure) GetFloat64Val() float64 {
-	if x != nil && x.Float64Val != nil {
-		return *x.Float64Val
-	}
-	return 0
-}
+func (*ExistsManySwamp) ProtoMessage() {}
 
-func (x *Treasure) GetStringVal() string {
-	if x != nil && x.StringVal != nil {
-		return *x.StringVal
+func (x *ExistsManySwamp) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
-
-func (x *Treasure) GetBoolVal() Boolean_Type {
-	if x != nil && x.BoolVal != nil {
-		return *x.BoolVal
-	}
-	return Boolean_TRUE
+
+// Deprecated: Use ExistsManySwamp.ProtoReflect.Descriptor instead.
+func (*ExistsManySwamp) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{139}
 }
 
-func (x *Treasure) GetBytesVal() []byte {
+func (x *ExistsManySwamp) GetIslandID() uint64 {
 	if x != nil {
-		return x.BytesVal
+		return x.IslandID
 	}
-	return nil
+	return 0
 }
 
-func (x *Treasure) GetUint32Slice() []uint32 {
+func (x *ExistsManySwamp) GetSwampName() string {
 	if x != nil {
-		return x.Uint32Slice
+		return x.SwampName
 	}
-	return nil
+	return ""
 }
 
-func (x *Treasure) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+// ExistsManyResponse returns the existing swamps for every requested name or pattern.
+type ExistsManyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Results contains one entry per requested swamp name or pattern, in the order of the request.
+	Results       []*ExistsManyResult `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Treasure) GetCreatedBy() string {
-	if x != nil && x.CreatedBy != nil {
-		return *x.CreatedBy
-	}
-	return ""
+func (x *ExistsManyResponse) Reset() {
+	*x = ExistsManyResponse{}
+	mi := &file_hydraide_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Treasure) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *ExistsManyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsManyResponse) ProtoMessage() {}
+
+func (x *ExistsManyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[140]
 	if x != nil {
-		return x.UpdatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Treasure) GetUpdatedBy() string {
-	if x != nil && x.UpdatedBy != nil {
-		return *x.UpdatedBy
-	}
-	return ""
+// Deprecated: Use ExistsManyResponse.ProtoReflect.Descriptor instead.
+func (*ExistsManyResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{140}
 }
 
-func (x *Treasure) GetExpiredAt() *timestamppb.Timestamp {
+func (x *ExistsManyResponse) GetResults() []*ExistsManyResult {
 	if x != nil {
-		return x.ExpiredAt
+		return x.Results
 	}
 	return nil
 }
 
-type Boolean struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ExistsManyResult is the result of one requested swamp name or pattern.
+type ExistsManyResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SwampName is the requested exact name or wildcard pattern.
+	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// ExistingSwamps contains the names of the existing swamps matching the request.
+	// Empty if no swamp exists.
+	ExistingSwamps []string `protobuf:"bytes,2,rep,name=ExistingSwamps,proto3" json:"ExistingSwamps,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Boolean) Reset() {
-	*x = Boolean{}
-	mi := &file_hydraide_proto_msgTypes[33]
+func (x *ExistsManyResult) Reset() {
+	*x = ExistsManyResult{}
+	mi := &file_hydraide_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Boolean) String() string {
+func (x *ExistsManyResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Boolean) ProtoMessage() {}
+func (*ExistsManyResult) ProtoMessage() {}
 
-func (x *Boolean) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[33]
+func (x *ExistsManyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2644,60 +10159,53 @@ func (x *Boolean) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Boolean.ProtoReflect.Descriptor instead.
-func (*Boolean) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ExistsManyResult.ProtoReflect.Descriptor instead.
+func (*ExistsManyResult) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{141}
 }
 
-type GetByIndexRequest struct {
+func (x *ExistsManyResult) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *ExistsManyResult) GetExistingSwamps() []string {
+	if x != nil {
+		return x.ExistingSwamps
+	}
+	return nil
+}
+
+// IsKeyExistRequest checks whether a specific key exists within a given swamp.
+type IsKeyExistRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp to query.
-	//
-	// The index will be built in memory for this swamp at query time.
+	// SwampName is the name of the swamp where the key is expected.
 	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// IndexType specifies which field to index and query on.
-	//
-	// Supported indexes:
-	// - KEY → Sort alphabetically by key
-	// - EXPIRATION_TIME → Query based on ExpiredAt timestamps
-	// - CREATION_TIME → Query based on CreatedAt timestamps
-	// - UPDATE_TIME → Query based on UpdatedAt timestamps
-	// - VALUE_* → Query by the actual value (e.g., int, string, float)
-	IndexType IndexType_Type `protobuf:"varint,3,opt,name=IndexType,proto3,enum=hydraidepbgo.IndexType_Type" json:"IndexType,omitempty"`
-	// OrderType defines the sort direction:
-	// - ASC → Oldest/smallest first
-	// - DESC → Newest/largest first
-	OrderType OrderType_Type `protobuf:"varint,4,opt,name=OrderType,proto3,enum=hydraidepbgo.OrderType_Type" json:"OrderType,omitempty"`
-	// From defines the starting index (zero-based).
-	//
-	// Use it for pagination or skipping elements.
-	// Set to 0 to start from the beginning.
-	From int32 `protobuf:"varint,5,opt,name=From,proto3" json:"From,omitempty"`
-	// Limit defines how many items to return.
-	//
-	// Set to 0 to return all results (⚠️ caution on large datasets).
-	Limit         int32 `protobuf:"varint,6,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	// Key is the identifier of the treasure to check for existence.
+	Key           string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetByIndexRequest) Reset() {
-	*x = GetByIndexRequest{}
-	mi := &file_hydraide_proto_msgTypes[34]
+func (x *IsKeyExistRequest) Reset() {
+	*x = IsKeyExistRequest{}
+	mi := &file_hydraide_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetByIndexRequest) String() string {
+func (x *IsKeyExistRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetByIndexRequest) ProtoMessage() {}
+func (*IsKeyExistRequest) ProtoMessage() {}
 
-func (x *GetByIndexRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[34]
+func (x *IsKeyExistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2708,74 +10216,106 @@ func (x *GetByIndexRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetByIndexRequest.ProtoReflect.Descriptor instead.
-func (*GetByIndexRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use IsKeyExistRequest.ProtoReflect.Descriptor instead.
+func (*IsKeyExistRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{142}
 }
 
-func (x *GetByIndexRequest) GetIslandID() uint64 {
+func (x *IsKeyExistRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *GetByIndexRequest) GetSwampName() string {
+func (x *IsKeyExistRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *GetByIndexRequest) GetIndexType() IndexType_Type {
+func (x *IsKeyExistRequest) GetKey() string {
 	if x != nil {
-		return x.IndexType
+		return x.Key
 	}
-	return IndexType_KEY
+	return ""
 }
 
-func (x *GetByIndexRequest) GetOrderType() OrderType_Type {
-	if x != nil {
-		return x.OrderType
-	}
-	return OrderType_ASC
+// IsKeyExistResponse returns the existence status of the given key in the swamp.
+type IsKeyExistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IsExist will be true if the key is present in the swamp, false otherwise.
+	IsExist       bool `protobuf:"varint,1,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetByIndexRequest) GetFrom() int32 {
+func (x *IsKeyExistResponse) Reset() {
+	*x = IsKeyExistResponse{}
+	mi := &file_hydraide_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsKeyExistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsKeyExistResponse) ProtoMessage() {}
+
+func (x *IsKeyExistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[143]
 	if x != nil {
-		return x.From
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *GetByIndexRequest) GetLimit() int32 {
+// Deprecated: Use IsKeyExistResponse.ProtoReflect.Descriptor instead.
+func (*IsKeyExistResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *IsKeyExistResponse) GetIsExist() bool {
 	if x != nil {
-		return x.Limit
+		return x.IsExist
 	}
-	return 0
+	return false
 }
 
-type IndexType struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// IsKeysExistRequest checks whether the given keys exist within a given swamp.
+type IsKeysExistRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp where the keys are expected.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Keys are the identifiers of the treasures to check for existence.
+	Keys          []string `protobuf:"bytes,3,rep,name=Keys,proto3" json:"Keys,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IndexType) Reset() {
-	*x = IndexType{}
-	mi := &file_hydraide_proto_msgTypes[35]
+func (x *IsKeysExistRequest) Reset() {
+	*x = IsKeysExistRequest{}
+	mi := &file_hydraide_proto_msgTypes[144]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IndexType) String() string {
+func (x *IsKeysExistRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexType) ProtoMessage() {}
+func (*IsKeysExistRequest) ProtoMessage() {}
 
-func (x *IndexType) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[35]
+func (x *IsKeysExistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[144]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2786,32 +10326,57 @@ func (x *IndexType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexType.ProtoReflect.Descriptor instead.
-func (*IndexType) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use IsKeysExistRequest.ProtoReflect.Descriptor instead.
+func (*IsKeysExistRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{144}
 }
 
-type OrderType struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *IsKeysExistRequest) GetIslandID() uint64 {
+	if x != nil {
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *IsKeysExistRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *IsKeysExistRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// IsKeysExistResponse returns the existence status of the requested keys.
+type IsKeysExistResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IsExist contains one element for every requested key, in the order of the request.
+	// The element is true if the key is present in the swamp, false otherwise.
+	IsExist       []bool `protobuf:"varint,1,rep,packed,name=IsExist,proto3" json:"IsExist,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderType) Reset() {
-	*x = OrderType{}
-	mi := &file_hydraide_proto_msgTypes[36]
+func (x *IsKeysExistResponse) Reset() {
+	*x = IsKeysExistResponse{}
+	mi := &file_hydraide_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderType) String() string {
+func (x *IsKeysExistResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderType) ProtoMessage() {}
+func (*IsKeysExistResponse) ProtoMessage() {}
 
-func (x *OrderType) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[36]
+func (x *IsKeysExistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2822,35 +10387,44 @@ func (x *OrderType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderType.ProtoReflect.Descriptor instead.
-func (*OrderType) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use IsKeysExistResponse.ProtoReflect.Descriptor instead.
+func (*IsKeysExistResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{145}
 }
 
-type GetByIndexResponse struct {
+func (x *IsKeysExistResponse) GetIsExist() []bool {
+	if x != nil {
+		return x.IsExist
+	}
+	return nil
+}
+
+// ListDeletedRequest lists the shadow-deleted treasures of a swamp.
+type ListDeletedRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Treasures contains the list of key-value pairs sorted and filtered
-	// according to the request's index and parameters.
-	Treasures     []*Treasure `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp.
+	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetByIndexResponse) Reset() {
-	*x = GetByIndexResponse{}
-	mi := &file_hydraide_proto_msgTypes[37]
+func (x *ListDeletedRequest) Reset() {
+	*x = ListDeletedRequest{}
+	mi := &file_hydraide_proto_msgTypes[146]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetByIndexResponse) String() string {
+func (x *ListDeletedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetByIndexResponse) ProtoMessage() {}
+func (*ListDeletedRequest) ProtoMessage() {}
 
-func (x *GetByIndexResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[37]
+func (x *ListDeletedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[146]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2861,43 +10435,48 @@ func (x *GetByIndexResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetByIndexResponse.ProtoReflect.Descriptor instead.
-func (*GetByIndexResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use ListDeletedRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{146}
 }
 
-func (x *GetByIndexResponse) GetTreasures() []*Treasure {
+func (x *ListDeletedRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.Treasures
+		return x.IslandID
 	}
-	return nil
+	return 0
+}
+
+func (x *ListDeletedRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
 }
 
-type DeleteRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Swamps contains one or more swamp/key combinations for deletion.
-	//
-	// You can batch-delete multiple keys from multiple swamps in a single request.
-	Swamps        []*DeleteRequest_SwampKeys `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+// ListDeletedResponse contains the shadow-deleted treasures with their DeletedAt fields.
+type ListDeletedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Treasures     []*Treasure            `protobuf:"bytes,1,rep,name=Treasures,proto3" json:"Treasures,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
-	mi := &file_hydraide_proto_msgTypes[38]
+func (x *ListDeletedResponse) Reset() {
+	*x = ListDeletedResponse{}
+	mi := &file_hydraide_proto_msgTypes[147]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRequest) String() string {
+func (x *ListDeletedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*ListDeletedResponse) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[38]
+func (x *ListDeletedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[147]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2908,41 +10487,46 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use ListDeletedResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{147}
 }
 
-func (x *DeleteRequest) GetSwamps() []*DeleteRequest_SwampKeys {
+func (x *ListDeletedResponse) GetTreasures() []*Treasure {
 	if x != nil {
-		return x.Swamps
+		return x.Treasures
 	}
 	return nil
 }
 
-type DeleteResponse struct {
+// RestoreRequest restores shadow-deleted treasures of a swamp.
+type RestoreRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Responses holds the result of the delete operations, swamp by swamp.
-	Responses     []*DeleteResponse_SwampDeleteResponse `protobuf:"bytes,1,rep,name=Responses,proto3" json:"Responses,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Keys are the keys of the shadow-deleted treasures to restore.
+	Keys          []string `protobuf:"bytes,3,rep,name=Keys,proto3" json:"Keys,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
-	mi := &file_hydraide_proto_msgTypes[39]
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	mi := &file_hydraide_proto_msgTypes[148]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteResponse) String() string {
+func (x *RestoreRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*RestoreRequest) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[39]
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[148]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2953,43 +10537,56 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{148}
 }
 
-func (x *DeleteResponse) GetResponses() []*DeleteResponse_SwampDeleteResponse {
+func (x *RestoreRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.Responses
+		return x.IslandID
+	}
+	return 0
+}
+
+func (x *RestoreRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *RestoreRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
 	}
 	return nil
 }
 
-type CountRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// SwampNames is the list of swamps you want to inspect.
-	//
-	// You can count multiple swamps at once.
-	Swamps        []*CountRequest_SwampIdentifier `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+// RestoreResponse contains the result of the restore for every requested key, in the order of the request.
+// The status is NEW if the treasure is restored, and NOT_FOUND if there was no shadow-deleted treasure with the key.
+type RestoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyStatuses   []*KeyStatusPair       `protobuf:"bytes,1,rep,name=KeyStatuses,proto3" json:"KeyStatuses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CountRequest) Reset() {
-	*x = CountRequest{}
-	mi := &file_hydraide_proto_msgTypes[40]
+func (x *RestoreResponse) Reset() {
+	*x = RestoreResponse{}
+	mi := &file_hydraide_proto_msgTypes[149]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CountRequest) String() string {
+func (x *RestoreResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountRequest) ProtoMessage() {}
+func (*RestoreResponse) ProtoMessage() {}
 
-func (x *CountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[40]
+func (x *RestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[149]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3000,41 +10597,46 @@ func (x *CountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
-func (*CountRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use RestoreResponse.ProtoReflect.Descriptor instead.
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{149}
 }
 
-func (x *CountRequest) GetSwamps() []*CountRequest_SwampIdentifier {
+func (x *RestoreResponse) GetKeyStatuses() []*KeyStatusPair {
 	if x != nil {
-		return x.Swamps
+		return x.KeyStatuses
 	}
 	return nil
 }
 
-type CountResponse struct {
+// GetHistoryRequest asks for the stored versions of a treasure.
+type GetHistoryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Swamps contains the count result for each swamp queried.
-	Swamps        []*CountSwamp `protobuf:"bytes,1,rep,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the key of the treasure.
+	Key           string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CountResponse) Reset() {
-	*x = CountResponse{}
-	mi := &file_hydraide_proto_msgTypes[41]
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_hydraide_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CountResponse) String() string {
+func (x *GetHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountResponse) ProtoMessage() {}
+func (*GetHistoryRequest) ProtoMessage() {}
 
-func (x *CountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[41]
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3045,45 +10647,56 @@ func (x *CountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
-func (*CountResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{150}
 }
 
-func (x *CountResponse) GetSwamps() []*CountSwamp {
+func (x *GetHistoryRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.Swamps
+		return x.IslandID
 	}
-	return nil
+	return 0
 }
 
-type CountSwamp struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// SwampName is the name of the swamp counted.
-	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// IsExist tells whether the swamp exists in the system.
-	IsExist bool `protobuf:"varint,2,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
-	// Count is the number of treasures currently stored in this swamp.
-	Count         int32 `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`
+func (x *GetHistoryRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// GetHistoryResponse contains the versions of the treasure, oldest first.
+// Every version has the Version, UpdatedAt and UpdatedBy fields set.
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*Treasure            `protobuf:"bytes,1,rep,name=Versions,proto3" json:"Versions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CountSwamp) Reset() {
-	*x = CountSwamp{}
-	mi := &file_hydraide_proto_msgTypes[42]
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_hydraide_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CountSwamp) String() string {
+func (x *GetHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountSwamp) ProtoMessage() {}
+func (*GetHistoryResponse) ProtoMessage() {}
 
-func (x *CountSwamp) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[42]
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3094,68 +10707,50 @@ func (x *CountSwamp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountSwamp.ProtoReflect.Descriptor instead.
-func (*CountSwamp) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{42}
-}
-
-func (x *CountSwamp) GetSwampName() string {
-	if x != nil {
-		return x.SwampName
-	}
-	return ""
-}
-
-func (x *CountSwamp) GetIsExist() bool {
-	if x != nil {
-		return x.IsExist
-	}
-	return false
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{151}
 }
 
-func (x *CountSwamp) GetCount() int32 {
+func (x *GetHistoryResponse) GetVersions() []*Treasure {
 	if x != nil {
-		return x.Count
+		return x.Versions
 	}
-	return 0
+	return nil
 }
 
-type IncrementInt8Request struct {
+// RevertToRequest sets the content of a treasure back to a version of its history.
+type RevertToRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the value resides.
+	// SwampName is the name of the swamp.
 	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the identifier of the treasure to increment.
+	// Key is the key of the treasure.
 	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the signed integer to add (or subtract) from the current value.
-	//
-	// ⚠️ Although this is declared as int32, only int8-range values are stored.
-	// HydrAIDE internally converts and enforces the correct range.
-	//
-	// 💡 Supports negative values for decrementing.
-	IncrementBy int32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition determines whether the increment should proceed.
-	Condition     *IncrementInt8Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	// Version is the number of the version to revert to, as returned by GetHistory.
+	Version uint64 `protobuf:"varint,4,opt,name=Version,proto3" json:"Version,omitempty"`
+	// UpdatedBy is the modifier of the treasure stored with the revert. Optional.
+	UpdatedBy     *string `protobuf:"bytes,5,opt,name=UpdatedBy,proto3,oneof" json:"UpdatedBy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt8Request) Reset() {
-	*x = IncrementInt8Request{}
-	mi := &file_hydraide_proto_msgTypes[43]
+func (x *RevertToRequest) Reset() {
+	*x = RevertToRequest{}
+	mi := &file_hydraide_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt8Request) String() string {
+func (x *RevertToRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt8Request) ProtoMessage() {}
+func (*RevertToRequest) ProtoMessage() {}
 
-func (x *IncrementInt8Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[43]
+func (x *RevertToRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3166,73 +10761,68 @@ func (x *IncrementInt8Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt8Request.ProtoReflect.Descriptor instead.
-func (*IncrementInt8Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use RevertToRequest.ProtoReflect.Descriptor instead.
+func (*RevertToRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{152}
 }
 
-func (x *IncrementInt8Request) GetIslandID() uint64 {
+func (x *RevertToRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementInt8Request) GetSwampName() string {
+func (x *RevertToRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *IncrementInt8Request) GetKey() string {
+func (x *RevertToRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-func (x *IncrementInt8Request) GetIncrementBy() int32 {
+func (x *RevertToRequest) GetVersion() uint64 {
 	if x != nil {
-		return x.IncrementBy
+		return x.Version
 	}
 	return 0
 }
 
-func (x *IncrementInt8Request) GetCondition() *IncrementInt8Condition {
-	if x != nil {
-		return x.Condition
+func (x *RevertToRequest) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
 	}
-	return nil
+	return ""
 }
 
-type IncrementInt8Condition struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The relational operator to apply (e.g., GREATER_THAN, EQUAL).
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// The value to compare against.
-	//
-	// ⚠️ Despite being int32 here (due to proto3 limitation), only valid int8 values are considered.
-	Value         int32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+// RevertToResponse is returned after the treasure is reverted.
+type RevertToResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt8Condition) Reset() {
-	*x = IncrementInt8Condition{}
-	mi := &file_hydraide_proto_msgTypes[44]
+func (x *RevertToResponse) Reset() {
+	*x = RevertToResponse{}
+	mi := &file_hydraide_proto_msgTypes[153]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt8Condition) String() string {
+func (x *RevertToResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt8Condition) ProtoMessage() {}
+func (*RevertToResponse) ProtoMessage() {}
 
-func (x *IncrementInt8Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[44]
+func (x *RevertToResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[153]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3243,53 +10833,40 @@ func (x *IncrementInt8Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt8Condition.ProtoReflect.Descriptor instead.
-func (*IncrementInt8Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{44}
-}
-
-func (x *IncrementInt8Condition) GetRelationalOperator() Relational_Operator {
-	if x != nil {
-		return x.RelationalOperator
-	}
-	return Relational_EQUAL
-}
-
-func (x *IncrementInt8Condition) GetValue() int32 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
+// Deprecated: Use RevertToResponse.ProtoReflect.Descriptor instead.
+func (*RevertToResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{153}
 }
 
-type IncrementInt8Response struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the resulting value after the increment (or original if unchanged).
-	//
-	// Note: ⚠️ This is represented as int32 due to proto3 constraints,
-	// but internally HydrAIDE uses int8 precision and guarantees type-safety.
-	Value int32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented indicates whether the increment was actually applied.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+// ErrorReason is the machine-readable reason of a failed request.
+//
+// The server attaches the reason to the gRPC status as a google.rpc.ErrorInfo detail, where:
+// - ErrorInfo.Reason is the name of the enum value (e.g. "SWAMP_NOT_FOUND")
+// - ErrorInfo.Domain is always "hydraide"
+//
+// The SDKs should use the reason instead of parsing the human-readable status message,
+// because the messages may change at any time, the reasons never do.
+type ErrorReason struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt8Response) Reset() {
-	*x = IncrementInt8Response{}
-	mi := &file_hydraide_proto_msgTypes[45]
+func (x *ErrorReason) Reset() {
+	*x = ErrorReason{}
+	mi := &file_hydraide_proto_msgTypes[154]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt8Response) String() string {
+func (x *ErrorReason) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt8Response) ProtoMessage() {}
+func (*ErrorReason) ProtoMessage() {}
 
-func (x *IncrementInt8Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[45]
+func (x *ErrorReason) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[154]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3300,59 +10877,41 @@ func (x *IncrementInt8Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt8Response.ProtoReflect.Descriptor instead.
-func (*IncrementInt8Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{45}
-}
-
-func (x *IncrementInt8Response) GetValue() int32 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
-}
-
-func (x *IncrementInt8Response) GetIsIncremented() bool {
-	if x != nil {
-		return x.IsIncremented
-	}
-	return false
+// Deprecated: Use ErrorReason.ProtoReflect.Descriptor instead.
+func (*ErrorReason) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{154}
 }
 
-type IncrementInt16Request struct {
+// SetSwampAnnotationRequest sets or deletes one annotation of a swamp.
+type SetSwampAnnotationRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the value resides.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the identifier of the treasure to increment.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the signed integer to add (or subtract) from the current value.
-	//
-	// ⚠️ Although this is declared as int32, HydrAIDE stores the value as int16.
-	// The system enforces proper bounds and type conversion internally.
-	IncrementBy int32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition determines whether the increment should proceed.
-	Condition     *IncrementInt16Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to annotate.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is the name of the annotation. Can not be empty.
+	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Value is the value of the annotation. An empty value deletes the annotation.
+	Value         string `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt16Request) Reset() {
-	*x = IncrementInt16Request{}
-	mi := &file_hydraide_proto_msgTypes[46]
+func (x *SetSwampAnnotationRequest) Reset() {
+	*x = SetSwampAnnotationRequest{}
+	mi := &file_hydraide_proto_msgTypes[155]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt16Request) String() string {
+func (x *SetSwampAnnotationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt16Request) ProtoMessage() {}
+func (*SetSwampAnnotationRequest) ProtoMessage() {}
 
-func (x *IncrementInt16Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[46]
+func (x *SetSwampAnnotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[155]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3363,74 +10922,102 @@ func (x *IncrementInt16Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt16Request.ProtoReflect.Descriptor instead.
-func (*IncrementInt16Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use SetSwampAnnotationRequest.ProtoReflect.Descriptor instead.
+func (*SetSwampAnnotationRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{155}
 }
 
-func (x *IncrementInt16Request) GetIslandID() uint64 {
+func (x *SetSwampAnnotationRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementInt16Request) GetSwampName() string {
+func (x *SetSwampAnnotationRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *IncrementInt16Request) GetKey() string {
+func (x *SetSwampAnnotationRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-func (x *IncrementInt16Request) GetIncrementBy() int32 {
+func (x *SetSwampAnnotationRequest) GetValue() string {
 	if x != nil {
-		return x.IncrementBy
+		return x.Value
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementInt16Request) GetCondition() *IncrementInt16Condition {
+// SetSwampAnnotationResponse is returned after the annotation is set.
+type SetSwampAnnotationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSwampAnnotationResponse) Reset() {
+	*x = SetSwampAnnotationResponse{}
+	mi := &file_hydraide_proto_msgTypes[156]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSwampAnnotationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSwampAnnotationResponse) ProtoMessage() {}
+
+func (x *SetSwampAnnotationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[156]
 	if x != nil {
-		return x.Condition
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type IncrementInt16Condition struct {
+// Deprecated: Use SetSwampAnnotationResponse.ProtoReflect.Descriptor instead.
+func (*SetSwampAnnotationResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{156}
+}
+
+// GetSwampAnnotationsRequest asks for all annotations of a swamp.
+type GetSwampAnnotationsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The relational operator to apply (e.g., GREATER_THAN, LESS_THAN).
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// The value to compare against.
-	//
-	// ⚠️ While represented as int32 (due to proto3 constraints),
-	// HydrAIDE treats it as an int16 behind the scenes.
-	Value         int32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp.
+	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt16Condition) Reset() {
-	*x = IncrementInt16Condition{}
-	mi := &file_hydraide_proto_msgTypes[47]
+func (x *GetSwampAnnotationsRequest) Reset() {
+	*x = GetSwampAnnotationsRequest{}
+	mi := &file_hydraide_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt16Condition) String() string {
+func (x *GetSwampAnnotationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt16Condition) ProtoMessage() {}
+func (*GetSwampAnnotationsRequest) ProtoMessage() {}
 
-func (x *IncrementInt16Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[47]
+func (x *GetSwampAnnotationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3441,53 +11028,49 @@ func (x *IncrementInt16Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt16Condition.ProtoReflect.Descriptor instead.
-func (*IncrementInt16Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use GetSwampAnnotationsRequest.ProtoReflect.Descriptor instead.
+func (*GetSwampAnnotationsRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{157}
 }
 
-func (x *IncrementInt16Condition) GetRelationalOperator() Relational_Operator {
+func (x *GetSwampAnnotationsRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.RelationalOperator
+		return x.IslandID
 	}
-	return Relational_EQUAL
+	return 0
 }
 
-func (x *IncrementInt16Condition) GetValue() int32 {
+func (x *GetSwampAnnotationsRequest) GetSwampName() string {
 	if x != nil {
-		return x.Value
+		return x.SwampName
 	}
-	return 0
+	return ""
 }
 
-type IncrementInt16Response struct {
+// GetSwampAnnotationsResponse contains all annotations of the swamp.
+type GetSwampAnnotationsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the resulting value after incrementing.
-	//
-	// Note: Represented as int32 for compatibility, but HydrAIDE ensures
-	// that the underlying type remains int16 and no data loss occurs.
-	Value int32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented tells whether the value was actually modified.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// Annotations are the key-value annotations of the swamp. Empty if the swamp has no annotations.
+	Annotations   map[string]string `protobuf:"bytes,1,rep,name=Annotations,proto3" json:"Annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt16Response) Reset() {
-	*x = IncrementInt16Response{}
-	mi := &file_hydraide_proto_msgTypes[48]
+func (x *GetSwampAnnotationsResponse) Reset() {
+	*x = GetSwampAnnotationsResponse{}
+	mi := &file_hydraide_proto_msgTypes[158]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt16Response) String() string {
+func (x *GetSwampAnnotationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt16Response) ProtoMessage() {}
+func (*GetSwampAnnotationsResponse) ProtoMessage() {}
 
-func (x *IncrementInt16Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[48]
+func (x *GetSwampAnnotationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[158]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3498,56 +11081,58 @@ func (x *IncrementInt16Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt16Response.ProtoReflect.Descriptor instead.
-func (*IncrementInt16Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{48}
-}
-
-func (x *IncrementInt16Response) GetValue() int32 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
+// Deprecated: Use GetSwampAnnotationsResponse.ProtoReflect.Descriptor instead.
+func (*GetSwampAnnotationsResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{158}
 }
 
-func (x *IncrementInt16Response) GetIsIncremented() bool {
+func (x *GetSwampAnnotationsResponse) GetAnnotations() map[string]string {
 	if x != nil {
-		return x.IsIncremented
+		return x.Annotations
 	}
-	return false
+	return nil
 }
 
-type IncrementInt32Request struct {
+// AggregateRequest asks for the aggregates of the numeric values of a swamp.
+type AggregateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the value resides.
+	// SwampName is the name of the swamp to aggregate.
 	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the treasure key whose value we want to increment.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the signed 32-bit integer to add or subtract.
-	IncrementBy int32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition must be satisfied for the increment to apply.
-	Condition     *IncrementInt32Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	// KeyPrefix limits the aggregation to the keys starting with the prefix. Empty means all keys.
+	KeyPrefix string `protobuf:"bytes,3,opt,name=KeyPrefix,proto3" json:"KeyPrefix,omitempty"`
+	// IndexType is the index the From and Limit range is applied on. Defaults to KEY.
+	IndexType IndexType_Type `protobuf:"varint,4,opt,name=IndexType,proto3,enum=hydraidepbgo.IndexType_Type" json:"IndexType,omitempty"`
+	// OrderType is the order of the index. Defaults to ASC.
+	OrderType OrderType_Type `protobuf:"varint,5,opt,name=OrderType,proto3,enum=hydraidepbgo.OrderType_Type" json:"OrderType,omitempty"`
+	// From skips the first From matching numeric treasures of the index.
+	From int32 `protobuf:"varint,6,opt,name=From,proto3" json:"From,omitempty"`
+	// Limit aggregates at most Limit matching numeric treasures. 0 means no limit.
+	Limit int32 `protobuf:"varint,7,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	// FilterExpr is an optional server-side filter expression, the same as the FilterExpr of GetByIndex.
+	//
+	// The key prefix and the filter are applied before From and Limit.
+	FilterExpr    *string `protobuf:"bytes,8,opt,name=FilterExpr,proto3,oneof" json:"FilterExpr,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt32Request) Reset() {
-	*x = IncrementInt32Request{}
-	mi := &file_hydraide_proto_msgTypes[49]
+func (x *AggregateRequest) Reset() {
+	*x = AggregateRequest{}
+	mi := &file_hydraide_proto_msgTypes[159]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt32Request) String() string {
+func (x *AggregateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt32Request) ProtoMessage() {}
+func (*AggregateRequest) ProtoMessage() {}
 
-func (x *IncrementInt32Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[49]
+func (x *AggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[159]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3558,71 +11143,102 @@ func (x *IncrementInt32Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt32Request.ProtoReflect.Descriptor instead.
-func (*IncrementInt32Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use AggregateRequest.ProtoReflect.Descriptor instead.
+func (*AggregateRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{159}
 }
 
-func (x *IncrementInt32Request) GetIslandID() uint64 {
+func (x *AggregateRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementInt32Request) GetSwampName() string {
+func (x *AggregateRequest) GetSwampName() string {
 	if x != nil {
 		return x.SwampName
 	}
 	return ""
 }
 
-func (x *IncrementInt32Request) GetKey() string {
+func (x *AggregateRequest) GetKeyPrefix() string {
 	if x != nil {
-		return x.Key
+		return x.KeyPrefix
 	}
 	return ""
 }
 
-func (x *IncrementInt32Request) GetIncrementBy() int32 {
+func (x *AggregateRequest) GetIndexType() IndexType_Type {
 	if x != nil {
-		return x.IncrementBy
+		return x.IndexType
+	}
+	return IndexType_KEY
+}
+
+func (x *AggregateRequest) GetOrderType() OrderType_Type {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ASC
+}
+
+func (x *AggregateRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
 	}
 	return 0
 }
 
-func (x *IncrementInt32Request) GetCondition() *IncrementInt32Condition {
+func (x *AggregateRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Condition
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type IncrementInt32Condition struct {
+func (x *AggregateRequest) GetFilterExpr() string {
+	if x != nil && x.FilterExpr != nil {
+		return *x.FilterExpr
+	}
+	return ""
+}
+
+// AggregateResponse contains the aggregates of the numeric values.
+type AggregateResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The relational operator for evaluating the current value.
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// Value to compare against (int32).
-	Value         int32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Count is the number of the aggregated numeric values.
+	Count int64 `protobuf:"varint,1,opt,name=Count,proto3" json:"Count,omitempty"`
+	// Sum is the sum of the values as float64.
+	Sum float64 `protobuf:"fixed64,2,opt,name=Sum,proto3" json:"Sum,omitempty"`
+	// Min is the smallest value. Not set if Count is 0.
+	Min *float64 `protobuf:"fixed64,3,opt,name=Min,proto3,oneof" json:"Min,omitempty"`
+	// Max is the largest value. Not set if Count is 0.
+	Max *float64 `protobuf:"fixed64,4,opt,name=Max,proto3,oneof" json:"Max,omitempty"`
+	// Avg is the arithmetic mean of the values. Not set if Count is 0.
+	Avg *float64 `protobuf:"fixed64,5,opt,name=Avg,proto3,oneof" json:"Avg,omitempty"`
+	// IntegerSum is the exact sum of the values. Set only if every value is an integer
+	// and the sum fits into int64, because float64 loses precision above 2^53.
+	IntegerSum    *int64 `protobuf:"varint,6,opt,name=IntegerSum,proto3,oneof" json:"IntegerSum,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt32Condition) Reset() {
-	*x = IncrementInt32Condition{}
-	mi := &file_hydraide_proto_msgTypes[50]
+func (x *AggregateResponse) Reset() {
+	*x = AggregateResponse{}
+	mi := &file_hydraide_proto_msgTypes[160]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt32Condition) String() string {
+func (x *AggregateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt32Condition) ProtoMessage() {}
+func (*AggregateResponse) ProtoMessage() {}
 
-func (x *IncrementInt32Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[50]
+func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[160]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3633,50 +11249,75 @@ func (x *IncrementInt32Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt32Condition.ProtoReflect.Descriptor instead.
-func (*IncrementInt32Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use AggregateResponse.ProtoReflect.Descriptor instead.
+func (*AggregateResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{160}
 }
 
-func (x *IncrementInt32Condition) GetRelationalOperator() Relational_Operator {
+func (x *AggregateResponse) GetCount() int64 {
 	if x != nil {
-		return x.RelationalOperator
+		return x.Count
 	}
-	return Relational_EQUAL
+	return 0
 }
 
-func (x *IncrementInt32Condition) GetValue() int32 {
+func (x *AggregateResponse) GetSum() float64 {
 	if x != nil {
-		return x.Value
+		return x.Sum
 	}
 	return 0
 }
 
-type IncrementInt32Response struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the resulting int32 value after increment.
-	Value int32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented is true if the value was changed.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+func (x *AggregateResponse) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *AggregateResponse) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *AggregateResponse) GetAvg() float64 {
+	if x != nil && x.Avg != nil {
+		return *x.Avg
+	}
+	return 0
+}
+
+func (x *AggregateResponse) GetIntegerSum() int64 {
+	if x != nil && x.IntegerSum != nil {
+		return *x.IntegerSum
+	}
+	return 0
+}
+
+// ListCorruptedFilesRequest asks for the corrupted files of the server.
+type ListCorruptedFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt32Response) Reset() {
-	*x = IncrementInt32Response{}
-	mi := &file_hydraide_proto_msgTypes[51]
+func (x *ListCorruptedFilesRequest) Reset() {
+	*x = ListCorruptedFilesRequest{}
+	mi := &file_hydraide_proto_msgTypes[161]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt32Response) String() string {
+func (x *ListCorruptedFilesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt32Response) ProtoMessage() {}
+func (*ListCorruptedFilesRequest) ProtoMessage() {}
 
-func (x *IncrementInt32Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[51]
+func (x *ListCorruptedFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[161]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3687,133 +11328,98 @@ func (x *IncrementInt32Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt32Response.ProtoReflect.Descriptor instead.
-func (*IncrementInt32Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{51}
-}
-
-func (x *IncrementInt32Response) GetValue() int32 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
-}
-
-func (x *IncrementInt32Response) GetIsIncremented() bool {
-	if x != nil {
-		return x.IsIncremented
-	}
-	return false
+// Deprecated: Use ListCorruptedFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListCorruptedFilesRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{161}
 }
 
-type IncrementInt64Request struct {
+// CorruptedFile is a chunk file found corrupted.
+type CorruptedFile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the treasure is stored.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key identifies the treasure you want to increment.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the signed 64-bit integer to add (can be negative).
-	//
-	// 💡 Ideal for working with timestamps, counters, large numeric values.
-	IncrementBy int64 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition must be met for the increment to happen.
-	Condition     *IncrementInt64Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	// FilePath is the absolute path of the file on the server.
+	FilePath string `protobuf:"bytes,1,opt,name=FilePath,proto3" json:"FilePath,omitempty"`
+	// Reason describes the damage, e.g. the checksum mismatch.
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// DetectedAt is the time the corruption was detected last.
+	DetectedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=DetectedAt,proto3" json:"DetectedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt64Request) Reset() {
-	*x = IncrementInt64Request{}
-	mi := &file_hydraide_proto_msgTypes[52]
+func (x *CorruptedFile) Reset() {
+	*x = CorruptedFile{}
+	mi := &file_hydraide_proto_msgTypes[162]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt64Request) String() string {
+func (x *CorruptedFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt64Request) ProtoMessage() {}
-
-func (x *IncrementInt64Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[52]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use IncrementInt64Request.ProtoReflect.Descriptor instead.
-func (*IncrementInt64Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{52}
-}
+func (*CorruptedFile) ProtoMessage() {}
 
-func (x *IncrementInt64Request) GetIslandID() uint64 {
+func (x *CorruptedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[162]
 	if x != nil {
-		return x.IslandID
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *IncrementInt64Request) GetSwampName() string {
-	if x != nil {
-		return x.SwampName
-	}
-	return ""
+// Deprecated: Use CorruptedFile.ProtoReflect.Descriptor instead.
+func (*CorruptedFile) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{162}
 }
 
-func (x *IncrementInt64Request) GetKey() string {
+func (x *CorruptedFile) GetFilePath() string {
 	if x != nil {
-		return x.Key
+		return x.FilePath
 	}
 	return ""
 }
 
-func (x *IncrementInt64Request) GetIncrementBy() int64 {
+func (x *CorruptedFile) GetReason() string {
 	if x != nil {
-		return x.IncrementBy
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementInt64Request) GetCondition() *IncrementInt64Condition {
+func (x *CorruptedFile) GetDetectedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Condition
+		return x.DetectedAt
 	}
 	return nil
 }
 
-type IncrementInt64Condition struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Relational operator used for conditional increment.
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// The reference value to compare the current value against.
-	Value         int64 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+// ListCorruptedFilesResponse contains the corrupted files of the server, ordered by their paths.
+type ListCorruptedFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*CorruptedFile       `protobuf:"bytes,1,rep,name=Files,proto3" json:"Files,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt64Condition) Reset() {
-	*x = IncrementInt64Condition{}
-	mi := &file_hydraide_proto_msgTypes[53]
+func (x *ListCorruptedFilesResponse) Reset() {
+	*x = ListCorruptedFilesResponse{}
+	mi := &file_hydraide_proto_msgTypes[163]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt64Condition) String() string {
+func (x *ListCorruptedFilesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt64Condition) ProtoMessage() {}
+func (*ListCorruptedFilesResponse) ProtoMessage() {}
 
-func (x *IncrementInt64Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[53]
+func (x *ListCorruptedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[163]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3824,50 +11430,42 @@ func (x *IncrementInt64Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt64Condition.ProtoReflect.Descriptor instead.
-func (*IncrementInt64Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{53}
-}
-
-func (x *IncrementInt64Condition) GetRelationalOperator() Relational_Operator {
-	if x != nil {
-		return x.RelationalOperator
-	}
-	return Relational_EQUAL
+// Deprecated: Use ListCorruptedFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListCorruptedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{163}
 }
 
-func (x *IncrementInt64Condition) GetValue() int64 {
+func (x *ListCorruptedFilesResponse) GetFiles() []*CorruptedFile {
 	if x != nil {
-		return x.Value
+		return x.Files
 	}
-	return 0
+	return nil
 }
 
-type IncrementInt64Response struct {
+// GetNamespaceUsageRequest asks for the usage of the Sanctuaries and the Realms of the server.
+type GetNamespaceUsageRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the resulting value after the increment (or the original value if condition failed).
-	Value int64 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented indicates whether the operation was applied.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// Refresh walks the swamp folders again, instead of returning the cached usage.
+	Refresh       bool `protobuf:"varint,1,opt,name=Refresh,proto3" json:"Refresh,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementInt64Response) Reset() {
-	*x = IncrementInt64Response{}
-	mi := &file_hydraide_proto_msgTypes[54]
+func (x *GetNamespaceUsageRequest) Reset() {
+	*x = GetNamespaceUsageRequest{}
+	mi := &file_hydraide_proto_msgTypes[164]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementInt64Response) String() string {
+func (x *GetNamespaceUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementInt64Response) ProtoMessage() {}
+func (*GetNamespaceUsageRequest) ProtoMessage() {}
 
-func (x *IncrementInt64Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[54]
+func (x *GetNamespaceUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[164]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3878,61 +11476,50 @@ func (x *IncrementInt64Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementInt64Response.ProtoReflect.Descriptor instead.
-func (*IncrementInt64Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{54}
-}
-
-func (x *IncrementInt64Response) GetValue() int64 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
+// Deprecated: Use GetNamespaceUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetNamespaceUsageRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{164}
 }
 
-func (x *IncrementInt64Response) GetIsIncremented() bool {
+func (x *GetNamespaceUsageRequest) GetRefresh() bool {
 	if x != nil {
-		return x.IsIncremented
+		return x.Refresh
 	}
 	return false
 }
 
-type IncrementUint8Request struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName identifies the swamp where the treasure lives.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the treasure to increment.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the unsigned integer to add to the current value.
-	//
-	// ⚠️ Although represented as uint32 due to proto3 limitations,
-	// HydrAIDE stores this as a true uint8 internally (0–255).
-	//
-	// Any overflow is safely rejected and not applied.
-	IncrementBy uint32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition to be evaluated before applying the increment.
-	Condition     *IncrementUint8Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+// NamespaceUsage is the usage of the swamps of a Realm of a Sanctuary.
+type NamespaceUsage struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sanctuary string                 `protobuf:"bytes,1,opt,name=Sanctuary,proto3" json:"Sanctuary,omitempty"`
+	Realm     string                 `protobuf:"bytes,2,opt,name=Realm,proto3" json:"Realm,omitempty"`
+	// SwampCount is the number of the swamps of the Realm, including the open in-memory swamps.
+	SwampCount uint64 `protobuf:"varint,3,opt,name=SwampCount,proto3" json:"SwampCount,omitempty"`
+	// TreasureCount is the number of the treasures of the counted swamps.
+	TreasureCount uint64 `protobuf:"varint,4,opt,name=TreasureCount,proto3" json:"TreasureCount,omitempty"`
+	// UncountedSwamps is the number of the swamps without a recorded treasure count.
+	UncountedSwamps uint64 `protobuf:"varint,5,opt,name=UncountedSwamps,proto3" json:"UncountedSwamps,omitempty"`
+	// DiskBytes is the size of the files of the swamps in bytes.
+	DiskBytes     uint64 `protobuf:"varint,6,opt,name=DiskBytes,proto3" json:"DiskBytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint8Request) Reset() {
-	*x = IncrementUint8Request{}
-	mi := &file_hydraide_proto_msgTypes[55]
+func (x *NamespaceUsage) Reset() {
+	*x = NamespaceUsage{}
+	mi := &file_hydraide_proto_msgTypes[165]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint8Request) String() string {
+func (x *NamespaceUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint8Request) ProtoMessage() {}
+func (*NamespaceUsage) ProtoMessage() {}
 
-func (x *IncrementUint8Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[55]
+func (x *NamespaceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[165]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3943,73 +11530,79 @@ func (x *IncrementUint8Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint8Request.ProtoReflect.Descriptor instead.
-func (*IncrementUint8Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use NamespaceUsage.ProtoReflect.Descriptor instead.
+func (*NamespaceUsage) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{165}
 }
 
-func (x *IncrementUint8Request) GetIslandID() uint64 {
+func (x *NamespaceUsage) GetSanctuary() string {
 	if x != nil {
-		return x.IslandID
+		return x.Sanctuary
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementUint8Request) GetSwampName() string {
+func (x *NamespaceUsage) GetRealm() string {
 	if x != nil {
-		return x.SwampName
+		return x.Realm
 	}
 	return ""
 }
 
-func (x *IncrementUint8Request) GetKey() string {
+func (x *NamespaceUsage) GetSwampCount() uint64 {
 	if x != nil {
-		return x.Key
+		return x.SwampCount
 	}
-	return ""
+	return 0
 }
 
-func (x *IncrementUint8Request) GetIncrementBy() uint32 {
+func (x *NamespaceUsage) GetTreasureCount() uint64 {
 	if x != nil {
-		return x.IncrementBy
+		return x.TreasureCount
 	}
 	return 0
 }
 
-func (x *IncrementUint8Request) GetCondition() *IncrementUint8Condition {
+func (x *NamespaceUsage) GetUncountedSwamps() uint64 {
 	if x != nil {
-		return x.Condition
+		return x.UncountedSwamps
 	}
-	return nil
+	return 0
 }
 
-type IncrementUint8Condition struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The relational operator (e.g. EQUAL, GREATER_THAN, etc.)
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// Value to compare against before incrementing.
-	//
-	// ⚠️ Same as above: Only values within the uint8 range are accepted internally.
-	Value         uint32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+func (x *NamespaceUsage) GetDiskBytes() uint64 {
+	if x != nil {
+		return x.DiskBytes
+	}
+	return 0
+}
+
+// GetNamespaceUsageResponse contains the usage of every Realm with at least one swamp, ordered by the Sanctuary and
+// the Realm.
+type GetNamespaceUsageResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Usages []*NamespaceUsage      `protobuf:"bytes,1,rep,name=Usages,proto3" json:"Usages,omitempty"`
+	// CollectedAt is the time the swamp folders were walked.
+	CollectedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=CollectedAt,proto3" json:"CollectedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint8Condition) Reset() {
-	*x = IncrementUint8Condition{}
-	mi := &file_hydraide_proto_msgTypes[56]
+func (x *GetNamespaceUsageResponse) Reset() {
+	*x = GetNamespaceUsageResponse{}
+	mi := &file_hydraide_proto_msgTypes[166]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint8Condition) String() string {
+func (x *GetNamespaceUsageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint8Condition) ProtoMessage() {}
+func (*GetNamespaceUsageResponse) ProtoMessage() {}
 
-func (x *IncrementUint8Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[56]
+func (x *GetNamespaceUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[166]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4020,52 +11613,54 @@ func (x *IncrementUint8Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint8Condition.ProtoReflect.Descriptor instead.
-func (*IncrementUint8Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use GetNamespaceUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetNamespaceUsageResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{166}
 }
 
-func (x *IncrementUint8Condition) GetRelationalOperator() Relational_Operator {
+func (x *GetNamespaceUsageResponse) GetUsages() []*NamespaceUsage {
 	if x != nil {
-		return x.RelationalOperator
+		return x.Usages
 	}
-	return Relational_EQUAL
+	return nil
 }
 
-func (x *IncrementUint8Condition) GetValue() uint32 {
+func (x *GetNamespaceUsageResponse) GetCollectedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Value
+		return x.CollectedAt
 	}
-	return 0
+	return nil
 }
 
-type IncrementUint8Response struct {
+// CompactSwampRequest asks for the compaction of a swamp.
+type CompactSwampRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the resulting value after increment.
-	//
-	// ⚠️ Represented as uint32 in the response, but internally it's stored as uint8.
-	Value uint32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented is true if the increment was applied.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// SwampName is the name of the swamp to compact.
+	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// MinLiveRatio is the ratio of the live data (0 < MinLiveRatio <= 1), below which a chunk is rewritten.
+	// Defaults to 0.5 if not set, so the chunks with more dead data than live data are rewritten.
+	MinLiveRatio  float64 `protobuf:"fixed64,3,opt,name=MinLiveRatio,proto3" json:"MinLiveRatio,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint8Response) Reset() {
-	*x = IncrementUint8Response{}
-	mi := &file_hydraide_proto_msgTypes[57]
+func (x *CompactSwampRequest) Reset() {
+	*x = CompactSwampRequest{}
+	mi := &file_hydraide_proto_msgTypes[167]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint8Response) String() string {
+func (x *CompactSwampRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint8Response) ProtoMessage() {}
+func (*CompactSwampRequest) ProtoMessage() {}
 
-func (x *IncrementUint8Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[57]
+func (x *CompactSwampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[167]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4076,59 +11671,62 @@ func (x *IncrementUint8Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint8Response.ProtoReflect.Descriptor instead.
-func (*IncrementUint8Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use CompactSwampRequest.ProtoReflect.Descriptor instead.
+func (*CompactSwampRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{167}
 }
 
-func (x *IncrementUint8Response) GetValue() uint32 {
+func (x *CompactSwampRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.Value
+		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementUint8Response) GetIsIncremented() bool {
+func (x *CompactSwampRequest) GetSwampName() string {
 	if x != nil {
-		return x.IsIncremented
+		return x.SwampName
 	}
-	return false
+	return ""
 }
 
-type IncrementUint16Request struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the treasure is stored.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key identifies the target treasure.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the unsigned integer to add (or subtract, if allowed).
-	//
-	// ⚠️ Represented as `uint32` in the gRPC interface, but HydrAIDE stores it as true `uint16` internally.
-	// Allowed value range: 0 to 65,535
-	IncrementBy uint32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition to be satisfied before increment is applied.
-	Condition     *IncrementUint16Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CompactSwampRequest) GetMinLiveRatio() float64 {
+	if x != nil {
+		return x.MinLiveRatio
+	}
+	return 0
 }
 
-func (x *IncrementUint16Request) Reset() {
-	*x = IncrementUint16Request{}
-	mi := &file_hydraide_proto_msgTypes[58]
+// CompactSwampResponse summarizes the compaction.
+type CompactSwampResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CompactedFiles is the number of the rewritten and deleted chunks.
+	CompactedFiles int32 `protobuf:"varint,1,opt,name=CompactedFiles,proto3" json:"CompactedFiles,omitempty"`
+	// WrittenFiles is the number of the new chunks the live treasures are written to.
+	WrittenFiles int32 `protobuf:"varint,2,opt,name=WrittenFiles,proto3" json:"WrittenFiles,omitempty"`
+	// RemovedTreasures is the number of the deleted treasures removed from the chunks.
+	RemovedTreasures int32 `protobuf:"varint,3,opt,name=RemovedTreasures,proto3" json:"RemovedTreasures,omitempty"`
+	// ReclaimedBytes is the disk space freed by the compaction.
+	ReclaimedBytes int64 `protobuf:"varint,4,opt,name=ReclaimedBytes,proto3" json:"ReclaimedBytes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompactSwampResponse) Reset() {
+	*x = CompactSwampResponse{}
+	mi := &file_hydraide_proto_msgTypes[168]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint16Request) String() string {
+func (x *CompactSwampResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint16Request) ProtoMessage() {}
+func (*CompactSwampResponse) ProtoMessage() {}
 
-func (x *IncrementUint16Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[58]
+func (x *CompactSwampResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[168]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4139,73 +11737,68 @@ func (x *IncrementUint16Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint16Request.ProtoReflect.Descriptor instead.
-func (*IncrementUint16Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{58}
+// Deprecated: Use CompactSwampResponse.ProtoReflect.Descriptor instead.
+func (*CompactSwampResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{168}
 }
 
-func (x *IncrementUint16Request) GetIslandID() uint64 {
+func (x *CompactSwampResponse) GetCompactedFiles() int32 {
 	if x != nil {
-		return x.IslandID
+		return x.CompactedFiles
 	}
 	return 0
 }
 
-func (x *IncrementUint16Request) GetSwampName() string {
-	if x != nil {
-		return x.SwampName
-	}
-	return ""
-}
-
-func (x *IncrementUint16Request) GetKey() string {
+func (x *CompactSwampResponse) GetWrittenFiles() int32 {
 	if x != nil {
-		return x.Key
+		return x.WrittenFiles
 	}
-	return ""
+	return 0
 }
 
-func (x *IncrementUint16Request) GetIncrementBy() uint32 {
+func (x *CompactSwampResponse) GetRemovedTreasures() int32 {
 	if x != nil {
-		return x.IncrementBy
+		return x.RemovedTreasures
 	}
 	return 0
 }
 
-func (x *IncrementUint16Request) GetCondition() *IncrementUint16Condition {
+func (x *CompactSwampResponse) GetReclaimedBytes() int64 {
 	if x != nil {
-		return x.Condition
+		return x.ReclaimedBytes
 	}
-	return nil
+	return 0
 }
 
-type IncrementUint16Condition struct {
+type PutBlobRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The logical operator used for evaluating the current value.
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// Comparison reference value.
-	//
-	// ⚠️ Although this is a `uint32`, HydrAIDE handles it as `uint16` internally.
-	Value         uint32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") of the blob, computed from its hash. Only in the first
+	// message.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// Hash is the lowercase hex encoded SHA-256 hash of the whole content. Only in the first message.
+	// The blob is rejected with DATA_CORRUPTED reason if the received content has a different hash.
+	Hash string `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	// Chunk is the next part of the content. Can be set in every message, including the first one.
+	Chunk         []byte `protobuf:"bytes,3,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint16Condition) Reset() {
-	*x = IncrementUint16Condition{}
-	mi := &file_hydraide_proto_msgTypes[59]
+func (x *PutBlobRequest) Reset() {
+	*x = PutBlobRequest{}
+	mi := &file_hydraide_proto_msgTypes[169]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint16Condition) String() string {
+func (x *PutBlobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint16Condition) ProtoMessage() {}
+func (*PutBlobRequest) ProtoMessage() {}
 
-func (x *IncrementUint16Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[59]
+func (x *PutBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[169]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4216,52 +11809,61 @@ func (x *IncrementUint16Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint16Condition.ProtoReflect.Descriptor instead.
-func (*IncrementUint16Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use PutBlobRequest.ProtoReflect.Descriptor instead.
+func (*PutBlobRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{169}
 }
 
-func (x *IncrementUint16Condition) GetRelationalOperator() Relational_Operator {
+func (x *PutBlobRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.RelationalOperator
+		return x.IslandID
 	}
-	return Relational_EQUAL
+	return 0
 }
 
-func (x *IncrementUint16Condition) GetValue() uint32 {
+func (x *PutBlobRequest) GetHash() string {
 	if x != nil {
-		return x.Value
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *PutBlobRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
 	}
-	return 0
+	return nil
 }
 
-type IncrementUint16Response struct {
+type PutBlobResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the final (or original) value after attempted increment.
-	//
-	// Still represented as `uint32` due to proto limitations, but only 0..65535 values are stored.
-	Value uint32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented tells whether the value was actually modified.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// Hash is the hash of the stored content.
+	Hash string `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	// IsNew is true if the content was stored now, false if only a reference was added to the existing blob.
+	IsNew bool `protobuf:"varint,2,opt,name=IsNew,proto3" json:"IsNew,omitempty"`
+	// References is the number of the references of the blob after the put.
+	References int64 `protobuf:"varint,3,opt,name=References,proto3" json:"References,omitempty"`
+	// Size is the size of the content in bytes.
+	Size          int64 `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint16Response) Reset() {
-	*x = IncrementUint16Response{}
-	mi := &file_hydraide_proto_msgTypes[60]
+func (x *PutBlobResponse) Reset() {
+	*x = PutBlobResponse{}
+	mi := &file_hydraide_proto_msgTypes[170]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint16Response) String() string {
+func (x *PutBlobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint16Response) ProtoMessage() {}
+func (*PutBlobResponse) ProtoMessage() {}
 
-func (x *IncrementUint16Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[60]
+func (x *PutBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[170]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4272,58 +11874,66 @@ func (x *IncrementUint16Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint16Response.ProtoReflect.Descriptor instead.
-func (*IncrementUint16Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{60}
+// Deprecated: Use PutBlobResponse.ProtoReflect.Descriptor instead.
+func (*PutBlobResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{170}
 }
 
-func (x *IncrementUint16Response) GetValue() uint32 {
+func (x *PutBlobResponse) GetHash() string {
 	if x != nil {
-		return x.Value
+		return x.Hash
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementUint16Response) GetIsIncremented() bool {
+func (x *PutBlobResponse) GetIsNew() bool {
 	if x != nil {
-		return x.IsIncremented
+		return x.IsNew
 	}
 	return false
 }
 
-type IncrementUint32Request struct {
+func (x *PutBlobResponse) GetReferences() int64 {
+	if x != nil {
+		return x.References
+	}
+	return 0
+}
+
+func (x *PutBlobResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetBlobRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	// IslandID is the deterministic storage zone (or "island") of the blob, computed from its hash.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName specifies the target swamp.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key identifies the treasure to increment.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the unsigned 32-bit integer value to add.
-	//
-	// 💡 Supports any uint32 value (0–4,294,967,295)
-	IncrementBy uint32 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition must be true for the increment to be applied.
-	Condition     *IncrementUint32Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	// Hash is the hash of the blob.
+	Hash string `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	// ChunkSize is the max size of the chunks of the content in bytes. 0 means 1 MB.
+	ChunkSize     uint32 `protobuf:"varint,3,opt,name=ChunkSize,proto3" json:"ChunkSize,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint32Request) Reset() {
-	*x = IncrementUint32Request{}
-	mi := &file_hydraide_proto_msgTypes[61]
+func (x *GetBlobRequest) Reset() {
+	*x = GetBlobRequest{}
+	mi := &file_hydraide_proto_msgTypes[171]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint32Request) String() string {
+func (x *GetBlobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint32Request) ProtoMessage() {}
+func (*GetBlobRequest) ProtoMessage() {}
 
-func (x *IncrementUint32Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[61]
+func (x *GetBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[171]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4334,71 +11944,55 @@ func (x *IncrementUint32Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint32Request.ProtoReflect.Descriptor instead.
-func (*IncrementUint32Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{61}
+// Deprecated: Use GetBlobRequest.ProtoReflect.Descriptor instead.
+func (*GetBlobRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{171}
 }
 
-func (x *IncrementUint32Request) GetIslandID() uint64 {
+func (x *GetBlobRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementUint32Request) GetSwampName() string {
-	if x != nil {
-		return x.SwampName
-	}
-	return ""
-}
-
-func (x *IncrementUint32Request) GetKey() string {
+func (x *GetBlobRequest) GetHash() string {
 	if x != nil {
-		return x.Key
+		return x.Hash
 	}
 	return ""
 }
 
-func (x *IncrementUint32Request) GetIncrementBy() uint32 {
+func (x *GetBlobRequest) GetChunkSize() uint32 {
 	if x != nil {
-		return x.IncrementBy
+		return x.ChunkSize
 	}
 	return 0
 }
 
-func (x *IncrementUint32Request) GetCondition() *IncrementUint32Condition {
-	if x != nil {
-		return x.Condition
-	}
-	return nil
-}
-
-type IncrementUint32Condition struct {
+type GetBlobResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The relational operator used to evaluate the current value.
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// The value to compare against.
-	Value         uint32 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Chunk is the next part of the content.
+	Chunk         []byte `protobuf:"bytes,1,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint32Condition) Reset() {
-	*x = IncrementUint32Condition{}
-	mi := &file_hydraide_proto_msgTypes[62]
+func (x *GetBlobResponse) Reset() {
+	*x = GetBlobResponse{}
+	mi := &file_hydraide_proto_msgTypes[172]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint32Condition) String() string {
+func (x *GetBlobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint32Condition) ProtoMessage() {}
+func (*GetBlobResponse) ProtoMessage() {}
 
-func (x *IncrementUint32Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[62]
+func (x *GetBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[172]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4409,51 +12003,46 @@ func (x *IncrementUint32Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint32Condition.ProtoReflect.Descriptor instead.
-func (*IncrementUint32Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{62}
-}
-
-func (x *IncrementUint32Condition) GetRelationalOperator() Relational_Operator {
-	if x != nil {
-		return x.RelationalOperator
-	}
-	return Relational_EQUAL
+// Deprecated: Use GetBlobResponse.ProtoReflect.Descriptor instead.
+func (*GetBlobResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{172}
 }
 
-func (x *IncrementUint32Condition) GetValue() uint32 {
+func (x *GetBlobResponse) GetChunk() []byte {
 	if x != nil {
-		return x.Value
+		return x.Chunk
 	}
-	return 0
+	return nil
 }
 
-type IncrementUint32Response struct {
+type RefBlobRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the updated value after incrementing,
-	// or the original if the condition failed.
-	Value uint32 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented indicates whether the value was modified.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// IslandID is the deterministic storage zone (or "island") of the blob, computed from its hash.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// Hash is the hash of the blob.
+	Hash string `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	// Delta is the number of the added references, negative to remove references.
+	// The references can not be negative, the request is rejected with INVALID_ARGUMENT reason.
+	Delta         int64 `protobuf:"varint,3,opt,name=Delta,proto3" json:"Delta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint32Response) Reset() {
-	*x = IncrementUint32Response{}
-	mi := &file_hydraide_proto_msgTypes[63]
+func (x *RefBlobRequest) Reset() {
+	*x = RefBlobRequest{}
+	mi := &file_hydraide_proto_msgTypes[173]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint32Response) String() string {
+func (x *RefBlobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint32Response) ProtoMessage() {}
+func (*RefBlobRequest) ProtoMessage() {}
 
-func (x *IncrementUint32Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[63]
+func (x *RefBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[173]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4464,58 +12053,55 @@ func (x *IncrementUint32Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint32Response.ProtoReflect.Descriptor instead.
-func (*IncrementUint32Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{63}
+// Deprecated: Use RefBlobRequest.ProtoReflect.Descriptor instead.
+func (*RefBlobRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{173}
 }
 
-func (x *IncrementUint32Response) GetValue() uint32 {
+func (x *RefBlobRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.Value
+		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementUint32Response) GetIsIncremented() bool {
+func (x *RefBlobRequest) GetHash() string {
 	if x != nil {
-		return x.IsIncremented
+		return x.Hash
 	}
-	return false
+	return ""
 }
 
-type IncrementUint64Request struct {
+func (x *RefBlobRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type RefBlobResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the swamp where the treasure is located.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key identifies the treasure whose value will be incremented.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the amount to add.
-	//
-	// 💡 Supports the full range of unsigned 64-bit integers (0 to 18 quintillion+)
-	IncrementBy uint64 `protobuf:"varint,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition must be satisfied for the operation to take place.
-	Condition     *IncrementUint64Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	// References is the number of the references of the blob after the change.
+	References    int64 `protobuf:"varint,1,opt,name=References,proto3" json:"References,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint64Request) Reset() {
-	*x = IncrementUint64Request{}
-	mi := &file_hydraide_proto_msgTypes[64]
+func (x *RefBlobResponse) Reset() {
+	*x = RefBlobResponse{}
+	mi := &file_hydraide_proto_msgTypes[174]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint64Request) String() string {
+func (x *RefBlobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint64Request) ProtoMessage() {}
+func (*RefBlobResponse) ProtoMessage() {}
 
-func (x *IncrementUint64Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[64]
+func (x *RefBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[174]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4526,71 +12112,88 @@ func (x *IncrementUint64Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint64Request.ProtoReflect.Descriptor instead.
-func (*IncrementUint64Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{64}
+// Deprecated: Use RefBlobResponse.ProtoReflect.Descriptor instead.
+func (*RefBlobResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{174}
 }
 
-func (x *IncrementUint64Request) GetIslandID() uint64 {
+func (x *RefBlobResponse) GetReferences() int64 {
 	if x != nil {
-		return x.IslandID
+		return x.References
 	}
 	return 0
 }
 
-func (x *IncrementUint64Request) GetSwampName() string {
-	if x != nil {
-		return x.SwampName
-	}
-	return ""
+type CollectBlobGarbageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MinAgeSeconds is the time since the last reference of a blob was removed, after the blob can be collected.
+	MinAgeSeconds int64 `protobuf:"varint,1,opt,name=MinAgeSeconds,proto3" json:"MinAgeSeconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint64Request) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
+func (x *CollectBlobGarbageRequest) Reset() {
+	*x = CollectBlobGarbageRequest{}
+	mi := &file_hydraide_proto_msgTypes[175]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint64Request) GetIncrementBy() uint64 {
+func (x *CollectBlobGarbageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectBlobGarbageRequest) ProtoMessage() {}
+
+func (x *CollectBlobGarbageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[175]
 	if x != nil {
-		return x.IncrementBy
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *IncrementUint64Request) GetCondition() *IncrementUint64Condition {
+// Deprecated: Use CollectBlobGarbageRequest.ProtoReflect.Descriptor instead.
+func (*CollectBlobGarbageRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{175}
+}
+
+func (x *CollectBlobGarbageRequest) GetMinAgeSeconds() int64 {
 	if x != nil {
-		return x.Condition
+		return x.MinAgeSeconds
 	}
-	return nil
+	return 0
 }
 
-type IncrementUint64Condition struct {
+type CollectBlobGarbageResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Logical operator used to compare current value.
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// The uint64 value to compare the current treasure value against.
-	Value         uint64 `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// RemovedBlobs is the number of the removed blobs.
+	RemovedBlobs int64 `protobuf:"varint,1,opt,name=RemovedBlobs,proto3" json:"RemovedBlobs,omitempty"`
+	// FreedBytes is the disk space freed by the garbage collection.
+	FreedBytes    int64 `protobuf:"varint,2,opt,name=FreedBytes,proto3" json:"FreedBytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint64Condition) Reset() {
-	*x = IncrementUint64Condition{}
-	mi := &file_hydraide_proto_msgTypes[65]
+func (x *CollectBlobGarbageResponse) Reset() {
+	*x = CollectBlobGarbageResponse{}
+	mi := &file_hydraide_proto_msgTypes[176]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint64Condition) String() string {
+func (x *CollectBlobGarbageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint64Condition) ProtoMessage() {}
+func (*CollectBlobGarbageResponse) ProtoMessage() {}
 
-func (x *IncrementUint64Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[65]
+func (x *CollectBlobGarbageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[176]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4601,50 +12204,61 @@ func (x *IncrementUint64Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint64Condition.ProtoReflect.Descriptor instead.
-func (*IncrementUint64Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{65}
+// Deprecated: Use CollectBlobGarbageResponse.ProtoReflect.Descriptor instead.
+func (*CollectBlobGarbageResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{176}
 }
 
-func (x *IncrementUint64Condition) GetRelationalOperator() Relational_Operator {
+func (x *CollectBlobGarbageResponse) GetRemovedBlobs() int64 {
 	if x != nil {
-		return x.RelationalOperator
+		return x.RemovedBlobs
 	}
-	return Relational_EQUAL
+	return 0
 }
 
-func (x *IncrementUint64Condition) GetValue() uint64 {
+func (x *CollectBlobGarbageResponse) GetFreedBytes() int64 {
 	if x != nil {
-		return x.Value
+		return x.FreedBytes
 	}
 	return 0
 }
 
-type IncrementUint64Response struct {
+// QueryAuditLogRequest selects the records of the audit log. The unset fields match every record.
+type QueryAuditLogRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the resulting value after the operation (or the original).
-	Value uint64 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented is true if the operation was applied.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// From is the earliest time of the records, inclusive.
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
+	// To is the latest time of the records, exclusive.
+	To *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
+	// Method is the name of the RPC, e.g. "Delete".
+	Method string `protobuf:"bytes,3,opt,name=Method,proto3" json:"Method,omitempty"`
+	// ClientID is the client ID of the records, the hydraide-client-id metadata of the requests.
+	ClientID string `protobuf:"bytes,4,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	// SwampName is a swamp of the records.
+	SwampName string `protobuf:"bytes,5,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Key is a key of the records.
+	Key string `protobuf:"bytes,6,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Limit is the max number of the returned records. 0 means 100, the max is 10000.
+	Limit         int32 `protobuf:"varint,7,opt,name=Limit,proto3" json:"Limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementUint64Response) Reset() {
-	*x = IncrementUint64Response{}
-	mi := &file_hydraide_proto_msgTypes[66]
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_hydraide_proto_msgTypes[177]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementUint64Response) String() string {
+func (x *QueryAuditLogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementUint64Response) ProtoMessage() {}
+func (*QueryAuditLogRequest) ProtoMessage() {}
 
-func (x *IncrementUint64Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[66]
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[177]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4655,46 +12269,104 @@ func (x *IncrementUint64Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementUint64Response.ProtoReflect.Descriptor instead.
-func (*IncrementUint64Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{66}
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{177}
 }
 
-func (x *IncrementUint64Response) GetValue() uint64 {
+func (x *QueryAuditLogRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Value
+		return x.From
+	}
+	return nil
+}
+
+func (x *QueryAuditLogRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *QueryAuditLogRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetClientID() string {
+	if x != nil {
+		return x.ClientID
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetSwampName() string {
+	if x != nil {
+		return x.SwampName
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementUint64Response) GetIsIncremented() bool {
+func (x *QueryAuditLogRequest) GetLimit() int32 {
 	if x != nil {
-		return x.IsIncremented
+		return x.Limit
 	}
-	return false
+	return 0
 }
 
-type Relational struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// AuditRecord is a mutating request recorded by the audit log.
+type AuditRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time is the arrival of the request.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=Time,proto3" json:"Time,omitempty"`
+	// Method is the name of the RPC, e.g. "Delete".
+	Method string `protobuf:"bytes,2,opt,name=Method,proto3" json:"Method,omitempty"`
+	// ClientID is the hydraide-client-id metadata of the request, the tenant ID of the tenants.
+	ClientID string `protobuf:"bytes,3,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	// ClientIP is the IP address of the client.
+	ClientIP string `protobuf:"bytes,4,opt,name=ClientIP,proto3" json:"ClientIP,omitempty"`
+	// Tenant is the ID of the authenticated tenant. Empty on the single-tenant servers.
+	Tenant string `protobuf:"bytes,5,opt,name=Tenant,proto3" json:"Tenant,omitempty"`
+	// SwampNames are the swamps of the request, at most 32.
+	SwampNames []string `protobuf:"bytes,6,rep,name=SwampNames,proto3" json:"SwampNames,omitempty"`
+	// SwampCount is the number of the swamps of the request.
+	SwampCount int32 `protobuf:"varint,7,opt,name=SwampCount,proto3" json:"SwampCount,omitempty"`
+	// Keys are the keys of the request, at most 1000.
+	Keys []string `protobuf:"bytes,8,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// KeyCount is the number of the keys of the request.
+	KeyCount int32 `protobuf:"varint,9,opt,name=KeyCount,proto3" json:"KeyCount,omitempty"`
+	// Code is the gRPC status code of the result, e.g. "OK" or "NotFound".
+	Code string `protobuf:"bytes,10,opt,name=Code,proto3" json:"Code,omitempty"`
+	// Error is the error message of the failed request.
+	Error         string `protobuf:"bytes,11,opt,name=Error,proto3" json:"Error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Relational) Reset() {
-	*x = Relational{}
-	mi := &file_hydraide_proto_msgTypes[67]
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	mi := &file_hydraide_proto_msgTypes[178]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Relational) String() string {
+func (x *AuditRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Relational) ProtoMessage() {}
+func (*AuditRecord) ProtoMessage() {}
 
-func (x *Relational) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[67]
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[178]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4705,119 +12377,111 @@ func (x *Relational) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Relational.ProtoReflect.Descriptor instead.
-func (*Relational) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{67}
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{178}
 }
 
-type IncrementFloat32Request struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName identifies the swamp where the treasure is stored.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key points to the specific treasure we want to modify.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the amount to add (can be negative).
-	//
-	// 💡 Supports 32-bit floating point numbers.
-	IncrementBy float32 `protobuf:"fixed32,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition must be met for the change to happen.
-	Condition     *IncrementFloat32Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AuditRecord) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
 }
 
-func (x *IncrementFloat32Request) Reset() {
-	*x = IncrementFloat32Request{}
-	mi := &file_hydraide_proto_msgTypes[68]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AuditRecord) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
 }
 
-func (x *IncrementFloat32Request) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AuditRecord) GetClientID() string {
+	if x != nil {
+		return x.ClientID
+	}
+	return ""
 }
 
-func (*IncrementFloat32Request) ProtoMessage() {}
+func (x *AuditRecord) GetClientIP() string {
+	if x != nil {
+		return x.ClientIP
+	}
+	return ""
+}
 
-func (x *IncrementFloat32Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[68]
+func (x *AuditRecord) GetTenant() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Tenant
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use IncrementFloat32Request.ProtoReflect.Descriptor instead.
-func (*IncrementFloat32Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{68}
+func (x *AuditRecord) GetSwampNames() []string {
+	if x != nil {
+		return x.SwampNames
+	}
+	return nil
 }
 
-func (x *IncrementFloat32Request) GetIslandID() uint64 {
+func (x *AuditRecord) GetSwampCount() int32 {
 	if x != nil {
-		return x.IslandID
+		return x.SwampCount
 	}
 	return 0
 }
 
-func (x *IncrementFloat32Request) GetSwampName() string {
+func (x *AuditRecord) GetKeys() []string {
 	if x != nil {
-		return x.SwampName
+		return x.Keys
 	}
-	return ""
+	return nil
 }
 
-func (x *IncrementFloat32Request) GetKey() string {
+func (x *AuditRecord) GetKeyCount() int32 {
 	if x != nil {
-		return x.Key
+		return x.KeyCount
 	}
-	return ""
+	return 0
 }
 
-func (x *IncrementFloat32Request) GetIncrementBy() float32 {
+func (x *AuditRecord) GetCode() string {
 	if x != nil {
-		return x.IncrementBy
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementFloat32Request) GetCondition() *IncrementFloat32Condition {
+func (x *AuditRecord) GetError() string {
 	if x != nil {
-		return x.Condition
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-type IncrementFloat32Condition struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Logical check to evaluate before applying the increment.
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// The float value to compare the current treasure against.
-	Value         float32 `protobuf:"fixed32,2,opt,name=Value,proto3" json:"Value,omitempty"`
+// QueryAuditLogResponse contains the matching records, the newest first.
+type QueryAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*AuditRecord         `protobuf:"bytes,1,rep,name=Records,proto3" json:"Records,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementFloat32Condition) Reset() {
-	*x = IncrementFloat32Condition{}
-	mi := &file_hydraide_proto_msgTypes[69]
+func (x *QueryAuditLogResponse) Reset() {
+	*x = QueryAuditLogResponse{}
+	mi := &file_hydraide_proto_msgTypes[179]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementFloat32Condition) String() string {
+func (x *QueryAuditLogResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementFloat32Condition) ProtoMessage() {}
+func (*QueryAuditLogResponse) ProtoMessage() {}
 
-func (x *IncrementFloat32Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[69]
+func (x *QueryAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[179]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4828,50 +12492,43 @@ func (x *IncrementFloat32Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementFloat32Condition.ProtoReflect.Descriptor instead.
-func (*IncrementFloat32Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{69}
-}
-
-func (x *IncrementFloat32Condition) GetRelationalOperator() Relational_Operator {
-	if x != nil {
-		return x.RelationalOperator
-	}
-	return Relational_EQUAL
+// Deprecated: Use QueryAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{179}
 }
 
-func (x *IncrementFloat32Condition) GetValue() float32 {
+func (x *QueryAuditLogResponse) GetRecords() []*AuditRecord {
 	if x != nil {
-		return x.Value
+		return x.Records
 	}
-	return 0
+	return nil
 }
 
-type IncrementFloat32Response struct {
+type VerifyIslandMappingRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the result after the (conditional) increment.
-	Value float32 `protobuf:"fixed32,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented is true if the change was applied.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+	// SwampName is the name of the swamp in the format sanctuary/realm/swamp.
+	SwampName string `protobuf:"bytes,1,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// AllIslands is the number of all islands of the client.
+	AllIslands    uint64 `protobuf:"varint,2,opt,name=AllIslands,proto3" json:"AllIslands,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementFloat32Response) Reset() {
-	*x = IncrementFloat32Response{}
-	mi := &file_hydraide_proto_msgTypes[70]
+func (x *VerifyIslandMappingRequest) Reset() {
+	*x = VerifyIslandMappingRequest{}
+	mi := &file_hydraide_proto_msgTypes[180]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementFloat32Response) String() string {
+func (x *VerifyIslandMappingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementFloat32Response) ProtoMessage() {}
+func (*VerifyIslandMappingRequest) ProtoMessage() {}
 
-func (x *IncrementFloat32Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[70]
+func (x *VerifyIslandMappingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[180]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4882,59 +12539,50 @@ func (x *IncrementFloat32Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementFloat32Response.ProtoReflect.Descriptor instead.
-func (*IncrementFloat32Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{70}
+// Deprecated: Use VerifyIslandMappingRequest.ProtoReflect.Descriptor instead.
+func (*VerifyIslandMappingRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{180}
 }
 
-func (x *IncrementFloat32Response) GetValue() float32 {
+func (x *VerifyIslandMappingRequest) GetSwampName() string {
 	if x != nil {
-		return x.Value
+		return x.SwampName
 	}
-	return 0
+	return ""
 }
 
-func (x *IncrementFloat32Response) GetIsIncremented() bool {
+func (x *VerifyIslandMappingRequest) GetAllIslands() uint64 {
 	if x != nil {
-		return x.IsIncremented
+		return x.AllIslands
 	}
-	return false
+	return 0
 }
 
-type IncrementFloat64Request struct {
+type VerifyIslandMappingResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	// IslandID is the 1-based island the server computes for the swamp.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the target swamp where the treasure lives.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key identifies the treasure to be incremented.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// IncrementBy is the floating-point value to add.
-	//
-	// ✅ Can be positive or negative.
-	// ✅ Uses 64-bit floating-point precision.
-	IncrementBy float64 `protobuf:"fixed64,4,opt,name=IncrementBy,proto3" json:"IncrementBy,omitempty"`
-	// Condition that must be true for the increment to apply.
-	Condition     *IncrementFloat64Condition `protobuf:"bytes,5,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	// HashFunction is the human-readable description of the hash function of the islands, for the authors of the SDKs.
+	HashFunction  string `protobuf:"bytes,2,opt,name=HashFunction,proto3" json:"HashFunction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IncrementFloat64Request) Reset() {
-	*x = IncrementFloat64Request{}
-	mi := &file_hydraide_proto_msgTypes[71]
+func (x *VerifyIslandMappingResponse) Reset() {
+	*x = VerifyIslandMappingResponse{}
+	mi := &file_hydraide_proto_msgTypes[181]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementFloat64Request) String() string {
+func (x *VerifyIslandMappingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementFloat64Request) ProtoMessage() {}
+func (*VerifyIslandMappingResponse) ProtoMessage() {}
 
-func (x *IncrementFloat64Request) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[71]
+func (x *VerifyIslandMappingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[181]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4945,71 +12593,119 @@ func (x *IncrementFloat64Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementFloat64Request.ProtoReflect.Descriptor instead.
-func (*IncrementFloat64Request) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{71}
+// Deprecated: Use VerifyIslandMappingResponse.ProtoReflect.Descriptor instead.
+func (*VerifyIslandMappingResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{181}
 }
 
-func (x *IncrementFloat64Request) GetIslandID() uint64 {
+func (x *VerifyIslandMappingResponse) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *IncrementFloat64Request) GetSwampName() string {
+func (x *VerifyIslandMappingResponse) GetHashFunction() string {
 	if x != nil {
-		return x.SwampName
+		return x.HashFunction
 	}
 	return ""
 }
 
-func (x *IncrementFloat64Request) GetKey() string {
+type RestorePointInTimeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Until is the point in time, the swamps get their state at this time. It can not be in the future.
+	Until *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=Until,proto3" json:"Until,omitempty"`
+	// Patterns select the restored swamps by swamp name patterns, e.g. "users/profiles/*".
+	Patterns []string `protobuf:"bytes,2,rep,name=Patterns,proto3" json:"Patterns,omitempty"`
+	// IslandIDs select the restored swamps by their islands. The swamps matching a pattern or an island are restored.
+	IslandIDs     []uint64 `protobuf:"varint,3,rep,packed,name=IslandIDs,proto3" json:"IslandIDs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePointInTimeRequest) Reset() {
+	*x = RestorePointInTimeRequest{}
+	mi := &file_hydraide_proto_msgTypes[182]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePointInTimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePointInTimeRequest) ProtoMessage() {}
+
+func (x *RestorePointInTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[182]
 	if x != nil {
-		return x.Key
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *IncrementFloat64Request) GetIncrementBy() float64 {
+// Deprecated: Use RestorePointInTimeRequest.ProtoReflect.Descriptor instead.
+func (*RestorePointInTimeRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{182}
+}
+
+func (x *RestorePointInTimeRequest) GetUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.IncrementBy
+		return x.Until
 	}
-	return 0
+	return nil
 }
 
-func (x *IncrementFloat64Request) GetCondition() *IncrementFloat64Condition {
+func (x *RestorePointInTimeRequest) GetPatterns() []string {
 	if x != nil {
-		return x.Condition
+		return x.Patterns
 	}
 	return nil
 }
 
-type IncrementFloat64Condition struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Comparison operator (e.g. GREATER_THAN, EQUAL, etc.)
-	RelationalOperator Relational_Operator `protobuf:"varint,1,opt,name=RelationalOperator,proto3,enum=hydraidepbgo.Relational_Operator" json:"RelationalOperator,omitempty"`
-	// Reference value to evaluate the current treasure against.
-	Value         float64 `protobuf:"fixed64,2,opt,name=Value,proto3" json:"Value,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RestorePointInTimeRequest) GetIslandIDs() []uint64 {
+	if x != nil {
+		return x.IslandIDs
+	}
+	return nil
 }
 
-func (x *IncrementFloat64Condition) Reset() {
-	*x = IncrementFloat64Condition{}
-	mi := &file_hydraide_proto_msgTypes[72]
+type RestorePointInTimeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// BackupID is the ID of the backup the restore started from.
+	BackupID string `protobuf:"bytes,1,opt,name=BackupID,proto3" json:"BackupID,omitempty"`
+	// Swamps is the number of the restored swamps.
+	Swamps int64 `protobuf:"varint,2,opt,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// ReplayedRecords is the number of the records of the write-ahead log replayed on the backup.
+	ReplayedRecords int64 `protobuf:"varint,3,opt,name=ReplayedRecords,proto3" json:"ReplayedRecords,omitempty"`
+	// RestoredTreasures is the number of the treasures written back to the swamps.
+	RestoredTreasures int64 `protobuf:"varint,4,opt,name=RestoredTreasures,proto3" json:"RestoredTreasures,omitempty"`
+	// DeletedTreasures is the number of the treasures deleted, because they did not exist at the point in time.
+	DeletedTreasures int64 `protobuf:"varint,5,opt,name=DeletedTreasures,proto3" json:"DeletedTreasures,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestorePointInTimeResponse) Reset() {
+	*x = RestorePointInTimeResponse{}
+	mi := &file_hydraide_proto_msgTypes[183]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementFloat64Condition) String() string {
+func (x *RestorePointInTimeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementFloat64Condition) ProtoMessage() {}
+func (*RestorePointInTimeResponse) ProtoMessage() {}
 
-func (x *IncrementFloat64Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[72]
+func (x *RestorePointInTimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[183]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5020,50 +12716,67 @@ func (x *IncrementFloat64Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementFloat64Condition.ProtoReflect.Descriptor instead.
-func (*IncrementFloat64Condition) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{72}
+// Deprecated: Use RestorePointInTimeResponse.ProtoReflect.Descriptor instead.
+func (*RestorePointInTimeResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{183}
 }
 
-func (x *IncrementFloat64Condition) GetRelationalOperator() Relational_Operator {
+func (x *RestorePointInTimeResponse) GetBackupID() string {
 	if x != nil {
-		return x.RelationalOperator
+		return x.BackupID
 	}
-	return Relational_EQUAL
+	return ""
 }
 
-func (x *IncrementFloat64Condition) GetValue() float64 {
+func (x *RestorePointInTimeResponse) GetSwamps() int64 {
 	if x != nil {
-		return x.Value
+		return x.Swamps
 	}
 	return 0
 }
 
-type IncrementFloat64Response struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value is the new (or unchanged) value after the request.
-	Value float64 `protobuf:"fixed64,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	// IsIncremented tells if the change actually occurred.
-	IsIncremented bool `protobuf:"varint,2,opt,name=IsIncremented,proto3" json:"IsIncremented,omitempty"`
+func (x *RestorePointInTimeResponse) GetReplayedRecords() int64 {
+	if x != nil {
+		return x.ReplayedRecords
+	}
+	return 0
+}
+
+func (x *RestorePointInTimeResponse) GetRestoredTreasures() int64 {
+	if x != nil {
+		return x.RestoredTreasures
+	}
+	return 0
+}
+
+func (x *RestorePointInTimeResponse) GetDeletedTreasures() int64 {
+	if x != nil {
+		return x.DeletedTreasures
+	}
+	return 0
+}
+
+type GetClusterTopologyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
-
-func (x *IncrementFloat64Response) Reset() {
-	*x = IncrementFloat64Response{}
-	mi := &file_hydraide_proto_msgTypes[73]
+
+func (x *GetClusterTopologyRequest) Reset() {
+	*x = GetClusterTopologyRequest{}
+	mi := &file_hydraide_proto_msgTypes[184]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IncrementFloat64Response) String() string {
+func (x *GetClusterTopologyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrementFloat64Response) ProtoMessage() {}
+func (*GetClusterTopologyRequest) ProtoMessage() {}
 
-func (x *IncrementFloat64Response) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[73]
+func (x *GetClusterTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[184]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5074,52 +12787,38 @@ func (x *IncrementFloat64Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrementFloat64Response.ProtoReflect.Descriptor instead.
-func (*IncrementFloat64Response) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{73}
-}
-
-func (x *IncrementFloat64Response) GetValue() float64 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
-}
-
-func (x *IncrementFloat64Response) GetIsIncremented() bool {
-	if x != nil {
-		return x.IsIncremented
-	}
-	return false
+// Deprecated: Use GetClusterTopologyRequest.ProtoReflect.Descriptor instead.
+func (*GetClusterTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{184}
 }
 
-// KeySlicePair represents a mapping between a key and a list of uint32 values.
-// Used for slice-related operations like push and delete.
-type KeySlicePair struct {
+type ClusterServer struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Key identifies the treasure containing the uint32 slice.
-	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	// Values are the uint32 values to add or remove.
-	Values        []uint32 `protobuf:"varint,2,rep,packed,name=Values,proto3" json:"Values,omitempty"`
+	// Host is the gRPC endpoint of the server, e.g. hydra01:4444.
+	Host string `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
+	// FromIsland is the first island of the server, inclusive.
+	FromIsland uint64 `protobuf:"varint,2,opt,name=FromIsland,proto3" json:"FromIsland,omitempty"`
+	// ToIsland is the last island of the server, inclusive.
+	ToIsland      uint64 `protobuf:"varint,3,opt,name=ToIsland,proto3" json:"ToIsland,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeySlicePair) Reset() {
-	*x = KeySlicePair{}
-	mi := &file_hydraide_proto_msgTypes[74]
+func (x *ClusterServer) Reset() {
+	*x = ClusterServer{}
+	mi := &file_hydraide_proto_msgTypes[185]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeySlicePair) String() string {
+func (x *ClusterServer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeySlicePair) ProtoMessage() {}
+func (*ClusterServer) ProtoMessage() {}
 
-func (x *KeySlicePair) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[74]
+func (x *ClusterServer) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[185]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5130,53 +12829,59 @@ func (x *KeySlicePair) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeySlicePair.ProtoReflect.Descriptor instead.
-func (*KeySlicePair) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{74}
+// Deprecated: Use ClusterServer.ProtoReflect.Descriptor instead.
+func (*ClusterServer) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{185}
 }
 
-func (x *KeySlicePair) GetKey() string {
+func (x *ClusterServer) GetHost() string {
 	if x != nil {
-		return x.Key
+		return x.Host
 	}
 	return ""
 }
 
-func (x *KeySlicePair) GetValues() []uint32 {
+func (x *ClusterServer) GetFromIsland() uint64 {
 	if x != nil {
-		return x.Values
+		return x.FromIsland
 	}
-	return nil
+	return 0
 }
 
-// AddToUint32SlicePushRequest adds one or more uint32 values to one or more keys.
-type AddToUint32SlicePushRequest struct {
+func (x *ClusterServer) GetToIsland() uint64 {
+	if x != nil {
+		return x.ToIsland
+	}
+	return 0
+}
+
+type GetClusterTopologyResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp containing the target slices.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// KeySlicePairs contains one or more key → value(s) mappings to push into the slices.
-	KeySlicePairs []*KeySlicePair `protobuf:"bytes,3,rep,name=KeySlicePairs,proto3" json:"KeySlicePairs,omitempty"`
+	// Version is the hash of the topology, it changes with every change of the topology.
+	Version string `protobuf:"bytes,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	// AllIslands is the number of all islands of the cluster.
+	AllIslands uint64 `protobuf:"varint,2,opt,name=AllIslands,proto3" json:"AllIslands,omitempty"`
+	// Servers are the servers of the cluster. Their island ranges cover every island exactly once.
+	Servers       []*ClusterServer `protobuf:"bytes,3,rep,name=Servers,proto3" json:"Servers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddToUint32SlicePushRequest) Reset() {
-	*x = AddToUint32SlicePushRequest{}
-	mi := &file_hydraide_proto_msgTypes[75]
+func (x *GetClusterTopologyResponse) Reset() {
+	*x = GetClusterTopologyResponse{}
+	mi := &file_hydraide_proto_msgTypes[186]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddToUint32SlicePushRequest) String() string {
+func (x *GetClusterTopologyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddToUint32SlicePushRequest) ProtoMessage() {}
+func (*GetClusterTopologyResponse) ProtoMessage() {}
 
-func (x *AddToUint32SlicePushRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[75]
+func (x *GetClusterTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[186]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5187,55 +12892,60 @@ func (x *AddToUint32SlicePushRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddToUint32SlicePushRequest.ProtoReflect.Descriptor instead.
-func (*AddToUint32SlicePushRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{75}
+// Deprecated: Use GetClusterTopologyResponse.ProtoReflect.Descriptor instead.
+func (*GetClusterTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{186}
 }
 
-func (x *AddToUint32SlicePushRequest) GetIslandID() uint64 {
+func (x *GetClusterTopologyResponse) GetVersion() string {
 	if x != nil {
-		return x.IslandID
+		return x.Version
 	}
-	return 0
+	return ""
 }
 
-func (x *AddToUint32SlicePushRequest) GetSwampName() string {
+func (x *GetClusterTopologyResponse) GetAllIslands() uint64 {
 	if x != nil {
-		return x.SwampName
+		return x.AllIslands
 	}
-	return ""
+	return 0
 }
 
-func (x *AddToUint32SlicePushRequest) GetKeySlicePairs() []*KeySlicePair {
+func (x *GetClusterTopologyResponse) GetServers() []*ClusterServer {
 	if x != nil {
-		return x.KeySlicePairs
+		return x.Servers
 	}
 	return nil
 }
 
-// AddToUint32SlicePushResponse is returned after a successful push operation.
-// This message is intentionally empty.
-type AddToUint32SlicePushResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type SetClusterTopologyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ExpectedVersion is the current version of the topology of the server, empty if the server has no valid topology
+	// yet.
+	ExpectedVersion string `protobuf:"bytes,1,opt,name=ExpectedVersion,proto3" json:"ExpectedVersion,omitempty"`
+	// AllIslands is the number of all islands of the cluster.
+	AllIslands uint64 `protobuf:"varint,2,opt,name=AllIslands,proto3" json:"AllIslands,omitempty"`
+	// Servers are the servers of the cluster. Their island ranges must cover every island exactly once.
+	Servers       []*ClusterServer `protobuf:"bytes,3,rep,name=Servers,proto3" json:"Servers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddToUint32SlicePushResponse) Reset() {
-	*x = AddToUint32SlicePushResponse{}
-	mi := &file_hydraide_proto_msgTypes[76]
+func (x *SetClusterTopologyRequest) Reset() {
+	*x = SetClusterTopologyRequest{}
+	mi := &file_hydraide_proto_msgTypes[187]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddToUint32SlicePushResponse) String() string {
+func (x *SetClusterTopologyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddToUint32SlicePushResponse) ProtoMessage() {}
+func (*SetClusterTopologyRequest) ProtoMessage() {}
 
-func (x *AddToUint32SlicePushResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[76]
+func (x *SetClusterTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[187]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5246,39 +12956,55 @@ func (x *AddToUint32SlicePushResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddToUint32SlicePushResponse.ProtoReflect.Descriptor instead.
-func (*AddToUint32SlicePushResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{76}
+// Deprecated: Use SetClusterTopologyRequest.ProtoReflect.Descriptor instead.
+func (*SetClusterTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{187}
 }
 
-// Uint32SliceDeleteRequest removes one or more values from one or more uint32 slices.
-type Uint32SliceDeleteRequest struct {
+func (x *SetClusterTopologyRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+func (x *SetClusterTopologyRequest) GetAllIslands() uint64 {
+	if x != nil {
+		return x.AllIslands
+	}
+	return 0
+}
+
+func (x *SetClusterTopologyRequest) GetServers() []*ClusterServer {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type SetClusterTopologyResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the slices are located.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// KeySlicePairs contains the keys and values to remove from the corresponding slices.
-	KeySlicePairs []*KeySlicePair `protobuf:"bytes,3,rep,name=KeySlicePairs,proto3" json:"KeySlicePairs,omitempty"`
+	// Version is the version of the new topology.
+	Version       string `protobuf:"bytes,1,opt,name=Version,proto3" json:"Version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Uint32SliceDeleteRequest) Reset() {
-	*x = Uint32SliceDeleteRequest{}
-	mi := &file_hydraide_proto_msgTypes[77]
+func (x *SetClusterTopologyResponse) Reset() {
+	*x = SetClusterTopologyResponse{}
+	mi := &file_hydraide_proto_msgTypes[188]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Uint32SliceDeleteRequest) String() string {
+func (x *SetClusterTopologyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Uint32SliceDeleteRequest) ProtoMessage() {}
+func (*SetClusterTopologyResponse) ProtoMessage() {}
 
-func (x *Uint32SliceDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[77]
+func (x *SetClusterTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[188]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5289,55 +13015,39 @@ func (x *Uint32SliceDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Uint32SliceDeleteRequest.ProtoReflect.Descriptor instead.
-func (*Uint32SliceDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{77}
-}
-
-func (x *Uint32SliceDeleteRequest) GetIslandID() uint64 {
-	if x != nil {
-		return x.IslandID
-	}
-	return 0
+// Deprecated: Use SetClusterTopologyResponse.ProtoReflect.Descriptor instead.
+func (*SetClusterTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{188}
 }
 
-func (x *Uint32SliceDeleteRequest) GetSwampName() string {
+func (x *SetClusterTopologyResponse) GetVersion() string {
 	if x != nil {
-		return x.SwampName
+		return x.Version
 	}
 	return ""
 }
 
-func (x *Uint32SliceDeleteRequest) GetKeySlicePairs() []*KeySlicePair {
-	if x != nil {
-		return x.KeySlicePairs
-	}
-	return nil
-}
-
-// Uint32SliceDeleteResponse is returned after a successful delete operation.
-// This message is intentionally empty.
-type Uint32SliceDeleteResponse struct {
+type IslandState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Uint32SliceDeleteResponse) Reset() {
-	*x = Uint32SliceDeleteResponse{}
-	mi := &file_hydraide_proto_msgTypes[78]
+func (x *IslandState) Reset() {
+	*x = IslandState{}
+	mi := &file_hydraide_proto_msgTypes[189]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Uint32SliceDeleteResponse) String() string {
+func (x *IslandState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Uint32SliceDeleteResponse) ProtoMessage() {}
+func (*IslandState) ProtoMessage() {}
 
-func (x *Uint32SliceDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[78]
+func (x *IslandState) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[189]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5348,39 +13058,38 @@ func (x *Uint32SliceDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Uint32SliceDeleteResponse.ProtoReflect.Descriptor instead.
-func (*Uint32SliceDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{78}
+// Deprecated: Use IslandState.ProtoReflect.Descriptor instead.
+func (*IslandState) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{189}
 }
 
-// Uint32SliceSizeRequest queries the size (number of elements) of a specific uint32 slice.
-type Uint32SliceSizeRequest struct {
+type SetIslandStateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
+	// IslandID is the Island whose state is set.
 	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp containing the slice.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the identifier of the treasure whose slice we want to measure.
-	Key           string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	// State is the new state of the Island.
+	State IslandState_State `protobuf:"varint,2,opt,name=State,proto3,enum=hydraidepbgo.IslandState_State" json:"State,omitempty"`
+	// MovedTo is the host of the new server of the Island, required for the MOVED state.
+	MovedTo       string `protobuf:"bytes,3,opt,name=MovedTo,proto3" json:"MovedTo,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Uint32SliceSizeRequest) Reset() {
-	*x = Uint32SliceSizeRequest{}
-	mi := &file_hydraide_proto_msgTypes[79]
+func (x *SetIslandStateRequest) Reset() {
+	*x = SetIslandStateRequest{}
+	mi := &file_hydraide_proto_msgTypes[190]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Uint32SliceSizeRequest) String() string {
+func (x *SetIslandStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Uint32SliceSizeRequest) ProtoMessage() {}
+func (*SetIslandStateRequest) ProtoMessage() {}
 
-func (x *Uint32SliceSizeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[79]
+func (x *SetIslandStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[190]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5391,56 +13100,53 @@ func (x *Uint32SliceSizeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Uint32SliceSizeRequest.ProtoReflect.Descriptor instead.
-func (*Uint32SliceSizeRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{79}
+// Deprecated: Use SetIslandStateRequest.ProtoReflect.Descriptor instead.
+func (*SetIslandStateRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{190}
 }
 
-func (x *Uint32SliceSizeRequest) GetIslandID() uint64 {
+func (x *SetIslandStateRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *Uint32SliceSizeRequest) GetSwampName() string {
+func (x *SetIslandStateRequest) GetState() IslandState_State {
 	if x != nil {
-		return x.SwampName
+		return x.State
 	}
-	return ""
+	return IslandState_ACTIVE
 }
 
-func (x *Uint32SliceSizeRequest) GetKey() string {
+func (x *SetIslandStateRequest) GetMovedTo() string {
 	if x != nil {
-		return x.Key
+		return x.MovedTo
 	}
 	return ""
 }
 
-// Uint32SliceSizeResponse returns the current number of values in the requested slice.
-type Uint32SliceSizeResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Size is the total number of uint32 values in the slice.
-	Size          int64 `protobuf:"varint,1,opt,name=Size,proto3" json:"Size,omitempty"`
+type SetIslandStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Uint32SliceSizeResponse) Reset() {
-	*x = Uint32SliceSizeResponse{}
-	mi := &file_hydraide_proto_msgTypes[80]
+func (x *SetIslandStateResponse) Reset() {
+	*x = SetIslandStateResponse{}
+	mi := &file_hydraide_proto_msgTypes[191]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Uint32SliceSizeResponse) String() string {
+func (x *SetIslandStateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Uint32SliceSizeResponse) ProtoMessage() {}
+func (*SetIslandStateResponse) ProtoMessage() {}
 
-func (x *Uint32SliceSizeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[80]
+func (x *SetIslandStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[191]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5451,48 +13157,34 @@ func (x *Uint32SliceSizeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Uint32SliceSizeResponse.ProtoReflect.Descriptor instead.
-func (*Uint32SliceSizeResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{80}
-}
-
-func (x *Uint32SliceSizeResponse) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
+// Deprecated: Use SetIslandStateResponse.ProtoReflect.Descriptor instead.
+func (*SetIslandStateResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{191}
 }
 
-// Uint32SliceIsValueExistRequest checks if a specific uint32 value exists in a slice.
-type Uint32SliceIsValueExistRequest struct {
+type ExportIslandRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp containing the slice.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the treasure containing the slice.
-	Key string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	// Value is the uint32 number we want to check for existence.
-	Value         uint32 `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	// IslandID is the Island to export, it must be FROZEN.
+	IslandID      uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Uint32SliceIsValueExistRequest) Reset() {
-	*x = Uint32SliceIsValueExistRequest{}
-	mi := &file_hydraide_proto_msgTypes[81]
+func (x *ExportIslandRequest) Reset() {
+	*x = ExportIslandRequest{}
+	mi := &file_hydraide_proto_msgTypes[192]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Uint32SliceIsValueExistRequest) String() string {
+func (x *ExportIslandRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Uint32SliceIsValueExistRequest) ProtoMessage() {}
+func (*ExportIslandRequest) ProtoMessage() {}
 
-func (x *Uint32SliceIsValueExistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[81]
+func (x *ExportIslandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[192]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5503,63 +13195,99 @@ func (x *Uint32SliceIsValueExistRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Uint32SliceIsValueExistRequest.ProtoReflect.Descriptor instead.
-func (*Uint32SliceIsValueExistRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{81}
+// Deprecated: Use ExportIslandRequest.ProtoReflect.Descriptor instead.
+func (*ExportIslandRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{192}
 }
 
-func (x *Uint32SliceIsValueExistRequest) GetIslandID() uint64 {
+func (x *ExportIslandRequest) GetIslandID() uint64 {
 	if x != nil {
 		return x.IslandID
 	}
 	return 0
 }
 
-func (x *Uint32SliceIsValueExistRequest) GetSwampName() string {
+type ExportIslandResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chunk is the next part of the archive.
+	Chunk []byte `protobuf:"bytes,1,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
+	// Manifest is the JSON manifest of the archive, only in the last message.
+	Manifest      []byte `protobuf:"bytes,2,opt,name=Manifest,proto3" json:"Manifest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportIslandResponse) Reset() {
+	*x = ExportIslandResponse{}
+	mi := &file_hydraide_proto_msgTypes[193]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportIslandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportIslandResponse) ProtoMessage() {}
+
+func (x *ExportIslandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[193]
 	if x != nil {
-		return x.SwampName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Uint32SliceIsValueExistRequest) GetKey() string {
+// Deprecated: Use ExportIslandResponse.ProtoReflect.Descriptor instead.
+func (*ExportIslandResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{193}
+}
+
+func (x *ExportIslandResponse) GetChunk() []byte {
 	if x != nil {
-		return x.Key
+		return x.Chunk
 	}
-	return ""
+	return nil
 }
 
-func (x *Uint32SliceIsValueExistRequest) GetValue() uint32 {
+func (x *ExportIslandResponse) GetManifest() []byte {
 	if x != nil {
-		return x.Value
+		return x.Manifest
 	}
-	return 0
+	return nil
 }
 
-// Uint32SliceIsValueExistResponse returns whether the specified value is present in the slice.
-type Uint32SliceIsValueExistResponse struct {
+type ImportIslandRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IsExist is true if the value is present in the slice, false otherwise.
-	IsExist       bool `protobuf:"varint,1,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+	// IslandID is the Island to import. Only in the first message.
+	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
+	// Chunk is the next part of the archive.
+	Chunk []byte `protobuf:"bytes,2,opt,name=Chunk,proto3" json:"Chunk,omitempty"`
+	// Manifest is the JSON manifest of the archive, only in the last message.
+	Manifest      []byte `protobuf:"bytes,3,opt,name=Manifest,proto3" json:"Manifest,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Uint32SliceIsValueExistResponse) Reset() {
-	*x = Uint32SliceIsValueExistResponse{}
-	mi := &file_hydraide_proto_msgTypes[82]
+func (x *ImportIslandRequest) Reset() {
+	*x = ImportIslandRequest{}
+	mi := &file_hydraide_proto_msgTypes[194]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Uint32SliceIsValueExistResponse) String() string {
+func (x *ImportIslandRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Uint32SliceIsValueExistResponse) ProtoMessage() {}
+func (*ImportIslandRequest) ProtoMessage() {}
 
-func (x *Uint32SliceIsValueExistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[82]
+func (x *ImportIslandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[194]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5570,44 +13298,57 @@ func (x *Uint32SliceIsValueExistResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Uint32SliceIsValueExistResponse.ProtoReflect.Descriptor instead.
-func (*Uint32SliceIsValueExistResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{82}
+// Deprecated: Use ImportIslandRequest.ProtoReflect.Descriptor instead.
+func (*ImportIslandRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{194}
 }
 
-func (x *Uint32SliceIsValueExistResponse) GetIsExist() bool {
+func (x *ImportIslandRequest) GetIslandID() uint64 {
 	if x != nil {
-		return x.IsExist
+		return x.IslandID
 	}
-	return false
+	return 0
 }
 
-// IsSwampExistRequest checks whether a specific swamp exists in the current sanctuary.
-type IsSwampExistRequest struct {
+func (x *ImportIslandRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *ImportIslandRequest) GetManifest() []byte {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+type ImportIslandResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp to check.
-	SwampName     string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
+	// Swamps is the number of the imported Swamps and blob folders.
+	Swamps uint64 `protobuf:"varint,1,opt,name=Swamps,proto3" json:"Swamps,omitempty"`
+	// Files is the number of the imported files.
+	Files         uint64 `protobuf:"varint,2,opt,name=Files,proto3" json:"Files,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IsSwampExistRequest) Reset() {
-	*x = IsSwampExistRequest{}
-	mi := &file_hydraide_proto_msgTypes[83]
+func (x *ImportIslandResponse) Reset() {
+	*x = ImportIslandResponse{}
+	mi := &file_hydraide_proto_msgTypes[195]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsSwampExistRequest) String() string {
+func (x *ImportIslandResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsSwampExistRequest) ProtoMessage() {}
+func (*ImportIslandResponse) ProtoMessage() {}
 
-func (x *IsSwampExistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[83]
+func (x *ImportIslandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[195]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5618,49 +13359,46 @@ func (x *IsSwampExistRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsSwampExistRequest.ProtoReflect.Descriptor instead.
-func (*IsSwampExistRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{83}
+// Deprecated: Use ImportIslandResponse.ProtoReflect.Descriptor instead.
+func (*ImportIslandResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{195}
 }
 
-func (x *IsSwampExistRequest) GetIslandID() uint64 {
+func (x *ImportIslandResponse) GetSwamps() uint64 {
 	if x != nil {
-		return x.IslandID
+		return x.Swamps
 	}
 	return 0
 }
 
-func (x *IsSwampExistRequest) GetSwampName() string {
+func (x *ImportIslandResponse) GetFiles() uint64 {
 	if x != nil {
-		return x.SwampName
+		return x.Files
 	}
-	return ""
+	return 0
 }
 
-// IsSwampExistResponse returns the existence status of the swamp.
-type IsSwampExistResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// IsExist will be true if the swamp exists, false otherwise.
-	IsExist       bool `protobuf:"varint,1,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
+type ReloadDefaultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IsSwampExistResponse) Reset() {
-	*x = IsSwampExistResponse{}
-	mi := &file_hydraide_proto_msgTypes[84]
+func (x *ReloadDefaultsRequest) Reset() {
+	*x = ReloadDefaultsRequest{}
+	mi := &file_hydraide_proto_msgTypes[196]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsSwampExistResponse) String() string {
+func (x *ReloadDefaultsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsSwampExistResponse) ProtoMessage() {}
+func (*ReloadDefaultsRequest) ProtoMessage() {}
 
-func (x *IsSwampExistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[84]
+func (x *ReloadDefaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[196]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5671,46 +13409,44 @@ func (x *IsSwampExistResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsSwampExistResponse.ProtoReflect.Descriptor instead.
-func (*IsSwampExistResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{84}
-}
-
-func (x *IsSwampExistResponse) GetIsExist() bool {
-	if x != nil {
-		return x.IsExist
-	}
-	return false
+// Deprecated: Use ReloadDefaultsRequest.ProtoReflect.Descriptor instead.
+func (*ReloadDefaultsRequest) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{196}
 }
 
-// IsKeyExistRequest checks whether a specific key exists within a given swamp.
-type IsKeyExistRequest struct {
+type ReloadDefaultsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// IslandID is the deterministic storage zone (or "island") where this Swamp lives.
-	IslandID uint64 `protobuf:"varint,1,opt,name=IslandID,proto3" json:"IslandID,omitempty"`
-	// SwampName is the name of the swamp where the key is expected.
-	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
-	// Key is the identifier of the treasure to check for existence.
-	Key           string `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *IsKeyExistRequest) Reset() {
-	*x = IsKeyExistRequest{}
-	mi := &file_hydraide_proto_msgTypes[85]
+	// CloseAfterIdle is the default close after idle time of the swamps in seconds.
+	CloseAfterIdle int64 `protobuf:"varint,1,opt,name=CloseAfterIdle,proto3" json:"CloseAfterIdle,omitempty"`
+	// WriteInterval is the default write interval of the swamps in seconds.
+	WriteInterval int64 `protobuf:"varint,2,opt,name=WriteInterval,proto3" json:"WriteInterval,omitempty"`
+	// MaxFileSize is the default max size of the chunk files of the swamps in bytes.
+	MaxFileSize int64 `protobuf:"varint,3,opt,name=MaxFileSize,proto3" json:"MaxFileSize,omitempty"`
+	// Fsync is the default fsync policy of the swamps.
+	Fsync FsyncPolicy_Policy `protobuf:"varint,4,opt,name=Fsync,proto3,enum=hydraidepbgo.FsyncPolicy_Policy" json:"Fsync,omitempty"`
+	// FsyncInterval is the default min time between two fsyncs of the swamps with the INTERVAL policy in seconds.
+	FsyncInterval int64 `protobuf:"varint,5,opt,name=FsyncInterval,proto3" json:"FsyncInterval,omitempty"`
+	// ApplyToUnregisteredSwamps is true if the swamps without a registered pattern use the defaults, too.
+	ApplyToUnregisteredSwamps bool `protobuf:"varint,6,opt,name=ApplyToUnregisteredSwamps,proto3" json:"ApplyToUnregisteredSwamps,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ReloadDefaultsResponse) Reset() {
+	*x = ReloadDefaultsResponse{}
+	mi := &file_hydraide_proto_msgTypes[197]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsKeyExistRequest) String() string {
+func (x *ReloadDefaultsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsKeyExistRequest) ProtoMessage() {}
+func (*ReloadDefaultsResponse) ProtoMessage() {}
 
-func (x *IsKeyExistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[85]
+func (x *ReloadDefaultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydraide_proto_msgTypes[197]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5721,74 +13457,49 @@ func (x *IsKeyExistRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsKeyExistRequest.ProtoReflect.Descriptor instead.
-func (*IsKeyExistRequest) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{85}
+// Deprecated: Use ReloadDefaultsResponse.ProtoReflect.Descriptor instead.
+func (*ReloadDefaultsResponse) Descriptor() ([]byte, []int) {
+	return file_hydraide_proto_rawDescGZIP(), []int{197}
 }
 
-func (x *IsKeyExistRequest) GetIslandID() uint64 {
+func (x *ReloadDefaultsResponse) GetCloseAfterIdle() int64 {
 	if x != nil {
-		return x.IslandID
+		return x.CloseAfterIdle
 	}
 	return 0
 }
 
-func (x *IsKeyExistRequest) GetSwampName() string {
+func (x *ReloadDefaultsResponse) GetWriteInterval() int64 {
 	if x != nil {
-		return x.SwampName
+		return x.WriteInterval
 	}
-	return ""
+	return 0
 }
 
-func (x *IsKeyExistRequest) GetKey() string {
+func (x *ReloadDefaultsResponse) GetMaxFileSize() int64 {
 	if x != nil {
-		return x.Key
+		return x.MaxFileSize
 	}
-	return ""
-}
-
-// IsKeyExistResponse returns the existence status of the given key in the swamp.
-type IsKeyExistResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// IsExist will be true if the key is present in the swamp, false otherwise.
-	IsExist       bool `protobuf:"varint,1,opt,name=IsExist,proto3" json:"IsExist,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *IsKeyExistResponse) Reset() {
-	*x = IsKeyExistResponse{}
-	mi := &file_hydraide_proto_msgTypes[86]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *IsKeyExistResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*IsKeyExistResponse) ProtoMessage() {}
-
-func (x *IsKeyExistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[86]
+func (x *ReloadDefaultsResponse) GetFsync() FsyncPolicy_Policy {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Fsync
 	}
-	return mi.MessageOf(x)
+	return FsyncPolicy_DEFAULT
 }
 
-// Deprecated: Use IsKeyExistResponse.ProtoReflect.Descriptor instead.
-func (*IsKeyExistResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{86}
+func (x *ReloadDefaultsResponse) GetFsyncInterval() int64 {
+	if x != nil {
+		return x.FsyncInterval
+	}
+	return 0
 }
 
-func (x *IsKeyExistResponse) GetIsExist() bool {
+func (x *ReloadDefaultsResponse) GetApplyToUnregisteredSwamps() bool {
 	if x != nil {
-		return x.IsExist
+		return x.ApplyToUnregisteredSwamps
 	}
 	return false
 }
@@ -5800,14 +13511,17 @@ type DeleteRequest_SwampKeys struct {
 	// SwampName identifies the target swamp.
 	SwampName string `protobuf:"bytes,2,opt,name=SwampName,proto3" json:"SwampName,omitempty"`
 	// Keys are the specific treasure keys to delete from the swamp.
-	Keys          []string `protobuf:"bytes,3,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	Keys []string `protobuf:"bytes,3,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// ShadowDelete keeps the deleted treasures with their content, so they can be listed with ListDeleted and
+	// restored with Restore. The shadow-deleted treasures are invisible for all other operations.
+	ShadowDelete  bool `protobuf:"varint,4,opt,name=ShadowDelete,proto3" json:"ShadowDelete,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteRequest_SwampKeys) Reset() {
 	*x = DeleteRequest_SwampKeys{}
-	mi := &file_hydraide_proto_msgTypes[87]
+	mi := &file_hydraide_proto_msgTypes[199]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5819,7 +13533,7 @@ func (x *DeleteRequest_SwampKeys) String() string {
 func (*DeleteRequest_SwampKeys) ProtoMessage() {}
 
 func (x *DeleteRequest_SwampKeys) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[87]
+	mi := &file_hydraide_proto_msgTypes[199]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5832,7 +13546,7 @@ func (x *DeleteRequest_SwampKeys) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRequest_SwampKeys.ProtoReflect.Descriptor instead.
 func (*DeleteRequest_SwampKeys) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{38, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{70, 0}
 }
 
 func (x *DeleteRequest_SwampKeys) GetIslandID() uint64 {
@@ -5856,6 +13570,13 @@ func (x *DeleteRequest_SwampKeys) GetKeys() []string {
 	return nil
 }
 
+func (x *DeleteRequest_SwampKeys) GetShadowDelete() bool {
+	if x != nil {
+		return x.ShadowDelete
+	}
+	return false
+}
+
 type DeleteResponse_SwampDeleteResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// SwampName is the swamp where deletion was attempted.
@@ -5871,7 +13592,7 @@ type DeleteResponse_SwampDeleteResponse struct {
 
 func (x *DeleteResponse_SwampDeleteResponse) Reset() {
 	*x = DeleteResponse_SwampDeleteResponse{}
-	mi := &file_hydraide_proto_msgTypes[88]
+	mi := &file_hydraide_proto_msgTypes[200]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5883,7 +13604,7 @@ func (x *DeleteResponse_SwampDeleteResponse) String() string {
 func (*DeleteResponse_SwampDeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse_SwampDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[88]
+	mi := &file_hydraide_proto_msgTypes[200]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5896,7 +13617,7 @@ func (x *DeleteResponse_SwampDeleteResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use DeleteResponse_SwampDeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse_SwampDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{39, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{71, 0}
 }
 
 func (x *DeleteResponse_SwampDeleteResponse) GetSwampName() string {
@@ -5932,7 +13653,7 @@ type CountRequest_SwampIdentifier struct {
 
 func (x *CountRequest_SwampIdentifier) Reset() {
 	*x = CountRequest_SwampIdentifier{}
-	mi := &file_hydraide_proto_msgTypes[89]
+	mi := &file_hydraide_proto_msgTypes[201]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5944,7 +13665,7 @@ func (x *CountRequest_SwampIdentifier) String() string {
 func (*CountRequest_SwampIdentifier) ProtoMessage() {}
 
 func (x *CountRequest_SwampIdentifier) ProtoReflect() protoreflect.Message {
-	mi := &file_hydraide_proto_msgTypes[89]
+	mi := &file_hydraide_proto_msgTypes[201]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5957,7 +13678,7 @@ func (x *CountRequest_SwampIdentifier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountRequest_SwampIdentifier.ProtoReflect.Descriptor instead.
 func (*CountRequest_SwampIdentifier) Descriptor() ([]byte, []int) {
-	return file_hydraide_proto_rawDescGZIP(), []int{40, 0}
+	return file_hydraide_proto_rawDescGZIP(), []int{72, 0}
 }
 
 func (x *CountRequest_SwampIdentifier) GetIslandID() uint64 {
@@ -5980,9 +13701,13 @@ const file_hydraide_proto_rawDesc = "" +
 	"\n" +
 	"\x0ehydraide.proto\x12\fhydraidepbgo\x1a\x1fgoogle/protobuf/timestamp.proto\"&\n" +
 	"\x10HeartbeatRequest\x12\x12\n" +
-	"\x04Ping\x18\x01 \x01(\tR\x04Ping\"'\n" +
+	"\x04Ping\x18\x01 \x01(\tR\x04Ping\"\x89\x01\n" +
 	"\x11HeartbeatResponse\x12\x12\n" +
-	"\x04Pong\x18\x01 \x01(\tR\x04Pong\"1\n" +
+	"\x04Pong\x18\x01 \x01(\tR\x04Pong\x12$\n" +
+	"\rServerVersion\x18\x02 \x01(\tR\rServerVersion\x12:\n" +
+	"\n" +
+	"ServerTime\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"ServerTime\"1\n" +
 	"\vLockRequest\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x10\n" +
 	"\x03TTL\x18\x02 \x01(\x03R\x03TTL\"&\n" +
@@ -5995,38 +13720,131 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x0eDestroyRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"\x11\n" +
-	"\x0fDestroyResponse\"R\n" +
+	"\x0fDestroyResponse\"f\n" +
+	"\x12DestroyManyRequest\x12\"\n" +
+	"\fSwampPattern\x18\x01 \x01(\tR\fSwampPattern\x12,\n" +
+	"\x11ConfirmationToken\x18\x02 \x01(\tR\x11ConfirmationToken\"3\n" +
+	"\x13DestroyManyResponse\x12\x1c\n" +
+	"\tSwampName\x18\x01 \x01(\tR\tSwampName\"R\n" +
 	"\x16SubscribeToInfoRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"Y\n" +
 	"\x17SubscribeToInfoResponse\x12\x1c\n" +
 	"\tSwampName\x18\x01 \x01(\tR\tSwampName\x12 \n" +
-	"\vAllElements\x18\x02 \x01(\x04R\vAllElements\"T\n" +
+	"\vAllElements\x18\x02 \x01(\x04R\vAllElements\"\x91\x02\n" +
 	"\x18SubscribeToEventsRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
-	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"\xd6\x02\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12(\n" +
+	"\x0fIncludeSnapshot\x18\x03 \x01(\bR\x0fIncludeSnapshot\x120\n" +
+	"\x05Since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05Since\x12\x1e\n" +
+	"\n" +
+	"BufferSize\x18\x05 \x01(\rR\n" +
+	"BufferSize\x12?\n" +
+	"\bOverflow\x18\x06 \x01(\x0e2#.hydraidepbgo.OverflowPolicy.PolicyR\bOverflow\"\xac\x02\n" +
+	"\x13SubscribeAllRequest\x12\x1a\n" +
+	"\bPatterns\x18\x01 \x03(\tR\bPatterns\x12B\n" +
+	"\x05Since\x18\x02 \x03(\v2,.hydraidepbgo.SubscribeAllRequest.SinceEntryR\x05Since\x12\x1e\n" +
+	"\n" +
+	"BufferSize\x18\x03 \x01(\rR\n" +
+	"BufferSize\x12?\n" +
+	"\bOverflow\x18\x04 \x01(\x0e2#.hydraidepbgo.OverflowPolicy.PolicyR\bOverflow\x1aT\n" +
+	"\n" +
+	"SinceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05value:\x028\x01\"O\n" +
+	"\x0eOverflowPolicy\"=\n" +
+	"\x06Policy\x12\v\n" +
+	"\aDEFAULT\x10\x00\x12\t\n" +
+	"\x05BLOCK\x10\x01\x12\x0f\n" +
+	"\vDROP_OLDEST\x10\x02\x12\n" +
+	"\n" +
+	"\x06CANCEL\x10\x03\"\x8c\x04\n" +
 	"\x19SubscribeToEventsResponse\x12\x1c\n" +
 	"\tSwampName\x18\x01 \x01(\tR\tSwampName\x122\n" +
 	"\bTreasure\x18\x02 \x01(\v2\x16.hydraidepbgo.TreasureR\bTreasure\x128\n" +
 	"\vOldTreasure\x18\x03 \x01(\v2\x16.hydraidepbgo.TreasureR\vOldTreasure\x12@\n" +
 	"\x0fDeletedTreasure\x18\x04 \x01(\v2\x16.hydraidepbgo.TreasureR\x0fDeletedTreasure\x128\n" +
 	"\tEventTime\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tEventTime\x121\n" +
-	"\x06Status\x18\x06 \x01(\x0e2\x19.hydraidepbgo.Status.CodeR\x06Status\"=\n" +
+	"\x06Status\x18\x06 \x01(\x0e2\x19.hydraidepbgo.Status.CodeR\x06Status\x12\x1a\n" +
+	"\bSequence\x18\a \x01(\x04R\bSequence\x12 \n" +
+	"\vSnapshotEnd\x18\b \x01(\bR\vSnapshotEnd\x12 \n" +
+	"\vResumeToken\x18\t \x01(\x04R\vResumeToken\x12.\n" +
+	"\x12ReplayNotAvailable\x18\n" +
+	" \x01(\bR\x12ReplayNotAvailable\x12$\n" +
+	"\rDroppedEvents\x18\v \x01(\x04R\rDroppedEvents\"=\n" +
 	"\tSwampKeys\x12\x1c\n" +
 	"\tSwampName\x18\x01 \x01(\tR\tSwampName\x12\x12\n" +
-	"\x04Keys\x18\x02 \x03(\tR\x04Keys\"\x80\x02\n" +
+	"\x04Keys\x18\x02 \x03(\tR\x04Keys\"\xad\b\n" +
 	"\x14RegisterSwampRequest\x12\"\n" +
 	"\fSwampPattern\x18\x01 \x01(\tR\fSwampPattern\x12&\n" +
 	"\x0eCloseAfterIdle\x18\x02 \x01(\x03R\x0eCloseAfterIdle\x12(\n" +
 	"\x0fIsInMemorySwamp\x18\x03 \x01(\bR\x0fIsInMemorySwamp\x12)\n" +
 	"\rWriteInterval\x18\x04 \x01(\x03H\x00R\rWriteInterval\x88\x01\x01\x12%\n" +
-	"\vMaxFileSize\x18\x05 \x01(\x03H\x01R\vMaxFileSize\x88\x01\x01B\x10\n" +
+	"\vMaxFileSize\x18\x05 \x01(\x03H\x01R\vMaxFileSize\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"ValueIndex\x18\x06 \x01(\bR\n" +
+	"ValueIndex\x12*\n" +
+	"\x10EventJournalSize\x18\a \x01(\x03R\x10EventJournalSize\x124\n" +
+	"\x15EventJournalRetention\x18\b \x01(\x03R\x15EventJournalRetention\x12*\n" +
+	"\x10ServerTimestamps\x18\t \x01(\bR\x10ServerTimestamps\x12\"\n" +
+	"\fHistoryDepth\x18\n" +
+	" \x01(\x03R\fHistoryDepth\x12&\n" +
+	"\x0eMaxTreasureAge\x18\v \x01(\x03R\x0eMaxTreasureAge\x12\"\n" +
+	"\fMaxTreasures\x18\f \x01(\x03R\fMaxTreasures\x12\"\n" +
+	"\fMaxSwampSize\x18\r \x01(\x03R\fMaxSwampSize\x12.\n" +
+	"\x12DefaultExpireAfter\x18\x0e \x01(\x03R\x12DefaultExpireAfter\x12\"\n" +
+	"\fMaxKeyLength\x18\x0f \x01(\x03R\fMaxKeyLength\x12\"\n" +
+	"\fMaxValueSize\x18\x10 \x01(\x03R\fMaxValueSize\x12H\n" +
+	"\x10RequiredMetadata\x18\x11 \x03(\x0e2\x1c.hydraidepbgo.Metadata.FieldR\x10RequiredMetadata\x126\n" +
+	"\x05Fsync\x18\x12 \x01(\x0e2 .hydraidepbgo.FsyncPolicy.PolicyR\x05Fsync\x12$\n" +
+	"\rFsyncInterval\x18\x13 \x01(\x03R\rFsyncInterval\x12\x14\n" +
+	"\x05Force\x18\x14 \x01(\bR\x05Force\x12\x1c\n" +
+	"\tKeyFilter\x18\x15 \x01(\bR\tKeyFilter\x12$\n" +
+	"\rLazyHydration\x18\x16 \x01(\bR\rLazyHydration\x12;\n" +
+	"\vKeptBeacons\x18\x17 \x03(\x0e2\x19.hydraidepbgo.Beacon.TypeR\vKeptBeacons\x122\n" +
+	"\x14BeaconEvictAfterIdle\x18\x18 \x01(\x03R\x14BeaconEvictAfterIdleB\x10\n" +
 	"\x0e_WriteIntervalB\x0e\n" +
-	"\f_MaxFileSize\"\x17\n" +
-	"\x15RegisterSwampResponse\"<\n" +
+	"\f_MaxFileSize\"I\n" +
+	"\vFsyncPolicy\":\n" +
+	"\x06Policy\x12\v\n" +
+	"\aDEFAULT\x10\x00\x12\t\n" +
+	"\x05NEVER\x10\x01\x12\f\n" +
+	"\bINTERVAL\x10\x02\x12\n" +
+	"\n" +
+	"\x06ALWAYS\x10\x03\"\x17\n" +
+	"\x15RegisterSwampResponse\"j\n" +
 	"\x16DeRegisterSwampRequest\x12\"\n" +
-	"\fSwampPattern\x18\x01 \x01(\tR\fSwampPattern\"\x19\n" +
-	"\x17DeRegisterSwampResponse\"@\n" +
+	"\fSwampPattern\x18\x01 \x01(\tR\fSwampPattern\x12\x14\n" +
+	"\x05Purge\x18\x02 \x01(\bR\x05Purge\x12\x16\n" +
+	"\x06DryRun\x18\x03 \x01(\bR\x06DryRun\"=\n" +
+	"\x17DeRegisterSwampResponse\x12\"\n" +
+	"\fPurgedSwamps\x18\x01 \x03(\tR\fPurgedSwamps\"\x1a\n" +
+	"\x18ListSwampPatternsRequest\"S\n" +
+	"\x19ListSwampPatternsResponse\x126\n" +
+	"\bPatterns\x18\x01 \x03(\v2\x1a.hydraidepbgo.SwampPatternR\bPatterns\"\xd2\x03\n" +
+	"\fSwampPattern\x12\"\n" +
+	"\fSwampPattern\x18\x01 \x01(\tR\fSwampPattern\x12(\n" +
+	"\x0fIsInMemorySwamp\x18\x02 \x01(\bR\x0fIsInMemorySwamp\x12&\n" +
+	"\x0eCloseAfterIdle\x18\x03 \x01(\x03R\x0eCloseAfterIdle\x12$\n" +
+	"\rWriteInterval\x18\x04 \x01(\x03R\rWriteInterval\x12 \n" +
+	"\vMaxFileSize\x18\x05 \x01(\x03R\vMaxFileSize\x126\n" +
+	"\x05Fsync\x18\x06 \x01(\x0e2 .hydraidepbgo.FsyncPolicy.PolicyR\x05Fsync\x12$\n" +
+	"\rFsyncInterval\x18\a \x01(\x03R\rFsyncInterval\x12\"\n" +
+	"\fRegisteredBy\x18\b \x01(\tR\fRegisteredBy\x12>\n" +
+	"\fRegisteredAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fRegisteredAt\x12\x1c\n" +
+	"\tKeyFilter\x18\n" +
+	" \x01(\bR\tKeyFilter\x12$\n" +
+	"\rLazyHydration\x18\v \x01(\bR\rLazyHydration\"\xf3\x01\n" +
+	"\x19UpdateSwampPatternRequest\x12\"\n" +
+	"\fSwampPattern\x18\x01 \x01(\tR\fSwampPattern\x12+\n" +
+	"\x0eCloseAfterIdle\x18\x02 \x01(\x03H\x00R\x0eCloseAfterIdle\x88\x01\x01\x12)\n" +
+	"\rWriteInterval\x18\x03 \x01(\x03H\x01R\rWriteInterval\x88\x01\x01\x12%\n" +
+	"\vMaxFileSize\x18\x04 \x01(\x03H\x02R\vMaxFileSize\x88\x01\x01B\x11\n" +
+	"\x0f_CloseAfterIdleB\x10\n" +
+	"\x0e_WriteIntervalB\x0e\n" +
+	"\f_MaxFileSize\"R\n" +
+	"\x1aUpdateSwampPatternResponse\x124\n" +
+	"\aPattern\x18\x01 \x01(\v2\x1a.hydraidepbgo.SwampPatternR\aPattern\"@\n" +
 	"\n" +
 	"SetRequest\x122\n" +
 	"\x06Swamps\x18\x01 \x03(\v2\x1a.hydraidepbgo.SwampRequestR\x06Swamps\"\xcc\x01\n" +
@@ -6035,7 +13853,7 @@ const file_hydraide_proto_rawDesc = "" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x128\n" +
 	"\tKeyValues\x18\x03 \x03(\v2\x1a.hydraidepbgo.KeyValuePairR\tKeyValues\x12*\n" +
 	"\x10CreateIfNotExist\x18\x04 \x01(\bR\x10CreateIfNotExist\x12\x1c\n" +
-	"\tOverwrite\x18\x05 \x01(\bR\tOverwrite\"\xba\b\n" +
+	"\tOverwrite\x18\x05 \x01(\bR\tOverwrite\"\xf7\b\n" +
 	"\fKeyValuePair\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x1d\n" +
 	"\aInt8Val\x18\x02 \x01(\x05H\x00R\aInt8Val\x88\x01\x01\x12\x1f\n" +
@@ -6063,7 +13881,8 @@ const file_hydraide_proto_rawDesc = "" +
 	"\tCreatedBy\x18\x12 \x01(\tH\x0fR\tCreatedBy\x88\x01\x01\x12=\n" +
 	"\tUpdatedAt\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampH\x10R\tUpdatedAt\x88\x01\x01\x12!\n" +
 	"\tUpdatedBy\x18\x14 \x01(\tH\x11R\tUpdatedBy\x88\x01\x01\x12=\n" +
-	"\tExpiredAt\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampH\x12R\tExpiredAt\x88\x01\x01B\n" +
+	"\tExpiredAt\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampH\x12R\tExpiredAt\x88\x01\x01\x12)\n" +
+	"\rSchemaVersion\x18\x16 \x01(\rH\x13R\rSchemaVersion\x88\x01\x01B\n" +
 	"\n" +
 	"\b_Int8ValB\v\n" +
 	"\t_Int16ValB\v\n" +
@@ -6094,7 +13913,8 @@ const file_hydraide_proto_rawDesc = "" +
 	"\n" +
 	"_UpdatedByB\f\n" +
 	"\n" +
-	"_ExpiredAt\"B\n" +
+	"_ExpiredAtB\x10\n" +
+	"\x0e_SchemaVersion\"B\n" +
 	"\vSetResponse\x123\n" +
 	"\x06Swamps\x18\x01 \x03(\v2\x1b.hydraidepbgo.SwampResponseR\x06Swamps\"\x8a\x02\n" +
 	"\rSwampResponse\x12\x1c\n" +
@@ -6105,10 +13925,12 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x10CanNotBeExecuted\x10\x00\x12\x15\n" +
 	"\x11SwampDoesNotExist\x10\x01B\f\n" +
 	"\n" +
-	"_ErrorCode\"T\n" +
+	"_ErrorCode\"\xc8\x01\n" +
 	"\rKeyStatusPair\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x121\n" +
-	"\x06Status\x18\x02 \x01(\x0e2\x19.hydraidepbgo.Status.CodeR\x06Status\"W\n" +
+	"\x06Status\x18\x02 \x01(\x0e2\x19.hydraidepbgo.Status.CodeR\x06Status\x128\n" +
+	"\tCreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tUpdatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\"W\n" +
 	"\x06Status\"M\n" +
 	"\x04Code\x12\r\n" +
 	"\tNOT_FOUND\x10\x00\x12\a\n" +
@@ -6118,28 +13940,102 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x0fNOTHING_CHANGED\x10\x04\"<\n" +
 	"\n" +
 	"GetRequest\x12.\n" +
-	"\x06Swamps\x18\x01 \x03(\v2\x16.hydraidepbgo.GetSwampR\x06Swamps\"X\n" +
+	"\x06Swamps\x18\x01 \x03(\v2\x16.hydraidepbgo.GetSwampR\x06Swamps\"\xa6\x01\n" +
 	"\bGetSwamp\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x12\n" +
-	"\x04Keys\x18\x03 \x03(\tR\x04Keys\"E\n" +
+	"\x04Keys\x18\x03 \x03(\tR\x04Keys\x12=\n" +
+	"\n" +
+	"Projection\x18\x04 \x01(\v2\x18.hydraidepbgo.ProjectionH\x00R\n" +
+	"Projection\x88\x01\x01B\r\n" +
+	"\v_Projection\"\xb8\x01\n" +
+	"\n" +
+	"Projection\x12\x14\n" +
+	"\x05Value\x18\x01 \x01(\bR\x05Value\x12\x1c\n" +
+	"\tCreatedAt\x18\x02 \x01(\bR\tCreatedAt\x12\x1c\n" +
+	"\tCreatedBy\x18\x03 \x01(\bR\tCreatedBy\x12\x1c\n" +
+	"\tUpdatedAt\x18\x04 \x01(\bR\tUpdatedAt\x12\x1c\n" +
+	"\tUpdatedBy\x18\x05 \x01(\bR\tUpdatedBy\x12\x1c\n" +
+	"\tExpiredAt\x18\x06 \x01(\bR\tExpiredAt\"E\n" +
 	"\vGetResponse\x126\n" +
 	"\x06Swamps\x18\x01 \x03(\v2\x1e.hydraidepbgo.GetSwampResponseR\x06Swamps\"\x80\x01\n" +
 	"\x10GetSwampResponse\x12\x1c\n" +
 	"\tSwampName\x18\x01 \x01(\tR\tSwampName\x12\x18\n" +
 	"\aIsExist\x18\x02 \x01(\bR\aIsExist\x124\n" +
-	"\tTreasures\x18\x03 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"I\n" +
+	"\tTreasures\x18\x03 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xe8\x01\n" +
+	"\x14SetLargeValueRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x126\n" +
+	"\bKeyValue\x18\x03 \x01(\v2\x1a.hydraidepbgo.KeyValuePairR\bKeyValue\x12*\n" +
+	"\x10CreateIfNotExist\x18\x04 \x01(\bR\x10CreateIfNotExist\x12\x1c\n" +
+	"\tOverwrite\x18\x05 \x01(\bR\tOverwrite\x12\x14\n" +
+	"\x05Chunk\x18\x06 \x01(\fR\x05Chunk\"J\n" +
+	"\x15SetLargeValueResponse\x121\n" +
+	"\x05Swamp\x18\x01 \x01(\v2\x1b.hydraidepbgo.SwampResponseR\x05Swamp\"\x80\x01\n" +
+	"\x14GetLargeValueRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x1c\n" +
+	"\tChunkSize\x18\x04 \x01(\rR\tChunkSize\"a\n" +
+	"\x15GetLargeValueResponse\x122\n" +
+	"\bTreasure\x18\x01 \x01(\v2\x16.hydraidepbgo.TreasureR\bTreasure\x12\x14\n" +
+	"\x05Chunk\x18\x02 \x01(\fR\x05Chunk\"I\n" +
 	"\rGetAllRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"F\n" +
 	"\x0eGetAllResponse\x124\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xb5\x02\n" +
+	"\x13GetAllStreamRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12#\n" +
+	"\n" +
+	"FilterExpr\x18\x03 \x01(\tH\x00R\n" +
+	"FilterExpr\x88\x01\x01\x12\x12\n" +
+	"\x04From\x18\x04 \x01(\x05R\x04From\x12\x14\n" +
+	"\x05Limit\x18\x05 \x01(\x05R\x05Limit\x12\x1a\n" +
+	"\bKeysOnly\x18\x06 \x01(\bR\bKeysOnly\x12=\n" +
+	"\n" +
+	"Projection\x18\a \x01(\v2\x18.hydraidepbgo.ProjectionH\x01R\n" +
+	"Projection\x88\x01\x01\x12\x1c\n" +
+	"\tBatchSize\x18\b \x01(\x05R\tBatchSizeB\r\n" +
+	"\v_FilterExprB\r\n" +
+	"\v_Projection\"L\n" +
+	"\x14GetAllStreamResponse\x124\n" +
 	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"r\n" +
 	"\x1cShiftExpiredTreasuresRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x18\n" +
 	"\aHowMany\x18\x03 \x01(\x05R\aHowMany\"U\n" +
 	"\x1dShiftExpiredTreasuresResponse\x124\n" +
-	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xa5\b\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\x90\x01\n" +
+	"\x1cLeaseExpiredTreasuresRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x18\n" +
+	"\aHowMany\x18\x03 \x01(\x05R\aHowMany\x12\x1c\n" +
+	"\tLeaseTime\x18\x04 \x01(\x03R\tLeaseTime\"[\n" +
+	"\x1dLeaseExpiredTreasuresResponse\x12:\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x1c.hydraidepbgo.LeasedTreasureR\tTreasures\"~\n" +
+	"\x0eLeasedTreasure\x122\n" +
+	"\bTreasure\x18\x01 \x01(\v2\x16.hydraidepbgo.TreasureR\bTreasure\x12\x18\n" +
+	"\aLeaseID\x18\x02 \x01(\x03R\aLeaseID\x12\x1e\n" +
+	"\n" +
+	"Deliveries\x18\x03 \x01(\x05R\n" +
+	"Deliveries\"w\n" +
+	"\x0fAckLeaseRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x18\n" +
+	"\aLeaseID\x18\x04 \x01(\x03R\aLeaseID\"\x12\n" +
+	"\x10AckLeaseResponse\"\x98\x01\n" +
+	"\x10NackLeaseRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x18\n" +
+	"\aLeaseID\x18\x04 \x01(\x03R\aLeaseID\x12\x1e\n" +
+	"\n" +
+	"RetryAfter\x18\x05 \x01(\x03R\n" +
+	"RetryAfter\"\x13\n" +
+	"\x11NackLeaseResponse\"\xda\t\n" +
 	"\bTreasure\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x18\n" +
 	"\aIsExist\x18\x02 \x01(\bR\aIsExist\x12\x1d\n" +
@@ -6167,7 +14063,10 @@ const file_hydraide_proto_rawDesc = "" +
 	"\tCreatedBy\x18\x12 \x01(\tH\x0eR\tCreatedBy\x88\x01\x01\x12=\n" +
 	"\tUpdatedAt\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampH\x0fR\tUpdatedAt\x88\x01\x01\x12!\n" +
 	"\tUpdatedBy\x18\x14 \x01(\tH\x10R\tUpdatedBy\x88\x01\x01\x12=\n" +
-	"\tExpiredAt\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampH\x11R\tExpiredAt\x88\x01\x01B\n" +
+	"\tExpiredAt\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampH\x11R\tExpiredAt\x88\x01\x01\x12)\n" +
+	"\rSchemaVersion\x18\x16 \x01(\rH\x12R\rSchemaVersion\x88\x01\x01\x12=\n" +
+	"\tDeletedAt\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampH\x13R\tDeletedAt\x88\x01\x01\x12\x1d\n" +
+	"\aVersion\x18\x18 \x01(\x04H\x14R\aVersion\x88\x01\x01B\n" +
 	"\n" +
 	"\b_Int8ValB\v\n" +
 	"\t_Int16ValB\v\n" +
@@ -6196,18 +14095,51 @@ const file_hydraide_proto_rawDesc = "" +
 	"\n" +
 	"_UpdatedByB\f\n" +
 	"\n" +
-	"_ExpiredAt\"&\n" +
+	"_ExpiredAtB\x10\n" +
+	"\x0e_SchemaVersionB\f\n" +
+	"\n" +
+	"_DeletedAtB\n" +
+	"\n" +
+	"\b_Version\"c\n" +
+	"\bMetadata\"W\n" +
+	"\x05Field\x12\x0e\n" +
+	"\n" +
+	"CREATED_AT\x10\x00\x12\x0e\n" +
+	"\n" +
+	"CREATED_BY\x10\x01\x12\x0e\n" +
+	"\n" +
+	"UPDATED_AT\x10\x02\x12\x0e\n" +
+	"\n" +
+	"UPDATED_BY\x10\x03\x12\x0e\n" +
+	"\n" +
+	"EXPIRED_AT\x10\x04\"]\n" +
+	"\x06Beacon\"S\n" +
+	"\x04Type\x12\a\n" +
+	"\x03KEY\x10\x00\x12\x11\n" +
+	"\rCREATION_TIME\x10\x01\x12\x0f\n" +
+	"\vUPDATE_TIME\x10\x02\x12\x13\n" +
+	"\x0fEXPIRATION_TIME\x10\x03\x12\t\n" +
+	"\x05VALUE\x10\x04\"&\n" +
 	"\aBoolean\"\x1b\n" +
 	"\x04Type\x12\b\n" +
 	"\x04TRUE\x10\x00\x12\t\n" +
-	"\x05FALSE\x10\x01\"\xef\x01\n" +
+	"\x05FALSE\x10\x01\"\x8d\x03\n" +
 	"\x11GetByIndexRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12:\n" +
 	"\tIndexType\x18\x03 \x01(\x0e2\x1c.hydraidepbgo.IndexType.TypeR\tIndexType\x12:\n" +
 	"\tOrderType\x18\x04 \x01(\x0e2\x1c.hydraidepbgo.OrderType.TypeR\tOrderType\x12\x12\n" +
 	"\x04From\x18\x05 \x01(\x05R\x04From\x12\x14\n" +
-	"\x05Limit\x18\x06 \x01(\x05R\x05Limit\"\x98\x02\n" +
+	"\x05Limit\x18\x06 \x01(\x05R\x05Limit\x12#\n" +
+	"\n" +
+	"FilterExpr\x18\a \x01(\tH\x00R\n" +
+	"FilterExpr\x88\x01\x01\x12\x1a\n" +
+	"\bKeysOnly\x18\b \x01(\bR\bKeysOnly\x12=\n" +
+	"\n" +
+	"Projection\x18\t \x01(\v2\x18.hydraidepbgo.ProjectionH\x01R\n" +
+	"Projection\x88\x01\x01B\r\n" +
+	"\v_FilterExprB\r\n" +
+	"\v_Projection\"\x98\x02\n" +
 	"\tIndexType\"\x8a\x02\n" +
 	"\x04Type\x12\a\n" +
 	"\x03KEY\x10\x00\x12\x13\n" +
@@ -6232,13 +14164,41 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x03ASC\x10\x00\x12\b\n" +
 	"\x04DESC\x10\x01\"J\n" +
 	"\x12GetByIndexResponse\x124\n" +
-	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xa9\x01\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xd0\x01\n" +
+	"\x0eGetTopNRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12:\n" +
+	"\tIndexType\x18\x03 \x01(\x0e2\x1c.hydraidepbgo.IndexType.TypeR\tIndexType\x12:\n" +
+	"\tOrderType\x18\x04 \x01(\x0e2\x1c.hydraidepbgo.OrderType.TypeR\tOrderType\x12\f\n" +
+	"\x01N\x18\x05 \x01(\x05R\x01N\"G\n" +
+	"\x0fGetTopNResponse\x124\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\x7f\n" +
+	"\x11GetByValueRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x120\n" +
+	"\x05Value\x18\x03 \x01(\v2\x1a.hydraidepbgo.KeyValuePairR\x05Value\"J\n" +
+	"\x12GetByValueResponse\x124\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xad\x02\n" +
+	"\x15GetByReferenceRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12&\n" +
+	"\x0eTargetIslandID\x18\x04 \x01(\x04R\x0eTargetIslandID\x12(\n" +
+	"\x0fTargetSwampName\x18\x05 \x01(\tR\x0fTargetSwampName\x12(\n" +
+	"\x0fTargetKeyPrefix\x18\x06 \x01(\tR\x0fTargetKeyPrefix\x12=\n" +
+	"\n" +
+	"Projection\x18\a \x01(\v2\x18.hydraidepbgo.ProjectionH\x00R\n" +
+	"Projection\x88\x01\x01B\r\n" +
+	"\v_Projection\"N\n" +
+	"\x16GetByReferenceResponse\x124\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"\xcd\x01\n" +
 	"\rDeleteRequest\x12=\n" +
-	"\x06Swamps\x18\x01 \x03(\v2%.hydraidepbgo.DeleteRequest.SwampKeysR\x06Swamps\x1aY\n" +
+	"\x06Swamps\x18\x01 \x03(\v2%.hydraidepbgo.DeleteRequest.SwampKeysR\x06Swamps\x1a}\n" +
 	"\tSwampKeys\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x12\n" +
-	"\x04Keys\x18\x03 \x03(\tR\x04Keys\"\xee\x02\n" +
+	"\x04Keys\x18\x03 \x03(\tR\x04Keys\x12\"\n" +
+	"\fShadowDelete\x18\x04 \x01(\bR\fShadowDelete\"\xee\x02\n" +
 	"\x0eDeleteResponse\x12N\n" +
 	"\tResponses\x18\x01 \x03(\v20.hydraidepbgo.DeleteResponse.SwampDeleteResponseR\tResponses\x1a\x8b\x02\n" +
 	"\x13SwampDeleteResponse\x12\x1c\n" +
@@ -6248,12 +14208,19 @@ const file_hydraide_proto_rawDesc = "" +
 	"\rErrorCodeEnum\x12\x15\n" +
 	"\x11SwampDoesNotExist\x10\x00B\f\n" +
 	"\n" +
-	"_ErrorCode\"\x9f\x01\n" +
+	"_ErrorCode\"\xe2\x01\n" +
 	"\fCountRequest\x12B\n" +
-	"\x06Swamps\x18\x01 \x03(\v2*.hydraidepbgo.CountRequest.SwampIdentifierR\x06Swamps\x1aK\n" +
+	"\x06Swamps\x18\x01 \x03(\v2*.hydraidepbgo.CountRequest.SwampIdentifierR\x06Swamps\x126\n" +
+	"\x06Filter\x18\x02 \x01(\v2\x19.hydraidepbgo.CountFilterH\x00R\x06Filter\x88\x01\x01\x1aK\n" +
 	"\x0fSwampIdentifier\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
-	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"A\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampNameB\t\n" +
+	"\a_Filter\"\xa3\x01\n" +
+	"\vCountFilter\x12 \n" +
+	"\vExpiredOnly\x18\x01 \x01(\bR\vExpiredOnly\x12\x1c\n" +
+	"\tKeyPrefix\x18\x02 \x01(\tR\tKeyPrefix\x12C\n" +
+	"\fUpdatedSince\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\fUpdatedSince\x88\x01\x01B\x0f\n" +
+	"\r_UpdatedSince\"A\n" +
 	"\rCountResponse\x120\n" +
 	"\x06Swamps\x18\x01 \x03(\v2\x18.hydraidepbgo.CountSwampR\x06Swamps\"Z\n" +
 	"\n" +
@@ -6389,15 +14356,65 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x05Value\x18\x02 \x01(\x01R\x05Value\"V\n" +
 	"\x18IncrementFloat64Response\x12\x14\n" +
 	"\x05Value\x18\x01 \x01(\x01R\x05Value\x12$\n" +
-	"\rIsIncremented\x18\x02 \x01(\bR\rIsIncremented\"8\n" +
+	"\rIsIncremented\x18\x02 \x01(\bR\rIsIncremented\"}\n" +
+	"\x11SetIfLaterRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x1c\n" +
+	"\tTimestamp\x18\x04 \x01(\x03R\tTimestamp\"H\n" +
+	"\x12SetIfLaterResponse\x12\x1c\n" +
+	"\tTimestamp\x18\x01 \x01(\x03R\tTimestamp\x12\x14\n" +
+	"\x05IsSet\x18\x02 \x01(\bR\x05IsSet\"\xa0\x01\n" +
+	"\x12AddDurationRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x18\n" +
+	"\aSeconds\x18\x04 \x01(\x03R\aSeconds\x12$\n" +
+	"\rFromNowIfPast\x18\x05 \x01(\bR\rFromNowIfPast\"3\n" +
+	"\x13AddDurationResponse\x12\x1c\n" +
+	"\tTimestamp\x18\x01 \x01(\x03R\tTimestamp\".\n" +
+	"\x05SetIf\"%\n" +
+	"\bOperator\x12\t\n" +
+	"\x05EQUAL\x10\x00\x12\x0e\n" +
+	"\n" +
+	"NOT_EXISTS\x10\x01\"\xb6\x01\n" +
+	"\x12SetStringIfRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x14\n" +
+	"\x05Value\x18\x04 \x01(\tR\x05Value\x12>\n" +
+	"\tCondition\x18\x05 \x01(\v2 .hydraidepbgo.SetStringConditionR\tCondition\"d\n" +
+	"\x12SetStringCondition\x128\n" +
+	"\bOperator\x18\x01 \x01(\x0e2\x1c.hydraidepbgo.SetIf.OperatorR\bOperator\x12\x14\n" +
+	"\x05Value\x18\x02 \x01(\tR\x05Value\"A\n" +
+	"\x13SetStringIfResponse\x12\x14\n" +
+	"\x05Value\x18\x01 \x01(\tR\x05Value\x12\x14\n" +
+	"\x05IsSet\x18\x02 \x01(\bR\x05IsSet\"\xb2\x01\n" +
+	"\x10SetBoolIfRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x14\n" +
+	"\x05Value\x18\x04 \x01(\bR\x05Value\x12<\n" +
+	"\tCondition\x18\x05 \x01(\v2\x1e.hydraidepbgo.SetBoolConditionR\tCondition\"b\n" +
+	"\x10SetBoolCondition\x128\n" +
+	"\bOperator\x18\x01 \x01(\x0e2\x1c.hydraidepbgo.SetIf.OperatorR\bOperator\x12\x14\n" +
+	"\x05Value\x18\x02 \x01(\bR\x05Value\"?\n" +
+	"\x11SetBoolIfResponse\x12\x14\n" +
+	"\x05Value\x18\x01 \x01(\bR\x05Value\x12\x14\n" +
+	"\x05IsSet\x18\x02 \x01(\bR\x05IsSet\"8\n" +
 	"\fKeySlicePair\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x16\n" +
 	"\x06Values\x18\x02 \x03(\rR\x06Values\"\x99\x01\n" +
 	"\x1bAddToUint32SlicePushRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12@\n" +
-	"\rKeySlicePairs\x18\x03 \x03(\v2\x1a.hydraidepbgo.KeySlicePairR\rKeySlicePairs\"\x1e\n" +
-	"\x1cAddToUint32SlicePushResponse\"\x96\x01\n" +
+	"\rKeySlicePairs\x18\x03 \x03(\v2\x1a.hydraidepbgo.KeySlicePairR\rKeySlicePairs\"]\n" +
+	"\x1cAddToUint32SlicePushResponse\x12=\n" +
+	"\aResults\x18\x01 \x03(\v2#.hydraidepbgo.Uint32SlicePushResultR\aResults\"g\n" +
+	"\x15Uint32SlicePushResult\x12\x10\n" +
+	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x14\n" +
+	"\x05Added\x18\x02 \x01(\x04R\x05Added\x12&\n" +
+	"\x0eAlreadyPresent\x18\x03 \x01(\x04R\x0eAlreadyPresent\"\x96\x01\n" +
 	"\x18Uint32SliceDeleteRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12@\n" +
@@ -6415,42 +14432,386 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x14\n" +
 	"\x05Value\x18\x04 \x01(\rR\x05Value\";\n" +
 	"\x1fUint32SliceIsValueExistResponse\x12\x18\n" +
-	"\aIsExist\x18\x01 \x01(\bR\aIsExist\"O\n" +
+	"\aIsExist\x18\x01 \x01(\bR\aIsExist\"\x96\x01\n" +
+	"\x1aUint32SliceGetRangeRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x16\n" +
+	"\x06Offset\x18\x04 \x01(\x03R\x06Offset\x12\x14\n" +
+	"\x05Limit\x18\x05 \x01(\x03R\x05Limit\"I\n" +
+	"\x1bUint32SliceGetRangeResponse\x12\x16\n" +
+	"\x06Values\x18\x01 \x03(\rR\x06Values\x12\x12\n" +
+	"\x04Size\x18\x02 \x01(\x03R\x04Size\"\x84\x01\n" +
+	"\x18Uint32SliceStreamRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x1c\n" +
+	"\tBatchSize\x18\x04 \x01(\x05R\tBatchSize\"3\n" +
+	"\x19Uint32SliceStreamResponse\x12\x16\n" +
+	"\x06Values\x18\x01 \x03(\rR\x06Values\"Q\n" +
+	"\x16Uint32SliceCombination\"7\n" +
+	"\bOperator\x12\t\n" +
+	"\x05UNION\x10\x00\x12\x10\n" +
+	"\fINTERSECTION\x10\x01\x12\x0e\n" +
+	"\n" +
+	"DIFFERENCE\x10\x02\"\xd2\x01\n" +
+	"\x19Uint32SliceCombineRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12I\n" +
+	"\bOperator\x18\x03 \x01(\x0e2-.hydraidepbgo.Uint32SliceCombination.OperatorR\bOperator\x12\x12\n" +
+	"\x04Keys\x18\x04 \x03(\tR\x04Keys\x12\x1c\n" +
+	"\tBatchSize\x18\x05 \x01(\x05R\tBatchSize\"\xe0\x01\n" +
+	"\x1dUint32SliceCombineIntoRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12I\n" +
+	"\bOperator\x18\x03 \x01(\x0e2-.hydraidepbgo.Uint32SliceCombination.OperatorR\bOperator\x12\x12\n" +
+	"\x04Keys\x18\x04 \x03(\tR\x04Keys\x12&\n" +
+	"\x0eDestinationKey\x18\x05 \x01(\tR\x0eDestinationKey\"4\n" +
+	"\x1eUint32SliceCombineIntoResponse\x12\x12\n" +
+	"\x04Size\x18\x01 \x01(\x03R\x04Size\"O\n" +
 	"\x13IsSwampExistRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"0\n" +
 	"\x14IsSwampExistResponse\x12\x18\n" +
-	"\aIsExist\x18\x01 \x01(\bR\aIsExist\"_\n" +
+	"\aIsExist\x18\x01 \x01(\bR\aIsExist\"J\n" +
+	"\x11ExistsManyRequest\x125\n" +
+	"\x06Swamps\x18\x01 \x03(\v2\x1d.hydraidepbgo.ExistsManySwampR\x06Swamps\"K\n" +
+	"\x0fExistsManySwamp\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"N\n" +
+	"\x12ExistsManyResponse\x128\n" +
+	"\aResults\x18\x01 \x03(\v2\x1e.hydraidepbgo.ExistsManyResultR\aResults\"X\n" +
+	"\x10ExistsManyResult\x12\x1c\n" +
+	"\tSwampName\x18\x01 \x01(\tR\tSwampName\x12&\n" +
+	"\x0eExistingSwamps\x18\x02 \x03(\tR\x0eExistingSwamps\"_\n" +
 	"\x11IsKeyExistRequest\x12\x1a\n" +
 	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
 	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
 	"\x03Key\x18\x03 \x01(\tR\x03Key\".\n" +
 	"\x12IsKeyExistResponse\x12\x18\n" +
-	"\aIsExist\x18\x01 \x01(\bR\aIsExist2\xf8\x15\n" +
+	"\aIsExist\x18\x01 \x01(\bR\aIsExist\"b\n" +
+	"\x12IsKeysExistRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x12\n" +
+	"\x04Keys\x18\x03 \x03(\tR\x04Keys\"/\n" +
+	"\x13IsKeysExistResponse\x12\x18\n" +
+	"\aIsExist\x18\x01 \x03(\bR\aIsExist\"N\n" +
+	"\x12ListDeletedRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"K\n" +
+	"\x13ListDeletedResponse\x124\n" +
+	"\tTreasures\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\tTreasures\"^\n" +
+	"\x0eRestoreRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x12\n" +
+	"\x04Keys\x18\x03 \x03(\tR\x04Keys\"P\n" +
+	"\x0fRestoreResponse\x12=\n" +
+	"\vKeyStatuses\x18\x01 \x03(\v2\x1b.hydraidepbgo.KeyStatusPairR\vKeyStatuses\"_\n" +
+	"\x11GetHistoryRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\"H\n" +
+	"\x12GetHistoryResponse\x122\n" +
+	"\bVersions\x18\x01 \x03(\v2\x16.hydraidepbgo.TreasureR\bVersions\"\xa8\x01\n" +
+	"\x0fRevertToRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x18\n" +
+	"\aVersion\x18\x04 \x01(\x04R\aVersion\x12!\n" +
+	"\tUpdatedBy\x18\x05 \x01(\tH\x00R\tUpdatedBy\x88\x01\x01B\f\n" +
+	"\n" +
+	"_UpdatedBy\"\x12\n" +
+	"\x10RevertToResponse\"\xe0\x06\n" +
+	"\vErrorReason\"\xd0\x06\n" +
+	"\x06Reason\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSWAMP_NOT_FOUND\x10\x01\x12\x11\n" +
+	"\rKEY_NOT_FOUND\x10\x02\x12\x0e\n" +
+	"\n" +
+	"KEY_EXISTS\x10\x03\x12\x15\n" +
+	"\x11CONDITION_NOT_MET\x10\x04\x12\x12\n" +
+	"\x0eQUOTA_EXCEEDED\x10\x05\x12\x14\n" +
+	"\x10INVALID_ARGUMENT\x10\x06\x12\x1d\n" +
+	"\x19INVALID_FILTER_EXPRESSION\x10\a\x12\x14\n" +
+	"\x10WRONG_VALUE_TYPE\x10\b\x12\x1b\n" +
+	"\x17VALUE_INDEX_NOT_ENABLED\x10\t\x12\x12\n" +
+	"\x0eLOCK_NOT_FOUND\x10\n" +
+	"\x12\x1a\n" +
+	"\x16LOCK_DEADLINE_EXCEEDED\x10\v\x12\f\n" +
+	"\bINTERNAL\x10\f\x12\x12\n" +
+	"\x0eDATA_CORRUPTED\x10\r\x12\x18\n" +
+	"\x14REPLAY_NOT_AVAILABLE\x10\x0e\x12\x13\n" +
+	"\x0fLEASE_NOT_FOUND\x10\x0f\x12\x15\n" +
+	"\x11VERSION_NOT_FOUND\x10\x10\x12\x15\n" +
+	"\x11MESSAGE_TOO_LARGE\x10\x11\x12\x12\n" +
+	"\x0eBLOB_NOT_FOUND\x10\x12\x12\x18\n" +
+	"\x14INSUFFICIENT_STORAGE\x10\x13\x12\x16\n" +
+	"\x12AUDIT_LOG_DISABLED\x10\x14\x12\x14\n" +
+	"\x10SCHEMA_VIOLATION\x10\x15\x12\x17\n" +
+	"\x13SUBSCRIBER_OVERFLOW\x10\x16\x12\"\n" +
+	"\x1ePOINT_IN_TIME_RESTORE_DISABLED\x10\x17\x12\x1d\n" +
+	"\x19POINT_IN_TIME_NOT_COVERED\x10\x18\x12\x1b\n" +
+	"\x17CONSISTENCY_NOT_REACHED\x10\x19\x12#\n" +
+	"\x1fCLUSTER_TOPOLOGY_NOT_CONFIGURED\x10\x1a\x12\x14\n" +
+	"\x10ISLAND_MIGRATING\x10\x1b\x12\x10\n" +
+	"\fISLAND_MOVED\x10\x1c\x12\x1d\n" +
+	"\x19ISLAND_MIGRATION_DISABLED\x10\x1d\x12\x15\n" +
+	"\x11TOPOLOGY_CONFLICT\x10\x1e\x12\x1c\n" +
+	"\x18DEFAULTS_RELOAD_DISABLED\x10\x1f\x12\x1b\n" +
+	"\x17SWAMP_PATTERN_NOT_FOUND\x10 \x12\x14\n" +
+	"\x10PATTERN_CONFLICT\x10!\x12\x12\n" +
+	"\x0eLIMIT_EXCEEDED\x10\"\"}\n" +
+	"\x19SetSwampAnnotationRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x03 \x01(\tR\x03Key\x12\x14\n" +
+	"\x05Value\x18\x04 \x01(\tR\x05Value\"\x1c\n" +
+	"\x1aSetSwampAnnotationResponse\"V\n" +
+	"\x1aGetSwampAnnotationsRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\"\xbb\x01\n" +
+	"\x1bGetSwampAnnotationsResponse\x12\\\n" +
+	"\vAnnotations\x18\x01 \x03(\v2:.hydraidepbgo.GetSwampAnnotationsResponse.AnnotationsEntryR\vAnnotations\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc0\x02\n" +
+	"\x10AggregateRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\x1c\n" +
+	"\tKeyPrefix\x18\x03 \x01(\tR\tKeyPrefix\x12:\n" +
+	"\tIndexType\x18\x04 \x01(\x0e2\x1c.hydraidepbgo.IndexType.TypeR\tIndexType\x12:\n" +
+	"\tOrderType\x18\x05 \x01(\x0e2\x1c.hydraidepbgo.OrderType.TypeR\tOrderType\x12\x12\n" +
+	"\x04From\x18\x06 \x01(\x05R\x04From\x12\x14\n" +
+	"\x05Limit\x18\a \x01(\x05R\x05Limit\x12#\n" +
+	"\n" +
+	"FilterExpr\x18\b \x01(\tH\x00R\n" +
+	"FilterExpr\x88\x01\x01B\r\n" +
+	"\v_FilterExpr\"\xcc\x01\n" +
+	"\x11AggregateResponse\x12\x14\n" +
+	"\x05Count\x18\x01 \x01(\x03R\x05Count\x12\x10\n" +
+	"\x03Sum\x18\x02 \x01(\x01R\x03Sum\x12\x15\n" +
+	"\x03Min\x18\x03 \x01(\x01H\x00R\x03Min\x88\x01\x01\x12\x15\n" +
+	"\x03Max\x18\x04 \x01(\x01H\x01R\x03Max\x88\x01\x01\x12\x15\n" +
+	"\x03Avg\x18\x05 \x01(\x01H\x02R\x03Avg\x88\x01\x01\x12#\n" +
+	"\n" +
+	"IntegerSum\x18\x06 \x01(\x03H\x03R\n" +
+	"IntegerSum\x88\x01\x01B\x06\n" +
+	"\x04_MinB\x06\n" +
+	"\x04_MaxB\x06\n" +
+	"\x04_AvgB\r\n" +
+	"\v_IntegerSum\"\x1b\n" +
+	"\x19ListCorruptedFilesRequest\"\x7f\n" +
+	"\rCorruptedFile\x12\x1a\n" +
+	"\bFilePath\x18\x01 \x01(\tR\bFilePath\x12\x16\n" +
+	"\x06Reason\x18\x02 \x01(\tR\x06Reason\x12:\n" +
+	"\n" +
+	"DetectedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"DetectedAt\"O\n" +
+	"\x1aListCorruptedFilesResponse\x121\n" +
+	"\x05Files\x18\x01 \x03(\v2\x1b.hydraidepbgo.CorruptedFileR\x05Files\"4\n" +
+	"\x18GetNamespaceUsageRequest\x12\x18\n" +
+	"\aRefresh\x18\x01 \x01(\bR\aRefresh\"\xd2\x01\n" +
+	"\x0eNamespaceUsage\x12\x1c\n" +
+	"\tSanctuary\x18\x01 \x01(\tR\tSanctuary\x12\x14\n" +
+	"\x05Realm\x18\x02 \x01(\tR\x05Realm\x12\x1e\n" +
+	"\n" +
+	"SwampCount\x18\x03 \x01(\x04R\n" +
+	"SwampCount\x12$\n" +
+	"\rTreasureCount\x18\x04 \x01(\x04R\rTreasureCount\x12(\n" +
+	"\x0fUncountedSwamps\x18\x05 \x01(\x04R\x0fUncountedSwamps\x12\x1c\n" +
+	"\tDiskBytes\x18\x06 \x01(\x04R\tDiskBytes\"\x8f\x01\n" +
+	"\x19GetNamespaceUsageResponse\x124\n" +
+	"\x06Usages\x18\x01 \x03(\v2\x1c.hydraidepbgo.NamespaceUsageR\x06Usages\x12<\n" +
+	"\vCollectedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vCollectedAt\"s\n" +
+	"\x13CompactSwampRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x1c\n" +
+	"\tSwampName\x18\x02 \x01(\tR\tSwampName\x12\"\n" +
+	"\fMinLiveRatio\x18\x03 \x01(\x01R\fMinLiveRatio\"\xb6\x01\n" +
+	"\x14CompactSwampResponse\x12&\n" +
+	"\x0eCompactedFiles\x18\x01 \x01(\x05R\x0eCompactedFiles\x12\"\n" +
+	"\fWrittenFiles\x18\x02 \x01(\x05R\fWrittenFiles\x12*\n" +
+	"\x10RemovedTreasures\x18\x03 \x01(\x05R\x10RemovedTreasures\x12&\n" +
+	"\x0eReclaimedBytes\x18\x04 \x01(\x03R\x0eReclaimedBytes\"V\n" +
+	"\x0ePutBlobRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x12\n" +
+	"\x04Hash\x18\x02 \x01(\tR\x04Hash\x12\x14\n" +
+	"\x05Chunk\x18\x03 \x01(\fR\x05Chunk\"o\n" +
+	"\x0fPutBlobResponse\x12\x12\n" +
+	"\x04Hash\x18\x01 \x01(\tR\x04Hash\x12\x14\n" +
+	"\x05IsNew\x18\x02 \x01(\bR\x05IsNew\x12\x1e\n" +
+	"\n" +
+	"References\x18\x03 \x01(\x03R\n" +
+	"References\x12\x12\n" +
+	"\x04Size\x18\x04 \x01(\x03R\x04Size\"^\n" +
+	"\x0eGetBlobRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x12\n" +
+	"\x04Hash\x18\x02 \x01(\tR\x04Hash\x12\x1c\n" +
+	"\tChunkSize\x18\x03 \x01(\rR\tChunkSize\"'\n" +
+	"\x0fGetBlobResponse\x12\x14\n" +
+	"\x05Chunk\x18\x01 \x01(\fR\x05Chunk\"V\n" +
+	"\x0eRefBlobRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x12\n" +
+	"\x04Hash\x18\x02 \x01(\tR\x04Hash\x12\x14\n" +
+	"\x05Delta\x18\x03 \x01(\x03R\x05Delta\"1\n" +
+	"\x0fRefBlobResponse\x12\x1e\n" +
+	"\n" +
+	"References\x18\x01 \x01(\x03R\n" +
+	"References\"A\n" +
+	"\x19CollectBlobGarbageRequest\x12$\n" +
+	"\rMinAgeSeconds\x18\x01 \x01(\x03R\rMinAgeSeconds\"`\n" +
+	"\x1aCollectBlobGarbageResponse\x12\"\n" +
+	"\fRemovedBlobs\x18\x01 \x01(\x03R\fRemovedBlobs\x12\x1e\n" +
+	"\n" +
+	"FreedBytes\x18\x02 \x01(\x03R\n" +
+	"FreedBytes\"\xec\x01\n" +
+	"\x14QueryAuditLogRequest\x12.\n" +
+	"\x04From\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04From\x12*\n" +
+	"\x02To\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02To\x12\x16\n" +
+	"\x06Method\x18\x03 \x01(\tR\x06Method\x12\x1a\n" +
+	"\bClientID\x18\x04 \x01(\tR\bClientID\x12\x1c\n" +
+	"\tSwampName\x18\x05 \x01(\tR\tSwampName\x12\x10\n" +
+	"\x03Key\x18\x06 \x01(\tR\x03Key\x12\x14\n" +
+	"\x05Limit\x18\a \x01(\x05R\x05Limit\"\xbf\x02\n" +
+	"\vAuditRecord\x12.\n" +
+	"\x04Time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04Time\x12\x16\n" +
+	"\x06Method\x18\x02 \x01(\tR\x06Method\x12\x1a\n" +
+	"\bClientID\x18\x03 \x01(\tR\bClientID\x12\x1a\n" +
+	"\bClientIP\x18\x04 \x01(\tR\bClientIP\x12\x16\n" +
+	"\x06Tenant\x18\x05 \x01(\tR\x06Tenant\x12\x1e\n" +
+	"\n" +
+	"SwampNames\x18\x06 \x03(\tR\n" +
+	"SwampNames\x12\x1e\n" +
+	"\n" +
+	"SwampCount\x18\a \x01(\x05R\n" +
+	"SwampCount\x12\x12\n" +
+	"\x04Keys\x18\b \x03(\tR\x04Keys\x12\x1a\n" +
+	"\bKeyCount\x18\t \x01(\x05R\bKeyCount\x12\x12\n" +
+	"\x04Code\x18\n" +
+	" \x01(\tR\x04Code\x12\x14\n" +
+	"\x05Error\x18\v \x01(\tR\x05Error\"L\n" +
+	"\x15QueryAuditLogResponse\x123\n" +
+	"\aRecords\x18\x01 \x03(\v2\x19.hydraidepbgo.AuditRecordR\aRecords\"Z\n" +
+	"\x1aVerifyIslandMappingRequest\x12\x1c\n" +
+	"\tSwampName\x18\x01 \x01(\tR\tSwampName\x12\x1e\n" +
+	"\n" +
+	"AllIslands\x18\x02 \x01(\x04R\n" +
+	"AllIslands\"]\n" +
+	"\x1bVerifyIslandMappingResponse\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\"\n" +
+	"\fHashFunction\x18\x02 \x01(\tR\fHashFunction\"\x87\x01\n" +
+	"\x19RestorePointInTimeRequest\x120\n" +
+	"\x05Until\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05Until\x12\x1a\n" +
+	"\bPatterns\x18\x02 \x03(\tR\bPatterns\x12\x1c\n" +
+	"\tIslandIDs\x18\x03 \x03(\x04R\tIslandIDs\"\xd4\x01\n" +
+	"\x1aRestorePointInTimeResponse\x12\x1a\n" +
+	"\bBackupID\x18\x01 \x01(\tR\bBackupID\x12\x16\n" +
+	"\x06Swamps\x18\x02 \x01(\x03R\x06Swamps\x12(\n" +
+	"\x0fReplayedRecords\x18\x03 \x01(\x03R\x0fReplayedRecords\x12,\n" +
+	"\x11RestoredTreasures\x18\x04 \x01(\x03R\x11RestoredTreasures\x12*\n" +
+	"\x10DeletedTreasures\x18\x05 \x01(\x03R\x10DeletedTreasures\"\x1b\n" +
+	"\x19GetClusterTopologyRequest\"_\n" +
+	"\rClusterServer\x12\x12\n" +
+	"\x04Host\x18\x01 \x01(\tR\x04Host\x12\x1e\n" +
+	"\n" +
+	"FromIsland\x18\x02 \x01(\x04R\n" +
+	"FromIsland\x12\x1a\n" +
+	"\bToIsland\x18\x03 \x01(\x04R\bToIsland\"\x8d\x01\n" +
+	"\x1aGetClusterTopologyResponse\x12\x18\n" +
+	"\aVersion\x18\x01 \x01(\tR\aVersion\x12\x1e\n" +
+	"\n" +
+	"AllIslands\x18\x02 \x01(\x04R\n" +
+	"AllIslands\x125\n" +
+	"\aServers\x18\x03 \x03(\v2\x1b.hydraidepbgo.ClusterServerR\aServers\"\x9c\x01\n" +
+	"\x19SetClusterTopologyRequest\x12(\n" +
+	"\x0fExpectedVersion\x18\x01 \x01(\tR\x0fExpectedVersion\x12\x1e\n" +
+	"\n" +
+	"AllIslands\x18\x02 \x01(\x04R\n" +
+	"AllIslands\x125\n" +
+	"\aServers\x18\x03 \x03(\v2\x1b.hydraidepbgo.ClusterServerR\aServers\"6\n" +
+	"\x1aSetClusterTopologyResponse\x12\x18\n" +
+	"\aVersion\x18\x01 \x01(\tR\aVersion\"9\n" +
+	"\vIslandState\"*\n" +
+	"\x05State\x12\n" +
+	"\n" +
+	"\x06ACTIVE\x10\x00\x12\n" +
+	"\n" +
+	"\x06FROZEN\x10\x01\x12\t\n" +
+	"\x05MOVED\x10\x02\"\x84\x01\n" +
+	"\x15SetIslandStateRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x125\n" +
+	"\x05State\x18\x02 \x01(\x0e2\x1f.hydraidepbgo.IslandState.StateR\x05State\x12\x18\n" +
+	"\aMovedTo\x18\x03 \x01(\tR\aMovedTo\"\x18\n" +
+	"\x16SetIslandStateResponse\"1\n" +
+	"\x13ExportIslandRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\"H\n" +
+	"\x14ExportIslandResponse\x12\x14\n" +
+	"\x05Chunk\x18\x01 \x01(\fR\x05Chunk\x12\x1a\n" +
+	"\bManifest\x18\x02 \x01(\fR\bManifest\"c\n" +
+	"\x13ImportIslandRequest\x12\x1a\n" +
+	"\bIslandID\x18\x01 \x01(\x04R\bIslandID\x12\x14\n" +
+	"\x05Chunk\x18\x02 \x01(\fR\x05Chunk\x12\x1a\n" +
+	"\bManifest\x18\x03 \x01(\fR\bManifest\"D\n" +
+	"\x14ImportIslandResponse\x12\x16\n" +
+	"\x06Swamps\x18\x01 \x01(\x04R\x06Swamps\x12\x14\n" +
+	"\x05Files\x18\x02 \x01(\x04R\x05Files\"\x17\n" +
+	"\x15ReloadDefaultsRequest\"\xa4\x02\n" +
+	"\x16ReloadDefaultsResponse\x12&\n" +
+	"\x0eCloseAfterIdle\x18\x01 \x01(\x03R\x0eCloseAfterIdle\x12$\n" +
+	"\rWriteInterval\x18\x02 \x01(\x03R\rWriteInterval\x12 \n" +
+	"\vMaxFileSize\x18\x03 \x01(\x03R\vMaxFileSize\x126\n" +
+	"\x05Fsync\x18\x04 \x01(\x0e2 .hydraidepbgo.FsyncPolicy.PolicyR\x05Fsync\x12$\n" +
+	"\rFsyncInterval\x18\x05 \x01(\x03R\rFsyncInterval\x12<\n" +
+	"\x19ApplyToUnregisteredSwamps\x18\x06 \x01(\bR\x19ApplyToUnregisteredSwamps2\xbb7\n" +
 	"\x0fHydraideService\x12N\n" +
 	"\tHeartbeat\x12\x1e.hydraidepbgo.HeartbeatRequest\x1a\x1f.hydraidepbgo.HeartbeatResponse\"\x00\x12?\n" +
 	"\x04Lock\x12\x19.hydraidepbgo.LockRequest\x1a\x1a.hydraidepbgo.LockResponse\"\x00\x12E\n" +
 	"\x06Unlock\x12\x1b.hydraidepbgo.UnlockRequest\x1a\x1c.hydraidepbgo.UnlockResponse\"\x00\x12Z\n" +
 	"\rRegisterSwamp\x12\".hydraidepbgo.RegisterSwampRequest\x1a#.hydraidepbgo.RegisterSwampResponse\"\x00\x12`\n" +
-	"\x0fDeRegisterSwamp\x12$.hydraidepbgo.DeRegisterSwampRequest\x1a%.hydraidepbgo.DeRegisterSwampResponse\"\x00\x12<\n" +
+	"\x0fDeRegisterSwamp\x12$.hydraidepbgo.DeRegisterSwampRequest\x1a%.hydraidepbgo.DeRegisterSwampResponse\"\x00\x12f\n" +
+	"\x11ListSwampPatterns\x12&.hydraidepbgo.ListSwampPatternsRequest\x1a'.hydraidepbgo.ListSwampPatternsResponse\"\x00\x12i\n" +
+	"\x12UpdateSwampPattern\x12'.hydraidepbgo.UpdateSwampPatternRequest\x1a(.hydraidepbgo.UpdateSwampPatternResponse\"\x00\x12<\n" +
 	"\x03Set\x12\x18.hydraidepbgo.SetRequest\x1a\x19.hydraidepbgo.SetResponse\"\x00\x12<\n" +
-	"\x03Get\x12\x18.hydraidepbgo.GetRequest\x1a\x19.hydraidepbgo.GetResponse\"\x00\x12E\n" +
-	"\x06GetAll\x12\x1b.hydraidepbgo.GetAllRequest\x1a\x1c.hydraidepbgo.GetAllResponse\"\x00\x12Q\n" +
+	"\x03Get\x12\x18.hydraidepbgo.GetRequest\x1a\x19.hydraidepbgo.GetResponse\"\x00\x12\\\n" +
+	"\rSetLargeValue\x12\".hydraidepbgo.SetLargeValueRequest\x1a#.hydraidepbgo.SetLargeValueResponse\"\x00(\x01\x12\\\n" +
+	"\rGetLargeValue\x12\".hydraidepbgo.GetLargeValueRequest\x1a#.hydraidepbgo.GetLargeValueResponse\"\x000\x01\x12E\n" +
+	"\x06GetAll\x12\x1b.hydraidepbgo.GetAllRequest\x1a\x1c.hydraidepbgo.GetAllResponse\"\x00\x12Y\n" +
+	"\fGetAllStream\x12!.hydraidepbgo.GetAllStreamRequest\x1a\".hydraidepbgo.GetAllStreamResponse\"\x000\x01\x12Q\n" +
 	"\n" +
-	"GetByIndex\x12\x1f.hydraidepbgo.GetByIndexRequest\x1a .hydraidepbgo.GetByIndexResponse\"\x00\x12r\n" +
-	"\x15ShiftExpiredTreasures\x12*.hydraidepbgo.ShiftExpiredTreasuresRequest\x1a+.hydraidepbgo.ShiftExpiredTreasuresResponse\"\x00\x12H\n" +
-	"\aDestroy\x12\x1c.hydraidepbgo.DestroyRequest\x1a\x1d.hydraidepbgo.DestroyResponse\"\x00\x12E\n" +
-	"\x06Delete\x12\x1b.hydraidepbgo.DeleteRequest\x1a\x1c.hydraidepbgo.DeleteResponse\"\x00\x12B\n" +
+	"GetByIndex\x12\x1f.hydraidepbgo.GetByIndexRequest\x1a .hydraidepbgo.GetByIndexResponse\"\x00\x12H\n" +
+	"\aGetTopN\x12\x1c.hydraidepbgo.GetTopNRequest\x1a\x1d.hydraidepbgo.GetTopNResponse\"\x00\x12Q\n" +
+	"\n" +
+	"GetByValue\x12\x1f.hydraidepbgo.GetByValueRequest\x1a .hydraidepbgo.GetByValueResponse\"\x00\x12]\n" +
+	"\x0eGetByReference\x12#.hydraidepbgo.GetByReferenceRequest\x1a$.hydraidepbgo.GetByReferenceResponse\"\x00\x12r\n" +
+	"\x15ShiftExpiredTreasures\x12*.hydraidepbgo.ShiftExpiredTreasuresRequest\x1a+.hydraidepbgo.ShiftExpiredTreasuresResponse\"\x00\x12r\n" +
+	"\x15LeaseExpiredTreasures\x12*.hydraidepbgo.LeaseExpiredTreasuresRequest\x1a+.hydraidepbgo.LeaseExpiredTreasuresResponse\"\x00\x12K\n" +
+	"\bAckLease\x12\x1d.hydraidepbgo.AckLeaseRequest\x1a\x1e.hydraidepbgo.AckLeaseResponse\"\x00\x12N\n" +
+	"\tNackLease\x12\x1e.hydraidepbgo.NackLeaseRequest\x1a\x1f.hydraidepbgo.NackLeaseResponse\"\x00\x12H\n" +
+	"\aDestroy\x12\x1c.hydraidepbgo.DestroyRequest\x1a\x1d.hydraidepbgo.DestroyResponse\"\x00\x12V\n" +
+	"\vDestroyMany\x12 .hydraidepbgo.DestroyManyRequest\x1a!.hydraidepbgo.DestroyManyResponse\"\x000\x01\x12E\n" +
+	"\x06Delete\x12\x1b.hydraidepbgo.DeleteRequest\x1a\x1c.hydraidepbgo.DeleteResponse\"\x00\x12T\n" +
+	"\vListDeleted\x12 .hydraidepbgo.ListDeletedRequest\x1a!.hydraidepbgo.ListDeletedResponse\"\x00\x12H\n" +
+	"\aRestore\x12\x1c.hydraidepbgo.RestoreRequest\x1a\x1d.hydraidepbgo.RestoreResponse\"\x00\x12Q\n" +
+	"\n" +
+	"GetHistory\x12\x1f.hydraidepbgo.GetHistoryRequest\x1a .hydraidepbgo.GetHistoryResponse\"\x00\x12K\n" +
+	"\bRevertTo\x12\x1d.hydraidepbgo.RevertToRequest\x1a\x1e.hydraidepbgo.RevertToResponse\"\x00\x12B\n" +
 	"\x05Count\x12\x1a.hydraidepbgo.CountRequest\x1a\x1b.hydraidepbgo.CountResponse\"\x00\x12W\n" +
 	"\fIsSwampExist\x12!.hydraidepbgo.IsSwampExistRequest\x1a\".hydraidepbgo.IsSwampExistResponse\"\x00\x12Q\n" +
 	"\n" +
-	"IsKeyExist\x12\x1f.hydraidepbgo.IsKeyExistRequest\x1a .hydraidepbgo.IsKeyExistResponse\"\x00\x12h\n" +
-	"\x11SubscribeToEvents\x12&.hydraidepbgo.SubscribeToEventsRequest\x1a'.hydraidepbgo.SubscribeToEventsResponse\"\x000\x01\x12b\n" +
+	"ExistsMany\x12\x1f.hydraidepbgo.ExistsManyRequest\x1a .hydraidepbgo.ExistsManyResponse\"\x00\x12Q\n" +
+	"\n" +
+	"IsKeyExist\x12\x1f.hydraidepbgo.IsKeyExistRequest\x1a .hydraidepbgo.IsKeyExistResponse\"\x00\x12T\n" +
+	"\vIsKeysExist\x12 .hydraidepbgo.IsKeysExistRequest\x1a!.hydraidepbgo.IsKeysExistResponse\"\x00\x12h\n" +
+	"\x11SubscribeToEvents\x12&.hydraidepbgo.SubscribeToEventsRequest\x1a'.hydraidepbgo.SubscribeToEventsResponse\"\x000\x01\x12^\n" +
+	"\fSubscribeAll\x12!.hydraidepbgo.SubscribeAllRequest\x1a'.hydraidepbgo.SubscribeToEventsResponse\"\x000\x01\x12b\n" +
 	"\x0fSubscribeToInfo\x12$.hydraidepbgo.SubscribeToInfoRequest\x1a%.hydraidepbgo.SubscribeToInfoResponse\"\x000\x01\x12j\n" +
 	"\x0fUint32SlicePush\x12).hydraidepbgo.AddToUint32SlicePushRequest\x1a*.hydraidepbgo.AddToUint32SlicePushResponse\"\x00\x12f\n" +
 	"\x11Uint32SliceDelete\x12&.hydraidepbgo.Uint32SliceDeleteRequest\x1a'.hydraidepbgo.Uint32SliceDeleteResponse\"\x00\x12`\n" +
 	"\x0fUint32SliceSize\x12$.hydraidepbgo.Uint32SliceSizeRequest\x1a%.hydraidepbgo.Uint32SliceSizeResponse\"\x00\x12x\n" +
-	"\x17Uint32SliceIsValueExist\x12,.hydraidepbgo.Uint32SliceIsValueExistRequest\x1a-.hydraidepbgo.Uint32SliceIsValueExistResponse\"\x00\x12Z\n" +
+	"\x17Uint32SliceIsValueExist\x12,.hydraidepbgo.Uint32SliceIsValueExistRequest\x1a-.hydraidepbgo.Uint32SliceIsValueExistResponse\"\x00\x12l\n" +
+	"\x13Uint32SliceGetRange\x12(.hydraidepbgo.Uint32SliceGetRangeRequest\x1a).hydraidepbgo.Uint32SliceGetRangeResponse\"\x00\x12h\n" +
+	"\x11Uint32SliceStream\x12&.hydraidepbgo.Uint32SliceStreamRequest\x1a'.hydraidepbgo.Uint32SliceStreamResponse\"\x000\x01\x12j\n" +
+	"\x12Uint32SliceCombine\x12'.hydraidepbgo.Uint32SliceCombineRequest\x1a'.hydraidepbgo.Uint32SliceStreamResponse\"\x000\x01\x12u\n" +
+	"\x16Uint32SliceCombineInto\x12+.hydraidepbgo.Uint32SliceCombineIntoRequest\x1a,.hydraidepbgo.Uint32SliceCombineIntoResponse\"\x00\x12Z\n" +
 	"\rIncrementInt8\x12\".hydraidepbgo.IncrementInt8Request\x1a#.hydraidepbgo.IncrementInt8Response\"\x00\x12]\n" +
 	"\x0eIncrementInt16\x12#.hydraidepbgo.IncrementInt16Request\x1a$.hydraidepbgo.IncrementInt16Response\"\x00\x12]\n" +
 	"\x0eIncrementInt32\x12#.hydraidepbgo.IncrementInt32Request\x1a$.hydraidepbgo.IncrementInt32Response\"\x00\x12]\n" +
@@ -6460,7 +14821,31 @@ const file_hydraide_proto_rawDesc = "" +
 	"\x0fIncrementUint32\x12$.hydraidepbgo.IncrementUint32Request\x1a%.hydraidepbgo.IncrementUint32Response\"\x00\x12`\n" +
 	"\x0fIncrementUint64\x12$.hydraidepbgo.IncrementUint64Request\x1a%.hydraidepbgo.IncrementUint64Response\"\x00\x12c\n" +
 	"\x10IncrementFloat32\x12%.hydraidepbgo.IncrementFloat32Request\x1a&.hydraidepbgo.IncrementFloat32Response\"\x00\x12c\n" +
-	"\x10IncrementFloat64\x12%.hydraidepbgo.IncrementFloat64Request\x1a&.hydraidepbgo.IncrementFloat64Response\"\x00BBZ@github.com/hydraide/hydraide/generated/hydraidepbgo;hydraidepbgob\x06proto3"
+	"\x10IncrementFloat64\x12%.hydraidepbgo.IncrementFloat64Request\x1a&.hydraidepbgo.IncrementFloat64Response\"\x00\x12Q\n" +
+	"\n" +
+	"SetIfLater\x12\x1f.hydraidepbgo.SetIfLaterRequest\x1a .hydraidepbgo.SetIfLaterResponse\"\x00\x12T\n" +
+	"\vAddDuration\x12 .hydraidepbgo.AddDurationRequest\x1a!.hydraidepbgo.AddDurationResponse\"\x00\x12T\n" +
+	"\vSetStringIf\x12 .hydraidepbgo.SetStringIfRequest\x1a!.hydraidepbgo.SetStringIfResponse\"\x00\x12N\n" +
+	"\tSetBoolIf\x12\x1e.hydraidepbgo.SetBoolIfRequest\x1a\x1f.hydraidepbgo.SetBoolIfResponse\"\x00\x12i\n" +
+	"\x12SetSwampAnnotation\x12'.hydraidepbgo.SetSwampAnnotationRequest\x1a(.hydraidepbgo.SetSwampAnnotationResponse\"\x00\x12l\n" +
+	"\x13GetSwampAnnotations\x12(.hydraidepbgo.GetSwampAnnotationsRequest\x1a).hydraidepbgo.GetSwampAnnotationsResponse\"\x00\x12N\n" +
+	"\tAggregate\x12\x1e.hydraidepbgo.AggregateRequest\x1a\x1f.hydraidepbgo.AggregateResponse\"\x00\x12i\n" +
+	"\x12ListCorruptedFiles\x12'.hydraidepbgo.ListCorruptedFilesRequest\x1a(.hydraidepbgo.ListCorruptedFilesResponse\"\x00\x12f\n" +
+	"\x11GetNamespaceUsage\x12&.hydraidepbgo.GetNamespaceUsageRequest\x1a'.hydraidepbgo.GetNamespaceUsageResponse\"\x00\x12W\n" +
+	"\fCompactSwamp\x12!.hydraidepbgo.CompactSwampRequest\x1a\".hydraidepbgo.CompactSwampResponse\"\x00\x12J\n" +
+	"\aPutBlob\x12\x1c.hydraidepbgo.PutBlobRequest\x1a\x1d.hydraidepbgo.PutBlobResponse\"\x00(\x01\x12J\n" +
+	"\aGetBlob\x12\x1c.hydraidepbgo.GetBlobRequest\x1a\x1d.hydraidepbgo.GetBlobResponse\"\x000\x01\x12H\n" +
+	"\aRefBlob\x12\x1c.hydraidepbgo.RefBlobRequest\x1a\x1d.hydraidepbgo.RefBlobResponse\"\x00\x12i\n" +
+	"\x12CollectBlobGarbage\x12'.hydraidepbgo.CollectBlobGarbageRequest\x1a(.hydraidepbgo.CollectBlobGarbageResponse\"\x00\x12Z\n" +
+	"\rQueryAuditLog\x12\".hydraidepbgo.QueryAuditLogRequest\x1a#.hydraidepbgo.QueryAuditLogResponse\"\x00\x12l\n" +
+	"\x13VerifyIslandMapping\x12(.hydraidepbgo.VerifyIslandMappingRequest\x1a).hydraidepbgo.VerifyIslandMappingResponse\"\x00\x12i\n" +
+	"\x12RestorePointInTime\x12'.hydraidepbgo.RestorePointInTimeRequest\x1a(.hydraidepbgo.RestorePointInTimeResponse\"\x00\x12i\n" +
+	"\x12GetClusterTopology\x12'.hydraidepbgo.GetClusterTopologyRequest\x1a(.hydraidepbgo.GetClusterTopologyResponse\"\x00\x12i\n" +
+	"\x12SetClusterTopology\x12'.hydraidepbgo.SetClusterTopologyRequest\x1a(.hydraidepbgo.SetClusterTopologyResponse\"\x00\x12]\n" +
+	"\x0eSetIslandState\x12#.hydraidepbgo.SetIslandStateRequest\x1a$.hydraidepbgo.SetIslandStateResponse\"\x00\x12Y\n" +
+	"\fExportIsland\x12!.hydraidepbgo.ExportIslandRequest\x1a\".hydraidepbgo.ExportIslandResponse\"\x000\x01\x12Y\n" +
+	"\fImportIsland\x12!.hydraidepbgo.ImportIslandRequest\x1a\".hydraidepbgo.ImportIslandResponse\"\x00(\x01\x12]\n" +
+	"\x0eReloadDefaults\x12#.hydraidepbgo.ReloadDefaultsRequest\x1a$.hydraidepbgo.ReloadDefaultsResponse\"\x00BBZ@github.com/hydraide/hydraide/generated/hydraidepbgo;hydraidepbgob\x06proto3"
 
 var (
 	file_hydraide_proto_rawDescOnce sync.Once
@@ -6474,231 +14859,506 @@ func file_hydraide_proto_rawDescGZIP() []byte {
 	return file_hydraide_proto_rawDescData
 }
 
-var file_hydraide_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_hydraide_proto_msgTypes = make([]protoimpl.MessageInfo, 90)
+var file_hydraide_proto_enumTypes = make([]protoimpl.EnumInfo, 15)
+var file_hydraide_proto_msgTypes = make([]protoimpl.MessageInfo, 203)
 var file_hydraide_proto_goTypes = []any{
-	(SwampResponse_ErrCodeEnum)(0), // 0: hydraidepbgo.SwampResponse.ErrCodeEnum
-	(Status_Code)(0),               // 1: hydraidepbgo.Status.Code
-	(Boolean_Type)(0),              // 2: hydraidepbgo.Boolean.Type
-	(IndexType_Type)(0),            // 3: hydraidepbgo.IndexType.Type
-	(OrderType_Type)(0),            // 4: hydraidepbgo.OrderType.Type
-	(DeleteResponse_SwampDeleteResponse_ErrorCodeEnum)(0), // 5: hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCodeEnum
-	(Relational_Operator)(0),                              // 6: hydraidepbgo.Relational.Operator
-	(*HeartbeatRequest)(nil),                              // 7: hydraidepbgo.HeartbeatRequest
-	(*HeartbeatResponse)(nil),                             // 8: hydraidepbgo.HeartbeatResponse
-	(*LockRequest)(nil),                                   // 9: hydraidepbgo.LockRequest
-	(*LockResponse)(nil),                                  // 10: hydraidepbgo.LockResponse
-	(*UnlockRequest)(nil),                                 // 11: hydraidepbgo.UnlockRequest
-	(*UnlockResponse)(nil),                                // 12: hydraidepbgo.UnlockResponse
-	(*DestroyRequest)(nil),                                // 13: hydraidepbgo.DestroyRequest
-	(*DestroyResponse)(nil),                               // 14: hydraidepbgo.DestroyResponse
-	(*SubscribeToInfoRequest)(nil),                        // 15: hydraidepbgo.SubscribeToInfoRequest
-	(*SubscribeToInfoResponse)(nil),                       // 16: hydraidepbgo.SubscribeToInfoResponse
-	(*SubscribeToEventsRequest)(nil),                      // 17: hydraidepbgo.SubscribeToEventsRequest
-	(*SubscribeToEventsResponse)(nil),                     // 18: hydraidepbgo.SubscribeToEventsResponse
-	(*SwampKeys)(nil),                                     // 19: hydraidepbgo.SwampKeys
-	(*RegisterSwampRequest)(nil),                          // 20: hydraidepbgo.RegisterSwampRequest
-	(*RegisterSwampResponse)(nil),                         // 21: hydraidepbgo.RegisterSwampResponse
-	(*DeRegisterSwampRequest)(nil),                        // 22: hydraidepbgo.DeRegisterSwampRequest
-	(*DeRegisterSwampResponse)(nil),                       // 23: hydraidepbgo.DeRegisterSwampResponse
-	(*SetRequest)(nil),                                    // 24: hydraidepbgo.SetRequest
-	(*SwampRequest)(nil),                                  // 25: hydraidepbgo.SwampRequest
-	(*KeyValuePair)(nil),                                  // 26: hydraidepbgo.KeyValuePair
-	(*SetResponse)(nil),                                   // 27: hydraidepbgo.SetResponse
-	(*SwampResponse)(nil),                                 // 28: hydraidepbgo.SwampResponse
-	(*KeyStatusPair)(nil),                                 // 29: hydraidepbgo.KeyStatusPair
-	(*Status)(nil),                                        // 30: hydraidepbgo.Status
-	(*GetRequest)(nil),                                    // 31: hydraidepbgo.GetRequest
-	(*GetSwamp)(nil),                                      // 32: hydraidepbgo.GetSwamp
-	(*GetResponse)(nil),                                   // 33: hydraidepbgo.GetResponse
-	(*GetSwampResponse)(nil),                              // 34: hydraidepbgo.GetSwampResponse
-	(*GetAllRequest)(nil),                                 // 35: hydraidepbgo.GetAllRequest
-	(*GetAllResponse)(nil),                                // 36: hydraidepbgo.GetAllResponse
-	(*ShiftExpiredTreasuresRequest)(nil),                  // 37: hydraidepbgo.ShiftExpiredTreasuresRequest
-	(*ShiftExpiredTreasuresResponse)(nil),                 // 38: hydraidepbgo.ShiftExpiredTreasuresResponse
-	(*Treasure)(nil),                                      // 39: hydraidepbgo.Treasure
-	(*Boolean)(nil),                                       // 40: hydraidepbgo.Boolean
-	(*GetByIndexRequest)(nil),                             // 41: hydraidepbgo.GetByIndexRequest
-	(*IndexType)(nil),                                     // 42: hydraidepbgo.IndexType
-	(*OrderType)(nil),                                     // 43: hydraidepbgo.OrderType
-	(*GetByIndexResponse)(nil),                            // 44: hydraidepbgo.GetByIndexResponse
-	(*DeleteRequest)(nil),                                 // 45: hydraidepbgo.DeleteRequest
-	(*DeleteResponse)(nil),                                // 46: hydraidepbgo.DeleteResponse
-	(*CountRequest)(nil),                                  // 47: hydraidepbgo.CountRequest
-	(*CountResponse)(nil),                                 // 48: hydraidepbgo.CountResponse
-	(*CountSwamp)(nil),                                    // 49: hydraidepbgo.CountSwamp
-	(*IncrementInt8Request)(nil),                          // 50: hydraidepbgo.IncrementInt8Request
-	(*IncrementInt8Condition)(nil),                        // 51: hydraidepbgo.IncrementInt8Condition
-	(*IncrementInt8Response)(nil),                         // 52: hydraidepbgo.IncrementInt8Response
-	(*IncrementInt16Request)(nil),                         // 53: hydraidepbgo.IncrementInt16Request
-	(*IncrementInt16Condition)(nil),                       // 54: hydraidepbgo.IncrementInt16Condition
-	(*IncrementInt16Response)(nil),                        // 55: hydraidepbgo.IncrementInt16Response
-	(*IncrementInt32Request)(nil),                         // 56: hydraidepbgo.IncrementInt32Request
-	(*IncrementInt32Condition)(nil),                       // 57: hydraidepbgo.IncrementInt32Condition
-	(*IncrementInt32Response)(nil),                        // 58: hydraidepbgo.IncrementInt32Response
-	(*IncrementInt64Request)(nil),                         // 59: hydraidepbgo.IncrementInt64Request
-	(*IncrementInt64Condition)(nil),                       // 60: hydraidepbgo.IncrementInt64Condition
-	(*IncrementInt64Response)(nil),                        // 61: hydraidepbgo.IncrementInt64Response
-	(*IncrementUint8Request)(nil),                         // 62: hydraidepbgo.IncrementUint8Request
-	(*IncrementUint8Condition)(nil),                       // 63: hydraidepbgo.IncrementUint8Condition
-	(*IncrementUint8Response)(nil),                        // 64: hydraidepbgo.IncrementUint8Response
-	(*IncrementUint16Request)(nil),                        // 65: hydraidepbgo.IncrementUint16Request
-	(*IncrementUint16Condition)(nil),                      // 66: hydraidepbgo.IncrementUint16Condition
-	(*IncrementUint16Response)(nil),                       // 67: hydraidepbgo.IncrementUint16Response
-	(*IncrementUint32Request)(nil),                        // 68: hydraidepbgo.IncrementUint32Request
-	(*IncrementUint32Condition)(nil),                      // 69: hydraidepbgo.IncrementUint32Condition
-	(*IncrementUint32Response)(nil),                       // 70: hydraidepbgo.IncrementUint32Response
-	(*IncrementUint64Request)(nil),                        // 71: hydraidepbgo.IncrementUint64Request
-	(*IncrementUint64Condition)(nil),                      // 72: hydraidepbgo.IncrementUint64Condition
-	(*IncrementUint64Response)(nil),                       // 73: hydraidepbgo.IncrementUint64Response
-	(*Relational)(nil),                                    // 74: hydraidepbgo.Relational
-	(*IncrementFloat32Request)(nil),                       // 75: hydraidepbgo.IncrementFloat32Request
-	(*IncrementFloat32Condition)(nil),                     // 76: hydraidepbgo.IncrementFloat32Condition
-	(*IncrementFloat32Response)(nil),                      // 77: hydraidepbgo.IncrementFloat32Response
-	(*IncrementFloat64Request)(nil),                       // 78: hydraidepbgo.IncrementFloat64Request
-	(*IncrementFloat64Condition)(nil),                     // 79: hydraidepbgo.IncrementFloat64Condition
-	(*IncrementFloat64Response)(nil),                      // 80: hydraidepbgo.IncrementFloat64Response
-	(*KeySlicePair)(nil),                                  // 81: hydraidepbgo.KeySlicePair
-	(*AddToUint32SlicePushRequest)(nil),                   // 82: hydraidepbgo.AddToUint32SlicePushRequest
-	(*AddToUint32SlicePushResponse)(nil),                  // 83: hydraidepbgo.AddToUint32SlicePushResponse
-	(*Uint32SliceDeleteRequest)(nil),                      // 84: hydraidepbgo.Uint32SliceDeleteRequest
-	(*Uint32SliceDeleteResponse)(nil),                     // 85: hydraidepbgo.Uint32SliceDeleteResponse
-	(*Uint32SliceSizeRequest)(nil),                        // 86: hydraidepbgo.Uint32SliceSizeRequest
-	(*Uint32SliceSizeResponse)(nil),                       // 87: hydraidepbgo.Uint32SliceSizeResponse
-	(*Uint32SliceIsValueExistRequest)(nil),                // 88: hydraidepbgo.Uint32SliceIsValueExistRequest
-	(*Uint32SliceIsValueExistResponse)(nil),               // 89: hydraidepbgo.Uint32SliceIsValueExistResponse
-	(*IsSwampExistRequest)(nil),                           // 90: hydraidepbgo.IsSwampExistRequest
-	(*IsSwampExistResponse)(nil),                          // 91: hydraidepbgo.IsSwampExistResponse
-	(*IsKeyExistRequest)(nil),                             // 92: hydraidepbgo.IsKeyExistRequest
-	(*IsKeyExistResponse)(nil),                            // 93: hydraidepbgo.IsKeyExistResponse
-	(*DeleteRequest_SwampKeys)(nil),                       // 94: hydraidepbgo.DeleteRequest.SwampKeys
-	(*DeleteResponse_SwampDeleteResponse)(nil),            // 95: hydraidepbgo.DeleteResponse.SwampDeleteResponse
-	(*CountRequest_SwampIdentifier)(nil),                  // 96: hydraidepbgo.CountRequest.SwampIdentifier
-	(*timestamppb.Timestamp)(nil),                         // 97: google.protobuf.Timestamp
+	(OverflowPolicy_Policy)(0),     // 0: hydraidepbgo.OverflowPolicy.Policy
+	(FsyncPolicy_Policy)(0),        // 1: hydraidepbgo.FsyncPolicy.Policy
+	(SwampResponse_ErrCodeEnum)(0), // 2: hydraidepbgo.SwampResponse.ErrCodeEnum
+	(Status_Code)(0),               // 3: hydraidepbgo.Status.Code
+	(Metadata_Field)(0),            // 4: hydraidepbgo.Metadata.Field
+	(Beacon_Type)(0),               // 5: hydraidepbgo.Beacon.Type
+	(Boolean_Type)(0),              // 6: hydraidepbgo.Boolean.Type
+	(IndexType_Type)(0),            // 7: hydraidepbgo.IndexType.Type
+	(OrderType_Type)(0),            // 8: hydraidepbgo.OrderType.Type
+	(DeleteResponse_SwampDeleteResponse_ErrorCodeEnum)(0), // 9: hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCodeEnum
+	(Relational_Operator)(0),                              // 10: hydraidepbgo.Relational.Operator
+	(SetIf_Operator)(0),                                   // 11: hydraidepbgo.SetIf.Operator
+	(Uint32SliceCombination_Operator)(0),                  // 12: hydraidepbgo.Uint32SliceCombination.Operator
+	(ErrorReason_Reason)(0),                               // 13: hydraidepbgo.ErrorReason.Reason
+	(IslandState_State)(0),                                // 14: hydraidepbgo.IslandState.State
+	(*HeartbeatRequest)(nil),                              // 15: hydraidepbgo.HeartbeatRequest
+	(*HeartbeatResponse)(nil),                             // 16: hydraidepbgo.HeartbeatResponse
+	(*LockRequest)(nil),                                   // 17: hydraidepbgo.LockRequest
+	(*LockResponse)(nil),                                  // 18: hydraidepbgo.LockResponse
+	(*UnlockRequest)(nil),                                 // 19: hydraidepbgo.UnlockRequest
+	(*UnlockResponse)(nil),                                // 20: hydraidepbgo.UnlockResponse
+	(*DestroyRequest)(nil),                                // 21: hydraidepbgo.DestroyRequest
+	(*DestroyResponse)(nil),                               // 22: hydraidepbgo.DestroyResponse
+	(*DestroyManyRequest)(nil),                            // 23: hydraidepbgo.DestroyManyRequest
+	(*DestroyManyResponse)(nil),                           // 24: hydraidepbgo.DestroyManyResponse
+	(*SubscribeToInfoRequest)(nil),                        // 25: hydraidepbgo.SubscribeToInfoRequest
+	(*SubscribeToInfoResponse)(nil),                       // 26: hydraidepbgo.SubscribeToInfoResponse
+	(*SubscribeToEventsRequest)(nil),                      // 27: hydraidepbgo.SubscribeToEventsRequest
+	(*SubscribeAllRequest)(nil),                           // 28: hydraidepbgo.SubscribeAllRequest
+	(*OverflowPolicy)(nil),                                // 29: hydraidepbgo.OverflowPolicy
+	(*SubscribeToEventsResponse)(nil),                     // 30: hydraidepbgo.SubscribeToEventsResponse
+	(*SwampKeys)(nil),                                     // 31: hydraidepbgo.SwampKeys
+	(*RegisterSwampRequest)(nil),                          // 32: hydraidepbgo.RegisterSwampRequest
+	(*FsyncPolicy)(nil),                                   // 33: hydraidepbgo.FsyncPolicy
+	(*RegisterSwampResponse)(nil),                         // 34: hydraidepbgo.RegisterSwampResponse
+	(*DeRegisterSwampRequest)(nil),                        // 35: hydraidepbgo.DeRegisterSwampRequest
+	(*DeRegisterSwampResponse)(nil),                       // 36: hydraidepbgo.DeRegisterSwampResponse
+	(*ListSwampPatternsRequest)(nil),                      // 37: hydraidepbgo.ListSwampPatternsRequest
+	(*ListSwampPatternsResponse)(nil),                     // 38: hydraidepbgo.ListSwampPatternsResponse
+	(*SwampPattern)(nil),                                  // 39: hydraidepbgo.SwampPattern
+	(*UpdateSwampPatternRequest)(nil),                     // 40: hydraidepbgo.UpdateSwampPatternRequest
+	(*UpdateSwampPatternResponse)(nil),                    // 41: hydraidepbgo.UpdateSwampPatternResponse
+	(*SetRequest)(nil),                                    // 42: hydraidepbgo.SetRequest
+	(*SwampRequest)(nil),                                  // 43: hydraidepbgo.SwampRequest
+	(*KeyValuePair)(nil),                                  // 44: hydraidepbgo.KeyValuePair
+	(*SetResponse)(nil),                                   // 45: hydraidepbgo.SetResponse
+	(*SwampResponse)(nil),                                 // 46: hydraidepbgo.SwampResponse
+	(*KeyStatusPair)(nil),                                 // 47: hydraidepbgo.KeyStatusPair
+	(*Status)(nil),                                        // 48: hydraidepbgo.Status
+	(*GetRequest)(nil),                                    // 49: hydraidepbgo.GetRequest
+	(*GetSwamp)(nil),                                      // 50: hydraidepbgo.GetSwamp
+	(*Projection)(nil),                                    // 51: hydraidepbgo.Projection
+	(*GetResponse)(nil),                                   // 52: hydraidepbgo.GetResponse
+	(*GetSwampResponse)(nil),                              // 53: hydraidepbgo.GetSwampResponse
+	(*SetLargeValueRequest)(nil),                          // 54: hydraidepbgo.SetLargeValueRequest
+	(*SetLargeValueResponse)(nil),                         // 55: hydraidepbgo.SetLargeValueResponse
+	(*GetLargeValueRequest)(nil),                          // 56: hydraidepbgo.GetLargeValueRequest
+	(*GetLargeValueResponse)(nil),                         // 57: hydraidepbgo.GetLargeValueResponse
+	(*GetAllRequest)(nil),                                 // 58: hydraidepbgo.GetAllRequest
+	(*GetAllResponse)(nil),                                // 59: hydraidepbgo.GetAllResponse
+	(*GetAllStreamRequest)(nil),                           // 60: hydraidepbgo.GetAllStreamRequest
+	(*GetAllStreamResponse)(nil),                          // 61: hydraidepbgo.GetAllStreamResponse
+	(*ShiftExpiredTreasuresRequest)(nil),                  // 62: hydraidepbgo.ShiftExpiredTreasuresRequest
+	(*ShiftExpiredTreasuresResponse)(nil),                 // 63: hydraidepbgo.ShiftExpiredTreasuresResponse
+	(*LeaseExpiredTreasuresRequest)(nil),                  // 64: hydraidepbgo.LeaseExpiredTreasuresRequest
+	(*LeaseExpiredTreasuresResponse)(nil),                 // 65: hydraidepbgo.LeaseExpiredTreasuresResponse
+	(*LeasedTreasure)(nil),                                // 66: hydraidepbgo.LeasedTreasure
+	(*AckLeaseRequest)(nil),                               // 67: hydraidepbgo.AckLeaseRequest
+	(*AckLeaseResponse)(nil),                              // 68: hydraidepbgo.AckLeaseResponse
+	(*NackLeaseRequest)(nil),                              // 69: hydraidepbgo.NackLeaseRequest
+	(*NackLeaseResponse)(nil),                             // 70: hydraidepbgo.NackLeaseResponse
+	(*Treasure)(nil),                                      // 71: hydraidepbgo.Treasure
+	(*Metadata)(nil),                                      // 72: hydraidepbgo.Metadata
+	(*Beacon)(nil),                                        // 73: hydraidepbgo.Beacon
+	(*Boolean)(nil),                                       // 74: hydraidepbgo.Boolean
+	(*GetByIndexRequest)(nil),                             // 75: hydraidepbgo.GetByIndexRequest
+	(*IndexType)(nil),                                     // 76: hydraidepbgo.IndexType
+	(*OrderType)(nil),                                     // 77: hydraidepbgo.OrderType
+	(*GetByIndexResponse)(nil),                            // 78: hydraidepbgo.GetByIndexResponse
+	(*GetTopNRequest)(nil),                                // 79: hydraidepbgo.GetTopNRequest
+	(*GetTopNResponse)(nil),                               // 80: hydraidepbgo.GetTopNResponse
+	(*GetByValueRequest)(nil),                             // 81: hydraidepbgo.GetByValueRequest
+	(*GetByValueResponse)(nil),                            // 82: hydraidepbgo.GetByValueResponse
+	(*GetByReferenceRequest)(nil),                         // 83: hydraidepbgo.GetByReferenceRequest
+	(*GetByReferenceResponse)(nil),                        // 84: hydraidepbgo.GetByReferenceResponse
+	(*DeleteRequest)(nil),                                 // 85: hydraidepbgo.DeleteRequest
+	(*DeleteResponse)(nil),                                // 86: hydraidepbgo.DeleteResponse
+	(*CountRequest)(nil),                                  // 87: hydraidepbgo.CountRequest
+	(*CountFilter)(nil),                                   // 88: hydraidepbgo.CountFilter
+	(*CountResponse)(nil),                                 // 89: hydraidepbgo.CountResponse
+	(*CountSwamp)(nil),                                    // 90: hydraidepbgo.CountSwamp
+	(*IncrementInt8Request)(nil),                          // 91: hydraidepbgo.IncrementInt8Request
+	(*IncrementInt8Condition)(nil),                        // 92: hydraidepbgo.IncrementInt8Condition
+	(*IncrementInt8Response)(nil),                         // 93: hydraidepbgo.IncrementInt8Response
+	(*IncrementInt16Request)(nil),                         // 94: hydraidepbgo.IncrementInt16Request
+	(*IncrementInt16Condition)(nil),                       // 95: hydraidepbgo.IncrementInt16Condition
+	(*IncrementInt16Response)(nil),                        // 96: hydraidepbgo.IncrementInt16Response
+	(*IncrementInt32Request)(nil),                         // 97: hydraidepbgo.IncrementInt32Request
+	(*IncrementInt32Condition)(nil),                       // 98: hydraidepbgo.IncrementInt32Condition
+	(*IncrementInt32Response)(nil),                        // 99: hydraidepbgo.IncrementInt32Response
+	(*IncrementInt64Request)(nil),                         // 100: hydraidepbgo.IncrementInt64Request
+	(*IncrementInt64Condition)(nil),                       // 101: hydraidepbgo.IncrementInt64Condition
+	(*IncrementInt64Response)(nil),                        // 102: hydraidepbgo.IncrementInt64Response
+	(*IncrementUint8Request)(nil),                         // 103: hydraidepbgo.IncrementUint8Request
+	(*IncrementUint8Condition)(nil),                       // 104: hydraidepbgo.IncrementUint8Condition
+	(*IncrementUint8Response)(nil),                        // 105: hydraidepbgo.IncrementUint8Response
+	(*IncrementUint16Request)(nil),                        // 106: hydraidepbgo.IncrementUint16Request
+	(*IncrementUint16Condition)(nil),                      // 107: hydraidepbgo.IncrementUint16Condition
+	(*IncrementUint16Response)(nil),                       // 108: hydraidepbgo.IncrementUint16Response
+	(*IncrementUint32Request)(nil),                        // 109: hydraidepbgo.IncrementUint32Request
+	(*IncrementUint32Condition)(nil),                      // 110: hydraidepbgo.IncrementUint32Condition
+	(*IncrementUint32Response)(nil),                       // 111: hydraidepbgo.IncrementUint32Response
+	(*IncrementUint64Request)(nil),                        // 112: hydraidepbgo.IncrementUint64Request
+	(*IncrementUint64Condition)(nil),                      // 113: hydraidepbgo.IncrementUint64Condition
+	(*IncrementUint64Response)(nil),                       // 114: hydraidepbgo.IncrementUint64Response
+	(*Relational)(nil),                                    // 115: hydraidepbgo.Relational
+	(*IncrementFloat32Request)(nil),                       // 116: hydraidepbgo.IncrementFloat32Request
+	(*IncrementFloat32Condition)(nil),                     // 117: hydraidepbgo.IncrementFloat32Condition
+	(*IncrementFloat32Response)(nil),                      // 118: hydraidepbgo.IncrementFloat32Response
+	(*IncrementFloat64Request)(nil),                       // 119: hydraidepbgo.IncrementFloat64Request
+	(*IncrementFloat64Condition)(nil),                     // 120: hydraidepbgo.IncrementFloat64Condition
+	(*IncrementFloat64Response)(nil),                      // 121: hydraidepbgo.IncrementFloat64Response
+	(*SetIfLaterRequest)(nil),                             // 122: hydraidepbgo.SetIfLaterRequest
+	(*SetIfLaterResponse)(nil),                            // 123: hydraidepbgo.SetIfLaterResponse
+	(*AddDurationRequest)(nil),                            // 124: hydraidepbgo.AddDurationRequest
+	(*AddDurationResponse)(nil),                           // 125: hydraidepbgo.AddDurationResponse
+	(*SetIf)(nil),                                         // 126: hydraidepbgo.SetIf
+	(*SetStringIfRequest)(nil),                            // 127: hydraidepbgo.SetStringIfRequest
+	(*SetStringCondition)(nil),                            // 128: hydraidepbgo.SetStringCondition
+	(*SetStringIfResponse)(nil),                           // 129: hydraidepbgo.SetStringIfResponse
+	(*SetBoolIfRequest)(nil),                              // 130: hydraidepbgo.SetBoolIfRequest
+	(*SetBoolCondition)(nil),                              // 131: hydraidepbgo.SetBoolCondition
+	(*SetBoolIfResponse)(nil),                             // 132: hydraidepbgo.SetBoolIfResponse
+	(*KeySlicePair)(nil),                                  // 133: hydraidepbgo.KeySlicePair
+	(*AddToUint32SlicePushRequest)(nil),                   // 134: hydraidepbgo.AddToUint32SlicePushRequest
+	(*AddToUint32SlicePushResponse)(nil),                  // 135: hydraidepbgo.AddToUint32SlicePushResponse
+	(*Uint32SlicePushResult)(nil),                         // 136: hydraidepbgo.Uint32SlicePushResult
+	(*Uint32SliceDeleteRequest)(nil),                      // 137: hydraidepbgo.Uint32SliceDeleteRequest
+	(*Uint32SliceDeleteResponse)(nil),                     // 138: hydraidepbgo.Uint32SliceDeleteResponse
+	(*Uint32SliceSizeRequest)(nil),                        // 139: hydraidepbgo.Uint32SliceSizeRequest
+	(*Uint32SliceSizeResponse)(nil),                       // 140: hydraidepbgo.Uint32SliceSizeResponse
+	(*Uint32SliceIsValueExistRequest)(nil),                // 141: hydraidepbgo.Uint32SliceIsValueExistRequest
+	(*Uint32SliceIsValueExistResponse)(nil),               // 142: hydraidepbgo.Uint32SliceIsValueExistResponse
+	(*Uint32SliceGetRangeRequest)(nil),                    // 143: hydraidepbgo.Uint32SliceGetRangeRequest
+	(*Uint32SliceGetRangeResponse)(nil),                   // 144: hydraidepbgo.Uint32SliceGetRangeResponse
+	(*Uint32SliceStreamRequest)(nil),                      // 145: hydraidepbgo.Uint32SliceStreamRequest
+	(*Uint32SliceStreamResponse)(nil),                     // 146: hydraidepbgo.Uint32SliceStreamResponse
+	(*Uint32SliceCombination)(nil),                        // 147: hydraidepbgo.Uint32SliceCombination
+	(*Uint32SliceCombineRequest)(nil),                     // 148: hydraidepbgo.Uint32SliceCombineRequest
+	(*Uint32SliceCombineIntoRequest)(nil),                 // 149: hydraidepbgo.Uint32SliceCombineIntoRequest
+	(*Uint32SliceCombineIntoResponse)(nil),                // 150: hydraidepbgo.Uint32SliceCombineIntoResponse
+	(*IsSwampExistRequest)(nil),                           // 151: hydraidepbgo.IsSwampExistRequest
+	(*IsSwampExistResponse)(nil),                          // 152: hydraidepbgo.IsSwampExistResponse
+	(*ExistsManyRequest)(nil),                             // 153: hydraidepbgo.ExistsManyRequest
+	(*ExistsManySwamp)(nil),                               // 154: hydraidepbgo.ExistsManySwamp
+	(*ExistsManyResponse)(nil),                            // 155: hydraidepbgo.ExistsManyResponse
+	(*ExistsManyResult)(nil),                              // 156: hydraidepbgo.ExistsManyResult
+	(*IsKeyExistRequest)(nil),                             // 157: hydraidepbgo.IsKeyExistRequest
+	(*IsKeyExistResponse)(nil),                            // 158: hydraidepbgo.IsKeyExistResponse
+	(*IsKeysExistRequest)(nil),                            // 159: hydraidepbgo.IsKeysExistRequest
+	(*IsKeysExistResponse)(nil),                           // 160: hydraidepbgo.IsKeysExistResponse
+	(*ListDeletedRequest)(nil),                            // 161: hydraidepbgo.ListDeletedRequest
+	(*ListDeletedResponse)(nil),                           // 162: hydraidepbgo.ListDeletedResponse
+	(*RestoreRequest)(nil),                                // 163: hydraidepbgo.RestoreRequest
+	(*RestoreResponse)(nil),                               // 164: hydraidepbgo.RestoreResponse
+	(*GetHistoryRequest)(nil),                             // 165: hydraidepbgo.GetHistoryRequest
+	(*GetHistoryResponse)(nil),                            // 166: hydraidepbgo.GetHistoryResponse
+	(*RevertToRequest)(nil),                               // 167: hydraidepbgo.RevertToRequest
+	(*RevertToResponse)(nil),                              // 168: hydraidepbgo.RevertToResponse
+	(*ErrorReason)(nil),                                   // 169: hydraidepbgo.ErrorReason
+	(*SetSwampAnnotationRequest)(nil),                     // 170: hydraidepbgo.SetSwampAnnotationRequest
+	(*SetSwampAnnotationResponse)(nil),                    // 171: hydraidepbgo.SetSwampAnnotationResponse
+	(*GetSwampAnnotationsRequest)(nil),                    // 172: hydraidepbgo.GetSwampAnnotationsRequest
+	(*GetSwampAnnotationsResponse)(nil),                   // 173: hydraidepbgo.GetSwampAnnotationsResponse
+	(*AggregateRequest)(nil),                              // 174: hydraidepbgo.AggregateRequest
+	(*AggregateResponse)(nil),                             // 175: hydraidepbgo.AggregateResponse
+	(*ListCorruptedFilesRequest)(nil),                     // 176: hydraidepbgo.ListCorruptedFilesRequest
+	(*CorruptedFile)(nil),                                 // 177: hydraidepbgo.CorruptedFile
+	(*ListCorruptedFilesResponse)(nil),                    // 178: hydraidepbgo.ListCorruptedFilesResponse
+	(*GetNamespaceUsageRequest)(nil),                      // 179: hydraidepbgo.GetNamespaceUsageRequest
+	(*NamespaceUsage)(nil),                                // 180: hydraidepbgo.NamespaceUsage
+	(*GetNamespaceUsageResponse)(nil),                     // 181: hydraidepbgo.GetNamespaceUsageResponse
+	(*CompactSwampRequest)(nil),                           // 182: hydraidepbgo.CompactSwampRequest
+	(*CompactSwampResponse)(nil),                          // 183: hydraidepbgo.CompactSwampResponse
+	(*PutBlobRequest)(nil),                                // 184: hydraidepbgo.PutBlobRequest
+	(*PutBlobResponse)(nil),                               // 185: hydraidepbgo.PutBlobResponse
+	(*GetBlobRequest)(nil),                                // 186: hydraidepbgo.GetBlobRequest
+	(*GetBlobResponse)(nil),                               // 187: hydraidepbgo.GetBlobResponse
+	(*RefBlobRequest)(nil),                                // 188: hydraidepbgo.RefBlobRequest
+	(*RefBlobResponse)(nil),                               // 189: hydraidepbgo.RefBlobResponse
+	(*CollectBlobGarbageRequest)(nil),                     // 190: hydraidepbgo.CollectBlobGarbageRequest
+	(*CollectBlobGarbageResponse)(nil),                    // 191: hydraidepbgo.CollectBlobGarbageResponse
+	(*QueryAuditLogRequest)(nil),                          // 192: hydraidepbgo.QueryAuditLogRequest
+	(*AuditRecord)(nil),                                   // 193: hydraidepbgo.AuditRecord
+	(*QueryAuditLogResponse)(nil),                         // 194: hydraidepbgo.QueryAuditLogResponse
+	(*VerifyIslandMappingRequest)(nil),                    // 195: hydraidepbgo.VerifyIslandMappingRequest
+	(*VerifyIslandMappingResponse)(nil),                   // 196: hydraidepbgo.VerifyIslandMappingResponse
+	(*RestorePointInTimeRequest)(nil),                     // 197: hydraidepbgo.RestorePointInTimeRequest
+	(*RestorePointInTimeResponse)(nil),                    // 198: hydraidepbgo.RestorePointInTimeResponse
+	(*GetClusterTopologyRequest)(nil),                     // 199: hydraidepbgo.GetClusterTopologyRequest
+	(*ClusterServer)(nil),                                 // 200: hydraidepbgo.ClusterServer
+	(*GetClusterTopologyResponse)(nil),                    // 201: hydraidepbgo.GetClusterTopologyResponse
+	(*SetClusterTopologyRequest)(nil),                     // 202: hydraidepbgo.SetClusterTopologyRequest
+	(*SetClusterTopologyResponse)(nil),                    // 203: hydraidepbgo.SetClusterTopologyResponse
+	(*IslandState)(nil),                                   // 204: hydraidepbgo.IslandState
+	(*SetIslandStateRequest)(nil),                         // 205: hydraidepbgo.SetIslandStateRequest
+	(*SetIslandStateResponse)(nil),                        // 206: hydraidepbgo.SetIslandStateResponse
+	(*ExportIslandRequest)(nil),                           // 207: hydraidepbgo.ExportIslandRequest
+	(*ExportIslandResponse)(nil),                          // 208: hydraidepbgo.ExportIslandResponse
+	(*ImportIslandRequest)(nil),                           // 209: hydraidepbgo.ImportIslandRequest
+	(*ImportIslandResponse)(nil),                          // 210: hydraidepbgo.ImportIslandResponse
+	(*ReloadDefaultsRequest)(nil),                         // 211: hydraidepbgo.ReloadDefaultsRequest
+	(*ReloadDefaultsResponse)(nil),                        // 212: hydraidepbgo.ReloadDefaultsResponse
+	nil,                                                   // 213: hydraidepbgo.SubscribeAllRequest.SinceEntry
+	(*DeleteRequest_SwampKeys)(nil),                       // 214: hydraidepbgo.DeleteRequest.SwampKeys
+	(*DeleteResponse_SwampDeleteResponse)(nil),            // 215: hydraidepbgo.DeleteResponse.SwampDeleteResponse
+	(*CountRequest_SwampIdentifier)(nil),                  // 216: hydraidepbgo.CountRequest.SwampIdentifier
+	nil,                                                   // 217: hydraidepbgo.GetSwampAnnotationsResponse.AnnotationsEntry
+	(*timestamppb.Timestamp)(nil),                         // 218: google.protobuf.Timestamp
 }
 var file_hydraide_proto_depIdxs = []int32{
-	39, // 0: hydraidepbgo.SubscribeToEventsResponse.Treasure:type_name -> hydraidepbgo.Treasure
-	39, // 1: hydraidepbgo.SubscribeToEventsResponse.OldTreasure:type_name -> hydraidepbgo.Treasure
-	39, // 2: hydraidepbgo.SubscribeToEventsResponse.DeletedTreasure:type_name -> hydraidepbgo.Treasure
-	97, // 3: hydraidepbgo.SubscribeToEventsResponse.EventTime:type_name -> google.protobuf.Timestamp
-	1,  // 4: hydraidepbgo.SubscribeToEventsResponse.Status:type_name -> hydraidepbgo.Status.Code
-	25, // 5: hydraidepbgo.SetRequest.Swamps:type_name -> hydraidepbgo.SwampRequest
-	26, // 6: hydraidepbgo.SwampRequest.KeyValues:type_name -> hydraidepbgo.KeyValuePair
-	2,  // 7: hydraidepbgo.KeyValuePair.BoolVal:type_name -> hydraidepbgo.Boolean.Type
-	97, // 8: hydraidepbgo.KeyValuePair.CreatedAt:type_name -> google.protobuf.Timestamp
-	97, // 9: hydraidepbgo.KeyValuePair.UpdatedAt:type_name -> google.protobuf.Timestamp
-	97, // 10: hydraidepbgo.KeyValuePair.ExpiredAt:type_name -> google.protobuf.Timestamp
-	28, // 11: hydraidepbgo.SetResponse.Swamps:type_name -> hydraidepbgo.SwampResponse
-	29, // 12: hydraidepbgo.SwampResponse.KeysAndStatuses:type_name -> hydraidepbgo.KeyStatusPair
-	0,  // 13: hydraidepbgo.SwampResponse.ErrorCode:type_name -> hydraidepbgo.SwampResponse.ErrCodeEnum
-	1,  // 14: hydraidepbgo.KeyStatusPair.Status:type_name -> hydraidepbgo.Status.Code
-	32, // 15: hydraidepbgo.GetRequest.Swamps:type_name -> hydraidepbgo.GetSwamp
-	34, // 16: hydraidepbgo.GetResponse.Swamps:type_name -> hydraidepbgo.GetSwampResponse
-	39, // 17: hydraidepbgo.GetSwampResponse.Treasures:type_name -> hydraidepbgo.Treasure
-	39, // 18: hydraidepbgo.GetAllResponse.Treasures:type_name -> hydraidepbgo.Treasure
-	39, // 19: hydraidepbgo.ShiftExpiredTreasuresResponse.Treasures:type_name -> hydraidepbgo.Treasure
-	2,  // 20: hydraidepbgo.Treasure.BoolVal:type_name -> hydraidepbgo.Boolean.Type
-	97, // 21: hydraidepbgo.Treasure.CreatedAt:type_name -> google.protobuf.Timestamp
-	97, // 22: hydraidepbgo.Treasure.UpdatedAt:type_name -> google.protobuf.Timestamp
-	97, // 23: hydraidepbgo.Treasure.ExpiredAt:type_name -> google.protobuf.Timestamp
-	3,  // 24: hydraidepbgo.GetByIndexRequest.IndexType:type_name -> hydraidepbgo.IndexType.Type
-	4,  // 25: hydraidepbgo.GetByIndexRequest.OrderType:type_name -> hydraidepbgo.OrderType.Type
-	39, // 26: hydraidepbgo.GetByIndexResponse.Treasures:type_name -> hydraidepbgo.Treasure
-	94, // 27: hydraidepbgo.DeleteRequest.Swamps:type_name -> hydraidepbgo.DeleteRequest.SwampKeys
-	95, // 28: hydraidepbgo.DeleteResponse.Responses:type_name -> hydraidepbgo.DeleteResponse.SwampDeleteResponse
-	96, // 29: hydraidepbgo.CountRequest.Swamps:type_name -> hydraidepbgo.CountRequest.SwampIdentifier
-	49, // 30: hydraidepbgo.CountResponse.Swamps:type_name -> hydraidepbgo.CountSwamp
-	51, // 31: hydraidepbgo.IncrementInt8Request.Condition:type_name -> hydraidepbgo.IncrementInt8Condition
-	6,  // 32: hydraidepbgo.IncrementInt8Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	54, // 33: hydraidepbgo.IncrementInt16Request.Condition:type_name -> hydraidepbgo.IncrementInt16Condition
-	6,  // 34: hydraidepbgo.IncrementInt16Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	57, // 35: hydraidepbgo.IncrementInt32Request.Condition:type_name -> hydraidepbgo.IncrementInt32Condition
-	6,  // 36: hydraidepbgo.IncrementInt32Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	60, // 37: hydraidepbgo.IncrementInt64Request.Condition:type_name -> hydraidepbgo.IncrementInt64Condition
-	6,  // 38: hydraidepbgo.IncrementInt64Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	63, // 39: hydraidepbgo.IncrementUint8Request.Condition:type_name -> hydraidepbgo.IncrementUint8Condition
-	6,  // 40: hydraidepbgo.IncrementUint8Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	66, // 41: hydraidepbgo.IncrementUint16Request.Condition:type_name -> hydraidepbgo.IncrementUint16Condition
-	6,  // 42: hydraidepbgo.IncrementUint16Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	69, // 43: hydraidepbgo.IncrementUint32Request.Condition:type_name -> hydraidepbgo.IncrementUint32Condition
-	6,  // 44: hydraidepbgo.IncrementUint32Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	72, // 45: hydraidepbgo.IncrementUint64Request.Condition:type_name -> hydraidepbgo.IncrementUint64Condition
-	6,  // 46: hydraidepbgo.IncrementUint64Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	76, // 47: hydraidepbgo.IncrementFloat32Request.Condition:type_name -> hydraidepbgo.IncrementFloat32Condition
-	6,  // 48: hydraidepbgo.IncrementFloat32Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	79, // 49: hydraidepbgo.IncrementFloat64Request.Condition:type_name -> hydraidepbgo.IncrementFloat64Condition
-	6,  // 50: hydraidepbgo.IncrementFloat64Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
-	81, // 51: hydraidepbgo.AddToUint32SlicePushRequest.KeySlicePairs:type_name -> hydraidepbgo.KeySlicePair
-	81, // 52: hydraidepbgo.Uint32SliceDeleteRequest.KeySlicePairs:type_name -> hydraidepbgo.KeySlicePair
-	5,  // 53: hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCode:type_name -> hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCodeEnum
-	29, // 54: hydraidepbgo.DeleteResponse.SwampDeleteResponse.KeyStatuses:type_name -> hydraidepbgo.KeyStatusPair
-	7,  // 55: hydraidepbgo.HydraideService.Heartbeat:input_type -> hydraidepbgo.HeartbeatRequest
-	9,  // 56: hydraidepbgo.HydraideService.Lock:input_type -> hydraidepbgo.LockRequest
-	11, // 57: hydraidepbgo.HydraideService.Unlock:input_type -> hydraidepbgo.UnlockRequest
-	20, // 58: hydraidepbgo.HydraideService.RegisterSwamp:input_type -> hydraidepbgo.RegisterSwampRequest
-	22, // 59: hydraidepbgo.HydraideService.DeRegisterSwamp:input_type -> hydraidepbgo.DeRegisterSwampRequest
-	24, // 60: hydraidepbgo.HydraideService.Set:input_type -> hydraidepbgo.SetRequest
-	31, // 61: hydraidepbgo.HydraideService.Get:input_type -> hydraidepbgo.GetRequest
-	35, // 62: hydraidepbgo.HydraideService.GetAll:input_type -> hydraidepbgo.GetAllRequest
-	41, // 63: hydraidepbgo.HydraideService.GetByIndex:input_type -> hydraidepbgo.GetByIndexRequest
-	37, // 64: hydraidepbgo.HydraideService.ShiftExpiredTreasures:input_type -> hydraidepbgo.ShiftExpiredTreasuresRequest
-	13, // 65: hydraidepbgo.HydraideService.Destroy:input_type -> hydraidepbgo.DestroyRequest
-	45, // 66: hydraidepbgo.HydraideService.Delete:input_type -> hydraidepbgo.DeleteRequest
-	47, // 67: hydraidepbgo.HydraideService.Count:input_type -> hydraidepbgo.CountRequest
-	90, // 68: hydraidepbgo.HydraideService.IsSwampExist:input_type -> hydraidepbgo.IsSwampExistRequest
-	92, // 69: hydraidepbgo.HydraideService.IsKeyExist:input_type -> hydraidepbgo.IsKeyExistRequest
-	17, // 70: hydraidepbgo.HydraideService.SubscribeToEvents:input_type -> hydraidepbgo.SubscribeToEventsRequest
-	15, // 71: hydraidepbgo.HydraideService.SubscribeToInfo:input_type -> hydraidepbgo.SubscribeToInfoRequest
-	82, // 72: hydraidepbgo.HydraideService.Uint32SlicePush:input_type -> hydraidepbgo.AddToUint32SlicePushRequest
-	84, // 73: hydraidepbgo.HydraideService.Uint32SliceDelete:input_type -> hydraidepbgo.Uint32SliceDeleteRequest
-	86, // 74: hydraidepbgo.HydraideService.Uint32SliceSize:input_type -> hydraidepbgo.Uint32SliceSizeRequest
-	88, // 75: hydraidepbgo.HydraideService.Uint32SliceIsValueExist:input_type -> hydraidepbgo.Uint32SliceIsValueExistRequest
-	50, // 76: hydraidepbgo.HydraideService.IncrementInt8:input_type -> hydraidepbgo.IncrementInt8Request
-	53, // 77: hydraidepbgo.HydraideService.IncrementInt16:input_type -> hydraidepbgo.IncrementInt16Request
-	56, // 78: hydraidepbgo.HydraideService.IncrementInt32:input_type -> hydraidepbgo.IncrementInt32Request
-	59, // 79: hydraidepbgo.HydraideService.IncrementInt64:input_type -> hydraidepbgo.IncrementInt64Request
-	62, // 80: hydraidepbgo.HydraideService.IncrementUint8:input_type -> hydraidepbgo.IncrementUint8Request
-	65, // 81: hydraidepbgo.HydraideService.IncrementUint16:input_type -> hydraidepbgo.IncrementUint16Request
-	68, // 82: hydraidepbgo.HydraideService.IncrementUint32:input_type -> hydraidepbgo.IncrementUint32Request
-	71, // 83: hydraidepbgo.HydraideService.IncrementUint64:input_type -> hydraidepbgo.IncrementUint64Request
-	75, // 84: hydraidepbgo.HydraideService.IncrementFloat32:input_type -> hydraidepbgo.IncrementFloat32Request
-	78, // 85: hydraidepbgo.HydraideService.IncrementFloat64:input_type -> hydraidepbgo.IncrementFloat64Request
-	8,  // 86: hydraidepbgo.HydraideService.Heartbeat:output_type -> hydraidepbgo.HeartbeatResponse
-	10, // 87: hydraidepbgo.HydraideService.Lock:output_type -> hydraidepbgo.LockResponse
-	12, // 88: hydraidepbgo.HydraideService.Unlock:output_type -> hydraidepbgo.UnlockResponse
-	21, // 89: hydraidepbgo.HydraideService.RegisterSwamp:output_type -> hydraidepbgo.RegisterSwampResponse
-	23, // 90: hydraidepbgo.HydraideService.DeRegisterSwamp:output_type -> hydraidepbgo.DeRegisterSwampResponse
-	27, // 91: hydraidepbgo.HydraideService.Set:output_type -> hydraidepbgo.SetResponse
-	33, // 92: hydraidepbgo.HydraideService.Get:output_type -> hydraidepbgo.GetResponse
-	36, // 93: hydraidepbgo.HydraideService.GetAll:output_type -> hydraidepbgo.GetAllResponse
-	44, // 94: hydraidepbgo.HydraideService.GetByIndex:output_type -> hydraidepbgo.GetByIndexResponse
-	38, // 95: hydraidepbgo.HydraideService.ShiftExpiredTreasures:output_type -> hydraidepbgo.ShiftExpiredTreasuresResponse
-	14, // 96: hydraidepbgo.HydraideService.Destroy:output_type -> hydraidepbgo.DestroyResponse
-	46, // 97: hydraidepbgo.HydraideService.Delete:output_type -> hydraidepbgo.DeleteResponse
-	48, // 98: hydraidepbgo.HydraideService.Count:output_type -> hydraidepbgo.CountResponse
-	91, // 99: hydraidepbgo.HydraideService.IsSwampExist:output_type -> hydraidepbgo.IsSwampExistResponse
-	93, // 100: hydraidepbgo.HydraideService.IsKeyExist:output_type -> hydraidepbgo.IsKeyExistResponse
-	18, // 101: hydraidepbgo.HydraideService.SubscribeToEvents:output_type -> hydraidepbgo.SubscribeToEventsResponse
-	16, // 102: hydraidepbgo.HydraideService.SubscribeToInfo:output_type -> hydraidepbgo.SubscribeToInfoResponse
-	83, // 103: hydraidepbgo.HydraideService.Uint32SlicePush:output_type -> hydraidepbgo.AddToUint32SlicePushResponse
-	85, // 104: hydraidepbgo.HydraideService.Uint32SliceDelete:output_type -> hydraidepbgo.Uint32SliceDeleteResponse
-	87, // 105: hydraidepbgo.HydraideService.Uint32SliceSize:output_type -> hydraidepbgo.Uint32SliceSizeResponse
-	89, // 106: hydraidepbgo.HydraideService.Uint32SliceIsValueExist:output_type -> hydraidepbgo.Uint32SliceIsValueExistResponse
-	52, // 107: hydraidepbgo.HydraideService.IncrementInt8:output_type -> hydraidepbgo.IncrementInt8Response
-	55, // 108: hydraidepbgo.HydraideService.IncrementInt16:output_type -> hydraidepbgo.IncrementInt16Response
-	58, // 109: hydraidepbgo.HydraideService.IncrementInt32:output_type -> hydraidepbgo.IncrementInt32Response
-	61, // 110: hydraidepbgo.HydraideService.IncrementInt64:output_type -> hydraidepbgo.IncrementInt64Response
-	64, // 111: hydraidepbgo.HydraideService.IncrementUint8:output_type -> hydraidepbgo.IncrementUint8Response
-	67, // 112: hydraidepbgo.HydraideService.IncrementUint16:output_type -> hydraidepbgo.IncrementUint16Response
-	70, // 113: hydraidepbgo.HydraideService.IncrementUint32:output_type -> hydraidepbgo.IncrementUint32Response
-	73, // 114: hydraidepbgo.HydraideService.IncrementUint64:output_type -> hydraidepbgo.IncrementUint64Response
-	77, // 115: hydraidepbgo.HydraideService.IncrementFloat32:output_type -> hydraidepbgo.IncrementFloat32Response
-	80, // 116: hydraidepbgo.HydraideService.IncrementFloat64:output_type -> hydraidepbgo.IncrementFloat64Response
-	86, // [86:117] is the sub-list for method output_type
-	55, // [55:86] is the sub-list for method input_type
-	55, // [55:55] is the sub-list for extension type_name
-	55, // [55:55] is the sub-list for extension extendee
-	0,  // [0:55] is the sub-list for field type_name
+	218, // 0: hydraidepbgo.HeartbeatResponse.ServerTime:type_name -> google.protobuf.Timestamp
+	218, // 1: hydraidepbgo.SubscribeToEventsRequest.Since:type_name -> google.protobuf.Timestamp
+	0,   // 2: hydraidepbgo.SubscribeToEventsRequest.Overflow:type_name -> hydraidepbgo.OverflowPolicy.Policy
+	213, // 3: hydraidepbgo.SubscribeAllRequest.Since:type_name -> hydraidepbgo.SubscribeAllRequest.SinceEntry
+	0,   // 4: hydraidepbgo.SubscribeAllRequest.Overflow:type_name -> hydraidepbgo.OverflowPolicy.Policy
+	71,  // 5: hydraidepbgo.SubscribeToEventsResponse.Treasure:type_name -> hydraidepbgo.Treasure
+	71,  // 6: hydraidepbgo.SubscribeToEventsResponse.OldTreasure:type_name -> hydraidepbgo.Treasure
+	71,  // 7: hydraidepbgo.SubscribeToEventsResponse.DeletedTreasure:type_name -> hydraidepbgo.Treasure
+	218, // 8: hydraidepbgo.SubscribeToEventsResponse.EventTime:type_name -> google.protobuf.Timestamp
+	3,   // 9: hydraidepbgo.SubscribeToEventsResponse.Status:type_name -> hydraidepbgo.Status.Code
+	4,   // 10: hydraidepbgo.RegisterSwampRequest.RequiredMetadata:type_name -> hydraidepbgo.Metadata.Field
+	1,   // 11: hydraidepbgo.RegisterSwampRequest.Fsync:type_name -> hydraidepbgo.FsyncPolicy.Policy
+	5,   // 12: hydraidepbgo.RegisterSwampRequest.KeptBeacons:type_name -> hydraidepbgo.Beacon.Type
+	39,  // 13: hydraidepbgo.ListSwampPatternsResponse.Patterns:type_name -> hydraidepbgo.SwampPattern
+	1,   // 14: hydraidepbgo.SwampPattern.Fsync:type_name -> hydraidepbgo.FsyncPolicy.Policy
+	218, // 15: hydraidepbgo.SwampPattern.RegisteredAt:type_name -> google.protobuf.Timestamp
+	39,  // 16: hydraidepbgo.UpdateSwampPatternResponse.Pattern:type_name -> hydraidepbgo.SwampPattern
+	43,  // 17: hydraidepbgo.SetRequest.Swamps:type_name -> hydraidepbgo.SwampRequest
+	44,  // 18: hydraidepbgo.SwampRequest.KeyValues:type_name -> hydraidepbgo.KeyValuePair
+	6,   // 19: hydraidepbgo.KeyValuePair.BoolVal:type_name -> hydraidepbgo.Boolean.Type
+	218, // 20: hydraidepbgo.KeyValuePair.CreatedAt:type_name -> google.protobuf.Timestamp
+	218, // 21: hydraidepbgo.KeyValuePair.UpdatedAt:type_name -> google.protobuf.Timestamp
+	218, // 22: hydraidepbgo.KeyValuePair.ExpiredAt:type_name -> google.protobuf.Timestamp
+	46,  // 23: hydraidepbgo.SetResponse.Swamps:type_name -> hydraidepbgo.SwampResponse
+	47,  // 24: hydraidepbgo.SwampResponse.KeysAndStatuses:type_name -> hydraidepbgo.KeyStatusPair
+	2,   // 25: hydraidepbgo.SwampResponse.ErrorCode:type_name -> hydraidepbgo.SwampResponse.ErrCodeEnum
+	3,   // 26: hydraidepbgo.KeyStatusPair.Status:type_name -> hydraidepbgo.Status.Code
+	218, // 27: hydraidepbgo.KeyStatusPair.CreatedAt:type_name -> google.protobuf.Timestamp
+	218, // 28: hydraidepbgo.KeyStatusPair.UpdatedAt:type_name -> google.protobuf.Timestamp
+	50,  // 29: hydraidepbgo.GetRequest.Swamps:type_name -> hydraidepbgo.GetSwamp
+	51,  // 30: hydraidepbgo.GetSwamp.Projection:type_name -> hydraidepbgo.Projection
+	53,  // 31: hydraidepbgo.GetResponse.Swamps:type_name -> hydraidepbgo.GetSwampResponse
+	71,  // 32: hydraidepbgo.GetSwampResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	44,  // 33: hydraidepbgo.SetLargeValueRequest.KeyValue:type_name -> hydraidepbgo.KeyValuePair
+	46,  // 34: hydraidepbgo.SetLargeValueResponse.Swamp:type_name -> hydraidepbgo.SwampResponse
+	71,  // 35: hydraidepbgo.GetLargeValueResponse.Treasure:type_name -> hydraidepbgo.Treasure
+	71,  // 36: hydraidepbgo.GetAllResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	51,  // 37: hydraidepbgo.GetAllStreamRequest.Projection:type_name -> hydraidepbgo.Projection
+	71,  // 38: hydraidepbgo.GetAllStreamResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	71,  // 39: hydraidepbgo.ShiftExpiredTreasuresResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	66,  // 40: hydraidepbgo.LeaseExpiredTreasuresResponse.Treasures:type_name -> hydraidepbgo.LeasedTreasure
+	71,  // 41: hydraidepbgo.LeasedTreasure.Treasure:type_name -> hydraidepbgo.Treasure
+	6,   // 42: hydraidepbgo.Treasure.BoolVal:type_name -> hydraidepbgo.Boolean.Type
+	218, // 43: hydraidepbgo.Treasure.CreatedAt:type_name -> google.protobuf.Timestamp
+	218, // 44: hydraidepbgo.Treasure.UpdatedAt:type_name -> google.protobuf.Timestamp
+	218, // 45: hydraidepbgo.Treasure.ExpiredAt:type_name -> google.protobuf.Timestamp
+	218, // 46: hydraidepbgo.Treasure.DeletedAt:type_name -> google.protobuf.Timestamp
+	7,   // 47: hydraidepbgo.GetByIndexRequest.IndexType:type_name -> hydraidepbgo.IndexType.Type
+	8,   // 48: hydraidepbgo.GetByIndexRequest.OrderType:type_name -> hydraidepbgo.OrderType.Type
+	51,  // 49: hydraidepbgo.GetByIndexRequest.Projection:type_name -> hydraidepbgo.Projection
+	71,  // 50: hydraidepbgo.GetByIndexResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	7,   // 51: hydraidepbgo.GetTopNRequest.IndexType:type_name -> hydraidepbgo.IndexType.Type
+	8,   // 52: hydraidepbgo.GetTopNRequest.OrderType:type_name -> hydraidepbgo.OrderType.Type
+	71,  // 53: hydraidepbgo.GetTopNResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	44,  // 54: hydraidepbgo.GetByValueRequest.Value:type_name -> hydraidepbgo.KeyValuePair
+	71,  // 55: hydraidepbgo.GetByValueResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	51,  // 56: hydraidepbgo.GetByReferenceRequest.Projection:type_name -> hydraidepbgo.Projection
+	71,  // 57: hydraidepbgo.GetByReferenceResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	214, // 58: hydraidepbgo.DeleteRequest.Swamps:type_name -> hydraidepbgo.DeleteRequest.SwampKeys
+	215, // 59: hydraidepbgo.DeleteResponse.Responses:type_name -> hydraidepbgo.DeleteResponse.SwampDeleteResponse
+	216, // 60: hydraidepbgo.CountRequest.Swamps:type_name -> hydraidepbgo.CountRequest.SwampIdentifier
+	88,  // 61: hydraidepbgo.CountRequest.Filter:type_name -> hydraidepbgo.CountFilter
+	218, // 62: hydraidepbgo.CountFilter.UpdatedSince:type_name -> google.protobuf.Timestamp
+	90,  // 63: hydraidepbgo.CountResponse.Swamps:type_name -> hydraidepbgo.CountSwamp
+	92,  // 64: hydraidepbgo.IncrementInt8Request.Condition:type_name -> hydraidepbgo.IncrementInt8Condition
+	10,  // 65: hydraidepbgo.IncrementInt8Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	95,  // 66: hydraidepbgo.IncrementInt16Request.Condition:type_name -> hydraidepbgo.IncrementInt16Condition
+	10,  // 67: hydraidepbgo.IncrementInt16Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	98,  // 68: hydraidepbgo.IncrementInt32Request.Condition:type_name -> hydraidepbgo.IncrementInt32Condition
+	10,  // 69: hydraidepbgo.IncrementInt32Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	101, // 70: hydraidepbgo.IncrementInt64Request.Condition:type_name -> hydraidepbgo.IncrementInt64Condition
+	10,  // 71: hydraidepbgo.IncrementInt64Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	104, // 72: hydraidepbgo.IncrementUint8Request.Condition:type_name -> hydraidepbgo.IncrementUint8Condition
+	10,  // 73: hydraidepbgo.IncrementUint8Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	107, // 74: hydraidepbgo.IncrementUint16Request.Condition:type_name -> hydraidepbgo.IncrementUint16Condition
+	10,  // 75: hydraidepbgo.IncrementUint16Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	110, // 76: hydraidepbgo.IncrementUint32Request.Condition:type_name -> hydraidepbgo.IncrementUint32Condition
+	10,  // 77: hydraidepbgo.IncrementUint32Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	113, // 78: hydraidepbgo.IncrementUint64Request.Condition:type_name -> hydraidepbgo.IncrementUint64Condition
+	10,  // 79: hydraidepbgo.IncrementUint64Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	117, // 80: hydraidepbgo.IncrementFloat32Request.Condition:type_name -> hydraidepbgo.IncrementFloat32Condition
+	10,  // 81: hydraidepbgo.IncrementFloat32Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	120, // 82: hydraidepbgo.IncrementFloat64Request.Condition:type_name -> hydraidepbgo.IncrementFloat64Condition
+	10,  // 83: hydraidepbgo.IncrementFloat64Condition.RelationalOperator:type_name -> hydraidepbgo.Relational.Operator
+	128, // 84: hydraidepbgo.SetStringIfRequest.Condition:type_name -> hydraidepbgo.SetStringCondition
+	11,  // 85: hydraidepbgo.SetStringCondition.Operator:type_name -> hydraidepbgo.SetIf.Operator
+	131, // 86: hydraidepbgo.SetBoolIfRequest.Condition:type_name -> hydraidepbgo.SetBoolCondition
+	11,  // 87: hydraidepbgo.SetBoolCondition.Operator:type_name -> hydraidepbgo.SetIf.Operator
+	133, // 88: hydraidepbgo.AddToUint32SlicePushRequest.KeySlicePairs:type_name -> hydraidepbgo.KeySlicePair
+	136, // 89: hydraidepbgo.AddToUint32SlicePushResponse.Results:type_name -> hydraidepbgo.Uint32SlicePushResult
+	133, // 90: hydraidepbgo.Uint32SliceDeleteRequest.KeySlicePairs:type_name -> hydraidepbgo.KeySlicePair
+	12,  // 91: hydraidepbgo.Uint32SliceCombineRequest.Operator:type_name -> hydraidepbgo.Uint32SliceCombination.Operator
+	12,  // 92: hydraidepbgo.Uint32SliceCombineIntoRequest.Operator:type_name -> hydraidepbgo.Uint32SliceCombination.Operator
+	154, // 93: hydraidepbgo.ExistsManyRequest.Swamps:type_name -> hydraidepbgo.ExistsManySwamp
+	156, // 94: hydraidepbgo.ExistsManyResponse.Results:type_name -> hydraidepbgo.ExistsManyResult
+	71,  // 95: hydraidepbgo.ListDeletedResponse.Treasures:type_name -> hydraidepbgo.Treasure
+	47,  // 96: hydraidepbgo.RestoreResponse.KeyStatuses:type_name -> hydraidepbgo.KeyStatusPair
+	71,  // 97: hydraidepbgo.GetHistoryResponse.Versions:type_name -> hydraidepbgo.Treasure
+	217, // 98: hydraidepbgo.GetSwampAnnotationsResponse.Annotations:type_name -> hydraidepbgo.GetSwampAnnotationsResponse.AnnotationsEntry
+	7,   // 99: hydraidepbgo.AggregateRequest.IndexType:type_name -> hydraidepbgo.IndexType.Type
+	8,   // 100: hydraidepbgo.AggregateRequest.OrderType:type_name -> hydraidepbgo.OrderType.Type
+	218, // 101: hydraidepbgo.CorruptedFile.DetectedAt:type_name -> google.protobuf.Timestamp
+	177, // 102: hydraidepbgo.ListCorruptedFilesResponse.Files:type_name -> hydraidepbgo.CorruptedFile
+	180, // 103: hydraidepbgo.GetNamespaceUsageResponse.Usages:type_name -> hydraidepbgo.NamespaceUsage
+	218, // 104: hydraidepbgo.GetNamespaceUsageResponse.CollectedAt:type_name -> google.protobuf.Timestamp
+	218, // 105: hydraidepbgo.QueryAuditLogRequest.From:type_name -> google.protobuf.Timestamp
+	218, // 106: hydraidepbgo.QueryAuditLogRequest.To:type_name -> google.protobuf.Timestamp
+	218, // 107: hydraidepbgo.AuditRecord.Time:type_name -> google.protobuf.Timestamp
+	193, // 108: hydraidepbgo.QueryAuditLogResponse.Records:type_name -> hydraidepbgo.AuditRecord
+	218, // 109: hydraidepbgo.RestorePointInTimeRequest.Until:type_name -> google.protobuf.Timestamp
+	200, // 110: hydraidepbgo.GetClusterTopologyResponse.Servers:type_name -> hydraidepbgo.ClusterServer
+	200, // 111: hydraidepbgo.SetClusterTopologyRequest.Servers:type_name -> hydraidepbgo.ClusterServer
+	14,  // 112: hydraidepbgo.SetIslandStateRequest.State:type_name -> hydraidepbgo.IslandState.State
+	1,   // 113: hydraidepbgo.ReloadDefaultsResponse.Fsync:type_name -> hydraidepbgo.FsyncPolicy.Policy
+	218, // 114: hydraidepbgo.SubscribeAllRequest.SinceEntry.value:type_name -> google.protobuf.Timestamp
+	9,   // 115: hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCode:type_name -> hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCodeEnum
+	47,  // 116: hydraidepbgo.DeleteResponse.SwampDeleteResponse.KeyStatuses:type_name -> hydraidepbgo.KeyStatusPair
+	15,  // 117: hydraidepbgo.HydraideService.Heartbeat:input_type -> hydraidepbgo.HeartbeatRequest
+	17,  // 118: hydraidepbgo.HydraideService.Lock:input_type -> hydraidepbgo.LockRequest
+	19,  // 119: hydraidepbgo.HydraideService.Unlock:input_type -> hydraidepbgo.UnlockRequest
+	32,  // 120: hydraidepbgo.HydraideService.RegisterSwamp:input_type -> hydraidepbgo.RegisterSwampRequest
+	35,  // 121: hydraidepbgo.HydraideService.DeRegisterSwamp:input_type -> hydraidepbgo.DeRegisterSwampRequest
+	37,  // 122: hydraidepbgo.HydraideService.ListSwampPatterns:input_type -> hydraidepbgo.ListSwampPatternsRequest
+	40,  // 123: hydraidepbgo.HydraideService.UpdateSwampPattern:input_type -> hydraidepbgo.UpdateSwampPatternRequest
+	42,  // 124: hydraidepbgo.HydraideService.Set:input_type -> hydraidepbgo.SetRequest
+	49,  // 125: hydraidepbgo.HydraideService.Get:input_type -> hydraidepbgo.GetRequest
+	54,  // 126: hydraidepbgo.HydraideService.SetLargeValue:input_type -> hydraidepbgo.SetLargeValueRequest
+	56,  // 127: hydraidepbgo.HydraideService.GetLargeValue:input_type -> hydraidepbgo.GetLargeValueRequest
+	58,  // 128: hydraidepbgo.HydraideService.GetAll:input_type -> hydraidepbgo.GetAllRequest
+	60,  // 129: hydraidepbgo.HydraideService.GetAllStream:input_type -> hydraidepbgo.GetAllStreamRequest
+	75,  // 130: hydraidepbgo.HydraideService.GetByIndex:input_type -> hydraidepbgo.GetByIndexRequest
+	79,  // 131: hydraidepbgo.HydraideService.GetTopN:input_type -> hydraidepbgo.GetTopNRequest
+	81,  // 132: hydraidepbgo.HydraideService.GetByValue:input_type -> hydraidepbgo.GetByValueRequest
+	83,  // 133: hydraidepbgo.HydraideService.GetByReference:input_type -> hydraidepbgo.GetByReferenceRequest
+	62,  // 134: hydraidepbgo.HydraideService.ShiftExpiredTreasures:input_type -> hydraidepbgo.ShiftExpiredTreasuresRequest
+	64,  // 135: hydraidepbgo.HydraideService.LeaseExpiredTreasures:input_type -> hydraidepbgo.LeaseExpiredTreasuresRequest
+	67,  // 136: hydraidepbgo.HydraideService.AckLease:input_type -> hydraidepbgo.AckLeaseRequest
+	69,  // 137: hydraidepbgo.HydraideService.NackLease:input_type -> hydraidepbgo.NackLeaseRequest
+	21,  // 138: hydraidepbgo.HydraideService.Destroy:input_type -> hydraidepbgo.DestroyRequest
+	23,  // 139: hydraidepbgo.HydraideService.DestroyMany:input_type -> hydraidepbgo.DestroyManyRequest
+	85,  // 140: hydraidepbgo.HydraideService.Delete:input_type -> hydraidepbgo.DeleteRequest
+	161, // 141: hydraidepbgo.HydraideService.ListDeleted:input_type -> hydraidepbgo.ListDeletedRequest
+	163, // 142: hydraidepbgo.HydraideService.Restore:input_type -> hydraidepbgo.RestoreRequest
+	165, // 143: hydraidepbgo.HydraideService.GetHistory:input_type -> hydraidepbgo.GetHistoryRequest
+	167, // 144: hydraidepbgo.HydraideService.RevertTo:input_type -> hydraidepbgo.RevertToRequest
+	87,  // 145: hydraidepbgo.HydraideService.Count:input_type -> hydraidepbgo.CountRequest
+	151, // 146: hydraidepbgo.HydraideService.IsSwampExist:input_type -> hydraidepbgo.IsSwampExistRequest
+	153, // 147: hydraidepbgo.HydraideService.ExistsMany:input_type -> hydraidepbgo.ExistsManyRequest
+	157, // 148: hydraidepbgo.HydraideService.IsKeyExist:input_type -> hydraidepbgo.IsKeyExistRequest
+	159, // 149: hydraidepbgo.HydraideService.IsKeysExist:input_type -> hydraidepbgo.IsKeysExistRequest
+	27,  // 150: hydraidepbgo.HydraideService.SubscribeToEvents:input_type -> hydraidepbgo.SubscribeToEventsRequest
+	28,  // 151: hydraidepbgo.HydraideService.SubscribeAll:input_type -> hydraidepbgo.SubscribeAllRequest
+	25,  // 152: hydraidepbgo.HydraideService.SubscribeToInfo:input_type -> hydraidepbgo.SubscribeToInfoRequest
+	134, // 153: hydraidepbgo.HydraideService.Uint32SlicePush:input_type -> hydraidepbgo.AddToUint32SlicePushRequest
+	137, // 154: hydraidepbgo.HydraideService.Uint32SliceDelete:input_type -> hydraidepbgo.Uint32SliceDeleteRequest
+	139, // 155: hydraidepbgo.HydraideService.Uint32SliceSize:input_type -> hydraidepbgo.Uint32SliceSizeRequest
+	141, // 156: hydraidepbgo.HydraideService.Uint32SliceIsValueExist:input_type -> hydraidepbgo.Uint32SliceIsValueExistRequest
+	143, // 157: hydraidepbgo.HydraideService.Uint32SliceGetRange:input_type -> hydraidepbgo.Uint32SliceGetRangeRequest
+	145, // 158: hydraidepbgo.HydraideService.Uint32SliceStream:input_type -> hydraidepbgo.Uint32SliceStreamRequest
+	148, // 159: hydraidepbgo.HydraideService.Uint32SliceCombine:input_type -> hydraidepbgo.Uint32SliceCombineRequest
+	149, // 160: hydraidepbgo.HydraideService.Uint32SliceCombineInto:input_type -> hydraidepbgo.Uint32SliceCombineIntoRequest
+	91,  // 161: hydraidepbgo.HydraideService.IncrementInt8:input_type -> hydraidepbgo.IncrementInt8Request
+	94,  // 162: hydraidepbgo.HydraideService.IncrementInt16:input_type -> hydraidepbgo.IncrementInt16Request
+	97,  // 163: hydraidepbgo.HydraideService.IncrementInt32:input_type -> hydraidepbgo.IncrementInt32Request
+	100, // 164: hydraidepbgo.HydraideService.IncrementInt64:input_type -> hydraidepbgo.IncrementInt64Request
+	103, // 165: hydraidepbgo.HydraideService.IncrementUint8:input_type -> hydraidepbgo.IncrementUint8Request
+	106, // 166: hydraidepbgo.HydraideService.IncrementUint16:input_type -> hydraidepbgo.IncrementUint16Request
+	109, // 167: hydraidepbgo.HydraideService.IncrementUint32:input_type -> hydraidepbgo.IncrementUint32Request
+	112, // 168: hydraidepbgo.HydraideService.IncrementUint64:input_type -> hydraidepbgo.IncrementUint64Request
+	116, // 169: hydraidepbgo.HydraideService.IncrementFloat32:input_type -> hydraidepbgo.IncrementFloat32Request
+	119, // 170: hydraidepbgo.HydraideService.IncrementFloat64:input_type -> hydraidepbgo.IncrementFloat64Request
+	122, // 171: hydraidepbgo.HydraideService.SetIfLater:input_type -> hydraidepbgo.SetIfLaterRequest
+	124, // 172: hydraidepbgo.HydraideService.AddDuration:input_type -> hydraidepbgo.AddDurationRequest
+	127, // 173: hydraidepbgo.HydraideService.SetStringIf:input_type -> hydraidepbgo.SetStringIfRequest
+	130, // 174: hydraidepbgo.HydraideService.SetBoolIf:input_type -> hydraidepbgo.SetBoolIfRequest
+	170, // 175: hydraidepbgo.HydraideService.SetSwampAnnotation:input_type -> hydraidepbgo.SetSwampAnnotationRequest
+	172, // 176: hydraidepbgo.HydraideService.GetSwampAnnotations:input_type -> hydraidepbgo.GetSwampAnnotationsRequest
+	174, // 177: hydraidepbgo.HydraideService.Aggregate:input_type -> hydraidepbgo.AggregateRequest
+	176, // 178: hydraidepbgo.HydraideService.ListCorruptedFiles:input_type -> hydraidepbgo.ListCorruptedFilesRequest
+	179, // 179: hydraidepbgo.HydraideService.GetNamespaceUsage:input_type -> hydraidepbgo.GetNamespaceUsageRequest
+	182, // 180: hydraidepbgo.HydraideService.CompactSwamp:input_type -> hydraidepbgo.CompactSwampRequest
+	184, // 181: hydraidepbgo.HydraideService.PutBlob:input_type -> hydraidepbgo.PutBlobRequest
+	186, // 182: hydraidepbgo.HydraideService.GetBlob:input_type -> hydraidepbgo.GetBlobRequest
+	188, // 183: hydraidepbgo.HydraideService.RefBlob:input_type -> hydraidepbgo.RefBlobRequest
+	190, // 184: hydraidepbgo.HydraideService.CollectBlobGarbage:input_type -> hydraidepbgo.CollectBlobGarbageRequest
+	192, // 185: hydraidepbgo.HydraideService.QueryAuditLog:input_type -> hydraidepbgo.QueryAuditLogRequest
+	195, // 186: hydraidepbgo.HydraideService.VerifyIslandMapping:input_type -> hydraidepbgo.VerifyIslandMappingRequest
+	197, // 187: hydraidepbgo.HydraideService.RestorePointInTime:input_type -> hydraidepbgo.RestorePointInTimeRequest
+	199, // 188: hydraidepbgo.HydraideService.GetClusterTopology:input_type -> hydraidepbgo.GetClusterTopologyRequest
+	202, // 189: hydraidepbgo.HydraideService.SetClusterTopology:input_type -> hydraidepbgo.SetClusterTopologyRequest
+	205, // 190: hydraidepbgo.HydraideService.SetIslandState:input_type -> hydraidepbgo.SetIslandStateRequest
+	207, // 191: hydraidepbgo.HydraideService.ExportIsland:input_type -> hydraidepbgo.ExportIslandRequest
+	209, // 192: hydraidepbgo.HydraideService.ImportIsland:input_type -> hydraidepbgo.ImportIslandRequest
+	211, // 193: hydraidepbgo.HydraideService.ReloadDefaults:input_type -> hydraidepbgo.ReloadDefaultsRequest
+	16,  // 194: hydraidepbgo.HydraideService.Heartbeat:output_type -> hydraidepbgo.HeartbeatResponse
+	18,  // 195: hydraidepbgo.HydraideService.Lock:output_type -> hydraidepbgo.LockResponse
+	20,  // 196: hydraidepbgo.HydraideService.Unlock:output_type -> hydraidepbgo.UnlockResponse
+	34,  // 197: hydraidepbgo.HydraideService.RegisterSwamp:output_type -> hydraidepbgo.RegisterSwampResponse
+	36,  // 198: hydraidepbgo.HydraideService.DeRegisterSwamp:output_type -> hydraidepbgo.DeRegisterSwampResponse
+	38,  // 199: hydraidepbgo.HydraideService.ListSwampPatterns:output_type -> hydraidepbgo.ListSwampPatternsResponse
+	41,  // 200: hydraidepbgo.HydraideService.UpdateSwampPattern:output_type -> hydraidepbgo.UpdateSwampPatternResponse
+	45,  // 201: hydraidepbgo.HydraideService.Set:output_type -> hydraidepbgo.SetResponse
+	52,  // 202: hydraidepbgo.HydraideService.Get:output_type -> hydraidepbgo.GetResponse
+	55,  // 203: hydraidepbgo.HydraideService.SetLargeValue:output_type -> hydraidepbgo.SetLargeValueResponse
+	57,  // 204: hydraidepbgo.HydraideService.GetLargeValue:output_type -> hydraidepbgo.GetLargeValueResponse
+	59,  // 205: hydraidepbgo.HydraideService.GetAll:output_type -> hydraidepbgo.GetAllResponse
+	61,  // 206: hydraidepbgo.HydraideService.GetAllStream:output_type -> hydraidepbgo.GetAllStreamResponse
+	78,  // 207: hydraidepbgo.HydraideService.GetByIndex:output_type -> hydraidepbgo.GetByIndexResponse
+	80,  // 208: hydraidepbgo.HydraideService.GetTopN:output_type -> hydraidepbgo.GetTopNResponse
+	82,  // 209: hydraidepbgo.HydraideService.GetByValue:output_type -> hydraidepbgo.GetByValueResponse
+	84,  // 210: hydraidepbgo.HydraideService.GetByReference:output_type -> hydraidepbgo.GetByReferenceResponse
+	63,  // 211: hydraidepbgo.HydraideService.ShiftExpiredTreasures:output_type -> hydraidepbgo.ShiftExpiredTreasuresResponse
+	65,  // 212: hydraidepbgo.HydraideService.LeaseExpiredTreasures:output_type -> hydraidepbgo.LeaseExpiredTreasuresResponse
+	68,  // 213: hydraidepbgo.HydraideService.AckLease:output_type -> hydraidepbgo.AckLeaseResponse
+	70,  // 214: hydraidepbgo.HydraideService.NackLease:output_type -> hydraidepbgo.NackLeaseResponse
+	22,  // 215: hydraidepbgo.HydraideService.Destroy:output_type -> hydraidepbgo.DestroyResponse
+	24,  // 216: hydraidepbgo.HydraideService.DestroyMany:output_type -> hydraidepbgo.DestroyManyResponse
+	86,  // 217: hydraidepbgo.HydraideService.Delete:output_type -> hydraidepbgo.DeleteResponse
+	162, // 218: hydraidepbgo.HydraideService.ListDeleted:output_type -> hydraidepbgo.ListDeletedResponse
+	164, // 219: hydraidepbgo.HydraideService.Restore:output_type -> hydraidepbgo.RestoreResponse
+	166, // 220: hydraidepbgo.HydraideService.GetHistory:output_type -> hydraidepbgo.GetHistoryResponse
+	168, // 221: hydraidepbgo.HydraideService.RevertTo:output_type -> hydraidepbgo.RevertToResponse
+	89,  // 222: hydraidepbgo.HydraideService.Count:output_type -> hydraidepbgo.CountResponse
+	152, // 223: hydraidepbgo.HydraideService.IsSwampExist:output_type -> hydraidepbgo.IsSwampExistResponse
+	155, // 224: hydraidepbgo.HydraideService.ExistsMany:output_type -> hydraidepbgo.ExistsManyResponse
+	158, // 225: hydraidepbgo.HydraideService.IsKeyExist:output_type -> hydraidepbgo.IsKeyExistResponse
+	160, // 226: hydraidepbgo.HydraideService.IsKeysExist:output_type -> hydraidepbgo.IsKeysExistResponse
+	30,  // 227: hydraidepbgo.HydraideService.SubscribeToEvents:output_type -> hydraidepbgo.SubscribeToEventsResponse
+	30,  // 228: hydraidepbgo.HydraideService.SubscribeAll:output_type -> hydraidepbgo.SubscribeToEventsResponse
+	26,  // 229: hydraidepbgo.HydraideService.SubscribeToInfo:output_type -> hydraidepbgo.SubscribeToInfoResponse
+	135, // 230: hydraidepbgo.HydraideService.Uint32SlicePush:output_type -> hydraidepbgo.AddToUint32SlicePushResponse
+	138, // 231: hydraidepbgo.HydraideService.Uint32SliceDelete:output_type -> hydraidepbgo.Uint32SliceDeleteResponse
+	140, // 232: hydraidepbgo.HydraideService.Uint32SliceSize:output_type -> hydraidepbgo.Uint32SliceSizeResponse
+	142, // 233: hydraidepbgo.HydraideService.Uint32SliceIsValueExist:output_type -> hydraidepbgo.Uint32SliceIsValueExistResponse
+	144, // 234: hydraidepbgo.HydraideService.Uint32SliceGetRange:output_type -> hydraidepbgo.Uint32SliceGetRangeResponse
+	146, // 235: hydraidepbgo.HydraideService.Uint32SliceStream:output_type -> hydraidepbgo.Uint32SliceStreamResponse
+	146, // 236: hydraidepbgo.HydraideService.Uint32SliceCombine:output_type -> hydraidepbgo.Uint32SliceStreamResponse
+	150, // 237: hydraidepbgo.HydraideService.Uint32SliceCombineInto:output_type -> hydraidepbgo.Uint32SliceCombineIntoResponse
+	93,  // 238: hydraidepbgo.HydraideService.IncrementInt8:output_type -> hydraidepbgo.IncrementInt8Response
+	96,  // 239: hydraidepbgo.HydraideService.IncrementInt16:output_type -> hydraidepbgo.IncrementInt16Response
+	99,  // 240: hydraidepbgo.HydraideService.IncrementInt32:output_type -> hydraidepbgo.IncrementInt32Response
+	102, // 241: hydraidepbgo.HydraideService.IncrementInt64:output_type -> hydraidepbgo.IncrementInt64Response
+	105, // 242: hydraidepbgo.HydraideService.IncrementUint8:output_type -> hydraidepbgo.IncrementUint8Response
+	108, // 243: hydraidepbgo.HydraideService.IncrementUint16:output_type -> hydraidepbgo.IncrementUint16Response
+	111, // 244: hydraidepbgo.HydraideService.IncrementUint32:output_type -> hydraidepbgo.IncrementUint32Response
+	114, // 245: hydraidepbgo.HydraideService.IncrementUint64:output_type -> hydraidepbgo.IncrementUint64Response
+	118, // 246: hydraidepbgo.HydraideService.IncrementFloat32:output_type -> hydraidepbgo.IncrementFloat32Response
+	121, // 247: hydraidepbgo.HydraideService.IncrementFloat64:output_type -> hydraidepbgo.IncrementFloat64Response
+	123, // 248: hydraidepbgo.HydraideService.SetIfLater:output_type -> hydraidepbgo.SetIfLaterResponse
+	125, // 249: hydraidepbgo.HydraideService.AddDuration:output_type -> hydraidepbgo.AddDurationResponse
+	129, // 250: hydraidepbgo.HydraideService.SetStringIf:output_type -> hydraidepbgo.SetStringIfResponse
+	132, // 251: hydraidepbgo.HydraideService.SetBoolIf:output_type -> hydraidepbgo.SetBoolIfResponse
+	171, // 252: hydraidepbgo.HydraideService.SetSwampAnnotation:output_type -> hydraidepbgo.SetSwampAnnotationResponse
+	173, // 253: hydraidepbgo.HydraideService.GetSwampAnnotations:output_type -> hydraidepbgo.GetSwampAnnotationsResponse
+	175, // 254: hydraidepbgo.HydraideService.Aggregate:output_type -> hydraidepbgo.AggregateResponse
+	178, // 255: hydraidepbgo.HydraideService.ListCorruptedFiles:output_type -> hydraidepbgo.ListCorruptedFilesResponse
+	181, // 256: hydraidepbgo.HydraideService.GetNamespaceUsage:output_type -> hydraidepbgo.GetNamespaceUsageResponse
+	183, // 257: hydraidepbgo.HydraideService.CompactSwamp:output_type -> hydraidepbgo.CompactSwampResponse
+	185, // 258: hydraidepbgo.HydraideService.PutBlob:output_type -> hydraidepbgo.PutBlobResponse
+	187, // 259: hydraidepbgo.HydraideService.GetBlob:output_type -> hydraidepbgo.GetBlobResponse
+	189, // 260: hydraidepbgo.HydraideService.RefBlob:output_type -> hydraidepbgo.RefBlobResponse
+	191, // 261: hydraidepbgo.HydraideService.CollectBlobGarbage:output_type -> hydraidepbgo.CollectBlobGarbageResponse
+	194, // 262: hydraidepbgo.HydraideService.QueryAuditLog:output_type -> hydraidepbgo.QueryAuditLogResponse
+	196, // 263: hydraidepbgo.HydraideService.VerifyIslandMapping:output_type -> hydraidepbgo.VerifyIslandMappingResponse
+	198, // 264: hydraidepbgo.HydraideService.RestorePointInTime:output_type -> hydraidepbgo.RestorePointInTimeResponse
+	201, // 265: hydraidepbgo.HydraideService.GetClusterTopology:output_type -> hydraidepbgo.GetClusterTopologyResponse
+	203, // 266: hydraidepbgo.HydraideService.SetClusterTopology:output_type -> hydraidepbgo.SetClusterTopologyResponse
+	206, // 267: hydraidepbgo.HydraideService.SetIslandState:output_type -> hydraidepbgo.SetIslandStateResponse
+	208, // 268: hydraidepbgo.HydraideService.ExportIsland:output_type -> hydraidepbgo.ExportIslandResponse
+	210, // 269: hydraidepbgo.HydraideService.ImportIsland:output_type -> hydraidepbgo.ImportIslandResponse
+	212, // 270: hydraidepbgo.HydraideService.ReloadDefaults:output_type -> hydraidepbgo.ReloadDefaultsResponse
+	194, // [194:271] is the sub-list for method output_type
+	117, // [117:194] is the sub-list for method input_type
+	117, // [117:117] is the sub-list for extension type_name
+	117, // [117:117] is the sub-list for extension extendee
+	0,   // [0:117] is the sub-list for field type_name
 }
 
 func init() { file_hydraide_proto_init() }
@@ -6706,18 +15366,28 @@ func file_hydraide_proto_init() {
 	if File_hydraide_proto != nil {
 		return
 	}
-	file_hydraide_proto_msgTypes[13].OneofWrappers = []any{}
-	file_hydraide_proto_msgTypes[19].OneofWrappers = []any{}
-	file_hydraide_proto_msgTypes[21].OneofWrappers = []any{}
-	file_hydraide_proto_msgTypes[32].OneofWrappers = []any{}
-	file_hydraide_proto_msgTypes[88].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[17].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[25].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[29].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[31].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[35].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[45].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[56].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[60].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[68].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[72].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[73].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[152].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[159].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[160].OneofWrappers = []any{}
+	file_hydraide_proto_msgTypes[200].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hydraide_proto_rawDesc), len(file_hydraide_proto_rawDesc)),
-			NumEnums:      7,
-			NumMessages:   90,
+			NumEnums:      15,
+			NumMessages:   203,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
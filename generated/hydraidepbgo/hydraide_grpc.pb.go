@@ -24,22 +24,45 @@ const (
 	HydraideService_Unlock_FullMethodName                  = "/hydraidepbgo.HydraideService/Unlock"
 	HydraideService_RegisterSwamp_FullMethodName           = "/hydraidepbgo.HydraideService/RegisterSwamp"
 	HydraideService_DeRegisterSwamp_FullMethodName         = "/hydraidepbgo.HydraideService/DeRegisterSwamp"
+	HydraideService_ListSwampPatterns_FullMethodName       = "/hydraidepbgo.HydraideService/ListSwampPatterns"
+	HydraideService_UpdateSwampPattern_FullMethodName      = "/hydraidepbgo.HydraideService/UpdateSwampPattern"
 	HydraideService_Set_FullMethodName                     = "/hydraidepbgo.HydraideService/Set"
 	HydraideService_Get_FullMethodName                     = "/hydraidepbgo.HydraideService/Get"
+	HydraideService_SetLargeValue_FullMethodName           = "/hydraidepbgo.HydraideService/SetLargeValue"
+	HydraideService_GetLargeValue_FullMethodName           = "/hydraidepbgo.HydraideService/GetLargeValue"
 	HydraideService_GetAll_FullMethodName                  = "/hydraidepbgo.HydraideService/GetAll"
+	HydraideService_GetAllStream_FullMethodName            = "/hydraidepbgo.HydraideService/GetAllStream"
 	HydraideService_GetByIndex_FullMethodName              = "/hydraidepbgo.HydraideService/GetByIndex"
+	HydraideService_GetTopN_FullMethodName                 = "/hydraidepbgo.HydraideService/GetTopN"
+	HydraideService_GetByValue_FullMethodName              = "/hydraidepbgo.HydraideService/GetByValue"
+	HydraideService_GetByReference_FullMethodName          = "/hydraidepbgo.HydraideService/GetByReference"
 	HydraideService_ShiftExpiredTreasures_FullMethodName   = "/hydraidepbgo.HydraideService/ShiftExpiredTreasures"
+	HydraideService_LeaseExpiredTreasures_FullMethodName   = "/hydraidepbgo.HydraideService/LeaseExpiredTreasures"
+	HydraideService_AckLease_FullMethodName                = "/hydraidepbgo.HydraideService/AckLease"
+	HydraideService_NackLease_FullMethodName               = "/hydraidepbgo.HydraideService/NackLease"
 	HydraideService_Destroy_FullMethodName                 = "/hydraidepbgo.HydraideService/Destroy"
+	HydraideService_DestroyMany_FullMethodName             = "/hydraidepbgo.HydraideService/DestroyMany"
 	HydraideService_Delete_FullMethodName                  = "/hydraidepbgo.HydraideService/Delete"
+	HydraideService_ListDeleted_FullMethodName             = "/hydraidepbgo.HydraideService/ListDeleted"
+	HydraideService_Restore_FullMethodName                 = "/hydraidepbgo.HydraideService/Restore"
+	HydraideService_GetHistory_FullMethodName              = "/hydraidepbgo.HydraideService/GetHistory"
+	HydraideService_RevertTo_FullMethodName                = "/hydraidepbgo.HydraideService/RevertTo"
 	HydraideService_Count_FullMethodName                   = "/hydraidepbgo.HydraideService/Count"
 	HydraideService_IsSwampExist_FullMethodName            = "/hydraidepbgo.HydraideService/IsSwampExist"
+	HydraideService_ExistsMany_FullMethodName              = "/hydraidepbgo.HydraideService/ExistsMany"
 	HydraideService_IsKeyExist_FullMethodName              = "/hydraidepbgo.HydraideService/IsKeyExist"
+	HydraideService_IsKeysExist_FullMethodName             = "/hydraidepbgo.HydraideService/IsKeysExist"
 	HydraideService_SubscribeToEvents_FullMethodName       = "/hydraidepbgo.HydraideService/SubscribeToEvents"
+	HydraideService_SubscribeAll_FullMethodName            = "/hydraidepbgo.HydraideService/SubscribeAll"
 	HydraideService_SubscribeToInfo_FullMethodName         = "/hydraidepbgo.HydraideService/SubscribeToInfo"
 	HydraideService_Uint32SlicePush_FullMethodName         = "/hydraidepbgo.HydraideService/Uint32SlicePush"
 	HydraideService_Uint32SliceDelete_FullMethodName       = "/hydraidepbgo.HydraideService/Uint32SliceDelete"
 	HydraideService_Uint32SliceSize_FullMethodName         = "/hydraidepbgo.HydraideService/Uint32SliceSize"
 	HydraideService_Uint32SliceIsValueExist_FullMethodName = "/hydraidepbgo.HydraideService/Uint32SliceIsValueExist"
+	HydraideService_Uint32SliceGetRange_FullMethodName     = "/hydraidepbgo.HydraideService/Uint32SliceGetRange"
+	HydraideService_Uint32SliceStream_FullMethodName       = "/hydraidepbgo.HydraideService/Uint32SliceStream"
+	HydraideService_Uint32SliceCombine_FullMethodName      = "/hydraidepbgo.HydraideService/Uint32SliceCombine"
+	HydraideService_Uint32SliceCombineInto_FullMethodName  = "/hydraidepbgo.HydraideService/Uint32SliceCombineInto"
 	HydraideService_IncrementInt8_FullMethodName           = "/hydraidepbgo.HydraideService/IncrementInt8"
 	HydraideService_IncrementInt16_FullMethodName          = "/hydraidepbgo.HydraideService/IncrementInt16"
 	HydraideService_IncrementInt32_FullMethodName          = "/hydraidepbgo.HydraideService/IncrementInt32"
@@ -50,6 +73,29 @@ const (
 	HydraideService_IncrementUint64_FullMethodName         = "/hydraidepbgo.HydraideService/IncrementUint64"
 	HydraideService_IncrementFloat32_FullMethodName        = "/hydraidepbgo.HydraideService/IncrementFloat32"
 	HydraideService_IncrementFloat64_FullMethodName        = "/hydraidepbgo.HydraideService/IncrementFloat64"
+	HydraideService_SetIfLater_FullMethodName              = "/hydraidepbgo.HydraideService/SetIfLater"
+	HydraideService_AddDuration_FullMethodName             = "/hydraidepbgo.HydraideService/AddDuration"
+	HydraideService_SetStringIf_FullMethodName             = "/hydraidepbgo.HydraideService/SetStringIf"
+	HydraideService_SetBoolIf_FullMethodName               = "/hydraidepbgo.HydraideService/SetBoolIf"
+	HydraideService_SetSwampAnnotation_FullMethodName      = "/hydraidepbgo.HydraideService/SetSwampAnnotation"
+	HydraideService_GetSwampAnnotations_FullMethodName     = "/hydraidepbgo.HydraideService/GetSwampAnnotations"
+	HydraideService_Aggregate_FullMethodName               = "/hydraidepbgo.HydraideService/Aggregate"
+	HydraideService_ListCorruptedFiles_FullMethodName      = "/hydraidepbgo.HydraideService/ListCorruptedFiles"
+	HydraideService_GetNamespaceUsage_FullMethodName       = "/hydraidepbgo.HydraideService/GetNamespaceUsage"
+	HydraideService_CompactSwamp_FullMethodName            = "/hydraidepbgo.HydraideService/CompactSwamp"
+	HydraideService_PutBlob_FullMethodName                 = "/hydraidepbgo.HydraideService/PutBlob"
+	HydraideService_GetBlob_FullMethodName                 = "/hydraidepbgo.HydraideService/GetBlob"
+	HydraideService_RefBlob_FullMethodName                 = "/hydraidepbgo.HydraideService/RefBlob"
+	HydraideService_CollectBlobGarbage_FullMethodName      = "/hydraidepbgo.HydraideService/CollectBlobGarbage"
+	HydraideService_QueryAuditLog_FullMethodName           = "/hydraidepbgo.HydraideService/QueryAuditLog"
+	HydraideService_VerifyIslandMapping_FullMethodName     = "/hydraidepbgo.HydraideService/VerifyIslandMapping"
+	HydraideService_RestorePointInTime_FullMethodName      = "/hydraidepbgo.HydraideService/RestorePointInTime"
+	HydraideService_GetClusterTopology_FullMethodName      = "/hydraidepbgo.HydraideService/GetClusterTopology"
+	HydraideService_SetClusterTopology_FullMethodName      = "/hydraidepbgo.HydraideService/SetClusterTopology"
+	HydraideService_SetIslandState_FullMethodName          = "/hydraidepbgo.HydraideService/SetIslandState"
+	HydraideService_ExportIsland_FullMethodName            = "/hydraidepbgo.HydraideService/ExportIsland"
+	HydraideService_ImportIsland_FullMethodName            = "/hydraidepbgo.HydraideService/ImportIsland"
+	HydraideService_ReloadDefaults_FullMethodName          = "/hydraidepbgo.HydraideService/ReloadDefaults"
 )
 
 // HydraideServiceClient is the client API for HydraideService service.
@@ -86,12 +132,42 @@ type HydraideServiceClient interface {
 	// - Maximum file size per chunk (MaxFileSize)
 	//
 	// Use this to define behavior per collection before inserting data.
+	//
+	// ⚠️ If a registered pattern overlaps with the new one, i.e. a swamp can match both, and it has different memory
+	// or filesystem settings (IsInMemorySwamp, CloseAfterIdle, WriteInterval, MaxFileSize, Fsync, FsyncInterval), the
+	// registration is rejected with an AlreadyExists error with PATTERN_CONFLICT reason, and the registered pattern
+	// is attached to the error as a SwampPattern detail. Set Force to register the pattern anyway, e.g. when the
+	// settings of a pattern are changed on purpose. The server records which client registered each pattern, see
+	// ListSwampPatterns.
 	RegisterSwamp(ctx context.Context, in *RegisterSwampRequest, opts ...grpc.CallOption) (*RegisterSwampResponse, error)
 	// DeRegisterSwamp removes a previously registered swamp pattern.
-	// This does not delete the swamp data — it only removes its active configuration.
+	// By default, this does not delete the swamp data — it only removes its active configuration.
+	//
+	// Set Purge to destroy the swamps matching the pattern with their files first, and DryRun to only list the
+	// swamps a purge would destroy. The swamps are destroyed before the pattern is removed, so a failed purge can be
+	// retried with the same request.
 	//
 	// Use this to clean up unused swamp definitions.
 	DeRegisterSwamp(ctx context.Context, in *DeRegisterSwampRequest, opts ...grpc.CallOption) (*DeRegisterSwampResponse, error)
+	// ListSwampPatterns lists the swamp patterns registered on the server, with their settings.
+	//
+	// The settings are the ones in effect: the values left unset at the registration show the defaults of the
+	// server at the time of the registration. The patterns are sorted by their names.
+	//
+	// Use this to see how the swamps of a pattern are kept in memory and written to the disk.
+	ListSwampPatterns(ctx context.Context, in *ListSwampPatternsRequest, opts ...grpc.CallOption) (*ListSwampPatternsResponse, error)
+	// UpdateSwampPattern changes the CloseAfterIdle, WriteInterval and MaxFileSize of a registered swamp pattern,
+	// and keeps its other settings.
+	//
+	// The fields left unset are not changed. The change is saved like a registration, and applies to the swamps
+	// opened after it: the open swamps keep their settings until they are closed, so nothing is rehydrated.
+	// A pattern that is not registered returns a NotFound error with SWAMP_PATTERN_NOT_FOUND reason. The WriteInterval
+	// and the MaxFileSize of an in-memory pattern can not be set.
+	//
+	// Use this to tune a pattern without redeploying the clients that register it. ⚠️ A client that registers the
+	// pattern again with other settings gets a PATTERN_CONFLICT error, unless it forces the registration, which
+	// overwrites the change.
+	UpdateSwampPattern(ctx context.Context, in *UpdateSwampPatternRequest, opts ...grpc.CallOption) (*UpdateSwampPatternResponse, error)
 	// Set inserts or updates one or more key-value pairs into one or more swamps.
 	// You can control the behavior using two flags:
 	// - CreateIfNotExist: If false, the swamp/key must already exist (update only).
@@ -122,12 +198,38 @@ type HydraideServiceClient interface {
 	//
 	// Each response includes the key, value, and metadata fields (timestamps, creators).
 	// This is a type-safe, structured read – no JSON parsing needed on the client side.
+	//
+	// 💡 If the pattern of the swamp is registered with LazyHydration, and the swamp is not in the memory, only the
+	// chunk files of the requested keys are read, without loading the swamp.
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// SetLargeValue writes one treasure whose bytes value is too large for one gRPC message.
+	//
+	// The client streams the value in chunks, and the server reassembles it before the treasure is written
+	// with the same semantics as Set. The first message carries the swamp, the flags and the KeyValuePair
+	// without the bytes value, every message can carry the next chunk of the value.
+	//
+	// 💡 The SDKs use it transparently if a single bytes value (e.g. a GOB-encoded struct) would not fit
+	// into the max message size.
+	//
+	// ⚠️ The server holds the whole value in memory while it is reassembled and written.
+	SetLargeValue(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SetLargeValueRequest, SetLargeValueResponse], error)
+	// GetLargeValue reads one treasure whose bytes value is too large for one gRPC message.
+	//
+	// The first message of the stream carries the treasure without the bytes value, the next messages
+	// carry the chunks of the value in order. If the treasure does not exist, the stream has only the
+	// first message with IsExist = false.
+	GetLargeValue(ctx context.Context, in *GetLargeValueRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetLargeValueResponse], error)
 	// GetAll retrieves **all** key-value pairs from a given swamp.
 	//
 	// This is useful for debug purposes, cache rebuilding, or initial snapshot creation.
 	// Not recommended for large datasets unless scoped and filtered client-side.
 	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error)
+	// GetAllStream streams all treasures of a given swamp in batches.
+	//
+	// Unlike GetAll, neither the server nor the client holds the whole swamp in one message,
+	// so it can read huge swamps with constant memory. The treasures come in no particular order.
+	// Use GetByIndex if the treasures must be ordered.
+	GetAllStream(ctx context.Context, in *GetAllStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetAllStreamResponse], error)
 	// GetByIndex allows indexed reads based on fields like:
 	// - ExpirationTime
 	// - CreationTime
@@ -142,6 +244,40 @@ type HydraideServiceClient interface {
 	//
 	// You do not need to pre-define indexes. Simply call this method with the right IndexType.
 	GetByIndex(ctx context.Context, in *GetByIndexRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error)
+	// GetTopN returns the first N treasures of an index, e.g. the top 100 scores of a leaderboard.
+	//
+	// The treasures are read directly from the in-memory index, so the answer costs O(N) of the result
+	// instead of reading the whole index range with From and Limit.
+	//
+	// For the VALUE_* indexes only the treasures holding the type of the index are returned.
+	// If the index can not be built because the swamp holds values of another type,
+	// a FailedPrecondition error with WRONG_VALUE_TYPE reason is returned.
+	GetTopN(ctx context.Context, in *GetTopNRequest, opts ...grpc.CallOption) (*GetTopNResponse, error)
+	// GetByValue returns all treasures of a swamp whose value equals the given value.
+	//
+	// The lookup uses the secondary value index of the swamp, so it does not scan the swamp.
+	// The value index must be enabled for the swamp pattern with the `ValueIndex` flag of RegisterSwamp,
+	// otherwise the request fails with FailedPrecondition.
+	//
+	// ⚠️ The value index lives only in memory. It is built at the first GetByValue call and maintained
+	// incrementally by every write and delete until the swamp is closed.
+	//
+	// Use this for lookups like:
+	// - Find the user keys registered with a given email address
+	// - Find all tasks in a given state
+	GetByValue(ctx context.Context, in *GetByValueRequest, opts ...grpc.CallOption) (*GetByValueResponse, error)
+	// GetByReference returns the treasures referenced by the IDs of a uint32 slice, in one roundtrip.
+	//
+	// The uint32 slice is read from the given swamp and key, and every ID in it is resolved to the
+	// treasure of the target swamp whose key is the TargetKeyPrefix followed by the decimal ID.
+	//
+	// ⚠️ The target swamp must be served by the same server as the slice swamp, because the server
+	// resolves the references locally. The SDK falls back to two reads if they are on different servers.
+	//
+	// Use this for joins like:
+	// - Read the products of a tag, where the tag holds the IDs of its products in a uint32 slice
+	// - Read the users who viewed a product
+	GetByReference(ctx context.Context, in *GetByReferenceRequest, opts ...grpc.CallOption) (*GetByReferenceResponse, error)
 	// ShiftExpiredTreasures retrieves and deletes expired treasures from a given swamp.
 	//
 	// This method is ideal for implementing task queues, time-based processing systems,
@@ -161,6 +297,29 @@ type HydraideServiceClient interface {
 	// - Expiring caches
 	// - Scheduled triggers (e.g. publish-after-expiry)
 	ShiftExpiredTreasures(ctx context.Context, in *ShiftExpiredTreasuresRequest, opts ...grpc.CallOption) (*ShiftExpiredTreasuresResponse, error)
+	// LeaseExpiredTreasures retrieves the expired treasures of a swamp like ShiftExpiredTreasures, but instead of
+	// deleting them, it hides them for the lease time.
+	//
+	// Every returned treasure has a LeaseID, and the consumer must end the lease after the processing:
+	// - AckLease deletes the treasure, after a successful processing
+	// - NackLease makes the treasure visible again, after a failed processing
+	//
+	// 🔁 If the consumer crashes, the treasure becomes visible again when the lease expires, so no item is lost.
+	// The Deliveries of the treasure counts how many times it was leased, so the repeatedly failing items can be
+	// moved to a dead-letter swamp by the client.
+	//
+	// ⚠️ The delivery counters are kept in the memory of the server, so they start from zero after a restart.
+	LeaseExpiredTreasures(ctx context.Context, in *LeaseExpiredTreasuresRequest, opts ...grpc.CallOption) (*LeaseExpiredTreasuresResponse, error)
+	// AckLease deletes a leased treasure after its successful processing.
+	//
+	// Fails with the LEASE_NOT_FOUND reason if the treasure does not exist, or it was leased again by an other
+	// consumer after the lease expired.
+	AckLease(ctx context.Context, in *AckLeaseRequest, opts ...grpc.CallOption) (*AckLeaseResponse, error)
+	// NackLease gives back a leased treasure after a failed processing. The treasure becomes visible again after
+	// the RetryAfter time, and its delivery counter is kept.
+	//
+	// Fails with the LEASE_NOT_FOUND reason like AckLease.
+	NackLease(ctx context.Context, in *NackLeaseRequest, opts ...grpc.CallOption) (*NackLeaseResponse, error)
 	// Destroy permanently deletes the entire swamp and all its treasures.
 	//
 	// This removes all data associated with the given swamp, including metadata, indexes, and chunks.
@@ -173,6 +332,19 @@ type HydraideServiceClient interface {
 	// - User data deletion requests
 	// - Full environment reset
 	Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyResponse, error)
+	// DestroyMany permanently deletes every swamp of the server matching a wildcard pattern, with all their treasures.
+	//
+	// The ConfirmationToken of the request must be the SwampPattern itself, so a pattern built by mistake, e.g. with
+	// an empty realm, is never destroyed. One response is streamed for each destroyed swamp, so the caller can follow
+	// the progress of a large teardown. If the stream is broken, the remaining swamps are not destroyed, and the same
+	// request can be sent again.
+	//
+	// ⚠️ Warning: This operation is irreversible, like Destroy.
+	//
+	// Typical use cases:
+	// - Tearing down all swamps of a tenant
+	// - Cleanup after a migration
+	DestroyMany(ctx context.Context, in *DestroyManyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DestroyManyResponse], error)
 	// Delete removes one or more treasures (key-value pairs) from the specified swamp.
 	//
 	// This is a precise, non-destructive swamp-level operation.
@@ -186,9 +358,40 @@ type HydraideServiceClient interface {
 	//
 	// Use this to:
 	// - Manually remove outdated or invalid entries
-	// - Implement "soft delete" logic (client-side)
+	// - Implement "soft delete" logic with the ShadowDelete flag
 	// - Perform targeted cleanup operations
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// ListDeleted returns the shadow-deleted treasures of a swamp, ordered by their deletion time.
+	//
+	// The shadow-deleted treasures keep their content and metadata, but they are invisible for all other
+	// operations, until they are restored with Restore, or replaced by a new treasure with the same key.
+	//
+	// Use this to:
+	// - Audit the deletions of a swamp
+	// - Find the treasures to restore after an accidental deletion
+	ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error)
+	// Restore puts shadow-deleted treasures back to their swamp, with the content they had before the deletion.
+	//
+	// 🔔 Realtime: the subscribers receive the restored treasures as new treasures.
+	//
+	// The status of a key is NOT_FOUND if there is no shadow-deleted treasure with the key.
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	// GetHistory returns the stored versions of a treasure, oldest first.
+	//
+	// The history is kept only if the swamp pattern is registered with HistoryDepth. Every version contains the
+	// content of the treasure as it was saved, the Version number, the time of the save in UpdatedAt, and the
+	// creator or the modifier of the version in UpdatedBy.
+	//
+	// Use this to:
+	// - Audit the changes of a treasure
+	// - Show the earlier states of a document
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// RevertTo sets the content of a treasure back to a version of its history.
+	//
+	// The revert is saved as a new version, so the versions after the reverted one are kept in the history.
+	//
+	// 🔔 Realtime: the subscribers receive the reverted treasure as a modified treasure.
+	RevertTo(ctx context.Context, in *RevertToRequest, opts ...grpc.CallOption) (*RevertToResponse, error)
 	// Count returns the number of treasures in one or more specified swamps.
 	//
 	// You provide a list of swamp names, and for each swamp HydrAIDE returns:
@@ -203,6 +406,10 @@ type HydraideServiceClient interface {
 	// 💡 The count is calculated instantly from the swamp’s current state,
 	// with no need for full traversal or manual iteration.
 	//
+	// 🔍 An optional filter counts only the expired treasures, the keys with a prefix, or the treasures updated since
+	// a timestamp, e.g. "how many jobs are overdue", without shifting them or streaming the whole index.
+	// The filtered count walks the treasures of the swamp on the server.
+	//
 	// Can be used across multiple swamps in a single request.
 	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error)
 	// IsSwampExist checks if the specified swamp exists in the given sanctuary.
@@ -217,6 +424,21 @@ type HydraideServiceClient interface {
 	//
 	// 💡 Note: Swamp existence does not guarantee any treasures inside – it's purely structural.
 	IsSwampExist(ctx context.Context, in *IsSwampExistRequest, opts ...grpc.CallOption) (*IsSwampExistResponse, error)
+	// ExistsMany checks the existence of many swamps in a single request.
+	//
+	// Every requested item is either an exact swamp name or a wildcard pattern, where the
+	// realm and/or the swamp part of the name is "*" (e.g. "domains/*/example.com").
+	// For every item HydrAIDE returns the names of the existing swamps matching it:
+	// - For an exact name: the name itself if the swamp exists, otherwise nothing
+	// - For a wildcard pattern: all existing swamps on this server that match the pattern
+	//
+	// ✅ This is useful for:
+	// - Rendering existence badges for hundreds of items in a UI with one roundtrip
+	// - Discovering the swamps of a sanctuary or realm for admin tooling
+	//
+	// ⚠️ Wildcard patterns are resolved by walking all island folders of the server, so they are
+	// much more expensive than exact names. Use them for admin and UI purposes, not in hot paths.
+	ExistsMany(ctx context.Context, in *ExistsManyRequest, opts ...grpc.CallOption) (*ExistsManyResponse, error)
 	// IsKeyExist checks whether a specific key exists in a given swamp.
 	//
 	// This allows you to verify the presence of a treasure without retrieving its content.
@@ -227,7 +449,23 @@ type HydraideServiceClient interface {
 	// - TTL-aware logic (e.g. only update if key is still present)
 	//
 	// 💡 Note: The value is not returned – only a boolean indicator of existence.
+	//
+	// 💡 If the pattern of the swamp is registered with KeyFilter, and the swamp is not in the memory, a missing key is
+	// usually answered from the bloom filter of the swamp, without loading the swamp.
 	IsKeyExist(ctx context.Context, in *IsKeyExistRequest, opts ...grpc.CallOption) (*IsKeyExistResponse, error)
+	// IsKeysExist checks the existence of many keys in a given swamp with a single request.
+	//
+	// This is the bulk variant of IsKeyExist. The values are not returned, so checking thousands of
+	// keys costs a single roundtrip and a few bytes per key.
+	//
+	// Use cases include:
+	// - Deduplication pipelines that filter out the already seen candidates in batches
+	// - Checking which items of a list still exist before a batch update
+	//
+	// 💡 The response contains one boolean for every requested key, in the order of the request.
+	//
+	// 💡 With a KeyFilter, the swamp is loaded only if the bloom filter can not exclude all keys, see IsKeyExist.
+	IsKeysExist(ctx context.Context, in *IsKeysExistRequest, opts ...grpc.CallOption) (*IsKeysExistResponse, error)
 	// SubscribeToEvents allows clients to subscribe to **all data changes** within a given swamp.
 	//
 	// When any treasure in the swamp is created, updated, or deleted,
@@ -249,7 +487,39 @@ type HydraideServiceClient interface {
 	// - Microservice communication via event stream
 	//
 	// 💡 You can use this to completely replace traditional polling or cron-based checks.
+	//
+	// 📸 With IncludeSnapshot, the stream starts with all current treasures of the swamp (status NOTHING_CHANGED),
+	// followed by a message with SnapshotEnd and the ResumeToken. The snapshot and the start of the stream are
+	// atomic: no change is lost or delivered twice between them.
+	//
+	// ⏪ With Since, the stream starts with the events of the swamp after the given time, replayed from the event
+	// journal of the swamp, followed by a message with SnapshotEnd. The journal must be enabled for the pattern of the
+	// swamp in the RegisterSwampRequest. If the journal does not cover the time, the call fails with the
+	// REPLAY_NOT_AVAILABLE reason, and the client should read the whole swamp instead.
+	//
+	// 🚦 The events wait for the client in a bounded queue of BufferSize events. If the client is too slow and the
+	// queue is full, the Overflow policy decides: the writers of the swamp wait (BLOCK, the default), the oldest events
+	// are dropped and a message with DroppedEvents is sent (DROP_OLDEST), or the stream is closed with a
+	// ResourceExhausted error and SUBSCRIBER_OVERFLOW reason (CANCEL).
 	SubscribeToEvents(ctx context.Context, in *SubscribeToEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToEventsResponse], error)
+	// SubscribeAll streams the events of every swamp of the server matching any of the patterns, e.g. to feed a
+	// change data capture (CDC) pipeline of a data warehouse.
+	//
+	// Unlike SubscribeToEvents, the subscription does not hydrate the swamps. A swamp can not change without being
+	// hydrated, so the events of every change of the matching swamps are streamed, both of the swamps in the memory and
+	// of the swamps hydrated later.
+	//
+	// The first message of the stream has SnapshotEnd set and no treasure. It is sent when the subscription is active,
+	// so every change after it is streamed. The SwampName of the events names their swamp, and their Sequence is the
+	// sequence number of the event within its swamp.
+	//
+	// 🌍 The stream covers only the swamps of this server, so the clients must subscribe to every server.
+	//
+	// ⚠️ The events wait for the slow clients in a bounded queue of BufferSize events. By default, if the queue is
+	// full, the stream is closed with a ResourceExhausted error and SUBSCRIBER_OVERFLOW reason, instead of dropping
+	// events silently. The client should resynchronize the matching swamps, and subscribe again. The Overflow policy
+	// can drop the oldest events instead (DROP_OLDEST), or make the writers wait (BLOCK).
+	SubscribeAll(ctx context.Context, in *SubscribeAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToEventsResponse], error)
 	// SubscribeToInfo allows clients to subscribe to the **size** of a given swamp.
 	//
 	// Whenever the number of treasures changes in the swamp (insert, delete, expire),
@@ -278,6 +548,9 @@ type HydraideServiceClient interface {
 	// - Subscriptions, permissions, ID-based tagging
 	// - Memory-efficient, append-only ID lists
 	//
+	// The response counts the added and the already present values of every key, so an indexing job can track
+	// its progress.
+	//
 	// ⚠️ Recommended for cases where the slice size is small to moderate,
 	// as the full slice is returned on each update.
 	Uint32SlicePush(ctx context.Context, in *AddToUint32SlicePushRequest, opts ...grpc.CallOption) (*AddToUint32SlicePushResponse, error)
@@ -300,6 +573,40 @@ type HydraideServiceClient interface {
 	// This is useful when you want to validate membership before taking actions,
 	// such as displaying UI states or preventing duplicate logic.
 	Uint32SliceIsValueExist(ctx context.Context, in *Uint32SliceIsValueExistRequest, opts ...grpc.CallOption) (*Uint32SliceIsValueExistResponse, error)
+	// Uint32SliceGetRange returns a page of the values of the uint32 slice, in the order they were pushed.
+	//
+	// Use it to page through huge slices (e.g. a reverse index with millions of IDs), which do not fit into one
+	// gRPC message. The response includes the size of the whole slice, so the client knows when to stop.
+	//
+	// ⚠️ The pages are read one by one, so a push or a delete between two pages can shift the values.
+	// Use Uint32SliceStream to read a consistent snapshot of the whole slice.
+	Uint32SliceGetRange(ctx context.Context, in *Uint32SliceGetRangeRequest, opts ...grpc.CallOption) (*Uint32SliceGetRangeResponse, error)
+	// Uint32SliceStream streams all values of the uint32 slice in batches, in the order they were pushed.
+	//
+	// The stream is a snapshot of the slice at the start of the request, so the pushes and the deletes during the
+	// stream do not shift the values. Neither side holds the whole slice in one gRPC message.
+	Uint32SliceStream(ctx context.Context, in *Uint32SliceStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Uint32SliceStreamResponse], error)
+	// Uint32SliceCombine computes the union, the intersection or the difference of the uint32 slices of many keys of
+	// a swamp, and streams the result back in batches.
+	//
+	// 💡 The slices never leave the server, only the result — e.g. a search engine intersects the reverse indexes of
+	// the words of a query without downloading millions of IDs.
+	//
+	// - UNION: the values of any of the keys, in the order of their first appearance
+	// - INTERSECTION: the values of the first key which are in all other keys, in the order of the first key
+	// - DIFFERENCE: the values of the first key which are in none of the other keys, in the order of the first key
+	//
+	// A missing key is an empty slice. Fails with the WRONG_VALUE_TYPE reason, if a key is not a uint32 slice.
+	Uint32SliceCombine(ctx context.Context, in *Uint32SliceCombineRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Uint32SliceStreamResponse], error)
+	// Uint32SliceCombineInto computes the same set operations as Uint32SliceCombine, and stores the result in the
+	// destination key of the swamp, instead of streaming it back.
+	//
+	// The destination key is replaced by the result, and deleted if the result is empty, like an emptied slice of
+	// Uint32SliceDelete. The destination key can be one of the combined keys, e.g. to remove the values of other keys
+	// from it.
+	//
+	// 🔔 The change of the destination key triggers an event to the swamp subscribers.
+	Uint32SliceCombineInto(ctx context.Context, in *Uint32SliceCombineIntoRequest, opts ...grpc.CallOption) (*Uint32SliceCombineIntoResponse, error)
 	// IncrementInt8 increments (or decrements) the value of the key by the specified amount,
 	// if a given condition is satisfied.
 	//
@@ -335,6 +642,207 @@ type HydraideServiceClient interface {
 	IncrementFloat32(ctx context.Context, in *IncrementFloat32Request, opts ...grpc.CallOption) (*IncrementFloat32Response, error)
 	// IncrementFloat64 same logic as IncrementInt8 but for float64 values
 	IncrementFloat64(ctx context.Context, in *IncrementFloat64Request, opts ...grpc.CallOption) (*IncrementFloat64Response, error)
+	// SetIfLater stores the timestamp in the key, if it is later than the stored timestamp, or the key does not exist.
+	//
+	// The stored value is an int64 UNIX timestamp in seconds, like the time.Time values of the SDK models, so the
+	// heartbeats and the "last seen" times can be moved forward without a lock-read-compare-write sequence, and a late
+	// writer never moves them back.
+	//
+	// The response includes the stored timestamp after the request, and whether it was set.
+	// Fails with the WRONG_VALUE_TYPE reason, if the stored value is not an int64.
+	//
+	// 🔔 Realtime: a set timestamp triggers an event to the swamp subscribers, like the increments.
+	SetIfLater(ctx context.Context, in *SetIfLaterRequest, opts ...grpc.CallOption) (*SetIfLaterResponse, error)
+	// AddDuration adds a duration to the int64 UNIX timestamp (in seconds) stored in the key, e.g. to extend a lease.
+	//
+	// If the key does not exist, the duration is added to the current time of the server. With FromNowIfPast, the
+	// duration is added to the current time also if the stored timestamp is already in the past, so an expired lease
+	// is renewed from now, instead of from its expiration.
+	//
+	// The response includes the new timestamp.
+	// Fails with the WRONG_VALUE_TYPE reason, if the stored value is not an int64.
+	//
+	// 🔔 Realtime: the change triggers an event to the swamp subscribers, like the increments.
+	AddDuration(ctx context.Context, in *AddDurationRequest, opts ...grpc.CallOption) (*AddDurationResponse, error)
+	// SetStringIf stores the string value in the key, if the condition is satisfied.
+	//
+	// 💡 The compare and the set are atomic, so simple state machines can be enforced by the server, e.g. a status
+	// moves from "pending" to "processing" only once, even if many workers try it at the same time.
+	//
+	// The conditions:
+	// - EQUAL: the key exists, and its stored value is equal to the value of the condition
+	// - NOT_EXISTS: the key does not exist
+	// Without a condition, the value is stored unconditionally.
+	//
+	// The response includes the stored value after the request, and whether the value was set.
+	// Fails with the WRONG_VALUE_TYPE reason, if the stored value is not a string.
+	//
+	// 🔔 Realtime: a set value triggers an event to the swamp subscribers, like the increments.
+	SetStringIf(ctx context.Context, in *SetStringIfRequest, opts ...grpc.CallOption) (*SetStringIfResponse, error)
+	// SetBoolIf same logic as SetStringIf but for bool values
+	SetBoolIf(ctx context.Context, in *SetBoolIfRequest, opts ...grpc.CallOption) (*SetBoolIfResponse, error)
+	// SetSwampAnnotation attaches a small key-value annotation to an existing swamp.
+	//
+	// 💡 Annotations are free-form labels owned by the application, for example:
+	// - the owner service of the swamp
+	// - the schema version of the stored models
+	// - the retention class of the data
+	//
+	// An empty Value deletes the annotation.
+	// The annotations are stored in the swamp's metadata and persisted when the swamp is written to disk.
+	//
+	// ⚠️ Limits: at most 64 annotations per swamp, keys up to 128 bytes, values up to 1024 bytes.
+	// The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+	SetSwampAnnotation(ctx context.Context, in *SetSwampAnnotationRequest, opts ...grpc.CallOption) (*SetSwampAnnotationResponse, error)
+	// GetSwampAnnotations returns all annotations of an existing swamp.
+	GetSwampAnnotations(ctx context.Context, in *GetSwampAnnotationsRequest, opts ...grpc.CallOption) (*GetSwampAnnotationsResponse, error)
+	// Aggregate computes count, sum, min, max and avg over the numeric values of a swamp on the server.
+	//
+	// Only the aggregates travel over the network, so summing a million int64 values does not mean
+	// pulling a million treasures to the client.
+	//
+	// The treasures can be narrowed with:
+	// - KeyPrefix → only the keys starting with the prefix
+	// - FilterExpr → the same server-side filter expression as GetByIndex
+	// - IndexType, OrderType, From and Limit → an index range, e.g. the latest 100 treasures by creation time
+	//
+	// Treasures without a numeric value (strings, bools, bytes, void, etc.) are skipped and not counted.
+	// The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+	Aggregate(ctx context.Context, in *AggregateRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
+	// ListCorruptedFiles is an admin RPC that lists the chunk files of the server found corrupted since its start.
+	//
+	// Every chunk file is written with a CRC-32C checksum and verified when the swamp is loaded. A damaged file is
+	// skipped (or fails the loading of its swamp with a DataLoss error and DATA_CORRUPTED reason, if the server does
+	// not skip the corrupted files), and it is listed here until it is overwritten or deleted.
+	//
+	// The list is per server, so the clients must ask every server.
+	ListCorruptedFiles(ctx context.Context, in *ListCorruptedFilesRequest, opts ...grpc.CallOption) (*ListCorruptedFilesResponse, error)
+	// GetNamespaceUsage is an admin RPC that reports the disk usage, the number of the swamps and the number of the
+	// treasures of the server, grouped by the Sanctuaries and the Realms of the swamps.
+	//
+	// The usage is collected by walking the swamp folders of the islands, without loading the swamps into the memory.
+	// The treasure count of a closed swamp is the count recorded at its last close, the open swamps are counted in the
+	// memory. The swamps closed before the count was recorded are reported as uncounted.
+	//
+	// The walk of a big server takes a while, so the usage is cached for a minute, unless Refresh is set.
+	// The usage is per server, so the clients must ask every server and sum the results.
+	GetNamespaceUsage(ctx context.Context, in *GetNamespaceUsageRequest, opts ...grpc.CallOption) (*GetNamespaceUsageResponse, error)
+	// CompactSwamp is an admin RPC that rewrites the chunk files of a swamp that are mostly dead data.
+	//
+	// The deleted treasures stay in their chunk files as tombstones, so after heavy delete churn the swamp folder
+	// accumulates chunks with only a few live treasures. They waste disk space and slow down the hydration of the swamp.
+	// The compaction rewrites the live treasures of the chunks, where the ratio of the live data is below MinLiveRatio,
+	// into as few new chunks as possible, and deletes the old ones.
+	//
+	// The chunk the new treasures are appended to is never compacted.
+	// The in-memory swamps have no chunks, so the compaction does nothing for them.
+	// The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+	CompactSwamp(ctx context.Context, in *CompactSwampRequest, opts ...grpc.CallOption) (*CompactSwampResponse, error)
+	// PutBlob stores a large binary value once, under the SHA-256 hash of its content, and adds one reference to it.
+	//
+	// 📦 The blobs are content-addressed: storing the same content again, e.g. the same attachment of many treasures
+	// in many swamps, adds only a reference, the content takes the disk space only once. The treasures store the
+	// hash of the blob, and read the content by GetBlob.
+	//
+	// The content is streamed in chunks, so the blob can be larger than the max message size. The first message
+	// carries the IslandID and the expected hash, which is verified by the server.
+	PutBlob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PutBlobRequest, PutBlobResponse], error)
+	// GetBlob streams the content of a blob in chunks.
+	// A missing blob returns a NotFound error with BLOB_NOT_FOUND reason.
+	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetBlobResponse], error)
+	// RefBlob adds or removes references of a blob, when a treasure starts or stops referencing it.
+	//
+	// ⚠️ The blobs without references are removed by CollectBlobGarbage, so every PutBlob and every positive RefBlob
+	// should be balanced by a negative RefBlob, when the treasure is deleted or it references an other blob.
+	RefBlob(ctx context.Context, in *RefBlobRequest, opts ...grpc.CallOption) (*RefBlobResponse, error)
+	// CollectBlobGarbage is an admin RPC that removes the blobs of the server that have no references for at least
+	// MinAgeSeconds. The grace period protects the blobs whose references are being moved from one treasure to an
+	// other one.
+	CollectBlobGarbage(ctx context.Context, in *CollectBlobGarbageRequest, opts ...grpc.CallOption) (*CollectBlobGarbageResponse, error)
+	// QueryAuditLog is an admin RPC that returns the records of the audit log of the server, the newest first.
+	//
+	// If the audit log is enabled, the server records every mutating request: who sent it (the client ID, the IP
+	// address and the tenant), when, which operation on which swamps and keys, and its result. The values of the
+	// treasures are never recorded. The tenants see only their own records.
+	//
+	// The audit log is per server, so the clients must ask every server.
+	// If the audit log is not enabled, a FailedPrecondition error with AUDIT_LOG_DISABLED reason is returned.
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
+	// VerifyIslandMapping returns the island the server computes for a swamp name, and the description of the hash
+	// function of the islands.
+	//
+	// 🏝️ The clients compute the islands of the swamps, and the server stores the swamps on the islands sent by the
+	// clients. If an SDK, e.g. an SDK of an other language, computed different islands, its swamps would be scattered
+	// to the wrong islands and servers. The clients can compare their islands with the islands of the server, e.g. in
+	// their tests or at their start, to catch these regressions before they write any data.
+	//
+	// AllIslands must be between 1 and 65535, otherwise an InvalidArgument error is returned.
+	VerifyIslandMapping(ctx context.Context, in *VerifyIslandMappingRequest, opts ...grpc.CallOption) (*VerifyIslandMappingResponse, error)
+	// RestorePointInTime is an admin RPC that restores the swamps of the server to their state at a point in time.
+	//
+	// ⏪ The server takes the newest complete backup before the point in time, and replays its write-ahead log from
+	// the backup to the point in time. The restored swamps are written back to the server: the changed treasures are
+	// replaced, the treasures created after the point in time are deleted. The swamps can be selected by patterns and
+	// islands, the unset selectors restore every swamp. The tenants restore only their own swamps.
+	//
+	// ⚠️ The restore is not atomic, the swamps are restored one by one while the server is serving the clients.
+	//
+	// If the scheduled backups or the write-ahead log are not enabled, a FailedPrecondition error with
+	// POINT_IN_TIME_RESTORE_DISABLED reason is returned. If the backups and the write-ahead log do not cover the point
+	// in time, a FailedPrecondition error with POINT_IN_TIME_NOT_COVERED reason is returned.
+	RestorePointInTime(ctx context.Context, in *RestorePointInTimeRequest, opts ...grpc.CallOption) (*RestorePointInTimeResponse, error)
+	// GetClusterTopology returns the servers of the cluster and the ranges of their islands.
+	//
+	// 🌐 The clients can connect to any server as a seed, and discover all servers of the cluster from its answer,
+	// instead of configuring every server and island range in every client. The topology is read from the topology
+	// file of the server, the same file on every server. The Version changes with every change of the file, so the
+	// clients refresh their connections only if it changed.
+	//
+	// If the server has no valid topology file, a FailedPrecondition error with CLUSTER_TOPOLOGY_NOT_CONFIGURED reason
+	// is returned.
+	GetClusterTopology(ctx context.Context, in *GetClusterTopologyRequest, opts ...grpc.CallOption) (*GetClusterTopologyResponse, error)
+	// SetClusterTopology replaces the topology file of the server, if its version is still the ExpectedVersion.
+	//
+	// The migration of the Islands updates the topology of every server with it, so the clients follow the moved
+	// Islands without a restart. If the topology changed meanwhile, an Aborted error with TOPOLOGY_CONFLICT reason is
+	// returned, and the file is not changed. If the server has no topology file configured, a FailedPrecondition error
+	// with CLUSTER_TOPOLOGY_NOT_CONFIGURED reason is returned.
+	SetClusterTopology(ctx context.Context, in *SetClusterTopologyRequest, opts ...grpc.CallOption) (*SetClusterTopologyResponse, error)
+	// SetIslandState sets the migration state of an Island on the server.
+	//
+	// 🚚 The Islands are moved between the servers without downtime, one by one:
+	//   1. FROZEN: the writes of the Island are refused with an Unavailable error with ISLAND_MIGRATING reason, which
+	//      the clients retry, while the reads are still served.
+	//   2. ExportIsland streams the files of the Island to the ImportIsland of the new server.
+	//   3. SetClusterTopology routes the Island to the new server on every server.
+	//   4. MOVED: every request of the Island is refused with a FailedPrecondition error with ISLAND_MOVED reason and
+	//      the new host, so the clients refresh their topology.
+	//
+	// ACTIVE serves the Island again, e.g. if the migration is aborted. The state is kept over the restarts. The
+	// migration is not available for the tenants, a FailedPrecondition error with ISLAND_MIGRATION_DISABLED reason is
+	// returned to them.
+	SetIslandState(ctx context.Context, in *SetIslandStateRequest, opts ...grpc.CallOption) (*SetIslandStateResponse, error)
+	// ExportIsland streams the archive of the Swamps and the blobs of a FROZEN Island. The last message carries the
+	// manifest of the archive.
+	ExportIsland(ctx context.Context, in *ExportIslandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportIslandResponse], error)
+	// ImportIsland streams the archive of an ExportIsland into the server. The files are verified by the manifest of
+	// the last message, and written only if all of them are valid. The Island must have no data on the server, except
+	// if it was MOVED from the server earlier. The Island is ACTIVE after the import.
+	ImportIsland(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportIslandRequest, ImportIslandResponse], error)
+	// ReloadDefaults is an admin RPC that reloads the default swamp settings from the configuration of the server,
+	// without a restart.
+	//
+	// 🔧 The server reads its config file and environment variables again, and applies their defaults section: the
+	// close after idle time, the write interval, the file size and the fsync policy. The swamp patterns registered
+	// after the reload without these settings get the new defaults. If applyToUnregisteredSwamps is set in the
+	// configuration, the swamps without a registered pattern also use the defaults, when they are opened next time.
+	// The swamps already open keep their settings until they are closed, so nothing is rehydrated. The other keys of
+	// the configuration still need a restart. The server reloads the defaults on SIGHUP, too.
+	//
+	// The response contains the defaults in effect after the reload. If the configuration is invalid, a
+	// FailedPrecondition error is returned and the previous defaults stay in effect. The defaults are shared by the
+	// tenants, so the tenants get a FailedPrecondition error with DEFAULTS_RELOAD_DISABLED reason.
+	ReloadDefaults(ctx context.Context, in *ReloadDefaultsRequest, opts ...grpc.CallOption) (*ReloadDefaultsResponse, error)
 }
 
 type hydraideServiceClient struct {
@@ -395,6 +903,26 @@ func (c *hydraideServiceClient) DeRegisterSwamp(ctx context.Context, in *DeRegis
 	return out, nil
 }
 
+func (c *hydraideServiceClient) ListSwampPatterns(ctx context.Context, in *ListSwampPatternsRequest, opts ...grpc.CallOption) (*ListSwampPatternsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSwampPatternsResponse)
+	err := c.cc.Invoke(ctx, HydraideService_ListSwampPatterns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) UpdateSwampPattern(ctx context.Context, in *UpdateSwampPatternRequest, opts ...grpc.CallOption) (*UpdateSwampPatternResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSwampPatternResponse)
+	err := c.cc.Invoke(ctx, HydraideService_UpdateSwampPattern_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hydraideServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetResponse)
@@ -415,6 +943,38 @@ func (c *hydraideServiceClient) Get(ctx context.Context, in *GetRequest, opts ..
 	return out, nil
 }
 
+func (c *hydraideServiceClient) SetLargeValue(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SetLargeValueRequest, SetLargeValueResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[0], HydraideService_SetLargeValue_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SetLargeValueRequest, SetLargeValueResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SetLargeValueClient = grpc.ClientStreamingClient[SetLargeValueRequest, SetLargeValueResponse]
+
+func (c *hydraideServiceClient) GetLargeValue(ctx context.Context, in *GetLargeValueRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetLargeValueResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[1], HydraideService_GetLargeValue_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetLargeValueRequest, GetLargeValueResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_GetLargeValueClient = grpc.ServerStreamingClient[GetLargeValueResponse]
+
 func (c *hydraideServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAllResponse)
@@ -425,6 +985,25 @@ func (c *hydraideServiceClient) GetAll(ctx context.Context, in *GetAllRequest, o
 	return out, nil
 }
 
+func (c *hydraideServiceClient) GetAllStream(ctx context.Context, in *GetAllStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetAllStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[2], HydraideService_GetAllStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAllStreamRequest, GetAllStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_GetAllStreamClient = grpc.ServerStreamingClient[GetAllStreamResponse]
+
 func (c *hydraideServiceClient) GetByIndex(ctx context.Context, in *GetByIndexRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetByIndexResponse)
@@ -435,92 +1014,93 @@ func (c *hydraideServiceClient) GetByIndex(ctx context.Context, in *GetByIndexRe
 	return out, nil
 }
 
-func (c *hydraideServiceClient) ShiftExpiredTreasures(ctx context.Context, in *ShiftExpiredTreasuresRequest, opts ...grpc.CallOption) (*ShiftExpiredTreasuresResponse, error) {
+func (c *hydraideServiceClient) GetTopN(ctx context.Context, in *GetTopNRequest, opts ...grpc.CallOption) (*GetTopNResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ShiftExpiredTreasuresResponse)
-	err := c.cc.Invoke(ctx, HydraideService_ShiftExpiredTreasures_FullMethodName, in, out, cOpts...)
+	out := new(GetTopNResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetTopN_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyResponse, error) {
+func (c *hydraideServiceClient) GetByValue(ctx context.Context, in *GetByValueRequest, opts ...grpc.CallOption) (*GetByValueResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DestroyResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Destroy_FullMethodName, in, out, cOpts...)
+	out := new(GetByValueResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetByValue_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+func (c *hydraideServiceClient) GetByReference(ctx context.Context, in *GetByReferenceRequest, opts ...grpc.CallOption) (*GetByReferenceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Delete_FullMethodName, in, out, cOpts...)
+	out := new(GetByReferenceResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetByReference_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error) {
+func (c *hydraideServiceClient) ShiftExpiredTreasures(ctx context.Context, in *ShiftExpiredTreasuresRequest, opts ...grpc.CallOption) (*ShiftExpiredTreasuresResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CountResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Count_FullMethodName, in, out, cOpts...)
+	out := new(ShiftExpiredTreasuresResponse)
+	err := c.cc.Invoke(ctx, HydraideService_ShiftExpiredTreasures_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IsSwampExist(ctx context.Context, in *IsSwampExistRequest, opts ...grpc.CallOption) (*IsSwampExistResponse, error) {
+func (c *hydraideServiceClient) LeaseExpiredTreasures(ctx context.Context, in *LeaseExpiredTreasuresRequest, opts ...grpc.CallOption) (*LeaseExpiredTreasuresResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IsSwampExistResponse)
-	err := c.cc.Invoke(ctx, HydraideService_IsSwampExist_FullMethodName, in, out, cOpts...)
+	out := new(LeaseExpiredTreasuresResponse)
+	err := c.cc.Invoke(ctx, HydraideService_LeaseExpiredTreasures_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IsKeyExist(ctx context.Context, in *IsKeyExistRequest, opts ...grpc.CallOption) (*IsKeyExistResponse, error) {
+func (c *hydraideServiceClient) AckLease(ctx context.Context, in *AckLeaseRequest, opts ...grpc.CallOption) (*AckLeaseResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IsKeyExistResponse)
-	err := c.cc.Invoke(ctx, HydraideService_IsKeyExist_FullMethodName, in, out, cOpts...)
+	out := new(AckLeaseResponse)
+	err := c.cc.Invoke(ctx, HydraideService_AckLease_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) SubscribeToEvents(ctx context.Context, in *SubscribeToEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToEventsResponse], error) {
+func (c *hydraideServiceClient) NackLease(ctx context.Context, in *NackLeaseRequest, opts ...grpc.CallOption) (*NackLeaseResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[0], HydraideService_SubscribeToEvents_FullMethodName, cOpts...)
+	out := new(NackLeaseResponse)
+	err := c.cc.Invoke(ctx, HydraideService_NackLease_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[SubscribeToEventsRequest, SubscribeToEventsResponse]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DestroyResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Destroy_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type HydraideService_SubscribeToEventsClient = grpc.ServerStreamingClient[SubscribeToEventsResponse]
-
-func (c *hydraideServiceClient) SubscribeToInfo(ctx context.Context, in *SubscribeToInfoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToInfoResponse], error) {
+func (c *hydraideServiceClient) DestroyMany(ctx context.Context, in *DestroyManyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DestroyManyResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[1], HydraideService_SubscribeToInfo_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[3], HydraideService_DestroyMany_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[SubscribeToInfoRequest, SubscribeToInfoResponse]{ClientStream: stream}
+	x := &grpc.GenericClientStream[DestroyManyRequest, DestroyManyResponse]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -531,116 +1111,331 @@ func (c *hydraideServiceClient) SubscribeToInfo(ctx context.Context, in *Subscri
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type HydraideService_SubscribeToInfoClient = grpc.ServerStreamingClient[SubscribeToInfoResponse]
+type HydraideService_DestroyManyClient = grpc.ServerStreamingClient[DestroyManyResponse]
 
-func (c *hydraideServiceClient) Uint32SlicePush(ctx context.Context, in *AddToUint32SlicePushRequest, opts ...grpc.CallOption) (*AddToUint32SlicePushResponse, error) {
+func (c *hydraideServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AddToUint32SlicePushResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Uint32SlicePush_FullMethodName, in, out, cOpts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Delete_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) Uint32SliceDelete(ctx context.Context, in *Uint32SliceDeleteRequest, opts ...grpc.CallOption) (*Uint32SliceDeleteResponse, error) {
+func (c *hydraideServiceClient) ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Uint32SliceDeleteResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceDelete_FullMethodName, in, out, cOpts...)
+	out := new(ListDeletedResponse)
+	err := c.cc.Invoke(ctx, HydraideService_ListDeleted_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) Uint32SliceSize(ctx context.Context, in *Uint32SliceSizeRequest, opts ...grpc.CallOption) (*Uint32SliceSizeResponse, error) {
+func (c *hydraideServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Uint32SliceSizeResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceSize_FullMethodName, in, out, cOpts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Restore_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) Uint32SliceIsValueExist(ctx context.Context, in *Uint32SliceIsValueExistRequest, opts ...grpc.CallOption) (*Uint32SliceIsValueExistResponse, error) {
+func (c *hydraideServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Uint32SliceIsValueExistResponse)
-	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceIsValueExist_FullMethodName, in, out, cOpts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetHistory_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementInt8(ctx context.Context, in *IncrementInt8Request, opts ...grpc.CallOption) (*IncrementInt8Response, error) {
+func (c *hydraideServiceClient) RevertTo(ctx context.Context, in *RevertToRequest, opts ...grpc.CallOption) (*RevertToResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementInt8Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementInt8_FullMethodName, in, out, cOpts...)
+	out := new(RevertToResponse)
+	err := c.cc.Invoke(ctx, HydraideService_RevertTo_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementInt16(ctx context.Context, in *IncrementInt16Request, opts ...grpc.CallOption) (*IncrementInt16Response, error) {
+func (c *hydraideServiceClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementInt16Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementInt16_FullMethodName, in, out, cOpts...)
+	out := new(CountResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Count_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementInt32(ctx context.Context, in *IncrementInt32Request, opts ...grpc.CallOption) (*IncrementInt32Response, error) {
+func (c *hydraideServiceClient) IsSwampExist(ctx context.Context, in *IsSwampExistRequest, opts ...grpc.CallOption) (*IsSwampExistResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementInt32Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementInt32_FullMethodName, in, out, cOpts...)
+	out := new(IsSwampExistResponse)
+	err := c.cc.Invoke(ctx, HydraideService_IsSwampExist_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementInt64(ctx context.Context, in *IncrementInt64Request, opts ...grpc.CallOption) (*IncrementInt64Response, error) {
+func (c *hydraideServiceClient) ExistsMany(ctx context.Context, in *ExistsManyRequest, opts ...grpc.CallOption) (*ExistsManyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementInt64Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementInt64_FullMethodName, in, out, cOpts...)
+	out := new(ExistsManyResponse)
+	err := c.cc.Invoke(ctx, HydraideService_ExistsMany_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementUint8(ctx context.Context, in *IncrementUint8Request, opts ...grpc.CallOption) (*IncrementUint8Response, error) {
+func (c *hydraideServiceClient) IsKeyExist(ctx context.Context, in *IsKeyExistRequest, opts ...grpc.CallOption) (*IsKeyExistResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementUint8Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementUint8_FullMethodName, in, out, cOpts...)
+	out := new(IsKeyExistResponse)
+	err := c.cc.Invoke(ctx, HydraideService_IsKeyExist_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementUint16(ctx context.Context, in *IncrementUint16Request, opts ...grpc.CallOption) (*IncrementUint16Response, error) {
+func (c *hydraideServiceClient) IsKeysExist(ctx context.Context, in *IsKeysExistRequest, opts ...grpc.CallOption) (*IsKeysExistResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementUint16Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementUint16_FullMethodName, in, out, cOpts...)
+	out := new(IsKeysExistResponse)
+	err := c.cc.Invoke(ctx, HydraideService_IsKeysExist_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hydraideServiceClient) IncrementUint32(ctx context.Context, in *IncrementUint32Request, opts ...grpc.CallOption) (*IncrementUint32Response, error) {
+func (c *hydraideServiceClient) SubscribeToEvents(ctx context.Context, in *SubscribeToEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToEventsResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(IncrementUint32Response)
-	err := c.cc.Invoke(ctx, HydraideService_IncrementUint32_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[4], HydraideService_SubscribeToEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[SubscribeToEventsRequest, SubscribeToEventsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SubscribeToEventsClient = grpc.ServerStreamingClient[SubscribeToEventsResponse]
+
+func (c *hydraideServiceClient) SubscribeAll(ctx context.Context, in *SubscribeAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToEventsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[5], HydraideService_SubscribeAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeAllRequest, SubscribeToEventsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SubscribeAllClient = grpc.ServerStreamingClient[SubscribeToEventsResponse]
+
+func (c *hydraideServiceClient) SubscribeToInfo(ctx context.Context, in *SubscribeToInfoRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeToInfoResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[6], HydraideService_SubscribeToInfo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeToInfoRequest, SubscribeToInfoResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SubscribeToInfoClient = grpc.ServerStreamingClient[SubscribeToInfoResponse]
+
+func (c *hydraideServiceClient) Uint32SlicePush(ctx context.Context, in *AddToUint32SlicePushRequest, opts ...grpc.CallOption) (*AddToUint32SlicePushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddToUint32SlicePushResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Uint32SlicePush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Uint32SliceDelete(ctx context.Context, in *Uint32SliceDeleteRequest, opts ...grpc.CallOption) (*Uint32SliceDeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Uint32SliceDeleteResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Uint32SliceSize(ctx context.Context, in *Uint32SliceSizeRequest, opts ...grpc.CallOption) (*Uint32SliceSizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Uint32SliceSizeResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceSize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Uint32SliceIsValueExist(ctx context.Context, in *Uint32SliceIsValueExistRequest, opts ...grpc.CallOption) (*Uint32SliceIsValueExistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Uint32SliceIsValueExistResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceIsValueExist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Uint32SliceGetRange(ctx context.Context, in *Uint32SliceGetRangeRequest, opts ...grpc.CallOption) (*Uint32SliceGetRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Uint32SliceGetRangeResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceGetRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Uint32SliceStream(ctx context.Context, in *Uint32SliceStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Uint32SliceStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[7], HydraideService_Uint32SliceStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Uint32SliceStreamRequest, Uint32SliceStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_Uint32SliceStreamClient = grpc.ServerStreamingClient[Uint32SliceStreamResponse]
+
+func (c *hydraideServiceClient) Uint32SliceCombine(ctx context.Context, in *Uint32SliceCombineRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Uint32SliceStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[8], HydraideService_Uint32SliceCombine_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Uint32SliceCombineRequest, Uint32SliceStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_Uint32SliceCombineClient = grpc.ServerStreamingClient[Uint32SliceStreamResponse]
+
+func (c *hydraideServiceClient) Uint32SliceCombineInto(ctx context.Context, in *Uint32SliceCombineIntoRequest, opts ...grpc.CallOption) (*Uint32SliceCombineIntoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Uint32SliceCombineIntoResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Uint32SliceCombineInto_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementInt8(ctx context.Context, in *IncrementInt8Request, opts ...grpc.CallOption) (*IncrementInt8Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementInt8Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementInt8_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementInt16(ctx context.Context, in *IncrementInt16Request, opts ...grpc.CallOption) (*IncrementInt16Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementInt16Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementInt16_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementInt32(ctx context.Context, in *IncrementInt32Request, opts ...grpc.CallOption) (*IncrementInt32Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementInt32Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementInt32_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementInt64(ctx context.Context, in *IncrementInt64Request, opts ...grpc.CallOption) (*IncrementInt64Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementInt64Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementInt64_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementUint8(ctx context.Context, in *IncrementUint8Request, opts ...grpc.CallOption) (*IncrementUint8Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementUint8Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementUint8_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementUint16(ctx context.Context, in *IncrementUint16Request, opts ...grpc.CallOption) (*IncrementUint16Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementUint16Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementUint16_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) IncrementUint32(ctx context.Context, in *IncrementUint32Request, opts ...grpc.CallOption) (*IncrementUint32Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementUint32Response)
+	err := c.cc.Invoke(ctx, HydraideService_IncrementUint32_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *hydraideServiceClient) IncrementUint64(ctx context.Context, in *IncrementUint64Request, opts ...grpc.CallOption) (*IncrementUint64Response, error) {
@@ -673,127 +1468,507 @@ func (c *hydraideServiceClient) IncrementFloat64(ctx context.Context, in *Increm
 	return out, nil
 }
 
-// HydraideServiceServer is the server API for HydraideService service.
-// All implementations must embed UnimplementedHydraideServiceServer
-// for forward compatibility.
-type HydraideServiceServer interface {
-	// Heartbeat checks if the HydrAIDE server is alive.
-	// The client sends a Ping and expects a Pong response.
-	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
-	// Lock allows you to create a mutex-like lock based on a given key.
-	// It queues all lock requests for the same key and only returns once the lock is granted.
-	// This mechanism enables swamp- or treasure-level locking to coordinate access between microservices.
-	//
-	// ⚠️ Note: Locking is logical only — it does not block the actual data access unless your logic respects the lock.
-	// You must explicitly use the Lock/Unlock sequence around relevant operations.
-	//
-	// Required: TTL must be set (timeout in milliseconds).
-	// If the lock cannot be acquired within this TTL, the call returns an error.
-	// If a lock is granted, a LockID is returned, which must be passed to Unlock.
-	Lock(context.Context, *LockRequest) (*LockResponse, error)
-	// Unlock releases the previously granted lock based on the key and LockID.
-	// If the LockID is invalid or does not match an existing lock, the call fails.
-	//
-	// It is crucial to always unlock your locks to avoid deadlocks.
-	// If the TTL expires, the lock is automatically released.
-	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
-	// RegisterSwamp registers a new swamp pattern in the HydrAIDE system.
-	// A swamp pattern defines the namespace (Sanctuary/Realm/Swamp) and behavior of a storage unit.
-	//
-	// You can configure:
-	// - Whether the swamp lives in memory or on disk
-	// - How long it should stay alive without access (CloseAfterIdle)
-	// - How frequently it should flush to disk (WriteInterval)
-	// - Maximum file size per chunk (MaxFileSize)
-	//
-	// Use this to define behavior per collection before inserting data.
-	RegisterSwamp(context.Context, *RegisterSwampRequest) (*RegisterSwampResponse, error)
-	// DeRegisterSwamp removes a previously registered swamp pattern.
-	// This does not delete the swamp data — it only removes its active configuration.
-	//
-	// Use this to clean up unused swamp definitions.
-	DeRegisterSwamp(context.Context, *DeRegisterSwampRequest) (*DeRegisterSwampResponse, error)
-	// Set inserts or updates one or more key-value pairs into one or more swamps.
-	// You can control the behavior using two flags:
-	// - CreateIfNotExist: If false, the swamp/key must already exist (update only).
-	// - Overwrite: If false, the existing value will not be overwritten.
-	//
-	// ⚠️ Each KeyValuePair supports multiple data types:
-	// - All numeric primitives: int8, int16, int32, int64, uint8, uint16, uint32, uint64
-	// - Floating point types: float32, float64
-	// - Booleans, strings, bytes, and even slices (e.g., uint32[])
-	//
-	// 💡 HydrAIDE stores the value using its **actual underlying type** without upcasting,
-	// which means no wasted memory or disk space – you only store what you really need.
-	//
-	// You can also set metadata like CreatedAt, UpdatedBy, ExpiredAt, etc.
-	//
-	// ✅ Supports multiple swamps in a single request for batch operations.
-	//
-	// 🔔 Realtime: Any inserted or updated treasures will trigger an immediate
-	// event to all active subscribers via the SubscribeToEvents stream.
-	//
-	// This makes HydrAIDE suitable for:
-	// - Real-time dashboards
-	// - Cache invalidation listeners
-	// - Live analytics feeds
-	Set(context.Context, *SetRequest) (*SetResponse, error)
-	// Get retrieves one or more key-value pairs by key from one or more swamps.
-	// You must specify the swamp name and the list of keys per swamp.
+func (c *hydraideServiceClient) SetIfLater(ctx context.Context, in *SetIfLaterRequest, opts ...grpc.CallOption) (*SetIfLaterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIfLaterResponse)
+	err := c.cc.Invoke(ctx, HydraideService_SetIfLater_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) AddDuration(ctx context.Context, in *AddDurationRequest, opts ...grpc.CallOption) (*AddDurationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDurationResponse)
+	err := c.cc.Invoke(ctx, HydraideService_AddDuration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) SetStringIf(ctx context.Context, in *SetStringIfRequest, opts ...grpc.CallOption) (*SetStringIfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetStringIfResponse)
+	err := c.cc.Invoke(ctx, HydraideService_SetStringIf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) SetBoolIf(ctx context.Context, in *SetBoolIfRequest, opts ...grpc.CallOption) (*SetBoolIfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBoolIfResponse)
+	err := c.cc.Invoke(ctx, HydraideService_SetBoolIf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) SetSwampAnnotation(ctx context.Context, in *SetSwampAnnotationRequest, opts ...grpc.CallOption) (*SetSwampAnnotationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetSwampAnnotationResponse)
+	err := c.cc.Invoke(ctx, HydraideService_SetSwampAnnotation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) GetSwampAnnotations(ctx context.Context, in *GetSwampAnnotationsRequest, opts ...grpc.CallOption) (*GetSwampAnnotationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSwampAnnotationsResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetSwampAnnotations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) Aggregate(ctx context.Context, in *AggregateRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, HydraideService_Aggregate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) ListCorruptedFiles(ctx context.Context, in *ListCorruptedFilesRequest, opts ...grpc.CallOption) (*ListCorruptedFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCorruptedFilesResponse)
+	err := c.cc.Invoke(ctx, HydraideService_ListCorruptedFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) GetNamespaceUsage(ctx context.Context, in *GetNamespaceUsageRequest, opts ...grpc.CallOption) (*GetNamespaceUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNamespaceUsageResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetNamespaceUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) CompactSwamp(ctx context.Context, in *CompactSwampRequest, opts ...grpc.CallOption) (*CompactSwampResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompactSwampResponse)
+	err := c.cc.Invoke(ctx, HydraideService_CompactSwamp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) PutBlob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PutBlobRequest, PutBlobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[9], HydraideService_PutBlob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PutBlobRequest, PutBlobResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_PutBlobClient = grpc.ClientStreamingClient[PutBlobRequest, PutBlobResponse]
+
+func (c *hydraideServiceClient) GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetBlobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[10], HydraideService_GetBlob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetBlobRequest, GetBlobResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_GetBlobClient = grpc.ServerStreamingClient[GetBlobResponse]
+
+func (c *hydraideServiceClient) RefBlob(ctx context.Context, in *RefBlobRequest, opts ...grpc.CallOption) (*RefBlobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefBlobResponse)
+	err := c.cc.Invoke(ctx, HydraideService_RefBlob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) CollectBlobGarbage(ctx context.Context, in *CollectBlobGarbageRequest, opts ...grpc.CallOption) (*CollectBlobGarbageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectBlobGarbageResponse)
+	err := c.cc.Invoke(ctx, HydraideService_CollectBlobGarbage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, HydraideService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) VerifyIslandMapping(ctx context.Context, in *VerifyIslandMappingRequest, opts ...grpc.CallOption) (*VerifyIslandMappingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyIslandMappingResponse)
+	err := c.cc.Invoke(ctx, HydraideService_VerifyIslandMapping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) RestorePointInTime(ctx context.Context, in *RestorePointInTimeRequest, opts ...grpc.CallOption) (*RestorePointInTimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestorePointInTimeResponse)
+	err := c.cc.Invoke(ctx, HydraideService_RestorePointInTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) GetClusterTopology(ctx context.Context, in *GetClusterTopologyRequest, opts ...grpc.CallOption) (*GetClusterTopologyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetClusterTopologyResponse)
+	err := c.cc.Invoke(ctx, HydraideService_GetClusterTopology_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) SetClusterTopology(ctx context.Context, in *SetClusterTopologyRequest, opts ...grpc.CallOption) (*SetClusterTopologyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetClusterTopologyResponse)
+	err := c.cc.Invoke(ctx, HydraideService_SetClusterTopology_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) SetIslandState(ctx context.Context, in *SetIslandStateRequest, opts ...grpc.CallOption) (*SetIslandStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIslandStateResponse)
+	err := c.cc.Invoke(ctx, HydraideService_SetIslandState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraideServiceClient) ExportIsland(ctx context.Context, in *ExportIslandRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportIslandResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[11], HydraideService_ExportIsland_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportIslandRequest, ExportIslandResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_ExportIslandClient = grpc.ServerStreamingClient[ExportIslandResponse]
+
+func (c *hydraideServiceClient) ImportIsland(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportIslandRequest, ImportIslandResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HydraideService_ServiceDesc.Streams[12], HydraideService_ImportIsland_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportIslandRequest, ImportIslandResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_ImportIslandClient = grpc.ClientStreamingClient[ImportIslandRequest, ImportIslandResponse]
+
+func (c *hydraideServiceClient) ReloadDefaults(ctx context.Context, in *ReloadDefaultsRequest, opts ...grpc.CallOption) (*ReloadDefaultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadDefaultsResponse)
+	err := c.cc.Invoke(ctx, HydraideService_ReloadDefaults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HydraideServiceServer is the server API for HydraideService service.
+// All implementations must embed UnimplementedHydraideServiceServer
+// for forward compatibility.
+type HydraideServiceServer interface {
+	// Heartbeat checks if the HydrAIDE server is alive.
+	// The client sends a Ping and expects a Pong response.
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// Lock allows you to create a mutex-like lock based on a given key.
+	// It queues all lock requests for the same key and only returns once the lock is granted.
+	// This mechanism enables swamp- or treasure-level locking to coordinate access between microservices.
 	//
-	// Each response includes the key, value, and metadata fields (timestamps, creators).
-	// This is a type-safe, structured read – no JSON parsing needed on the client side.
-	Get(context.Context, *GetRequest) (*GetResponse, error)
-	// GetAll retrieves **all** key-value pairs from a given swamp.
+	// ⚠️ Note: Locking is logical only — it does not block the actual data access unless your logic respects the lock.
+	// You must explicitly use the Lock/Unlock sequence around relevant operations.
 	//
-	// This is useful for debug purposes, cache rebuilding, or initial snapshot creation.
-	// Not recommended for large datasets unless scoped and filtered client-side.
-	GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error)
-	// GetByIndex allows indexed reads based on fields like:
-	// - ExpirationTime
-	// - CreationTime
-	// - Value types (e.g., VALUE_STRING, VALUE_INT32, etc.)
+	// Required: TTL must be set (timeout in milliseconds).
+	// If the lock cannot be acquired within this TTL, the call returns an error.
+	// If a lock is granted, a LockID is returned, which must be passed to Unlock.
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	// Unlock releases the previously granted lock based on the key and LockID.
+	// If the LockID is invalid or does not match an existing lock, the call fails.
 	//
-	// You can specify the index type, sorting order (ASC/DESC), and a range using From/Limit.
-	// This makes the HydrAIDE engine powerful for search, feed, and queue-like structures.
+	// It is crucial to always unlock your locks to avoid deadlocks.
+	// If the TTL expires, the lock is automatically released.
+	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
+	// RegisterSwamp registers a new swamp pattern in the HydrAIDE system.
+	// A swamp pattern defines the namespace (Sanctuary/Realm/Swamp) and behavior of a storage unit.
 	//
-	// ⚠️ HydrAIDE creates index structures on-demand in memory when this method is called.
-	// These indexes are **not persisted**, and are **discarded when the swamp is closed** due to inactivity.
-	// This means indexing is stateless, fast, and leaves no storage overhead.
+	// You can configure:
+	// - Whether the swamp lives in memory or on disk
+	// - How long it should stay alive without access (CloseAfterIdle)
+	// - How frequently it should flush to disk (WriteInterval)
+	// - Maximum file size per chunk (MaxFileSize)
 	//
-	// You do not need to pre-define indexes. Simply call this method with the right IndexType.
-	GetByIndex(context.Context, *GetByIndexRequest) (*GetByIndexResponse, error)
-	// ShiftExpiredTreasures retrieves and deletes expired treasures from a given swamp.
+	// Use this to define behavior per collection before inserting data.
 	//
-	// This method is ideal for implementing task queues, time-based processing systems,
-	// or scheduled cleanup mechanisms where only expired items should be processed.
+	// ⚠️ If a registered pattern overlaps with the new one, i.e. a swamp can match both, and it has different memory
+	// or filesystem settings (IsInMemorySwamp, CloseAfterIdle, WriteInterval, MaxFileSize, Fsync, FsyncInterval), the
+	// registration is rejected with an AlreadyExists error with PATTERN_CONFLICT reason, and the registered pattern
+	// is attached to the error as a SwampPattern detail. Set Force to register the pattern anyway, e.g. when the
+	// settings of a pattern are changed on purpose. The server records which client registered each pattern, see
+	// ListSwampPatterns.
+	RegisterSwamp(context.Context, *RegisterSwampRequest) (*RegisterSwampResponse, error)
+	// DeRegisterSwamp removes a previously registered swamp pattern.
+	// By default, this does not delete the swamp data — it only removes its active configuration.
 	//
-	// You can control how many items to retrieve at once using the `HowMany` parameter:
-	// - If `HowMany` is 0, all expired treasures will be returned (use with caution on large swamps)
-	// - If `HowMany` > 0, only that many expired entries will be returned in the response
+	// Set Purge to destroy the swamps matching the pattern with their files first, and DryRun to only list the
+	// swamps a purge would destroy. The swamps are destroyed before the pattern is removed, so a failed purge can be
+	// retried with the same request.
 	//
-	// ⚠️ Important: This method is destructive – the returned items will be permanently removed from the swamp.
+	// Use this to clean up unused swamp definitions.
+	DeRegisterSwamp(context.Context, *DeRegisterSwampRequest) (*DeRegisterSwampResponse, error)
+	// ListSwampPatterns lists the swamp patterns registered on the server, with their settings.
 	//
-	// 🔔 Realtime: All subscribers to the swamp will receive an immediate
-	// notification about the deleted treasures via the SubscribeToEvents stream.
+	// The settings are the ones in effect: the values left unset at the registration show the defaults of the
+	// server at the time of the registration. The patterns are sorted by their names.
 	//
-	// Use this for building things like:
-	// - Delayed jobs
-	// - Expiring caches
-	// - Scheduled triggers (e.g. publish-after-expiry)
-	ShiftExpiredTreasures(context.Context, *ShiftExpiredTreasuresRequest) (*ShiftExpiredTreasuresResponse, error)
-	// Destroy permanently deletes the entire swamp and all its treasures.
+	// Use this to see how the swamps of a pattern are kept in memory and written to the disk.
+	ListSwampPatterns(context.Context, *ListSwampPatternsRequest) (*ListSwampPatternsResponse, error)
+	// UpdateSwampPattern changes the CloseAfterIdle, WriteInterval and MaxFileSize of a registered swamp pattern,
+	// and keeps its other settings.
 	//
-	// This removes all data associated with the given swamp, including metadata, indexes, and chunks.
+	// The fields left unset are not changed. The change is saved like a registration, and applies to the swamps
+	// opened after it: the open swamps keep their settings until they are closed, so nothing is rehydrated.
+	// A pattern that is not registered returns a NotFound error with SWAMP_PATTERN_NOT_FOUND reason. The WriteInterval
+	// and the MaxFileSize of an in-memory pattern can not be set.
 	//
-	// ⚠️ Warning: This operation is irreversible. All data will be lost.
-	// Use only when you're absolutely sure the swamp is no longer needed.
+	// Use this to tune a pattern without redeploying the clients that register it. ⚠️ A client that registers the
+	// pattern again with other settings gets a PATTERN_CONFLICT error, unless it forces the registration, which
+	// overwrites the change.
+	UpdateSwampPattern(context.Context, *UpdateSwampPatternRequest) (*UpdateSwampPatternResponse, error)
+	// Set inserts or updates one or more key-value pairs into one or more swamps.
+	// You can control the behavior using two flags:
+	// - CreateIfNotExist: If false, the swamp/key must already exist (update only).
+	// - Overwrite: If false, the existing value will not be overwritten.
 	//
-	// Typical use cases:
-	// - Cleanup after tests or temporary data
+	// ⚠️ Each KeyValuePair supports multiple data types:
+	// - All numeric primitives: int8, int16, int32, int64, uint8, uint16, uint32, uint64
+	// - Floating point types: float32, float64
+	// - Booleans, strings, bytes, and even slices (e.g., uint32[])
+	//
+	// 💡 HydrAIDE stores the value using its **actual underlying type** without upcasting,
+	// which means no wasted memory or disk space – you only store what you really need.
+	//
+	// You can also set metadata like CreatedAt, UpdatedBy, ExpiredAt, etc.
+	//
+	// ✅ Supports multiple swamps in a single request for batch operations.
+	//
+	// 🔔 Realtime: Any inserted or updated treasures will trigger an immediate
+	// event to all active subscribers via the SubscribeToEvents stream.
+	//
+	// This makes HydrAIDE suitable for:
+	// - Real-time dashboards
+	// - Cache invalidation listeners
+	// - Live analytics feeds
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	// Get retrieves one or more key-value pairs by key from one or more swamps.
+	// You must specify the swamp name and the list of keys per swamp.
+	//
+	// Each response includes the key, value, and metadata fields (timestamps, creators).
+	// This is a type-safe, structured read – no JSON parsing needed on the client side.
+	//
+	// 💡 If the pattern of the swamp is registered with LazyHydration, and the swamp is not in the memory, only the
+	// chunk files of the requested keys are read, without loading the swamp.
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// SetLargeValue writes one treasure whose bytes value is too large for one gRPC message.
+	//
+	// The client streams the value in chunks, and the server reassembles it before the treasure is written
+	// with the same semantics as Set. The first message carries the swamp, the flags and the KeyValuePair
+	// without the bytes value, every message can carry the next chunk of the value.
+	//
+	// 💡 The SDKs use it transparently if a single bytes value (e.g. a GOB-encoded struct) would not fit
+	// into the max message size.
+	//
+	// ⚠️ The server holds the whole value in memory while it is reassembled and written.
+	SetLargeValue(grpc.ClientStreamingServer[SetLargeValueRequest, SetLargeValueResponse]) error
+	// GetLargeValue reads one treasure whose bytes value is too large for one gRPC message.
+	//
+	// The first message of the stream carries the treasure without the bytes value, the next messages
+	// carry the chunks of the value in order. If the treasure does not exist, the stream has only the
+	// first message with IsExist = false.
+	GetLargeValue(*GetLargeValueRequest, grpc.ServerStreamingServer[GetLargeValueResponse]) error
+	// GetAll retrieves **all** key-value pairs from a given swamp.
+	//
+	// This is useful for debug purposes, cache rebuilding, or initial snapshot creation.
+	// Not recommended for large datasets unless scoped and filtered client-side.
+	GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error)
+	// GetAllStream streams all treasures of a given swamp in batches.
+	//
+	// Unlike GetAll, neither the server nor the client holds the whole swamp in one message,
+	// so it can read huge swamps with constant memory. The treasures come in no particular order.
+	// Use GetByIndex if the treasures must be ordered.
+	GetAllStream(*GetAllStreamRequest, grpc.ServerStreamingServer[GetAllStreamResponse]) error
+	// GetByIndex allows indexed reads based on fields like:
+	// - ExpirationTime
+	// - CreationTime
+	// - Value types (e.g., VALUE_STRING, VALUE_INT32, etc.)
+	//
+	// You can specify the index type, sorting order (ASC/DESC), and a range using From/Limit.
+	// This makes the HydrAIDE engine powerful for search, feed, and queue-like structures.
+	//
+	// ⚠️ HydrAIDE creates index structures on-demand in memory when this method is called.
+	// These indexes are **not persisted**, and are **discarded when the swamp is closed** due to inactivity.
+	// This means indexing is stateless, fast, and leaves no storage overhead.
+	//
+	// You do not need to pre-define indexes. Simply call this method with the right IndexType.
+	GetByIndex(context.Context, *GetByIndexRequest) (*GetByIndexResponse, error)
+	// GetTopN returns the first N treasures of an index, e.g. the top 100 scores of a leaderboard.
+	//
+	// The treasures are read directly from the in-memory index, so the answer costs O(N) of the result
+	// instead of reading the whole index range with From and Limit.
+	//
+	// For the VALUE_* indexes only the treasures holding the type of the index are returned.
+	// If the index can not be built because the swamp holds values of another type,
+	// a FailedPrecondition error with WRONG_VALUE_TYPE reason is returned.
+	GetTopN(context.Context, *GetTopNRequest) (*GetTopNResponse, error)
+	// GetByValue returns all treasures of a swamp whose value equals the given value.
+	//
+	// The lookup uses the secondary value index of the swamp, so it does not scan the swamp.
+	// The value index must be enabled for the swamp pattern with the `ValueIndex` flag of RegisterSwamp,
+	// otherwise the request fails with FailedPrecondition.
+	//
+	// ⚠️ The value index lives only in memory. It is built at the first GetByValue call and maintained
+	// incrementally by every write and delete until the swamp is closed.
+	//
+	// Use this for lookups like:
+	// - Find the user keys registered with a given email address
+	// - Find all tasks in a given state
+	GetByValue(context.Context, *GetByValueRequest) (*GetByValueResponse, error)
+	// GetByReference returns the treasures referenced by the IDs of a uint32 slice, in one roundtrip.
+	//
+	// The uint32 slice is read from the given swamp and key, and every ID in it is resolved to the
+	// treasure of the target swamp whose key is the TargetKeyPrefix followed by the decimal ID.
+	//
+	// ⚠️ The target swamp must be served by the same server as the slice swamp, because the server
+	// resolves the references locally. The SDK falls back to two reads if they are on different servers.
+	//
+	// Use this for joins like:
+	// - Read the products of a tag, where the tag holds the IDs of its products in a uint32 slice
+	// - Read the users who viewed a product
+	GetByReference(context.Context, *GetByReferenceRequest) (*GetByReferenceResponse, error)
+	// ShiftExpiredTreasures retrieves and deletes expired treasures from a given swamp.
+	//
+	// This method is ideal for implementing task queues, time-based processing systems,
+	// or scheduled cleanup mechanisms where only expired items should be processed.
+	//
+	// You can control how many items to retrieve at once using the `HowMany` parameter:
+	// - If `HowMany` is 0, all expired treasures will be returned (use with caution on large swamps)
+	// - If `HowMany` > 0, only that many expired entries will be returned in the response
+	//
+	// ⚠️ Important: This method is destructive – the returned items will be permanently removed from the swamp.
+	//
+	// 🔔 Realtime: All subscribers to the swamp will receive an immediate
+	// notification about the deleted treasures via the SubscribeToEvents stream.
+	//
+	// Use this for building things like:
+	// - Delayed jobs
+	// - Expiring caches
+	// - Scheduled triggers (e.g. publish-after-expiry)
+	ShiftExpiredTreasures(context.Context, *ShiftExpiredTreasuresRequest) (*ShiftExpiredTreasuresResponse, error)
+	// LeaseExpiredTreasures retrieves the expired treasures of a swamp like ShiftExpiredTreasures, but instead of
+	// deleting them, it hides them for the lease time.
+	//
+	// Every returned treasure has a LeaseID, and the consumer must end the lease after the processing:
+	// - AckLease deletes the treasure, after a successful processing
+	// - NackLease makes the treasure visible again, after a failed processing
+	//
+	// 🔁 If the consumer crashes, the treasure becomes visible again when the lease expires, so no item is lost.
+	// The Deliveries of the treasure counts how many times it was leased, so the repeatedly failing items can be
+	// moved to a dead-letter swamp by the client.
+	//
+	// ⚠️ The delivery counters are kept in the memory of the server, so they start from zero after a restart.
+	LeaseExpiredTreasures(context.Context, *LeaseExpiredTreasuresRequest) (*LeaseExpiredTreasuresResponse, error)
+	// AckLease deletes a leased treasure after its successful processing.
+	//
+	// Fails with the LEASE_NOT_FOUND reason if the treasure does not exist, or it was leased again by an other
+	// consumer after the lease expired.
+	AckLease(context.Context, *AckLeaseRequest) (*AckLeaseResponse, error)
+	// NackLease gives back a leased treasure after a failed processing. The treasure becomes visible again after
+	// the RetryAfter time, and its delivery counter is kept.
+	//
+	// Fails with the LEASE_NOT_FOUND reason like AckLease.
+	NackLease(context.Context, *NackLeaseRequest) (*NackLeaseResponse, error)
+	// Destroy permanently deletes the entire swamp and all its treasures.
+	//
+	// This removes all data associated with the given swamp, including metadata, indexes, and chunks.
+	//
+	// ⚠️ Warning: This operation is irreversible. All data will be lost.
+	// Use only when you're absolutely sure the swamp is no longer needed.
+	//
+	// Typical use cases:
+	// - Cleanup after tests or temporary data
 	// - User data deletion requests
 	// - Full environment reset
 	Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error)
+	// DestroyMany permanently deletes every swamp of the server matching a wildcard pattern, with all their treasures.
+	//
+	// The ConfirmationToken of the request must be the SwampPattern itself, so a pattern built by mistake, e.g. with
+	// an empty realm, is never destroyed. One response is streamed for each destroyed swamp, so the caller can follow
+	// the progress of a large teardown. If the stream is broken, the remaining swamps are not destroyed, and the same
+	// request can be sent again.
+	//
+	// ⚠️ Warning: This operation is irreversible, like Destroy.
+	//
+	// Typical use cases:
+	// - Tearing down all swamps of a tenant
+	// - Cleanup after a migration
+	DestroyMany(*DestroyManyRequest, grpc.ServerStreamingServer[DestroyManyResponse]) error
 	// Delete removes one or more treasures (key-value pairs) from the specified swamp.
 	//
 	// This is a precise, non-destructive swamp-level operation.
@@ -807,9 +1982,40 @@ type HydraideServiceServer interface {
 	//
 	// Use this to:
 	// - Manually remove outdated or invalid entries
-	// - Implement "soft delete" logic (client-side)
+	// - Implement "soft delete" logic with the ShadowDelete flag
 	// - Perform targeted cleanup operations
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// ListDeleted returns the shadow-deleted treasures of a swamp, ordered by their deletion time.
+	//
+	// The shadow-deleted treasures keep their content and metadata, but they are invisible for all other
+	// operations, until they are restored with Restore, or replaced by a new treasure with the same key.
+	//
+	// Use this to:
+	// - Audit the deletions of a swamp
+	// - Find the treasures to restore after an accidental deletion
+	ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error)
+	// Restore puts shadow-deleted treasures back to their swamp, with the content they had before the deletion.
+	//
+	// 🔔 Realtime: the subscribers receive the restored treasures as new treasures.
+	//
+	// The status of a key is NOT_FOUND if there is no shadow-deleted treasure with the key.
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	// GetHistory returns the stored versions of a treasure, oldest first.
+	//
+	// The history is kept only if the swamp pattern is registered with HistoryDepth. Every version contains the
+	// content of the treasure as it was saved, the Version number, the time of the save in UpdatedAt, and the
+	// creator or the modifier of the version in UpdatedBy.
+	//
+	// Use this to:
+	// - Audit the changes of a treasure
+	// - Show the earlier states of a document
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// RevertTo sets the content of a treasure back to a version of its history.
+	//
+	// The revert is saved as a new version, so the versions after the reverted one are kept in the history.
+	//
+	// 🔔 Realtime: the subscribers receive the reverted treasure as a modified treasure.
+	RevertTo(context.Context, *RevertToRequest) (*RevertToResponse, error)
 	// Count returns the number of treasures in one or more specified swamps.
 	//
 	// You provide a list of swamp names, and for each swamp HydrAIDE returns:
@@ -824,6 +2030,10 @@ type HydraideServiceServer interface {
 	// 💡 The count is calculated instantly from the swamp’s current state,
 	// with no need for full traversal or manual iteration.
 	//
+	// 🔍 An optional filter counts only the expired treasures, the keys with a prefix, or the treasures updated since
+	// a timestamp, e.g. "how many jobs are overdue", without shifting them or streaming the whole index.
+	// The filtered count walks the treasures of the swamp on the server.
+	//
 	// Can be used across multiple swamps in a single request.
 	Count(context.Context, *CountRequest) (*CountResponse, error)
 	// IsSwampExist checks if the specified swamp exists in the given sanctuary.
@@ -838,6 +2048,21 @@ type HydraideServiceServer interface {
 	//
 	// 💡 Note: Swamp existence does not guarantee any treasures inside – it's purely structural.
 	IsSwampExist(context.Context, *IsSwampExistRequest) (*IsSwampExistResponse, error)
+	// ExistsMany checks the existence of many swamps in a single request.
+	//
+	// Every requested item is either an exact swamp name or a wildcard pattern, where the
+	// realm and/or the swamp part of the name is "*" (e.g. "domains/*/example.com").
+	// For every item HydrAIDE returns the names of the existing swamps matching it:
+	// - For an exact name: the name itself if the swamp exists, otherwise nothing
+	// - For a wildcard pattern: all existing swamps on this server that match the pattern
+	//
+	// ✅ This is useful for:
+	// - Rendering existence badges for hundreds of items in a UI with one roundtrip
+	// - Discovering the swamps of a sanctuary or realm for admin tooling
+	//
+	// ⚠️ Wildcard patterns are resolved by walking all island folders of the server, so they are
+	// much more expensive than exact names. Use them for admin and UI purposes, not in hot paths.
+	ExistsMany(context.Context, *ExistsManyRequest) (*ExistsManyResponse, error)
 	// IsKeyExist checks whether a specific key exists in a given swamp.
 	//
 	// This allows you to verify the presence of a treasure without retrieving its content.
@@ -848,7 +2073,23 @@ type HydraideServiceServer interface {
 	// - TTL-aware logic (e.g. only update if key is still present)
 	//
 	// 💡 Note: The value is not returned – only a boolean indicator of existence.
+	//
+	// 💡 If the pattern of the swamp is registered with KeyFilter, and the swamp is not in the memory, a missing key is
+	// usually answered from the bloom filter of the swamp, without loading the swamp.
 	IsKeyExist(context.Context, *IsKeyExistRequest) (*IsKeyExistResponse, error)
+	// IsKeysExist checks the existence of many keys in a given swamp with a single request.
+	//
+	// This is the bulk variant of IsKeyExist. The values are not returned, so checking thousands of
+	// keys costs a single roundtrip and a few bytes per key.
+	//
+	// Use cases include:
+	// - Deduplication pipelines that filter out the already seen candidates in batches
+	// - Checking which items of a list still exist before a batch update
+	//
+	// 💡 The response contains one boolean for every requested key, in the order of the request.
+	//
+	// 💡 With a KeyFilter, the swamp is loaded only if the bloom filter can not exclude all keys, see IsKeyExist.
+	IsKeysExist(context.Context, *IsKeysExistRequest) (*IsKeysExistResponse, error)
 	// SubscribeToEvents allows clients to subscribe to **all data changes** within a given swamp.
 	//
 	// When any treasure in the swamp is created, updated, or deleted,
@@ -870,7 +2111,39 @@ type HydraideServiceServer interface {
 	// - Microservice communication via event stream
 	//
 	// 💡 You can use this to completely replace traditional polling or cron-based checks.
+	//
+	// 📸 With IncludeSnapshot, the stream starts with all current treasures of the swamp (status NOTHING_CHANGED),
+	// followed by a message with SnapshotEnd and the ResumeToken. The snapshot and the start of the stream are
+	// atomic: no change is lost or delivered twice between them.
+	//
+	// ⏪ With Since, the stream starts with the events of the swamp after the given time, replayed from the event
+	// journal of the swamp, followed by a message with SnapshotEnd. The journal must be enabled for the pattern of the
+	// swamp in the RegisterSwampRequest. If the journal does not cover the time, the call fails with the
+	// REPLAY_NOT_AVAILABLE reason, and the client should read the whole swamp instead.
+	//
+	// 🚦 The events wait for the client in a bounded queue of BufferSize events. If the client is too slow and the
+	// queue is full, the Overflow policy decides: the writers of the swamp wait (BLOCK, the default), the oldest events
+	// are dropped and a message with DroppedEvents is sent (DROP_OLDEST), or the stream is closed with a
+	// ResourceExhausted error and SUBSCRIBER_OVERFLOW reason (CANCEL).
 	SubscribeToEvents(*SubscribeToEventsRequest, grpc.ServerStreamingServer[SubscribeToEventsResponse]) error
+	// SubscribeAll streams the events of every swamp of the server matching any of the patterns, e.g. to feed a
+	// change data capture (CDC) pipeline of a data warehouse.
+	//
+	// Unlike SubscribeToEvents, the subscription does not hydrate the swamps. A swamp can not change without being
+	// hydrated, so the events of every change of the matching swamps are streamed, both of the swamps in the memory and
+	// of the swamps hydrated later.
+	//
+	// The first message of the stream has SnapshotEnd set and no treasure. It is sent when the subscription is active,
+	// so every change after it is streamed. The SwampName of the events names their swamp, and their Sequence is the
+	// sequence number of the event within its swamp.
+	//
+	// 🌍 The stream covers only the swamps of this server, so the clients must subscribe to every server.
+	//
+	// ⚠️ The events wait for the slow clients in a bounded queue of BufferSize events. By default, if the queue is
+	// full, the stream is closed with a ResourceExhausted error and SUBSCRIBER_OVERFLOW reason, instead of dropping
+	// events silently. The client should resynchronize the matching swamps, and subscribe again. The Overflow policy
+	// can drop the oldest events instead (DROP_OLDEST), or make the writers wait (BLOCK).
+	SubscribeAll(*SubscribeAllRequest, grpc.ServerStreamingServer[SubscribeToEventsResponse]) error
 	// SubscribeToInfo allows clients to subscribe to the **size** of a given swamp.
 	//
 	// Whenever the number of treasures changes in the swamp (insert, delete, expire),
@@ -899,6 +2172,9 @@ type HydraideServiceServer interface {
 	// - Subscriptions, permissions, ID-based tagging
 	// - Memory-efficient, append-only ID lists
 	//
+	// The response counts the added and the already present values of every key, so an indexing job can track
+	// its progress.
+	//
 	// ⚠️ Recommended for cases where the slice size is small to moderate,
 	// as the full slice is returned on each update.
 	Uint32SlicePush(context.Context, *AddToUint32SlicePushRequest) (*AddToUint32SlicePushResponse, error)
@@ -921,6 +2197,40 @@ type HydraideServiceServer interface {
 	// This is useful when you want to validate membership before taking actions,
 	// such as displaying UI states or preventing duplicate logic.
 	Uint32SliceIsValueExist(context.Context, *Uint32SliceIsValueExistRequest) (*Uint32SliceIsValueExistResponse, error)
+	// Uint32SliceGetRange returns a page of the values of the uint32 slice, in the order they were pushed.
+	//
+	// Use it to page through huge slices (e.g. a reverse index with millions of IDs), which do not fit into one
+	// gRPC message. The response includes the size of the whole slice, so the client knows when to stop.
+	//
+	// ⚠️ The pages are read one by one, so a push or a delete between two pages can shift the values.
+	// Use Uint32SliceStream to read a consistent snapshot of the whole slice.
+	Uint32SliceGetRange(context.Context, *Uint32SliceGetRangeRequest) (*Uint32SliceGetRangeResponse, error)
+	// Uint32SliceStream streams all values of the uint32 slice in batches, in the order they were pushed.
+	//
+	// The stream is a snapshot of the slice at the start of the request, so the pushes and the deletes during the
+	// stream do not shift the values. Neither side holds the whole slice in one gRPC message.
+	Uint32SliceStream(*Uint32SliceStreamRequest, grpc.ServerStreamingServer[Uint32SliceStreamResponse]) error
+	// Uint32SliceCombine computes the union, the intersection or the difference of the uint32 slices of many keys of
+	// a swamp, and streams the result back in batches.
+	//
+	// 💡 The slices never leave the server, only the result — e.g. a search engine intersects the reverse indexes of
+	// the words of a query without downloading millions of IDs.
+	//
+	// - UNION: the values of any of the keys, in the order of their first appearance
+	// - INTERSECTION: the values of the first key which are in all other keys, in the order of the first key
+	// - DIFFERENCE: the values of the first key which are in none of the other keys, in the order of the first key
+	//
+	// A missing key is an empty slice. Fails with the WRONG_VALUE_TYPE reason, if a key is not a uint32 slice.
+	Uint32SliceCombine(*Uint32SliceCombineRequest, grpc.ServerStreamingServer[Uint32SliceStreamResponse]) error
+	// Uint32SliceCombineInto computes the same set operations as Uint32SliceCombine, and stores the result in the
+	// destination key of the swamp, instead of streaming it back.
+	//
+	// The destination key is replaced by the result, and deleted if the result is empty, like an emptied slice of
+	// Uint32SliceDelete. The destination key can be one of the combined keys, e.g. to remove the values of other keys
+	// from it.
+	//
+	// 🔔 The change of the destination key triggers an event to the swamp subscribers.
+	Uint32SliceCombineInto(context.Context, *Uint32SliceCombineIntoRequest) (*Uint32SliceCombineIntoResponse, error)
 	// IncrementInt8 increments (or decrements) the value of the key by the specified amount,
 	// if a given condition is satisfied.
 	//
@@ -956,6 +2266,207 @@ type HydraideServiceServer interface {
 	IncrementFloat32(context.Context, *IncrementFloat32Request) (*IncrementFloat32Response, error)
 	// IncrementFloat64 same logic as IncrementInt8 but for float64 values
 	IncrementFloat64(context.Context, *IncrementFloat64Request) (*IncrementFloat64Response, error)
+	// SetIfLater stores the timestamp in the key, if it is later than the stored timestamp, or the key does not exist.
+	//
+	// The stored value is an int64 UNIX timestamp in seconds, like the time.Time values of the SDK models, so the
+	// heartbeats and the "last seen" times can be moved forward without a lock-read-compare-write sequence, and a late
+	// writer never moves them back.
+	//
+	// The response includes the stored timestamp after the request, and whether it was set.
+	// Fails with the WRONG_VALUE_TYPE reason, if the stored value is not an int64.
+	//
+	// 🔔 Realtime: a set timestamp triggers an event to the swamp subscribers, like the increments.
+	SetIfLater(context.Context, *SetIfLaterRequest) (*SetIfLaterResponse, error)
+	// AddDuration adds a duration to the int64 UNIX timestamp (in seconds) stored in the key, e.g. to extend a lease.
+	//
+	// If the key does not exist, the duration is added to the current time of the server. With FromNowIfPast, the
+	// duration is added to the current time also if the stored timestamp is already in the past, so an expired lease
+	// is renewed from now, instead of from its expiration.
+	//
+	// The response includes the new timestamp.
+	// Fails with the WRONG_VALUE_TYPE reason, if the stored value is not an int64.
+	//
+	// 🔔 Realtime: the change triggers an event to the swamp subscribers, like the increments.
+	AddDuration(context.Context, *AddDurationRequest) (*AddDurationResponse, error)
+	// SetStringIf stores the string value in the key, if the condition is satisfied.
+	//
+	// 💡 The compare and the set are atomic, so simple state machines can be enforced by the server, e.g. a status
+	// moves from "pending" to "processing" only once, even if many workers try it at the same time.
+	//
+	// The conditions:
+	// - EQUAL: the key exists, and its stored value is equal to the value of the condition
+	// - NOT_EXISTS: the key does not exist
+	// Without a condition, the value is stored unconditionally.
+	//
+	// The response includes the stored value after the request, and whether the value was set.
+	// Fails with the WRONG_VALUE_TYPE reason, if the stored value is not a string.
+	//
+	// 🔔 Realtime: a set value triggers an event to the swamp subscribers, like the increments.
+	SetStringIf(context.Context, *SetStringIfRequest) (*SetStringIfResponse, error)
+	// SetBoolIf same logic as SetStringIf but for bool values
+	SetBoolIf(context.Context, *SetBoolIfRequest) (*SetBoolIfResponse, error)
+	// SetSwampAnnotation attaches a small key-value annotation to an existing swamp.
+	//
+	// 💡 Annotations are free-form labels owned by the application, for example:
+	// - the owner service of the swamp
+	// - the schema version of the stored models
+	// - the retention class of the data
+	//
+	// An empty Value deletes the annotation.
+	// The annotations are stored in the swamp's metadata and persisted when the swamp is written to disk.
+	//
+	// ⚠️ Limits: at most 64 annotations per swamp, keys up to 128 bytes, values up to 1024 bytes.
+	// The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+	SetSwampAnnotation(context.Context, *SetSwampAnnotationRequest) (*SetSwampAnnotationResponse, error)
+	// GetSwampAnnotations returns all annotations of an existing swamp.
+	GetSwampAnnotations(context.Context, *GetSwampAnnotationsRequest) (*GetSwampAnnotationsResponse, error)
+	// Aggregate computes count, sum, min, max and avg over the numeric values of a swamp on the server.
+	//
+	// Only the aggregates travel over the network, so summing a million int64 values does not mean
+	// pulling a million treasures to the client.
+	//
+	// The treasures can be narrowed with:
+	// - KeyPrefix → only the keys starting with the prefix
+	// - FilterExpr → the same server-side filter expression as GetByIndex
+	// - IndexType, OrderType, From and Limit → an index range, e.g. the latest 100 treasures by creation time
+	//
+	// Treasures without a numeric value (strings, bools, bytes, void, etc.) are skipped and not counted.
+	// The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+	Aggregate(context.Context, *AggregateRequest) (*AggregateResponse, error)
+	// ListCorruptedFiles is an admin RPC that lists the chunk files of the server found corrupted since its start.
+	//
+	// Every chunk file is written with a CRC-32C checksum and verified when the swamp is loaded. A damaged file is
+	// skipped (or fails the loading of its swamp with a DataLoss error and DATA_CORRUPTED reason, if the server does
+	// not skip the corrupted files), and it is listed here until it is overwritten or deleted.
+	//
+	// The list is per server, so the clients must ask every server.
+	ListCorruptedFiles(context.Context, *ListCorruptedFilesRequest) (*ListCorruptedFilesResponse, error)
+	// GetNamespaceUsage is an admin RPC that reports the disk usage, the number of the swamps and the number of the
+	// treasures of the server, grouped by the Sanctuaries and the Realms of the swamps.
+	//
+	// The usage is collected by walking the swamp folders of the islands, without loading the swamps into the memory.
+	// The treasure count of a closed swamp is the count recorded at its last close, the open swamps are counted in the
+	// memory. The swamps closed before the count was recorded are reported as uncounted.
+	//
+	// The walk of a big server takes a while, so the usage is cached for a minute, unless Refresh is set.
+	// The usage is per server, so the clients must ask every server and sum the results.
+	GetNamespaceUsage(context.Context, *GetNamespaceUsageRequest) (*GetNamespaceUsageResponse, error)
+	// CompactSwamp is an admin RPC that rewrites the chunk files of a swamp that are mostly dead data.
+	//
+	// The deleted treasures stay in their chunk files as tombstones, so after heavy delete churn the swamp folder
+	// accumulates chunks with only a few live treasures. They waste disk space and slow down the hydration of the swamp.
+	// The compaction rewrites the live treasures of the chunks, where the ratio of the live data is below MinLiveRatio,
+	// into as few new chunks as possible, and deletes the old ones.
+	//
+	// The chunk the new treasures are appended to is never compacted.
+	// The in-memory swamps have no chunks, so the compaction does nothing for them.
+	// The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+	CompactSwamp(context.Context, *CompactSwampRequest) (*CompactSwampResponse, error)
+	// PutBlob stores a large binary value once, under the SHA-256 hash of its content, and adds one reference to it.
+	//
+	// 📦 The blobs are content-addressed: storing the same content again, e.g. the same attachment of many treasures
+	// in many swamps, adds only a reference, the content takes the disk space only once. The treasures store the
+	// hash of the blob, and read the content by GetBlob.
+	//
+	// The content is streamed in chunks, so the blob can be larger than the max message size. The first message
+	// carries the IslandID and the expected hash, which is verified by the server.
+	PutBlob(grpc.ClientStreamingServer[PutBlobRequest, PutBlobResponse]) error
+	// GetBlob streams the content of a blob in chunks.
+	// A missing blob returns a NotFound error with BLOB_NOT_FOUND reason.
+	GetBlob(*GetBlobRequest, grpc.ServerStreamingServer[GetBlobResponse]) error
+	// RefBlob adds or removes references of a blob, when a treasure starts or stops referencing it.
+	//
+	// ⚠️ The blobs without references are removed by CollectBlobGarbage, so every PutBlob and every positive RefBlob
+	// should be balanced by a negative RefBlob, when the treasure is deleted or it references an other blob.
+	RefBlob(context.Context, *RefBlobRequest) (*RefBlobResponse, error)
+	// CollectBlobGarbage is an admin RPC that removes the blobs of the server that have no references for at least
+	// MinAgeSeconds. The grace period protects the blobs whose references are being moved from one treasure to an
+	// other one.
+	CollectBlobGarbage(context.Context, *CollectBlobGarbageRequest) (*CollectBlobGarbageResponse, error)
+	// QueryAuditLog is an admin RPC that returns the records of the audit log of the server, the newest first.
+	//
+	// If the audit log is enabled, the server records every mutating request: who sent it (the client ID, the IP
+	// address and the tenant), when, which operation on which swamps and keys, and its result. The values of the
+	// treasures are never recorded. The tenants see only their own records.
+	//
+	// The audit log is per server, so the clients must ask every server.
+	// If the audit log is not enabled, a FailedPrecondition error with AUDIT_LOG_DISABLED reason is returned.
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	// VerifyIslandMapping returns the island the server computes for a swamp name, and the description of the hash
+	// function of the islands.
+	//
+	// 🏝️ The clients compute the islands of the swamps, and the server stores the swamps on the islands sent by the
+	// clients. If an SDK, e.g. an SDK of an other language, computed different islands, its swamps would be scattered
+	// to the wrong islands and servers. The clients can compare their islands with the islands of the server, e.g. in
+	// their tests or at their start, to catch these regressions before they write any data.
+	//
+	// AllIslands must be between 1 and 65535, otherwise an InvalidArgument error is returned.
+	VerifyIslandMapping(context.Context, *VerifyIslandMappingRequest) (*VerifyIslandMappingResponse, error)
+	// RestorePointInTime is an admin RPC that restores the swamps of the server to their state at a point in time.
+	//
+	// ⏪ The server takes the newest complete backup before the point in time, and replays its write-ahead log from
+	// the backup to the point in time. The restored swamps are written back to the server: the changed treasures are
+	// replaced, the treasures created after the point in time are deleted. The swamps can be selected by patterns and
+	// islands, the unset selectors restore every swamp. The tenants restore only their own swamps.
+	//
+	// ⚠️ The restore is not atomic, the swamps are restored one by one while the server is serving the clients.
+	//
+	// If the scheduled backups or the write-ahead log are not enabled, a FailedPrecondition error with
+	// POINT_IN_TIME_RESTORE_DISABLED reason is returned. If the backups and the write-ahead log do not cover the point
+	// in time, a FailedPrecondition error with POINT_IN_TIME_NOT_COVERED reason is returned.
+	RestorePointInTime(context.Context, *RestorePointInTimeRequest) (*RestorePointInTimeResponse, error)
+	// GetClusterTopology returns the servers of the cluster and the ranges of their islands.
+	//
+	// 🌐 The clients can connect to any server as a seed, and discover all servers of the cluster from its answer,
+	// instead of configuring every server and island range in every client. The topology is read from the topology
+	// file of the server, the same file on every server. The Version changes with every change of the file, so the
+	// clients refresh their connections only if it changed.
+	//
+	// If the server has no valid topology file, a FailedPrecondition error with CLUSTER_TOPOLOGY_NOT_CONFIGURED reason
+	// is returned.
+	GetClusterTopology(context.Context, *GetClusterTopologyRequest) (*GetClusterTopologyResponse, error)
+	// SetClusterTopology replaces the topology file of the server, if its version is still the ExpectedVersion.
+	//
+	// The migration of the Islands updates the topology of every server with it, so the clients follow the moved
+	// Islands without a restart. If the topology changed meanwhile, an Aborted error with TOPOLOGY_CONFLICT reason is
+	// returned, and the file is not changed. If the server has no topology file configured, a FailedPrecondition error
+	// with CLUSTER_TOPOLOGY_NOT_CONFIGURED reason is returned.
+	SetClusterTopology(context.Context, *SetClusterTopologyRequest) (*SetClusterTopologyResponse, error)
+	// SetIslandState sets the migration state of an Island on the server.
+	//
+	// 🚚 The Islands are moved between the servers without downtime, one by one:
+	//   1. FROZEN: the writes of the Island are refused with an Unavailable error with ISLAND_MIGRATING reason, which
+	//      the clients retry, while the reads are still served.
+	//   2. ExportIsland streams the files of the Island to the ImportIsland of the new server.
+	//   3. SetClusterTopology routes the Island to the new server on every server.
+	//   4. MOVED: every request of the Island is refused with a FailedPrecondition error with ISLAND_MOVED reason and
+	//      the new host, so the clients refresh their topology.
+	//
+	// ACTIVE serves the Island again, e.g. if the migration is aborted. The state is kept over the restarts. The
+	// migration is not available for the tenants, a FailedPrecondition error with ISLAND_MIGRATION_DISABLED reason is
+	// returned to them.
+	SetIslandState(context.Context, *SetIslandStateRequest) (*SetIslandStateResponse, error)
+	// ExportIsland streams the archive of the Swamps and the blobs of a FROZEN Island. The last message carries the
+	// manifest of the archive.
+	ExportIsland(*ExportIslandRequest, grpc.ServerStreamingServer[ExportIslandResponse]) error
+	// ImportIsland streams the archive of an ExportIsland into the server. The files are verified by the manifest of
+	// the last message, and written only if all of them are valid. The Island must have no data on the server, except
+	// if it was MOVED from the server earlier. The Island is ACTIVE after the import.
+	ImportIsland(grpc.ClientStreamingServer[ImportIslandRequest, ImportIslandResponse]) error
+	// ReloadDefaults is an admin RPC that reloads the default swamp settings from the configuration of the server,
+	// without a restart.
+	//
+	// 🔧 The server reads its config file and environment variables again, and applies their defaults section: the
+	// close after idle time, the write interval, the file size and the fsync policy. The swamp patterns registered
+	// after the reload without these settings get the new defaults. If applyToUnregisteredSwamps is set in the
+	// configuration, the swamps without a registered pattern also use the defaults, when they are opened next time.
+	// The swamps already open keep their settings until they are closed, so nothing is rehydrated. The other keys of
+	// the configuration still need a restart. The server reloads the defaults on SIGHUP, too.
+	//
+	// The response contains the defaults in effect after the reload. If the configuration is invalid, a
+	// FailedPrecondition error is returned and the previous defaults stay in effect. The defaults are shared by the
+	// tenants, so the tenants get a FailedPrecondition error with DEFAULTS_RELOAD_DISABLED reason.
+	ReloadDefaults(context.Context, *ReloadDefaultsRequest) (*ReloadDefaultsResponse, error)
 	mustEmbedUnimplementedHydraideServiceServer()
 }
 
@@ -981,645 +2492,1522 @@ func (UnimplementedHydraideServiceServer) RegisterSwamp(context.Context, *Regist
 func (UnimplementedHydraideServiceServer) DeRegisterSwamp(context.Context, *DeRegisterSwampRequest) (*DeRegisterSwampResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeRegisterSwamp not implemented")
 }
+func (UnimplementedHydraideServiceServer) ListSwampPatterns(context.Context, *ListSwampPatternsRequest) (*ListSwampPatternsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSwampPatterns not implemented")
+}
+func (UnimplementedHydraideServiceServer) UpdateSwampPattern(context.Context, *UpdateSwampPatternRequest) (*UpdateSwampPatternResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSwampPattern not implemented")
+}
 func (UnimplementedHydraideServiceServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
 }
-func (UnimplementedHydraideServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+func (UnimplementedHydraideServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetLargeValue(grpc.ClientStreamingServer[SetLargeValueRequest, SetLargeValueResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SetLargeValue not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetLargeValue(*GetLargeValueRequest, grpc.ServerStreamingServer[GetLargeValueResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GetLargeValue not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetAllStream(*GetAllStreamRequest, grpc.ServerStreamingServer[GetAllStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllStream not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetByIndex(context.Context, *GetByIndexRequest) (*GetByIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByIndex not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetTopN(context.Context, *GetTopNRequest) (*GetTopNResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopN not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetByValue(context.Context, *GetByValueRequest) (*GetByValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByValue not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetByReference(context.Context, *GetByReferenceRequest) (*GetByReferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByReference not implemented")
+}
+func (UnimplementedHydraideServiceServer) ShiftExpiredTreasures(context.Context, *ShiftExpiredTreasuresRequest) (*ShiftExpiredTreasuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShiftExpiredTreasures not implemented")
+}
+func (UnimplementedHydraideServiceServer) LeaseExpiredTreasures(context.Context, *LeaseExpiredTreasuresRequest) (*LeaseExpiredTreasuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaseExpiredTreasures not implemented")
+}
+func (UnimplementedHydraideServiceServer) AckLease(context.Context, *AckLeaseRequest) (*AckLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckLease not implemented")
+}
+func (UnimplementedHydraideServiceServer) NackLease(context.Context, *NackLeaseRequest) (*NackLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NackLease not implemented")
+}
+func (UnimplementedHydraideServiceServer) Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+}
+func (UnimplementedHydraideServiceServer) DestroyMany(*DestroyManyRequest, grpc.ServerStreamingServer[DestroyManyResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DestroyMany not implemented")
+}
+func (UnimplementedHydraideServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedHydraideServiceServer) ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
+func (UnimplementedHydraideServiceServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedHydraideServiceServer) RevertTo(context.Context, *RevertToRequest) (*RevertToResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertTo not implemented")
+}
+func (UnimplementedHydraideServiceServer) Count(context.Context, *CountRequest) (*CountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedHydraideServiceServer) IsSwampExist(context.Context, *IsSwampExistRequest) (*IsSwampExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsSwampExist not implemented")
+}
+func (UnimplementedHydraideServiceServer) ExistsMany(context.Context, *ExistsManyRequest) (*ExistsManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExistsMany not implemented")
+}
+func (UnimplementedHydraideServiceServer) IsKeyExist(context.Context, *IsKeyExistRequest) (*IsKeyExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsKeyExist not implemented")
+}
+func (UnimplementedHydraideServiceServer) IsKeysExist(context.Context, *IsKeysExistRequest) (*IsKeysExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsKeysExist not implemented")
+}
+func (UnimplementedHydraideServiceServer) SubscribeToEvents(*SubscribeToEventsRequest, grpc.ServerStreamingServer[SubscribeToEventsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToEvents not implemented")
+}
+func (UnimplementedHydraideServiceServer) SubscribeAll(*SubscribeAllRequest, grpc.ServerStreamingServer[SubscribeToEventsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAll not implemented")
+}
+func (UnimplementedHydraideServiceServer) SubscribeToInfo(*SubscribeToInfoRequest, grpc.ServerStreamingServer[SubscribeToInfoResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToInfo not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SlicePush(context.Context, *AddToUint32SlicePushRequest) (*AddToUint32SlicePushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uint32SlicePush not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceDelete(context.Context, *Uint32SliceDeleteRequest) (*Uint32SliceDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceDelete not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceSize(context.Context, *Uint32SliceSizeRequest) (*Uint32SliceSizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceSize not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceIsValueExist(context.Context, *Uint32SliceIsValueExistRequest) (*Uint32SliceIsValueExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceIsValueExist not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceGetRange(context.Context, *Uint32SliceGetRangeRequest) (*Uint32SliceGetRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceGetRange not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceStream(*Uint32SliceStreamRequest, grpc.ServerStreamingServer[Uint32SliceStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Uint32SliceStream not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceCombine(*Uint32SliceCombineRequest, grpc.ServerStreamingServer[Uint32SliceStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Uint32SliceCombine not implemented")
+}
+func (UnimplementedHydraideServiceServer) Uint32SliceCombineInto(context.Context, *Uint32SliceCombineIntoRequest) (*Uint32SliceCombineIntoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceCombineInto not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementInt8(context.Context, *IncrementInt8Request) (*IncrementInt8Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt8 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementInt16(context.Context, *IncrementInt16Request) (*IncrementInt16Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt16 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementInt32(context.Context, *IncrementInt32Request) (*IncrementInt32Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt32 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementInt64(context.Context, *IncrementInt64Request) (*IncrementInt64Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt64 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementUint8(context.Context, *IncrementUint8Request) (*IncrementUint8Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint8 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementUint16(context.Context, *IncrementUint16Request) (*IncrementUint16Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint16 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementUint32(context.Context, *IncrementUint32Request) (*IncrementUint32Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint32 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementUint64(context.Context, *IncrementUint64Request) (*IncrementUint64Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint64 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementFloat32(context.Context, *IncrementFloat32Request) (*IncrementFloat32Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementFloat32 not implemented")
+}
+func (UnimplementedHydraideServiceServer) IncrementFloat64(context.Context, *IncrementFloat64Request) (*IncrementFloat64Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrementFloat64 not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetIfLater(context.Context, *SetIfLaterRequest) (*SetIfLaterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIfLater not implemented")
+}
+func (UnimplementedHydraideServiceServer) AddDuration(context.Context, *AddDurationRequest) (*AddDurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDuration not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetStringIf(context.Context, *SetStringIfRequest) (*SetStringIfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStringIf not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetBoolIf(context.Context, *SetBoolIfRequest) (*SetBoolIfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBoolIf not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetSwampAnnotation(context.Context, *SetSwampAnnotationRequest) (*SetSwampAnnotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSwampAnnotation not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetSwampAnnotations(context.Context, *GetSwampAnnotationsRequest) (*GetSwampAnnotationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSwampAnnotations not implemented")
+}
+func (UnimplementedHydraideServiceServer) Aggregate(context.Context, *AggregateRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Aggregate not implemented")
+}
+func (UnimplementedHydraideServiceServer) ListCorruptedFiles(context.Context, *ListCorruptedFilesRequest) (*ListCorruptedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCorruptedFiles not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetNamespaceUsage(context.Context, *GetNamespaceUsageRequest) (*GetNamespaceUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNamespaceUsage not implemented")
+}
+func (UnimplementedHydraideServiceServer) CompactSwamp(context.Context, *CompactSwampRequest) (*CompactSwampResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactSwamp not implemented")
+}
+func (UnimplementedHydraideServiceServer) PutBlob(grpc.ClientStreamingServer[PutBlobRequest, PutBlobResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PutBlob not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetBlob(*GetBlobRequest, grpc.ServerStreamingServer[GetBlobResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlob not implemented")
+}
+func (UnimplementedHydraideServiceServer) RefBlob(context.Context, *RefBlobRequest) (*RefBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefBlob not implemented")
+}
+func (UnimplementedHydraideServiceServer) CollectBlobGarbage(context.Context, *CollectBlobGarbageRequest) (*CollectBlobGarbageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectBlobGarbage not implemented")
+}
+func (UnimplementedHydraideServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedHydraideServiceServer) VerifyIslandMapping(context.Context, *VerifyIslandMappingRequest) (*VerifyIslandMappingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyIslandMapping not implemented")
+}
+func (UnimplementedHydraideServiceServer) RestorePointInTime(context.Context, *RestorePointInTimeRequest) (*RestorePointInTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePointInTime not implemented")
+}
+func (UnimplementedHydraideServiceServer) GetClusterTopology(context.Context, *GetClusterTopologyRequest) (*GetClusterTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterTopology not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetClusterTopology(context.Context, *SetClusterTopologyRequest) (*SetClusterTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClusterTopology not implemented")
+}
+func (UnimplementedHydraideServiceServer) SetIslandState(context.Context, *SetIslandStateRequest) (*SetIslandStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIslandState not implemented")
+}
+func (UnimplementedHydraideServiceServer) ExportIsland(*ExportIslandRequest, grpc.ServerStreamingServer[ExportIslandResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportIsland not implemented")
+}
+func (UnimplementedHydraideServiceServer) ImportIsland(grpc.ClientStreamingServer[ImportIslandRequest, ImportIslandResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportIsland not implemented")
+}
+func (UnimplementedHydraideServiceServer) ReloadDefaults(context.Context, *ReloadDefaultsRequest) (*ReloadDefaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadDefaults not implemented")
+}
+func (UnimplementedHydraideServiceServer) mustEmbedUnimplementedHydraideServiceServer() {}
+func (UnimplementedHydraideServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeHydraideServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HydraideServiceServer will
+// result in compilation errors.
+type UnsafeHydraideServiceServer interface {
+	mustEmbedUnimplementedHydraideServiceServer()
+}
+
+func RegisterHydraideServiceServer(s grpc.ServiceRegistrar, srv HydraideServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHydraideServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HydraideService_ServiceDesc, srv)
+}
+
+func _HydraideService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Lock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Unlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_RegisterSwamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSwampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).RegisterSwamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_RegisterSwamp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).RegisterSwamp(ctx, req.(*RegisterSwampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_DeRegisterSwamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeRegisterSwampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).DeRegisterSwamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_DeRegisterSwamp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).DeRegisterSwamp(ctx, req.(*DeRegisterSwampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_ListSwampPatterns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSwampPatternsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).ListSwampPatterns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_ListSwampPatterns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).ListSwampPatterns(ctx, req.(*ListSwampPatternsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_UpdateSwampPattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSwampPatternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).UpdateSwampPattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_UpdateSwampPattern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).UpdateSwampPattern(ctx, req.(*UpdateSwampPatternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Set_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_SetLargeValue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HydraideServiceServer).SetLargeValue(&grpc.GenericServerStream[SetLargeValueRequest, SetLargeValueResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SetLargeValueServer = grpc.ClientStreamingServer[SetLargeValueRequest, SetLargeValueResponse]
+
+func _HydraideService_GetLargeValue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLargeValueRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).GetLargeValue(m, &grpc.GenericServerStream[GetLargeValueRequest, GetLargeValueResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_GetLargeValueServer = grpc.ServerStreamingServer[GetLargeValueResponse]
+
+func _HydraideService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_GetAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).GetAll(ctx, req.(*GetAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_GetAllStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAllStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).GetAllStream(m, &grpc.GenericServerStream[GetAllStreamRequest, GetAllStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_GetAllStreamServer = grpc.ServerStreamingServer[GetAllStreamResponse]
+
+func _HydraideService_GetByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).GetByIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_GetByIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).GetByIndex(ctx, req.(*GetByIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_GetTopN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopNRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).GetTopN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_GetTopN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).GetTopN(ctx, req.(*GetTopNRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_GetByValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).GetByValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_GetByValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).GetByValue(ctx, req.(*GetByValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_GetByReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).GetByReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_GetByReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).GetByReference(ctx, req.(*GetByReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+
+func _HydraideService_ShiftExpiredTreasures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShiftExpiredTreasuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).ShiftExpiredTreasures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_ShiftExpiredTreasures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).ShiftExpiredTreasures(ctx, req.(*ShiftExpiredTreasuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) GetByIndex(context.Context, *GetByIndexRequest) (*GetByIndexResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetByIndex not implemented")
+
+func _HydraideService_LeaseExpiredTreasures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseExpiredTreasuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).LeaseExpiredTreasures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_LeaseExpiredTreasures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).LeaseExpiredTreasures(ctx, req.(*LeaseExpiredTreasuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) ShiftExpiredTreasures(context.Context, *ShiftExpiredTreasuresRequest) (*ShiftExpiredTreasuresResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ShiftExpiredTreasures not implemented")
+
+func _HydraideService_AckLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).AckLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_AckLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).AckLease(ctx, req.(*AckLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+
+func _HydraideService_NackLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NackLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).NackLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_NackLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).NackLease(ctx, req.(*NackLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+
+func _HydraideService_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DestroyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Destroy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Destroy(ctx, req.(*DestroyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Count(context.Context, *CountRequest) (*CountResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+
+func _HydraideService_DestroyMany_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DestroyManyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).DestroyMany(m, &grpc.GenericServerStream[DestroyManyRequest, DestroyManyResponse]{ServerStream: stream})
 }
-func (UnimplementedHydraideServiceServer) IsSwampExist(context.Context, *IsSwampExistRequest) (*IsSwampExistResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsSwampExist not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_DestroyManyServer = grpc.ServerStreamingServer[DestroyManyResponse]
+
+func _HydraideService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IsKeyExist(context.Context, *IsKeyExistRequest) (*IsKeyExistResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsKeyExist not implemented")
+
+func _HydraideService_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_ListDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).ListDeleted(ctx, req.(*ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) SubscribeToEvents(*SubscribeToEventsRequest, grpc.ServerStreamingServer[SubscribeToEventsResponse]) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeToEvents not implemented")
+
+func _HydraideService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) SubscribeToInfo(*SubscribeToInfoRequest, grpc.ServerStreamingServer[SubscribeToInfoResponse]) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeToInfo not implemented")
+
+func _HydraideService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Uint32SlicePush(context.Context, *AddToUint32SlicePushRequest) (*AddToUint32SlicePushResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Uint32SlicePush not implemented")
+
+func _HydraideService_RevertTo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertToRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).RevertTo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_RevertTo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).RevertTo(ctx, req.(*RevertToRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Uint32SliceDelete(context.Context, *Uint32SliceDeleteRequest) (*Uint32SliceDeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceDelete not implemented")
+
+func _HydraideService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Count(ctx, req.(*CountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Uint32SliceSize(context.Context, *Uint32SliceSizeRequest) (*Uint32SliceSizeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceSize not implemented")
+
+func _HydraideService_IsSwampExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsSwampExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).IsSwampExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_IsSwampExist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).IsSwampExist(ctx, req.(*IsSwampExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) Uint32SliceIsValueExist(context.Context, *Uint32SliceIsValueExistRequest) (*Uint32SliceIsValueExistResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Uint32SliceIsValueExist not implemented")
+
+func _HydraideService_ExistsMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExistsManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).ExistsMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_ExistsMany_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).ExistsMany(ctx, req.(*ExistsManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementInt8(context.Context, *IncrementInt8Request) (*IncrementInt8Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt8 not implemented")
+
+func _HydraideService_IsKeyExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsKeyExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).IsKeyExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_IsKeyExist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).IsKeyExist(ctx, req.(*IsKeyExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementInt16(context.Context, *IncrementInt16Request) (*IncrementInt16Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt16 not implemented")
+
+func _HydraideService_IsKeysExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsKeysExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).IsKeysExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_IsKeysExist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).IsKeysExist(ctx, req.(*IsKeysExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementInt32(context.Context, *IncrementInt32Request) (*IncrementInt32Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt32 not implemented")
+
+func _HydraideService_SubscribeToEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).SubscribeToEvents(m, &grpc.GenericServerStream[SubscribeToEventsRequest, SubscribeToEventsResponse]{ServerStream: stream})
 }
-func (UnimplementedHydraideServiceServer) IncrementInt64(context.Context, *IncrementInt64Request) (*IncrementInt64Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementInt64 not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SubscribeToEventsServer = grpc.ServerStreamingServer[SubscribeToEventsResponse]
+
+func _HydraideService_SubscribeAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).SubscribeAll(m, &grpc.GenericServerStream[SubscribeAllRequest, SubscribeToEventsResponse]{ServerStream: stream})
 }
-func (UnimplementedHydraideServiceServer) IncrementUint8(context.Context, *IncrementUint8Request) (*IncrementUint8Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint8 not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SubscribeAllServer = grpc.ServerStreamingServer[SubscribeToEventsResponse]
+
+func _HydraideService_SubscribeToInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToInfoRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).SubscribeToInfo(m, &grpc.GenericServerStream[SubscribeToInfoRequest, SubscribeToInfoResponse]{ServerStream: stream})
 }
-func (UnimplementedHydraideServiceServer) IncrementUint16(context.Context, *IncrementUint16Request) (*IncrementUint16Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint16 not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_SubscribeToInfoServer = grpc.ServerStreamingServer[SubscribeToInfoResponse]
+
+func _HydraideService_Uint32SlicePush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToUint32SlicePushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Uint32SlicePush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Uint32SlicePush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Uint32SlicePush(ctx, req.(*AddToUint32SlicePushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementUint32(context.Context, *IncrementUint32Request) (*IncrementUint32Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint32 not implemented")
+
+func _HydraideService_Uint32SliceDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Uint32SliceDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Uint32SliceDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Uint32SliceDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Uint32SliceDelete(ctx, req.(*Uint32SliceDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementUint64(context.Context, *IncrementUint64Request) (*IncrementUint64Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementUint64 not implemented")
+
+func _HydraideService_Uint32SliceSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Uint32SliceSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Uint32SliceSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Uint32SliceSize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Uint32SliceSize(ctx, req.(*Uint32SliceSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementFloat32(context.Context, *IncrementFloat32Request) (*IncrementFloat32Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementFloat32 not implemented")
+
+func _HydraideService_Uint32SliceIsValueExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Uint32SliceIsValueExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Uint32SliceIsValueExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Uint32SliceIsValueExist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Uint32SliceIsValueExist(ctx, req.(*Uint32SliceIsValueExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) IncrementFloat64(context.Context, *IncrementFloat64Request) (*IncrementFloat64Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IncrementFloat64 not implemented")
+
+func _HydraideService_Uint32SliceGetRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Uint32SliceGetRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).Uint32SliceGetRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_Uint32SliceGetRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).Uint32SliceGetRange(ctx, req.(*Uint32SliceGetRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedHydraideServiceServer) mustEmbedUnimplementedHydraideServiceServer() {}
-func (UnimplementedHydraideServiceServer) testEmbeddedByValue()                         {}
 
-// UnsafeHydraideServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to HydraideServiceServer will
-// result in compilation errors.
-type UnsafeHydraideServiceServer interface {
-	mustEmbedUnimplementedHydraideServiceServer()
+func _HydraideService_Uint32SliceStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Uint32SliceStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).Uint32SliceStream(m, &grpc.GenericServerStream[Uint32SliceStreamRequest, Uint32SliceStreamResponse]{ServerStream: stream})
 }
 
-func RegisterHydraideServiceServer(s grpc.ServiceRegistrar, srv HydraideServiceServer) {
-	// If the following call pancis, it indicates UnimplementedHydraideServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_Uint32SliceStreamServer = grpc.ServerStreamingServer[Uint32SliceStreamResponse]
+
+func _HydraideService_Uint32SliceCombine_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Uint32SliceCombineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	s.RegisterService(&HydraideService_ServiceDesc, srv)
+	return srv.(HydraideServiceServer).Uint32SliceCombine(m, &grpc.GenericServerStream[Uint32SliceCombineRequest, Uint32SliceStreamResponse]{ServerStream: stream})
 }
 
-func _HydraideService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HeartbeatRequest)
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_Uint32SliceCombineServer = grpc.ServerStreamingServer[Uint32SliceStreamResponse]
+
+func _HydraideService_Uint32SliceCombineInto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Uint32SliceCombineIntoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Heartbeat(ctx, in)
+		return srv.(HydraideServiceServer).Uint32SliceCombineInto(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Heartbeat_FullMethodName,
+		FullMethod: HydraideService_Uint32SliceCombineInto_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+		return srv.(HydraideServiceServer).Uint32SliceCombineInto(ctx, req.(*Uint32SliceCombineIntoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LockRequest)
+func _HydraideService_IncrementInt8_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementInt8Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Lock(ctx, in)
+		return srv.(HydraideServiceServer).IncrementInt8(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Lock_FullMethodName,
+		FullMethod: HydraideService_IncrementInt8_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Lock(ctx, req.(*LockRequest))
+		return srv.(HydraideServiceServer).IncrementInt8(ctx, req.(*IncrementInt8Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnlockRequest)
+func _HydraideService_IncrementInt16_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementInt16Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Unlock(ctx, in)
+		return srv.(HydraideServiceServer).IncrementInt16(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Unlock_FullMethodName,
+		FullMethod: HydraideService_IncrementInt16_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Unlock(ctx, req.(*UnlockRequest))
+		return srv.(HydraideServiceServer).IncrementInt16(ctx, req.(*IncrementInt16Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_RegisterSwamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterSwampRequest)
+func _HydraideService_IncrementInt32_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementInt32Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).RegisterSwamp(ctx, in)
+		return srv.(HydraideServiceServer).IncrementInt32(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_RegisterSwamp_FullMethodName,
+		FullMethod: HydraideService_IncrementInt32_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).RegisterSwamp(ctx, req.(*RegisterSwampRequest))
+		return srv.(HydraideServiceServer).IncrementInt32(ctx, req.(*IncrementInt32Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_DeRegisterSwamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeRegisterSwampRequest)
+func _HydraideService_IncrementInt64_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementInt64Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).DeRegisterSwamp(ctx, in)
+		return srv.(HydraideServiceServer).IncrementInt64(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_DeRegisterSwamp_FullMethodName,
+		FullMethod: HydraideService_IncrementInt64_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).DeRegisterSwamp(ctx, req.(*DeRegisterSwampRequest))
+		return srv.(HydraideServiceServer).IncrementInt64(ctx, req.(*IncrementInt64Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetRequest)
+func _HydraideService_IncrementUint8_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementUint8Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Set(ctx, in)
+		return srv.(HydraideServiceServer).IncrementUint8(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Set_FullMethodName,
+		FullMethod: HydraideService_IncrementUint8_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Set(ctx, req.(*SetRequest))
+		return srv.(HydraideServiceServer).IncrementUint8(ctx, req.(*IncrementUint8Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
+func _HydraideService_IncrementUint16_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementUint16Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Get(ctx, in)
+		return srv.(HydraideServiceServer).IncrementUint16(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Get_FullMethodName,
+		FullMethod: HydraideService_IncrementUint16_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Get(ctx, req.(*GetRequest))
+		return srv.(HydraideServiceServer).IncrementUint16(ctx, req.(*IncrementUint16Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllRequest)
+func _HydraideService_IncrementUint32_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementUint32Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).GetAll(ctx, in)
+		return srv.(HydraideServiceServer).IncrementUint32(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_GetAll_FullMethodName,
+		FullMethod: HydraideService_IncrementUint32_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).GetAll(ctx, req.(*GetAllRequest))
+		return srv.(HydraideServiceServer).IncrementUint32(ctx, req.(*IncrementUint32Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_GetByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetByIndexRequest)
+func _HydraideService_IncrementUint64_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementUint64Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraideServiceServer).IncrementUint64(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HydraideService_IncrementUint64_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraideServiceServer).IncrementUint64(ctx, req.(*IncrementUint64Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HydraideService_IncrementFloat32_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementFloat32Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).GetByIndex(ctx, in)
+		return srv.(HydraideServiceServer).IncrementFloat32(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_GetByIndex_FullMethodName,
+		FullMethod: HydraideService_IncrementFloat32_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).GetByIndex(ctx, req.(*GetByIndexRequest))
+		return srv.(HydraideServiceServer).IncrementFloat32(ctx, req.(*IncrementFloat32Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_ShiftExpiredTreasures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShiftExpiredTreasuresRequest)
+func _HydraideService_IncrementFloat64_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementFloat64Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).ShiftExpiredTreasures(ctx, in)
+		return srv.(HydraideServiceServer).IncrementFloat64(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_ShiftExpiredTreasures_FullMethodName,
+		FullMethod: HydraideService_IncrementFloat64_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).ShiftExpiredTreasures(ctx, req.(*ShiftExpiredTreasuresRequest))
+		return srv.(HydraideServiceServer).IncrementFloat64(ctx, req.(*IncrementFloat64Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DestroyRequest)
+func _HydraideService_SetIfLater_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIfLaterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Destroy(ctx, in)
+		return srv.(HydraideServiceServer).SetIfLater(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Destroy_FullMethodName,
+		FullMethod: HydraideService_SetIfLater_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Destroy(ctx, req.(*DestroyRequest))
+		return srv.(HydraideServiceServer).SetIfLater(ctx, req.(*SetIfLaterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _HydraideService_AddDuration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDurationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Delete(ctx, in)
+		return srv.(HydraideServiceServer).AddDuration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Delete_FullMethodName,
+		FullMethod: HydraideService_AddDuration_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(HydraideServiceServer).AddDuration(ctx, req.(*AddDurationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CountRequest)
+func _HydraideService_SetStringIf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStringIfRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Count(ctx, in)
+		return srv.(HydraideServiceServer).SetStringIf(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Count_FullMethodName,
+		FullMethod: HydraideService_SetStringIf_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Count(ctx, req.(*CountRequest))
+		return srv.(HydraideServiceServer).SetStringIf(ctx, req.(*SetStringIfRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IsSwampExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IsSwampExistRequest)
+func _HydraideService_SetBoolIf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBoolIfRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IsSwampExist(ctx, in)
+		return srv.(HydraideServiceServer).SetBoolIf(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IsSwampExist_FullMethodName,
+		FullMethod: HydraideService_SetBoolIf_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IsSwampExist(ctx, req.(*IsSwampExistRequest))
+		return srv.(HydraideServiceServer).SetBoolIf(ctx, req.(*SetBoolIfRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IsKeyExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IsKeyExistRequest)
+func _HydraideService_SetSwampAnnotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSwampAnnotationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IsKeyExist(ctx, in)
+		return srv.(HydraideServiceServer).SetSwampAnnotation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IsKeyExist_FullMethodName,
+		FullMethod: HydraideService_SetSwampAnnotation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IsKeyExist(ctx, req.(*IsKeyExistRequest))
+		return srv.(HydraideServiceServer).SetSwampAnnotation(ctx, req.(*SetSwampAnnotationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_SubscribeToEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribeToEventsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(HydraideServiceServer).SubscribeToEvents(m, &grpc.GenericServerStream[SubscribeToEventsRequest, SubscribeToEventsResponse]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type HydraideService_SubscribeToEventsServer = grpc.ServerStreamingServer[SubscribeToEventsResponse]
-
-func _HydraideService_SubscribeToInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribeToInfoRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(HydraideServiceServer).SubscribeToInfo(m, &grpc.GenericServerStream[SubscribeToInfoRequest, SubscribeToInfoResponse]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type HydraideService_SubscribeToInfoServer = grpc.ServerStreamingServer[SubscribeToInfoResponse]
-
-func _HydraideService_Uint32SlicePush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddToUint32SlicePushRequest)
+func _HydraideService_GetSwampAnnotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSwampAnnotationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Uint32SlicePush(ctx, in)
+		return srv.(HydraideServiceServer).GetSwampAnnotations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Uint32SlicePush_FullMethodName,
+		FullMethod: HydraideService_GetSwampAnnotations_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Uint32SlicePush(ctx, req.(*AddToUint32SlicePushRequest))
+		return srv.(HydraideServiceServer).GetSwampAnnotations(ctx, req.(*GetSwampAnnotationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Uint32SliceDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Uint32SliceDeleteRequest)
+func _HydraideService_Aggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Uint32SliceDelete(ctx, in)
+		return srv.(HydraideServiceServer).Aggregate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Uint32SliceDelete_FullMethodName,
+		FullMethod: HydraideService_Aggregate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Uint32SliceDelete(ctx, req.(*Uint32SliceDeleteRequest))
+		return srv.(HydraideServiceServer).Aggregate(ctx, req.(*AggregateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Uint32SliceSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Uint32SliceSizeRequest)
+func _HydraideService_ListCorruptedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCorruptedFilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Uint32SliceSize(ctx, in)
+		return srv.(HydraideServiceServer).ListCorruptedFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Uint32SliceSize_FullMethodName,
+		FullMethod: HydraideService_ListCorruptedFiles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Uint32SliceSize(ctx, req.(*Uint32SliceSizeRequest))
+		return srv.(HydraideServiceServer).ListCorruptedFiles(ctx, req.(*ListCorruptedFilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_Uint32SliceIsValueExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Uint32SliceIsValueExistRequest)
+func _HydraideService_GetNamespaceUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNamespaceUsageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).Uint32SliceIsValueExist(ctx, in)
+		return srv.(HydraideServiceServer).GetNamespaceUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_Uint32SliceIsValueExist_FullMethodName,
+		FullMethod: HydraideService_GetNamespaceUsage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).Uint32SliceIsValueExist(ctx, req.(*Uint32SliceIsValueExistRequest))
+		return srv.(HydraideServiceServer).GetNamespaceUsage(ctx, req.(*GetNamespaceUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementInt8_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementInt8Request)
+func _HydraideService_CompactSwamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactSwampRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementInt8(ctx, in)
+		return srv.(HydraideServiceServer).CompactSwamp(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementInt8_FullMethodName,
+		FullMethod: HydraideService_CompactSwamp_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementInt8(ctx, req.(*IncrementInt8Request))
+		return srv.(HydraideServiceServer).CompactSwamp(ctx, req.(*CompactSwampRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementInt16_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementInt16Request)
+func _HydraideService_PutBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HydraideServiceServer).PutBlob(&grpc.GenericServerStream[PutBlobRequest, PutBlobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_PutBlobServer = grpc.ClientStreamingServer[PutBlobRequest, PutBlobResponse]
+
+func _HydraideService_GetBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).GetBlob(m, &grpc.GenericServerStream[GetBlobRequest, GetBlobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_GetBlobServer = grpc.ServerStreamingServer[GetBlobResponse]
+
+func _HydraideService_RefBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefBlobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementInt16(ctx, in)
+		return srv.(HydraideServiceServer).RefBlob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementInt16_FullMethodName,
+		FullMethod: HydraideService_RefBlob_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementInt16(ctx, req.(*IncrementInt16Request))
+		return srv.(HydraideServiceServer).RefBlob(ctx, req.(*RefBlobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementInt32_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementInt32Request)
+func _HydraideService_CollectBlobGarbage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectBlobGarbageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementInt32(ctx, in)
+		return srv.(HydraideServiceServer).CollectBlobGarbage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementInt32_FullMethodName,
+		FullMethod: HydraideService_CollectBlobGarbage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementInt32(ctx, req.(*IncrementInt32Request))
+		return srv.(HydraideServiceServer).CollectBlobGarbage(ctx, req.(*CollectBlobGarbageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementInt64_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementInt64Request)
+func _HydraideService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementInt64(ctx, in)
+		return srv.(HydraideServiceServer).QueryAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementInt64_FullMethodName,
+		FullMethod: HydraideService_QueryAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementInt64(ctx, req.(*IncrementInt64Request))
+		return srv.(HydraideServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementUint8_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementUint8Request)
+func _HydraideService_VerifyIslandMapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyIslandMappingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementUint8(ctx, in)
+		return srv.(HydraideServiceServer).VerifyIslandMapping(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementUint8_FullMethodName,
+		FullMethod: HydraideService_VerifyIslandMapping_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementUint8(ctx, req.(*IncrementUint8Request))
+		return srv.(HydraideServiceServer).VerifyIslandMapping(ctx, req.(*VerifyIslandMappingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementUint16_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementUint16Request)
+func _HydraideService_RestorePointInTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePointInTimeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementUint16(ctx, in)
+		return srv.(HydraideServiceServer).RestorePointInTime(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementUint16_FullMethodName,
+		FullMethod: HydraideService_RestorePointInTime_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementUint16(ctx, req.(*IncrementUint16Request))
+		return srv.(HydraideServiceServer).RestorePointInTime(ctx, req.(*RestorePointInTimeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementUint32_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementUint32Request)
+func _HydraideService_GetClusterTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterTopologyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementUint32(ctx, in)
+		return srv.(HydraideServiceServer).GetClusterTopology(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementUint32_FullMethodName,
+		FullMethod: HydraideService_GetClusterTopology_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementUint32(ctx, req.(*IncrementUint32Request))
+		return srv.(HydraideServiceServer).GetClusterTopology(ctx, req.(*GetClusterTopologyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementUint64_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementUint64Request)
+func _HydraideService_SetClusterTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClusterTopologyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementUint64(ctx, in)
+		return srv.(HydraideServiceServer).SetClusterTopology(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementUint64_FullMethodName,
+		FullMethod: HydraideService_SetClusterTopology_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementUint64(ctx, req.(*IncrementUint64Request))
+		return srv.(HydraideServiceServer).SetClusterTopology(ctx, req.(*SetClusterTopologyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementFloat32_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementFloat32Request)
+func _HydraideService_SetIslandState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIslandStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementFloat32(ctx, in)
+		return srv.(HydraideServiceServer).SetIslandState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementFloat32_FullMethodName,
+		FullMethod: HydraideService_SetIslandState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementFloat32(ctx, req.(*IncrementFloat32Request))
+		return srv.(HydraideServiceServer).SetIslandState(ctx, req.(*SetIslandStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _HydraideService_IncrementFloat64_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IncrementFloat64Request)
+func _HydraideService_ExportIsland_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportIslandRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraideServiceServer).ExportIsland(m, &grpc.GenericServerStream[ExportIslandRequest, ExportIslandResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_ExportIslandServer = grpc.ServerStreamingServer[ExportIslandResponse]
+
+func _HydraideService_ImportIsland_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HydraideServiceServer).ImportIsland(&grpc.GenericServerStream[ImportIslandRequest, ImportIslandResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HydraideService_ImportIslandServer = grpc.ClientStreamingServer[ImportIslandRequest, ImportIslandResponse]
+
+func _HydraideService_ReloadDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadDefaultsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HydraideServiceServer).IncrementFloat64(ctx, in)
+		return srv.(HydraideServiceServer).ReloadDefaults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: HydraideService_IncrementFloat64_FullMethodName,
+		FullMethod: HydraideService_ReloadDefaults_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HydraideServiceServer).IncrementFloat64(ctx, req.(*IncrementFloat64Request))
+		return srv.(HydraideServiceServer).ReloadDefaults(ctx, req.(*ReloadDefaultsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1651,6 +4039,14 @@ var HydraideService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeRegisterSwamp",
 			Handler:    _HydraideService_DeRegisterSwamp_Handler,
 		},
+		{
+			MethodName: "ListSwampPatterns",
+			Handler:    _HydraideService_ListSwampPatterns_Handler,
+		},
+		{
+			MethodName: "UpdateSwampPattern",
+			Handler:    _HydraideService_UpdateSwampPattern_Handler,
+		},
 		{
 			MethodName: "Set",
 			Handler:    _HydraideService_Set_Handler,
@@ -1667,10 +4063,34 @@ var HydraideService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByIndex",
 			Handler:    _HydraideService_GetByIndex_Handler,
 		},
+		{
+			MethodName: "GetTopN",
+			Handler:    _HydraideService_GetTopN_Handler,
+		},
+		{
+			MethodName: "GetByValue",
+			Handler:    _HydraideService_GetByValue_Handler,
+		},
+		{
+			MethodName: "GetByReference",
+			Handler:    _HydraideService_GetByReference_Handler,
+		},
 		{
 			MethodName: "ShiftExpiredTreasures",
 			Handler:    _HydraideService_ShiftExpiredTreasures_Handler,
 		},
+		{
+			MethodName: "LeaseExpiredTreasures",
+			Handler:    _HydraideService_LeaseExpiredTreasures_Handler,
+		},
+		{
+			MethodName: "AckLease",
+			Handler:    _HydraideService_AckLease_Handler,
+		},
+		{
+			MethodName: "NackLease",
+			Handler:    _HydraideService_NackLease_Handler,
+		},
 		{
 			MethodName: "Destroy",
 			Handler:    _HydraideService_Destroy_Handler,
@@ -1679,6 +4099,22 @@ var HydraideService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _HydraideService_Delete_Handler,
 		},
+		{
+			MethodName: "ListDeleted",
+			Handler:    _HydraideService_ListDeleted_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _HydraideService_Restore_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _HydraideService_GetHistory_Handler,
+		},
+		{
+			MethodName: "RevertTo",
+			Handler:    _HydraideService_RevertTo_Handler,
+		},
 		{
 			MethodName: "Count",
 			Handler:    _HydraideService_Count_Handler,
@@ -1687,10 +4123,18 @@ var HydraideService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsSwampExist",
 			Handler:    _HydraideService_IsSwampExist_Handler,
 		},
+		{
+			MethodName: "ExistsMany",
+			Handler:    _HydraideService_ExistsMany_Handler,
+		},
 		{
 			MethodName: "IsKeyExist",
 			Handler:    _HydraideService_IsKeyExist_Handler,
 		},
+		{
+			MethodName: "IsKeysExist",
+			Handler:    _HydraideService_IsKeysExist_Handler,
+		},
 		{
 			MethodName: "Uint32SlicePush",
 			Handler:    _HydraideService_Uint32SlicePush_Handler,
@@ -1707,6 +4151,14 @@ var HydraideService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Uint32SliceIsValueExist",
 			Handler:    _HydraideService_Uint32SliceIsValueExist_Handler,
 		},
+		{
+			MethodName: "Uint32SliceGetRange",
+			Handler:    _HydraideService_Uint32SliceGetRange_Handler,
+		},
+		{
+			MethodName: "Uint32SliceCombineInto",
+			Handler:    _HydraideService_Uint32SliceCombineInto_Handler,
+		},
 		{
 			MethodName: "IncrementInt8",
 			Handler:    _HydraideService_IncrementInt8_Handler,
@@ -1747,18 +4199,149 @@ var HydraideService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IncrementFloat64",
 			Handler:    _HydraideService_IncrementFloat64_Handler,
 		},
+		{
+			MethodName: "SetIfLater",
+			Handler:    _HydraideService_SetIfLater_Handler,
+		},
+		{
+			MethodName: "AddDuration",
+			Handler:    _HydraideService_AddDuration_Handler,
+		},
+		{
+			MethodName: "SetStringIf",
+			Handler:    _HydraideService_SetStringIf_Handler,
+		},
+		{
+			MethodName: "SetBoolIf",
+			Handler:    _HydraideService_SetBoolIf_Handler,
+		},
+		{
+			MethodName: "SetSwampAnnotation",
+			Handler:    _HydraideService_SetSwampAnnotation_Handler,
+		},
+		{
+			MethodName: "GetSwampAnnotations",
+			Handler:    _HydraideService_GetSwampAnnotations_Handler,
+		},
+		{
+			MethodName: "Aggregate",
+			Handler:    _HydraideService_Aggregate_Handler,
+		},
+		{
+			MethodName: "ListCorruptedFiles",
+			Handler:    _HydraideService_ListCorruptedFiles_Handler,
+		},
+		{
+			MethodName: "GetNamespaceUsage",
+			Handler:    _HydraideService_GetNamespaceUsage_Handler,
+		},
+		{
+			MethodName: "CompactSwamp",
+			Handler:    _HydraideService_CompactSwamp_Handler,
+		},
+		{
+			MethodName: "RefBlob",
+			Handler:    _HydraideService_RefBlob_Handler,
+		},
+		{
+			MethodName: "CollectBlobGarbage",
+			Handler:    _HydraideService_CollectBlobGarbage_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _HydraideService_QueryAuditLog_Handler,
+		},
+		{
+			MethodName: "VerifyIslandMapping",
+			Handler:    _HydraideService_VerifyIslandMapping_Handler,
+		},
+		{
+			MethodName: "RestorePointInTime",
+			Handler:    _HydraideService_RestorePointInTime_Handler,
+		},
+		{
+			MethodName: "GetClusterTopology",
+			Handler:    _HydraideService_GetClusterTopology_Handler,
+		},
+		{
+			MethodName: "SetClusterTopology",
+			Handler:    _HydraideService_SetClusterTopology_Handler,
+		},
+		{
+			MethodName: "SetIslandState",
+			Handler:    _HydraideService_SetIslandState_Handler,
+		},
+		{
+			MethodName: "ReloadDefaults",
+			Handler:    _HydraideService_ReloadDefaults_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SetLargeValue",
+			Handler:       _HydraideService_SetLargeValue_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetLargeValue",
+			Handler:       _HydraideService_GetLargeValue_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllStream",
+			Handler:       _HydraideService_GetAllStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DestroyMany",
+			Handler:       _HydraideService_DestroyMany_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeToEvents",
 			Handler:       _HydraideService_SubscribeToEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeAll",
+			Handler:       _HydraideService_SubscribeAll_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeToInfo",
 			Handler:       _HydraideService_SubscribeToInfo_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Uint32SliceStream",
+			Handler:       _HydraideService_Uint32SliceStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Uint32SliceCombine",
+			Handler:       _HydraideService_Uint32SliceCombine_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PutBlob",
+			Handler:       _HydraideService_PutBlob_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetBlob",
+			Handler:       _HydraideService_GetBlob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportIsland",
+			Handler:       _HydraideService_ExportIsland_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportIsland",
+			Handler:       _HydraideService_ImportIsland_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "hydraide.proto",
 }
@@ -0,0 +1,187 @@
+package hydraidego
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"io"
+	"time"
+)
+
+// BlobGarbageResult summarizes the garbage collection of the blobs by `CollectBlobGarbage()`.
+type BlobGarbageResult struct {
+	RemovedBlobs int64 // the number of the removed blobs on all servers
+	FreedBytes   int64 // the disk space freed on all servers
+}
+
+// PutBlob stores a large binary value once, under the SHA-256 hash of its content, and returns the hash.
+//
+// 📦 The blobs are content-addressed: if the same content is already stored, only a reference is added to it, so the
+// same attachment referenced by many Treasures, even in many Swamps, takes the disk space only once. Store the hash
+// in the Treasure, and read the content by `GetBlob()`.
+//
+// ✅ Use when:
+//   - The same large value (file, image, document) is referenced by many Treasures
+//   - The value is too large to be loaded into the memory with its Swamp
+//
+// ⚙️ Behavior:
+//   - Every PutBlob adds one reference to the blob, the same as a `RefBlob(ctx, hash, 1)`
+//   - The content is streamed in chunks, so it can be larger than the max message size
+//   - The server verifies the hash of the received content
+//   - The blobs are distributed across the servers by their hash
+//
+// ⚠️ Remove the reference by `RefBlob(ctx, hash, -1)` when the Treasure is deleted or it references an other blob,
+// otherwise the blob is never removed by `CollectBlobGarbage()`.
+//
+// 🔧 Example:
+//
+//	hash, err := h.PutBlob(ctx, pdf)
+//	if err != nil {
+//	    return err
+//	}
+//	err = h.CatalogCreate(ctx, swampName, &Invoice{ID: "2025-001", AttachmentHash: hash})
+func (h *hydraidego) PutBlob(ctx context.Context, content []byte) (string, error) {
+
+	hash := blobHash(content)
+	blobName := blobSwampName(hash)
+
+	stream, err := h.client.GetServiceClient(blobName).PutBlob(ctx)
+	if err != nil {
+		return "", errorHandler(err)
+	}
+
+	chunks := splitLargeValue(content, h.client.GetMaxMessageSize())
+	messages := []*hydraidepbgo.PutBlobRequest{{
+		IslandID: blobName.GetIslandID(h.client.GetAllIslands()),
+		Hash:     hash,
+	}}
+	if len(chunks) > 0 {
+		messages[0].Chunk = chunks[0]
+		for _, chunk := range chunks[1:] {
+			messages = append(messages, &hydraidepbgo.PutBlobRequest{Chunk: chunk})
+		}
+	}
+
+	for _, message := range messages {
+		if err := stream.Send(message); err != nil {
+			if errors.Is(err, io.EOF) {
+				// the server closed the stream, the reason is returned by the CloseAndRecv
+				break
+			}
+			return "", errorHandler(err)
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", errorHandler(err)
+	}
+
+	return response.GetHash(), nil
+
+}
+
+// GetBlob returns the content of the blob stored by `PutBlob()`.
+//
+// The content is streamed in chunks, so it can be larger than the max message size.
+//
+// 🧯 Errors:
+//   - The blob does not exist, or it was removed by the garbage collection → `ErrCodeNotFound`
+//   - The hash is not a valid SHA-256 hash → `ErrCodeInvalidArgument`
+//   - The content on the server does not match its hash anymore → `ErrCodeDataCorrupted`
+func (h *hydraidego) GetBlob(ctx context.Context, hash string) ([]byte, error) {
+
+	blobName := blobSwampName(hash)
+
+	stream, err := h.client.GetServiceClient(blobName).GetBlob(ctx, &hydraidepbgo.GetBlobRequest{
+		IslandID:  blobName.GetIslandID(h.client.GetAllIslands()),
+		Hash:      hash,
+		ChunkSize: uint32(largeValueChunkSize(h.client.GetMaxMessageSize())),
+	})
+	if err != nil {
+		return nil, errorHandler(err)
+	}
+
+	content := make([]byte, 0)
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		content = append(content, response.GetChunk()...)
+	}
+
+	return content, nil
+
+}
+
+// RefBlob adds references to the blob, or removes them with a negative delta, and returns the number of the
+// references after the change.
+//
+// Add a reference when an other Treasure starts referencing the same blob without storing it again, and remove it
+// when a Treasure is deleted or it references an other blob. A blob without references is removed by
+// `CollectBlobGarbage()`.
+//
+// 🧯 Errors:
+//   - The blob does not exist → `ErrCodeNotFound`
+//   - The references would be negative → `ErrCodeInvalidArgument`
+func (h *hydraidego) RefBlob(ctx context.Context, hash string, delta int64) (int64, error) {
+
+	blobName := blobSwampName(hash)
+
+	response, err := h.client.GetServiceClient(blobName).RefBlob(ctx, &hydraidepbgo.RefBlobRequest{
+		IslandID: blobName.GetIslandID(h.client.GetAllIslands()),
+		Hash:     hash,
+		Delta:    delta,
+	})
+	if err != nil {
+		return 0, errorHandler(err)
+	}
+
+	return response.GetReferences(), nil
+
+}
+
+// CollectBlobGarbage removes the blobs without references from all servers.
+//
+// A blob is removed only if it has had no references for at least minAge, so a blob whose reference is being moved
+// from one Treasure to an other one is not lost. Run it periodically, e.g. once a day, with a minAge longer than
+// the longest such move, for example an hour.
+//
+// The results of the servers are summed. If a server fails, the error is returned without the result.
+func (h *hydraidego) CollectBlobGarbage(ctx context.Context, minAge time.Duration) (*BlobGarbageResult, error) {
+
+	result := &BlobGarbageResult{}
+
+	for _, serviceClient := range h.client.GetUniqueServiceClients() {
+		response, err := serviceClient.CollectBlobGarbage(ctx, &hydraidepbgo.CollectBlobGarbageRequest{
+			MinAgeSeconds: int64(minAge / time.Second),
+		})
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		result.RemovedBlobs += response.GetRemovedBlobs()
+		result.FreedBytes += response.GetFreedBytes()
+	}
+
+	return result, nil
+
+}
+
+// blobHash returns the hash of the content, the same as the server uses
+func blobHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// blobSwampName returns the name the blob is routed by, so the blobs are distributed across the servers by their
+// hash, like the Swamps by their names
+func blobSwampName(hash string) name.Name {
+	return name.New().Sanctuary("hydraide").Realm("blobs").Swamp(hash)
+}
@@ -0,0 +1,306 @@
+package hydraidego
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"sync"
+	"time"
+)
+
+// Change is a change of a Swamp, passed to the iterator of `SubscribeAll()`.
+type Change struct {
+	SwampName name.Name   // the Swamp of the change
+	Key       string      // the key of the changed Treasure
+	Status    EventStatus // StatusNew, StatusModified or StatusDeleted
+	EventTime time.Time   // the time of the change on the server
+	Sequence  uint64      // the sequence number of the change within its Swamp
+	treasure  *hydraidepbgo.Treasure
+	// strict is the decoding mode of the subscription, see StrictDecoding
+	strict bool
+}
+
+// Decode loads the Treasure of the change into a catalog model. The model must be a pointer to a struct. The deleted
+// changes carry the Treasure as it was at the deletion.
+//
+// The Swamps of a change feed usually store different models, so the model can be chosen by the SwampName:
+//
+//	switch change.SwampName.Get() { ... }
+func (c *Change) Decode(model any) error {
+	if c.treasure == nil {
+		return NewError(ErrCodeNotFound, "the change has no treasure")
+	}
+	if err := convertProtoTreasureToCatalogModel(c.treasure, model, c.strict); err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+// Value returns the value of the Treasure of the change in its stored type: a string, an int64, a uint64, a float64,
+// a bool, a []uint32 or a []byte. The structs are stored encoded, so they are returned as []byte, use Decode() to
+// load them into a model. Nil if the Treasure has no value.
+//
+// It is meant for the generic consumers of the change feed, e.g. a connector forwarding the changes of any Swamp to
+// a message bus, without knowing the models.
+func (c *Change) Value() any {
+	t := c.treasure
+	switch {
+	case t == nil:
+		return nil
+	case t.StringVal != nil:
+		return t.GetStringVal()
+	case t.Int8Val != nil:
+		return int64(t.GetInt8Val())
+	case t.Int16Val != nil:
+		return int64(t.GetInt16Val())
+	case t.Int32Val != nil:
+		return int64(t.GetInt32Val())
+	case t.Int64Val != nil:
+		return t.GetInt64Val()
+	case t.Uint8Val != nil:
+		return uint64(t.GetUint8Val())
+	case t.Uint16Val != nil:
+		return uint64(t.GetUint16Val())
+	case t.Uint32Val != nil:
+		return uint64(t.GetUint32Val())
+	case t.Uint64Val != nil:
+		return t.GetUint64Val()
+	case t.Float32Val != nil:
+		return float64(t.GetFloat32Val())
+	case t.Float64Val != nil:
+		return t.GetFloat64Val()
+	case t.BoolVal != nil:
+		return t.GetBoolVal() == hydraidepbgo.Boolean_TRUE
+	case t.Uint32Slice != nil:
+		return t.GetUint32Slice()
+	case t.BytesVal != nil:
+		return t.GetBytesVal()
+	default:
+		return nil
+	}
+}
+
+// SubscribeAllIteratorFunc is called by `SubscribeAll()` for every change, or with the error of a stream.
+type SubscribeAllIteratorFunc func(change *Change, err error) error
+
+// SubscribeAll streams the changes of every Swamp matching any of the patterns, from all servers.
+//
+// A change data capture (CDC) pipeline, e.g. feeding a data warehouse, needs every change of many Swamps. Subscribing
+// to every Swamp one by one would hydrate all of them, and it would miss the Swamps created later. SubscribeAll asks
+// every server for one stream of the changes of its matching Swamps instead, without hydrating any of them.
+//
+// ✅ Use when:
+//   - You copy the changes of many Swamps to an other system, e.g. a data warehouse, a search index or a message bus
+//   - You audit or monitor the writes of a whole Sanctuary or Realm
+//
+// ⚙️ Behavior:
+//   - A `*` part of a pattern matches any part of the names, e.g. `users/*/*`
+//   - The function returns, when every server confirmed the subscription, so every change after the return is
+//     streamed. The existing Treasures are not streamed
+//   - The changes are streamed in a background goroutine per server, but the iterator is called by one goroutine at
+//     a time. The changes of one Swamp arrive in the order of their Sequence
+//   - Use `change.Decode(model)` to load the Treasure of the change into a catalog model
+//
+// ⚠️ Notes:
+//   - If the iterator returns an error, all streams are closed
+//   - If a stream fails or the server closes it, e.g. at its shutdown, the iterator is called with the error, and the
+//     streams of the other servers continue. Return the error from the iterator to close them too
+//   - A client that can not keep up with the changes gets an error where `IsSubscriberOverflow(err)` is true,
+//     instead of silently lost changes. Resynchronize the Swamps, and subscribe again. The buffer size and the
+//     overflow policy can be changed with `WithSubscriberBuffer()`
+//   - The streams are closed when the returned Subscription is closed, or the context is canceled. Done of the
+//     Subscription is closed when the streams of every server stopped
+func (h *hydraidego) SubscribeAll(ctx context.Context, patterns []name.Name, iterator SubscribeAllIteratorFunc) (*Subscription, error) {
+	return h.subscribeAll(ctx, patterns, nil, iterator)
+}
+
+// SubscribeAllFrom works like `SubscribeAll()`, but first replays the changes of the given Swamps the client missed.
+//
+// A connector copying the changes to an other system saves the EventTime of the last change it delivered per Swamp,
+// e.g. in a checkpoint file. After a restart it passes these times as since, keyed by the name of the Swamp
+// (`change.SwampName.Get()`), and continues where it stopped, instead of copying every Swamp again.
+//
+// ⚙️ Behavior:
+//   - The missed changes are replayed from the event journal of the Swamps, see `EventJournalSize` of
+//     `RegisterSwamp()`. They arrive to the iterator before the new changes, without duplicates
+//   - The Swamps of since must match the patterns
+//   - The function returns, when every server confirmed the subscription, like `SubscribeAll()`
+//
+// ⚠️ Notes:
+//   - If the journal of a Swamp does not cover the time since the last change, e.g. the server was restarted or the
+//     journal is disabled, the iterator is called with a change carrying only the SwampName, and an error where
+//     `IsReplayNotAvailable(err)` is true. Resynchronize the Swamp, e.g. with `CatalogReadMany()`, the new changes
+//     of the Swamp are streamed meanwhile
+//   - The changes with the same EventTime as the saved one are not replayed
+func (h *hydraidego) SubscribeAllFrom(ctx context.Context, patterns []name.Name, since map[string]time.Time, iterator SubscribeAllIteratorFunc) (*Subscription, error) {
+	return h.subscribeAll(ctx, patterns, since, iterator)
+}
+
+// subscribeAll opens the change feed on every server. The since times of the swamps are only sent to the server of
+// the swamp
+func (h *hydraidego) subscribeAll(ctx context.Context, patterns []name.Name, since map[string]time.Time, iterator SubscribeAllIteratorFunc) (*Subscription, error) {
+
+	if iterator == nil {
+		return nil, NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+	if len(patterns) == 0 {
+		return nil, NewError(ErrCodeInvalidArgument, "at least one pattern is required")
+	}
+
+	buffer := subscriberBufferFrom(ctx)
+	strict := h.isStrictDecoding(ctx)
+	request := &hydraidepbgo.SubscribeAllRequest{
+		BufferSize: buffer.bufferSize(),
+		Overflow:   buffer.overflowPolicy(),
+	}
+	for _, pattern := range patterns {
+		if pattern == nil {
+			return nil, NewError(ErrCodeInvalidArgument, "pattern cannot be nil")
+		}
+		request.Patterns = append(request.Patterns, pattern.Get())
+	}
+
+	serviceClients := h.client.GetUniqueServiceClients()
+	requests := make(map[hydraidepbgo.HydraideServiceClient]*hydraidepbgo.SubscribeAllRequest, len(serviceClients))
+	for _, serviceClient := range serviceClients {
+		requests[serviceClient] = request
+	}
+	for swampName, sinceTime := range since {
+		serviceClient := h.serviceClient(ctx, name.Load(swampName))
+		serverRequest, ok := requests[serviceClient]
+		if !ok {
+			return nil, NewError(ErrCodeInvalidArgument, fmt.Sprintf("no server serves the swamp %q", swampName))
+		}
+		if serverRequest == request {
+			serverRequest = &hydraidepbgo.SubscribeAllRequest{
+				Patterns:   request.Patterns,
+				Since:      make(map[string]*timestamppb.Timestamp),
+				BufferSize: request.BufferSize,
+				Overflow:   request.Overflow,
+			}
+			requests[serviceClient] = serverRequest
+		}
+		serverRequest.Since[swampName] = timestamppb.New(sinceTime)
+	}
+
+	streamCtx, cancelStreams := context.WithCancel(ctx)
+	subscription := newSubscription(cancelStreams)
+
+	var iteratorMu sync.Mutex
+	stopped := false
+	callIterator := func(change *Change, err error) {
+		iteratorMu.Lock()
+		defer iteratorMu.Unlock()
+		if stopped || subscription.closed.Load() {
+			return
+		}
+		// the changes carry the errors of their Swamps, only the errors without a change are the errors of a stream
+		if change == nil {
+			subscription.fail(err)
+		} else if err == nil {
+			subscription.eventReceived(change.EventTime)
+		}
+		if iErr := iterator(change, err); iErr != nil {
+			stopped = true
+			subscription.fail(iErr)
+			cancelStreams()
+		}
+	}
+
+	// the replayed changes arrive before the first SnapshotEnd message of the server, which confirms the
+	// subscription
+	streams := make([]hydraidepbgo.HydraideService_SubscribeAllClient, 0)
+	for _, serviceClient := range serviceClients {
+		stream, err := serviceClient.SubscribeAll(streamCtx, requests[serviceClient])
+		if err != nil {
+			cancelStreams()
+			return nil, errorHandler(err)
+		}
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				cancelStreams()
+				// the iterator stopped the streams during the replay
+				if stopped {
+					subscription.finish()
+					return subscription, nil
+				}
+				return nil, errorHandler(err)
+			}
+			if event.GetSnapshotEnd() {
+				break
+			}
+			callIterator(convertEventToChange(event, strict))
+		}
+		streams = append(streams, stream)
+	}
+
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					// the stream is closed by the client
+					if streamCtx.Err() != nil {
+						subscription.fail(errorHandler(err))
+						return
+					}
+					// the change feed has no end, the server only closes it gracefully when it stops
+					if err == io.EOF {
+						callIterator(nil, NewError(ErrCodeConnectionError, "the server closed the change feed"))
+						return
+					}
+					callIterator(nil, errorHandler(err))
+					return
+				}
+				if event.GetSnapshotEnd() || buffer.dropped(event) {
+					continue
+				}
+				callIterator(convertEventToChange(event, strict))
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		subscription.finish()
+	}()
+
+	return subscription, nil
+
+}
+
+// convertEventToChange converts the event of the change feed to a change. The deleted events carry the deleted
+// treasure, the others the current one. A swamp whose missed events can not be replayed is returned with an error
+func convertEventToChange(event *hydraidepbgo.SubscribeToEventsResponse, strict bool) (*Change, error) {
+
+	if event.GetReplayNotAvailable() {
+		return &Change{
+			SwampName: name.Load(event.GetSwampName()),
+			EventTime: event.GetEventTime().AsTime(),
+		}, NewError(ErrCodeReplayNotAvailable, fmt.Sprintf("%s: the event journal of the swamp %s does not cover the time since %s",
+			errorMessageReplayNotAvailable, event.GetSwampName(), event.GetEventTime().AsTime().Format(time.RFC3339Nano)))
+	}
+
+	change := &Change{
+		SwampName: name.Load(event.GetSwampName()),
+		Status:    convertProtoStatusToStatus(event.GetStatus()),
+		EventTime: event.GetEventTime().AsTime(),
+		Sequence:  event.GetSequence(),
+		treasure:  event.GetTreasure(),
+		strict:    strict,
+	}
+	if event.GetStatus() == hydraidepbgo.Status_DELETED {
+		change.treasure = event.GetDeletedTreasure()
+	}
+	change.Key = change.treasure.GetKey()
+
+	return change, nil
+
+}
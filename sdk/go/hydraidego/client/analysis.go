@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"sort"
+	"time"
+)
+
+// analysisHeartbeats is the number of the heartbeats sent to every server to measure its latency
+const analysisHeartbeats = 10
+
+// errNotConnected is the error of the servers that are not connected by Connect
+var errNotConnected = errors.New("the server is not connected")
+
+// ClusterReport is the health report of the HydrAIDE servers of the client, returned by AnalyzeCluster.
+type ClusterReport struct {
+	// Servers are the reports of the servers, in the order of the configuration
+	Servers []*ServerReport
+	// AllIslands is the number of all Islands of the client
+	AllIslands uint64
+	// CoveredIslands is the number of the Islands routed to a connected server
+	CoveredIslands uint64
+	// UncoveredIslands lists the Islands without a connected server. The Swamps of these Islands can not be reached
+	UncoveredIslands []uint64
+	// RangeError is the error of the Island ranges of the servers (gaps or overlaps), nil if the ranges are valid
+	RangeError error
+}
+
+// ServerReport is the health report of one HydrAIDE server.
+type ServerReport struct {
+	Host       string
+	FromIsland uint64
+	ToIsland   uint64
+	// Reachable is true if every heartbeat of the analysis was answered
+	Reachable bool
+	// Error is the error of the first failed heartbeat, or the reason why the server was not analyzed
+	Error error
+	// Version is the release version of the server. Empty if the server does not report it
+	Version string
+	// The percentiles of the roundtrip time of the heartbeats
+	LatencyP50 time.Duration
+	LatencyP90 time.Duration
+	LatencyP99 time.Duration
+	LatencyMax time.Duration
+	// ClockSkew is the clock of the server minus the clock of the client, estimated at the middle of the fastest
+	// heartbeat. Zero if the server does not report its time
+	ClockSkew time.Duration
+}
+
+// Healthy returns true if the Island ranges are valid, every Island is covered and every server is reachable.
+//
+// The versions and the clock skews are not checked, because their limits depend on the application. Check them
+// from the reports of the servers, e.g. to stop a deployment if the clock of a server is off by more than a second.
+func (r *ClusterReport) Healthy() bool {
+	if r.RangeError != nil || len(r.UncoveredIslands) > 0 {
+		return false
+	}
+	for _, server := range r.Servers {
+		if !server.Reachable {
+			return false
+		}
+	}
+	return true
+}
+
+// AnalyzeCluster checks the health of the connected HydrAIDE servers and returns a report about them.
+//
+// Unlike the connection analysis of Connect, which only logs, the result can be checked by the program, e.g. a
+// deploy pipeline can refuse to roll out the application if the cluster is not healthy.
+//
+// For every configured server it sends a few heartbeats, and reports their latency percentiles, the version of the
+// server and the skew between the clocks of the server and the client. It also reports which Islands are not routed
+// to a connected server.
+//
+// The servers that are not reachable are reported with their error, so the error of AnalyzeCluster is nil unless the
+// context is done before the analysis finishes.
+func (c *client) AnalyzeCluster(ctx context.Context) (*ClusterReport, error) {
+
+	c.mu.RLock()
+	servers := c.servers
+	allIslands := c.allIslands
+	serviceClients := make(map[uint64]*ServiceClient, len(c.serviceClients))
+	for island, serviceClient := range c.serviceClients {
+		serviceClients[island] = serviceClient
+	}
+	c.mu.RUnlock()
+
+	report := &ClusterReport{
+		AllIslands: allIslands,
+		RangeError: c.rangeErr,
+	}
+
+	for island := uint64(1); island <= allIslands; island++ {
+		if _, ok := serviceClients[island]; ok {
+			report.CoveredIslands++
+			continue
+		}
+		report.UncoveredIslands = append(report.UncoveredIslands, island)
+	}
+
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		var serviceClient hydraidepbgo.HydraideServiceClient
+		if routed, ok := serviceClients[server.FromIsland]; ok && routed.Host == server.Host {
+			serviceClient = routed.GrpcClient
+		}
+		report.Servers = append(report.Servers, AnalyzeServer(ctx, server, serviceClient))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+
+}
+
+// AnalyzeServer measures the latency, the version and the clock skew of one server by heartbeats. A nil service
+// client is reported as a server that is not connected.
+//
+// It is used by AnalyzeCluster, and by the clients that do not connect by the configuration of the servers.
+func AnalyzeServer(ctx context.Context, server *Server, serviceClient hydraidepbgo.HydraideServiceClient) *ServerReport {
+
+	report := &ServerReport{
+		Host:       server.Host,
+		FromIsland: server.FromIsland,
+		ToIsland:   server.ToIsland,
+	}
+
+	if serviceClient == nil {
+		report.Error = errNotConnected
+		return report
+	}
+
+	latencies := make([]time.Duration, 0, analysisHeartbeats)
+	fastest := time.Duration(-1)
+	for i := 0; i < analysisHeartbeats; i++ {
+
+		sentAt := time.Now()
+		pong, err := serviceClient.Heartbeat(ctx, &hydraidepbgo.HeartbeatRequest{Ping: "beat"})
+		receivedAt := time.Now()
+
+		if err == nil && pong.GetPong() != "beat" {
+			err = fmt.Errorf("unexpected heartbeat answer: %q", pong.GetPong())
+		}
+		if err != nil {
+			report.Error = err
+			return report
+		}
+
+		latency := receivedAt.Sub(sentAt)
+		latencies = append(latencies, latency)
+		report.Version = pong.GetServerVersion()
+
+		// the fastest roundtrip gives the most accurate estimation of the clock skew
+		if pong.GetServerTime() != nil && (fastest < 0 || latency < fastest) {
+			fastest = latency
+			report.ClockSkew = pong.GetServerTime().AsTime().Sub(sentAt.Add(latency / 2))
+		}
+
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	report.Reachable = true
+	report.LatencyP50 = percentile(latencies, 50)
+	report.LatencyP90 = percentile(latencies, 90)
+	report.LatencyP99 = percentile(latencies, 99)
+	report.LatencyMax = latencies[len(latencies)-1]
+
+	return report
+
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// skewedServiceClient answers the heartbeats with a clock that is ahead of the client
+type skewedServiceClient struct {
+	hydraidepbgo.HydraideServiceClient
+	skew time.Duration
+	err  error
+}
+
+func (s *skewedServiceClient) Heartbeat(_ context.Context, in *hydraidepbgo.HeartbeatRequest, _ ...grpc.CallOption) (*hydraidepbgo.HeartbeatResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &hydraidepbgo.HeartbeatResponse{
+		Pong:          in.GetPing(),
+		ServerVersion: "v1.2.3",
+		ServerTime:    timestamppb.New(time.Now().Add(s.skew)),
+	}, nil
+}
+
+func TestClient_AnalyzeCluster(t *testing.T) {
+
+	servers := []*Server{
+		{Host: "hydra01", FromIsland: 1, ToIsland: 5},
+		{Host: "hydra02", FromIsland: 6, ToIsland: 10},
+	}
+
+	connected := &ServiceClient{GrpcClient: &skewedServiceClient{skew: time.Hour}, Host: "hydra01"}
+
+	t.Run("should report the servers and the islands that are not connected", func(t *testing.T) {
+
+		c := &client{
+			allIslands:     10,
+			servers:        servers,
+			serviceClients: map[uint64]*ServiceClient{},
+		}
+		for island := uint64(1); island <= 5; island++ {
+			c.serviceClients[island] = connected
+		}
+
+		report, err := c.AnalyzeCluster(context.Background())
+		require.NoError(t, err)
+		require.Len(t, report.Servers, 2)
+
+		first := report.Servers[0]
+		assert.True(t, first.Reachable)
+		assert.NoError(t, first.Error)
+		assert.Equal(t, "v1.2.3", first.Version)
+		assert.InDelta(t, time.Hour.Seconds(), first.ClockSkew.Seconds(), 1)
+		assert.LessOrEqual(t, first.LatencyP50, first.LatencyP90)
+		assert.LessOrEqual(t, first.LatencyP99, first.LatencyMax)
+
+		second := report.Servers[1]
+		assert.False(t, second.Reachable)
+		assert.ErrorIs(t, second.Error, errNotConnected)
+
+		assert.Equal(t, uint64(5), report.CoveredIslands)
+		assert.Equal(t, []uint64{6, 7, 8, 9, 10}, report.UncoveredIslands)
+		assert.False(t, report.Healthy())
+
+	})
+
+	t.Run("should report the failing heartbeats", func(t *testing.T) {
+
+		heartbeatErr := errors.New("unavailable")
+		failing := &ServiceClient{GrpcClient: &skewedServiceClient{err: heartbeatErr}, Host: "hydra02"}
+
+		c := &client{
+			allIslands:     10,
+			servers:        servers,
+			serviceClients: map[uint64]*ServiceClient{},
+		}
+		for island := uint64(1); island <= 10; island++ {
+			c.serviceClients[island] = connected
+			if island > 5 {
+				c.serviceClients[island] = failing
+			}
+		}
+
+		report, err := c.AnalyzeCluster(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, report.UncoveredIslands)
+		assert.ErrorIs(t, report.Servers[1].Error, heartbeatErr)
+		assert.False(t, report.Healthy())
+
+		failing.GrpcClient = &skewedServiceClient{}
+		report, err = c.AnalyzeCluster(context.Background())
+		require.NoError(t, err)
+		assert.True(t, report.Healthy())
+
+	})
+
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, time.Duration(5), percentile(sorted, 50))
+	assert.Equal(t, time.Duration(9), percentile(sorted, 90))
+	assert.Equal(t, time.Duration(10), percentile(sorted, 99))
+}
@@ -3,24 +3,24 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/hydraide/hydraide/generated/hydraidepbgo"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
-	"google.golang.org/grpc/keepalive"
 	"log/slog"
 	"net"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	errorNoConnection = "there is no connection to the HydrAIDE server"
-	errorConnection   = "error while connecting to the server"
+	errorConnection = "error while connecting to the server"
 )
 
 type Client interface {
@@ -30,6 +30,13 @@ type Client interface {
 	GetServiceClientAndHost(swampName name.Name) *ServiceClient
 	GetUniqueServiceClients() []hydraidepbgo.HydraideServiceClient
 	GetAllIslands() uint64
+	// GetMaxMessageSize returns the max size of a gRPC message in bytes. 0 means there is no limit.
+	GetMaxMessageSize() int
+	// AnalyzeCluster returns the health report of the servers: latencies, versions, clock skews and Island coverage.
+	AnalyzeCluster(ctx context.Context) (*ClusterReport, error)
+	// MigrateIslands moves the Islands from-to to the server of the target host without downtime. The client must be
+	// created with seeds, see MigrateIslands of the client for the steps.
+	MigrateIslands(ctx context.Context, fromIsland, toIsland uint64, targetHost string) (*MigrationReport, error)
 }
 
 type ServiceClient struct {
@@ -46,6 +53,31 @@ type client struct {
 	servers        []*Server
 	mu             sync.RWMutex
 	certFile       string
+	tracing        bool
+	// compression is the name of the gRPC compressor of the messages, empty if the messages are not compressed
+	compression string
+	// deadlines are the default timeouts of the RPCs, see WithDeadlines
+	deadlines Deadlines
+	// middlewares wrap the unary RPCs, see WithMiddleware
+	middlewares []Middleware
+	// rangeErr is the error of the Island ranges of the servers, returned by Connect
+	rangeErr error
+	// dial connects to a server, dialServer except in the tests
+	dial func(server *Server, connectionLog bool) (*connection, error)
+	// hosts are the connections of the servers by their hosts
+	hosts map[string]*connection
+	// seeds are the servers the topology is discovered from, nil if the servers are configured with their Islands
+	seeds []*Server
+	// topologyVersion is the version of the discovered topology
+	topologyVersion string
+	// topologyRefresh is the interval between two checks of the topology, see WithTopologyRefresh
+	topologyRefresh time.Duration
+	// stopRefresh stops the refresh of the topology, nil if it is not running
+	stopRefresh chan struct{}
+	// closed is true after CloseConnection, so a running refresh does not connect again
+	closed bool
+	// refreshMu serializes the refreshes of the topology
+	refreshMu sync.Mutex
 }
 
 // Server represents a HydrAIDE server instance that handles one or more Islands.
@@ -59,6 +91,17 @@ type client struct {
 //   - FromIsland: The first Island (inclusive) that this server is responsible for
 //   - ToIsland: The last Island (inclusive) this server handles
 //   - CertFilePath: Optional TLS certificate path for secure connections
+//   - TenantToken: Optional bearer token of the tenant, required if the server runs in multi-tenant mode.
+//     The server stores the data of every tenant under its own root path, so the tenants can't see each other's data.
+//   - KeepaliveTime, KeepaliveTimeout, KeepaliveWithoutStream: Optional keepalive pings of the connection
+//   - InitialWindowSize, InitialConnWindowSize: Optional HTTP/2 flow control windows of the connection
+//
+// 🔌 Connections behind a NAT:
+// A NAT or a load balancer drops the connections idle longer than its timeout, and a Subscribe stream without
+// events dies silently. The keepalive pings keep the connection alive: set KeepaliveTime below the idle timeout of
+// the NAT, but not below the keepaliveMinTimeSec of the server (10 seconds by default), otherwise the server closes
+// the connection as abusive. The max number of the concurrent streams of a connection is set by the server
+// (maxConcurrentStreams), the client follows it.
 //
 // 🏝️ Why Islands?
 // An Island is a routing and storage unit — a top-level hash partition where Swamps reside.
@@ -67,7 +110,7 @@ type client struct {
 //
 // 💡 Best practices:
 // - Island ranges must not overlap between servers.
-// - The Island range must be consistent with the total `allIslands` space (e.g. 1–1000).
+// - The Island ranges must cover the total `allIslands` space (e.g. 1–1000) without gaps (see ValidateIslandRanges).
 // - The client is responsible for ensuring deterministic routing via Swamp name hashing.
 //
 // Example:
@@ -81,6 +124,22 @@ type Server struct {
 	FromIsland   uint64
 	ToIsland     uint64
 	CertFilePath string
+	TenantToken  string
+	// KeepaliveTime is the idle time of the connection with an open RPC, e.g. a Subscribe stream, before the client
+	// pings the server. Zero means DefaultKeepaliveTime
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout is the time the client waits for the answer of its ping before it closes the connection and
+	// reconnects. Zero means DefaultKeepaliveTimeout
+	KeepaliveTimeout time.Duration
+	// KeepaliveWithoutStream pings the server even if the connection has no open RPC, so an idle connection is not
+	// dropped by the NAT either
+	KeepaliveWithoutStream bool
+	// InitialWindowSize is the HTTP/2 flow control window of a stream in bytes. The values below 64 KB are ignored.
+	// Zero means the default of gRPC, which grows with the bandwidth of the connection
+	InitialWindowSize int32
+	// InitialConnWindowSize is the HTTP/2 flow control window of the connection in bytes. The values below 64 KB are
+	// ignored. Zero means the default of gRPC, which grows with the bandwidth of the connection
+	InitialConnWindowSize int32
 }
 
 // New creates a new HydrAIDE client instance that connects to one or more servers,
@@ -94,8 +153,11 @@ type Server struct {
 // Parameters:
 //   - servers: list of HydrAIDE servers to connect to
 //     Each server is responsible for a specific Island range (From → To).
-//   - allIslands: total number of hash buckets (Islands) in the system — must be fixed (e.g. 1000)
+//   - allIslands: total number of hash buckets (Islands) in the system — must be fixed (e.g. 1000).
+//     0 means the servers are seeds, and the Island ranges are discovered from them (see below)
 //   - maxMessageSize: maximum allowed message size for gRPC communication (in bytes)
+//   - options: optional settings, e.g. WithTracing() for OpenTelemetry tracing, WithCompression() for compressed messages
+//     WithDeadlines() for the default timeouts of the calls, or WithHooks() and WithMiddleware() around the calls
 //
 // The returned Client instance handles:
 //   - Stateless and deterministic Swamp → Island → server resolution
@@ -130,13 +192,45 @@ type Server struct {
 //	if service != nil {
 //	    res, err := service.Read(...) // raw gRPC call to the correct Island-hosting server
 //	}
-func New(servers []*Server, allIslands uint64, maxMessageSize int) Client {
-	return &client{
-		serviceClients: make(map[uint64]*ServiceClient),
-		servers:        servers,
-		allIslands:     allIslands,
-		maxMessageSize: maxMessageSize,
+//
+// 🌐 Seeds:
+// With allIslands 0, the servers are seeds: only their hosts and connection settings are used, their Island ranges
+// are ignored. Connect fetches the topology of the cluster (all servers, their Island ranges and the number of all
+// Islands) from the first reachable seed by the GetClusterTopology RPC, and connects to every server of it. The
+// discovered servers use the certificate, the token and the connection settings of the seed with the same host, or
+// of the first seed. The topology is checked periodically (see WithTopologyRefresh), and the client follows its
+// changes without a restart. The servers must have the same topology file, see HYDRAIDE_CLUSTER_TOPOLOGY_FILE.
+//
+//	client := client.New([]*client.Server{
+//	    {Host: "hydra01:4444", CertFilePath: "certs/ca.pem"},
+//	    {Host: "hydra02:4444", CertFilePath: "certs/ca.pem"},
+//	}, 0, 1024*1024*1024)
+func New(servers []*Server, allIslands uint64, maxMessageSize int, options ...Option) Client {
+	c := &client{
+		serviceClients:  make(map[uint64]*ServiceClient),
+		servers:         servers,
+		allIslands:      allIslands,
+		maxMessageSize:  maxMessageSize,
+		topologyRefresh: DefaultTopologyRefresh,
 	}
+	c.dial = c.dialServer
+	for _, option := range options {
+		option(c)
+	}
+	if allIslands == 0 {
+		// the servers are seeds, the Island ranges are discovered by Connect
+		c.seeds = servers
+		c.servers = nil
+		if len(servers) == 0 {
+			c.rangeErr = fmt.Errorf("%w: no seed server is configured", ErrInvalidIslandRanges)
+		}
+		return c
+	}
+	if err := ValidateIslandRanges(servers, allIslands); err != nil {
+		slog.Error("the island ranges of the HydrAIDE servers are misconfigured", "error", err)
+		c.rangeErr = err
+	}
+	return c
 }
 
 // Connect establishes gRPC connections to all configured HydrAIDE servers
@@ -165,8 +259,15 @@ func New(servers []*Server, allIslands uint64, maxMessageSize int) Client {
 //   - If a server fails TLS validation, connection, or heartbeat, the error is logged
 //   - Connection proceeds for all other available servers — partial success is allowed
 //
+// Seeds:
+//   - If the client was created with seeds, the topology is fetched from the first reachable seed first, and the
+//     servers of the topology are connected. The refresh of the topology is started in the background
+//
 // Returns:
 //   - nil if all servers connect successfully
+//   - ErrInvalidIslandRanges (wrapped) if the Island ranges of the servers have gaps or overlaps, without connecting
+//   - the error of the last seed, if the topology can not be fetched from any seed, wrapping
+//     ErrClusterTopologyNotConfigured if the seed has no topology file
 //   - otherwise, returns an error and logs the connection failures
 //
 // Example:
@@ -177,117 +278,205 @@ func New(servers []*Server, allIslands uint64, maxMessageSize int) Client {
 //	}
 func (c *client) Connect(connectionLog bool) error {
 
+	if c.rangeErr != nil {
+		return c.rangeErr
+	}
+
+	// with seeds, the servers and their Islands are discovered from the first reachable seed
+	if c.seeds != nil {
+		if err := c.discoverTopology(connectionLog); err != nil {
+			return err
+		}
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	var errorMessages []error
 
+	hosts := make(map[string]*connection, len(c.servers))
 	for _, server := range c.servers {
+		if _, ok := hosts[server.Host]; ok {
+			continue
+		}
+		conn, err := c.dial(server, connectionLog)
+		if err != nil {
+			errorMessages = append(errorMessages, err)
+			continue
+		}
+		hosts[server.Host] = conn
+	}
 
-		func() {
+	c.route(c.servers, hosts)
+	c.closed = false
 
-			if connectionLog {
-				pingHost(server.Host)
-				grpclog.SetLoggerV2(grpclog.NewLoggerV2WithVerbosity(os.Stdout, os.Stderr, os.Stderr, 99))
-			}
+	if c.seeds != nil && c.topologyRefresh > 0 && c.stopRefresh == nil {
+		c.stopRefresh = make(chan struct{})
+		go c.watchTopology(c.stopRefresh)
+	}
 
-			serviceConfigJSON := `{
-			  "methodConfig": [{
-				"name": [{"service": "hydraidepbgo.HydraideService"}],
-				"waitForReady": true,
-				"retryPolicy": {
-				  "MaxAttempts": 100,
-				  "InitialBackoff": ".5s",
-				  "MaxBackoff": "10s",
-				  "BackoffMultiplier": 1.5,
-				  "RetryableStatusCodes": ["UNAVAILABLE", "DEADLINE_EXCEEDED", "RESOURCE_EXHAUSTED", "INTERNAL", "UNKNOWN"]
-				}
-			  }]
-			}`
+	if len(errorMessages) > 0 {
+		return errors.New(errorConnection)
+	}
 
-			creds, certErr := credentials.NewClientTLSFromFile(server.CertFilePath, "")
-			if certErr != nil {
+	return nil
 
-				slog.Error("error while loading TLS credentials: ", "error", certErr, "server", server.Host, "fromIsland", server.FromIsland, "toIsland", server.ToIsland)
+}
 
-				errorMessages = append(errorMessages, certErr)
+// connection is the gRPC connection of a server and its service client
+type connection struct {
+	conn    *grpc.ClientConn
+	service hydraidepbgo.HydraideServiceClient
+}
 
-			}
+// close closes the gRPC connection and logs its error
+func (c *connection) close() {
+	if c.conn == nil {
+		return
+	}
+	if err := c.conn.Close(); err != nil {
+		slog.Error("error while closing connection", "error", err)
+	}
+}
 
-			var opts []grpc.DialOption
+// dialServer connects to the server and checks it with a Heartbeat.
+//
+// It loads the TLS credentials, sets the interceptors, the retry policy and the keepalive of the connection, and
+// negotiates the compression with the server.
+func (c *client) dialServer(server *Server, connectionLog bool) (*connection, error) {
 
-			opts = append(opts, grpc.WithTransportCredentials(creds))
-			opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(c.maxMessageSize)))
-			opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(c.maxMessageSize)))
-			opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfigJSON))
+	if connectionLog {
+		pingHost(server.Host)
+		grpclog.SetLoggerV2(grpclog.NewLoggerV2WithVerbosity(os.Stdout, os.Stderr, os.Stderr, 99))
+	}
 
-			// Add keepalive settings to prevent idle connections from being closed.
-			//
-			// Time: how often to send a ping when there's no ongoing data traffic.
-			// Timeout: how long to wait for a response before considering the connection dead.
-			// PermitWithoutStream: whether to allow keepalive pings even when there are no active RPC streams.
-			opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
-				Time:                60 * time.Second,
-				Timeout:             10 * time.Second,
-				PermitWithoutStream: false,
-			}))
+	serviceConfigJSON := `{
+	  "methodConfig": [{
+		"name": [{"service": "hydraidepbgo.HydraideService"}],
+		"waitForReady": true,
+		"retryPolicy": {
+		  "MaxAttempts": 100,
+		  "InitialBackoff": ".5s",
+		  "MaxBackoff": "10s",
+		  "BackoffMultiplier": 1.5,
+		  "RetryableStatusCodes": ["UNAVAILABLE", "DEADLINE_EXCEEDED", "RESOURCE_EXHAUSTED", "INTERNAL", "UNKNOWN"]
+		}
+	  }]
+	}`
 
-			var conn *grpc.ClientConn
-			var err error
+	creds, certErr := credentials.NewClientTLSFromFile(server.CertFilePath, "")
+	if certErr != nil {
+		slog.Error("error while loading TLS credentials: ", "error", certErr, "server", server.Host, "fromIsland", server.FromIsland, "toIsland", server.ToIsland)
+		return nil, certErr
+	}
 
-			conn, err = grpc.NewClient(server.Host, opts...)
-			if err != nil {
+	var opts []grpc.DialOption
 
-				slog.Error("error while connecting to the server: ", "error", err, "server", server.Host, "fromIsland", server.FromIsland, "toIsland", server.ToIsland)
+	opts = append(opts, grpc.WithTransportCredentials(creds))
+	opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(c.maxMessageSize)))
+	opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(c.maxMessageSize)))
+	opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfigJSON))
+	if server.TenantToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tenantCredentials{token: server.TenantToken}))
+	}
+	var interceptors []grpc.UnaryClientInterceptor
+	if len(c.middlewares) > 0 {
+		// the outermost interceptor, so a retrying middleware gets a fresh deadline for every attempt
+		interceptors = append(interceptors, middlewareInterceptor(server.Host, c.middlewares))
+	}
+	if c.deadlines.enabled() {
+		// inside the middlewares, so every attempt of a middleware gets its own deadline, and outside the others, so
+		// the deadline covers the built-in retries and the spans of the RPC
+		interceptors = append(interceptors, deadlineInterceptor(c.deadlines))
+		opts = append(opts, grpc.WithChainStreamInterceptor(deadlineStreamInterceptor(c.deadlines)))
+	}
+	if c.tracing {
+		interceptors = append(interceptors, tracingInterceptor(server.Host))
+	}
+	interceptors = append(interceptors, messageSizeInterceptor(c.maxMessageSize))
+	// the consistency tokens of the writes are collected only for the contexts with a collector
+	interceptors = append(interceptors, consistencyInterceptor())
+	opts = append(opts, grpc.WithChainStreamInterceptor(consistencyStreamInterceptor()))
+	// the topology discovered from the seeds is refreshed as soon as a server reports a moved Island
+	if c.seeds != nil {
+		interceptors = append(interceptors, islandMovedInterceptor(c))
+	}
+	// the compression is enabled after it is negotiated with the server
+	compressed := &atomic.Bool{}
+	if c.compression != "" {
+		interceptors = append(interceptors, compressionInterceptor(c.compression, compressed))
+		opts = append(opts, grpc.WithChainStreamInterceptor(compressionStreamInterceptor(c.compression, compressed)))
+	}
+	opts = append(opts, grpc.WithChainUnaryInterceptor(interceptors...))
 
-				errorMessages = append(errorMessages, err)
+	opts = append(opts, connectionOptions(server)...)
 
-				return
+	conn, err := grpc.NewClient(server.Host, opts...)
+	if err != nil {
+		slog.Error("error while connecting to the server: ", "error", err, "server", server.Host, "fromIsland", server.FromIsland, "toIsland", server.ToIsland)
+		return nil, err
+	}
 
-			}
+	serviceClient := hydraidepbgo.NewHydraideServiceClient(conn)
 
-			serviceClient := hydraidepbgo.NewHydraideServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
+	pong, err := serviceClient.Heartbeat(ctx, &hydraidepbgo.HeartbeatRequest{Ping: "beat"})
+	if err != nil || pong == nil || pong.Pong != "beat" {
 
-			pong, err := serviceClient.Heartbeat(ctx, &hydraidepbgo.HeartbeatRequest{Ping: "beat"})
-			if err != nil || pong == nil || pong.Pong != "beat" {
+		slog.Error("error while sending heartbeat request: ",
+			"error", err,
+			"server", server.Host,
+			"fromIsland", server.FromIsland,
+			"toIsland", server.ToIsland,
+			"pongMessage", pong)
 
-				slog.Error("error while sending heartbeat request: ",
-					"error", err,
-					"server", server.Host,
-					"fromIsland", server.FromIsland,
-					"toIsland", server.ToIsland,
-					"pongMessage", pong,
-					"errorMessages", errorMessages)
+		_ = conn.Close()
+		if err == nil {
+			err = fmt.Errorf("invalid heartbeat answer of the server %s", server.Host)
+		}
+		return nil, err
 
-				errorMessages = append(errorMessages, err)
+	}
 
-				return
-			}
+	if c.compression != "" {
+		compressed.Store(negotiateCompression(ctx, serviceClient, c.compression))
+	}
 
-			slog.Info("connected to the hydra server successfully")
+	slog.Info("connected to the hydra server successfully", "server", server.Host)
 
-			for island := server.FromIsland; island <= server.ToIsland; island++ {
-				c.serviceClients[island] = &ServiceClient{
-					GrpcClient: serviceClient,
-					Host:       server.Host,
-				}
-			}
+	return &connection{conn: conn, service: serviceClient}, nil
 
-			c.connections = append(c.connections, conn)
-			c.uniqueServices = append(c.uniqueServices, serviceClient)
+}
 
-		}()
+// route maps every Island of the servers to the connection of its server. The servers without a connection are
+// left out, so their Islands are not routed. The caller must hold the write lock
+func (c *client) route(servers []*Server, hosts map[string]*connection) {
 
-	}
+	c.serviceClients = make(map[uint64]*ServiceClient)
+	c.uniqueServices = nil
+	c.connections = nil
 
-	if len(errorMessages) > 0 {
-		return errors.New(errorConnection)
+	for _, server := range servers {
+		conn, ok := hosts[server.Host]
+		if !ok {
+			continue
+		}
+		serviceClient := &ServiceClient{
+			GrpcClient: conn.service,
+			Host:       server.Host,
+		}
+		for island := server.FromIsland; island <= server.ToIsland; island++ {
+			c.serviceClients[island] = serviceClient
+		}
 	}
 
-	return nil
+	for _, conn := range hosts {
+		c.connections = append(c.connections, conn.conn)
+		c.uniqueServices = append(c.uniqueServices, conn.service)
+	}
+	c.hosts = hosts
 
 }
 
@@ -298,6 +487,7 @@ func (c *client) Connect(connectionLog bool) error {
 // - Each connection is closed safely
 // - Any connection close errors are logged (but not returned)
 // - Internal connection list is cleaned up
+// - The refresh of the topology discovered from the seeds is stopped
 //
 // Typically called when the application is shutting down, or when reconnecting
 // with new configuration is required.
@@ -310,12 +500,14 @@ func (c *client) CloseConnection() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, conn := range c.connections {
-		if conn != nil {
-			if err := conn.Close(); err != nil {
-				slog.Error("error while closing connection", "error", err)
-			}
-		}
+	if c.stopRefresh != nil {
+		close(c.stopRefresh)
+		c.stopRefresh = nil
+	}
+	c.closed = true
+
+	for _, conn := range c.hosts {
+		conn.close()
 	}
 
 }
@@ -331,7 +523,7 @@ func (c *client) CloseConnection() {
 //
 // Returns:
 //   - hydraidepbgo.HydraideServiceClient (bound to the correct server)
-//   - nil if no client is registered for the calculated folder
+//   - a client failing every call with ErrNoServerForIsland (InvalidArgument) if no server serves the folder
 //
 // Example:
 //
@@ -359,16 +551,25 @@ func (c *client) GetServiceClient(swampName name.Name) hydraidepbgo.HydraideServ
 	}
 
 	slog.Error("error while getting service client by swamp name",
-		"swampName", swampName.Get())
-	return nil
+		"swampName", swampName.Get(),
+		"island", folderNumber,
+		"error", ErrNoServerForIsland)
+	return newUnroutableServiceClient(swampName.Get(), folderNumber)
 
 }
 
-// GetAllIslands returns the total number of Islands configured in the client.
+// GetAllIslands returns the total number of Islands configured in the client, or discovered from the seeds.
 func (c *client) GetAllIslands() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.allIslands
 }
 
+// GetMaxMessageSize returns the max size of a gRPC message in bytes, set in New
+func (c *client) GetMaxMessageSize() int {
+	return c.maxMessageSize
+}
+
 // GetServiceClientAndHost returns the full HydrAIDE service client wrapper for a given Swamp name.
 //
 // Unlike GetServiceClient(), which only returns the raw gRPC client,
@@ -383,7 +584,7 @@ func (c *client) GetAllIslands() uint64 {
 //   - `GrpcClient` → the actual gRPC HydrAIDEServiceClient
 //   - `Host`       → the Host string of the resolved server (e.g. IP:port or logical name)
 //
-// - nil if no matching server is registered for the calculated folder
+// - a client failing every call with ErrNoServerForIsland and an empty Host, if no server serves the folder
 //
 // Example:
 //
@@ -412,9 +613,12 @@ func (c *client) GetServiceClientAndHost(swampName name.Name) *ServiceClient {
 
 	slog.Error("error while getting service client by swamp name",
 		"swampName", swampName.Get(),
-		"error", errorNoConnection)
+		"island", folderNumber,
+		"error", ErrNoServerForIsland)
 
-	return nil
+	return &ServiceClient{
+		GrpcClient: newUnroutableServiceClient(swampName.Get(), folderNumber),
+	}
 
 }
 
@@ -482,3 +686,17 @@ func pingHost(hostnameOrIP string) {
 		}
 	}
 }
+
+// tenantCredentials sends the bearer token of the tenant with every RPC
+type tenantCredentials struct {
+	token string
+}
+
+func (t tenantCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity prevents sending the token over an unencrypted connection
+func (t tenantCredentials) RequireTransportSecurity() bool {
+	return true
+}
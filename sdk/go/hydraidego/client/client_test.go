@@ -58,3 +58,13 @@ func (c *mockedClient) GetServiceClient(swamp name.Name) hydraidepbgo.HydraideSe
 	folder := swamp.GetIslandID(c.allFolders)
 	return c.serviceClients[folder]
 }
+
+func TestTenantCredentials(t *testing.T) {
+
+	creds := tenantCredentials{token: "acme-token"}
+	md, err := creds.GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer acme-token"}, md)
+	assert.True(t, creds.RequireTransportSecurity())
+
+}
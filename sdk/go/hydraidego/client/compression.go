@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/compression"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"sync/atomic"
+)
+
+// WithCompression compresses the messages between the client and the servers with the given compressor,
+// compression.Zstd or compression.Gzip. An unknown compressor is ignored with an error log.
+//
+// The compressor is negotiated with every server at Connect: a compressed Heartbeat is sent, and if the server does
+// not support the compressor (an older server), the messages to that server are sent uncompressed, with a warning
+// log. The servers answer with the compressor of the request, so the responses are compressed, too.
+//
+// 💡 The compression cuts the bandwidth of the text-heavy treasures, e.g. product catalogs, between datacenters,
+// for some CPU time on both sides. On a local network the uncompressed transport is usually faster, see the
+// benchmarks of the compression package.
+//
+// Example:
+//
+//	c := client.New(servers, 1000, 104857600, client.WithCompression(compression.Zstd))
+func WithCompression(compressor string) Option {
+	return func(c *client) {
+		if !compression.IsSupported(compressor) {
+			slog.Error("the compressor is not supported, the messages are sent uncompressed", "compressor", compressor)
+			return
+		}
+		c.compression = compressor
+	}
+}
+
+// negotiateCompression sends a compressed Heartbeat to the server and returns true if the server accepted it
+func negotiateCompression(ctx context.Context, serviceClient hydraidepbgo.HydraideServiceClient, compressor string) bool {
+
+	pong, err := serviceClient.Heartbeat(ctx, &hydraidepbgo.HeartbeatRequest{Ping: "beat"}, grpc.UseCompressor(compressor))
+	if err == nil && pong != nil && pong.Pong == "beat" {
+		return true
+	}
+
+	// the servers without the compressor answer Unimplemented, the other errors are logged as they are
+	if status.Code(err) == codes.Unimplemented {
+		slog.Warn("the server does not support the compressor, the messages are sent uncompressed", "compressor", compressor, "error", err)
+	} else {
+		slog.Warn("the compressor can not be negotiated with the server, the messages are sent uncompressed", "compressor", compressor, "error", err)
+	}
+
+	return false
+
+}
+
+// compressionInterceptor returns a unary client interceptor that compresses the messages with the compressor, if the
+// compression is enabled for the server
+func compressionInterceptor(compressor string, enabled *atomic.Bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if enabled.Load() {
+			opts = append(opts, grpc.UseCompressor(compressor))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// compressionStreamInterceptor is the compressionInterceptor of the streams, e.g. the large values and the blobs
+func compressionStreamInterceptor(compressor string, enabled *atomic.Bool) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if enabled.Load() {
+			opts = append(opts, grpc.UseCompressor(compressor))
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
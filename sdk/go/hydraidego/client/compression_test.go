@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync/atomic"
+	"testing"
+)
+
+// heartbeatConn answers the Heartbeat like a server that supports only the given compressor
+type heartbeatConn struct {
+	supported string
+}
+
+func (h *heartbeatConn) Invoke(_ context.Context, _ string, req any, reply any, opts ...grpc.CallOption) error {
+	for _, opt := range opts {
+		if compressor, ok := opt.(grpc.CompressorCallOption); ok && compressor.CompressorType != h.supported {
+			return status.Errorf(codes.Unimplemented, "grpc: Decompressor is not installed for grpc-encoding %q", compressor.CompressorType)
+		}
+	}
+	reply.(*hydraidepbgo.HeartbeatResponse).Pong = req.(*hydraidepbgo.HeartbeatRequest).Ping
+	return nil
+}
+
+func (h *heartbeatConn) NewStream(_ context.Context, _ *grpc.StreamDesc, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func TestWithCompression(t *testing.T) {
+
+	t.Run("should set the supported compressor", func(t *testing.T) {
+		c := New(nil, 0, 0, WithCompression(compression.Zstd)).(*client)
+		assert.Equal(t, compression.Zstd, c.compression)
+	})
+
+	t.Run("should ignore the unknown compressor", func(t *testing.T) {
+		c := New(nil, 0, 0, WithCompression("snappy")).(*client)
+		assert.Empty(t, c.compression)
+	})
+
+}
+
+func TestNegotiateCompression(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("should enable the compressor supported by the server", func(t *testing.T) {
+		serviceClient := hydraidepbgo.NewHydraideServiceClient(&heartbeatConn{supported: compression.Zstd})
+		assert.True(t, negotiateCompression(ctx, serviceClient, compression.Zstd))
+	})
+
+	t.Run("should fall back to the uncompressed messages if the server does not support the compressor", func(t *testing.T) {
+		serviceClient := hydraidepbgo.NewHydraideServiceClient(&heartbeatConn{})
+		assert.False(t, negotiateCompression(ctx, serviceClient, compression.Zstd))
+	})
+
+}
+
+func TestCompressionInterceptor(t *testing.T) {
+
+	enabled := &atomic.Bool{}
+	interceptor := compressionInterceptor(compression.Zstd, enabled)
+
+	var sentOpts []grpc.CallOption
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sentOpts = opts
+		return nil
+	}
+
+	require.NoError(t, interceptor(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, nil, nil, nil, invoker))
+	assert.Empty(t, sentOpts, "the messages are not compressed before the negotiation")
+
+	enabled.Store(true)
+	require.NoError(t, interceptor(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, nil, nil, nil, invoker))
+	require.Len(t, sentOpts, 1)
+	assert.Equal(t, grpc.UseCompressor(compression.Zstd), sentOpts[0])
+
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"time"
+)
+
+const (
+	// DefaultKeepaliveTime is the idle time of a connection with an open RPC before the client pings the server
+	DefaultKeepaliveTime = 60 * time.Second
+	// DefaultKeepaliveTimeout is the time the client waits for the answer of its ping before it closes the connection
+	DefaultKeepaliveTimeout = 10 * time.Second
+)
+
+// connectionOptions returns the keepalive and the flow control dial options of the server
+func connectionOptions(server *Server) []grpc.DialOption {
+
+	keepaliveTime := server.KeepaliveTime
+	if keepaliveTime <= 0 {
+		keepaliveTime = DefaultKeepaliveTime
+	}
+	keepaliveTimeout := server.KeepaliveTimeout
+	if keepaliveTimeout <= 0 {
+		keepaliveTimeout = DefaultKeepaliveTimeout
+	}
+
+	// Add keepalive settings to prevent idle connections from being closed.
+	//
+	// Time: how often to send a ping when there's no ongoing data traffic.
+	// Timeout: how long to wait for a response before considering the connection dead.
+	// PermitWithoutStream: whether to allow keepalive pings even when there are no active RPC streams.
+	options := []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                keepaliveTime,
+			Timeout:             keepaliveTimeout,
+			PermitWithoutStream: server.KeepaliveWithoutStream,
+		}),
+	}
+
+	if server.InitialWindowSize > 0 {
+		options = append(options, grpc.WithInitialWindowSize(server.InitialWindowSize))
+	}
+	if server.InitialConnWindowSize > 0 {
+		options = append(options, grpc.WithInitialConnWindowSize(server.InitialConnWindowSize))
+	}
+
+	return options
+
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConnectionOptions(t *testing.T) {
+
+	t.Run("should set only the keepalive by default", func(t *testing.T) {
+		assert.Len(t, connectionOptions(&Server{Host: "hydra01:4444"}), 1)
+	})
+
+	t.Run("should set the flow control windows if they are configured", func(t *testing.T) {
+		assert.Len(t, connectionOptions(&Server{
+			Host:                  "hydra01:4444",
+			InitialWindowSize:     1 << 20,
+			InitialConnWindowSize: 1 << 22,
+		}), 3)
+	})
+
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/consistency"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"log/slog"
+)
+
+// collectToken adds the consistency token of the trailer to the collector
+func collectToken(collector *consistency.Collector, trailer metadata.MD) {
+	for _, value := range trailer.Get(consistency.Metadata) {
+		token, err := consistency.Parse(value)
+		if err != nil {
+			slog.Warn("the server returned an invalid consistency token", "error", err)
+			continue
+		}
+		collector.Add(token)
+	}
+}
+
+// consistencyInterceptor returns a unary client interceptor that collects the consistency tokens of the successful
+// writes, if the context of the call has a collector
+func consistencyInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		collector := consistency.CollectorFrom(ctx)
+		if collector == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var trailer metadata.MD
+		opts = append(opts, grpc.Trailer(&trailer))
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		collectToken(collector, trailer)
+
+		return nil
+
+	}
+}
+
+// consistencyStreamInterceptor is the consistencyInterceptor of the streams, e.g. the large values and the blobs
+func consistencyStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		collector := consistency.CollectorFrom(ctx)
+		if collector == nil {
+			return stream, nil
+		}
+
+		return &consistencyStream{ClientStream: stream, collector: collector, serverStreams: desc.ServerStreams}, nil
+
+	}
+}
+
+// consistencyStream collects the consistency token of the trailer when the stream is finished successfully
+type consistencyStream struct {
+	grpc.ClientStream
+	collector     *consistency.Collector
+	serverStreams bool
+}
+
+func (s *consistencyStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	// the response of a client stream is its last message, a server stream ends with io.EOF
+	if (err == nil && !s.serverStreams) || errors.Is(err, io.EOF) {
+		collectToken(s.collector, s.ClientStream.Trailer())
+	}
+	return err
+}
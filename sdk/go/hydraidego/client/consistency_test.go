@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/consistency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// trailerInvoker answers the call with the consistency token in the trailer
+func trailerInvoker(token string, err error) grpc.UnaryInvoker {
+	return func(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, opt := range opts {
+			if trailer, ok := opt.(grpc.TrailerCallOption); ok {
+				*trailer.TrailerAddr = metadata.Pairs(consistency.Metadata, token)
+			}
+		}
+		return err
+	}
+}
+
+func TestConsistencyInterceptor(t *testing.T) {
+
+	interceptor := consistencyInterceptor()
+
+	t.Run("should collect the tokens of the successful calls", func(t *testing.T) {
+
+		ctx, collector := consistency.WithCollector(context.Background())
+		require.NoError(t, interceptor(ctx, "/hydraidepbgo.HydraideService/Set", nil, nil, nil, trailerInvoker("a.10.1", nil)))
+		require.NoError(t, interceptor(ctx, "/hydraidepbgo.HydraideService/Set", nil, nil, nil, trailerInvoker("b.20.4", nil)))
+		assert.Equal(t, "a.10.1,b.20.4", collector.Token().String())
+
+		err := interceptor(ctx, "/hydraidepbgo.HydraideService/Set", nil, nil, nil, trailerInvoker("a.10.2", status.Error(codes.Unavailable, "down")))
+		assert.Error(t, err)
+		assert.Equal(t, "a.10.1,b.20.4", collector.Token().String(), "the failed calls are not collected")
+
+	})
+
+	t.Run("should not ask for the trailer without a collector", func(t *testing.T) {
+		invoker := func(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+			assert.Empty(t, opts)
+			return nil
+		}
+		assert.NoError(t, interceptor(context.Background(), "/hydraidepbgo.HydraideService/Set", nil, nil, nil, invoker))
+	})
+
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// Deadlines are the default timeouts of the RPCs by the class of the operation. The zero values mean no default.
+type Deadlines struct {
+	// Read is the timeout of the reads, e.g. Get, GetAll, Count, if the context of the call has no deadline
+	Read time.Duration
+	// Write is the timeout of the writes, e.g. Set, Delete, the increments and the locks, if the context of the call
+	// has no deadline
+	Write time.Duration
+	// Stream is the timeout of the large value and the blob transfers, if the context of the call has no deadline
+	Stream time.Duration
+	// Max is the hard limit of every RPC, even if the context of the call has a later deadline
+	Max time.Duration
+}
+
+// writeMethods are the unary RPCs of the Write class, the other unary RPCs are reads
+var writeMethods = map[string]struct{}{
+	hydraidepbgo.HydraideService_Lock_FullMethodName:                   {},
+	hydraidepbgo.HydraideService_Unlock_FullMethodName:                 {},
+	hydraidepbgo.HydraideService_RegisterSwamp_FullMethodName:          {},
+	hydraidepbgo.HydraideService_DeRegisterSwamp_FullMethodName:        {},
+	hydraidepbgo.HydraideService_Set_FullMethodName:                    {},
+	hydraidepbgo.HydraideService_ShiftExpiredTreasures_FullMethodName:  {},
+	hydraidepbgo.HydraideService_LeaseExpiredTreasures_FullMethodName:  {},
+	hydraidepbgo.HydraideService_AckLease_FullMethodName:               {},
+	hydraidepbgo.HydraideService_NackLease_FullMethodName:              {},
+	hydraidepbgo.HydraideService_Destroy_FullMethodName:                {},
+	hydraidepbgo.HydraideService_Delete_FullMethodName:                 {},
+	hydraidepbgo.HydraideService_Restore_FullMethodName:                {},
+	hydraidepbgo.HydraideService_RevertTo_FullMethodName:               {},
+	hydraidepbgo.HydraideService_Uint32SlicePush_FullMethodName:        {},
+	hydraidepbgo.HydraideService_Uint32SliceDelete_FullMethodName:      {},
+	hydraidepbgo.HydraideService_IncrementInt8_FullMethodName:          {},
+	hydraidepbgo.HydraideService_IncrementInt16_FullMethodName:         {},
+	hydraidepbgo.HydraideService_IncrementInt32_FullMethodName:         {},
+	hydraidepbgo.HydraideService_IncrementInt64_FullMethodName:         {},
+	hydraidepbgo.HydraideService_IncrementUint8_FullMethodName:         {},
+	hydraidepbgo.HydraideService_IncrementUint16_FullMethodName:        {},
+	hydraidepbgo.HydraideService_IncrementUint32_FullMethodName:        {},
+	hydraidepbgo.HydraideService_IncrementUint64_FullMethodName:        {},
+	hydraidepbgo.HydraideService_IncrementFloat32_FullMethodName:       {},
+	hydraidepbgo.HydraideService_IncrementFloat64_FullMethodName:       {},
+	hydraidepbgo.HydraideService_SetIfLater_FullMethodName:             {},
+	hydraidepbgo.HydraideService_AddDuration_FullMethodName:            {},
+	hydraidepbgo.HydraideService_SetStringIf_FullMethodName:            {},
+	hydraidepbgo.HydraideService_SetBoolIf_FullMethodName:              {},
+	hydraidepbgo.HydraideService_Uint32SliceCombineInto_FullMethodName: {},
+	hydraidepbgo.HydraideService_SetSwampAnnotation_FullMethodName:     {},
+	hydraidepbgo.HydraideService_CompactSwamp_FullMethodName:           {},
+	hydraidepbgo.HydraideService_RefBlob_FullMethodName:                {},
+	hydraidepbgo.HydraideService_CollectBlobGarbage_FullMethodName:     {},
+	hydraidepbgo.HydraideService_RestorePointInTime_FullMethodName:     {},
+}
+
+// subscriptionMethods are the streams open until the caller cancels them, so they get no deadline at all
+var subscriptionMethods = map[string]struct{}{
+	hydraidepbgo.HydraideService_SubscribeToEvents_FullMethodName: {},
+	hydraidepbgo.HydraideService_SubscribeToInfo_FullMethodName:   {},
+	hydraidepbgo.HydraideService_SubscribeAll_FullMethodName:      {},
+}
+
+// WithDeadlines sets the default timeouts of the RPCs whose context has no deadline, and the hard limit of all RPCs.
+//
+// Without a deadline, a call to a stuck server, e.g. a server with a full disk or a network partition without a
+// closed connection, blocks its goroutine forever, because the client waits for the server and retries the failed
+// calls. A forgotten context.WithTimeout is easy to miss, so the defaults are the safety net: the call fails with
+// a timeout error instead, where hydraidego.IsCtxTimeout(err) is true.
+//
+// ⚠️ The deadline of the context always wins over the default of the class, but not over the Max: a call with a
+// later deadline than the Max fails after the Max, too.
+// ⚠️ The subscriptions (Subscribe and SubscribeFrom) run until their context is cancelled, so they get
+// no deadline.
+// ⚠️ A Lock waits for the lock within the Write timeout, so a lock held longer than it can not be acquired without
+// an explicit deadline.
+//
+// Example:
+//
+//	c := client.New(servers, 1000, 104857600, client.WithDeadlines(client.Deadlines{
+//	    Read:   5 * time.Second,
+//	    Write:  10 * time.Second,
+//	    Stream: time.Minute,
+//	    Max:    5 * time.Minute,
+//	}))
+func WithDeadlines(deadlines Deadlines) Option {
+	return func(c *client) {
+		c.deadlines = deadlines
+	}
+}
+
+// enabled reports whether any deadline is set
+func (d Deadlines) enabled() bool {
+	return d.Read > 0 || d.Write > 0 || d.Stream > 0 || d.Max > 0
+}
+
+// timeout returns the default timeout of the unary RPC
+func (d Deadlines) timeout(method string) time.Duration {
+	if _, ok := writeMethods[method]; ok {
+		return d.Write
+	}
+	return d.Read
+}
+
+// withDeadline returns the context of the RPC with the default timeout, if the context has no deadline, limited by
+// the Max. The context is not changed if no deadline applies
+func (d Deadlines) withDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+
+	if deadline, ok := ctx.Deadline(); ok {
+		// the deadline of the caller wins, but not over the Max
+		if d.Max > 0 && time.Until(deadline) > d.Max {
+			return context.WithTimeout(ctx, d.Max)
+		}
+		return ctx, func() {}
+	}
+
+	if d.Max > 0 && (timeout <= 0 || timeout > d.Max) {
+		timeout = d.Max
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+
+}
+
+// deadlineInterceptor returns a unary client interceptor that applies the deadlines to the RPCs
+func deadlineInterceptor(deadlines Deadlines) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := deadlines.withDeadline(ctx, deadlines.timeout(method))
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// deadlineStreamInterceptor is the deadlineInterceptor of the streams. The deadline is released when the stream ends
+func deadlineStreamInterceptor(deadlines Deadlines) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+		if _, ok := subscriptionMethods[method]; ok {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		ctx, cancel := deadlines.withDeadline(ctx, deadlines.Stream)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		return &deadlineStream{ClientStream: stream, cancel: cancel, serverStreams: desc.ServerStreams}, nil
+
+	}
+}
+
+// deadlineStream releases the deadline of the stream after its last message
+type deadlineStream struct {
+	grpc.ClientStream
+	cancel        context.CancelFunc
+	serverStreams bool
+}
+
+func (s *deadlineStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	// the client streams end with their only response, the server streams with an error or io.EOF
+	if err != nil || !s.serverStreams {
+		s.cancel()
+	}
+	return err
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWithDeadlines(t *testing.T) {
+	c := New(nil, 0, 0, WithDeadlines(Deadlines{Read: time.Second})).(*client)
+	assert.Equal(t, time.Second, c.deadlines.Read)
+	assert.True(t, c.deadlines.enabled())
+	assert.False(t, Deadlines{}.enabled())
+}
+
+func TestDeadlineInterceptor(t *testing.T) {
+
+	deadlines := Deadlines{Read: 2 * time.Second, Write: 5 * time.Second, Max: 10 * time.Second}
+	interceptor := deadlineInterceptor(deadlines)
+
+	// remaining returns the time left from the deadline of the RPC, or zero if the RPC has no deadline
+	remaining := func(ctx context.Context, method string) time.Duration {
+		var left time.Duration
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			if deadline, ok := ctx.Deadline(); ok {
+				left = time.Until(deadline)
+			}
+			return nil
+		}
+		require.NoError(t, interceptor(ctx, method, nil, nil, nil, invoker))
+		return left
+	}
+
+	t.Run("should apply the default of the class if the context has no deadline", func(t *testing.T) {
+		assert.InDelta(t, float64(2*time.Second), float64(remaining(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName)), float64(100*time.Millisecond))
+		assert.InDelta(t, float64(5*time.Second), float64(remaining(context.Background(), hydraidepbgo.HydraideService_Set_FullMethodName)), float64(100*time.Millisecond))
+	})
+
+	t.Run("should keep the deadline of the caller", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 7*time.Second)
+		defer cancel()
+		assert.InDelta(t, float64(7*time.Second), float64(remaining(ctx, hydraidepbgo.HydraideService_Get_FullMethodName)), float64(100*time.Millisecond))
+	})
+
+	t.Run("should limit the deadline of the caller to the max", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		assert.InDelta(t, float64(10*time.Second), float64(remaining(ctx, hydraidepbgo.HydraideService_Get_FullMethodName)), float64(100*time.Millisecond))
+	})
+
+	t.Run("should apply the max if the class has no default", func(t *testing.T) {
+		i := deadlineInterceptor(Deadlines{Max: 3 * time.Second})
+		var left time.Duration
+		require.NoError(t, i(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				left = time.Until(deadline)
+				return nil
+			}))
+		assert.InDelta(t, float64(3*time.Second), float64(left), float64(100*time.Millisecond))
+	})
+
+	t.Run("should not set a deadline without a default", func(t *testing.T) {
+		i := deadlineInterceptor(Deadlines{Write: time.Second})
+		require.NoError(t, i(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				_, ok := ctx.Deadline()
+				assert.False(t, ok)
+				return nil
+			}))
+	})
+
+}
+
+// eofStream is a client stream that ends with io.EOF at the first RecvMsg
+type eofStream struct {
+	grpc.ClientStream
+}
+
+func (s *eofStream) RecvMsg(_ interface{}) error {
+	return io.EOF
+}
+
+func TestDeadlineStreamInterceptor(t *testing.T) {
+
+	interceptor := deadlineStreamInterceptor(Deadlines{Stream: time.Minute, Max: time.Hour})
+
+	var streamCtx context.Context
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamCtx = ctx
+		return &eofStream{}, nil
+	}
+
+	t.Run("should apply the stream default and release it at the end of the stream", func(t *testing.T) {
+
+		stream, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, hydraidepbgo.HydraideService_GetBlob_FullMethodName, streamer)
+		require.NoError(t, err)
+
+		deadline, ok := streamCtx.Deadline()
+		require.True(t, ok)
+		assert.InDelta(t, float64(time.Minute), float64(time.Until(deadline)), float64(time.Second))
+		assert.NoError(t, streamCtx.Err())
+
+		assert.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+		assert.ErrorIs(t, streamCtx.Err(), context.Canceled, "the deadline is released after the last message")
+
+	})
+
+	t.Run("should not set a deadline to the subscriptions", func(t *testing.T) {
+		_, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, hydraidepbgo.HydraideService_SubscribeToEvents_FullMethodName, streamer)
+		require.NoError(t, err)
+		_, ok := streamCtx.Deadline()
+		assert.False(t, ok)
+	})
+
+}
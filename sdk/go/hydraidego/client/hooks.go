@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// Call is a unary RPC passed to the middlewares and the hooks
+type Call struct {
+	// Operation is the name of the RPC, e.g. "Set", "Get" or "IncrementInt64"
+	Operation string
+	// SwampName is the first swamp of the request, empty if the request has no swamp, e.g. a Heartbeat
+	SwampName string
+	// SwampCount is the number of the swamps of the request, more than one in the batch requests
+	SwampCount int
+	// Host is the server of the RPC
+	Host string
+}
+
+// Middleware wraps a unary RPC. It must call next to send the RPC, and it may call it again, e.g. to retry the
+// failed calls, or not at all, e.g. to fail fast while a circuit breaker is open.
+//
+// The error is the gRPC status error of the server, e.g. status.Code(err) returns its code.
+type Middleware func(ctx context.Context, call *Call, next func(ctx context.Context) error) error
+
+// Hooks are called around every unary RPC. The nil hooks are skipped.
+type Hooks struct {
+	// OnBeforeCall is called before the RPC is sent. The returned context is used by the RPC and passed to the
+	// OnAfterCall, e.g. with a started span or a request ID. Nil means the context of the call
+	OnBeforeCall func(ctx context.Context, call *Call) context.Context
+	// OnAfterCall is called after the RPC returned, with the time it took and its gRPC status error
+	OnAfterCall func(ctx context.Context, call *Call, duration time.Duration, err error)
+}
+
+// WithMiddleware adds middlewares to the unary RPCs, so the metrics, the logging or a custom retry are plugged in
+// once for all functions of the SDK, instead of wrapping every function of the Hydraidego interface.
+//
+// The middlewares are called in the order they were added, the first one is the outermost. They run before the
+// deadlines of WithDeadlines, so a retrying middleware gets a fresh default deadline for every attempt, and before
+// the built-in retries of the connection, so next returns after they gave up.
+//
+// ⚠️ The streams, e.g. Subscribe, the large values and the blobs, are not passed to the middlewares.
+// ⚠️ The embedded engine and the fake are not behind a client, so they have no middlewares.
+//
+// Example:
+//
+//	retry := func(ctx context.Context, call *client.Call, next func(ctx context.Context) error) error {
+//	    err := next(ctx)
+//	    if status.Code(err) == codes.Aborted {
+//	        err = next(ctx)
+//	    }
+//	    return err
+//	}
+//
+//	c := client.New(servers, 1000, 104857600, client.WithMiddleware(retry))
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithHooks adds the hooks as a middleware, see WithMiddleware.
+//
+// Example:
+//
+//	c := client.New(servers, 1000, 104857600, client.WithHooks(client.Hooks{
+//	    OnAfterCall: func(ctx context.Context, call *client.Call, duration time.Duration, err error) {
+//	        callDuration.WithLabelValues(call.Operation, status.Code(err).String()).Observe(duration.Seconds())
+//	    },
+//	}))
+func WithHooks(hooks Hooks) Option {
+	return WithMiddleware(hooks.middleware)
+}
+
+// middleware calls the hooks around the RPC
+func (h Hooks) middleware(ctx context.Context, call *Call, next func(ctx context.Context) error) error {
+
+	if h.OnBeforeCall != nil {
+		if hookCtx := h.OnBeforeCall(ctx, call); hookCtx != nil {
+			ctx = hookCtx
+		}
+	}
+
+	started := time.Now()
+	err := next(ctx)
+
+	if h.OnAfterCall != nil {
+		h.OnAfterCall(ctx, call, time.Since(started), err)
+	}
+
+	return err
+
+}
+
+// middlewareInterceptor returns a unary client interceptor that passes the RPCs sent to the given server through the
+// middlewares
+func middlewareInterceptor(host string, middlewares []Middleware) grpc.UnaryClientInterceptor {
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		_, operation := splitMethod(method)
+		call := &Call{
+			Operation: operation,
+			Host:      host,
+		}
+		if message, ok := req.(proto.Message); ok {
+			summary := summarizeRequest(message)
+			call.SwampCount = summary.swampCount
+			if len(summary.swampNames) > 0 {
+				call.SwampName = summary.swampNames[0]
+			}
+		}
+
+		next := func(ctx context.Context) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		// the first middleware is the outermost, so the chain is built from the last one
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			middleware, inner := middlewares[i], next
+			next = func(ctx context.Context) error {
+				return middleware(ctx, call, inner)
+			}
+		}
+
+		return next(ctx)
+
+	}
+
+}
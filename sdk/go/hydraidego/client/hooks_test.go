@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestWithHooks(t *testing.T) {
+	c := New(nil, 0, 0, WithMiddleware(func(ctx context.Context, call *Call, next func(ctx context.Context) error) error {
+		return next(ctx)
+	}), WithHooks(Hooks{})).(*client)
+	assert.Len(t, c.middlewares, 2)
+}
+
+func TestMiddlewareInterceptor(t *testing.T) {
+
+	type contextKey struct{}
+
+	request := &hydraidepbgo.SetRequest{Swamps: []*hydraidepbgo.SwampRequest{
+		{IslandID: 3, SwampName: "users/profiles/alex"},
+		{IslandID: 4, SwampName: "users/profiles/bob"},
+	}}
+
+	t.Run("should call the hooks with the operation and the swamp", func(t *testing.T) {
+
+		var before, after *Call
+		var afterErr error
+		var afterValue any
+		hooks := Hooks{
+			OnBeforeCall: func(ctx context.Context, call *Call) context.Context {
+				before = call
+				return context.WithValue(ctx, contextKey{}, "request-1")
+			},
+			OnAfterCall: func(ctx context.Context, call *Call, duration time.Duration, err error) {
+				after = call
+				afterErr = err
+				afterValue = ctx.Value(contextKey{})
+			},
+		}
+
+		var invokedValue any
+		interceptor := middlewareInterceptor("hydra01:4444", []Middleware{hooks.middleware})
+		err := interceptor(context.Background(), hydraidepbgo.HydraideService_Set_FullMethodName, request, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				invokedValue = ctx.Value(contextKey{})
+				return status.Error(codes.NotFound, "swamp not found")
+			})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		require.NotNil(t, before)
+		assert.Same(t, before, after)
+		assert.Equal(t, "Set", before.Operation)
+		assert.Equal(t, "users/profiles/alex", before.SwampName)
+		assert.Equal(t, 2, before.SwampCount)
+		assert.Equal(t, "hydra01:4444", before.Host)
+		assert.Equal(t, codes.NotFound, status.Code(afterErr))
+		assert.Equal(t, "request-1", invokedValue, "the RPC gets the context of the OnBeforeCall")
+		assert.Equal(t, "request-1", afterValue)
+
+	})
+
+	t.Run("should call the middlewares in the order they were added", func(t *testing.T) {
+
+		var order []string
+		middleware := func(name string) Middleware {
+			return func(ctx context.Context, call *Call, next func(ctx context.Context) error) error {
+				order = append(order, name+" before")
+				err := next(ctx)
+				order = append(order, name+" after")
+				return err
+			}
+		}
+
+		interceptor := middlewareInterceptor("", []Middleware{middleware("first"), middleware("second")})
+		err := interceptor(context.Background(), hydraidepbgo.HydraideService_Heartbeat_FullMethodName, &hydraidepbgo.HeartbeatRequest{}, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				order = append(order, "invoke")
+				return nil
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first before", "second before", "invoke", "second after", "first after"}, order)
+
+	})
+
+	t.Run("should give every attempt of the middleware its own deadline", func(t *testing.T) {
+
+		var middlewareHasDeadline bool
+		retry := func(ctx context.Context, call *Call, next func(ctx context.Context) error) error {
+			_, middlewareHasDeadline = ctx.Deadline()
+			if err := next(ctx); status.Code(err) != codes.DeadlineExceeded {
+				return err
+			}
+			return next(ctx)
+		}
+
+		// chained the same way as by the dialServer: the middlewares first, the deadlines inside them
+		deadlines := deadlineInterceptor(Deadlines{Write: 50 * time.Millisecond})
+		attempts := 0
+		interceptor := middlewareInterceptor("", []Middleware{retry})
+		err := interceptor(context.Background(), hydraidepbgo.HydraideService_Set_FullMethodName, request, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return deadlines(ctx, method, req, reply, cc,
+					func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+						attempts++
+						if attempts == 1 {
+							<-ctx.Done()
+							return status.FromContextError(ctx.Err()).Err()
+						}
+						deadline, ok := ctx.Deadline()
+						require.True(t, ok)
+						assert.Greater(t, time.Until(deadline), 25*time.Millisecond, "the retry gets a fresh deadline")
+						return nil
+					}, opts...)
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.False(t, middlewareHasDeadline, "the middlewares run outside the deadline")
+
+	})
+
+	t.Run("should let the middleware retry the call", func(t *testing.T) {
+
+		retry := func(ctx context.Context, call *Call, next func(ctx context.Context) error) error {
+			err := next(ctx)
+			if status.Code(err) == codes.Aborted {
+				err = next(ctx)
+			}
+			return err
+		}
+
+		attempts := 0
+		interceptor := middlewareInterceptor("", []Middleware{retry})
+		err := interceptor(context.Background(), hydraidepbgo.HydraideService_Set_FullMethodName, request, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				attempts++
+				if attempts == 1 {
+					return status.Error(codes.Aborted, "conflict")
+				}
+				return nil
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+
+	})
+
+}
@@ -0,0 +1,352 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/grpc"
+	"io"
+	"log/slog"
+	"sort"
+)
+
+// ErrMigrationNeedsSeeds is returned by MigrateIslands if the client was created with fixed Island ranges. The
+// clients with fixed ranges do not follow the topology, so they would still send the requests of the moved Islands
+// to their old server.
+var ErrMigrationNeedsSeeds = errors.New("the islands can be migrated only by a client created with seeds")
+
+// MigrationReport is the result of MigrateIslands
+type MigrationReport struct {
+	// Islands are the migrated Islands, in the order of their migration
+	Islands []*IslandMigration
+	// TopologyVersion is the version of the cluster topology after the migration
+	TopologyVersion string
+}
+
+// IslandMigration is the result of the migration of one Island
+type IslandMigration struct {
+	IslandID uint64
+	// SourceHost is the host of the server the Island moved from
+	SourceHost string
+	// Swamps is the number of the migrated Swamps and blob folders
+	Swamps uint64
+	// Files is the number of the migrated files
+	Files uint64
+}
+
+// MigrateIslands moves the Islands from-to to the server of the target host, one by one, without downtime.
+//
+// 🚚 The steps of every Island:
+//  1. The Island is FROZEN on its current server: its writes are refused with a retryable error, which the SDK
+//     retries, while its reads are still served.
+//  2. The files of the Island are streamed from the current server to the target server by ExportIsland and
+//     ImportIsland.
+//  3. The topology of every server of the cluster is updated, so the Island is routed to the target server.
+//  4. The Island is MOVED on its old server, so the clients with the old topology get an ISLAND_MOVED error, and
+//     refresh their topology.
+//
+// The Islands already served by the target host are skipped. The target server must be reachable with the
+// certificate and the token of the seed with the same host, or of the first seed. It may be a new server without
+// any Island yet.
+//
+// If the export or the import fails, the Island is ACTIVE again on its current server, and the migration stops.
+// If the topology can not be updated on every server, the Island stays FROZEN on its current server, so no write
+// can be lost, and the error must be resolved by hand, e.g. by setting the same topology file on every server.
+//
+// ⚠️ The client must be created with seeds, otherwise ErrMigrationNeedsSeeds is returned. The clients with fixed
+// Island ranges get an ISLAND_MOVED error for the moved Islands, until they are created again with the new ranges.
+//
+// Example:
+//
+//	report, err := c.MigrateIslands(ctx, 401, 500, "hydra03:4444")
+func (c *client) MigrateIslands(ctx context.Context, fromIsland, toIsland uint64, targetHost string) (*MigrationReport, error) {
+
+	if c.seeds == nil {
+		return nil, ErrMigrationNeedsSeeds
+	}
+	c.mu.RLock()
+	allIslands := c.allIslands
+	c.mu.RUnlock()
+	if fromIsland < 1 || fromIsland > toIsland || toIsland > allIslands {
+		return nil, fmt.Errorf("%w: the range %d-%d must be within 1-%d", ErrInvalidIslandRanges, fromIsland, toIsland, allIslands)
+	}
+	if targetHost == "" {
+		return nil, fmt.Errorf("%w: the target host is empty", ErrInvalidIslandRanges)
+	}
+
+	target, dialed, err := c.migrationTarget(targetHost)
+	if err != nil {
+		return nil, err
+	}
+	if dialed {
+		// the refresh of the topology connects the target server again, if it got Islands
+		defer target.close()
+	}
+
+	report := &MigrationReport{}
+	for islandID := fromIsland; islandID <= toIsland; islandID++ {
+
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		c.mu.RLock()
+		source := c.serviceClients[islandID]
+		c.mu.RUnlock()
+		if source == nil {
+			return report, fmt.Errorf("the island %d has no connected server", islandID)
+		}
+		if source.Host == targetHost {
+			continue
+		}
+
+		migrated, version, err := c.migrateIsland(ctx, islandID, source, target.service, targetHost)
+		if err != nil {
+			return report, fmt.Errorf("can not migrate the island %d from %s to %s: %w", islandID, source.Host, targetHost, err)
+		}
+		report.Islands = append(report.Islands, migrated)
+		report.TopologyVersion = version
+
+		// the next island is routed by the new topology
+		if err := c.refreshTopology(); err != nil {
+			return report, fmt.Errorf("the island %d is migrated, but the topology of the client can not be refreshed: %w", islandID, err)
+		}
+
+		slog.Info("the island is migrated", "island", islandID, "from", source.Host, "to", targetHost,
+			"swamps", migrated.Swamps, "files", migrated.Files)
+
+	}
+
+	return report, nil
+
+}
+
+// migrationTarget returns the connection of the target host, and dials it if the client is not connected to it
+func (c *client) migrationTarget(targetHost string) (*connection, bool, error) {
+
+	c.mu.RLock()
+	conn, ok := c.hosts[targetHost]
+	c.mu.RUnlock()
+	if ok {
+		return conn, false, nil
+	}
+
+	servers := serversFromTopology(&hydraidepbgo.GetClusterTopologyResponse{
+		Servers: []*hydraidepbgo.ClusterServer{{Host: targetHost}},
+	}, c.seeds)
+	conn, err := c.dial(servers[0], false)
+	if err != nil {
+		return nil, false, fmt.Errorf("can not connect to the target server %s: %w", targetHost, err)
+	}
+
+	return conn, true, nil
+
+}
+
+// migrateIsland freezes the Island on its source server, copies it to the target server, routes it to the target
+// server on every server, and marks it as moved on the source server. It returns the new version of the topology
+func (c *client) migrateIsland(ctx context.Context, islandID uint64, source *ServiceClient, target hydraidepbgo.HydraideServiceClient, targetHost string) (*IslandMigration, string, error) {
+
+	if _, err := source.GrpcClient.SetIslandState(ctx, &hydraidepbgo.SetIslandStateRequest{
+		IslandID: islandID,
+		State:    hydraidepbgo.IslandState_FROZEN,
+	}); err != nil {
+		return nil, "", fmt.Errorf("can not freeze the island: %w", err)
+	}
+
+	imported, err := copyIsland(ctx, islandID, source.GrpcClient, target)
+	if err != nil {
+		// the island is served again by its source, the migration can be retried later
+		_, activateErr := source.GrpcClient.SetIslandState(context.WithoutCancel(ctx), &hydraidepbgo.SetIslandStateRequest{
+			IslandID: islandID,
+			State:    hydraidepbgo.IslandState_ACTIVE,
+		})
+		return nil, "", errors.Join(err, activateErr)
+	}
+
+	version, err := c.moveIslandInTopology(ctx, islandID, source.GrpcClient, target, targetHost)
+	if err != nil {
+		return nil, "", fmt.Errorf("the island is copied, but it stays frozen on its source, because the topology can not be updated: %w", err)
+	}
+
+	if _, err := source.GrpcClient.SetIslandState(ctx, &hydraidepbgo.SetIslandStateRequest{
+		IslandID: islandID,
+		State:    hydraidepbgo.IslandState_MOVED,
+		MovedTo:  targetHost,
+	}); err != nil {
+		return nil, "", fmt.Errorf("the island is routed to the target, but it can not be marked as moved on its source: %w", err)
+	}
+
+	return &IslandMigration{
+		IslandID:   islandID,
+		SourceHost: source.Host,
+		Swamps:     imported.GetSwamps(),
+		Files:      imported.GetFiles(),
+	}, version, nil
+
+}
+
+// copyIsland streams the archive of the Island from the ExportIsland of the source to the ImportIsland of the target
+func copyIsland(ctx context.Context, islandID uint64, source, target hydraidepbgo.HydraideServiceClient) (*hydraidepbgo.ImportIslandResponse, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	exportStream, err := source.ExportIsland(ctx, &hydraidepbgo.ExportIslandRequest{IslandID: islandID})
+	if err != nil {
+		return nil, fmt.Errorf("can not export the island: %w", err)
+	}
+	importStream, err := target.ImportIsland(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can not import the island: %w", err)
+	}
+
+	// the island ID is sent in the first message, even if the island has no file
+	if err := importStream.Send(&hydraidepbgo.ImportIslandRequest{IslandID: islandID}); err != nil {
+		return nil, importError(importStream, err)
+	}
+	for {
+		chunk, err := exportStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can not export the island: %w", err)
+		}
+		if err := importStream.Send(&hydraidepbgo.ImportIslandRequest{
+			Chunk:    chunk.GetChunk(),
+			Manifest: chunk.GetManifest(),
+		}); err != nil {
+			return nil, importError(importStream, err)
+		}
+	}
+
+	imported, err := importStream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("can not import the island: %w", err)
+	}
+
+	return imported, nil
+
+}
+
+// importError returns the error of the import stream. A failed Send returns io.EOF, and the real error of the
+// server is returned by the CloseAndRecv
+func importError(stream grpc.ClientStreamingClient[hydraidepbgo.ImportIslandRequest, hydraidepbgo.ImportIslandResponse], err error) error {
+	if errors.Is(err, io.EOF) {
+		_, err = stream.CloseAndRecv()
+	}
+	return fmt.Errorf("can not import the island: %w", err)
+}
+
+// moveIslandInTopology routes the Island to the target host in the topology of the source, and saves the new
+// topology on every server of it and on the target. It returns the version of the new topology
+func (c *client) moveIslandInTopology(ctx context.Context, islandID uint64, source, target hydraidepbgo.HydraideServiceClient, targetHost string) (string, error) {
+
+	current, err := fetchTopology(source)
+	if err != nil {
+		return "", fmt.Errorf("can not get the cluster topology: %w", err)
+	}
+	servers := moveIsland(current.GetServers(), islandID, targetHost)
+
+	c.mu.RLock()
+	services := make(map[string]hydraidepbgo.HydraideServiceClient, len(c.hosts)+1)
+	for host, conn := range c.hosts {
+		services[host] = conn.service
+	}
+	c.mu.RUnlock()
+	services[targetHost] = target
+
+	var version string
+	for _, host := range topologyHosts(current.GetServers(), targetHost) {
+
+		service, ok := services[host]
+		if !ok {
+			return "", fmt.Errorf("the server %s of the topology is not connected", host)
+		}
+
+		// every server is updated from its own version, so a server with an other topology file is not overwritten
+		serverTopology, err := fetchTopology(service)
+		expectedVersion := serverTopology.GetVersion()
+		switch {
+		case isTopologyNotConfigured(err):
+			// e.g. a new server without a valid topology file yet
+			expectedVersion = ""
+		case err != nil:
+			return "", fmt.Errorf("can not get the cluster topology of %s: %w", host, err)
+		case version != "" && expectedVersion == version:
+			// the server has the new topology already
+			continue
+		case expectedVersion != current.GetVersion():
+			return "", fmt.Errorf("the server %s has an other cluster topology (version %q) than the source (version %q)",
+				host, expectedVersion, current.GetVersion())
+		}
+
+		saved, err := service.SetClusterTopology(ctx, &hydraidepbgo.SetClusterTopologyRequest{
+			ExpectedVersion: expectedVersion,
+			AllIslands:      current.GetAllIslands(),
+			Servers:         servers,
+		})
+		if err != nil {
+			return "", fmt.Errorf("can not update the cluster topology of %s: %w", host, err)
+		}
+		version = saved.GetVersion()
+
+	}
+
+	return version, nil
+
+}
+
+// moveIsland returns the servers of the topology where the Island is served by the host. The range of the Island is
+// split out of the range of its current server, and the neighbouring ranges of the same host are merged
+func moveIsland(servers []*hydraidepbgo.ClusterServer, islandID uint64, host string) []*hydraidepbgo.ClusterServer {
+
+	var split []*hydraidepbgo.ClusterServer
+	for _, server := range servers {
+		from, to := server.GetFromIsland(), server.GetToIsland()
+		if islandID < from || islandID > to {
+			split = append(split, &hydraidepbgo.ClusterServer{Host: server.GetHost(), FromIsland: from, ToIsland: to})
+			continue
+		}
+		if from < islandID {
+			split = append(split, &hydraidepbgo.ClusterServer{Host: server.GetHost(), FromIsland: from, ToIsland: islandID - 1})
+		}
+		split = append(split, &hydraidepbgo.ClusterServer{Host: host, FromIsland: islandID, ToIsland: islandID})
+		if islandID < to {
+			split = append(split, &hydraidepbgo.ClusterServer{Host: server.GetHost(), FromIsland: islandID + 1, ToIsland: to})
+		}
+	}
+
+	// the ranges are merged in the order of their Islands
+	sort.Slice(split, func(i, j int) bool {
+		return split[i].GetFromIsland() < split[j].GetFromIsland()
+	})
+	var merged []*hydraidepbgo.ClusterServer
+	for _, server := range split {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].GetHost() == server.GetHost() && merged[last].GetToIsland()+1 == server.GetFromIsland() {
+			merged[last].ToIsland = server.GetToIsland()
+			continue
+		}
+		merged = append(merged, server)
+	}
+
+	return merged
+
+}
+
+// topologyHosts returns the hosts of the servers and the target host, each once
+func topologyHosts(servers []*hydraidepbgo.ClusterServer, targetHost string) []string {
+	seen := make(map[string]struct{}, len(servers)+1)
+	var hosts []string
+	for _, server := range append(servers, &hydraidepbgo.ClusterServer{Host: targetHost}) {
+		if _, ok := seen[server.GetHost()]; ok {
+			continue
+		}
+		seen[server.GetHost()] = struct{}{}
+		hosts = append(hosts, server.GetHost())
+	}
+	return hosts
+}
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// migrationService is a fake server with its own topology file and island states
+type migrationService struct {
+	hydraidepbgo.HydraideServiceClient
+	mu        sync.Mutex
+	topology  *hydraidepbgo.GetClusterTopologyResponse
+	states    map[uint64]*hydraidepbgo.SetIslandStateRequest
+	imported  map[uint64]string
+	importErr error
+}
+
+func (s *migrationService) GetClusterTopology(_ context.Context, _ *hydraidepbgo.GetClusterTopologyRequest, _ ...grpc.CallOption) (*hydraidepbgo.GetClusterTopologyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.topology, nil
+}
+
+func (s *migrationService) SetClusterTopology(_ context.Context, in *hydraidepbgo.SetClusterTopologyRequest, _ ...grpc.CallOption) (*hydraidepbgo.SetClusterTopologyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if in.GetExpectedVersion() != s.topology.GetVersion() {
+		return nil, status.Error(codes.Aborted, "conflict")
+	}
+	// the version depends only on the content, like the hash of the topology file
+	ranges := make([]string, 0, len(in.GetServers()))
+	for _, server := range in.GetServers() {
+		ranges = append(ranges, fmt.Sprintf("%s:%d-%d", server.GetHost(), server.GetFromIsland(), server.GetToIsland()))
+	}
+	s.topology = &hydraidepbgo.GetClusterTopologyResponse{
+		Version:    strings.Join(ranges, ","),
+		AllIslands: in.GetAllIslands(),
+		Servers:    in.GetServers(),
+	}
+	return &hydraidepbgo.SetClusterTopologyResponse{Version: s.topology.Version}, nil
+}
+
+func (s *migrationService) SetIslandState(_ context.Context, in *hydraidepbgo.SetIslandStateRequest, _ ...grpc.CallOption) (*hydraidepbgo.SetIslandStateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[in.GetIslandID()] = in
+	return &hydraidepbgo.SetIslandStateResponse{}, nil
+}
+
+func (s *migrationService) state(islandID uint64) hydraidepbgo.IslandState_State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[islandID].GetState()
+}
+
+func (s *migrationService) ExportIsland(_ context.Context, in *hydraidepbgo.ExportIslandRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[hydraidepbgo.ExportIslandResponse], error) {
+	return &exportStream{messages: []*hydraidepbgo.ExportIslandResponse{
+		{Chunk: []byte(fmt.Sprintf("island-%d-", in.GetIslandID()))},
+		{Chunk: []byte("files")},
+		{Manifest: []byte("{}")},
+	}}, nil
+}
+
+func (s *migrationService) ImportIsland(_ context.Context, _ ...grpc.CallOption) (grpc.ClientStreamingClient[hydraidepbgo.ImportIslandRequest, hydraidepbgo.ImportIslandResponse], error) {
+	return &importStream{service: s}, nil
+}
+
+type exportStream struct {
+	grpc.ClientStream
+	messages []*hydraidepbgo.ExportIslandResponse
+}
+
+func (s *exportStream) Recv() (*hydraidepbgo.ExportIslandResponse, error) {
+	if len(s.messages) == 0 {
+		return nil, io.EOF
+	}
+	message := s.messages[0]
+	s.messages = s.messages[1:]
+	return message, nil
+}
+
+type importStream struct {
+	grpc.ClientStream
+	service  *migrationService
+	islandID uint64
+	archive  strings.Builder
+	manifest bool
+}
+
+func (s *importStream) Send(in *hydraidepbgo.ImportIslandRequest) error {
+	if s.islandID == 0 {
+		s.islandID = in.GetIslandID()
+	}
+	s.archive.Write(in.GetChunk())
+	s.manifest = s.manifest || len(in.GetManifest()) > 0
+	return nil
+}
+
+func (s *importStream) CloseAndRecv() (*hydraidepbgo.ImportIslandResponse, error) {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+	if s.service.importErr != nil {
+		return nil, s.service.importErr
+	}
+	if !s.manifest {
+		return nil, errors.New("the manifest is missing")
+	}
+	s.service.imported[s.islandID] = s.archive.String()
+	return &hydraidepbgo.ImportIslandResponse{Swamps: 1, Files: 2}, nil
+}
+
+func newMigrationCluster() map[string]*migrationService {
+	services := map[string]*migrationService{}
+	for _, host := range []string{"hydra01:4444", "hydra02:4444", "hydra03:4444"} {
+		services[host] = &migrationService{
+			topology: twoServerTopology(),
+			states:   map[uint64]*hydraidepbgo.SetIslandStateRequest{},
+			imported: map[uint64]string{},
+		}
+	}
+	return services
+}
+
+func newMigrationClient(t *testing.T, services map[string]*migrationService) *client {
+	c := New([]*Server{{Host: "hydra01:4444"}}, 0, 0, WithTopologyRefresh(0)).(*client)
+	c.dial = func(server *Server, _ bool) (*connection, error) {
+		return &connection{service: services[server.Host]}, nil
+	}
+	require.NoError(t, c.Connect(false))
+	return c
+}
+
+func TestClient_MigrateIslands(t *testing.T) {
+
+	t.Run("should move the islands to the new server and route them there", func(t *testing.T) {
+
+		services := newMigrationCluster()
+		c := newMigrationClient(t, services)
+
+		report, err := c.MigrateIslands(context.Background(), 4, 6, "hydra03:4444")
+		require.NoError(t, err)
+		require.Len(t, report.Islands, 3)
+		assert.Equal(t, "hydra01:4444", report.Islands[0].SourceHost)
+		assert.Equal(t, "hydra02:4444", report.Islands[2].SourceHost)
+		assert.Equal(t, uint64(2), report.Islands[0].Files)
+
+		expected := []*hydraidepbgo.ClusterServer{
+			{Host: "hydra01:4444", FromIsland: 1, ToIsland: 3},
+			{Host: "hydra03:4444", FromIsland: 4, ToIsland: 6},
+			{Host: "hydra02:4444", FromIsland: 7, ToIsland: 10},
+		}
+		for host, service := range services {
+			assert.Equal(t, report.TopologyVersion, service.topology.GetVersion(), host)
+			assert.Equal(t, expected, service.topology.GetServers(), host)
+		}
+
+		assert.Equal(t, "island-4-files", services["hydra03:4444"].imported[4])
+		assert.Len(t, services["hydra03:4444"].imported, 3)
+		assert.Equal(t, hydraidepbgo.IslandState_MOVED, services["hydra01:4444"].state(5))
+		assert.Equal(t, "hydra03:4444", services["hydra02:4444"].states[6].GetMovedTo())
+		assert.Equal(t, report.TopologyVersion, c.topologyVersion)
+		assert.Equal(t, "hydra03:4444", c.serviceClients[5].Host)
+
+		again, err := c.MigrateIslands(context.Background(), 4, 6, "hydra03:4444")
+		require.NoError(t, err)
+		assert.Empty(t, again.Islands, "the islands of the target are skipped")
+
+	})
+
+	t.Run("should activate the island again if the import fails", func(t *testing.T) {
+
+		services := newMigrationCluster()
+		services["hydra03:4444"].importErr = status.Error(codes.FailedPrecondition, "the island already has files")
+		c := newMigrationClient(t, services)
+
+		report, err := c.MigrateIslands(context.Background(), 2, 3, "hydra03:4444")
+		assert.ErrorContains(t, err, "the island already has files")
+		assert.Empty(t, report.Islands)
+		assert.Equal(t, hydraidepbgo.IslandState_ACTIVE, services["hydra01:4444"].state(2))
+		assert.Equal(t, "v1", services["hydra01:4444"].topology.GetVersion())
+		assert.Equal(t, "hydra01:4444", c.serviceClients[2].Host)
+
+	})
+
+	t.Run("should refuse the clients without seeds and the invalid ranges", func(t *testing.T) {
+
+		fixed := New([]*Server{{Host: "hydra01:4444", FromIsland: 1, ToIsland: 10}}, 10, 0)
+		_, err := fixed.MigrateIslands(context.Background(), 1, 2, "hydra02:4444")
+		assert.ErrorIs(t, err, ErrMigrationNeedsSeeds)
+
+		c := newMigrationClient(t, newMigrationCluster())
+		_, err = c.MigrateIslands(context.Background(), 5, 11, "hydra03:4444")
+		assert.ErrorIs(t, err, ErrInvalidIslandRanges)
+		_, err = c.MigrateIslands(context.Background(), 1, 2, "")
+		assert.ErrorIs(t, err, ErrInvalidIslandRanges)
+
+	})
+
+}
+
+func TestClient_IslandMovedInterceptor(t *testing.T) {
+
+	services := newMigrationCluster()
+	c := newMigrationClient(t, services)
+	for _, service := range services {
+		_, err := service.SetClusterTopology(context.Background(), &hydraidepbgo.SetClusterTopologyRequest{
+			ExpectedVersion: "v1",
+			AllIslands:      10,
+			Servers:         []*hydraidepbgo.ClusterServer{{Host: "hydra02:4444", FromIsland: 1, ToIsland: 10}},
+		})
+		require.NoError(t, err)
+	}
+
+	st, err := status.New(codes.FailedPrecondition, "the island 1 moved to hydra02:4444").WithDetails(&errdetails.ErrorInfo{
+		Reason: hydraidepbgo.ErrorReason_ISLAND_MOVED.String(),
+		Domain: errorDomain,
+	})
+	require.NoError(t, err)
+	interceptor := islandMovedInterceptor(c)
+
+	err = interceptor(context.Background(), "/hydraidepbgo.HydraideService/Set", nil, nil, nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return st.Err()
+		})
+	assert.Equal(t, st.Err(), err, "the error is returned to the caller")
+	assert.Equal(t, "hydra02:4444", c.serviceClients[1].Host, "the topology is refreshed")
+
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+)
+
+// errorDomain is the domain of the ErrorInfo details, the same as the server uses
+const errorDomain = "hydraide"
+
+// ErrInvalidIslandRanges is returned by Connect if the Island ranges of the servers do not cover every Island from
+// 1 to allIslands exactly once.
+var ErrInvalidIslandRanges = errors.New("invalid island ranges")
+
+// ErrNoServerForIsland is the error of the requests sent to a Swamp whose Island is not served by any connected
+// server. The SDK returns it as an ErrCodeInvalidArgument error.
+var ErrNoServerForIsland = errors.New("no server is configured for the island of the swamp")
+
+// ValidateIslandRanges checks that the Island ranges of the servers cover every Island from 1 to allIslands,
+// without gaps and without overlaps.
+//
+// A gap means that the Swamps hashed to the missing Islands can not be reached, an overlap means that the same
+// Swamp may be written to two different servers. Both are configuration errors, so New logs them and Connect
+// returns them wrapped in ErrInvalidIslandRanges.
+func ValidateIslandRanges(servers []*Server, allIslands uint64) error {
+
+	if allIslands == 0 {
+		return fmt.Errorf("%w: the number of all islands must be greater than 0", ErrInvalidIslandRanges)
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("%w: no server is configured", ErrInvalidIslandRanges)
+	}
+
+	sorted := make([]*Server, 0, len(servers))
+	for _, server := range servers {
+		if server == nil {
+			return fmt.Errorf("%w: the server can not be nil", ErrInvalidIslandRanges)
+		}
+		if server.FromIsland < 1 || server.FromIsland > server.ToIsland || server.ToIsland > allIslands {
+			return fmt.Errorf("%w: the range %d-%d of the server %s must be within 1-%d",
+				ErrInvalidIslandRanges, server.FromIsland, server.ToIsland, server.Host, allIslands)
+		}
+		sorted = append(sorted, server)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].FromIsland < sorted[j].FromIsland
+	})
+
+	next := uint64(1)
+	for i, server := range sorted {
+		if server.FromIsland > next {
+			return fmt.Errorf("%w: the islands %d-%d are not served by any server", ErrInvalidIslandRanges, next, server.FromIsland-1)
+		}
+		if server.FromIsland < next {
+			return fmt.Errorf("%w: the range %d-%d of the server %s overlaps the range of the server %s",
+				ErrInvalidIslandRanges, server.FromIsland, server.ToIsland, server.Host, sorted[i-1].Host)
+		}
+		next = server.ToIsland + 1
+	}
+
+	if next <= allIslands {
+		return fmt.Errorf("%w: the islands %d-%d are not served by any server", ErrInvalidIslandRanges, next, allIslands)
+	}
+
+	return nil
+
+}
+
+// newUnroutableServiceClient returns a service client for a Swamp that can not be routed to any server.
+//
+// Every call of the client fails with an InvalidArgument status, so a misconfigured client returns a normal SDK
+// error instead of panicking on a nil client deep in a call.
+func newUnroutableServiceClient(swampName string, islandID uint64) hydraidepbgo.HydraideServiceClient {
+	return hydraidepbgo.NewHydraideServiceClient(&unroutableConn{
+		swampName: swampName,
+		islandID:  islandID,
+	})
+}
+
+// unroutableConn is a gRPC connection that fails every call without sending anything
+type unroutableConn struct {
+	swampName string
+	islandID  uint64
+}
+
+func (u *unroutableConn) Invoke(_ context.Context, _ string, _ any, _ any, _ ...grpc.CallOption) error {
+	return u.err()
+}
+
+func (u *unroutableConn) NewStream(_ context.Context, _ *grpc.StreamDesc, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, u.err()
+}
+
+func (u *unroutableConn) err() error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("%v: swamp %s, island %d", ErrNoServerForIsland, u.swampName, u.islandID))
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: hydraidepbgo.ErrorReason_INVALID_ARGUMENT.String(),
+		Domain: errorDomain,
+	}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
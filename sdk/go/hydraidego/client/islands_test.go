@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestValidateIslandRanges(t *testing.T) {
+
+	t.Run("should accept the ranges covering every island once", func(t *testing.T) {
+		assert.NoError(t, ValidateIslandRanges([]*Server{
+			{Host: "hydra02", FromIsland: 501, ToIsland: 1000},
+			{Host: "hydra01", FromIsland: 1, ToIsland: 500},
+		}, 1000))
+		assert.NoError(t, ValidateIslandRanges([]*Server{{Host: "hydra01", FromIsland: 1, ToIsland: 1}}, 1))
+	})
+
+	t.Run("should reject the misconfigured ranges", func(t *testing.T) {
+
+		tests := map[string]struct {
+			servers    []*Server
+			allIslands uint64
+		}{
+			"no servers":       {nil, 1000},
+			"zero islands":     {[]*Server{{Host: "hydra01", FromIsland: 1, ToIsland: 1}}, 0},
+			"nil server":       {[]*Server{nil}, 1000},
+			"zero from island": {[]*Server{{Host: "hydra01", FromIsland: 0, ToIsland: 1000}}, 1000},
+			"reversed range":   {[]*Server{{Host: "hydra01", FromIsland: 1000, ToIsland: 1}}, 1000},
+			"out of range":     {[]*Server{{Host: "hydra01", FromIsland: 1, ToIsland: 1001}}, 1000},
+			"gap at the start": {[]*Server{{Host: "hydra01", FromIsland: 2, ToIsland: 1000}}, 1000},
+			"gap in the middle": {[]*Server{
+				{Host: "hydra01", FromIsland: 1, ToIsland: 499},
+				{Host: "hydra02", FromIsland: 501, ToIsland: 1000},
+			}, 1000},
+			"gap at the end": {[]*Server{{Host: "hydra01", FromIsland: 1, ToIsland: 999}}, 1000},
+			"overlap": {[]*Server{
+				{Host: "hydra01", FromIsland: 1, ToIsland: 500},
+				{Host: "hydra02", FromIsland: 500, ToIsland: 1000},
+			}, 1000},
+		}
+
+		for testName, test := range tests {
+			assert.ErrorIs(t, ValidateIslandRanges(test.servers, test.allIslands), ErrInvalidIslandRanges, testName)
+		}
+
+	})
+
+	t.Run("should refuse to connect with misconfigured ranges", func(t *testing.T) {
+		c := New([]*Server{{Host: "hydra01", FromIsland: 1, ToIsland: 10}}, 1000, 1024)
+		assert.ErrorIs(t, c.Connect(false), ErrInvalidIslandRanges)
+	})
+
+}
+
+func TestClient_GetServiceClientWithoutServer(t *testing.T) {
+
+	c := New([]*Server{{Host: "hydra01", FromIsland: 1, ToIsland: 1000}}, 1000, 1024)
+	swamp := name.New().Sanctuary("users").Realm("profiles").Swamp("john.doe")
+
+	// the client is not connected, so no island is served
+	serviceClient := c.GetServiceClient(swamp)
+	if assert.NotNil(t, serviceClient) {
+
+		_, err := serviceClient.Get(context.Background(), &hydraidepbgo.GetRequest{})
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), ErrNoServerForIsland.Error())
+
+		var reason string
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+				reason = info.GetReason()
+			}
+		}
+		assert.Equal(t, hydraidepbgo.ErrorReason_INVALID_ARGUMENT.String(), reason)
+
+	}
+
+	serviceClientAndHost := c.GetServiceClientAndHost(swamp)
+	if assert.NotNil(t, serviceClientAndHost) {
+		assert.Equal(t, "", serviceClientAndHost.Host)
+		_, err := serviceClientAndHost.GrpcClient.Set(context.Background(), &hydraidepbgo.SetRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"strings"
+)
+
+// grpcMessageTooLarge is the part of the status message gRPC uses, if a message is larger than the max size,
+// e.g. "grpc: received message larger than max (5242880 vs. 4194304)"
+const grpcMessageTooLarge = "larger than max"
+
+// messageSizeInterceptor returns a unary client interceptor that guards the max message size of the connection.
+//
+// A request larger than the max size is not sent at all, the call fails with a MESSAGE_TOO_LARGE reason that
+// contains the actual and the max size. The opaque ResourceExhausted errors of gRPC, returned if the server
+// rejects the request or the response is too large for the client, get the same reason, so the SDK reports all
+// of them as ErrCodeMessageTooLarge.
+func messageSizeInterceptor(maxMessageSize int) grpc.UnaryClientInterceptor {
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		if message, ok := req.(proto.Message); ok && maxMessageSize > 0 {
+			if size := proto.Size(message); size > maxMessageSize {
+				return messageTooLargeError(fmt.Sprintf("the request of %s is %d bytes, larger than the max message size of %d bytes of the client",
+					method, size, maxMessageSize))
+			}
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+
+		st, ok := status.FromError(err)
+		if !ok || st.Code() != codes.ResourceExhausted || len(st.Details()) > 0 || !strings.Contains(st.Message(), grpcMessageTooLarge) {
+			return err
+		}
+
+		return messageTooLargeError(fmt.Sprintf("%s: %s, split the request or raise the max message size of the client and the server (GRPC_MAX_MESSAGE_SIZE)",
+			method, st.Message()))
+
+	}
+
+}
+
+// messageTooLargeError returns a ResourceExhausted status with the MESSAGE_TOO_LARGE reason
+func messageTooLargeError(message string) error {
+	st := status.New(codes.ResourceExhausted, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: hydraidepbgo.ErrorReason_MESSAGE_TOO_LARGE.String(),
+		Domain: errorDomain,
+	}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
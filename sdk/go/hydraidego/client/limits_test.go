@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestMessageSizeInterceptor(t *testing.T) {
+
+	reasonOf := func(err error) string {
+		for _, detail := range status.Convert(err).Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+				return info.GetReason()
+			}
+		}
+		return ""
+	}
+
+	req := &hydraidepbgo.GetRequest{Swamps: []*hydraidepbgo.GetSwamp{
+		{IslandID: 3, SwampName: "users/profiles/alex", Keys: []string{"a", "b"}},
+	}}
+
+	t.Run("should not send a request larger than the max message size", func(t *testing.T) {
+
+		invoked := false
+		err := messageSizeInterceptor(10)(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, req, &hydraidepbgo.GetResponse{}, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				invoked = true
+				return nil
+			})
+
+		require.Error(t, err)
+		assert.False(t, invoked)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, hydraidepbgo.ErrorReason_MESSAGE_TOO_LARGE.String(), reasonOf(err))
+		assert.Contains(t, status.Convert(err).Message(), "larger than the max message size of 10 bytes")
+
+	})
+
+	t.Run("should add the reason to the message size errors of gRPC", func(t *testing.T) {
+
+		err := messageSizeInterceptor(1024)(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, req, &hydraidepbgo.GetResponse{}, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return status.Error(codes.ResourceExhausted, "grpc: received message larger than max (5242880 vs. 1024)")
+			})
+
+		assert.Equal(t, hydraidepbgo.ErrorReason_MESSAGE_TOO_LARGE.String(), reasonOf(err))
+		assert.Contains(t, status.Convert(err).Message(), "5242880 vs. 1024")
+
+	})
+
+	t.Run("should keep the other errors", func(t *testing.T) {
+
+		quotaErr := status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		err := messageSizeInterceptor(1024)(context.Background(), hydraidepbgo.HydraideService_Get_FullMethodName, req, &hydraidepbgo.GetResponse{}, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return quotaErr
+			})
+
+		assert.Equal(t, quotaErr, err)
+
+	})
+
+}
@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+// DefaultTopologyRefresh is the default interval between two checks of the topology discovered from the seeds
+const DefaultTopologyRefresh = 30 * time.Second
+
+// topologyTimeout is the timeout of a GetClusterTopology call
+const topologyTimeout = 5 * time.Second
+
+// ErrClusterTopologyNotConfigured is returned by Connect if the seeds have no valid topology file, so the servers
+// can not be discovered. Set the HYDRAIDE_CLUSTER_TOPOLOGY_FILE of the servers, or create the client with the Island
+// ranges of the servers.
+var ErrClusterTopologyNotConfigured = errors.New("the cluster topology is not configured on the seed servers")
+
+// WithTopologyRefresh sets the interval between two checks of the cluster topology, if the client was created with
+// seeds. The client asks a connected server for the topology, and if its version changed, it connects the new
+// servers, routes the Islands by the new ranges, and closes the connections of the removed servers. Zero disables
+// the refresh, so the topology of Connect is used until the client is closed.
+//
+// ⚠️ The number of all Islands can not change while the cluster has data. A topology with a different number of all
+// Islands is refused with an error log, and the client keeps the previous topology.
+//
+// Example:
+//
+//	c := client.New(seeds, 0, 104857600, client.WithTopologyRefresh(10*time.Second))
+func WithTopologyRefresh(interval time.Duration) Option {
+	return func(c *client) {
+		c.topologyRefresh = max(interval, 0)
+	}
+}
+
+// discoverTopology fetches the topology from the first reachable seed, and sets the servers of the client by it
+func (c *client) discoverTopology(connectionLog bool) error {
+
+	topology, err := c.topologyFromSeeds(connectionLog)
+	if err != nil {
+		return err
+	}
+
+	servers := serversFromTopology(topology, c.seeds)
+	if err := ValidateIslandRanges(servers, topology.GetAllIslands()); err != nil {
+		slog.Error("the cluster topology of the seeds is invalid", "error", err)
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.servers = servers
+	c.allIslands = topology.GetAllIslands()
+	c.topologyVersion = topology.GetVersion()
+
+	slog.Info("the cluster topology is discovered from the seeds", "version", topology.GetVersion(), "servers", len(servers))
+
+	return nil
+
+}
+
+// topologyFromSeeds asks the seeds for the topology, one by one, until a seed answers
+func (c *client) topologyFromSeeds(connectionLog bool) (*hydraidepbgo.GetClusterTopologyResponse, error) {
+
+	var lastErr error
+	for _, seed := range c.seeds {
+
+		conn, err := c.dial(seed, connectionLog)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		topology, err := fetchTopology(conn.service)
+		conn.close()
+		if isTopologyNotConfigured(err) {
+			err = fmt.Errorf("%w: %v", ErrClusterTopologyNotConfigured, err)
+		}
+		if err != nil {
+			slog.Warn("can not get the cluster topology from the seed", "seed", seed.Host, "error", err)
+			lastErr = err
+			continue
+		}
+
+		return topology, nil
+
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no seed server is configured")
+	}
+	return nil, fmt.Errorf("can not get the cluster topology from any seed: %w", lastErr)
+
+}
+
+// fetchTopology calls the GetClusterTopology of the server
+func fetchTopology(service hydraidepbgo.HydraideServiceClient) (*hydraidepbgo.GetClusterTopologyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), topologyTimeout)
+	defer cancel()
+	return service.GetClusterTopology(ctx, &hydraidepbgo.GetClusterTopologyRequest{})
+}
+
+// isTopologyNotConfigured reports whether the error has the CLUSTER_TOPOLOGY_NOT_CONFIGURED reason
+func isTopologyNotConfigured(err error) bool {
+	return hasErrorReason(err, hydraidepbgo.ErrorReason_CLUSTER_TOPOLOGY_NOT_CONFIGURED)
+}
+
+// hasErrorReason reports whether the gRPC error of the server has the reason
+func hasErrorReason(err error, reason hydraidepbgo.ErrorReason_Reason) bool {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain && info.GetReason() == reason.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// serversFromTopology returns the servers of the topology. Every server inherits the certificate, the token and the
+// connection settings of the seed with the same host, or of the first seed
+func serversFromTopology(topology *hydraidepbgo.GetClusterTopologyResponse, seeds []*Server) []*Server {
+
+	servers := make([]*Server, 0, len(topology.GetServers()))
+	for _, clusterServer := range topology.GetServers() {
+
+		server := &Server{}
+		if len(seeds) > 0 && seeds[0] != nil {
+			*server = *seeds[0]
+		}
+		for _, seed := range seeds {
+			if seed != nil && seed.Host == clusterServer.GetHost() {
+				*server = *seed
+				break
+			}
+		}
+
+		server.Host = clusterServer.GetHost()
+		server.FromIsland = clusterServer.GetFromIsland()
+		server.ToIsland = clusterServer.GetToIsland()
+		servers = append(servers, server)
+
+	}
+
+	return servers
+
+}
+
+// watchTopology refreshes the topology periodically until the stop channel is closed
+func (c *client) watchTopology(stop <-chan struct{}) {
+
+	ticker := time.NewTicker(c.topologyRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := c.refreshTopology(); err != nil {
+				slog.Error("can not refresh the cluster topology, the previous topology is used", "error", err)
+			}
+		}
+	}
+
+}
+
+// refreshTopology fetches the topology from a connected server, or from the seeds if no server answers, and follows
+// its changes: the new servers are connected, the Islands are routed by the new ranges, and the connections of the
+// removed servers are closed. The topology is not changed if any new server can not be connected
+func (c *client) refreshTopology() error {
+
+	// the watcher, the moved Islands and the migrations refresh the topology concurrently
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.mu.RLock()
+	services := c.uniqueServices
+	version := c.topologyVersion
+	allIslands := c.allIslands
+	known := make(map[string]*connection, len(c.hosts))
+	for host, conn := range c.hosts {
+		known[host] = conn
+	}
+	c.mu.RUnlock()
+
+	var topology *hydraidepbgo.GetClusterTopologyResponse
+	var err error
+	for _, service := range services {
+		if topology, err = fetchTopology(service); err == nil {
+			break
+		}
+	}
+	if topology == nil {
+		if topology, err = c.topologyFromSeeds(false); err != nil {
+			return err
+		}
+	}
+
+	if topology.GetVersion() == version {
+		return nil
+	}
+	if topology.GetAllIslands() != allIslands {
+		return fmt.Errorf("%w: the number of all islands changed from %d to %d, the clients must be restarted with the new number",
+			ErrInvalidIslandRanges, allIslands, topology.GetAllIslands())
+	}
+
+	servers := serversFromTopology(topology, c.seeds)
+	if err := ValidateIslandRanges(servers, allIslands); err != nil {
+		return err
+	}
+
+	hosts := make(map[string]*connection, len(servers))
+	var dialed []*connection
+	for _, server := range servers {
+		if _, ok := hosts[server.Host]; ok {
+			continue
+		}
+		if conn, ok := known[server.Host]; ok {
+			hosts[server.Host] = conn
+			continue
+		}
+		conn, err := c.dial(server, false)
+		if err != nil {
+			for _, conn := range dialed {
+				conn.close()
+			}
+			return fmt.Errorf("can not connect to the new server %s: %w", server.Host, err)
+		}
+		hosts[server.Host] = conn
+		dialed = append(dialed, conn)
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		for _, conn := range dialed {
+			conn.close()
+		}
+		return nil
+	}
+	var removed []*connection
+	for host, conn := range c.hosts {
+		if _, ok := hosts[host]; !ok {
+			removed = append(removed, conn)
+		}
+	}
+	c.route(servers, hosts)
+	c.servers = servers
+	c.topologyVersion = topology.GetVersion()
+	c.mu.Unlock()
+
+	for _, conn := range removed {
+		conn.close()
+	}
+
+	slog.Info("the cluster topology is refreshed", "version", topology.GetVersion(), "servers", len(servers),
+		"connected", len(dialed), "disconnected", len(removed))
+
+	return nil
+
+}
+
+// islandMovedInterceptor refreshes the topology of the client, if the server refused the request because its
+// Island moved to an other server. The error is still returned, but the retried request goes to the new server
+func islandMovedInterceptor(c *client) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if hasErrorReason(err, hydraidepbgo.ErrorReason_ISLAND_MOVED) {
+			if refreshErr := c.refreshTopology(); refreshErr != nil {
+				slog.Error("can not refresh the cluster topology after a moved island", "error", refreshErr)
+			}
+		}
+		return err
+	}
+}
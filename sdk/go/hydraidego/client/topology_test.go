@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"testing"
+	"time"
+)
+
+// topologyServiceClient answers the GetClusterTopology with the topology of the test
+type topologyServiceClient struct {
+	hydraidepbgo.HydraideServiceClient
+	mu       sync.Mutex
+	topology *hydraidepbgo.GetClusterTopologyResponse
+	err      error
+}
+
+func (s *topologyServiceClient) GetClusterTopology(_ context.Context, _ *hydraidepbgo.GetClusterTopologyRequest, _ ...grpc.CallOption) (*hydraidepbgo.GetClusterTopologyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.topology, s.err
+}
+
+func (s *topologyServiceClient) set(topology *hydraidepbgo.GetClusterTopologyResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topology = topology
+}
+
+// topologyCluster is a fake cluster whose servers all serve the same topology
+type topologyCluster struct {
+	mu       sync.Mutex
+	services map[string]*topologyServiceClient
+	dialed   []*Server
+	down     map[string]bool
+}
+
+func newTopologyCluster(topology *hydraidepbgo.GetClusterTopologyResponse) *topologyCluster {
+	cluster := &topologyCluster{services: map[string]*topologyServiceClient{}, down: map[string]bool{}}
+	for _, host := range []string{"hydra01:4444", "hydra02:4444", "hydra03:4444"} {
+		cluster.services[host] = &topologyServiceClient{topology: topology}
+	}
+	return cluster
+}
+
+func (c *topologyCluster) dial(server *Server, _ bool) (*connection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down[server.Host] {
+		return nil, errors.New("connection refused")
+	}
+	c.dialed = append(c.dialed, server)
+	return &connection{service: c.services[server.Host]}, nil
+}
+
+func (c *topologyCluster) setTopology(topology *hydraidepbgo.GetClusterTopologyResponse) {
+	for _, service := range c.services {
+		service.set(topology)
+	}
+}
+
+func twoServerTopology() *hydraidepbgo.GetClusterTopologyResponse {
+	return &hydraidepbgo.GetClusterTopologyResponse{
+		Version:    "v1",
+		AllIslands: 10,
+		Servers: []*hydraidepbgo.ClusterServer{
+			{Host: "hydra01:4444", FromIsland: 1, ToIsland: 5},
+			{Host: "hydra02:4444", FromIsland: 6, ToIsland: 10},
+		},
+	}
+}
+
+func newSeedClient(cluster *topologyCluster, seeds ...*Server) *client {
+	c := New(seeds, 0, 0, WithTopologyRefresh(0)).(*client)
+	c.dial = cluster.dial
+	return c
+}
+
+func TestClient_SeedDiscovery(t *testing.T) {
+
+	swamp := name.New().Sanctuary("users").Realm("profiles").Swamp("john.doe")
+
+	t.Run("should discover the servers from the first reachable seed", func(t *testing.T) {
+
+		cluster := newTopologyCluster(twoServerTopology())
+		cluster.down["hydra03:4444"] = true
+		c := newSeedClient(cluster,
+			&Server{Host: "hydra03:4444", CertFilePath: "ca.pem", TenantToken: "token"},
+			&Server{Host: "hydra02:4444", CertFilePath: "ca-02.pem"},
+		)
+
+		require.NoError(t, c.Connect(false))
+		assert.Equal(t, uint64(10), c.GetAllIslands())
+		assert.Len(t, c.GetUniqueServiceClients(), 2)
+
+		serviceClient := c.GetServiceClientAndHost(swamp)
+		island := swamp.GetIslandID(10)
+		if island <= 5 {
+			assert.Equal(t, "hydra01:4444", serviceClient.Host)
+		} else {
+			assert.Equal(t, "hydra02:4444", serviceClient.Host)
+		}
+
+		hosts := map[string]*Server{}
+		for _, server := range c.servers {
+			hosts[server.Host] = server
+		}
+		assert.Equal(t, "ca.pem", hosts["hydra01:4444"].CertFilePath, "a server without seed inherits the first seed")
+		assert.Equal(t, "token", hosts["hydra01:4444"].TenantToken)
+		assert.Equal(t, "ca-02.pem", hosts["hydra02:4444"].CertFilePath, "a seed server keeps its own settings")
+
+	})
+
+	t.Run("should fail if no seed has a topology", func(t *testing.T) {
+
+		cluster := newTopologyCluster(nil)
+		st, err := status.New(codes.FailedPrecondition, "no topology").WithDetails(&errdetails.ErrorInfo{
+			Reason: hydraidepbgo.ErrorReason_CLUSTER_TOPOLOGY_NOT_CONFIGURED.String(),
+			Domain: errorDomain,
+		})
+		require.NoError(t, err)
+		cluster.services["hydra01:4444"].err = st.Err()
+
+		c := newSeedClient(cluster, &Server{Host: "hydra01:4444"})
+		assert.ErrorIs(t, c.Connect(false), ErrClusterTopologyNotConfigured)
+
+	})
+
+	t.Run("should refuse the client without seeds", func(t *testing.T) {
+		c := New(nil, 0, 0).(*client)
+		assert.ErrorIs(t, c.Connect(false), ErrInvalidIslandRanges)
+	})
+
+}
+
+func TestClient_RefreshTopology(t *testing.T) {
+
+	t.Run("should connect the new servers and close the removed ones", func(t *testing.T) {
+
+		cluster := newTopologyCluster(twoServerTopology())
+		c := newSeedClient(cluster, &Server{Host: "hydra01:4444"})
+		require.NoError(t, c.Connect(false))
+
+		require.NoError(t, c.refreshTopology())
+		assert.Len(t, cluster.dialed, 3, "the same version does not reconnect")
+
+		cluster.setTopology(&hydraidepbgo.GetClusterTopologyResponse{
+			Version:    "v2",
+			AllIslands: 10,
+			Servers: []*hydraidepbgo.ClusterServer{
+				{Host: "hydra01:4444", FromIsland: 1, ToIsland: 3},
+				{Host: "hydra03:4444", FromIsland: 4, ToIsland: 10},
+			},
+		})
+		require.NoError(t, c.refreshTopology())
+
+		assert.Len(t, cluster.dialed, 4, "only the new server is connected")
+		assert.Equal(t, "hydra03:4444", cluster.dialed[3].Host)
+		assert.Equal(t, "v2", c.topologyVersion)
+		assert.Contains(t, c.hosts, "hydra03:4444")
+		assert.NotContains(t, c.hosts, "hydra02:4444")
+		assert.Equal(t, "hydra01:4444", c.serviceClients[3].Host)
+		assert.Equal(t, "hydra03:4444", c.serviceClients[4].Host)
+
+	})
+
+	t.Run("should keep the topology if a new server can not be connected or the islands changed", func(t *testing.T) {
+
+		cluster := newTopologyCluster(twoServerTopology())
+		c := newSeedClient(cluster, &Server{Host: "hydra01:4444"})
+		require.NoError(t, c.Connect(false))
+
+		cluster.down["hydra03:4444"] = true
+		cluster.setTopology(&hydraidepbgo.GetClusterTopologyResponse{
+			Version:    "v2",
+			AllIslands: 10,
+			Servers:    []*hydraidepbgo.ClusterServer{{Host: "hydra03:4444", FromIsland: 1, ToIsland: 10}},
+		})
+		assert.Error(t, c.refreshTopology())
+		assert.Equal(t, "v1", c.topologyVersion)
+
+		cluster.setTopology(&hydraidepbgo.GetClusterTopologyResponse{
+			Version:    "v3",
+			AllIslands: 20,
+			Servers:    []*hydraidepbgo.ClusterServer{{Host: "hydra01:4444", FromIsland: 1, ToIsland: 20}},
+		})
+		assert.ErrorIs(t, c.refreshTopology(), ErrInvalidIslandRanges)
+		assert.Equal(t, uint64(10), c.GetAllIslands())
+
+	})
+
+	t.Run("should refresh in the background until the connection is closed", func(t *testing.T) {
+
+		cluster := newTopologyCluster(twoServerTopology())
+		c := New([]*Server{{Host: "hydra01:4444"}}, 0, 0, WithTopologyRefresh(10*time.Millisecond)).(*client)
+		c.dial = cluster.dial
+		require.NoError(t, c.Connect(false))
+
+		cluster.setTopology(&hydraidepbgo.GetClusterTopologyResponse{
+			Version:    "v2",
+			AllIslands: 10,
+			Servers:    []*hydraidepbgo.ClusterServer{{Host: "hydra01:4444", FromIsland: 1, ToIsland: 10}},
+		})
+		assert.Eventually(t, func() bool {
+			return len(c.GetUniqueServiceClients()) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		c.CloseConnection()
+		assert.Nil(t, c.stopRefresh)
+
+	})
+
+}
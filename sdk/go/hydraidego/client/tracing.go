@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+const (
+	// tracerName is the name of the tracer of the SDK
+	tracerName = "github.com/hydraide/hydraide/sdk/go/hydraidego"
+	// maxTracedSwampNames is the maximum number of swamp names added to a span
+	maxTracedSwampNames = 32
+)
+
+// The attribute keys of the client spans. The server uses the same keys, so the client and the server spans of the
+// same RPC can be compared side by side.
+const (
+	attributeSwampNames   = attribute.Key("hydraide.swamp.names")
+	attributeSwampCount   = attribute.Key("hydraide.swamp.count")
+	attributeIslandIDs    = attribute.Key("hydraide.island.ids")
+	attributeKeyCount     = attribute.Key("hydraide.key.count")
+	attributeRequestSize  = attribute.Key("hydraide.request.size")
+	attributeResponseSize = attribute.Key("hydraide.response.size")
+	attributeServerHost   = attribute.Key("hydraide.server.host")
+)
+
+// Option is an optional setting of the client
+type Option func(*client)
+
+// WithTracing enables the OpenTelemetry tracing of the unary RPCs.
+//
+// Every RPC gets a client span with the swamp names, island IDs, the number of keys and the payload sizes as
+// attributes, and the trace context is sent to the server in the gRPC metadata. If the server has tracing enabled,
+// its spans continue the same trace, so a distributed trace shows exactly which swamp read is slow.
+//
+// ⚠️ The SDK uses the global OpenTelemetry tracer provider and propagator of the application. Without them, the
+// spans are no-ops and nothing is propagated:
+//
+//	otel.SetTracerProvider(tracerProvider)
+//	otel.SetTextMapPropagator(propagation.TraceContext{})
+//
+// Example:
+//
+//	c := client.New(servers, 1000, 104857600, client.WithTracing())
+func WithTracing() Option {
+	return func(c *client) {
+		c.tracing = true
+	}
+}
+
+// tracingInterceptor returns a unary client interceptor that traces the RPCs sent to the given server
+func tracingInterceptor(host string) grpc.UnaryClientInterceptor {
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		service, rpcMethod := splitMethod(method)
+		attributes := []attribute.KeyValue{
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(rpcMethod),
+			attributeServerHost.String(host),
+		}
+		if message, ok := req.(proto.Message); ok {
+			attributes = append(attributes, requestAttributes(message)...)
+		}
+
+		ctx, span := otel.Tracer(tracerName).Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attributes...),
+		)
+		defer span.End()
+
+		// send the trace context to the server next to the existing metadata of the call
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		if message, ok := reply.(proto.Message); ok && err == nil {
+			span.SetAttributes(attributeResponseSize.Int(proto.Size(message)))
+		}
+
+		s, _ := status.FromError(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(s.Code())))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, s.Message())
+		}
+
+		return err
+
+	}
+
+}
+
+// requestAttributes returns the swamp names, island IDs, the number of keys and the size of the request
+func requestAttributes(message proto.Message) []attribute.KeyValue {
+
+	summary := summarizeRequest(message)
+
+	attributes := []attribute.KeyValue{
+		attributeRequestSize.Int(proto.Size(message)),
+		attributeSwampCount.Int(summary.swampCount),
+		attributeKeyCount.Int(summary.keyCount),
+	}
+	if len(summary.swampNames) > 0 {
+		attributes = append(attributes, attributeSwampNames.StringSlice(summary.swampNames))
+	}
+	if len(summary.islandIDs) > 0 {
+		attributes = append(attributes, attributeIslandIDs.Int64Slice(summary.islandIDs))
+	}
+
+	return attributes
+
+}
+
+// requestSummary is the swamps, the islands and the number of keys of a request
+type requestSummary struct {
+	// swampNames are the first maxTracedSwampNames swamp names of the request
+	swampNames []string
+	swampCount int
+	islandIDs  []int64
+	keyCount   int
+}
+
+// summarizeRequest collects the fields by name (SwampName, IslandID, Key, Keys, KeyValues) from the request and its
+// repeated swamp messages
+func summarizeRequest(message proto.Message) requestSummary {
+
+	summary := requestSummary{}
+	seenIslands := make(map[int64]struct{})
+
+	var collect func(m protoreflect.Message, nested bool)
+	collect = func(m protoreflect.Message, nested bool) {
+		m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			switch {
+			case field.Name() == "SwampName" && field.Kind() == protoreflect.StringKind && !field.IsList():
+				summary.swampCount++
+				if len(summary.swampNames) < maxTracedSwampNames {
+					summary.swampNames = append(summary.swampNames, value.String())
+				}
+			case field.Name() == "IslandID" && field.Kind() == protoreflect.Uint64Kind && !field.IsList():
+				islandID := int64(value.Uint())
+				if _, ok := seenIslands[islandID]; !ok {
+					seenIslands[islandID] = struct{}{}
+					summary.islandIDs = append(summary.islandIDs, islandID)
+				}
+			case field.Name() == "Key" && field.Kind() == protoreflect.StringKind && !field.IsList():
+				summary.keyCount++
+			case (field.Name() == "Keys" || field.Name() == "KeyValues") && field.IsList():
+				summary.keyCount += value.List().Len()
+			case field.Kind() == protoreflect.MessageKind && field.IsList() && !nested:
+				// the swamps of the batch requests, the deeper messages are the treasures
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					collect(list.Get(i).Message(), true)
+				}
+			}
+			return true
+		})
+	}
+	collect(message.ProtoReflect(), false)
+
+	return summary
+
+}
+
+// splitMethod splits the /package.Service/Method form of the method name
+func splitMethod(method string) (string, string) {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		return method[:i], method[i+1:]
+	}
+	return "", method
+}
+
+// metadataCarrier adapts the outgoing gRPC metadata to the OpenTelemetry propagators
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
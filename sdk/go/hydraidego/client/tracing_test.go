@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	interceptor := tracingInterceptor("hydra01:4444")
+	req := &hydraidepbgo.GetRequest{Swamps: []*hydraidepbgo.GetSwamp{
+		{IslandID: 3, SwampName: "users/profiles/alex", Keys: []string{"a", "b"}},
+		{IslandID: 9, SwampName: "users/profiles/peter", Keys: []string{"c"}},
+	}}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), metadataClientIDForTest, "importer")
+
+	var sentMetadata metadata.MD
+	err := interceptor(ctx, hydraidepbgo.HydraideService_Get_FullMethodName, req, &hydraidepbgo.GetResponse{}, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			sentMetadata, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, "hydraidepbgo.HydraideService/Get", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+
+	// the trace context is propagated without losing the metadata of the caller
+	assert.NotEmpty(t, sentMetadata.Get("traceparent"))
+	assert.Equal(t, []string{"importer"}, sentMetadata.Get(metadataClientIDForTest))
+
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, a := range span.Attributes() {
+		attributes[a.Key] = a.Value
+	}
+	assert.Equal(t, []string{"users/profiles/alex", "users/profiles/peter"}, attributes[attributeSwampNames].AsStringSlice())
+	assert.Equal(t, []int64{3, 9}, attributes[attributeIslandIDs].AsInt64Slice())
+	assert.Equal(t, int64(3), attributes[attributeKeyCount].AsInt64())
+	assert.Equal(t, "hydra01:4444", attributes[attributeServerHost].AsString())
+
+}
+
+// metadataClientIDForTest is any metadata key of the caller that must survive the injection of the trace context
+const metadataClientIDForTest = "hydraide-client-id"
@@ -0,0 +1,109 @@
+// Package compression registers the gRPC compressors shared by the HydrAIDE server and the Go SDK.
+//
+// Importing the package registers the gzip and the zstd compressors in gRPC. The server imports it, so it accepts
+// the compressed requests and answers them with the same compressor, and the client compresses its requests if it
+// is created with client.WithCompression.
+//
+// 💡 The compression pays off between datacenters and for text-heavy treasures, e.g. product catalogs, where the
+// bandwidth is more expensive than the CPU. On a local network the uncompressed transport is usually faster.
+package compression
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"io"
+	"sync"
+)
+
+const (
+	// Gzip is the name of the gzip compressor of gRPC. Every gRPC implementation supports it, but it is slower than
+	// zstd
+	Gzip = gzip.Name
+	// Zstd is the name of the Zstandard compressor. It is the best choice for most workloads: it is fast and it
+	// compresses the text-heavy messages well
+	Zstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(newZstdCompressor())
+}
+
+// IsSupported returns true if the name is a compressor of this package
+func IsSupported(name string) bool {
+	return name == Gzip || name == Zstd
+}
+
+// zstdCompressor is the zstd compressor of gRPC. The encoders and the decoders are pooled, because creating them
+// allocates a lot of memory
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func newZstdCompressor() *zstdCompressor {
+	c := &zstdCompressor{}
+	c.encoders.New = func() any {
+		// the options are valid, so the error is always nil
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+		return &zstdWriter{Encoder: encoder, pool: &c.encoders}
+	}
+	return c
+}
+
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	z := c.encoders.Get().(*zstdWriter)
+	z.Encoder.Reset(w)
+	return z, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+
+	z, inPool := c.decoders.Get().(*zstdReader)
+	if !inPool {
+		decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &zstdReader{Decoder: decoder, pool: &c.decoders}, nil
+	}
+
+	if err := z.Decoder.Reset(r); err != nil {
+		c.decoders.Put(z)
+		return nil, err
+	}
+
+	return z, nil
+
+}
+
+// zstdWriter returns the encoder to the pool when the message is written
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (z *zstdWriter) Close() error {
+	defer z.pool.Put(z)
+	return z.Encoder.Close()
+}
+
+// zstdReader returns the decoder to the pool when the message is read
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (z *zstdReader) Read(p []byte) (n int, err error) {
+	n, err = z.Decoder.Read(p)
+	if err == io.EOF {
+		// the decoder must not hold the reader of the finished message in the pool
+		_ = z.Decoder.Reset(nil)
+		z.pool.Put(z)
+	}
+	return n, err
+}
@@ -0,0 +1,194 @@
+package compression
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+)
+
+// catalogPayload returns a text-heavy payload of about the given size, like the descriptions of a product catalog
+func catalogPayload(size int) []byte {
+	var buffer bytes.Buffer
+	for i := 0; buffer.Len() < size; i++ {
+		fmt.Fprintf(&buffer, `{"sku":"SKU-%06d","title":"Organic cotton t-shirt, size %d","description":"Soft, breathable and durable everyday t-shirt made of 100%% organic cotton. Machine washable.","price":%d.99}`, i, i%6, 10+i%40)
+	}
+	return buffer.Bytes()[:size]
+}
+
+func TestCompressors(t *testing.T) {
+
+	payload := catalogPayload(256 * 1024)
+
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run("should compress and decompress with "+name, func(t *testing.T) {
+
+			compressor := encoding.GetCompressor(name)
+			require.NotNil(t, compressor, "importing the package registers the compressor")
+
+			// the pooled encoders and decoders are reused by the second round
+			for round := 0; round < 2; round++ {
+				var compressed bytes.Buffer
+				w, err := compressor.Compress(&compressed)
+				require.NoError(t, err)
+				_, err = w.Write(payload)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				assert.Less(t, compressed.Len(), len(payload)/4, "the catalog compresses well")
+
+				r, err := compressor.Decompress(&compressed)
+				require.NoError(t, err)
+				decompressed, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, payload, decompressed)
+			}
+
+		})
+	}
+
+	t.Run("should support only the registered compressors", func(t *testing.T) {
+		assert.True(t, IsSupported(Zstd))
+		assert.True(t, IsSupported(Gzip))
+		assert.False(t, IsSupported("snappy"))
+		assert.False(t, IsSupported(""))
+	})
+
+}
+
+func TestCompressedHeartbeat(t *testing.T) {
+
+	serviceClient := startEchoServer(t)
+	ping := string(catalogPayload(64 * 1024))
+
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run("should send and receive the compressed messages with "+name, func(t *testing.T) {
+			pong, err := serviceClient.Heartbeat(context.Background(), &hydraidepbgo.HeartbeatRequest{Ping: ping}, grpc.UseCompressor(name))
+			require.NoError(t, err)
+			assert.Equal(t, ping, pong.Pong)
+		})
+	}
+
+}
+
+// The compressors with a 64 KB catalog message, the ratio is the compressed size per the original size.
+//
+// BenchmarkCompress/gzip         	    2979	    399825 ns/op	 163.91 MB/s	         0.03392 ratio
+// BenchmarkCompress/zstd         	   10000	    102415 ns/op	 639.91 MB/s	         0.01852 ratio
+// BenchmarkDecompress/gzip       	   21446	     69773 ns/op	 939.27 MB/s
+// BenchmarkDecompress/zstd       	   12016	    119273 ns/op	 549.46 MB/s
+func BenchmarkCompress(b *testing.B) {
+
+	payload := catalogPayload(64 * 1024)
+
+	for _, name := range []string{Gzip, Zstd} {
+		b.Run(name, func(b *testing.B) {
+			compressor := encoding.GetCompressor(name)
+			var compressed bytes.Buffer
+			b.SetBytes(int64(len(payload)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				compressed.Reset()
+				w, _ := compressor.Compress(&compressed)
+				_, _ = w.Write(payload)
+				_ = w.Close()
+			}
+			b.ReportMetric(float64(compressed.Len())/float64(len(payload)), "ratio")
+		})
+	}
+
+}
+
+func BenchmarkDecompress(b *testing.B) {
+
+	payload := catalogPayload(64 * 1024)
+
+	for _, name := range []string{Gzip, Zstd} {
+		b.Run(name, func(b *testing.B) {
+			compressor := encoding.GetCompressor(name)
+			var compressed bytes.Buffer
+			w, _ := compressor.Compress(&compressed)
+			_, _ = w.Write(payload)
+			_ = w.Close()
+			b.SetBytes(int64(len(payload)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r, _ := compressor.Decompress(bytes.NewReader(compressed.Bytes()))
+				_, _ = io.Copy(io.Discard, r)
+			}
+		})
+	}
+
+}
+
+// The latency of a round trip of a 64 KB catalog message over an in-memory connection, so it is the CPU cost of the
+// compression. Over a slow link between datacenters the compressed messages are faster, because they are 29-54
+// times smaller.
+//
+// BenchmarkHeartbeat/identity    	    2352	    464011 ns/op
+// BenchmarkHeartbeat/gzip        	     812	   1478649 ns/op
+// BenchmarkHeartbeat/zstd        	    1599	    708579 ns/op
+func BenchmarkHeartbeat(b *testing.B) {
+
+	serviceClient := startEchoServer(b)
+	ping := string(catalogPayload(64 * 1024))
+
+	for _, name := range []string{encoding.Identity, Gzip, Zstd} {
+		b.Run(name, func(b *testing.B) {
+			var opts []grpc.CallOption
+			if name != encoding.Identity {
+				opts = append(opts, grpc.UseCompressor(name))
+			}
+			for i := 0; i < b.N; i++ {
+				if _, err := serviceClient.Heartbeat(context.Background(), &hydraidepbgo.HeartbeatRequest{Ping: ping}, opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+}
+
+// echoServer answers the Heartbeat with the ping
+type echoServer struct {
+	hydraidepbgo.UnimplementedHydraideServiceServer
+}
+
+func (e *echoServer) Heartbeat(_ context.Context, in *hydraidepbgo.HeartbeatRequest) (*hydraidepbgo.HeartbeatResponse, error) {
+	return &hydraidepbgo.HeartbeatResponse{Pong: in.GetPing()}, nil
+}
+
+// startEchoServer starts an echo server on an in-memory connection and returns its client
+func startEchoServer(tb testing.TB) hydraidepbgo.HydraideServiceClient {
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	hydraidepbgo.RegisterHydraideServiceServer(grpcServer, &echoServer{})
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	tb.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return hydraidepbgo.NewHydraideServiceClient(conn)
+
+}
@@ -0,0 +1,150 @@
+// Package consistency is the read-your-writes consistency token of HydrAIDE, shared by the SDK and the server.
+//
+// Every successful write returns the mark of its server in the hydraide-consistency-token trailer: the ID of the
+// server, the run of the server and the sequence number of the write in the run. A token is the list of the marks,
+// the newest one per server, so the writes to many servers are collected in one token. A request sending the token
+// back in its hydraide-consistency-token metadata is served only if its server still has the writes of its mark.
+//
+// The token is a plain string, e.g. "3f0c…-9a1e.1760752800000000000.42", so it can be passed between the services,
+// e.g. in a HTTP header or a cookie, from the service that wrote the data to the service that reads it.
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metadata is the gRPC metadata of the token: the trailer of the writes, and the request metadata of the reads
+const Metadata = "hydraide-consistency-token"
+
+// Mark is the position of a write on a server
+type Mark struct {
+	// ServerID is the persistent ID of the server
+	ServerID string
+	// Run is the start of the run of the server in Unix nanoseconds. It changes at every start of the server
+	Run int64
+	// Sequence is the sequence number of the write in the run
+	Sequence uint64
+}
+
+// newer returns true if the mark is after the other mark of the same server
+func (m Mark) newer(other Mark) bool {
+	if m.Run != other.Run {
+		return m.Run > other.Run
+	}
+	return m.Sequence > other.Sequence
+}
+
+// String returns the mark in the server.run.sequence format
+func (m Mark) String() string {
+	return fmt.Sprintf("%s.%d.%d", m.ServerID, m.Run, m.Sequence)
+}
+
+// Token is the list of the marks of the writes, one per server, ordered by the server ID
+type Token []Mark
+
+// Parse parses the token. The empty string is the empty token
+func Parse(token string) (Token, error) {
+
+	if token == "" {
+		return nil, nil
+	}
+
+	var t Token
+	for _, part := range strings.Split(token, ",") {
+		fields := strings.Split(part, ".")
+		if len(fields) != 3 || fields[0] == "" {
+			return nil, fmt.Errorf("invalid consistency token mark %q, it must be in the server.run.sequence format", part)
+		}
+		run, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid run of the consistency token mark %q: %w", part, err)
+		}
+		sequence, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sequence of the consistency token mark %q: %w", part, err)
+		}
+		t = t.Merge(Token{{ServerID: fields[0], Run: run, Sequence: sequence}})
+	}
+
+	return t, nil
+
+}
+
+// String returns the token in the format of Parse
+func (t Token) String() string {
+	parts := make([]string, 0, len(t))
+	for _, m := range t {
+		parts = append(parts, m.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// Merge returns the token with the newest mark of every server of the two tokens
+func (t Token) Merge(other Token) Token {
+
+	merged := slices.Clone(t)
+	for _, m := range other {
+		i := slices.IndexFunc(merged, func(existing Mark) bool { return existing.ServerID == m.ServerID })
+		switch {
+		case i < 0:
+			merged = append(merged, m)
+		case m.newer(merged[i]):
+			merged[i] = m
+		}
+	}
+
+	slices.SortFunc(merged, func(a, b Mark) int {
+		return strings.Compare(a.ServerID, b.ServerID)
+	})
+
+	return merged
+
+}
+
+// Mark returns the mark of the server, false if the token has no write on the server
+func (t Token) Mark(serverID string) (Mark, bool) {
+	i := slices.IndexFunc(t, func(m Mark) bool { return m.ServerID == serverID })
+	if i < 0 {
+		return Mark{}, false
+	}
+	return t[i], true
+}
+
+// Collector collects the tokens of the writes of a context. It is safe for concurrent use
+type Collector struct {
+	mu    sync.Mutex
+	token Token
+}
+
+// Add merges the token into the collected token
+func (c *Collector) Add(token Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = c.token.Merge(token)
+}
+
+// Token returns the collected token
+func (c *Collector) Token() Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.token)
+}
+
+type collectorKey struct{}
+
+// WithCollector returns a context whose writes are collected by the returned collector
+func WithCollector(ctx context.Context) (context.Context, *Collector) {
+	c := &Collector{}
+	return context.WithValue(ctx, collectorKey{}, c), c
+}
+
+// CollectorFrom returns the collector of the context, nil if the writes of the context are not collected
+func CollectorFrom(ctx context.Context) *Collector {
+	c, _ := ctx.Value(collectorKey{}).(*Collector)
+	return c
+}
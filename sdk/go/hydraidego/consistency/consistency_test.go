@@ -0,0 +1,64 @@
+package consistency
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestToken(t *testing.T) {
+
+	t.Run("should parse the string of the token", func(t *testing.T) {
+
+		token := Token{{ServerID: "b", Run: 20, Sequence: 3}, {ServerID: "a", Run: 10, Sequence: 7}}.Merge(nil)
+		assert.Equal(t, "a.10.7,b.20.3", token.String(), "the marks are ordered by the server")
+
+		parsed, err := Parse(token.String())
+		require.NoError(t, err)
+		assert.Equal(t, token, parsed)
+
+		parsed, err = Parse("")
+		require.NoError(t, err)
+		assert.Empty(t, parsed)
+
+	})
+
+	t.Run("should refuse the invalid tokens", func(t *testing.T) {
+		for _, invalid := range []string{"a.10", ".10.7", "a.x.7", "a.10.-1", "a.10.7,"} {
+			_, err := Parse(invalid)
+			assert.Error(t, err, invalid)
+		}
+	})
+
+	t.Run("should keep the newest mark of every server", func(t *testing.T) {
+
+		token := Token{{ServerID: "a", Run: 10, Sequence: 7}}.
+			Merge(Token{{ServerID: "a", Run: 10, Sequence: 5}, {ServerID: "b", Run: 20, Sequence: 1}}).
+			Merge(Token{{ServerID: "b", Run: 30, Sequence: 1}})
+
+		mark, ok := token.Mark("a")
+		require.True(t, ok)
+		assert.Equal(t, uint64(7), mark.Sequence)
+		mark, ok = token.Mark("b")
+		require.True(t, ok)
+		assert.Equal(t, int64(30), mark.Run, "the later run wins over the sequence")
+		_, ok = token.Mark("c")
+		assert.False(t, ok)
+
+	})
+
+}
+
+func TestCollector(t *testing.T) {
+
+	assert.Nil(t, CollectorFrom(context.Background()))
+
+	ctx, collector := WithCollector(context.Background())
+	require.Same(t, collector, CollectorFrom(ctx))
+
+	CollectorFrom(ctx).Add(Token{{ServerID: "a", Run: 10, Sequence: 1}})
+	CollectorFrom(ctx).Add(Token{{ServerID: "a", Run: 10, Sequence: 2}})
+	assert.Equal(t, "a.10.2", collector.Token().String())
+
+}
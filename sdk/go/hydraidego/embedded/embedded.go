@@ -0,0 +1,171 @@
+// Package embedded runs the HydrAIDE engine in the process of the application, behind the normal Hydraidego
+// interface of the SDK.
+//
+// The embedded engine is the same engine the server runs, with the same gateway, but the SDK calls the gateway
+// directly instead of sending the requests over gRPC. There is no port, no TLS certificate and no container, so
+// the unit tests and the CI pipelines can run against a real engine in a temporary folder:
+//
+//	engine, err := embedded.New(nil)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer engine.Close()
+//
+//	h := engine.GetHydraidego()
+//	_, err = h.CatalogSave(ctx, swampName, model)
+//
+// ⚠️ The embedded engine is meant for tests and tools. It serves only its own process, and it has none of the
+// server features around the gateway: no rate limiting, tenancy, tracing, metrics or REST gateway.
+package embedded
+
+import (
+	"fmt"
+	"github.com/hydraide/hydraide/app/core/filesystem"
+	"github.com/hydraide/hydraide/app/core/settings"
+	"github.com/hydraide/hydraide/app/core/settings/defaults"
+	"github.com/hydraide/hydraide/app/core/zeus"
+	"github.com/hydraide/hydraide/app/server/gateway"
+	"github.com/hydraide/hydraide/app/server/observer"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/internal/inprocess"
+	"os"
+	"sync"
+)
+
+const (
+	maxDepth        = 1
+	foldersPerLevel = 1000
+	// embeddedHost is the host name of the embedded engine in the routing of the client
+	embeddedHost = "embedded"
+)
+
+// Options are the optional settings of the embedded engine. The zero values mean the defaults of the server.
+type Options struct {
+	// RootPath is the folder of the data and the settings of the engine. Empty means a new temporary folder, which
+	// is removed by Close. A given folder is kept, so the data can be loaded again by an other engine.
+	RootPath string
+	// AllIslands is the number of the Islands the Swamps are hashed to. 0 means 1000
+	AllIslands uint64
+	// DefaultCloseAfterIdle is the idle time in seconds before an unregistered Swamp is closed. 0 means 1 second
+	DefaultCloseAfterIdle int64
+	// DefaultWriteInterval is the time in seconds between two writes of an unregistered Swamp. 0 means 10 seconds
+	DefaultWriteInterval int64
+	// DefaultFileSize is the max size of a chunk file of an unregistered Swamp in bytes. 0 means 8192 bytes
+	DefaultFileSize int64
+	// MaxTreasuresPerSwamp is the max number of the Treasures in one Swamp. 0 means unlimited
+	MaxTreasuresPerSwamp int
+	// MaxKeyLength is the max length of a written key in bytes. 0 means unlimited
+	MaxKeyLength int
+	// MaxValueSize is the max size of a written bytes, string or uint32 slice value in bytes. 0 means unlimited
+	MaxValueSize int64
+	// MaxMessageSize emulates the max message size of the gRPC connection in bytes, so the tests can cover the
+	// requests and responses that are too large for a real server. 0 means unlimited
+	MaxMessageSize int
+	// SDKOptions are the options of the SDK returned by GetHydraidego, e.g. hydraidego.RegisterOnFirstUse
+	SDKOptions []hydraidego.Option
+}
+
+type Embedded interface {
+	// GetHydraidego returns the SDK connected to the embedded engine
+	GetHydraidego() hydraidego.Hydraidego
+	// GetRootPath returns the folder of the data and the settings of the engine
+	GetRootPath() string
+	// Close stops the engine gracefully, so all Swamps are written to the disk, then removes the temporary root
+	// folder if the engine created it. The SDK can not be used after the Close.
+	Close()
+}
+
+type embedded struct {
+	rootPath           string
+	temporary          bool
+	zeusInterface      zeus.Zeus
+	observerInterface  observer.Observer
+	hydraidegoInstance hydraidego.Hydraidego
+	// conn is the in-process connection of the gateway, behind the SDK
+	conn      *inprocess.Conn
+	closeOnce sync.Once
+}
+
+// New starts a new embedded engine. The options can be nil.
+func New(options *Options) (Embedded, error) {
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	e := &embedded{
+		rootPath: options.RootPath,
+	}
+
+	if e.rootPath == "" {
+		rootPath, err := os.MkdirTemp("", "hydraide-embedded-")
+		if err != nil {
+			return nil, fmt.Errorf("can not create the root folder of the embedded engine: %w", err)
+		}
+		e.rootPath = rootPath
+		e.temporary = true
+	} else if err := os.MkdirAll(e.rootPath, 0755); err != nil {
+		return nil, fmt.Errorf("can not create the root folder of the embedded engine: %w", err)
+	}
+
+	settingsInterface := settings.NewWithRootPath(e.rootPath, maxDepth, foldersPerLevel)
+	settingsInterface.SetDefaults(defaults.New(defaults.Values{
+		CloseAfterIdleSec: defaultValue(options.DefaultCloseAfterIdle, 1),
+		WriteIntervalSec:  defaultValue(options.DefaultWriteInterval, 10),
+		MaxFileSizeByte:   defaultValue(options.DefaultFileSize, 8192),
+	}))
+	e.zeusInterface = zeus.New(settingsInterface, filesystem.New())
+	e.zeusInterface.StartHydra()
+
+	e.observerInterface = observer.New()
+
+	service := &gateway.Gateway{
+		ObserverInterface:    e.observerInterface,
+		SettingsInterface:    settingsInterface,
+		ZeusInterface:        e.zeusInterface,
+		MaxTreasuresPerSwamp: options.MaxTreasuresPerSwamp,
+		MaxKeyLength:         options.MaxKeyLength,
+		MaxValueSize:         options.MaxValueSize,
+	}
+
+	e.conn = inprocess.NewConn(service)
+	e.conn.MaxMessageSize = options.MaxMessageSize
+
+	e.hydraidegoInstance = hydraidego.New(inprocess.NewClient(
+		hydraidepbgo.NewHydraideServiceClient(e.conn),
+		embeddedHost,
+		uint64(defaultValue(int64(options.AllIslands), 1000)),
+		options.MaxMessageSize,
+	), options.SDKOptions...)
+
+	return e, nil
+
+}
+
+func (e *embedded) GetHydraidego() hydraidego.Hydraidego {
+	return e.hydraidegoInstance
+}
+
+func (e *embedded) GetRootPath() string {
+	return e.rootPath
+}
+
+func (e *embedded) Close() {
+	e.closeOnce.Do(func() {
+		// the same order as the server stops: the subscriptions and the background processes first, then the hydra
+		e.conn.Close()
+		e.observerInterface.WaitingForAllProcessesFinished()
+		e.zeusInterface.StopHydra()
+		if e.temporary {
+			_ = os.RemoveAll(e.rootPath)
+		}
+	})
+}
+
+func defaultValue(value int64, defaultValue int64) int64 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,1445 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testModel struct {
+	Key   string `hydraide:"key"`
+	Value string `hydraide:"value"`
+}
+
+func TestEmbedded(t *testing.T) {
+
+	swampName := name.New().Sanctuary("embedded").Realm("test").Swamp("models")
+
+	registerSwamp := func(h hydraidego.Hydraidego) {
+		errs := h.RegisterSwamp(context.Background(), &hydraidego.RegisterSwampRequest{
+			SwampPattern:   swampName,
+			CloseAfterIdle: time.Hour,
+			FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+				WriteInterval: time.Hour,
+				MaxFileSize:   8192,
+			},
+		})
+		assert.Nil(t, errs)
+	}
+
+	t.Run("should save and read the treasures without a server", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		assert.NoError(t, h.Heartbeat(ctx))
+		registerSwamp(h)
+
+		status, err := h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNew, status)
+
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "alpha", read))
+		assert.Equal(t, "first", read.Value)
+
+		err = h.CatalogRead(ctx, swampName, "missing", &testModel{})
+		assert.True(t, hydraidego.IsNotFound(err))
+
+	})
+
+	t.Run("should stream the events of the subscriptions", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "existing"})
+		assert.NoError(t, err)
+
+		// the existing data is streamed before the Subscribe returns, so the next save is always streamed, too
+		var mu sync.Mutex
+		var values []string
+		_, err = h.Subscribe(ctx, swampName, true, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			mu.Lock()
+			defer mu.Unlock()
+			values = append(values, model.(*testModel).Value)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		_, err = h.CatalogSave(context.Background(), swampName, &testModel{Key: "alpha", Value: "streamed"})
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(values) == 2 && values[0] == "existing" && values[1] == "streamed"
+		}, 5*time.Second, 10*time.Millisecond)
+
+	})
+
+	t.Run("should manage the subscriptions by their handles", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		// the context is shared with the other work, so it is not canceled by the subscriptions
+		ctx := context.Background()
+		failing := errors.New("the iterator failed")
+
+		// the subscriptions with the existing data are ready when the Subscribe returns, so the next save is streamed
+		closed, err := h.Subscribe(ctx, swampName, true, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		failed, err := h.Subscribe(ctx, swampName, true, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			return failing
+		})
+		assert.NoError(t, err)
+		feed, err := h.SubscribeAll(ctx, []name.Name{name.New().Sanctuary("embedded").Realm("test").Swamp("*")}, func(change *hydraidego.Change, err error) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, closed.LastEventTime().IsZero())
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "streamed"})
+		assert.NoError(t, err)
+
+		select {
+		case <-failed.Done():
+			assert.ErrorIs(t, failed.Err(), failing)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the subscription must stop after the error of its iterator")
+		}
+
+		assert.Eventually(t, func() bool {
+			return !closed.LastEventTime().IsZero() && !feed.LastEventTime().IsZero()
+		}, 5*time.Second, 10*time.Millisecond)
+
+		for _, subscription := range []*hydraidego.Subscription{closed, feed} {
+			assert.NoError(t, subscription.Err())
+			subscription.Close()
+			subscription.Close()
+			select {
+			case <-subscription.Done():
+				assert.NoError(t, subscription.Err())
+			case <-time.After(5 * time.Second):
+				t.Fatal("the subscription must stop after Close")
+			}
+		}
+
+	})
+
+	t.Run("should stream the changes of every matching swamp", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+		otherSwamp := name.New().Sanctuary("embedded").Realm("other").Swamp("models")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var mu sync.Mutex
+		var changes []string
+		_, err = h.SubscribeAll(ctx, []name.Name{name.New().Sanctuary("embedded").Realm("test").Swamp("*")}, func(change *hydraidego.Change, err error) error {
+			assert.NoError(t, err)
+			model := &testModel{}
+			assert.NoError(t, change.Decode(model))
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, fmt.Sprintf("%s %s %s %d", change.SwampName.Get(), change.Key, model.Value, change.Status))
+			return nil
+		})
+		assert.NoError(t, err)
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+		_, err = h.CatalogSave(ctx, otherSwamp, &testModel{Key: "beta", Value: "other"})
+		assert.NoError(t, err)
+		err = h.CatalogDelete(ctx, swampName, "alpha")
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return assert.ObjectsAreEqual([]string{
+				fmt.Sprintf("%s alpha first %d", swampName.Get(), hydraidego.StatusNew),
+				fmt.Sprintf("%s alpha first %d", swampName.Get(), hydraidego.StatusDeleted),
+			}, changes)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		_, err = h.SubscribeAll(ctx, nil, func(change *hydraidego.Change, err error) error { return nil })
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+	})
+
+	t.Run("should replay the missed changes of the swamps", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		h := engine.GetHydraidego()
+		journalSwamp := name.New().Sanctuary("embedded").Realm("journal").Swamp("models")
+		assert.Nil(t, h.RegisterSwamp(context.Background(), &hydraidego.RegisterSwampRequest{
+			SwampPattern:     journalSwamp,
+			CloseAfterIdle:   time.Hour,
+			EventJournalSize: 100,
+			FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+				WriteInterval: time.Hour,
+				MaxFileSize:   8192,
+			},
+		}))
+		registerSwamp(h)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = h.CatalogSave(ctx, journalSwamp, &testModel{Key: "alpha", Value: "processed"})
+		assert.NoError(t, err)
+		since := time.Now()
+		_, err = h.CatalogSave(ctx, journalSwamp, &testModel{Key: "beta", Value: "missed"})
+		assert.NoError(t, err)
+
+		var mu sync.Mutex
+		var changes []string
+		var gaps []string
+		patterns := []name.Name{name.New().Sanctuary("embedded").Realm("*").Swamp("models")}
+		_, err = h.SubscribeAllFrom(ctx, patterns, map[string]time.Time{
+			journalSwamp.Get(): since,
+			swampName.Get():    since,
+		}, func(change *hydraidego.Change, err error) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if hydraidego.IsReplayNotAvailable(err) {
+				gaps = append(gaps, change.SwampName.Get())
+				return nil
+			}
+			assert.NoError(t, err)
+			changes = append(changes, change.Key)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// the replay arrives before the function returns
+		mu.Lock()
+		assert.Equal(t, []string{"beta"}, changes)
+		assert.Equal(t, []string{swampName.Get()}, gaps, "the swamp without journal can not be replayed")
+		mu.Unlock()
+
+		_, err = h.CatalogSave(ctx, journalSwamp, &testModel{Key: "gamma", Value: "new"})
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return assert.ObjectsAreEqual([]string{"beta", "gamma"}, changes)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		_, err = h.SubscribeAllFrom(ctx, patterns, map[string]time.Time{"embedded/unmatched/swamp": since}, func(change *hydraidego.Change, err error) error { return nil })
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+	})
+
+	t.Run("should keep the data of a given root path", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		ctx := context.Background()
+
+		engine, err := New(&Options{RootPath: rootPath})
+		assert.NoError(t, err)
+		registerSwamp(engine.GetHydraidego())
+		_, err = engine.GetHydraidego().CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "kept"})
+		assert.NoError(t, err)
+		engine.Close()
+
+		_, err = os.Stat(rootPath)
+		assert.NoError(t, err)
+
+		engine, err = New(&Options{RootPath: rootPath})
+		assert.NoError(t, err)
+		defer engine.Close()
+		registerSwamp(engine.GetHydraidego())
+
+		read := &testModel{}
+		assert.NoError(t, engine.GetHydraidego().CatalogRead(ctx, swampName, "alpha", read))
+		assert.Equal(t, "kept", read.Value)
+
+	})
+
+	t.Run("should keep the data of the swamps flushed by the fsync policy", func(t *testing.T) {
+
+		rootPath := t.TempDir()
+		ctx := context.Background()
+		paymentsSwamp := name.New().Sanctuary("embedded").Realm("fsync").Swamp("payments")
+
+		registerPayments := func(h hydraidego.Hydraidego) {
+			assert.Nil(t, h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+				SwampPattern:   paymentsSwamp,
+				CloseAfterIdle: time.Hour,
+				FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+					WriteInterval: time.Second,
+					MaxFileSize:   8192,
+					FsyncPolicy:   hydraidego.FsyncAlways,
+				},
+			}))
+		}
+
+		engine, err := New(&Options{RootPath: rootPath})
+		assert.NoError(t, err)
+		registerPayments(engine.GetHydraidego())
+		_, err = engine.GetHydraidego().CatalogSave(ctx, paymentsSwamp, &testModel{Key: "alpha", Value: "flushed"})
+		assert.NoError(t, err)
+		engine.Close()
+
+		engine, err = New(&Options{RootPath: rootPath})
+		assert.NoError(t, err)
+		defer engine.Close()
+		registerPayments(engine.GetHydraidego())
+
+		read := &testModel{}
+		assert.NoError(t, engine.GetHydraidego().CatalogRead(ctx, paymentsSwamp, "alpha", read))
+		assert.Equal(t, "flushed", read.Value)
+
+	})
+
+	t.Run("should list and update the registered patterns", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		patterns, err := h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, patterns, 1)
+		assert.Equal(t, swampName.Get(), patterns[0].SwampPattern.Get())
+		assert.Equal(t, time.Hour, patterns[0].CloseAfterIdle)
+		assert.Equal(t, 8192, patterns[0].MaxFileSize)
+		assert.Equal(t, hydraidego.FsyncNever, patterns[0].FsyncPolicy)
+
+		assert.Nil(t, h.UpdateSwampPattern(ctx, &hydraidego.UpdateSwampPatternRequest{
+			SwampPattern:  swampName,
+			WriteInterval: 5 * time.Second,
+			MaxFileSize:   65536,
+		}))
+
+		patterns, err = h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, patterns, 1)
+		assert.Equal(t, time.Hour, patterns[0].CloseAfterIdle)
+		assert.Equal(t, 5*time.Second, patterns[0].WriteInterval)
+		assert.Equal(t, 65536, patterns[0].MaxFileSize)
+
+		errs := h.UpdateSwampPattern(ctx, &hydraidego.UpdateSwampPatternRequest{
+			SwampPattern:   name.New().Sanctuary("embedded").Realm("test").Swamp("unregistered"),
+			CloseAfterIdle: time.Minute,
+		})
+		assert.Len(t, errs, 1)
+		assert.True(t, hydraidego.IsNotFound(errs[0]))
+
+	})
+
+	t.Run("should reject the conflicting pattern registrations unless forced", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+		registerSwamp(h)
+
+		conflictingRequest := &hydraidego.RegisterSwampRequest{
+			SwampPattern:    name.New().Sanctuary("embedded").Realm("*").Swamp("*"),
+			IsInMemorySwamp: true,
+			CloseAfterIdle:  time.Minute,
+		}
+		errs := h.RegisterSwamp(ctx, conflictingRequest)
+		if assert.Len(t, errs, 1) {
+			assert.True(t, hydraidego.IsPatternConflict(errs[0]))
+			conflicting := hydraidego.GetConflictingPattern(errs[0])
+			if assert.NotNil(t, conflicting) {
+				assert.Equal(t, swampName.Get(), conflicting.SwampPattern.Get())
+				assert.Equal(t, time.Hour, conflicting.CloseAfterIdle)
+				assert.NotEmpty(t, conflicting.RegisteredBy)
+				assert.False(t, conflicting.RegisteredAt.IsZero())
+			}
+		}
+
+		conflictingRequest.Force = true
+		assert.Nil(t, h.RegisterSwamp(ctx, conflictingRequest))
+
+		patterns, err := h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, patterns, 2)
+
+	})
+
+	t.Run("should register the declared patterns on first use", func(t *testing.T) {
+
+		registry := hydraidego.NewPatternRegistry()
+		assert.NoError(t, registry.Declare(&hydraidego.RegisterSwampRequest{
+			SwampPattern:   name.New().Sanctuary("embedded").Realm("lazy").Swamp("*"),
+			CloseAfterIdle: time.Hour,
+		}))
+		assert.True(t, hydraidego.IsAlreadyExists(registry.Declare(&hydraidego.RegisterSwampRequest{
+			SwampPattern: name.New().Sanctuary("embedded").Realm("lazy").Swamp("*"),
+		})))
+
+		engine, err := New(&Options{SDKOptions: []hydraidego.Option{hydraidego.RegisterOnFirstUse(registry)}})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+
+		patterns, err := h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, patterns)
+
+		// the swamps do not exist, only the registration of their patterns matters
+		_, _ = h.IsKeyExists(ctx, swampName, "key")
+		patterns, err = h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, patterns, "the pattern of an other swamp must not be registered")
+
+		lazySwamp := name.New().Sanctuary("embedded").Realm("lazy").Swamp("first")
+		_, _ = h.IsKeyExists(ctx, lazySwamp, "key")
+		patterns, err = h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, patterns, 1) {
+			assert.Equal(t, "embedded/lazy/*", patterns[0].SwampPattern.Get())
+			assert.Equal(t, time.Hour, patterns[0].CloseAfterIdle)
+		}
+
+	})
+
+	t.Run("should stream the treasures without an index type", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		expected := make([]string, 0, 1200)
+		for i := 0; i < 1200; i++ {
+			expected = append(expected, fmt.Sprintf("value-%04d", i))
+			_, err := h.CatalogSave(ctx, swampName, &testModel{Key: fmt.Sprintf("key-%04d", i), Value: expected[i]})
+			assert.NoError(t, err)
+		}
+
+		// the treasures come in more batches, in no particular order
+		var values []string
+		assert.NoError(t, h.CatalogReadMany(ctx, swampName, &hydraidego.Index{}, testModel{}, func(model any) error {
+			values = append(values, model.(*testModel).Value)
+			return nil
+		}))
+		assert.ElementsMatch(t, expected, values)
+
+		values = nil
+		assert.NoError(t, h.CatalogReadMany(ctx, swampName, &hydraidego.Index{FilterExpr: "value >= 'value-1100'", Limit: 10}, testModel{}, func(model any) error {
+			values = append(values, model.(*testModel).Value)
+			return nil
+		}))
+		assert.Len(t, values, 10)
+		for _, value := range values {
+			assert.GreaterOrEqual(t, value, "value-1100")
+		}
+
+		var keys []string
+		assert.NoError(t, h.CatalogReadManyKeys(ctx, swampName, &hydraidego.Index{From: 1000}, func(key string, metadata *hydraidego.KeyMetadata) error {
+			keys = append(keys, key)
+			return nil
+		}))
+		assert.Len(t, keys, 200)
+
+		// the error of the iterator stops the stream
+		errStop := fmt.Errorf("stop")
+		read := 0
+		assert.ErrorIs(t, h.CatalogReadMany(ctx, swampName, &hydraidego.Index{}, testModel{}, func(model any) error {
+			read++
+			return errStop
+		}), errStop)
+		assert.Equal(t, 1, read)
+
+		err = h.CatalogReadMany(ctx, swampName, &hydraidego.Index{FilterExpr: "value >>> 1"}, testModel{}, func(model any) error {
+			return nil
+		})
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+	})
+
+	t.Run("should write and read the values larger than the max message size", func(t *testing.T) {
+
+		type blob struct {
+			Data []byte
+		}
+		type blobModel struct {
+			Key   string `hydraide:"key"`
+			Value *blob  `hydraide:"value"`
+		}
+
+		engine, err := New(&Options{MaxMessageSize: 4096})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		// the GOB-encoded value is sent and read in chunks
+		data := make([]byte, 64*1024)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		status, err := h.CatalogSave(ctx, swampName, &blobModel{Key: "large", Value: &blob{Data: data}})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNew, status)
+
+		read := &blobModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "large", read))
+		assert.Equal(t, data, read.Value.Data)
+
+		// the batch is split into requests under the limit
+		models := make([]any, 0, 20)
+		for i := 0; i < 20; i++ {
+			models = append(models, &testModel{Key: fmt.Sprintf("key-%d", i), Value: string(make([]byte, 1024))})
+		}
+		var keys []string
+		assert.NoError(t, h.CatalogSaveMany(ctx, swampName, models, func(key string, status hydraidego.EventStatus) error {
+			keys = append(keys, key)
+			return nil
+		}))
+		assert.Len(t, keys, 20)
+
+		// only the bytes values can be chunked
+		err = h.CatalogCreate(ctx, swampName, &testModel{Key: "string", Value: string(make([]byte, 8192))})
+		assert.True(t, hydraidego.IsMessageTooLarge(err))
+
+	})
+
+	t.Run("should reject the keys and the values over the limits of the server", func(t *testing.T) {
+
+		engine, err := New(&Options{MaxKeyLength: 16, MaxValueSize: 1024, MaxTreasuresPerSwamp: 1})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "a-key-longer-than-the-limit", Value: "first"})
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+		assert.Equal(t, &hydraidego.LimitViolation{Name: "maxKeyLength", Max: 16, Actual: 27}, hydraidego.GetLimitViolation(err))
+
+		_, err = h.IncrementInt64(ctx, swampName, "a-key-longer-than-the-limit", 1, nil)
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "large", Value: string(make([]byte, 2048))})
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+		assert.Equal(t, &hydraidego.LimitViolation{Name: "maxValueSize", Max: 1024, Actual: 2048}, hydraidego.GetLimitViolation(err))
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "beta", Value: "second"})
+		// the same request is never accepted, like the other limits, so it is not a retryable quota
+		assert.Equal(t, hydraidego.ErrCodeInvalidArgument, hydraidego.GetErrorCode(err))
+		assert.False(t, hydraidego.IsQuotaExceeded(err))
+		assert.Equal(t, &hydraidego.LimitViolation{Name: "maxTreasuresPerSwamp", Max: 1, Actual: 2}, hydraidego.GetLimitViolation(err))
+
+	})
+
+	t.Run("should write nothing of the batch if a swamp would exceed the max treasures", func(t *testing.T) {
+
+		engine, err := New(&Options{MaxTreasuresPerSwamp: 2})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+		otherSwamp := name.New().Sanctuary("embedded").Realm("other").Swamp("models")
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+
+		// the other swamp is before the full one in the request, and it is not written either
+		err = h.CatalogSaveManyToMany(ctx, []*hydraidego.CatalogManyToManyRequest{
+			{SwampName: otherSwamp, Models: []any{&testModel{Key: "gamma", Value: "other"}}},
+			{SwampName: swampName, Models: []any{
+				&testModel{Key: "alpha", Value: "updated"},
+				&testModel{Key: "beta", Value: "second"},
+				&testModel{Key: "delta", Value: "third"},
+			}},
+		}, nil)
+		assert.Equal(t, hydraidego.ErrCodeInvalidArgument, hydraidego.GetErrorCode(err))
+		assert.Equal(t, &hydraidego.LimitViolation{Name: "maxTreasuresPerSwamp", Max: 2, Actual: 3}, hydraidego.GetLimitViolation(err))
+
+		err = h.CatalogRead(ctx, otherSwamp, "gamma", &testModel{})
+		assert.True(t, hydraidego.IsNotFound(err) || hydraidego.IsSwampNotFound(err))
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "alpha", read))
+		assert.Equal(t, "first", read.Value)
+
+		// the concurrent writers can not take the last free room together
+		var wg sync.WaitGroup
+		var saved atomic.Int32
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := h.CatalogSave(ctx, swampName, &testModel{Key: fmt.Sprintf("key-%d", i), Value: "parallel"}); err == nil {
+					saved.Add(1)
+				} else {
+					assert.NotNil(t, hydraidego.GetLimitViolation(err))
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), saved.Load())
+
+	})
+
+	t.Run("should reject the slices over the max value size after the push", func(t *testing.T) {
+
+		engine, err := New(&Options{MaxValueSize: 1024})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		values := func(from, to uint32) []uint32 {
+			result := make([]uint32, 0, to-from)
+			for v := from; v < to; v++ {
+				result = append(result, v)
+			}
+			return result
+		}
+
+		assert.NoError(t, h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{{Key: "ids", Values: values(0, 200)}}, nil))
+
+		// 200 present and 100 new values are 1200 bytes, and nothing of the request is written
+		err = h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{
+			{Key: "other", Values: values(0, 10)},
+			{Key: "ids", Values: values(150, 300)},
+		}, nil)
+		assert.Equal(t, hydraidego.ErrCodeInvalidArgument, hydraidego.GetErrorCode(err))
+		assert.Equal(t, &hydraidego.LimitViolation{Name: "maxValueSize", Max: 1024, Actual: 1200}, hydraidego.GetLimitViolation(err))
+		size, err := h.Uint32SliceSize(ctx, swampName, "ids")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(200), size)
+		size, err = h.Uint32SliceSize(ctx, swampName, "other")
+		assert.True(t, err != nil || size == 0)
+
+		// the already present values do not grow the slice
+		assert.NoError(t, h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{{Key: "ids", Values: values(0, 256)}}, nil))
+
+		// the combined slice is checked, too
+		assert.NoError(t, h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{{Key: "more", Values: values(1000, 1100)}}, nil))
+		_, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceUnion, []string{"ids", "more"}, "all")
+		assert.Equal(t, &hydraidego.LimitViolation{Name: "maxValueSize", Max: 1024, Actual: 1424}, hydraidego.GetLimitViolation(err))
+
+	})
+
+	t.Run("should reject the chunked value at the first chunk over the max value size", func(t *testing.T) {
+
+		engine, err := New(&Options{MaxMessageSize: 4096, MaxValueSize: 16 * 1024})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		type blobModel struct {
+			Key   string `hydraide:"key"`
+			Value []byte `hydraide:"value"`
+		}
+
+		_, err = h.CatalogSave(ctx, swampName, &blobModel{Key: "large", Value: make([]byte, 64*1024)})
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+		violation := hydraidego.GetLimitViolation(err)
+		require.NotNil(t, violation)
+		assert.Equal(t, "maxValueSize", violation.Name)
+		assert.Equal(t, int64(16*1024), violation.Max)
+		// the rest of the value was not received
+		assert.Greater(t, violation.Actual, int64(16*1024))
+		assert.Less(t, violation.Actual, int64(64*1024))
+
+		err = h.CatalogRead(ctx, swampName, "large", &blobModel{})
+		assert.True(t, hydraidego.IsNotFound(err) || hydraidego.IsSwampNotFound(err))
+
+	})
+
+	t.Run("should store the blobs once and collect the unreferenced ones", func(t *testing.T) {
+
+		engine, err := New(&Options{MaxMessageSize: 4096})
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+
+		// the blob is larger than the max message size, so it is streamed in chunks
+		content := make([]byte, 32*1024)
+		for i := range content {
+			content[i] = byte(i % 251)
+		}
+
+		hash, err := h.PutBlob(ctx, content)
+		assert.NoError(t, err)
+		sameHash, err := h.PutBlob(ctx, content)
+		assert.NoError(t, err)
+		assert.Equal(t, hash, sameHash)
+
+		read, err := h.GetBlob(ctx, hash)
+		assert.NoError(t, err)
+		assert.Equal(t, content, read)
+
+		references, err := h.RefBlob(ctx, hash, -2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), references)
+
+		_, err = h.RefBlob(ctx, hash, -1)
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+		// the grace period is not over yet
+		result, err := h.CollectBlobGarbage(ctx, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), result.RemovedBlobs)
+
+		result, err = h.CollectBlobGarbage(ctx, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.RemovedBlobs)
+		assert.Equal(t, int64(len(content)), result.FreedBytes)
+
+		_, err = h.GetBlob(ctx, hash)
+		assert.True(t, hydraidego.IsNotFound(err))
+
+	})
+
+	t.Run("should move the timestamps atomically", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		seen := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+		stored, err := h.SetIfLater(ctx, swampName, "last-seen", seen)
+		assert.NoError(t, err)
+		assert.True(t, seen.Equal(stored))
+
+		stored, err = h.SetIfLater(ctx, swampName, "last-seen", seen.Add(-time.Minute))
+		assert.True(t, hydraidego.IsConditionNotMet(err))
+		assert.True(t, seen.Equal(stored), "the later stored timestamp is returned")
+
+		expiresAt, err := h.AddDuration(ctx, swampName, "last-seen", 90*time.Second, false)
+		assert.NoError(t, err)
+		assert.True(t, seen.Add(90*time.Second).Equal(expiresAt))
+
+		type timestampModel struct {
+			Key   string    `hydraide:"key"`
+			Value time.Time `hydraide:"value"`
+		}
+		read := &timestampModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "last-seen", read))
+		assert.True(t, expiresAt.Equal(read.Value), "the timestamp is read as a time.Time value")
+
+		renewedAt, err := h.AddDuration(ctx, swampName, "last-seen", time.Minute, true)
+		assert.NoError(t, err)
+		assert.True(t, renewedAt.After(time.Now()), "the past timestamp is renewed from now")
+
+		_, err = h.AddDuration(ctx, swampName, "last-seen", time.Millisecond, false)
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "name", Value: "not a timestamp"})
+		assert.NoError(t, err)
+		_, err = h.SetIfLater(ctx, swampName, "name", seen)
+		assert.True(t, hydraidego.IsFailedPrecondition(err))
+
+	})
+
+	t.Run("should enforce the status transitions atomically", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		_, err = h.SetStringIfEquals(ctx, swampName, "job-42", "pending", "processing")
+		assert.True(t, hydraidego.IsConditionNotMet(err), "the missing key never equals")
+
+		status, err := h.SetStringIfNotExists(ctx, swampName, "job-42", "pending")
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", status)
+
+		status, err = h.SetStringIfNotExists(ctx, swampName, "job-42", "pending")
+		assert.True(t, hydraidego.IsConditionNotMet(err))
+		assert.Equal(t, "pending", status)
+
+		status, err = h.SetStringIfEquals(ctx, swampName, "job-42", "pending", "processing")
+		assert.NoError(t, err)
+		assert.Equal(t, "processing", status)
+
+		status, err = h.SetStringIfEquals(ctx, swampName, "job-42", "pending", "processing")
+		assert.True(t, hydraidego.IsConditionNotMet(err))
+		assert.Equal(t, "processing", status, "the stored value is returned")
+
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "job-42", read))
+		assert.Equal(t, "processing", read.Value)
+
+		sent, err := h.SetBoolIfNotExists(ctx, swampName, "invoice-42-sent", false)
+		assert.NoError(t, err)
+		assert.False(t, sent)
+
+		sent, err = h.SetBoolIfEquals(ctx, swampName, "invoice-42-sent", false, true)
+		assert.NoError(t, err)
+		assert.True(t, sent)
+
+		sent, err = h.SetBoolIfEquals(ctx, swampName, "invoice-42-sent", false, true)
+		assert.True(t, hydraidego.IsConditionNotMet(err))
+		assert.True(t, sent)
+
+		_, err = h.SetBoolIfEquals(ctx, swampName, "job-42", false, true)
+		assert.True(t, hydraidego.IsFailedPrecondition(err))
+
+	})
+
+	t.Run("should create a new key only once by the concurrent writers", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+		ctx := context.Background()
+
+		// the existence of the key is checked under its lock, so only one of the writers can create it
+		var created, existing atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := h.CatalogCreate(ctx, swampName, &testModel{Key: "contended", Value: fmt.Sprintf("writer-%d", i)})
+				switch {
+				case err == nil:
+					created.Add(1)
+				case hydraidego.IsAlreadyExists(err):
+					existing.Add(1)
+				default:
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), created.Load())
+		assert.Equal(t, int32(49), existing.Load())
+
+	})
+
+	t.Run("should count the added and the already present values of the slices", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		type counts struct {
+			added          int
+			alreadyPresent int
+		}
+		push := func(pairs []*hydraidego.KeyValuesPair) map[string]counts {
+			results := make(map[string]counts)
+			assert.NoError(t, h.Uint32SlicePush(ctx, swampName, pairs, func(key string, added int, alreadyPresent int) error {
+				results[key] = counts{added, alreadyPresent}
+				return nil
+			}))
+			return results
+		}
+
+		assert.Equal(t, map[string]counts{
+			"word:go":    {added: 3},
+			"word:swamp": {added: 1},
+		}, push([]*hydraidego.KeyValuesPair{
+			{Key: "word:go", Values: []uint32{1, 2, 3}},
+			{Key: "word:swamp", Values: []uint32{7}},
+		}))
+
+		assert.Equal(t, map[string]counts{
+			"word:go": {added: 2, alreadyPresent: 3},
+		}, push([]*hydraidego.KeyValuesPair{
+			{Key: "word:go", Values: []uint32{3, 4, 5, 5, 1}},
+		}), "the repeated values of the request are counted as already present")
+
+		size, err := h.Uint32SliceSize(ctx, swampName, "word:go")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), size)
+
+		stop := errors.New("stop")
+		calls := 0
+		err = h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{
+			{Key: "word:go", Values: []uint32{6}},
+			{Key: "word:swamp", Values: []uint32{8}},
+		}, func(key string, added int, alreadyPresent int) error {
+			calls++
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+
+		assert.NoError(t, h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{
+			{Key: "word:swamp", Values: []uint32{9}},
+		}, nil))
+		size, err = h.Uint32SliceSize(ctx, swampName, "word:swamp")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), size, "the values are pushed even if the iterator stops")
+
+	})
+
+	t.Run("should page and stream the values of the slices", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		ids := make([]uint32, 0, 25000)
+		for i := uint32(0); i < 25000; i++ {
+			ids = append(ids, 100000-i)
+		}
+		assert.NoError(t, h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{{Key: "word:go", Values: ids}}, nil))
+
+		var paged []uint32
+		for offset := int64(0); ; offset += 10000 {
+			page, size, err := h.Uint32SliceGetRange(ctx, swampName, "word:go", offset, 10000)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(25000), size)
+			paged = append(paged, page...)
+			if offset+10000 >= size {
+				break
+			}
+		}
+		assert.Equal(t, ids, paged, "the pages keep the order of the push")
+
+		page, _, err := h.Uint32SliceGetRange(ctx, swampName, "word:go", 30000, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, page)
+
+		var streamed []uint32
+		assert.NoError(t, h.Uint32SliceStream(ctx, swampName, "word:go", func(value uint32) error {
+			streamed = append(streamed, value)
+			return nil
+		}))
+		assert.Equal(t, ids, streamed)
+
+		stop := errors.New("stop")
+		read := 0
+		err = h.Uint32SliceStream(ctx, swampName, "word:go", func(value uint32) error {
+			read++
+			if read == 3 {
+				return stop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 3, read)
+
+		_, _, err = h.Uint32SliceGetRange(ctx, swampName, "word:go", 0, 0)
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+		_, _, err = h.Uint32SliceGetRange(ctx, swampName, "word:missing", 0, 10)
+		assert.True(t, hydraidego.IsNotFound(err))
+
+		err = h.Uint32SliceStream(ctx, swampName, "word:missing", func(value uint32) error { return nil })
+		assert.True(t, hydraidego.IsNotFound(err))
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "name", Value: "not a slice"})
+		assert.NoError(t, err)
+		_, _, err = h.Uint32SliceGetRange(ctx, swampName, "name", 0, 10)
+		assert.True(t, hydraidego.IsFailedPrecondition(err))
+
+	})
+
+	t.Run("should combine the slices on the server", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		assert.NoError(t, h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{
+			{Key: "word:go", Values: []uint32{5, 1, 3, 7}},
+			{Key: "word:database", Values: []uint32{3, 9, 5}},
+			{Key: "word:spam", Values: []uint32{5}},
+		}, nil))
+
+		combine := func(operator hydraidego.SliceOperator, keys ...string) []uint32 {
+			result := make([]uint32, 0)
+			assert.NoError(t, h.Uint32SliceCombine(ctx, swampName, operator, keys, func(value uint32) error {
+				result = append(result, value)
+				return nil
+			}))
+			return result
+		}
+
+		assert.Equal(t, []uint32{5, 1, 3, 7, 9}, combine(hydraidego.SliceUnion, "word:go", "word:database"))
+		assert.Equal(t, []uint32{5, 3}, combine(hydraidego.SliceIntersection, "word:go", "word:database"))
+		assert.Equal(t, []uint32{1, 7}, combine(hydraidego.SliceDifference, "word:go", "word:database"))
+		assert.Empty(t, combine(hydraidego.SliceIntersection, "word:go", "word:missing"), "a missing key is an empty slice")
+
+		size, err := h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceIntersection, []string{"word:go", "word:database"}, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), size)
+
+		size, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceDifference, []string{"query", "word:spam"}, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), size, "the destination can be a source")
+		page, _, err := h.Uint32SliceGetRange(ctx, swampName, "query", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{3}, page)
+
+		size, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceIntersection, []string{"word:spam", "query"}, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), size)
+		_, err = h.Uint32SliceSize(ctx, swampName, "query")
+		assert.Error(t, err, "an empty result deletes the destination")
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "name", Value: "not a slice"})
+		assert.NoError(t, err)
+		_, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceUnion, []string{"word:go"}, "name")
+		assert.True(t, hydraidego.IsFailedPrecondition(err))
+		_, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceUnion, []string{"word:go", "name"}, "query")
+		assert.True(t, hydraidego.IsFailedPrecondition(err))
+
+		_, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceUnion, nil, "query")
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+		_, err = h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceOperator(42), []string{"word:go"}, "query")
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+	})
+
+	t.Run("should count the existing swamps and skip the missing ones", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+
+		first := name.New().Sanctuary("embedded").Realm("count").Swamp("first")
+		second := name.New().Sanctuary("embedded").Realm("count").Swamp("second")
+		missing := name.New().Sanctuary("embedded").Realm("count").Swamp("missing")
+		for _, key := range []string{"alpha", "beta"} {
+			_, err = h.CatalogSave(ctx, first, &testModel{Key: key, Value: key})
+			assert.NoError(t, err)
+		}
+		_, err = h.CatalogSave(ctx, second, &testModel{Key: "alpha", Value: "alpha"})
+		assert.NoError(t, err)
+
+		// the swamps after the missing one are counted too, and the duplicates are counted once
+		counts, err := h.CountMany(ctx, []name.Name{first, missing, second, first})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int32{first.Get(): 2, second.Get(): 1}, counts)
+
+		counts, err = h.CountMany(ctx, []name.Name{missing})
+		assert.NoError(t, err)
+		assert.Empty(t, counts)
+
+		count, err := h.Count(ctx, first)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), count)
+		_, err = h.Count(ctx, missing)
+		assert.True(t, hydraidego.IsSwampNotFound(err))
+
+	})
+
+	t.Run("should check the existence of many keys", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+
+		for _, key := range []string{"alpha", "gamma"} {
+			_, err = h.CatalogSave(ctx, swampName, &testModel{Key: key, Value: key})
+			assert.NoError(t, err)
+		}
+
+		isExist, err := h.IsKeysExist(ctx, swampName, []string{"alpha", "beta", "gamma", "alpha"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"alpha": true, "beta": false, "gamma": true}, isExist)
+
+		isExist, err = h.IsKeysExist(ctx, swampName, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, isExist)
+
+		missing := name.New().Sanctuary("embedded").Realm("test").Swamp("missing")
+		isExist, err = h.IsKeysExist(ctx, missing, []string{"alpha", "beta"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"alpha": false, "beta": false}, isExist)
+
+	})
+
+	t.Run("should compute the same islands as the server", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		defer engine.Close()
+
+		ctx := context.Background()
+		h := engine.GetHydraidego()
+
+		for i := 0; i < 100; i++ {
+			mapping, err := h.VerifyIslandMapping(ctx, name.New().Sanctuary("users").Realm(fmt.Sprintf("realm-%d", i)).Swamp(fmt.Sprintf("swamp-%d", i)))
+			assert.NoError(t, err)
+			assert.Equal(t, mapping.ClientIslandID, mapping.ServerIslandID)
+			assert.NotEmpty(t, mapping.HashFunction)
+		}
+
+		_, err = h.VerifyIslandMapping(ctx, name.New().Sanctuary("users").Realm("*").Swamp("*"))
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+
+	})
+
+	t.Run("should stop the open subscriptions at close", func(t *testing.T) {
+
+		engine, err := New(nil)
+		assert.NoError(t, err)
+
+		h := engine.GetHydraidego()
+		registerSwamp(h)
+
+		ctx := context.Background()
+		subscription, err := h.Subscribe(ctx, swampName, true, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		feed, err := h.SubscribeAll(ctx, []name.Name{name.New().Sanctuary("embedded").Realm("test").Swamp("*")}, func(change *hydraidego.Change, err error) error {
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// the subscriptions are not closed by the client, so the Close of the engine must stop them before the hydra
+		engine.Close()
+
+		for _, s := range []*hydraidego.Subscription{subscription, feed} {
+			select {
+			case <-s.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("the subscription must stop at the close of the engine")
+			}
+		}
+
+		_, err = h.Subscribe(ctx, swampName, false, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			return nil
+		})
+		assert.Error(t, err)
+
+	})
+
+	t.Run("should remove the temporary root path at close", func(t *testing.T) {
+		engine, err := New(nil)
+		assert.NoError(t, err)
+		engine.Close()
+		engine.Close()
+		_, err = os.Stat(engine.GetRootPath())
+		assert.True(t, os.IsNotExist(err))
+	})
+
+}
+
+func TestCluster(t *testing.T) {
+
+	t.Run("should count the swamps with one request per server", func(t *testing.T) {
+
+		c := newCluster(t)
+		ctx := context.Background()
+
+		var swamps []name.Name
+		expected := make(map[string]int32)
+		for server := range c.engines {
+			for i := 0; i < 2; i++ {
+				swampName := c.swampOn(server, fmt.Sprintf("count-%d", i))
+				_, err := c.h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "alpha"})
+				require.NoError(t, err)
+				swamps = append(swamps, swampName)
+				expected[swampName.Get()] = 1
+			}
+		}
+
+		c.resetCalls()
+		counts, err := c.h.CountMany(ctx, append(swamps, swamps[0]))
+		require.NoError(t, err)
+		assert.Equal(t, expected, counts)
+		assert.Equal(t, map[string][]string{
+			"server-1": {hydraidepbgo.HydraideService_Count_FullMethodName},
+			"server-2": {hydraidepbgo.HydraideService_Count_FullMethodName},
+		}, c.sentCalls())
+
+	})
+
+	t.Run("should update the existing treasures of many swamps with one request per server", func(t *testing.T) {
+
+		c := newCluster(t)
+		ctx := context.Background()
+
+		first := c.swampOn(0, "update-first")
+		second := c.swampOn(1, "update-second")
+		missing := c.swampOn(0, "update-missing")
+		for _, swampName := range []name.Name{first, second} {
+			_, err := c.h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+			require.NoError(t, err)
+		}
+
+		request := []*hydraidego.CatalogManyToManyRequest{
+			{SwampName: first, Models: []any{&testModel{Key: "alpha", Value: "second"}, &testModel{Key: "beta", Value: "new"}}},
+			{SwampName: second, Models: []any{&testModel{Key: "alpha", Value: "third"}}},
+			{SwampName: missing, Models: []any{&testModel{Key: "alpha", Value: "new"}}},
+		}
+
+		c.resetCalls()
+		statuses := make(map[string]hydraidego.EventStatus)
+		err := c.h.CatalogUpdateManyToMany(ctx, request, func(swampName name.Name, key string, status hydraidego.EventStatus) error {
+			statuses[swampName.Get()+"|"+key] = status
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]hydraidego.EventStatus{
+			first.Get() + "|alpha":  hydraidego.StatusModified,
+			first.Get() + "|beta":   hydraidego.StatusTreasureNotFound,
+			second.Get() + "|alpha": hydraidego.StatusModified,
+			missing.Get() + "|":     hydraidego.StatusSwampNotFound,
+		}, statuses)
+		assert.Equal(t, map[string][]string{
+			"server-1": {hydraidepbgo.HydraideService_Set_FullMethodName},
+			"server-2": {hydraidepbgo.HydraideService_Set_FullMethodName},
+		}, c.sentCalls())
+
+		read := &testModel{}
+		require.NoError(t, c.h.CatalogRead(ctx, first, "alpha", read))
+		assert.Equal(t, "second", read.Value)
+		err = c.h.CatalogRead(ctx, first, "beta", &testModel{})
+		assert.True(t, hydraidego.IsNotFound(err), "the missing keys are not created")
+		_, err = c.h.IsSwampExist(ctx, missing)
+		assert.True(t, hydraidego.IsSwampNotFound(err), "the missing swamps are not created")
+
+		// the update stops at the first error of the iterator
+		iteratorErr := errors.New("stop")
+		calls := 0
+		err = c.h.CatalogUpdateManyToMany(ctx, request, func(swampName name.Name, key string, status hydraidego.EventStatus) error {
+			calls++
+			return iteratorErr
+		})
+		assert.ErrorIs(t, err, iteratorErr)
+		assert.Equal(t, 1, calls)
+
+	})
+
+	t.Run("should read the referenced treasures on the same and on another server", func(t *testing.T) {
+
+		c := newCluster(t)
+		ctx := context.Background()
+
+		sliceSwamp := c.swampOn(0, "reference-tags")
+		require.NoError(t, c.h.Uint32SlicePush(ctx, sliceSwamp, []*hydraidego.KeyValuesPair{{Key: "summer", Values: []uint32{3, 1, 2}}}, nil))
+
+		// the target on the same server is resolved by the server, the target on the other one by two reads
+		for server, expectedCalls := range []map[string][]string{
+			{"server-1": {hydraidepbgo.HydraideService_GetByReference_FullMethodName}},
+			{"server-1": {hydraidepbgo.HydraideService_Get_FullMethodName}, "server-2": {hydraidepbgo.HydraideService_Get_FullMethodName}},
+		} {
+
+			targetSwamp := c.swampOn(server, "reference-products")
+			request := &hydraidego.ReferenceRequest{
+				SliceSwampName:  sliceSwamp,
+				SliceKey:        "summer",
+				TargetSwampName: targetSwamp,
+				TargetKeyPrefix: "product-",
+			}
+			readKeys := func(request *hydraidego.ReferenceRequest) ([]string, error) {
+				var keys []string
+				err := c.h.CatalogReadByReference(ctx, request, testModel{}, func(model any) error {
+					keys = append(keys, model.(*testModel).Key)
+					return nil
+				})
+				return keys, err
+			}
+
+			// all references are missing while the target swamp does not exist
+			keys, err := readKeys(request)
+			require.NoError(t, err)
+			assert.Empty(t, keys)
+
+			// product-2 is missing, so it is skipped
+			for _, key := range []string{"product-1", "product-3"} {
+				_, err := c.h.CatalogSave(ctx, targetSwamp, &testModel{Key: key, Value: key})
+				require.NoError(t, err)
+			}
+
+			c.resetCalls()
+			keys, err = readKeys(request)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"product-3", "product-1"}, keys, "the treasures are read in the order of the slice")
+			assert.Equal(t, expectedCalls, c.sentCalls())
+
+			_, err = readKeys(&hydraidego.ReferenceRequest{SliceSwampName: sliceSwamp, SliceKey: "winter", TargetSwampName: targetSwamp})
+			assert.True(t, hydraidego.IsNotFound(err), "the slice of the missing key is not found")
+
+		}
+
+	})
+
+}
+
+// cluster routes the lower half of the Islands to the first engine and the upper half to the second, like a client
+// of two servers, and records the RPCs sent to the servers
+type cluster struct {
+	client.Client
+	engines [2]*embedded
+	h       hydraidego.Hydraidego
+
+	mu    sync.Mutex
+	calls map[string][]string
+}
+
+func newCluster(t *testing.T) *cluster {
+	c := &cluster{calls: make(map[string][]string)}
+	for i := range c.engines {
+		engine, err := New(nil)
+		require.NoError(t, err)
+		t.Cleanup(engine.Close)
+		c.engines[i] = engine.(*embedded)
+	}
+	c.h = hydraidego.New(c)
+	return c
+}
+
+func (c *cluster) GetAllIslands() uint64 {
+	return 1000
+}
+
+func (c *cluster) GetMaxMessageSize() int {
+	return 0
+}
+
+func (c *cluster) GetServiceClient(swampName name.Name) hydraidepbgo.HydraideServiceClient {
+	return c.GetServiceClientAndHost(swampName).GrpcClient
+}
+
+func (c *cluster) GetServiceClientAndHost(swampName name.Name) *client.ServiceClient {
+	server := c.serverOf(swampName)
+	host := fmt.Sprintf("server-%d", server+1)
+	return &client.ServiceClient{
+		GrpcClient: hydraidepbgo.NewHydraideServiceClient(&recordingConn{ClientConnInterface: c.engines[server].conn, cluster: c, host: host}),
+		Host:       host,
+	}
+}
+
+// serverOf returns the index of the engine serving the Swamp
+func (c *cluster) serverOf(swampName name.Name) int {
+	if swampName.GetIslandID(c.GetAllIslands()) > c.GetAllIslands()/2 {
+		return 1
+	}
+	return 0
+}
+
+// swampOn returns a Swamp name served by the given engine
+func (c *cluster) swampOn(server int, prefix string) name.Name {
+	for i := 0; ; i++ {
+		swampName := name.New().Sanctuary("cluster").Realm("test").Swamp(fmt.Sprintf("%s-%d", prefix, i))
+		if c.serverOf(swampName) == server {
+			return swampName
+		}
+	}
+}
+
+func (c *cluster) resetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = make(map[string][]string)
+}
+
+// sentCalls returns the unary RPCs sent to the servers by their hosts
+func (c *cluster) sentCalls() map[string][]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+// recordingConn records the unary RPCs sent through the connection of an engine
+type recordingConn struct {
+	grpc.ClientConnInterface
+	cluster *cluster
+	host    string
+}
+
+func (r *recordingConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	r.cluster.mu.Lock()
+	r.cluster.calls[r.host] = append(r.cluster.calls[r.host], method)
+	r.cluster.mu.Unlock()
+	return r.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
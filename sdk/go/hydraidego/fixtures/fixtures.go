@@ -0,0 +1,517 @@
+// Package fixtures loads a deterministic dataset into a HydrAIDE, for the integration tests, the demos and the
+// tutorials. The dataset is described in a YAML or a JSON file by its Sanctuaries, Realms, Swamps and Treasures:
+//
+//	patterns:
+//	  - pattern: shop/products/*
+//	    closeAfterIdle: 10s
+//	sanctuaries:
+//	  - name: shop
+//	    realms:
+//	      - name: products
+//	        swamps:
+//	          - name: books
+//	            treasures:
+//	              - key: dune
+//	                value: Dune
+//	              - key: dune-price
+//	                type: float32
+//	                value: 12.5
+//	                createdBy: fixtures
+//
+// The fixture works with any Hydraidego, e.g. a connected client, an embedded engine, or the HydrAIDE of
+// hydraidetest:
+//
+//	func TestCheckout(t *testing.T) {
+//	    h := hydraidetest.New(t, nil)
+//	    fixtures.Seed(t, h, "testdata/shop.yaml")
+//	    // the Swamps of the fixture are loaded, and they are destroyed at the end of the test
+//	}
+//
+// 🧾 Treasures:
+//   - key: the key of the Treasure, required
+//   - value: the value of the Treasure. Without a value the Treasure has only its key and its metadata
+//   - type: the type of the value, see the Type constants. Without it, a string is a TypeString, an integer is a
+//     TypeInt64, a decimal is a TypeFloat64, a boolean is a TypeBool, and a timestamp is a TypeTime
+//   - expireAt, createdAt, updatedAt: RFC 3339 times of the metadata
+//   - createdBy, updatedBy: the authors of the metadata
+//
+// ⚠️ The struct values of the catalog models are encoded by Go, so they can not be described in a file. Save them
+// with CatalogSave after the Load.
+package fixtures
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"gopkg.in/yaml.v3"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Type is the type of the value of a Treasure in the fixture
+type Type string
+
+const (
+	TypeString      Type = "string"
+	TypeInt8        Type = "int8"
+	TypeInt16       Type = "int16"
+	TypeInt32       Type = "int32"
+	TypeInt64       Type = "int64"
+	TypeUint8       Type = "uint8"
+	TypeUint16      Type = "uint16"
+	TypeUint32      Type = "uint32"
+	TypeUint64      Type = "uint64"
+	TypeFloat32     Type = "float32"
+	TypeFloat64     Type = "float64"
+	TypeBool        Type = "bool"
+	TypeTime        Type = "time"        // an RFC 3339 time, stored as unix seconds like the time.Time fields
+	TypeBytes       Type = "bytes"       // a base64 encoded string
+	TypeUint32Slice Type = "uint32Slice" // a list of numbers, stored by Uint32SlicePush. It has no metadata
+)
+
+// Fixture is a dataset of a HydrAIDE
+type Fixture struct {
+	// Patterns are registered by RegisterSwamp before the Treasures are loaded
+	Patterns    []*Pattern   `yaml:"patterns" json:"patterns"`
+	Sanctuaries []*Sanctuary `yaml:"sanctuaries" json:"sanctuaries"`
+}
+
+// Pattern is a Swamp pattern of the fixture. The durations are Go durations, e.g. 10s or 5m
+type Pattern struct {
+	Pattern        string        `yaml:"pattern" json:"pattern"`
+	InMemory       bool          `yaml:"inMemory" json:"inMemory"`
+	CloseAfterIdle time.Duration `yaml:"closeAfterIdle" json:"closeAfterIdle"`
+	WriteInterval  time.Duration `yaml:"writeInterval" json:"writeInterval"`
+	MaxFileSize    int           `yaml:"maxFileSize" json:"maxFileSize"`
+	// Force registers the pattern even if it conflicts with a registered pattern, see RegisterSwampRequest.Force
+	Force bool `yaml:"force" json:"force"`
+}
+
+type Sanctuary struct {
+	Name   string   `yaml:"name" json:"name"`
+	Realms []*Realm `yaml:"realms" json:"realms"`
+}
+
+type Realm struct {
+	Name   string   `yaml:"name" json:"name"`
+	Swamps []*Swamp `yaml:"swamps" json:"swamps"`
+}
+
+type Swamp struct {
+	Name      string      `yaml:"name" json:"name"`
+	Treasures []*Treasure `yaml:"treasures" json:"treasures"`
+}
+
+type Treasure struct {
+	Key       string    `yaml:"key" json:"key"`
+	Type      Type      `yaml:"type" json:"type"`
+	Value     any       `yaml:"value" json:"value"`
+	ExpireAt  time.Time `yaml:"expireAt" json:"expireAt"`
+	CreatedAt time.Time `yaml:"createdAt" json:"createdAt"`
+	CreatedBy string    `yaml:"createdBy" json:"createdBy"`
+	UpdatedAt time.Time `yaml:"updatedAt" json:"updatedAt"`
+	UpdatedBy string    `yaml:"updatedBy" json:"updatedBy"`
+}
+
+// Parse parses a fixture from YAML or JSON, and validates it, so a Load fails only on the errors of the HydrAIDE
+func Parse(data []byte) (*Fixture, error) {
+
+	// JSON is YAML, so one decoder reads both
+	fixture := &Fixture{}
+	if err := yaml.Unmarshal(data, fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+
+	if err := fixture.validate(); err != nil {
+		return nil, err
+	}
+
+	return fixture, nil
+
+}
+
+// ParseFile parses the fixture of a YAML or a JSON file
+func ParseFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read the fixture: %w", err)
+	}
+	fixture, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Seed parses the fixture file and loads it into the HydrAIDE for the test. The Swamps and the patterns of the
+// fixture are removed by Teardown, when the test and all its subtests are finished. Any error fails the test
+// immediately.
+func Seed(t testing.TB, h hydraidego.Hydraidego, path string) *Fixture {
+
+	t.Helper()
+
+	fixture, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("can not parse the fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := fixture.Teardown(context.Background(), h); err != nil {
+			t.Errorf("can not tear down the fixture %s: %v", path, err)
+		}
+	})
+
+	if err := fixture.Load(context.Background(), h); err != nil {
+		t.Fatalf("can not load the fixture %s: %v", path, err)
+	}
+
+	return fixture
+
+}
+
+// Load registers the patterns, then loads the Swamps of the fixture into the HydrAIDE, in the order of the fixture.
+//
+// Every Swamp of the fixture is destroyed before its Treasures are saved, so the Swamps have the same content after
+// every Load, even if they were modified or loaded before. The other Swamps are not touched.
+func (f *Fixture) Load(ctx context.Context, h hydraidego.Hydraidego) error {
+
+	for _, pattern := range f.Patterns {
+		if errs := h.RegisterSwamp(ctx, pattern.request()); errs != nil {
+			return fmt.Errorf("can not register the pattern %s: %w", pattern.Pattern, errors.Join(errs...))
+		}
+	}
+
+	for _, swampName := range f.SwampNames() {
+		if err := h.Destroy(ctx, swampName); err != nil {
+			return fmt.Errorf("can not destroy the swamp %s before the load: %w", swampName.Get(), err)
+		}
+	}
+
+	for _, sanctuary := range f.Sanctuaries {
+		for _, realm := range sanctuary.Realms {
+			for _, swamp := range realm.Swamps {
+				swampName := name.New().Sanctuary(sanctuary.Name).Realm(realm.Name).Swamp(swamp.Name)
+				for _, treasure := range swamp.Treasures {
+					if err := treasure.save(ctx, h, swampName); err != nil {
+						return fmt.Errorf("can not save the treasure %s of the swamp %s: %w", treasure.Key, swampName.Get(), err)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// Teardown destroys the Swamps and deregisters the patterns of the fixture
+func (f *Fixture) Teardown(ctx context.Context, h hydraidego.Hydraidego) error {
+
+	var allErrors []error
+	for _, swampName := range f.SwampNames() {
+		if err := h.Destroy(ctx, swampName); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("can not destroy the swamp %s: %w", swampName.Get(), err))
+		}
+	}
+	for _, pattern := range f.Patterns {
+		if errs := h.DeRegisterSwamp(ctx, name.Load(pattern.Pattern)); errs != nil {
+			allErrors = append(allErrors, fmt.Errorf("can not deregister the pattern %s: %w", pattern.Pattern, errors.Join(errs...)))
+		}
+	}
+
+	return errors.Join(allErrors...)
+
+}
+
+// SwampNames returns the names of the Swamps of the fixture, in the order of the fixture
+func (f *Fixture) SwampNames() []name.Name {
+	var swampNames []name.Name
+	for _, sanctuary := range f.Sanctuaries {
+		for _, realm := range sanctuary.Realms {
+			for _, swamp := range realm.Swamps {
+				swampNames = append(swampNames, name.New().Sanctuary(sanctuary.Name).Realm(realm.Name).Swamp(swamp.Name))
+			}
+		}
+	}
+	return swampNames
+}
+
+// validate checks the names, the keys and the values of the fixture
+func (f *Fixture) validate() error {
+
+	for _, pattern := range f.Patterns {
+		if pattern == nil {
+			return errors.New("invalid fixture: a pattern is empty")
+		}
+		patternName, err := name.Parse(pattern.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern.Pattern, err)
+		}
+		pattern.Pattern = patternName.Get()
+	}
+
+	swamps := make(map[string]bool)
+	for _, sanctuary := range f.Sanctuaries {
+		if sanctuary == nil {
+			return errors.New("invalid fixture: a sanctuary is empty")
+		}
+		for _, realm := range sanctuary.Realms {
+			if realm == nil {
+				return fmt.Errorf("invalid fixture: a realm of the sanctuary %s is empty", sanctuary.Name)
+			}
+			for _, swamp := range realm.Swamps {
+				if swamp == nil {
+					return fmt.Errorf("invalid fixture: a swamp of the realm %s/%s is empty", sanctuary.Name, realm.Name)
+				}
+				swampName := name.New().Sanctuary(sanctuary.Name).Realm(realm.Name).Swamp(swamp.Name)
+				if err := name.Validate(swampName); err != nil {
+					return fmt.Errorf("invalid swamp %q: %w", swampName.Get(), err)
+				}
+				if swampName.IsWildcardPattern() {
+					return fmt.Errorf("invalid swamp %q: the swamps of a fixture can not be patterns", swampName.Get())
+				}
+				if swamps[swampName.Get()] {
+					return fmt.Errorf("invalid swamp %q: the swamp is described more than once", swampName.Get())
+				}
+				swamps[swampName.Get()] = true
+				keys := make(map[string]bool)
+				for _, treasure := range swamp.Treasures {
+					if treasure == nil || treasure.Key == "" {
+						return fmt.Errorf("invalid treasure of the swamp %s: the key is required", swampName.Get())
+					}
+					if keys[treasure.Key] {
+						return fmt.Errorf("invalid treasure %s of the swamp %s: the key is used more than once", treasure.Key, swampName.Get())
+					}
+					keys[treasure.Key] = true
+					if _, err := treasure.value(); err != nil {
+						return fmt.Errorf("invalid treasure %s of the swamp %s: %w", treasure.Key, swampName.Get(), err)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// request returns the registration of the pattern
+func (p *Pattern) request() *hydraidego.RegisterSwampRequest {
+	request := &hydraidego.RegisterSwampRequest{
+		SwampPattern:    name.Load(p.Pattern),
+		IsInMemorySwamp: p.InMemory,
+		CloseAfterIdle:  p.CloseAfterIdle,
+		Force:           p.Force,
+	}
+	if !p.InMemory && (p.WriteInterval > 0 || p.MaxFileSize > 0) {
+		request.FilesystemSettings = &hydraidego.SwampFilesystemSettings{
+			WriteInterval: p.WriteInterval,
+			MaxFileSize:   p.MaxFileSize,
+		}
+	}
+	return request
+}
+
+// save saves the treasure into the swamp. The uint32 slices are pushed, the other treasures are saved as catalog
+// models built for the fields of the treasure
+func (t *Treasure) save(ctx context.Context, h hydraidego.Hydraidego, swampName name.Name) error {
+
+	value, err := t.value()
+	if err != nil {
+		return err
+	}
+
+	if values, ok := value.([]uint32); ok {
+		return h.Uint32SlicePush(ctx, swampName, []*hydraidego.KeyValuesPair{{Key: t.Key, Values: values}}, nil)
+	}
+
+	fields := []reflect.StructField{{Name: "Key", Type: reflect.TypeOf(""), Tag: `hydraide:"key"`}}
+	fieldValues := []any{t.Key}
+	addField := func(fieldName string, tag string, fieldValue any) {
+		fields = append(fields, reflect.StructField{Name: fieldName, Type: reflect.TypeOf(fieldValue), Tag: reflect.StructTag(`hydraide:"` + tag + `"`)})
+		fieldValues = append(fieldValues, fieldValue)
+	}
+
+	if value != nil {
+		addField("Value", "value", value)
+	}
+	if !t.ExpireAt.IsZero() {
+		addField("ExpireAt", "expireAt", t.ExpireAt)
+	}
+	if !t.CreatedAt.IsZero() {
+		addField("CreatedAt", "createdAt", t.CreatedAt)
+	}
+	if t.CreatedBy != "" {
+		addField("CreatedBy", "createdBy", t.CreatedBy)
+	}
+	if !t.UpdatedAt.IsZero() {
+		addField("UpdatedAt", "updatedAt", t.UpdatedAt)
+	}
+	if t.UpdatedBy != "" {
+		addField("UpdatedBy", "updatedBy", t.UpdatedBy)
+	}
+
+	model := reflect.New(reflect.StructOf(fields))
+	for i, fieldValue := range fieldValues {
+		model.Elem().Field(i).Set(reflect.ValueOf(fieldValue))
+	}
+
+	_, err = h.CatalogSave(ctx, swampName, model.Interface())
+	return err
+
+}
+
+// value converts the value of the fixture to the Go type of its Type. Nil if the treasure has no value
+func (t *Treasure) value() (any, error) {
+
+	if t.Value == nil {
+		if t.Type != "" {
+			return nil, fmt.Errorf("the value of the type %s is missing", t.Type)
+		}
+		return nil, nil
+	}
+
+	valueType := t.Type
+	if valueType == "" {
+		switch t.Value.(type) {
+		case string:
+			valueType = TypeString
+		case int, int64, uint64:
+			valueType = TypeInt64
+		case float64:
+			valueType = TypeFloat64
+		case bool:
+			valueType = TypeBool
+		case time.Time:
+			valueType = TypeTime
+		default:
+			return nil, fmt.Errorf("the type of the value %v is required", t.Value)
+		}
+	}
+
+	switch valueType {
+	case TypeString:
+		if value, ok := t.Value.(string); ok {
+			return value, nil
+		}
+	case TypeInt8:
+		return signedValue[int8](t.Value, math.MinInt8, math.MaxInt8)
+	case TypeInt16:
+		return signedValue[int16](t.Value, math.MinInt16, math.MaxInt16)
+	case TypeInt32:
+		return signedValue[int32](t.Value, math.MinInt32, math.MaxInt32)
+	case TypeInt64:
+		return signedValue[int64](t.Value, math.MinInt64, math.MaxInt64)
+	case TypeUint8:
+		return unsignedValue[uint8](t.Value, math.MaxUint8)
+	case TypeUint16:
+		return unsignedValue[uint16](t.Value, math.MaxUint16)
+	case TypeUint32:
+		return unsignedValue[uint32](t.Value, math.MaxUint32)
+	case TypeUint64:
+		return unsignedValue[uint64](t.Value, math.MaxUint64)
+	case TypeFloat32:
+		if value, ok := floatValue(t.Value); ok && math.Abs(value) <= math.MaxFloat32 {
+			return float32(value), nil
+		}
+	case TypeFloat64:
+		if value, ok := floatValue(t.Value); ok {
+			return value, nil
+		}
+	case TypeBool:
+		if value, ok := t.Value.(bool); ok {
+			return value, nil
+		}
+	case TypeTime:
+		switch value := t.Value.(type) {
+		case time.Time:
+			return value, nil
+		case string:
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, fmt.Errorf("the value %q is not an RFC 3339 time", value)
+			}
+			return parsed, nil
+		}
+	case TypeBytes:
+		if value, ok := t.Value.(string); ok {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("the value %q is not base64 encoded", value)
+			}
+			return decoded, nil
+		}
+	case TypeUint32Slice:
+		if items, ok := t.Value.([]any); ok {
+			values := make([]uint32, 0, len(items))
+			for _, item := range items {
+				value, err := unsignedValue[uint32](item, math.MaxUint32)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			return values, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", valueType)
+	}
+
+	return nil, fmt.Errorf("the value %v is not a valid %s", t.Value, valueType)
+
+}
+
+// signedValue converts an integer of the decoder to T, if it is in the range of T
+func signedValue[T int8 | int16 | int32 | int64](value any, minValue, maxValue int64) (T, error) {
+	switch v := value.(type) {
+	case int:
+		if int64(v) >= minValue && int64(v) <= maxValue {
+			return T(v), nil
+		}
+	case int64:
+		if v >= minValue && v <= maxValue {
+			return T(v), nil
+		}
+	}
+	return 0, fmt.Errorf("the value %v is not a valid %T", value, T(0))
+}
+
+// unsignedValue converts an integer of the decoder to T, if it is in the range of T
+func unsignedValue[T uint8 | uint16 | uint32 | uint64](value any, maxValue uint64) (T, error) {
+	switch v := value.(type) {
+	case int:
+		if v >= 0 && uint64(v) <= maxValue {
+			return T(v), nil
+		}
+	case int64:
+		if v >= 0 && uint64(v) <= maxValue {
+			return T(v), nil
+		}
+	case uint64:
+		if v <= maxValue {
+			return T(v), nil
+		}
+	}
+	return 0, fmt.Errorf("the value %v is not a valid %T", value, T(0))
+}
+
+// floatValue converts a number of the decoder to float64
+func floatValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,176 @@
+package fixtures
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/hydraidetest"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type book struct {
+	Key       string    `hydraide:"key"`
+	Value     string    `hydraide:"value"`
+	CreatedBy string    `hydraide:"createdBy"`
+	CreatedAt time.Time `hydraide:"createdAt"`
+}
+
+type title struct {
+	Key   string `hydraide:"key"`
+	Value string `hydraide:"value"`
+}
+
+type price struct {
+	Key   string  `hydraide:"key"`
+	Value float32 `hydraide:"value"`
+}
+
+type stock struct {
+	Key   string `hydraide:"key"`
+	Value uint16 `hydraide:"value"`
+}
+
+type cover struct {
+	Key   string `hydraide:"key"`
+	Value []byte `hydraide:"value"`
+}
+
+func TestParse(t *testing.T) {
+
+	t.Run("should parse the same fixture from JSON", func(t *testing.T) {
+
+		fixture, err := Parse([]byte(`{
+			"patterns": [{"pattern": "/fixtures/products/*/", "closeAfterIdle": "10s"}],
+			"sanctuaries": [{"name": "fixtures", "realms": [{"name": "products", "swamps": [{"name": "books", "treasures": [
+				{"key": "dune", "value": "Dune", "createdAt": "2025-01-02T03:04:05Z"},
+				{"key": "dune-stock", "type": "uint16", "value": 42},
+				{"key": "dune-readers", "type": "uint32Slice", "value": [3, 1, 2]}
+			]}]}]}]
+		}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "fixtures/products/*", fixture.Patterns[0].Pattern)
+		assert.Equal(t, 10*time.Second, fixture.Patterns[0].CloseAfterIdle)
+		assert.Equal(t, []string{"fixtures/products/books"}, swampPaths(fixture))
+
+		treasures := fixture.Sanctuaries[0].Realms[0].Swamps[0].Treasures
+		assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), treasures[0].CreatedAt)
+		value, err := treasures[1].value()
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(42), value)
+		value, err = treasures[2].value()
+		assert.NoError(t, err)
+		assert.Equal(t, []uint32{3, 1, 2}, value)
+
+	})
+
+	t.Run("should reject the invalid fixtures", func(t *testing.T) {
+		for fixtureName, data := range map[string]string{
+			"invalid document": `sanctuaries: [`,
+			"invalid pattern":  `patterns: [{pattern: "fixtures/../*"}]`,
+			"invalid swamp":    `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: "a b"}]}]}]`,
+			"wildcard swamp":   `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: "*"}]}]}]`,
+			"duplicate swamp":  `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books}, {name: books}]}]}]`,
+			"missing key":      `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books, treasures: [{value: 1}]}]}]}]`,
+			"duplicate key":    `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books, treasures: [{key: a}, {key: a}]}]}]}]`,
+			"unknown type":     `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books, treasures: [{key: a, type: complex, value: 1}]}]}]}]`,
+			"out of range":     `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books, treasures: [{key: a, type: uint8, value: 256}]}]}]}]`,
+			"invalid bytes":    `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books, treasures: [{key: a, type: bytes, value: "!"}]}]}]}]`,
+			"missing value":    `sanctuaries: [{name: fixtures, realms: [{name: products, swamps: [{name: books, treasures: [{key: a, type: int64}]}]}]}]`,
+		} {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err, fixtureName)
+		}
+	})
+
+}
+
+func TestSeed(t *testing.T) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	h := hydraidetest.New(t, nil)
+	swampName := name.New().Sanctuary("fixtures").Realm("products").Swamp("books")
+
+	t.Run("should load the treasures of the fixture", func(t *testing.T) {
+
+		Seed(t, h, "testdata/shop.yaml")
+
+		readBook := &book{}
+		require.NoError(t, h.CatalogRead(ctx, swampName, "dune", readBook))
+		assert.Equal(t, "Dune", readBook.Value)
+		assert.Equal(t, "fixtures", readBook.CreatedBy)
+		assert.True(t, readBook.CreatedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+		readPrice := &price{}
+		require.NoError(t, h.CatalogRead(ctx, swampName, "dune-price", readPrice))
+		assert.Equal(t, float32(12.5), readPrice.Value)
+
+		readStock := &stock{}
+		require.NoError(t, h.CatalogRead(ctx, swampName, "dune-stock", readStock))
+		assert.Equal(t, uint16(42), readStock.Value)
+
+		readCover := &cover{}
+		require.NoError(t, h.CatalogRead(ctx, swampName, "dune-cover", readCover))
+		assert.Equal(t, []byte("hello"), readCover.Value)
+
+		size, err := h.Uint32SliceSize(ctx, swampName, "dune-readers")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), size)
+
+		exists, err := h.IsKeyExists(ctx, swampName, "dune-draft")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+	})
+
+	t.Run("should tear down the swamps and the patterns after the test", func(t *testing.T) {
+
+		exists, err := h.IsSwampExist(ctx, swampName)
+		assert.True(t, err == nil || hydraidego.IsSwampNotFound(err))
+		assert.False(t, exists)
+
+		patterns, err := h.ListSwampPatterns(ctx)
+		assert.NoError(t, err)
+		for _, pattern := range patterns {
+			assert.NotEqual(t, "fixtures/products/*", pattern.SwampPattern.Get())
+		}
+
+	})
+
+	t.Run("should reset the modified swamps by a new load", func(t *testing.T) {
+
+		fixture, err := ParseFile("testdata/shop.yaml")
+		require.NoError(t, err)
+		require.NoError(t, fixture.Load(ctx, h))
+		defer func() {
+			assert.NoError(t, fixture.Teardown(ctx, h))
+		}()
+
+		_, err = h.CatalogSave(ctx, swampName, &title{Key: "extra", Value: "Extra"})
+		require.NoError(t, err)
+		_, err = h.CatalogSave(ctx, swampName, &title{Key: "dune", Value: "Changed"})
+		require.NoError(t, err)
+
+		require.NoError(t, fixture.Load(ctx, h))
+
+		assert.True(t, hydraidego.IsNotFound(h.CatalogRead(ctx, swampName, "extra", &title{})))
+		readTitle := &title{}
+		require.NoError(t, h.CatalogRead(ctx, swampName, "dune", readTitle))
+		assert.Equal(t, "Dune", readTitle.Value)
+
+	})
+
+}
+
+func swampPaths(fixture *Fixture) []string {
+	var paths []string
+	for _, swampName := range fixture.SwampNames() {
+		paths = append(paths, swampName.Get())
+	}
+	return paths
+}
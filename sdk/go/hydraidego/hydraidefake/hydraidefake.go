@@ -0,0 +1,110 @@
+// Package hydraidefake is an in-memory fake of the Hydraidego interface for the unit tests of the services.
+//
+// The fake keeps the Swamps in maps, in the memory of the test. It needs no server, no folder and no certificate,
+// and it starts in no time, so every test can have its own:
+//
+//	func TestUserService(t *testing.T) {
+//	    h := hydraidefake.New(nil)
+//	    service := NewUserService(h)
+//	    // ...
+//	}
+//
+// The fake is behind the same SDK as the server, so the models are converted, the statuses are returned and the
+// errors are mapped exactly the same way. Only the storage behind the SDK is replaced.
+//
+// ✅ Supported, with the semantics of the server:
+//   - Heartbeat, RegisterSwamp, DeRegisterSwamp, DeRegisterSwampAndPurge (ServerTimestamps is applied, the other
+//     settings are ignored)
+//   - IsSwampExist, ExistsMany, ParallelForEachSwamp, IsKeyExists, IsKeysExist, Count, CountMany, CountFiltered,
+//     CountManyFiltered, Destroy, DestroyMany
+//   - CatalogCreate, CatalogCreateMany, CatalogCreateManyToMany, CatalogSave, CatalogSaveMany, CatalogTrySaveMany,
+//     CatalogSaveManyToMany, CatalogSaveRaw
+//   - CatalogRead, CatalogReadRaw, CatalogReadMany and CatalogReadManyKeys (without filter expressions), CatalogUpdate, CatalogUpdateMany, CatalogMutate
+//   - CatalogDelete, CatalogDeleteMany, CatalogDeleteManyFromMany
+//   - Lock and Unlock, in the memory of the fake
+//   - ProfileSave, ProfileRead, ProfileReadFields
+//   - Subscribe, with and without the existing data
+//
+// ⚠️ Not supported:
+//   - Every other function returns an ErrCodeUnknown error with the "not implemented" message, for example the
+//     increments, the shadow delete, the history, the leases and the aggregations
+//   - SubscribeFrom always fails with IsReplayNotAvailable, like a Swamp without event journal
+//   - There is no persistence, expiration, idle close or quota
+//
+// 💡 A Swamp exists while it has at least one Treasure, so IsSwampExist returns an ErrCodeSwampNotFound error for
+// a Swamp whose last Treasure was deleted, like the server does after the empty Swamp is closed.
+//
+// 💡 Unlike the server, a Subscribe without the existing data returns only after the subscription is registered,
+// so a write right after the Subscribe is never missed.
+//
+// To inject an error, embed the fake in your own type and override the function:
+//
+//	type failingSave struct {
+//	    hydraidefake.Fake
+//	}
+//
+//	func (f *failingSave) CatalogSave(ctx context.Context, swampName name.Name, model any) (hydraidego.EventStatus, error) {
+//	    return hydraidego.StatusUnknown, hydraidego.NewError(hydraidego.ErrCodeConnectionError, "connection error")
+//	}
+package hydraidefake
+
+import (
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/internal/inprocess"
+)
+
+const (
+	defaultAllIslands = 1000
+	// fakeHost is the host name of the fake in the routing of the client
+	fakeHost = "fake"
+)
+
+// Options are the optional settings of the fake
+type Options struct {
+	// AllIslands is the number of the Islands the Swamps are hashed to. 0 means 1000
+	AllIslands uint64
+}
+
+// Fake is the Hydraidego interface backed by the memory, with some helpers for the tests
+type Fake interface {
+	hydraidego.Hydraidego
+	// SwampNames returns the names of all existing Swamps in alphabetical order. A Swamp exists while it has at
+	// least one Treasure.
+	SwampNames() []string
+	// Reset removes all Swamps and registered patterns, so the same fake can be reused by the next test. The open
+	// subscriptions get no more events.
+	Reset()
+}
+
+type fake struct {
+	hydraidego.Hydraidego
+	service *service
+}
+
+// New creates a new, empty fake. The options can be nil.
+func New(options *Options) Fake {
+
+	allIslands := uint64(defaultAllIslands)
+	if options != nil && options.AllIslands > 0 {
+		allIslands = options.AllIslands
+	}
+
+	s := newService()
+	conn := inprocess.NewConn(s)
+	conn.WaitForStreamReady = true
+
+	return &fake{
+		Hydraidego: hydraidego.New(inprocess.NewClient(hydraidepbgo.NewHydraideServiceClient(conn), fakeHost, allIslands, 0)),
+		service:    s,
+	}
+
+}
+
+func (f *fake) SwampNames() []string {
+	return f.service.swampNames()
+}
+
+func (f *fake) Reset() {
+	f.service.reset()
+}
@@ -0,0 +1,708 @@
+package hydraidefake
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testModel struct {
+	Key   string `hydraide:"key"`
+	Value string `hydraide:"value"`
+}
+
+type timestampedModel struct {
+	Key       string    `hydraide:"key"`
+	Value     string    `hydraide:"value"`
+	CreatedAt time.Time `hydraide:"createdAt"`
+}
+
+type validatedModel struct {
+	Key   string `hydraide:"key"`
+	Email string `hydraide:"value"`
+}
+
+func (m *validatedModel) Validate() error {
+	if m.Email == "" {
+		return errors.New("the email is required")
+	}
+	return nil
+}
+
+func TestFake(t *testing.T) {
+
+	swampName := name.New().Sanctuary("fake").Realm("test").Swamp("models")
+
+	t.Run("should implement the create, save, read, update and delete semantics of the server", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+		assert.NoError(t, h.Heartbeat(ctx))
+
+		err := h.CatalogRead(ctx, swampName, "alpha", &testModel{})
+		assert.True(t, hydraidego.IsSwampNotFound(err))
+
+		assert.NoError(t, h.CatalogCreate(ctx, swampName, &testModel{Key: "alpha", Value: "first"}))
+		err = h.CatalogCreate(ctx, swampName, &testModel{Key: "alpha", Value: "other"})
+		assert.True(t, hydraidego.IsAlreadyExists(err))
+
+		status, err := h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNothingChanged, status)
+		status, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "second"})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusModified, status)
+		status, err = h.CatalogSave(ctx, swampName, &testModel{Key: "beta", Value: "new"})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNew, status)
+
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "alpha", read))
+		assert.Equal(t, "second", read.Value)
+		err = h.CatalogRead(ctx, swampName, "missing", &testModel{})
+		assert.True(t, hydraidego.IsNotFound(err))
+
+		err = h.CatalogUpdate(ctx, swampName, &testModel{Key: "missing", Value: "value"})
+		assert.True(t, hydraidego.IsNotFound(err))
+		assert.NoError(t, h.CatalogUpdate(ctx, swampName, &testModel{Key: "beta", Value: "updated"}))
+
+		count, err := h.Count(ctx, swampName)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), count)
+
+		assert.NoError(t, h.CatalogDelete(ctx, swampName, "alpha"))
+		err = h.CatalogDelete(ctx, swampName, "alpha")
+		assert.True(t, hydraidego.IsNotFound(err))
+
+		// the swamp disappears with its last treasure, like on the server
+		assert.Equal(t, []string{swampName.Get()}, h.SwampNames())
+		assert.NoError(t, h.CatalogDelete(ctx, swampName, "beta"))
+		isExist, err := h.IsSwampExist(ctx, swampName)
+		assert.True(t, hydraidego.IsSwampNotFound(err))
+		assert.False(t, isExist)
+		assert.Empty(t, h.SwampNames())
+
+	})
+
+	t.Run("should count only the treasures matching the filter", func(t *testing.T) {
+
+		type jobModel struct {
+			Key      string    `hydraide:"key"`
+			Value    string    `hydraide:"value"`
+			ExpireAt time.Time `hydraide:"expireAt"`
+		}
+
+		ctx := context.Background()
+		h := New(nil)
+
+		otherSwamp := name.New().Sanctuary("fake").Realm("test").Swamp("others")
+		_, err := h.CatalogSave(ctx, otherSwamp, &jobModel{Key: "job-x", Value: "x", ExpireAt: time.Now().Add(-time.Minute)})
+		assert.NoError(t, err)
+
+		for key, expireAt := range map[string]time.Time{
+			"job-1":    time.Now().Add(-time.Minute),
+			"job-2":    time.Now().Add(-time.Second),
+			"job-3":    time.Now().Add(time.Hour),
+			"report-1": time.Now().Add(-time.Minute),
+		} {
+			_, err := h.CatalogSave(ctx, swampName, &jobModel{Key: key, Value: key, ExpireAt: expireAt})
+			assert.NoError(t, err)
+		}
+
+		overdue, err := h.CountFiltered(ctx, swampName, &hydraidego.CountFilter{ExpiredOnly: true, KeyPrefix: "job-"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), overdue)
+
+		all, err := h.CountFiltered(ctx, swampName, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), all)
+
+		updated, err := h.CountFiltered(ctx, swampName, &hydraidego.CountFilter{UpdatedSince: time.Now().Add(time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), updated)
+
+		counts, err := h.CountManyFiltered(ctx, []name.Name{swampName, otherSwamp}, &hydraidego.CountFilter{KeyPrefix: "job-"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int32{swampName.Get(): 3, otherSwamp.Get(): 1}, counts)
+
+	})
+
+	t.Run("should read the treasures in the order of the index", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		for _, value := range []string{"b", "c", "a"} {
+			_, err := h.CatalogSave(ctx, swampName, &testModel{Key: "key-" + value, Value: value})
+			assert.NoError(t, err)
+		}
+
+		var values []string
+		err := h.CatalogReadMany(ctx, swampName, &hydraidego.Index{
+			IndexType:  hydraidego.IndexValueString,
+			IndexOrder: hydraidego.IndexOrderDesc,
+			Limit:      2,
+		}, testModel{}, func(model any) error {
+			values = append(values, model.(*testModel).Value)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "b"}, values)
+
+	})
+
+	t.Run("should read only the keys in the order of the index", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		createdAt := time.Now().Add(-time.Hour).UTC()
+		for i, value := range []string{"b", "c", "a"} {
+			_, err := h.CatalogSave(ctx, swampName, &timestampedModel{
+				Key:       "key-" + value,
+				Value:     value,
+				CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+			})
+			assert.NoError(t, err)
+		}
+
+		var keys []string
+		err := h.CatalogReadManyKeys(ctx, swampName, &hydraidego.Index{
+			IndexType:  hydraidego.IndexCreationTime,
+			IndexOrder: hydraidego.IndexOrderAsc,
+		}, func(key string, metadata *hydraidego.KeyMetadata) error {
+			keys = append(keys, key)
+			assert.True(t, createdAt.Add(time.Duration(len(keys)-1)*time.Minute).Equal(metadata.CreatedAt))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"key-b", "key-c", "key-a"}, keys)
+
+	})
+
+	t.Run("should set the server timestamps of the registered patterns", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		assert.Nil(t, h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+			SwampPattern:     swampName,
+			IsInMemorySwamp:  true,
+			ServerTimestamps: true,
+		}))
+
+		// the time of the client is ignored
+		clientTime := time.Now().Add(-time.Hour)
+		model := &timestampedModel{Key: "alpha", Value: "first", CreatedAt: clientTime}
+		assert.NoError(t, h.CatalogCreate(ctx, swampName, model))
+		assert.True(t, model.CreatedAt.After(clientTime))
+
+		read := &timestampedModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "alpha", read))
+		assert.True(t, model.CreatedAt.Equal(read.CreatedAt))
+
+	})
+
+	t.Run("should stream the events of the subscriptions", func(t *testing.T) {
+
+		h := New(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		type event struct {
+			value  string
+			status hydraidego.EventStatus
+		}
+		var mu sync.Mutex
+		var events []event
+
+		// the subscription is registered before the Subscribe returns, so the next writes are always streamed
+		_, err := h.Subscribe(ctx, swampName, false, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event{value: model.(*testModel).Value, status: eventStatus})
+			return nil
+		})
+		assert.NoError(t, err)
+
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+		_, err = h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "second"})
+		assert.NoError(t, err)
+		assert.NoError(t, h.CatalogDelete(ctx, swampName, "alpha"))
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(events) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []event{
+			{value: "first", status: hydraidego.StatusNew},
+			{value: "second", status: hydraidego.StatusModified},
+			{value: "second", status: hydraidego.StatusDeleted},
+		}, events)
+
+	})
+
+	t.Run("should send the existing data before the subscribe returns", func(t *testing.T) {
+
+		h := New(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		for _, key := range []string{"c", "a", "b"} {
+			_, err := h.CatalogSave(ctx, swampName, &testModel{Key: key, Value: key})
+			assert.NoError(t, err)
+		}
+
+		var keys []string
+		_, err := h.Subscribe(ctx, swampName, true, testModel{}, func(model any, eventStatus hydraidego.EventStatus, err error) error {
+			keys = append(keys, model.(*testModel).Key)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// in the order of the creation
+		assert.Equal(t, []string{"c", "a", "b"}, keys)
+
+	})
+
+	t.Run("should stream the field-level changes of a profile", func(t *testing.T) {
+
+		type address struct {
+			City string
+		}
+		type settings struct {
+			Theme    string
+			PageSize int32
+			Address  *address
+		}
+
+		profileName := name.New().Sanctuary("fake").Realm("settings").Swamp("user-1")
+		h := New(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		assert.NoError(t, h.ProfileSave(ctx, profileName, &settings{Theme: "dark", PageSize: 20}))
+
+		var mu sync.Mutex
+		var changes []*hydraidego.ProfileChange
+		current := &settings{}
+		_, err := h.ProfileSubscribe(ctx, profileName, current, func(change *hydraidego.ProfileChange, err error) error {
+			assert.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, change)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// the current profile is loaded before the function returns
+		assert.Equal(t, "dark", current.Theme)
+		assert.Equal(t, int32(20), current.PageSize)
+
+		assert.NoError(t, h.ProfileSave(ctx, profileName, &settings{Theme: "light", PageSize: 20, Address: &address{City: "Budapest"}}))
+		err = h.CatalogDelete(ctx, profileName, "Theme")
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(changes) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// the unchanged page size is not streamed
+		assert.Equal(t, "Theme", changes[0].Field)
+		assert.Equal(t, "light", changes[0].Value)
+		assert.Equal(t, hydraidego.StatusModified, changes[0].Status)
+		assert.Equal(t, "Address", changes[1].Field)
+		assert.Equal(t, &address{City: "Budapest"}, changes[1].Value)
+		// the nil address was saved by the first ProfileSave, too
+		assert.Equal(t, hydraidego.StatusModified, changes[1].Status)
+		assert.Equal(t, "Theme", changes[2].Field)
+		assert.Nil(t, changes[2].Value)
+		assert.Equal(t, hydraidego.StatusDeleted, changes[2].Status)
+		assert.False(t, changes[2].EventTime.IsZero())
+
+	})
+
+	t.Run("should read only the given fields of a profile", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		type profile struct {
+			Name  string
+			Email string
+		}
+
+		profileName := name.New().Sanctuary("fake").Realm("test").Swamp("profile")
+		assert.NoError(t, h.ProfileSave(ctx, profileName, &profile{Name: "Alice", Email: "alice@example.com"}))
+
+		read := &profile{}
+		assert.NoError(t, h.ProfileReadFields(ctx, profileName, read, "Email"))
+		assert.Equal(t, &profile{Email: "alice@example.com"}, read)
+
+		err := h.ProfileReadFields(ctx, profileName, read, "Phone")
+		assert.Equal(t, hydraidego.ErrCodeInvalidArgument, hydraidego.GetErrorCode(err))
+
+	})
+
+	t.Run("should call the function for every swamp of the patterns with bounded concurrency", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		for _, user := range []string{"alice", "bob", "carol", "dave", "eve"} {
+			_, err := h.CatalogSave(ctx, name.New().Sanctuary("fake").Realm("users").Swamp(user), &testModel{Key: "k", Value: "v"})
+			assert.NoError(t, err)
+		}
+
+		var mu sync.Mutex
+		var visited []string
+		running, maxRunning := 0, 0
+		err := h.ParallelForEachSwamp(ctx, []name.Name{
+			name.New().Sanctuary("fake").Realm("users").Swamp("*"),
+			name.New().Sanctuary("fake").Realm("users").Swamp("alice"),
+			name.New().Sanctuary("fake").Realm("users").Swamp("frank"),
+		}, 2, func(ctx context.Context, swampName name.Name) error {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			visited = append(visited, swampName.Get())
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"fake/users/alice", "fake/users/bob", "fake/users/carol", "fake/users/dave",
+			"fake/users/eve", "fake/users/frank"}, visited)
+		assert.Equal(t, 2, maxRunning)
+
+		// the first error stops the processing
+		stopErr := errors.New("stop")
+		calls := 0
+		err = h.ParallelForEachSwamp(ctx, []name.Name{name.New().Sanctuary("fake").Realm("users").Swamp("*")}, 1,
+			func(ctx context.Context, swampName name.Name) error {
+				calls++
+				return stopErr
+			})
+		assert.ErrorIs(t, err, stopErr)
+		assert.Equal(t, 1, calls)
+
+	})
+
+	t.Run("should purge the swamps of the pattern with the deregistration", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		sessions := name.New().Sanctuary("fake").Realm("sessions").Swamp("*")
+		assert.Nil(t, h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{SwampPattern: sessions}))
+		for _, swampName := range []name.Name{
+			name.New().Sanctuary("fake").Realm("sessions").Swamp("bob"),
+			name.New().Sanctuary("fake").Realm("sessions").Swamp("alice"),
+			name.New().Sanctuary("fake").Realm("users").Swamp("alice"),
+		} {
+			_, err := h.CatalogSave(ctx, swampName, &testModel{Key: "k", Value: "v"})
+			assert.NoError(t, err)
+		}
+
+		// the dry run changes nothing
+		purged, errs := h.DeRegisterSwampAndPurge(ctx, sessions, true)
+		assert.Nil(t, errs)
+		assert.Len(t, purged, 2)
+		assert.Equal(t, "fake/sessions/alice", purged[0].Get())
+		assert.Equal(t, "fake/sessions/bob", purged[1].Get())
+		exists, err := h.ExistsMany(ctx, []name.Name{sessions})
+		assert.NoError(t, err)
+		assert.True(t, exists["fake/sessions/alice"])
+
+		purged, errs = h.DeRegisterSwampAndPurge(ctx, sessions, false)
+		assert.Nil(t, errs)
+		assert.Len(t, purged, 2)
+		exists, err = h.ExistsMany(ctx, []name.Name{sessions, name.New().Sanctuary("fake").Realm("users").Swamp("*")})
+		assert.NoError(t, err)
+		assert.False(t, exists["fake/sessions/alice"])
+		assert.False(t, exists["fake/sessions/bob"])
+		assert.True(t, exists["fake/users/alice"], "the swamps of the other patterns are kept")
+
+	})
+
+	t.Run("should destroy the swamps of a wildcard pattern with the confirmation", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		for _, swampName := range []name.Name{
+			name.New().Sanctuary("tenant-1").Realm("users").Swamp("alice"),
+			name.New().Sanctuary("tenant-1").Realm("orders").Swamp("1"),
+			name.New().Sanctuary("tenant-2").Realm("users").Swamp("bob"),
+		} {
+			_, err := h.CatalogSave(ctx, swampName, &testModel{Key: "k", Value: "v"})
+			assert.NoError(t, err)
+		}
+
+		tenant := name.New().Sanctuary("tenant-1").Realm("*").Swamp("*")
+		err := h.DestroyMany(ctx, tenant, "tenant-2/*/*", nil)
+		assert.Equal(t, hydraidego.ErrCodeInvalidArgument, hydraidego.GetErrorCode(err))
+
+		var destroyed []string
+		err = h.DestroyMany(ctx, tenant, tenant.Get(), func(swampName name.Name) error {
+			destroyed = append(destroyed, swampName.Get())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tenant-1/orders/1", "tenant-1/users/alice"}, destroyed)
+
+		exists, err := h.ExistsMany(ctx, []name.Name{tenant, name.New().Sanctuary("tenant-2").Realm("*").Swamp("*")})
+		assert.NoError(t, err)
+		assert.False(t, exists["tenant-1/users/alice"])
+		assert.True(t, exists["tenant-2/users/bob"], "the swamps of the other tenants are kept")
+
+	})
+
+	t.Run("should save the valid models of a batch and report the invalid ones", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		assert.NoError(t, h.CatalogCreate(ctx, swampName, &testModel{Key: "alpha", Value: "first"}))
+
+		models := []any{
+			&testModel{Key: "alpha", Value: "second"},
+			&testModel{Value: "without key"},
+			&testModel{Key: "beta", Value: "new"},
+			testModel{Key: "gamma", Value: "not a pointer"},
+		}
+
+		err := h.CatalogSaveMany(ctx, swampName, models, nil)
+		assert.True(t, hydraidego.IsInvalidModel(err))
+		assert.Contains(t, hydraidego.GetErrorMessage(err), "index 1", "the error names the invalid model")
+
+		type result struct {
+			index  int
+			key    string
+			status hydraidego.EventStatus
+			failed bool
+		}
+		var results []result
+		err = h.CatalogTrySaveMany(ctx, swampName, models, func(index int, key string, status hydraidego.EventStatus, err error) error {
+			if err != nil {
+				assert.True(t, hydraidego.IsInvalidModel(err))
+			}
+			results = append(results, result{index: index, key: key, status: status, failed: err != nil})
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []result{
+			{index: 0, key: "alpha", status: hydraidego.StatusModified},
+			{index: 1, key: "", status: hydraidego.StatusUnknown, failed: true},
+			{index: 2, key: "beta", status: hydraidego.StatusNew},
+			{index: 3, key: "", status: hydraidego.StatusUnknown, failed: true},
+		}, results)
+
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "beta", read))
+		assert.Equal(t, "new", read.Value)
+
+		err = h.CatalogTrySaveMany(ctx, swampName, models, nil)
+		assert.True(t, hydraidego.IsInvalidModel(err), "without an iterator the invalid models are returned as an error")
+		assert.Contains(t, hydraidego.GetErrorMessage(err), "2 of 4 models are not saved")
+
+		err = h.CatalogTrySaveMany(ctx, swampName, []any{&testModel{Key: "delta", Value: "valid"}}, nil)
+		assert.NoError(t, err)
+
+	})
+
+	t.Run("should not write the models failing their validation", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		_, err := h.CatalogSave(ctx, swampName, &validatedModel{Key: "alpha"})
+		assert.True(t, hydraidego.IsInvalidModel(err))
+		assert.Contains(t, hydraidego.GetErrorMessage(err), "the email is required")
+		err = h.CatalogRead(ctx, swampName, "alpha", &validatedModel{})
+		assert.True(t, hydraidego.IsSwampNotFound(err))
+
+		err = h.CatalogSaveMany(ctx, swampName, []any{&validatedModel{Key: "alpha", Email: "a@b.c"}, &validatedModel{Key: "beta"}}, nil)
+		assert.True(t, hydraidego.IsInvalidModel(err))
+		assert.Contains(t, hydraidego.GetErrorMessage(err), "index 1")
+
+		status, err := h.CatalogSave(ctx, swampName, &validatedModel{Key: "alpha", Email: "a@b.c"})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNew, status)
+
+	})
+
+	t.Run("should read and save the treasures without a model", func(t *testing.T) {
+
+		ctx := hydraidego.WithActor(context.Background(), "exporter")
+		h := New(nil)
+
+		_, err := h.CatalogSave(ctx, swampName, &timestampedModel{Key: "alpha", Value: "first", CreatedAt: time.Now()})
+		assert.NoError(t, err)
+
+		treasure, err := h.CatalogReadRaw(ctx, swampName, "alpha")
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StringValue("first"), treasure.Value)
+		assert.IsType(t, time.Time{}, treasure.Metadata[hydraidego.MetadataCreatedAt])
+		_, err = h.CatalogReadRaw(ctx, swampName, "missing")
+		assert.True(t, hydraidego.IsNotFound(err))
+
+		// the treasure is copied to another swamp without knowing its model
+		otherSwamp := name.New().Sanctuary("fake").Realm("test").Swamp("copies")
+		status, err := h.CatalogSaveRaw(ctx, otherSwamp, treasure)
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNew, status)
+		read := &timestampedModel{}
+		assert.NoError(t, h.CatalogRead(ctx, otherSwamp, "alpha", read))
+		assert.Equal(t, "first", read.Value)
+
+		status, err = h.CatalogSaveRaw(ctx, otherSwamp, &hydraidego.Treasure{Key: "visits", Value: hydraidego.Value{Type: hydraidego.ValueTypeInt32, Int: 42}})
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.StatusNew, status)
+		treasure, err = h.CatalogReadRaw(ctx, otherSwamp, "visits")
+		assert.NoError(t, err)
+		assert.Equal(t, hydraidego.Value{Type: hydraidego.ValueTypeInt32, Int: 42}, treasure.Value)
+		assert.Equal(t, "exporter", treasure.Metadata[hydraidego.MetadataCreatedBy])
+		assert.Equal(t, "exporter", treasure.Metadata[hydraidego.MetadataUpdatedBy])
+
+		_, err = h.CatalogSaveRaw(ctx, otherSwamp, &hydraidego.Treasure{Key: "invalid", Metadata: map[hydraidego.MetadataField]any{hydraidego.MetadataCreatedAt: "yesterday"}})
+		assert.Equal(t, hydraidego.ErrCodeInvalidModel, hydraidego.GetErrorCode(err))
+
+	})
+
+	t.Run("should read, mutate and save the treasure under a lock", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		var currents []any
+		appendValue := func(current any) (any, error) {
+			currents = append(currents, current)
+			model, ok := current.(*testModel)
+			if !ok {
+				return &testModel{Key: "alpha", Value: "first"}, nil
+			}
+			model.Value += ",second"
+			return model, nil
+		}
+
+		assert.NoError(t, h.CatalogMutate(ctx, swampName, "alpha", testModel{}, appendValue))
+		assert.NoError(t, h.CatalogMutate(ctx, swampName, "alpha", testModel{}, appendValue))
+		assert.Len(t, currents, 2)
+		assert.Nil(t, currents[0], "the missing treasure is passed as nil")
+		assert.Equal(t, &testModel{Key: "alpha", Value: "first,second"}, currents[1])
+
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "alpha", read))
+		assert.Equal(t, "first,second", read.Value)
+
+	})
+
+	t.Run("should not save the mutation returning nil or another key", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		assert.NoError(t, h.CatalogMutate(ctx, swampName, "alpha", testModel{}, func(current any) (any, error) {
+			return nil, nil
+		}))
+		_, err := h.IsSwampExist(ctx, swampName)
+		assert.True(t, hydraidego.IsSwampNotFound(err), "nothing is saved for a nil mutation")
+
+		err = h.CatalogMutate(ctx, swampName, "alpha", testModel{}, func(current any) (any, error) {
+			return &testModel{Key: "beta", Value: "other"}, nil
+		})
+		assert.True(t, hydraidego.IsInvalidArgument(err))
+		_, err = h.IsSwampExist(ctx, swampName)
+		assert.True(t, hydraidego.IsSwampNotFound(err), "the model of another key is not saved")
+
+	})
+
+	t.Run("should release the lock if the read, the mutation or the save fails", func(t *testing.T) {
+
+		type numberModel struct {
+			Key   string `hydraide:"key"`
+			Value int64  `hydraide:"value"`
+		}
+
+		h := New(nil)
+		_, err := h.CatalogSave(context.Background(), swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+
+		// the read fails, because the stored string does not decode into the int64 of the model in strict mode
+		strictCtx := hydraidego.WithStrictDecoding(context.Background(), true)
+		err = h.CatalogMutate(strictCtx, swampName, "alpha", numberModel{}, func(current any) (any, error) {
+			return current, nil
+		})
+		assert.NotNil(t, hydraidego.GetTypeMismatch(err))
+
+		mutateErr := errors.New("the mutation failed")
+		err = h.CatalogMutate(context.Background(), swampName, "alpha", testModel{}, func(current any) (any, error) {
+			return nil, mutateErr
+		})
+		assert.ErrorIs(t, err, mutateErr)
+
+		// the save fails, because the context is canceled by the mutation
+		cancelCtx, cancel := context.WithCancel(context.Background())
+		err = h.CatalogMutate(cancelCtx, swampName, "alpha", testModel{}, func(current any) (any, error) {
+			cancel()
+			return &testModel{Key: "alpha", Value: "second"}, nil
+		})
+		assert.Error(t, err)
+
+		// the lock of the key is free, so the next mutation does not wait for the TTL of the failed ones
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, h.CatalogMutate(ctx, swampName, "alpha", testModel{}, func(current any) (any, error) {
+			return &testModel{Key: "alpha", Value: "third"}, nil
+		}))
+
+	})
+
+	t.Run("should return an error for the unsupported functions", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		_, err := h.IncrementInt32(ctx, swampName, "counter", 1, nil)
+		assert.True(t, hydraidego.IsUnknown(err))
+
+		_, err = h.SubscribeFrom(ctx, swampName, time.Now(), testModel{}, func(model any, eventStatus hydraidego.EventStatus, eventTime time.Time, err error) error {
+			return nil
+		})
+		assert.True(t, hydraidego.IsReplayNotAvailable(err))
+
+	})
+
+	t.Run("should remove everything by the reset", func(t *testing.T) {
+
+		ctx := context.Background()
+		h := New(nil)
+
+		_, err := h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "first"})
+		assert.NoError(t, err)
+
+		h.Reset()
+
+		isExist, err := h.IsSwampExist(ctx, swampName)
+		assert.True(t, hydraidego.IsSwampNotFound(err))
+		assert.False(t, isExist)
+
+	})
+
+}
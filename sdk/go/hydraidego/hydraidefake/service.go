@@ -0,0 +1,1118 @@
+package hydraidefake
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/internal/inprocess"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errorDomain is the domain of the ErrorInfo details, the same as the server uses
+const errorDomain = "hydraide"
+
+// service is the map-based implementation of the RPCs of the server. The RPCs it does not implement return an
+// Unimplemented error.
+type service struct {
+	hydraidepbgo.UnimplementedHydraideServiceServer
+
+	mu       sync.Mutex
+	swamps   map[string]*swamp
+	patterns map[string]*hydraidepbgo.RegisterSwampRequest
+	// locks are the held business locks by their keys
+	locks      map[string]*heldLock
+	lockNumber uint64
+}
+
+// heldLock is a business lock, released by the Unlock or by its TTL
+type heldLock struct {
+	lockID   string
+	released chan struct{}
+	timer    *time.Timer
+}
+
+// swamp is the content of one Swamp
+type swamp struct {
+	treasures map[string]*storedTreasure
+	// sequence is the sequence number of the last event, and the creation order of the treasures
+	sequence    uint64
+	subscribers map[*subscriber]struct{}
+}
+
+type storedTreasure struct {
+	treasure *hydraidepbgo.Treasure
+	// order is the position of the treasure in the creation order of the Swamp
+	order uint64
+}
+
+// subscriber queues the events of a subscription. The events are sent by the stream handler, not by the writer, so
+// a slow or blocking iterator of the SDK never blocks the writes.
+type subscriber struct {
+	mu     sync.Mutex
+	queue  []*hydraidepbgo.SubscribeToEventsResponse
+	notify chan struct{}
+}
+
+func newService() *service {
+	return &service{
+		swamps:   make(map[string]*swamp),
+		patterns: make(map[string]*hydraidepbgo.RegisterSwampRequest),
+		locks:    make(map[string]*heldLock),
+	}
+}
+
+// reset removes all Swamps and registered patterns, and releases the locks. The open subscriptions stay, but they
+// get no more events.
+func (s *service) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.swamps = make(map[string]*swamp)
+	s.patterns = make(map[string]*hydraidepbgo.RegisterSwampRequest)
+	for key := range s.locks {
+		s.releaseLock(key)
+	}
+}
+
+// swampNames returns the names of the existing Swamps in alphabetical order
+func (s *service) swampNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.swamps))
+	for swampName, sw := range s.swamps {
+		if len(sw.treasures) > 0 {
+			names = append(names, swampName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *service) Heartbeat(_ context.Context, in *hydraidepbgo.HeartbeatRequest) (*hydraidepbgo.HeartbeatResponse, error) {
+	return &hydraidepbgo.HeartbeatResponse{
+		Pong: in.Ping,
+	}, nil
+}
+
+// Lock waits until the key is free, or the context is done, like the server
+func (s *service) Lock(ctx context.Context, in *hydraidepbgo.LockRequest) (*hydraidepbgo.LockResponse, error) {
+
+	for {
+
+		s.mu.Lock()
+		held, ok := s.locks[in.GetKey()]
+		if !ok {
+			s.lockNumber++
+			lockID := fmt.Sprintf("fake-lock-%d", s.lockNumber)
+			held = &heldLock{lockID: lockID, released: make(chan struct{})}
+			key := in.GetKey()
+			held.timer = time.AfterFunc(time.Duration(in.GetTTL())*time.Millisecond, func() {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				if current, ok := s.locks[key]; ok && current.lockID == lockID {
+					s.releaseLock(key)
+				}
+			})
+			s.locks[key] = held
+			s.mu.Unlock()
+			return &hydraidepbgo.LockResponse{LockID: lockID}, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-held.released:
+		case <-ctx.Done():
+			return nil, statusError(codes.DeadlineExceeded, hydraidepbgo.ErrorReason_LOCK_DEADLINE_EXCEEDED, fmt.Sprintf("lock deadline exceeded: %s", ctx.Err()))
+		}
+
+	}
+
+}
+
+func (s *service) Unlock(_ context.Context, in *hydraidepbgo.UnlockRequest) (*hydraidepbgo.UnlockResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	held, ok := s.locks[in.GetKey()]
+	if !ok || held.lockID != in.GetLockID() {
+		return nil, statusError(codes.NotFound, hydraidepbgo.ErrorReason_LOCK_NOT_FOUND, fmt.Sprintf("lock not found: %s", in.GetKey()))
+	}
+	s.releaseLock(in.GetKey())
+
+	return &hydraidepbgo.UnlockResponse{}, nil
+
+}
+
+// releaseLock releases the lock of the key and wakes up the waiting callers. The caller holds the mutex
+func (s *service) releaseLock(key string) {
+	held := s.locks[key]
+	held.timer.Stop()
+	close(held.released)
+	delete(s.locks, key)
+}
+
+func (s *service) RegisterSwamp(_ context.Context, in *hydraidepbgo.RegisterSwampRequest) (*hydraidepbgo.RegisterSwampResponse, error) {
+
+	if in.GetSwampPattern() == "" {
+		return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampPattern cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.patterns[in.GetSwampPattern()] = proto.Clone(in).(*hydraidepbgo.RegisterSwampRequest)
+
+	return &hydraidepbgo.RegisterSwampResponse{}, nil
+
+}
+
+func (s *service) DeRegisterSwamp(_ context.Context, in *hydraidepbgo.DeRegisterSwampRequest) (*hydraidepbgo.DeRegisterSwampResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := &hydraidepbgo.DeRegisterSwampResponse{}
+
+	// like the destroy, the purge sends no events
+	if in.GetPurge() || in.GetDryRun() {
+		for swampName, sw := range s.swamps {
+			if len(sw.treasures) > 0 && matchPattern(in.GetSwampPattern(), swampName) {
+				response.PurgedSwamps = append(response.PurgedSwamps, swampName)
+				if !in.GetDryRun() {
+					clear(sw.treasures)
+				}
+			}
+		}
+		sort.Strings(response.PurgedSwamps)
+		if in.GetDryRun() {
+			return response, nil
+		}
+	}
+
+	delete(s.patterns, in.GetSwampPattern())
+
+	return response, nil
+
+}
+
+func (s *service) IsSwampExist(_ context.Context, in *hydraidepbgo.IsSwampExistRequest) (*hydraidepbgo.IsSwampExistResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.existingSwamp(in.GetSwampName()); err != nil {
+		return &hydraidepbgo.IsSwampExistResponse{IsExist: false}, err
+	}
+
+	return &hydraidepbgo.IsSwampExistResponse{IsExist: true}, nil
+
+}
+
+func (s *service) ExistsMany(_ context.Context, in *hydraidepbgo.ExistsManyRequest) (*hydraidepbgo.ExistsManyResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := &hydraidepbgo.ExistsManyResponse{
+		Results: make([]*hydraidepbgo.ExistsManyResult, 0, len(in.GetSwamps())),
+	}
+
+	for _, swampIdentifier := range in.GetSwamps() {
+
+		pattern := swampIdentifier.GetSwampName()
+		if strings.Count(pattern, "/") != 2 {
+			return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("invalid swamp name or pattern: %s", pattern))
+		}
+		if strings.HasPrefix(pattern, "*/") {
+			return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "the sanctuary part of the pattern cannot be a wildcard")
+		}
+
+		result := &hydraidepbgo.ExistsManyResult{
+			SwampName: pattern,
+		}
+		for swampName, sw := range s.swamps {
+			if len(sw.treasures) > 0 && matchPattern(pattern, swampName) {
+				result.ExistingSwamps = append(result.ExistingSwamps, swampName)
+			}
+		}
+		sort.Strings(result.ExistingSwamps)
+		response.Results = append(response.Results, result)
+
+	}
+
+	return response, nil
+
+}
+
+func (s *service) IsKeyExist(_ context.Context, in *hydraidepbgo.IsKeyExistRequest) (*hydraidepbgo.IsKeyExistResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sw, err := s.existingSwamp(in.GetSwampName())
+	if err != nil {
+		return nil, err
+	}
+
+	_, isExist := sw.treasures[in.GetKey()]
+	return &hydraidepbgo.IsKeyExistResponse{IsExist: isExist}, nil
+
+}
+
+func (s *service) IsKeysExist(_ context.Context, in *hydraidepbgo.IsKeysExistRequest) (*hydraidepbgo.IsKeysExistResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sw, err := s.existingSwamp(in.GetSwampName())
+	if err != nil {
+		return nil, err
+	}
+
+	isExist := make([]bool, len(in.GetKeys()))
+	for i, key := range in.GetKeys() {
+		_, isExist[i] = sw.treasures[key]
+	}
+
+	return &hydraidepbgo.IsKeysExistResponse{IsExist: isExist}, nil
+
+}
+
+func (s *service) Set(_ context.Context, in *hydraidepbgo.SetRequest) (*hydraidepbgo.SetResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, swampRequest := range in.GetSwamps() {
+		if swampRequest.GetSwampName() == "" {
+			return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+		}
+		if swampRequest.GetKeyValues() == nil {
+			return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("KeyValues cannot be empty for the swamp: %s", swampRequest.GetSwampName()))
+		}
+	}
+
+	response := &hydraidepbgo.SetResponse{}
+
+	for _, swampRequest := range in.GetSwamps() {
+
+		swampName := swampRequest.GetSwampName()
+
+		// the same meaningless and missing cases as the server
+		if !swampRequest.GetCreateIfNotExist() && !swampRequest.GetOverwrite() {
+			response.Swamps = append(response.Swamps, &hydraidepbgo.SwampResponse{
+				SwampName:       swampName,
+				KeysAndStatuses: []*hydraidepbgo.KeyStatusPair{},
+				ErrorCode:       hydraidepbgo.SwampResponse_CanNotBeExecuted.Enum(),
+			})
+			continue
+		}
+		if !swampRequest.GetCreateIfNotExist() && !s.isSwampExist(swampName) {
+			response.Swamps = append(response.Swamps, &hydraidepbgo.SwampResponse{
+				SwampName:       swampName,
+				KeysAndStatuses: []*hydraidepbgo.KeyStatusPair{},
+				ErrorCode:       hydraidepbgo.SwampResponse_SwampDoesNotExist.Enum(),
+			})
+			continue
+		}
+
+		sw := s.summonSwamp(swampName)
+		serverTimestamps := s.isServerTimestamped(swampName)
+		keysAndStatuses := make([]*hydraidepbgo.KeyStatusPair, 0, len(swampRequest.GetKeyValues()))
+
+		for _, keyValuePair := range swampRequest.GetKeyValues() {
+
+			existing, isExist := sw.treasures[keyValuePair.GetKey()]
+
+			if !swampRequest.GetCreateIfNotExist() && !isExist {
+				keysAndStatuses = append(keysAndStatuses, &hydraidepbgo.KeyStatusPair{
+					Key:    keyValuePair.GetKey(),
+					Status: hydraidepbgo.Status_NOT_FOUND,
+				})
+				continue
+			}
+			if !swampRequest.GetOverwrite() && isExist {
+				keysAndStatuses = append(keysAndStatuses, &hydraidepbgo.KeyStatusPair{
+					Key:    keyValuePair.GetKey(),
+					Status: hydraidepbgo.Status_NOTHING_CHANGED,
+				})
+				continue
+			}
+
+			var old *hydraidepbgo.Treasure
+			t := &hydraidepbgo.Treasure{Key: keyValuePair.GetKey(), IsExist: true}
+			if isExist {
+				old = existing.treasure
+				t = proto.Clone(old).(*hydraidepbgo.Treasure)
+			}
+			applyKeyValuePair(t, keyValuePair, serverTimestamps)
+
+			keyStatusPair := &hydraidepbgo.KeyStatusPair{
+				Key: keyValuePair.GetKey(),
+			}
+
+			switch {
+			case !isExist:
+				if serverTimestamps {
+					t.CreatedAt = timestamppb.Now()
+				}
+				sw.sequence++
+				sw.treasures[t.Key] = &storedTreasure{treasure: t, order: sw.sequence}
+				sw.publish(&hydraidepbgo.SubscribeToEventsResponse{
+					SwampName:       swampName,
+					Treasure:        t,
+					OldTreasure:     &hydraidepbgo.Treasure{},
+					DeletedTreasure: &hydraidepbgo.Treasure{},
+					Status:          hydraidepbgo.Status_NEW,
+				})
+				keyStatusPair.Status = hydraidepbgo.Status_NEW
+			case !proto.Equal(old, t):
+				if serverTimestamps {
+					t.UpdatedAt = timestamppb.Now()
+				}
+				sw.sequence++
+				existing.treasure = t
+				sw.publish(&hydraidepbgo.SubscribeToEventsResponse{
+					SwampName:       swampName,
+					Treasure:        t,
+					OldTreasure:     old,
+					DeletedTreasure: &hydraidepbgo.Treasure{},
+					Status:          hydraidepbgo.Status_UPDATED,
+				})
+				keyStatusPair.Status = hydraidepbgo.Status_UPDATED
+			default:
+				keyStatusPair.Status = hydraidepbgo.Status_NOTHING_CHANGED
+			}
+
+			if serverTimestamps {
+				keyStatusPair.CreatedAt = t.GetCreatedAt()
+				keyStatusPair.UpdatedAt = t.GetUpdatedAt()
+			}
+			keysAndStatuses = append(keysAndStatuses, keyStatusPair)
+
+		}
+
+		response.Swamps = append(response.Swamps, &hydraidepbgo.SwampResponse{
+			SwampName:       swampName,
+			KeysAndStatuses: keysAndStatuses,
+		})
+
+	}
+
+	return response, nil
+
+}
+
+func (s *service) Get(_ context.Context, in *hydraidepbgo.GetRequest) (*hydraidepbgo.GetResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, swampRequest := range in.GetSwamps() {
+		if _, err := s.existingSwamp(swampRequest.GetSwampName()); err != nil {
+			return nil, err
+		}
+		if len(swampRequest.GetKeys()) == 0 || swampRequest.GetKeys()[0] == "" {
+			return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "Keys cannot be empty")
+		}
+	}
+
+	response := &hydraidepbgo.GetResponse{}
+	for _, swampRequest := range in.GetSwamps() {
+
+		sw := s.swamps[swampRequest.GetSwampName()]
+		swampResponse := &hydraidepbgo.GetSwampResponse{
+			SwampName: swampRequest.GetSwampName(),
+			IsExist:   true,
+		}
+		for _, key := range swampRequest.GetKeys() {
+			if stored, ok := sw.treasures[key]; ok {
+				swampResponse.Treasures = append(swampResponse.Treasures, project(stored.treasure, swampRequest.Projection))
+				continue
+			}
+			swampResponse.Treasures = append(swampResponse.Treasures, &hydraidepbgo.Treasure{Key: key, IsExist: false})
+		}
+		response.Swamps = append(response.Swamps, swampResponse)
+
+	}
+
+	return response, nil
+
+}
+
+func (s *service) GetAll(_ context.Context, in *hydraidepbgo.GetAllRequest) (*hydraidepbgo.GetAllResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sw, err := s.existingSwamp(in.GetSwampName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &hydraidepbgo.GetAllResponse{
+		Treasures: sw.inCreationOrder(),
+	}, nil
+
+}
+
+func (s *service) GetByIndex(_ context.Context, in *hydraidepbgo.GetByIndexRequest) (*hydraidepbgo.GetByIndexResponse, error) {
+
+	if in.GetFilterExpr() != "" {
+		return nil, status.Error(codes.Unimplemented, "the fake does not support the filter expressions")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sw, err := s.existingSwamp(in.GetSwampName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &hydraidepbgo.GetByIndexResponse{
+		Treasures: paginate(sw.byIndex(in.GetIndexType(), in.GetOrderType()), in.GetFrom(), in.GetLimit(), in.GetKeysOnly(), in.Projection),
+	}, nil
+
+}
+
+// GetAllStream sends the treasures in their creation order, in one message
+func (s *service) GetAllStream(in *hydraidepbgo.GetAllStreamRequest, stream hydraidepbgo.HydraideService_GetAllStreamServer) error {
+
+	if in.GetFilterExpr() != "" {
+		return status.Error(codes.Unimplemented, "the fake does not support the filter expressions")
+	}
+
+	s.mu.Lock()
+	sw, err := s.existingSwamp(in.GetSwampName())
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	treasures := paginate(sw.inCreationOrder(), in.GetFrom(), in.GetLimit(), in.GetKeysOnly(), in.Projection)
+	s.mu.Unlock()
+
+	if len(treasures) == 0 {
+		return nil
+	}
+
+	return stream.Send(&hydraidepbgo.GetAllStreamResponse{Treasures: treasures})
+
+}
+
+// paginate returns the page of the treasures with the parts selected by the keys only flag or the projection, the
+// same way as the server does
+func paginate(treasures []*hydraidepbgo.Treasure, from int32, limit int32, keysOnly bool, projection *hydraidepbgo.Projection) []*hydraidepbgo.Treasure {
+
+	if int(from) >= len(treasures) {
+		return nil
+	}
+	treasures = treasures[from:]
+	if limit > 0 && int(limit) < len(treasures) {
+		treasures = treasures[:limit]
+	}
+
+	// the keys only response is a projection to the metadata
+	if keysOnly {
+		projection = &hydraidepbgo.Projection{CreatedAt: true, CreatedBy: true, UpdatedAt: true, UpdatedBy: true, ExpiredAt: true}
+	}
+	if projection != nil {
+		projected := make([]*hydraidepbgo.Treasure, 0, len(treasures))
+		for _, t := range treasures {
+			projected = append(projected, project(t, projection))
+		}
+		treasures = projected
+	}
+
+	return treasures
+
+}
+
+func (s *service) Delete(_ context.Context, in *hydraidepbgo.DeleteRequest) (*hydraidepbgo.DeleteResponse, error) {
+
+	for _, swampRequest := range in.GetSwamps() {
+		if swampRequest.GetShadowDelete() {
+			return nil, status.Error(codes.Unimplemented, "the fake does not support the shadow delete")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	responses := make([]*hydraidepbgo.DeleteResponse_SwampDeleteResponse, 0, len(in.GetSwamps()))
+
+	for _, swampRequest := range in.GetSwamps() {
+
+		sw, err := s.existingSwamp(swampRequest.GetSwampName())
+		if err != nil {
+			responses = append(responses, &hydraidepbgo.DeleteResponse_SwampDeleteResponse{
+				SwampName: swampRequest.GetSwampName(),
+				ErrorCode: hydraidepbgo.DeleteResponse_SwampDeleteResponse_SwampDoesNotExist.Enum(),
+			})
+			continue
+		}
+
+		keyStatuses := make([]*hydraidepbgo.KeyStatusPair, 0, len(swampRequest.GetKeys()))
+		for _, key := range swampRequest.GetKeys() {
+
+			stored, ok := sw.treasures[key]
+			if !ok {
+				keyStatuses = append(keyStatuses, &hydraidepbgo.KeyStatusPair{Key: key, Status: hydraidepbgo.Status_NOT_FOUND})
+				continue
+			}
+
+			delete(sw.treasures, key)
+			sw.sequence++
+			sw.publish(&hydraidepbgo.SubscribeToEventsResponse{
+				SwampName:       swampRequest.GetSwampName(),
+				Treasure:        &hydraidepbgo.Treasure{},
+				OldTreasure:     &hydraidepbgo.Treasure{},
+				DeletedTreasure: stored.treasure,
+				Status:          hydraidepbgo.Status_DELETED,
+			})
+			keyStatuses = append(keyStatuses, &hydraidepbgo.KeyStatusPair{Key: key, Status: hydraidepbgo.Status_DELETED})
+
+		}
+
+		responses = append(responses, &hydraidepbgo.DeleteResponse_SwampDeleteResponse{
+			SwampName:   swampRequest.GetSwampName(),
+			KeyStatuses: keyStatuses,
+		})
+
+	}
+
+	return &hydraidepbgo.DeleteResponse{
+		Responses: responses,
+	}, nil
+
+}
+
+func (s *service) Count(_ context.Context, in *hydraidepbgo.CountRequest) (*hydraidepbgo.CountResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := &hydraidepbgo.CountResponse{}
+	for _, swampIdentifier := range in.GetSwamps() {
+
+		if swampIdentifier.GetSwampName() == "" {
+			return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+		}
+
+		// a swamp that does not exist is not an error, because one request can count many swamps
+		count := &hydraidepbgo.CountSwamp{
+			SwampName: swampIdentifier.GetSwampName(),
+		}
+		if sw, err := s.existingSwamp(swampIdentifier.GetSwampName()); err == nil {
+			count.IsExist = true
+			for _, t := range sw.treasures {
+				if matchCountFilter(t.treasure, in.GetFilter()) {
+					count.Count++
+				}
+			}
+		}
+		response.Swamps = append(response.Swamps, count)
+
+	}
+
+	return response, nil
+
+}
+
+func (s *service) Destroy(_ context.Context, in *hydraidepbgo.DestroyRequest) (*hydraidepbgo.DestroyResponse, error) {
+
+	if in.GetSwampName() == "" {
+		return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// like the server, the destroy sends no events, but the subscriptions stay and get the events of the new
+	// treasures of the same swamp
+	if sw, ok := s.swamps[in.GetSwampName()]; ok {
+		clear(sw.treasures)
+	}
+
+	return &hydraidepbgo.DestroyResponse{}, nil
+
+}
+
+func (s *service) DestroyMany(in *hydraidepbgo.DestroyManyRequest, stream hydraidepbgo.HydraideService_DestroyManyServer) error {
+
+	if in.GetSwampPattern() == "" {
+		return statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampPattern cannot be empty")
+	}
+	if strings.Count(in.GetSwampPattern(), "/") != 2 {
+		return statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, fmt.Sprintf("invalid swamp pattern: %s", in.GetSwampPattern()))
+	}
+	if in.GetConfirmationToken() != in.GetSwampPattern() {
+		return statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "ConfirmationToken must be the same as the SwampPattern")
+	}
+
+	// the swamps are collected first, so the lock is not held while the responses are sent
+	s.mu.Lock()
+	var destroyed []string
+	for swampName, sw := range s.swamps {
+		if len(sw.treasures) > 0 && matchPattern(in.GetSwampPattern(), swampName) {
+			clear(sw.treasures)
+			destroyed = append(destroyed, swampName)
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(destroyed)
+
+	inprocess.StreamReady(stream.Context())
+
+	for _, swampName := range destroyed {
+		if err := stream.Send(&hydraidepbgo.DestroyManyResponse{SwampName: swampName}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+func (s *service) SubscribeToEvents(in *hydraidepbgo.SubscribeToEventsRequest, eventServer hydraidepbgo.HydraideService_SubscribeToEventsServer) error {
+
+	if in.GetSwampName() == "" {
+		return statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if in.GetSince() != nil {
+		// the fake has no event journal, like a swamp registered without EventJournalSize
+		return statusError(codes.OutOfRange, hydraidepbgo.ErrorReason_REPLAY_NOT_AVAILABLE, "the fake has no event journal")
+	}
+
+	sub := &subscriber{
+		notify: make(chan struct{}, 1),
+	}
+
+	// the snapshot and the registration are atomic, so no event is lost or sent twice
+	var snapshot []*hydraidepbgo.Treasure
+	var resumeToken uint64
+	func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		sw := s.summonSwamp(in.GetSwampName())
+		if in.GetIncludeSnapshot() {
+			snapshot = sw.inCreationOrder()
+			resumeToken = sw.sequence
+		}
+		sw.subscribers[sub] = struct{}{}
+	}()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if sw, ok := s.swamps[in.GetSwampName()]; ok {
+			delete(sw.subscribers, sub)
+		}
+	}()
+
+	inprocess.StreamReady(eventServer.Context())
+
+	if in.GetIncludeSnapshot() {
+		snapshotTime := timestamppb.Now()
+		for _, t := range snapshot {
+			if err := eventServer.Send(&hydraidepbgo.SubscribeToEventsResponse{
+				SwampName: in.GetSwampName(),
+				Treasure:  t,
+				Status:    hydraidepbgo.Status_NOTHING_CHANGED,
+				EventTime: snapshotTime,
+			}); err != nil {
+				return nil
+			}
+		}
+		if err := eventServer.Send(&hydraidepbgo.SubscribeToEventsResponse{
+			SwampName:   in.GetSwampName(),
+			SnapshotEnd: true,
+			ResumeToken: resumeToken,
+			EventTime:   timestamppb.Now(),
+		}); err != nil {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case <-eventServer.Context().Done():
+			return nil
+		case <-sub.notify:
+			for _, event := range sub.drain() {
+				if err := eventServer.Send(event); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+
+}
+
+// existingSwamp returns the Swamp, or the same error as the server if it does not exist. The caller must hold the
+// lock of the service.
+func (s *service) existingSwamp(swampName string) (*swamp, error) {
+	if swampName == "" {
+		return nil, statusError(codes.InvalidArgument, hydraidepbgo.ErrorReason_INVALID_ARGUMENT, "SwampName cannot be empty")
+	}
+	if !s.isSwampExist(swampName) {
+		return nil, statusError(codes.FailedPrecondition, hydraidepbgo.ErrorReason_SWAMP_NOT_FOUND, "Swamp does not exist")
+	}
+	return s.swamps[swampName], nil
+}
+
+// isSwampExist returns true if the Swamp has at least one treasure, because the server removes the empty Swamps.
+// The caller must hold the lock of the service.
+func (s *service) isSwampExist(swampName string) bool {
+	sw, ok := s.swamps[swampName]
+	return ok && len(sw.treasures) > 0
+}
+
+// summonSwamp returns the Swamp, and creates it if it does not exist yet. The caller must hold the lock of the
+// service.
+func (s *service) summonSwamp(swampName string) *swamp {
+	sw, ok := s.swamps[swampName]
+	if !ok {
+		sw = &swamp{
+			treasures:   make(map[string]*storedTreasure),
+			subscribers: make(map[*subscriber]struct{}),
+		}
+		s.swamps[swampName] = sw
+	}
+	return sw
+}
+
+// isServerTimestamped returns true if the Swamp matches a pattern registered with ServerTimestamps. The caller must
+// hold the lock of the service.
+func (s *service) isServerTimestamped(swampName string) bool {
+	for pattern, request := range s.patterns {
+		if request.GetServerTimestamps() && matchPattern(pattern, swampName) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish queues the event for every subscriber of the Swamp. The caller must hold the lock of the service.
+func (sw *swamp) publish(event *hydraidepbgo.SubscribeToEventsResponse) {
+	event.EventTime = timestamppb.Now()
+	event.Sequence = sw.sequence
+	for sub := range sw.subscribers {
+		sub.push(event)
+	}
+}
+
+// inCreationOrder returns the treasures of the Swamp in the order they were created
+func (sw *swamp) inCreationOrder() []*hydraidepbgo.Treasure {
+	stored := make([]*storedTreasure, 0, len(sw.treasures))
+	for _, st := range sw.treasures {
+		stored = append(stored, st)
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		return stored[i].order < stored[j].order
+	})
+	treasures := make([]*hydraidepbgo.Treasure, 0, len(stored))
+	for _, st := range stored {
+		treasures = append(treasures, st.treasure)
+	}
+	return treasures
+}
+
+// byIndex returns the treasures of the index in the given order. Like the beacons of the server, the time and value
+// indexes contain only the treasures which have the indexed field.
+func (sw *swamp) byIndex(indexType hydraidepbgo.IndexType_Type, orderType hydraidepbgo.OrderType_Type) []*hydraidepbgo.Treasure {
+
+	treasures := make([]*hydraidepbgo.Treasure, 0, len(sw.treasures))
+	for _, st := range sw.treasures {
+		if _, ok := indexValue(st.treasure, indexType); ok {
+			treasures = append(treasures, st.treasure)
+		}
+	}
+
+	sort.Slice(treasures, func(i, j int) bool {
+		a, _ := indexValue(treasures[i], indexType)
+		b, _ := indexValue(treasures[j], indexType)
+		if orderType == hydraidepbgo.OrderType_DESC {
+			a, b = b, a
+		}
+		if c := compare(a, b); c != 0 {
+			return c < 0
+		}
+		// the equal values are in the order of their keys
+		if orderType == hydraidepbgo.OrderType_DESC {
+			return treasures[i].Key > treasures[j].Key
+		}
+		return treasures[i].Key < treasures[j].Key
+	})
+
+	return treasures
+
+}
+
+func (sub *subscriber) push(event *hydraidepbgo.SubscribeToEventsResponse) {
+	sub.mu.Lock()
+	sub.queue = append(sub.queue, event)
+	sub.mu.Unlock()
+	select {
+	case sub.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (sub *subscriber) drain() []*hydraidepbgo.SubscribeToEventsResponse {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	events := sub.queue
+	sub.queue = nil
+	return events
+}
+
+// applyKeyValuePair writes the key-value pair to the treasure the same way the server does: the value is replaced,
+// the uint32 slice is merged, and the metadata is changed only if it is set
+func applyKeyValuePair(t *hydraidepbgo.Treasure, kv *hydraidepbgo.KeyValuePair, serverTimestamps bool) {
+
+	slice := t.Uint32Slice
+	isSlice := t.Uint32Slice != nil
+
+	t.Int8Val, t.Int16Val, t.Int32Val, t.Int64Val = nil, nil, nil, nil
+	t.Uint8Val, t.Uint16Val, t.Uint32Val, t.Uint64Val = nil, nil, nil, nil
+	t.Float32Val, t.Float64Val, t.StringVal, t.BoolVal = nil, nil, nil, nil
+	t.BytesVal, t.Uint32Slice = nil, nil
+
+	switch {
+	case kv.Int8Val != nil:
+		t.Int8Val = proto.Int32(kv.GetInt8Val())
+	case kv.Int16Val != nil:
+		t.Int16Val = proto.Int32(kv.GetInt16Val())
+	case kv.Int32Val != nil:
+		t.Int32Val = proto.Int32(kv.GetInt32Val())
+	case kv.Int64Val != nil:
+		t.Int64Val = proto.Int64(kv.GetInt64Val())
+	case kv.Uint8Val != nil:
+		t.Uint8Val = proto.Uint32(kv.GetUint8Val())
+	case kv.Uint16Val != nil:
+		t.Uint16Val = proto.Uint32(kv.GetUint16Val())
+	case kv.Uint32Val != nil:
+		t.Uint32Val = proto.Uint32(kv.GetUint32Val())
+	case kv.Uint64Val != nil:
+		t.Uint64Val = proto.Uint64(kv.GetUint64Val())
+	case kv.Float32Val != nil:
+		t.Float32Val = proto.Float32(kv.GetFloat32Val())
+	case kv.Float64Val != nil:
+		t.Float64Val = proto.Float64(kv.GetFloat64Val())
+	case kv.StringVal != nil:
+		t.StringVal = proto.String(kv.GetStringVal())
+	case kv.BoolVal != nil:
+		t.BoolVal = kv.GetBoolVal().Enum()
+	case kv.BytesVal != nil:
+		t.BytesVal = append([]byte{}, kv.GetBytesVal()...)
+	case kv.Uint32Slice != nil:
+		// the values are pushed to the existing slice, without duplicates
+		if !isSlice {
+			slice = nil
+		}
+		for _, value := range kv.GetUint32Slice() {
+			found := false
+			for _, existing := range slice {
+				if existing == value {
+					found = true
+					break
+				}
+			}
+			if !found {
+				slice = append(slice, value)
+			}
+		}
+		t.Uint32Slice = append([]uint32{}, slice...)
+	}
+
+	if !serverTimestamps && isValidTimestamp(kv.GetCreatedAt()) {
+		t.CreatedAt = kv.GetCreatedAt()
+	}
+	if kv.GetCreatedBy() != "" {
+		t.CreatedBy = proto.String(kv.GetCreatedBy())
+	}
+	if !serverTimestamps && isValidTimestamp(kv.GetUpdatedAt()) {
+		t.UpdatedAt = kv.GetUpdatedAt()
+	}
+	if kv.GetUpdatedBy() != "" {
+		t.UpdatedBy = proto.String(kv.GetUpdatedBy())
+	}
+	if isValidTimestamp(kv.GetExpiredAt()) {
+		t.ExpiredAt = kv.GetExpiredAt()
+	}
+	if kv.SchemaVersion != nil && kv.GetSchemaVersion() > 0 {
+		t.SchemaVersion = proto.Uint32(kv.GetSchemaVersion())
+	}
+
+}
+
+// matchCountFilter tells whether the treasure is counted by the filter of the Count request, like the server does
+func matchCountFilter(t *hydraidepbgo.Treasure, filter *hydraidepbgo.CountFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if !strings.HasPrefix(t.GetKey(), filter.GetKeyPrefix()) {
+		return false
+	}
+	if filter.GetExpiredOnly() && (!isValidTimestamp(t.GetExpiredAt()) || !t.GetExpiredAt().AsTime().Before(time.Now())) {
+		return false
+	}
+	if isValidTimestamp(filter.GetUpdatedSince()) {
+		updatedAt := t.GetUpdatedAt()
+		if !isValidTimestamp(updatedAt) {
+			updatedAt = t.GetCreatedAt()
+		}
+		if updatedAt.AsTime().Before(filter.GetUpdatedSince().AsTime()) {
+			return false
+		}
+	}
+	return true
+}
+
+// project returns a copy of the treasure with the parts selected by the projection, like the server converts it.
+// Without projection the treasure itself is returned.
+func project(t *hydraidepbgo.Treasure, projection *hydraidepbgo.Projection) *hydraidepbgo.Treasure {
+
+	if projection == nil {
+		return t
+	}
+
+	projected := &hydraidepbgo.Treasure{
+		Key:           t.GetKey(),
+		IsExist:       t.GetIsExist(),
+		SchemaVersion: t.SchemaVersion,
+	}
+	if projection.GetValue() {
+		projected = proto.Clone(t).(*hydraidepbgo.Treasure)
+		projected.CreatedAt, projected.CreatedBy, projected.UpdatedAt, projected.UpdatedBy, projected.ExpiredAt = nil, nil, nil, nil, nil
+	}
+
+	if projection.GetCreatedAt() {
+		projected.CreatedAt = t.CreatedAt
+	}
+	if projection.GetCreatedBy() {
+		projected.CreatedBy = t.CreatedBy
+	}
+	if projection.GetUpdatedAt() {
+		projected.UpdatedAt = t.UpdatedAt
+	}
+	if projection.GetUpdatedBy() {
+		projected.UpdatedBy = t.UpdatedBy
+	}
+	if projection.GetExpiredAt() {
+		projected.ExpiredAt = t.ExpiredAt
+	}
+
+	return projected
+
+}
+
+// isValidTimestamp returns true if the timestamp is set and it is after the Unix epoch, like the server checks it
+func isValidTimestamp(ts *timestamppb.Timestamp) bool {
+	if ts == nil {
+		return false
+	}
+	return ts.GetSeconds() > 0 || ts.GetNanos() > 0
+}
+
+// indexValue returns the value of the treasure in the index, and false if the treasure is not in the index
+func indexValue(t *hydraidepbgo.Treasure, indexType hydraidepbgo.IndexType_Type) (any, bool) {
+	switch indexType {
+	case hydraidepbgo.IndexType_KEY:
+		return t.GetKey(), true
+	case hydraidepbgo.IndexType_EXPIRATION_TIME:
+		return t.GetExpiredAt().AsTime().UnixNano(), t.ExpiredAt != nil
+	case hydraidepbgo.IndexType_CREATION_TIME:
+		return t.GetCreatedAt().AsTime().UnixNano(), t.CreatedAt != nil
+	case hydraidepbgo.IndexType_UPDATE_TIME:
+		return t.GetUpdatedAt().AsTime().UnixNano(), t.UpdatedAt != nil
+	case hydraidepbgo.IndexType_VALUE_INT8:
+		return int64(t.GetInt8Val()), t.Int8Val != nil
+	case hydraidepbgo.IndexType_VALUE_INT16:
+		return int64(t.GetInt16Val()), t.Int16Val != nil
+	case hydraidepbgo.IndexType_VALUE_INT32:
+		return int64(t.GetInt32Val()), t.Int32Val != nil
+	case hydraidepbgo.IndexType_VALUE_INT64:
+		return t.GetInt64Val(), t.Int64Val != nil
+	case hydraidepbgo.IndexType_VALUE_UINT8:
+		return uint64(t.GetUint8Val()), t.Uint8Val != nil
+	case hydraidepbgo.IndexType_VALUE_UINT16:
+		return uint64(t.GetUint16Val()), t.Uint16Val != nil
+	case hydraidepbgo.IndexType_VALUE_UINT32:
+		return uint64(t.GetUint32Val()), t.Uint32Val != nil
+	case hydraidepbgo.IndexType_VALUE_UINT64:
+		return t.GetUint64Val(), t.Uint64Val != nil
+	case hydraidepbgo.IndexType_VALUE_FLOAT32:
+		return float64(t.GetFloat32Val()), t.Float32Val != nil
+	case hydraidepbgo.IndexType_VALUE_FLOAT64:
+		return t.GetFloat64Val(), t.Float64Val != nil
+	case hydraidepbgo.IndexType_VALUE_STRING:
+		return t.GetStringVal(), t.StringVal != nil
+	default:
+		return nil, false
+	}
+}
+
+// compare compares two values of the same index
+func compare(a any, b any) int {
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(x, b.(string))
+	case int64:
+		return compareOrdered(x, b.(int64))
+	case uint64:
+		return compareOrdered(x, b.(uint64))
+	case float64:
+		return compareOrdered(x, b.(float64))
+	default:
+		return 0
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// matchPattern returns true if the Swamp name matches the pattern. A "*" part of the pattern matches any part of the
+// name.
+func matchPattern(pattern string, swampName string) bool {
+	patternParts := strings.Split(pattern, "/")
+	nameParts := strings.Split(swampName, "/")
+	if len(patternParts) != len(nameParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if part != "*" && part != nameParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// statusError creates a gRPC error with the machine-readable reason, the same way as the server
+func statusError(code codes.Code, reason hydraidepbgo.ErrorReason_Reason, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason.String(),
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -8,17 +8,42 @@ import (
 	"fmt"
 	"github.com/hydraide/hydraide/generated/hydraidepbgo"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/consistency"
 	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
+	"math"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
+	// errorDomain is the domain of the ErrorInfo details sent by the HydrAIDE server
+	errorDomain = "hydraide"
+	// setRequestSwampsField and swampRequestKeyValuesField are the field numbers of the SetRequest.Swamps and the
+	// SwampRequest.KeyValues, to calculate the size of a SetRequest before it is built
+	setRequestSwampsField      = protowire.Number(1)
+	swampRequestKeyValuesField = protowire.Number(3)
+	// maxLargeValueChunkSize is the max size of a chunk of the values sent by the SetLargeValue and GetLargeValue
+	maxLargeValueChunkSize = 1024 * 1024
+	// metadataClientID is the gRPC metadata key of the client identity used by the rate limits of the server
+	metadataClientID = "hydraide-client-id"
+	// metadataHydrationPriority is the gRPC metadata key of the priority of the swamp loading at the server
+	metadataHydrationPriority = "hydraide-hydration-priority"
+	// defaultAuditQueryLimit is the max number of the records of the QueryAuditLog if the filter does not set one,
+	// the same as the default of the server
+	defaultAuditQueryLimit = 100
+
 	errorMessageConnectionError     = "connection error"
 	errorMessageCtxTimeout          = "context timeout exceeded"
 	errorMessageCtxClosedByClient   = "context closed by client"
@@ -31,6 +56,26 @@ const (
 	errorMessageKeyAlreadyExists    = "key already exists"
 	errorMessageKeyNotFound         = "key not found"
 	errorMessageConditionNotMet     = "condition not met - the value is"
+	errorMessageQuotaExceeded       = "quota exceeded"
+	errorMessageWrongValueType      = "wrong value type"
+	errorMessageDataCorrupted       = "data corrupted"
+	errorMessageReplayNotAvailable  = "replay not available"
+	errorMessageLeaseNotFound       = "lease not found"
+	errorMessageVersionNotFound     = "version not found"
+	errorMessageMessageTooLarge     = "message too large"
+	errorMessageBlobNotFound        = "blob not found"
+	errorMessageInsufficientStorage = "insufficient storage"
+	errorMessageAuditLogDisabled    = "audit log disabled"
+	errorMessageSchemaViolation     = "schema violation"
+	errorMessageIslandMismatch      = "island mismatch"
+	errorMessageSubscriberOverflow  = "subscriber overflow"
+	errorMessagePITRDisabled        = "point-in-time restore disabled"
+	errorMessagePointNotCovered     = "point in time not covered"
+	errorMessageConsistency         = "consistency not reached"
+	errorMessageIslandMigrating     = "island migrating"
+	errorMessageIslandMoved         = "island moved"
+	errorMessagePatternNotFound     = "swamp pattern not found"
+	errorMessagePatternConflict     = "swamp pattern conflict"
 )
 
 const (
@@ -43,35 +88,101 @@ const (
 	tagUpdatedAt = "updatedAt"
 	tagUpdatedBy = "updatedBy"
 	tagExpireAt  = "expireAt"
+	tagVersion   = "version"
 )
 
+// Validator is an optional interface of the Catalog and Profile models, checked by the SDK before the model is
+// converted and sent to the server.
+//
+// If `Validate()` returns an error, the model is not written, and the write fails with an error where
+// `IsInvalidModel(err)` is true, and whose message contains the error of the model. In the batch writes, e.g.
+// `CatalogSaveMany()`, the message names the index and the key of the model, and `CatalogTrySaveMany()` saves the
+// other models.
+//
+// Example:
+//
+//	func (u *User) Validate() error {
+//	    if !strings.Contains(u.Email, "@") {
+//	        return fmt.Errorf("invalid email address: %q", u.Email)
+//	    }
+//	    return nil
+//	}
+type Validator interface {
+	Validate() error
+}
+
 type Hydraidego interface {
 	Heartbeat(ctx context.Context) error
 	RegisterSwamp(ctx context.Context, request *RegisterSwampRequest) []error
 	DeRegisterSwamp(ctx context.Context, swampName name.Name) []error
+	DeRegisterSwampAndPurge(ctx context.Context, swampName name.Name, dryRun bool) ([]name.Name, []error)
+	ListSwampPatterns(ctx context.Context) ([]*SwampPattern, error)
+	UpdateSwampPattern(ctx context.Context, request *UpdateSwampPatternRequest) []error
 	Lock(ctx context.Context, key string, ttl time.Duration) (lockID string, err error)
 	Unlock(ctx context.Context, key string, lockID string) error
 	IsSwampExist(ctx context.Context, swampName name.Name) (bool, error)
+	ExistsMany(ctx context.Context, patterns []name.Name) (map[string]bool, error)
+	ParallelForEachSwamp(ctx context.Context, patterns []name.Name, concurrencyPerServer int, fn SwampFunc) error
 	IsKeyExists(ctx context.Context, swampName name.Name, key string) (bool, error)
+	IsKeysExist(ctx context.Context, swampName name.Name, keys []string) (map[string]bool, error)
+	SetSwampAnnotation(ctx context.Context, swampName name.Name, key string, value string) error
+	GetSwampAnnotations(ctx context.Context, swampName name.Name) (map[string]string, error)
 	CatalogCreate(ctx context.Context, swampName name.Name, model any) error
 	CatalogCreateMany(ctx context.Context, swampName name.Name, models []any, iterator CreateManyIteratorFunc) error
 	CatalogCreateManyToMany(ctx context.Context, request []*CatalogManyToManyRequest, iterator CatalogCreateManyToManyIteratorFunc) error
 	CatalogRead(ctx context.Context, swampName name.Name, key string, model any) error
+	CatalogReadRaw(ctx context.Context, swampName name.Name, key string) (*Treasure, error)
 	CatalogReadMany(ctx context.Context, swampName name.Name, index *Index, model any, iterator CatalogReadManyIteratorFunc) error
+	CatalogReadManyKeys(ctx context.Context, swampName name.Name, index *Index, iterator CatalogReadManyKeysIteratorFunc) error
+	CatalogReadByValue(ctx context.Context, swampName name.Name, value any, model any, iterator CatalogReadManyIteratorFunc) error
+	CatalogReadTopN(ctx context.Context, swampName name.Name, indexType IndexType, n int32, order IndexOrder, model any, iterator CatalogReadManyIteratorFunc) error
+	CatalogReadByReference(ctx context.Context, request *ReferenceRequest, model any, iterator CatalogReadManyIteratorFunc) error
 	CatalogUpdate(ctx context.Context, swampName name.Name, model any) error
+	CatalogMutate(ctx context.Context, swampName name.Name, key string, model any, mutate CatalogMutateFunc) error
 	CatalogUpdateMany(ctx context.Context, swampName name.Name, models []any, iterator CatalogUpdateManyIteratorFunc) error
+	CatalogUpdateManyToMany(ctx context.Context, request []*CatalogManyToManyRequest, iterator CatalogUpdateManyToManyIteratorFunc) error
 	CatalogDelete(ctx context.Context, swampName name.Name, key string) error
+	CatalogShadowDelete(ctx context.Context, swampName name.Name, key string) error
+	CatalogListDeleted(ctx context.Context, swampName name.Name, model any, iterator CatalogListDeletedIteratorFunc) error
+	CatalogRestore(ctx context.Context, swampName name.Name, key string) error
+	CatalogHistory(ctx context.Context, swampName name.Name, key string, model any, iterator CatalogHistoryIteratorFunc) error
+	CatalogRevertTo(ctx context.Context, swampName name.Name, key string, version uint64, updatedBy string) error
 	CatalogDeleteMany(ctx context.Context, swampName name.Name, keys []string, iterator CatalogDeleteIteratorFunc) error
 	CatalogDeleteManyFromMany(ctx context.Context, request []*CatalogDeleteManyFromManyRequest, iterator CatalogDeleteIteratorFunc) error
 	CatalogSave(ctx context.Context, swampName name.Name, model any) (eventStatus EventStatus, err error)
+	CatalogSaveRaw(ctx context.Context, swampName name.Name, treasure *Treasure) (EventStatus, error)
 	CatalogSaveMany(ctx context.Context, swampName name.Name, models []any, iterator CatalogSaveManyIteratorFunc) error
+	CatalogTrySaveMany(ctx context.Context, swampName name.Name, models []any, iterator CatalogTrySaveManyIteratorFunc) error
 	CatalogSaveManyToMany(ctx context.Context, request []*CatalogManyToManyRequest, iterator CatalogSaveManyToManyIteratorFunc) error
 	CatalogShiftExpired(ctx context.Context, swampName name.Name, howMany int32, model any, iterator CatalogShiftExpiredIteratorFunc) error
+	CatalogShiftExpiredWithLease(ctx context.Context, swampName name.Name, howMany int32, leaseTTL time.Duration, model any, iterator CatalogShiftExpiredWithLeaseIteratorFunc) error
+	CatalogAck(ctx context.Context, lease *Lease) error
+	CatalogNack(ctx context.Context, lease *Lease, options *NackOptions) (deadLettered bool, err error)
 	ProfileSave(ctx context.Context, swampName name.Name, model any) (err error)
 	ProfileRead(ctx context.Context, swampName name.Name, model any) (err error)
+	ProfileReadFields(ctx context.Context, swampName name.Name, model any, fields ...string) error
 	Count(ctx context.Context, swampName name.Name) (int32, error)
+	CountMany(ctx context.Context, swampNames []name.Name) (map[string]int32, error)
+	CountFiltered(ctx context.Context, swampName name.Name, filter *CountFilter) (int32, error)
+	CountManyFiltered(ctx context.Context, swampNames []name.Name, filter *CountFilter) (map[string]int32, error)
+	Aggregate(ctx context.Context, swampName name.Name, request *AggregateRequest) (*AggregateResult, error)
+	ListCorruptedFiles(ctx context.Context) ([]*CorruptedFile, error)
+	GetNamespaceUsage(ctx context.Context, refresh bool) ([]*NamespaceUsage, error)
+	QueryAuditLog(ctx context.Context, filter *AuditFilter) ([]*AuditRecord, error)
+	RestorePointInTime(ctx context.Context, request *PointInTimeRestore) (*PointInTimeRestoreResult, error)
+	VerifyIslandMapping(ctx context.Context, swampName name.Name) (*IslandMapping, error)
+	CompactSwamp(ctx context.Context, swampName name.Name, minLiveRatio float64) (*CompactionResult, error)
+	PutBlob(ctx context.Context, content []byte) (string, error)
+	GetBlob(ctx context.Context, hash string) ([]byte, error)
+	RefBlob(ctx context.Context, hash string, delta int64) (int64, error)
+	CollectBlobGarbage(ctx context.Context, minAge time.Duration) (*BlobGarbageResult, error)
 	Destroy(ctx context.Context, swampName name.Name) error
-	Subscribe(ctx context.Context, swampName name.Name, getExistingData bool, model any, iterator SubscribeIteratorFunc) error
+	DestroyMany(ctx context.Context, pattern name.Name, confirmation string, iterator DestroyManyIteratorFunc) error
+	Subscribe(ctx context.Context, swampName name.Name, getExistingData bool, model any, iterator SubscribeIteratorFunc) (*Subscription, error)
+	SubscribeFrom(ctx context.Context, swampName name.Name, since time.Time, model any, iterator SubscribeFromIteratorFunc) (*Subscription, error)
+	ProfileSubscribe(ctx context.Context, swampName name.Name, model any, iterator ProfileSubscribeIteratorFunc) (*Subscription, error)
+	SubscribeAll(ctx context.Context, patterns []name.Name, iterator SubscribeAllIteratorFunc) (*Subscription, error)
+	SubscribeAllFrom(ctx context.Context, patterns []name.Name, since map[string]time.Time, iterator SubscribeAllIteratorFunc) (*Subscription, error)
 	IncrementInt8(ctx context.Context, swampName name.Name, key string, value int8, condition *Int8Condition) (int8, error)
 	IncrementInt16(ctx context.Context, swampName name.Name, key string, value int16, condition *Int16Condition) (int16, error)
 	IncrementInt32(ctx context.Context, swampName name.Name, key string, value int32, condition *Int32Condition) (int32, error)
@@ -82,10 +193,20 @@ type Hydraidego interface {
 	IncrementUint64(ctx context.Context, swampName name.Name, key string, value uint64, condition *Uint64Condition) (uint64, error)
 	IncrementFloat32(ctx context.Context, swampName name.Name, key string, value float32, condition *Float32Condition) (float32, error)
 	IncrementFloat64(ctx context.Context, swampName name.Name, key string, value float64, condition *Float64Condition) (float64, error)
-	Uint32SlicePush(ctx context.Context, swampName name.Name, KeyValuesPair []*KeyValuesPair) error
+	SetIfLater(ctx context.Context, swampName name.Name, key string, timestamp time.Time) (time.Time, error)
+	AddDuration(ctx context.Context, swampName name.Name, key string, duration time.Duration, fromNowIfPast bool) (time.Time, error)
+	SetStringIfEquals(ctx context.Context, swampName name.Name, key string, expected string, value string) (string, error)
+	SetStringIfNotExists(ctx context.Context, swampName name.Name, key string, value string) (string, error)
+	SetBoolIfEquals(ctx context.Context, swampName name.Name, key string, expected bool, value bool) (bool, error)
+	SetBoolIfNotExists(ctx context.Context, swampName name.Name, key string, value bool) (bool, error)
+	Uint32SlicePush(ctx context.Context, swampName name.Name, KeyValuesPair []*KeyValuesPair, iterator Uint32SlicePushIteratorFunc) error
 	Uint32SliceDelete(ctx context.Context, swampName name.Name, KeyValuesPair []*KeyValuesPair) error
 	Uint32SliceSize(ctx context.Context, swampName name.Name, key string) (int64, error)
 	Uint32SliceIsValueExist(ctx context.Context, swampName name.Name, key string, value uint32) (bool, error)
+	Uint32SliceGetRange(ctx context.Context, swampName name.Name, key string, offset int64, limit int64) ([]uint32, int64, error)
+	Uint32SliceStream(ctx context.Context, swampName name.Name, key string, iterator Uint32SliceStreamIteratorFunc) error
+	Uint32SliceCombine(ctx context.Context, swampName name.Name, operator SliceOperator, keys []string, iterator Uint32SliceStreamIteratorFunc) error
+	Uint32SliceCombineInto(ctx context.Context, swampName name.Name, operator SliceOperator, keys []string, destinationKey string) (int64, error)
 }
 
 // Index defines the configuration for index-based queries in HydrAIDE.
@@ -97,10 +218,12 @@ type Hydraidego interface {
 // based on keys, values, or metadata fields like creation time.
 //
 // Fields:
-//   - IndexType:     what field to index on (key, value, createdAt, etc.)
+//   - IndexType:     what field to index on (key, value, createdAt, etc.). Unset streams the Treasures in no
+//     particular order, without building an index on the server
 //   - IndexOrder:    ascending or descending result order
 //   - From:          offset for pagination (0 = from start)
 //   - Limit:         max number of results to return (0 = no limit)
+//   - FilterExpr:    optional server-side filter expression (empty = no filter)
 //
 // Example:
 //
@@ -113,10 +236,22 @@ type Hydraidego interface {
 //	    Limit:      10,
 //	}
 type Index struct {
-	IndexType        // What field to use for sorting/filtering
+	IndexType        // What field to use for sorting/filtering (unset = no order, the Treasures are streamed)
 	IndexOrder       // Ascending or Descending order
 	From       int32 // Offset: how many records to skip (0 = start from first)
 	Limit      int32 // Max results to return (0 = return all)
+
+	// FilterExpr is an optional filter expression evaluated by the server before From and Limit.
+	//
+	// Fields:    key, value, createdAt, createdBy, updatedAt, updatedBy, expiredAt
+	// Operators: =, !=, >, >=, <, <= combined with AND, OR, NOT and parentheses
+	// Literals:  numbers, 'quoted' or "quoted" strings, true/false and dates (2024-01-01 or RFC3339)
+	//
+	// Example: `value >= 18 AND createdAt > 2024-01-01`
+	//
+	// ⚠️ Only primitive values can be filtered, because complex values (structs, maps, slices)
+	// are stored in binary format on the server.
+	FilterExpr string
 }
 
 // IndexType specifies which field to use as the index during a read.
@@ -231,6 +366,161 @@ type RegisterSwampRequest struct {
 	//
 	// If nil, the server will use its default settings.
 	FilesystemSettings *SwampFilesystemSettings
+
+	// ValueIndex enables the secondary value index for the Swamps of the pattern.
+	//
+	// If true → HydrAIDE keeps a hash index from values to keys, so CatalogReadByValue
+	// can find the Treasures holding a given value without scanning the Swamp.
+	//
+	// The index lives only in memory, it is built at the first lookup and then kept
+	// up to date on every write and delete. It costs some memory and a small overhead per write.
+	ValueIndex bool
+
+	// EventJournalSize enables the event journal for the Swamps of the pattern, and sets the max number
+	// of the events kept per Swamp. 0 disables the journal.
+	//
+	// The journal lets SubscribeFrom replay the events a subscriber missed while it was away, e.g. after a crash,
+	// instead of reading the whole Swamp again.
+	//
+	// ⚠️ The journal lives in the memory of the server, so it starts empty after a server restart.
+	EventJournalSize int
+
+	// EventJournalRetention sets how long the events are kept in the journal (whole seconds).
+	// 0 means the events are only dropped when the journal is full.
+	EventJournalRetention time.Duration
+
+	// ServerTimestamps makes the server set the `createdAt` and `updatedAt` metadata of the Treasures.
+	//
+	// By default these times come from the client models, so the clock skew between your application servers
+	// can break the ordering by creation or update time. With ServerTimestamps:
+	//   - the server sets `createdAt` when a Treasure is created, and `updatedAt` when it is modified, by every
+	//     write operation (Save, Create, Update, Increment, etc.)
+	//   - the times sent by the clients are ignored
+	//   - CatalogSave, CatalogCreate, CatalogUpdate and CatalogSaveMany write the assigned times back to the
+	//     `createdAt` and `updatedAt` fields of the saved models
+	//
+	// ⚠️ The setting applies to the Swamps hydrated after the registration.
+	ServerTimestamps bool
+
+	// HistoryDepth sets how many versions of every Treasure are kept by the server. 0 disables the history.
+	//
+	// With the history enabled, every save of a Treasure is stored as a new version, with the time of the save
+	// and its `createdBy` or `updatedBy` metadata. Above the depth the oldest versions are dropped.
+	//   - CatalogHistory lists the versions of a Treasure
+	//   - CatalogRevertTo sets a Treasure back to one of its versions
+	//
+	// ⚠️ The versions are stored in the Treasure itself, so every version takes space on the disk and in the memory.
+	// Keep the depth low for large Treasures.
+	HistoryDepth int
+
+	// Retention makes the server delete the old Treasures of the Swamps of the pattern. nil means no retention.
+	//
+	// It replaces the cron jobs reading and deleting the old Treasures with CatalogReadMany and CatalogDeleteMany.
+	//
+	// ⚙️ The server applies the retention every time a Swamp writes to the disk, and when it closes:
+	//   - the Treasures older than MaxAge by `createdAt` are deleted
+	//   - above MaxTreasures or MaxSize the oldest Treasures by `createdAt` are deleted
+	//   - the deletions are sent to the subscribers like any other deletion
+	//
+	// ⚠️ The ordering uses the `createdAt` metadata, so the models must have a `createdAt` field, or the pattern must
+	// use ServerTimestamps. The retention applies only to the persistent Swamps.
+	Retention *RetentionPolicy
+
+	// DefaultExpireAfter sets the `expireAt` of the Treasures written without it, measured from the write
+	// (whole seconds). 0 means the Treasures never expire by default.
+	//
+	// It keeps the session-like Swamps from collecting immortal keys when a client forgets to set the `expireAt` tag.
+	//   - every write of a new or a modified Treasure without an expiration time sets it
+	//   - the Treasures with an expiration time keep it, so the clients can still set their own
+	//   - the expired Treasures are removed like any other, e.g. by CatalogShiftExpired
+	//
+	// ⚠️ The setting applies to the Swamps hydrated after the registration.
+	DefaultExpireAfter time.Duration
+
+	// KeptIndexes are the indexes the server maintains all the time for the Swamps of the pattern. Empty keeps every
+	// index in the memory after its first use, until the Swamp closes.
+	//
+	// An open Swamp can hold up to eight ordered lists of its Treasures for the reads by index, e.g. CatalogReadMany.
+	// The Swamps read only by keys don't need most of them:
+	//   - the kept indexes are built when a Swamp is loaded
+	//   - the other indexes are built at their first use, and dropped from the memory after IndexEvictAfterIdle
+	//   - any IndexValue... type keeps the value index, whatever type it is sorted by
+	//
+	// 🧪 Example: a Swamp of user profiles, read by keys and listed by the registration time:
+	//   KeptIndexes: []IndexType{IndexKey, IndexCreationTime}
+	//
+	// ⚠️ The setting applies to the Swamps hydrated after the registration.
+	KeptIndexes []IndexType
+
+	// IndexEvictAfterIdle is how long the indexes that are not kept stay in the memory after their last use (whole
+	// seconds). 0 means 10 minutes. Applies only if KeptIndexes is set.
+	IndexEvictAfterIdle time.Duration
+
+	// Constraints are the schema constraints the server checks on every write of the Swamps of the pattern. nil
+	// means no constraints.
+	//
+	// A write violating a constraint is rejected with an error where `IsSchemaViolation(err)` is true, and whose
+	// message names the Swamp, the key and the constraint. Nothing of the rejected request is written. Unlike the
+	// `Validator` of the models, the constraints protect the Swamps from every client, e.g. from an older version
+	// of a service.
+	Constraints *SwampConstraints
+
+	// Force registers the pattern even if it conflicts with a registered pattern.
+	//
+	// Without it, the server rejects the registration with an error where `IsPatternConflict(err)` is true, if a
+	// registered pattern overlaps with this one, i.e. a Swamp can match both, and it has other memory or filesystem
+	// settings (IsInMemorySwamp, CloseAfterIdle, FilesystemSettings). So two services can not overwrite the settings
+	// of each other silently. `GetConflictingPattern(err)` returns the registered pattern with the client that
+	// registered it.
+	//
+	// ⚠️ Set it only when you change the settings of a pattern on purpose, e.g. in a new release of the service that
+	// owns the pattern.
+	Force bool
+}
+
+// SwampConstraints are the schema constraints of the Treasures written to a Swamp. The zero values mean no constraint.
+type SwampConstraints struct {
+	// MaxKeyLength is the max length of the keys in bytes.
+	MaxKeyLength int
+
+	// MaxValueSize is the max size of the string, []byte, []uint32 and struct (GOB-encoded) values in bytes.
+	MaxValueSize int64
+
+	// RequiredMetadata are the metadata fields every written Treasure must have, e.g. MetadataCreatedBy, so the
+	// models must have the field with a non-zero value.
+	//
+	// 💡 The `createdAt` and `updatedAt` fields are always present if the pattern uses ServerTimestamps, and the
+	// `expireAt` field if it uses DefaultExpireAfter.
+	RequiredMetadata []MetadataField
+}
+
+// MetadataField is a metadata field of the Treasures
+type MetadataField int
+
+const (
+	MetadataCreatedAt MetadataField = iota
+	MetadataCreatedBy
+	MetadataUpdatedAt
+	MetadataUpdatedBy
+	MetadataExpireAt
+)
+
+// RetentionPolicy sets the limits of the Treasures kept in a Swamp. The zero values mean no limit.
+type RetentionPolicy struct {
+	// MaxAge is the max age of the Treasures by their `createdAt` metadata (whole seconds).
+	// The Treasures without `createdAt` are never deleted by the age.
+	MaxAge time.Duration
+
+	// MaxTreasures is the max number of the Treasures in a Swamp. The oldest Treasures are deleted above it, and the
+	// Treasures without `createdAt` are the oldest ones.
+	MaxTreasures int
+
+	// MaxSize is the max size of the files of a Swamp on the disk in bytes. The oldest Treasures are deleted in
+	// proportion to the excess, and the files are compacted.
+	//
+	// 💡 The size is approximate: the chunk file the new Treasures are written to is not counted, so a Swamp can
+	// exceed the limit by about one MaxFileSize.
+	MaxSize int64
 }
 
 type SwampFilesystemSettings struct {
@@ -257,16 +547,101 @@ type SwampFilesystemSettings struct {
 	// ⚠️ Always ensure MaxFileSize is larger than the filesystem block size.
 	// HydrAIDE automatically compresses data, so this refers to the compressed size.
 	MaxFileSize int
+
+	// FsyncPolicy decides when the written chunk files are flushed from the page cache of the operating system
+	// to the disk. FsyncDefault uses the default policy of the server.
+	//
+	// A write that is not flushed yet survives a crash of the server, but not a power loss or a kernel panic:
+	// - FsyncAlways = every write is durable, e.g. for financial Swamps, but each write waits for the disk.
+	// - FsyncInterval = at most FsyncInterval of writes can be lost, a balance of safety and throughput.
+	// - FsyncNever = the best throughput, e.g. for analytics Swamps, the operating system flushes the files.
+	FsyncPolicy FsyncPolicy
+
+	// FsyncInterval is the min time between two flushes of a Swamp with FsyncInterval (whole seconds).
+	// 0 means the default of the server.
+	FsyncInterval time.Duration
+
+	// KeyFilter makes the Swamps keep a bloom filter of their keys next to their chunk files.
+	//
+	// The filter is written when a Swamp closes, and `IsKeyExists()` and `IsKeysExist()` answer the missing keys
+	// of a closed Swamp from it, without hydrating the Swamp. Turn it on for deduplication-heavy Swamps, where most
+	// of the checked keys are new — e.g. the already seen URLs of a crawler.
+	// - About 1% of the missing keys still hydrate the Swamp (the false positives of the filter).
+	// - The existing keys always hydrate the Swamp.
+	// - The filter costs about 10 bits per key on the disk.
+	KeyFilter bool
+
+	// LazyHydration makes the Swamps keep a directory of the chunk files of their keys next to their chunk files.
+	//
+	// The directory is written when a Swamp closes, and `CatalogRead()` reads only the chunk of the key of a closed
+	// Swamp by it, without hydrating the Swamp. Turn it on for huge Swamps that are mostly read by single keys, and
+	// rarely written — e.g. the profiles of millions of users behind a profile page.
+	// - Every other function, e.g. a write, an index read or a subscription, hydrates the whole Swamp as before.
+	// - The directory costs about the size of the keys on the disk.
+	LazyHydration bool
+}
+
+// FsyncPolicy decides when the written files of a Swamp are flushed to the disk
+type FsyncPolicy int
+
+const (
+	// FsyncDefault is the default policy of the server, FsyncNever if the server does not configure it
+	FsyncDefault FsyncPolicy = iota
+	// FsyncNever leaves the flushing to the operating system
+	FsyncNever
+	// FsyncInterval flushes the written files at most once per FsyncInterval, and when the Swamp closes
+	FsyncInterval
+	// FsyncAlways flushes the written files after every write of the Swamp
+	FsyncAlways
+)
+
+// toProto returns the policy of the RegisterSwampRequest
+func (p FsyncPolicy) toProto() hydraidepbgo.FsyncPolicy_Policy {
+	switch p {
+	case FsyncNever:
+		return hydraidepbgo.FsyncPolicy_NEVER
+	case FsyncInterval:
+		return hydraidepbgo.FsyncPolicy_INTERVAL
+	case FsyncAlways:
+		return hydraidepbgo.FsyncPolicy_ALWAYS
+	default:
+		return hydraidepbgo.FsyncPolicy_DEFAULT
+	}
+}
+
+// fsyncPolicyFromProto returns the policy of the SwampPattern
+func fsyncPolicyFromProto(policy hydraidepbgo.FsyncPolicy_Policy) FsyncPolicy {
+	switch policy {
+	case hydraidepbgo.FsyncPolicy_NEVER:
+		return FsyncNever
+	case hydraidepbgo.FsyncPolicy_INTERVAL:
+		return FsyncInterval
+	case hydraidepbgo.FsyncPolicy_ALWAYS:
+		return FsyncAlways
+	default:
+		return FsyncDefault
+	}
 }
 
 type hydraidego struct {
 	client client.Client
+	// registry holds the patterns registered on the first use of their Swamps, nil without RegisterOnFirstUse
+	registry *PatternRegistry
+	// strictDecoding fails the reads of the values whose stored type differs from the model, see StrictDecoding
+	strictDecoding bool
 }
 
-func New(client client.Client) Hydraidego {
-	return &hydraidego{
+// Option configures the Hydraidego instance created by New
+type Option func(*hydraidego)
+
+func New(client client.Client, options ...Option) Hydraidego {
+	h := &hydraidego{
 		client: client,
 	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
 }
 
 // Heartbeat checks if all HydrAIDE servers are reachable.
@@ -305,6 +680,421 @@ func (h *hydraidego) Heartbeat(ctx context.Context) error {
 	return nil
 }
 
+// CorruptedFile is a chunk file of a server found corrupted, e.g. its checksum does not match its content.
+type CorruptedFile struct {
+	FilePath   string    // the absolute path of the file on the server
+	Reason     string    // the description of the damage
+	DetectedAt time.Time // the time the corruption was detected last
+}
+
+// ListCorruptedFiles lists the corrupted chunk files of all HydrAIDE servers.
+//
+// Every chunk file is written with a checksum, and verified when its Swamp is loaded into the memory.
+// A damaged file is skipped by default, so its Swamp is served without the Treasures of the file, and the file
+// is listed here until it is overwritten or deleted. If the server runs with `failOnCorruptedFiles`, the Swamp
+// is not loaded at all, and the requests of the Swamp fail with an error where IsDataCorrupted(err) is true.
+//
+// ✅ Use when:
+//   - You monitor the health of the stored data, e.g. in an admin dashboard or a periodic check
+//   - A Swamp is missing Treasures, or its requests fail with IsDataCorrupted(err)
+//
+// ⚠️ Only the files of the Swamps loaded since the start of the servers are verified.
+func (h *hydraidego) ListCorruptedFiles(ctx context.Context) ([]*CorruptedFile, error) {
+
+	corruptedFiles := make([]*CorruptedFile, 0)
+
+	for _, serviceClient := range h.client.GetUniqueServiceClients() {
+		response, err := serviceClient.ListCorruptedFiles(ctx, &hydraidepbgo.ListCorruptedFilesRequest{})
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		for _, file := range response.GetFiles() {
+			corruptedFiles = append(corruptedFiles, &CorruptedFile{
+				FilePath:   file.GetFilePath(),
+				Reason:     file.GetReason(),
+				DetectedAt: file.GetDetectedAt().AsTime(),
+			})
+		}
+	}
+
+	return corruptedFiles, nil
+
+}
+
+// NamespaceUsage is the usage of the Swamps of a Realm of a Sanctuary, summed across all HydrAIDE servers.
+type NamespaceUsage struct {
+	Sanctuary       string    // the Sanctuary of the Swamps
+	Realm           string    // the Realm of the Swamps
+	SwampCount      uint64    // the number of the Swamps, including the open in-memory Swamps
+	TreasureCount   uint64    // the number of the Treasures of the counted Swamps
+	UncountedSwamps uint64    // the number of the Swamps without a recorded Treasure count
+	DiskBytes       uint64    // the size of the files of the Swamps in bytes
+	CollectedAt     time.Time // the oldest collection time of the servers
+}
+
+// GetNamespaceUsage returns the disk usage, the Swamp count and the Treasure count of every Realm of every
+// Sanctuary, summed across all HydrAIDE servers, and ordered by the Sanctuary and the Realm.
+//
+// The servers walk the Swamp folders of their Islands without loading the Swamps into the memory. The Treasure
+// count of a closed Swamp is the count recorded when the Swamp was closed last, so the Swamps not closed since the
+// upgrade of the server are reported in UncountedSwamps instead.
+//
+// The servers cache the usage for a minute. Set refresh to walk the Swamp folders again.
+//
+// ✅ Use when:
+//   - You track the growth of the Sanctuaries and the Realms, e.g. in an admin dashboard or a periodic report
+//   - You look for the Realm that fills the disks of the servers
+//
+// ⚠️ The walk of a refresh reads every Swamp folder of the server, so avoid refreshing often on big servers.
+func (h *hydraidego) GetNamespaceUsage(ctx context.Context, refresh bool) ([]*NamespaceUsage, error) {
+
+	type namespaceKey struct {
+		sanctuary string
+		realm     string
+	}
+
+	usages := make([]*NamespaceUsage, 0)
+	usagesByNamespace := make(map[namespaceKey]*NamespaceUsage)
+
+	for _, serviceClient := range h.client.GetUniqueServiceClients() {
+		response, err := serviceClient.GetNamespaceUsage(ctx, &hydraidepbgo.GetNamespaceUsageRequest{Refresh: refresh})
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		collectedAt := response.GetCollectedAt().AsTime()
+		for _, serverUsage := range response.GetUsages() {
+			key := namespaceKey{sanctuary: serverUsage.GetSanctuary(), realm: serverUsage.GetRealm()}
+			usage, ok := usagesByNamespace[key]
+			if !ok {
+				usage = &NamespaceUsage{
+					Sanctuary:   key.sanctuary,
+					Realm:       key.realm,
+					CollectedAt: collectedAt,
+				}
+				usagesByNamespace[key] = usage
+				usages = append(usages, usage)
+			}
+			usage.SwampCount += serverUsage.GetSwampCount()
+			usage.TreasureCount += serverUsage.GetTreasureCount()
+			usage.UncountedSwamps += serverUsage.GetUncountedSwamps()
+			usage.DiskBytes += serverUsage.GetDiskBytes()
+			if collectedAt.Before(usage.CollectedAt) {
+				usage.CollectedAt = collectedAt
+			}
+		}
+	}
+
+	slices.SortFunc(usages, func(a, b *NamespaceUsage) int {
+		if a.Sanctuary != b.Sanctuary {
+			return strings.Compare(a.Sanctuary, b.Sanctuary)
+		}
+		return strings.Compare(a.Realm, b.Realm)
+	})
+
+	return usages, nil
+
+}
+
+// IslandMapping is the Island of a Swamp, computed by the SDK and by the server.
+type IslandMapping struct {
+	SwampName      string // the name of the Swamp
+	AllIslands     uint64 // the number of all Islands of the client
+	ClientIslandID uint64 // the Island computed by the SDK, used to route the requests of the Swamp
+	ServerIslandID uint64 // the Island computed by the server
+	HashFunction   string // the description of the hash function of the server
+}
+
+// VerifyIslandMapping asserts that the SDK and the server compute the same Island for the Swamp.
+//
+// The SDK computes the Island of every Swamp, and the server stores the Swamp on the Island sent by the SDK. If an
+// SDK computed a different Island than the others, e.g. after a regression in the hashing of an SDK of an other
+// language, its Swamps would be scattered to the wrong Islands and servers, and the other clients would not find
+// them. The function asks the server of the Swamp to compute the Island with its own hash function, and compares it
+// with the Island of the SDK. It reads and writes no data.
+//
+// ✅ Use when:
+//   - You write an SDK or a client of an other language, and test its hashing against the server
+//   - You want to fail fast at the start of a service, before it writes anything
+//
+// Returns:
+//   - The Islands of both sides, and the description of the hash function of the server
+//   - An error where IsIslandMismatch(err) is true, if the Islands differ. The mapping is returned in this case, too
+//   - An error where IsInvalidArgument(err) is true, if the name is not a full Swamp name, or the number of all
+//     Islands is larger than the 65535 supported by the server
+//
+// Example:
+//
+//	mapping, err := h.VerifyIslandMapping(ctx, name.New().Sanctuary("users").Realm("profiles").Swamp("alice"))
+//	if hydraidego.IsIslandMismatch(err) {
+//	    log.Fatalf("the SDK routes to island %d, the server computes %d", mapping.ClientIslandID, mapping.ServerIslandID)
+//	}
+func (h *hydraidego) VerifyIslandMapping(ctx context.Context, swampName name.Name) (*IslandMapping, error) {
+
+	if swampName == nil {
+		return nil, NewError(ErrCodeInvalidArgument, "swamp name cannot be nil")
+	}
+	if swampName.IsWildcardPattern() {
+		return nil, NewError(ErrCodeInvalidArgument, "the island of a wildcard pattern can not be verified")
+	}
+
+	allIslands := h.client.GetAllIslands()
+	response, err := h.client.GetServiceClient(swampName).VerifyIslandMapping(ctx, &hydraidepbgo.VerifyIslandMappingRequest{
+		SwampName:  swampName.Get(),
+		AllIslands: allIslands,
+	})
+	if err != nil {
+		return nil, errorHandler(err)
+	}
+
+	mapping := &IslandMapping{
+		SwampName:      swampName.Get(),
+		AllIslands:     allIslands,
+		ClientIslandID: swampName.GetIslandID(allIslands),
+		ServerIslandID: response.GetIslandID(),
+		HashFunction:   response.GetHashFunction(),
+	}
+
+	if mapping.ClientIslandID != mapping.ServerIslandID {
+		return mapping, NewError(ErrCodeIslandMismatch, fmt.Sprintf("%s: the SDK computes the island %d of the swamp %s, the server computes %d with %s",
+			errorMessageIslandMismatch, mapping.ClientIslandID, mapping.SwampName, mapping.ServerIslandID, mapping.HashFunction))
+	}
+
+	return mapping, nil
+
+}
+
+// AuditRecord is a mutating request recorded by the audit log of a server.
+type AuditRecord struct {
+	Time       time.Time // the time the request arrived
+	Method     string    // the name of the RPC, e.g. "Set" or "Destroy"
+	ClientID   string    // the client ID of the SDK, empty if the client did not send one
+	ClientIP   string    // the IP address of the client
+	Tenant     string    // the tenant of the request, empty if the server runs without tenants
+	SwampNames []string  // the Swamps of the request, limited to the first ones
+	SwampCount int32     // the number of all Swamps of the request
+	Keys       []string  // the keys of the request, limited to the first ones
+	KeyCount   int32     // the number of all keys of the request
+	Code       string    // the gRPC status code of the result, e.g. "OK" or "NotFound"
+	Error      string    // the error message, empty if the request succeeded
+}
+
+// AuditFilter selects the records of QueryAuditLog. The zero values mean no filtering.
+type AuditFilter struct {
+	From      time.Time // the first time of the records, inclusive
+	To        time.Time // the last time of the records, exclusive
+	Method    string    // the name of the RPC, e.g. "Delete"
+	ClientID  string    // the client ID of the SDK
+	SwampName name.Name // the Swamp the request touched
+	Key       string    // the key the request touched
+	Limit     int32     // the max number of the records. Zero means the default limit of the server (100)
+}
+
+// QueryAuditLog returns the recorded mutating requests of all HydrAIDE servers, the newest first.
+//
+// If the audit log is enabled on the server (HYDRAIDE_AUDIT_ENABLED=true), every mutating request, e.g. Set, Delete,
+// Destroy or an Increment, is recorded with the client, the time, the Swamps, the keys and the result, including
+// the failed and the rejected requests. The reads are not recorded. With tenants, a tenant can query only its own
+// records.
+//
+// ✅ Use when:
+//   - You investigate who changed or deleted a Treasure, and when
+//   - You need a compliance trail of the changes of the data
+//
+// ⚠️ If the audit log is not enabled on a server, the error is IsAuditLogDisabled(err).
+// ⚠️ The records of the servers are merged, and the limit applies to the merged result.
+func (h *hydraidego) QueryAuditLog(ctx context.Context, filter *AuditFilter) ([]*AuditRecord, error) {
+
+	if filter == nil {
+		filter = &AuditFilter{}
+	}
+	if filter.Limit < 0 {
+		return nil, NewError(ErrCodeInvalidArgument, "the limit can not be negative")
+	}
+
+	request := &hydraidepbgo.QueryAuditLogRequest{
+		Method:   filter.Method,
+		ClientID: filter.ClientID,
+		Key:      filter.Key,
+		Limit:    filter.Limit,
+	}
+	if !filter.From.IsZero() {
+		request.From = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		request.To = timestamppb.New(filter.To)
+	}
+	if filter.SwampName != nil {
+		request.SwampName = filter.SwampName.Get()
+	}
+
+	records := make([]*AuditRecord, 0)
+
+	for _, serviceClient := range h.client.GetUniqueServiceClients() {
+		response, err := serviceClient.QueryAuditLog(ctx, request)
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		for _, record := range response.GetRecords() {
+			records = append(records, &AuditRecord{
+				Time:       record.GetTime().AsTime(),
+				Method:     record.GetMethod(),
+				ClientID:   record.GetClientID(),
+				ClientIP:   record.GetClientIP(),
+				Tenant:     record.GetTenant(),
+				SwampNames: record.GetSwampNames(),
+				SwampCount: record.GetSwampCount(),
+				Keys:       record.GetKeys(),
+				KeyCount:   record.GetKeyCount(),
+				Code:       record.GetCode(),
+				Error:      record.GetError(),
+			})
+		}
+	}
+
+	// every server returns its own newest records, so the merged list is sorted and trimmed again
+	slices.SortStableFunc(records, func(a, b *AuditRecord) int {
+		return b.Time.Compare(a.Time)
+	})
+	limit := int(filter.Limit)
+	if limit == 0 {
+		limit = defaultAuditQueryLimit
+	}
+	if len(records) > limit {
+		records = records[:limit]
+	}
+
+	return records, nil
+
+}
+
+// PointInTimeRestore selects the point in time and the Swamps of RestorePointInTime.
+type PointInTimeRestore struct {
+	Until     time.Time   // the point in time, the Swamps get their state at this time
+	Patterns  []name.Name // the Swamps matching the patterns are restored, the Sanctuary, the Realm and the Swamp can be "*"
+	IslandIDs []uint64    // the Swamps of the Islands are restored. Both selectors empty means all Swamps
+}
+
+// PointInTimeRestoreResult summarizes the RestorePointInTime of all servers.
+type PointInTimeRestoreResult struct {
+	BackupIDs         []string // the IDs of the backups the servers started from
+	Swamps            int64    // the number of the restored Swamps
+	ReplayedRecords   int64    // the number of the records of the write-ahead logs replayed on the backups
+	RestoredTreasures int64    // the number of the Treasures written back to their state at the point in time
+	DeletedTreasures  int64    // the number of the Treasures deleted, because they did not exist at the point in time
+}
+
+// RestorePointInTime restores the Swamps of all HydrAIDE servers to their state at a point in time.
+//
+// Every server takes its newest complete backup before the point in time, and replays its write-ahead log from the
+// backup to the point in time. The changed Treasures of the restored Swamps are written back, and the Treasures
+// created after the point in time are deleted. The other Swamps are not touched. With tenants, a tenant restores
+// only its own Swamps.
+//
+// ✅ Use when:
+//   - A bug or a wrong script corrupted the data, and you know when it happened
+//   - You need the state of some Swamps before an accidental Delete or Destroy
+//
+// Example:
+//
+//	result, err := h.RestorePointInTime(ctx, &hydraidego.PointInTimeRestore{
+//	    Until:    time.Date(2026, 10, 18, 9, 41, 0, 0, time.UTC),
+//	    Patterns: []name.Name{name.New().Sanctuary("users").Realm("profiles").Swamp("*")},
+//	})
+//
+// ⚠️ The server needs the scheduled backups and the write-ahead log (HYDRAIDE_BACKUP_ENABLED=true and
+// HYDRAIDE_WAL_ENABLED=true), otherwise the error is IsPointInTimeRestoreDisabled(err).
+// ⚠️ If the backups and the write-ahead log do not cover the point in time, the error is IsPointInTimeNotCovered(err).
+// ⚠️ The restore is not atomic: the Swamps are restored one by one while the servers serve the clients, so stop the
+// writers of the restored Swamps first. The servers are restored one after the other, and the first error stops it.
+func (h *hydraidego) RestorePointInTime(ctx context.Context, request *PointInTimeRestore) (*PointInTimeRestoreResult, error) {
+
+	if request == nil || request.Until.IsZero() {
+		return nil, NewError(ErrCodeInvalidArgument, "the point in time is required")
+	}
+
+	restoreRequest := &hydraidepbgo.RestorePointInTimeRequest{
+		Until:     timestamppb.New(request.Until),
+		IslandIDs: request.IslandIDs,
+	}
+	for _, pattern := range request.Patterns {
+		restoreRequest.Patterns = append(restoreRequest.Patterns, pattern.Get())
+	}
+
+	result := &PointInTimeRestoreResult{}
+
+	for _, serviceClient := range h.client.GetUniqueServiceClients() {
+		response, err := serviceClient.RestorePointInTime(ctx, restoreRequest)
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		result.BackupIDs = append(result.BackupIDs, response.GetBackupID())
+		result.Swamps += response.GetSwamps()
+		result.ReplayedRecords += response.GetReplayedRecords()
+		result.RestoredTreasures += response.GetRestoredTreasures()
+		result.DeletedTreasures += response.GetDeletedTreasures()
+	}
+
+	return result, nil
+
+}
+
+// CompactionResult summarizes the compaction of a Swamp by `CompactSwamp()`.
+type CompactionResult struct {
+	CompactedFiles   int32 // the number of the rewritten and deleted chunk files
+	WrittenFiles     int32 // the number of the new chunk files the live Treasures are written to
+	RemovedTreasures int32 // the number of the deleted Treasures removed from the chunk files
+	ReclaimedBytes   int64 // the disk space freed by the compaction
+}
+
+// CompactSwamp rewrites the chunk files of a Swamp that are mostly dead data.
+//
+// The deleted Treasures stay in their chunk files as tombstones, so after heavy delete churn the Swamp folder
+// accumulates chunks with only a few live Treasures. They waste disk space and slow down the hydration of the Swamp.
+// The compaction rewrites the live Treasures of the chunks, where the ratio of the live data is below minLiveRatio,
+// into as few new chunks as possible, and deletes the old ones.
+//
+// ✅ Use when:
+//   - You deleted a large part of a Swamp, e.g. after a cleanup job or the expiration of many Treasures
+//   - The hydration of a Swamp is slow, or its folder is much bigger than its live data
+//
+// ⚙️ Behavior:
+//   - minLiveRatio must be between 0 and 1, 0 means the server default (0.5)
+//   - The chunk the new Treasures are appended to is never compacted
+//   - In-memory Swamps have no chunks, so the result is empty
+//   - If the Swamp does not exist → returns `ErrCodeSwampNotFound`
+//
+// 🔧 Example:
+//
+//	// rewrite the chunks with less than 30% live data
+//	result, err := h.CompactSwamp(ctx, swampName, 0.3)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(result.CompactedFiles, result.ReclaimedBytes)
+func (h *hydraidego) CompactSwamp(ctx context.Context, swampName name.Name, minLiveRatio float64) (*CompactionResult, error) {
+
+	if swampName == nil {
+		return nil, NewError(ErrCodeInvalidArgument, "swamp name cannot be nil")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).CompactSwamp(ctx, &hydraidepbgo.CompactSwampRequest{
+		IslandID:     swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:    swampName.Get(),
+		MinLiveRatio: minLiveRatio,
+	})
+	if err != nil {
+		return nil, errorHandler(err)
+	}
+
+	return &CompactionResult{
+		CompactedFiles:   response.GetCompactedFiles(),
+		WrittenFiles:     response.GetWrittenFiles(),
+		RemovedTreasures: response.GetRemovedTreasures(),
+		ReclaimedBytes:   response.GetReclaimedBytes(),
+	}, nil
+
+}
+
 // RegisterSwamp registers a Swamp pattern across the appropriate HydrAIDE servers.
 //
 // This method is required before using a Swamp. It tells HydrAIDE how to handle
@@ -349,9 +1139,35 @@ func (h *hydraidego) RegisterSwamp(ctx context.Context, request *RegisterSwampRe
 
 		// Construct the RegisterSwampRequest payload for the gRPC call.
 		rsr := &hydraidepbgo.RegisterSwampRequest{
-			SwampPattern:    request.SwampPattern.Get(),
-			CloseAfterIdle:  int64(request.CloseAfterIdle.Seconds()),
-			IsInMemorySwamp: request.IsInMemorySwamp,
+			SwampPattern:          request.SwampPattern.Get(),
+			CloseAfterIdle:        int64(request.CloseAfterIdle.Seconds()),
+			IsInMemorySwamp:       request.IsInMemorySwamp,
+			ValueIndex:            request.ValueIndex,
+			EventJournalSize:      int64(request.EventJournalSize),
+			EventJournalRetention: int64(request.EventJournalRetention.Seconds()),
+			ServerTimestamps:      request.ServerTimestamps,
+			HistoryDepth:          int64(request.HistoryDepth),
+			DefaultExpireAfter:    int64(request.DefaultExpireAfter.Seconds()),
+			BeaconEvictAfterIdle:  int64(request.IndexEvictAfterIdle.Seconds()),
+			Force:                 request.Force,
+		}
+
+		for _, indexType := range request.KeptIndexes {
+			rsr.KeptBeacons = append(rsr.KeptBeacons, convertIndexTypeToProtoBeacon(indexType))
+		}
+
+		if request.Constraints != nil {
+			rsr.MaxKeyLength = int64(request.Constraints.MaxKeyLength)
+			rsr.MaxValueSize = request.Constraints.MaxValueSize
+			for _, field := range request.Constraints.RequiredMetadata {
+				rsr.RequiredMetadata = append(rsr.RequiredMetadata, convertMetadataFieldToProto(field))
+			}
+		}
+
+		if request.Retention != nil {
+			rsr.MaxTreasureAge = int64(request.Retention.MaxAge.Seconds())
+			rsr.MaxTreasures = int64(request.Retention.MaxTreasures)
+			rsr.MaxSwampSize = request.Retention.MaxSize
 		}
 
 		// If the Swamp is persistent (not in-memory), apply filesystem settings.
@@ -360,6 +1176,10 @@ func (h *hydraidego) RegisterSwamp(ctx context.Context, request *RegisterSwampRe
 			mfs := int64(request.FilesystemSettings.MaxFileSize)
 			rsr.WriteInterval = &wi
 			rsr.MaxFileSize = &mfs
+			rsr.Fsync = request.FilesystemSettings.FsyncPolicy.toProto()
+			rsr.FsyncInterval = int64(request.FilesystemSettings.FsyncInterval.Seconds())
+			rsr.KeyFilter = request.FilesystemSettings.KeyFilter
+			rsr.LazyHydration = request.FilesystemSettings.LazyHydration
 		}
 
 		// Attempt to register the Swamp pattern on the current server.
@@ -367,7 +1187,9 @@ func (h *hydraidego) RegisterSwamp(ctx context.Context, request *RegisterSwampRe
 
 		// Handle any errors returned from the gRPC call.
 		if err != nil {
-			if s, ok := status.FromError(err); ok {
+			if reasonErr, found := errorFromReason(err); found {
+				allErrors = append(allErrors, reasonErr)
+			} else if s, ok := status.FromError(err); ok {
 				switch s.Code() {
 				case codes.Unavailable:
 					allErrors = append(allErrors, NewError(ErrCodeConnectionError, errorMessageConnectionError))
@@ -426,11 +1248,46 @@ func (h *hydraidego) RegisterSwamp(ctx context.Context, request *RegisterSwampRe
 // 3. Finally, call `DeRegisterSwamp()` to remove the pattern itself
 //
 // ❗ If you skip step 2, the Swamp files may remain on disk even if the pattern is gone.
+// Use DeRegisterSwampAndPurge() to do step 2 and 3 in one call.
 //
 // Returns:
 // - A list of errors if deregistration fails on any server
 // - Nil if deregistration completes successfully across all relevant servers
 func (h *hydraidego) DeRegisterSwamp(ctx context.Context, swampName name.Name) []error {
+	_, errs := h.deRegisterSwamp(ctx, swampName, false, false)
+	return errs
+}
+
+// DeRegisterSwampAndPurge destroys all Swamps matching the pattern with their files on the disk, and then removes the
+// pattern registration, like DeRegisterSwamp() does.
+//
+// 🧠 It retires a pattern and its data in one call, instead of deleting the Treasures of every Swamp first. The
+// routing is the same as DeRegisterSwamp(): a wildcard pattern is purged on all servers, an exact name only on its
+// own server.
+//
+// ✅ Use when:
+//   - A migration moved the data to a new pattern, and the old Swamps are not needed anymore
+//   - A feature is removed with all of its data
+//
+// 🧪 Check first with dryRun, which lists the Swamps that would be destroyed, without destroying them and without
+// removing the pattern:
+//
+//	swamps, errs := h.DeRegisterSwampAndPurge(ctx, name.New().Sanctuary("users").Realm("sessions").Swamp("*"), true)
+//
+// ⚠️ The destroyed Swamps can not be restored. A Swamp written during the purge can be created again, so stop
+// writing the pattern before the purge.
+//
+// Returns:
+//   - The names of the destroyed Swamps, or the Swamps that would be destroyed with dryRun, sorted by their names
+//   - A list of errors if the purge or the deregistration fails on any server. The pattern stays registered on
+//     the failed servers, so the call can be repeated.
+func (h *hydraidego) DeRegisterSwampAndPurge(ctx context.Context, swampName name.Name, dryRun bool) ([]name.Name, []error) {
+	return h.deRegisterSwamp(ctx, swampName, true, dryRun)
+}
+
+// deRegisterSwamp removes the pattern registration on the selected servers, and destroys the matching Swamps first
+// if purge is true
+func (h *hydraidego) deRegisterSwamp(ctx context.Context, swampName name.Name, purge bool, dryRun bool) ([]name.Name, []error) {
 
 	// Container to collect any errors during the deregistration process.
 	allErrors := make([]error, 0)
@@ -438,9 +1295,11 @@ func (h *hydraidego) DeRegisterSwamp(ctx context.Context, swampName name.Name) [
 	// Validate that a SwampPattern (name) is provided.
 	if swampName == nil {
 		allErrors = append(allErrors, fmt.Errorf("SwampPattern is required"))
-		return allErrors
+		return nil, allErrors
 	}
 
+	var purgedNames []string
+
 	// Determine the list of servers from which the Swamp pattern should be deregistered.
 	selectedServers := make([]hydraidepbgo.HydraideServiceClient, 0)
 
@@ -460,14 +1319,18 @@ func (h *hydraidego) DeRegisterSwamp(ctx context.Context, swampName name.Name) [
 		// Build the DeregisterSwampRequest payload for the gRPC call.
 		rsr := &hydraidepbgo.DeRegisterSwampRequest{
 			SwampPattern: swampName.Get(),
+			Purge:        purge,
+			DryRun:       dryRun,
 		}
 
 		// Send the deregistration request to the server.
-		_, err := serviceClient.DeRegisterSwamp(ctx, rsr)
+		response, err := serviceClient.DeRegisterSwamp(ctx, rsr)
 
 		// Handle any errors returned by the gRPC layer and convert them to SDK error codes.
 		if err != nil {
-			if s, ok := status.FromError(err); ok {
+			if reasonErr, found := errorFromReason(err); found {
+				allErrors = append(allErrors, reasonErr)
+			} else if s, ok := status.FromError(err); ok {
 				switch s.Code() {
 				case codes.Unavailable:
 					allErrors = append(allErrors, NewError(ErrCodeConnectionError, errorMessageConnectionError))
@@ -485,19 +1348,174 @@ func (h *hydraidego) DeRegisterSwamp(ctx context.Context, swampName name.Name) [
 			} else {
 				allErrors = append(allErrors, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err)))
 			}
+			continue
 		}
+
+		purgedNames = append(purgedNames, response.GetPurgedSwamps()...)
+	}
+
+	// the Swamps of the servers are merged into one list
+	slices.Sort(purgedNames)
+	var purgedSwamps []name.Name
+	for _, purgedName := range purgedNames {
+		purgedSwamps = append(purgedSwamps, name.Load(purgedName))
 	}
 
 	// Return any collected errors if deregistration failed on one or more servers.
 	if len(allErrors) > 0 {
-		return allErrors
+		return purgedSwamps, allErrors
 	}
 
 	// Deregistration completed successfully on all target servers.
-	return nil
+	return purgedSwamps, nil
 }
 
-// Lock acquires a distributed business-level lock for a specific domain/key.
+// SwampPattern is a Swamp pattern registered on a HydrAIDE server, with the settings in effect.
+type SwampPattern struct {
+	SwampPattern    name.Name     // the registered pattern, e.g. Sanctuary("users").Realm("*").Swamp("*")
+	IsInMemorySwamp bool          // true if the Swamps of the pattern exist only in memory
+	CloseAfterIdle  time.Duration // the idle time after which a Swamp is closed
+	WriteInterval   time.Duration // how often a Swamp writes its changes to the disk, 0 for in-memory Swamps
+	MaxFileSize     int           // the max size of a compressed chunk file, 0 for in-memory Swamps
+	FsyncPolicy     FsyncPolicy   // when the written files are flushed to the disk, FsyncDefault for in-memory Swamps
+	FsyncInterval   time.Duration // the min time between two flushes with FsyncInterval
+	KeyFilter       bool          // true if the Swamps keep a bloom filter of their keys for the existence checks
+	LazyHydration   bool          // true if the Swamps keep a directory of the chunk files of their keys for the reads
+	RegisteredBy    string        // the client ID, or the IP address of the client that registered the pattern last
+	RegisteredAt    time.Time     // the time of the last registration that changed the pattern, zero if it is unknown
+}
+
+// swampPatternFromProto converts the SwampPattern of the server
+func swampPatternFromProto(pattern *hydraidepbgo.SwampPattern) *SwampPattern {
+	swampPattern := &SwampPattern{
+		SwampPattern:    name.Load(pattern.GetSwampPattern()),
+		IsInMemorySwamp: pattern.GetIsInMemorySwamp(),
+		CloseAfterIdle:  time.Duration(pattern.GetCloseAfterIdle()) * time.Second,
+		WriteInterval:   time.Duration(pattern.GetWriteInterval()) * time.Second,
+		MaxFileSize:     int(pattern.GetMaxFileSize()),
+		FsyncPolicy:     fsyncPolicyFromProto(pattern.GetFsync()),
+		FsyncInterval:   time.Duration(pattern.GetFsyncInterval()) * time.Second,
+		KeyFilter:       pattern.GetKeyFilter(),
+		LazyHydration:   pattern.GetLazyHydration(),
+		RegisteredBy:    pattern.GetRegisteredBy(),
+	}
+	if pattern.GetRegisteredAt() != nil {
+		swampPattern.RegisteredAt = pattern.GetRegisteredAt().AsTime()
+	}
+	return swampPattern
+}
+
+// ListSwampPatterns lists the Swamp patterns registered on the HydrAIDE servers, sorted by their names.
+//
+// The settings are the ones in effect on the servers: a setting left unset at the registration shows the default of
+// the server at the time of the registration. A wildcard pattern is registered on all servers, and it is listed
+// once, with the settings of the first server.
+//
+// ✅ Use when:
+//   - You want to see how the Swamps of your patterns are kept in memory and written to the disk
+//   - You check the result of an UpdateSwampPattern, e.g. in an admin tool
+func (h *hydraidego) ListSwampPatterns(ctx context.Context) ([]*SwampPattern, error) {
+
+	patterns := make(map[string]*SwampPattern)
+
+	for _, serviceClient := range h.client.GetUniqueServiceClients() {
+		response, err := serviceClient.ListSwampPatterns(ctx, &hydraidepbgo.ListSwampPatternsRequest{})
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		for _, pattern := range response.GetPatterns() {
+			if _, ok := patterns[pattern.GetSwampPattern()]; ok {
+				continue
+			}
+			patterns[pattern.GetSwampPattern()] = swampPatternFromProto(pattern)
+		}
+	}
+
+	patternNames := make([]string, 0, len(patterns))
+	for patternName := range patterns {
+		patternNames = append(patternNames, patternName)
+	}
+	slices.Sort(patternNames)
+
+	result := make([]*SwampPattern, 0, len(patterns))
+	for _, patternName := range patternNames {
+		result = append(result, patterns[patternName])
+	}
+
+	return result, nil
+
+}
+
+// UpdateSwampPatternRequest contains the settings of a registered Swamp pattern to change. The zero values keep the
+// current settings of the pattern.
+type UpdateSwampPatternRequest struct {
+	// SwampPattern is the registered pattern, exactly as it was registered by RegisterSwamp.
+	SwampPattern name.Name
+	// CloseAfterIdle is the new idle time after which a Swamp is closed (whole seconds).
+	CloseAfterIdle time.Duration
+	// WriteInterval is the new interval of writing the changes of a Swamp to the disk (whole seconds).
+	// Can not be set for an in-memory pattern.
+	WriteInterval time.Duration
+	// MaxFileSize is the new max size of a compressed chunk file. Can not be set for an in-memory pattern.
+	MaxFileSize int
+}
+
+// UpdateSwampPattern changes the CloseAfterIdle, the WriteInterval and the MaxFileSize of a registered Swamp
+// pattern, and keeps its other settings, e.g. the indexes or the retention.
+//
+// The request follows the routing of RegisterSwamp: a wildcard pattern is updated on all servers, an exact pattern
+// only on its responsible server. The change is saved on the servers, and applies to the Swamps opened after it.
+// The open Swamps keep their settings until they close, so nothing is rehydrated.
+//
+// ✅ Use when:
+//   - You tune the memory usage or the disk writes of a pattern without redeploying the services that register it
+//
+// ⚠️ A service that calls RegisterSwamp again with the old settings, e.g. at its next start, gets an error where
+// IsPatternConflict(err) is true, and the pattern keeps the change. Update the registration in the code of the
+// service, too.
+//
+// Returns a list of errors, one for each server where the update failed, or nil if it succeeded everywhere. If the
+// pattern is not registered on a server, the error of the server is IsNotFound(err).
+func (h *hydraidego) UpdateSwampPattern(ctx context.Context, request *UpdateSwampPatternRequest) []error {
+
+	if request == nil || request.SwampPattern == nil {
+		return []error{NewError(ErrCodeInvalidArgument, "SwampPattern is required")}
+	}
+
+	selectedServers := []hydraidepbgo.HydraideServiceClient{h.client.GetServiceClient(request.SwampPattern)}
+	if request.SwampPattern.IsWildcardPattern() {
+		selectedServers = h.client.GetUniqueServiceClients()
+	}
+
+	usr := &hydraidepbgo.UpdateSwampPatternRequest{
+		SwampPattern: request.SwampPattern.Get(),
+	}
+	if request.CloseAfterIdle > 0 {
+		usr.CloseAfterIdle = proto.Int64(int64(request.CloseAfterIdle.Seconds()))
+	}
+	if request.WriteInterval > 0 {
+		usr.WriteInterval = proto.Int64(int64(request.WriteInterval.Seconds()))
+	}
+	if request.MaxFileSize > 0 {
+		usr.MaxFileSize = proto.Int64(int64(request.MaxFileSize))
+	}
+
+	allErrors := make([]error, 0)
+	for _, serviceClient := range selectedServers {
+		if _, err := serviceClient.UpdateSwampPattern(ctx, usr); err != nil {
+			allErrors = append(allErrors, errorHandler(err))
+		}
+	}
+
+	if len(allErrors) > 0 {
+		return allErrors
+	}
+
+	return nil
+
+}
+
+// Lock acquires a distributed business-level lock for a specific domain/key.
 //
 // This is not tied to a single Swamp or Treasure — it’s a **cross-cutting domain lock**.
 // You can use it to serialize logic across services or modules that operate
@@ -546,7 +1564,9 @@ func (h *hydraidego) Lock(ctx context.Context, key string, ttl time.Duration) (l
 
 	// Handle network and gRPC-specific errors
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return "", reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return "", NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -595,7 +1615,9 @@ func (h *hydraidego) Unlock(ctx context.Context, key string, lockID string) erro
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -653,13 +1675,15 @@ func (h *hydraidego) Unlock(ctx context.Context, key string, lockID string) erro
 // ⚠️ Requires that the Swamp pattern for the given name was previously registered.
 func (h *hydraidego) IsSwampExist(ctx context.Context, swampName name.Name) (bool, error) {
 
-	response, err := h.client.GetServiceClient(swampName).IsSwampExist(ctx, &hydraidepbgo.IsSwampExistRequest{
+	response, err := h.serviceClient(ctx, swampName).IsSwampExist(ctx, &hydraidepbgo.IsSwampExistRequest{
 		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName: swampName.Get(),
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return false, reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return false, NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -687,12 +1711,117 @@ func (h *hydraidego) IsSwampExist(ctx context.Context, swampName name.Name) (boo
 
 }
 
+// ExistsMany checks the existence of many Swamps, or Swamps matching wildcard patterns, in a single roundtrip per server.
+//
+// This is the batch variant of `IsSwampExist()`. Every item of `patterns` is either an exact Swamp name,
+// or a wildcard pattern where the Realm and/or the Swamp part is "*".
+//
+// ✅ Use when:
+//   - You render existence badges for hundreds of items in a UI
+//   - You want to discover which Swamps exist under a Sanctuary or Realm
+//
+// ⚙️ Behavior:
+//   - Exact names are grouped by the server they live on, and checked with one request per server
+//   - Wildcard patterns are sent to every server, because the matching Swamps can live on any of them
+//   - Returns a map keyed by the Swamp name (`name.Get()`):
+//   - exact names are always in the map, with true if the Swamp exists, false otherwise
+//   - wildcard patterns add every existing matching Swamp to the map with true
+//   - If any server request fails, the whole call fails with the mapped SDK error
+//
+// ⚠️ Wildcard patterns are resolved by walking all island folders of the servers, so they are much more expensive
+// than exact names. The Sanctuary part of a pattern cannot be a wildcard.
+//
+// 🔧 Example:
+//
+//	exists, err := h.ExistsMany(ctx, []name.Name{
+//	    name.New().Sanctuary("domains").Realm("ai").Swamp("example.com"),
+//	    name.New().Sanctuary("domains").Realm("*").Swamp("hydraide.io"),
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if exists["domains/ai/example.com"] {
+//	    fmt.Println("example.com is analyzed")
+//	}
+func (h *hydraidego) ExistsMany(ctx context.Context, patterns []name.Name) (map[string]bool, error) {
+
+	// the service clients are shared per server, so they can be used to group the requests per server
+	serverRequests := make(map[hydraidepbgo.HydraideServiceClient][]*hydraidepbgo.ExistsManySwamp)
+	result := make(map[string]bool, len(patterns))
+
+	for _, pattern := range patterns {
+
+		if pattern == nil {
+			return nil, NewError(ErrCodeInvalidArgument, "swamp name cannot be nil")
+		}
+
+		if pattern.IsWildcardPattern() {
+			for _, serviceClient := range h.client.GetUniqueServiceClients() {
+				serverRequests[serviceClient] = append(serverRequests[serviceClient], &hydraidepbgo.ExistsManySwamp{
+					SwampName: pattern.Get(),
+				})
+			}
+			continue
+		}
+
+		result[pattern.Get()] = false
+		serviceClient := h.serviceClient(ctx, pattern)
+		serverRequests[serviceClient] = append(serverRequests[serviceClient], &hydraidepbgo.ExistsManySwamp{
+			IslandID:  pattern.GetIslandID(h.client.GetAllIslands()),
+			SwampName: pattern.Get(),
+		})
+
+	}
+
+	for serviceClient, swamps := range serverRequests {
+
+		response, err := serviceClient.ExistsMany(ctx, &hydraidepbgo.ExistsManyRequest{
+			Swamps: swamps,
+		})
+
+		if err != nil {
+			if reasonErr, found := errorFromReason(err); found {
+				return nil, reasonErr
+			} else if s, ok := status.FromError(err); ok {
+				switch s.Code() {
+				case codes.Unavailable:
+					return nil, NewError(ErrCodeConnectionError, errorMessageConnectionError)
+				case codes.DeadlineExceeded:
+					return nil, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
+				case codes.Canceled:
+					return nil, NewError(ErrCodeCtxClosedByClient, errorMessageCtxClosedByClient)
+				case codes.InvalidArgument:
+					return nil, NewError(ErrCodeInvalidArgument, fmt.Sprintf("%s: %v", errorMessageSwampNameNotCorrect, s.Message()))
+				case codes.Internal:
+					return nil, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
+				default:
+					return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+				}
+			}
+			return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+		}
+
+		for _, existsResult := range response.GetResults() {
+			for _, swampName := range existsResult.GetExistingSwamps() {
+				result[swampName] = true
+			}
+		}
+
+	}
+
+	return result, nil
+
+}
+
 // IsKeyExists checks whether a specific key exists inside a given Swamp.
 //
 // 🔍 This is a **memory-aware** check — the Swamp is always hydrated (loaded into memory) as part of this operation.
 // If the Swamp was not yet loaded, it will be loaded now and remain in memory based on its registered settings
 // (e.g. CloseAfterIdle, persistence, etc.).
 //
+// 💡 If the pattern of the Swamp is registered with `KeyFilter`, a missing key of a closed Swamp is usually answered
+// from the bloom filter of the Swamp, without hydrating it. See SwampFilesystemSettings.KeyFilter.
+//
 // ✅ When to use this:
 // - When you want to check if a given key has been previously inserted into a Swamp
 // - When you're implementing **unique key checks**, deduplication, or conditional inserts
@@ -718,14 +1847,16 @@ func (h *hydraidego) IsSwampExist(ctx context.Context, swampName name.Name) (boo
 // If the Swamp was not registered, or was deleted due to being empty, this will return `ErrCodeSwampNotFound`.
 func (h *hydraidego) IsKeyExists(ctx context.Context, swampName name.Name, key string) (bool, error) {
 
-	response, err := h.client.GetServiceClient(swampName).IsKeyExist(ctx, &hydraidepbgo.IsKeyExistRequest{
+	response, err := h.serviceClient(ctx, swampName).IsKeyExist(ctx, &hydraidepbgo.IsKeyExistRequest{
 		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName: swampName.Get(),
 		Key:       key,
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return false, reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return false, NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -753,6 +1884,127 @@ func (h *hydraidego) IsKeyExists(ctx context.Context, swampName name.Name, key s
 
 }
 
+// IsKeysExist checks the existence of many keys inside a given Swamp, with a single request.
+//
+// This is the bulk variant of `IsKeyExists()`. Only the existence flags travel over the network, not the values,
+// so checking 10 000 candidate keys costs one roundtrip instead of 10 000.
+//
+// ✅ When to use this:
+// - Deduplication pipelines that filter out the already seen candidates in batches
+// - Checking which items of a list still exist before a batch operation
+//
+// 💡 With `KeyFilter`, a closed Swamp is hydrated only if its bloom filter can not exclude all keys of the batch.
+//
+// 🔁 Return values:
+// - `(map, nil)` → the map contains every requested key, with true if the key exists in the Swamp
+// - `(map, nil)` with all false → the Swamp does not exist, so none of the keys exist
+// - `(nil, <other error>)` → Some database/server issue occurred
+//
+// ⚠️ Always use **fully qualified Swamp names** – no wildcards allowed.
+// Very large key lists are limited by the maximum gRPC message size of the client, split them into batches
+// (e.g. 10 000 keys per call) if needed.
+func (h *hydraidego) IsKeysExist(ctx context.Context, swampName name.Name, keys []string) (map[string]bool, error) {
+
+	result := make(map[string]bool, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	response, err := h.serviceClient(ctx, swampName).IsKeysExist(ctx, &hydraidepbgo.IsKeysExistRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Keys:      keys,
+	})
+	if err != nil {
+		sdkErr := errorHandler(err)
+		if !IsSwampNotFound(sdkErr) {
+			return nil, sdkErr
+		}
+		// none of the keys exist in a missing Swamp, the same as in an empty one
+		for _, key := range keys {
+			result[key] = false
+		}
+		return result, nil
+	}
+
+	isExist := response.GetIsExist()
+	if len(isExist) != len(keys) {
+		return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: the server returned %d flags for %d keys", errorMessageUnknown, len(isExist), len(keys)))
+	}
+
+	for i, key := range keys {
+		result[key] = isExist[i]
+	}
+
+	return result, nil
+
+}
+
+// SetSwampAnnotation attaches a small key-value annotation to an existing Swamp.
+//
+// 🏷️ Annotations are free-form labels owned by your application. They live in the Swamp's metadata,
+// next to the data, so any service can find out who owns a Swamp or what is inside it — without reading the data.
+//
+// ✅ Typical annotations:
+// - `owner` → the service that writes the Swamp (e.g. "billing-service")
+// - `schemaVersion` → the version of the model stored in the Swamp
+// - `retentionClass` → how long the data should be kept
+//
+// ⚙️ Behavior:
+// - Setting an existing key overwrites its value
+// - An empty value deletes the annotation
+// - The annotations are persisted together with the Swamp's metadata
+//
+// ⚠️ Limits:
+// - At most 64 annotations per Swamp → `ErrCodeQuotaExceeded`
+// - Keys up to 128 bytes, values up to 1024 bytes → `ErrCodeInvalidArgument`
+// - The Swamp must exist → `ErrCodeSwampNotFound`
+func (h *hydraidego) SetSwampAnnotation(ctx context.Context, swampName name.Name, key string, value string) error {
+
+	if key == "" {
+		return NewError(ErrCodeInvalidArgument, "annotation key cannot be empty")
+	}
+
+	_, err := h.serviceClient(ctx, swampName).SetSwampAnnotation(ctx, &hydraidepbgo.SetSwampAnnotationRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Value:     value,
+	})
+
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	return nil
+
+}
+
+// GetSwampAnnotations returns all annotations of an existing Swamp.
+//
+// The returned map is never nil. If the Swamp has no annotations, it is empty.
+//
+// ⚠️ Returns `ErrCodeSwampNotFound` if the Swamp does not exist.
+func (h *hydraidego) GetSwampAnnotations(ctx context.Context, swampName name.Name) (map[string]string, error) {
+
+	response, err := h.serviceClient(ctx, swampName).GetSwampAnnotations(ctx, &hydraidepbgo.GetSwampAnnotationsRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+	})
+
+	if err != nil {
+		return nil, errorHandler(err)
+	}
+
+	annotations := make(map[string]string, len(response.GetAnnotations()))
+	for k, v := range response.GetAnnotations() {
+		annotations[k] = v
+	}
+
+	return annotations, nil
+
+}
+
 // CatalogCreate inserts a new Treasure into a Swamp using a tagged Go struct as the input model.
 //
 // 🧠 Purpose:
@@ -823,29 +2075,27 @@ func (h *hydraidego) IsKeyExists(ctx context.Context, swampName name.Name, key s
 // Each record is identified by UserUUID and optionally enriched with metadata.
 func (h *hydraidego) CatalogCreate(ctx context.Context, swampName name.Name, model any) error {
 
-	kvPair, err := convertCatalogModelToKeyValuePair(model)
+	kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 	if err != nil {
 		return NewError(ErrCodeInvalidModel, err.Error())
 	}
 
 	// egyetlen adatot hozunk létre a hydrában overwrit nélkül.
 	// A swamp mindenképpen létrejön, ha még nem létezett, de a kulcs csak akkor, ha még nem létezett
-	setResponse, err := h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
-		Swamps: []*hydraidepbgo.SwampRequest{
-			{
-				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName: swampName.Get(),
-				KeyValues: []*hydraidepbgo.KeyValuePair{
-					kvPair,
-				},
-				CreateIfNotExist: true,
-				Overwrite:        false,
-			},
+	setResponse, err := h.set(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		KeyValues: []*hydraidepbgo.KeyValuePair{
+			kvPair,
 		},
+		CreateIfNotExist: true,
+		Overwrite:        false,
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -869,6 +2119,7 @@ func (h *hydraidego) CatalogCreate(ctx context.Context, swampName name.Name, mod
 			if kv.GetStatus() == hydraidepbgo.Status_NOTHING_CHANGED {
 				return NewError(ErrCodeAlreadyExists, errorMessageKeyAlreadyExists)
 			}
+			setServerTimestampsToCatalogModel(kv, model)
 		}
 	}
 
@@ -888,7 +2139,7 @@ type CreateManyIteratorFunc func(key string, err error) error
 // ✅ Behavior:
 // - Creates the Swamp if it does not exist yet
 // - Converts each input model into a KeyValuePair using `convertCatalogModelToKeyValuePair()`
-// - Inserts all items in a single SetRequest
+// - Inserts all items in a single SetRequest, or in as many as needed to stay under the max message size
 // - Fails **only** if the gRPC call fails or if a model is invalid
 //
 // 📦 Model Requirements:
@@ -925,6 +2176,7 @@ type CreateManyIteratorFunc func(key string, err error) error
 // 🧯 Error Handling:
 // - If any model is invalid → `ErrCodeInvalidModel`
 // - If the entire gRPC Set call fails → appropriate connection or database error
+// - If a single model is larger than the max message size → `ErrCodeMessageTooLarge`
 // - If a key already exists → passed back through the iterator as `ErrCodeAlreadyExists`
 // - If no iterator is provided, duplicates are silently skipped
 //
@@ -938,27 +2190,25 @@ func (h *hydraidego) CatalogCreateMany(ctx context.Context, swampName name.Name,
 	kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, len(models))
 
 	for _, model := range models {
-		kvPair, err := convertCatalogModelToKeyValuePair(model)
+		kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 		if err != nil {
 			return NewError(ErrCodeInvalidModel, err.Error())
 		}
 		kvPairs = append(kvPairs, kvPair)
 	}
 
-	setResponse, err := h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
-		Swamps: []*hydraidepbgo.SwampRequest{
-			{
-				IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName:        swampName.Get(),
-				KeyValues:        kvPairs,
-				CreateIfNotExist: true,
-				Overwrite:        false,
-			},
-		},
+	setResponse, err := h.setInBatches(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:        swampName.Get(),
+		KeyValues:        kvPairs,
+		CreateIfNotExist: true,
+		Overwrite:        false,
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -1076,7 +2326,7 @@ func (h *hydraidego) CatalogCreateManyToMany(ctx context.Context, request []*Cat
 	for _, req := range request {
 
 		// lekérdezzük a szewrver adatait a swamp neve alapján
-		clientAndHost := h.client.GetServiceClientAndHost(req.SwampName)
+		clientAndHost := h.serviceClientAndHost(ctx, req.SwampName)
 
 		if _, ok := serverRequests[clientAndHost.Host]; !ok {
 			serverRequests[clientAndHost.Host] = &requestGroup{
@@ -1087,7 +2337,7 @@ func (h *hydraidego) CatalogCreateManyToMany(ctx context.Context, request []*Cat
 		kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, len(req.Models))
 
 		for _, model := range req.Models {
-			kvPair, err := convertCatalogModelToKeyValuePair(model)
+			kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 			if err != nil {
 				return NewError(ErrCodeInvalidModel, err.Error())
 			}
@@ -1111,7 +2361,9 @@ func (h *hydraidego) CatalogCreateManyToMany(ctx context.Context, request []*Cat
 		})
 
 		if err != nil {
-			if s, ok := status.FromError(err); ok {
+			if reasonErr, found := errorFromReason(err); found {
+				return reasonErr
+			} else if s, ok := status.FromError(err); ok {
 				switch s.Code() {
 				case codes.Unavailable:
 					return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -1172,11 +2424,17 @@ func (h *hydraidego) CatalogCreateManyToMany(ctx context.Context, request []*Cat
 //   - Optional: `hydraide:"value"`, `expireAt`, `createdBy`, etc.
 //
 // - Supports GOB-decoded slices, maps, pointers, and all primitive types
+// - Upgrades models stored with an older `hydraide:"version"` transparently (see RegisterMigration)
+// - Reads a value larger than the max message size in chunks transparently
+// - Reads only the parts of the Treasure the model has fields for (a model without value reads only the metadata)
 //
 // 📌 Notes:
 // - The model parameter must be a pointer to a struct
 // - Only one Treasure is expected — if none found, returns ErrCodeNotFound
 //
+// 💡 If the pattern of the Swamp is registered with `LazyHydration`, a closed Swamp is not hydrated, only the chunk
+// file of the key is read. See SwampFilesystemSettings.LazyHydration.
+//
 // 🔥 Ideal for:
 // - Real-time lookups
 // - Detail views
@@ -1191,18 +2449,25 @@ func (h *hydraidego) CatalogRead(ctx context.Context, swampName name.Name, key s
 
 	swamps := []*hydraidepbgo.GetSwamp{
 		{
-			IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-			SwampName: swampName.Get(),
-			Keys:      []string{key},
+			IslandID:   swampName.GetIslandID(h.client.GetAllIslands()),
+			SwampName:  swampName.Get(),
+			Keys:       []string{key},
+			Projection: catalogModelProjection(model),
 		},
 	}
 
-	response, err := h.client.GetServiceClient(swampName).Get(ctx, &hydraidepbgo.GetRequest{
+	response, err := h.serviceClient(ctx, swampName).Get(ctx, &hydraidepbgo.GetRequest{
 		Swamps: swamps,
 	})
 
 	if err != nil {
-		return errorHandler(err)
+		if sdkErr := errorHandler(err); !IsMessageTooLarge(sdkErr) {
+			return sdkErr
+		}
+		// the Treasure is too large for one message, so its value is read in chunks
+		if response, err = h.getLargeValue(ctx, swampName, key); err != nil {
+			return err
+		}
 	}
 
 	for _, swamp := range response.GetSwamps() {
@@ -1210,8 +2475,17 @@ func (h *hydraidego) CatalogRead(ctx context.Context, swampName name.Name, key s
 			if treasure.IsExist == false {
 				return NewError(ErrCodeNotFound, "key not found")
 			}
-			if convErr := convertProtoTreasureToCatalogModel(treasure, model); convErr != nil {
-				return NewError(ErrCodeInvalidModel, convErr.Error())
+			if convErr := convertProtoTreasureToCatalogModel(treasure, model, h.isStrictDecoding(ctx)); convErr != nil {
+				return decodeError(convErr)
+			}
+			// upgrade the model to the latest schema version if it was stored with an older one
+			migrated, writeBack, migrationErr := migrateModel(model)
+			if migrationErr != nil {
+				return NewError(ErrCodeInvalidModel, migrationErr.Error())
+			}
+			if migrated && writeBack {
+				_, saveErr := h.CatalogSave(ctx, swampName, model)
+				return saveErr
 			}
 			return nil
 		}
@@ -1247,7 +2521,14 @@ type CatalogReadManyIteratorFunc func(model any) error
 //
 // 📦 Behavior:
 //   - Internally calls Hydra’s `GetByIndex` gRPC method to fetch raw Treasures.
+//   - If `index.IndexType` is not set, the Treasures are streamed by the `GetAllStream` gRPC method in batches,
+//     in no particular order. Neither the server nor the client holds the whole Swamp in memory, so use it to
+//     process huge Swamps, e.g. for exports or migrations.
+//   - If `index.FilterExpr` is set, the server returns only the matching Treasures,
+//     and From/Limit are applied to the filtered result.
 //   - Skips non-existing (`IsExist == false`) entries silently.
+//   - Only the parts of the Treasures the model has fields for are sent by the server, so a model
+//     without a `hydraide:"value"` field reads only the metadata, without transferring the values.
 //   - For each result, creates a new instance of the model type, fills it from the Treasure,
 //     and passes it to `iterator`.
 //   - If `iterator` returns an error, iteration halts and the same error is returned.
@@ -1271,104 +2552,563 @@ func (h *hydraidego) CatalogReadMany(ctx context.Context, swampName name.Name, i
 		return NewError(ErrCodeInvalidArgument, "model cannot be a pointer")
 	}
 
-	// Convert index type and order into the proto format expected by the backend
-	indexTypeProtoFormat := convertIndexTypeToProtoIndexType(index.IndexType)
-	orderTypeProtoFormat := convertOrderTypeToProtoOrderType(index.IndexOrder)
-
-	// Fetch all matching Treasures from the Hydra engine based on the Index parameters
-	response, err := h.client.GetServiceClient(swampName).GetByIndex(ctx, &hydraidepbgo.GetByIndexRequest{
-		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-		SwampName: swampName.Get(),
-		IndexType: indexTypeProtoFormat,
-		OrderType: orderTypeProtoFormat,
-		From:      index.From,
-		Limit:     index.Limit,
-	})
-
-	if err != nil {
-		return errorHandler(err)
-	}
-
-	// Iterate through each returned Treasure and convert it into a usable model instance
-	for _, treasure := range response.GetTreasures() {
-
-		// Skip non-existent records
-		if treasure.IsExist == false {
-			continue
-		}
+	// Iterate through each read Treasure and convert it into a usable model instance
+	return h.readIndexedTreasures(ctx, swampName, index, false, catalogModelProjection(model), func(treasure *hydraidepbgo.Treasure) error {
 
 		// Create a fresh instance of the model (we clone the type, not the original value)
 		modelValue := reflect.New(reflect.TypeOf(model)).Interface()
 
 		// Unmarshal the Treasure into the model using the internal conversion logic
-		if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue); convErr != nil {
-			return NewError(ErrCodeInvalidModel, convErr.Error())
+		if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
 		}
 
 		// Pass the result to the user-provided iterator function
 		// If it returns an error, halt iteration and return the error
-		if iterErr := iterator(modelValue); iterErr != nil {
-			return iterErr
-		}
-	}
+		return iterator(modelValue)
 
-	// If we reached here, everything was successful
-	return nil
+	})
 }
 
-// CatalogUpdate updates a single existing Treasure inside a given Swamp.
-//
-// This method performs an *in-place update* based on the key derived from the provided model.
-// It will NOT create the Swamp or the key if they do not already exist.
-// If the Swamp or key is missing, a descriptive error will be returned.
+// readIndexedTreasures reads the existing Treasures selected by the Index, and calls the function with them one by one.
 //
-// ✅ Use when:
-//   - You want to overwrite an existing value in a Swamp
-//   - You already know the key exists and just want to update its content
-//
-// ⚠️ Constraints:
-//   - `model` must not be nil
-//   - `model` must implement a valid key via `hydrun:"key"`
-//   - The Swamp and key must already exist
-//
-// 🧠 Behavior:
-//   - Converts the model to a typed binary KeyValuePair
-//   - Sends an update (not insert) request to the Hydra engine
-//   - If the key or Swamp doesn’t exist, returns a clear error
-//
-// 🛠️ No creation. No upsert. Just pure update.
-func (h *hydraidego) CatalogUpdate(ctx context.Context, swampName name.Name, model any) error {
+// Without an index type the order of the Treasures does not matter, so they are streamed by the `GetAllStream` in
+// batches, and neither the server nor the client holds the whole Swamp in one message. Otherwise, they are read by
+// the `GetByIndex` in the order of the index. The errors of the function are returned as they are.
+func (h *hydraidego) readIndexedTreasures(ctx context.Context, swampName name.Name, index *Index, keysOnly bool, projection *hydraidepbgo.Projection, fn func(treasure *hydraidepbgo.Treasure) error) error {
 
-	// Ensure the model is provided
-	if model == nil {
-		return NewError(ErrCodeInvalidModel, "model is nil")
+	// the invalid filter expression is reported as invalid argument by the server
+	handleError := func(err error) error {
+		if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+			return NewError(ErrCodeInvalidArgument, s.Message())
+		}
+		return errorHandler(err)
 	}
 
-	// Convert the model into a typed key-value pair based on struct tags and reflection
-	kvPair, err := convertCatalogModelToKeyValuePair(model)
-	if err != nil {
-		return NewError(ErrCodeInvalidModel, err.Error())
+	var filterExpr *string
+	if index.FilterExpr != "" {
+		filterExpr = &index.FilterExpr
 	}
 
-	// Send a Set request to update the value in Hydra
-	// Note:
-	// - CreateIfNotExist = false → Swamp must already exist
-	// - Overwrite = true         → Overwrite existing key, but do NOT create new key
-	response, err := h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
-		Swamps: []*hydraidepbgo.SwampRequest{
-			{
-				IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName:        swampName.Get(),
-				KeyValues:        []*hydraidepbgo.KeyValuePair{kvPair},
-				CreateIfNotExist: false,
-				Overwrite:        true,
+	if index.IndexType == 0 {
+
+		// the stream is canceled if the function stops the iteration
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := h.serviceClient(ctx, swampName).GetAllStream(streamCtx, &hydraidepbgo.GetAllStreamRequest{
+			IslandID:   swampName.GetIslandID(h.client.GetAllIslands()),
+			SwampName:  swampName.Get(),
+			FilterExpr: filterExpr,
+			From:       index.From,
+			Limit:      index.Limit,
+			KeysOnly:   keysOnly,
+			Projection: projection,
+		})
+		if err != nil {
+			return handleError(err)
+		}
+
+		for {
+			response, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return handleError(err)
+			}
+			for _, treasure := range response.GetTreasures() {
+				if treasure.IsExist == false {
+					continue
+				}
+				if err := fn(treasure); err != nil {
+					return err
+				}
+			}
+		}
+
+	}
+
+	response, err := h.serviceClient(ctx, swampName).GetByIndex(ctx, &hydraidepbgo.GetByIndexRequest{
+		IslandID:   swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:  swampName.Get(),
+		IndexType:  convertIndexTypeToProtoIndexType(index.IndexType),
+		OrderType:  convertOrderTypeToProtoOrderType(index.IndexOrder),
+		From:       index.From,
+		Limit:      index.Limit,
+		FilterExpr: filterExpr,
+		KeysOnly:   keysOnly,
+		Projection: projection,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+
+	for _, treasure := range response.GetTreasures() {
+		// Skip non-existent records
+		if treasure.IsExist == false {
+			continue
+		}
+		if err := fn(treasure); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// KeyMetadata holds the metadata of a Treasure read by `CatalogReadManyKeys()`.
+//
+// The fields not set on the Treasure are left at their zero value.
+type KeyMetadata struct {
+	CreatedAt time.Time
+	CreatedBy string
+	UpdatedAt time.Time
+	UpdatedBy string
+	ExpiredAt time.Time
+}
+
+type CatalogReadManyKeysIteratorFunc func(key string, metadata *KeyMetadata) error
+
+// CatalogReadManyKeys reads only the keys and the metadata of the Treasures selected by the Index, without their values.
+//
+// It works the same way as `CatalogReadMany()`, but the server does not send the values of the Treasures, so
+// neither their transfer nor their deserialization has to be paid.
+//
+// ✅ Use when you want to:
+//   - Collect the keys to delete in a cleanup job
+//   - Check the age or the expiration of the Treasures without reading their content
+//
+// ⚙️ Parameters:
+//   - ctx: Context for cancellation and timeout.
+//   - swampName: The logical name of the Swamp to query.
+//   - index: A non-nil Index instance describing how to filter, order, and limit the read.
+//   - iterator: A non-nil function that is called once per key. Returning an error stops the loop.
+//
+// 📦 Behavior:
+//   - The index, the filter expression and the pagination work the same way as in `CatalogReadMany()`
+//   - The iterator gets the key and the metadata of the Treasure, in the order of the index
+//   - If `iterator` returns an error, iteration halts and the same error is returned.
+func (h *hydraidego) CatalogReadManyKeys(ctx context.Context, swampName name.Name, index *Index, iterator CatalogReadManyKeysIteratorFunc) error {
+
+	if index == nil {
+		return NewError(ErrCodeInvalidArgument, "index can not be nil")
+	}
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	return h.readIndexedTreasures(ctx, swampName, index, true, nil, func(treasure *hydraidepbgo.Treasure) error {
+
+		metadata := &KeyMetadata{
+			CreatedBy: treasure.GetCreatedBy(),
+			UpdatedBy: treasure.GetUpdatedBy(),
+		}
+		if treasure.CreatedAt != nil {
+			metadata.CreatedAt = treasure.GetCreatedAt().AsTime()
+		}
+		if treasure.UpdatedAt != nil {
+			metadata.UpdatedAt = treasure.GetUpdatedAt().AsTime()
+		}
+		if treasure.ExpiredAt != nil {
+			metadata.ExpiredAt = treasure.GetExpiredAt().AsTime()
+		}
+
+		return iterator(treasure.GetKey(), metadata)
+
+	})
+}
+
+// CatalogReadByValue reads all Treasures from a Swamp whose value equals the given value, and applies a callback to each.
+//
+// The lookup uses the secondary value index of the Swamp, so HydrAIDE does not scan the Swamp.
+// The index must be enabled with `ValueIndex: true` in the RegisterSwampRequest of the Swamp's pattern.
+//
+// ✅ Use when you want to:
+//   - Find the keys holding a given value (e.g. the user registered with an email address)
+//   - Query a catalog by state (e.g. all tasks with status "pending")
+//
+// ⚙️ Parameters:
+//   - ctx: Context for cancellation and timeout.
+//   - swampName: The logical name of the Swamp to query.
+//   - value: The value to look for. It is converted the same way as the `hydraide:"value"` field of a model,
+//     so it must have the same Go type as the stored value (an int8 never matches an int64).
+//   - model: A non-pointer struct type. Used as the template for unmarshaling Treasures.
+//   - iterator: A non-nil function that is called once per result. Returning an error stops the loop.
+//
+// 📦 Behavior:
+//   - Internally calls Hydra’s `GetByValue` gRPC method.
+//   - The results come in no particular order.
+//   - If no Treasure holds the value, the iterator is never called and the function returns nil.
+//
+// 🧯 Errors:
+//   - Value index is not enabled for the Swamp → `ErrCodeFailedPrecondition`
+//   - Swamp not found → `ErrCodeSwampNotFound`
+//   - Invalid value or model → `ErrCodeInvalidArgument` / `ErrCodeInvalidModel`
+func (h *hydraidego) CatalogReadByValue(ctx context.Context, swampName name.Name, value any, model any, iterator CatalogReadManyIteratorFunc) error {
+
+	// Validate required parameters
+	if value == nil {
+		return NewError(ErrCodeInvalidArgument, "value can not be nil")
+	}
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	// Ensure that the model is not a pointer type (we create new instances internally)
+	if reflect.TypeOf(model).Kind() == reflect.Ptr {
+		return NewError(ErrCodeInvalidArgument, "model cannot be a pointer")
+	}
+
+	// Convert the value into the typed proto format
+	kvPair := &hydraidepbgo.KeyValuePair{}
+	if convErr := convertFieldToKvPair(reflect.ValueOf(value), kvPair); convErr != nil {
+		return NewError(ErrCodeInvalidArgument, convErr.Error())
+	}
+
+	response, err := h.serviceClient(ctx, swampName).GetByValue(ctx, &hydraidepbgo.GetByValueRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Value:     kvPair,
+	})
+
+	if err != nil {
+		// the server uses FailedPrecondition both for the missing Swamp and for the missing value index
+		// the value index error is reported with the VALUE_INDEX_NOT_ENABLED reason, see errorFromReason
+		return errorHandler(err)
+	}
+
+	// Iterate through each returned Treasure and convert it into a usable model instance
+	for _, treasure := range response.GetTreasures() {
+
+		// Skip non-existent records
+		if treasure.IsExist == false {
+			continue
+		}
+
+		// Create a fresh instance of the model (we clone the type, not the original value)
+		modelValue := reflect.New(reflect.TypeOf(model)).Interface()
+
+		// Unmarshal the Treasure into the model using the internal conversion logic
+		if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
+		}
+
+		// Pass the result to the user-provided iterator function
+		if iterErr := iterator(modelValue); iterErr != nil {
+			return iterErr
+		}
+	}
+
+	return nil
+
+}
+
+// CatalogReadTopN reads the first N Treasures of an index, and applies a callback to each.
+//
+// This is the leaderboard query of HydrAIDE: the server reads the top N Treasures directly from its
+// in-memory index, so the answer costs O(N) of the result — no need to page through the index with
+// From/Limit from the client.
+//
+// ✅ Use when:
+//   - You need the top 100 scores, the 10 most expensive products, the 5 oldest jobs, etc.
+//
+// ⚙️ Behavior:
+//   - `IndexOrderDesc` returns the largest values first, `IndexOrderAsc` the smallest values first
+//   - For the value indexes (e.g. `IndexValueInt64`) only the Treasures holding the type of the index are returned
+//   - If the Swamp holds values of another type, the index can not be built → returns `ErrCodeFailedPrecondition`
+//   - If n is not greater than 0 → returns `ErrCodeInvalidArgument`
+//   - If the Swamp does not exist → returns `ErrCodeSwampNotFound`
+//
+// 🔧 Example:
+//
+//	err := h.CatalogReadTopN(ctx, swampName, hydraidego.IndexValueInt64, 100, hydraidego.IndexOrderDesc, Score{},
+//	    func(model any) error {
+//	        score := model.(*Score)
+//	        fmt.Println(score.PlayerID, score.Points)
+//	        return nil
+//	    })
+func (h *hydraidego) CatalogReadTopN(ctx context.Context, swampName name.Name, indexType IndexType, n int32, order IndexOrder, model any, iterator CatalogReadManyIteratorFunc) error {
+
+	if n <= 0 {
+		return NewError(ErrCodeInvalidArgument, "n must be greater than 0")
+	}
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	// Ensure that the model is not a pointer type (we create new instances internally)
+	if reflect.TypeOf(model).Kind() == reflect.Ptr {
+		return NewError(ErrCodeInvalidArgument, "model cannot be a pointer")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).GetTopN(ctx, &hydraidepbgo.GetTopNRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		IndexType: convertIndexTypeToProtoIndexType(indexType),
+		OrderType: convertOrderTypeToProtoOrderType(order),
+		N:         n,
+	})
+
+	if err != nil {
+		// the value type mismatch of the index is reported with the WRONG_VALUE_TYPE reason, see errorFromReason
+		return errorHandler(err)
+	}
+
+	// Iterate through each returned Treasure and convert it into a usable model instance
+	for _, treasure := range response.GetTreasures() {
+
+		// Skip non-existent records
+		if treasure.IsExist == false {
+			continue
+		}
+
+		// Create a fresh instance of the model (we clone the type, not the original value)
+		modelValue := reflect.New(reflect.TypeOf(model)).Interface()
+
+		// Unmarshal the Treasure into the model using the internal conversion logic
+		if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
+		}
+
+		// Pass the result to the user-provided iterator function
+		if iterErr := iterator(modelValue); iterErr != nil {
+			return iterErr
+		}
+	}
+
+	return nil
+
+}
+
+// ReferenceRequest describes the Treasures referenced by the IDs of a uint32 slice, for `CatalogReadByReference()`.
+type ReferenceRequest struct {
+	SliceSwampName  name.Name // the Swamp holding the uint32 slice of the IDs
+	SliceKey        string    // the key of the uint32 slice
+	TargetSwampName name.Name // the Catalog Swamp holding the referenced Treasures
+	TargetKeyPrefix string    // prepended to the decimal IDs to get the keys, e.g. "user-" references "user-42" by 42
+}
+
+// CatalogReadByReference reads the Treasures referenced by the IDs of a uint32 slice, and applies a callback to each.
+//
+// This is the join of HydrAIDE: a uint32 slice (e.g. the IDs of the products of a tag, maintained by
+// `Uint32SlicePush()`) is resolved to the Treasures of a Catalog Swamp, instead of reading the slice first
+// and then the Treasures one by one.
+//
+// ✅ Use when:
+//   - A reverse index holds the IDs of the records, and the records themselves are needed
+//
+// ⚙️ Behavior:
+//   - The key of the referenced Treasure is the TargetKeyPrefix followed by the decimal ID
+//   - The Treasures are passed to the iterator in the order of the IDs in the slice
+//   - The referenced Treasures that do not exist are skipped silently, the same as if the target Swamp does not exist
+//   - If the two Swamps are served by the same server, the references are resolved by the server in one roundtrip,
+//     otherwise the slice and the Treasures are read by two calls
+//   - Only the parts of the Treasures the model has fields for are read, the same as by `CatalogReadMany()`
+//
+// 🧯 Errors:
+//   - The Swamp of the slice does not exist → `ErrCodeSwampNotFound`
+//   - The slice does not exist → `ErrCodeNotFound`
+//   - The key does not hold a uint32 slice → `ErrCodeFailedPrecondition`
+//   - The request, its Swamp names or the iterator is nil, or the model is a pointer → `ErrCodeInvalidArgument`
+//
+// 🔧 Example:
+//
+//	err := h.CatalogReadByReference(ctx, &hydraidego.ReferenceRequest{
+//	    SliceSwampName:  name.New().Sanctuary("tags").Realm("products").Swamp("summer"),
+//	    SliceKey:        "product-ids",
+//	    TargetSwampName: name.New().Sanctuary("catalog").Realm("products").Swamp("all"),
+//	    TargetKeyPrefix: "product-",
+//	}, Product{}, func(model any) error {
+//	    fmt.Println(model.(*Product).Title)
+//	    return nil
+//	})
+func (h *hydraidego) CatalogReadByReference(ctx context.Context, request *ReferenceRequest, model any, iterator CatalogReadManyIteratorFunc) error {
+
+	if request == nil || request.SliceSwampName == nil || request.TargetSwampName == nil {
+		return NewError(ErrCodeInvalidArgument, "request and its swamp names can not be nil")
+	}
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	// Ensure that the model is not a pointer type (we create new instances internally)
+	if reflect.TypeOf(model).Kind() == reflect.Ptr {
+		return NewError(ErrCodeInvalidArgument, "model cannot be a pointer")
+	}
+
+	projection := catalogModelProjection(model)
+
+	sliceClient := h.serviceClientAndHost(ctx, request.SliceSwampName)
+	targetClient := h.serviceClientAndHost(ctx, request.TargetSwampName)
+
+	var treasures []*hydraidepbgo.Treasure
+	if sliceClient.Host == targetClient.Host {
+		// the server resolves the references in one roundtrip
+		response, err := sliceClient.GrpcClient.GetByReference(ctx, &hydraidepbgo.GetByReferenceRequest{
+			IslandID:        request.SliceSwampName.GetIslandID(h.client.GetAllIslands()),
+			SwampName:       request.SliceSwampName.Get(),
+			Key:             request.SliceKey,
+			TargetIslandID:  request.TargetSwampName.GetIslandID(h.client.GetAllIslands()),
+			TargetSwampName: request.TargetSwampName.Get(),
+			TargetKeyPrefix: request.TargetKeyPrefix,
+			Projection:      projection,
+		})
+		if err != nil {
+			return errorHandler(err)
+		}
+		treasures = response.GetTreasures()
+	} else {
+		var err error
+		if treasures, err = h.readReferences(ctx, request, sliceClient.GrpcClient, targetClient.GrpcClient, projection); err != nil {
+			return err
+		}
+	}
+
+	for _, treasure := range treasures {
+
+		// Skip the missing references
+		if treasure.IsExist == false {
+			continue
+		}
+
+		// Create a fresh instance of the model (we clone the type, not the original value)
+		modelValue := reflect.New(reflect.TypeOf(model)).Interface()
+
+		if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
+		}
+
+		if iterErr := iterator(modelValue); iterErr != nil {
+			return iterErr
+		}
+	}
+
+	return nil
+
+}
+
+// readReferences reads the uint32 slice and the referenced Treasures by two calls, if their Swamps are served by
+// different servers
+func (h *hydraidego) readReferences(ctx context.Context, request *ReferenceRequest, sliceClient hydraidepbgo.HydraideServiceClient,
+	targetClient hydraidepbgo.HydraideServiceClient, projection *hydraidepbgo.Projection) ([]*hydraidepbgo.Treasure, error) {
+
+	sliceResponse, err := sliceClient.Get(ctx, &hydraidepbgo.GetRequest{
+		Swamps: []*hydraidepbgo.GetSwamp{
+			{
+				IslandID:  request.SliceSwampName.GetIslandID(h.client.GetAllIslands()),
+				SwampName: request.SliceSwampName.Get(),
+				Keys:      []string{request.SliceKey},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errorHandler(err)
+	}
+
+	var ids []uint32
+	found := false
+	for _, swamp := range sliceResponse.GetSwamps() {
+		for _, treasure := range swamp.GetTreasures() {
+			if !treasure.IsExist {
+				continue
+			}
+			found = true
+			ids = treasure.GetUint32Slice()
+		}
+	}
+	if !found {
+		return nil, NewError(ErrCodeNotFound, "key not found")
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, request.TargetKeyPrefix+strconv.FormatUint(uint64(id), 10))
+	}
+
+	targetResponse, err := targetClient.Get(ctx, &hydraidepbgo.GetRequest{
+		Swamps: []*hydraidepbgo.GetSwamp{
+			{
+				IslandID:   request.TargetSwampName.GetIslandID(h.client.GetAllIslands()),
+				SwampName:  request.TargetSwampName.Get(),
+				Keys:       keys,
+				Projection: projection,
 			},
 		},
 	})
+	if err != nil {
+		sdkErr := errorHandler(err)
+		// the missing target Swamp means that all references are missing, the same as on the server
+		if IsSwampNotFound(sdkErr) {
+			return nil, nil
+		}
+		return nil, sdkErr
+	}
+
+	var treasures []*hydraidepbgo.Treasure
+	for _, swamp := range targetResponse.GetSwamps() {
+		treasures = append(treasures, swamp.GetTreasures()...)
+	}
+
+	return treasures, nil
+
+}
+
+// CatalogUpdate updates a single existing Treasure inside a given Swamp.
+//
+// This method performs an *in-place update* based on the key derived from the provided model.
+// It will NOT create the Swamp or the key if they do not already exist.
+// If the Swamp or key is missing, a descriptive error will be returned.
+//
+// ✅ Use when:
+//   - You want to overwrite an existing value in a Swamp
+//   - You already know the key exists and just want to update its content
+//
+// ⚠️ Constraints:
+//   - `model` must not be nil
+//   - `model` must implement a valid key via `hydrun:"key"`
+//   - The Swamp and key must already exist
+//
+// 🧠 Behavior:
+//   - Converts the model to a typed binary KeyValuePair
+//   - Sends an update (not insert) request to the Hydra engine
+//   - If the key or Swamp doesn’t exist, returns a clear error
+//
+// 🛠️ No creation. No upsert. Just pure update.
+func (h *hydraidego) CatalogUpdate(ctx context.Context, swampName name.Name, model any) error {
+
+	// Ensure the model is provided
+	if model == nil {
+		return NewError(ErrCodeInvalidModel, "model is nil")
+	}
+
+	// Convert the model into a typed key-value pair based on struct tags and reflection
+	kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
+	if err != nil {
+		return NewError(ErrCodeInvalidModel, err.Error())
+	}
+
+	// Send a Set request to update the value in Hydra
+	// Note:
+	// - CreateIfNotExist = false → Swamp must already exist
+	// - Overwrite = true         → Overwrite existing key, but do NOT create new key
+	response, err := h.set(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:        swampName.Get(),
+		KeyValues:        []*hydraidepbgo.KeyValuePair{kvPair},
+		CreateIfNotExist: false,
+		Overwrite:        true,
+	})
 
 	// Handle potential gRPC or Hydra-specific errors
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -1397,6 +3137,7 @@ func (h *hydraidego) CatalogUpdate(ctx context.Context, swampName name.Name, mod
 			if kStatus.GetStatus() == hydraidepbgo.Status_NOT_FOUND {
 				return NewError(ErrCodeNotFound, errorMessageKeyNotFound)
 			}
+			setServerTimestampsToCatalogModel(kStatus, model)
 		}
 	}
 
@@ -1404,6 +3145,111 @@ func (h *hydraidego) CatalogUpdate(ctx context.Context, swampName name.Name, mod
 	return nil
 }
 
+// catalogMutateLockTTL is the TTL of the lock of CatalogMutate if the context has no deadline
+const catalogMutateLockTTL = 30 * time.Second
+
+// CatalogMutateFunc receives the current state of the Treasure in CatalogMutate, and returns its new state.
+//
+//   - `current` is a pointer to a fresh model filled with the stored Treasure, or nil if the Treasure (or the whole
+//     Swamp) does not exist yet
+//   - Return a pointer to the model to save it. It may be the modified `current` itself.
+//   - Return nil to leave the Treasure untouched
+//   - Return an error to abort the mutation. The error is returned by CatalogMutate as it is.
+type CatalogMutateFunc func(current any) (any, error)
+
+// CatalogMutate performs a safe read-modify-write on a single Treasure.
+//
+// Reading a value, changing it in the client and saving it back is racy: two clients that read the same value at
+// the same time overwrite each other's changes. CatalogMutate serializes these flows per key, so the `mutate`
+// function always sees the latest saved state.
+//
+// ✅ Use when:
+//   - The new state depends on the current one (append to a list, merge settings, move a status forward)
+//   - The change is more complex than what the `Increment*` functions can express
+//
+// ⚙️ Behavior:
+//   - Acquires a business-level Lock on the Swamp name and the key, so all CatalogMutate calls of the same
+//     Treasure run one after another, from any client
+//   - Reads the Treasure into a fresh instance of the model and passes it to `mutate`
+//   - Saves the returned model with CatalogSave, then releases the lock
+//   - The lock TTL follows the deadline of the context (or 30 seconds without a deadline), so a crashed client
+//     does not block the key for long
+//
+// 📦 `model` must be a **non-pointer, empty struct instance**, used only for type inference, like
+// `ModelCatalogQueue{}`. The returned model must have the same key.
+//
+// ⚠️ Notes:
+//   - Only the other CatalogMutate calls are serialized. A plain CatalogSave or CatalogUpdate of the same key can
+//     still happen between the read and the write.
+//   - `mutate` runs while the lock is held, so keep it short and free of other slow calls
+//
+// Example:
+//
+//	err := h.CatalogMutate(ctx, swampName, "user-42", UserSettings{}, func(current any) (any, error) {
+//		settings, ok := current.(*UserSettings)
+//		if !ok {
+//			settings = &UserSettings{UserID: "user-42"}
+//		}
+//		settings.Theme = "dark"
+//		return settings, nil
+//	})
+func (h *hydraidego) CatalogMutate(ctx context.Context, swampName name.Name, key string, model any, mutate CatalogMutateFunc) error {
+
+	if mutate == nil {
+		return NewError(ErrCodeInvalidArgument, "mutate function can not be nil")
+	}
+
+	modelType := reflect.TypeOf(model)
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return NewError(ErrCodeInvalidModel, "model must be a non-pointer struct instance")
+	}
+
+	lockTTL := catalogMutateLockTTL
+	if deadline, ok := ctx.Deadline(); ok {
+		lockTTL = time.Until(deadline) + time.Second
+	}
+
+	lockKey := fmt.Sprintf("hydraide-mutate/%s/%s", swampName.Get(), key)
+	lockID, err := h.Lock(ctx, lockKey, lockTTL)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the unlock must run even if the context of the caller is already done
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = h.Unlock(unlockCtx, lockKey, lockID)
+	}()
+
+	var current any = reflect.New(modelType).Interface()
+	if readErr := h.CatalogRead(ctx, swampName, key, current); readErr != nil {
+		if !IsNotFound(readErr) && !IsSwampNotFound(readErr) {
+			return readErr
+		}
+		current = nil
+	}
+
+	next, err := mutate(current)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		return nil
+	}
+
+	kvPair, err := convertCatalogModelToKeyValuePair(ctx, next)
+	if err != nil {
+		return NewError(ErrCodeInvalidModel, err.Error())
+	}
+	if kvPair.GetKey() != key {
+		return NewError(ErrCodeInvalidArgument, fmt.Sprintf("the mutated model has a different key: %s", kvPair.GetKey()))
+	}
+
+	_, err = h.CatalogSave(ctx, swampName, next)
+	return err
+
+}
+
 type CatalogUpdateManyIteratorFunc func(key string, status EventStatus) error
 
 // CatalogUpdateMany updates multiple existing Treasures inside a single Swamp.
@@ -1427,7 +3273,8 @@ type CatalogUpdateManyIteratorFunc func(key string, status EventStatus) error
 //
 // 🧠 Behavior:
 //   - Converts each model to a binary KeyValuePair
-//   - Sends them in a single Set request with overwrite-only behavior
+//   - Sends them in a single Set request with overwrite-only behavior, split into more requests if the models are
+//     larger than the max message size together
 //   - Streams each key’s result status to the provided iterator
 //   - Iterator can early-return with error to abort processing
 func (h *hydraidego) CatalogUpdateMany(ctx context.Context, swampName name.Name, models []any, iterator CatalogUpdateManyIteratorFunc) error {
@@ -1440,7 +3287,7 @@ func (h *hydraidego) CatalogUpdateMany(ctx context.Context, swampName name.Name,
 	// Convert all models to KeyValuePair (binary form)
 	kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, len(models))
 	for _, model := range models {
-		kvPair, err := convertCatalogModelToKeyValuePair(model)
+		kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 		if err != nil {
 			return NewError(ErrCodeInvalidModel, err.Error())
 		}
@@ -1451,21 +3298,19 @@ func (h *hydraidego) CatalogUpdateMany(ctx context.Context, swampName name.Name,
 	// Note:
 	// - CreateIfNotExist = false → No new Swamps will be created
 	// - Overwrite = true         → Only update existing keys
-	response, err := h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
-		Swamps: []*hydraidepbgo.SwampRequest{
-			{
-				IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName:        swampName.Get(),
-				KeyValues:        kvPairs,
-				CreateIfNotExist: false,
-				Overwrite:        true,
-			},
-		},
+	response, err := h.setInBatches(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:        swampName.Get(),
+		KeyValues:        kvPairs,
+		CreateIfNotExist: false,
+		Overwrite:        true,
 	})
 
 	// Handle transport or protocol-level errors
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -1479,31 +3324,148 @@ func (h *hydraidego) CatalogUpdateMany(ctx context.Context, swampName name.Name,
 				return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
 			}
 		}
-		return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+		return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+	}
+
+	// If an iterator is provided, report status per key
+	if iterator != nil {
+		for _, swamp := range response.GetSwamps() {
+
+			// Report if the entire Swamp was not found
+			if swamp.GetErrorCode() == hydraidepbgo.SwampResponse_SwampDoesNotExist {
+				if iterErr := iterator("", StatusSwampNotFound); iterErr != nil {
+					return iterErr
+				}
+			}
+
+			// Report status per Treasure (key)
+			for _, kStatus := range swamp.GetKeysAndStatuses() {
+				stat := convertProtoStatusToStatus(kStatus.GetStatus())
+				if iterErr := iterator(kStatus.GetKey(), stat); iterErr != nil {
+					return iterErr
+				}
+			}
+		}
+	}
+
+	// All updates and iteration finished successfully
+	return nil
+}
+
+// CatalogUpdateManyToManyIteratorFunc is used to stream per-Treasure result feedback in CatalogUpdateManyToMany.
+//
+// Parameters:
+//   - `swampName`: The Swamp in which the key was updated
+//   - `key`: The unique identifier of the Treasure, empty if the whole Swamp was not found
+//   - `status`: The result of the operation (Modified, NothingChanged, TreasureNotFound, SwampNotFound)
+//
+// Returning an error aborts the entire update operation immediately.
+type CatalogUpdateManyToManyIteratorFunc func(swampName name.Name, key string, status EventStatus) error
+
+// CatalogUpdateManyToMany updates existing Treasures across multiple Swamps, distributed across servers.
+//
+// This is the multi-Swamp version of CatalogUpdateMany: it only overwrites the Treasures that already exist,
+// and it never creates new Treasures or Swamps.
+//
+// ✅ Use when:
+//   - You want to update many Treasures in many different Swamps in a single operation
+//   - You want to ensure that no new Treasures or Swamps are accidentally created
+//   - You want per-Treasure feedback using an iterator
+//
+// ⚙️ Behavior:
+//   - Each model is converted into a Treasure (KeyValuePair)
+//   - Swamps are grouped by their deterministic host (via name hashing)
+//   - Each server receives its subset of Swamps and executes a batch Set with overwrite-only behavior
+//   - Iterator (if provided) reports back key-level status with Swamp name context
+//
+// 🔁 Possible `EventStatus` values:
+//   - StatusModified
+//   - StatusNothingChanged
+//   - StatusTreasureNotFound → the key does not exist, nothing was created
+//   - StatusSwampNotFound → the whole Swamp does not exist, reported once with an empty key
+func (h *hydraidego) CatalogUpdateManyToMany(ctx context.Context, request []*CatalogManyToManyRequest, iterator CatalogUpdateManyToManyIteratorFunc) error {
+
+	type requestGroup struct {
+		client   hydraidepbgo.HydraideServiceClient
+		requests []*hydraidepbgo.SwampRequest
+	}
+
+	// Group requests by target Hydra server (based on SwampName hashing)
+	serverRequests := make(map[string]*requestGroup)
+	for _, req := range request {
+
+		kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, len(req.Models))
+
+		// Convert each model into a KeyValuePair
+		for _, model := range req.Models {
+			kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
+			if err != nil {
+				return NewError(ErrCodeInvalidModel, err.Error())
+			}
+			kvPairs = append(kvPairs, kvPair)
+		}
+
+		// Resolve which server should handle this Swamp
+		clientAndHost := h.serviceClientAndHost(ctx, req.SwampName)
+
+		// Initialize group for server if needed
+		if _, ok := serverRequests[clientAndHost.Host]; !ok {
+			serverRequests[clientAndHost.Host] = &requestGroup{
+				client: clientAndHost.GrpcClient,
+			}
+		}
+
+		// Note:
+		// - CreateIfNotExist = false → No new Swamps or Treasures will be created
+		// - Overwrite = true         → Only update existing keys
+		serverRequests[clientAndHost.Host].requests = append(serverRequests[clientAndHost.Host].requests, &hydraidepbgo.SwampRequest{
+			IslandID:         req.SwampName.GetIslandID(h.client.GetAllIslands()),
+			SwampName:        req.SwampName.Get(),
+			KeyValues:        kvPairs,
+			CreateIfNotExist: false,
+			Overwrite:        true,
+		})
+
 	}
 
-	// If an iterator is provided, report status per key
-	if iterator != nil {
-		for _, swamp := range response.GetSwamps() {
+	// Process requests grouped per server
+	for _, reqGroup := range serverRequests {
 
-			// Report if the entire Swamp was not found
-			if swamp.GetErrorCode() == hydraidepbgo.SwampResponse_SwampDoesNotExist {
-				if iterErr := iterator("", StatusSwampNotFound); iterErr != nil {
-					return iterErr
+		// Perform the batch Set operation for this server
+		setResponse, err := reqGroup.client.Set(ctx, &hydraidepbgo.SetRequest{
+			Swamps: reqGroup.requests,
+		})
+
+		if err != nil {
+			return errorHandler(err)
+		}
+
+		// Stream back statuses to the iterator, if one was provided
+		if iterator != nil {
+			for _, swamp := range setResponse.GetSwamps() {
+
+				// Restore the logical Swamp name from the response
+				swampNameObj := name.Load(swamp.GetSwampName())
+
+				// Report if the entire Swamp was not found
+				if swamp.GetErrorCode() == hydraidepbgo.SwampResponse_SwampDoesNotExist {
+					if iterErr := iterator(swampNameObj, "", StatusSwampNotFound); iterErr != nil {
+						return iterErr
+					}
+					continue
 				}
-			}
 
-			// Report status per Treasure (key)
-			for _, kStatus := range swamp.GetKeysAndStatuses() {
-				stat := convertProtoStatusToStatus(kStatus.GetStatus())
-				if iterErr := iterator(kStatus.GetKey(), stat); iterErr != nil {
-					return iterErr
+				// Iterate through each key's status and invoke the callback
+				for _, kv := range swamp.GetKeysAndStatuses() {
+					if iterErr := iterator(swampNameObj, kv.GetKey(), convertProtoStatusToStatus(kv.GetStatus())); iterErr != nil {
+						return iterErr
+					}
 				}
 			}
 		}
 	}
 
-	// All updates and iteration finished successfully
+	// All operations completed successfully
 	return nil
 }
 
@@ -1524,14 +3486,40 @@ func (h *hydraidego) CatalogUpdateMany(ctx context.Context, swampName name.Name,
 //
 // 💡 This is an idempotent operation: calling it on a non-existent key is safe, but results in error.
 func (h *hydraidego) CatalogDelete(ctx context.Context, swampName name.Name, key string) error {
+	return h.catalogDelete(ctx, swampName, key, false)
+}
+
+// CatalogShadowDelete removes a single Treasure from a given Swamp by key, but keeps it for auditing and restoring.
+//
+// The shadow-deleted Treasure is invisible for all reads, counts, indexes and queries, like a deleted one,
+// but the server keeps its content and metadata, also on the disk.
+//
+// ✅ Use when:
+//   - Deletions must be auditable (who had what, and when was it deleted)
+//   - Accidental deletions must be reversible with CatalogRestore
+//
+// ⚠️ Behavior:
+//   - If the Swamp does not exist → returns ErrCodeSwampNotFound
+//   - If the key does not exist   → returns ErrCodeNotFound
+//   - The Swamp is not removed while it has shadow-deleted Treasures, even if it has no live Treasures left
+//   - Saving a new Treasure with the same key drops the shadow-deleted version for good
+//
+// 💡 List the shadow-deleted Treasures with CatalogListDeleted.
+func (h *hydraidego) CatalogShadowDelete(ctx context.Context, swampName name.Name, key string) error {
+	return h.catalogDelete(ctx, swampName, key, true)
+}
+
+// catalogDelete deletes a single Treasure with a hard or a shadow delete
+func (h *hydraidego) catalogDelete(ctx context.Context, swampName name.Name, key string, shadowDelete bool) error {
 
 	// Send a delete request for the specified key inside the given Swamp
-	response, err := h.client.GetServiceClient(swampName).Delete(ctx, &hydraidepbgo.DeleteRequest{
+	response, err := h.serviceClient(ctx, swampName).Delete(ctx, &hydraidepbgo.DeleteRequest{
 		Swamps: []*hydraidepbgo.DeleteRequest_SwampKeys{
 			{
-				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName: swampName.Get(),
-				Keys:      []string{key},
+				IslandID:     swampName.GetIslandID(h.client.GetAllIslands()),
+				SwampName:    swampName.Get(),
+				Keys:         []string{key},
+				ShadowDelete: shadowDelete,
 			},
 		},
 	})
@@ -1568,6 +3556,166 @@ func (h *hydraidego) CatalogDelete(ctx context.Context, swampName name.Name, key
 	return NewError(ErrCodeUnknown, errorMessageUnknown)
 }
 
+// CatalogListDeletedIteratorFunc receives a shadow-deleted Treasure, converted to the model, and the time of its
+// deletion.
+type CatalogListDeletedIteratorFunc func(model any, deletedAt time.Time) error
+
+// CatalogListDeleted lists the shadow-deleted Treasures of a Swamp, ordered by their deletion time.
+//
+// Every Treasure is converted to a new instance of the model, with the content it had when it was deleted.
+//
+// ⚙️ Parameters:
+//   - model: A non-pointer struct type. Used as the template for unmarshaling Treasures.
+//   - iterator: Called once per shadow-deleted Treasure. Returning an error stops the loop.
+//
+// 🧯 Errors:
+//   - Swamp not found → `ErrCodeSwampNotFound`
+//   - Invalid model or conversion error → `ErrCodeInvalidModel`
+func (h *hydraidego) CatalogListDeleted(ctx context.Context, swampName name.Name, model any, iterator CatalogListDeletedIteratorFunc) error {
+
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).ListDeleted(ctx, &hydraidepbgo.ListDeletedRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	for _, treasure := range response.GetTreasures() {
+
+		// Create a fresh instance of the model (we clone the type, not the original value)
+		modelValue := reflect.New(reflect.TypeOf(model)).Interface()
+		if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
+		}
+
+		if iterErr := iterator(modelValue, treasure.GetDeletedAt().AsTime()); iterErr != nil {
+			return iterErr
+		}
+
+	}
+
+	return nil
+
+}
+
+// CatalogRestore puts a shadow-deleted Treasure back to its Swamp, with the content it had before the deletion.
+//
+// The subscribers of the Swamp receive the restored Treasure as a new Treasure.
+//
+// 🧯 Errors:
+//   - Swamp not found → `ErrCodeSwampNotFound`
+//   - No shadow-deleted Treasure with the key → `ErrCodeNotFound`
+func (h *hydraidego) CatalogRestore(ctx context.Context, swampName name.Name, key string) error {
+
+	response, err := h.serviceClient(ctx, swampName).Restore(ctx, &hydraidepbgo.RestoreRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Keys:      []string{key},
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	for _, keyStatus := range response.GetKeyStatuses() {
+		if keyStatus.GetStatus() == hydraidepbgo.Status_NEW {
+			return nil
+		}
+	}
+
+	return NewError(ErrCodeNotFound, errorMessageKeyNotFound)
+
+}
+
+// CatalogHistoryIteratorFunc receives a version of a Treasure, converted to the model, with the number of the
+// version, the time of the save and the creator or the modifier of the version.
+type CatalogHistoryIteratorFunc func(model any, version uint64, updatedAt time.Time, updatedBy string) error
+
+// CatalogHistory lists the stored versions of a Treasure, from the oldest to the newest.
+//
+// The versions are kept only if the Swamp pattern is registered with HistoryDepth. The last version is the current
+// content of the Treasure.
+//
+// ⚙️ Parameters:
+//   - key: The key of the Treasure.
+//   - model: A non-pointer struct type. Used as the template for unmarshaling the versions.
+//   - iterator: Called once per version. Returning an error stops the loop.
+//
+// 🧯 Errors:
+//   - Swamp not found → `ErrCodeSwampNotFound`
+//   - Key not found → `ErrCodeNotFound`
+//   - Invalid model or conversion error → `ErrCodeInvalidModel`
+func (h *hydraidego) CatalogHistory(ctx context.Context, swampName name.Name, key string, model any, iterator CatalogHistoryIteratorFunc) error {
+
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).GetHistory(ctx, &hydraidepbgo.GetHistoryRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	for _, version := range response.GetVersions() {
+
+		// Create a fresh instance of the model (we clone the type, not the original value)
+		modelValue := reflect.New(reflect.TypeOf(model)).Interface()
+		if convErr := convertProtoTreasureToCatalogModel(version, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
+		}
+
+		if iterErr := iterator(modelValue, version.GetVersion(), version.GetUpdatedAt().AsTime(), version.GetUpdatedBy()); iterErr != nil {
+			return iterErr
+		}
+
+	}
+
+	return nil
+
+}
+
+// CatalogRevertTo sets the content of a Treasure back to a version listed by CatalogHistory.
+//
+// The revert is saved as a new version, so the newer versions stay in the history, and the revert itself can be
+// reverted. The subscribers of the Swamp receive the reverted Treasure as a modified Treasure.
+//
+// ⚙️ Parameters:
+//   - key: The key of the Treasure.
+//   - version: The number of the version, as received by the CatalogHistory iterator.
+//   - updatedBy: Stored as the `updatedBy` metadata of the Treasure. Optional, leave it empty to keep the current,
+//     or to use the actor of the context set by WithActor.
+//
+// 🧯 Errors:
+//   - Swamp not found → `ErrCodeSwampNotFound`
+//   - Key or version not found → `ErrCodeNotFound`
+func (h *hydraidego) CatalogRevertTo(ctx context.Context, swampName name.Name, key string, version uint64, updatedBy string) error {
+
+	request := &hydraidepbgo.RevertToRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Version:   version,
+	}
+	if updatedBy = actorOrDefault(ctx, updatedBy); updatedBy != "" {
+		request.UpdatedBy = &updatedBy
+	}
+
+	if _, err := h.serviceClient(ctx, swampName).RevertTo(ctx, request); err != nil {
+		return errorHandler(err)
+	}
+
+	return nil
+
+}
+
 type CatalogDeleteIteratorFunc func(key string, err error) error
 
 // CatalogDeleteMany removes multiple Treasures from a single Swamp by key.
@@ -1593,7 +3741,7 @@ type CatalogDeleteIteratorFunc func(key string, err error) error
 func (h *hydraidego) CatalogDeleteMany(ctx context.Context, swampName name.Name, keys []string, iterator CatalogDeleteIteratorFunc) error {
 
 	// Send a bulk delete request to Hydra for all specified keys
-	response, err := h.client.GetServiceClient(swampName).Delete(ctx, &hydraidepbgo.DeleteRequest{
+	response, err := h.serviceClient(ctx, swampName).Delete(ctx, &hydraidepbgo.DeleteRequest{
 		Swamps: []*hydraidepbgo.DeleteRequest_SwampKeys{
 			{
 				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
@@ -1679,7 +3827,7 @@ func (h *hydraidego) CatalogDeleteManyFromMany(ctx context.Context, request []*C
 	for _, req := range request {
 
 		// Determine which server hosts the given Swamp (based on its name)
-		clientAndHost := h.client.GetServiceClientAndHost(req.SwampName)
+		clientAndHost := h.serviceClientAndHost(ctx, req.SwampName)
 
 		// Initialize group for this server if needed
 		if _, ok := serverRequests[clientAndHost.Host]; !ok {
@@ -1776,10 +3924,13 @@ func (h *hydraidego) CatalogDeleteManyFromMany(ctx context.Context, request []*C
 //
 // 💡 This function is preferred for cases where you don’t want to check existence beforehand.
 // It is atomic, clean, and supports real-time reactive updates.
+//
+// 📦 A struct or []byte value larger than the max message size of the client is sent in chunks, and the server
+// reassembles it. CatalogCreate and CatalogUpdate do the same, and CatalogRead reads such a value in chunks.
 func (h *hydraidego) CatalogSave(ctx context.Context, swampName name.Name, model any) (eventStatus EventStatus, err error) {
 
 	// Convert the model into a KeyValuePair (binary format) using reflection + hydrun tags
-	kvPair, err := convertCatalogModelToKeyValuePair(model)
+	kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 	if err != nil {
 		return StatusUnknown, NewError(ErrCodeInvalidModel, err.Error())
 	}
@@ -1787,20 +3938,18 @@ func (h *hydraidego) CatalogSave(ctx context.Context, swampName name.Name, model
 	// Perform the Set operation with full upsert behavior:
 	// - CreateIfNotExist = true → will create Swamp if needed
 	// - Overwrite = true        → will update key if it exists
-	setResponse, err := h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
-		Swamps: []*hydraidepbgo.SwampRequest{
-			{
-				IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName:        swampName.Get(),
-				KeyValues:        []*hydraidepbgo.KeyValuePair{kvPair},
-				CreateIfNotExist: true,
-				Overwrite:        true,
-			},
-		},
+	setResponse, err := h.set(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:        swampName.Get(),
+		KeyValues:        []*hydraidepbgo.KeyValuePair{kvPair},
+		CreateIfNotExist: true,
+		Overwrite:        true,
 	})
 	if err != nil {
 		// Translate gRPC or Hydra-specific error into user-friendly error
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return StatusUnknown, reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return StatusUnknown, NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -1822,6 +3971,7 @@ func (h *hydraidego) CatalogSave(ctx context.Context, swampName name.Name, model
 	// (We only sent one key, so only one result is expected)
 	for _, swamp := range setResponse.GetSwamps() {
 		for _, kv := range swamp.GetKeysAndStatuses() {
+			setServerTimestampsToCatalogModel(kv, model)
 			// Translate the proto response status into our local EventStatus enum
 			return convertProtoStatusToStatus(kv.GetStatus()), nil
 		}
@@ -1855,6 +4005,10 @@ type CatalogSaveManyIteratorFunc func(key string, status EventStatus) error
 //   - If a key does not exist     → it will be created
 //   - If a key exists             → it will be updated or left untouched (if identical)
 //   - `iterator` (optional) will be called for each key with its EventStatus
+//   - If the models are larger than the max message size of the client together, they are sent in more requests,
+//     and a single model larger than the limit fails with `ErrCodeMessageTooLarge`
+//   - If a model is invalid, nothing is saved, and the `ErrCodeInvalidModel` error names the index and the key of
+//     the model. Use `CatalogTrySaveMany()` to save the valid models and get the errors of the invalid ones.
 //
 // 🔁 Possible statuses per key (via iterator):
 //   - StatusNew
@@ -1866,10 +4020,10 @@ func (h *hydraidego) CatalogSaveMany(ctx context.Context, swampName name.Name, m
 
 	// Convert all provided models into KeyValuePair slices
 	kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, len(models))
-	for _, model := range models {
-		kvPair, err := convertCatalogModelToKeyValuePair(model)
+	for i, model := range models {
+		kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 		if err != nil {
-			return NewError(ErrCodeInvalidModel, err.Error())
+			return invalidModelError(i, model, err)
 		}
 		kvPairs = append(kvPairs, kvPair)
 	}
@@ -1877,21 +4031,19 @@ func (h *hydraidego) CatalogSaveMany(ctx context.Context, swampName name.Name, m
 	// Send a Set request with upsert semantics:
 	// - CreateIfNotExist = true → creates Swamp if needed
 	// - Overwrite = true        → updates keys if they exist
-	setResponse, err := h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
-		Swamps: []*hydraidepbgo.SwampRequest{
-			{
-				IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName:        swampName.Get(),
-				KeyValues:        kvPairs,
-				CreateIfNotExist: true,
-				Overwrite:        true,
-			},
-		},
+	setResponse, err := h.setInBatches(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:        swampName.Get(),
+		KeyValues:        kvPairs,
+		CreateIfNotExist: true,
+		Overwrite:        true,
 	})
 
 	// Handle gRPC or internal errors with detailed messages
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -1909,6 +4061,17 @@ func (h *hydraidego) CatalogSaveMany(ctx context.Context, swampName name.Name, m
 		return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
 	}
 
+	// the statuses are in the order of the models, also if the models were sent in more batches
+	var keysAndStatuses []*hydraidepbgo.KeyStatusPair
+	for _, swamp := range setResponse.GetSwamps() {
+		keysAndStatuses = append(keysAndStatuses, swamp.GetKeysAndStatuses()...)
+	}
+	if len(keysAndStatuses) == len(models) {
+		for i, kv := range keysAndStatuses {
+			setServerTimestampsToCatalogModel(kv, models[i])
+		}
+	}
+
 	// Process response and trigger iterator if defined
 	if iterator != nil {
 		for _, swamp := range setResponse.GetSwamps() {
@@ -1926,6 +4089,134 @@ func (h *hydraidego) CatalogSaveMany(ctx context.Context, swampName name.Name, m
 	return nil
 }
 
+// CatalogTrySaveManyIteratorFunc is a callback used by CatalogTrySaveMany.
+//
+// It is invoked for every model, in the order of the models, with:
+//   - `index`: The index of the model in the models slice
+//   - `key`: The key of the model, empty if the model has no key
+//   - `status`: The result status (New, Modified, NothingChanged), or StatusUnknown if the model was not saved
+//   - `err`: nil if the model was saved, or the reason it was not, where IsInvalidModel(err) is true
+//
+// Returning an error will immediately halt the iteration. The valid models are already saved at this point.
+type CatalogTrySaveManyIteratorFunc func(index int, key string, status EventStatus, err error) error
+
+// CatalogTrySaveMany stores or updates the valid models of a batch in a single Swamp, and reports the invalid ones.
+//
+// It works like `CatalogSaveMany()`, but an invalid model (e.g. an empty key or an unsupported value type) does not
+// abort the whole batch. All models are validated first, the valid ones are saved, and the iterator gets the result
+// of every model with its index, so the caller knows exactly which models to fix.
+//
+// ✅ Use when:
+//   - You import data from an external source, where some records may be broken
+//   - You want to save as much of a batch as possible, and retry or log the rest
+//
+// ⚙️ Behavior:
+//   - If the Swamp does not exist → it will be created, if at least one model is valid
+//   - The valid models are saved exactly like by `CatalogSaveMany()`
+//   - `iterator` is called for every model after the save, in the order of the models
+//   - Without an iterator, the error lists the number of the invalid models and the first one, after the valid
+//     models are saved
+//   - A failed request (e.g. a connection error) returns the error, and the iterator is not called
+//
+// 🔁 Possible results per model (via iterator):
+//   - StatusNew, StatusModified, StatusNothingChanged with a nil error
+//   - StatusUnknown with an error, where IsInvalidModel(err) is true
+func (h *hydraidego) CatalogTrySaveMany(ctx context.Context, swampName name.Name, models []any, iterator CatalogTrySaveManyIteratorFunc) error {
+
+	// validate all models before the save, and remember the index of the valid ones
+	kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, len(models))
+	validModels := make([]int, 0, len(models))
+	modelErrors := make(map[int]error)
+	var firstInvalid error
+	for i, model := range models {
+		kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
+		if err != nil {
+			modelErrors[i] = invalidModelError(i, model, err)
+			if firstInvalid == nil {
+				firstInvalid = modelErrors[i]
+			}
+			continue
+		}
+		kvPairs = append(kvPairs, kvPair)
+		validModels = append(validModels, i)
+	}
+
+	statuses := make(map[int]EventStatus, len(validModels))
+	if len(kvPairs) > 0 {
+
+		setResponse, err := h.setInBatches(ctx, swampName, &hydraidepbgo.SwampRequest{
+			IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+			SwampName:        swampName.Get(),
+			KeyValues:        kvPairs,
+			CreateIfNotExist: true,
+			Overwrite:        true,
+		})
+		if err != nil {
+			return errorHandler(err)
+		}
+
+		// the statuses are in the order of the valid models, also if the models were sent in more batches
+		var keysAndStatuses []*hydraidepbgo.KeyStatusPair
+		for _, swamp := range setResponse.GetSwamps() {
+			keysAndStatuses = append(keysAndStatuses, swamp.GetKeysAndStatuses()...)
+		}
+		if len(keysAndStatuses) == len(validModels) {
+			for i, kv := range keysAndStatuses {
+				setServerTimestampsToCatalogModel(kv, models[validModels[i]])
+				statuses[validModels[i]] = convertProtoStatusToStatus(kv.GetStatus())
+			}
+		}
+
+	}
+
+	if iterator == nil {
+		if firstInvalid != nil {
+			return NewError(ErrCodeInvalidModel, fmt.Sprintf("%d of %d models are not saved, the first: %s", len(modelErrors), len(models), GetErrorMessage(firstInvalid)))
+		}
+		return nil
+	}
+
+	for i, model := range models {
+		status, ok := statuses[i]
+		if !ok {
+			status = StatusUnknown
+		}
+		if iterErr := iterator(i, catalogModelKey(model), status, modelErrors[i]); iterErr != nil {
+			return iterErr
+		}
+	}
+
+	return nil
+
+}
+
+// invalidModelError returns the ErrCodeInvalidModel error of the model at the index of a batch
+func invalidModelError(index int, model any, err error) error {
+	if key := catalogModelKey(model); key != "" {
+		return NewError(ErrCodeInvalidModel, fmt.Sprintf("the model at index %d with the key %q is invalid: %v", index, key, err))
+	}
+	return NewError(ErrCodeInvalidModel, fmt.Sprintf("the model at index %d is invalid: %v", index, err))
+}
+
+// catalogModelKey returns the value of the key field of the model, or an empty string if the model has no key
+func catalogModelKey(model any) string {
+
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup(tagHydrAIDE); ok && tag == tagKey && t.Field(i).Type.Kind() == reflect.String {
+			return v.Elem().Field(i).String()
+		}
+	}
+
+	return ""
+
+}
+
 // CatalogSaveManyToManyIteratorFunc is used to stream per-Treasure result feedback in CatalogSaveManyToMany.
 //
 // Parameters:
@@ -1976,7 +4267,7 @@ func (h *hydraidego) CatalogSaveManyToMany(ctx context.Context, request []*Catal
 
 		// Convert each model into a KeyValuePair
 		for _, model := range req.Models {
-			kvPair, err := convertCatalogModelToKeyValuePair(model)
+			kvPair, err := convertCatalogModelToKeyValuePair(ctx, model)
 			if err != nil {
 				return NewError(ErrCodeInvalidModel, err.Error())
 			}
@@ -2006,7 +4297,7 @@ func (h *hydraidego) CatalogSaveManyToMany(ctx context.Context, request []*Catal
 	for _, sw := range swamps {
 
 		// Resolve which server should handle this Swamp
-		clientAndHost := h.client.GetServiceClientAndHost(sw.swampName)
+		clientAndHost := h.serviceClientAndHost(ctx, sw.swampName)
 
 		// Initialize group for server if needed
 		if _, ok := serverRequests[clientAndHost.Host]; !ok {
@@ -2029,7 +4320,9 @@ func (h *hydraidego) CatalogSaveManyToMany(ctx context.Context, request []*Catal
 
 		if err != nil {
 			// Map gRPC-level errors to internal codes
-			if s, ok := status.FromError(err); ok {
+			if reasonErr, found := errorFromReason(err); found {
+				return reasonErr
+			} else if s, ok := status.FromError(err); ok {
 				switch s.Code() {
 				case codes.Unavailable:
 					return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -2124,7 +4417,7 @@ type CatalogShiftExpiredIteratorFunc func(model any) error
 func (h *hydraidego) CatalogShiftExpired(ctx context.Context, swampName name.Name, howMany int32, model any, iterator CatalogShiftExpiredIteratorFunc) error {
 
 	// send a ShiftExpiredTreasures request to the HydrAIDE service
-	response, err := h.client.GetServiceClient(swampName).ShiftExpiredTreasures(ctx, &hydraidepbgo.ShiftExpiredTreasuresRequest{
+	response, err := h.serviceClient(ctx, swampName).ShiftExpiredTreasures(ctx, &hydraidepbgo.ShiftExpiredTreasuresRequest{
 		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName: swampName.Get(),
 		HowMany:   howMany,
@@ -2133,7 +4426,9 @@ func (h *hydraidego) CatalogShiftExpired(ctx context.Context, swampName name.Nam
 	// Handle gRPC or internal errors with detailed messages
 	if err != nil {
 
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -2166,8 +4461,8 @@ func (h *hydraidego) CatalogShiftExpired(ctx context.Context, swampName name.Nam
 			modelValue := reflect.New(reflect.TypeOf(model)).Interface()
 
 			// Unmarshal the Treasure into the model using the internal conversion logic
-			if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue); convErr != nil {
-				return NewError(ErrCodeInvalidModel, convErr.Error())
+			if convErr := convertProtoTreasureToCatalogModel(treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+				return decodeError(convErr)
 			}
 
 			// Pass the result to the user-provided iterator function
@@ -2183,6 +4478,204 @@ func (h *hydraidego) CatalogShiftExpired(ctx context.Context, swampName name.Nam
 
 }
 
+// Lease is the lease of a Treasure taken by CatalogShiftExpiredWithLease.
+//
+// The lease must be ended with CatalogAck after a successful processing, or with CatalogNack after a failure.
+type Lease struct {
+	// SwampName is the Swamp of the leased Treasure
+	SwampName name.Name
+	// Key is the key of the leased Treasure
+	Key string
+	// ID identifies the lease at the server
+	ID int64
+	// Deliveries is how many times the Treasure was leased, including this lease
+	Deliveries int32
+
+	// the treasure and the model type at the time of the lease, for the move to the dead-letter Swamp
+	treasure  *hydraidepbgo.Treasure
+	modelType reflect.Type
+}
+
+// NackOptions controls what happens with a Treasure given back by CatalogNack.
+type NackOptions struct {
+	// RetryAfter is how long the Treasure stays hidden before it can be leased again. 0 means immediately.
+	RetryAfter time.Duration
+	// MaxDeliveries is the number of the deliveries after which the Treasure is moved to the DeadLetterSwamp
+	// instead of retrying it again. 0 means no limit.
+	MaxDeliveries int32
+	// DeadLetterSwamp is the Swamp where the Treasures are moved after MaxDeliveries failed deliveries.
+	// The Swamp pattern must be registered like any other Swamp.
+	DeadLetterSwamp name.Name
+}
+
+// CatalogShiftExpiredWithLeaseIteratorFunc receives the leased Treasures of CatalogShiftExpiredWithLease one by one.
+//
+// Returning an error stops the iteration, but the Treasures already leased stay hidden until their lease expires.
+type CatalogShiftExpiredWithLeaseIteratorFunc func(model any, lease *Lease) error
+
+// CatalogShiftExpiredWithLease takes expired Treasures from a queue-style Swamp like CatalogShiftExpired, but it does
+// not delete them: it hides them for the `leaseTTL` until the consumer acknowledges them.
+//
+// CatalogShiftExpired pops the items destructively, so if the worker crashes in the middle of the processing, the
+// item is gone. With the lease, the item comes back when the lease expires, so it is processed at least once.
+//
+// ✅ Use when:
+//   - Losing an item of the queue is not acceptable
+//   - You want to retry the failed items later, and park the repeatedly failing ones in a dead-letter Swamp
+//
+// ⚙️ Behavior:
+//   - Leases up to `howMany` expired Treasures (0 means all), ordered by expiry time
+//   - The leased Treasures are hidden for the `leaseTTL`: their `expireAt` is moved to the end of the lease
+//   - Every Treasure is passed to the iterator with its *Lease
+//   - After the processing, call:
+//   - `CatalogAck(ctx, lease)` → the Treasure is deleted
+//   - `CatalogNack(ctx, lease, options)` → the Treasure is retried later, or moved to the dead-letter Swamp
+//   - If neither is called before the `leaseTTL`, the Treasure becomes expired again, and the next call leases it
+//     again with a higher `Deliveries` count
+//
+// 📦 `model` must be a **non-pointer, empty struct instance**, like at CatalogShiftExpired.
+//
+// ⚠️ Notes:
+//   - Choose a `leaseTTL` longer than the processing time, otherwise an other worker can lease the same item
+//   - The delivery counters live in the memory of the server, they start from zero after a server restart
+//   - Every lease modifies the `expireAt` of the Treasure, so the subscribers of the Swamp receive an update event
+func (h *hydraidego) CatalogShiftExpiredWithLease(ctx context.Context, swampName name.Name, howMany int32, leaseTTL time.Duration, model any, iterator CatalogShiftExpiredWithLeaseIteratorFunc) error {
+
+	if leaseTTL < time.Millisecond {
+		return NewError(ErrCodeInvalidArgument, "leaseTTL must be at least one millisecond")
+	}
+
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).LeaseExpiredTreasures(ctx, &hydraidepbgo.LeaseExpiredTreasuresRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		HowMany:   howMany,
+		LeaseTime: leaseTTL.Milliseconds(),
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	modelType := reflect.TypeOf(model)
+	for _, leased := range response.GetTreasures() {
+
+		// Create a fresh instance of the model (we clone the type, not the original value)
+		modelValue := reflect.New(modelType).Interface()
+
+		// Unmarshal the Treasure into the model using the internal conversion logic
+		if convErr := convertProtoTreasureToCatalogModel(leased.GetTreasure(), modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return decodeError(convErr)
+		}
+
+		lease := &Lease{
+			SwampName:  swampName,
+			Key:        leased.GetTreasure().GetKey(),
+			ID:         leased.GetLeaseID(),
+			Deliveries: leased.GetDeliveries(),
+			treasure:   leased.GetTreasure(),
+			modelType:  modelType,
+		}
+
+		if iterErr := iterator(modelValue, lease); iterErr != nil {
+			return iterErr
+		}
+
+	}
+
+	return nil
+
+}
+
+// CatalogAck acknowledges the successful processing of a leased Treasure, and deletes it from its Swamp.
+//
+// Returns an error with `IsLeaseNotFound(err) == true` if the lease expired and the Treasure was leased again by
+// an other consumer, or the Treasure was deleted meanwhile.
+func (h *hydraidego) CatalogAck(ctx context.Context, lease *Lease) error {
+
+	if lease == nil {
+		return NewError(ErrCodeInvalidArgument, "lease can not be nil")
+	}
+
+	_, err := h.serviceClient(ctx, lease.SwampName).AckLease(ctx, &hydraidepbgo.AckLeaseRequest{
+		IslandID:  lease.SwampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: lease.SwampName.Get(),
+		Key:       lease.Key,
+		LeaseID:   lease.ID,
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	return nil
+
+}
+
+// CatalogNack gives back a leased Treasure after a failed processing.
+//
+// ⚙️ Behavior:
+//   - If the Treasure reached the `MaxDeliveries` and a `DeadLetterSwamp` is set, the Treasure is saved to the
+//     dead-letter Swamp with the same key and content, then deleted from its Swamp, and deadLettered is true
+//   - Otherwise the Treasure becomes visible again after the `RetryAfter` time, and the next lease counts a new
+//     delivery
+//   - nil options means immediate retry without dead-letter Swamp
+//
+// ⚠️ The dead-letter Swamp can live on an other server, so the move is not atomic. If the client crashes between
+// the save and the delete, the Treasure is delivered again, and it is saved to the dead-letter Swamp again with
+// the same key, so it is never lost or duplicated there.
+//
+// Returns an error with `IsLeaseNotFound(err) == true` like CatalogAck.
+func (h *hydraidego) CatalogNack(ctx context.Context, lease *Lease, options *NackOptions) (deadLettered bool, err error) {
+
+	if lease == nil {
+		return false, NewError(ErrCodeInvalidArgument, "lease can not be nil")
+	}
+
+	if options == nil {
+		options = &NackOptions{}
+	}
+
+	if options.RetryAfter < 0 {
+		return false, NewError(ErrCodeInvalidArgument, "RetryAfter can not be negative")
+	}
+
+	// move the treasure to the dead-letter swamp, then delete it from the queue
+	if options.DeadLetterSwamp != nil && options.MaxDeliveries > 0 && lease.Deliveries >= options.MaxDeliveries {
+
+		modelValue := reflect.New(lease.modelType).Interface()
+		if convErr := convertProtoTreasureToCatalogModel(lease.treasure, modelValue, h.isStrictDecoding(ctx)); convErr != nil {
+			return false, decodeError(convErr)
+		}
+
+		if _, saveErr := h.CatalogSave(ctx, options.DeadLetterSwamp, modelValue); saveErr != nil {
+			return false, saveErr
+		}
+
+		if ackErr := h.CatalogAck(ctx, lease); ackErr != nil {
+			return false, ackErr
+		}
+
+		return true, nil
+
+	}
+
+	_, err = h.serviceClient(ctx, lease.SwampName).NackLease(ctx, &hydraidepbgo.NackLeaseRequest{
+		IslandID:   lease.SwampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:  lease.SwampName.Get(),
+		Key:        lease.Key,
+		LeaseID:    lease.ID,
+		RetryAfter: options.RetryAfter.Milliseconds(),
+	})
+	if err != nil {
+		return false, errorHandler(err)
+	}
+
+	return false, nil
+
+}
+
 // ProfileSave stores a full profile-like struct in the given Swamp as a set of key-value pairs.
 //
 // Unlike the Catalog-based Save methods (which use a single key per record), ProfileSave decomposes
@@ -2212,7 +4705,7 @@ func (h *hydraidego) ProfileSave(ctx context.Context, swampName name.Name, model
 		return NewError(ErrCodeInvalidModel, err.Error())
 	}
 
-	_, err = h.client.GetServiceClient(swampName).Set(ctx, &hydraidepbgo.SetRequest{
+	_, err = h.serviceClient(ctx, swampName).Set(ctx, &hydraidepbgo.SetRequest{
 		Swamps: []*hydraidepbgo.SwampRequest{
 			{
 				IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
@@ -2225,7 +4718,9 @@ func (h *hydraidego) ProfileSave(ctx context.Context, swampName name.Name, model
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -2263,6 +4758,8 @@ func (h *hydraidego) ProfileSave(ctx context.Context, swampName name.Name, model
 //   - If the Swamp doesn't exist → returns ErrCodeSwampNotFound
 //   - If a key is missing → silently skipped
 //   - Fields are populated using reflection-based decoding
+//   - If the model has a `hydraide:"version"` field and registered migrations (see RegisterMigration),
+//     profiles stored with an older version are upgraded, and saved back if write-back is enabled
 //
 // ⚠️ **Important: `model` must be a pointer to a struct.**
 //   - This is required for mutation and correct data binding via reflection.
@@ -2276,8 +4773,68 @@ func (h *hydraidego) ProfileRead(ctx context.Context, swampName name.Name, model
 		return NewError(ErrCodeInvalidModel, err.Error())
 	}
 
+	if err = h.readProfileKeys(ctx, swampName, model, keys); err != nil {
+		return err
+	}
+
+	// Upgrade the profile to the latest schema version if it was stored with an older one
+	migrated, writeBack, err := migrateModel(model)
+	if err != nil {
+		return NewError(ErrCodeInvalidModel, err.Error())
+	}
+	if migrated && writeBack {
+		return h.ProfileSave(ctx, swampName, model)
+	}
+
+	// Successfully populated all available fields into the model
+	return nil
+
+}
+
+// ProfileReadFields loads only the given fields of a profile-like struct from a Swamp, in one `Get` call.
+//
+// It works the same way as `ProfileRead`, but only the Treasures of the listed fields are read and
+// transferred, the other fields of the model are left untouched.
+//
+// ✅ Use when:
+//   - Only a few fields of a large profile are needed (e.g. the email address of a user)
+//   - Some fields of the profile hold large values that are not needed for the current operation
+//
+// ⚙️ Behavior:
+//   - The fields are the names of the struct fields, the same as the keys of the Treasures
+//   - If the Swamp doesn't exist → returns ErrCodeSwampNotFound
+//   - If a key is missing → silently skipped
+//
+// ⚠️ The migrations registered by RegisterMigration are not applied, because they need the whole profile.
+//
+// 🧯 Errors:
+//   - The model is not a pointer to a struct → `ErrCodeInvalidModel`
+//   - No field is given, or the model has no field with a given name → `ErrCodeInvalidArgument`
+func (h *hydraidego) ProfileReadFields(ctx context.Context, swampName name.Name, model any, fields ...string) error {
+
+	keys, err := getKeyFromProfileModel(model)
+	if err != nil {
+		return NewError(ErrCodeInvalidModel, err.Error())
+	}
+
+	if len(fields) == 0 {
+		return NewError(ErrCodeInvalidArgument, "at least one field must be given")
+	}
+	for _, field := range fields {
+		if !slices.Contains(keys, field) {
+			return NewError(ErrCodeInvalidArgument, fmt.Sprintf("the model has no field named %s", field))
+		}
+	}
+
+	return h.readProfileKeys(ctx, swampName, model, fields)
+
+}
+
+// readProfileKeys reads the Treasures of the keys from the profile Swamp, and sets them in the fields of the model
+func (h *hydraidego) readProfileKeys(ctx context.Context, swampName name.Name, model any, keys []string) error {
+
 	// Try to fetch all keys from the Swamp in a single operation
-	response, err := h.client.GetServiceClient(swampName).Get(ctx, &hydraidepbgo.GetRequest{
+	response, err := h.serviceClient(ctx, swampName).Get(ctx, &hydraidepbgo.GetRequest{
 		Swamps: []*hydraidepbgo.GetSwamp{
 			{
 				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
@@ -2288,7 +4845,9 @@ func (h *hydraidego) ProfileRead(ctx context.Context, swampName name.Name, model
 	})
 	if err != nil {
 		// Translate server-side or network error to client-side semantics
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -2304,90 +4863,364 @@ func (h *hydraidego) ProfileRead(ctx context.Context, swampName name.Name, model
 				return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
 			}
 		}
-		return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+		return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+	}
+
+	// Parse the response and assign values to the model fields
+	for _, swamp := range response.GetSwamps() {
+		for _, treasure := range swamp.GetTreasures() {
+			// If the key does not exist, skip it silently
+			if !treasure.IsExist {
+				continue
+			}
+
+			// Use reflection to set the value into the model struct
+			err = setTreasureValueToProfileModel(model, treasure, h.isStrictDecoding(ctx))
+			if err != nil {
+				// the type mismatches fail the load in strict mode, the other faulty assignments are skipped
+				// silently to avoid halting the whole load
+				if mismatch := GetTypeMismatch(err); mismatch != nil {
+					return decodeError(err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// Count returns the number of Treasures stored in a given Swamp.
+//
+// This function queries the Hydra cluster and asks for the element count (Treasure count)
+// for the specified Swamp. It is optimized for fast metadata retrieval without loading the actual data.
+//
+// ✅ Use when:
+//   - You need to check how many elements are inside a Swamp
+//   - You want to decide whether to load, paginate, or process based on size
+//   - You want to verify existence (a non-existent Swamp will return an error)
+//
+// ⚙️ Behavior:
+//   - If the Swamp exists, returns its element count (int32)
+//   - If the Swamp does not exist → returns `ErrCodeSwampNotFound`
+//   - If other errors occur (timeout, unavailable, etc.) → returns relevant wrapped error
+//   - A valid Swamp will always contain at least 1 Treasure
+//
+// 💡 Best used for dashboards, admin tooling, paginated APIs, or cleanup logic.
+// To count only a part of the Swamp, use `CountFiltered()`.
+func (h *hydraidego) Count(ctx context.Context, swampName name.Name) (int32, error) {
+	return h.CountFiltered(ctx, swampName, nil)
+}
+
+// CountFilter selects the Treasures counted by `CountFiltered()` and `CountManyFiltered()`.
+//
+// The conditions are combined with AND, and the zero values do not filter.
+//
+// Fields:
+//   - ExpiredOnly:  only the Treasures whose expiration time is set and is already in the past
+//   - KeyPrefix:    only the keys starting with the prefix
+//   - UpdatedSince: only the Treasures updated at or after the time. A Treasure that was never updated counts with its creation time
+type CountFilter struct {
+	ExpiredOnly  bool
+	KeyPrefix    string
+	UpdatedSince time.Time
+}
+
+// toPb converts the filter to the filter of the Count request, nil if the filter is nil
+func (f *CountFilter) toPb() *hydraidepbgo.CountFilter {
+	if f == nil {
+		return nil
+	}
+	filter := &hydraidepbgo.CountFilter{
+		ExpiredOnly: f.ExpiredOnly,
+		KeyPrefix:   f.KeyPrefix,
+	}
+	if !f.UpdatedSince.IsZero() {
+		filter.UpdatedSince = timestamppb.New(f.UpdatedSince)
+	}
+	return filter
+}
+
+// CountFiltered returns the number of Treasures of a Swamp matching the filter.
+//
+// The Treasures are counted on the server, so monitoring e.g. "how many jobs are overdue" needs neither shifting
+// the expired Treasures nor streaming the whole index to the client.
+//
+// ✅ Use when:
+//   - You monitor the overdue (expired) elements of a queue Swamp
+//   - You count a group of keys sharing a prefix
+//   - You count the Treasures changed since the last run of a job
+//
+// ⚙️ Behavior:
+//   - A nil filter counts all Treasures, the same as `Count()`
+//   - The filtered count walks the Treasures of the Swamp on the server, so it is slower than `Count()` on large Swamps
+//   - If the Swamp does not exist → returns `ErrCodeSwampNotFound`
+//
+// 🔧 Example:
+//
+//	overdue, err := h.CountFiltered(ctx, swampName, &hydraidego.CountFilter{ExpiredOnly: true})
+func (h *hydraidego) CountFiltered(ctx context.Context, swampName name.Name, filter *CountFilter) (int32, error) {
+
+	// Request the count of treasures from the given Swamp
+	response, err := h.serviceClient(ctx, swampName).Count(ctx, &hydraidepbgo.CountRequest{
+		Swamps: []*hydraidepbgo.CountRequest_SwampIdentifier{
+			{
+				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+				SwampName: swampName.Get(),
+			},
+		},
+		Filter: filter.toPb(),
+	})
+
+	// Translate known gRPC and internal errors
+	if err != nil {
+		if reasonErr, found := errorFromReason(err); found {
+			return 0, reasonErr
+		} else if s, ok := status.FromError(err); ok {
+			switch s.Code() {
+			case codes.Unavailable:
+				return 0, NewError(ErrCodeConnectionError, errorMessageConnectionError)
+			case codes.DeadlineExceeded:
+				return 0, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
+			case codes.Canceled:
+				return 0, NewError(ErrCodeCtxClosedByClient, errorMessageCtxClosedByClient)
+			case codes.Internal:
+				return 0, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
+			case codes.FailedPrecondition:
+				return 0, NewError(ErrCodeSwampNotFound, fmt.Sprintf("%s: %v", errorMessageSwampNotFound, s.Message()))
+			case codes.InvalidArgument:
+				return 0, NewError(ErrCodeInvalidArgument, fmt.Sprintf("%s: %v", errorMessageInvalidArgument, s.Message()))
+			default:
+				return 0, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+			}
+		}
+		return 0, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+	}
+
+	// Return the count from the response (exactly one Swamp expected)
+	for _, swamp := range response.GetSwamps() {
+		if !swamp.GetIsExist() {
+			return 0, NewError(ErrCodeSwampNotFound, errorMessageSwampNotFound)
+		}
+		return swamp.GetCount(), nil
+	}
+
+	// Should not reach here – fallback error
+	return 0, NewError(ErrCodeUnknown, errorMessageUnknown)
+}
+
+// CountMany returns the number of Treasures stored in many Swamps, with one request per server.
+//
+// This is the batch variant of `Count()`. The Swamps are grouped by the server they live on,
+// and every server receives exactly one CountRequest with all of its Swamps — instead of one request per Swamp.
+//
+// ✅ Use when:
+//   - You render dashboards or admin lists with the sizes of hundreds or thousands of Swamps
+//   - You want to avoid the latency of many sequential `Count()` calls
+//
+// ⚙️ Behavior:
+//   - Returns a map keyed by the Swamp name (`name.Get()`) with the element count of the Swamp
+//   - Swamps that do not exist are left out from the map, so `_, ok := counts[swampName.Get()]`
+//     tells whether the Swamp exists
+//   - Duplicated Swamp names are counted only once
+//   - If any server request fails, the whole call fails with the mapped SDK error
+//
+// 🔧 Example:
+//
+//	counts, err := h.CountMany(ctx, []name.Name{
+//	    name.New().Sanctuary("users").Realm("messages").Swamp("alice"),
+//	    name.New().Sanctuary("users").Realm("messages").Swamp("bob"),
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	for swampName, count := range counts {
+//	    fmt.Println(swampName, count)
+//	}
+func (h *hydraidego) CountMany(ctx context.Context, swampNames []name.Name) (map[string]int32, error) {
+	return h.CountManyFiltered(ctx, swampNames, nil)
+}
+
+// CountManyFiltered is the batch variant of `CountFiltered()`: it counts the Treasures matching the filter in many
+// Swamps, with one request per server, the same way as `CountMany()`.
+//
+// The Swamps that do not exist are left out from the map. A nil filter counts all Treasures.
+func (h *hydraidego) CountManyFiltered(ctx context.Context, swampNames []name.Name, filter *CountFilter) (map[string]int32, error) {
+
+	type requestGroup struct {
+		client hydraidepbgo.HydraideServiceClient
+		swamps []*hydraidepbgo.CountRequest_SwampIdentifier
+	}
+
+	serverRequests := make(map[string]*requestGroup)
+	seen := make(map[string]struct{}, len(swampNames))
+
+	for _, swampName := range swampNames {
+
+		if swampName == nil {
+			return nil, NewError(ErrCodeInvalidArgument, "swamp name cannot be nil")
+		}
+		if _, ok := seen[swampName.Get()]; ok {
+			continue
+		}
+		seen[swampName.Get()] = struct{}{}
+
+		// group the swamps by the server they live on
+		clientAndHost := h.serviceClientAndHost(ctx, swampName)
+		if _, ok := serverRequests[clientAndHost.Host]; !ok {
+			serverRequests[clientAndHost.Host] = &requestGroup{
+				client: clientAndHost.GrpcClient,
+			}
+		}
+
+		serverRequests[clientAndHost.Host].swamps = append(serverRequests[clientAndHost.Host].swamps, &hydraidepbgo.CountRequest_SwampIdentifier{
+			IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+			SwampName: swampName.Get(),
+		})
+
 	}
 
-	// Parse the response and assign values to the model fields
-	for _, swamp := range response.GetSwamps() {
-		for _, treasure := range swamp.GetTreasures() {
-			// If the key does not exist, skip it silently
-			if !treasure.IsExist {
-				continue
+	counts := make(map[string]int32, len(seen))
+
+	for _, reqGroup := range serverRequests {
+
+		response, err := reqGroup.client.Count(ctx, &hydraidepbgo.CountRequest{
+			Swamps: reqGroup.swamps,
+			Filter: filter.toPb(),
+		})
+
+		if err != nil {
+			if reasonErr, found := errorFromReason(err); found {
+				return nil, reasonErr
+			} else if s, ok := status.FromError(err); ok {
+				switch s.Code() {
+				case codes.Unavailable:
+					return nil, NewError(ErrCodeConnectionError, errorMessageConnectionError)
+				case codes.DeadlineExceeded:
+					return nil, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
+				case codes.Canceled:
+					return nil, NewError(ErrCodeCtxClosedByClient, errorMessageCtxClosedByClient)
+				case codes.Internal:
+					return nil, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
+				case codes.InvalidArgument:
+					return nil, NewError(ErrCodeInvalidArgument, fmt.Sprintf("%s: %v", errorMessageInvalidArgument, s.Message()))
+				default:
+					return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+				}
 			}
+			return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+		}
 
-			// Use reflection to set the value into the model struct
-			err = setTreasureValueToProfileModel(model, treasure)
-			if err != nil {
-				// Skip faulty assignments silently to avoid halting the whole load
-				continue
+		for _, swamp := range response.GetSwamps() {
+			if swamp.GetIsExist() {
+				counts[swamp.GetSwampName()] = swamp.GetCount()
 			}
 		}
+
 	}
 
-	// Successfully populated all available fields into the model
-	return nil
+	return counts, nil
 
 }
 
-// Count returns the number of Treasures stored in a given Swamp.
+// AggregateRequest narrows the Treasures aggregated by `Aggregate()`.
 //
-// This function queries the Hydra cluster and asks for the element count (Treasure count)
-// for the specified Swamp. It is optimized for fast metadata retrieval without loading the actual data.
+// Fields:
+//   - KeyPrefix: only the keys starting with the prefix are aggregated (empty = all keys)
+//   - Index:     optional index range and filter expression, the same as at `CatalogReadMany()`.
+//     The key prefix and the FilterExpr are applied first, then From and Limit on the numeric Treasures.
+type AggregateRequest struct {
+	KeyPrefix string
+	Index     *Index
+}
+
+// AggregateResult contains the aggregates of the numeric values of a Swamp.
+//
+// Min, Max and Avg are meaningful only if Count is greater than 0.
+// IntegerSum is the exact sum of the values, and valid only if IntegerSumExact is true,
+// that is, every aggregated value is an integer and the sum fits into int64.
+// Use it instead of Sum for money, counters and other large integers, because float64 loses precision above 2^53.
+type AggregateResult struct {
+	Count           int64
+	Sum             float64
+	Min             float64
+	Max             float64
+	Avg             float64
+	IntegerSum      int64
+	IntegerSumExact bool
+}
+
+// Aggregate computes count, sum, min, max and avg over the numeric values of a Swamp on the server.
+//
+// Only the aggregates travel over the network, so summing a million int64 values does not mean
+// pulling a million Treasures to the client.
 //
 // ✅ Use when:
-//   - You need to check how many elements are inside a Swamp
-//   - You want to decide whether to load, paginate, or process based on size
-//   - You want to verify existence (a non-existent Swamp will return an error)
+//   - You need totals, averages or ranges of counters, prices, scores, etc.
+//   - You would otherwise read the whole Swamp with `CatalogReadMany()` just to sum the values
 //
 // ⚙️ Behavior:
-//   - If the Swamp exists, returns its element count (int32)
+//   - Treasures without a numeric value (string, bool, struct, void, etc.) are skipped and not counted
+//   - A nil request aggregates the whole Swamp
 //   - If the Swamp does not exist → returns `ErrCodeSwampNotFound`
-//   - If other errors occur (timeout, unavailable, etc.) → returns relevant wrapped error
-//   - A valid Swamp will always contain at least 1 Treasure
+//   - If the filter expression is invalid → returns `ErrCodeInvalidArgument`
 //
-// 💡 Best used for dashboards, admin tooling, paginated APIs, or cleanup logic.
-func (h *hydraidego) Count(ctx context.Context, swampName name.Name) (int32, error) {
+// 🔧 Example:
+//
+//	// the sum of the last 100 order amounts
+//	result, err := h.Aggregate(ctx, swampName, &hydraidego.AggregateRequest{
+//	    KeyPrefix: "order-",
+//	    Index: &hydraidego.Index{
+//	        IndexType:  hydraidego.IndexCreationTime,
+//	        IndexOrder: hydraidego.IndexOrderDesc,
+//	        Limit:      100,
+//	    },
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(result.Count, result.Sum, result.Avg)
+func (h *hydraidego) Aggregate(ctx context.Context, swampName name.Name, request *AggregateRequest) (*AggregateResult, error) {
 
-	// Request the count of treasures from the given Swamp
-	response, err := h.client.GetServiceClient(swampName).Count(ctx, &hydraidepbgo.CountRequest{
-		Swamps: []*hydraidepbgo.CountRequest_SwampIdentifier{
-			{
-				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-				SwampName: swampName.Get(),
-			},
-		},
-	})
+	if swampName == nil {
+		return nil, NewError(ErrCodeInvalidArgument, "swamp name cannot be nil")
+	}
 
-	// Translate known gRPC and internal errors
-	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			switch s.Code() {
-			case codes.Unavailable:
-				return 0, NewError(ErrCodeConnectionError, errorMessageConnectionError)
-			case codes.DeadlineExceeded:
-				return 0, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
-			case codes.Canceled:
-				return 0, NewError(ErrCodeCtxClosedByClient, errorMessageCtxClosedByClient)
-			case codes.Internal:
-				return 0, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
-			case codes.FailedPrecondition:
-				return 0, NewError(ErrCodeSwampNotFound, fmt.Sprintf("%s: %v", errorMessageSwampNotFound, s.Message()))
-			case codes.InvalidArgument:
-				return 0, NewError(ErrCodeInvalidArgument, fmt.Sprintf("%s: %v", errorMessageInvalidArgument, s.Message()))
-			default:
-				return 0, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+	aggregateRequest := &hydraidepbgo.AggregateRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+	}
+
+	if request != nil {
+		aggregateRequest.KeyPrefix = request.KeyPrefix
+		if request.Index != nil {
+			aggregateRequest.IndexType = convertIndexTypeToProtoIndexType(request.Index.IndexType)
+			aggregateRequest.OrderType = convertOrderTypeToProtoOrderType(request.Index.IndexOrder)
+			aggregateRequest.From = request.Index.From
+			aggregateRequest.Limit = request.Index.Limit
+			// Send the filter expression only if it is set
+			if request.Index.FilterExpr != "" {
+				aggregateRequest.FilterExpr = &request.Index.FilterExpr
 			}
 		}
-		return 0, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
 	}
 
-	// Return the count from the response (exactly one Swamp expected)
-	for _, swamp := range response.GetSwamps() {
-		return swamp.GetCount(), nil
+	response, err := h.serviceClient(ctx, swampName).Aggregate(ctx, aggregateRequest)
+	if err != nil {
+		return nil, errorHandler(err)
 	}
 
-	// Should not reach here – fallback error
-	return 0, NewError(ErrCodeUnknown, errorMessageUnknown)
+	return &AggregateResult{
+		Count:           response.GetCount(),
+		Sum:             response.GetSum(),
+		Min:             response.GetMin(),
+		Max:             response.GetMax(),
+		Avg:             response.GetAvg(),
+		IntegerSum:      response.GetIntegerSum(),
+		IntegerSumExact: response.IntegerSum != nil,
+	}, nil
+
 }
 
 // Destroy permanently deletes an entire Swamp and all of its Treasures.
@@ -2416,7 +5249,7 @@ func (h *hydraidego) Count(ctx context.Context, swampName name.Name) (int32, err
 func (h *hydraidego) Destroy(ctx context.Context, swampName name.Name) error {
 
 	// Send the destroy request to the correct server based on swampName hashing
-	_, err := h.client.GetServiceClient(swampName).Destroy(ctx, &hydraidepbgo.DestroyRequest{
+	_, err := h.serviceClient(ctx, swampName).Destroy(ctx, &hydraidepbgo.DestroyRequest{
 		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName: swampName.Get(),
 	})
@@ -2430,6 +5263,85 @@ func (h *hydraidego) Destroy(ctx context.Context, swampName name.Name) error {
 	return nil
 }
 
+// DestroyManyIteratorFunc is called by DestroyMany after each destroyed Swamp, e.g. to report the progress.
+// Returning an error stops the destroy, and DestroyMany returns the error. The Swamps destroyed before are not
+// restored.
+type DestroyManyIteratorFunc func(swampName name.Name) error
+
+// DestroyMany permanently deletes every Swamp matching a wildcard pattern, with all of their Treasures.
+//
+// One call tears down e.g. all Swamps of a tenant, instead of a Destroy for each of thousands of Swamps. The
+// servers find the matching Swamps on their disks and in their memory themselves.
+//
+// ⚙️ Behavior:
+//   - A wildcard pattern is destroyed on all servers one by one, an exact name only on its own server
+//   - The confirmation must be the same as `pattern.Get()`, otherwise nothing is destroyed, and the call fails with
+//     `ErrCodeInvalidArgument`. It makes the caller repeat what is going to be destroyed.
+//   - The iterator is called after each destroyed Swamp, it can be nil
+//   - If the call fails, e.g. by a timeout, the remaining Swamps are not destroyed, and the same call can be repeated
+//
+// 💡 Typical usage:
+//
+//	tenant := name.New().Sanctuary("tenant-42").Realm("*").Swamp("*")
+//	err := h.DestroyMany(ctx, tenant, "tenant-42/*/*", func(swampName name.Name) error {
+//	    slog.Info("swamp destroyed", "swamp", swampName.Get())
+//	    return nil
+//	})
+//
+// ⚠️ There is no undo, like with Destroy. Stop the writes of the pattern first, because a Swamp written during the
+// call can be created again.
+func (h *hydraidego) DestroyMany(ctx context.Context, pattern name.Name, confirmation string, iterator DestroyManyIteratorFunc) error {
+
+	if pattern == nil {
+		return NewError(ErrCodeInvalidArgument, "the pattern cannot be nil")
+	}
+	if confirmation != pattern.Get() {
+		return NewError(ErrCodeInvalidArgument, fmt.Sprintf("the confirmation must be the pattern %s", pattern.Get()))
+	}
+
+	// a wildcard pattern can match the Swamps of any server
+	var serviceClients []hydraidepbgo.HydraideServiceClient
+	if pattern.IsWildcardPattern() {
+		serviceClients = h.client.GetUniqueServiceClients()
+	} else {
+		serviceClients = append(serviceClients, h.serviceClient(ctx, pattern))
+	}
+
+	// the stream is canceled if the iterator stops the destroy
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, serviceClient := range serviceClients {
+
+		stream, err := serviceClient.DestroyMany(streamCtx, &hydraidepbgo.DestroyManyRequest{
+			SwampPattern:      pattern.Get(),
+			ConfirmationToken: confirmation,
+		})
+		if err != nil {
+			return errorHandler(err)
+		}
+
+		for {
+			response, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return errorHandler(err)
+			}
+			if iterator != nil {
+				if err := iterator(name.Load(response.GetSwampName())); err != nil {
+					return err
+				}
+			}
+		}
+
+	}
+
+	return nil
+
+}
+
 type SubscribeIteratorFunc func(model any, eventStatus EventStatus, err error) error
 
 // Subscribe sets up a real-time event stream for a given Swamp, allowing you to react to changes as they happen.
@@ -2448,164 +5360,358 @@ type SubscribeIteratorFunc func(model any, eventStatus EventStatus, err error) e
 //   - `model` must be a **non-pointer type**, used as a blueprint
 //   - Each call to `iterator(modelInstance, status, err)` passes a freshly filled pointer to modelInstance
 //   - If `getExistingData` is true:
-//   - All current Treasures are loaded and passed first (in ascending creation time)
+//   - All current Treasures are loaded and passed first (in ascending creation time), before the function returns
 //   - Then the live stream begins from that point
+//   - The server takes the snapshot and starts the stream atomically, so no change is lost or delivered twice
 //
 // ⚠️ Notes:
 //   - The subscription is **non-blocking**; the stream runs in a background goroutine
+//   - The returned Subscription is the handle of the stream, see its Close, Done, LastEventTime and Err
 //   - The stream will stop if:
+//   - the subscription is closed
 //   - the context is canceled
 //   - the iterator returns an error
 //   - the server closes the stream
 //   - If an event conversion fails, the error is passed to the iterator (non-fatal)
+//   - A slow iterator makes the writers of the Swamp wait for it, unless an other overflow policy is set with
+//     `WithSubscriberBuffer()`
 //
 // 💡 Typical use cases:
 //   - Watching a Swamp for AI completion signals
 //   - Acting as a message queue for microservices
 //   - Forwarding real-time updates to WebSocket clients
 //   - Triggering logic in distributed workflows
-func (h *hydraidego) Subscribe(ctx context.Context, swampName name.Name, getExistingData bool, model any, iterator SubscribeIteratorFunc) error {
+func (h *hydraidego) Subscribe(ctx context.Context, swampName name.Name, getExistingData bool, model any, iterator SubscribeIteratorFunc) (*Subscription, error) {
 
 	// check if the iterator is nil
 	if iterator == nil {
 		// iterator can not be nil
-		return NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+		return nil, NewError(ErrCodeInvalidArgument, "iterator can not be nil")
 	}
 
-	// get the existing data if needed
-	if getExistingData {
+	// subscribe to the events. The server sends the existing data first if needed, and the snapshot and the start of
+	// the stream are atomic, so no change is lost or delivered twice between them
+	return h.subscribe(ctx, swampName, &hydraidepbgo.SubscribeToEventsRequest{
+		IslandID:        swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:       swampName.Get(),
+		IncludeSnapshot: getExistingData,
+	}, catalogEventConverter(model, h.isStrictDecoding(ctx)), nil, func(model any, eventStatus EventStatus, _ time.Time, err error) error {
+		return iterator(model, eventStatus, err)
+	})
 
-		// get all data by the index creation time in ascending order
-		response, err := h.client.GetServiceClient(swampName).GetByIndex(ctx, &hydraidepbgo.GetByIndexRequest{
-			IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-			SwampName: swampName.Get(),
-			IndexType: hydraidepbgo.IndexType_CREATION_TIME,
-			OrderType: hydraidepbgo.OrderType_ASC,
-			From:      0,
-			Limit:     0,
-		})
+}
 
-		if err != nil {
-			if s, ok := status.FromError(err); ok {
-				switch s.Code() {
-				case codes.Unavailable:
-					return NewError(ErrCodeConnectionError, errorMessageConnectionError)
-				case codes.DeadlineExceeded:
-					return NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
-				case codes.InvalidArgument:
-					return NewError(ErrCodeInvalidArgument, errorMessageInvalidArgument)
-				case codes.Internal:
-					return NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
-				default:
-					return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
-				}
-			} else {
-				return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
-			}
-		}
+type SubscribeFromIteratorFunc func(model any, eventStatus EventStatus, eventTime time.Time, err error) error
 
-		// go through the treasures and load them to the model if the user wants to get the existing data
-		for _, treasure := range response.GetTreasures() {
+// SubscribeFrom sets up a real-time event stream for a given Swamp like Subscribe, but first it replays the events
+// of the Swamp that happened after the given time.
+//
+// This is the way to continue a subscription after a crash or a restart of your service, without reading the whole
+// Swamp again: store the eventTime of the last processed event, and pass it as `since` at the next start.
+//
+// ✅ Use when:
+//   - Your consumer must not miss any change of the Swamp, even while it is down
+//   - A full resync of the Swamp would be too slow or too expensive
+//
+// ⚙️ Behavior:
+//   - The event journal must be enabled for the Swamp pattern with `EventJournalSize` in the RegisterSwampRequest
+//   - The missed events (new, updated, deleted) are passed to the iterator in their original order, before the
+//     function returns
+//   - Then the live stream continues from that point, in the background like Subscribe
+//   - The server reads the journal and starts the stream atomically, so no change is lost or delivered twice
+//   - The iterator receives the time of each event, store it as your checkpoint
+//
+// ⚠️ Notes:
+//   - If the journal is disabled, or it does not have all events since the given time (the journal is full,
+//     the events are older than its retention, or the server restarted), an error is returned and
+//     `IsReplayNotAvailable(err)` is true. Read the whole Swamp with Subscribe(getExistingData=true) instead.
+//   - The stream stops on the same conditions as Subscribe
+//
+// 💡 Typical use cases:
+//   - Reliable consumers of a Swamp used as a message queue
+//   - Syncing a Swamp into a search index or a cache that survives restarts
+func (h *hydraidego) SubscribeFrom(ctx context.Context, swampName name.Name, since time.Time, model any, iterator SubscribeFromIteratorFunc) (*Subscription, error) {
 
-			if treasure.IsExist == false {
-				continue
-			}
+	// check if the iterator is nil
+	if iterator == nil {
+		// iterator can not be nil
+		return nil, NewError(ErrCodeInvalidArgument, "iterator can not be nil")
+	}
 
-			// create a new instance of the model
-			modelInstance := reflect.New(reflect.TypeOf(model)).Interface()
+	return h.subscribe(ctx, swampName, &hydraidepbgo.SubscribeToEventsRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Since:     timestamppb.New(since),
+	}, catalogEventConverter(model, h.isStrictDecoding(ctx)), nil, iterator)
 
-			// ConvertProtoTreasureToModel function will load the data to the model
-			if convErr := convertProtoTreasureToCatalogModel(treasure, modelInstance); convErr != nil {
-				return NewError(ErrCodeInvalidModel, convErr.Error())
-			}
+}
 
-			// call the iterator function and handle its error
-			// exit the loop if the iterator returns an error
-			if iErr := iterator(modelInstance, StatusNothingChanged, nil); iErr != nil {
-				return iErr
-			}
+// ProfileChange is a field-level change of a profile Swamp, passed to the iterator of ProfileSubscribe
+type ProfileChange struct {
+	Field     string      // the name of the changed field of the profile model
+	Value     any         // the new value of the field, with the type of the field. nil if the field was deleted
+	Status    EventStatus // StatusNew, StatusModified or StatusDeleted
+	EventTime time.Time   // the time of the change on the server
+}
 
-		}
+type ProfileSubscribeIteratorFunc func(change *ProfileChange, err error) error
+
+// ProfileSubscribe loads the current profile into the model, then streams the changes of its fields one by one.
+//
+// This is the live counterpart of `ProfileRead`: every field of a profile is its own Treasure, so every change of
+// the Swamp is the change of one field. Instead of reading the whole profile again on every event, the iterator
+// receives the name of the changed field and its new value, already decoded to the type of the field.
+//
+// ✅ Use when:
+//   - A settings or profile UI must live-update the fields changed by other users or services
+//   - You forward the changes of a profile to WebSocket clients as small patches
+//
+// ⚙️ Behavior:
+//   - `model` must be a pointer to the profile struct, like in ProfileRead
+//   - The current profile is loaded into the model before the function returns, and the server starts the stream
+//     at the same moment, so no change is lost or delivered twice between the read and the stream
+//   - The iterator receives the changes in a background goroutine, the model is not modified by them
+//   - `change.Value` has the type of the field, e.g. `string` or `*Address`, so it can be type-asserted directly
+//   - Deleted fields are passed with `StatusDeleted` and a nil Value
+//   - The Treasures without a matching field in the model are skipped
+//
+// ⚠️ Notes:
+//   - The stream stops on the same conditions as Subscribe
+//   - If a value can not be decoded to its field, the error is passed to the iterator (non-fatal)
+//
+// 🔧 Example:
+//
+//	settings := &UserSettings{}
+//	subscription, err := h.ProfileSubscribe(ctx, swampName, settings, func(change *ProfileChange, err error) error {
+//	    if err != nil {
+//	        return nil
+//	    }
+//	    return socket.Send(change.Field, change.Value)
+//	})
+func (h *hydraidego) ProfileSubscribe(ctx context.Context, swampName name.Name, model any, iterator ProfileSubscribeIteratorFunc) (*Subscription, error) {
 
+	if iterator == nil {
+		return nil, NewError(ErrCodeInvalidArgument, "iterator can not be nil")
 	}
 
-	// subscribe to the events
-	eventClient, err := h.client.GetServiceClient(swampName).SubscribeToEvents(ctx, &hydraidepbgo.SubscribeToEventsRequest{
-		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-		SwampName: swampName.Get(),
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, NewError(ErrCodeInvalidModel, "model must be a pointer to a struct")
+	}
+	modelType := v.Elem().Type()
+
+	return h.subscribe(ctx, swampName, &hydraidepbgo.SubscribeToEventsRequest{
+		IslandID:        swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:       swampName.Get(),
+		IncludeSnapshot: true,
+	}, func(event *hydraidepbgo.SubscribeToEventsResponse) (any, error) {
+		return convertEventToProfileChange(event, modelType, h.isStrictDecoding(ctx))
+	}, func(change any, _ EventStatus, _ time.Time, _ error) error {
+		// the existing fields are loaded into the model
+		profileChange := change.(*ProfileChange)
+		if profileChange.Value != nil {
+			v.Elem().FieldByName(profileChange.Field).Set(reflect.ValueOf(profileChange.Value))
+		}
+		return nil
+	}, func(change any, eventStatus EventStatus, eventTime time.Time, err error) error {
+		if change == nil {
+			return iterator(nil, err)
+		}
+		// the fields saved with the same value did not change
+		if eventStatus == StatusNothingChanged {
+			return nil
+		}
+		profileChange := change.(*ProfileChange)
+		profileChange.EventTime = eventTime
+		return iterator(profileChange, err)
 	})
 
+}
+
+// convertEventToProfileChange converts the event to the change of the field named by the key of the Treasure.
+// Returns nil if the model has no such field.
+func convertEventToProfileChange(event *hydraidepbgo.SubscribeToEventsResponse, modelType reflect.Type, strict bool) (any, error) {
+
+	treasure := event.GetTreasure()
+	if event.Status == hydraidepbgo.Status_DELETED {
+		treasure = event.GetDeletedTreasure()
+	}
+
+	field, found := modelType.FieldByName(treasure.GetKey())
+	if !found || !field.IsExported() {
+		return nil, nil
+	}
+
+	change := &ProfileChange{
+		Field:  field.Name,
+		Status: convertProtoStatusToStatus(event.Status),
+	}
+	if event.Status == hydraidepbgo.Status_DELETED {
+		return change, nil
+	}
+
+	value := reflect.New(field.Type).Elem()
+	if err := setProtoTreasureToModel(treasure, value, field.Name, strict); err != nil {
+		return change, err
+	}
+	change.Value = value.Interface()
+
+	return change, nil
+
+}
+
+// eventConverter converts the event of the stream to the value passed to the iterator. A nil value without error
+// skips the event.
+type eventConverter func(event *hydraidepbgo.SubscribeToEventsResponse) (any, error)
+
+// subscribe opens the event stream of the request. The snapshot or the replayed events are passed to the
+// snapshotIterator before the function returns, or to the iterator if it is nil, then the new events are passed to
+// the iterator in a background goroutine
+func (h *hydraidego) subscribe(ctx context.Context, swampName name.Name, request *hydraidepbgo.SubscribeToEventsRequest, convert eventConverter, snapshotIterator SubscribeFromIteratorFunc, iterator SubscribeFromIteratorFunc) (*Subscription, error) {
+
+	if snapshotIterator == nil {
+		snapshotIterator = iterator
+	}
+
+	buffer := subscriberBufferFrom(ctx)
+	request.BufferSize = buffer.bufferSize()
+	request.Overflow = buffer.overflowPolicy()
+
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	eventClient, err := h.serviceClient(ctx, swampName).SubscribeToEvents(streamCtx, request)
+
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		cancelStream()
+		if reasonErr, found := errorFromReason(err); found {
+			return nil, reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
-				return NewError(ErrCodeConnectionError, errorMessageConnectionError)
+				return nil, NewError(ErrCodeConnectionError, errorMessageConnectionError)
 			case codes.DeadlineExceeded:
-				return NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
+				return nil, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
 			case codes.InvalidArgument:
-				return NewError(ErrCodeInvalidArgument, errorMessageInvalidArgument)
+				return nil, NewError(ErrCodeInvalidArgument, errorMessageInvalidArgument)
 			case codes.Internal:
-				return NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
+				return nil, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
 			default:
-				return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+				return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
 			}
 		} else {
-			return NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+			return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
 		}
 	}
 
-	// listen to the events and block until the context is closed, the event stream is closed or error occurs in the
-	// stream or the iterator
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				// context closed by client
-				return
-			default:
+	subscription := newSubscription(cancelStream)
 
-				event, receiveErr := eventClient.Recv()
-				// if the connection is closed, then we can exit the loop and do not listen to the events anymore
-				if receiveErr != nil {
-					if receiveErr == io.EOF {
-						// connection gracefully closed by the server
-						return
-					}
-					// call iterator function with error
-					if iErr := iterator(nil, StatusUnknown, NewError(ErrCodeUnknown, receiveErr.Error())); iErr != nil {
-						return
-					}
-					// unexpected error while receiving the event
-					return
-				}
+	// pass the existing data or the replayed events to the iterator before the function returns, until the end
+	// marker of the server
+	for request.GetIncludeSnapshot() || request.GetSince() != nil {
+
+		response, receiveErr := eventClient.Recv()
+		if receiveErr != nil {
+			cancelStream()
+			return nil, errorHandler(receiveErr)
+		}
+
+		if response.GetSnapshotEnd() {
+			break
+		}
+
+		modelInstance, convErr := convert(response)
+		if convErr != nil {
+			cancelStream()
+			return nil, decodeError(convErr)
+		}
+		if modelInstance == nil {
+			continue
+		}
+
+		// call the iterator function and handle its error
+		// exit the loop if the iterator returns an error
+		subscription.eventReceived(response.GetEventTime().AsTime())
+		if iErr := snapshotIterator(modelInstance, convertProtoStatusToStatus(response.Status), response.GetEventTime().AsTime(), nil); iErr != nil {
+			cancelStream()
+			return nil, iErr
+		}
+
+	}
 
-				// create a new instance of the model
-				modelInstance := reflect.New(reflect.TypeOf(model)).Interface()
-				var convErr error
+	// listen to the events until the subscription is closed, the context is closed, the event stream is closed or
+	// error occurs in the stream or the iterator
+	go func() {
+		defer subscription.finish()
+		for {
 
-				// switch the event status and load the data to the model
-				// the conversion error will be stored in the convErr variable and pass it to the iterator
-				switch event.Status {
-				case hydraidepbgo.Status_NEW, hydraidepbgo.Status_UPDATED, hydraidepbgo.Status_NOTHING_CHANGED:
-					convErr = convertProtoTreasureToCatalogModel(event.GetTreasure(), modelInstance)
-				case hydraidepbgo.Status_DELETED:
-					convErr = convertProtoTreasureToCatalogModel(event.GetDeletedTreasure(), modelInstance)
+			event, receiveErr := eventClient.Recv()
+			// if the connection is closed, then we can exit the loop and do not listen to the events anymore
+			if receiveErr != nil {
+				// the subscription is closed by Close, the iterator is not called anymore
+				if subscription.closed.Load() {
+					return
 				}
-
-				// call the iterator function and handle its error
-				// exit the loop if the iterator returns an error
-				if iErr := iterator(modelInstance, convertProtoStatusToStatus(event.Status), convErr); iErr != nil {
-					// iteration error
+				if receiveErr == io.EOF {
+					// connection gracefully closed by the server
+					subscription.fail(NewError(ErrCodeConnectionError, "the server closed the subscription"))
 					return
 				}
+				// call iterator function with error, e.g. the overflow of the buffer on the server
+				streamErr := errorHandler(receiveErr)
+				subscription.fail(streamErr)
+				_ = iterator(nil, StatusUnknown, time.Time{}, streamErr)
+				return
+			}
+
+			// the events dropped by the buffer on the server are reported, not passed to the iterator
+			if buffer.dropped(event) {
+				continue
+			}
 
+			// the conversion error is passed to the iterator
+			modelInstance, convErr := convert(event)
+			if modelInstance == nil && convErr == nil {
 				continue
+			}
 
+			// call the iterator function and handle its error
+			// exit the loop if the iterator returns an error
+			subscription.eventReceived(event.GetEventTime().AsTime())
+			if iErr := iterator(modelInstance, convertProtoStatusToStatus(event.Status), event.GetEventTime().AsTime(), convErr); iErr != nil {
+				// iteration error
+				subscription.fail(iErr)
+				return
 			}
+
 		}
 	}()
 
-	return nil
+	return subscription, nil
+
+}
+
+// catalogEventConverter converts the events to new instances of the catalog model
+func catalogEventConverter(model any, strict bool) eventConverter {
+	return func(event *hydraidepbgo.SubscribeToEventsResponse) (any, error) {
+		return convertEventToModel(event, model, strict)
+	}
+}
+
+// convertEventToModel loads the treasure of the event to a new instance of the model. The deleted events carry the
+// deleted treasure, the others the current one
+func convertEventToModel(event *hydraidepbgo.SubscribeToEventsResponse, model any, strict bool) (any, error) {
+
+	// create a new instance of the model
+	modelInstance := reflect.New(reflect.TypeOf(model)).Interface()
+
+	var convErr error
+	switch event.Status {
+	case hydraidepbgo.Status_NEW, hydraidepbgo.Status_UPDATED, hydraidepbgo.Status_NOTHING_CHANGED:
+		convErr = convertProtoTreasureToCatalogModel(event.GetTreasure(), modelInstance, strict)
+	case hydraidepbgo.Status_DELETED:
+		convErr = convertProtoTreasureToCatalogModel(event.GetDeletedTreasure(), modelInstance, strict)
+	}
+
+	return modelInstance, convErr
 
 }
 
@@ -2742,7 +5848,7 @@ func (h *hydraidego) IncrementInt8(ctx context.Context, swampName name.Name, key
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementInt8(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementInt8(ctx, r)
 
 	if err != nil {
 		return 0, errorHandler(err)
@@ -2831,7 +5937,7 @@ func (h *hydraidego) IncrementInt16(ctx context.Context, swampName name.Name, ke
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementInt16(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementInt16(ctx, r)
 
 	if err != nil {
 		return 0, errorHandler(err)
@@ -2919,7 +6025,7 @@ func (h *hydraidego) IncrementInt32(ctx context.Context, swampName name.Name, ke
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementInt32(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementInt32(ctx, r)
 
 	if err != nil {
 		return 0, errorHandler(err)
@@ -3007,7 +6113,7 @@ func (h *hydraidego) IncrementInt64(ctx context.Context, swampName name.Name, ke
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementInt64(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementInt64(ctx, r)
 
 	if err != nil {
 		return 0, errorHandler(err)
@@ -3095,7 +6201,7 @@ func (h *hydraidego) IncrementUint8(ctx context.Context, swampName name.Name, ke
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementUint8(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementUint8(ctx, r)
 	if err != nil {
 		return 0, errorHandler(err)
 	}
@@ -3180,7 +6286,7 @@ func (h *hydraidego) IncrementUint16(ctx context.Context, swampName name.Name, k
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementUint16(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementUint16(ctx, r)
 	if err != nil {
 		return 0, errorHandler(err)
 	}
@@ -3264,7 +6370,7 @@ func (h *hydraidego) IncrementUint32(ctx context.Context, swampName name.Name, k
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementUint32(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementUint32(ctx, r)
 	if err != nil {
 		return 0, errorHandler(err)
 	}
@@ -3348,7 +6454,7 @@ func (h *hydraidego) IncrementUint64(ctx context.Context, swampName name.Name, k
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementUint64(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementUint64(ctx, r)
 	if err != nil {
 		return 0, errorHandler(err)
 	}
@@ -3433,7 +6539,7 @@ func (h *hydraidego) IncrementFloat32(ctx context.Context, swampName name.Name,
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementFloat32(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementFloat32(ctx, r)
 	if err != nil {
 		return 0, errorHandler(err)
 	}
@@ -3518,7 +6624,7 @@ func (h *hydraidego) IncrementFloat64(ctx context.Context, swampName name.Name,
 		}
 	}
 
-	response, err := h.client.GetServiceClient(swampName).IncrementFloat64(ctx, r)
+	response, err := h.serviceClient(ctx, swampName).IncrementFloat64(ctx, r)
 	if err != nil {
 		return 0, errorHandler(err)
 	}
@@ -3530,11 +6636,214 @@ func (h *hydraidego) IncrementFloat64(ctx context.Context, swampName name.Name,
 	return 0, NewError(ErrConditionNotMet, fmt.Sprintf("%s: %f", errorMessageConditionNotMet, response.GetValue()))
 }
 
+// SetIfLater atomically moves a timestamp forward: the timestamp is stored in the key only if it is later than the
+// stored one, or the key does not exist yet.
+//
+// 📦 A heartbeat or a "last seen" time written by many workers needs no lock-read-compare-write sequence, and a
+// late or retried write can never move the time back.
+//
+// ⚙️ Behavior:
+//   - The timestamp is stored as an int64 UNIX time in seconds, like the time.Time fields of the catalog models, so
+//     the key can be read by CatalogRead into a model with a time.Time value
+//   - The Swamp and the Treasure are created if they do not exist
+//   - A stored timestamp triggers an event to the subscribers of the Swamp, like the increments
+//
+// 🧯 Errors:
+//   - The timestamp is not later than the stored one → `ErrConditionNotMet`, and the stored timestamp is returned
+//     with the error
+//   - The key holds a value other than an int64 → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	lastSeen, err := h.SetIfLater(ctx, swampName, "worker-7", time.Now())
+//	if hydraidego.IsConditionNotMet(err) {
+//	    // an other writer has already stored a later time, lastSeen is that time
+//	}
+func (h *hydraidego) SetIfLater(ctx context.Context, swampName name.Name, key string, timestamp time.Time) (time.Time, error) {
+
+	response, err := h.serviceClient(ctx, swampName).SetIfLater(ctx, &hydraidepbgo.SetIfLaterRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Timestamp: timestamp.Unix(),
+	})
+	if err != nil {
+		return time.Time{}, errorHandler(err)
+	}
+
+	storedTimestamp := time.Unix(response.GetTimestamp(), 0).UTC()
+	if !response.GetIsSet() {
+		return storedTimestamp, NewError(ErrConditionNotMet, fmt.Sprintf("%s: %s", errorMessageConditionNotMet, storedTimestamp.Format(time.RFC3339)))
+	}
+
+	return storedTimestamp, nil
+
+}
+
+// AddDuration atomically adds a duration to the timestamp stored in the key, and returns the new timestamp, e.g. to
+// extend a lease or a deadline without a lock-read-compare-write sequence.
+//
+// ⚙️ Behavior:
+//   - The timestamp is an int64 UNIX time in seconds, like the time.Time fields of the catalog models, so the
+//     duration is truncated to whole seconds
+//   - If the key does not exist, the duration is added to the current time of the server
+//   - With fromNowIfPast, the duration is added to the current time of the server also if the stored timestamp is in
+//     the past, so an expired lease is renewed from now, and not from its expiration
+//   - A negative duration moves the timestamp back
+//   - The change triggers an event to the subscribers of the Swamp, like the increments
+//
+// 🧯 Errors:
+//   - The duration is shorter than a second → `ErrCodeInvalidArgument`
+//   - The key holds a value other than an int64 → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	// extend the lease by 30 seconds, from now if it has already expired
+//	expiresAt, err := h.AddDuration(ctx, swampName, "lease:job-42", 30*time.Second, true)
+func (h *hydraidego) AddDuration(ctx context.Context, swampName name.Name, key string, duration time.Duration, fromNowIfPast bool) (time.Time, error) {
+
+	seconds := int64(duration / time.Second)
+	if seconds == 0 {
+		return time.Time{}, NewError(ErrCodeInvalidArgument, "the duration must be at least one second")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).AddDuration(ctx, &hydraidepbgo.AddDurationRequest{
+		IslandID:      swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:     swampName.Get(),
+		Key:           key,
+		Seconds:       seconds,
+		FromNowIfPast: fromNowIfPast,
+	})
+	if err != nil {
+		return time.Time{}, errorHandler(err)
+	}
+
+	return time.Unix(response.GetTimestamp(), 0).UTC(), nil
+
+}
+
+// SetStringIfEquals atomically replaces the string value of the key, if the stored value is equal to the expected
+// one. It enforces simple state machines on the server side, e.g. a status moves from "pending" to "processing"
+// only once, even if many workers try it at the same time.
+//
+// ⚙️ Behavior:
+//   - The compare and the set are atomic, no other writer of the key can come in between
+//   - The key must exist: a missing key never equals the expected value
+//   - A set value triggers an event to the subscribers of the Swamp, like the increments
+//
+// 🧯 Errors:
+//   - The stored value is not equal to the expected one, or the key does not exist → `ErrConditionNotMet`, and the
+//     stored value is returned with the error
+//   - The key holds a value other than a string → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	_, err := h.SetStringIfEquals(ctx, swampName, "job-42", "pending", "processing")
+//	if hydraidego.IsConditionNotMet(err) {
+//	    // an other worker has already taken the job
+//	}
+func (h *hydraidego) SetStringIfEquals(ctx context.Context, swampName name.Name, key string, expected string, value string) (string, error) {
+	return h.setStringIf(ctx, swampName, key, value, &hydraidepbgo.SetStringCondition{
+		Operator: hydraidepbgo.SetIf_EQUAL,
+		Value:    expected,
+	})
+}
+
+// SetStringIfNotExists atomically stores the string value of the key, if the key does not exist yet, e.g. to claim
+// a name or to create the first status of a state machine.
+//
+// 🧯 Errors:
+//   - The key already exists → `ErrConditionNotMet`, and the stored value is returned with the error
+//   - The key holds a value other than a string → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	_, err := h.SetStringIfNotExists(ctx, swampName, "job-42", "pending")
+func (h *hydraidego) SetStringIfNotExists(ctx context.Context, swampName name.Name, key string, value string) (string, error) {
+	return h.setStringIf(ctx, swampName, key, value, &hydraidepbgo.SetStringCondition{
+		Operator: hydraidepbgo.SetIf_NOT_EXISTS,
+	})
+}
+
+// SetBoolIfEquals same logic as SetStringIfEquals but for bool values, e.g. a feature flag or a "done" mark switched
+// only by the first writer.
+//
+// 🔧 Example:
+//
+//	_, err := h.SetBoolIfEquals(ctx, swampName, "invoice-42-sent", false, true)
+func (h *hydraidego) SetBoolIfEquals(ctx context.Context, swampName name.Name, key string, expected bool, value bool) (bool, error) {
+	return h.setBoolIf(ctx, swampName, key, value, &hydraidepbgo.SetBoolCondition{
+		Operator: hydraidepbgo.SetIf_EQUAL,
+		Value:    expected,
+	})
+}
+
+// SetBoolIfNotExists same logic as SetStringIfNotExists but for bool values.
+func (h *hydraidego) SetBoolIfNotExists(ctx context.Context, swampName name.Name, key string, value bool) (bool, error) {
+	return h.setBoolIf(ctx, swampName, key, value, &hydraidepbgo.SetBoolCondition{
+		Operator: hydraidepbgo.SetIf_NOT_EXISTS,
+	})
+}
+
+func (h *hydraidego) setStringIf(ctx context.Context, swampName name.Name, key string, value string, condition *hydraidepbgo.SetStringCondition) (string, error) {
+
+	response, err := h.serviceClient(ctx, swampName).SetStringIf(ctx, &hydraidepbgo.SetStringIfRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Value:     value,
+		Condition: condition,
+	})
+	if err != nil {
+		return "", errorHandler(err)
+	}
+
+	if !response.GetIsSet() {
+		return response.GetValue(), NewError(ErrConditionNotMet, fmt.Sprintf("%s: %q", errorMessageConditionNotMet, response.GetValue()))
+	}
+
+	return response.GetValue(), nil
+
+}
+
+func (h *hydraidego) setBoolIf(ctx context.Context, swampName name.Name, key string, value bool, condition *hydraidepbgo.SetBoolCondition) (bool, error) {
+
+	response, err := h.serviceClient(ctx, swampName).SetBoolIf(ctx, &hydraidepbgo.SetBoolIfRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Value:     value,
+		Condition: condition,
+	})
+	if err != nil {
+		return false, errorHandler(err)
+	}
+
+	if !response.GetIsSet() {
+		return response.GetValue(), NewError(ErrConditionNotMet, fmt.Sprintf("%s: %t", errorMessageConditionNotMet, response.GetValue()))
+	}
+
+	return response.GetValue(), nil
+
+}
+
 type KeyValuesPair struct {
 	Key    string
 	Values []uint32
 }
 
+// Uint32SlicePushIteratorFunc is a callback used by Uint32SlicePush.
+//
+// It is invoked for each key of the push, in the order of the KeyValuesPairs, with:
+//   - `key`: The key of the slice
+//   - `added`: The number of the values added to the slice
+//   - `alreadyPresent`: The number of the values not added, because they were already in the slice, or they were
+//     repeated in the pushed values
+//
+// Returning an error will immediately halt the iteration, and Uint32SlicePush returns the error. The values are
+// already pushed at that point.
+type Uint32SlicePushIteratorFunc func(key string, added int, alreadyPresent int) error
+
 // Uint32SlicePush adds unique uint32 values to multiple slice-type Treasures within a given Swamp.
 //
 // For each key in the provided KeyValuesPair list, the function will push the given values
@@ -3548,6 +6857,8 @@ type KeyValuesPair struct {
 //   - ctx:           context for cancellation and timeout
 //   - swampName:     the target Swamp where the Treasures are stored
 //   - KeyValuesPair: list of keys and the values to add to each corresponding Treasure slice
+//   - iterator:      optional callback, called with the counts of the added and the already present values of
+//     each key, e.g. to track the progress of an indexing job. It can be nil
 //
 // Behavior:
 //   - If a value is **already present** in the slice, it will not be added again.
@@ -3558,21 +6869,25 @@ type KeyValuesPair struct {
 //
 // Returns:
 //   - nil if all operations succeed
-//   - error only if there is a low-level database or type mismatch issue
+//   - error only if there is a low-level database or type mismatch issue, or the error of the iterator
 //
 // ✅ Example usage:
 //
 //	err := sdk.Uint32SlicePush(ctx, "index:reverse", []*KeyValuesPair{
 //	  {Key: "domain:google.com", Values: []uint32{123, 456}},
 //	  {Key: "domain:openai.com",  Values: []uint32{789}},
+//	}, func(key string, added int, alreadyPresent int) error {
+//	  indexed += added
+//	  return nil
 //	})
 //
 //	// Result:
 //	// - domain:google.com slice will now include 123 and 456 (only if not already present)
 //	// - domain:openai.com slice will now include 789
-func (h *hydraidego) Uint32SlicePush(ctx context.Context, swampName name.Name, KeyValuesPair []*KeyValuesPair) error {
+//	// - indexed is increased by the number of the new values only
+func (h *hydraidego) Uint32SlicePush(ctx context.Context, swampName name.Name, KeyValuesPair []*KeyValuesPair, iterator Uint32SlicePushIteratorFunc) error {
 
-	keySlices := make([]*hydraidepbgo.KeySlicePair, len(KeyValuesPair))
+	keySlices := make([]*hydraidepbgo.KeySlicePair, 0, len(KeyValuesPair))
 
 	for _, value := range KeyValuesPair {
 		keySlices = append(keySlices, &hydraidepbgo.KeySlicePair{
@@ -3581,7 +6896,7 @@ func (h *hydraidego) Uint32SlicePush(ctx context.Context, swampName name.Name, K
 		})
 	}
 
-	_, err := h.client.GetServiceClient(swampName).Uint32SlicePush(ctx, &hydraidepbgo.AddToUint32SlicePushRequest{
+	response, err := h.serviceClient(ctx, swampName).Uint32SlicePush(ctx, &hydraidepbgo.AddToUint32SlicePushRequest{
 		IslandID:      swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName:     swampName.Get(),
 		KeySlicePairs: keySlices,
@@ -3591,6 +6906,14 @@ func (h *hydraidego) Uint32SlicePush(ctx context.Context, swampName name.Name, K
 		return errorHandler(err)
 	}
 
+	if iterator != nil {
+		for _, result := range response.GetResults() {
+			if iterErr := iterator(result.GetKey(), int(result.GetAdded()), int(result.GetAlreadyPresent())); iterErr != nil {
+				return iterErr
+			}
+		}
+	}
+
 	return nil
 
 }
@@ -3644,7 +6967,7 @@ func (h *hydraidego) Uint32SliceDelete(ctx context.Context, swampName name.Name,
 		})
 	}
 
-	_, err := h.client.GetServiceClient(swampName).Uint32SliceDelete(ctx, &hydraidepbgo.Uint32SliceDeleteRequest{
+	_, err := h.serviceClient(ctx, swampName).Uint32SliceDelete(ctx, &hydraidepbgo.Uint32SliceDeleteRequest{
 		IslandID:      swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName:     swampName.Get(),
 		KeySlicePairs: keySlices,
@@ -3690,14 +7013,16 @@ func (h *hydraidego) Uint32SliceDelete(ctx context.Context, swampName name.Name,
 //	fmt.Printf("Slice has %d items.\n", size)
 func (h *hydraidego) Uint32SliceSize(ctx context.Context, swampName name.Name, key string) (int64, error) {
 
-	response, err := h.client.GetServiceClient(swampName).Uint32SliceSize(ctx, &hydraidepbgo.Uint32SliceSizeRequest{
+	response, err := h.serviceClient(ctx, swampName).Uint32SliceSize(ctx, &hydraidepbgo.Uint32SliceSizeRequest{
 		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
 		SwampName: swampName.Get(),
 		Key:       key,
 	})
 
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
+		if reasonErr, found := errorFromReason(err); found {
+			return 0, reasonErr
+		} else if s, ok := status.FromError(err); ok {
 			switch s.Code() {
 			case codes.Unavailable:
 				return 0, NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -3760,35 +7085,285 @@ func (h *hydraidego) Uint32SliceSize(ctx context.Context, swampName name.Name, k
 //	}
 func (h *hydraidego) Uint32SliceIsValueExist(ctx context.Context, swampName name.Name, key string, value uint32) (bool, error) {
 
-	response, err := h.client.GetServiceClient(swampName).Uint32SliceIsValueExist(ctx, &hydraidepbgo.Uint32SliceIsValueExistRequest{
-		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
-		SwampName: swampName.Get(),
-		Key:       key,
-		Value:     value,
-	})
+	response, err := h.serviceClient(ctx, swampName).Uint32SliceIsValueExist(ctx, &hydraidepbgo.Uint32SliceIsValueExistRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Value:     value,
+	})
+
+	if err != nil {
+		if reasonErr, found := errorFromReason(err); found {
+			return false, reasonErr
+		} else if s, ok := status.FromError(err); ok {
+			switch s.Code() {
+			case codes.Unavailable:
+				return false, NewError(ErrCodeConnectionError, errorMessageConnectionError)
+			case codes.DeadlineExceeded:
+				return false, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
+			case codes.FailedPrecondition:
+				return false, NewError(ErrCodeFailedPrecondition, fmt.Sprintf("%v", s.Message()))
+			case codes.Internal:
+				return false, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
+			default:
+				return false, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+			}
+		} else {
+			return false, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
+		}
+	}
+
+	// if the request was successful
+	return response.GetIsExist(), nil
+
+}
+
+// Uint32SliceGetRange returns a page of the values of a slice-type Treasure, in the order they were pushed, and the
+// size of the whole slice.
+//
+// Use it to page through huge slices — e.g. a reverse index with millions of IDs — which do not fit into one gRPC
+// message, or to show a slice page by page.
+//
+// ⚙️ Behavior:
+//   - At most `limit` values are returned from the `offset`, the last page can be shorter
+//   - An offset beyond the end of the slice returns no values
+//   - The pages are read one by one: a push or a delete between two pages can shift the values. Use
+//     `Uint32SliceStream()` to read a consistent snapshot of the whole slice
+//
+// 🧯 Errors:
+//   - The offset is negative, or the limit is not positive → `ErrCodeInvalidArgument`
+//   - The key does not exist → `ErrCodeNotFound`
+//   - The key is not a uint32 slice → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	for offset := int64(0); ; offset += 1000 {
+//	    ids, size, err := h.Uint32SliceGetRange(ctx, swampName, "word:hydraide", offset, 1000)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(ids)
+//	    if offset+1000 >= size {
+//	        break
+//	    }
+//	}
+func (h *hydraidego) Uint32SliceGetRange(ctx context.Context, swampName name.Name, key string, offset int64, limit int64) ([]uint32, int64, error) {
+
+	if offset < 0 || limit <= 0 {
+		return nil, 0, NewError(ErrCodeInvalidArgument, "the offset cannot be negative, and the limit must be positive")
+	}
+
+	response, err := h.serviceClient(ctx, swampName).Uint32SliceGetRange(ctx, &hydraidepbgo.Uint32SliceGetRangeRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, 0, errorHandler(err)
+	}
+
+	return response.GetValues(), response.GetSize(), nil
+
+}
+
+// Uint32SliceStreamIteratorFunc is a callback used by Uint32SliceStream.
+//
+// It is invoked for each value of the slice, in the order they were pushed.
+// Returning an error will immediately stop the stream, and Uint32SliceStream returns the error.
+type Uint32SliceStreamIteratorFunc func(value uint32) error
+
+// Uint32SliceStream streams all values of a slice-type Treasure to the iterator, in the order they were pushed.
+//
+// The values come in batches, so neither the server nor the client holds a huge slice in one gRPC message, and the
+// client never holds more than one batch in memory.
+//
+// ⚙️ Behavior:
+//   - The stream is a snapshot of the slice at the start of the call: the pushes and the deletes during the stream
+//     do not shift the values
+//   - If the iterator returns an error, the stream is canceled
+//
+// 🧯 Errors:
+//   - The key does not exist → `ErrCodeNotFound`
+//   - The key is not a uint32 slice → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	err := h.Uint32SliceStream(ctx, swampName, "word:hydraide", func(domainID uint32) error {
+//	    return index.Add(domainID)
+//	})
+func (h *hydraidego) Uint32SliceStream(ctx context.Context, swampName name.Name, key string, iterator Uint32SliceStreamIteratorFunc) error {
+
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "the iterator cannot be nil")
+	}
+
+	// the stream is canceled if the iterator stops the iteration
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := h.serviceClient(ctx, swampName).Uint32SliceStream(streamCtx, &hydraidepbgo.Uint32SliceStreamRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errorHandler(err)
+		}
+		for _, value := range response.GetValues() {
+			if err := iterator(value); err != nil {
+				return err
+			}
+		}
+	}
+
+}
+
+// SliceOperator is the set operation of Uint32SliceCombine and Uint32SliceCombineInto.
+type SliceOperator int
+
+const (
+	SliceUnion        SliceOperator = iota + 1 // The values of any of the slices
+	SliceIntersection                          // The values of the first slice which are in all other slices
+	SliceDifference                            // The values of the first slice which are in none of the other slices
+)
+
+// Uint32SliceCombine computes the union, the intersection or the difference of the slice-type Treasures of many keys
+// on the server, and streams the result to the iterator.
+//
+// Only the result travels over the network — e.g. a search intersects the reverse indexes of the words of a query
+// without downloading millions of IDs.
+//
+// ⚙️ Behavior:
+//   - SliceUnion: the values of any of the keys, in the order of their first appearance
+//   - SliceIntersection: the values of the first key which are in all other keys, in the order of the first key
+//   - SliceDifference: the values of the first key which are in none of the other keys, in the order of the first key
+//   - A missing key is an empty slice, and no value is repeated in the result
+//   - The keys are read one by one: a push to a key during the combination may or may not be in the result
+//   - If the iterator returns an error, the stream is canceled
+//
+// 🧯 Errors:
+//   - No keys, an unknown operator or a nil iterator → `ErrCodeInvalidArgument`
+//   - A key is not a uint32 slice → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	// the domains of "hydraide" and "database", but not of "spam"
+//	err := h.Uint32SliceCombine(ctx, swampName, hydraidego.SliceIntersection, []string{"word:hydraide", "word:database"}, func(domainID uint32) error {
+//	    results = append(results, domainID)
+//	    return nil
+//	})
+func (h *hydraidego) Uint32SliceCombine(ctx context.Context, swampName name.Name, operator SliceOperator, keys []string, iterator Uint32SliceStreamIteratorFunc) error {
+
+	if iterator == nil {
+		return NewError(ErrCodeInvalidArgument, "the iterator cannot be nil")
+	}
+	if len(keys) == 0 {
+		return NewError(ErrCodeInvalidArgument, "the keys cannot be empty")
+	}
+	combinationOperator, err := convertSliceOperatorToProtoOperator(operator)
+	if err != nil {
+		return err
+	}
+
+	// the stream is canceled if the iterator stops the iteration
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := h.serviceClient(ctx, swampName).Uint32SliceCombine(streamCtx, &hydraidepbgo.Uint32SliceCombineRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Operator:  combinationOperator,
+		Keys:      keys,
+	})
+	if err != nil {
+		return errorHandler(err)
+	}
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errorHandler(err)
+		}
+		for _, value := range response.GetValues() {
+			if err := iterator(value); err != nil {
+				return err
+			}
+		}
+	}
+
+}
+
+// Uint32SliceCombineInto computes the same set operations as Uint32SliceCombine, but stores the result in the
+// destination key of the Swamp instead of returning it, and returns the size of the result.
+//
+// Use it to materialize a combination that is read many times, e.g. the active users of a tag, without the values
+// ever leaving the server.
+//
+// ⚙️ Behavior:
+//   - The destination key is replaced by the result, and deleted if the result is empty
+//   - The destination key can be one of the combined keys, e.g. to remove the values of other keys from it
+//   - The destination key is locked during the call, the other keys are not
+//   - 🔔 The subscribers of the Swamp get an event if the destination key changed
+//
+// 🧯 Errors:
+//   - No keys, an empty destination key or an unknown operator → `ErrCodeInvalidArgument`
+//   - A key or the destination key is not a uint32 slice → `ErrCodeFailedPrecondition`
+//
+// 🔧 Example:
+//
+//	size, err := h.Uint32SliceCombineInto(ctx, swampName, hydraidego.SliceDifference, []string{"tag:go", "tag:go:banned"}, "tag:go:visible")
+func (h *hydraidego) Uint32SliceCombineInto(ctx context.Context, swampName name.Name, operator SliceOperator, keys []string, destinationKey string) (int64, error) {
 
+	if len(keys) == 0 {
+		return 0, NewError(ErrCodeInvalidArgument, "the keys cannot be empty")
+	}
+	if destinationKey == "" {
+		return 0, NewError(ErrCodeInvalidArgument, "the destination key cannot be empty")
+	}
+	combinationOperator, err := convertSliceOperatorToProtoOperator(operator)
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			switch s.Code() {
-			case codes.Unavailable:
-				return false, NewError(ErrCodeConnectionError, errorMessageConnectionError)
-			case codes.DeadlineExceeded:
-				return false, NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
-			case codes.FailedPrecondition:
-				return false, NewError(ErrCodeFailedPrecondition, fmt.Sprintf("%v", s.Message()))
-			case codes.Internal:
-				return false, NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message()))
-			default:
-				return false, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
-			}
-		} else {
-			return false, NewError(ErrCodeUnknown, fmt.Sprintf("%s: %v", errorMessageUnknown, err))
-		}
+		return 0, err
 	}
 
-	// if the request was successful
-	return response.GetIsExist(), nil
+	response, err := h.serviceClient(ctx, swampName).Uint32SliceCombineInto(ctx, &hydraidepbgo.Uint32SliceCombineIntoRequest{
+		IslandID:       swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:      swampName.Get(),
+		Operator:       combinationOperator,
+		Keys:           keys,
+		DestinationKey: destinationKey,
+	})
+	if err != nil {
+		return 0, errorHandler(err)
+	}
+
+	return response.GetSize(), nil
+
+}
 
+func convertSliceOperatorToProtoOperator(operator SliceOperator) (hydraidepbgo.Uint32SliceCombination_Operator, error) {
+	switch operator {
+	case SliceUnion:
+		return hydraidepbgo.Uint32SliceCombination_UNION, nil
+	case SliceIntersection:
+		return hydraidepbgo.Uint32SliceCombination_INTERSECTION, nil
+	case SliceDifference:
+		return hydraidepbgo.Uint32SliceCombination_DIFFERENCE, nil
+	}
+	return 0, NewError(ErrCodeInvalidArgument, fmt.Sprintf("unknown slice operator: %d", operator))
 }
 
 func getKeyFromProfileModel(model any) ([]string, error) {
@@ -3815,7 +7390,7 @@ func getKeyFromProfileModel(model any) ([]string, error) {
 
 }
 
-func setTreasureValueToProfileModel(model any, treasure *hydraidepbgo.Treasure) error {
+func setTreasureValueToProfileModel(model any, treasure *hydraidepbgo.Treasure, strict bool) error {
 
 	key := treasure.GetKey()
 	// find the key in the model by the name of the field.
@@ -3828,7 +7403,7 @@ func setTreasureValueToProfileModel(model any, treasure *hydraidepbgo.Treasure)
 		if t.Field(i).Name == key {
 			// we found the key in the model
 			field := v.Field(i)
-			if err := setProtoTreasureToModel(treasure, field); err != nil {
+			if err := setProtoTreasureToModel(treasure, field, t.Field(i).Name, strict); err != nil {
 				return err
 			}
 		}
@@ -3888,6 +7463,16 @@ func convertOrderTypeToProtoOrderType(orderType IndexOrder) hydraidepbgo.OrderTy
 	}
 }
 
+// validateModel calls the Validate of the model, if the model implements the Validator
+func validateModel(model any) error {
+	if validator, ok := model.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("the validation of the model failed: %w", err)
+		}
+	}
+	return nil
+}
+
 // convertCatalogModelToKeyValuePair converts a Go struct (passed as pointer) into a HydrAIDE-compatible KeyValuePair message.
 //
 // 🧠 This is an **internal serialization helper** used by the Go SDK to translate user-defined models
@@ -3897,7 +7482,7 @@ func convertOrderTypeToProtoOrderType(orderType IndexOrder) hydraidepbgo.OrderTy
 // - `hydraide:"key"`       → Marks the string field to use as the Treasure key (must be non-empty).
 // - `hydraide:"value"`     → Marks the value field (can be any supported primitive or complex type).
 // - `hydraide:"expireAt"`  → Optional `time.Time`, marks the logical expiry time of the Treasure.
-// - `hydraide:"createdAt"` / `createdBy` / `updatedAt` / `updatedBy` → Optional metadata fields.
+// - `hydraide:"createdAt"` / `createdBy` / `updatedAt` / `updatedBy` → Optional metadata fields, the empty `createdBy` and `updatedBy` get the actor of WithActor.
 // - `hydraide:"omitempty"` → Skips the field during encoding if it's zero, nil, or empty.
 //
 // ✅ Supported value types:
@@ -3928,7 +7513,7 @@ func convertOrderTypeToProtoOrderType(orderType IndexOrder) hydraidepbgo.OrderTy
 // - Metadata injection
 // - Optional field skipping (e.g. omitempty)
 // - Consistent type coercion for known value types
-func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, error) {
+func convertCatalogModelToKeyValuePair(ctx context.Context, model any) (*hydraidepbgo.KeyValuePair, error) {
 
 	// Get the reflection value of the input model
 	v := reflect.ValueOf(model)
@@ -3940,6 +7525,10 @@ func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, e
 		return nil, errors.New("input must be a pointer to a struct")
 	}
 
+	if err := validateModel(model); err != nil {
+		return nil, err
+	}
+
 	// This flag tracks whether any value has been set.
 	// If no value is provided (only key or metadata), we'll later set VoidVal = true.
 	valueVoid := true
@@ -3998,6 +7587,26 @@ func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, e
 
 		}
 
+		// Process the `version` field (tagged with `hydraide:"version"`).
+		// The schema version of the model, used by the migrations registered with RegisterMigration.
+		// - Must be an integer type
+		// - A zero value is stamped with the latest registered version of the model
+		// - Not stored if the model has no version and no registered migrations
+		if key, ok := field.Tag.Lookup(tagHydrAIDE); ok && key == tagVersion {
+			value := v.Field(i)
+			if !isVersionKind(value.Kind()) {
+				return nil, errors.New("version field must be an integer")
+			}
+			if version := schemaVersionToWrite(t, value); version > 0 {
+				if version > math.MaxUint32 {
+					return nil, errors.New("version field must fit into uint32")
+				}
+				schemaVersion := uint32(version)
+				kvPair.SchemaVersion = &schemaVersion
+			}
+			continue
+		}
+
 		// Process the `expireAt` field (tagged with `hydraide:"expireAt"`).
 		// This defines the logical expiration time of the Treasure.
 		// Once the given timestamp is reached, HydrAIDE will treat the record as expired.
@@ -4021,14 +7630,13 @@ func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, e
 		// Process the `createdBy` field (tagged with `hydraide:"createdBy"`).
 		// Optional metadata indicating who or what created the Treasure.
 		// - Must be of type `string`
-		// - Empty values are ignored
+		// - Empty values get the actor of the context, or they are ignored without actor
 		if key, ok := field.Tag.Lookup(tagHydrAIDE); ok && key == tagCreatedBy {
 			value := v.Field(i)
 			if value.Kind() != reflect.String {
 				return nil, errors.New("createdBy field must be a string")
 			}
-			if value.String() != "" {
-				createdBy := value.String()
+			if createdBy := actorOrDefault(ctx, value.String()); createdBy != "" {
 				kvPair.CreatedBy = &createdBy
 				valueVoid = false
 			}
@@ -4057,14 +7665,13 @@ func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, e
 		// Process the `updatedBy` field (tagged with `hydraide:"updatedBy"`).
 		// Optional metadata indicating who or what last updated the Treasure.
 		// - Must be of type `string`
-		// - Ignored if empty
+		// - Empty values get the actor of the context, or they are ignored without actor
 		if key, ok := field.Tag.Lookup(tagHydrAIDE); ok && key == tagUpdatedBy {
 			value := v.Field(i)
 			if value.Kind() != reflect.String {
 				return nil, errors.New("updatedBy field must be a string")
 			}
-			if value.String() != "" {
-				updatedBy := value.String()
+			if updatedBy := actorOrDefault(ctx, value.String()); updatedBy != "" {
 				kvPair.UpdatedBy = &updatedBy
 				valueVoid = false
 			}
@@ -4109,6 +7716,49 @@ func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, e
 
 }
 
+// catalogModelProjection returns the projection of the parts of the Treasures the catalog model has fields for.
+// It returns nil if the model needs the whole Treasures, or it is not a struct.
+func catalogModelProjection(model any) *hydraidepbgo.Projection {
+
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	projection := &hydraidepbgo.Projection{}
+	for i := 0; i < t.NumField(); i++ {
+		switch t.Field(i).Tag.Get(tagHydrAIDE) {
+		case tagValue:
+			projection.Value = true
+		case tagCreatedAt:
+			projection.CreatedAt = true
+		case tagCreatedBy:
+			projection.CreatedBy = true
+		case tagUpdatedAt:
+			projection.UpdatedAt = true
+		case tagUpdatedBy:
+			projection.UpdatedBy = true
+		case tagExpireAt:
+			projection.ExpiredAt = true
+		}
+	}
+
+	// the model needs every part, so the whole Treasures are read
+	if projection.Value && projection.CreatedAt && projection.CreatedBy && projection.UpdatedAt &&
+		projection.UpdatedBy && projection.ExpiredAt {
+		return nil
+	}
+
+	return projection
+
+}
+
 // convertProtoTreasureToCatalogModel maps a hydraidepbgo.Treasure protobuf object back into a Go struct.
 //
 // The target model must be a pointer to a struct. Fields are matched using `hydraide` struct tags:
@@ -4124,7 +7774,7 @@ func convertCatalogModelToKeyValuePair(model any) (*hydraidepbgo.KeyValuePair, e
 //
 // If the field type does not match the Treasure value type, it is silently skipped.
 // If decoding fails (e.g. from GOB), an error is returned.
-func convertProtoTreasureToCatalogModel(treasure *hydraidepbgo.Treasure, model any) error {
+func convertProtoTreasureToCatalogModel(treasure *hydraidepbgo.Treasure, model any, strict bool) error {
 
 	v := reflect.ValueOf(model)
 	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
@@ -4144,7 +7794,7 @@ func convertProtoTreasureToCatalogModel(treasure *hydraidepbgo.Treasure, model a
 			field := v.Elem().Field(i)
 
 			// set proto treasure to model
-			if err := setProtoTreasureToModel(treasure, field); err != nil {
+			if err := setProtoTreasureToModel(treasure, field, t.Field(i).Name, strict); err != nil {
 				return err
 			}
 
@@ -4152,6 +7802,13 @@ func convertProtoTreasureToCatalogModel(treasure *hydraidepbgo.Treasure, model a
 
 		}
 
+		if key, ok := t.Field(i).Tag.Lookup(tagHydrAIDE); ok && key == tagVersion {
+			if treasure.SchemaVersion != nil {
+				setVersionFieldValue(v.Elem().Field(i), uint64(treasure.GetSchemaVersion()))
+			}
+			continue
+		}
+
 		if key, ok := t.Field(i).Tag.Lookup(tagHydrAIDE); ok && key == tagExpireAt {
 			if treasure.ExpiredAt != nil {
 				v.Elem().Field(i).Set(reflect.ValueOf(treasure.ExpiredAt.AsTime()))
@@ -4193,7 +7850,47 @@ func convertProtoTreasureToCatalogModel(treasure *hydraidepbgo.Treasure, model a
 
 }
 
-func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Value) error {
+// setServerTimestampsToCatalogModel copies the creation and modification times assigned by the server to the
+// `createdAt` and `updatedAt` fields of the model. The server sends them only for the Swamps registered with
+// ServerTimestamps, so the model is untouched otherwise.
+func setServerTimestampsToCatalogModel(keyStatus *hydraidepbgo.KeyStatusPair, model any) {
+
+	if keyStatus.GetCreatedAt() == nil && keyStatus.GetUpdatedAt() == nil {
+		return
+	}
+
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	timeType := reflect.TypeOf(time.Time{})
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+
+		field := v.Elem().Field(i)
+		if field.Type() != timeType || !field.CanSet() {
+			continue
+		}
+
+		switch t.Field(i).Tag.Get(tagHydrAIDE) {
+		case tagCreatedAt:
+			if keyStatus.GetCreatedAt() != nil {
+				field.Set(reflect.ValueOf(keyStatus.GetCreatedAt().AsTime()))
+			}
+		case tagUpdatedAt:
+			if keyStatus.GetUpdatedAt() != nil {
+				field.Set(reflect.ValueOf(keyStatus.GetUpdatedAt().AsTime()))
+			}
+		}
+
+	}
+
+}
+
+// setProtoTreasureToModel sets the value of the treasure to the field of the model. A field whose type differs from
+// the type of the stored value is skipped, or in strict mode, a *TypeMismatch is returned with the name of the field.
+func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Value, fieldName string, strict bool) error {
 
 	if treasure.StringVal != nil {
 		switch field.Kind() {
@@ -4201,7 +7898,8 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			field.SetString(treasure.GetStringVal())
 			return nil
 		default:
-			return nil
+			// skip the field because the value type is not the same as the model field type
+			return typeMismatch(strict, fieldName, "string", field)
 		}
 	}
 
@@ -4212,7 +7910,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "uint8", field)
 		}
 	}
 
@@ -4223,7 +7921,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "uint16", field)
 		}
 	}
 
@@ -4234,7 +7932,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "uint32", field)
 		}
 	}
 
@@ -4245,7 +7943,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "uint64", field)
 		}
 	}
 
@@ -4256,7 +7954,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "int8", field)
 		}
 	}
 
@@ -4267,7 +7965,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "int16", field)
 		}
 	}
 
@@ -4278,7 +7976,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "int32", field)
 		}
 	}
 
@@ -4295,12 +7993,13 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 				// konvertáljuk vissza time.Time-ra az int64 UNIX timestampet
 				timestamp := time.Unix(treasure.GetInt64Val(), 0).UTC()
 				field.Set(reflect.ValueOf(timestamp))
+				return nil
 			}
-			return nil
+			return typeMismatch(strict, fieldName, "int64", field)
 
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "int64", field)
 		}
 	}
 
@@ -4311,7 +8010,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "float32", field)
 		}
 	}
 
@@ -4322,7 +8021,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "float64", field)
 		}
 	}
 
@@ -4333,7 +8032,7 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			return nil
 		default:
 			// skip the field because the value type is not the same as the model field type
-			return nil
+			return typeMismatch(strict, fieldName, "bool", field)
 		}
 	}
 
@@ -4366,7 +8065,8 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 			field.Set(reflect.ValueOf(decoded).Elem())
 
 		default:
-			return nil
+			// skip the field because the value type is not the same as the model field type
+			return typeMismatch(strict, fieldName, "bytes", field)
 		}
 	}
 
@@ -4374,6 +8074,18 @@ func setProtoTreasureToModel(treasure *hydraidepbgo.Treasure, field reflect.Valu
 
 }
 
+// typeMismatch returns nil, so the field is skipped, or in strict mode the mismatch of the stored type and the field
+func typeMismatch(strict bool, fieldName string, storedType string, field reflect.Value) error {
+	if !strict {
+		return nil
+	}
+	return &TypeMismatch{
+		Field:      fieldName,
+		StoredType: storedType,
+		ModelType:  field.Type().String(),
+	}
+}
+
 // convertComplexModelToKeyValuePair convert a complex model to a key value pair
 func convertProfileModelToKeyValuePair(model any) ([]*hydraidepbgo.KeyValuePair, error) {
 
@@ -4385,6 +8097,10 @@ func convertProfileModelToKeyValuePair(model any) ([]*hydraidepbgo.KeyValuePair,
 		return nil, errors.New("input must be a pointer to a struct")
 	}
 
+	if err := validateModel(model); err != nil {
+		return nil, err
+	}
+
 	var kvPairs []*hydraidepbgo.KeyValuePair
 
 	v = v.Elem()
@@ -4410,6 +8126,14 @@ func convertProfileModelToKeyValuePair(model any) ([]*hydraidepbgo.KeyValuePair,
 		// ellenőrizzük, hogy mi a mező típusa és annak megfelelően beállítjuk a value-t
 		value := v.Field(i)
 
+		// the schema version is stored as a normal field of the profile, but a zero version is stamped with the
+		// latest registered version of the model
+		if tag, ok := field.Tag.Lookup(tagHydrAIDE); ok && tag == tagVersion && isVersionKind(value.Kind()) {
+			stamped := reflect.New(value.Type()).Elem()
+			setVersionFieldValue(stamped, schemaVersionToWrite(t, value))
+			value = stamped
+		}
+
 		// convert to KeyValuePair the value
 		if err := convertFieldToKvPair(value, kvPair); err != nil {
 			return nil, err
@@ -4549,91 +8273,303 @@ func convertFieldToKvPair(value reflect.Value, kvPair *hydraidepbgo.KeyValuePair
 			break
 		}
 
-		kvPair.BytesVal = buf.Bytes()
+		kvPair.BytesVal = buf.Bytes()
+
+	// 🕒 Special case for time.Time → store as int64 (Unix timestamp)
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(time.Time{}) {
+			timeValue := value.Interface().(time.Time)
+			if !timeValue.IsZero() {
+				intVal := timeValue.UTC().Unix()
+				kvPair.Int64Val = &intVal
+			}
+		}
+
+	// ❌ Any other unsupported type is rejected explicitly
+	default:
+		err = errors.New(fmt.Sprintf("unsupported value type: %s", value.Kind().String()))
+	}
+
+	return err
+
+}
+
+var (
+	// registeredTypes keeps track of all types registered with gob to prevent duplicate registrations.
+	registeredTypes = make(map[reflect.Type]struct{})
+	mutex           sync.Mutex
+)
+
+// registerGobTypeIfNeeded safely registers a type with the gob encoder,
+// making sure the same type is not registered multiple times.
+//
+// This function handles both pointer and base types by recursively registering
+// the underlying struct when a pointer is provided.
+//
+// If the type has already been registered, the function does nothing.
+// If gob.Register panics (e.g. due to naming conflicts), it recovers gracefully.
+func registerGobTypeIfNeeded(val interface{}) {
+	t := reflect.TypeOf(val)
+
+	// If it's a pointer, first register the underlying base type (e.g., *StructX -> StructX)
+	if t.Kind() == reflect.Ptr {
+		registerGobTypeIfNeeded(reflect.Zero(t.Elem()).Interface())
+		// Avoid registering the pointer type separately to reduce conflict risk.
+		// Remove this return if you want to explicitly register both base and pointer types.
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Only register if not already registered
+	if _, ok := registeredTypes[t]; !ok {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Printf("Recovered in registerGobTypeIfNeeded: %v\n", r)
+			}
+		}()
+
+		gob.Register(val)
+		registeredTypes[t] = struct{}{}
+	}
+}
+
+// convertProtoStatusToStatus convert the proto status to the event status
+func convertProtoStatusToStatus(status hydraidepbgo.Status_Code) EventStatus {
+
+	switch status {
+	case hydraidepbgo.Status_NOT_FOUND:
+		return StatusTreasureNotFound
+	case hydraidepbgo.Status_NEW:
+		return StatusNew
+	case hydraidepbgo.Status_UPDATED:
+		return StatusModified
+	case hydraidepbgo.Status_DELETED:
+		return StatusDeleted
+	case hydraidepbgo.Status_NOTHING_CHANGED:
+		return StatusNothingChanged
+	default:
+		return StatusNothingChanged
+	}
+
+}
+
+// setInBatches sends the KeyValues of one SwampRequest in as many Set requests as needed to keep every request under
+// the max message size of the client.
+//
+// The batches are sent one after the other, and the SwampResponses of the batches are returned in one SetResponse,
+// in the order of the KeyValues. The Set is not atomic, so if a batch fails, the Treasures of the previous batches
+// are already written. If the Swamp does not exist, the next batches are not sent.
+func (h *hydraidego) setInBatches(ctx context.Context, swampName name.Name, swampRequest *hydraidepbgo.SwampRequest) (*hydraidepbgo.SetResponse, error) {
+
+	kvPairs := swampRequest.GetKeyValues()
+	swampRequest.KeyValues = nil
+
+	setResponse := &hydraidepbgo.SetResponse{}
+
+	for _, batch := range splitKeyValues(swampRequest, kvPairs, h.client.GetMaxMessageSize()) {
+
+		batchRequest := proto.Clone(swampRequest).(*hydraidepbgo.SwampRequest)
+		batchRequest.KeyValues = batch
+
+		response, err := h.set(ctx, swampName, batchRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		setResponse.Swamps = append(setResponse.Swamps, response.GetSwamps()...)
+		for _, swamp := range response.GetSwamps() {
+			if swamp.GetErrorCode() == hydraidepbgo.SwampResponse_SwampDoesNotExist {
+				return setResponse, nil
+			}
+		}
+
+	}
+
+	return setResponse, nil
+
+}
+
+// set sends one SwampRequest by a Set.
+//
+// If the SwampRequest has only one KeyValue, and its bytes value (e.g. a GOB-encoded struct) makes the request larger
+// than the max message size of the client, the value is sent in chunks by the SetLargeValue instead, and the server
+// reassembles it. The response is the same as the response of the Set.
+func (h *hydraidego) set(ctx context.Context, swampName name.Name, swampRequest *hydraidepbgo.SwampRequest) (*hydraidepbgo.SetResponse, error) {
+
+	serviceClient := h.serviceClient(ctx, swampName)
+	request := &hydraidepbgo.SetRequest{Swamps: []*hydraidepbgo.SwampRequest{swampRequest}}
+
+	maxMessageSize := h.client.GetMaxMessageSize()
+	kvPairs := swampRequest.GetKeyValues()
+	if maxMessageSize <= 0 || len(kvPairs) != 1 || len(kvPairs[0].GetBytesVal()) == 0 || proto.Size(request) <= maxMessageSize {
+		return serviceClient.Set(ctx, request)
+	}
+
+	kvPair := kvPairs[0]
+	value := kvPair.GetBytesVal()
+
+	// the KeyValue is sent without the value in the first message, the value follows in chunks
+	kvPair.BytesVal = nil
+	header := proto.Clone(kvPair).(*hydraidepbgo.KeyValuePair)
+	kvPair.BytesVal = value
+
+	stream, err := serviceClient.SetLargeValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*hydraidepbgo.SetLargeValueRequest{{
+		IslandID:         swampRequest.GetIslandID(),
+		SwampName:        swampRequest.GetSwampName(),
+		KeyValue:         header,
+		CreateIfNotExist: swampRequest.GetCreateIfNotExist(),
+		Overwrite:        swampRequest.GetOverwrite(),
+	}}
+	for _, chunk := range splitLargeValue(value, maxMessageSize) {
+		messages = append(messages, &hydraidepbgo.SetLargeValueRequest{Chunk: chunk})
+	}
+
+	for _, message := range messages {
+		if err := stream.Send(message); err != nil {
+			if errors.Is(err, io.EOF) {
+				// the server closed the stream, the reason is returned by the CloseAndRecv
+				break
+			}
+			return nil, err
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	setResponse := &hydraidepbgo.SetResponse{}
+	if response.GetSwamp() != nil {
+		setResponse.Swamps = []*hydraidepbgo.SwampResponse{response.GetSwamp()}
+	}
+
+	return setResponse, nil
+
+}
+
+// getLargeValue reads one Treasure by the GetLargeValue, whose value is too large for one message, and returns it
+// in the same form as the Get. The returned errors are SDK errors.
+func (h *hydraidego) getLargeValue(ctx context.Context, swampName name.Name, key string) (*hydraidepbgo.GetResponse, error) {
+
+	stream, err := h.serviceClient(ctx, swampName).GetLargeValue(ctx, &hydraidepbgo.GetLargeValueRequest{
+		IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName: swampName.Get(),
+		Key:       key,
+		ChunkSize: uint32(largeValueChunkSize(h.client.GetMaxMessageSize())),
+	})
+	if err != nil {
+		return nil, errorHandler(err)
+	}
+
+	var treasure *hydraidepbgo.Treasure
+	var value []byte
 
-	// 🕒 Special case for time.Time → store as int64 (Unix timestamp)
-	case reflect.Struct:
-		if value.Type() == reflect.TypeOf(time.Time{}) {
-			timeValue := value.Interface().(time.Time)
-			if !timeValue.IsZero() {
-				intVal := timeValue.UTC().Unix()
-				kvPair.Int64Val = &intVal
-			}
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errorHandler(err)
+		}
+		if treasure == nil {
+			treasure = response.GetTreasure()
+			continue
 		}
+		value = append(value, response.GetChunk()...)
+	}
 
-	// ❌ Any other unsupported type is rejected explicitly
-	default:
-		err = errors.New(fmt.Sprintf("unsupported value type: %s", value.Kind().String()))
+	if treasure == nil {
+		return nil, NewError(ErrCodeUnknown, fmt.Sprintf("%s: the stream of the large value ended without the treasure", errorMessageUnknown))
+	}
+	if len(value) > 0 {
+		treasure.BytesVal = value
 	}
 
-	return err
+	return &hydraidepbgo.GetResponse{
+		Swamps: []*hydraidepbgo.GetSwampResponse{
+			{
+				SwampName: swampName.Get(),
+				IsExist:   true,
+				Treasures: []*hydraidepbgo.Treasure{treasure},
+			},
+		},
+	}, nil
 
 }
 
-var (
-	// registeredTypes keeps track of all types registered with gob to prevent duplicate registrations.
-	registeredTypes = make(map[reflect.Type]struct{})
-	mutex           sync.Mutex
-)
-
-// registerGobTypeIfNeeded safely registers a type with the gob encoder,
-// making sure the same type is not registered multiple times.
-//
-// This function handles both pointer and base types by recursively registering
-// the underlying struct when a pointer is provided.
-//
-// If the type has already been registered, the function does nothing.
-// If gob.Register panics (e.g. due to naming conflicts), it recovers gracefully.
-func registerGobTypeIfNeeded(val interface{}) {
-	t := reflect.TypeOf(val)
+// largeValueChunkSize returns the size of the chunks of a large value. The chunks are at most 1 MB, and they leave
+// room for the rest of the message under the max message size.
+func largeValueChunkSize(maxMessageSize int) int {
+	if maxMessageSize <= 0 {
+		return maxLargeValueChunkSize
+	}
+	return max(1, min(maxLargeValueChunkSize, maxMessageSize/2))
+}
 
-	// If it's a pointer, first register the underlying base type (e.g., *StructX -> StructX)
-	if t.Kind() == reflect.Ptr {
-		registerGobTypeIfNeeded(reflect.Zero(t.Elem()).Interface())
-		// Avoid registering the pointer type separately to reduce conflict risk.
-		// Remove this return if you want to explicitly register both base and pointer types.
-		return
+// splitLargeValue splits the value into chunks for the SetLargeValue
+func splitLargeValue(value []byte, maxMessageSize int) [][]byte {
+	chunkSize := largeValueChunkSize(maxMessageSize)
+	chunks := make([][]byte, 0, len(value)/chunkSize+1)
+	for start := 0; start < len(value); start += chunkSize {
+		chunks = append(chunks, value[start:min(start+chunkSize, len(value))])
 	}
+	return chunks
+}
 
-	mutex.Lock()
-	defer mutex.Unlock()
+// splitKeyValues splits the KeyValues into batches, so the SetRequest of every batch with the given SwampRequest is
+// not larger than the max message size. The size is calculated exactly, as the KeyValues are serialized in the
+// request. A KeyValue that is too large alone gets its own batch, which is sent in chunks if it has a bytes value,
+// otherwise it fails with ErrCodeMessageTooLarge. If the max message size is 0, all KeyValues are in one batch.
+func splitKeyValues(swampRequest *hydraidepbgo.SwampRequest, kvPairs []*hydraidepbgo.KeyValuePair, maxMessageSize int) [][]*hydraidepbgo.KeyValuePair {
 
-	// Only register if not already registered
-	if _, ok := registeredTypes[t]; !ok {
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Printf("Recovered in registerGobTypeIfNeeded: %v\n", r)
-			}
-		}()
+	if maxMessageSize <= 0 || len(kvPairs) == 0 {
+		return [][]*hydraidepbgo.KeyValuePair{kvPairs}
+	}
 
-		gob.Register(val)
-		registeredTypes[t] = struct{}{}
+	// the size of the SetRequest with a SwampRequest of the given size
+	requestSize := func(swampRequestSize int) int {
+		return protowire.SizeTag(setRequestSwampsField) + protowire.SizeBytes(swampRequestSize)
 	}
-}
 
-// convertProtoStatusToStatus convert the proto status to the event status
-func convertProtoStatusToStatus(status hydraidepbgo.Status_Code) EventStatus {
+	baseSize := proto.Size(swampRequest)
+	swampRequestSize := baseSize
+
+	var batches [][]*hydraidepbgo.KeyValuePair
+	var batch []*hydraidepbgo.KeyValuePair
+
+	for _, kvPair := range kvPairs {
+
+		kvPairSize := protowire.SizeTag(swampRequestKeyValuesField) + protowire.SizeBytes(proto.Size(kvPair))
+
+		if len(batch) > 0 && requestSize(swampRequestSize+kvPairSize) > maxMessageSize {
+			batches = append(batches, batch)
+			batch = nil
+			swampRequestSize = baseSize
+		}
+
+		batch = append(batch, kvPair)
+		swampRequestSize += kvPairSize
 
-	switch status {
-	case hydraidepbgo.Status_NOT_FOUND:
-		return StatusTreasureNotFound
-	case hydraidepbgo.Status_NEW:
-		return StatusNew
-	case hydraidepbgo.Status_UPDATED:
-		return StatusModified
-	case hydraidepbgo.Status_DELETED:
-		return StatusDeleted
-	case hydraidepbgo.Status_NOTHING_CHANGED:
-		return StatusNothingChanged
-	default:
-		return StatusNothingChanged
 	}
 
+	return append(batches, batch)
+
 }
 
 func errorHandler(err error) error {
 
-	if s, ok := status.FromError(err); ok {
+	if reasonErr, found := errorFromReason(err); found {
+		return reasonErr
+	} else if s, ok := status.FromError(err); ok {
 		switch s.Code() {
 		case codes.Unavailable:
 			return NewError(ErrCodeConnectionError, errorMessageConnectionError)
@@ -4654,6 +8590,146 @@ func errorHandler(err error) error {
 
 }
 
+// errorFromReason maps the machine-readable reason attached by the server to the gRPC status to an SDK error.
+//
+// The server sends the reason as a google.rpc.ErrorInfo detail in the "hydraide" domain. The reason is more precise
+// than the status code (e.g. a FailedPrecondition can mean a missing swamp or a missing value index), so it has
+// priority over the status code. Returns false if the error carries no known reason, for example because the
+// server is an older version, in which case the caller falls back to the status code.
+func errorFromReason(err error) (error, bool) {
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+
+	for _, detail := range s.Details() {
+
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != errorDomain {
+			continue
+		}
+
+		reason, ok := hydraidepbgo.ErrorReason_Reason_value[info.GetReason()]
+		if !ok {
+			continue
+		}
+
+		switch hydraidepbgo.ErrorReason_Reason(reason) {
+		case hydraidepbgo.ErrorReason_SWAMP_NOT_FOUND:
+			return NewError(ErrCodeSwampNotFound, fmt.Sprintf("%s: %v", errorMessageSwampNotFound, s.Message())), true
+		case hydraidepbgo.ErrorReason_KEY_NOT_FOUND:
+			return NewError(ErrCodeNotFound, fmt.Sprintf("%s: %v", errorMessageKeyNotFound, s.Message())), true
+		case hydraidepbgo.ErrorReason_KEY_EXISTS:
+			return NewError(ErrCodeAlreadyExists, fmt.Sprintf("%s: %v", errorMessageKeyAlreadyExists, s.Message())), true
+		case hydraidepbgo.ErrorReason_CONDITION_NOT_MET:
+			return NewError(ErrConditionNotMet, s.Message()), true
+		case hydraidepbgo.ErrorReason_QUOTA_EXCEEDED:
+			return &Error{
+				Code:       ErrCodeQuotaExceeded,
+				Message:    fmt.Sprintf("%s: %v", errorMessageQuotaExceeded, s.Message()),
+				RetryAfter: retryDelayFromStatus(s),
+				Limit:      limitViolationFromErrorInfo(info),
+			}, true
+		case hydraidepbgo.ErrorReason_LIMIT_EXCEEDED:
+			return &Error{
+				Code:    ErrCodeInvalidArgument,
+				Message: fmt.Sprintf("%s: %v", errorMessageInvalidArgument, s.Message()),
+				Limit:   limitViolationFromErrorInfo(info),
+			}, true
+		case hydraidepbgo.ErrorReason_INVALID_ARGUMENT, hydraidepbgo.ErrorReason_INVALID_FILTER_EXPRESSION:
+			return NewError(ErrCodeInvalidArgument, fmt.Sprintf("%s: %v", errorMessageInvalidArgument, s.Message())), true
+		case hydraidepbgo.ErrorReason_WRONG_VALUE_TYPE:
+			return NewError(ErrCodeFailedPrecondition, fmt.Sprintf("%s: %v", errorMessageWrongValueType, s.Message())), true
+		case hydraidepbgo.ErrorReason_VALUE_INDEX_NOT_ENABLED:
+			return NewError(ErrCodeFailedPrecondition, s.Message()), true
+		case hydraidepbgo.ErrorReason_LOCK_NOT_FOUND:
+			return NewError(ErrCodeNotFound, s.Message()), true
+		case hydraidepbgo.ErrorReason_LOCK_DEADLINE_EXCEEDED:
+			return NewError(ErrCodeCtxTimeout, s.Message()), true
+		case hydraidepbgo.ErrorReason_INTERNAL:
+			return NewError(ErrCodeInternalDatabaseError, fmt.Sprintf("%s: %v", errorMessageInternalError, s.Message())), true
+		case hydraidepbgo.ErrorReason_DATA_CORRUPTED:
+			return NewError(ErrCodeDataCorrupted, fmt.Sprintf("%s: %v", errorMessageDataCorrupted, s.Message())), true
+		case hydraidepbgo.ErrorReason_REPLAY_NOT_AVAILABLE:
+			return NewError(ErrCodeReplayNotAvailable, fmt.Sprintf("%s: %v", errorMessageReplayNotAvailable, s.Message())), true
+		case hydraidepbgo.ErrorReason_LEASE_NOT_FOUND:
+			return NewError(ErrCodeLeaseNotFound, fmt.Sprintf("%s: %v", errorMessageLeaseNotFound, s.Message())), true
+		case hydraidepbgo.ErrorReason_VERSION_NOT_FOUND:
+			return NewError(ErrCodeNotFound, fmt.Sprintf("%s: %v", errorMessageVersionNotFound, s.Message())), true
+		case hydraidepbgo.ErrorReason_BLOB_NOT_FOUND:
+			return NewError(ErrCodeNotFound, fmt.Sprintf("%s: %v", errorMessageBlobNotFound, s.Message())), true
+		case hydraidepbgo.ErrorReason_MESSAGE_TOO_LARGE:
+			return NewError(ErrCodeMessageTooLarge, fmt.Sprintf("%s: %v", errorMessageMessageTooLarge, s.Message())), true
+		case hydraidepbgo.ErrorReason_INSUFFICIENT_STORAGE:
+			return NewError(ErrCodeInsufficientStorage, fmt.Sprintf("%s: %v", errorMessageInsufficientStorage, s.Message())), true
+		case hydraidepbgo.ErrorReason_AUDIT_LOG_DISABLED:
+			return NewError(ErrCodeAuditLogDisabled, fmt.Sprintf("%s: %v", errorMessageAuditLogDisabled, s.Message())), true
+		case hydraidepbgo.ErrorReason_SCHEMA_VIOLATION:
+			return NewError(ErrCodeSchemaViolation, fmt.Sprintf("%s: %v", errorMessageSchemaViolation, s.Message())), true
+		case hydraidepbgo.ErrorReason_SUBSCRIBER_OVERFLOW:
+			return NewError(ErrCodeSubscriberOverflow, fmt.Sprintf("%s: %v", errorMessageSubscriberOverflow, s.Message())), true
+		case hydraidepbgo.ErrorReason_POINT_IN_TIME_RESTORE_DISABLED:
+			return NewError(ErrCodePointInTimeRestoreDisabled, fmt.Sprintf("%s: %v", errorMessagePITRDisabled, s.Message())), true
+		case hydraidepbgo.ErrorReason_POINT_IN_TIME_NOT_COVERED:
+			return NewError(ErrCodePointInTimeNotCovered, fmt.Sprintf("%s: %v", errorMessagePointNotCovered, s.Message())), true
+		case hydraidepbgo.ErrorReason_CONSISTENCY_NOT_REACHED:
+			return NewError(ErrCodeConsistencyNotReached, fmt.Sprintf("%s: %v", errorMessageConsistency, s.Message())), true
+		case hydraidepbgo.ErrorReason_ISLAND_MIGRATING:
+			return NewError(ErrCodeIslandMigrating, fmt.Sprintf("%s: %v", errorMessageIslandMigrating, s.Message())), true
+		case hydraidepbgo.ErrorReason_ISLAND_MOVED:
+			return NewError(ErrCodeIslandMoved, fmt.Sprintf("%s: %v", errorMessageIslandMoved, s.Message())), true
+		case hydraidepbgo.ErrorReason_SWAMP_PATTERN_NOT_FOUND:
+			return NewError(ErrCodeNotFound, fmt.Sprintf("%s: %v", errorMessagePatternNotFound, s.Message())), true
+		case hydraidepbgo.ErrorReason_PATTERN_CONFLICT:
+			return &Error{
+				Code:               ErrCodePatternConflict,
+				Message:            fmt.Sprintf("%s: %v", errorMessagePatternConflict, s.Message()),
+				ConflictingPattern: conflictingPatternFromStatus(s),
+			}, true
+		default:
+			// unspecified reason, the status code decides
+		}
+
+	}
+
+	return nil, false
+
+}
+
+// convertMetadataFieldToProto converts the metadata field to the proto field
+func convertMetadataFieldToProto(field MetadataField) hydraidepbgo.Metadata_Field {
+	switch field {
+	case MetadataCreatedBy:
+		return hydraidepbgo.Metadata_CREATED_BY
+	case MetadataUpdatedAt:
+		return hydraidepbgo.Metadata_UPDATED_AT
+	case MetadataUpdatedBy:
+		return hydraidepbgo.Metadata_UPDATED_BY
+	case MetadataExpireAt:
+		return hydraidepbgo.Metadata_EXPIRED_AT
+	default:
+		return hydraidepbgo.Metadata_CREATED_AT
+	}
+}
+
+// convertIndexTypeToProtoBeacon converts the index type to the proto beacon. All value index types are the value
+// beacon.
+func convertIndexTypeToProtoBeacon(indexType IndexType) hydraidepbgo.Beacon_Type {
+	switch indexType {
+	case IndexExpirationTime:
+		return hydraidepbgo.Beacon_EXPIRATION_TIME
+	case IndexCreationTime:
+		return hydraidepbgo.Beacon_CREATION_TIME
+	case IndexUpdateTime:
+		return hydraidepbgo.Beacon_UPDATE_TIME
+	case IndexKey:
+		return hydraidepbgo.Beacon_KEY
+	default:
+		return hydraidepbgo.Beacon_VALUE
+	}
+}
+
 // ConvertRelationalOperatorToProtoOperator connvert the relational operator to proto operator
 func convertRelationalOperatorToProtoOperator(operator RelationalOperator) hydraidepbgo.Relational_Operator {
 	switch operator {
@@ -4691,12 +8767,61 @@ const (
 	ErrCodeInvalidModel
 	ErrConditionNotMet
 	ErrCodeUnknown
+	ErrCodeQuotaExceeded
+	ErrCodeDataCorrupted
+	ErrCodeReplayNotAvailable
+	ErrCodeLeaseNotFound
+	ErrCodeMessageTooLarge
+	ErrCodeInsufficientStorage
+	ErrCodeAuditLogDisabled
+	ErrCodeSchemaViolation
+	ErrCodeIslandMismatch
+	ErrCodeSubscriberOverflow
+	ErrCodePointInTimeRestoreDisabled
+	ErrCodePointInTimeNotCovered
+	ErrCodeConsistencyNotReached
+	ErrCodeIslandMigrating
+	ErrCodeIslandMoved
+	ErrCodePatternConflict
 )
 
 // Error represents a structured error used across HydrAIDE operations.
 type Error struct {
-	Code    ErrorCode // Unique error code
-	Message string    // Human-readable error message
+	Code       ErrorCode     // Unique error code
+	Message    string        // Human-readable error message
+	RetryAfter time.Duration // The time to wait before retrying, if the server sent it (e.g. rate limited requests)
+	// ConflictingPattern is the registered pattern a RegisterSwamp conflicts with, if the server sent it
+	ConflictingPattern *SwampPattern
+	// Limit is the limit of the server the request exceeded, if the server sent it
+	Limit *LimitViolation
+	// Mismatch is the field of the model whose type differs from the stored value, if the read is strict
+	Mismatch *TypeMismatch
+}
+
+// TypeMismatch is a field of a model whose type differs from the type of the stored value, e.g. an int64 field
+// of a value stored as int32. It is returned by the strict reads only, see StrictDecoding.
+type TypeMismatch struct {
+	// Field is the name of the field of the model
+	Field string
+	// StoredType is the type of the stored value: string, bool, bytes, int8-int64, uint8-uint64, float32 or float64
+	StoredType string
+	// ModelType is the Go type of the field of the model, e.g. int64 or []string
+	ModelType string
+}
+
+// Error implements the built-in error interface.
+func (m *TypeMismatch) Error() string {
+	return fmt.Sprintf("the field %s of the model is %s, but the stored value is %s", m.Field, m.ModelType, m.StoredType)
+}
+
+// LimitViolation is a limit of the server exceeded by a request, e.g. a key longer than the max key length
+type LimitViolation struct {
+	// Name is the name of the limit: maxKeyLength, maxValueSize or maxTreasuresPerSwamp
+	Name string
+	// Max is the value of the limit on the server
+	Max int64
+	// Actual is the value of the request, e.g. the length of the key in bytes
+	Actual int64
 }
 
 // Error implements the built-in error interface.
@@ -4806,3 +8931,369 @@ func IsUnknown(err error) bool {
 func IsConditionNotMet(err error) bool {
 	return GetErrorCode(err) == ErrConditionNotMet
 }
+
+// IsQuotaExceeded returns true if the request was rejected because a limit or quota of the server is exceeded.
+func IsQuotaExceeded(err error) bool {
+	return GetErrorCode(err) == ErrCodeQuotaExceeded
+}
+
+// IsDataCorrupted returns true if the Swamp can not be loaded, because one of its files is corrupted on the server.
+// The corrupted files can be listed with ListCorruptedFiles.
+func IsDataCorrupted(err error) bool {
+	return GetErrorCode(err) == ErrCodeDataCorrupted
+}
+
+// IsReplayNotAvailable returns true if SubscribeFrom can not replay the missed events, because the event journal of
+// the Swamp is disabled or it does not cover the requested time. Read the whole Swamp and subscribe again instead.
+func IsReplayNotAvailable(err error) bool {
+	return GetErrorCode(err) == ErrCodeReplayNotAvailable
+}
+
+// IsLeaseNotFound returns true if CatalogAck or CatalogNack failed, because the lease is not valid anymore. It
+// happens if the lease expired and an other consumer leased the Treasure again, or the Treasure was deleted.
+func IsLeaseNotFound(err error) bool {
+	return GetErrorCode(err) == ErrCodeLeaseNotFound
+}
+
+// IsMessageTooLarge returns true if the request or the response is larger than the max message size of the gRPC
+// connection (GRPC_MAX_MESSAGE_SIZE on the server, maxMessageSize of the client). The message of the error contains
+// the actual and the max size. Read or write less Treasures in one request, or raise the limit on both sides.
+func IsMessageTooLarge(err error) bool {
+	return GetErrorCode(err) == ErrCodeMessageTooLarge
+}
+
+// IsInsufficientStorage returns true if the write was refused, because the disk of the server is almost full. The
+// reads and the deletes still work, so free space by deleting Treasures, or add disk space to the server, then retry.
+func IsInsufficientStorage(err error) bool {
+	return GetErrorCode(err) == ErrCodeInsufficientStorage
+}
+
+// IsAuditLogDisabled returns true if QueryAuditLog failed, because the audit log is not enabled on the server.
+// Start the server with HYDRAIDE_AUDIT_ENABLED=true to record the mutating requests.
+func IsAuditLogDisabled(err error) bool {
+	return GetErrorCode(err) == ErrCodeAuditLogDisabled
+}
+
+// IsSchemaViolation returns true if the write was rejected, because a Treasure violates a constraint of its Swamp
+// pattern, e.g. its key is too long or a required metadata is missing. The message names the Swamp, the key and the
+// constraint. Nothing of the rejected request is written, and retrying it does not help.
+func IsSchemaViolation(err error) bool {
+	return GetErrorCode(err) == ErrCodeSchemaViolation
+}
+
+// IsIslandMismatch returns true if VerifyIslandMapping found that the SDK and the server compute different Islands
+// for the same Swamp, so the SDK would route the Swamps to the wrong Islands.
+func IsIslandMismatch(err error) bool {
+	return GetErrorCode(err) == ErrCodeIslandMismatch
+}
+
+// IsSubscriberOverflow returns true if the server closed the stream of SubscribeAll, because the client could not
+// keep up with the changes. Some changes are not delivered, so resynchronize the Swamps, and subscribe again.
+func IsSubscriberOverflow(err error) bool {
+	return GetErrorCode(err) == ErrCodeSubscriberOverflow
+}
+
+// IsPointInTimeRestoreDisabled returns true if RestorePointInTime failed, because the scheduled backups or the
+// write-ahead log are not enabled on the server. Start the server with HYDRAIDE_BACKUP_ENABLED=true and
+// HYDRAIDE_WAL_ENABLED=true to restore the points in time.
+func IsPointInTimeRestoreDisabled(err error) bool {
+	return GetErrorCode(err) == ErrCodePointInTimeRestoreDisabled
+}
+
+// IsPointInTimeNotCovered returns true if RestorePointInTime failed, because the server has no complete backup
+// before the point in time, or its write-ahead log does not reach back to the backup. Nothing is restored.
+func IsPointInTimeNotCovered(err error) bool {
+	return GetErrorCode(err) == ErrCodePointInTimeNotCovered
+}
+
+// IsConsistencyNotReached returns true if the server refused the request, because it does not have the writes of
+// the consistency token of the context. It happens if the server crashed after the writes, so the writes not yet
+// flushed to the disk can be lost. Retrying does not help, write the data again or read it without the token.
+func IsConsistencyNotReached(err error) bool {
+	return GetErrorCode(err) == ErrCodeConsistencyNotReached
+}
+
+// IsIslandMigrating returns true if the server refused the write, because the Island of the Swamp is being migrated
+// to an other server. The SDK already retries the write until the migration is done, so this is returned only if
+// the migration took longer than the retries or the deadline of the context.
+func IsIslandMigrating(err error) bool {
+	return GetErrorCode(err) == ErrCodeIslandMigrating
+}
+
+// IsIslandMoved returns true if the server refused the request, because the Island of the Swamp moved to an other
+// server. A client created with seeds refreshes its topology before it returns the error, so the request can be
+// retried at once. A client with fixed Island ranges must be created again with the new ranges.
+func IsIslandMoved(err error) bool {
+	return GetErrorCode(err) == ErrCodeIslandMoved
+}
+
+// IsPatternConflict returns true if the server rejected a RegisterSwamp, because a registered pattern overlaps with
+// the new one, and it has other memory or filesystem settings. GetConflictingPattern returns the registered pattern.
+// Set Force in the RegisterSwampRequest to register the pattern anyway.
+func IsPatternConflict(err error) bool {
+	return GetErrorCode(err) == ErrCodePatternConflict
+}
+
+// GetConflictingPattern returns the registered pattern a RegisterSwamp conflicts with, including its settings and
+// the client that registered it, or nil if the error is not a pattern conflict.
+//
+// 🔧 Example:
+//
+//	errs := h.RegisterSwamp(ctx, request)
+//	for _, err := range errs {
+//	    if conflicting := hydraidego.GetConflictingPattern(err); conflicting != nil {
+//	        log.Printf("%s is registered by %s", conflicting.SwampPattern.Get(), conflicting.RegisteredBy)
+//	    }
+//	}
+func GetConflictingPattern(err error) *SwampPattern {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.ConflictingPattern
+	}
+	return nil
+}
+
+// GetLimitViolation returns the limit of the server the request exceeded, or nil if the error is not a limit error.
+// The keys longer than the max key length and the values larger than the max value size are invalid arguments, the
+// Swamps over the max number of Treasures are quota errors.
+//
+// 🔧 Example:
+//
+//	if limit := hydraidego.GetLimitViolation(err); limit != nil {
+//	    log.Printf("the %s limit is %d, the request has %d", limit.Name, limit.Max, limit.Actual)
+//	}
+func GetLimitViolation(err error) *LimitViolation {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Limit
+	}
+	return nil
+}
+
+// GetTypeMismatch returns the field of the model whose type differs from the stored value, or nil if the error is
+// not a type mismatch. The type mismatches are returned by the strict reads only, see StrictDecoding.
+//
+// 🔧 Example:
+//
+//	if mismatch := hydraidego.GetTypeMismatch(err); mismatch != nil {
+//	    log.Printf("the %s field is %s, but %s is stored", mismatch.Field, mismatch.ModelType, mismatch.StoredType)
+//	}
+func GetTypeMismatch(err error) *TypeMismatch {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Mismatch
+	}
+	var mismatch *TypeMismatch
+	if errors.As(err, &mismatch) {
+		return mismatch
+	}
+	return nil
+}
+
+// decodeError converts the error of the loading of a model to an ErrCodeInvalidModel error, with the type
+// mismatch if the error is one
+func decodeError(err error) error {
+	e := &Error{
+		Code:    ErrCodeInvalidModel,
+		Message: err.Error(),
+	}
+	errors.As(err, &e.Mismatch)
+	return e
+}
+
+// GetRetryAfter returns the time the server asked the client to wait before retrying the request.
+// Returns 0 if the error is not a HydrAIDE error or the server did not send a retry time, e.g. if the quota
+// can not be satisfied by waiting, like the maximum number of Treasures in a Swamp.
+//
+// The SDK already retries the rate limited requests after the wait time until the context deadline,
+// so this is useful only if the request failed finally, e.g. to schedule a background job later.
+func GetRetryAfter(err error) time.Duration {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.RetryAfter
+	}
+	return 0
+}
+
+// WithClientID returns a context that identifies the client to the server with the given ID.
+//
+// The server applies the rate limits per client identity. Without a client ID the identity of the client is
+// its IP address, so all services behind the same NAT or on the same host share the same limits.
+// With a client ID, every service can have its own limits, configured in the `limits.rateLimit.clients`
+// section of the server's hydraide.yaml.
+//
+// 🔧 Example:
+//
+//	ctx = hydraidego.WithClientID(ctx, "importer")
+//	_, err := h.CatalogSave(ctx, swampName, model)
+func WithClientID(ctx context.Context, clientID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, metadataClientID, clientID)
+}
+
+// WithBackgroundHydration returns a context whose requests load the cold Swamps with background priority.
+//
+// The server limits the number of the Swamps loaded from the disk at the same time, and serves the waiting
+// interactive requests first. Use it for batch jobs and scans that touch many cold Swamps, so they do not slow
+// down the requests of the users. GetAll, ExistsMany, Aggregate and CompactSwamp are background by default.
+//
+// 🔧 Example:
+//
+//	ctx = hydraidego.WithBackgroundHydration(ctx)
+//	for _, swampName := range userSwamps {
+//		_, err := h.Count(ctx, swampName)
+//	}
+func WithBackgroundHydration(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, metadataHydrationPriority, "background")
+}
+
+// CaptureConsistencyToken returns a context whose successful writes collect their consistency token, and the
+// function returning the token of the writes collected so far.
+//
+// Every write answered by the server is already applied, so a read after the write sees it on any replica of the
+// app. The token proves it: pass it to the service or the replica that reads the data, and read with
+// WithConsistencyToken. The server refuses the read if it lost the writes of the token, e.g. because it crashed
+// before they were flushed to the disk, instead of serving the stale data silently.
+//
+// ✅ The token covers the writes to all servers of the client, it has one mark per server.
+// ✅ The token is a plain string, it can be sent in a HTTP header or a cookie.
+// ⚠️ The token is empty if no write succeeded with the context.
+//
+// 🔧 Example:
+//
+//	ctx, token := hydraidego.CaptureConsistencyToken(ctx)
+//	_, err := h.CatalogSave(ctx, swampName, model)
+//	w.Header().Set("X-Consistency-Token", token())
+func CaptureConsistencyToken(ctx context.Context) (context.Context, func() string) {
+	ctx, collector := consistency.WithCollector(ctx)
+	return ctx, func() string {
+		return collector.Token().String()
+	}
+}
+
+// WithConsistencyToken returns a context whose requests are served only if the servers have the writes of the
+// consistency token returned by CaptureConsistencyToken. The servers of the request check only their own marks of
+// the token, the marks of the other servers are ignored.
+//
+// ⚠️ If the server lost the writes of the token, the error is IsConsistencyNotReached(err).
+//
+// 🔧 Example:
+//
+//	ctx = hydraidego.WithConsistencyToken(ctx, r.Header.Get("X-Consistency-Token"))
+//	err := h.CatalogRead(ctx, swampName, key, model)
+func WithConsistencyToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, consistency.Metadata, token)
+}
+
+// strictDecodingContextKey is the context key of the decoding mode set by WithStrictDecoding
+type strictDecodingContextKey struct{}
+
+// StrictDecoding makes the reads of the SDK fail if the type of a stored value differs from the type of its field
+// in the model, instead of skipping the field.
+//
+// By default, a value stored with another type than the field of the model is skipped silently, so the field keeps
+// its zero value. It keeps the old readers working while a model changes, but it also hides the real bugs: a value
+// stored as int32 and read into an int64 field is read as zero. In strict mode, the read fails with an
+// ErrCodeInvalidModel error, and GetTypeMismatch(err) returns the field, the stored type and the type of the model.
+//
+// ✅ Covers the catalog reads, the profile reads, the subscriptions and the changes of the change feed.
+// 💡 Use WithStrictDecoding to switch the mode of a single call.
+//
+// 🔧 Example:
+//
+//	h := hydraidego.New(client, hydraidego.StrictDecoding())
+func StrictDecoding() Option {
+	return func(h *hydraidego) {
+		h.strictDecoding = true
+	}
+}
+
+// WithStrictDecoding returns a context whose reads are strict or lenient, regardless of the StrictDecoding option of
+// the SDK. See StrictDecoding for the strict mode.
+//
+// 🔧 Example:
+//
+//	err := h.CatalogRead(hydraidego.WithStrictDecoding(ctx, true), swampName, key, model)
+//	if mismatch := hydraidego.GetTypeMismatch(err); mismatch != nil {
+//	    // the stored value does not fit into the model
+//	}
+func WithStrictDecoding(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, strictDecodingContextKey{}, strict)
+}
+
+// isStrictDecoding returns the decoding mode of the context, or of the SDK if the context has none
+func (h *hydraidego) isStrictDecoding(ctx context.Context) bool {
+	if ctx != nil {
+		if strict, ok := ctx.Value(strictDecodingContextKey{}).(bool); ok {
+			return strict
+		}
+	}
+	return h.strictDecoding
+}
+
+// actorContextKey is the context key of the actor set by WithActor
+type actorContextKey struct{}
+
+// WithActor returns a context whose writes fill the empty `createdBy` and `updatedBy` fields of the models with the
+// actor, e.g. the name of the service or the ID of the user behind the request.
+//
+// Without it, every call site must set the audit fields of the model, and a forgotten one leaves the Treasure
+// without its creator or modifier. With it, the actor is set once, e.g. in a middleware of the service, and every
+// write of the context is attributed the same way.
+//
+// ✅ The fields set on the model always win over the actor.
+// ✅ Only the models with a `createdBy` or `updatedBy` field get the actor, the other models stay unchanged.
+// ⚠️ The server stores the `createdBy` of every write, so a model with a `createdBy` field overwrites the creator on
+// every update, with or without the actor. Leave the field out of the update models to keep the original creator.
+//
+// 🔧 Example:
+//
+//	ctx = hydraidego.WithActor(ctx, "billing-service")
+//	_, err := h.CatalogSave(ctx, swampName, invoice) // invoice.UpdatedBy == "" → "billing-service"
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// actorOrDefault returns the value, or the actor of the context if the value is empty
+func actorOrDefault(ctx context.Context, value string) string {
+	if value != "" || ctx == nil {
+		return value
+	}
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// conflictingPatternFromStatus returns the SwampPattern detail of the status, or nil if it is missing
+func conflictingPatternFromStatus(s *status.Status) *SwampPattern {
+	for _, detail := range s.Details() {
+		if pattern, ok := detail.(*hydraidepbgo.SwampPattern); ok {
+			return swampPatternFromProto(pattern)
+		}
+	}
+	return nil
+}
+
+// limitViolationFromErrorInfo returns the limit of the metadata of the ErrorInfo, or nil if the server did not send it
+func limitViolationFromErrorInfo(info *errdetails.ErrorInfo) *LimitViolation {
+	metadata := info.GetMetadata()
+	name, ok := metadata["limit"]
+	if !ok {
+		return nil
+	}
+	limit := &LimitViolation{Name: name}
+	limit.Max, _ = strconv.ParseInt(metadata["max"], 10, 64)
+	limit.Actual, _ = strconv.ParseInt(metadata["actual"], 10, 64)
+	return limit
+}
+
+// retryDelayFromStatus returns the retry delay of the RetryInfo detail of the status, or 0 if it is missing
+func retryDelayFromStatus(s *status.Status) time.Duration {
+	for _, detail := range s.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
@@ -1,8 +1,16 @@
 package hydraidego
 
 import (
+	"context"
+	"fmt"
 	"github.com/hydraide/hydraide/generated/hydraidepbgo"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"reflect"
 	"testing"
 	"time"
@@ -160,16 +168,517 @@ func TestHydraideTypeConversions(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			kv, err := convertCatalogModelToKeyValuePair(tc.input)
+			kv, err := convertCatalogModelToKeyValuePair(context.Background(), tc.input)
 			require.NoError(t, err)
 
 			treasure := convertKeyValuePairToTreasure(kv)
 
 			restored := reflect.New(reflect.TypeOf(tc.expected)).Interface()
-			err = convertProtoTreasureToCatalogModel(treasure, restored)
+			err = convertProtoTreasureToCatalogModel(treasure, restored, false)
 			require.NoError(t, err)
 
 			require.Equal(t, tc.expected, reflect.ValueOf(restored).Elem().Interface())
 		})
 	}
 }
+
+func TestStrictDecoding(t *testing.T) {
+
+	type counterModel struct {
+		Key   string `hydraide:"key"`
+		Value int64  `hydraide:"value"`
+	}
+
+	stored := &hydraidepbgo.Treasure{Key: "visits", IsExist: true, Int32Val: proto.Int32(42)}
+
+	t.Run("should skip the mismatching field by default", func(t *testing.T) {
+		model := &counterModel{}
+		require.NoError(t, convertProtoTreasureToCatalogModel(stored, model, false))
+		require.Equal(t, "visits", model.Key)
+		require.Zero(t, model.Value)
+	})
+
+	t.Run("should return the mismatch in strict mode", func(t *testing.T) {
+		err := convertProtoTreasureToCatalogModel(stored, &counterModel{}, true)
+		require.Equal(t, &TypeMismatch{Field: "Value", StoredType: "int32", ModelType: "int64"}, GetTypeMismatch(err))
+		require.Equal(t, ErrCodeInvalidModel, GetErrorCode(decodeError(err)))
+		require.Equal(t, "Value", GetTypeMismatch(decodeError(err)).Field)
+	})
+
+	t.Run("should return the mismatch of the profile field in strict mode", func(t *testing.T) {
+		profile := &struct {
+			Visits int64
+		}{}
+		treasure := &hydraidepbgo.Treasure{Key: "Visits", IsExist: true, StringVal: proto.String("42")}
+		require.NoError(t, setTreasureValueToProfileModel(profile, treasure, false))
+		err := setTreasureValueToProfileModel(profile, treasure, true)
+		require.Equal(t, &TypeMismatch{Field: "Visits", StoredType: "string", ModelType: "int64"}, GetTypeMismatch(err))
+	})
+
+	t.Run("should accept the time stored as int64 in strict mode", func(t *testing.T) {
+		model := &struct {
+			Key   string    `hydraide:"key"`
+			Value time.Time `hydraide:"value"`
+		}{}
+		treasure := &hydraidepbgo.Treasure{Key: "seen", IsExist: true, Int64Val: proto.Int64(1700000000)}
+		require.NoError(t, convertProtoTreasureToCatalogModel(treasure, model, true))
+		require.Equal(t, time.Unix(1700000000, 0).UTC(), model.Value)
+	})
+
+	t.Run("should let the context override the mode of the SDK", func(t *testing.T) {
+		h := New(nil, StrictDecoding()).(*hydraidego)
+		require.True(t, h.isStrictDecoding(context.Background()))
+		require.False(t, h.isStrictDecoding(WithStrictDecoding(context.Background(), false)))
+		require.True(t, New(nil).(*hydraidego).isStrictDecoding(WithStrictDecoding(context.Background(), true)))
+	})
+
+}
+
+func TestWithActor(t *testing.T) {
+
+	type auditedModel struct {
+		Key       string `hydraide:"key"`
+		Value     string `hydraide:"value"`
+		CreatedBy string `hydraide:"createdBy"`
+		UpdatedBy string `hydraide:"updatedBy"`
+	}
+
+	ctx := WithActor(context.Background(), "billing-service")
+
+	t.Run("should fill the empty audit fields with the actor", func(t *testing.T) {
+		kv, err := convertCatalogModelToKeyValuePair(ctx, &auditedModel{Key: "invoice-1", Value: "paid"})
+		require.NoError(t, err)
+		require.Equal(t, "billing-service", kv.GetCreatedBy())
+		require.Equal(t, "billing-service", kv.GetUpdatedBy())
+	})
+
+	t.Run("should keep the audit fields of the model", func(t *testing.T) {
+		kv, err := convertCatalogModelToKeyValuePair(ctx, &auditedModel{Key: "invoice-1", CreatedBy: "importer"})
+		require.NoError(t, err)
+		require.Equal(t, "importer", kv.GetCreatedBy())
+		require.Equal(t, "billing-service", kv.GetUpdatedBy())
+	})
+
+	t.Run("should not add the audit fields to the models without them", func(t *testing.T) {
+		kv, err := convertCatalogModelToKeyValuePair(ctx, &struct {
+			Key   string `hydraide:"key"`
+			Value string `hydraide:"value"`
+		}{Key: "invoice-1", Value: "paid"})
+		require.NoError(t, err)
+		require.Nil(t, kv.CreatedBy)
+		require.Nil(t, kv.UpdatedBy)
+	})
+
+	t.Run("should leave the audit fields empty without actor", func(t *testing.T) {
+		kv, err := convertCatalogModelToKeyValuePair(context.Background(), &auditedModel{Key: "invoice-1"})
+		require.NoError(t, err)
+		require.Nil(t, kv.CreatedBy)
+		require.Nil(t, kv.UpdatedBy)
+	})
+
+}
+
+func TestErrorFromReason(t *testing.T) {
+
+	withReason := func(code codes.Code, reason hydraidepbgo.ErrorReason_Reason, domain string) error {
+		st, err := status.New(code, "message").WithDetails(&errdetails.ErrorInfo{Reason: reason.String(), Domain: domain})
+		require.NoError(t, err)
+		return st.Err()
+	}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected ErrorCode
+	}{
+		{"swamp not found", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_SWAMP_NOT_FOUND, errorDomain), ErrCodeSwampNotFound},
+		{"value index is not enabled", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_VALUE_INDEX_NOT_ENABLED, errorDomain), ErrCodeFailedPrecondition},
+		{"key not found", withReason(codes.InvalidArgument, hydraidepbgo.ErrorReason_KEY_NOT_FOUND, errorDomain), ErrCodeNotFound},
+		{"key exists", withReason(codes.AlreadyExists, hydraidepbgo.ErrorReason_KEY_EXISTS, errorDomain), ErrCodeAlreadyExists},
+		{"condition not met", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_CONDITION_NOT_MET, errorDomain), ErrConditionNotMet},
+		{"quota exceeded", withReason(codes.ResourceExhausted, hydraidepbgo.ErrorReason_QUOTA_EXCEEDED, errorDomain), ErrCodeQuotaExceeded},
+		{"internal", withReason(codes.Internal, hydraidepbgo.ErrorReason_INTERNAL, errorDomain), ErrCodeInternalDatabaseError},
+		{"message too large", withReason(codes.ResourceExhausted, hydraidepbgo.ErrorReason_MESSAGE_TOO_LARGE, errorDomain), ErrCodeMessageTooLarge},
+		{"insufficient storage", withReason(codes.ResourceExhausted, hydraidepbgo.ErrorReason_INSUFFICIENT_STORAGE, errorDomain), ErrCodeInsufficientStorage},
+		{"audit log disabled", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_AUDIT_LOG_DISABLED, errorDomain), ErrCodeAuditLogDisabled},
+		{"schema violation", withReason(codes.InvalidArgument, hydraidepbgo.ErrorReason_SCHEMA_VIOLATION, errorDomain), ErrCodeSchemaViolation},
+		{"subscriber overflow", withReason(codes.ResourceExhausted, hydraidepbgo.ErrorReason_SUBSCRIBER_OVERFLOW, errorDomain), ErrCodeSubscriberOverflow},
+		{"point-in-time restore disabled", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_POINT_IN_TIME_RESTORE_DISABLED, errorDomain), ErrCodePointInTimeRestoreDisabled},
+		{"point in time not covered", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_POINT_IN_TIME_NOT_COVERED, errorDomain), ErrCodePointInTimeNotCovered},
+		{"consistency not reached", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_CONSISTENCY_NOT_REACHED, errorDomain), ErrCodeConsistencyNotReached},
+		{"island migrating", withReason(codes.Unavailable, hydraidepbgo.ErrorReason_ISLAND_MIGRATING, errorDomain), ErrCodeIslandMigrating},
+		{"island moved", withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_ISLAND_MOVED, errorDomain), ErrCodeIslandMoved},
+		{"blob not found", withReason(codes.NotFound, hydraidepbgo.ErrorReason_BLOB_NOT_FOUND, errorDomain), ErrCodeNotFound},
+		{"swamp pattern not found", withReason(codes.NotFound, hydraidepbgo.ErrorReason_SWAMP_PATTERN_NOT_FOUND, errorDomain), ErrCodeNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := errorHandler(tc.err)
+			require.Equal(t, tc.expected, GetErrorCode(err))
+		})
+	}
+
+	t.Run("should fall back to the status code without reason", func(t *testing.T) {
+		_, found := errorFromReason(status.Error(codes.FailedPrecondition, "Swamp does not exist"))
+		require.False(t, found)
+		require.True(t, IsSwampNotFound(errorHandler(status.Error(codes.FailedPrecondition, "Swamp does not exist"))))
+	})
+
+	t.Run("should keep the retry delay of the quota errors", func(t *testing.T) {
+		st, err := status.New(codes.ResourceExhausted, "request rate limit exceeded").WithDetails(
+			&errdetails.ErrorInfo{Reason: hydraidepbgo.ErrorReason_QUOTA_EXCEEDED.String(), Domain: errorDomain},
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)},
+		)
+		require.NoError(t, err)
+		quotaErr := errorHandler(st.Err())
+		require.True(t, IsQuotaExceeded(quotaErr))
+		require.Equal(t, 1500*time.Millisecond, GetRetryAfter(quotaErr))
+		require.Zero(t, GetRetryAfter(errorHandler(withReason(codes.ResourceExhausted, hydraidepbgo.ErrorReason_QUOTA_EXCEEDED, errorDomain))))
+	})
+
+	t.Run("should keep the conflicting pattern of the pattern conflicts", func(t *testing.T) {
+		st, err := status.New(codes.AlreadyExists, "the pattern conflicts").WithDetails(
+			&errdetails.ErrorInfo{Reason: hydraidepbgo.ErrorReason_PATTERN_CONFLICT.String(), Domain: errorDomain},
+			&hydraidepbgo.SwampPattern{SwampPattern: "users/*/*", CloseAfterIdle: 60, RegisteredBy: "service-a", RegisteredAt: timestamppb.New(time.Unix(1700000000, 0))},
+		)
+		require.NoError(t, err)
+		conflictErr := errorHandler(st.Err())
+		require.True(t, IsPatternConflict(conflictErr))
+		conflicting := GetConflictingPattern(conflictErr)
+		require.NotNil(t, conflicting)
+		require.Equal(t, "users/*/*", conflicting.SwampPattern.Get())
+		require.Equal(t, time.Minute, conflicting.CloseAfterIdle)
+		require.Equal(t, "service-a", conflicting.RegisteredBy)
+		require.True(t, conflicting.RegisteredAt.Equal(time.Unix(1700000000, 0)))
+		require.Nil(t, GetConflictingPattern(errorHandler(withReason(codes.AlreadyExists, hydraidepbgo.ErrorReason_KEY_EXISTS, errorDomain))))
+	})
+
+	t.Run("should ignore the reasons of other domains", func(t *testing.T) {
+		_, found := errorFromReason(withReason(codes.FailedPrecondition, hydraidepbgo.ErrorReason_KEY_EXISTS, "example.com"))
+		require.False(t, found)
+	})
+
+}
+
+func TestSplitKeyValues(t *testing.T) {
+
+	swampRequest := &hydraidepbgo.SwampRequest{IslandID: 42, SwampName: "users/profiles/alex", CreateIfNotExist: true, Overwrite: true}
+
+	kvPairs := make([]*hydraidepbgo.KeyValuePair, 0, 100)
+	for i := 0; i < 100; i++ {
+		value := string(make([]byte, 100+i))
+		kvPairs = append(kvPairs, &hydraidepbgo.KeyValuePair{Key: fmt.Sprintf("key-%d", i), StringVal: &value})
+	}
+
+	requestSize := func(batch []*hydraidepbgo.KeyValuePair) int {
+		batchRequest := proto.Clone(swampRequest).(*hydraidepbgo.SwampRequest)
+		batchRequest.KeyValues = batch
+		return proto.Size(&hydraidepbgo.SetRequest{Swamps: []*hydraidepbgo.SwampRequest{batchRequest}})
+	}
+
+	t.Run("should send everything in one batch without limit", func(t *testing.T) {
+		batches := splitKeyValues(swampRequest, kvPairs, 0)
+		require.Len(t, batches, 1)
+		require.Len(t, batches[0], 100)
+	})
+
+	t.Run("should keep every batch under the limit in the order of the key values", func(t *testing.T) {
+
+		maxMessageSize := 2000
+		batches := splitKeyValues(swampRequest, kvPairs, maxMessageSize)
+		require.Greater(t, len(batches), 1)
+
+		var keys []string
+		for i, batch := range batches {
+			require.LessOrEqual(t, requestSize(batch), maxMessageSize)
+			if i < len(batches)-1 {
+				// the batch is full, the next key value would not fit
+				require.Greater(t, requestSize(append(batch[:len(batch):len(batch)], batches[i+1][0])), maxMessageSize)
+			}
+			for _, kvPair := range batch {
+				keys = append(keys, kvPair.GetKey())
+			}
+		}
+
+		require.Len(t, keys, 100)
+		for i, key := range keys {
+			require.Equal(t, fmt.Sprintf("key-%d", i), key)
+		}
+
+	})
+
+	t.Run("should put a too large key value alone into a batch", func(t *testing.T) {
+		batches := splitKeyValues(swampRequest, kvPairs[:3], 150)
+		require.Len(t, batches, 3)
+		for _, batch := range batches {
+			require.Len(t, batch, 1)
+		}
+	})
+
+}
+
+func TestSetServerTimestampsToCatalogModel(t *testing.T) {
+
+	type order struct {
+		ID        string    `hydraide:"key"`
+		Status    string    `hydraide:"value"`
+		CreatedAt time.Time `hydraide:"createdAt"`
+		UpdatedAt time.Time `hydraide:"updatedAt"`
+	}
+
+	createdAt := time.Date(2025, time.March, 14, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Minute)
+	clientTime := createdAt.Add(-time.Hour)
+
+	t.Run("should copy the server times to the model", func(t *testing.T) {
+		model := &order{ID: "order", CreatedAt: clientTime, UpdatedAt: clientTime}
+		setServerTimestampsToCatalogModel(&hydraidepbgo.KeyStatusPair{
+			Key:       "order",
+			CreatedAt: timestamppb.New(createdAt),
+			UpdatedAt: timestamppb.New(updatedAt),
+		}, model)
+		require.True(t, createdAt.Equal(model.CreatedAt))
+		require.True(t, updatedAt.Equal(model.UpdatedAt))
+	})
+
+	t.Run("should keep the model without server times", func(t *testing.T) {
+		model := &order{ID: "order", CreatedAt: clientTime}
+		setServerTimestampsToCatalogModel(&hydraidepbgo.KeyStatusPair{Key: "order"}, model)
+		require.True(t, clientTime.Equal(model.CreatedAt))
+		require.True(t, model.UpdatedAt.IsZero())
+	})
+
+	t.Run("should ignore the non-pointer models", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			setServerTimestampsToCatalogModel(&hydraidepbgo.KeyStatusPair{CreatedAt: timestamppb.New(createdAt)}, order{})
+		})
+	})
+
+}
+
+func TestCatalogModelProjection(t *testing.T) {
+
+	t.Run("should read the whole treasures for the models with every part", func(t *testing.T) {
+		type full struct {
+			ID        string    `hydraide:"key"`
+			Status    string    `hydraide:"value"`
+			CreatedAt time.Time `hydraide:"createdAt"`
+			CreatedBy string    `hydraide:"createdBy"`
+			UpdatedAt time.Time `hydraide:"updatedAt"`
+			UpdatedBy string    `hydraide:"updatedBy"`
+			ExpireAt  time.Time `hydraide:"expireAt"`
+		}
+		require.Nil(t, catalogModelProjection(&full{}))
+		require.Nil(t, catalogModelProjection(full{}))
+	})
+
+	t.Run("should read only the parts of the model", func(t *testing.T) {
+		type metadataOnly struct {
+			ID        string    `hydraide:"key"`
+			CreatedAt time.Time `hydraide:"createdAt"`
+		}
+		projection := catalogModelProjection(&metadataOnly{})
+		require.NotNil(t, projection)
+		require.False(t, projection.GetValue())
+		require.True(t, projection.GetCreatedAt())
+		require.False(t, projection.GetUpdatedAt())
+	})
+
+	t.Run("should ignore the non-struct models", func(t *testing.T) {
+		require.Nil(t, catalogModelProjection(nil))
+		require.Nil(t, catalogModelProjection("model"))
+	})
+
+}
+
+func TestConvertEventToChange(t *testing.T) {
+
+	t.Run("should convert the event with the value of the treasure", func(t *testing.T) {
+		eventTime := time.Now()
+		change, err := convertEventToChange(&hydraidepbgo.SubscribeToEventsResponse{
+			SwampName: "users/profiles/alex",
+			Treasure:  &hydraidepbgo.Treasure{Key: "name", IsExist: true, StringVal: proto.String("Alex")},
+			EventTime: timestamppb.New(eventTime),
+			Status:    hydraidepbgo.Status_NEW,
+			Sequence:  7,
+		}, false)
+		require.NoError(t, err)
+		require.Equal(t, "users/profiles/alex", change.SwampName.Get())
+		require.Equal(t, "name", change.Key)
+		require.Equal(t, StatusNew, change.Status)
+		require.Equal(t, uint64(7), change.Sequence)
+		require.True(t, eventTime.Equal(change.EventTime))
+		require.Equal(t, "Alex", change.Value())
+	})
+
+	t.Run("should carry the deleted treasure of the deleted events", func(t *testing.T) {
+		change, err := convertEventToChange(&hydraidepbgo.SubscribeToEventsResponse{
+			SwampName:       "users/profiles/alex",
+			DeletedTreasure: &hydraidepbgo.Treasure{Key: "age", Int8Val: proto.Int32(42)},
+			Status:          hydraidepbgo.Status_DELETED,
+		}, false)
+		require.NoError(t, err)
+		require.Equal(t, "age", change.Key)
+		require.Equal(t, int64(42), change.Value())
+	})
+
+	t.Run("should return an error if the swamp can not be replayed", func(t *testing.T) {
+		change, err := convertEventToChange(&hydraidepbgo.SubscribeToEventsResponse{
+			SwampName:          "users/profiles/alex",
+			ReplayNotAvailable: true,
+			EventTime:          timestamppb.Now(),
+		}, false)
+		require.True(t, IsReplayNotAvailable(err))
+		require.Equal(t, "users/profiles/alex", change.SwampName.Get())
+		require.Nil(t, change.Value())
+	})
+
+	t.Run("should decode the change in the mode of the subscription", func(t *testing.T) {
+		change, err := convertEventToChange(&hydraidepbgo.SubscribeToEventsResponse{
+			SwampName: "users/profiles/alex",
+			Treasure:  &hydraidepbgo.Treasure{Key: "age", IsExist: true, Int32Val: proto.Int32(42)},
+			Status:    hydraidepbgo.Status_NEW,
+		}, true)
+		require.NoError(t, err)
+		model := &struct {
+			Key   string `hydraide:"key"`
+			Value int64  `hydraide:"value"`
+		}{}
+		err = change.Decode(model)
+		require.Equal(t, ErrCodeInvalidModel, GetErrorCode(err))
+		require.Equal(t, &TypeMismatch{Field: "Value", StoredType: "int32", ModelType: "int64"}, GetTypeMismatch(err))
+	})
+
+}
+
+func TestFsyncPolicy(t *testing.T) {
+
+	t.Run("should send the fsync policy of the swamp", func(t *testing.T) {
+		require.Equal(t, hydraidepbgo.FsyncPolicy_DEFAULT, FsyncDefault.toProto())
+		require.Equal(t, hydraidepbgo.FsyncPolicy_NEVER, FsyncNever.toProto())
+		require.Equal(t, hydraidepbgo.FsyncPolicy_INTERVAL, FsyncInterval.toProto())
+		require.Equal(t, hydraidepbgo.FsyncPolicy_ALWAYS, FsyncAlways.toProto())
+	})
+
+	t.Run("should read back the fsync policy of the listed patterns", func(t *testing.T) {
+		for _, policy := range []FsyncPolicy{FsyncDefault, FsyncNever, FsyncInterval, FsyncAlways} {
+			require.Equal(t, policy, fsyncPolicyFromProto(policy.toProto()))
+		}
+	})
+
+}
+
+func TestSubscriberBuffer(t *testing.T) {
+
+	t.Run("should use the defaults of the server without a buffer in the context", func(t *testing.T) {
+		buffer := subscriberBufferFrom(context.Background())
+		require.Equal(t, uint32(0), buffer.bufferSize())
+		require.Equal(t, hydraidepbgo.OverflowPolicy_DEFAULT, buffer.overflowPolicy())
+	})
+
+	t.Run("should send the buffer of the context", func(t *testing.T) {
+		buffer := subscriberBufferFrom(WithSubscriberBuffer(context.Background(), SubscriberBuffer{Size: 500, Overflow: OverflowDropOldest}))
+		require.Equal(t, uint32(500), buffer.bufferSize())
+		require.Equal(t, hydraidepbgo.OverflowPolicy_DROP_OLDEST, buffer.overflowPolicy())
+	})
+
+	t.Run("should report the dropped events", func(t *testing.T) {
+		var reported uint64
+		buffer := SubscriberBuffer{OnDropped: func(dropped uint64) { reported += dropped }}
+		require.True(t, buffer.dropped(&hydraidepbgo.SubscribeToEventsResponse{DroppedEvents: 3}))
+		require.False(t, buffer.dropped(&hydraidepbgo.SubscribeToEventsResponse{SwampName: "users/profiles/alex"}))
+		require.Equal(t, uint64(3), reported)
+		require.True(t, SubscriberBuffer{}.dropped(&hydraidepbgo.SubscribeToEventsResponse{DroppedEvents: 1}), "the drops are skipped without a callback")
+	})
+
+}
+
+func TestTreasureConversion(t *testing.T) {
+
+	t.Run("should keep the type and the metadata of the value", func(t *testing.T) {
+
+		createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		for _, value := range []Value{
+			StringValue("hello"),
+			BoolValue(true),
+			BoolValue(false),
+			BytesValue([]byte{1, 2, 3}),
+			{Type: ValueTypeInt8, Int: -8},
+			{Type: ValueTypeInt16, Int: -16},
+			{Type: ValueTypeInt32, Int: -32},
+			Int64Value(-64),
+			{Type: ValueTypeUint8, Uint: 8},
+			{Type: ValueTypeUint16, Uint: 16},
+			{Type: ValueTypeUint32, Uint: 32},
+			Uint64Value(64),
+			{Type: ValueTypeFloat32, Float: 0.5},
+			Float64Value(0.25),
+		} {
+			treasure := &Treasure{
+				Key:           "key",
+				Value:         value,
+				SchemaVersion: 2,
+				Metadata: map[MetadataField]any{
+					MetadataCreatedAt: createdAt,
+					MetadataCreatedBy: "alice",
+				},
+			}
+			kvPair, err := convertTreasureToKeyValuePair(context.Background(), treasure)
+			require.NoError(t, err, value.Type.String())
+
+			stored := &hydraidepbgo.Treasure{
+				Key: kvPair.Key, IsExist: true, Int8Val: kvPair.Int8Val, Int16Val: kvPair.Int16Val, Int32Val: kvPair.Int32Val,
+				Int64Val: kvPair.Int64Val, Uint8Val: kvPair.Uint8Val, Uint16Val: kvPair.Uint16Val, Uint32Val: kvPair.Uint32Val,
+				Uint64Val: kvPair.Uint64Val, Float32Val: kvPair.Float32Val, Float64Val: kvPair.Float64Val, StringVal: kvPair.StringVal,
+				BoolVal: kvPair.BoolVal, BytesVal: kvPair.BytesVal, CreatedAt: kvPair.CreatedAt, CreatedBy: kvPair.CreatedBy,
+				SchemaVersion: kvPair.SchemaVersion,
+			}
+			require.Equal(t, treasure, convertProtoTreasureToTreasure(stored), value.Type.String())
+		}
+
+	})
+
+	t.Run("should convert the void and the uint32 slice treasures", func(t *testing.T) {
+		kvPair, err := convertTreasureToKeyValuePair(context.Background(), &Treasure{Key: "empty"})
+		require.NoError(t, err)
+		require.True(t, kvPair.GetVoidVal())
+
+		kvPair, err = convertTreasureToKeyValuePair(context.Background(), &Treasure{Key: "ids", Uint32Slice: []uint32{1, 2}})
+		require.NoError(t, err)
+		require.Nil(t, kvPair.VoidVal)
+		require.Equal(t, []uint32{1, 2}, kvPair.GetUint32Slice())
+
+		treasure := convertProtoTreasureToTreasure(&hydraidepbgo.Treasure{Key: "ids", IsExist: true, Uint32Slice: []uint32{1, 2}})
+		require.Equal(t, ValueTypeVoid, treasure.Value.Type)
+		require.Equal(t, []uint32{1, 2}, treasure.Uint32Slice)
+	})
+
+	t.Run("should set the actor as the missing authors", func(t *testing.T) {
+		ctx := WithActor(context.Background(), "importer")
+		kvPair, err := convertTreasureToKeyValuePair(ctx, &Treasure{Key: "key", Value: StringValue("value")})
+		require.NoError(t, err)
+		require.Equal(t, "importer", kvPair.GetCreatedBy())
+		require.Equal(t, "importer", kvPair.GetUpdatedBy())
+
+		kvPair, err = convertTreasureToKeyValuePair(ctx, &Treasure{Key: "key", Metadata: map[MetadataField]any{MetadataCreatedBy: "alice"}})
+		require.NoError(t, err)
+		require.Equal(t, "alice", kvPair.GetCreatedBy(), "the author of the treasure is kept")
+		require.Equal(t, "importer", kvPair.GetUpdatedBy())
+	})
+
+	t.Run("should reject the invalid treasures", func(t *testing.T) {
+		for _, treasure := range []*Treasure{
+			nil,
+			{Value: StringValue("without key")},
+			{Key: "key", Value: Value{Type: ValueType(99)}},
+			{Key: "key", Value: StringValue("value"), Uint32Slice: []uint32{1}},
+			{Key: "key", Metadata: map[MetadataField]any{MetadataExpireAt: "tomorrow"}},
+			{Key: "key", Metadata: map[MetadataField]any{MetadataUpdatedBy: 42}},
+		} {
+			_, err := convertTreasureToKeyValuePair(context.Background(), treasure)
+			require.Error(t, err)
+		}
+	})
+
+}
@@ -0,0 +1,75 @@
+package hydraidetest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// certificateFileName and keyFileName are the names the server expects in its certificate folder
+	certificateFileName = "server.crt"
+	keyFileName         = "server.key"
+)
+
+// writeCertificate generates a throwaway, self-signed TLS certificate for localhost into the folder, and returns
+// the paths of the certificate and the key files. The certificate is its own CA, so the client can trust it by the
+// same file.
+func writeCertificate(folder string) (certificatePath string, keyPath string, err error) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("can not generate the key: %w", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", fmt.Errorf("can not generate the serial number: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: "localhost", Organization: []string{"HydrAIDE test"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", fmt.Errorf("can not create the certificate: %w", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("can not marshal the key: %w", err)
+	}
+
+	certificatePath = filepath.Join(folder, certificateFileName)
+	keyPath = filepath.Join(folder, keyFileName)
+
+	if err := os.WriteFile(certificatePath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0644); err != nil {
+		return "", "", fmt.Errorf("can not write the certificate: %w", err)
+	}
+	// the key is readable by everyone, because the server of the container runs as an other user
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0644); err != nil {
+		return "", "", fmt.Errorf("can not write the key: %w", err)
+	}
+
+	return certificatePath, keyPath, nil
+
+}
@@ -0,0 +1,257 @@
+// Package hydraidetest starts a throwaway HydrAIDE for the integration tests, and tears it down at the end of the
+// test.
+//
+//	func TestUserRepository(t *testing.T) {
+//	    h := hydraidetest.New(t, nil)
+//	    // h is a connected hydraidego.Hydraidego, backed by an empty HydrAIDE
+//	}
+//
+// 🧪 Modes:
+//   - ModeEmbedded (default): the engine runs in the test process, without gRPC. The fastest, and it needs nothing.
+//   - ModeServer: the real server runs in the test process, and the SDK connects to it over gRPC and TLS, with a
+//     generated certificate. Use it to test the full client stack.
+//   - ModeContainer: the server image runs in a Docker container, with a generated certificate. Use it to test
+//     against a released version of the server. Needs the `docker` command.
+//
+// Every HydrAIDE gets its own temporary folder, so the tests can run in parallel.
+package hydraidetest
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/app/server/server"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/embedded"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Mode is the way the HydrAIDE of the test is started
+type Mode int
+
+const (
+	ModeEmbedded  Mode = iota // the engine in the test process, without gRPC
+	ModeServer                // the server in the test process, with gRPC and TLS
+	ModeContainer             // the server in a Docker container, with gRPC and TLS
+)
+
+const (
+	// DefaultImage is the server image of the ModeContainer
+	DefaultImage = "ghcr.io/hydraide/hydraide:latest"
+
+	defaultAllIslands     = 1000
+	defaultStartupTimeout = time.Minute
+	maxMessageSize        = 100 * 1024 * 1024
+	// containerPort is the gRPC port of the server in the container
+	containerPort = "4444/tcp"
+)
+
+// Options are the optional settings of the HydrAIDE of the test
+type Options struct {
+	// Mode is the way the HydrAIDE is started. The default is ModeEmbedded
+	Mode Mode
+	// AllIslands is the number of the Islands the Swamps are hashed to. 0 means 1000
+	AllIslands uint64
+	// Image is the server image of the ModeContainer. Empty means DefaultImage
+	Image string
+	// StartupTimeout is how long the client waits for the server to accept the connection. 0 means 1 minute
+	StartupTimeout time.Duration
+}
+
+// New starts a new, empty HydrAIDE, and returns the SDK connected to it. The HydrAIDE is stopped and its data is
+// removed when the test and all its subtests are finished. Any error of the start fails the test immediately.
+//
+// The options can be nil.
+func New(t testing.TB, options *Options) hydraidego.Hydraidego {
+
+	t.Helper()
+
+	if options == nil {
+		options = &Options{}
+	}
+	allIslands := options.AllIslands
+	if allIslands == 0 {
+		allIslands = defaultAllIslands
+	}
+
+	switch options.Mode {
+	case ModeEmbedded:
+		return newEmbedded(t, allIslands)
+	case ModeServer:
+		return newServer(t, options, allIslands)
+	case ModeContainer:
+		return newContainer(t, options, allIslands)
+	default:
+		t.Fatalf("hydraidetest: unknown mode %d", options.Mode)
+		return nil
+	}
+
+}
+
+func newEmbedded(t testing.TB, allIslands uint64) hydraidego.Hydraidego {
+
+	t.Helper()
+
+	engine, err := embedded.New(&embedded.Options{
+		RootPath:   t.TempDir(),
+		AllIslands: allIslands,
+	})
+	if err != nil {
+		t.Fatalf("hydraidetest: can not start the embedded engine: %v", err)
+	}
+	t.Cleanup(engine.Close)
+
+	return engine.GetHydraidego()
+
+}
+
+func newServer(t testing.TB, options *Options, allIslands uint64) hydraidego.Hydraidego {
+
+	t.Helper()
+
+	certificatePath, keyPath, err := writeCertificate(t.TempDir())
+	if err != nil {
+		t.Fatalf("hydraidetest: %v", err)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatalf("hydraidetest: can not find a free port: %v", err)
+	}
+
+	serverInterface := server.New(&server.Configuration{
+		RootPath:              t.TempDir(),
+		CertificateCrtFile:    certificatePath,
+		CertificateKeyFile:    keyPath,
+		HydraServerPort:       port,
+		HydraMaxMessageSize:   maxMessageSize,
+		DefaultCloseAfterIdle: 1,
+		DefaultWriteInterval:  10,
+		DefaultFileSize:       8192,
+	})
+	if err := serverInterface.Start(); err != nil {
+		t.Fatalf("hydraidetest: can not start the server: %v", err)
+	}
+	t.Cleanup(serverInterface.Stop)
+
+	return connect(t, fmt.Sprintf("localhost:%d", port), certificatePath, options, allIslands)
+
+}
+
+func newContainer(t testing.TB, options *Options, allIslands uint64) hydraidego.Hydraidego {
+
+	t.Helper()
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Fatalf("hydraidetest: the docker command is required by the ModeContainer: %v", err)
+	}
+
+	certificateFolder := t.TempDir()
+	certificatePath, _, err := writeCertificate(certificateFolder)
+	if err != nil {
+		t.Fatalf("hydraidetest: %v", err)
+	}
+
+	image := options.Image
+	if image == "" {
+		image = DefaultImage
+	}
+
+	// the port of the host is chosen by docker, and the files of the container are owned by the user of the test,
+	// so the temporary folder can be removed at the end
+	containerID, err := docker("run", "--detach", "--rm",
+		"--publish", "127.0.0.1::"+containerPort,
+		"--env", fmt.Sprintf("PUID=%d", os.Getuid()),
+		"--env", fmt.Sprintf("PGID=%d", os.Getgid()),
+		"--volume", certificateFolder+":/hydraide/certificate",
+		image)
+	if err != nil {
+		t.Fatalf("hydraidetest: can not start the container: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := docker("stop", containerID); err != nil {
+			t.Logf("hydraidetest: can not stop the container %s: %v", containerID, err)
+		}
+	})
+
+	address, err := docker("port", containerID, containerPort)
+	if err != nil {
+		t.Fatalf("hydraidetest: can not get the port of the container: %v", err)
+	}
+	// the first line is the IPv4 address, like 127.0.0.1:49153
+	address = strings.Split(address, "\n")[0]
+	port := address[strings.LastIndex(address, ":")+1:]
+
+	return connect(t, "localhost:"+port, certificatePath, options, allIslands)
+
+}
+
+// connect connects the SDK to the server, and retries until the server accepts the connection or the startup
+// timeout is over
+func connect(t testing.TB, host string, certificatePath string, options *Options, allIslands uint64) hydraidego.Hydraidego {
+
+	t.Helper()
+
+	startupTimeout := options.StartupTimeout
+	if startupTimeout == 0 {
+		startupTimeout = defaultStartupTimeout
+	}
+
+	servers := []*client.Server{{
+		Host:         host,
+		FromIsland:   1,
+		ToIsland:     allIslands,
+		CertFilePath: certificatePath,
+	}}
+
+	deadline := time.Now().Add(startupTimeout)
+	for {
+
+		clientInterface := client.New(servers, allIslands, maxMessageSize)
+		err := clientInterface.Connect(false)
+		if err == nil {
+			t.Cleanup(clientInterface.CloseConnection)
+			h := hydraidego.New(clientInterface)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if heartbeatErr := h.Heartbeat(ctx); heartbeatErr != nil {
+				t.Fatalf("hydraidetest: the server is not healthy: %v", heartbeatErr)
+			}
+			return h
+		}
+
+		clientInterface.CloseConnection()
+		if time.Now().After(deadline) {
+			t.Fatalf("hydraidetest: can not connect to the server %s: %v", host, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+
+	}
+
+}
+
+// freePort returns a TCP port of the localhost which is free at the moment
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// docker runs a docker command and returns its trimmed output
+func docker(arguments ...string) (string, error) {
+	output, err := exec.Command("docker", arguments...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", arguments[0], err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
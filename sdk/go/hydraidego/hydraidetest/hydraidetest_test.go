@@ -0,0 +1,63 @@
+package hydraidetest
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+type testModel struct {
+	Key   string `hydraide:"key"`
+	Value string `hydraide:"value"`
+}
+
+func TestNew(t *testing.T) {
+
+	swampName := name.New().Sanctuary("hydraidetest").Realm("test").Swamp("models")
+
+	saveAndRead := func(t *testing.T, h hydraidego.Hydraidego) {
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		assert.Nil(t, h.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+			SwampPattern:   swampName,
+			CloseAfterIdle: time.Hour,
+			FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+				WriteInterval: time.Second,
+				MaxFileSize:   8192,
+			},
+		}))
+
+		_, err := h.CatalogSave(ctx, swampName, &testModel{Key: "alpha", Value: "value"})
+		assert.NoError(t, err)
+
+		read := &testModel{}
+		assert.NoError(t, h.CatalogRead(ctx, swampName, "alpha", read))
+		assert.Equal(t, "value", read.Value)
+
+		assert.True(t, hydraidego.IsNotFound(h.CatalogRead(ctx, swampName, "missing", &testModel{})))
+
+	}
+
+	t.Run("should start an embedded engine", func(t *testing.T) {
+		saveAndRead(t, New(t, nil))
+	})
+
+	t.Run("should start a server with a throwaway certificate", func(t *testing.T) {
+		saveAndRead(t, New(t, &Options{Mode: ModeServer}))
+	})
+
+	t.Run("should start a server container", func(t *testing.T) {
+		if _, err := exec.LookPath("docker"); err != nil || os.Getenv("HYDRAIDETEST_CONTAINER") == "" {
+			t.Skip("set HYDRAIDETEST_CONTAINER and install docker to test the container mode")
+		}
+		saveAndRead(t, New(t, &Options{Mode: ModeContainer}))
+	})
+
+}
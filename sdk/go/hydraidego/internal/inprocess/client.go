@@ -0,0 +1,73 @@
+package inprocess
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+)
+
+// NewClient returns a client that routes every Swamp to the same service client, under the given host name. The max
+// message size should be the MaxMessageSize of the connection of the service client, 0 means unlimited.
+func NewClient(serviceClient hydraidepbgo.HydraideServiceClient, host string, allIslands uint64, maxMessageSize int) client.Client {
+	return &inProcessClient{
+		serviceClient:  serviceClient,
+		host:           host,
+		allIslands:     allIslands,
+		maxMessageSize: maxMessageSize,
+	}
+}
+
+type inProcessClient struct {
+	serviceClient hydraidepbgo.HydraideServiceClient
+	host           string
+	allIslands     uint64
+	maxMessageSize int
+}
+
+func (c *inProcessClient) Connect(_ bool) error { return nil }
+func (c *inProcessClient) CloseConnection()     {}
+func (c *inProcessClient) GetAllIslands() uint64 {
+	return c.allIslands
+}
+
+// GetMaxMessageSize returns the max message size given in NewClient
+func (c *inProcessClient) GetMaxMessageSize() int {
+	return c.maxMessageSize
+}
+
+func (c *inProcessClient) GetServiceClient(_ name.Name) hydraidepbgo.HydraideServiceClient {
+	return c.serviceClient
+}
+
+func (c *inProcessClient) GetServiceClientAndHost(_ name.Name) *client.ServiceClient {
+	return &client.ServiceClient{
+		GrpcClient: c.serviceClient,
+		Host:       c.host,
+	}
+}
+
+// AnalyzeCluster analyzes the only server, which serves every Island
+func (c *inProcessClient) AnalyzeCluster(ctx context.Context) (*client.ClusterReport, error) {
+
+	server := client.AnalyzeServer(ctx, &client.Server{Host: c.host, FromIsland: 1, ToIsland: c.allIslands}, c.serviceClient)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &client.ClusterReport{
+		Servers:        []*client.ServerReport{server},
+		AllIslands:     c.allIslands,
+		CoveredIslands: c.allIslands,
+	}, nil
+
+}
+
+func (c *inProcessClient) GetUniqueServiceClients() []hydraidepbgo.HydraideServiceClient {
+	return []hydraidepbgo.HydraideServiceClient{c.serviceClient}
+}
+
+// MigrateIslands is not supported, the only server serves every Island
+func (c *inProcessClient) MigrateIslands(_ context.Context, _, _ uint64, _ string) (*client.MigrationReport, error) {
+	return nil, client.ErrMigrationNeedsSeeds
+}
@@ -0,0 +1,299 @@
+// Package inprocess connects the SDK to a HydrAIDE service in the same process, without gRPC. It is shared by the
+// embedded engine and the fake of the SDK.
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"sync"
+)
+
+// Conn is a gRPC connection that calls the handlers of the service directly, in the same process.
+//
+// The messages are copied in both directions, so the caller and the service never share a message, like with a real
+// connection. Nothing is serialized, and there is no network, TLS or HTTP/2 in between.
+type Conn struct {
+	// WaitForStreamReady makes the CloseSend of the client wait until the handler of the stream calls StreamReady,
+	// or returns. The generated clients of the server streams call CloseSend after the request is sent, so the
+	// caller can not miss anything, for example an event of a subscription, that happens after the call returned.
+	// Only for services whose stream handlers call StreamReady.
+	WaitForStreamReady bool
+	// MaxMessageSize emulates the max message size of a gRPC connection in bytes. A larger message fails with a
+	// ResourceExhausted error with the MESSAGE_TOO_LARGE reason, like the guarded gRPC client of the SDK. 0 means
+	// unlimited.
+	MaxMessageSize int
+
+	service hydraidepbgo.HydraideServiceServer
+	methods map[string]grpc.MethodDesc
+	streams map[string]grpc.StreamDesc
+
+	// mu guards closed and cancels, handlers counts the running stream handlers
+	mu       sync.Mutex
+	closed   bool
+	cancels  map[*inProcessStream]context.CancelFunc
+	handlers sync.WaitGroup
+}
+
+// errorDomain is the domain of the ErrorInfo details, the same as the server uses
+const errorDomain = "hydraide"
+
+// streamReadyKey is the context key of the ready function of the stream
+type streamReadyKey struct{}
+
+// StreamReady tells the connection that the stream handler of the context is ready. It does nothing if the context
+// is not the context of an in-process stream.
+func StreamReady(ctx context.Context) {
+	if ready, ok := ctx.Value(streamReadyKey{}).(func()); ok {
+		ready()
+	}
+}
+
+// NewConn creates the connection from the service descriptor, so every RPC of the service is reachable
+func NewConn(service hydraidepbgo.HydraideServiceServer) *Conn {
+
+	desc := hydraidepbgo.HydraideService_ServiceDesc
+	c := &Conn{
+		service: service,
+		methods: make(map[string]grpc.MethodDesc, len(desc.Methods)),
+		streams: make(map[string]grpc.StreamDesc, len(desc.Streams)),
+		cancels: make(map[*inProcessStream]context.CancelFunc),
+	}
+	for _, method := range desc.Methods {
+		c.methods[fmt.Sprintf("/%s/%s", desc.ServiceName, method.MethodName)] = method
+	}
+	for _, stream := range desc.Streams {
+		c.streams[fmt.Sprintf("/%s/%s", desc.ServiceName, stream.StreamName)] = stream
+	}
+	return c
+
+}
+
+func (c *Conn) Invoke(ctx context.Context, method string, args any, reply any, _ ...grpc.CallOption) error {
+
+	desc, ok := c.methods[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if err := checkMessageSize(args, c.MaxMessageSize, "trying to send"); err != nil {
+		return err
+	}
+
+	response, err := desc.Handler(c.service, ctx, func(in any) error {
+		proto.Merge(in.(proto.Message), args.(proto.Message))
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if err := checkMessageSize(response, c.MaxMessageSize, "received"); err != nil {
+		return err
+	}
+
+	proto.Reset(reply.(proto.Message))
+	proto.Merge(reply.(proto.Message), response.(proto.Message))
+	return nil
+
+}
+
+func (c *Conn) NewStream(ctx context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+
+	desc, ok := c.streams[method]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+
+	ready := make(chan struct{})
+	var readyOnce sync.Once
+	streamCtx, cancel := context.WithCancel(context.WithValue(ctx, streamReadyKey{}, func() {
+		readyOnce.Do(func() { close(ready) })
+	}))
+	stream := &inProcessStream{
+		ctx:            streamCtx,
+		maxMessageSize: c.MaxMessageSize,
+		ready:     ready,
+		waitReady: c.WaitForStreamReady,
+		requests:  make(chan proto.Message, 1),
+		responses: make(chan proto.Message),
+		done:      make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		cancel()
+		return nil, status.Error(codes.Unavailable, "the connection is closed")
+	}
+	c.cancels[stream] = cancel
+	c.handlers.Add(1)
+	c.mu.Unlock()
+
+	// the handler runs until the client cancels the context, the connection is closed, or the service ends the stream
+	go func() {
+		defer c.handlers.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.cancels, stream)
+			c.mu.Unlock()
+			cancel()
+		}()
+		stream.err = desc.Handler(c.service, &serverStream{stream})
+		close(stream.done)
+	}()
+
+	return stream, nil
+
+}
+
+// Close cancels the open streams, and waits until their handlers return. The new streams fail with an Unavailable
+// error after the Close, so the service can be stopped without running handlers.
+func (c *Conn) Close() {
+
+	c.mu.Lock()
+	c.closed = true
+	for _, cancel := range c.cancels {
+		cancel()
+	}
+	c.mu.Unlock()
+
+	c.handlers.Wait()
+
+}
+
+// inProcessStream is the client side of a stream. The server side is the serverStream wrapper of the same stream.
+type inProcessStream struct {
+	ctx       context.Context
+	requests  chan proto.Message
+	responses chan proto.Message
+	done      chan struct{}
+	ready     chan struct{}
+	waitReady bool
+	// maxMessageSize is the MaxMessageSize of the connection
+	maxMessageSize int
+	err            error // the result of the handler, readable after done is closed
+	closeOnce sync.Once
+}
+
+func (s *inProcessStream) Header() (metadata.MD, error) { return metadata.MD{}, nil }
+func (s *inProcessStream) Trailer() metadata.MD         { return metadata.MD{} }
+func (s *inProcessStream) Context() context.Context     { return s.ctx }
+
+func (s *inProcessStream) CloseSend() error {
+	s.closeOnce.Do(func() { close(s.requests) })
+	if s.waitReady {
+		select {
+		case <-s.ready:
+		case <-s.done:
+			// the handler failed before it was ready, the error is returned by the first RecvMsg
+		case <-s.ctx.Done():
+			return status.FromContextError(s.ctx.Err()).Err()
+		}
+	}
+	return nil
+}
+
+func (s *inProcessStream) SendMsg(m any) error {
+	if err := checkMessageSize(m, s.maxMessageSize, "trying to send"); err != nil {
+		return err
+	}
+	select {
+	case s.requests <- proto.Clone(m.(proto.Message)):
+		return nil
+	case <-s.done:
+		return io.EOF
+	case <-s.ctx.Done():
+		// the context is canceled after the handler returned, too, and then its error is read by the RecvMsg
+		select {
+		case <-s.done:
+			return io.EOF
+		default:
+		}
+		return status.FromContextError(s.ctx.Err()).Err()
+	}
+}
+
+func (s *inProcessStream) RecvMsg(m any) error {
+	select {
+	case response := <-s.responses:
+		proto.Reset(m.(proto.Message))
+		proto.Merge(m.(proto.Message), response)
+		return nil
+	case <-s.done:
+		// the responses channel is unbuffered, so every sent response is received before the handler returns
+		if s.err != nil {
+			return s.err
+		}
+		return io.EOF
+	}
+}
+
+// serverStream is the server side of an inProcessStream
+type serverStream struct {
+	stream *inProcessStream
+}
+
+func (s *serverStream) SetHeader(metadata.MD) error  { return nil }
+func (s *serverStream) SendHeader(metadata.MD) error { return nil }
+func (s *serverStream) SetTrailer(metadata.MD)       {}
+func (s *serverStream) Context() context.Context     { return s.stream.ctx }
+
+func (s *serverStream) SendMsg(m any) error {
+	if err := checkMessageSize(m, s.stream.maxMessageSize, "received"); err != nil {
+		return err
+	}
+	select {
+	case s.stream.responses <- proto.Clone(m.(proto.Message)):
+		return nil
+	case <-s.stream.ctx.Done():
+		return status.FromContextError(s.stream.ctx.Err()).Err()
+	}
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	select {
+	case request, ok := <-s.stream.requests:
+		if !ok {
+			return io.EOF
+		}
+		proto.Reset(m.(proto.Message))
+		proto.Merge(m.(proto.Message), request)
+		return nil
+	case <-s.stream.ctx.Done():
+		return status.FromContextError(s.stream.ctx.Err()).Err()
+	}
+}
+
+// checkMessageSize returns a ResourceExhausted error with the MESSAGE_TOO_LARGE reason if the message is larger than
+// the max message size, with the same message as gRPC. Returns nil if the max message size is 0.
+func checkMessageSize(m any, maxMessageSize int, direction string) error {
+
+	if maxMessageSize <= 0 {
+		return nil
+	}
+
+	size := proto.Size(m.(proto.Message))
+	if size <= maxMessageSize {
+		return nil
+	}
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("grpc: %s message larger than max (%d vs. %d)", direction, size, maxMessageSize))
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: hydraidepbgo.ErrorReason_MESSAGE_TOO_LARGE.String(),
+		Domain: errorDomain,
+	}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+
+}
@@ -0,0 +1,246 @@
+package hydraidego
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// MigrationFunc upgrades a model from one schema version to the next one.
+//
+// The function receives a pointer to the model, already filled with the stored data, and modifies it in place.
+// For example, it can fill a new field from an old one, split a field, or set a default value.
+//
+// ⚠️ The model is decoded with the current struct type, so the fields the migration reads from must still exist
+// in the struct (mark them as deprecated instead of removing them, until all data is migrated).
+type MigrationFunc func(model any) error
+
+// modelMigrations holds the registered migrations of one model type
+type modelMigrations struct {
+	// steps maps the source version to the migration that upgrades the model to the next version
+	steps map[uint64]MigrationFunc
+	// current is the latest schema version of the model
+	current uint64
+	// writeBack is true if the upgraded model should be saved back to HydrAIDE after the migration
+	writeBack bool
+}
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = make(map[reflect.Type]*modelMigrations)
+)
+
+// RegisterMigration registers an upgrade function for the model type, from `fromVersion` to `fromVersion + 1`.
+//
+// 🧬 Schema versioning in HydrAIDE:
+//
+//   - Mark an integer field of the model with the `hydraide:"version"` tag.
+//   - Register one migration per version step: v1→v2, v2→v3, and so on.
+//   - The latest version of the model is the highest registered target version.
+//   - When writing, a zero version field is stamped with the latest version automatically.
+//   - When reading with `CatalogRead()` or `ProfileRead()`, models stored with an older version are upgraded
+//     transparently by running the migrations in order, and the version field is set to the latest version.
+//   - Data written before versioning was introduced has no version. It is handled as version 1.
+//
+// 🔧 Example:
+//
+//	type User struct {
+//	    ID       string `hydraide:"key"`
+//	    Profile  *UserProfile `hydraide:"value"`
+//	    Version  uint32 `hydraide:"version"`
+//	}
+//
+//	err := hydraidego.RegisterMigration(User{}, 1, func(model any) error {
+//	    user := model.(*User)
+//	    user.Profile.DisplayName = user.Profile.FirstName + " " + user.Profile.LastName
+//	    return nil
+//	})
+//
+// The model can be passed as a struct or as a pointer to a struct. The function is safe for concurrent use,
+// but the migrations should be registered at startup, before the first read.
+func RegisterMigration(model any, fromVersion uint64, migration MigrationFunc) error {
+
+	if migration == nil {
+		return errors.New("migration function cannot be nil")
+	}
+	if fromVersion < 1 {
+		return errors.New("fromVersion must be at least 1")
+	}
+
+	t, err := migrationModelType(model)
+	if err != nil {
+		return err
+	}
+	if _, ok := findVersionField(t); !ok {
+		return fmt.Errorf("model %s has no integer field with the `hydraide:\"version\"` tag", t.Name())
+	}
+
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+
+	m, ok := migrations[t]
+	if !ok {
+		m = &modelMigrations{steps: make(map[uint64]MigrationFunc)}
+		migrations[t] = m
+	}
+	if _, exists := m.steps[fromVersion]; exists {
+		return fmt.Errorf("migration from version %d is already registered for model %s", fromVersion, t.Name())
+	}
+
+	m.steps[fromVersion] = migration
+	if fromVersion+1 > m.current {
+		m.current = fromVersion + 1
+	}
+
+	return nil
+
+}
+
+// SetMigrationWriteBack sets whether the upgraded models are saved back to HydrAIDE after a migration.
+//
+// With write-back enabled, every model is migrated only once: the first read upgrades it, and saves the new form,
+// so the next reads find the latest version and skip the migrations. Without write-back (the default), the stored
+// data is never modified by a read, and the migrations run at every read.
+func SetMigrationWriteBack(model any, writeBack bool) error {
+
+	t, err := migrationModelType(model)
+	if err != nil {
+		return err
+	}
+
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+
+	m, ok := migrations[t]
+	if !ok {
+		return fmt.Errorf("no migration is registered for model %s", t.Name())
+	}
+	m.writeBack = writeBack
+
+	return nil
+
+}
+
+// migrateModel upgrades the model to the latest schema version if it is stored with an older one.
+// Returns true if any migration ran, and whether the model should be written back.
+func migrateModel(model any) (migrated bool, writeBack bool, err error) {
+
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return false, false, nil
+	}
+
+	t := v.Elem().Type()
+
+	migrationsMu.RLock()
+	m, ok := migrations[t]
+	migrationsMu.RUnlock()
+	if !ok {
+		return false, false, nil
+	}
+
+	fieldIndex, _ := findVersionField(t)
+	versionField := v.Elem().Field(fieldIndex)
+
+	version := getVersionFieldValue(versionField)
+	if version == 0 {
+		// stored before versioning was introduced
+		version = 1
+	}
+
+	for version < m.current {
+		step, exists := m.steps[version]
+		if !exists {
+			return migrated, false, fmt.Errorf("no migration is registered for model %s from version %d", t.Name(), version)
+		}
+		if err := step(model); err != nil {
+			return migrated, false, fmt.Errorf("migration of model %s from version %d failed: %w", t.Name(), version, err)
+		}
+		version++
+		migrated = true
+	}
+
+	setVersionFieldValue(versionField, version)
+
+	return migrated, migrated && m.writeBack, nil
+
+}
+
+// currentSchemaVersion returns the latest registered schema version of the model type, or 0 if the model type has
+// no migrations
+func currentSchemaVersion(t reflect.Type) uint64 {
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+	if m, ok := migrations[t]; ok {
+		return m.current
+	}
+	return 0
+}
+
+// schemaVersionToWrite returns the version that should be stored for the version field: the value of the field,
+// or the latest registered version if the field is zero
+func schemaVersionToWrite(modelType reflect.Type, field reflect.Value) uint64 {
+	if version := getVersionFieldValue(field); version > 0 {
+		return version
+	}
+	return currentSchemaVersion(modelType)
+}
+
+func migrationModelType(model any) (reflect.Type, error) {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return nil, errors.New("model cannot be nil")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("model must be a struct or a pointer to a struct")
+	}
+	return t, nil
+}
+
+// findVersionField returns the index of the field tagged with `hydraide:"version"`
+func findVersionField(t reflect.Type) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup(tagHydrAIDE); ok && tag == tagVersion && isVersionKind(t.Field(i).Type.Kind()) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func isVersionKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func getVersionFieldValue(field reflect.Value) uint64 {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Int() < 0 {
+			return 0
+		}
+		return uint64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint()
+	default:
+		return 0
+	}
+}
+
+func setVersionFieldValue(field reflect.Value, version uint64) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(int64(version))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(version)
+	default:
+	}
+}
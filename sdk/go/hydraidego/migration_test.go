@@ -0,0 +1,118 @@
+package hydraidego
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type migrationTestModel struct {
+	Key         string `hydraide:"key"`
+	Value       string `hydraide:"value"`
+	SchemaVer   uint32 `hydraide:"version"`
+	DisplayName string
+}
+
+type migrationTestModelWithoutVersion struct {
+	Key   string `hydraide:"key"`
+	Value string `hydraide:"value"`
+}
+
+func TestMigration(t *testing.T) {
+
+	t.Run("register validation", func(t *testing.T) {
+		noop := func(model any) error { return nil }
+		require.Error(t, RegisterMigration(migrationTestModelWithoutVersion{}, 1, noop))
+		require.Error(t, RegisterMigration(migrationTestModel{}, 0, noop))
+		require.Error(t, RegisterMigration(migrationTestModel{}, 1, nil))
+		require.Error(t, RegisterMigration("not a struct", 1, noop))
+		require.Error(t, SetMigrationWriteBack(migrationTestModelWithoutVersion{}, true))
+	})
+
+	require.NoError(t, RegisterMigration(migrationTestModel{}, 1, func(model any) error {
+		m := model.(*migrationTestModel)
+		m.DisplayName = "v2:" + m.Value
+		return nil
+	}))
+	require.NoError(t, RegisterMigration(&migrationTestModel{}, 2, func(model any) error {
+		m := model.(*migrationTestModel)
+		m.DisplayName += ":v3"
+		return nil
+	}))
+	require.Error(t, RegisterMigration(migrationTestModel{}, 2, func(model any) error { return nil }), "duplicated step")
+
+	t.Run("migrate unversioned model", func(t *testing.T) {
+		m := &migrationTestModel{Key: "k", Value: "hello"}
+		migrated, writeBack, err := migrateModel(m)
+		require.NoError(t, err)
+		require.True(t, migrated)
+		require.False(t, writeBack)
+		require.Equal(t, uint32(3), m.SchemaVer)
+		require.Equal(t, "v2:hello:v3", m.DisplayName)
+	})
+
+	t.Run("migrate from the middle", func(t *testing.T) {
+		m := &migrationTestModel{Key: "k", Value: "hello", SchemaVer: 2}
+		migrated, _, err := migrateModel(m)
+		require.NoError(t, err)
+		require.True(t, migrated)
+		require.Equal(t, ":v3", m.DisplayName)
+	})
+
+	t.Run("latest version is not migrated", func(t *testing.T) {
+		m := &migrationTestModel{Key: "k", Value: "hello", SchemaVer: 3}
+		migrated, _, err := migrateModel(m)
+		require.NoError(t, err)
+		require.False(t, migrated)
+		require.Empty(t, m.DisplayName)
+	})
+
+	t.Run("write back", func(t *testing.T) {
+		require.NoError(t, SetMigrationWriteBack(migrationTestModel{}, true))
+		defer func() {
+			require.NoError(t, SetMigrationWriteBack(migrationTestModel{}, false))
+		}()
+		m := &migrationTestModel{Key: "k", Value: "hello", SchemaVer: 1}
+		migrated, writeBack, err := migrateModel(m)
+		require.NoError(t, err)
+		require.True(t, migrated)
+		require.True(t, writeBack)
+	})
+
+	t.Run("unregistered model", func(t *testing.T) {
+		m := &migrationTestModelWithoutVersion{Key: "k"}
+		migrated, _, err := migrateModel(m)
+		require.NoError(t, err)
+		require.False(t, migrated)
+	})
+
+	t.Run("catalog conversion stamps and reads the version", func(t *testing.T) {
+		kvPair, err := convertCatalogModelToKeyValuePair(context.Background(), &migrationTestModel{Key: "k", Value: "hello"})
+		require.NoError(t, err)
+		require.NotNil(t, kvPair.SchemaVersion)
+		require.Equal(t, uint32(3), kvPair.GetSchemaVersion())
+
+		version := uint32(1)
+		value := "hello"
+		loaded := &migrationTestModel{}
+		require.NoError(t, convertProtoTreasureToCatalogModel(&hydraidepbgo.Treasure{
+			Key: "k", IsExist: true, StringVal: &value, SchemaVersion: &version,
+		}, loaded, false))
+		require.Equal(t, uint32(1), loaded.SchemaVer)
+	})
+
+	t.Run("migration error", func(t *testing.T) {
+		type failingModel struct {
+			Key     string `hydraide:"key"`
+			Version int    `hydraide:"version"`
+		}
+		require.NoError(t, RegisterMigration(failingModel{}, 1, func(model any) error {
+			return errors.New("broken")
+		}))
+		_, _, err := migrateModel(&failingModel{Key: "k"})
+		require.Error(t, err)
+	})
+
+}
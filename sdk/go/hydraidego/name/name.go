@@ -20,6 +20,14 @@
 //
 // Use Load(path) to reconstruct a Name from an existing path string.
 //
+// Use Parse(path) to build a Name from user input. Unlike the builder and Load, Parse validates the
+// parts of the name, so invalid characters and path traversal can't create unexpected Swamps:
+//
+//	swampName, err := Parse("users/profiles/alice123")
+//	if errors.Is(err, ErrInvalidName) { ... }
+//
+// Match(pattern, name) and Matches(pattern, paths) find the names matching a wildcard pattern.
+//
 // This package is used across HydrAIDE SDKs to:
 // - Determine data placement
 // - Support distributed architectures
@@ -32,11 +40,24 @@
 package name
 
 import (
+	"errors"
+	"fmt"
 	"github.com/cespare/xxhash/v2"
+	"iter"
 	"strings"
 	"sync"
 )
 
+const (
+	// MaxPartLength is the max length of a Sanctuary, Realm or Swamp part of a name in bytes.
+	MaxPartLength = 128
+	// wildcard is the part of a pattern matching any part of a name
+	wildcard = "*"
+)
+
+// ErrInvalidName is wrapped by the errors of Parse and Validate.
+var ErrInvalidName = errors.New("invalid swamp name")
+
 // Name defines a structured identifier used in HydrAIDE to deterministically
 // map data into a distributed, folder-based architecture.
 //
@@ -183,6 +204,151 @@ func (n *name) IsWildcardPattern() bool {
 	return n.SanctuaryID == "*" || n.RealmName == "*" || n.SwampName == "*"
 }
 
+// Parse builds a Name from a path string in the format:
+//
+//	"sanctuary/realm/swamp"
+//
+// Unlike Load, Parse is safe for user input. It canonicalizes the path by trimming the surrounding whitespace and
+// slashes, then validates every part with the rules of Validate. A part can be a "*" wildcard, so Parse also accepts
+// the patterns, e.g. "users/profiles/*".
+//
+// The returned error wraps ErrInvalidName, and names the invalid part.
+//
+// Example:
+//
+//	swampName, err := name.Parse(" /users/profiles/alice123/ ")
+//	// swampName.Get() == "users/profiles/alice123"
+func Parse(path string) (Name, error) {
+
+	canonical := strings.Trim(strings.TrimSpace(path), "/")
+	if err := validatePath(canonical); err != nil {
+		return nil, err
+	}
+
+	return Load(canonical), nil
+
+}
+
+// Validate returns an error wrapping ErrInvalidName, if any part of the name is invalid.
+//
+// A valid part:
+// - is not empty, and not longer than MaxPartLength bytes
+// - contains only the ASCII letters, digits, and the '-', '_' and '.' characters
+// - is not "." or "..", so it can't be used for path traversal
+// - or is a "*" wildcard
+//
+// 💡 The builder (New().Sanctuary().Realm().Swamp()) does not validate the parts. Call Validate, if the parts come
+// from user input.
+func Validate(n Name) error {
+
+	if n == nil {
+		return fmt.Errorf("%w: the name is nil", ErrInvalidName)
+	}
+
+	return validatePath(n.Get())
+
+}
+
+// validatePath returns an error wrapping ErrInvalidName, if the path is not a valid sanctuary/realm/swamp path
+func validatePath(path string) error {
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: %q must have 3 parts in the format sanctuary/realm/swamp", ErrInvalidName, path)
+	}
+
+	for i, level := range []string{"sanctuary", "realm", "swamp"} {
+		if err := validatePart(parts[i]); err != nil {
+			return fmt.Errorf("%w: the %s %q %v", ErrInvalidName, level, parts[i], err)
+		}
+	}
+
+	return nil
+
+}
+
+// validatePart returns an error, if the part of the name is invalid
+func validatePart(part string) error {
+
+	if part == wildcard {
+		return nil
+	}
+
+	if part == "" {
+		return errors.New("is empty")
+	}
+
+	if len(part) > MaxPartLength {
+		return fmt.Errorf("is longer than %d bytes", MaxPartLength)
+	}
+
+	if part == "." || part == ".." {
+		return errors.New("is a relative path")
+	}
+
+	for _, r := range part {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			continue
+		}
+		return fmt.Errorf("contains the invalid character %q", r)
+	}
+
+	return nil
+
+}
+
+// Match returns true if the name matches the pattern. A "*" part of the pattern matches any part of the name,
+// the other parts must be equal. A name without wildcards matches only itself.
+//
+// Example:
+//
+//	pattern := name.New().Sanctuary("users").Realm("profiles").Swamp("*")
+//	name.Match(pattern, name.New().Sanctuary("users").Realm("profiles").Swamp("alice123")) // true
+func Match(pattern Name, n Name) bool {
+
+	if pattern == nil || n == nil {
+		return false
+	}
+
+	patternParts := strings.Split(pattern.Get(), "/")
+	nameParts := strings.Split(n.Get(), "/")
+	if len(patternParts) != len(nameParts) {
+		return false
+	}
+
+	for i, part := range patternParts {
+		if part != wildcard && part != nameParts[i] {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// Matches iterates over the names of the paths matching the pattern, in the order of the paths.
+// The invalid paths are skipped, so the function is safe for paths coming from user input or from a listing.
+//
+// Example:
+//
+//	pattern := name.New().Sanctuary("users").Realm("profiles").Swamp("*")
+//	for swampName := range name.Matches(pattern, paths) {
+//	    fmt.Println(swampName.Get())
+//	}
+func Matches(pattern Name, paths []string) iter.Seq[Name] {
+	return func(yield func(Name) bool) {
+		for _, path := range paths {
+			n, err := Parse(path)
+			if err != nil || n.IsWildcardPattern() || !Match(pattern, n) {
+				continue
+			}
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
 // Load reconstructs a Name from a given path string in the format:
 //
 //	"sanctuary/realm/swamp"
@@ -2,8 +2,10 @@ package name
 
 import (
 	"crypto/rand"
+	"github.com/stretchr/testify/assert"
 	"math/big"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -57,6 +59,75 @@ func TestGetFolderNumberDistribution(t *testing.T) {
 	}
 }
 
+func TestParse(t *testing.T) {
+
+	t.Run("should parse and canonicalize the valid names", func(t *testing.T) {
+		n, err := Parse(" /users/profiles/alice-123_v1.0/ ")
+		assert.NoError(t, err)
+		assert.Equal(t, "users/profiles/alice-123_v1.0", n.Get())
+		assert.Equal(t, New().Sanctuary("users").Realm("profiles").Swamp("alice-123_v1.0").GetIslandID(allFolders), n.GetIslandID(allFolders))
+
+		pattern, err := Parse("users/*/*")
+		assert.NoError(t, err)
+		assert.True(t, pattern.IsWildcardPattern())
+	})
+
+	t.Run("should reject the invalid names", func(t *testing.T) {
+		for _, path := range []string{
+			"",
+			"users/profiles",
+			"users/profiles/alice/extra",
+			"users//alice",
+			"users/../alice",
+			"users/./alice",
+			"users/profiles/alice bob",
+			"users/profiles/al\\ice",
+			"users/profiles/álice",
+			"users/profiles/" + strings.Repeat("a", MaxPartLength+1),
+		} {
+			_, err := Parse(path)
+			assert.ErrorIs(t, err, ErrInvalidName, path)
+		}
+	})
+
+	t.Run("should validate the names of the builder", func(t *testing.T) {
+		assert.NoError(t, Validate(New().Sanctuary("users").Realm("profiles").Swamp("alice")))
+		err := Validate(New().Sanctuary("users").Realm("profiles").Swamp("../../etc"))
+		assert.ErrorIs(t, err, ErrInvalidName)
+		err = Validate(New().Sanctuary("users").Realm("pro:files").Swamp("alice"))
+		assert.ErrorIs(t, err, ErrInvalidName)
+		assert.Contains(t, err.Error(), "realm")
+		assert.ErrorIs(t, Validate(nil), ErrInvalidName)
+	})
+
+}
+
+func TestMatch(t *testing.T) {
+
+	pattern := New().Sanctuary("users").Realm("*").Swamp("alice")
+
+	t.Run("should match the names of the pattern", func(t *testing.T) {
+		assert.True(t, Match(pattern, New().Sanctuary("users").Realm("profiles").Swamp("alice")))
+		assert.False(t, Match(pattern, New().Sanctuary("users").Realm("profiles").Swamp("bob")))
+		assert.True(t, Match(Load("users/profiles/alice"), Load("users/profiles/alice")))
+		assert.False(t, Match(nil, Load("users/profiles/alice")))
+	})
+
+	t.Run("should iterate over the valid matching paths", func(t *testing.T) {
+		var matches []string
+		for n := range Matches(pattern, []string{"users/profiles/alice", "users/settings/alice", "users/*/alice", "users/profiles/bob", "users/../alice", "users/x"}) {
+			matches = append(matches, n.Get())
+		}
+		assert.Equal(t, []string{"users/profiles/alice", "users/settings/alice"}, matches)
+
+		for n := range Matches(pattern, []string{"users/profiles/alice", "users/settings/alice"}) {
+			assert.Equal(t, "users/profiles/alice", n.Get())
+			break
+		}
+	})
+
+}
+
 // BenchmarkName_Load measures the performance of reconstructing a Name
 // from a canonical path string (e.g. "users/johndoe/info").
 // goos: linux
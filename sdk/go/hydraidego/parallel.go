@@ -0,0 +1,174 @@
+package hydraidego
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"sort"
+	"sync"
+)
+
+// SwampFunc is the function called for every Swamp by `ParallelForEachSwamp()`.
+type SwampFunc func(ctx context.Context, swampName name.Name) error
+
+// ParallelForEachSwamp calls fn for every Swamp of the patterns, in parallel, with at most concurrencyPerServer calls
+// running at the same time per server.
+//
+// 📦 A naive goroutine loop over the Swamps of a bulk operation (e.g. a migration) hammers the server of the Swamps
+// that happen to be first, while the other servers are idle. This helper partitions the Swamps by the server their
+// Island is routed to, and runs a bounded worker pool per server, so all servers are loaded evenly, and none of them
+// gets more than concurrencyPerServer parallel calls from the helper.
+//
+// ⚙️ Behavior:
+//   - A wildcard pattern (e.g. `users/profiles/*`) is resolved to the existing Swamps matching it, by `ExistsMany()`
+//   - A Swamp name without wildcard is passed to fn as it is, even if the Swamp does not exist yet
+//   - Every Swamp is passed to fn once, even if it matches more patterns
+//   - The Swamps of the same server are processed in the alphabetical order of their names
+//
+// ⚠️ If fn returns an error, no more Swamps are started, the context of the running calls is canceled, and the first
+// error is returned after the running calls returned.
+//
+// 🧯 Errors:
+//   - fn is nil or concurrencyPerServer is less than 1 → `ErrCodeInvalidArgument`
+//   - A pattern is nil, or a wildcard pattern can not be resolved → the error of `ExistsMany()`
+//   - The context is canceled or its deadline exceeded → `ErrCodeCtxClosedByClient` or `ErrCodeCtxTimeout`
+//
+// 🔧 Example:
+//
+//	err := h.ParallelForEachSwamp(ctx, []name.Name{
+//	    name.New().Sanctuary("users").Realm("profiles").Swamp("*"),
+//	}, 4, func(ctx context.Context, swampName name.Name) error {
+//	    return migrateProfile(ctx, h, swampName)
+//	})
+func (h *hydraidego) ParallelForEachSwamp(ctx context.Context, patterns []name.Name, concurrencyPerServer int, fn SwampFunc) error {
+
+	if fn == nil {
+		return NewError(ErrCodeInvalidArgument, "fn can not be nil")
+	}
+	if concurrencyPerServer < 1 {
+		return NewError(ErrCodeInvalidArgument, "concurrencyPerServer must be at least 1")
+	}
+
+	swampNames, err := h.resolveSwampPatterns(ctx, patterns)
+	if err != nil {
+		return err
+	}
+
+	// the Swamps are partitioned by the host of the server their Island is routed to
+	var hosts []string
+	swampsOfHosts := make(map[string][]name.Name)
+	for _, swampName := range swampNames {
+		host := h.client.GetServiceClientAndHost(swampName).Host
+		if _, ok := swampsOfHosts[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		swampsOfHosts[host] = append(swampsOfHosts[host], swampName)
+	}
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var firstErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+
+	for _, host := range hosts {
+
+		swamps := swampsOfHosts[host]
+		queue := make(chan name.Name)
+
+		workers := min(concurrencyPerServer, len(swamps))
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for swampName := range queue {
+					// the Swamps received after the first error are skipped
+					if workCtx.Err() != nil {
+						continue
+					}
+					if fnErr := fn(workCtx, swampName); fnErr != nil {
+						errOnce.Do(func() {
+							firstErr = fnErr
+							cancel()
+						})
+					}
+				}
+			}()
+		}
+
+		// the feeder stops at the first error, so no more Swamps are started
+		go func() {
+			defer close(queue)
+			for _, swampName := range swamps {
+				select {
+				case queue <- swampName:
+				case <-workCtx.Done():
+					return
+				}
+			}
+		}()
+
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			return NewError(ErrCodeCtxTimeout, errorMessageCtxTimeout)
+		}
+		return NewError(ErrCodeCtxClosedByClient, errorMessageCtxClosedByClient)
+	}
+
+	return nil
+
+}
+
+// resolveSwampPatterns returns the Swamp names of the patterns in alphabetical order, without duplicates. The
+// wildcard patterns are resolved to the existing Swamps matching them.
+func (h *hydraidego) resolveSwampPatterns(ctx context.Context, patterns []name.Name) ([]name.Name, error) {
+
+	unique := make(map[string]name.Name)
+	var wildcardPatterns []name.Name
+
+	for _, pattern := range patterns {
+		if pattern == nil {
+			return nil, NewError(ErrCodeInvalidArgument, "swamp name cannot be nil")
+		}
+		if pattern.IsWildcardPattern() {
+			wildcardPatterns = append(wildcardPatterns, pattern)
+			continue
+		}
+		unique[pattern.Get()] = pattern
+	}
+
+	if len(wildcardPatterns) > 0 {
+		existing, err := h.ExistsMany(ctx, wildcardPatterns)
+		if err != nil {
+			return nil, err
+		}
+		for swampName, isExist := range existing {
+			if _, ok := unique[swampName]; isExist && !ok {
+				unique[swampName] = name.Load(swampName)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(unique))
+	for key := range unique {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	swampNames := make([]name.Name, 0, len(keys))
+	for _, key := range keys {
+		swampNames = append(swampNames, unique[key])
+	}
+
+	return swampNames, nil
+
+}
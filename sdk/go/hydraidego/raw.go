@@ -0,0 +1,400 @@
+package hydraidego
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// ValueType is the type of the stored value of a Treasure
+type ValueType int
+
+const (
+	ValueTypeVoid ValueType = iota // the Treasure has no value, only a key and metadata
+	ValueTypeString
+	ValueTypeBool
+	ValueTypeBytes // the []byte values, and the slices, maps and structs of the models, GOB-encoded
+	ValueTypeInt8
+	ValueTypeInt16
+	ValueTypeInt32
+	ValueTypeInt64 // the int64 values, and the time.Time values of the models as UNIX timestamps
+	ValueTypeUint8
+	ValueTypeUint16
+	ValueTypeUint32
+	ValueTypeUint64
+	ValueTypeFloat32
+	ValueTypeFloat64
+)
+
+// String returns the name of the type, the same as the StoredType of a TypeMismatch
+func (t ValueType) String() string {
+	switch t {
+	case ValueTypeVoid:
+		return "void"
+	case ValueTypeString:
+		return "string"
+	case ValueTypeBool:
+		return "bool"
+	case ValueTypeBytes:
+		return "bytes"
+	case ValueTypeInt8:
+		return "int8"
+	case ValueTypeInt16:
+		return "int16"
+	case ValueTypeInt32:
+		return "int32"
+	case ValueTypeInt64:
+		return "int64"
+	case ValueTypeUint8:
+		return "uint8"
+	case ValueTypeUint16:
+		return "uint16"
+	case ValueTypeUint32:
+		return "uint32"
+	case ValueTypeUint64:
+		return "uint64"
+	case ValueTypeFloat32:
+		return "float32"
+	case ValueTypeFloat64:
+		return "float64"
+	default:
+		return fmt.Sprintf("ValueType(%d)", int(t))
+	}
+}
+
+// Value is the typed value of a Treasure. Only the field of the Type is used: Int for the signed integers, Uint
+// for the unsigned integers and Float for the floats, whatever their width is.
+type Value struct {
+	Type   ValueType
+	String string
+	Bool   bool
+	Bytes  []byte
+	Int    int64
+	Uint   uint64
+	Float  float64
+}
+
+// StringValue returns a string value
+func StringValue(value string) Value {
+	return Value{Type: ValueTypeString, String: value}
+}
+
+// BoolValue returns a bool value
+func BoolValue(value bool) Value {
+	return Value{Type: ValueTypeBool, Bool: value}
+}
+
+// BytesValue returns a []byte value
+func BytesValue(value []byte) Value {
+	return Value{Type: ValueTypeBytes, Bytes: value}
+}
+
+// Int64Value returns an int64 value. The narrower integers are set by their Type, e.g. Value{Type: ValueTypeInt32, Int: 42}
+func Int64Value(value int64) Value {
+	return Value{Type: ValueTypeInt64, Int: value}
+}
+
+// Uint64Value returns a uint64 value
+func Uint64Value(value uint64) Value {
+	return Value{Type: ValueTypeUint64, Uint: value}
+}
+
+// Float64Value returns a float64 value
+func Float64Value(value float64) Value {
+	return Value{Type: ValueTypeFloat64, Float: value}
+}
+
+// Treasure is a stored Treasure without a model: its key, its typed value and its metadata.
+//
+// The metadata are keyed by their fields. The values of MetadataCreatedAt, MetadataUpdatedAt and MetadataExpireAt
+// are time.Time, the values of MetadataCreatedBy and MetadataUpdatedBy are strings. The missing metadata are not in
+// the map.
+type Treasure struct {
+	Key   string
+	Value Value
+	// Uint32Slice is the uint32 slice of the Treasure, see Uint32SlicePush. Nil if the Treasure has none. A Treasure
+	// with a slice has no value, so its Value is ValueTypeVoid
+	Uint32Slice []uint32
+	// SchemaVersion is the version of the model stored in the value, zero if it was stored without a version
+	SchemaVersion uint32
+	Metadata      map[MetadataField]any
+}
+
+// CatalogReadRaw reads a single Treasure by key from the Swamp without a model, with its typed value and metadata.
+//
+// It is the untyped variant of CatalogRead, for the tools that work with any Swamp without knowing its models at
+// compile time, e.g. the CLI, the exporters and the admin UIs.
+//
+// ✅ Use when:
+//   - You inspect or export Treasures of Swamps whose models you do not know
+//   - You copy Treasures between Swamps or servers without converting them, together with CatalogSaveRaw
+//
+// ⚠️ The slices, maps and structs of the models are GOB-encoded, so they are returned as ValueTypeBytes.
+//
+// 🧯 Errors:
+//   - Key not found → `ErrCodeNotFound`
+//   - Swamp not found → `ErrCodeSwampNotFound`
+//   - Timeout / context / network issues → appropriate SDK error codes
+func (h *hydraidego) CatalogReadRaw(ctx context.Context, swampName name.Name, key string) (*Treasure, error) {
+
+	response, err := h.serviceClient(ctx, swampName).Get(ctx, &hydraidepbgo.GetRequest{
+		Swamps: []*hydraidepbgo.GetSwamp{
+			{
+				IslandID:  swampName.GetIslandID(h.client.GetAllIslands()),
+				SwampName: swampName.Get(),
+				Keys:      []string{key},
+			},
+		},
+	})
+	if err != nil {
+		if sdkErr := errorHandler(err); !IsMessageTooLarge(sdkErr) {
+			return nil, sdkErr
+		}
+		// the Treasure is too large for one message, so its value is read in chunks
+		if response, err = h.getLargeValue(ctx, swampName, key); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, swamp := range response.GetSwamps() {
+		for _, treasure := range swamp.GetTreasures() {
+			if !treasure.GetIsExist() {
+				return nil, NewError(ErrCodeNotFound, "key not found")
+			}
+			return convertProtoTreasureToTreasure(treasure), nil
+		}
+	}
+
+	return nil, NewError(ErrCodeNotFound, "key not found")
+
+}
+
+// CatalogSaveRaw stores or updates a single Treasure in the Swamp without a model, creating the Swamp and the key
+// if needed, like CatalogSave.
+//
+// It is the untyped variant of CatalogSave, see CatalogReadRaw. The value is stored with its Type, so a Treasure read
+// by CatalogReadRaw is saved with the same type, and the typed models read it the same way as before.
+//
+// ⚠️ The uint32 slice of the Treasure is merged into the stored one, like Uint32SlicePush does.
+//
+// ⚙️ Returns:
+//   - `StatusNew`, `StatusModified` or `StatusNothingChanged`, like CatalogSave
+//   - `ErrCodeInvalidModel` if the key is empty, the value type is unknown, the Treasure has both a value and a
+//     uint32 slice or a metadata has the wrong type
+func (h *hydraidego) CatalogSaveRaw(ctx context.Context, swampName name.Name, treasure *Treasure) (EventStatus, error) {
+
+	kvPair, err := convertTreasureToKeyValuePair(ctx, treasure)
+	if err != nil {
+		return StatusUnknown, NewError(ErrCodeInvalidModel, err.Error())
+	}
+
+	setResponse, err := h.set(ctx, swampName, &hydraidepbgo.SwampRequest{
+		IslandID:         swampName.GetIslandID(h.client.GetAllIslands()),
+		SwampName:        swampName.Get(),
+		KeyValues:        []*hydraidepbgo.KeyValuePair{kvPair},
+		CreateIfNotExist: true,
+		Overwrite:        true,
+	})
+	if err != nil {
+		return StatusUnknown, errorHandler(err)
+	}
+
+	for _, swamp := range setResponse.GetSwamps() {
+		for _, kv := range swamp.GetKeysAndStatuses() {
+			return convertProtoStatusToStatus(kv.GetStatus()), nil
+		}
+	}
+
+	return StatusUnknown, NewError(ErrCodeUnknown, errorMessageUnknown)
+
+}
+
+// convertProtoTreasureToTreasure converts the treasure of the server to a Treasure
+func convertProtoTreasureToTreasure(treasure *hydraidepbgo.Treasure) *Treasure {
+
+	raw := &Treasure{
+		Key:           treasure.GetKey(),
+		Uint32Slice:   treasure.GetUint32Slice(),
+		SchemaVersion: treasure.GetSchemaVersion(),
+		Metadata:      make(map[MetadataField]any),
+	}
+
+	switch {
+	case treasure.StringVal != nil:
+		raw.Value = StringValue(treasure.GetStringVal())
+	case treasure.BoolVal != nil:
+		raw.Value = BoolValue(treasure.GetBoolVal() == hydraidepbgo.Boolean_TRUE)
+	case treasure.BytesVal != nil:
+		raw.Value = BytesValue(treasure.GetBytesVal())
+	case treasure.Int8Val != nil:
+		raw.Value = Value{Type: ValueTypeInt8, Int: int64(treasure.GetInt8Val())}
+	case treasure.Int16Val != nil:
+		raw.Value = Value{Type: ValueTypeInt16, Int: int64(treasure.GetInt16Val())}
+	case treasure.Int32Val != nil:
+		raw.Value = Value{Type: ValueTypeInt32, Int: int64(treasure.GetInt32Val())}
+	case treasure.Int64Val != nil:
+		raw.Value = Int64Value(treasure.GetInt64Val())
+	case treasure.Uint8Val != nil:
+		raw.Value = Value{Type: ValueTypeUint8, Uint: uint64(treasure.GetUint8Val())}
+	case treasure.Uint16Val != nil:
+		raw.Value = Value{Type: ValueTypeUint16, Uint: uint64(treasure.GetUint16Val())}
+	case treasure.Uint32Val != nil:
+		raw.Value = Value{Type: ValueTypeUint32, Uint: uint64(treasure.GetUint32Val())}
+	case treasure.Uint64Val != nil:
+		raw.Value = Uint64Value(treasure.GetUint64Val())
+	case treasure.Float32Val != nil:
+		raw.Value = Value{Type: ValueTypeFloat32, Float: float64(treasure.GetFloat32Val())}
+	case treasure.Float64Val != nil:
+		raw.Value = Float64Value(treasure.GetFloat64Val())
+	}
+
+	if treasure.CreatedAt != nil {
+		raw.Metadata[MetadataCreatedAt] = treasure.GetCreatedAt().AsTime()
+	}
+	if treasure.CreatedBy != nil {
+		raw.Metadata[MetadataCreatedBy] = treasure.GetCreatedBy()
+	}
+	if treasure.UpdatedAt != nil {
+		raw.Metadata[MetadataUpdatedAt] = treasure.GetUpdatedAt().AsTime()
+	}
+	if treasure.UpdatedBy != nil {
+		raw.Metadata[MetadataUpdatedBy] = treasure.GetUpdatedBy()
+	}
+	if treasure.ExpiredAt != nil {
+		raw.Metadata[MetadataExpireAt] = treasure.GetExpiredAt().AsTime()
+	}
+
+	return raw
+
+}
+
+// convertTreasureToKeyValuePair converts the treasure to the key-value pair of a Set request. The empty
+// createdBy and updatedBy metadata get the actor of the context, like the fields of the models
+func convertTreasureToKeyValuePair(ctx context.Context, treasure *Treasure) (*hydraidepbgo.KeyValuePair, error) {
+
+	if treasure == nil {
+		return nil, fmt.Errorf("the treasure can not be nil")
+	}
+	if treasure.Key == "" {
+		return nil, fmt.Errorf("the key of the treasure can not be empty")
+	}
+	if treasure.Uint32Slice != nil && treasure.Value.Type != ValueTypeVoid {
+		return nil, fmt.Errorf("the treasure can not have both a %s value and a uint32 slice", treasure.Value.Type)
+	}
+
+	kvPair := &hydraidepbgo.KeyValuePair{
+		Key:         treasure.Key,
+		Uint32Slice: treasure.Uint32Slice,
+	}
+	if treasure.SchemaVersion != 0 {
+		schemaVersion := treasure.SchemaVersion
+		kvPair.SchemaVersion = &schemaVersion
+	}
+
+	value := treasure.Value
+	switch value.Type {
+	case ValueTypeVoid:
+		if kvPair.Uint32Slice == nil {
+			void := true
+			kvPair.VoidVal = &void
+		}
+	case ValueTypeString:
+		kvPair.StringVal = &value.String
+	case ValueTypeBool:
+		boolVal := hydraidepbgo.Boolean_FALSE
+		if value.Bool {
+			boolVal = hydraidepbgo.Boolean_TRUE
+		}
+		kvPair.BoolVal = &boolVal
+	case ValueTypeBytes:
+		kvPair.BytesVal = value.Bytes
+		if kvPair.BytesVal == nil {
+			kvPair.BytesVal = []byte{}
+		}
+	case ValueTypeInt8, ValueTypeInt16, ValueTypeInt32:
+		intVal := int32(value.Int)
+		switch value.Type {
+		case ValueTypeInt8:
+			kvPair.Int8Val = &intVal
+		case ValueTypeInt16:
+			kvPair.Int16Val = &intVal
+		default:
+			kvPair.Int32Val = &intVal
+		}
+	case ValueTypeInt64:
+		kvPair.Int64Val = &value.Int
+	case ValueTypeUint8, ValueTypeUint16, ValueTypeUint32:
+		uintVal := uint32(value.Uint)
+		switch value.Type {
+		case ValueTypeUint8:
+			kvPair.Uint8Val = &uintVal
+		case ValueTypeUint16:
+			kvPair.Uint16Val = &uintVal
+		default:
+			kvPair.Uint32Val = &uintVal
+		}
+	case ValueTypeUint64:
+		kvPair.Uint64Val = &value.Uint
+	case ValueTypeFloat32:
+		floatVal := float32(value.Float)
+		kvPair.Float32Val = &floatVal
+	case ValueTypeFloat64:
+		kvPair.Float64Val = &value.Float
+	default:
+		return nil, fmt.Errorf("unknown value type: %s", value.Type)
+	}
+
+	for field, metadata := range treasure.Metadata {
+		switch field {
+		case MetadataCreatedAt, MetadataUpdatedAt, MetadataExpireAt:
+			t, ok := metadata.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("the metadata %d must be a time.Time, got %T", field, metadata)
+			}
+			if t.IsZero() {
+				continue
+			}
+			switch field {
+			case MetadataCreatedAt:
+				kvPair.CreatedAt = timestamppb.New(t.UTC())
+			case MetadataUpdatedAt:
+				kvPair.UpdatedAt = timestamppb.New(t.UTC())
+			default:
+				kvPair.ExpiredAt = timestamppb.New(t.UTC())
+			}
+		case MetadataCreatedBy, MetadataUpdatedBy:
+			by, ok := metadata.(string)
+			if !ok {
+				return nil, fmt.Errorf("the metadata %d must be a string, got %T", field, metadata)
+			}
+			if by == "" {
+				continue
+			}
+			if field == MetadataCreatedBy {
+				kvPair.CreatedBy = &by
+			} else {
+				kvPair.UpdatedBy = &by
+			}
+		default:
+			return nil, fmt.Errorf("unknown metadata field: %d", field)
+		}
+	}
+
+	// the missing authors get the actor of the context, if the caller set one
+	if kvPair.CreatedBy == nil {
+		if createdBy := actorOrDefault(ctx, ""); createdBy != "" {
+			kvPair.CreatedBy = &createdBy
+		}
+	}
+	if kvPair.UpdatedBy == nil {
+		if updatedBy := actorOrDefault(ctx, ""); updatedBy != "" {
+			kvPair.UpdatedBy = &updatedBy
+		}
+	}
+
+	return kvPair, nil
+
+}
@@ -0,0 +1,188 @@
+package hydraidego
+
+import (
+	"context"
+	"fmt"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/client"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// PatternRegistry holds the Swamp patterns of a service that are registered on the first use of their Swamps,
+// instead of at the start of the service. See RegisterOnFirstUse.
+//
+// The registry is safe for concurrent use, the patterns can be declared before or after the Hydraidego instance is
+// created, e.g. in the init functions of the domain packages of the service.
+type PatternRegistry struct {
+	mu       sync.RWMutex
+	patterns []*declaredPattern
+	// pending is the number of the patterns not registered yet, so the operations skip the registry without a lock
+	// when every pattern is registered
+	pending atomic.Int64
+}
+
+// declaredPattern is a pattern of the registry with the state of its registration
+type declaredPattern struct {
+	request *RegisterSwampRequest
+	// mu serializes the registrations of the pattern, so the first operations of its Swamps wait for the same
+	// registration instead of registering the pattern again
+	mu         sync.Mutex
+	registered atomic.Bool
+}
+
+// NewPatternRegistry creates an empty registry of the patterns registered on first use
+func NewPatternRegistry() *PatternRegistry {
+	return &PatternRegistry{}
+}
+
+// Declare adds the patterns to the registry. They are registered by RegisterSwamp with the requests, when an
+// operation touches a Swamp matching them for the first time.
+//
+// 🧯 Errors:
+//   - A request or its SwampPattern is nil → `ErrCodeInvalidArgument`
+//   - The pattern is already declared in the registry → `ErrCodeAlreadyExists`
+//
+// 🔧 Example:
+//
+//	err := registry.Declare(&hydraidego.RegisterSwampRequest{
+//	    SwampPattern:   name.New().Sanctuary("invoices").Realm("*").Swamp("*"),
+//	    CloseAfterIdle: time.Minute,
+//	})
+func (r *PatternRegistry) Declare(requests ...*RegisterSwampRequest) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, request := range requests {
+		if request == nil || request.SwampPattern == nil {
+			return NewError(ErrCodeInvalidArgument, "the request and its SwampPattern can not be nil")
+		}
+		for _, pattern := range r.patterns {
+			if pattern.request.SwampPattern.Get() == request.SwampPattern.Get() {
+				return NewError(ErrCodeAlreadyExists, fmt.Sprintf("the pattern %s is already declared", request.SwampPattern.Get()))
+			}
+		}
+		r.patterns = append(r.patterns, &declaredPattern{request: request})
+		r.pending.Add(1)
+	}
+
+	return nil
+
+}
+
+// unregistered returns the patterns matching the Swamp that are not registered yet, in the order of their declaration
+func (r *PatternRegistry) unregistered(swampName name.Name) []*declaredPattern {
+
+	if r.pending.Load() == 0 {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var patterns []*declaredPattern
+	for _, pattern := range r.patterns {
+		if !pattern.registered.Load() && name.Match(pattern.request.SwampPattern, swampName) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+
+}
+
+// RegisterOnFirstUse registers the patterns of the registry lazily: a pattern is registered by RegisterSwamp when an
+// operation touches a Swamp matching it for the first time, before the operation is sent to the server.
+//
+// 📦 A microservice that touches some domains only sometimes does not have to register all of their patterns at its
+// start, and wait for every server. It declares the patterns in a registry, and HydrAIDE registers them when they are
+// needed.
+//
+// ⚙️ Behavior:
+//   - A pattern is registered once per registry, so every Hydraidego instance needs its own registry. The
+//     concurrent first operations of its Swamps wait for the same registration
+//   - Every declared pattern matching the Swamp is registered, in the order of their declaration
+//   - If the registration fails, e.g. the servers are not reachable, the error is logged, the operation is sent
+//     anyway, and the next operation of a matching Swamp tries to register the pattern again
+//   - If the server rejects the registration with a pattern conflict, the registered settings are kept, and the
+//     pattern is not registered again. See IsPatternConflict
+//   - The operations of a wildcard pattern, the blobs, and RegisterSwamp, DeRegisterSwamp and UpdateSwampPattern
+//     themselves do not register any pattern
+//
+// ⚠️ A pattern is registered only on the servers known at its registration. If a server joins the cluster later,
+// register its wildcard patterns by RegisterSwamp.
+//
+// 🔧 Example:
+//
+//	registry := hydraidego.NewPatternRegistry()
+//	if err := registry.Declare(invoiceSwampRequest, reportSwampRequest); err != nil {
+//	    log.Fatal(err)
+//	}
+//	h := hydraidego.New(clientInterface, hydraidego.RegisterOnFirstUse(registry))
+func RegisterOnFirstUse(registry *PatternRegistry) Option {
+	return func(h *hydraidego) {
+		h.registry = registry
+	}
+}
+
+// registerOnFirstUse registers the patterns of the registry matching the Swamp that are not registered yet
+func (h *hydraidego) registerOnFirstUse(ctx context.Context, swampName name.Name) {
+
+	if h.registry == nil || swampName == nil || swampName.IsWildcardPattern() {
+		return
+	}
+
+	for _, pattern := range h.registry.unregistered(swampName) {
+		h.registerDeclaredPattern(ctx, pattern)
+	}
+
+}
+
+// registerDeclaredPattern registers the pattern, unless a concurrent operation has already registered it
+func (h *hydraidego) registerDeclaredPattern(ctx context.Context, pattern *declaredPattern) {
+
+	pattern.mu.Lock()
+	defer pattern.mu.Unlock()
+
+	if pattern.registered.Load() {
+		return
+	}
+
+	errs := h.RegisterSwamp(ctx, pattern.request)
+	conflicts := len(errs) > 0
+	for _, err := range errs {
+		if !IsPatternConflict(err) {
+			conflicts = false
+		}
+	}
+
+	switch {
+	case len(errs) == 0:
+	case conflicts:
+		slog.Warn("the pattern registered on first use conflicts with a registered pattern, the registered settings are kept",
+			"pattern", pattern.request.SwampPattern.Get(), "errors", errs)
+	default:
+		slog.Error("can not register the pattern on first use, the registration is retried by the next operation",
+			"pattern", pattern.request.SwampPattern.Get(), "errors", errs)
+		return
+	}
+
+	pattern.registered.Store(true)
+	h.registry.pending.Add(-1)
+
+}
+
+// serviceClient returns the service client of the server of the Swamp, after the patterns of the Swamp declared
+// for RegisterOnFirstUse are registered
+func (h *hydraidego) serviceClient(ctx context.Context, swampName name.Name) hydraidepbgo.HydraideServiceClient {
+	h.registerOnFirstUse(ctx, swampName)
+	return h.client.GetServiceClient(swampName)
+}
+
+// serviceClientAndHost is the serviceClient with the host of the server
+func (h *hydraidego) serviceClientAndHost(ctx context.Context, swampName name.Name) *client.ServiceClient {
+	h.registerOnFirstUse(ctx, swampName)
+	return h.client.GetServiceClientAndHost(swampName)
+}
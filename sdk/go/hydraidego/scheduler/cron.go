@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed cron expression with the classic five fields:
+//
+//	┌───────────── minute (0-59)
+//	│ ┌─────────── hour (0-23)
+//	│ │ ┌───────── day of the month (1-31)
+//	│ │ │ ┌─────── month (1-12)
+//	│ │ │ │ ┌───── day of the week (0-6, Sunday is 0 or 7)
+//	│ │ │ │ │
+//	* * * * *
+//
+// Every field accepts `*`, single values, ranges (`1-5`), steps (`*/15`, `0-30/10`) and comma separated lists of them.
+// The descriptors `@hourly`, `@daily` (`@midnight`), `@weekly`, `@monthly` and `@yearly` (`@annually`) are also
+// accepted.
+//
+// Like in the classic cron, if both the day of the month and the day of the week are restricted, the job runs when
+// any of them matches.
+type Cron struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// anyDay is true if the day of the month or the day of the week is not restricted
+	anyDay bool
+}
+
+// cronSearchLimit is how far Next looks ahead for a matching time. An expression without any match (like the 30th
+// of February) returns a zero time after it.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression. See the Cron type for the accepted syntax.
+func ParseCron(expression string) (*Cron, error) {
+
+	expression = strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[expression]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d: %q", len(fields), expression)
+	}
+
+	c := &Cron{}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if c.dayOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day of month field: %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if c.dayOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day of week field: %w", err)
+	}
+
+	// both 0 and 7 mean Sunday
+	if c.dayOfWeek&(1<<7) != 0 {
+		c.dayOfWeek |= 1
+	}
+
+	c.anyDay = strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+
+}
+
+// Next returns the first matching time after the given time, in the location of the given time.
+// It returns a zero time if the expression does not match in the next five years.
+func (c *Cron) Next(after time.Time) time.Time {
+
+	// the cron works with whole minutes
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+
+		if !c.has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.has(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+
+	}
+
+	return time.Time{}
+
+}
+
+// matchDay checks the day of the month and the day of the week fields together
+func (c *Cron) matchDay(t time.Time) bool {
+	dayOfMonth := c.has(c.dayOfMonth, t.Day())
+	dayOfWeek := c.has(c.dayOfWeek, int(t.Weekday()))
+	if c.anyDay {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+func (c *Cron) has(field uint64, value int) bool {
+	return field&(1<<uint(value)) != 0
+}
+
+// parseCronField parses one field of the expression to a bit set of the allowed values
+func parseCronField(field string, min int, max int) (uint64, error) {
+
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+
+		rangePart, step := part, 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			rangePart = part[:index]
+			s, err := strconv.Atoi(part[index+1:])
+			if err != nil || s < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			step = s
+		}
+
+		from, to := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			f, errFrom := strconv.Atoi(bounds[0])
+			t, errTo := strconv.Atoi(bounds[1])
+			if errFrom != nil || errTo != nil || f > t {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+			from, to = f, t
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			from = v
+			// a single value with a step means from the value to the end, like 5/15
+			if step == 1 {
+				to = v
+			}
+		}
+
+		if from < min || to > max {
+			return 0, fmt.Errorf("%q is out of the range %d-%d", part, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+
+	}
+
+	return bits, nil
+
+}
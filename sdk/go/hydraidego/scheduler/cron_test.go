@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCron(t *testing.T) {
+
+	base := time.Date(2025, time.March, 14, 10, 17, 42, 0, time.UTC)
+
+	t.Run("should find the next matching time", func(t *testing.T) {
+
+		tests := []struct {
+			expression string
+			expected   time.Time
+		}{
+			{"* * * * *", time.Date(2025, time.March, 14, 10, 18, 0, 0, time.UTC)},
+			{"*/15 * * * *", time.Date(2025, time.March, 14, 10, 30, 0, 0, time.UTC)},
+			{"0 * * * *", time.Date(2025, time.March, 14, 11, 0, 0, 0, time.UTC)},
+			{"@daily", time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)},
+			{"30 9 * * 1-5", time.Date(2025, time.March, 17, 9, 30, 0, 0, time.UTC)},
+			{"0 12 1,15 * *", time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)},
+			{"0 0 1 1 *", time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{"0 0 * * 7", time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)},
+			// the day of the month or the day of the week (Sunday)
+			{"0 0 20 * 0", time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)},
+			{"5/20 10 * * *", time.Date(2025, time.March, 14, 10, 25, 0, 0, time.UTC)},
+		}
+
+		for _, test := range tests {
+			c, err := ParseCron(test.expression)
+			if assert.NoError(t, err, test.expression) {
+				assert.Equal(t, test.expected, c.Next(base), test.expression)
+			}
+		}
+
+	})
+
+	t.Run("should return zero time if the expression never matches", func(t *testing.T) {
+		c, err := ParseCron("0 0 30 2 *")
+		assert.NoError(t, err)
+		assert.True(t, c.Next(base).IsZero())
+	})
+
+	t.Run("should reject the invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+			_, err := ParseCron(expression)
+			assert.Error(t, err, expression)
+		}
+	})
+
+}
@@ -0,0 +1,503 @@
+// Package scheduler
+//
+// The scheduler runs one-shot and recurring (cron-like) jobs stored in a HydrAIDE Swamp.
+//
+// Every job is a Treasure in the Swamp of the scheduler, and its `expireAt` is the time of its next run. The
+// scheduler leases the expired jobs with CatalogShiftExpiredWithLease in a loop, runs them on a worker pool, then
+// acknowledges the one-shot jobs and moves the recurring jobs to their next run time. This is the pattern that the
+// queue examples implement by hand, with the details that are easy to miss:
+//
+//   - **Worker pool**: at most `Workers` jobs run at the same time, and the scheduler leases only as many jobs as
+//     there are free workers, so the other instances of the service can take the rest.
+//   - **Deduplication**: the ID of the job is the key of the Treasure. Scheduling a job with an existing ID replaces
+//     the old one, so a job registered by every instance at startup exists only once.
+//   - **At-least-once delivery**: the jobs are leased, not deleted. If the instance crashes during the run, the job
+//     is run again after the `LeaseTime`. The handlers should be idempotent.
+//   - **Retries**: a failed job is retried after `RetryAfter`. After `MaxAttempts` failed runs a one-shot job is
+//     moved to the `DeadLetterSwamp` (or dropped without it), and a recurring job waits for its next run time.
+//   - **Graceful shutdown**: Stop stops the leasing, and waits for the running jobs to finish.
+//
+// ## Example
+//
+// ```go
+//
+//	s := scheduler.New(hydraidegoInterface, name.New().Sanctuary("scheduler").Realm("jobs").Swamp("billing"), &scheduler.Options{
+//		Workers: 4,
+//	})
+//
+//	s.Handle("send-invoices", func(ctx context.Context, job *scheduler.Job) error {
+//		return sendInvoices(ctx, job.Payload)
+//	})
+//
+//	if err := s.Start(ctx); err != nil {
+//		return err
+//	}
+//	defer s.Stop(context.Background())
+//
+//	// every day at 06:00, only once across all instances
+//	_ = s.Schedule(ctx, &scheduler.Job{ID: "daily-invoices", Handler: "send-invoices", Cron: "0 6 * * *"})
+//
+// ```
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Scheduler runs the jobs of one Swamp
+type Scheduler interface {
+	// Handle registers the handler of the jobs with the given handler name. It must be called before Start.
+	Handle(handlerName string, handler Handler)
+	// Schedule saves the job. A job with the same ID is replaced.
+	Schedule(ctx context.Context, job *Job) error
+	// Cancel deletes the job. A running job finishes, but it is not scheduled again.
+	Cancel(ctx context.Context, jobID string) error
+	// Start registers the Swamp pattern of the scheduler, and starts the leasing loop in the background.
+	Start(ctx context.Context) error
+	// Stop stops the leasing loop and waits for the running jobs. If the ctx is done before the jobs finish, the
+	// context of the jobs is cancelled, and Stop returns the error of the ctx.
+	Stop(ctx context.Context) error
+}
+
+// Handler runs a job. A returned error or a panic means the run failed, and the job is retried.
+type Handler func(ctx context.Context, job *Job) error
+
+// Job is a one-shot or recurring job of the scheduler
+type Job struct {
+	// ID identifies the job in the Swamp. Scheduling a job with an existing ID replaces the old job.
+	ID string
+	// Handler is the name of the handler that runs the job
+	Handler string
+	// Payload is passed to the handler as it is
+	Payload []byte
+	// RunAt is the time of the first run. Zero means now, or the next matching time of the Cron.
+	RunAt time.Time
+	// Every makes the job recurring: the next run is Every after the end of the previous run
+	Every time.Duration
+	// Cron makes the job recurring by a cron expression, see the Cron type. It can not be used together with Every.
+	Cron string
+	// Attempt is the number of the runs of the job since its last successful run, including the current one.
+	// It is set by the scheduler when the job is passed to the handler.
+	Attempt int32
+}
+
+// Options of the scheduler. Every zero field gets its default value.
+type Options struct {
+	// Workers is the number of the jobs that run at the same time. Default: 1
+	Workers int
+	// PollInterval is how often the scheduler looks for jobs to run. Default: 1 second
+	PollInterval time.Duration
+	// LeaseTime is how long a job is hidden from the other instances while it runs. It must be longer than the
+	// longest run of a job, otherwise the job can run twice at the same time. Default: 5 minutes
+	LeaseTime time.Duration
+	// RetryAfter is the delay before a failed job is run again. Default: 30 seconds
+	RetryAfter time.Duration
+	// MaxAttempts is the number of the failed runs after which the job is given up. Default: 0 (no limit)
+	MaxAttempts int32
+	// DeadLetterSwamp receives the one-shot jobs that failed MaxAttempts times. Without it, these jobs are dropped.
+	DeadLetterSwamp name.Name
+	// Location is the time zone of the Cron expressions. Default: UTC
+	Location *time.Location
+	// RequestTimeout is the timeout of the requests of the scheduler to HydrAIDE. Default: 10 seconds
+	RequestTimeout time.Duration
+}
+
+// ErrNotStarted is returned by Stop if the scheduler is not running
+var ErrNotStarted = errors.New("the scheduler is not started")
+
+// jobModel is a job stored as a Treasure. The expireAt is the time of the next run.
+type jobModel struct {
+	ID       string    `hydraide:"key"`
+	Spec     *jobSpec  `hydraide:"value"`
+	ExpireAt time.Time `hydraide:"expireAt"`
+}
+
+type jobSpec struct {
+	Handler string
+	Payload []byte
+	Every   time.Duration
+	Cron    string
+}
+
+type scheduler struct {
+	hydraidegoInterface hydraidego.Hydraidego
+	swampName           name.Name
+	options             Options
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	// workers is the semaphore of the worker pool
+	workers chan struct{}
+	running sync.WaitGroup
+
+	// stopLoop stops the leasing loop, cancelJobs cancels the context of the running jobs
+	stopLoop   context.CancelFunc
+	cancelJobs context.CancelFunc
+	loopDone   chan struct{}
+}
+
+// New creates a scheduler for the jobs of the given Swamp.
+//
+// Every instance of the service can create a scheduler for the same Swamp: the jobs are distributed between them.
+func New(hydraidegoInterface hydraidego.Hydraidego, swampName name.Name, options *Options) Scheduler {
+
+	o := Options{}
+	if options != nil {
+		o = *options
+	}
+	if o.Workers < 1 {
+		o.Workers = 1
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.LeaseTime <= 0 {
+		o.LeaseTime = 5 * time.Minute
+	}
+	if o.RetryAfter <= 0 {
+		o.RetryAfter = 30 * time.Second
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if o.RequestTimeout <= 0 {
+		o.RequestTimeout = 10 * time.Second
+	}
+
+	return &scheduler{
+		hydraidegoInterface: hydraidegoInterface,
+		swampName:           swampName,
+		options:             o,
+		handlers:            make(map[string]Handler),
+		workers:             make(chan struct{}, o.Workers),
+	}
+
+}
+
+func (s *scheduler) Handle(handlerName string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[handlerName] = handler
+}
+
+func (s *scheduler) Schedule(ctx context.Context, job *Job) error {
+
+	if job == nil || job.ID == "" {
+		return errors.New("the job must have an ID")
+	}
+	if job.Handler == "" {
+		return errors.New("the job must have a handler name")
+	}
+	if job.Every < 0 {
+		return errors.New("the Every of the job can not be negative")
+	}
+	if job.Every > 0 && job.Cron != "" {
+		return errors.New("the job can not have both Every and Cron")
+	}
+
+	runAt := job.RunAt
+	if job.Cron != "" {
+		c, err := ParseCron(job.Cron)
+		if err != nil {
+			return err
+		}
+		if runAt.IsZero() {
+			if runAt = c.Next(time.Now().In(s.options.Location)); runAt.IsZero() {
+				return fmt.Errorf("the cron expression %q never matches", job.Cron)
+			}
+		}
+	}
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+
+	_, err := s.hydraidegoInterface.CatalogSave(ctx, s.swampName, &jobModel{
+		ID: job.ID,
+		Spec: &jobSpec{
+			Handler: job.Handler,
+			Payload: job.Payload,
+			Every:   job.Every,
+			Cron:    job.Cron,
+		},
+		ExpireAt: runAt.UTC(),
+	})
+
+	return err
+
+}
+
+func (s *scheduler) Cancel(ctx context.Context, jobID string) error {
+	err := s.hydraidegoInterface.CatalogDelete(ctx, s.swampName, jobID)
+	if hydraidego.IsNotFound(err) || hydraidego.IsSwampNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *scheduler) Start(ctx context.Context) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loopDone != nil {
+		return errors.New("the scheduler is already started")
+	}
+
+	if errs := s.hydraidegoInterface.RegisterSwamp(ctx, &hydraidego.RegisterSwampRequest{
+		SwampPattern:    s.swampName,
+		CloseAfterIdle:  time.Hour,
+		IsInMemorySwamp: false,
+		FilesystemSettings: &hydraidego.SwampFilesystemSettings{
+			WriteInterval: time.Second,
+			MaxFileSize:   8192,
+		},
+	}); errs != nil {
+		return errors.Join(errs...)
+	}
+
+	loopCtx, stopLoop := context.WithCancel(context.Background())
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	s.stopLoop = stopLoop
+	s.cancelJobs = cancelJobs
+	s.loopDone = make(chan struct{})
+
+	go s.loop(loopCtx, jobsCtx, s.loopDone)
+
+	return nil
+
+}
+
+func (s *scheduler) Stop(ctx context.Context) error {
+
+	s.mu.Lock()
+	loopDone := s.loopDone
+	s.loopDone = nil
+	s.mu.Unlock()
+
+	if loopDone == nil {
+		return ErrNotStarted
+	}
+
+	// no new jobs are leased after the loop stopped
+	s.stopLoop()
+	<-loopDone
+
+	jobsDone := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(jobsDone)
+	}()
+
+	select {
+	case <-jobsDone:
+		s.cancelJobs()
+		return nil
+	case <-ctx.Done():
+		// the jobs did not finish in time, so we ask them to stop. Their leases expire, so they run again later.
+		s.cancelJobs()
+		<-jobsDone
+		return ctx.Err()
+	}
+
+}
+
+// loop leases the expired jobs for the free workers until the loopCtx is done
+func (s *scheduler) loop(loopCtx context.Context, jobsCtx context.Context, done chan struct{}) {
+
+	defer close(done)
+
+	ticker := time.NewTicker(s.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+
+		if free := cap(s.workers) - len(s.workers); free > 0 {
+			s.lease(loopCtx, jobsCtx, free)
+		}
+
+		select {
+		case <-loopCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+	}
+
+}
+
+// lease leases at most howMany expired jobs, and starts them on the worker pool
+func (s *scheduler) lease(loopCtx context.Context, jobsCtx context.Context, howMany int) {
+
+	ctx, cancel := context.WithTimeout(loopCtx, s.options.RequestTimeout)
+	defer cancel()
+
+	err := s.hydraidegoInterface.CatalogShiftExpiredWithLease(ctx, s.swampName, int32(howMany), s.options.LeaseTime, jobModel{}, func(model any, lease *hydraidego.Lease) error {
+
+		jm, ok := model.(*jobModel)
+		if !ok || jm.Spec == nil {
+			slog.Error("invalid job in the scheduler swamp", "swampName", s.swampName.Get(), "key", lease.Key)
+			s.ack(lease)
+			return nil
+		}
+
+		// the free workers were counted before the lease, so the semaphore does not block here
+		s.workers <- struct{}{}
+		s.running.Add(1)
+		go func() {
+			defer func() {
+				<-s.workers
+				s.running.Done()
+			}()
+			s.execute(jobsCtx, jm, lease)
+		}()
+
+		return nil
+
+	})
+
+	// the swamp does not exist until the first job is scheduled
+	if err != nil && !hydraidego.IsSwampNotFound(err) && !hydraidego.IsCtxClosedByClient(err) && loopCtx.Err() == nil {
+		slog.Error("failed to lease the jobs of the scheduler", "swampName", s.swampName.Get(), "error", err)
+	}
+
+}
+
+// execute runs the job, then acknowledges, reschedules or retries it by the result
+func (s *scheduler) execute(jobsCtx context.Context, jm *jobModel, lease *hydraidego.Lease) {
+
+	job := &Job{
+		ID:      jm.ID,
+		Handler: jm.Spec.Handler,
+		Payload: jm.Spec.Payload,
+		RunAt:   jm.ExpireAt,
+		Every:   jm.Spec.Every,
+		Cron:    jm.Spec.Cron,
+		Attempt: lease.Deliveries,
+	}
+
+	runErr := s.run(jobsCtx, job)
+	recurring := job.Every > 0 || job.Cron != ""
+	givenUp := runErr != nil && s.options.MaxAttempts > 0 && lease.Deliveries >= s.options.MaxAttempts
+
+	if runErr != nil {
+		slog.Warn("scheduled job failed", "swampName", s.swampName.Get(), "jobID", job.ID, "attempt", job.Attempt, "givenUp", givenUp, "error", runErr)
+	}
+
+	switch {
+	case runErr == nil && !recurring:
+		s.ack(lease)
+	case (runErr == nil || givenUp) && recurring:
+		s.reschedule(jm, lease)
+	case givenUp:
+		s.deadLetter(lease)
+	default:
+		s.retry(lease)
+	}
+
+}
+
+// run runs the handler of the job, and converts its panic to an error
+func (s *scheduler) run(ctx context.Context, job *Job) (err error) {
+
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Handler]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler is registered with the name %q", job.Handler)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("the handler panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+
+}
+
+// reschedule moves the recurring job to its next run time. The update also ends the lease of the job.
+func (s *scheduler) reschedule(jm *jobModel, lease *hydraidego.Lease) {
+
+	var next time.Time
+	if jm.Spec.Cron != "" {
+		c, err := ParseCron(jm.Spec.Cron)
+		if err == nil {
+			next = c.Next(time.Now().In(s.options.Location))
+		}
+		if next.IsZero() {
+			slog.Error("the cron expression of the job has no next run, the job is deleted", "swampName", s.swampName.Get(), "jobID", jm.ID, "cron", jm.Spec.Cron)
+			s.ack(lease)
+			return
+		}
+	} else {
+		next = time.Now().Add(jm.Spec.Every)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.RequestTimeout)
+	defer cancel()
+
+	jm.ExpireAt = next.UTC()
+	// the update fails if the job was cancelled during its run, and then it must not be created again
+	if err := s.hydraidegoInterface.CatalogUpdate(ctx, s.swampName, jm); err != nil && !hydraidego.IsNotFound(err) && !hydraidego.IsSwampNotFound(err) {
+		slog.Error("failed to reschedule the job", "swampName", s.swampName.Get(), "jobID", jm.ID, "error", err)
+	}
+
+}
+
+// ack deletes the job after its successful run
+func (s *scheduler) ack(lease *hydraidego.Lease) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.RequestTimeout)
+	defer cancel()
+
+	// the lease is not found if the job was cancelled or replaced during its run
+	if err := s.hydraidegoInterface.CatalogAck(ctx, lease); err != nil && !hydraidego.IsLeaseNotFound(err) {
+		slog.Error("failed to acknowledge the job", "swampName", s.swampName.Get(), "jobID", lease.Key, "error", err)
+	}
+
+}
+
+// retry makes the failed job visible again after the RetryAfter
+func (s *scheduler) retry(lease *hydraidego.Lease) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.RequestTimeout)
+	defer cancel()
+
+	if _, err := s.hydraidegoInterface.CatalogNack(ctx, lease, &hydraidego.NackOptions{
+		RetryAfter: s.options.RetryAfter,
+	}); err != nil && !hydraidego.IsLeaseNotFound(err) {
+		slog.Error("failed to retry the job", "swampName", s.swampName.Get(), "jobID", lease.Key, "error", err)
+	}
+
+}
+
+// deadLetter moves the given up one-shot job to the DeadLetterSwamp, or drops it without a DeadLetterSwamp
+func (s *scheduler) deadLetter(lease *hydraidego.Lease) {
+
+	if s.options.DeadLetterSwamp == nil {
+		slog.Error("the job is dropped after too many failed attempts", "swampName", s.swampName.Get(), "jobID", lease.Key, "attempts", lease.Deliveries)
+		s.ack(lease)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.RequestTimeout)
+	defer cancel()
+
+	if _, err := s.hydraidegoInterface.CatalogNack(ctx, lease, &hydraidego.NackOptions{
+		RetryAfter:      s.options.RetryAfter,
+		MaxDeliveries:   lease.Deliveries,
+		DeadLetterSwamp: s.options.DeadLetterSwamp,
+	}); err != nil && !hydraidego.IsLeaseNotFound(err) {
+		slog.Error("failed to move the job to the dead-letter swamp", "swampName", s.swampName.Get(), "jobID", lease.Key, "error", err)
+	}
+
+}
@@ -0,0 +1,241 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego"
+	"github.com/hydraide/hydraide/sdk/go/hydraidego/name"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeHydraidego keeps the jobs of one swamp in the memory. The methods not used by the scheduler panic.
+type fakeHydraidego struct {
+	hydraidego.Hydraidego
+	mu         sync.Mutex
+	jobs       map[string]*jobModel
+	deliveries map[string]int32
+}
+
+func newFakeHydraidego() *fakeHydraidego {
+	return &fakeHydraidego{
+		jobs:       make(map[string]*jobModel),
+		deliveries: make(map[string]int32),
+	}
+}
+
+func (f *fakeHydraidego) RegisterSwamp(_ context.Context, _ *hydraidego.RegisterSwampRequest) []error {
+	return nil
+}
+
+func (f *fakeHydraidego) CatalogSave(_ context.Context, _ name.Name, model any) (hydraidego.EventStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jm := *model.(*jobModel)
+	f.jobs[jm.ID] = &jm
+	delete(f.deliveries, jm.ID)
+	return hydraidego.StatusNew, nil
+}
+
+func (f *fakeHydraidego) CatalogUpdate(_ context.Context, _ name.Name, model any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jm := *model.(*jobModel)
+	if _, ok := f.jobs[jm.ID]; !ok {
+		return hydraidego.NewError(hydraidego.ErrCodeNotFound, "not found")
+	}
+	f.jobs[jm.ID] = &jm
+	delete(f.deliveries, jm.ID)
+	return nil
+}
+
+func (f *fakeHydraidego) CatalogDelete(_ context.Context, _ name.Name, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.jobs, key)
+	return nil
+}
+
+func (f *fakeHydraidego) CatalogShiftExpiredWithLease(_ context.Context, swampName name.Name, howMany int32, leaseTTL time.Duration, _ any, iterator hydraidego.CatalogShiftExpiredWithLeaseIteratorFunc) error {
+
+	f.mu.Lock()
+	var expired []*jobModel
+	for _, jm := range f.jobs {
+		if jm.ExpireAt.Before(time.Now()) {
+			expired = append(expired, jm)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ExpireAt.Before(expired[j].ExpireAt) })
+	if len(expired) > int(howMany) {
+		expired = expired[:howMany]
+	}
+	leases := make([]*hydraidego.Lease, 0, len(expired))
+	models := make([]*jobModel, 0, len(expired))
+	for _, jm := range expired {
+		jm.ExpireAt = time.Now().Add(leaseTTL)
+		f.deliveries[jm.ID]++
+		leases = append(leases, &hydraidego.Lease{SwampName: swampName, Key: jm.ID, ID: jm.ExpireAt.UnixNano(), Deliveries: f.deliveries[jm.ID]})
+		clone := *jm
+		models = append(models, &clone)
+	}
+	f.mu.Unlock()
+
+	for i := range models {
+		if err := iterator(models[i], leases[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+func (f *fakeHydraidego) CatalogAck(_ context.Context, lease *hydraidego.Lease) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jm, ok := f.jobs[lease.Key]
+	if !ok || jm.ExpireAt.UnixNano() != lease.ID {
+		return hydraidego.NewError(hydraidego.ErrCodeLeaseNotFound, "lease not found")
+	}
+	delete(f.jobs, lease.Key)
+	delete(f.deliveries, lease.Key)
+	return nil
+}
+
+func (f *fakeHydraidego) CatalogNack(_ context.Context, lease *hydraidego.Lease, options *hydraidego.NackOptions) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jm, ok := f.jobs[lease.Key]
+	if !ok || jm.ExpireAt.UnixNano() != lease.ID {
+		return false, hydraidego.NewError(hydraidego.ErrCodeLeaseNotFound, "lease not found")
+	}
+	jm.ExpireAt = time.Now().Add(options.RetryAfter)
+	return false, nil
+}
+
+func (f *fakeHydraidego) jobCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.jobs)
+}
+
+func TestScheduler(t *testing.T) {
+
+	swampName := name.New().Sanctuary("scheduler").Realm("jobs").Swamp("test")
+	options := &Options{
+		Workers:      2,
+		PollInterval: 10 * time.Millisecond,
+		RetryAfter:   10 * time.Millisecond,
+	}
+
+	t.Run("should run a one-shot job once and delete it", func(t *testing.T) {
+
+		fake := newFakeHydraidego()
+		s := New(fake, swampName, options)
+
+		var runs int32
+		s.Handle("count", func(ctx context.Context, job *Job) error {
+			assert.Equal(t, []byte("payload"), job.Payload)
+			atomic.AddInt32(&runs, 1)
+			return nil
+		})
+
+		assert.NoError(t, s.Start(context.Background()))
+		assert.NoError(t, s.Schedule(context.Background(), &Job{ID: "job", Handler: "count", Payload: []byte("payload")}))
+
+		assert.Eventually(t, func() bool { return fake.jobCount() == 0 }, time.Second, 10*time.Millisecond)
+		assert.NoError(t, s.Stop(context.Background()))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+
+	})
+
+	t.Run("should run a recurring job again", func(t *testing.T) {
+
+		fake := newFakeHydraidego()
+		s := New(fake, swampName, options)
+
+		var runs int32
+		s.Handle("count", func(ctx context.Context, job *Job) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		})
+
+		assert.NoError(t, s.Start(context.Background()))
+		assert.NoError(t, s.Schedule(context.Background(), &Job{ID: "job", Handler: "count", Every: 20 * time.Millisecond}))
+
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, 10*time.Millisecond)
+		assert.NoError(t, s.Stop(context.Background()))
+		assert.Equal(t, 1, fake.jobCount())
+
+	})
+
+	t.Run("should retry the failed job and drop it after the max attempts", func(t *testing.T) {
+
+		fake := newFakeHydraidego()
+		s := New(fake, swampName, &Options{
+			PollInterval: 10 * time.Millisecond,
+			RetryAfter:   10 * time.Millisecond,
+			MaxAttempts:  3,
+		})
+
+		var attempts []int32
+		var mu sync.Mutex
+		s.Handle("fail", func(ctx context.Context, job *Job) error {
+			mu.Lock()
+			attempts = append(attempts, job.Attempt)
+			mu.Unlock()
+			if job.Attempt == 2 {
+				panic("boom")
+			}
+			return errors.New("failed")
+		})
+
+		assert.NoError(t, s.Start(context.Background()))
+		assert.NoError(t, s.Schedule(context.Background(), &Job{ID: "job", Handler: "fail"}))
+
+		assert.Eventually(t, func() bool { return fake.jobCount() == 0 }, time.Second, 10*time.Millisecond)
+		assert.NoError(t, s.Stop(context.Background()))
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []int32{1, 2, 3}, attempts)
+
+	})
+
+	t.Run("should wait for the running jobs at stop", func(t *testing.T) {
+
+		fake := newFakeHydraidego()
+		s := New(fake, swampName, options)
+
+		started := make(chan struct{})
+		var finished int32
+		s.Handle("slow", func(ctx context.Context, job *Job) error {
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&finished, 1)
+			return nil
+		})
+
+		assert.NoError(t, s.Start(context.Background()))
+		assert.NoError(t, s.Schedule(context.Background(), &Job{ID: "job", Handler: "slow"}))
+
+		<-started
+		assert.NoError(t, s.Stop(context.Background()))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&finished))
+		assert.ErrorIs(t, s.Stop(context.Background()), ErrNotStarted)
+
+	})
+
+	t.Run("should reject the invalid jobs", func(t *testing.T) {
+		s := New(newFakeHydraidego(), swampName, nil)
+		ctx := context.Background()
+		assert.Error(t, s.Schedule(ctx, &Job{Handler: "h"}))
+		assert.Error(t, s.Schedule(ctx, &Job{ID: "job"}))
+		assert.Error(t, s.Schedule(ctx, &Job{ID: "job", Handler: "h", Every: time.Minute, Cron: "* * * * *"}))
+		assert.Error(t, s.Schedule(ctx, &Job{ID: "job", Handler: "h", Cron: "invalid"}))
+	})
+
+}
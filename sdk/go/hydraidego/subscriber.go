@@ -0,0 +1,203 @@
+package hydraidego
+
+import (
+	"context"
+	"github.com/hydraide/hydraide/generated/hydraidepbgo"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Subscription is the handle of a running subscription, returned by Subscribe, SubscribeFrom, ProfileSubscribe,
+// SubscribeAll and SubscribeAllFrom.
+//
+// The streams of a subscription run in background goroutines until the subscription is closed, the context of the
+// subscription is canceled, the iterator returns an error, or the stream fails. The handle stops one subscription
+// without canceling the context, which may be shared with other work, and tells if the subscription is still
+// running, so it can be restarted.
+//
+// 🔧 Example:
+//
+//	subscription, err := h.Subscribe(ctx, swampName, false, Price{}, iterator)
+//	if err != nil {
+//	    return err
+//	}
+//	defer subscription.Close()
+//
+//	<-subscription.Done()
+//	if err := subscription.Err(); err != nil {
+//	    slog.Warn("the subscription stopped, restarting it", "error", err)
+//	}
+type Subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	// closed is true after Close, so the canceled streams are not reported as errors
+	closed atomic.Bool
+	// lastEventTime is the time of the last event passed to the iterator in unix nanoseconds, 0 without events
+	lastEventTime atomic.Int64
+	mu            sync.Mutex
+	err           error
+}
+
+// newSubscription creates the handle of the streams canceled by cancel
+func newSubscription(cancel context.CancelFunc) *Subscription {
+	return &Subscription{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+// Close stops the subscription and closes its streams. The iterator is not called after the streams are closed,
+// except for the call already running. Close does not wait for it, so it can be called by the iterator, too. Wait
+// for Done to be sure that the iterator returned. Calling Close more than once is safe.
+func (s *Subscription) Close() {
+	s.closed.Store(true)
+	s.cancel()
+}
+
+// Done returns a channel that is closed when the subscription stopped, and its iterator is not called anymore
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// LastEventTime returns the time of the last event passed to the iterator, as it was set by the server. The zero time
+// means no event has arrived yet. A subscription with an old LastEventTime may be stale, e.g. its connection died
+// silently behind a NAT.
+func (s *Subscription) LastEventTime() time.Time {
+	lastEventTime := s.lastEventTime.Load()
+	if lastEventTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, lastEventTime)
+}
+
+// Err returns the first error of the subscription: the error of a stream, the error returned by the iterator, or the
+// error of the canceled context. Nil if there was no error, or the subscription was stopped by Close.
+//
+// The subscription of one Swamp stops at its first error. SubscribeAll and SubscribeAllFrom keep the streams of the
+// other servers running, so Err can be set while Done is not closed yet.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// eventReceived records the time of an event passed to the iterator
+func (s *Subscription) eventReceived(eventTime time.Time) {
+	s.lastEventTime.Store(eventTime.UnixNano())
+}
+
+// fail records the error, unless an earlier error is recorded, or the subscription was stopped by Close
+func (s *Subscription) fail(err error) {
+	if err == nil || s.closed.Load() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// finish closes the streams and marks the subscription as stopped. It must be called once, when every stream
+// returned
+func (s *Subscription) finish() {
+	s.cancel()
+	close(s.done)
+}
+
+// OverflowPolicy decides what the server does with the events of a subscription whose iterator is slower than the
+// writes of the Swamps, so its buffer on the server is full
+type OverflowPolicy int
+
+const (
+	// OverflowDefault is OverflowBlock for Subscribe, SubscribeFrom and ProfileSubscribe, and OverflowCancel for
+	// SubscribeAll and SubscribeAllFrom
+	OverflowDefault OverflowPolicy = iota
+	// OverflowBlock makes the writers of the Swamps wait until the iterator catches up. No event is lost, but the
+	// writes slow down to the speed of the iterator
+	OverflowBlock
+	// OverflowDropOldest drops the oldest events waiting in the buffer for the new ones, and tells their number to
+	// OnDropped of the SubscriberBuffer
+	OverflowDropOldest
+	// OverflowCancel closes the stream, and the iterator gets an error where IsSubscriberOverflow(err) is true
+	OverflowCancel
+)
+
+// SubscriberBuffer is the buffer of the events of a subscription on the server, set by WithSubscriberBuffer
+type SubscriberBuffer struct {
+	// Size is the max number of the events waiting for the iterator on a server. 0 means 10000, the max is 1000000
+	Size int
+	// Overflow is the policy of the full buffer
+	Overflow OverflowPolicy
+	// OnDropped is called with the number of the events dropped by OverflowDropOldest, before the next event is
+	// passed to the iterator. It is called by the goroutine of the stream, so it must not block
+	OnDropped func(dropped uint64)
+}
+
+type subscriberBufferKey struct{}
+
+// WithSubscriberBuffer returns a context whose subscriptions use the buffer size and the overflow policy.
+//
+// The server sends the events from a bounded buffer per subscription. A slow iterator fills the buffer, and then
+// the overflow policy decides: the writers wait, the oldest events are dropped, or the subscription is cancelled.
+// The drops and the cancellations are counted on the `/metrics` endpoint of the server as
+// `hydraide_subscriber_dropped_events_total` and `hydraide_subscriber_cancellations_total`.
+//
+// ✅ Use OverflowDropOldest for the live views, where only the latest state matters, e.g. a dashboard.
+// ✅ Use OverflowCancel for the consumers that resynchronize, e.g. with SubscribeFrom after the error.
+// ⚠️ OverflowBlock slows down every writer of the Swamp to the speed of the slowest iterator.
+//
+// 🔧 Example:
+//
+//	ctx = hydraidego.WithSubscriberBuffer(ctx, hydraidego.SubscriberBuffer{
+//		Size:     1000,
+//		Overflow: hydraidego.OverflowDropOldest,
+//		OnDropped: func(dropped uint64) {
+//			slog.Warn("the dashboard missed events", "dropped", dropped)
+//		},
+//	})
+//	subscription, err := h.Subscribe(ctx, swampName, false, Price{}, iterator)
+func WithSubscriberBuffer(ctx context.Context, buffer SubscriberBuffer) context.Context {
+	return context.WithValue(ctx, subscriberBufferKey{}, buffer)
+}
+
+// subscriberBufferFrom returns the buffer of the context, the defaults of the server if it is not set
+func subscriberBufferFrom(ctx context.Context) SubscriberBuffer {
+	buffer, _ := ctx.Value(subscriberBufferKey{}).(SubscriberBuffer)
+	return buffer
+}
+
+// bufferSize returns the size of the buffer in the request, 0 for the default of the server
+func (b SubscriberBuffer) bufferSize() uint32 {
+	if b.Size <= 0 {
+		return 0
+	}
+	return uint32(min(b.Size, int(^uint32(0))))
+}
+
+// overflowPolicy returns the policy of the request
+func (b SubscriberBuffer) overflowPolicy() hydraidepbgo.OverflowPolicy_Policy {
+	switch b.Overflow {
+	case OverflowBlock:
+		return hydraidepbgo.OverflowPolicy_BLOCK
+	case OverflowDropOldest:
+		return hydraidepbgo.OverflowPolicy_DROP_OLDEST
+	case OverflowCancel:
+		return hydraidepbgo.OverflowPolicy_CANCEL
+	default:
+		return hydraidepbgo.OverflowPolicy_DEFAULT
+	}
+}
+
+// dropped reports the dropped events of the message, and returns true if the message only carries them
+func (b SubscriberBuffer) dropped(event *hydraidepbgo.SubscribeToEventsResponse) bool {
+	dropped := event.GetDroppedEvents()
+	if dropped == 0 {
+		return false
+	}
+	if b.OnDropped != nil {
+		b.OnDropped(dropped)
+	}
+	return true
+}
@@ -1,2 +1,48 @@
-def hello() -> str:
-    return "Hello from hydraidepy!"
+"""HydrAIDE Python SDK.
+
+The SDK talks to the same HydrAIDE servers as the Go SDK, with the same island routing, so Python and Go services
+can share the swamps of a cluster. See :class:`Hydraide` for the API and :class:`Client` for the connection.
+"""
+
+from .client import Client, Server
+from .errors import ErrorCode, HydraideError
+from .hydraide import Condition, EventStatus, Hydraide, Index, IndexOrder, IndexType, RelationalOperator
+from .models import (
+    ValueType,
+    created_at,
+    created_by,
+    expire_at,
+    key,
+    profile_field,
+    updated_at,
+    updated_by,
+    value,
+    version,
+)
+from .name import Name, load
+
+__all__ = [
+    "Client",
+    "Condition",
+    "ErrorCode",
+    "EventStatus",
+    "Hydraide",
+    "HydraideError",
+    "Index",
+    "IndexOrder",
+    "IndexType",
+    "Name",
+    "RelationalOperator",
+    "Server",
+    "ValueType",
+    "created_at",
+    "created_by",
+    "expire_at",
+    "key",
+    "load",
+    "profile_field",
+    "updated_at",
+    "updated_by",
+    "value",
+    "version",
+]
@@ -0,0 +1,81 @@
+"""Pure Python implementation of the 64 bit xxHash (XXH64) with seed 0.
+
+The island of a swamp is calculated from the xxhash of its name, exactly like the Go SDK does with
+github.com/cespare/xxhash/v2. The hash is implemented here, so the SDK doesn't need a native extension
+and the routing can never drift from the Go implementation because of a different library version.
+"""
+
+_MASK = 0xFFFFFFFFFFFFFFFF
+
+_PRIME1 = 0x9E3779B185EBCA87
+_PRIME2 = 0xC2B2AE3D27D4EB4F
+_PRIME3 = 0x165667B19E3779F9
+_PRIME4 = 0x85EBCA77C2B2AE63
+_PRIME5 = 0x27D4EB2F165667C5
+
+
+def _rotl(value: int, bits: int) -> int:
+    return ((value << bits) | (value >> (64 - bits))) & _MASK
+
+
+def _round(acc: int, lane: int) -> int:
+    acc = (acc + lane * _PRIME2) & _MASK
+    acc = _rotl(acc, 31)
+    return (acc * _PRIME1) & _MASK
+
+
+def _merge_round(acc: int, value: int) -> int:
+    acc ^= _round(0, value)
+    return (acc * _PRIME1 + _PRIME4) & _MASK
+
+
+def xxh64(data: bytes) -> int:
+    """Returns the XXH64 hash of the data with seed 0, as an unsigned 64 bit integer."""
+
+    length = len(data)
+    offset = 0
+
+    if length >= 32:
+        v1 = (_PRIME1 + _PRIME2) & _MASK
+        v2 = _PRIME2
+        v3 = 0
+        v4 = (-_PRIME1) & _MASK
+        while offset <= length - 32:
+            v1 = _round(v1, int.from_bytes(data[offset : offset + 8], "little"))
+            v2 = _round(v2, int.from_bytes(data[offset + 8 : offset + 16], "little"))
+            v3 = _round(v3, int.from_bytes(data[offset + 16 : offset + 24], "little"))
+            v4 = _round(v4, int.from_bytes(data[offset + 24 : offset + 32], "little"))
+            offset += 32
+        h = (_rotl(v1, 1) + _rotl(v2, 7) + _rotl(v3, 12) + _rotl(v4, 18)) & _MASK
+        h = _merge_round(h, v1)
+        h = _merge_round(h, v2)
+        h = _merge_round(h, v3)
+        h = _merge_round(h, v4)
+    else:
+        h = _PRIME5
+
+    h = (h + length) & _MASK
+
+    while offset + 8 <= length:
+        k1 = _round(0, int.from_bytes(data[offset : offset + 8], "little"))
+        h ^= k1
+        h = (_rotl(h, 27) * _PRIME1 + _PRIME4) & _MASK
+        offset += 8
+
+    if offset + 4 <= length:
+        h ^= (int.from_bytes(data[offset : offset + 4], "little") * _PRIME1) & _MASK
+        h = (_rotl(h, 23) * _PRIME2 + _PRIME3) & _MASK
+        offset += 4
+
+    while offset < length:
+        h ^= (data[offset] * _PRIME5) & _MASK
+        h = (_rotl(h, 11) * _PRIME1) & _MASK
+        offset += 1
+
+    h ^= h >> 33
+    h = (h * _PRIME2) & _MASK
+    h ^= h >> 29
+    h = (h * _PRIME3) & _MASK
+    h ^= h >> 32
+
+    return h
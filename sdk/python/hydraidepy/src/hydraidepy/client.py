@@ -0,0 +1,180 @@
+"""Connection handling of the HydrAIDE Python SDK.
+
+The client opens one TLS connection per HydrAIDE server and routes every swamp to the server that owns its island,
+the same way the client package of the Go SDK does. The island ranges of the servers and the number of all islands
+must be the same as in the Go services of the cluster.
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+import threading
+from dataclasses import dataclass
+
+import grpc
+
+from .generated import hydraide_pb2, hydraide_pb2_grpc
+from .name import Name
+
+logger = logging.getLogger("hydraidepy")
+
+ERROR_NO_CONNECTION = "there is no connection to the HydrAIDE server"
+ERROR_CONNECTION = "error while connecting to the server"
+
+# the retry policy of the Go SDK, so the two SDKs behave the same during a server restart
+_SERVICE_CONFIG = json.dumps(
+    {
+        "methodConfig": [
+            {
+                "name": [{"service": "hydraidepbgo.HydraideService"}],
+                "waitForReady": True,
+                "retryPolicy": {
+                    "maxAttempts": 100,
+                    "initialBackoff": "0.5s",
+                    "maxBackoff": "10s",
+                    "backoffMultiplier": 1.5,
+                    "retryableStatusCodes": [
+                        "UNAVAILABLE",
+                        "DEADLINE_EXCEEDED",
+                        "RESOURCE_EXHAUSTED",
+                        "INTERNAL",
+                        "UNKNOWN",
+                    ],
+                },
+            }
+        ]
+    }
+)
+
+
+@dataclass
+class Server:
+    """A HydrAIDE server and the island range it owns (both ends inclusive)."""
+
+    host: str
+    from_island: int
+    to_island: int
+    cert_file_path: str
+
+
+class Client:
+    """Manages the connections to the HydrAIDE servers and routes the swamps to them by island.
+
+    :param servers: the servers of the cluster with their island ranges
+    :param all_islands: the number of all islands of the cluster, the same as in every other client
+    :param max_message_size: the maximum size of the sent and received messages in bytes
+    """
+
+    def __init__(self, servers: list[Server], all_islands: int, max_message_size: int) -> None:
+        self._servers = servers
+        self._all_islands = all_islands
+        self._max_message_size = max_message_size
+        self._service_clients: dict[int, tuple[hydraide_pb2_grpc.HydraideServiceStub, str]] = {}
+        self._unique_services: list[hydraide_pb2_grpc.HydraideServiceStub] = []
+        self._channels: list[grpc.Channel] = []
+        self._lock = threading.RLock()
+
+    def connect(self) -> None:
+        """Connects to all servers and checks them with a heartbeat.
+
+        Raises ConnectionError if any server is unreachable. The reachable servers are connected anyway.
+        """
+
+        failed = False
+
+        with self._lock:
+            for server in self._servers:
+                try:
+                    with open(server.cert_file_path, "rb") as cert_file:
+                        credentials = grpc.ssl_channel_credentials(root_certificates=cert_file.read())
+                except OSError as err:
+                    logger.error(
+                        "error while loading TLS credentials: %s, server: %s, fromIsland: %d, toIsland: %d",
+                        err,
+                        server.host,
+                        server.from_island,
+                        server.to_island,
+                    )
+                    failed = True
+                    continue
+
+                channel = grpc.secure_channel(
+                    server.host,
+                    credentials,
+                    options=[
+                        ("grpc.max_send_message_length", self._max_message_size),
+                        ("grpc.max_receive_message_length", self._max_message_size),
+                        ("grpc.enable_retries", 1),
+                        ("grpc.service_config", _SERVICE_CONFIG),
+                        ("grpc.keepalive_time_ms", 60_000),
+                        ("grpc.keepalive_timeout_ms", 10_000),
+                        ("grpc.keepalive_permit_without_calls", 0),
+                    ],
+                )
+                stub = hydraide_pb2_grpc.HydraideServiceStub(channel)
+
+                try:
+                    pong = stub.Heartbeat(hydraide_pb2.HeartbeatRequest(Ping="beat"), timeout=5)
+                except grpc.RpcError as err:
+                    logger.error("error while sending heartbeat request: %s, server: %s", err, server.host)
+                    channel.close()
+                    failed = True
+                    continue
+                if pong.Pong != "beat":
+                    logger.error("wrong heartbeat response: %s, server: %s", pong.Pong, server.host)
+                    channel.close()
+                    failed = True
+                    continue
+
+                logger.info("connected to the hydra server successfully, server: %s", server.host)
+
+                for island in range(server.from_island, server.to_island + 1):
+                    self._service_clients[island] = (stub, server.host)
+                self._channels.append(channel)
+                self._unique_services.append(stub)
+
+        if failed:
+            raise ConnectionError(ERROR_CONNECTION)
+
+    def close(self) -> None:
+        """Closes all connections."""
+        with self._lock:
+            for channel in self._channels:
+                channel.close()
+            self._channels = []
+            self._service_clients = {}
+            self._unique_services = []
+
+    def service_client(self, swamp_name: Name) -> hydraide_pb2_grpc.HydraideServiceStub:
+        """Returns the service client of the server that owns the island of the swamp."""
+        return self.service_client_and_host(swamp_name)[0]
+
+    def service_client_and_host(self, swamp_name: Name) -> tuple[hydraide_pb2_grpc.HydraideServiceStub, str]:
+        """Returns the service client and the host of the server that owns the island of the swamp.
+
+        Raises ConnectionError if no connected server owns the island.
+        """
+        with self._lock:
+            island_id = swamp_name.island_id(self._all_islands)
+            if island_id in self._service_clients:
+                return self._service_clients[island_id]
+        logger.error("error while getting service client by swamp name: %s", swamp_name.get())
+        raise ConnectionError(ERROR_NO_CONNECTION)
+
+    def unique_service_clients(self) -> list[hydraide_pb2_grpc.HydraideServiceStub]:
+        """Returns one service client per connected server."""
+        with self._lock:
+            return list(self._unique_services)
+
+    @property
+    def all_islands(self) -> int:
+        """The number of all islands of the cluster."""
+        return self._all_islands
+
+    def __enter__(self) -> Client:
+        self.connect()
+        return self
+
+    def __exit__(self, *_: object) -> None:
+        self.close()
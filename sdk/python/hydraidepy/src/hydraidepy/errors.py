@@ -0,0 +1,213 @@
+"""Errors of the HydrAIDE Python SDK.
+
+Every failed operation raises :class:`HydraideError` with an :class:`ErrorCode` that has the same meaning (and the
+same numeric value) as the ErrorCode of the Go SDK, so the services of the two languages can handle the errors the
+same way.
+"""
+
+from __future__ import annotations
+
+import enum
+
+import grpc
+from google.protobuf import any_pb2, descriptor_pb2, descriptor_pool, duration_pb2, message_factory
+
+from .generated import hydraide_pb2
+
+ERROR_DOMAIN = "hydraide"
+
+MESSAGE_CONNECTION_ERROR = "connection error"
+MESSAGE_CTX_TIMEOUT = "context timeout exceeded"
+MESSAGE_CTX_CLOSED_BY_CLIENT = "context closed by client"
+MESSAGE_INVALID_ARGUMENT = "invalid argument"
+MESSAGE_NOT_FOUND = "sanctuary not found"
+MESSAGE_UNKNOWN = "unknown error"
+MESSAGE_SWAMP_NOT_FOUND = "swamp not found"
+MESSAGE_INTERNAL_ERROR = "internal error"
+MESSAGE_KEY_ALREADY_EXISTS = "key already exists"
+MESSAGE_KEY_NOT_FOUND = "key not found"
+MESSAGE_CONDITION_NOT_MET = "condition not met - the value is"
+MESSAGE_QUOTA_EXCEEDED = "quota exceeded"
+MESSAGE_WRONG_VALUE_TYPE = "wrong value type"
+
+
+# the gRPC trailer of the google.rpc.Status with the details of the error
+_STATUS_DETAILS_KEY = "grpc-status-details-bin"
+
+
+def _rpc_messages() -> dict[str, type]:
+    """Builds the google.rpc Status, ErrorInfo and RetryInfo messages in a private descriptor pool.
+
+    Only these three messages are needed from the googleapis protos, so they are declared here instead of depending
+    on the googleapis-common-protos package. The private pool can't conflict with that package if it is installed.
+    """
+
+    pool = descriptor_pool.DescriptorPool()
+    pool.AddSerializedFile(any_pb2.DESCRIPTOR.serialized_pb)
+    pool.AddSerializedFile(duration_pb2.DESCRIPTOR.serialized_pb)
+
+    file = descriptor_pb2.FileDescriptorProto(
+        name="hydraidepy/google_rpc.proto",
+        package="google.rpc",
+        syntax="proto3",
+        dependency=["google/protobuf/any.proto", "google/protobuf/duration.proto"],
+    )
+    field = descriptor_pb2.FieldDescriptorProto
+    optional, repeated = field.LABEL_OPTIONAL, field.LABEL_REPEATED
+
+    status = file.message_type.add(name="Status")
+    status.field.add(name="code", number=1, type=field.TYPE_INT32, label=optional)
+    status.field.add(name="message", number=2, type=field.TYPE_STRING, label=optional)
+    status.field.add(
+        name="details", number=3, type=field.TYPE_MESSAGE, label=repeated, type_name=".google.protobuf.Any"
+    )
+
+    error_info = file.message_type.add(name="ErrorInfo")
+    error_info.field.add(name="reason", number=1, type=field.TYPE_STRING, label=optional)
+    error_info.field.add(name="domain", number=2, type=field.TYPE_STRING, label=optional)
+
+    retry_info = file.message_type.add(name="RetryInfo")
+    retry_info.field.add(
+        name="retry_delay", number=1, type=field.TYPE_MESSAGE, label=optional, type_name=".google.protobuf.Duration"
+    )
+
+    pool.AddSerializedFile(file.SerializeToString())
+    return {
+        name: message_factory.GetMessageClass(pool.FindMessageTypeByName(f"google.rpc.{name}"))
+        for name in ("Status", "ErrorInfo", "RetryInfo")
+    }
+
+
+_RPC_MESSAGES = _rpc_messages()
+
+
+class ErrorCode(enum.IntEnum):
+    """The error codes of the SDK, in the same order as in the Go SDK."""
+
+    CONNECTION_ERROR = 0
+    INTERNAL_DATABASE_ERROR = 1
+    CTX_CLOSED_BY_CLIENT = 2
+    CTX_TIMEOUT = 3
+    SWAMP_NOT_FOUND = 4
+    FAILED_PRECONDITION = 5
+    INVALID_ARGUMENT = 6
+    NOT_FOUND = 7
+    ALREADY_EXISTS = 8
+    INVALID_MODEL = 9
+    CONDITION_NOT_MET = 10
+    UNKNOWN = 11
+    QUOTA_EXCEEDED = 12
+
+
+class HydraideError(Exception):
+    """A structured error of a HydrAIDE operation.
+
+    ``retry_after`` is the time in seconds to wait before retrying, if the server sent it (e.g. rate limited
+    requests), otherwise 0.
+    """
+
+    def __init__(self, code: ErrorCode, message: str, retry_after: float = 0.0) -> None:
+        super().__init__(f"Code: {int(code)}, Message: {message}")
+        self.code = code
+        self.message = message
+        self.retry_after = retry_after
+
+
+def _status_details(err: grpc.Call) -> tuple[list[tuple[str, str]], float]:
+    """Returns the (domain, reason) pairs of the ErrorInfo details and the delay of the RetryInfo detail in seconds,
+    if the server sent them."""
+
+    trailers = err.trailing_metadata() or ()
+    raw = next((v for k, v in trailers if k == _STATUS_DETAILS_KEY), None)
+    if raw is None:
+        return [], 0.0
+
+    status = _RPC_MESSAGES["Status"]()
+    status.ParseFromString(raw)
+
+    reasons: list[tuple[str, str]] = []
+    retry_after = 0.0
+    for detail in status.details:
+        message_name = detail.type_url.rsplit("/", 1)[-1]
+        if message_name == "google.rpc.ErrorInfo":
+            info = _RPC_MESSAGES["ErrorInfo"]()
+            info.ParseFromString(detail.value)
+            reasons.append((info.domain, info.reason))
+        elif message_name == "google.rpc.RetryInfo":
+            retry_info = _RPC_MESSAGES["RetryInfo"]()
+            retry_info.ParseFromString(detail.value)
+            retry_after = retry_info.retry_delay.seconds + retry_info.retry_delay.nanos / 1e9
+    return reasons, retry_after
+
+
+def _error_from_reason(reasons: list[tuple[str, str]], retry_after: float, message: str) -> HydraideError | None:
+    """Maps the machine-readable reason of the server to an SDK error.
+
+    The reason is more precise than the status code, so it has priority over it. Returns None if the error carries
+    no known reason, for example because the server is an older version.
+    """
+
+    known = hydraide_pb2.ErrorReason
+    for domain, reason_name in reasons:
+        if domain != ERROR_DOMAIN or reason_name not in known.Reason.keys():
+            continue
+        reason = known.Reason.Value(reason_name)
+        if reason == known.SWAMP_NOT_FOUND:
+            return HydraideError(ErrorCode.SWAMP_NOT_FOUND, f"{MESSAGE_SWAMP_NOT_FOUND}: {message}")
+        if reason == known.KEY_NOT_FOUND:
+            return HydraideError(ErrorCode.NOT_FOUND, f"{MESSAGE_KEY_NOT_FOUND}: {message}")
+        if reason == known.KEY_EXISTS:
+            return HydraideError(ErrorCode.ALREADY_EXISTS, f"{MESSAGE_KEY_ALREADY_EXISTS}: {message}")
+        if reason == known.CONDITION_NOT_MET:
+            return HydraideError(ErrorCode.CONDITION_NOT_MET, message)
+        if reason == known.QUOTA_EXCEEDED:
+            return HydraideError(ErrorCode.QUOTA_EXCEEDED, f"{MESSAGE_QUOTA_EXCEEDED}: {message}", retry_after)
+        if reason in (known.INVALID_ARGUMENT, known.INVALID_FILTER_EXPRESSION):
+            return HydraideError(ErrorCode.INVALID_ARGUMENT, f"{MESSAGE_INVALID_ARGUMENT}: {message}")
+        if reason == known.WRONG_VALUE_TYPE:
+            return HydraideError(ErrorCode.FAILED_PRECONDITION, f"{MESSAGE_WRONG_VALUE_TYPE}: {message}")
+        if reason == known.VALUE_INDEX_NOT_ENABLED:
+            return HydraideError(ErrorCode.FAILED_PRECONDITION, message)
+        if reason == known.LOCK_NOT_FOUND:
+            return HydraideError(ErrorCode.NOT_FOUND, message)
+        if reason == known.LOCK_DEADLINE_EXCEEDED:
+            return HydraideError(ErrorCode.CTX_TIMEOUT, message)
+        if reason == known.INTERNAL:
+            return HydraideError(ErrorCode.INTERNAL_DATABASE_ERROR, f"{MESSAGE_INTERNAL_ERROR}: {message}")
+
+    return None
+
+
+def from_grpc_error(err: Exception) -> HydraideError:
+    """Converts a gRPC error to a HydraideError.
+
+    The reason sent by the server decides first, then the gRPC status code, like in the errorHandler of the Go SDK.
+    """
+
+    if isinstance(err, HydraideError):
+        return err
+    if not isinstance(err, grpc.RpcError) or not isinstance(err, grpc.Call):
+        return HydraideError(ErrorCode.UNKNOWN, f"{MESSAGE_UNKNOWN}: {err}")
+
+    message = err.details() or ""
+    reasons, retry_after = _status_details(err)
+    reason_error = _error_from_reason(reasons, retry_after, message)
+    if reason_error is not None:
+        return reason_error
+
+    code = err.code()
+    if code == grpc.StatusCode.UNAVAILABLE:
+        return HydraideError(ErrorCode.CONNECTION_ERROR, MESSAGE_CONNECTION_ERROR)
+    if code == grpc.StatusCode.DEADLINE_EXCEEDED:
+        return HydraideError(ErrorCode.CTX_TIMEOUT, MESSAGE_CTX_TIMEOUT)
+    if code == grpc.StatusCode.CANCELLED:
+        return HydraideError(ErrorCode.CTX_CLOSED_BY_CLIENT, MESSAGE_CTX_CLOSED_BY_CLIENT)
+    if code == grpc.StatusCode.FAILED_PRECONDITION:
+        return HydraideError(ErrorCode.SWAMP_NOT_FOUND, f"{MESSAGE_SWAMP_NOT_FOUND}: {message}")
+    if code == grpc.StatusCode.INVALID_ARGUMENT:
+        return HydraideError(ErrorCode.INVALID_ARGUMENT, f"{MESSAGE_INVALID_ARGUMENT}: {message}")
+    if code == grpc.StatusCode.NOT_FOUND:
+        return HydraideError(ErrorCode.NOT_FOUND, f"{MESSAGE_NOT_FOUND}: {message}")
+    if code == grpc.StatusCode.INTERNAL:
+        return HydraideError(ErrorCode.INTERNAL_DATABASE_ERROR, f"{MESSAGE_INTERNAL_ERROR}: {message}")
+    return HydraideError(ErrorCode.UNKNOWN, f"{MESSAGE_UNKNOWN}: {message}")
@@ -25,7 +25,7 @@ _sym_db = _symbol_database.Default()
 from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0ehydraide.proto\x12\x0chydraidepbgo\x1a\x1fgoogle/protobuf/timestamp.proto\" \n\x10HeartbeatRequest\x12\x0c\n\x04Ping\x18\x01 \x01(\t\"!\n\x11HeartbeatResponse\x12\x0c\n\x04Pong\x18\x01 \x01(\t\"\'\n\x0bLockRequest\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0b\n\x03TTL\x18\x02 \x01(\x03\"\x1e\n\x0cLockResponse\x12\x0e\n\x06LockID\x18\x01 \x01(\t\",\n\rUnlockRequest\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0e\n\x06LockID\x18\x02 \x01(\t\"\x10\n\x0eUnlockResponse\"5\n\x0e\x44\x65stroyRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\x11\n\x0f\x44\x65stroyResponse\"=\n\x16SubscribeToInfoRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"A\n\x17SubscribeToInfoResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x13\n\x0b\x41llElements\x18\x02 \x01(\x04\"?\n\x18SubscribeToEventsRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\x90\x02\n\x19SubscribeToEventsResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12(\n\x08Treasure\x18\x02 \x01(\x0b\x32\x16.hydraidepbgo.Treasure\x12+\n\x0bOldTreasure\x18\x03 \x01(\x0b\x32\x16.hydraidepbgo.Treasure\x12/\n\x0f\x44\x65letedTreasure\x18\x04 \x01(\x0b\x32\x16.hydraidepbgo.Treasure\x12-\n\tEventTime\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12)\n\x06Status\x18\x06 \x01(\x0e\x32\x19.hydraidepbgo.Status.Code\",\n\tSwampKeys\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x0c\n\x04Keys\x18\x02 \x03(\t\"\xb5\x01\n\x14RegisterSwampRequest\x12\x14\n\x0cSwampPattern\x18\x01 \x01(\t\x12\x16\n\x0e\x43loseAfterIdle\x18\x02 \x01(\x03\x12\x17\n\x0fIsInMemorySwamp\x18\x03 \x01(\x08\x12\x1a\n\rWriteInterval\x18\x04 \x01(\x03H\x00\x88\x01\x01\x12\x18\n\x0bMaxFileSize\x18\x05 \x01(\x03H\x01\x88\x01\x01\x42\x10\n\x0e_WriteIntervalB\x0e\n\x0c_MaxFileSize\"\x17\n\x15RegisterSwampResponse\".\n\x16\x44\x65RegisterSwampRequest\x12\x14\n\x0cSwampPattern\x18\x01 \x01(\t\"\x19\n\x17\x44\x65RegisterSwampResponse\"8\n\nSetRequest\x12*\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1a.hydraidepbgo.SwampRequest\"\x8f\x01\n\x0cSwampRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12-\n\tKeyValues\x18\x03 \x03(\x0b\x32\x1a.hydraidepbgo.KeyValuePair\x12\x18\n\x10\x43reateIfNotExist\x18\x04 \x01(\x08\x12\x11\n\tOverwrite\x18\x05 \x01(\x08\"\xe0\x06\n\x0cKeyValuePair\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x14\n\x07Int8Val\x18\x02 \x01(\x05H\x00\x88\x01\x01\x12\x15\n\x08Int16Val\x18\x03 \x01(\x05H\x01\x88\x01\x01\x12\x15\n\x08Int32Val\x18\x04 \x01(\x05H\x02\x88\x01\x01\x12\x15\n\x08Int64Val\x18\x05 \x01(\x03H\x03\x88\x01\x01\x12\x15\n\x08Uint8Val\x18\x06 \x01(\rH\x04\x88\x01\x01\x12\x16\n\tUint16Val\x18\x07 \x01(\rH\x05\x88\x01\x01\x12\x16\n\tUint32Val\x18\x08 \x01(\rH\x06\x88\x01\x01\x12\x16\n\tUint64Val\x18\t \x01(\x04H\x07\x88\x01\x01\x12\x17\n\nFloat32Val\x18\n \x01(\x02H\x08\x88\x01\x01\x12\x17\n\nFloat64Val\x18\x0b \x01(\x01H\t\x88\x01\x01\x12\x16\n\tStringVal\x18\x0c \x01(\tH\n\x88\x01\x01\x12\x30\n\x07\x42oolVal\x18\r \x01(\x0e\x32\x1a.hydraidepbgo.Boolean.TypeH\x0b\x88\x01\x01\x12\x15\n\x08\x42ytesVal\x18\x0e \x01(\x0cH\x0c\x88\x01\x01\x12\x13\n\x0bUint32Slice\x18\x0f \x03(\r\x12\x14\n\x07VoidVal\x18\x10 \x01(\x08H\r\x88\x01\x01\x12\x32\n\tCreatedAt\x18\x11 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x0e\x88\x01\x01\x12\x16\n\tCreatedBy\x18\x12 \x01(\tH\x0f\x88\x01\x01\x12\x32\n\tUpdatedAt\x18\x13 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x10\x88\x01\x01\x12\x16\n\tUpdatedBy\x18\x14 \x01(\tH\x11\x88\x01\x01\x12\x32\n\tExpiredAt\x18\x15 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x12\x88\x01\x01\x42\n\n\x08_Int8ValB\x0b\n\t_Int16ValB\x0b\n\t_Int32ValB\x0b\n\t_Int64ValB\x0b\n\t_Uint8ValB\x0c\n\n_Uint16ValB\x0c\n\n_Uint32ValB\x0c\n\n_Uint64ValB\r\n\x0b_Float32ValB\r\n\x0b_Float64ValB\x0c\n\n_StringValB\n\n\x08_BoolValB\x0b\n\t_BytesValB\n\n\x08_VoidValB\x0c\n\n_CreatedAtB\x0c\n\n_CreatedByB\x0c\n\n_UpdatedAtB\x0c\n\n_UpdatedByB\x0c\n\n_ExpiredAt\":\n\x0bSetResponse\x12+\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1b.hydraidepbgo.SwampResponse\"\xe3\x01\n\rSwampResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x34\n\x0fKeysAndStatuses\x18\x02 \x03(\x0b\x32\x1b.hydraidepbgo.KeyStatusPair\x12?\n\tErrorCode\x18\x03 \x01(\x0e\x32\'.hydraidepbgo.SwampResponse.ErrCodeEnumH\x00\x88\x01\x01\":\n\x0b\x45rrCodeEnum\x12\x14\n\x10\x43\x61nNotBeExecuted\x10\x00\x12\x15\n\x11SwampDoesNotExist\x10\x01\x42\x0c\n\n_ErrorCode\"G\n\rKeyStatusPair\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12)\n\x06Status\x18\x02 \x01(\x0e\x32\x19.hydraidepbgo.Status.Code\"W\n\x06Status\"M\n\x04\x43ode\x12\r\n\tNOT_FOUND\x10\x00\x12\x07\n\x03NEW\x10\x01\x12\x0b\n\x07UPDATED\x10\x02\x12\x0b\n\x07\x44\x45LETED\x10\x03\x12\x13\n\x0fNOTHING_CHANGED\x10\x04\"4\n\nGetRequest\x12&\n\x06Swamps\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.GetSwamp\"=\n\x08GetSwamp\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0c\n\x04Keys\x18\x03 \x03(\t\"=\n\x0bGetResponse\x12.\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1e.hydraidepbgo.GetSwampResponse\"a\n\x10GetSwampResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x0f\n\x07IsExist\x18\x02 \x01(\x08\x12)\n\tTreasures\x18\x03 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"4\n\rGetAllRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\";\n\x0eGetAllResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"T\n\x1cShiftExpiredTreasuresRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0f\n\x07HowMany\x18\x03 \x01(\x05\"J\n\x1dShiftExpiredTreasuresResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"\xcb\x06\n\x08Treasure\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0f\n\x07IsExist\x18\x02 \x01(\x08\x12\x14\n\x07Int8Val\x18\x03 \x01(\x05H\x00\x88\x01\x01\x12\x15\n\x08Int16Val\x18\x04 \x01(\x05H\x01\x88\x01\x01\x12\x15\n\x08Int32Val\x18\x05 \x01(\x05H\x02\x88\x01\x01\x12\x15\n\x08Int64Val\x18\x06 \x01(\x03H\x03\x88\x01\x01\x12\x15\n\x08Uint8Val\x18\x07 \x01(\rH\x04\x88\x01\x01\x12\x16\n\tUint16Val\x18\x08 \x01(\rH\x05\x88\x01\x01\x12\x16\n\tUint32Val\x18\t \x01(\rH\x06\x88\x01\x01\x12\x16\n\tUint64Val\x18\n \x01(\x04H\x07\x88\x01\x01\x12\x17\n\nFloat32Val\x18\x0b \x01(\x02H\x08\x88\x01\x01\x12\x17\n\nFloat64Val\x18\x0c \x01(\x01H\t\x88\x01\x01\x12\x16\n\tStringVal\x18\r \x01(\tH\n\x88\x01\x01\x12\x30\n\x07\x42oolVal\x18\x0e \x01(\x0e\x32\x1a.hydraidepbgo.Boolean.TypeH\x0b\x88\x01\x01\x12\x15\n\x08\x42ytesVal\x18\x0f \x01(\x0cH\x0c\x88\x01\x01\x12\x13\n\x0bUint32Slice\x18\x10 \x03(\r\x12\x32\n\tCreatedAt\x18\x11 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\r\x88\x01\x01\x12\x16\n\tCreatedBy\x18\x12 \x01(\tH\x0e\x88\x01\x01\x12\x32\n\tUpdatedAt\x18\x13 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x0f\x88\x01\x01\x12\x16\n\tUpdatedBy\x18\x14 \x01(\tH\x10\x88\x01\x01\x12\x32\n\tExpiredAt\x18\x15 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x11\x88\x01\x01\x42\n\n\x08_Int8ValB\x0b\n\t_Int16ValB\x0b\n\t_Int32ValB\x0b\n\t_Int64ValB\x0b\n\t_Uint8ValB\x0c\n\n_Uint16ValB\x0c\n\n_Uint32ValB\x0c\n\n_Uint64ValB\r\n\x0b_Float32ValB\r\n\x0b_Float64ValB\x0c\n\n_StringValB\n\n\x08_BoolValB\x0b\n\t_BytesValB\x0c\n\n_CreatedAtB\x0c\n\n_CreatedByB\x0c\n\n_UpdatedAtB\x0c\n\n_UpdatedByB\x0c\n\n_ExpiredAt\"&\n\x07\x42oolean\"\x1b\n\x04Type\x12\x08\n\x04TRUE\x10\x00\x12\t\n\x05\x46\x41LSE\x10\x01\"\xb7\x01\n\x11GetByIndexRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12/\n\tIndexType\x18\x03 \x01(\x0e\x32\x1c.hydraidepbgo.IndexType.Type\x12/\n\tOrderType\x18\x04 \x01(\x0e\x32\x1c.hydraidepbgo.OrderType.Type\x12\x0c\n\x04\x46rom\x18\x05 \x01(\x05\x12\r\n\x05Limit\x18\x06 \x01(\x05\"\x98\x02\n\tIndexType\"\x8a\x02\n\x04Type\x12\x07\n\x03KEY\x10\x00\x12\x13\n\x0f\x45XPIRATION_TIME\x10\x01\x12\x11\n\rCREATION_TIME\x10\x02\x12\x0f\n\x0bUPDATE_TIME\x10\x03\x12\x0e\n\nVALUE_INT8\x10\x04\x12\x0f\n\x0bVALUE_INT16\x10\x05\x12\x0f\n\x0bVALUE_INT32\x10\x06\x12\x0f\n\x0bVALUE_INT64\x10\x07\x12\x0f\n\x0bVALUE_UINT8\x10\x08\x12\x10\n\x0cVALUE_UINT16\x10\t\x12\x10\n\x0cVALUE_UINT32\x10\n\x12\x10\n\x0cVALUE_UINT64\x10\x0b\x12\x11\n\rVALUE_FLOAT32\x10\x0c\x12\x11\n\rVALUE_FLOAT64\x10\r\x12\x10\n\x0cVALUE_STRING\x10\x0e\"&\n\tOrderType\"\x19\n\x04Type\x12\x07\n\x03\x41SC\x10\x00\x12\x08\n\x04\x44\x45SC\x10\x01\"?\n\x12GetByIndexResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"\x86\x01\n\rDeleteRequest\x12\x35\n\x06Swamps\x18\x01 \x03(\x0b\x32%.hydraidepbgo.DeleteRequest.SwampKeys\x1a>\n\tSwampKeys\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0c\n\x04Keys\x18\x03 \x03(\t\"\xc0\x02\n\x0e\x44\x65leteResponse\x12\x43\n\tResponses\x18\x01 \x03(\x0b\x32\x30.hydraidepbgo.DeleteResponse.SwampDeleteResponse\x1a\xe8\x01\n\x13SwampDeleteResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12V\n\tErrorCode\x18\x02 \x01(\x0e\x32>.hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCodeEnumH\x00\x88\x01\x01\x12\x30\n\x0bKeyStatuses\x18\x03 \x03(\x0b\x32\x1b.hydraidepbgo.KeyStatusPair\"&\n\rErrorCodeEnum\x12\x15\n\x11SwampDoesNotExist\x10\x00\x42\x0c\n\n_ErrorCode\"\x82\x01\n\x0c\x43ountRequest\x12:\n\x06Swamps\x18\x01 \x03(\x0b\x32*.hydraidepbgo.CountRequest.SwampIdentifier\x1a\x36\n\x0fSwampIdentifier\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"9\n\rCountResponse\x12(\n\x06Swamps\x18\x01 \x03(\x0b\x32\x18.hydraidepbgo.CountSwamp\"?\n\nCountSwamp\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x0f\n\x07IsExist\x18\x02 \x01(\x08\x12\r\n\x05\x43ount\x18\x03 \x01(\x05\"\x96\x01\n\x14IncrementInt8Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x05\x12\x37\n\tCondition\x18\x05 \x01(\x0b\x32$.hydraidepbgo.IncrementInt8Condition\"f\n\x16IncrementInt8Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x05\"=\n\x15IncrementInt8Response\x12\r\n\x05Value\x18\x01 \x01(\x05\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementInt16Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x05\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementInt16Condition\"g\n\x17IncrementInt16Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x05\">\n\x16IncrementInt16Response\x12\r\n\x05Value\x18\x01 \x01(\x05\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementInt32Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x05\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementInt32Condition\"g\n\x17IncrementInt32Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x05\">\n\x16IncrementInt32Response\x12\r\n\x05Value\x18\x01 \x01(\x05\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementInt64Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x03\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementInt64Condition\"g\n\x17IncrementInt64Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x03\">\n\x16IncrementInt64Response\x12\r\n\x05Value\x18\x01 \x01(\x03\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementUint8Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\r\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementUint8Condition\"g\n\x17IncrementUint8Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\r\">\n\x16IncrementUint8Response\x12\r\n\x05Value\x18\x01 \x01(\r\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9a\x01\n\x16IncrementUint16Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\r\x12\x39\n\tCondition\x18\x05 \x01(\x0b\x32&.hydraidepbgo.IncrementUint16Condition\"h\n\x18IncrementUint16Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\r\"?\n\x17IncrementUint16Response\x12\r\n\x05Value\x18\x01 \x01(\r\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9a\x01\n\x16IncrementUint32Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\r\x12\x39\n\tCondition\x18\x05 \x01(\x0b\x32&.hydraidepbgo.IncrementUint32Condition\"h\n\x18IncrementUint32Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\r\"?\n\x17IncrementUint32Response\x12\r\n\x05Value\x18\x01 \x01(\r\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9a\x01\n\x16IncrementUint64Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x04\x12\x39\n\tCondition\x18\x05 \x01(\x0b\x32&.hydraidepbgo.IncrementUint64Condition\"h\n\x18IncrementUint64Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x04\"?\n\x17IncrementUint64Response\x12\r\n\x05Value\x18\x01 \x01(\x04\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x86\x01\n\nRelational\"x\n\x08Operator\x12\t\n\x05\x45QUAL\x10\x00\x12\x10\n\x0cGREATER_THAN\x10\x01\x12\x19\n\x15GREATER_THAN_OR_EQUAL\x10\x02\x12\r\n\tLESS_THAN\x10\x03\x12\x16\n\x12LESS_THAN_OR_EQUAL\x10\x04\x12\r\n\tNOT_EQUAL\x10\x05\"\x9c\x01\n\x17IncrementFloat32Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x02\x12:\n\tCondition\x18\x05 \x01(\x0b\x32\'.hydraidepbgo.IncrementFloat32Condition\"i\n\x19IncrementFloat32Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x02\"@\n\x18IncrementFloat32Response\x12\r\n\x05Value\x18\x01 \x01(\x02\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9c\x01\n\x17IncrementFloat64Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x01\x12:\n\tCondition\x18\x05 \x01(\x0b\x32\'.hydraidepbgo.IncrementFloat64Condition\"i\n\x19IncrementFloat64Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x01\"@\n\x18IncrementFloat64Response\x12\r\n\x05Value\x18\x01 \x01(\x01\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"+\n\x0cKeySlicePair\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0e\n\x06Values\x18\x02 \x03(\r\"u\n\x1b\x41\x64\x64ToUint32SlicePushRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x31\n\rKeySlicePairs\x18\x03 \x03(\x0b\x32\x1a.hydraidepbgo.KeySlicePair\"\x1e\n\x1c\x41\x64\x64ToUint32SlicePushResponse\"r\n\x18Uint32SliceDeleteRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x31\n\rKeySlicePairs\x18\x03 \x03(\x0b\x32\x1a.hydraidepbgo.KeySlicePair\"\x1b\n\x19Uint32SliceDeleteResponse\"J\n\x16Uint32SliceSizeRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\"\'\n\x17Uint32SliceSizeResponse\x12\x0c\n\x04Size\x18\x01 \x01(\x03\"a\n\x1eUint32SliceIsValueExistRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\r\n\x05Value\x18\x04 \x01(\r\"2\n\x1fUint32SliceIsValueExistResponse\x12\x0f\n\x07IsExist\x18\x01 \x01(\x08\":\n\x13IsSwampExistRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\'\n\x14IsSwampExistResponse\x12\x0f\n\x07IsExist\x18\x01 \x01(\x08\"E\n\x11IsKeyExistRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\"%\n\x12IsKeyExistResponse\x12\x0f\n\x07IsExist\x18\x01 \x01(\x08\x32\xf8\x15\n\x0fHydraideService\x12N\n\tHeartbeat\x12\x1e.hydraidepbgo.HeartbeatRequest\x1a\x1f.hydraidepbgo.HeartbeatResponse\"\x00\x12?\n\x04Lock\x12\x19.hydraidepbgo.LockRequest\x1a\x1a.hydraidepbgo.LockResponse\"\x00\x12\x45\n\x06Unlock\x12\x1b.hydraidepbgo.UnlockRequest\x1a\x1c.hydraidepbgo.UnlockResponse\"\x00\x12Z\n\rRegisterSwamp\x12\".hydraidepbgo.RegisterSwampRequest\x1a#.hydraidepbgo.RegisterSwampResponse\"\x00\x12`\n\x0f\x44\x65RegisterSwamp\x12$.hydraidepbgo.DeRegisterSwampRequest\x1a%.hydraidepbgo.DeRegisterSwampResponse\"\x00\x12<\n\x03Set\x12\x18.hydraidepbgo.SetRequest\x1a\x19.hydraidepbgo.SetResponse\"\x00\x12<\n\x03Get\x12\x18.hydraidepbgo.GetRequest\x1a\x19.hydraidepbgo.GetResponse\"\x00\x12\x45\n\x06GetAll\x12\x1b.hydraidepbgo.GetAllRequest\x1a\x1c.hydraidepbgo.GetAllResponse\"\x00\x12Q\n\nGetByIndex\x12\x1f.hydraidepbgo.GetByIndexRequest\x1a .hydraidepbgo.GetByIndexResponse\"\x00\x12r\n\x15ShiftExpiredTreasures\x12*.hydraidepbgo.ShiftExpiredTreasuresRequest\x1a+.hydraidepbgo.ShiftExpiredTreasuresResponse\"\x00\x12H\n\x07\x44\x65stroy\x12\x1c.hydraidepbgo.DestroyRequest\x1a\x1d.hydraidepbgo.DestroyResponse\"\x00\x12\x45\n\x06\x44\x65lete\x12\x1b.hydraidepbgo.DeleteRequest\x1a\x1c.hydraidepbgo.DeleteResponse\"\x00\x12\x42\n\x05\x43ount\x12\x1a.hydraidepbgo.CountRequest\x1a\x1b.hydraidepbgo.CountResponse\"\x00\x12W\n\x0cIsSwampExist\x12!.hydraidepbgo.IsSwampExistRequest\x1a\".hydraidepbgo.IsSwampExistResponse\"\x00\x12Q\n\nIsKeyExist\x12\x1f.hydraidepbgo.IsKeyExistRequest\x1a .hydraidepbgo.IsKeyExistResponse\"\x00\x12h\n\x11SubscribeToEvents\x12&.hydraidepbgo.SubscribeToEventsRequest\x1a\'.hydraidepbgo.SubscribeToEventsResponse\"\x00\x30\x01\x12\x62\n\x0fSubscribeToInfo\x12$.hydraidepbgo.SubscribeToInfoRequest\x1a%.hydraidepbgo.SubscribeToInfoResponse\"\x00\x30\x01\x12j\n\x0fUint32SlicePush\x12).hydraidepbgo.AddToUint32SlicePushRequest\x1a*.hydraidepbgo.AddToUint32SlicePushResponse\"\x00\x12\x66\n\x11Uint32SliceDelete\x12&.hydraidepbgo.Uint32SliceDeleteRequest\x1a\'.hydraidepbgo.Uint32SliceDeleteResponse\"\x00\x12`\n\x0fUint32SliceSize\x12$.hydraidepbgo.Uint32SliceSizeRequest\x1a%.hydraidepbgo.Uint32SliceSizeResponse\"\x00\x12x\n\x17Uint32SliceIsValueExist\x12,.hydraidepbgo.Uint32SliceIsValueExistRequest\x1a-.hydraidepbgo.Uint32SliceIsValueExistResponse\"\x00\x12Z\n\rIncrementInt8\x12\".hydraidepbgo.IncrementInt8Request\x1a#.hydraidepbgo.IncrementInt8Response\"\x00\x12]\n\x0eIncrementInt16\x12#.hydraidepbgo.IncrementInt16Request\x1a$.hydraidepbgo.IncrementInt16Response\"\x00\x12]\n\x0eIncrementInt32\x12#.hydraidepbgo.IncrementInt32Request\x1a$.hydraidepbgo.IncrementInt32Response\"\x00\x12]\n\x0eIncrementInt64\x12#.hydraidepbgo.IncrementInt64Request\x1a$.hydraidepbgo.IncrementInt64Response\"\x00\x12]\n\x0eIncrementUint8\x12#.hydraidepbgo.IncrementUint8Request\x1a$.hydraidepbgo.IncrementUint8Response\"\x00\x12`\n\x0fIncrementUint16\x12$.hydraidepbgo.IncrementUint16Request\x1a%.hydraidepbgo.IncrementUint16Response\"\x00\x12`\n\x0fIncrementUint32\x12$.hydraidepbgo.IncrementUint32Request\x1a%.hydraidepbgo.IncrementUint32Response\"\x00\x12`\n\x0fIncrementUint64\x12$.hydraidepbgo.IncrementUint64Request\x1a%.hydraidepbgo.IncrementUint64Response\"\x00\x12\x63\n\x10IncrementFloat32\x12%.hydraidepbgo.IncrementFloat32Request\x1a&.hydraidepbgo.IncrementFloat32Response\"\x00\x12\x63\n\x10IncrementFloat64\x12%.hydraidepbgo.IncrementFloat64Request\x1a&.hydraidepbgo.IncrementFloat64Response\"\x00\x42\x42Z@github.com/hydraide/hydraide/generated/hydraidepbgo;hydraidepbgob\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0ehydraide.proto\x12\x0chydraidepbgo\x1a\x1fgoogle/protobuf/timestamp.proto\" \n\x10HeartbeatRequest\x12\x0c\n\x04Ping\x18\x01 \x01(\t\"!\n\x11HeartbeatResponse\x12\x0c\n\x04Pong\x18\x01 \x01(\t\"\'\n\x0bLockRequest\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0b\n\x03TTL\x18\x02 \x01(\x03\"\x1e\n\x0cLockResponse\x12\x0e\n\x06LockID\x18\x01 \x01(\t\",\n\rUnlockRequest\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0e\n\x06LockID\x18\x02 \x01(\t\"\x10\n\x0eUnlockResponse\"5\n\x0e\x44\x65stroyRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\x11\n\x0f\x44\x65stroyResponse\"=\n\x16SubscribeToInfoRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"A\n\x17SubscribeToInfoResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x13\n\x0b\x41llElements\x18\x02 \x01(\x04\"?\n\x18SubscribeToEventsRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\x90\x02\n\x19SubscribeToEventsResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12(\n\x08Treasure\x18\x02 \x01(\x0b\x32\x16.hydraidepbgo.Treasure\x12+\n\x0bOldTreasure\x18\x03 \x01(\x0b\x32\x16.hydraidepbgo.Treasure\x12/\n\x0f\x44\x65letedTreasure\x18\x04 \x01(\x0b\x32\x16.hydraidepbgo.Treasure\x12-\n\tEventTime\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12)\n\x06Status\x18\x06 \x01(\x0e\x32\x19.hydraidepbgo.Status.Code\",\n\tSwampKeys\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x0c\n\x04Keys\x18\x02 \x03(\t\"\xc9\x01\n\x14RegisterSwampRequest\x12\x14\n\x0cSwampPattern\x18\x01 \x01(\t\x12\x16\n\x0e\x43loseAfterIdle\x18\x02 \x01(\x03\x12\x17\n\x0fIsInMemorySwamp\x18\x03 \x01(\x08\x12\x1a\n\rWriteInterval\x18\x04 \x01(\x03H\x00\x88\x01\x01\x12\x18\n\x0bMaxFileSize\x18\x05 \x01(\x03H\x01\x88\x01\x01\x12\x12\n\nValueIndex\x18\x06 \x01(\x08\x42\x10\n\x0e_WriteIntervalB\x0e\n\x0c_MaxFileSize\"\x17\n\x15RegisterSwampResponse\".\n\x16\x44\x65RegisterSwampRequest\x12\x14\n\x0cSwampPattern\x18\x01 \x01(\t\"\x19\n\x17\x44\x65RegisterSwampResponse\"8\n\nSetRequest\x12*\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1a.hydraidepbgo.SwampRequest\"\x8f\x01\n\x0cSwampRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12-\n\tKeyValues\x18\x03 \x03(\x0b\x32\x1a.hydraidepbgo.KeyValuePair\x12\x18\n\x10\x43reateIfNotExist\x18\x04 \x01(\x08\x12\x11\n\tOverwrite\x18\x05 \x01(\x08\"\x8e\x07\n\x0cKeyValuePair\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x14\n\x07Int8Val\x18\x02 \x01(\x05H\x00\x88\x01\x01\x12\x15\n\x08Int16Val\x18\x03 \x01(\x05H\x01\x88\x01\x01\x12\x15\n\x08Int32Val\x18\x04 \x01(\x05H\x02\x88\x01\x01\x12\x15\n\x08Int64Val\x18\x05 \x01(\x03H\x03\x88\x01\x01\x12\x15\n\x08Uint8Val\x18\x06 \x01(\rH\x04\x88\x01\x01\x12\x16\n\tUint16Val\x18\x07 \x01(\rH\x05\x88\x01\x01\x12\x16\n\tUint32Val\x18\x08 \x01(\rH\x06\x88\x01\x01\x12\x16\n\tUint64Val\x18\t \x01(\x04H\x07\x88\x01\x01\x12\x17\n\nFloat32Val\x18\n \x01(\x02H\x08\x88\x01\x01\x12\x17\n\nFloat64Val\x18\x0b \x01(\x01H\t\x88\x01\x01\x12\x16\n\tStringVal\x18\x0c \x01(\tH\n\x88\x01\x01\x12\x30\n\x07\x42oolVal\x18\r \x01(\x0e\x32\x1a.hydraidepbgo.Boolean.TypeH\x0b\x88\x01\x01\x12\x15\n\x08\x42ytesVal\x18\x0e \x01(\x0cH\x0c\x88\x01\x01\x12\x13\n\x0bUint32Slice\x18\x0f \x03(\r\x12\x14\n\x07VoidVal\x18\x10 \x01(\x08H\r\x88\x01\x01\x12\x32\n\tCreatedAt\x18\x11 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x0e\x88\x01\x01\x12\x16\n\tCreatedBy\x18\x12 \x01(\tH\x0f\x88\x01\x01\x12\x32\n\tUpdatedAt\x18\x13 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x10\x88\x01\x01\x12\x16\n\tUpdatedBy\x18\x14 \x01(\tH\x11\x88\x01\x01\x12\x32\n\tExpiredAt\x18\x15 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x12\x88\x01\x01\x12\x1a\n\rSchemaVersion\x18\x16 \x01(\rH\x13\x88\x01\x01\x42\n\n\x08_Int8ValB\x0b\n\t_Int16ValB\x0b\n\t_Int32ValB\x0b\n\t_Int64ValB\x0b\n\t_Uint8ValB\x0c\n\n_Uint16ValB\x0c\n\n_Uint32ValB\x0c\n\n_Uint64ValB\r\n\x0b_Float32ValB\r\n\x0b_Float64ValB\x0c\n\n_StringValB\n\n\x08_BoolValB\x0b\n\t_BytesValB\n\n\x08_VoidValB\x0c\n\n_CreatedAtB\x0c\n\n_CreatedByB\x0c\n\n_UpdatedAtB\x0c\n\n_UpdatedByB\x0c\n\n_ExpiredAtB\x10\n\x0e_SchemaVersion\":\n\x0bSetResponse\x12+\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1b.hydraidepbgo.SwampResponse\"\xe3\x01\n\rSwampResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x34\n\x0fKeysAndStatuses\x18\x02 \x03(\x0b\x32\x1b.hydraidepbgo.KeyStatusPair\x12?\n\tErrorCode\x18\x03 \x01(\x0e\x32\'.hydraidepbgo.SwampResponse.ErrCodeEnumH\x00\x88\x01\x01\":\n\x0b\x45rrCodeEnum\x12\x14\n\x10\x43\x61nNotBeExecuted\x10\x00\x12\x15\n\x11SwampDoesNotExist\x10\x01\x42\x0c\n\n_ErrorCode\"G\n\rKeyStatusPair\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12)\n\x06Status\x18\x02 \x01(\x0e\x32\x19.hydraidepbgo.Status.Code\"W\n\x06Status\"M\n\x04\x43ode\x12\r\n\tNOT_FOUND\x10\x00\x12\x07\n\x03NEW\x10\x01\x12\x0b\n\x07UPDATED\x10\x02\x12\x0b\n\x07\x44\x45LETED\x10\x03\x12\x13\n\x0fNOTHING_CHANGED\x10\x04\"4\n\nGetRequest\x12&\n\x06Swamps\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.GetSwamp\"=\n\x08GetSwamp\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0c\n\x04Keys\x18\x03 \x03(\t\"=\n\x0bGetResponse\x12.\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1e.hydraidepbgo.GetSwampResponse\"a\n\x10GetSwampResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x0f\n\x07IsExist\x18\x02 \x01(\x08\x12)\n\tTreasures\x18\x03 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"4\n\rGetAllRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\";\n\x0eGetAllResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"T\n\x1cShiftExpiredTreasuresRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0f\n\x07HowMany\x18\x03 \x01(\x05\"J\n\x1dShiftExpiredTreasuresResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"\xf9\x06\n\x08Treasure\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0f\n\x07IsExist\x18\x02 \x01(\x08\x12\x14\n\x07Int8Val\x18\x03 \x01(\x05H\x00\x88\x01\x01\x12\x15\n\x08Int16Val\x18\x04 \x01(\x05H\x01\x88\x01\x01\x12\x15\n\x08Int32Val\x18\x05 \x01(\x05H\x02\x88\x01\x01\x12\x15\n\x08Int64Val\x18\x06 \x01(\x03H\x03\x88\x01\x01\x12\x15\n\x08Uint8Val\x18\x07 \x01(\rH\x04\x88\x01\x01\x12\x16\n\tUint16Val\x18\x08 \x01(\rH\x05\x88\x01\x01\x12\x16\n\tUint32Val\x18\t \x01(\rH\x06\x88\x01\x01\x12\x16\n\tUint64Val\x18\n \x01(\x04H\x07\x88\x01\x01\x12\x17\n\nFloat32Val\x18\x0b \x01(\x02H\x08\x88\x01\x01\x12\x17\n\nFloat64Val\x18\x0c \x01(\x01H\t\x88\x01\x01\x12\x16\n\tStringVal\x18\r \x01(\tH\n\x88\x01\x01\x12\x30\n\x07\x42oolVal\x18\x0e \x01(\x0e\x32\x1a.hydraidepbgo.Boolean.TypeH\x0b\x88\x01\x01\x12\x15\n\x08\x42ytesVal\x18\x0f \x01(\x0cH\x0c\x88\x01\x01\x12\x13\n\x0bUint32Slice\x18\x10 \x03(\r\x12\x32\n\tCreatedAt\x18\x11 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\r\x88\x01\x01\x12\x16\n\tCreatedBy\x18\x12 \x01(\tH\x0e\x88\x01\x01\x12\x32\n\tUpdatedAt\x18\x13 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x0f\x88\x01\x01\x12\x16\n\tUpdatedBy\x18\x14 \x01(\tH\x10\x88\x01\x01\x12\x32\n\tExpiredAt\x18\x15 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x11\x88\x01\x01\x12\x1a\n\rSchemaVersion\x18\x16 \x01(\rH\x12\x88\x01\x01\x42\n\n\x08_Int8ValB\x0b\n\t_Int16ValB\x0b\n\t_Int32ValB\x0b\n\t_Int64ValB\x0b\n\t_Uint8ValB\x0c\n\n_Uint16ValB\x0c\n\n_Uint32ValB\x0c\n\n_Uint64ValB\r\n\x0b_Float32ValB\r\n\x0b_Float64ValB\x0c\n\n_StringValB\n\n\x08_BoolValB\x0b\n\t_BytesValB\x0c\n\n_CreatedAtB\x0c\n\n_CreatedByB\x0c\n\n_UpdatedAtB\x0c\n\n_UpdatedByB\x0c\n\n_ExpiredAtB\x10\n\x0e_SchemaVersion\"&\n\x07\x42oolean\"\x1b\n\x04Type\x12\x08\n\x04TRUE\x10\x00\x12\t\n\x05\x46\x41LSE\x10\x01\"\xdf\x01\n\x11GetByIndexRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12/\n\tIndexType\x18\x03 \x01(\x0e\x32\x1c.hydraidepbgo.IndexType.Type\x12/\n\tOrderType\x18\x04 \x01(\x0e\x32\x1c.hydraidepbgo.OrderType.Type\x12\x0c\n\x04\x46rom\x18\x05 \x01(\x05\x12\r\n\x05Limit\x18\x06 \x01(\x05\x12\x17\n\nFilterExpr\x18\x07 \x01(\tH\x00\x88\x01\x01\x42\r\n\x0b_FilterExpr\"\x98\x02\n\tIndexType\"\x8a\x02\n\x04Type\x12\x07\n\x03KEY\x10\x00\x12\x13\n\x0f\x45XPIRATION_TIME\x10\x01\x12\x11\n\rCREATION_TIME\x10\x02\x12\x0f\n\x0bUPDATE_TIME\x10\x03\x12\x0e\n\nVALUE_INT8\x10\x04\x12\x0f\n\x0bVALUE_INT16\x10\x05\x12\x0f\n\x0bVALUE_INT32\x10\x06\x12\x0f\n\x0bVALUE_INT64\x10\x07\x12\x0f\n\x0bVALUE_UINT8\x10\x08\x12\x10\n\x0cVALUE_UINT16\x10\t\x12\x10\n\x0cVALUE_UINT32\x10\n\x12\x10\n\x0cVALUE_UINT64\x10\x0b\x12\x11\n\rVALUE_FLOAT32\x10\x0c\x12\x11\n\rVALUE_FLOAT64\x10\r\x12\x10\n\x0cVALUE_STRING\x10\x0e\"&\n\tOrderType\"\x19\n\x04Type\x12\x07\n\x03\x41SC\x10\x00\x12\x08\n\x04\x44\x45SC\x10\x01\"?\n\x12GetByIndexResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"c\n\x11GetByValueRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12)\n\x05Value\x18\x03 \x01(\x0b\x32\x1a.hydraidepbgo.KeyValuePair\"?\n\x12GetByValueResponse\x12)\n\tTreasures\x18\x01 \x03(\x0b\x32\x16.hydraidepbgo.Treasure\"\x86\x01\n\rDeleteRequest\x12\x35\n\x06Swamps\x18\x01 \x03(\x0b\x32%.hydraidepbgo.DeleteRequest.SwampKeys\x1a>\n\tSwampKeys\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0c\n\x04Keys\x18\x03 \x03(\t\"\xc0\x02\n\x0e\x44\x65leteResponse\x12\x43\n\tResponses\x18\x01 \x03(\x0b\x32\x30.hydraidepbgo.DeleteResponse.SwampDeleteResponse\x1a\xe8\x01\n\x13SwampDeleteResponse\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12V\n\tErrorCode\x18\x02 \x01(\x0e\x32>.hydraidepbgo.DeleteResponse.SwampDeleteResponse.ErrorCodeEnumH\x00\x88\x01\x01\x12\x30\n\x0bKeyStatuses\x18\x03 \x03(\x0b\x32\x1b.hydraidepbgo.KeyStatusPair\"&\n\rErrorCodeEnum\x12\x15\n\x11SwampDoesNotExist\x10\x00\x42\x0c\n\n_ErrorCode\"\x82\x01\n\x0c\x43ountRequest\x12:\n\x06Swamps\x18\x01 \x03(\x0b\x32*.hydraidepbgo.CountRequest.SwampIdentifier\x1a\x36\n\x0fSwampIdentifier\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"9\n\rCountResponse\x12(\n\x06Swamps\x18\x01 \x03(\x0b\x32\x18.hydraidepbgo.CountSwamp\"?\n\nCountSwamp\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x0f\n\x07IsExist\x18\x02 \x01(\x08\x12\r\n\x05\x43ount\x18\x03 \x01(\x05\"\x96\x01\n\x14IncrementInt8Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x05\x12\x37\n\tCondition\x18\x05 \x01(\x0b\x32$.hydraidepbgo.IncrementInt8Condition\"f\n\x16IncrementInt8Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x05\"=\n\x15IncrementInt8Response\x12\r\n\x05Value\x18\x01 \x01(\x05\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementInt16Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x05\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementInt16Condition\"g\n\x17IncrementInt16Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x05\">\n\x16IncrementInt16Response\x12\r\n\x05Value\x18\x01 \x01(\x05\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementInt32Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x05\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementInt32Condition\"g\n\x17IncrementInt32Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x05\">\n\x16IncrementInt32Response\x12\r\n\x05Value\x18\x01 \x01(\x05\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementInt64Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x03\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementInt64Condition\"g\n\x17IncrementInt64Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x03\">\n\x16IncrementInt64Response\x12\r\n\x05Value\x18\x01 \x01(\x03\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x98\x01\n\x15IncrementUint8Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\r\x12\x38\n\tCondition\x18\x05 \x01(\x0b\x32%.hydraidepbgo.IncrementUint8Condition\"g\n\x17IncrementUint8Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\r\">\n\x16IncrementUint8Response\x12\r\n\x05Value\x18\x01 \x01(\r\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9a\x01\n\x16IncrementUint16Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\r\x12\x39\n\tCondition\x18\x05 \x01(\x0b\x32&.hydraidepbgo.IncrementUint16Condition\"h\n\x18IncrementUint16Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\r\"?\n\x17IncrementUint16Response\x12\r\n\x05Value\x18\x01 \x01(\r\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9a\x01\n\x16IncrementUint32Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\r\x12\x39\n\tCondition\x18\x05 \x01(\x0b\x32&.hydraidepbgo.IncrementUint32Condition\"h\n\x18IncrementUint32Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\r\"?\n\x17IncrementUint32Response\x12\r\n\x05Value\x18\x01 \x01(\r\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9a\x01\n\x16IncrementUint64Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x04\x12\x39\n\tCondition\x18\x05 \x01(\x0b\x32&.hydraidepbgo.IncrementUint64Condition\"h\n\x18IncrementUint64Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x04\"?\n\x17IncrementUint64Response\x12\r\n\x05Value\x18\x01 \x01(\x04\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x86\x01\n\nRelational\"x\n\x08Operator\x12\t\n\x05\x45QUAL\x10\x00\x12\x10\n\x0cGREATER_THAN\x10\x01\x12\x19\n\x15GREATER_THAN_OR_EQUAL\x10\x02\x12\r\n\tLESS_THAN\x10\x03\x12\x16\n\x12LESS_THAN_OR_EQUAL\x10\x04\x12\r\n\tNOT_EQUAL\x10\x05\"\x9c\x01\n\x17IncrementFloat32Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x02\x12:\n\tCondition\x18\x05 \x01(\x0b\x32\'.hydraidepbgo.IncrementFloat32Condition\"i\n\x19IncrementFloat32Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x02\"@\n\x18IncrementFloat32Response\x12\r\n\x05Value\x18\x01 \x01(\x02\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"\x9c\x01\n\x17IncrementFloat64Request\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\x13\n\x0bIncrementBy\x18\x04 \x01(\x01\x12:\n\tCondition\x18\x05 \x01(\x0b\x32\'.hydraidepbgo.IncrementFloat64Condition\"i\n\x19IncrementFloat64Condition\x12=\n\x12RelationalOperator\x18\x01 \x01(\x0e\x32!.hydraidepbgo.Relational.Operator\x12\r\n\x05Value\x18\x02 \x01(\x01\"@\n\x18IncrementFloat64Response\x12\r\n\x05Value\x18\x01 \x01(\x01\x12\x15\n\rIsIncremented\x18\x02 \x01(\x08\"+\n\x0cKeySlicePair\x12\x0b\n\x03Key\x18\x01 \x01(\t\x12\x0e\n\x06Values\x18\x02 \x03(\r\"u\n\x1b\x41\x64\x64ToUint32SlicePushRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x31\n\rKeySlicePairs\x18\x03 \x03(\x0b\x32\x1a.hydraidepbgo.KeySlicePair\"\x1e\n\x1c\x41\x64\x64ToUint32SlicePushResponse\"r\n\x18Uint32SliceDeleteRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x31\n\rKeySlicePairs\x18\x03 \x03(\x0b\x32\x1a.hydraidepbgo.KeySlicePair\"\x1b\n\x19Uint32SliceDeleteResponse\"J\n\x16Uint32SliceSizeRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\"\'\n\x17Uint32SliceSizeResponse\x12\x0c\n\x04Size\x18\x01 \x01(\x03\"a\n\x1eUint32SliceIsValueExistRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\r\n\x05Value\x18\x04 \x01(\r\"2\n\x1fUint32SliceIsValueExistResponse\x12\x0f\n\x07IsExist\x18\x01 \x01(\x08\":\n\x13IsSwampExistRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\'\n\x14IsSwampExistResponse\x12\x0f\n\x07IsExist\x18\x01 \x01(\x08\"B\n\x11\x45xistsManyRequest\x12-\n\x06Swamps\x18\x01 \x03(\x0b\x32\x1d.hydraidepbgo.ExistsManySwamp\"6\n\x0f\x45xistsManySwamp\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"E\n\x12\x45xistsManyResponse\x12/\n\x07Results\x18\x01 \x03(\x0b\x32\x1e.hydraidepbgo.ExistsManyResult\"=\n\x10\x45xistsManyResult\x12\x11\n\tSwampName\x18\x01 \x01(\t\x12\x16\n\x0e\x45xistingSwamps\x18\x02 \x03(\t\"E\n\x11IsKeyExistRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\"%\n\x12IsKeyExistResponse\x12\x0f\n\x07IsExist\x18\x01 \x01(\x08\"\xb2\x02\n\x0b\x45rrorReason\"\xa2\x02\n\x06Reason\x12\x0f\n\x0bUNSPECIFIED\x10\x00\x12\x13\n\x0fSWAMP_NOT_FOUND\x10\x01\x12\x11\n\rKEY_NOT_FOUND\x10\x02\x12\x0e\n\nKEY_EXISTS\x10\x03\x12\x15\n\x11\x43ONDITION_NOT_MET\x10\x04\x12\x12\n\x0eQUOTA_EXCEEDED\x10\x05\x12\x14\n\x10INVALID_ARGUMENT\x10\x06\x12\x1d\n\x19INVALID_FILTER_EXPRESSION\x10\x07\x12\x14\n\x10WRONG_VALUE_TYPE\x10\x08\x12\x1b\n\x17VALUE_INDEX_NOT_ENABLED\x10\t\x12\x12\n\x0eLOCK_NOT_FOUND\x10\n\x12\x1a\n\x16LOCK_DEADLINE_EXCEEDED\x10\x0b\x12\x0c\n\x08INTERNAL\x10\x0c\"\\\n\x19SetSwampAnnotationRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\x12\x0b\n\x03Key\x18\x03 \x01(\t\x12\r\n\x05Value\x18\x04 \x01(\t\"\x1c\n\x1aSetSwampAnnotationResponse\"A\n\x1aGetSwampAnnotationsRequest\x12\x10\n\x08IslandID\x18\x01 \x01(\x04\x12\x11\n\tSwampName\x18\x02 \x01(\t\"\xa2\x01\n\x1bGetSwampAnnotationsResponse\x12O\n\x0b\x41nnotations\x18\x01 \x03(\x0b\x32:.hydraidepbgo.GetSwampAnnotationsResponse.AnnotationsEntry\x1a\x32\n\x10\x41nnotationsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x32\xf7\x18\n\x0fHydraideService\x12N\n\tHeartbeat\x12\x1e.hydraidepbgo.HeartbeatRequest\x1a\x1f.hydraidepbgo.HeartbeatResponse\"\x00\x12?\n\x04Lock\x12\x19.hydraidepbgo.LockRequest\x1a\x1a.hydraidepbgo.LockResponse\"\x00\x12\x45\n\x06Unlock\x12\x1b.hydraidepbgo.UnlockRequest\x1a\x1c.hydraidepbgo.UnlockResponse\"\x00\x12Z\n\rRegisterSwamp\x12\".hydraidepbgo.RegisterSwampRequest\x1a#.hydraidepbgo.RegisterSwampResponse\"\x00\x12`\n\x0f\x44\x65RegisterSwamp\x12$.hydraidepbgo.DeRegisterSwampRequest\x1a%.hydraidepbgo.DeRegisterSwampResponse\"\x00\x12<\n\x03Set\x12\x18.hydraidepbgo.SetRequest\x1a\x19.hydraidepbgo.SetResponse\"\x00\x12<\n\x03Get\x12\x18.hydraidepbgo.GetRequest\x1a\x19.hydraidepbgo.GetResponse\"\x00\x12\x45\n\x06GetAll\x12\x1b.hydraidepbgo.GetAllRequest\x1a\x1c.hydraidepbgo.GetAllResponse\"\x00\x12Q\n\nGetByIndex\x12\x1f.hydraidepbgo.GetByIndexRequest\x1a .hydraidepbgo.GetByIndexResponse\"\x00\x12Q\n\nGetByValue\x12\x1f.hydraidepbgo.GetByValueRequest\x1a .hydraidepbgo.GetByValueResponse\"\x00\x12r\n\x15ShiftExpiredTreasures\x12*.hydraidepbgo.ShiftExpiredTreasuresRequest\x1a+.hydraidepbgo.ShiftExpiredTreasuresResponse\"\x00\x12H\n\x07\x44\x65stroy\x12\x1c.hydraidepbgo.DestroyRequest\x1a\x1d.hydraidepbgo.DestroyResponse\"\x00\x12\x45\n\x06\x44\x65lete\x12\x1b.hydraidepbgo.DeleteRequest\x1a\x1c.hydraidepbgo.DeleteResponse\"\x00\x12\x42\n\x05\x43ount\x12\x1a.hydraidepbgo.CountRequest\x1a\x1b.hydraidepbgo.CountResponse\"\x00\x12W\n\x0cIsSwampExist\x12!.hydraidepbgo.IsSwampExistRequest\x1a\".hydraidepbgo.IsSwampExistResponse\"\x00\x12Q\n\nExistsMany\x12\x1f.hydraidepbgo.ExistsManyRequest\x1a .hydraidepbgo.ExistsManyResponse\"\x00\x12Q\n\nIsKeyExist\x12\x1f.hydraidepbgo.IsKeyExistRequest\x1a .hydraidepbgo.IsKeyExistResponse\"\x00\x12h\n\x11SubscribeToEvents\x12&.hydraidepbgo.SubscribeToEventsRequest\x1a\'.hydraidepbgo.SubscribeToEventsResponse\"\x00\x30\x01\x12\x62\n\x0fSubscribeToInfo\x12$.hydraidepbgo.SubscribeToInfoRequest\x1a%.hydraidepbgo.SubscribeToInfoResponse\"\x00\x30\x01\x12j\n\x0fUint32SlicePush\x12).hydraidepbgo.AddToUint32SlicePushRequest\x1a*.hydraidepbgo.AddToUint32SlicePushResponse\"\x00\x12\x66\n\x11Uint32SliceDelete\x12&.hydraidepbgo.Uint32SliceDeleteRequest\x1a\'.hydraidepbgo.Uint32SliceDeleteResponse\"\x00\x12`\n\x0fUint32SliceSize\x12$.hydraidepbgo.Uint32SliceSizeRequest\x1a%.hydraidepbgo.Uint32SliceSizeResponse\"\x00\x12x\n\x17Uint32SliceIsValueExist\x12,.hydraidepbgo.Uint32SliceIsValueExistRequest\x1a-.hydraidepbgo.Uint32SliceIsValueExistResponse\"\x00\x12Z\n\rIncrementInt8\x12\".hydraidepbgo.IncrementInt8Request\x1a#.hydraidepbgo.IncrementInt8Response\"\x00\x12]\n\x0eIncrementInt16\x12#.hydraidepbgo.IncrementInt16Request\x1a$.hydraidepbgo.IncrementInt16Response\"\x00\x12]\n\x0eIncrementInt32\x12#.hydraidepbgo.IncrementInt32Request\x1a$.hydraidepbgo.IncrementInt32Response\"\x00\x12]\n\x0eIncrementInt64\x12#.hydraidepbgo.IncrementInt64Request\x1a$.hydraidepbgo.IncrementInt64Response\"\x00\x12]\n\x0eIncrementUint8\x12#.hydraidepbgo.IncrementUint8Request\x1a$.hydraidepbgo.IncrementUint8Response\"\x00\x12`\n\x0fIncrementUint16\x12$.hydraidepbgo.IncrementUint16Request\x1a%.hydraidepbgo.IncrementUint16Response\"\x00\x12`\n\x0fIncrementUint32\x12$.hydraidepbgo.IncrementUint32Request\x1a%.hydraidepbgo.IncrementUint32Response\"\x00\x12`\n\x0fIncrementUint64\x12$.hydraidepbgo.IncrementUint64Request\x1a%.hydraidepbgo.IncrementUint64Response\"\x00\x12\x63\n\x10IncrementFloat32\x12%.hydraidepbgo.IncrementFloat32Request\x1a&.hydraidepbgo.IncrementFloat32Response\"\x00\x12\x63\n\x10IncrementFloat64\x12%.hydraidepbgo.IncrementFloat64Request\x1a&.hydraidepbgo.IncrementFloat64Response\"\x00\x12i\n\x12SetSwampAnnotation\x12\'.hydraidepbgo.SetSwampAnnotationRequest\x1a(.hydraidepbgo.SetSwampAnnotationResponse\"\x00\x12l\n\x13GetSwampAnnotations\x12(.hydraidepbgo.GetSwampAnnotationsRequest\x1a).hydraidepbgo.GetSwampAnnotationsResponse\"\x00\x42\x42Z@github.com/hydraide/hydraide/generated/hydraidepbgo;hydraidepbgob\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -33,6 +33,8 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'hydraide_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'Z@github.com/hydraide/hydraide/generated/hydraidepbgo;hydraidepbgo'
+  _globals['_GETSWAMPANNOTATIONSRESPONSE_ANNOTATIONSENTRY']._loaded_options = None
+  _globals['_GETSWAMPANNOTATIONSRESPONSE_ANNOTATIONSENTRY']._serialized_options = b'8\001'
   _globals['_HEARTBEATREQUEST']._serialized_start=65
   _globals['_HEARTBEATREQUEST']._serialized_end=97
   _globals['_HEARTBEATRESPONSE']._serialized_start=99
@@ -60,173 +62,199 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_SWAMPKEYS']._serialized_start=815
   _globals['_SWAMPKEYS']._serialized_end=859
   _globals['_REGISTERSWAMPREQUEST']._serialized_start=862
-  _globals['_REGISTERSWAMPREQUEST']._serialized_end=1043
-  _globals['_REGISTERSWAMPRESPONSE']._serialized_start=1045
-  _globals['_REGISTERSWAMPRESPONSE']._serialized_end=1068
-  _globals['_DEREGISTERSWAMPREQUEST']._serialized_start=1070
-  _globals['_DEREGISTERSWAMPREQUEST']._serialized_end=1116
-  _globals['_DEREGISTERSWAMPRESPONSE']._serialized_start=1118
-  _globals['_DEREGISTERSWAMPRESPONSE']._serialized_end=1143
-  _globals['_SETREQUEST']._serialized_start=1145
-  _globals['_SETREQUEST']._serialized_end=1201
-  _globals['_SWAMPREQUEST']._serialized_start=1204
-  _globals['_SWAMPREQUEST']._serialized_end=1347
-  _globals['_KEYVALUEPAIR']._serialized_start=1350
-  _globals['_KEYVALUEPAIR']._serialized_end=2214
-  _globals['_SETRESPONSE']._serialized_start=2216
-  _globals['_SETRESPONSE']._serialized_end=2274
-  _globals['_SWAMPRESPONSE']._serialized_start=2277
-  _globals['_SWAMPRESPONSE']._serialized_end=2504
-  _globals['_SWAMPRESPONSE_ERRCODEENUM']._serialized_start=2432
-  _globals['_SWAMPRESPONSE_ERRCODEENUM']._serialized_end=2490
-  _globals['_KEYSTATUSPAIR']._serialized_start=2506
-  _globals['_KEYSTATUSPAIR']._serialized_end=2577
-  _globals['_STATUS']._serialized_start=2579
-  _globals['_STATUS']._serialized_end=2666
-  _globals['_STATUS_CODE']._serialized_start=2589
-  _globals['_STATUS_CODE']._serialized_end=2666
-  _globals['_GETREQUEST']._serialized_start=2668
-  _globals['_GETREQUEST']._serialized_end=2720
-  _globals['_GETSWAMP']._serialized_start=2722
-  _globals['_GETSWAMP']._serialized_end=2783
-  _globals['_GETRESPONSE']._serialized_start=2785
-  _globals['_GETRESPONSE']._serialized_end=2846
-  _globals['_GETSWAMPRESPONSE']._serialized_start=2848
-  _globals['_GETSWAMPRESPONSE']._serialized_end=2945
-  _globals['_GETALLREQUEST']._serialized_start=2947
-  _globals['_GETALLREQUEST']._serialized_end=2999
-  _globals['_GETALLRESPONSE']._serialized_start=3001
-  _globals['_GETALLRESPONSE']._serialized_end=3060
-  _globals['_SHIFTEXPIREDTREASURESREQUEST']._serialized_start=3062
-  _globals['_SHIFTEXPIREDTREASURESREQUEST']._serialized_end=3146
-  _globals['_SHIFTEXPIREDTREASURESRESPONSE']._serialized_start=3148
-  _globals['_SHIFTEXPIREDTREASURESRESPONSE']._serialized_end=3222
-  _globals['_TREASURE']._serialized_start=3225
-  _globals['_TREASURE']._serialized_end=4068
-  _globals['_BOOLEAN']._serialized_start=4070
-  _globals['_BOOLEAN']._serialized_end=4108
-  _globals['_BOOLEAN_TYPE']._serialized_start=4081
-  _globals['_BOOLEAN_TYPE']._serialized_end=4108
-  _globals['_GETBYINDEXREQUEST']._serialized_start=4111
-  _globals['_GETBYINDEXREQUEST']._serialized_end=4294
-  _globals['_INDEXTYPE']._serialized_start=4297
-  _globals['_INDEXTYPE']._serialized_end=4577
-  _globals['_INDEXTYPE_TYPE']._serialized_start=4311
-  _globals['_INDEXTYPE_TYPE']._serialized_end=4577
-  _globals['_ORDERTYPE']._serialized_start=4579
-  _globals['_ORDERTYPE']._serialized_end=4617
-  _globals['_ORDERTYPE_TYPE']._serialized_start=4592
-  _globals['_ORDERTYPE_TYPE']._serialized_end=4617
-  _globals['_GETBYINDEXRESPONSE']._serialized_start=4619
-  _globals['_GETBYINDEXRESPONSE']._serialized_end=4682
-  _globals['_DELETEREQUEST']._serialized_start=4685
-  _globals['_DELETEREQUEST']._serialized_end=4819
-  _globals['_DELETEREQUEST_SWAMPKEYS']._serialized_start=4757
-  _globals['_DELETEREQUEST_SWAMPKEYS']._serialized_end=4819
-  _globals['_DELETERESPONSE']._serialized_start=4822
-  _globals['_DELETERESPONSE']._serialized_end=5142
-  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE']._serialized_start=4910
-  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE']._serialized_end=5142
-  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE_ERRORCODEENUM']._serialized_start=5090
-  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE_ERRORCODEENUM']._serialized_end=5128
-  _globals['_COUNTREQUEST']._serialized_start=5145
-  _globals['_COUNTREQUEST']._serialized_end=5275
-  _globals['_COUNTREQUEST_SWAMPIDENTIFIER']._serialized_start=5221
-  _globals['_COUNTREQUEST_SWAMPIDENTIFIER']._serialized_end=5275
-  _globals['_COUNTRESPONSE']._serialized_start=5277
-  _globals['_COUNTRESPONSE']._serialized_end=5334
-  _globals['_COUNTSWAMP']._serialized_start=5336
-  _globals['_COUNTSWAMP']._serialized_end=5399
-  _globals['_INCREMENTINT8REQUEST']._serialized_start=5402
-  _globals['_INCREMENTINT8REQUEST']._serialized_end=5552
-  _globals['_INCREMENTINT8CONDITION']._serialized_start=5554
-  _globals['_INCREMENTINT8CONDITION']._serialized_end=5656
-  _globals['_INCREMENTINT8RESPONSE']._serialized_start=5658
-  _globals['_INCREMENTINT8RESPONSE']._serialized_end=5719
-  _globals['_INCREMENTINT16REQUEST']._serialized_start=5722
-  _globals['_INCREMENTINT16REQUEST']._serialized_end=5874
-  _globals['_INCREMENTINT16CONDITION']._serialized_start=5876
-  _globals['_INCREMENTINT16CONDITION']._serialized_end=5979
-  _globals['_INCREMENTINT16RESPONSE']._serialized_start=5981
-  _globals['_INCREMENTINT16RESPONSE']._serialized_end=6043
-  _globals['_INCREMENTINT32REQUEST']._serialized_start=6046
-  _globals['_INCREMENTINT32REQUEST']._serialized_end=6198
-  _globals['_INCREMENTINT32CONDITION']._serialized_start=6200
-  _globals['_INCREMENTINT32CONDITION']._serialized_end=6303
-  _globals['_INCREMENTINT32RESPONSE']._serialized_start=6305
-  _globals['_INCREMENTINT32RESPONSE']._serialized_end=6367
-  _globals['_INCREMENTINT64REQUEST']._serialized_start=6370
-  _globals['_INCREMENTINT64REQUEST']._serialized_end=6522
-  _globals['_INCREMENTINT64CONDITION']._serialized_start=6524
-  _globals['_INCREMENTINT64CONDITION']._serialized_end=6627
-  _globals['_INCREMENTINT64RESPONSE']._serialized_start=6629
-  _globals['_INCREMENTINT64RESPONSE']._serialized_end=6691
-  _globals['_INCREMENTUINT8REQUEST']._serialized_start=6694
-  _globals['_INCREMENTUINT8REQUEST']._serialized_end=6846
-  _globals['_INCREMENTUINT8CONDITION']._serialized_start=6848
-  _globals['_INCREMENTUINT8CONDITION']._serialized_end=6951
-  _globals['_INCREMENTUINT8RESPONSE']._serialized_start=6953
-  _globals['_INCREMENTUINT8RESPONSE']._serialized_end=7015
-  _globals['_INCREMENTUINT16REQUEST']._serialized_start=7018
-  _globals['_INCREMENTUINT16REQUEST']._serialized_end=7172
-  _globals['_INCREMENTUINT16CONDITION']._serialized_start=7174
-  _globals['_INCREMENTUINT16CONDITION']._serialized_end=7278
-  _globals['_INCREMENTUINT16RESPONSE']._serialized_start=7280
-  _globals['_INCREMENTUINT16RESPONSE']._serialized_end=7343
-  _globals['_INCREMENTUINT32REQUEST']._serialized_start=7346
-  _globals['_INCREMENTUINT32REQUEST']._serialized_end=7500
-  _globals['_INCREMENTUINT32CONDITION']._serialized_start=7502
-  _globals['_INCREMENTUINT32CONDITION']._serialized_end=7606
-  _globals['_INCREMENTUINT32RESPONSE']._serialized_start=7608
-  _globals['_INCREMENTUINT32RESPONSE']._serialized_end=7671
-  _globals['_INCREMENTUINT64REQUEST']._serialized_start=7674
-  _globals['_INCREMENTUINT64REQUEST']._serialized_end=7828
-  _globals['_INCREMENTUINT64CONDITION']._serialized_start=7830
-  _globals['_INCREMENTUINT64CONDITION']._serialized_end=7934
-  _globals['_INCREMENTUINT64RESPONSE']._serialized_start=7936
-  _globals['_INCREMENTUINT64RESPONSE']._serialized_end=7999
-  _globals['_RELATIONAL']._serialized_start=8002
-  _globals['_RELATIONAL']._serialized_end=8136
-  _globals['_RELATIONAL_OPERATOR']._serialized_start=8016
-  _globals['_RELATIONAL_OPERATOR']._serialized_end=8136
-  _globals['_INCREMENTFLOAT32REQUEST']._serialized_start=8139
-  _globals['_INCREMENTFLOAT32REQUEST']._serialized_end=8295
-  _globals['_INCREMENTFLOAT32CONDITION']._serialized_start=8297
-  _globals['_INCREMENTFLOAT32CONDITION']._serialized_end=8402
-  _globals['_INCREMENTFLOAT32RESPONSE']._serialized_start=8404
-  _globals['_INCREMENTFLOAT32RESPONSE']._serialized_end=8468
-  _globals['_INCREMENTFLOAT64REQUEST']._serialized_start=8471
-  _globals['_INCREMENTFLOAT64REQUEST']._serialized_end=8627
-  _globals['_INCREMENTFLOAT64CONDITION']._serialized_start=8629
-  _globals['_INCREMENTFLOAT64CONDITION']._serialized_end=8734
-  _globals['_INCREMENTFLOAT64RESPONSE']._serialized_start=8736
-  _globals['_INCREMENTFLOAT64RESPONSE']._serialized_end=8800
-  _globals['_KEYSLICEPAIR']._serialized_start=8802
-  _globals['_KEYSLICEPAIR']._serialized_end=8845
-  _globals['_ADDTOUINT32SLICEPUSHREQUEST']._serialized_start=8847
-  _globals['_ADDTOUINT32SLICEPUSHREQUEST']._serialized_end=8964
-  _globals['_ADDTOUINT32SLICEPUSHRESPONSE']._serialized_start=8966
-  _globals['_ADDTOUINT32SLICEPUSHRESPONSE']._serialized_end=8996
-  _globals['_UINT32SLICEDELETEREQUEST']._serialized_start=8998
-  _globals['_UINT32SLICEDELETEREQUEST']._serialized_end=9112
-  _globals['_UINT32SLICEDELETERESPONSE']._serialized_start=9114
-  _globals['_UINT32SLICEDELETERESPONSE']._serialized_end=9141
-  _globals['_UINT32SLICESIZEREQUEST']._serialized_start=9143
-  _globals['_UINT32SLICESIZEREQUEST']._serialized_end=9217
-  _globals['_UINT32SLICESIZERESPONSE']._serialized_start=9219
-  _globals['_UINT32SLICESIZERESPONSE']._serialized_end=9258
-  _globals['_UINT32SLICEISVALUEEXISTREQUEST']._serialized_start=9260
-  _globals['_UINT32SLICEISVALUEEXISTREQUEST']._serialized_end=9357
-  _globals['_UINT32SLICEISVALUEEXISTRESPONSE']._serialized_start=9359
-  _globals['_UINT32SLICEISVALUEEXISTRESPONSE']._serialized_end=9409
-  _globals['_ISSWAMPEXISTREQUEST']._serialized_start=9411
-  _globals['_ISSWAMPEXISTREQUEST']._serialized_end=9469
-  _globals['_ISSWAMPEXISTRESPONSE']._serialized_start=9471
-  _globals['_ISSWAMPEXISTRESPONSE']._serialized_end=9510
-  _globals['_ISKEYEXISTREQUEST']._serialized_start=9512
-  _globals['_ISKEYEXISTREQUEST']._serialized_end=9581
-  _globals['_ISKEYEXISTRESPONSE']._serialized_start=9583
-  _globals['_ISKEYEXISTRESPONSE']._serialized_end=9620
-  _globals['_HYDRAIDESERVICE']._serialized_start=9623
-  _globals['_HYDRAIDESERVICE']._serialized_end=12431
+  _globals['_REGISTERSWAMPREQUEST']._serialized_end=1063
+  _globals['_REGISTERSWAMPRESPONSE']._serialized_start=1065
+  _globals['_REGISTERSWAMPRESPONSE']._serialized_end=1088
+  _globals['_DEREGISTERSWAMPREQUEST']._serialized_start=1090
+  _globals['_DEREGISTERSWAMPREQUEST']._serialized_end=1136
+  _globals['_DEREGISTERSWAMPRESPONSE']._serialized_start=1138
+  _globals['_DEREGISTERSWAMPRESPONSE']._serialized_end=1163
+  _globals['_SETREQUEST']._serialized_start=1165
+  _globals['_SETREQUEST']._serialized_end=1221
+  _globals['_SWAMPREQUEST']._serialized_start=1224
+  _globals['_SWAMPREQUEST']._serialized_end=1367
+  _globals['_KEYVALUEPAIR']._serialized_start=1370
+  _globals['_KEYVALUEPAIR']._serialized_end=2280
+  _globals['_SETRESPONSE']._serialized_start=2282
+  _globals['_SETRESPONSE']._serialized_end=2340
+  _globals['_SWAMPRESPONSE']._serialized_start=2343
+  _globals['_SWAMPRESPONSE']._serialized_end=2570
+  _globals['_SWAMPRESPONSE_ERRCODEENUM']._serialized_start=2498
+  _globals['_SWAMPRESPONSE_ERRCODEENUM']._serialized_end=2556
+  _globals['_KEYSTATUSPAIR']._serialized_start=2572
+  _globals['_KEYSTATUSPAIR']._serialized_end=2643
+  _globals['_STATUS']._serialized_start=2645
+  _globals['_STATUS']._serialized_end=2732
+  _globals['_STATUS_CODE']._serialized_start=2655
+  _globals['_STATUS_CODE']._serialized_end=2732
+  _globals['_GETREQUEST']._serialized_start=2734
+  _globals['_GETREQUEST']._serialized_end=2786
+  _globals['_GETSWAMP']._serialized_start=2788
+  _globals['_GETSWAMP']._serialized_end=2849
+  _globals['_GETRESPONSE']._serialized_start=2851
+  _globals['_GETRESPONSE']._serialized_end=2912
+  _globals['_GETSWAMPRESPONSE']._serialized_start=2914
+  _globals['_GETSWAMPRESPONSE']._serialized_end=3011
+  _globals['_GETALLREQUEST']._serialized_start=3013
+  _globals['_GETALLREQUEST']._serialized_end=3065
+  _globals['_GETALLRESPONSE']._serialized_start=3067
+  _globals['_GETALLRESPONSE']._serialized_end=3126
+  _globals['_SHIFTEXPIREDTREASURESREQUEST']._serialized_start=3128
+  _globals['_SHIFTEXPIREDTREASURESREQUEST']._serialized_end=3212
+  _globals['_SHIFTEXPIREDTREASURESRESPONSE']._serialized_start=3214
+  _globals['_SHIFTEXPIREDTREASURESRESPONSE']._serialized_end=3288
+  _globals['_TREASURE']._serialized_start=3291
+  _globals['_TREASURE']._serialized_end=4180
+  _globals['_BOOLEAN']._serialized_start=4182
+  _globals['_BOOLEAN']._serialized_end=4220
+  _globals['_BOOLEAN_TYPE']._serialized_start=4193
+  _globals['_BOOLEAN_TYPE']._serialized_end=4220
+  _globals['_GETBYINDEXREQUEST']._serialized_start=4223
+  _globals['_GETBYINDEXREQUEST']._serialized_end=4446
+  _globals['_INDEXTYPE']._serialized_start=4449
+  _globals['_INDEXTYPE']._serialized_end=4729
+  _globals['_INDEXTYPE_TYPE']._serialized_start=4463
+  _globals['_INDEXTYPE_TYPE']._serialized_end=4729
+  _globals['_ORDERTYPE']._serialized_start=4731
+  _globals['_ORDERTYPE']._serialized_end=4769
+  _globals['_ORDERTYPE_TYPE']._serialized_start=4744
+  _globals['_ORDERTYPE_TYPE']._serialized_end=4769
+  _globals['_GETBYINDEXRESPONSE']._serialized_start=4771
+  _globals['_GETBYINDEXRESPONSE']._serialized_end=4834
+  _globals['_GETBYVALUEREQUEST']._serialized_start=4836
+  _globals['_GETBYVALUEREQUEST']._serialized_end=4935
+  _globals['_GETBYVALUERESPONSE']._serialized_start=4937
+  _globals['_GETBYVALUERESPONSE']._serialized_end=5000
+  _globals['_DELETEREQUEST']._serialized_start=5003
+  _globals['_DELETEREQUEST']._serialized_end=5137
+  _globals['_DELETEREQUEST_SWAMPKEYS']._serialized_start=5075
+  _globals['_DELETEREQUEST_SWAMPKEYS']._serialized_end=5137
+  _globals['_DELETERESPONSE']._serialized_start=5140
+  _globals['_DELETERESPONSE']._serialized_end=5460
+  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE']._serialized_start=5228
+  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE']._serialized_end=5460
+  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE_ERRORCODEENUM']._serialized_start=5408
+  _globals['_DELETERESPONSE_SWAMPDELETERESPONSE_ERRORCODEENUM']._serialized_end=5446
+  _globals['_COUNTREQUEST']._serialized_start=5463
+  _globals['_COUNTREQUEST']._serialized_end=5593
+  _globals['_COUNTREQUEST_SWAMPIDENTIFIER']._serialized_start=5539
+  _globals['_COUNTREQUEST_SWAMPIDENTIFIER']._serialized_end=5593
+  _globals['_COUNTRESPONSE']._serialized_start=5595
+  _globals['_COUNTRESPONSE']._serialized_end=5652
+  _globals['_COUNTSWAMP']._serialized_start=5654
+  _globals['_COUNTSWAMP']._serialized_end=5717
+  _globals['_INCREMENTINT8REQUEST']._serialized_start=5720
+  _globals['_INCREMENTINT8REQUEST']._serialized_end=5870
+  _globals['_INCREMENTINT8CONDITION']._serialized_start=5872
+  _globals['_INCREMENTINT8CONDITION']._serialized_end=5974
+  _globals['_INCREMENTINT8RESPONSE']._serialized_start=5976
+  _globals['_INCREMENTINT8RESPONSE']._serialized_end=6037
+  _globals['_INCREMENTINT16REQUEST']._serialized_start=6040
+  _globals['_INCREMENTINT16REQUEST']._serialized_end=6192
+  _globals['_INCREMENTINT16CONDITION']._serialized_start=6194
+  _globals['_INCREMENTINT16CONDITION']._serialized_end=6297
+  _globals['_INCREMENTINT16RESPONSE']._serialized_start=6299
+  _globals['_INCREMENTINT16RESPONSE']._serialized_end=6361
+  _globals['_INCREMENTINT32REQUEST']._serialized_start=6364
+  _globals['_INCREMENTINT32REQUEST']._serialized_end=6516
+  _globals['_INCREMENTINT32CONDITION']._serialized_start=6518
+  _globals['_INCREMENTINT32CONDITION']._serialized_end=6621
+  _globals['_INCREMENTINT32RESPONSE']._serialized_start=6623
+  _globals['_INCREMENTINT32RESPONSE']._serialized_end=6685
+  _globals['_INCREMENTINT64REQUEST']._serialized_start=6688
+  _globals['_INCREMENTINT64REQUEST']._serialized_end=6840
+  _globals['_INCREMENTINT64CONDITION']._serialized_start=6842
+  _globals['_INCREMENTINT64CONDITION']._serialized_end=6945
+  _globals['_INCREMENTINT64RESPONSE']._serialized_start=6947
+  _globals['_INCREMENTINT64RESPONSE']._serialized_end=7009
+  _globals['_INCREMENTUINT8REQUEST']._serialized_start=7012
+  _globals['_INCREMENTUINT8REQUEST']._serialized_end=7164
+  _globals['_INCREMENTUINT8CONDITION']._serialized_start=7166
+  _globals['_INCREMENTUINT8CONDITION']._serialized_end=7269
+  _globals['_INCREMENTUINT8RESPONSE']._serialized_start=7271
+  _globals['_INCREMENTUINT8RESPONSE']._serialized_end=7333
+  _globals['_INCREMENTUINT16REQUEST']._serialized_start=7336
+  _globals['_INCREMENTUINT16REQUEST']._serialized_end=7490
+  _globals['_INCREMENTUINT16CONDITION']._serialized_start=7492
+  _globals['_INCREMENTUINT16CONDITION']._serialized_end=7596
+  _globals['_INCREMENTUINT16RESPONSE']._serialized_start=7598
+  _globals['_INCREMENTUINT16RESPONSE']._serialized_end=7661
+  _globals['_INCREMENTUINT32REQUEST']._serialized_start=7664
+  _globals['_INCREMENTUINT32REQUEST']._serialized_end=7818
+  _globals['_INCREMENTUINT32CONDITION']._serialized_start=7820
+  _globals['_INCREMENTUINT32CONDITION']._serialized_end=7924
+  _globals['_INCREMENTUINT32RESPONSE']._serialized_start=7926
+  _globals['_INCREMENTUINT32RESPONSE']._serialized_end=7989
+  _globals['_INCREMENTUINT64REQUEST']._serialized_start=7992
+  _globals['_INCREMENTUINT64REQUEST']._serialized_end=8146
+  _globals['_INCREMENTUINT64CONDITION']._serialized_start=8148
+  _globals['_INCREMENTUINT64CONDITION']._serialized_end=8252
+  _globals['_INCREMENTUINT64RESPONSE']._serialized_start=8254
+  _globals['_INCREMENTUINT64RESPONSE']._serialized_end=8317
+  _globals['_RELATIONAL']._serialized_start=8320
+  _globals['_RELATIONAL']._serialized_end=8454
+  _globals['_RELATIONAL_OPERATOR']._serialized_start=8334
+  _globals['_RELATIONAL_OPERATOR']._serialized_end=8454
+  _globals['_INCREMENTFLOAT32REQUEST']._serialized_start=8457
+  _globals['_INCREMENTFLOAT32REQUEST']._serialized_end=8613
+  _globals['_INCREMENTFLOAT32CONDITION']._serialized_start=8615
+  _globals['_INCREMENTFLOAT32CONDITION']._serialized_end=8720
+  _globals['_INCREMENTFLOAT32RESPONSE']._serialized_start=8722
+  _globals['_INCREMENTFLOAT32RESPONSE']._serialized_end=8786
+  _globals['_INCREMENTFLOAT64REQUEST']._serialized_start=8789
+  _globals['_INCREMENTFLOAT64REQUEST']._serialized_end=8945
+  _globals['_INCREMENTFLOAT64CONDITION']._serialized_start=8947
+  _globals['_INCREMENTFLOAT64CONDITION']._serialized_end=9052
+  _globals['_INCREMENTFLOAT64RESPONSE']._serialized_start=9054
+  _globals['_INCREMENTFLOAT64RESPONSE']._serialized_end=9118
+  _globals['_KEYSLICEPAIR']._serialized_start=9120
+  _globals['_KEYSLICEPAIR']._serialized_end=9163
+  _globals['_ADDTOUINT32SLICEPUSHREQUEST']._serialized_start=9165
+  _globals['_ADDTOUINT32SLICEPUSHREQUEST']._serialized_end=9282
+  _globals['_ADDTOUINT32SLICEPUSHRESPONSE']._serialized_start=9284
+  _globals['_ADDTOUINT32SLICEPUSHRESPONSE']._serialized_end=9314
+  _globals['_UINT32SLICEDELETEREQUEST']._serialized_start=9316
+  _globals['_UINT32SLICEDELETEREQUEST']._serialized_end=9430
+  _globals['_UINT32SLICEDELETERESPONSE']._serialized_start=9432
+  _globals['_UINT32SLICEDELETERESPONSE']._serialized_end=9459
+  _globals['_UINT32SLICESIZEREQUEST']._serialized_start=9461
+  _globals['_UINT32SLICESIZEREQUEST']._serialized_end=9535
+  _globals['_UINT32SLICESIZERESPONSE']._serialized_start=9537
+  _globals['_UINT32SLICESIZERESPONSE']._serialized_end=9576
+  _globals['_UINT32SLICEISVALUEEXISTREQUEST']._serialized_start=9578
+  _globals['_UINT32SLICEISVALUEEXISTREQUEST']._serialized_end=9675
+  _globals['_UINT32SLICEISVALUEEXISTRESPONSE']._serialized_start=9677
+  _globals['_UINT32SLICEISVALUEEXISTRESPONSE']._serialized_end=9727
+  _globals['_ISSWAMPEXISTREQUEST']._serialized_start=9729
+  _globals['_ISSWAMPEXISTREQUEST']._serialized_end=9787
+  _globals['_ISSWAMPEXISTRESPONSE']._serialized_start=9789
+  _globals['_ISSWAMPEXISTRESPONSE']._serialized_end=9828
+  _globals['_EXISTSMANYREQUEST']._serialized_start=9830
+  _globals['_EXISTSMANYREQUEST']._serialized_end=9896
+  _globals['_EXISTSMANYSWAMP']._serialized_start=9898
+  _globals['_EXISTSMANYSWAMP']._serialized_end=9952
+  _globals['_EXISTSMANYRESPONSE']._serialized_start=9954
+  _globals['_EXISTSMANYRESPONSE']._serialized_end=10023
+  _globals['_EXISTSMANYRESULT']._serialized_start=10025
+  _globals['_EXISTSMANYRESULT']._serialized_end=10086
+  _globals['_ISKEYEXISTREQUEST']._serialized_start=10088
+  _globals['_ISKEYEXISTREQUEST']._serialized_end=10157
+  _globals['_ISKEYEXISTRESPONSE']._serialized_start=10159
+  _globals['_ISKEYEXISTRESPONSE']._serialized_end=10196
+  _globals['_ERRORREASON']._serialized_start=10199
+  _globals['_ERRORREASON']._serialized_end=10505
+  _globals['_ERRORREASON_REASON']._serialized_start=10215
+  _globals['_ERRORREASON_REASON']._serialized_end=10505
+  _globals['_SETSWAMPANNOTATIONREQUEST']._serialized_start=10507
+  _globals['_SETSWAMPANNOTATIONREQUEST']._serialized_end=10599
+  _globals['_SETSWAMPANNOTATIONRESPONSE']._serialized_start=10601
+  _globals['_SETSWAMPANNOTATIONRESPONSE']._serialized_end=10629
+  _globals['_GETSWAMPANNOTATIONSREQUEST']._serialized_start=10631
+  _globals['_GETSWAMPANNOTATIONSREQUEST']._serialized_end=10696
+  _globals['_GETSWAMPANNOTATIONSRESPONSE']._serialized_start=10699
+  _globals['_GETSWAMPANNOTATIONSRESPONSE']._serialized_end=10861
+  _globals['_GETSWAMPANNOTATIONSRESPONSE_ANNOTATIONSENTRY']._serialized_start=10811
+  _globals['_GETSWAMPANNOTATIONSRESPONSE_ANNOTATIONSENTRY']._serialized_end=10861
+  _globals['_HYDRAIDESERVICE']._serialized_start=10864
+  _globals['_HYDRAIDESERVICE']._serialized_end=14055
 # @@protoc_insertion_point(module_scope)
@@ -3,7 +3,7 @@
 import grpc
 import warnings
 
-import hydraide_pb2 as hydraide__pb2
+from . import hydraide_pb2 as hydraide__pb2
 
 GRPC_GENERATED_VERSION = '1.74.0'
 GRPC_VERSION = grpc.__version__
@@ -79,6 +79,11 @@ class HydraideServiceStub(object):
                 request_serializer=hydraide__pb2.GetByIndexRequest.SerializeToString,
                 response_deserializer=hydraide__pb2.GetByIndexResponse.FromString,
                 _registered_method=True)
+        self.GetByValue = channel.unary_unary(
+                '/hydraidepbgo.HydraideService/GetByValue',
+                request_serializer=hydraide__pb2.GetByValueRequest.SerializeToString,
+                response_deserializer=hydraide__pb2.GetByValueResponse.FromString,
+                _registered_method=True)
         self.ShiftExpiredTreasures = channel.unary_unary(
                 '/hydraidepbgo.HydraideService/ShiftExpiredTreasures',
                 request_serializer=hydraide__pb2.ShiftExpiredTreasuresRequest.SerializeToString,
@@ -104,6 +109,11 @@ class HydraideServiceStub(object):
                 request_serializer=hydraide__pb2.IsSwampExistRequest.SerializeToString,
                 response_deserializer=hydraide__pb2.IsSwampExistResponse.FromString,
                 _registered_method=True)
+        self.ExistsMany = channel.unary_unary(
+                '/hydraidepbgo.HydraideService/ExistsMany',
+                request_serializer=hydraide__pb2.ExistsManyRequest.SerializeToString,
+                response_deserializer=hydraide__pb2.ExistsManyResponse.FromString,
+                _registered_method=True)
         self.IsKeyExist = channel.unary_unary(
                 '/hydraidepbgo.HydraideService/IsKeyExist',
                 request_serializer=hydraide__pb2.IsKeyExistRequest.SerializeToString,
@@ -189,6 +199,16 @@ class HydraideServiceStub(object):
                 request_serializer=hydraide__pb2.IncrementFloat64Request.SerializeToString,
                 response_deserializer=hydraide__pb2.IncrementFloat64Response.FromString,
                 _registered_method=True)
+        self.SetSwampAnnotation = channel.unary_unary(
+                '/hydraidepbgo.HydraideService/SetSwampAnnotation',
+                request_serializer=hydraide__pb2.SetSwampAnnotationRequest.SerializeToString,
+                response_deserializer=hydraide__pb2.SetSwampAnnotationResponse.FromString,
+                _registered_method=True)
+        self.GetSwampAnnotations = channel.unary_unary(
+                '/hydraidepbgo.HydraideService/GetSwampAnnotations',
+                request_serializer=hydraide__pb2.GetSwampAnnotationsRequest.SerializeToString,
+                response_deserializer=hydraide__pb2.GetSwampAnnotationsResponse.FromString,
+                _registered_method=True)
 
 
 class HydraideServiceServicer(object):
@@ -325,6 +345,24 @@ class HydraideServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GetByValue(self, request, context):
+        """GetByValue returns all treasures of a swamp whose value equals the given value.
+
+        The lookup uses the secondary value index of the swamp, so it does not scan the swamp.
+        The value index must be enabled for the swamp pattern with the `ValueIndex` flag of RegisterSwamp,
+        otherwise the request fails with FailedPrecondition.
+
+        ⚠️ The value index lives only in memory. It is built at the first GetByValue call and maintained
+        incrementally by every write and delete until the swamp is closed.
+
+        Use this for lookups like:
+        - Find the user keys registered with a given email address
+        - Find all tasks in a given state
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def ShiftExpiredTreasures(self, request, context):
         """ShiftExpiredTreasures retrieves and deletes expired treasures from a given swamp.
 
@@ -425,6 +463,26 @@ class HydraideServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def ExistsMany(self, request, context):
+        """ExistsMany checks the existence of many swamps in a single request.
+
+        Every requested item is either an exact swamp name or a wildcard pattern, where the
+        realm and/or the swamp part of the name is "*" (e.g. "domains/*/example.com").
+        For every item HydrAIDE returns the names of the existing swamps matching it:
+        - For an exact name: the name itself if the swamp exists, otherwise nothing
+        - For a wildcard pattern: all existing swamps on this server that match the pattern
+
+        ✅ This is useful for:
+        - Rendering existence badges for hundreds of items in a UI with one roundtrip
+        - Discovering the swamps of a sanctuary or realm for admin tooling
+
+        ⚠️ Wildcard patterns are resolved by walking all island folders of the server, so they are
+        much more expensive than exact names. Use them for admin and UI purposes, not in hot paths.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def IsKeyExist(self, request, context):
         """IsKeyExist checks whether a specific key exists in a given swamp.
 
@@ -628,6 +686,31 @@ class HydraideServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def SetSwampAnnotation(self, request, context):
+        """SetSwampAnnotation attaches a small key-value annotation to an existing swamp.
+
+        💡 Annotations are free-form labels owned by the application, for example:
+        - the owner service of the swamp
+        - the schema version of the stored models
+        - the retention class of the data
+
+        An empty Value deletes the annotation.
+        The annotations are stored in the swamp's metadata and persisted when the swamp is written to disk.
+
+        ⚠️ Limits: at most 64 annotations per swamp, keys up to 128 bytes, values up to 1024 bytes.
+        The swamp must exist, otherwise a FailedPrecondition error with SWAMP_NOT_FOUND reason is returned.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GetSwampAnnotations(self, request, context):
+        """GetSwampAnnotations returns all annotations of an existing swamp.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_HydraideServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -676,6 +759,11 @@ def add_HydraideServiceServicer_to_server(servicer, server):
                     request_deserializer=hydraide__pb2.GetByIndexRequest.FromString,
                     response_serializer=hydraide__pb2.GetByIndexResponse.SerializeToString,
             ),
+            'GetByValue': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetByValue,
+                    request_deserializer=hydraide__pb2.GetByValueRequest.FromString,
+                    response_serializer=hydraide__pb2.GetByValueResponse.SerializeToString,
+            ),
             'ShiftExpiredTreasures': grpc.unary_unary_rpc_method_handler(
                     servicer.ShiftExpiredTreasures,
                     request_deserializer=hydraide__pb2.ShiftExpiredTreasuresRequest.FromString,
@@ -701,6 +789,11 @@ def add_HydraideServiceServicer_to_server(servicer, server):
                     request_deserializer=hydraide__pb2.IsSwampExistRequest.FromString,
                     response_serializer=hydraide__pb2.IsSwampExistResponse.SerializeToString,
             ),
+            'ExistsMany': grpc.unary_unary_rpc_method_handler(
+                    servicer.ExistsMany,
+                    request_deserializer=hydraide__pb2.ExistsManyRequest.FromString,
+                    response_serializer=hydraide__pb2.ExistsManyResponse.SerializeToString,
+            ),
             'IsKeyExist': grpc.unary_unary_rpc_method_handler(
                     servicer.IsKeyExist,
                     request_deserializer=hydraide__pb2.IsKeyExistRequest.FromString,
@@ -786,6 +879,16 @@ def add_HydraideServiceServicer_to_server(servicer, server):
                     request_deserializer=hydraide__pb2.IncrementFloat64Request.FromString,
                     response_serializer=hydraide__pb2.IncrementFloat64Response.SerializeToString,
             ),
+            'SetSwampAnnotation': grpc.unary_unary_rpc_method_handler(
+                    servicer.SetSwampAnnotation,
+                    request_deserializer=hydraide__pb2.SetSwampAnnotationRequest.FromString,
+                    response_serializer=hydraide__pb2.SetSwampAnnotationResponse.SerializeToString,
+            ),
+            'GetSwampAnnotations': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetSwampAnnotations,
+                    request_deserializer=hydraide__pb2.GetSwampAnnotationsRequest.FromString,
+                    response_serializer=hydraide__pb2.GetSwampAnnotationsResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'hydraidepbgo.HydraideService', rpc_method_handlers)
@@ -1040,6 +1143,33 @@ class HydraideService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def GetByValue(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/hydraidepbgo.HydraideService/GetByValue',
+            hydraide__pb2.GetByValueRequest.SerializeToString,
+            hydraide__pb2.GetByValueResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def ShiftExpiredTreasures(request,
             target,
@@ -1175,6 +1305,33 @@ class HydraideService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def ExistsMany(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/hydraidepbgo.HydraideService/ExistsMany',
+            hydraide__pb2.ExistsManyRequest.SerializeToString,
+            hydraide__pb2.ExistsManyResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def IsKeyExist(request,
             target,
@@ -1633,3 +1790,57 @@ class HydraideService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def SetSwampAnnotation(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/hydraidepbgo.HydraideService/SetSwampAnnotation',
+            hydraide__pb2.SetSwampAnnotationRequest.SerializeToString,
+            hydraide__pb2.SetSwampAnnotationResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def GetSwampAnnotations(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/hydraidepbgo.HydraideService/GetSwampAnnotations',
+            hydraide__pb2.GetSwampAnnotationsRequest.SerializeToString,
+            hydraide__pb2.GetSwampAnnotationsResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -0,0 +1,747 @@
+"""The HydrAIDE API of the Python SDK.
+
+:class:`Hydraide` mirrors the Hydraidego interface of the Go SDK with snake_case names: swamp registration, locks,
+catalog and profile models, counters, increments, uint32 slices and subscriptions. The swamps are routed by the same
+island hash as in Go, so a Python and a Go service can read and write the same swamps on the same cluster.
+
+Example::
+
+    client = Client([Server("localhost:4900", 1, 1000, "certificate/server.crt")], 1000, 10 * 1024 * 1024)
+    client.connect()
+    h = Hydraide(client)
+
+    h.catalog_save(Name().sanctuary("users").realm("catalog").swamp("all"), User(id="alex", name="Alex"))
+
+Every method accepts an optional ``timeout`` in seconds, like the context deadline of the Go SDK, and raises
+:class:`~hydraidepy.errors.HydraideError` on failure.
+"""
+
+from __future__ import annotations
+
+import enum
+from collections.abc import Callable, Iterator
+from dataclasses import dataclass
+from typing import Any, TypeVar
+
+import grpc
+
+from . import models
+from .client import Client
+from .errors import (
+    MESSAGE_CONDITION_NOT_MET,
+    MESSAGE_KEY_ALREADY_EXISTS,
+    MESSAGE_KEY_NOT_FOUND,
+    MESSAGE_SWAMP_NOT_FOUND,
+    MESSAGE_UNKNOWN,
+    ErrorCode,
+    HydraideError,
+    from_grpc_error,
+)
+from .generated import hydraide_pb2 as pb
+from .name import Name
+
+T = TypeVar("T")
+
+# the gRPC metadata key of the client identity used by the rate limits of the server
+METADATA_CLIENT_ID = "hydraide-client-id"
+
+
+class IndexType(enum.IntEnum):
+    """The field used to sort the treasures during an indexed read, in the same order as in the Go SDK."""
+
+    KEY = 1
+    VALUE_STRING = 2
+    VALUE_UINT8 = 3
+    VALUE_UINT16 = 4
+    VALUE_UINT32 = 5
+    VALUE_UINT64 = 6
+    VALUE_INT8 = 7
+    VALUE_INT16 = 8
+    VALUE_INT32 = 9
+    VALUE_INT64 = 10
+    VALUE_FLOAT32 = 11
+    VALUE_FLOAT64 = 12
+    EXPIRATION_TIME = 13
+    CREATION_TIME = 14
+    UPDATE_TIME = 15
+
+
+class IndexOrder(enum.IntEnum):
+    """The order of an indexed read."""
+
+    ASC = 1
+    DESC = 2
+
+
+class EventStatus(enum.IntEnum):
+    """The status of a treasure after a write or in a subscription event, the same as in the Go SDK."""
+
+    UNKNOWN = 0
+    SWAMP_NOT_FOUND = 1
+    TREASURE_NOT_FOUND = 2
+    NEW = 3
+    MODIFIED = 4
+    NOTHING_CHANGED = 5
+    DELETED = 6
+
+
+class RelationalOperator(enum.IntEnum):
+    """The operator of the condition of an increment, in the same order as in the Go SDK."""
+
+    NOT_EQUAL = 0
+    EQUAL = 1
+    GREATER_THAN_OR_EQUAL = 2
+    GREATER_THAN = 3
+    LESS_THAN_OR_EQUAL = 4
+    LESS_THAN = 5
+
+
+@dataclass
+class Index:
+    """The settings of an indexed read.
+
+    ``limit`` 0 returns all treasures. ``filter_expr`` is an optional server-side filter expression,
+    e.g. ``value >= 18 AND createdAt > 2024-01-01``.
+    """
+
+    index_type: IndexType = IndexType.CREATION_TIME
+    index_order: IndexOrder = IndexOrder.ASC
+    from_: int = 0
+    limit: int = 0
+    filter_expr: str = ""
+
+
+@dataclass
+class Condition:
+    """The condition of an increment. The increment is executed only if the current value satisfies it."""
+
+    relational_operator: RelationalOperator
+    value: int | float
+
+
+_INDEX_TYPES = {
+    IndexType.KEY: pb.IndexType.KEY,
+    IndexType.VALUE_STRING: pb.IndexType.VALUE_STRING,
+    IndexType.VALUE_UINT8: pb.IndexType.VALUE_UINT8,
+    IndexType.VALUE_UINT16: pb.IndexType.VALUE_UINT16,
+    IndexType.VALUE_UINT32: pb.IndexType.VALUE_UINT32,
+    IndexType.VALUE_UINT64: pb.IndexType.VALUE_UINT64,
+    IndexType.VALUE_INT8: pb.IndexType.VALUE_INT8,
+    IndexType.VALUE_INT16: pb.IndexType.VALUE_INT16,
+    IndexType.VALUE_INT32: pb.IndexType.VALUE_INT32,
+    IndexType.VALUE_INT64: pb.IndexType.VALUE_INT64,
+    IndexType.VALUE_FLOAT32: pb.IndexType.VALUE_FLOAT32,
+    IndexType.VALUE_FLOAT64: pb.IndexType.VALUE_FLOAT64,
+    IndexType.EXPIRATION_TIME: pb.IndexType.EXPIRATION_TIME,
+    IndexType.CREATION_TIME: pb.IndexType.CREATION_TIME,
+    IndexType.UPDATE_TIME: pb.IndexType.UPDATE_TIME,
+}
+
+_OPERATORS = {
+    RelationalOperator.NOT_EQUAL: pb.Relational.NOT_EQUAL,
+    RelationalOperator.EQUAL: pb.Relational.EQUAL,
+    RelationalOperator.GREATER_THAN_OR_EQUAL: pb.Relational.GREATER_THAN_OR_EQUAL,
+    RelationalOperator.GREATER_THAN: pb.Relational.GREATER_THAN,
+    RelationalOperator.LESS_THAN_OR_EQUAL: pb.Relational.LESS_THAN_OR_EQUAL,
+    RelationalOperator.LESS_THAN: pb.Relational.LESS_THAN,
+}
+
+_STATUSES = {
+    pb.Status.NOT_FOUND: EventStatus.TREASURE_NOT_FOUND,
+    pb.Status.NEW: EventStatus.NEW,
+    pb.Status.UPDATED: EventStatus.MODIFIED,
+    pb.Status.DELETED: EventStatus.DELETED,
+    pb.Status.NOTHING_CHANGED: EventStatus.NOTHING_CHANGED,
+}
+
+# the RPC, the request, the condition message and the result converter of the increments per type
+_INCREMENTS: dict[str, tuple[str, Any, Any, Callable[[Any], Any]]] = {
+    "int8": ("IncrementInt8", pb.IncrementInt8Request, pb.IncrementInt8Condition, int),
+    "int16": ("IncrementInt16", pb.IncrementInt16Request, pb.IncrementInt16Condition, int),
+    "int32": ("IncrementInt32", pb.IncrementInt32Request, pb.IncrementInt32Condition, int),
+    "int64": ("IncrementInt64", pb.IncrementInt64Request, pb.IncrementInt64Condition, int),
+    "uint8": ("IncrementUint8", pb.IncrementUint8Request, pb.IncrementUint8Condition, int),
+    "uint16": ("IncrementUint16", pb.IncrementUint16Request, pb.IncrementUint16Condition, int),
+    "uint32": ("IncrementUint32", pb.IncrementUint32Request, pb.IncrementUint32Condition, int),
+    "uint64": ("IncrementUint64", pb.IncrementUint64Request, pb.IncrementUint64Condition, int),
+    "float32": ("IncrementFloat32", pb.IncrementFloat32Request, pb.IncrementFloat32Condition, float),
+    "float64": ("IncrementFloat64", pb.IncrementFloat64Request, pb.IncrementFloat64Condition, float),
+}
+
+
+def _status(status: int) -> EventStatus:
+    return _STATUSES.get(status, EventStatus.NOTHING_CHANGED)
+
+
+class Hydraide:
+    """The HydrAIDE API on top of a connected :class:`~hydraidepy.client.Client`.
+
+    :param client: the connected client
+    :param client_id: optional identity of the client, sent to the server for the per-client rate limits
+    """
+
+    def __init__(self, client: Client, client_id: str | None = None) -> None:
+        self._client = client
+        self._metadata = ((METADATA_CLIENT_ID, client_id),) if client_id else None
+
+    def _call(self, swamp_name: Name | None, method: str, request: Any, timeout: float | None) -> Any:
+        """Calls the RPC on the server of the swamp, or on the first server if the swamp is None."""
+        try:
+            if swamp_name is None:
+                stub = self._client.unique_service_clients()[0]
+            else:
+                stub = self._client.service_client(swamp_name)
+            return getattr(stub, method)(request, timeout=timeout, metadata=self._metadata)
+        except grpc.RpcError as err:
+            raise from_grpc_error(err) from err
+        except (ConnectionError, IndexError) as err:
+            raise HydraideError(ErrorCode.CONNECTION_ERROR, str(err)) from err
+
+    def _island(self, swamp_name: Name) -> int:
+        return swamp_name.island_id(self._client.all_islands)
+
+    @staticmethod
+    def _catalog_kv_pair(model: Any) -> pb.KeyValuePair:
+        try:
+            return models.catalog_to_key_value_pair(model)
+        except (TypeError, ValueError) as err:
+            raise HydraideError(ErrorCode.INVALID_MODEL, str(err)) from err
+
+    @staticmethod
+    def _to_catalog(treasure: pb.Treasure, model_type: type[T]) -> T:
+        try:
+            return models.treasure_to_catalog(treasure, model_type)
+        except (TypeError, ValueError) as err:
+            raise HydraideError(ErrorCode.INVALID_MODEL, str(err)) from err
+
+    # 🫀 Server and swamp management
+
+    def heartbeat(self, timeout: float | None = None) -> None:
+        """Checks every connected server. Raises an error if any of them doesn't answer."""
+        for stub in self._client.unique_service_clients():
+            try:
+                pong = stub.Heartbeat(pb.HeartbeatRequest(Ping="beat"), timeout=timeout, metadata=self._metadata)
+            except grpc.RpcError as err:
+                raise from_grpc_error(err) from err
+            if pong.Pong != "beat":
+                raise HydraideError(ErrorCode.CONNECTION_ERROR, "wrong heartbeat response")
+
+    def register_swamp(
+        self,
+        swamp_pattern: Name,
+        close_after_idle: float,
+        in_memory: bool = False,
+        write_interval: float | None = None,
+        max_file_size: int | None = None,
+        value_index: bool = False,
+        timeout: float | None = None,
+    ) -> list[HydraideError]:
+        """Registers the settings of a swamp pattern. A wildcard pattern is registered on every server.
+
+        The durations are in seconds. Returns the errors of the servers, or an empty list.
+        """
+        request = pb.RegisterSwampRequest(
+            SwampPattern=swamp_pattern.get(),
+            CloseAfterIdle=int(close_after_idle),
+            IsInMemorySwamp=in_memory,
+            ValueIndex=value_index,
+        )
+        if not in_memory and write_interval is not None and max_file_size is not None:
+            request.WriteInterval = int(write_interval)
+            request.MaxFileSize = max_file_size
+        return self._on_pattern_servers(swamp_pattern, "RegisterSwamp", request, timeout)
+
+    def deregister_swamp(self, swamp_pattern: Name, timeout: float | None = None) -> list[HydraideError]:
+        """Removes the registered settings of a swamp pattern. Returns the errors of the servers, or an empty list."""
+        request = pb.DeRegisterSwampRequest(SwampPattern=swamp_pattern.get())
+        return self._on_pattern_servers(swamp_pattern, "DeRegisterSwamp", request, timeout)
+
+    def _on_pattern_servers(
+        self, swamp_pattern: Name, method: str, request: Any, timeout: float | None
+    ) -> list[HydraideError]:
+        if not swamp_pattern.is_wildcard_pattern():
+            try:
+                self._call(swamp_pattern, method, request, timeout)
+            except HydraideError as err:
+                return [err]
+            return []
+        errors = []
+        for stub in self._client.unique_service_clients():
+            try:
+                getattr(stub, method)(request, timeout=timeout, metadata=self._metadata)
+            except grpc.RpcError as err:
+                errors.append(from_grpc_error(err))
+        return errors
+
+    def lock(self, key: str, ttl: float, timeout: float | None = None) -> str:
+        """Acquires a business-level lock on the key, for at most ``ttl`` seconds. Returns the lock ID."""
+        response = self._call(None, "Lock", pb.LockRequest(Key=key, TTL=int(ttl * 1000)), timeout)
+        if not response.LockID:
+            raise HydraideError(ErrorCode.NOT_FOUND, "lock ID not found")
+        return str(response.LockID)
+
+    def unlock(self, key: str, lock_id: str, timeout: float | None = None) -> None:
+        """Releases the lock of the key."""
+        self._call(None, "Unlock", pb.UnlockRequest(Key=key, LockID=lock_id), timeout)
+
+    def is_swamp_exist(self, swamp_name: Name, timeout: float | None = None) -> bool:
+        """Returns True if the swamp exists."""
+        request = pb.IsSwampExistRequest(IslandID=self._island(swamp_name), SwampName=swamp_name.get())
+        try:
+            return bool(self._call(swamp_name, "IsSwampExist", request, timeout).IsExist)
+        except HydraideError as err:
+            if err.code == ErrorCode.SWAMP_NOT_FOUND:
+                return False
+            raise
+
+    def exists_many(self, patterns: list[Name], timeout: float | None = None) -> dict[str, bool]:
+        """Checks many swamps with one request per server.
+
+        The concrete names are always in the result. Wildcard patterns are resolved on every server, and only the
+        existing matches are returned.
+        """
+        requests: dict[int, tuple[Any, list[Any]]] = {}
+        result: dict[str, bool] = {}
+        for pattern in patterns:
+            if pattern.is_wildcard_pattern():
+                for stub in self._client.unique_service_clients():
+                    requests.setdefault(id(stub), (stub, []))[1].append(pb.ExistsManySwamp(SwampName=pattern.get()))
+                continue
+            result[pattern.get()] = False
+            stub = self._client.service_client(pattern)
+            requests.setdefault(id(stub), (stub, []))[1].append(
+                pb.ExistsManySwamp(IslandID=self._island(pattern), SwampName=pattern.get())
+            )
+        for stub, swamps in requests.values():
+            try:
+                request = pb.ExistsManyRequest(Swamps=swamps)
+                response = stub.ExistsMany(request, timeout=timeout, metadata=self._metadata)
+            except grpc.RpcError as err:
+                raise from_grpc_error(err) from err
+            for exists_result in response.Results:
+                for name in exists_result.ExistingSwamps:
+                    result[name] = True
+        return result
+
+    def is_key_exists(self, swamp_name: Name, key: str, timeout: float | None = None) -> bool:
+        """Returns True if the key exists in the swamp."""
+        request = pb.IsKeyExistRequest(IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Key=key)
+        return bool(self._call(swamp_name, "IsKeyExist", request, timeout).IsExist)
+
+    def count(self, swamp_name: Name, timeout: float | None = None) -> int:
+        """Returns the number of treasures in the swamp."""
+        request = pb.CountRequest(
+            Swamps=[pb.CountRequest.SwampIdentifier(IslandID=self._island(swamp_name), SwampName=swamp_name.get())]
+        )
+        for swamp in self._call(swamp_name, "Count", request, timeout).Swamps:
+            if not swamp.IsExist:
+                raise HydraideError(ErrorCode.SWAMP_NOT_FOUND, MESSAGE_SWAMP_NOT_FOUND)
+            return int(swamp.Count)
+        raise HydraideError(ErrorCode.UNKNOWN, MESSAGE_UNKNOWN)
+
+    def count_many(self, swamp_names: list[Name], timeout: float | None = None) -> dict[str, int]:
+        """Counts many swamps with one request per server. The missing swamps are not in the result."""
+        requests: dict[int, tuple[Any, list[Any]]] = {}
+        for swamp_name in swamp_names:
+            stub = self._client.service_client(swamp_name)
+            requests.setdefault(id(stub), (stub, []))[1].append(
+                pb.CountRequest.SwampIdentifier(IslandID=self._island(swamp_name), SwampName=swamp_name.get())
+            )
+        result: dict[str, int] = {}
+        for stub, swamps in requests.values():
+            try:
+                response = stub.Count(pb.CountRequest(Swamps=swamps), timeout=timeout, metadata=self._metadata)
+            except grpc.RpcError as err:
+                raise from_grpc_error(err) from err
+            for swamp in response.Swamps:
+                if swamp.IsExist:
+                    result[swamp.SwampName] = int(swamp.Count)
+        return result
+
+    def destroy(self, swamp_name: Name, timeout: float | None = None) -> None:
+        """Deletes the swamp with all of its treasures."""
+        request = pb.DestroyRequest(IslandID=self._island(swamp_name), SwampName=swamp_name.get())
+        self._call(swamp_name, "Destroy", request, timeout)
+
+    # 📚 Catalog
+
+    def _set(
+        self, swamp_name: Name, kv_pairs: list[pb.KeyValuePair], create: bool, overwrite: bool, timeout: float | None
+    ) -> Any:
+        request = pb.SetRequest(
+            Swamps=[
+                pb.SwampRequest(
+                    IslandID=self._island(swamp_name),
+                    SwampName=swamp_name.get(),
+                    KeyValues=kv_pairs,
+                    CreateIfNotExist=create,
+                    Overwrite=overwrite,
+                )
+            ]
+        )
+        return self._call(swamp_name, "Set", request, timeout)
+
+    def catalog_create(self, swamp_name: Name, model: Any, timeout: float | None = None) -> None:
+        """Creates the treasure of the model. Raises ALREADY_EXISTS if the key already exists."""
+        response = self._set(swamp_name, [self._catalog_kv_pair(model)], True, False, timeout)
+        for swamp in response.Swamps:
+            for key_status in swamp.KeysAndStatuses:
+                if key_status.Status == pb.Status.NOTHING_CHANGED:
+                    raise HydraideError(ErrorCode.ALREADY_EXISTS, MESSAGE_KEY_ALREADY_EXISTS)
+
+    def catalog_create_many(
+        self, swamp_name: Name, model_list: list[Any], timeout: float | None = None
+    ) -> dict[str, HydraideError | None]:
+        """Creates many treasures in one request. Returns the result per key: None, or an ALREADY_EXISTS error."""
+        response = self._set(swamp_name, [self._catalog_kv_pair(m) for m in model_list], True, False, timeout)
+        result: dict[str, HydraideError | None] = {}
+        for swamp in response.Swamps:
+            for key_status in swamp.KeysAndStatuses:
+                result[key_status.Key] = (
+                    HydraideError(ErrorCode.ALREADY_EXISTS, MESSAGE_KEY_ALREADY_EXISTS)
+                    if key_status.Status == pb.Status.NOTHING_CHANGED
+                    else None
+                )
+        return result
+
+    def catalog_read(self, swamp_name: Name, key: str, model_type: type[T], timeout: float | None = None) -> T:
+        """Reads the treasure of the key into a new instance of the model. Raises NOT_FOUND if the key is missing."""
+        request = pb.GetRequest(
+            Swamps=[pb.GetSwamp(IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Keys=[key])]
+        )
+        for swamp in self._call(swamp_name, "Get", request, timeout).Swamps:
+            for treasure in swamp.Treasures:
+                if not treasure.IsExist:
+                    break
+                return self._to_catalog(treasure, model_type)
+        raise HydraideError(ErrorCode.NOT_FOUND, MESSAGE_KEY_NOT_FOUND)
+
+    def catalog_read_many(
+        self, swamp_name: Name, index: Index, model_type: type[T], timeout: float | None = None
+    ) -> list[T]:
+        """Reads the treasures of the swamp in the order of the index."""
+        request = pb.GetByIndexRequest(
+            IslandID=self._island(swamp_name),
+            SwampName=swamp_name.get(),
+            IndexType=_INDEX_TYPES.get(index.index_type, pb.IndexType.CREATION_TIME),
+            OrderType=pb.OrderType.DESC if index.index_order == IndexOrder.DESC else pb.OrderType.ASC,
+            From=index.from_,
+            Limit=index.limit,
+        )
+        if index.filter_expr:
+            request.FilterExpr = index.filter_expr
+        response = self._call(swamp_name, "GetByIndex", request, timeout)
+        return [self._to_catalog(t, model_type) for t in response.Treasures if t.IsExist]
+
+    def catalog_update(self, swamp_name: Name, model: Any, timeout: float | None = None) -> None:
+        """Updates an existing treasure. Raises SWAMP_NOT_FOUND or NOT_FOUND if the swamp or the key is missing."""
+        response = self._set(swamp_name, [self._catalog_kv_pair(model)], False, True, timeout)
+        for swamp in response.Swamps:
+            if swamp.HasField("ErrorCode") and swamp.ErrorCode == pb.SwampResponse.SwampDoesNotExist:
+                raise HydraideError(ErrorCode.SWAMP_NOT_FOUND, MESSAGE_SWAMP_NOT_FOUND)
+            for key_status in swamp.KeysAndStatuses:
+                if key_status.Status == pb.Status.NOT_FOUND:
+                    raise HydraideError(ErrorCode.NOT_FOUND, MESSAGE_KEY_NOT_FOUND)
+
+    def catalog_update_many(
+        self, swamp_name: Name, model_list: list[Any], timeout: float | None = None
+    ) -> dict[str, EventStatus]:
+        """Updates many existing treasures. Returns the status per key, TREASURE_NOT_FOUND for the missing keys."""
+        response = self._set(swamp_name, [self._catalog_kv_pair(m) for m in model_list], False, True, timeout)
+        result: dict[str, EventStatus] = {}
+        for swamp in response.Swamps:
+            if swamp.HasField("ErrorCode") and swamp.ErrorCode == pb.SwampResponse.SwampDoesNotExist:
+                raise HydraideError(ErrorCode.SWAMP_NOT_FOUND, MESSAGE_SWAMP_NOT_FOUND)
+            for key_status in swamp.KeysAndStatuses:
+                result[key_status.Key] = _status(key_status.Status)
+        return result
+
+    def catalog_delete(self, swamp_name: Name, key: str, timeout: float | None = None) -> None:
+        """Deletes the treasure of the key. Raises SWAMP_NOT_FOUND or NOT_FOUND if the swamp or the key is missing."""
+        result = self.catalog_delete_many(swamp_name, [key], timeout)
+        error = result.get(key, HydraideError(ErrorCode.UNKNOWN, MESSAGE_UNKNOWN))
+        if error is not None:
+            raise error
+
+    def catalog_delete_many(
+        self, swamp_name: Name, keys: list[str], timeout: float | None = None
+    ) -> dict[str, HydraideError | None]:
+        """Deletes many treasures. Returns the result per key: None, or a NOT_FOUND error.
+
+        Raises SWAMP_NOT_FOUND if the swamp doesn't exist.
+        """
+        request = pb.DeleteRequest(
+            Swamps=[
+                pb.DeleteRequest.SwampKeys(IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Keys=keys)
+            ]
+        )
+        result: dict[str, HydraideError | None] = {}
+        for response in self._call(swamp_name, "Delete", request, timeout).Responses:
+            if response.HasField("ErrorCode"):
+                raise HydraideError(ErrorCode.SWAMP_NOT_FOUND, MESSAGE_SWAMP_NOT_FOUND)
+            for key_status in response.KeyStatuses:
+                if key_status.Status == pb.Status.DELETED:
+                    result[key_status.Key] = None
+                elif key_status.Status == pb.Status.NOT_FOUND:
+                    result[key_status.Key] = HydraideError(
+                        ErrorCode.NOT_FOUND, f"key ({key_status.Key}) not found"
+                    )
+        return result
+
+    def catalog_save(self, swamp_name: Name, model: Any, timeout: float | None = None) -> EventStatus:
+        """Creates or overwrites the treasure of the model. Returns NEW, MODIFIED or NOTHING_CHANGED."""
+        response = self._set(swamp_name, [self._catalog_kv_pair(model)], True, True, timeout)
+        for swamp in response.Swamps:
+            for key_status in swamp.KeysAndStatuses:
+                return _status(key_status.Status)
+        raise HydraideError(ErrorCode.UNKNOWN, MESSAGE_UNKNOWN)
+
+    def catalog_save_many(
+        self, swamp_name: Name, model_list: list[Any], timeout: float | None = None
+    ) -> dict[str, EventStatus]:
+        """Creates or overwrites many treasures in one request. Returns the status per key."""
+        response = self._set(swamp_name, [self._catalog_kv_pair(m) for m in model_list], True, True, timeout)
+        return {ks.Key: _status(ks.Status) for swamp in response.Swamps for ks in swamp.KeysAndStatuses}
+
+    def catalog_shift_expired(
+        self, swamp_name: Name, how_many: int, model_type: type[T], timeout: float | None = None
+    ) -> list[T]:
+        """Removes and returns at most ``how_many`` expired treasures of the swamp (0 means all)."""
+        request = pb.ShiftExpiredTreasuresRequest(
+            IslandID=self._island(swamp_name), SwampName=swamp_name.get(), HowMany=how_many
+        )
+        response = self._call(swamp_name, "ShiftExpiredTreasures", request, timeout)
+        return [self._to_catalog(t, model_type) for t in response.Treasures if t.IsExist]
+
+    # 👤 Profile
+
+    def profile_save(self, swamp_name: Name, model: Any, timeout: float | None = None) -> None:
+        """Saves every field of the profile model as a separate treasure of the swamp."""
+        try:
+            kv_pairs = models.profile_to_key_value_pairs(model)
+        except (TypeError, ValueError) as err:
+            raise HydraideError(ErrorCode.INVALID_MODEL, str(err)) from err
+        self._set(swamp_name, kv_pairs, True, True, timeout)
+
+    def profile_read(self, swamp_name: Name, model: Any, timeout: float | None = None) -> None:
+        """Reads the treasures of the swamp into the fields of the profile model. Missing fields keep their value."""
+        try:
+            keys = models.profile_keys(type(model))
+        except TypeError as err:
+            raise HydraideError(ErrorCode.INVALID_MODEL, str(err)) from err
+        request = pb.GetRequest(
+            Swamps=[pb.GetSwamp(IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Keys=keys)]
+        )
+        for swamp in self._call(swamp_name, "Get", request, timeout).Swamps:
+            for treasure in swamp.Treasures:
+                if treasure.IsExist:
+                    models.set_treasure_to_profile(model, treasure)
+
+    # 🔢 Increments
+
+    def increment(
+        self,
+        value_type: models.ValueType,
+        swamp_name: Name,
+        key: str,
+        value: int | float,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int | float:
+        """Atomically increments the typed number of the key and returns the new value.
+
+        The swamp and the treasure are created if they don't exist. If the condition is not met, CONDITION_NOT_MET
+        is raised. The value type must be the same as the stored type, e.g. ValueType.INT64 for a Go int64.
+        """
+        if value_type.value not in _INCREMENTS:
+            raise HydraideError(ErrorCode.INVALID_ARGUMENT, f"{value_type.name} values can not be incremented")
+        method, request_type, condition_type, convert = _INCREMENTS[value_type.value]
+        request = request_type(
+            IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Key=key, IncrementBy=value
+        )
+        if condition is not None:
+            request.Condition.CopyFrom(
+                condition_type(RelationalOperator=_OPERATORS[condition.relational_operator], Value=condition.value)
+            )
+        response = self._call(swamp_name, method, request, timeout)
+        if not response.IsIncremented:
+            raise HydraideError(ErrorCode.CONDITION_NOT_MET, f"{MESSAGE_CONDITION_NOT_MET}: {response.Value}")
+        return convert(response.Value)
+
+    def increment_int8(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the int8 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.INT8, swamp_name, key, value, condition, timeout))
+
+    def increment_int16(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the int16 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.INT16, swamp_name, key, value, condition, timeout))
+
+    def increment_int32(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the int32 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.INT32, swamp_name, key, value, condition, timeout))
+
+    def increment_int64(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the int64 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.INT64, swamp_name, key, value, condition, timeout))
+
+    def increment_uint8(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the uint8 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.UINT8, swamp_name, key, value, condition, timeout))
+
+    def increment_uint16(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the uint16 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.UINT16, swamp_name, key, value, condition, timeout))
+
+    def increment_uint32(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the uint32 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.UINT32, swamp_name, key, value, condition, timeout))
+
+    def increment_uint64(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: int,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> int:
+        """Atomically increments the uint64 value of the key, see :meth:`increment`."""
+        return int(self.increment(models.ValueType.UINT64, swamp_name, key, value, condition, timeout))
+
+    def increment_float32(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: float,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> float:
+        """Atomically increments the float32 value of the key, see :meth:`increment`."""
+        return float(self.increment(models.ValueType.FLOAT32, swamp_name, key, value, condition, timeout))
+
+    def increment_float64(
+        self,
+        swamp_name: Name,
+        key: str,
+        value: float,
+        condition: Condition | None = None,
+        timeout: float | None = None,
+    ) -> float:
+        """Atomically increments the float64 value of the key, see :meth:`increment`."""
+        return float(self.increment(models.ValueType.FLOAT64, swamp_name, key, value, condition, timeout))
+
+    # 🧮 Uint32 slices
+
+    def uint32_slice_push(
+        self, swamp_name: Name, key_values: dict[str, list[int]], timeout: float | None = None
+    ) -> None:
+        """Adds the values to the uint32 slices of the keys. The existing values are not duplicated."""
+        request = pb.AddToUint32SlicePushRequest(
+            IslandID=self._island(swamp_name),
+            SwampName=swamp_name.get(),
+            KeySlicePairs=[pb.KeySlicePair(Key=k, Values=v) for k, v in key_values.items()],
+        )
+        self._call(swamp_name, "Uint32SlicePush", request, timeout)
+
+    def uint32_slice_delete(
+        self, swamp_name: Name, key_values: dict[str, list[int]], timeout: float | None = None
+    ) -> None:
+        """Removes the values from the uint32 slices of the keys. An emptied slice is deleted."""
+        request = pb.Uint32SliceDeleteRequest(
+            IslandID=self._island(swamp_name),
+            SwampName=swamp_name.get(),
+            KeySlicePairs=[pb.KeySlicePair(Key=k, Values=v) for k, v in key_values.items()],
+        )
+        self._call(swamp_name, "Uint32SliceDelete", request, timeout)
+
+    def uint32_slice_size(self, swamp_name: Name, key: str, timeout: float | None = None) -> int:
+        """Returns the number of values in the uint32 slice of the key."""
+        request = pb.Uint32SliceSizeRequest(IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Key=key)
+        return int(self._call(swamp_name, "Uint32SliceSize", request, timeout).Size)
+
+    def uint32_slice_is_value_exist(self, swamp_name: Name, key: str, value: int, timeout: float | None = None) -> bool:
+        """Returns True if the value is in the uint32 slice of the key."""
+        request = pb.Uint32SliceIsValueExistRequest(
+            IslandID=self._island(swamp_name), SwampName=swamp_name.get(), Key=key, Value=value
+        )
+        return bool(self._call(swamp_name, "Uint32SliceIsValueExist", request, timeout).IsExist)
+
+    # 📡 Subscription
+
+    def subscribe(
+        self, swamp_name: Name, get_existing_data: bool, model_type: type[T]
+    ) -> Iterator[tuple[T, EventStatus]]:
+        """Yields the changes of the swamp as (model, status) pairs, until the generator is closed.
+
+        If ``get_existing_data`` is True, the existing treasures are yielded first in the order of their creation
+        time, with NOTHING_CHANGED status. A deleted treasure is yielded with its last content and DELETED status.
+        Closing the generator (or leaving the for loop) cancels the subscription on the server.
+        """
+        if get_existing_data:
+            yield from (
+                (model, EventStatus.NOTHING_CHANGED)
+                for model in self.catalog_read_many(swamp_name, Index(IndexType.CREATION_TIME), model_type)
+            )
+
+        request = pb.SubscribeToEventsRequest(IslandID=self._island(swamp_name), SwampName=swamp_name.get())
+        try:
+            stream = self._client.service_client(swamp_name).SubscribeToEvents(request, metadata=self._metadata)
+        except ConnectionError as err:
+            raise HydraideError(ErrorCode.CONNECTION_ERROR, str(err)) from err
+        try:
+            for event in stream:
+                treasure = event.DeletedTreasure if event.Status == pb.Status.DELETED else event.Treasure
+                yield self._to_catalog(treasure, model_type), _status(event.Status)
+        except grpc.RpcError as err:
+            if err.code() != grpc.StatusCode.CANCELLED:  # type: ignore[attr-defined]
+                raise from_grpc_error(err) from err
+        finally:
+            stream.cancel()
@@ -0,0 +1,369 @@
+"""Conversion between Python dataclass models and the treasures of HydrAIDE.
+
+The models are plain dataclasses. The role of a field is set with the field helpers of this module, the same way the
+``hydraide:"..."`` struct tags of the Go SDK set it::
+
+    @dataclass
+    class User:
+        id: str = key()
+        name: str = value()
+        created_at: datetime | None = created_at()
+        expire_at: datetime | None = expire_at()
+
+🔢 Value types:
+Python has no fixed size numbers, so the stored type of a number is inferred as int64 (``int``) or float64
+(``float``), unless the field helper sets it explicitly, e.g. ``value(ValueType.UINT8)``. To share data with a Go
+service, use the type of the Go model, otherwise the typed index reads and the increments of the Go service will not
+match the stored value.
+
+Supported values: ``str``, ``bool``, ``int``, ``float``, ``bytes`` and ``datetime`` (stored as unix seconds in the
+int64 field, like ``time.Time`` values of the Go SDK). Complex Go values (structs, maps, slices) are GOB-encoded by the
+Go SDK, so they are returned as raw ``bytes`` and can not be written from Python in a Go-readable format.
+"""
+
+from __future__ import annotations
+
+import dataclasses
+import enum
+import typing
+from datetime import datetime, timezone
+from typing import Any, TypeVar
+
+from google.protobuf import timestamp_pb2
+
+from .generated import hydraide_pb2
+
+TAG = "hydraide"
+TAG_KEY = "key"
+TAG_VALUE = "value"
+TAG_CREATED_AT = "createdAt"
+TAG_CREATED_BY = "createdBy"
+TAG_UPDATED_AT = "updatedAt"
+TAG_UPDATED_BY = "updatedBy"
+TAG_EXPIRE_AT = "expireAt"
+TAG_VERSION = "version"
+
+_META_TYPE = "hydraide_type"
+_META_NAME = "hydraide_name"
+_META_OMITEMPTY = "hydraide_omitempty"
+
+T = TypeVar("T")
+
+
+class ValueType(enum.Enum):
+    """The stored type of a value, the same as the type of the field of the Go model."""
+
+    INT8 = "int8"
+    INT16 = "int16"
+    INT32 = "int32"
+    INT64 = "int64"
+    UINT8 = "uint8"
+    UINT16 = "uint16"
+    UINT32 = "uint32"
+    UINT64 = "uint64"
+    FLOAT32 = "float32"
+    FLOAT64 = "float64"
+    STRING = "string"
+    BOOL = "bool"
+    BYTES = "bytes"
+    TIME = "time"  # unix seconds in the int64 field, like time.Time values in the Go SDK
+
+
+_PROTO_FIELDS: dict[ValueType, str] = {
+    ValueType.INT8: "Int8Val",
+    ValueType.INT16: "Int16Val",
+    ValueType.INT32: "Int32Val",
+    ValueType.INT64: "Int64Val",
+    ValueType.UINT8: "Uint8Val",
+    ValueType.UINT16: "Uint16Val",
+    ValueType.UINT32: "Uint32Val",
+    ValueType.UINT64: "Uint64Val",
+    ValueType.FLOAT32: "Float32Val",
+    ValueType.FLOAT64: "Float64Val",
+}
+
+_INT_RANGES: dict[ValueType, tuple[int, int]] = {
+    ValueType.INT8: (-(2**7), 2**7 - 1),
+    ValueType.INT16: (-(2**15), 2**15 - 1),
+    ValueType.INT32: (-(2**31), 2**31 - 1),
+    ValueType.INT64: (-(2**63), 2**63 - 1),
+    ValueType.UINT8: (0, 2**8 - 1),
+    ValueType.UINT16: (0, 2**16 - 1),
+    ValueType.UINT32: (0, 2**32 - 1),
+    ValueType.UINT64: (0, 2**64 - 1),
+}
+
+_VALUE_FIELDS = (
+    "Int8Val",
+    "Int16Val",
+    "Int32Val",
+    "Int64Val",
+    "Uint8Val",
+    "Uint16Val",
+    "Uint32Val",
+    "Uint64Val",
+    "Float32Val",
+    "Float64Val",
+    "StringVal",
+    "BoolVal",
+    "BytesVal",
+)
+
+
+def _field(tag: str, default: Any, **metadata: Any) -> Any:
+    return dataclasses.field(default=default, metadata={TAG: tag, **metadata})
+
+
+def key() -> Any:
+    """Marks the key field of a catalog model. The key must be a non-empty string."""
+    return _field(TAG_KEY, "")
+
+
+def value(value_type: ValueType | None = None, default: Any = None) -> Any:
+    """Marks the value field of a catalog model, optionally with the stored type of the value."""
+    return _field(TAG_VALUE, default, **{_META_TYPE: value_type})
+
+
+def created_at() -> Any:
+    """Marks the creation time metadata field (datetime) of a catalog model."""
+    return _field(TAG_CREATED_AT, None)
+
+
+def created_by() -> Any:
+    """Marks the creator metadata field (str) of a catalog model."""
+    return _field(TAG_CREATED_BY, "")
+
+
+def updated_at() -> Any:
+    """Marks the update time metadata field (datetime) of a catalog model."""
+    return _field(TAG_UPDATED_AT, None)
+
+
+def updated_by() -> Any:
+    """Marks the updater metadata field (str) of a catalog model."""
+    return _field(TAG_UPDATED_BY, "")
+
+
+def expire_at() -> Any:
+    """Marks the expiration time field (datetime) of a catalog model."""
+    return _field(TAG_EXPIRE_AT, None)
+
+
+def version() -> Any:
+    """Marks the schema version field (int) of a catalog model."""
+    return _field(TAG_VERSION, 0)
+
+
+def profile_field(
+    name: str | None = None, value_type: ValueType | None = None, omitempty: bool = False, default: Any = None
+) -> Any:
+    """Configures a field of a profile model.
+
+    Every field of a profile model is stored as a separate treasure, keyed by the field name. The Go SDK uses the
+    exported Go field name as the key (e.g. ``Email``), so set ``name`` to share the profile with a Go service.
+    Empty values of ``omitempty`` fields are not stored.
+    """
+    return dataclasses.field(
+        default=default, metadata={_META_NAME: name, _META_TYPE: value_type, _META_OMITEMPTY: omitempty}
+    )
+
+
+def _ensure_dataclass(model: Any) -> None:
+    if not dataclasses.is_dataclass(model):
+        raise TypeError("the model must be a dataclass")
+
+
+def _is_empty(field_value: Any) -> bool:
+    return field_value is None or (not isinstance(field_value, bool) and field_value in ("", b"", 0, 0.0)) or (
+        isinstance(field_value, (list, dict)) and len(field_value) == 0
+    )
+
+
+def _datetime_hint(cls: type, field: dataclasses.Field[Any]) -> bool:
+    """Returns True if the annotation of the field is datetime or an optional datetime."""
+    hint = typing.get_type_hints(cls).get(field.name)
+    return hint is datetime or datetime in typing.get_args(hint)
+
+
+def _to_timestamp(moment: datetime) -> timestamp_pb2.Timestamp:
+    timestamp = timestamp_pb2.Timestamp()
+    if moment.tzinfo is None:
+        moment = moment.replace(tzinfo=timezone.utc)
+    timestamp.FromDatetime(moment.astimezone(timezone.utc))
+    return timestamp
+
+
+def _from_timestamp(timestamp: timestamp_pb2.Timestamp) -> datetime:
+    return timestamp.ToDatetime(tzinfo=timezone.utc)
+
+
+def _infer_type(field_value: Any) -> ValueType:
+    if isinstance(field_value, bool):
+        return ValueType.BOOL
+    if isinstance(field_value, int):
+        return ValueType.INT64
+    if isinstance(field_value, float):
+        return ValueType.FLOAT64
+    if isinstance(field_value, str):
+        return ValueType.STRING
+    if isinstance(field_value, (bytes, bytearray)):
+        return ValueType.BYTES
+    if isinstance(field_value, datetime):
+        return ValueType.TIME
+    raise TypeError(f"unsupported value type: {type(field_value).__name__}")
+
+
+def set_value(kv_pair: hydraide_pb2.KeyValuePair, field_value: Any, value_type: ValueType | None = None) -> None:
+    """Sets the value to the typed field of the key-value pair. A None value is not set."""
+
+    if field_value is None:
+        return
+    if value_type is None:
+        value_type = _infer_type(field_value)
+
+    if value_type is ValueType.TIME:
+        if not isinstance(field_value, datetime):
+            raise TypeError("a TIME value must be a datetime")
+        if field_value.tzinfo is None:
+            field_value = field_value.replace(tzinfo=timezone.utc)
+        kv_pair.Int64Val = int(field_value.timestamp())
+    elif value_type in _INT_RANGES:
+        if isinstance(field_value, bool) or not isinstance(field_value, int):
+            raise TypeError(f"a {value_type.name} value must be an int")
+        low, high = _INT_RANGES[value_type]
+        if not low <= field_value <= high:
+            raise ValueError(f"{field_value} is out of the range of {value_type.name}")
+        setattr(kv_pair, _PROTO_FIELDS[value_type], field_value)
+    elif value_type in (ValueType.FLOAT32, ValueType.FLOAT64):
+        setattr(kv_pair, _PROTO_FIELDS[value_type], float(field_value))
+    elif value_type is ValueType.STRING:
+        kv_pair.StringVal = str(field_value)
+    elif value_type is ValueType.BOOL:
+        # HydrAIDE uses an enum for the booleans, so a false value can be stored explicitly
+        kv_pair.BoolVal = hydraide_pb2.Boolean.TRUE if field_value else hydraide_pb2.Boolean.FALSE
+    elif value_type is ValueType.BYTES:
+        kv_pair.BytesVal = bytes(field_value)
+
+
+def get_value(treasure: hydraide_pb2.Treasure, as_datetime: bool = False) -> Any:
+    """Returns the value of the treasure from whichever typed field is set, or None if the treasure has no value."""
+
+    for field_name in _VALUE_FIELDS:
+        if not treasure.HasField(field_name):
+            continue
+        field_value = getattr(treasure, field_name)
+        if field_name == "BoolVal":
+            return field_value == hydraide_pb2.Boolean.TRUE
+        if field_name == "Int64Val" and as_datetime:
+            return datetime.fromtimestamp(field_value, tz=timezone.utc)
+        return field_value
+    return None
+
+
+def catalog_to_key_value_pair(model: Any) -> hydraide_pb2.KeyValuePair:
+    """Converts a catalog model to a key-value pair, like convertCatalogModelToKeyValuePair of the Go SDK."""
+
+    _ensure_dataclass(model)
+
+    kv_pair = hydraide_pb2.KeyValuePair()
+    has_value = False
+
+    for field in dataclasses.fields(model):
+        tag = field.metadata.get(TAG)
+        field_value = getattr(model, field.name)
+
+        if tag == TAG_KEY:
+            if not isinstance(field_value, str) or field_value == "":
+                raise ValueError("key field must be a non-empty string")
+            kv_pair.Key = field_value
+        elif tag == TAG_VALUE:
+            if field_value is not None:
+                set_value(kv_pair, field_value, field.metadata.get(_META_TYPE))
+                has_value = True
+        elif tag == TAG_VERSION:
+            if field_value:
+                kv_pair.SchemaVersion = int(field_value)
+        elif tag in (TAG_CREATED_AT, TAG_UPDATED_AT, TAG_EXPIRE_AT):
+            if field_value is not None:
+                if not isinstance(field_value, datetime):
+                    raise TypeError(f"{tag} field must be a datetime")
+                kv_pair_field = {TAG_CREATED_AT: "CreatedAt", TAG_UPDATED_AT: "UpdatedAt", TAG_EXPIRE_AT: "ExpiredAt"}
+                getattr(kv_pair, kv_pair_field[tag]).CopyFrom(_to_timestamp(field_value))
+        elif tag in (TAG_CREATED_BY, TAG_UPDATED_BY):
+            if field_value:
+                setattr(kv_pair, "CreatedBy" if tag == TAG_CREATED_BY else "UpdatedBy", str(field_value))
+
+    if kv_pair.Key == "":
+        raise ValueError("key field not found")
+
+    if not has_value:
+        kv_pair.VoidVal = True
+
+    return kv_pair
+
+
+def treasure_to_catalog(treasure: hydraide_pb2.Treasure, model_type: type[T]) -> T:
+    """Converts a treasure to a new instance of the catalog model."""
+
+    _ensure_dataclass(model_type)
+
+    values: dict[str, Any] = {}
+    for field in dataclasses.fields(model_type):  # type: ignore[arg-type]
+        tag = field.metadata.get(TAG)
+        if tag == TAG_KEY:
+            values[field.name] = treasure.Key
+        elif tag == TAG_VALUE:
+            as_datetime = field.metadata.get(_META_TYPE) is ValueType.TIME or _datetime_hint(model_type, field)
+            values[field.name] = get_value(treasure, as_datetime)
+        elif tag == TAG_VERSION and treasure.HasField("SchemaVersion"):
+            values[field.name] = treasure.SchemaVersion
+        elif tag == TAG_CREATED_AT and treasure.HasField("CreatedAt"):
+            values[field.name] = _from_timestamp(treasure.CreatedAt)
+        elif tag == TAG_UPDATED_AT and treasure.HasField("UpdatedAt"):
+            values[field.name] = _from_timestamp(treasure.UpdatedAt)
+        elif tag == TAG_EXPIRE_AT and treasure.HasField("ExpiredAt"):
+            values[field.name] = _from_timestamp(treasure.ExpiredAt)
+        elif tag == TAG_CREATED_BY and treasure.HasField("CreatedBy"):
+            values[field.name] = treasure.CreatedBy
+        elif tag == TAG_UPDATED_BY and treasure.HasField("UpdatedBy"):
+            values[field.name] = treasure.UpdatedBy
+
+    return model_type(**values)
+
+
+def _profile_key(field: dataclasses.Field[Any]) -> str:
+    return field.metadata.get(_META_NAME) or field.name
+
+
+def profile_keys(model_type: type) -> list[str]:
+    """Returns the keys of the treasures of a profile model."""
+    _ensure_dataclass(model_type)
+    return [_profile_key(field) for field in dataclasses.fields(model_type)]
+
+
+def profile_to_key_value_pairs(model: Any) -> list[hydraide_pb2.KeyValuePair]:
+    """Converts a profile model to key-value pairs, one pair per field, keyed by the field name."""
+
+    _ensure_dataclass(model)
+
+    kv_pairs = []
+    for field in dataclasses.fields(model):
+        field_value = getattr(model, field.name)
+        if field.metadata.get(_META_OMITEMPTY) and _is_empty(field_value):
+            continue
+        kv_pair = hydraide_pb2.KeyValuePair(Key=_profile_key(field))
+        set_value(kv_pair, field_value, field.metadata.get(_META_TYPE))
+        kv_pairs.append(kv_pair)
+
+    return kv_pairs
+
+
+def set_treasure_to_profile(model: Any, treasure: hydraide_pb2.Treasure) -> None:
+    """Sets the value of the treasure to the field of the profile model with the same key."""
+
+    for field in dataclasses.fields(model):
+        if _profile_key(field) != treasure.Key:
+            continue
+        as_datetime = field.metadata.get(_META_TYPE) is ValueType.TIME or _datetime_hint(type(model), field)
+        setattr(model, field.name, get_value(treasure, as_datetime))
+        return
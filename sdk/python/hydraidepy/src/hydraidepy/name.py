@@ -0,0 +1,86 @@
+"""Hierarchical swamp names and the island routing of HydrAIDE.
+
+A swamp name has three levels: ``sanctuary/realm/swamp``. The island of the swamp is calculated from the xxhash of
+the three segments, the same way as the Go SDK calculates it, so a Python and a Go service always route the same
+swamp to the same island and the same server, and they can share data on the same cluster.
+
+Example::
+
+    swamp = Name().sanctuary("users").realm("profiles").swamp("john.doe")
+    swamp.get()                # "users/profiles/john.doe"
+    swamp.island_id(1000)      # 518 - the same island as in Go
+"""
+
+from __future__ import annotations
+
+from ._xxhash import xxh64
+
+WILDCARD = "*"
+
+
+class Name:
+    """An immutable, hierarchical swamp name.
+
+    Build it by chaining :meth:`sanctuary`, :meth:`realm` and :meth:`swamp`. Every call returns a new instance,
+    so a partial name (e.g. the sanctuary and the realm) can be reused as a prefix.
+    """
+
+    __slots__ = ("_sanctuary_id", "_realm_name", "_swamp_name", "_path")
+
+    def __init__(self, sanctuary_id: str = "", realm_name: str = "", swamp_name: str = "", path: str = "") -> None:
+        self._sanctuary_id = sanctuary_id
+        self._realm_name = realm_name
+        self._swamp_name = swamp_name
+        self._path = path
+
+    def sanctuary(self, sanctuary_id: str) -> Name:
+        """Sets the top-level domain of the name (e.g. "users", "products")."""
+        return Name(sanctuary_id, "", "", sanctuary_id)
+
+    def realm(self, realm_name: str) -> Name:
+        """Sets the second level of the name under the sanctuary (e.g. "profiles", "settings")."""
+        return Name(self._sanctuary_id, realm_name, "", self._path + "/" + realm_name)
+
+    def swamp(self, swamp_name: str) -> Name:
+        """Sets the last level of the name, the swamp itself. The path becomes sanctuary/realm/swamp."""
+        return Name(self._sanctuary_id, self._realm_name, swamp_name, self._path + "/" + swamp_name)
+
+    def get(self) -> str:
+        """Returns the full path of the name in the sanctuary/realm/swamp format."""
+        return self._path
+
+    def island_id(self, all_islands: int) -> int:
+        """Returns the deterministic, 1-based island ID of the swamp.
+
+        The ID is the xxhash of the sanctuary, realm and swamp segments mapped into the ``all_islands`` range,
+        byte-for-byte identical with the GetIslandID of the Go SDK. ``all_islands`` must be the same in every
+        client of the cluster, otherwise the clients route the same swamp to different islands.
+        """
+        if all_islands <= 0:
+            raise ValueError("all_islands must be positive")
+        hash_value = xxh64((self._sanctuary_id + self._realm_name + self._swamp_name).encode("utf-8"))
+        return hash_value % all_islands + 1
+
+    def is_wildcard_pattern(self) -> bool:
+        """Returns True if any segment of the name is the "*" wildcard."""
+        return WILDCARD in (self._sanctuary_id, self._realm_name, self._swamp_name)
+
+    def __str__(self) -> str:
+        return self._path
+
+    def __repr__(self) -> str:
+        return f"Name({self._path!r})"
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Name) and self._path == other._path
+
+    def __hash__(self) -> int:
+        return hash(self._path)
+
+
+def load(path: str) -> Name:
+    """Reconstructs a name from a sanctuary/realm/swamp path."""
+    segments = path.split("/")
+    if len(segments) < 3:
+        raise ValueError(f"invalid swamp name: {path!r}, expected sanctuary/realm/swamp")
+    return Name().sanctuary(segments[0]).realm(segments[1]).swamp(segments[2])
@@ -0,0 +1,79 @@
+from typing import Any
+
+import grpc
+from google.protobuf import any_pb2
+
+from hydraidepy.errors import _RPC_MESSAGES, ErrorCode, HydraideError, from_grpc_error
+
+
+class FakeRpcError(grpc.RpcError, grpc.Call):  # type: ignore[misc]
+    def __init__(self, code: grpc.StatusCode, details: str, trailers: tuple[tuple[str, Any], ...] = ()) -> None:
+        self._code = code
+        self._details = details
+        self._trailers = trailers
+
+    def code(self) -> grpc.StatusCode:
+        return self._code
+
+    def details(self) -> str:
+        return self._details
+
+    def trailing_metadata(self) -> tuple[tuple[str, Any], ...]:
+        return self._trailers
+
+    def initial_metadata(self) -> tuple[tuple[str, Any], ...]:
+        return ()
+
+    def is_active(self) -> bool:
+        return False
+
+    def time_remaining(self) -> float | None:
+        return None
+
+    def cancel(self) -> bool:
+        return False
+
+    def add_callback(self, callback: Any) -> bool:
+        return False
+
+
+def status_trailers(reason: str, retry_seconds: int = 0) -> tuple[tuple[str, bytes], ...]:
+    status = _RPC_MESSAGES["Status"](code=8, message="too many requests")
+
+    error_info = any_pb2.Any(type_url="type.googleapis.com/google.rpc.ErrorInfo")
+    error_info.value = _RPC_MESSAGES["ErrorInfo"](reason=reason, domain="hydraide").SerializeToString()
+    status.details.append(error_info)
+
+    if retry_seconds:
+        retry_info = _RPC_MESSAGES["RetryInfo"]()
+        retry_info.retry_delay.seconds = retry_seconds
+        detail = any_pb2.Any(type_url="type.googleapis.com/google.rpc.RetryInfo", value=retry_info.SerializeToString())
+        status.details.append(detail)
+
+    return (("grpc-status-details-bin", status.SerializeToString()),)
+
+
+def test_reason_has_priority_over_status_code() -> None:
+    err = from_grpc_error(FakeRpcError(grpc.StatusCode.FAILED_PRECONDITION, "key", status_trailers("KEY_NOT_FOUND")))
+    assert err.code == ErrorCode.NOT_FOUND
+
+    err = from_grpc_error(
+        FakeRpcError(grpc.StatusCode.RESOURCE_EXHAUSTED, "limit", status_trailers("QUOTA_EXCEEDED", retry_seconds=3))
+    )
+    assert err.code == ErrorCode.QUOTA_EXCEEDED
+    assert err.retry_after == 3
+
+
+def test_status_code_fallback() -> None:
+    assert from_grpc_error(FakeRpcError(grpc.StatusCode.UNAVAILABLE, "")).code == ErrorCode.CONNECTION_ERROR
+    assert from_grpc_error(FakeRpcError(grpc.StatusCode.DEADLINE_EXCEEDED, "")).code == ErrorCode.CTX_TIMEOUT
+    assert from_grpc_error(FakeRpcError(grpc.StatusCode.FAILED_PRECONDITION, "")).code == ErrorCode.SWAMP_NOT_FOUND
+    assert from_grpc_error(FakeRpcError(grpc.StatusCode.INTERNAL, "")).code == ErrorCode.INTERNAL_DATABASE_ERROR
+    assert from_grpc_error(ValueError("boom")).code == ErrorCode.UNKNOWN
+
+
+def test_error_codes_match_the_go_sdk() -> None:
+    assert ErrorCode.CONNECTION_ERROR == 0
+    assert ErrorCode.CONDITION_NOT_MET == 10
+    assert ErrorCode.QUOTA_EXCEEDED == 12
+    assert str(HydraideError(ErrorCode.NOT_FOUND, "key not found")) == "Code: 7, Message: key not found"
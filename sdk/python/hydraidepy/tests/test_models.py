@@ -0,0 +1,103 @@
+from dataclasses import dataclass
+from datetime import datetime, timezone
+
+import pytest
+
+from hydraidepy.generated import hydraide_pb2
+from hydraidepy.models import (
+    ValueType,
+    catalog_to_key_value_pair,
+    created_at,
+    created_by,
+    expire_at,
+    key,
+    profile_field,
+    profile_keys,
+    profile_to_key_value_pairs,
+    set_treasure_to_profile,
+    treasure_to_catalog,
+    value,
+)
+
+
+@dataclass
+class Score:
+    user_id: str = key()
+    points: int | None = value(ValueType.UINT16)
+    created_at: datetime | None = created_at()
+    created_by: str = created_by()
+    expire_at: datetime | None = expire_at()
+
+
+@dataclass
+class Visit:
+    user_id: str = key()
+    at: datetime | None = value()
+
+
+@dataclass
+class Marker:
+    id: str = key()
+
+
+@dataclass
+class Profile:
+    email: str = profile_field(name="Email", default="")
+    age: int = profile_field(name="Age", value_type=ValueType.UINT8, default=0)
+    verified: bool = profile_field(name="Verified", default=False)
+    nickname: str = profile_field(name="Nickname", omitempty=True, default="")
+
+
+def test_catalog_round_trip() -> None:
+    created = datetime(2025, 1, 2, 3, 4, 5, tzinfo=timezone.utc)
+    kv_pair = catalog_to_key_value_pair(Score(user_id="alex", points=300, created_at=created, created_by="importer"))
+
+    assert kv_pair.Key == "alex"
+    assert kv_pair.HasField("Uint16Val") and kv_pair.Uint16Val == 300
+    assert not kv_pair.HasField("Int64Val")
+    assert kv_pair.CreatedAt.ToDatetime(tzinfo=timezone.utc) == created
+    assert kv_pair.CreatedBy == "importer"
+    assert not kv_pair.HasField("ExpiredAt")
+    assert not kv_pair.HasField("VoidVal")
+
+    treasure = hydraide_pb2.Treasure(IsExist=True, Key="alex", Uint16Val=300, CreatedBy="importer")
+    treasure.CreatedAt.CopyFrom(kv_pair.CreatedAt)
+
+    assert treasure_to_catalog(treasure, Score) == Score(
+        user_id="alex", points=300, created_at=created, created_by="importer"
+    )
+
+
+def test_catalog_time_value_is_unix_seconds() -> None:
+    at = datetime(2025, 6, 1, tzinfo=timezone.utc)
+    kv_pair = catalog_to_key_value_pair(Visit(user_id="alex", at=at))
+
+    assert kv_pair.Int64Val == int(at.timestamp())
+    assert treasure_to_catalog(hydraide_pb2.Treasure(Key="alex", Int64Val=kv_pair.Int64Val), Visit).at == at
+
+
+def test_catalog_void_and_invalid_models() -> None:
+    assert catalog_to_key_value_pair(Marker(id="m1")).VoidVal is True
+
+    with pytest.raises(ValueError):
+        catalog_to_key_value_pair(Marker(id=""))
+    with pytest.raises(ValueError):
+        catalog_to_key_value_pair(Score(user_id="alex", points=70000))
+    with pytest.raises(TypeError):
+        catalog_to_key_value_pair("not a dataclass")
+
+
+def test_profile_round_trip() -> None:
+    kv_pairs = profile_to_key_value_pairs(Profile(email="alex@example.com", age=42, verified=False))
+
+    assert [kv.Key for kv in kv_pairs] == ["Email", "Age", "Verified"]
+    assert kv_pairs[1].Uint8Val == 42
+    assert kv_pairs[2].BoolVal == hydraide_pb2.Boolean.FALSE
+    assert profile_keys(Profile) == ["Email", "Age", "Verified", "Nickname"]
+
+    profile = Profile()
+    set_treasure_to_profile(profile, hydraide_pb2.Treasure(Key="Email", StringVal="alex@example.com"))
+    set_treasure_to_profile(profile, hydraide_pb2.Treasure(Key="Age", Uint8Val=42))
+    set_treasure_to_profile(profile, hydraide_pb2.Treasure(Key="Verified", BoolVal=hydraide_pb2.Boolean.TRUE))
+
+    assert profile == Profile(email="alex@example.com", age=42, verified=True)
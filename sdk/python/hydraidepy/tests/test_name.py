@@ -0,0 +1,51 @@
+import pytest
+
+from hydraidepy._xxhash import xxh64
+from hydraidepy.name import Name, load
+
+
+@pytest.mark.parametrize(
+    ("data", "expected"),
+    [
+        # reference values of github.com/cespare/xxhash/v2
+        ("", 17241709254077376921),
+        ("a", 15154266338359012955),
+        ("abc", 4952883123889572249),
+        ("0123456789abcdefghijklmnopqrstuvwxyz0123456789", 5396834407657175988),
+    ],
+)
+def test_xxh64(data: str, expected: int) -> None:
+    assert xxh64(data.encode("utf-8")) == expected
+
+
+@pytest.mark.parametrize(
+    ("sanctuary", "realm", "swamp", "expected"),
+    [
+        # island IDs calculated by the name package of the Go SDK with 1000 islands
+        ("users", "profiles", "john.doe", 518),
+        ("products", "catalog", "2025", 986),
+        ("a", "b", "c", 250),
+        ("sanctuary", "realm", "swamp-with-a-very-long-name-over-32-bytes", 73),
+        ("ü", "ő", "日本", 830),
+    ],
+)
+def test_island_id_matches_go(sanctuary: str, realm: str, swamp: str, expected: int) -> None:
+    assert Name().sanctuary(sanctuary).realm(realm).swamp(swamp).island_id(1000) == expected
+
+
+def test_name() -> None:
+    name = Name().sanctuary("users").realm("profiles").swamp("john.doe")
+
+    assert name.get() == "users/profiles/john.doe"
+    assert str(name) == "users/profiles/john.doe"
+    assert not name.is_wildcard_pattern()
+    assert Name().sanctuary("users").realm("*").swamp("*").is_wildcard_pattern()
+
+    loaded = load("users/profiles/john.doe")
+    assert loaded == name
+    assert loaded.island_id(1000) == name.island_id(1000)
+
+    with pytest.raises(ValueError):
+        load("users/profiles")
+    with pytest.raises(ValueError):
+        name.island_id(0)
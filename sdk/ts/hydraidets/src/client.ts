@@ -0,0 +1,176 @@
+/**
+ * Connection handling of the HydrAIDE TypeScript SDK.
+ *
+ * The client opens one TLS connection per HydrAIDE server and routes every swamp to the server that owns its island,
+ * the same way the client package of the Go SDK does. The island ranges of the servers and the number of all islands
+ * must be the same as in the Go services of the cluster.
+ */
+
+import * as grpc from "@grpc/grpc-js";
+import * as protoLoader from "@grpc/proto-loader";
+import { readFileSync } from "node:fs";
+import * as path from "node:path";
+
+import { Name } from "./name";
+
+export const ERROR_NO_CONNECTION = "there is no connection to the HydrAIDE server";
+export const ERROR_CONNECTION = "error while connecting to the server";
+
+// the proto is copied into the package by the build, see the copy-proto script of the package.json
+const PROTO_PATH = path.join(__dirname, "..", "..", "proto", "hydraide.proto");
+
+// the retry policy of the Go SDK, so the two SDKs behave the same during a server restart
+const SERVICE_CONFIG = JSON.stringify({
+  methodConfig: [
+    {
+      name: [{ service: "hydraidepbgo.HydraideService" }],
+      waitForReady: true,
+      retryPolicy: {
+        maxAttempts: 100,
+        initialBackoff: "0.5s",
+        maxBackoff: "10s",
+        backoffMultiplier: 1.5,
+        retryableStatusCodes: ["UNAVAILABLE", "DEADLINE_EXCEEDED", "RESOURCE_EXHAUSTED", "INTERNAL", "UNKNOWN"],
+      },
+    },
+  ],
+});
+
+/** The options of the proto loader. The proto field names are kept and the 64 bit numbers are decimal strings. */
+export const PROTO_LOADER_OPTIONS: protoLoader.Options = {
+  keepCase: true,
+  longs: String,
+  enums: String,
+  defaults: true,
+  oneofs: true,
+};
+
+/** A HydrAIDE server and the island range it owns (both ends inclusive). */
+export interface Server {
+  host: string;
+  fromIsland: number;
+  toIsland: number;
+  certFilePath: string;
+}
+
+/** The generated service client. The methods are called through {@link ServiceClient} with their proto names. */
+export type ServiceClient = grpc.Client & Record<string, (...args: unknown[]) => unknown>;
+
+type ServiceClientConstructor = new (
+  address: string,
+  credentials: grpc.ChannelCredentials,
+  options?: grpc.ChannelOptions,
+) => ServiceClient;
+
+let serviceConstructor: ServiceClientConstructor | undefined;
+
+function loadService(): ServiceClientConstructor {
+  if (!serviceConstructor) {
+    const definition = protoLoader.loadSync(PROTO_PATH, PROTO_LOADER_OPTIONS);
+    const loaded = grpc.loadPackageDefinition(definition) as unknown as {
+      hydraidepbgo: { HydraideService: ServiceClientConstructor };
+    };
+    serviceConstructor = loaded.hydraidepbgo.HydraideService;
+  }
+  return serviceConstructor;
+}
+
+/**
+ * Manages the connections to the HydrAIDE servers and routes the swamps to them by island.
+ *
+ * @param servers the servers of the cluster with their island ranges
+ * @param allIslands the number of all islands of the cluster, the same as in every other client
+ * @param maxMessageSize the maximum size of the sent and received messages in bytes
+ */
+export class Client {
+  private serviceClients = new Map<number, { client: ServiceClient; host: string }>();
+  private uniqueServices: ServiceClient[] = [];
+
+  constructor(
+    private readonly servers: Server[],
+    readonly allIslands: number,
+    private readonly maxMessageSize: number,
+  ) {}
+
+  /**
+   * Connects to all servers and checks them with a heartbeat. Rejects if any server is unreachable, but the
+   * reachable servers are connected anyway.
+   */
+  async connect(): Promise<void> {
+    const HydraideService = loadService();
+    let failed = false;
+
+    for (const server of this.servers) {
+      let credentials: grpc.ChannelCredentials;
+      try {
+        credentials = grpc.credentials.createSsl(readFileSync(server.certFilePath));
+      } catch (err) {
+        console.error("error while loading TLS credentials", { error: err, server: server.host });
+        failed = true;
+        continue;
+      }
+
+      const client = new HydraideService(server.host, credentials, {
+        "grpc.max_send_message_length": this.maxMessageSize,
+        "grpc.max_receive_message_length": this.maxMessageSize,
+        "grpc.service_config": SERVICE_CONFIG,
+        "grpc.keepalive_time_ms": 60_000,
+        "grpc.keepalive_timeout_ms": 10_000,
+        "grpc.keepalive_permit_without_calls": 0,
+      });
+
+      try {
+        const pong = await new Promise<{ Pong: string }>((resolve, reject) => {
+          client.Heartbeat({ Ping: "beat" }, { deadline: Date.now() + 5000 }, (err: unknown, res: { Pong: string }) =>
+            err ? reject(err) : resolve(res),
+          );
+        });
+        if (pong.Pong !== "beat") {
+          throw new Error(`wrong heartbeat response: ${pong.Pong}`);
+        }
+      } catch (err) {
+        console.error("error while sending heartbeat request", { error: err, server: server.host });
+        client.close();
+        failed = true;
+        continue;
+      }
+
+      for (let island = server.fromIsland; island <= server.toIsland; island++) {
+        this.serviceClients.set(island, { client, host: server.host });
+      }
+      this.uniqueServices.push(client);
+    }
+
+    if (failed) {
+      throw new Error(ERROR_CONNECTION);
+    }
+  }
+
+  /** Closes all connections. */
+  close(): void {
+    for (const client of this.uniqueServices) {
+      client.close();
+    }
+    this.serviceClients.clear();
+    this.uniqueServices = [];
+  }
+
+  /** Returns the service client of the server that owns the island of the swamp. */
+  serviceClient(swampName: Name): ServiceClient {
+    return this.serviceClientAndHost(swampName).client;
+  }
+
+  /** Returns the service client and the host of the server that owns the island of the swamp. */
+  serviceClientAndHost(swampName: Name): { client: ServiceClient; host: string } {
+    const entry = this.serviceClients.get(swampName.islandId(this.allIslands));
+    if (!entry) {
+      throw new Error(`${ERROR_NO_CONNECTION}: ${swampName.get()}`);
+    }
+    return entry;
+  }
+
+  /** Returns one service client per connected server. */
+  uniqueServiceClients(): ServiceClient[] {
+    return [...this.uniqueServices];
+  }
+}
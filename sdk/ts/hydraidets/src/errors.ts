@@ -0,0 +1,204 @@
+/**
+ * Errors of the HydrAIDE TypeScript SDK.
+ *
+ * Every failed operation rejects with a {@link HydraideError} with an {@link ErrorCode} that has the same meaning
+ * (and the same numeric value) as the ErrorCode of the Go SDK.
+ */
+
+import { Metadata, ServiceError, status as grpcStatus } from "@grpc/grpc-js";
+import * as protobuf from "protobufjs";
+
+export const ERROR_DOMAIN = "hydraide";
+
+export const MESSAGE_CONNECTION_ERROR = "connection error";
+export const MESSAGE_CTX_TIMEOUT = "context timeout exceeded";
+export const MESSAGE_CTX_CLOSED_BY_CLIENT = "context closed by client";
+export const MESSAGE_INVALID_ARGUMENT = "invalid argument";
+export const MESSAGE_NOT_FOUND = "sanctuary not found";
+export const MESSAGE_UNKNOWN = "unknown error";
+export const MESSAGE_SWAMP_NOT_FOUND = "swamp not found";
+export const MESSAGE_INTERNAL_ERROR = "internal error";
+export const MESSAGE_KEY_ALREADY_EXISTS = "key already exists";
+export const MESSAGE_KEY_NOT_FOUND = "key not found";
+export const MESSAGE_QUOTA_EXCEEDED = "quota exceeded";
+export const MESSAGE_WRONG_VALUE_TYPE = "wrong value type";
+
+/** The error codes of the SDK, in the same order as in the Go SDK. */
+export enum ErrorCode {
+  ConnectionError = 0,
+  InternalDatabaseError = 1,
+  CtxClosedByClient = 2,
+  CtxTimeout = 3,
+  SwampNotFound = 4,
+  FailedPrecondition = 5,
+  InvalidArgument = 6,
+  NotFound = 7,
+  AlreadyExists = 8,
+  InvalidModel = 9,
+  ConditionNotMet = 10,
+  Unknown = 11,
+  QuotaExceeded = 12,
+}
+
+/**
+ * A structured error of a HydrAIDE operation.
+ *
+ * `retryAfterMs` is the time to wait before retrying, if the server sent it (e.g. rate limited requests), otherwise 0.
+ */
+export class HydraideError extends Error {
+  constructor(
+    readonly code: ErrorCode,
+    readonly detail: string,
+    readonly retryAfterMs: number = 0,
+  ) {
+    super(`Code: ${code}, Message: ${detail}`);
+    this.name = "HydraideError";
+  }
+}
+
+// the gRPC trailer of the google.rpc.Status with the details of the error
+const STATUS_DETAILS_KEY = "grpc-status-details-bin";
+
+// only the Status, ErrorInfo and RetryInfo messages of the googleapis protos are needed, so they are declared here
+// (with wire-compatible copies of Any and Duration) instead of shipping the googleapis proto files
+const rpcRoot = protobuf.Root.fromJSON({
+  nested: {
+    google: {
+      nested: {
+        rpc: {
+          nested: {
+            Any: { fields: { typeUrl: { type: "string", id: 1 }, value: { type: "bytes", id: 2 } } },
+            Duration: { fields: { seconds: { type: "int64", id: 1 }, nanos: { type: "int32", id: 2 } } },
+            Status: {
+              fields: {
+                code: { type: "int32", id: 1 },
+                message: { type: "string", id: 2 },
+                details: { rule: "repeated", type: "Any", id: 3 },
+              },
+            },
+            ErrorInfo: { fields: { reason: { type: "string", id: 1 }, domain: { type: "string", id: 2 } } },
+            RetryInfo: { fields: { retryDelay: { type: "Duration", id: 1 } } },
+          },
+        },
+      },
+    },
+  },
+});
+
+const StatusType = rpcRoot.lookupType("google.rpc.Status");
+const ErrorInfoType = rpcRoot.lookupType("google.rpc.ErrorInfo");
+const RetryInfoType = rpcRoot.lookupType("google.rpc.RetryInfo");
+
+interface StatusDetails {
+  reasons: Array<{ domain: string; reason: string }>;
+  retryAfterMs: number;
+}
+
+/** Returns the ErrorInfo reasons and the RetryInfo delay of the error, if the server sent them. */
+function statusDetails(metadata: Metadata | undefined): StatusDetails {
+  const result: StatusDetails = { reasons: [], retryAfterMs: 0 };
+  const raw = metadata?.get(STATUS_DETAILS_KEY)[0];
+  if (!raw || typeof raw === "string") {
+    return result;
+  }
+
+  const status = StatusType.toObject(StatusType.decode(raw), { longs: Number, defaults: true });
+  for (const detail of status.details as Array<{ typeUrl: string; value: Uint8Array }>) {
+    const messageName = detail.typeUrl.slice(detail.typeUrl.lastIndexOf("/") + 1);
+    if (messageName === "google.rpc.ErrorInfo") {
+      const info = ErrorInfoType.toObject(ErrorInfoType.decode(detail.value), { defaults: true });
+      result.reasons.push({ domain: info.domain, reason: info.reason });
+    } else if (messageName === "google.rpc.RetryInfo") {
+      const info = RetryInfoType.toObject(RetryInfoType.decode(detail.value), { longs: Number, defaults: true });
+      const delay = info.retryDelay ?? { seconds: 0, nanos: 0 };
+      result.retryAfterMs = delay.seconds * 1000 + Math.floor(delay.nanos / 1e6);
+    }
+  }
+  return result;
+}
+
+/**
+ * Maps the machine-readable reason of the server to an SDK error. The reason is more precise than the status code,
+ * so it has priority over it. Returns undefined if the error carries no known reason.
+ */
+function errorFromReason(details: StatusDetails, message: string): HydraideError | undefined {
+  for (const { domain, reason } of details.reasons) {
+    if (domain !== ERROR_DOMAIN) {
+      continue;
+    }
+    switch (reason) {
+      case "SWAMP_NOT_FOUND":
+        return new HydraideError(ErrorCode.SwampNotFound, `${MESSAGE_SWAMP_NOT_FOUND}: ${message}`);
+      case "KEY_NOT_FOUND":
+        return new HydraideError(ErrorCode.NotFound, `${MESSAGE_KEY_NOT_FOUND}: ${message}`);
+      case "KEY_EXISTS":
+        return new HydraideError(ErrorCode.AlreadyExists, `${MESSAGE_KEY_ALREADY_EXISTS}: ${message}`);
+      case "CONDITION_NOT_MET":
+        return new HydraideError(ErrorCode.ConditionNotMet, message);
+      case "QUOTA_EXCEEDED":
+        return new HydraideError(
+          ErrorCode.QuotaExceeded,
+          `${MESSAGE_QUOTA_EXCEEDED}: ${message}`,
+          details.retryAfterMs,
+        );
+      case "INVALID_ARGUMENT":
+      case "INVALID_FILTER_EXPRESSION":
+        return new HydraideError(ErrorCode.InvalidArgument, `${MESSAGE_INVALID_ARGUMENT}: ${message}`);
+      case "WRONG_VALUE_TYPE":
+        return new HydraideError(ErrorCode.FailedPrecondition, `${MESSAGE_WRONG_VALUE_TYPE}: ${message}`);
+      case "VALUE_INDEX_NOT_ENABLED":
+        return new HydraideError(ErrorCode.FailedPrecondition, message);
+      case "LOCK_NOT_FOUND":
+        return new HydraideError(ErrorCode.NotFound, message);
+      case "LOCK_DEADLINE_EXCEEDED":
+        return new HydraideError(ErrorCode.CtxTimeout, message);
+      case "INTERNAL":
+        return new HydraideError(ErrorCode.InternalDatabaseError, `${MESSAGE_INTERNAL_ERROR}: ${message}`);
+      default:
+      // unspecified reason, the status code decides
+    }
+  }
+  return undefined;
+}
+
+function isServiceError(err: unknown): err is ServiceError {
+  return typeof err === "object" && err !== null && "code" in err && "metadata" in err;
+}
+
+/**
+ * Converts a gRPC error to a HydraideError. The reason sent by the server decides first, then the gRPC status code,
+ * like in the errorHandler of the Go SDK.
+ */
+export function fromGrpcError(err: unknown): HydraideError {
+  if (err instanceof HydraideError) {
+    return err;
+  }
+  if (!isServiceError(err)) {
+    return new HydraideError(ErrorCode.Unknown, `${MESSAGE_UNKNOWN}: ${String(err)}`);
+  }
+
+  const message = err.details ?? "";
+  const reasonError = errorFromReason(statusDetails(err.metadata), message);
+  if (reasonError) {
+    return reasonError;
+  }
+
+  switch (err.code) {
+    case grpcStatus.UNAVAILABLE:
+      return new HydraideError(ErrorCode.ConnectionError, MESSAGE_CONNECTION_ERROR);
+    case grpcStatus.DEADLINE_EXCEEDED:
+      return new HydraideError(ErrorCode.CtxTimeout, MESSAGE_CTX_TIMEOUT);
+    case grpcStatus.CANCELLED:
+      return new HydraideError(ErrorCode.CtxClosedByClient, MESSAGE_CTX_CLOSED_BY_CLIENT);
+    case grpcStatus.FAILED_PRECONDITION:
+      return new HydraideError(ErrorCode.SwampNotFound, `${MESSAGE_SWAMP_NOT_FOUND}: ${message}`);
+    case grpcStatus.INVALID_ARGUMENT:
+      return new HydraideError(ErrorCode.InvalidArgument, `${MESSAGE_INVALID_ARGUMENT}: ${message}`);
+    case grpcStatus.NOT_FOUND:
+      return new HydraideError(ErrorCode.NotFound, `${MESSAGE_NOT_FOUND}: ${message}`);
+    case grpcStatus.INTERNAL:
+      return new HydraideError(ErrorCode.InternalDatabaseError, `${MESSAGE_INTERNAL_ERROR}: ${message}`);
+    default:
+      return new HydraideError(ErrorCode.Unknown, `${MESSAGE_UNKNOWN}: ${message}`);
+  }
+}
@@ -0,0 +1,530 @@
+/**
+ * The HydrAIDE API of the TypeScript SDK.
+ *
+ * {@link Hydraide} mirrors the Catalog, Profile and Subscribe primitives of the Go SDK with camelCase names. The
+ * swamps are routed by the same island hash as in Go, so a TypeScript and a Go service can read and write the same
+ * swamps on the same cluster.
+ *
+ * @example
+ * const client = new Client(
+ *   [{ host: "localhost:4900", fromIsland: 1, toIsland: 1000, certFilePath: "server.crt" }],
+ *   1000,
+ *   10 << 20,
+ * );
+ * await client.connect();
+ * const h = new Hydraide(client);
+ *
+ * await h.catalogSave(new Name().sanctuary("users").realm("catalog").swamp("all"), { key: "alex", value: "Alex" });
+ *
+ * Every method accepts an optional `timeoutMs`, like the context deadline of the Go SDK, and rejects with a
+ * {@link HydraideError} on failure.
+ */
+
+import * as grpc from "@grpc/grpc-js";
+
+import { Client, ServiceClient } from "./client";
+import {
+  ErrorCode,
+  HydraideError,
+  MESSAGE_KEY_ALREADY_EXISTS,
+  MESSAGE_KEY_NOT_FOUND,
+  MESSAGE_SWAMP_NOT_FOUND,
+  MESSAGE_UNKNOWN,
+  fromGrpcError,
+} from "./errors";
+import { Name } from "./name";
+import {
+  CatalogTreasure,
+  ProtoTreasure,
+  Value,
+  ValueType,
+  catalogToKeyValuePair,
+  getValue,
+  profileToKeyValuePairs,
+  treasureToCatalog,
+} from "./values";
+
+// the gRPC metadata key of the client identity used by the rate limits of the server
+export const METADATA_CLIENT_ID = "hydraide-client-id";
+
+/** The field used to sort the treasures during an indexed read. */
+export enum IndexType {
+  Key = "KEY",
+  ValueString = "VALUE_STRING",
+  ValueUint8 = "VALUE_UINT8",
+  ValueUint16 = "VALUE_UINT16",
+  ValueUint32 = "VALUE_UINT32",
+  ValueUint64 = "VALUE_UINT64",
+  ValueInt8 = "VALUE_INT8",
+  ValueInt16 = "VALUE_INT16",
+  ValueInt32 = "VALUE_INT32",
+  ValueInt64 = "VALUE_INT64",
+  ValueFloat32 = "VALUE_FLOAT32",
+  ValueFloat64 = "VALUE_FLOAT64",
+  ExpirationTime = "EXPIRATION_TIME",
+  CreationTime = "CREATION_TIME",
+  UpdateTime = "UPDATE_TIME",
+}
+
+/** The order of an indexed read. */
+export enum IndexOrder {
+  Asc = "ASC",
+  Desc = "DESC",
+}
+
+/** The status of a treasure after a write or in a subscription event, the same as in the Go SDK. */
+export enum EventStatus {
+  Unknown = 0,
+  SwampNotFound = 1,
+  TreasureNotFound = 2,
+  New = 3,
+  Modified = 4,
+  NothingChanged = 5,
+  Deleted = 6,
+}
+
+/**
+ * The settings of an indexed read. `limit` 0 returns all treasures. `filterExpr` is an optional server-side filter
+ * expression, e.g. `value >= 18 AND createdAt > 2024-01-01`.
+ */
+export interface Index {
+  indexType: IndexType;
+  indexOrder?: IndexOrder;
+  from?: number;
+  limit?: number;
+  filterExpr?: string;
+}
+
+/** The options of the reads that return catalog treasures. */
+export interface ReadOptions {
+  /** reads an int64 value as a Date, for the time.Time values of the Go models */
+  valueAsDate?: boolean;
+  timeoutMs?: number;
+}
+
+/** The options of every other call. */
+export interface CallOptions {
+  timeoutMs?: number;
+}
+
+/** The settings of a swamp pattern, see RegisterSwamp of the Go SDK. The durations are in seconds. */
+export interface RegisterSwampRequest {
+  swampPattern: Name;
+  closeAfterIdle: number;
+  isInMemorySwamp?: boolean;
+  writeInterval?: number;
+  maxFileSize?: number;
+  valueIndex?: boolean;
+}
+
+/** An event of a subscription. A deleted treasure is returned with its last content. */
+export interface SubscriptionEvent<V extends Value = Value> {
+  treasure: CatalogTreasure<V>;
+  status: EventStatus;
+}
+
+const STATUSES: Record<string, EventStatus> = {
+  NOT_FOUND: EventStatus.TreasureNotFound,
+  NEW: EventStatus.New,
+  UPDATED: EventStatus.Modified,
+  DELETED: EventStatus.Deleted,
+  NOTHING_CHANGED: EventStatus.NothingChanged,
+};
+
+function toStatus(status: unknown): EventStatus {
+  return STATUSES[String(status)] ?? EventStatus.NothingChanged;
+}
+
+type Response = Record<string, any>; // eslint-disable-line @typescript-eslint/no-explicit-any
+
+/** The HydrAIDE API on top of a connected {@link Client}. */
+export class Hydraide {
+  private readonly metadata: grpc.Metadata;
+
+  /**
+   * @param client the connected client
+   * @param clientId optional identity of the client, sent to the server for the per-client rate limits
+   */
+  constructor(
+    private readonly client: Client,
+    clientId?: string,
+  ) {
+    this.metadata = new grpc.Metadata();
+    if (clientId) {
+      this.metadata.set(METADATA_CLIENT_ID, clientId);
+    }
+  }
+
+  private unary(stub: ServiceClient, method: string, request: object, options?: CallOptions): Promise<Response> {
+    const callOptions: grpc.CallOptions = {};
+    if (options?.timeoutMs !== undefined) {
+      callOptions.deadline = Date.now() + options.timeoutMs;
+    }
+    return new Promise((resolve, reject) => {
+      stub[method](request, this.metadata, callOptions, (err: unknown, response: Response) =>
+        err ? reject(fromGrpcError(err)) : resolve(response),
+      );
+    });
+  }
+
+  private call(swampName: Name, method: string, request: object, options?: CallOptions): Promise<Response> {
+    let stub: ServiceClient;
+    try {
+      stub = this.client.serviceClient(swampName);
+    } catch (err) {
+      return Promise.reject(new HydraideError(ErrorCode.ConnectionError, String(err)));
+    }
+    return this.unary(stub, method, request, options);
+  }
+
+  private swamp(swampName: Name): { IslandID: number; SwampName: string } {
+    return { IslandID: swampName.islandId(this.client.allIslands), SwampName: swampName.get() };
+  }
+
+  private static kvPair(treasure: CatalogTreasure): ProtoTreasure {
+    try {
+      return catalogToKeyValuePair(treasure);
+    } catch (err) {
+      throw new HydraideError(ErrorCode.InvalidModel, String(err));
+    }
+  }
+
+  private set(
+    swampName: Name,
+    keyValues: ProtoTreasure[],
+    createIfNotExist: boolean,
+    overwrite: boolean,
+    options?: CallOptions,
+  ): Promise<Response> {
+    const request = {
+      Swamps: [
+        { ...this.swamp(swampName), KeyValues: keyValues, CreateIfNotExist: createIfNotExist, Overwrite: overwrite },
+      ],
+    };
+    return this.call(swampName, "Set", request, options);
+  }
+
+  // 🫀 Server and swamp management
+
+  /** Checks every connected server. Rejects if any of them doesn't answer. */
+  async heartbeat(options?: CallOptions): Promise<void> {
+    for (const stub of this.client.uniqueServiceClients()) {
+      const pong = await this.unary(stub, "Heartbeat", { Ping: "beat" }, options);
+      if (pong.Pong !== "beat") {
+        throw new HydraideError(ErrorCode.ConnectionError, "wrong heartbeat response");
+      }
+    }
+  }
+
+  /**
+   * Registers the settings of a swamp pattern. A wildcard pattern is registered on every server.
+   * Returns the errors of the servers, or an empty array.
+   */
+  async registerSwamp(request: RegisterSwampRequest, options?: CallOptions): Promise<HydraideError[]> {
+    const message: Record<string, unknown> = {
+      SwampPattern: request.swampPattern.get(),
+      CloseAfterIdle: Math.floor(request.closeAfterIdle),
+      IsInMemorySwamp: request.isInMemorySwamp ?? false,
+      ValueIndex: request.valueIndex ?? false,
+    };
+    if (!request.isInMemorySwamp && request.writeInterval !== undefined && request.maxFileSize !== undefined) {
+      message.WriteInterval = Math.floor(request.writeInterval);
+      message.MaxFileSize = request.maxFileSize;
+    }
+    const stubs = request.swampPattern.isWildcardPattern()
+      ? this.client.uniqueServiceClients()
+      : [this.client.serviceClient(request.swampPattern)];
+    const errors: HydraideError[] = [];
+    for (const stub of stubs) {
+      try {
+        await this.unary(stub, "RegisterSwamp", message, options);
+      } catch (err) {
+        errors.push(fromGrpcError(err));
+      }
+    }
+    return errors;
+  }
+
+  /** Returns true if the swamp exists. */
+  async isSwampExist(swampName: Name, options?: CallOptions): Promise<boolean> {
+    try {
+      const response = await this.call(swampName, "IsSwampExist", this.swamp(swampName), options);
+      return Boolean(response.IsExist);
+    } catch (err) {
+      if (err instanceof HydraideError && err.code === ErrorCode.SwampNotFound) {
+        return false;
+      }
+      throw err;
+    }
+  }
+
+  /** Returns true if the key exists in the swamp. */
+  async isKeyExists(swampName: Name, key: string, options?: CallOptions): Promise<boolean> {
+    const response = await this.call(swampName, "IsKeyExist", { ...this.swamp(swampName), Key: key }, options);
+    return Boolean(response.IsExist);
+  }
+
+  /** Returns the number of treasures in the swamp. */
+  async count(swampName: Name, options?: CallOptions): Promise<number> {
+    const response = await this.call(swampName, "Count", { Swamps: [this.swamp(swampName)] }, options);
+    for (const swamp of response.Swamps) {
+      if (!swamp.IsExist) {
+        throw new HydraideError(ErrorCode.SwampNotFound, MESSAGE_SWAMP_NOT_FOUND);
+      }
+      return Number(swamp.Count);
+    }
+    throw new HydraideError(ErrorCode.Unknown, MESSAGE_UNKNOWN);
+  }
+
+  /** Deletes the swamp with all of its treasures. */
+  async destroy(swampName: Name, options?: CallOptions): Promise<void> {
+    await this.call(swampName, "Destroy", this.swamp(swampName), options);
+  }
+
+  // 📚 Catalog
+
+  /** Creates the treasure. Rejects with AlreadyExists if the key already exists. */
+  async catalogCreate(swampName: Name, treasure: CatalogTreasure, options?: CallOptions): Promise<void> {
+    const result = await this.catalogCreateMany(swampName, [treasure], options);
+    const error = result.get(treasure.key);
+    if (error) {
+      throw error;
+    }
+  }
+
+  /** Creates many treasures in one request. Returns the result per key: null, or an AlreadyExists error. */
+  async catalogCreateMany(
+    swampName: Name,
+    treasures: CatalogTreasure[],
+    options?: CallOptions,
+  ): Promise<Map<string, HydraideError | null>> {
+    const response = await this.set(swampName, treasures.map(Hydraide.kvPair), true, false, options);
+    const result = new Map<string, HydraideError | null>();
+    for (const swamp of response.Swamps) {
+      for (const keyStatus of swamp.KeysAndStatuses) {
+        result.set(
+          keyStatus.Key,
+          keyStatus.Status === "NOTHING_CHANGED"
+            ? new HydraideError(ErrorCode.AlreadyExists, MESSAGE_KEY_ALREADY_EXISTS)
+            : null,
+        );
+      }
+    }
+    return result;
+  }
+
+  /** Reads the treasure of the key. Rejects with NotFound if the key is missing. */
+  async catalogRead<V extends Value = Value>(
+    swampName: Name,
+    key: string,
+    options?: ReadOptions,
+  ): Promise<CatalogTreasure<V>> {
+    const request = { Swamps: [{ ...this.swamp(swampName), Keys: [key] }] };
+    const response = await this.call(swampName, "Get", request, options);
+    for (const swamp of response.Swamps) {
+      for (const treasure of swamp.Treasures) {
+        if (treasure.IsExist) {
+          return treasureToCatalog<V>(treasure, options?.valueAsDate);
+        }
+      }
+    }
+    throw new HydraideError(ErrorCode.NotFound, MESSAGE_KEY_NOT_FOUND);
+  }
+
+  /** Reads the treasures of the swamp in the order of the index. */
+  async catalogReadMany<V extends Value = Value>(
+    swampName: Name,
+    index: Index,
+    options?: ReadOptions,
+  ): Promise<CatalogTreasure<V>[]> {
+    const request: Record<string, unknown> = {
+      ...this.swamp(swampName),
+      IndexType: index.indexType,
+      OrderType: index.indexOrder ?? IndexOrder.Asc,
+      From: index.from ?? 0,
+      Limit: index.limit ?? 0,
+    };
+    if (index.filterExpr) {
+      request.FilterExpr = index.filterExpr;
+    }
+    const response = await this.call(swampName, "GetByIndex", request, options);
+    return (response.Treasures as ProtoTreasure[])
+      .filter((treasure) => treasure.IsExist)
+      .map((treasure) => treasureToCatalog<V>(treasure, options?.valueAsDate));
+  }
+
+  /** Updates an existing treasure. Rejects with SwampNotFound or NotFound if the swamp or the key is missing. */
+  async catalogUpdate(swampName: Name, treasure: CatalogTreasure, options?: CallOptions): Promise<void> {
+    const result = await this.catalogUpdateMany(swampName, [treasure], options);
+    if (result.get(treasure.key) === EventStatus.TreasureNotFound) {
+      throw new HydraideError(ErrorCode.NotFound, MESSAGE_KEY_NOT_FOUND);
+    }
+  }
+
+  /** Updates many existing treasures. Returns the status per key, TreasureNotFound for the missing keys. */
+  async catalogUpdateMany(
+    swampName: Name,
+    treasures: CatalogTreasure[],
+    options?: CallOptions,
+  ): Promise<Map<string, EventStatus>> {
+    const response = await this.set(swampName, treasures.map(Hydraide.kvPair), false, true, options);
+    const result = new Map<string, EventStatus>();
+    for (const swamp of response.Swamps) {
+      if (swamp.ErrorCode === "SwampDoesNotExist") {
+        throw new HydraideError(ErrorCode.SwampNotFound, MESSAGE_SWAMP_NOT_FOUND);
+      }
+      for (const keyStatus of swamp.KeysAndStatuses) {
+        result.set(keyStatus.Key, toStatus(keyStatus.Status));
+      }
+    }
+    return result;
+  }
+
+  /** Deletes the treasure of the key. Rejects with SwampNotFound or NotFound if the swamp or the key is missing. */
+  async catalogDelete(swampName: Name, key: string, options?: CallOptions): Promise<void> {
+    const result = await this.catalogDeleteMany(swampName, [key], options);
+    const error = result.has(key) ? result.get(key) : new HydraideError(ErrorCode.Unknown, MESSAGE_UNKNOWN);
+    if (error) {
+      throw error;
+    }
+  }
+
+  /**
+   * Deletes many treasures. Returns the result per key: null, or a NotFound error.
+   * Rejects with SwampNotFound if the swamp doesn't exist.
+   */
+  async catalogDeleteMany(
+    swampName: Name,
+    keys: string[],
+    options?: CallOptions,
+  ): Promise<Map<string, HydraideError | null>> {
+    const request = { Swamps: [{ ...this.swamp(swampName), Keys: keys }] };
+    const response = await this.call(swampName, "Delete", request, options);
+    const result = new Map<string, HydraideError | null>();
+    for (const swampResponse of response.Responses) {
+      if (swampResponse.ErrorCode === "SwampDoesNotExist") {
+        throw new HydraideError(ErrorCode.SwampNotFound, MESSAGE_SWAMP_NOT_FOUND);
+      }
+      for (const keyStatus of swampResponse.KeyStatuses) {
+        if (keyStatus.Status === "DELETED") {
+          result.set(keyStatus.Key, null);
+        } else if (keyStatus.Status === "NOT_FOUND") {
+          result.set(keyStatus.Key, new HydraideError(ErrorCode.NotFound, `key (${keyStatus.Key}) not found`));
+        }
+      }
+    }
+    return result;
+  }
+
+  /** Creates or overwrites the treasure. Resolves to New, Modified or NothingChanged. */
+  async catalogSave(swampName: Name, treasure: CatalogTreasure, options?: CallOptions): Promise<EventStatus> {
+    const result = await this.catalogSaveMany(swampName, [treasure], options);
+    return result.get(treasure.key) ?? EventStatus.Unknown;
+  }
+
+  /** Creates or overwrites many treasures in one request. Returns the status per key. */
+  async catalogSaveMany(
+    swampName: Name,
+    treasures: CatalogTreasure[],
+    options?: CallOptions,
+  ): Promise<Map<string, EventStatus>> {
+    const response = await this.set(swampName, treasures.map(Hydraide.kvPair), true, true, options);
+    const result = new Map<string, EventStatus>();
+    for (const swamp of response.Swamps) {
+      for (const keyStatus of swamp.KeysAndStatuses) {
+        result.set(keyStatus.Key, toStatus(keyStatus.Status));
+      }
+    }
+    return result;
+  }
+
+  // 👤 Profile
+
+  /**
+   * Saves every field of the profile as a separate treasure of the swamp, keyed by the field name.
+   * `types` sets the stored type of the fields, e.g. `{ Age: ValueType.Uint8 }`.
+   */
+  async profileSave(
+    swampName: Name,
+    profile: Record<string, Value | undefined>,
+    types: Record<string, ValueType> = {},
+    options?: CallOptions,
+  ): Promise<void> {
+    let keyValues: ProtoTreasure[];
+    try {
+      keyValues = profileToKeyValuePairs(profile, types);
+    } catch (err) {
+      throw new HydraideError(ErrorCode.InvalidModel, String(err));
+    }
+    await this.set(swampName, keyValues, true, true, options);
+  }
+
+  /**
+   * Reads the fields of the profile. The missing fields are not in the result.
+   * `dateFields` are the fields read as a Date, for the time.Time fields of the Go models.
+   */
+  async profileRead(
+    swampName: Name,
+    fields: string[],
+    dateFields: string[] = [],
+    options?: CallOptions,
+  ): Promise<Record<string, Value>> {
+    const request = { Swamps: [{ ...this.swamp(swampName), Keys: fields }] };
+    const response = await this.call(swampName, "Get", request, options);
+    const profile: Record<string, Value> = {};
+    for (const swamp of response.Swamps) {
+      for (const treasure of swamp.Treasures) {
+        if (!treasure.IsExist) {
+          continue;
+        }
+        const { value } = getValue(treasure, dateFields.includes(treasure.Key));
+        if (value !== undefined) {
+          profile[treasure.Key] = value;
+        }
+      }
+    }
+    return profile;
+  }
+
+  // 📡 Subscription
+
+  /**
+   * Yields the changes of the swamp until the loop is left.
+   *
+   * If `getExistingData` is true, the existing treasures are yielded first in the order of their creation time,
+   * with NothingChanged status. Leaving the `for await` loop cancels the subscription on the server.
+   */
+  async *subscribe<V extends Value = Value>(
+    swampName: Name,
+    getExistingData: boolean,
+    options?: { valueAsDate?: boolean },
+  ): AsyncGenerator<SubscriptionEvent<V>> {
+    if (getExistingData) {
+      const existing = await this.catalogReadMany<V>(swampName, { indexType: IndexType.CreationTime }, options);
+      for (const treasure of existing) {
+        yield { treasure, status: EventStatus.NothingChanged };
+      }
+    }
+
+    let stream: grpc.ClientReadableStream<Response>;
+    try {
+      const service = this.client.serviceClient(swampName);
+      stream = service.SubscribeToEvents(this.swamp(swampName), this.metadata) as grpc.ClientReadableStream<Response>;
+    } catch (err) {
+      throw new HydraideError(ErrorCode.ConnectionError, String(err));
+    }
+
+    try {
+      for await (const event of stream) {
+        const treasure = event.Status === "DELETED" ? event.DeletedTreasure : event.Treasure;
+        yield { treasure: treasureToCatalog<V>(treasure ?? {}, options?.valueAsDate), status: toStatus(event.Status) };
+      }
+    } catch (err) {
+      if ((err as grpc.ServiceError).code !== grpc.status.CANCELLED) {
+        throw fromGrpcError(err);
+      }
+    } finally {
+      stream.cancel();
+    }
+  }
+}
@@ -0,0 +1,37 @@
+/**
+ * hydraidets – the official TypeScript / Node.js SDK of HydrAIDE.
+ */
+
+export { Client, ERROR_CONNECTION, ERROR_NO_CONNECTION, Server, ServiceClient } from "./client";
+export {
+  ERROR_DOMAIN,
+  ErrorCode,
+  HydraideError,
+  MESSAGE_CONNECTION_ERROR,
+  MESSAGE_CTX_CLOSED_BY_CLIENT,
+  MESSAGE_CTX_TIMEOUT,
+  MESSAGE_INTERNAL_ERROR,
+  MESSAGE_INVALID_ARGUMENT,
+  MESSAGE_KEY_ALREADY_EXISTS,
+  MESSAGE_KEY_NOT_FOUND,
+  MESSAGE_NOT_FOUND,
+  MESSAGE_QUOTA_EXCEEDED,
+  MESSAGE_SWAMP_NOT_FOUND,
+  MESSAGE_UNKNOWN,
+  MESSAGE_WRONG_VALUE_TYPE,
+  fromGrpcError,
+} from "./errors";
+export {
+  CallOptions,
+  EventStatus,
+  Hydraide,
+  Index,
+  IndexOrder,
+  IndexType,
+  METADATA_CLIENT_ID,
+  ReadOptions,
+  RegisterSwampRequest,
+  SubscriptionEvent,
+} from "./hydraide";
+export { Name, WILDCARD, load } from "./name";
+export { CatalogTreasure, Value, ValueType } from "./values";
@@ -0,0 +1,86 @@
+/**
+ * Hierarchical swamp names and the island routing of HydrAIDE.
+ *
+ * A swamp name has three levels: `sanctuary/realm/swamp`. The island of the swamp is calculated from the xxhash of
+ * the three segments, the same way as the Go SDK calculates it, so a TypeScript and a Go service always route the
+ * same swamp to the same island and the same server.
+ *
+ * @example
+ * const swamp = new Name().sanctuary("users").realm("profiles").swamp("john.doe");
+ * swamp.get();            // "users/profiles/john.doe"
+ * swamp.islandId(1000);   // 518 - the same island as in Go
+ */
+
+import { xxh64 } from "./xxhash";
+
+export const WILDCARD = "*";
+
+const encoder = new TextEncoder();
+
+/**
+ * An immutable, hierarchical swamp name.
+ *
+ * Build it by chaining {@link Name.sanctuary}, {@link Name.realm} and {@link Name.swamp}. Every call returns a new
+ * instance, so a partial name (e.g. the sanctuary and the realm) can be reused as a prefix.
+ */
+export class Name {
+  constructor(
+    private readonly sanctuaryId: string = "",
+    private readonly realmName: string = "",
+    private readonly swampName: string = "",
+    private readonly path: string = "",
+  ) {}
+
+  /** Sets the top-level domain of the name (e.g. "users", "products"). */
+  sanctuary(sanctuaryId: string): Name {
+    return new Name(sanctuaryId, "", "", sanctuaryId);
+  }
+
+  /** Sets the second level of the name under the sanctuary (e.g. "profiles", "settings"). */
+  realm(realmName: string): Name {
+    return new Name(this.sanctuaryId, realmName, "", `${this.path}/${realmName}`);
+  }
+
+  /** Sets the last level of the name, the swamp itself. The path becomes sanctuary/realm/swamp. */
+  swamp(swampName: string): Name {
+    return new Name(this.sanctuaryId, this.realmName, swampName, `${this.path}/${swampName}`);
+  }
+
+  /** Returns the full path of the name in the sanctuary/realm/swamp format. */
+  get(): string {
+    return this.path;
+  }
+
+  /**
+   * Returns the deterministic, 1-based island ID of the swamp.
+   *
+   * The ID is the xxhash of the UTF-8 bytes of the sanctuary, realm and swamp segments mapped into the `allIslands`
+   * range, byte-for-byte identical with the GetIslandID of the Go SDK. `allIslands` must be the same in every client
+   * of the cluster, otherwise the clients route the same swamp to different islands.
+   */
+  islandId(allIslands: number): number {
+    if (!Number.isSafeInteger(allIslands) || allIslands <= 0) {
+      throw new RangeError("allIslands must be a positive integer");
+    }
+    const hash = xxh64(encoder.encode(this.sanctuaryId + this.realmName + this.swampName));
+    return Number(hash % BigInt(allIslands)) + 1;
+  }
+
+  /** Returns true if any segment of the name is the "*" wildcard. */
+  isWildcardPattern(): boolean {
+    return this.sanctuaryId === WILDCARD || this.realmName === WILDCARD || this.swampName === WILDCARD;
+  }
+
+  toString(): string {
+    return this.path;
+  }
+}
+
+/** Reconstructs a name from a sanctuary/realm/swamp path. */
+export function load(path: string): Name {
+  const segments = path.split("/");
+  if (segments.length < 3) {
+    throw new Error(`invalid swamp name: ${path}, expected sanctuary/realm/swamp`);
+  }
+  return new Name().sanctuary(segments[0]).realm(segments[1]).swamp(segments[2]);
+}
@@ -0,0 +1,254 @@
+/**
+ * Conversion between TypeScript values and the treasures of HydrAIDE.
+ *
+ * 🔢 Value types:
+ * JavaScript has no fixed size numbers, so the stored type of a value is inferred as int64 (integer `number` or
+ * `bigint`) or float64 (any other `number`), unless the `valueType` sets it explicitly. To share data with a Go
+ * service, use the type of the Go model, otherwise the typed index reads and the increments of the Go service will
+ * not match the stored value.
+ *
+ * Supported values: `string`, `boolean`, `number`, `bigint`, `Uint8Array` and `Date` (stored as unix seconds in the
+ * int64 field, like `time.Time` values of the Go SDK). Complex Go values (structs, maps, slices) are GOB-encoded by the
+ * Go SDK, so they are returned as raw bytes and can not be written from TypeScript in a Go-readable format.
+ */
+
+/** The stored type of a value, the same as the type of the field of the Go model. */
+export enum ValueType {
+  Int8 = "int8",
+  Int16 = "int16",
+  Int32 = "int32",
+  Int64 = "int64",
+  Uint8 = "uint8",
+  Uint16 = "uint16",
+  Uint32 = "uint32",
+  Uint64 = "uint64",
+  Float32 = "float32",
+  Float64 = "float64",
+  String = "string",
+  Bool = "bool",
+  Bytes = "bytes",
+  /** unix seconds in the int64 field, like time.Time values in the Go SDK */
+  Time = "time",
+}
+
+export type Value = string | boolean | number | bigint | Uint8Array | Date;
+
+/**
+ * A treasure of a catalog swamp: a unique key, an optional value and the optional metadata.
+ *
+ * `valueType` sets the stored type of the value at write, and it is the stored type after a read.
+ */
+export interface CatalogTreasure<V extends Value = Value> {
+  key: string;
+  value?: V;
+  valueType?: ValueType;
+  createdAt?: Date;
+  createdBy?: string;
+  updatedAt?: Date;
+  updatedBy?: string;
+  expireAt?: Date;
+  version?: number;
+}
+
+/** The timestamp format of the gRPC messages. */
+export interface Timestamp {
+  seconds: string | number;
+  nanos: number;
+}
+
+/** The KeyValuePair and the Treasure messages in the format of the proto loader (the proto field names are kept). */
+export type ProtoTreasure = Record<string, unknown>;
+
+const PROTO_FIELDS: Record<string, string> = {
+  [ValueType.Int8]: "Int8Val",
+  [ValueType.Int16]: "Int16Val",
+  [ValueType.Int32]: "Int32Val",
+  [ValueType.Int64]: "Int64Val",
+  [ValueType.Uint8]: "Uint8Val",
+  [ValueType.Uint16]: "Uint16Val",
+  [ValueType.Uint32]: "Uint32Val",
+  [ValueType.Uint64]: "Uint64Val",
+  [ValueType.Float32]: "Float32Val",
+  [ValueType.Float64]: "Float64Val",
+  [ValueType.String]: "StringVal",
+  [ValueType.Bool]: "BoolVal",
+  [ValueType.Bytes]: "BytesVal",
+};
+
+const INT_RANGES: Record<string, [bigint, bigint]> = {
+  [ValueType.Int8]: [-(2n ** 7n), 2n ** 7n - 1n],
+  [ValueType.Int16]: [-(2n ** 15n), 2n ** 15n - 1n],
+  [ValueType.Int32]: [-(2n ** 31n), 2n ** 31n - 1n],
+  [ValueType.Int64]: [-(2n ** 63n), 2n ** 63n - 1n],
+  [ValueType.Uint8]: [0n, 2n ** 8n - 1n],
+  [ValueType.Uint16]: [0n, 2n ** 16n - 1n],
+  [ValueType.Uint32]: [0n, 2n ** 32n - 1n],
+  [ValueType.Uint64]: [0n, 2n ** 64n - 1n],
+};
+
+// the 64 bit fields are sent and received as decimal strings, so no precision is lost
+const LONG_TYPES = new Set<string>([ValueType.Int64, ValueType.Uint64]);
+
+function inferType(value: Value): ValueType {
+  if (typeof value === "boolean") return ValueType.Bool;
+  if (typeof value === "string") return ValueType.String;
+  if (typeof value === "bigint") return ValueType.Int64;
+  if (typeof value === "number") return Number.isInteger(value) ? ValueType.Int64 : ValueType.Float64;
+  if (value instanceof Date) return ValueType.Time;
+  if (value instanceof Uint8Array) return ValueType.Bytes;
+  throw new TypeError(`unsupported value type: ${typeof value}`);
+}
+
+export function toTimestamp(date: Date): Timestamp {
+  const ms = date.getTime();
+  const seconds = Math.floor(ms / 1000);
+  return { seconds: String(seconds), nanos: (ms - seconds * 1000) * 1e6 };
+}
+
+export function fromTimestamp(timestamp: Timestamp): Date {
+  return new Date(Number(timestamp.seconds) * 1000 + Math.floor(timestamp.nanos / 1e6));
+}
+
+/** Converts a 64 bit decimal string to a number if it is safe, otherwise to a bigint. */
+export function toNumberOrBigInt(value: string | number): number | bigint {
+  const n = Number(value);
+  return Number.isSafeInteger(n) ? n : BigInt(value);
+}
+
+/** Sets the value to the typed field of the message. An undefined value is not set. */
+export function setValue(message: ProtoTreasure, value: Value | undefined, valueType?: ValueType): void {
+  if (value === undefined) {
+    return;
+  }
+  const type = valueType ?? inferType(value);
+
+  if (type === ValueType.Time) {
+    if (!(value instanceof Date)) throw new TypeError("a time value must be a Date");
+    message.Int64Val = String(Math.floor(value.getTime() / 1000));
+    return;
+  }
+  if (type in INT_RANGES) {
+    if (typeof value !== "bigint" && !(typeof value === "number" && Number.isInteger(value))) {
+      throw new TypeError(`a ${type} value must be an integer`);
+    }
+    const [low, high] = INT_RANGES[type];
+    const big = BigInt(value);
+    if (big < low || big > high) throw new RangeError(`${value} is out of the range of ${type}`);
+    message[PROTO_FIELDS[type]] = LONG_TYPES.has(type) ? big.toString() : Number(big);
+    return;
+  }
+  switch (type) {
+    case ValueType.Float32:
+    case ValueType.Float64:
+      if (typeof value !== "number") throw new TypeError(`a ${type} value must be a number`);
+      message[PROTO_FIELDS[type]] = value;
+      return;
+    case ValueType.String:
+      message.StringVal = String(value);
+      return;
+    case ValueType.Bool:
+      // HydrAIDE uses an enum for the booleans, so a false value can be stored explicitly
+      message.BoolVal = value ? "TRUE" : "FALSE";
+      return;
+    case ValueType.Bytes:
+      if (!(value instanceof Uint8Array)) throw new TypeError("a bytes value must be an Uint8Array");
+      message.BytesVal = Buffer.from(value);
+      return;
+    default:
+      throw new TypeError(`unsupported value type: ${type}`);
+  }
+}
+
+/**
+ * Returns the value and its stored type from whichever typed field of the treasure is set, or undefined if the
+ * treasure has no value. `asDate` reads an int64 value as a Date.
+ */
+export function getValue(treasure: ProtoTreasure, asDate = false): { value?: Value; valueType?: ValueType } {
+  for (const [type, field] of Object.entries(PROTO_FIELDS)) {
+    const raw = treasure[field];
+    if (raw === undefined || raw === null) {
+      continue;
+    }
+    const valueType = type as ValueType;
+    switch (valueType) {
+      case ValueType.Bool:
+        return { value: raw === "TRUE" || raw === 0, valueType };
+      case ValueType.Bytes:
+        return { value: new Uint8Array(raw as Uint8Array), valueType };
+      case ValueType.Int64:
+        if (asDate) return { value: new Date(Number(raw) * 1000), valueType: ValueType.Time };
+        return { value: toNumberOrBigInt(raw as string), valueType };
+      case ValueType.Uint64:
+        return { value: toNumberOrBigInt(raw as string), valueType };
+      default:
+        return { value: raw as Value, valueType };
+    }
+  }
+  return {};
+}
+
+/** Converts a catalog treasure to a KeyValuePair message, like convertCatalogModelToKeyValuePair of the Go SDK. */
+export function catalogToKeyValuePair(treasure: CatalogTreasure): ProtoTreasure {
+  if (typeof treasure.key !== "string" || treasure.key === "") {
+    throw new TypeError("key field must be a non-empty string");
+  }
+  const message: ProtoTreasure = { Key: treasure.key };
+
+  if (treasure.value !== undefined) {
+    setValue(message, treasure.value, treasure.valueType);
+  } else {
+    message.VoidVal = true;
+  }
+  if (treasure.version) message.SchemaVersion = treasure.version;
+  if (treasure.createdAt) message.CreatedAt = toTimestamp(treasure.createdAt);
+  if (treasure.createdBy) message.CreatedBy = treasure.createdBy;
+  if (treasure.updatedAt) message.UpdatedAt = toTimestamp(treasure.updatedAt);
+  if (treasure.updatedBy) message.UpdatedBy = treasure.updatedBy;
+  if (treasure.expireAt) message.ExpiredAt = toTimestamp(treasure.expireAt);
+
+  return message;
+}
+
+/** Converts a Treasure message to a catalog treasure. `asDate` reads an int64 value as a Date. */
+export function treasureToCatalog<V extends Value = Value>(
+  treasure: ProtoTreasure,
+  asDate = false,
+): CatalogTreasure<V> {
+  const { value, valueType } = getValue(treasure, asDate);
+  const result: CatalogTreasure<V> = { key: treasure.Key as string };
+  if (value !== undefined) {
+    result.value = value as V;
+    result.valueType = valueType;
+  }
+  if (treasure.SchemaVersion !== undefined && treasure.SchemaVersion !== null) {
+    result.version = treasure.SchemaVersion as number;
+  }
+  if (treasure.CreatedAt) result.createdAt = fromTimestamp(treasure.CreatedAt as Timestamp);
+  if (treasure.CreatedBy !== undefined && treasure.CreatedBy !== null) result.createdBy = treasure.CreatedBy as string;
+  if (treasure.UpdatedAt) result.updatedAt = fromTimestamp(treasure.UpdatedAt as Timestamp);
+  if (treasure.UpdatedBy !== undefined && treasure.UpdatedBy !== null) result.updatedBy = treasure.UpdatedBy as string;
+  if (treasure.ExpiredAt) result.expireAt = fromTimestamp(treasure.ExpiredAt as Timestamp);
+  return result;
+}
+
+/**
+ * Converts the fields of a profile to KeyValuePair messages, one per field, keyed by the field name.
+ *
+ * The Go SDK uses the exported Go field names as keys (e.g. `Email`), so use the same names to share the profile
+ * with a Go service. Undefined fields are not stored.
+ */
+export function profileToKeyValuePairs(
+  profile: Record<string, Value | undefined>,
+  types: Record<string, ValueType> = {},
+): ProtoTreasure[] {
+  const messages: ProtoTreasure[] = [];
+  for (const [key, value] of Object.entries(profile)) {
+    if (value === undefined) {
+      continue;
+    }
+    const message: ProtoTreasure = { Key: key };
+    setValue(message, value, types[key]);
+    messages.push(message);
+  }
+  return messages;
+}
@@ -0,0 +1,102 @@
+/**
+ * Pure TypeScript implementation of the 64 bit xxHash (XXH64) with seed 0.
+ *
+ * The island of a swamp is calculated from the xxhash of its name, exactly like the Go SDK does with
+ * github.com/cespare/xxhash/v2. The hash is implemented here with BigInt arithmetic, so the SDK has no native
+ * dependency and the routing is byte-compatible with the Go name package.
+ */
+
+const MASK = 0xffffffffffffffffn;
+
+const PRIME1 = 0x9e3779b185ebca87n;
+const PRIME2 = 0xc2b2ae3d27d4eb4fn;
+const PRIME3 = 0x165667b19e3779f9n;
+const PRIME4 = 0x85ebca77c2b2ae63n;
+const PRIME5 = 0x27d4eb2f165667c5n;
+
+function rotl(value: bigint, bits: bigint): bigint {
+  return ((value << bits) | (value >> (64n - bits))) & MASK;
+}
+
+function round(acc: bigint, lane: bigint): bigint {
+  acc = (acc + lane * PRIME2) & MASK;
+  acc = rotl(acc, 31n);
+  return (acc * PRIME1) & MASK;
+}
+
+function mergeRound(acc: bigint, value: bigint): bigint {
+  acc ^= round(0n, value);
+  return (acc * PRIME1 + PRIME4) & MASK;
+}
+
+function readUint64(data: Uint8Array, offset: number): bigint {
+  let value = 0n;
+  for (let i = 7; i >= 0; i--) {
+    value = (value << 8n) | BigInt(data[offset + i]);
+  }
+  return value;
+}
+
+function readUint32(data: Uint8Array, offset: number): bigint {
+  let value = 0n;
+  for (let i = 3; i >= 0; i--) {
+    value = (value << 8n) | BigInt(data[offset + i]);
+  }
+  return value;
+}
+
+/** Returns the XXH64 hash of the data with seed 0, as an unsigned 64 bit BigInt. */
+export function xxh64(data: Uint8Array): bigint {
+  const length = data.length;
+  let offset = 0;
+  let h: bigint;
+
+  if (length >= 32) {
+    let v1 = (PRIME1 + PRIME2) & MASK;
+    let v2 = PRIME2;
+    let v3 = 0n;
+    let v4 = -PRIME1 & MASK;
+    while (offset <= length - 32) {
+      v1 = round(v1, readUint64(data, offset));
+      v2 = round(v2, readUint64(data, offset + 8));
+      v3 = round(v3, readUint64(data, offset + 16));
+      v4 = round(v4, readUint64(data, offset + 24));
+      offset += 32;
+    }
+    h = (rotl(v1, 1n) + rotl(v2, 7n) + rotl(v3, 12n) + rotl(v4, 18n)) & MASK;
+    h = mergeRound(h, v1);
+    h = mergeRound(h, v2);
+    h = mergeRound(h, v3);
+    h = mergeRound(h, v4);
+  } else {
+    h = PRIME5;
+  }
+
+  h = (h + BigInt(length)) & MASK;
+
+  while (offset + 8 <= length) {
+    h ^= round(0n, readUint64(data, offset));
+    h = (rotl(h, 27n) * PRIME1 + PRIME4) & MASK;
+    offset += 8;
+  }
+
+  if (offset + 4 <= length) {
+    h ^= (readUint32(data, offset) * PRIME1) & MASK;
+    h = (rotl(h, 23n) * PRIME2 + PRIME3) & MASK;
+    offset += 4;
+  }
+
+  while (offset < length) {
+    h ^= (BigInt(data[offset]) * PRIME5) & MASK;
+    h = (rotl(h, 11n) * PRIME1) & MASK;
+    offset++;
+  }
+
+  h ^= h >> 33n;
+  h = (h * PRIME2) & MASK;
+  h ^= h >> 29n;
+  h = (h * PRIME3) & MASK;
+  h ^= h >> 32n;
+
+  return h;
+}
@@ -0,0 +1,69 @@
+import assert from "node:assert/strict";
+import { describe, it } from "node:test";
+
+import { Name, load } from "../src/name";
+import { xxh64 } from "../src/xxhash";
+
+const encoder = new TextEncoder();
+
+describe("xxh64", () => {
+  // reference values of github.com/cespare/xxhash/v2
+  const cases: [string, bigint][] = [
+    ["", 17241709254077376921n],
+    ["a", 15154266338359012955n],
+    ["abc", 4952883123889572249n],
+    ["0123456789abcdefghijklmnopqrstuvwxyz0123456789", 5396834407657175988n],
+  ];
+  for (const [data, expected] of cases) {
+    it(`hashes ${JSON.stringify(data)}`, () => {
+      assert.equal(xxh64(encoder.encode(data)), expected);
+    });
+  }
+});
+
+describe("Name", () => {
+  it("matches the island IDs of the Go SDK", () => {
+    // island IDs calculated by the name package of the Go SDK with 1000 islands
+    const cases: [string, string, string, number][] = [
+      ["users", "profiles", "john.doe", 518],
+      ["products", "catalog", "2025", 986],
+      ["a", "b", "c", 250],
+      ["sanctuary", "realm", "swamp-with-a-very-long-name-over-32-bytes", 73],
+      ["ü", "ő", "日本", 830],
+    ];
+    for (const [sanctuary, realm, swamp, expected] of cases) {
+      assert.equal(new Name().sanctuary(sanctuary).realm(realm).swamp(swamp).islandId(1000), expected);
+    }
+  });
+
+  it("builds the path", () => {
+    const name = new Name().sanctuary("users").realm("profiles").swamp("john.doe");
+    assert.equal(name.get(), "users/profiles/john.doe");
+    assert.equal(String(name), "users/profiles/john.doe");
+  });
+
+  it("keeps the prefix immutable", () => {
+    const prefix = new Name().sanctuary("users").realm("profiles");
+    const first = prefix.swamp("alex");
+    const second = prefix.swamp("bob");
+    assert.equal(first.get(), "users/profiles/alex");
+    assert.equal(second.get(), "users/profiles/bob");
+    assert.equal(prefix.get(), "users/profiles");
+  });
+
+  it("loads a path with the same island", () => {
+    const name = load("users/profiles/john.doe");
+    assert.equal(name.get(), "users/profiles/john.doe");
+    assert.equal(name.islandId(1000), 518);
+    assert.throws(() => load("users/profiles"));
+  });
+
+  it("detects the wildcard patterns", () => {
+    assert.ok(new Name().sanctuary("users").realm("*").swamp("*").isWildcardPattern());
+    assert.ok(!new Name().sanctuary("users").realm("profiles").swamp("alex").isWildcardPattern());
+  });
+
+  it("rejects an invalid number of islands", () => {
+    assert.throws(() => new Name().sanctuary("a").realm("b").swamp("c").islandId(0), RangeError);
+  });
+});
@@ -0,0 +1,112 @@
+import assert from "node:assert/strict";
+import { describe, it } from "node:test";
+
+import {
+  ProtoTreasure,
+  ValueType,
+  catalogToKeyValuePair,
+  getValue,
+  profileToKeyValuePairs,
+  setValue,
+  treasureToCatalog,
+} from "../src/values";
+
+describe("setValue", () => {
+  it("infers the type of the value", () => {
+    const cases: [unknown, string, unknown][] = [
+      ["alex", "StringVal", "alex"],
+      [42, "Int64Val", "42"],
+      [1.5, "Float64Val", 1.5],
+      [true, "BoolVal", "TRUE"],
+      [false, "BoolVal", "FALSE"],
+      [2n ** 62n, "Int64Val", "4611686018427387904"],
+    ];
+    for (const [value, field, expected] of cases) {
+      const message: ProtoTreasure = {};
+      setValue(message, value as never);
+      assert.deepEqual(message, { [field]: expected });
+    }
+  });
+
+  it("uses the explicit type", () => {
+    const message: ProtoTreasure = {};
+    setValue(message, 200, ValueType.Uint8);
+    assert.deepEqual(message, { Uint8Val: 200 });
+  });
+
+  it("rejects the values out of range", () => {
+    assert.throws(() => setValue({}, 256, ValueType.Uint8), RangeError);
+    assert.throws(() => setValue({}, -1, ValueType.Uint64), RangeError);
+    assert.throws(() => setValue({}, 1.5, ValueType.Int32), TypeError);
+  });
+
+  it("stores a Date as unix seconds", () => {
+    const message: ProtoTreasure = {};
+    setValue(message, new Date("2025-01-02T03:04:05.678Z"));
+    assert.deepEqual(message, { Int64Val: "1735787045" });
+  });
+});
+
+describe("getValue", () => {
+  it("reads the typed field", () => {
+    assert.deepEqual(getValue({ Uint32Val: 7 }), { value: 7, valueType: ValueType.Uint32 });
+    assert.deepEqual(getValue({ BoolVal: "FALSE" }), { value: false, valueType: ValueType.Bool });
+    assert.deepEqual(getValue({ Uint64Val: "18446744073709551615" }), {
+      value: 18446744073709551615n,
+      valueType: ValueType.Uint64,
+    });
+    assert.deepEqual(getValue({}), {});
+  });
+
+  it("reads an int64 as a Date", () => {
+    const { value, valueType } = getValue({ Int64Val: "1735787045" }, true);
+    assert.equal(valueType, ValueType.Time);
+    assert.equal((value as Date).toISOString(), "2025-01-02T03:04:05.000Z");
+  });
+});
+
+describe("catalog treasures", () => {
+  it("round-trips the value and the metadata", () => {
+    const createdAt = new Date("2025-01-02T03:04:05.000Z");
+    const message = catalogToKeyValuePair({
+      key: "alex",
+      value: 300,
+      valueType: ValueType.Uint32,
+      createdAt,
+      createdBy: "tester",
+      version: 2,
+    });
+    assert.equal(message.Key, "alex");
+    assert.equal(message.Uint32Val, 300);
+    assert.equal(message.CreatedBy, "tester");
+    assert.equal(message.SchemaVersion, 2);
+
+    const treasure = treasureToCatalog(message);
+    assert.equal(treasure.key, "alex");
+    assert.equal(treasure.value, 300);
+    assert.equal(treasure.valueType, ValueType.Uint32);
+    assert.equal(treasure.createdAt?.getTime(), createdAt.getTime());
+    assert.equal(treasure.version, 2);
+  });
+
+  it("marks a treasure without value as void", () => {
+    assert.deepEqual(catalogToKeyValuePair({ key: "alex" }), { Key: "alex", VoidVal: true });
+  });
+
+  it("rejects an empty key", () => {
+    assert.throws(() => catalogToKeyValuePair({ key: "" }), TypeError);
+  });
+});
+
+describe("profileToKeyValuePairs", () => {
+  it("stores every defined field as a treasure", () => {
+    const messages = profileToKeyValuePairs(
+      { Email: "alex@example.com", Age: 42, Nickname: undefined },
+      { Age: ValueType.Uint8 },
+    );
+    assert.deepEqual(messages, [
+      { Key: "Email", StringVal: "alex@example.com" },
+      { Key: "Age", Uint8Val: 42 },
+    ]);
+  });
+});